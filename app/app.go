@@ -50,6 +50,8 @@ const (
 	DefaultEnvironment       = "development"
 	DefaultPort              = 8080
 	DefaultTLSPort           = 8443 // Default port when serving TLS or mTLS (overridable with WithPort)
+	DefaultAutoTLSPort       = 443  // Default port when serving automatic TLS (overridable with WithPort)
+	DefaultRedirectPort      = 80   // Default port of the HTTPS redirect listener (overridable with WithRedirectPort)
 	DefaultReadTimeout       = 10 * time.Second
 	DefaultWriteTimeout      = 10 * time.Second
 	DefaultIdleTimeout       = 60 * time.Second
@@ -88,25 +90,27 @@ type App struct {
 	reloadMu              sync.Mutex         // Serializes concurrent reload executions
 	routeValidationErrors []error            // Errors from nil route options; reported by ValidateRoutes()
 	routeValidationMu     sync.Mutex         // Protects routeValidationErrors
+	drain                 drainTracker       // In-flight requests per route, for graceful shutdown
 }
 
 // config holds the internal application configuration.
 // config maintains encapsulation by keeping all fields private.
 type config struct {
-	serviceName      string
-	serviceVersion   string
-	environment      string
-	server           *serverConfig
-	middleware       *middlewareConfig
-	router           *routerConfig
-	openapi          *openapiConfig
-	errors           *errorsConfig
-	observability    *observabilitySettings // Unified observability settings (metrics, tracing, logging)
-	health           *healthSettings        // Health endpoint settings (livez, readyz)
-	debug            *debugSettings         // Debug endpoint settings (pprof)
-	validationEngine *validation.Engine     // Optional; when set, Bind/Validate use this engine
-	envErrors        []error                // Errors from environment variable parsing
-	validationErrors []error                // Errors from nil options (e.g. WithServer)
+	serviceName       string
+	serviceVersion    string
+	environment       string
+	server            *serverConfig
+	middleware        *middlewareConfig
+	router            *routerConfig
+	openapi           *openapiConfig
+	openapiValidation *openapiValidationSettings // Runtime contract validation (nil if disabled)
+	errors            *errorsConfig
+	observability     *observabilitySettings // Unified observability settings (metrics, tracing, logging)
+	health            *healthSettings        // Health endpoint settings (livez, readyz)
+	debug             *debugSettings         // Debug endpoint settings (pprof)
+	validationEngine  *validation.Engine     // Optional; when set, Bind/Validate use this engine
+	envErrors         []error                // Errors from environment variable parsing
+	validationErrors  []error                // Errors from nil options (e.g. WithServer)
 }
 
 // metricsConfig holds metrics configuration settings.
@@ -137,6 +141,13 @@ type serverConfig struct {
 	// mTLS: serverCert present = serve mTLS
 	mtlsServerCert tls.Certificate
 	mtlsOpts       []MTLSOption
+	// Automatic TLS: domains present = serve HTTPS with ACME certificates
+	autoTLS autoTLSConfig
+	// HTTP/3: serve QUIC next to TLS, mTLS, or automatic TLS
+	http3 bool
+	// HTTPS redirect: plain HTTP listener on redirectPort
+	httpsRedirect bool
+	redirectPort  int
 }
 
 // ListenAddr returns the server listen address in "host:port" format.
@@ -249,6 +260,16 @@ func (sc *serverConfig) Validate() *ConfigErrors {
 			"must be between 1 and 65535"))
 	}
 
+	if sc.httpsRedirect {
+		if sc.redirectPort <= 0 || sc.redirectPort > 65535 {
+			errs.Add(newInvalidValueError("server.redirectPort", sc.redirectPort,
+				"must be between 1 and 65535"))
+		} else if sc.redirectPort == sc.port {
+			errs.Add(newInvalidValueError("server.redirectPort", sc.redirectPort,
+				"must differ from the server port"))
+		}
+	}
+
 	if !errs.HasErrors() {
 		return nil
 	}
@@ -335,6 +356,22 @@ func (c *config) validate() error {
 				errs.Add(newInvalidValueError("server", nil, "both cert file and key file are required for WithTLS"))
 			}
 		}
+		hasAutoTLS := c.server.autoTLS.enabled()
+		if hasAutoTLS && (hasTLS || hasMTLS) {
+			errs.Add(newInvalidValueError("server", nil, "cannot combine WithAutoTLS with WithTLS or WithMTLS"))
+		}
+		if !hasAutoTLS && (c.server.autoTLS.cache != nil || c.server.autoTLS.email != "" || c.server.autoTLS.directoryURL != "") {
+			errs.Add(newInvalidValueError("server", nil, "WithAutoTLSCache, WithAutoTLSEmail, and WithAutoTLSDirectory require WithAutoTLS"))
+		}
+		if slices.Contains(c.server.autoTLS.domains, "") {
+			errs.Add(newInvalidValueError("server", nil, "WithAutoTLS domains must not be empty"))
+		}
+		if c.server.http3 && !hasTLS && !hasMTLS && !hasAutoTLS {
+			errs.Add(newInvalidValueError("server", nil, "WithHTTP3 requires WithTLS, WithMTLS, or WithAutoTLS"))
+		}
+		if c.server.httpsRedirect && !hasTLS && !hasMTLS && !hasAutoTLS {
+			errs.Add(newInvalidValueError("server", nil, "WithHTTPSRedirect requires WithTLS, WithMTLS, or WithAutoTLS"))
+		}
 		if hasMTLS {
 			mtlsCfg := newMTLSConfig(c.server.mtlsServerCert, c.server.mtlsOpts...)
 			if mtlsErr := mtlsCfg.validate(); mtlsErr != nil {
@@ -356,6 +393,11 @@ func (c *config) validate() error {
 	if c.openapi != nil && c.openapi.enabled && c.openapi.initErr != nil {
 		errs.Add(newInvalidValueError("openapi", nil, c.openapi.initErr.Error()))
 	}
+	if c.openapiValidation != nil && (c.openapi == nil || !c.openapi.enabled) {
+		err := newInvalidValueError("openapiValidation", nil, "requires OpenAPI to be enabled")
+		err.Hint = "add app.WithOpenAPI(...)"
+		errs.Add(err)
+	}
 
 	// Validate error formatter configuration (from WithErrorFormatterFor)
 	if c.errors != nil && c.errors.initErr != nil {
@@ -413,6 +455,7 @@ func defaultConfig() *config {
 			readHeaderTimeout: DefaultReadHeaderTimeout,
 			maxHeaderBytes:    DefaultMaxHeaderBytes,
 			shutdownTimeout:   DefaultShutdownTimeout,
+			redirectPort:      DefaultRedirectPort,
 		}, // tlsCertFile, tlsKeyFile, mtlsServerCert, mtlsOpts zero values = HTTP
 		middleware: &middlewareConfig{
 			functions: []HandlerFunc{},
@@ -585,6 +628,11 @@ func New(opts ...Option) (*App, error) {
 		}
 	}
 
+	// Validate traffic against the generated spec before user middleware runs
+	if cfg.openapiValidation != nil && app.openapi != nil {
+		app.Use(app.openapiValidationMiddleware(cfg.openapiValidation))
+	}
+
 	// Add middleware from configuration
 	if len(cfg.middleware.functions) > 0 {
 		app.Use(cfg.middleware.functions...)
@@ -673,11 +721,18 @@ func getCallerLocation(skip int) string {
 // getHandlerFuncName extracts the function name from a HandlerFunc using reflection.
 // Returns the full qualified name (e.g., "example.com/pkg/handlers.CreateOrder").
 func getHandlerFuncName(handler HandlerFunc) string {
-	if handler == nil {
+	return getFuncName(handler)
+}
+
+// getFuncName extracts the function name of fn, a function of any signature,
+// like [getHandlerFuncName].
+func getFuncName(fn any) string {
+	v := reflect.ValueOf(fn)
+	if !v.IsValid() || v.Kind() != reflect.Func || v.IsNil() {
 		return "nil"
 	}
 
-	funcPtr := runtime.FuncForPC(reflect.ValueOf(handler).Pointer())
+	funcPtr := runtime.FuncForPC(v.Pointer())
 	if funcPtr == nil {
 		return "unknown"
 	}
@@ -722,6 +777,7 @@ type routeTarget struct {
 	prefixMiddleware []HandlerFunc
 	getFullPath      func(path string) string
 	version          string
+	drainTimeout     time.Duration // Default drain window of the group's routes
 	register         func(method, path, fullPath string, handlers []router.HandlerFunc) *route.Route
 }
 
@@ -756,9 +812,20 @@ func (a *App) registerRouteWithTarget(target routeTarget, method, path string, h
 		}
 		opt(cfg)
 	}
+	if cfg.handlerName != "" {
+		handlerName, callerLoc = cfg.handlerName, cfg.callerLocation
+	}
 
-	// Build handler chain: prefix middleware → before → handler → after
-	handlers := make([]router.HandlerFunc, 0, len(target.prefixMiddleware)+len(cfg.before)+1+len(cfg.after))
+	fullPath := target.getFullPath(path)
+
+	drainTimeout := cfg.drainTimeout
+	if drainTimeout == 0 {
+		drainTimeout = target.drainTimeout
+	}
+
+	// Build handler chain: drain tracking → prefix middleware → before → handler → after
+	handlers := make([]router.HandlerFunc, 0, 1+len(target.prefixMiddleware)+len(cfg.before)+1+len(cfg.after))
+	handlers = append(handlers, a.drain.track(method, fullPath, drainTimeout))
 	for _, h := range target.prefixMiddleware {
 		handlers = append(handlers, a.wrapHandler(h))
 	}
@@ -770,7 +837,6 @@ func (a *App) registerRouteWithTarget(target routeTarget, method, path string, h
 		handlers = append(handlers, a.wrapHandler(h))
 	}
 
-	fullPath := target.getFullPath(path)
 	rt := target.register(method, path, fullPath, handlers)
 
 	// Update route info with actual handler name and caller location
@@ -1014,12 +1080,15 @@ func (a *App) Version(version string) *VersionGroup {
 
 // Static serves static files from the given directory.
 // Static is a convenience wrapper that delegates to router.Static.
+// Options such as router.WithStaticCacheControl configure caching and
+// directory listings.
 //
 // Example:
 //
 //	app.Static("/static", "./public")
-func (a *App) Static(prefix, root string) {
-	a.router.Static(prefix, root)
+//	app.Static("/assets", "./dist", router.WithStaticCacheControl(router.WithPublic(), router.WithMaxAge(time.Hour)))
+func (a *App) Static(prefix, root string, opts ...router.StaticOption) {
+	a.router.Static(prefix, root, opts...)
 }
 
 // Any registers a route that matches all HTTP methods.
@@ -1068,8 +1137,8 @@ func (a *App) File(path, filepath string) {
 //	//go:embed static
 //	var staticFiles embed.FS
 //	app.StaticFS("/static", http.FS(staticFiles))
-func (a *App) StaticFS(prefix string, fs http.FileSystem) {
-	a.router.StaticFS(prefix, fs)
+func (a *App) StaticFS(prefix string, fs http.FileSystem, opts ...router.StaticOption) {
+	a.router.StaticFS(prefix, fs, opts...)
 }
 
 // NoRoute sets the handler for requests that don't match any registered routes.
@@ -0,0 +1,146 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"crypto/tls"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/crypto/acme"
+	"golang.org/x/crypto/acme/autocert"
+)
+
+// CertCache stores certificates obtained with [WithAutoTLS], so they survive
+// restarts. Implement it to keep certificates in a database or secret store
+// shared by all instances; use [DirCertCache] for a local directory.
+type CertCache = autocert.Cache
+
+// DirCertCache returns a [CertCache] that stores certificates as files in dir.
+// The directory is created on first use.
+func DirCertCache(dir string) CertCache {
+	return autocert.DirCache(dir)
+}
+
+// autoTLSConfig holds the ACME settings of [WithAutoTLS].
+type autoTLSConfig struct {
+	domains      []string
+	cache        CertCache
+	email        string
+	directoryURL string
+}
+
+// WithAutoTLS serves HTTPS with certificates that are obtained and renewed
+// from Let's Encrypt for the given domains. Requests for other hosts fail the
+// TLS handshake. Default listen port is 443, since ACME validates challenges
+// there, unless overridden by [WithPort] or RIVAAS_PORT when [WithEnv] is used.
+//
+// TLS-ALPN-01 challenges are answered on the HTTPS port. Add [WithHTTPSRedirect]
+// to also answer HTTP-01 challenges on port 80. Certificates are cached in the
+// user cache directory unless [WithAutoTLSCache] sets another [CertCache].
+// Only one of WithTLS, WithMTLS, or WithAutoTLS may be used.
+//
+// Example:
+//
+//	app.New(
+//	    app.WithServiceName("my-api"),
+//	    app.WithAutoTLS("api.example.com"),
+//	    app.WithAutoTLSEmail("ops@example.com"),
+//	    app.WithHTTPSRedirect(),
+//	)
+func WithAutoTLS(domains ...string) Option {
+	return func(c *config) {
+		c.server.autoTLS.domains = append(c.server.autoTLS.domains, domains...)
+		if c.server.port == DefaultPort {
+			c.server.port = DefaultAutoTLSPort
+		}
+	}
+}
+
+// WithAutoTLSCache sets where [WithAutoTLS] stores certificates and the ACME
+// account key. Instances behind a load balancer should share one cache.
+//
+// Example:
+//
+//	app.WithAutoTLSCache(app.DirCertCache("/var/lib/my-api/certs"))
+func WithAutoTLSCache(cache CertCache) Option {
+	return func(c *config) {
+		c.server.autoTLS.cache = cache
+	}
+}
+
+// WithAutoTLSEmail sets the contact address of the ACME account, which Let's
+// Encrypt uses to warn about expiring certificates.
+func WithAutoTLSEmail(email string) Option {
+	return func(c *config) {
+		c.server.autoTLS.email = email
+	}
+}
+
+// WithAutoTLSDirectory sets the ACME directory URL. Default is the Let's
+// Encrypt production directory; use the staging directory while testing to
+// avoid its rate limits.
+//
+// Example:
+//
+//	app.WithAutoTLSDirectory("https://acme-staging-v02.api.letsencrypt.org/directory")
+func WithAutoTLSDirectory(url string) Option {
+	return func(c *config) {
+		c.server.autoTLS.directoryURL = url
+	}
+}
+
+// enabled reports whether [WithAutoTLS] was used.
+func (c *autoTLSConfig) enabled() bool {
+	return len(c.domains) > 0
+}
+
+// manager returns the ACME certificate manager for the configuration.
+func (c *autoTLSConfig) manager() *autocert.Manager {
+	m := &autocert.Manager{
+		Prompt:     autocert.AcceptTOS,
+		HostPolicy: autocert.HostWhitelist(c.domains...),
+		Cache:      c.cache,
+		Email:      c.email,
+	}
+	if m.Cache == nil {
+		m.Cache = defaultCertCache()
+	}
+	if c.directoryURL != "" {
+		m.Client = &acme.Client{DirectoryURL: c.directoryURL}
+	}
+
+	return m
+}
+
+// defaultCertCache returns a [CertCache] in the user cache directory, or nil
+// to keep certificates in memory only if there is none.
+func defaultCertCache() CertCache {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return nil
+	}
+
+	return DirCertCache(filepath.Join(dir, "rivaas", "autocert"))
+}
+
+// newAutoTLSConfig returns the TLS configuration that serves certificates from m
+// and answers TLS-ALPN-01 challenges.
+func newAutoTLSConfig(m *autocert.Manager) *tls.Config {
+	tlsConfig := m.TLSConfig()
+	tlsConfig.MinVersion = tls.VersionTLS12
+
+	return tlsConfig
+}
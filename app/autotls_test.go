@@ -0,0 +1,103 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/acme"
+)
+
+func TestWithAutoTLS_Config(t *testing.T) {
+	t.Parallel()
+
+	cache := DirCertCache(t.TempDir())
+	a, err := New(
+		WithServiceName("test"),
+		WithServiceVersion("1.0.0"),
+		WithAutoTLS("example.com", "www.example.com"),
+		WithAutoTLSCache(cache),
+		WithAutoTLSEmail("ops@example.com"),
+		WithAutoTLSDirectory("https://acme.example.com/directory"),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultAutoTLSPort, a.config.server.port)
+
+	m := a.config.server.autoTLS.manager()
+	assert.Equal(t, cache, m.Cache)
+	assert.Equal(t, "ops@example.com", m.Email)
+	assert.Equal(t, "https://acme.example.com/directory", m.Client.DirectoryURL)
+	require.NoError(t, m.HostPolicy(context.Background(), "www.example.com"))
+	require.Error(t, m.HostPolicy(context.Background(), "other.example.com"))
+
+	tlsConfig := newAutoTLSConfig(m)
+	assert.Contains(t, tlsConfig.NextProtos, acme.ALPNProto)
+	assert.NotNil(t, tlsConfig.GetCertificate)
+}
+
+func TestWithAutoTLS_KeepsExplicitPort(t *testing.T) {
+	t.Parallel()
+
+	a, err := New(
+		WithServiceName("test"),
+		WithServiceVersion("1.0.0"),
+		WithPort(9443),
+		WithAutoTLS("example.com"),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, 9443, a.config.server.port)
+}
+
+func TestWithAutoTLS_Validation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		opts    []Option
+		wantErr string
+	}{
+		{
+			name:    "combined with WithTLS",
+			opts:    []Option{WithAutoTLS("example.com"), WithTLS("server.crt", "server.key")},
+			wantErr: "cannot combine WithAutoTLS with WithTLS or WithMTLS",
+		},
+		{
+			name:    "empty domain",
+			opts:    []Option{WithAutoTLS("example.com", "")},
+			wantErr: "WithAutoTLS domains must not be empty",
+		},
+		{
+			name:    "settings without WithAutoTLS",
+			opts:    []Option{WithAutoTLSEmail("ops@example.com")},
+			wantErr: "require WithAutoTLS",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			opts := append([]Option{WithServiceName("test"), WithServiceVersion("1.0.0")}, tt.opts...)
+			_, err := New(opts...)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
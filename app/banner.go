@@ -121,9 +121,37 @@ func normalizeAddr(addr string) string {
 	return addr
 }
 
+// listenAddr is an address the server accepts connections on.
+type listenAddr struct {
+	addr   string // "host:port", or "unix:///path" for Unix sockets
+	secure bool   // Served over TLS
+}
+
+// url returns the address for display, with the scheme clients use.
+func (l listenAddr) url() string {
+	if strings.HasPrefix(l.addr, "unix://") {
+		return l.addr
+	}
+	if l.secure {
+		return "https://" + normalizeAddr(l.addr)
+	}
+
+	return "http://" + normalizeAddr(l.addr)
+}
+
+// joinListenAddrs returns the addresses separated by commas, for logs.
+func joinListenAddrs(addrs []listenAddr) string {
+	s := make([]string, len(addrs))
+	for i, l := range addrs {
+		s[i] = l.addr
+	}
+
+	return strings.Join(s, ", ")
+}
+
 // printStartupBanner prints the startup banner to stdout.
-// It is called by [App.Run], [App.RunTLS], and [App.RunMTLS].
-func (a *App) printStartupBanner(addr, protocol string) {
+// It is called by [App.Start] with the addresses the server listens on.
+func (a *App) printStartupBanner(addrs []listenAddr) {
 	w := a.getColorWriter(os.Stdout)
 
 	// Generate ASCII art from service name using go-figure
@@ -160,14 +188,8 @@ func (a *App) printStartupBanner(addr, protocol string) {
 		_, _ = styledArt.WriteString("\n")
 	}
 
-	displayAddr := normalizeAddr(addr)
-
-	// Prepend scheme based on protocol
-	scheme := "http://"
-	if protocol == "HTTPS" || protocol == "mTLS" {
-		scheme = "https://"
-	}
-	displayAddr = scheme + displayAddr
+	// The first address serves the documentation links
+	displayAddr := addrs[0].url()
 
 	// Build categorized sections using bannerWriter
 	bw := newBannerWriter()
@@ -177,6 +199,9 @@ func (a *App) printStartupBanner(addr, protocol string) {
 	bw.field("Version:", a.config.serviceVersion, "14")
 	bw.field("Environment:", a.config.environment, "11")
 	bw.field("Address:", displayAddr, "10")
+	for _, l := range addrs[1:] {
+		bw.field("", l.url(), "10")
+	}
 	if a.hasReloadHooks() {
 		bw.field("Reload:", "SIGHUP (enabled)", "13")
 	}
@@ -17,10 +17,12 @@ package app
 import (
 	"bytes"
 	"context"
+	"encoding/xml"
 	"errors"
 	"fmt"
 	"io"
 	"log/slog"
+	"mime"
 	"net/http"
 	"reflect"
 	"strings"
@@ -495,7 +497,6 @@ func (c *Context) fail(err error) {
 	)
 
 	// Write response
-	c.Header("Content-Type", response.ContentType)
 	if response.Headers != nil {
 		for key, values := range response.Headers {
 			for _, value := range values {
@@ -504,11 +505,37 @@ func (c *Context) fail(err error) {
 		}
 	}
 
-	if jsonErr := c.JSON(response.Status, response.Body); jsonErr != nil {
-		logger.ErrorContext(c.RequestContext(), "failed to write JSON response", "err", jsonErr)
+	if writeErr := c.writeErrorResponse(response); writeErr != nil {
+		logger.ErrorContext(c.RequestContext(), "failed to write error response", "err", writeErr)
 	}
 }
 
+// writeErrorResponse writes the body of a formatted error response as JSON,
+// or as XML with the formatter's content type when it is an XML media type.
+func (c *Context) writeErrorResponse(response riverrors.Response) error {
+	if !isXMLContentType(response.ContentType) {
+		return c.JSON(response.Status, response.Body)
+	}
+
+	data, err := xml.Marshal(response.Body)
+	if err != nil {
+		return fmt.Errorf("XML encoding failed for type %T: %w", response.Body, err)
+	}
+
+	return c.Data(response.Status, response.ContentType, data)
+}
+
+// isXMLContentType reports whether contentType is an XML media type, such as
+// application/xml or application/problem+xml.
+func isXMLContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	return strings.HasSuffix(mediaType, "/xml") || strings.HasSuffix(mediaType, "+xml")
+}
+
 // selectFormatter chooses the appropriate formatter based on configuration.
 // selectFormatter is a private helper used by Fail().
 func (c *Context) selectFormatter() riverrors.Formatter {
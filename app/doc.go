@@ -202,6 +202,20 @@
 //	    log.Fatal(err)
 //	}
 //
+// # Draining
+//
+// On shutdown, in-flight requests get the server's shutdown timeout to finish.
+// Long-running routes can get a longer window with the [WithDrainTimeout]
+// route option or [Group.WithDrainTimeout]; shutdown waits for them only
+// while they are in flight. [App.InFlight] reports the requests still running,
+// e.g. from an OnShutdown hook:
+//
+//	app.POST("/exports", createExport, app.WithDrainTimeout(5*time.Minute))
+//
+//	app.OnShutdown(func(ctx context.Context) {
+//	    slog.InfoContext(ctx, "draining", "requests", app.InFlight().Total)
+//	})
+//
 // # Request Handling
 //
 // Handlers receive an app.Context that extends router.Context with app-level features:
@@ -270,6 +284,32 @@
 //	    // Only provided fields are validated
 //	})
 //
+// # Typed Handlers
+//
+// [Handle] registers a function from a request type to a response type. It binds and
+// validates the request, renders the response or error, and documents both types in
+// the OpenAPI specification:
+//
+//	func createUser(ctx context.Context, req CreateUserRequest) (User, error) { ... }
+//
+//	app.Handle(a, http.MethodPost, "/users", createUser,
+//	    app.WithSuccessStatus(http.StatusCreated),
+//	)
+//
+// Plain handlers can return an error instead of writing it themselves. [E] adapts a
+// [HandlerFuncE] and renders a returned error with the app's error formatter, which
+// [WithErrorHandler] sets, e.g. to RFC 9457 problem details:
+//
+//	a := app.MustNew(app.WithErrorHandler(errors.MustNew(errors.WithRFC9457("https://api.example.com/problems"))))
+//
+//	a.GET("/users/:id", app.E(func(c *app.Context) error {
+//	    user, err := store.Find(c.Param("id"))
+//	    if err != nil {
+//	        return err
+//	    }
+//	    return c.JSON(http.StatusOK, user)
+//	}))
+//
 // # Server Configuration
 //
 // Configure server address and timeouts using functional options:
@@ -285,10 +325,25 @@
 //	    ),
 //	)
 //
-// For HTTPS use WithTLS(certFile, keyFile); for mTLS use WithMTLS(serverCert, ...MTLSOption). Then call Start(ctx).
-// Default port is 8080 for HTTP and 8443 for TLS/mTLS; override with WithPort or RIVAAS_PORT.
+// For HTTPS use WithTLS(certFile, keyFile); for mTLS use WithMTLS(serverCert, ...MTLSOption);
+// for certificates from Let's Encrypt use WithAutoTLS(domains...). Then call Start(ctx).
+// Default port is 8080 for HTTP, 8443 for TLS/mTLS, and 443 for WithAutoTLS; override with WithPort or RIVAAS_PORT.
 // Configuration is automatically validated to catch common misconfigurations.
 //
+// WithHTTP3 also serves HTTP/3 over QUIC, on the UDP port with the same number, with the same
+// router, TLS configuration, and lifecycle hooks. HTTPS responses advertise it with Alt-Svc.
+// WithHTTPSRedirect adds a plain HTTP listener on port 80 that redirects to HTTPS and, with
+// WithAutoTLS, answers ACME HTTP-01 challenges.
+//
+// Start also accepts listeners to serve on instead of the configured address: TCP addresses
+// and Unix sockets with Listen, or open listeners, such as from systemd socket activation,
+// with FromListener. Each one uses the app's TLS configuration unless it sets its own:
+//
+//	err := a.Start(ctx,
+//	    app.Listen(":8443"),
+//	    app.Listen("unix:///run/my-api/api.sock", app.WithoutListenerTLS()),
+//	)
+//
 // # Environment Variables
 //
 // The app package supports configuration via environment variables using [WithEnv]:
@@ -306,7 +361,7 @@
 //	  RIVAAS_SERVICE_VERSION        - Service version
 //
 //	Server:
-//	  RIVAAS_PORT                   - Server port (default 8080 for HTTP, 8443 for TLS/mTLS; e.g., "8080", "443")
+//	  RIVAAS_PORT                   - Server port (default 8080 for HTTP, 8443 for TLS/mTLS, 443 for WithAutoTLS; e.g., "8080", "443")
 //	  RIVAAS_HOST                   - HTTP server host/interface (e.g., "127.0.0.1")
 //	  RIVAAS_READ_TIMEOUT           - Request read timeout (e.g., "10s")
 //	  RIVAAS_WRITE_TIMEOUT          - Response write timeout (e.g., "10s")
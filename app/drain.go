@@ -0,0 +1,163 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"rivaas.dev/router"
+)
+
+// drainPollInterval is how often shutdown checks whether requests with a
+// longer drain timeout are still in flight.
+const drainPollInterval = 100 * time.Millisecond
+
+// WithDrainTimeout gives requests to the route a drain window of d during
+// graceful shutdown, instead of the server's shutdown timeout. Use it for
+// long-running routes such as exports or uploads. Shutdown waits until every
+// in-flight request has finished or its window has passed, so a longer window
+// only delays shutdown while such requests are running.
+//
+// Example:
+//
+//	app.POST("/exports", createExport,
+//	    app.WithDrainTimeout(5*time.Minute),
+//	)
+func WithDrainTimeout(d time.Duration) RouteOption {
+	return func(c *routeConfig) {
+		c.drainTimeout = d
+	}
+}
+
+// InFlight reports the requests that are being handled.
+type InFlight struct {
+	// Total is the number of requests in flight.
+	Total int
+
+	// Routes is the number of requests in flight per route, keyed by
+	// "METHOD /path". Routes without requests are omitted.
+	Routes map[string]int
+}
+
+// InFlight returns the number of requests being handled by routes registered
+// on the app. OnShutdown hooks can use it to report or wait for the work that
+// is still draining.
+//
+// Example:
+//
+//	app.OnShutdown(func(ctx context.Context) {
+//	    inFlight := app.InFlight()
+//	    slog.InfoContext(ctx, "draining", "requests", inFlight.Total, "routes", inFlight.Routes)
+//	})
+func (a *App) InFlight() InFlight {
+	return a.drain.inFlight()
+}
+
+// drainRoute counts the in-flight requests of one route.
+type drainRoute struct {
+	key     string        // "METHOD /path"
+	timeout time.Duration // Drain window; zero means the shutdown timeout
+	count   atomic.Int64
+}
+
+// drainTracker tracks in-flight requests per route for graceful shutdown.
+type drainTracker struct {
+	mu     sync.Mutex
+	routes []*drainRoute
+}
+
+// track registers a route and returns the handler that counts its requests.
+func (d *drainTracker) track(method, path string, timeout time.Duration) router.HandlerFunc {
+	r := &drainRoute{key: method + " " + path, timeout: timeout}
+
+	d.mu.Lock()
+	d.routes = append(d.routes, r)
+	d.mu.Unlock()
+
+	return func(c *router.Context) {
+		r.count.Add(1)
+		defer r.count.Add(-1)
+		c.Next()
+	}
+}
+
+// inFlight returns the current in-flight counts.
+func (d *drainTracker) inFlight() InFlight {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	stats := InFlight{Routes: make(map[string]int)}
+	for _, r := range d.routes {
+		if n := int(r.count.Load()); n > 0 {
+			stats.Total += n
+			stats.Routes[r.key] += n
+		}
+	}
+
+	return stats
+}
+
+// window returns how long shutdown must wait for the requests in flight now,
+// given the default shutdown timeout.
+func (d *drainTracker) window(timeout time.Duration) time.Duration {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	longest := timeout
+	for _, r := range d.routes {
+		if r.timeout > longest && r.count.Load() > 0 {
+			longest = r.timeout
+		}
+	}
+
+	return longest
+}
+
+// shutdownContext returns the context that bounds graceful shutdown. It ends
+// after timeout, or later while requests with a longer drain window are in
+// flight, but never after the longest of those windows.
+func (d *drainTracker) shutdownContext(parent context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	longest := d.window(timeout)
+	if longest == timeout {
+		return context.WithTimeout(parent, timeout)
+	}
+
+	start := time.Now()
+	ctx, cancel := context.WithDeadline(parent, start.Add(longest))
+	ctx, cancelCause := context.WithCancelCause(ctx)
+	go func() {
+		ticker := time.NewTicker(drainPollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				if now.Sub(start) >= d.window(timeout) {
+					cancelCause(context.DeadlineExceeded)
+					return
+				}
+			}
+		}
+	}()
+
+	return ctx, func() {
+		cancelCause(context.Canceled)
+		cancel()
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package app
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApp_InFlight(t *testing.T) {
+	t.Parallel()
+
+	a := MustNew(WithServiceName("test"), WithServiceVersion("1.0.0"))
+	started := make(chan struct{})
+	release := make(chan struct{})
+	block := func(c *Context) {
+		started <- struct{}{}
+		<-release
+		c.Status(http.StatusNoContent)
+	}
+	a.POST("/exports", block, WithDrainTimeout(time.Minute))
+	a.Group("/jobs").WithDrainTimeout(time.Hour).GET("/:id", block)
+	a.GET("/quick", func(c *Context) { c.Status(http.StatusNoContent) })
+
+	assert.Equal(t, InFlight{Routes: map[string]int{}}, a.InFlight())
+
+	done := make(chan struct{})
+	for _, req := range []*http.Request{
+		httptest.NewRequest(http.MethodPost, "/exports", nil),
+		httptest.NewRequest(http.MethodPost, "/exports", nil),
+		httptest.NewRequest(http.MethodGet, "/jobs/7", nil),
+	} {
+		go func() {
+			a.Router().ServeHTTP(httptest.NewRecorder(), req)
+			done <- struct{}{}
+		}()
+		<-started
+	}
+	a.Router().ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/quick", nil))
+
+	inFlight := a.InFlight()
+	assert.Equal(t, 3, inFlight.Total)
+	assert.Equal(t, map[string]int{"POST /exports": 2, "GET /jobs/:id": 1}, inFlight.Routes)
+	assert.Equal(t, time.Hour, a.drain.window(30*time.Second), "the longest window of the routes in flight")
+
+	close(release)
+	for range 3 {
+		<-done
+	}
+	assert.Equal(t, 0, a.InFlight().Total)
+	assert.Equal(t, 30*time.Second, a.drain.window(30*time.Second))
+}
+
+func TestDrainTracker_ShutdownContext(t *testing.T) {
+	t.Parallel()
+
+	t.Run("default timeout", func(t *testing.T) {
+		t.Parallel()
+
+		var d drainTracker
+		d.track(http.MethodPost, "/exports", time.Hour)
+
+		ctx, cancel := d.shutdownContext(context.Background(), time.Second)
+		defer cancel()
+		deadline, ok := ctx.Deadline()
+		require.True(t, ok)
+		assert.WithinDuration(t, time.Now().Add(time.Second), deadline, 100*time.Millisecond, "idle long routes don't extend shutdown")
+	})
+
+	t.Run("extended while long requests run", func(t *testing.T) {
+		t.Parallel()
+
+		var d drainTracker
+		d.track(http.MethodPost, "/exports", time.Hour)
+		d.routes[0].count.Add(1)
+
+		ctx, cancel := d.shutdownContext(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		deadline, ok := ctx.Deadline()
+		require.True(t, ok)
+		assert.WithinDuration(t, time.Now().Add(time.Hour), deadline, time.Second)
+
+		select {
+		case <-ctx.Done():
+			t.Fatal("shutdown ended while a long request was in flight")
+		case <-time.After(3 * drainPollInterval):
+		}
+
+		d.routes[0].count.Add(-1)
+		select {
+		case <-ctx.Done():
+			assert.ErrorIs(t, context.Cause(ctx), context.DeadlineExceeded)
+		case <-time.After(5 * drainPollInterval):
+			t.Fatal("shutdown did not end after the long request finished")
+		}
+	})
+}
@@ -19,11 +19,13 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"strconv"
 	"time"
 
 	"go.opentelemetry.io/otel/attribute"
 
 	"rivaas.dev/app"
+	"rivaas.dev/openapi"
 )
 
 // In-memory storage for posts (for demo purposes)
@@ -178,12 +180,18 @@ func ListPosts(c *app.Context) {
 		attribute.Int("results", len(paginatedPosts)),
 	)
 
-	if err := c.JSON(http.StatusOK, map[string]any{
-		"posts":   paginatedPosts,
-		"total":   total,
-		"page":    params.Page,
-		"perPage": params.PerPage,
-	}); err != nil {
+	// The next page number doubles as the opaque cursor and is linked via the Link header.
+	var nextCursor string
+	if end < total {
+		nextCursor = strconv.Itoa(params.Page + 1)
+		next := *c.Request.URL
+		query := next.Query()
+		query.Set("page", nextCursor)
+		next.RawQuery = query.Encode()
+		c.Header("Link", fmt.Sprintf("<%s>; rel=\"next\"", next.RequestURI()))
+	}
+
+	if err := c.JSON(http.StatusOK, openapi.NewPage(paginatedPosts, total, nextCursor)); err != nil {
 		slog.ErrorContext(c.RequestContext(), "failed to write response", "err", err)
 	}
 }
@@ -189,12 +189,7 @@ func main() {
 			openapi.WithSummary("List posts"),
 			openapi.WithOperationDescription("Retrieves a paginated list of blog posts with optional filtering"),
 			openapi.WithRequest(handlers.ListPostsParams{}),
-			openapi.WithResponse(http.StatusOK, map[string]any{
-				"posts":   []handlers.PostResponse{},
-				"total":   0,
-				"page":    1,
-				"perPage": 10,
-			}),
+			openapi.WithResponse(http.StatusOK, openapi.Paginated[handlers.PostResponse]()),
 			openapi.WithTags("posts"),
 		),
 	)
@@ -135,19 +135,36 @@ func TestListPosts(t *testing.T) {
 		}
 		defer resp.Body.Close()
 
-		var result struct {
-			Posts   []handlers.PostResponse `json:"posts"`
-			Total   int                     `json:"total"`
-			Page    int                     `json:"page"`
-			PerPage int                     `json:"perPage"`
+		var result openapi.Page[handlers.PostResponse]
+		app.ExpectJSON(t, resp, http.StatusOK, &result)
+
+		if len(result.Items) > 10 {
+			t.Errorf("Expected at most 10 posts, got %d", len(result.Items))
+		}
+		if result.Total < len(result.Items) {
+			t.Errorf("Expected total >= %d, got %d", len(result.Items), result.Total)
+		}
+	})
+
+	t.Run("next page link", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/posts?perPage=1", nil)
+		resp, err := a.Test(req)
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
 		}
+		defer resp.Body.Close()
+
+		var result openapi.Page[handlers.PostResponse]
 		app.ExpectJSON(t, resp, http.StatusOK, &result)
 
-		if result.Page != 1 {
-			t.Errorf("Expected page 1, got %d", result.Page)
+		if result.Total <= 1 {
+			t.Fatalf("Expected more than 1 post in the seed data, got %d", result.Total)
+		}
+		if result.NextCursor != "2" {
+			t.Errorf("Expected next cursor 2, got %q", result.NextCursor)
 		}
-		if result.PerPage != 10 {
-			t.Errorf("Expected perPage 10, got %d", result.PerPage)
+		if link := resp.Header.Get("Link"); link != `</posts?page=2&perPage=1>; rel="next"` {
+			t.Errorf("Unexpected Link header %q", link)
 		}
 	})
 
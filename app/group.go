@@ -17,6 +17,7 @@ package app
 import (
 	"net/http"
 	"strings"
+	"time"
 
 	"rivaas.dev/openapi"
 	"rivaas.dev/router"
@@ -36,10 +37,11 @@ import (
 //	api.GET("/users", handler)    // handler receives *app.Context
 //	api.POST("/users", handler)   // handler receives *app.Context
 type Group struct {
-	app        *App
-	router     *route.Group
-	prefix     string        // Track prefix for building full paths
-	middleware []HandlerFunc // Group-specific middleware
+	app          *App
+	router       *route.Group
+	prefix       string        // Track prefix for building full paths
+	middleware   []HandlerFunc // Group-specific middleware
+	drainTimeout time.Duration // Drain window of the group's routes; zero uses the server's
 }
 
 // Use adds middleware to the group that will be executed for all routes in this group.
@@ -77,13 +79,29 @@ func (g *Group) Group(prefix string, middleware ...HandlerFunc) *Group {
 	routerGroup := g.router.Group(prefix)
 
 	return &Group{
-		app:        g.app,
-		router:     routerGroup,
-		prefix:     fullPrefix,
-		middleware: allMiddleware,
+		app:          g.app,
+		router:       routerGroup,
+		prefix:       fullPrefix,
+		middleware:   allMiddleware,
+		drainTimeout: g.drainTimeout,
 	}
 }
 
+// WithDrainTimeout gives requests to the group's routes a drain window of d
+// during graceful shutdown, instead of the server's shutdown timeout. It
+// applies to routes registered afterwards, including those of nested groups;
+// the [WithDrainTimeout] route option overrides it. It returns the group for
+// chaining.
+//
+// Example:
+//
+//	jobs := app.Group("/jobs").WithDrainTimeout(10 * time.Minute)
+//	jobs.POST("/import", runImport)
+func (g *Group) WithDrainTimeout(d time.Duration) *Group {
+	g.drainTimeout = d
+	return g
+}
+
 // addRoute adds a route to the group by combining the group's middleware with handlers.
 // It returns the underlying route.Route for constraint configuration.
 // It delegates to the app's registerRouteWithTarget with a routeTarget for this group.
@@ -92,6 +110,7 @@ func (g *Group) addRoute(method, path string, handler HandlerFunc, opts ...Route
 		prefixMiddleware: g.middleware,
 		getFullPath:      g.buildFullPath,
 		version:          "",
+		drainTimeout:     g.drainTimeout,
 		register: func(method, pathForRouter, _ string, handlers []router.HandlerFunc) *route.Route {
 			// route.Group expects []route.Handler (Handler = any)
 			routeHandlers := make([]route.Handler, 0, len(handlers))
@@ -0,0 +1,205 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	riverrors "rivaas.dev/errors"
+	"rivaas.dev/openapi"
+	"rivaas.dev/router"
+	"rivaas.dev/router/route"
+)
+
+// Routes registers routes. It is implemented by [App], [Group], and
+// [VersionGroup], so that [Handle] registers typed handlers on any of them.
+type Routes interface {
+	handle(method, path string, handler HandlerFunc, opts ...RouteOption) *route.Route
+}
+
+// Handle registers fn as the handler of method and path on r. For each
+// request, Handle binds a Req from the path, query, headers, and body,
+// validates it, and calls fn with the request context. The Resp that fn
+// returns is written as JSON with status 200, or the status set by
+// [WithSuccessStatus]; with 204 No Content no body is written. Errors are
+// written by [Context.Fail]: validation errors with 422, other binding errors
+// with 400, and the errors of fn with the status they declare, or 500.
+//
+// The route is documented in the OpenAPI specification with Req as the
+// request and Resp as the response, before any [WithDoc] options of opts.
+//
+// Example:
+//
+//	type GetUserRequest struct {
+//	    ID int `path:"id" validate:"required"`
+//	}
+//
+//	func getUser(ctx context.Context, req GetUserRequest) (User, error) {
+//	    return users.Find(ctx, req.ID)
+//	}
+//
+//	app.Handle(a, http.MethodGet, "/users/:id", getUser,
+//	    app.WithDoc(openapi.WithSummary("Get user")),
+//	)
+//	app.Handle(api, http.MethodPost, "/users", createUser,
+//	    app.WithSuccessStatus(http.StatusCreated),
+//	)
+func Handle[Req, Resp any](r Routes, method, path string, fn func(context.Context, Req) (Resp, error), opts ...RouteOption) *route.Route {
+	cfg := &routeConfig{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(cfg)
+		}
+	}
+	status := cfg.successStatus
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	handler := func(c *Context) {
+		req, err := Bind[Req](c)
+		if err != nil {
+			// Malformed requests are client errors unless the error tells otherwise
+			var typed riverrors.ErrorType
+			if !errors.As(err, &typed) {
+				err = riverrors.WithStatus(err, http.StatusBadRequest)
+			}
+			c.Fail(err)
+			return
+		}
+
+		resp, err := fn(c.RequestContext(), req)
+		if err != nil {
+			c.Fail(err)
+			return
+		}
+
+		if status == http.StatusNoContent {
+			c.Status(status)
+			return
+		}
+		if err = c.JSON(status, resp); err != nil {
+			slog.ErrorContext(c.RequestContext(), "failed to write response", "err", err)
+		}
+	}
+
+	var req Req
+	var resp any
+	if status != http.StatusNoContent {
+		resp = *new(Resp)
+	}
+	info := withHandlerInfo(getFuncName(fn), getCallerLocation(2))
+	doc := func(c *routeConfig) {
+		// Before the route's own documentation, which may override it
+		c.docOpts = append([]openapi.OperationOption{
+			openapi.WithRequest(req),
+			openapi.WithResponse(status, resp),
+		}, c.docOpts...)
+	}
+	opts = append(opts[:len(opts):len(opts)], info, doc)
+
+	return r.handle(method, path, handler, opts...)
+}
+
+// HandlerFuncE is a handler that returns its error instead of writing an
+// error response. Adapt it to a [HandlerFunc] with [E].
+type HandlerFuncE func(*Context) error
+
+// E adapts fn to a [HandlerFunc] that writes the error fn returns with
+// [Context.Fail], through the formatter of [WithErrorHandler]. If fn has
+// already written a response, the error is only logged.
+//
+// Example:
+//
+//	func getUser(c *app.Context) error {
+//	    user, err := users.Find(c.RequestContext(), c.Param("id"))
+//	    if err != nil {
+//	        return err // e.g. errors.WithStatus(err, http.StatusNotFound)
+//	    }
+//	    return c.JSON(http.StatusOK, user)
+//	}
+//
+//	a.GET("/users/:id", app.E(getUser))
+func E(fn HandlerFuncE) HandlerFunc {
+	return func(c *Context) {
+		written, ok := c.Response.(router.WrittenChecker)
+		if !ok {
+			wrapped := router.NewResponseWriterWrapper(c.Response)
+			c.Response = wrapped
+			written = wrapped
+		}
+
+		err := fn(c)
+		if err == nil {
+			return
+		}
+
+		if written.Written() {
+			logger := slog.Default()
+			if c.app != nil {
+				logger = c.app.BaseLogger()
+			}
+			logger.ErrorContext(c.RequestContext(), "handler error after response was written",
+				"error", err,
+				"method", c.Request.Method,
+				"path", c.Request.URL.Path,
+			)
+			return
+		}
+
+		c.Fail(err)
+	}
+}
+
+// WithSuccessStatus sets the status of successful responses of a route
+// registered with [Handle]. Default is 200 OK.
+//
+// Example:
+//
+//	app.Handle(a, http.MethodPost, "/users", createUser,
+//	    app.WithSuccessStatus(http.StatusCreated),
+//	)
+func WithSuccessStatus(status int) RouteOption {
+	return func(c *routeConfig) {
+		c.successStatus = status
+	}
+}
+
+// withHandlerInfo sets the handler name and location shown in the route
+// table, for handlers that wrap the function the user passed.
+func withHandlerInfo(name, location string) RouteOption {
+	return func(c *routeConfig) {
+		c.handlerName = name
+		c.callerLocation = location
+	}
+}
+
+// handle implements [Routes].
+func (a *App) handle(method, path string, handler HandlerFunc, opts ...RouteOption) *route.Route {
+	return a.registerRoute(method, path, handler, opts...)
+}
+
+// handle implements [Routes].
+func (g *Group) handle(method, path string, handler HandlerFunc, opts ...RouteOption) *route.Route {
+	return g.addRoute(method, path, handler, opts...)
+}
+
+// handle implements [Routes].
+func (vg *VersionGroup) handle(method, path string, handler HandlerFunc, opts ...RouteOption) *route.Route {
+	return vg.addRoute(method, path, handler, opts...)
+}
@@ -0,0 +1,223 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	riverrors "rivaas.dev/errors"
+	"rivaas.dev/openapi"
+)
+
+type handleGetRequest struct {
+	ID     int    `path:"id"`
+	Fields string `query:"fields"`
+}
+
+type handleCreateRequest struct {
+	Name  string `json:"name" validate:"required"`
+	Email string `json:"email" validate:"required,email"`
+}
+
+type handleUser struct {
+	ID     int    `json:"id"`
+	Name   string `json:"name"`
+	Fields string `json:"fields,omitempty"`
+}
+
+var errHandleNotFound = riverrors.WithStatus(errors.New("user not found"), http.StatusNotFound)
+
+func getHandleUser(_ context.Context, req handleGetRequest) (handleUser, error) {
+	if req.ID == 0 {
+		return handleUser{}, errHandleNotFound
+	}
+	return handleUser{ID: req.ID, Name: "Ada", Fields: req.Fields}, nil
+}
+
+func TestHandle(t *testing.T) {
+	t.Parallel()
+
+	a := MustNew(WithServiceName("test"), WithServiceVersion("1.0.0"))
+	Handle(a, http.MethodGet, "/users/:id", getHandleUser)
+	Handle(a, http.MethodPost, "/users", func(_ context.Context, req handleCreateRequest) (handleUser, error) {
+		return handleUser{ID: 1, Name: req.Name}, nil
+	}, WithSuccessStatus(http.StatusCreated))
+	Handle(a, http.MethodDelete, "/users/:id", func(context.Context, handleGetRequest) (struct{}, error) {
+		return struct{}{}, nil
+	}, WithSuccessStatus(http.StatusNoContent))
+
+	t.Run("binds path and query", func(t *testing.T) {
+		t.Parallel()
+
+		resp, err := a.Test(httptest.NewRequest(http.MethodGet, "/users/7?fields=name", nil))
+		require.NoError(t, err)
+		var user handleUser
+		ExpectJSON(t, resp, http.StatusOK, &user)
+		assert.Equal(t, handleUser{ID: 7, Name: "Ada", Fields: "name"}, user)
+	})
+
+	t.Run("binds body with success status", func(t *testing.T) {
+		t.Parallel()
+
+		resp, err := a.TestJSON(http.MethodPost, "/users", map[string]string{"name": "Ada", "email": "ada@example.com"})
+		require.NoError(t, err)
+		var user handleUser
+		ExpectJSON(t, resp, http.StatusCreated, &user)
+		assert.Equal(t, "Ada", user.Name)
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		t.Parallel()
+
+		resp, err := a.TestJSON(http.MethodPost, "/users", map[string]string{"name": "Ada", "email": "invalid"})
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	})
+
+	t.Run("malformed body", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader("{"))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := a.Test(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("handler error", func(t *testing.T) {
+		t.Parallel()
+
+		resp, err := a.Test(httptest.NewRequest(http.MethodGet, "/users/0", nil))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.Contains(t, string(body), "user not found")
+	})
+
+	t.Run("no content", func(t *testing.T) {
+		t.Parallel()
+
+		resp, err := a.Test(httptest.NewRequest(http.MethodDelete, "/users/7", nil))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.Empty(t, body)
+	})
+}
+
+func TestHandle_GroupsAndRouteInfo(t *testing.T) {
+	t.Parallel()
+
+	a := MustNew(WithServiceName("test"), WithServiceVersion("1.0.0"))
+	Handle(a.Group("/api"), http.MethodGet, "/users/:id", getHandleUser)
+
+	resp, err := a.Test(httptest.NewRequest(http.MethodGet, "/api/users/3", nil))
+	require.NoError(t, err)
+	var user handleUser
+	ExpectJSON(t, resp, http.StatusOK, &user)
+	assert.Equal(t, 3, user.ID)
+
+	var found bool
+	for _, info := range a.Router().Routes() {
+		if info.Path == "/api/users/:id" {
+			found = true
+			assert.Contains(t, info.HandlerName, "getHandleUser")
+			assert.Contains(t, info.HandlerName, "handle_test.go")
+		}
+	}
+	assert.True(t, found)
+}
+
+func TestHandle_OpenAPI(t *testing.T) {
+	t.Parallel()
+
+	a := MustNew(
+		WithServiceName("test"),
+		WithServiceVersion("1.0.0"),
+		WithOpenAPI(openapi.WithTitle("test-api", "1.0.0")),
+	)
+	Handle(a, http.MethodPost, "/users", func(_ context.Context, req handleCreateRequest) (handleUser, error) {
+		return handleUser{Name: req.Name}, nil
+	}, WithSuccessStatus(http.StatusCreated), WithDoc(openapi.WithSummary("Create user")))
+
+	specJSON, _, err := a.openapi.GenerateSpec(t.Context())
+	require.NoError(t, err)
+
+	var spec struct {
+		Paths map[string]map[string]struct {
+			Summary     string         `json:"summary"`
+			RequestBody map[string]any `json:"requestBody"`
+			Responses   map[string]any `json:"responses"`
+		} `json:"paths"`
+	}
+	require.NoError(t, json.Unmarshal(specJSON, &spec))
+	op := spec.Paths["/users"]["post"]
+	assert.Equal(t, "Create user", op.Summary)
+	assert.NotEmpty(t, op.RequestBody)
+	assert.Contains(t, op.Responses, "201")
+}
+
+func TestE(t *testing.T) {
+	t.Parallel()
+
+	a := MustNew(WithServiceName("test"), WithServiceVersion("1.0.0"))
+	a.GET("/ok", E(func(c *Context) error {
+		return c.JSON(http.StatusOK, handleUser{ID: 1})
+	}))
+	a.GET("/fail", E(func(*Context) error {
+		return errHandleNotFound
+	}))
+	a.GET("/late", E(func(c *Context) error {
+		if err := c.JSON(http.StatusAccepted, handleUser{ID: 2}); err != nil {
+			return err
+		}
+		return errors.New("failed after writing")
+	}))
+
+	resp, err := a.Test(httptest.NewRequest(http.MethodGet, "/ok", nil))
+	require.NoError(t, err)
+	var user handleUser
+	ExpectJSON(t, resp, http.StatusOK, &user)
+	assert.Equal(t, 1, user.ID)
+
+	resp, err = a.Test(httptest.NewRequest(http.MethodGet, "/fail", nil))
+	require.NoError(t, err)
+	var problem map[string]any
+	ExpectJSON(t, resp, http.StatusNotFound, &problem)
+	assert.Equal(t, "user not found", problem["detail"])
+
+	resp, err = a.Test(httptest.NewRequest(http.MethodGet, "/late", nil))
+	require.NoError(t, err)
+	ExpectJSON(t, resp, http.StatusAccepted, &user)
+	assert.Equal(t, 2, user.ID)
+}
@@ -0,0 +1,92 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/quic-go/quic-go/http3"
+)
+
+// WithHTTP3 serves HTTP/3 over QUIC next to HTTPS. The HTTP/3 server listens
+// on the UDP port with the same number as the TCP port, and uses the same
+// router, TLS configuration, and lifecycle hooks. Responses sent over TCP
+// advertise it with an Alt-Svc header, so browsers switch to HTTP/3 on later
+// requests.
+//
+// HTTP/3 requires TLS: use it with [WithTLS], [WithMTLS], or [WithAutoTLS].
+// Make sure the UDP port is open in firewalls and load balancers.
+//
+// Example:
+//
+//	app.New(
+//	    app.WithServiceName("my-api"),
+//	    app.WithTLS("server.crt", "server.key"),
+//	    app.WithHTTP3(),
+//	)
+func WithHTTP3() Option {
+	return func(c *config) {
+		c.server.http3 = true
+	}
+}
+
+// sidecarServer is a server that shares the lifecycle of the main HTTP
+// server, such as the HTTP/3 server.
+type sidecarServer struct {
+	serve    func() error
+	shutdown func(ctx context.Context) error
+}
+
+// newHTTP3Sidecar listens on the UDP address of server and returns the HTTP/3
+// server that serves handler there. It wraps the handler of server so that
+// TCP responses advertise HTTP/3.
+func (a *App) newHTTP3Sidecar(ctx context.Context, server *http.Server, tlsConfig *tls.Config, handler http.Handler) (sidecarServer, error) {
+	conn, err := (&net.ListenConfig{}).ListenPacket(ctx, "udp", server.Addr)
+	if err != nil {
+		return sidecarServer{}, fmt.Errorf("failed to listen on udp %s: %w", server.Addr, err)
+	}
+
+	h3 := &http3.Server{
+		Addr:           server.Addr,
+		Handler:        handler,
+		TLSConfig:      http3.ConfigureTLSConfig(tlsConfig),
+		IdleTimeout:    a.config.server.idleTimeout,
+		MaxHeaderBytes: a.config.server.maxHeaderBytes,
+	}
+
+	tcpHandler := server.Handler
+	server.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		//nolint:errcheck // Only fails before the listener is set up; the next response advertises it
+		h3.SetQUICHeaders(w.Header())
+		tcpHandler.ServeHTTP(w, r)
+	})
+
+	return sidecarServer{
+		serve: func() error {
+			return h3.Serve(conn)
+		},
+		shutdown: func(ctx context.Context) error {
+			err := h3.Shutdown(ctx)
+			if closeErr := conn.Close(); err == nil {
+				err = closeErr
+			}
+			return err
+		},
+	}, nil
+}
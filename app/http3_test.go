@@ -0,0 +1,122 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package app
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/quic-go/quic-go/http3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithHTTP3_RequiresTLS(t *testing.T) {
+	t.Parallel()
+
+	_, err := New(
+		WithServiceName("test"),
+		WithServiceVersion("1.0.0"),
+		WithHTTP3(),
+	)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "WithHTTP3 requires WithTLS, WithMTLS, or WithAutoTLS")
+
+	_, err = New(
+		WithServiceName("test"),
+		WithServiceVersion("1.0.0"),
+		WithTLS("server.crt", "server.key"),
+		WithHTTP3(),
+	)
+	require.NoError(t, err)
+}
+
+func TestNewHTTP3Sidecar(t *testing.T) {
+	t.Parallel()
+
+	a := MustNew(WithServiceName("test"), WithServiceVersion("1.0.0"))
+	cert, _ := mustGenServerCert(t)
+	tlsConfig := &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12}
+
+	server := &http.Server{
+		Addr: "127.0.0.1:0",
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = io.WriteString(w, "tcp")
+		}),
+	}
+	h3Handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, r.Proto)
+	})
+
+	sidecar, err := a.newHTTP3Sidecar(t.Context(), server, tlsConfig, h3Handler)
+	require.NoError(t, err)
+	serveErr := make(chan error, 1)
+	go func() { serveErr <- sidecar.serve() }()
+
+	// TCP responses advertise the UDP port once the server is listening
+	altSvc := regexp.MustCompile(`h3=":(\d+)"`)
+	var port string
+	require.Eventually(t, func() bool {
+		rec := httptest.NewRecorder()
+		server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.Equal(t, "tcp", rec.Body.String())
+		if m := altSvc.FindStringSubmatch(rec.Header().Get("Alt-Svc")); m != nil {
+			port = m[1]
+			return true
+		}
+		return false
+	}, 2*time.Second, 10*time.Millisecond)
+
+	transport := &http3.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}} //nolint:gosec // Self-signed test certificate
+	defer transport.Close()
+	client := &http.Client{Transport: transport, Timeout: 5 * time.Second}
+
+	resp, err := client.Get("https://127.0.0.1:" + port + "/")
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, "HTTP/3.0", string(body))
+
+	ctx, cancel := context.WithTimeout(t.Context(), 2*time.Second)
+	defer cancel()
+	require.NoError(t, sidecar.shutdown(ctx))
+	assert.ErrorIs(t, <-serveErr, http.ErrServerClosed)
+}
+
+func TestAuthorizeMTLSRequest(t *testing.T) {
+	t.Parallel()
+
+	cert, _ := mustGenServerCert(t)
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	require.NoError(t, err)
+	deny := &mtlsConfig{authorize: func(*x509.Certificate) (string, bool) { return "", false }}
+
+	withCert := httptest.NewRequest(http.MethodGet, "/", nil)
+	withCert.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{leaf}}
+
+	assert.False(t, authorizeMTLSRequest(withCert, deny))
+	assert.True(t, authorizeMTLSRequest(withCert, &mtlsConfig{}))
+	assert.True(t, authorizeMTLSRequest(httptest.NewRequest(http.MethodGet, "/", nil), deny))
+}
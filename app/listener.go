@@ -0,0 +1,307 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// Listener is an address or an open listener that [App.Start] serves on.
+// Create one with [Listen] or [FromListener].
+type Listener struct {
+	network string
+	address string
+	ln      net.Listener
+	err     error
+
+	// TLS: inherit the app's TLS configuration unless tlsSet is true,
+	// in which case tlsConfig is used, and nil means plain HTTP.
+	tlsSet    bool
+	tlsConfig *tls.Config
+}
+
+// ListenerOption configures a [Listener].
+type ListenerOption func(*Listener)
+
+// Listen returns a [Listener] for addr. Use "host:port" or "tcp://host:port"
+// for TCP, and "unix:///path/to/socket" for a Unix domain socket. A socket
+// file left behind by an unclean exit is removed before listening.
+//
+// Example:
+//
+//	err := a.Start(ctx,
+//	    app.Listen(":8080"),
+//	    app.Listen("unix:///run/my-api/api.sock", app.WithoutListenerTLS()),
+//	)
+func Listen(addr string, opts ...ListenerOption) Listener {
+	l := Listener{network: "tcp", address: addr}
+	switch {
+	case strings.HasPrefix(addr, "unix://"):
+		l.network, l.address = "unix", strings.TrimPrefix(addr, "unix://")
+	case strings.HasPrefix(addr, "tcp://"):
+		l.address = strings.TrimPrefix(addr, "tcp://")
+	}
+	if l.address == "" {
+		l.err = fmt.Errorf("invalid listen address %q", addr)
+	}
+
+	return l.apply(opts)
+}
+
+// FromListener returns a [Listener] that serves on ln, such as a socket
+// passed by systemd socket activation. The server closes ln on shutdown.
+//
+// Example:
+//
+//	// import "github.com/coreos/go-systemd/v22/activation"
+//	listeners, err := activation.Listeners()
+//	if err != nil || len(listeners) == 0 {
+//	    log.Fatal("no socket passed by systemd")
+//	}
+//	err = a.Start(ctx, app.FromListener(listeners[0]))
+func FromListener(ln net.Listener, opts ...ListenerOption) Listener {
+	l := Listener{ln: ln}
+	if ln == nil {
+		l.err = errors.New("listener cannot be nil")
+	}
+
+	return l.apply(opts)
+}
+
+// WithListenerTLS serves the listener with tlsConfig instead of the TLS
+// configuration of [WithTLS], [WithMTLS], or [WithAutoTLS].
+//
+// Example:
+//
+//	app.Listen(":9443", app.WithListenerTLS(&tls.Config{
+//	    Certificates: []tls.Certificate{internalCert},
+//	    MinVersion:   tls.VersionTLS13,
+//	}))
+func WithListenerTLS(tlsConfig *tls.Config) ListenerOption {
+	return func(l *Listener) {
+		l.tlsSet = true
+		l.tlsConfig = tlsConfig
+	}
+}
+
+// WithoutListenerTLS serves the listener over plain HTTP, even when the app
+// is configured with [WithTLS], [WithMTLS], or [WithAutoTLS]. It suits Unix
+// sockets that only local processes can reach.
+func WithoutListenerTLS() ListenerOption {
+	return WithListenerTLS(nil)
+}
+
+// apply applies opts to the listener.
+func (l Listener) apply(opts []ListenerOption) Listener {
+	for _, opt := range opts {
+		opt(&l)
+	}
+
+	return l
+}
+
+// String returns the address of the listener, with a "unix://" prefix for Unix sockets.
+func (l Listener) String() string {
+	if l.ln != nil {
+		addr := l.ln.Addr()
+		if addr.Network() == "unix" {
+			return "unix://" + addr.String()
+		}
+
+		return addr.String()
+	}
+	if l.network == "unix" {
+		return "unix://" + l.address
+	}
+
+	return l.address
+}
+
+// listen returns the open listener, or listens on the address.
+func (l Listener) listen(ctx context.Context) (net.Listener, error) {
+	if l.ln != nil {
+		return l.ln, nil
+	}
+
+	if l.network == "unix" {
+		if err := removeStaleSocket(ctx, l.address); err != nil {
+			return nil, fmt.Errorf("failed to listen on %s: %w", l, err)
+		}
+	}
+
+	ln, err := (&net.ListenConfig{}).Listen(ctx, l.network, l.address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", l, err)
+	}
+
+	return ln, nil
+}
+
+// removeStaleSocket removes the Unix socket at path left behind by a process
+// that did not shut down cleanly, so that listening on it does not fail with
+// "address already in use". Files that are not sockets and sockets that still
+// accept connections are left alone.
+func removeStaleSocket(ctx context.Context, path string) error {
+	info, err := os.Lstat(path)
+	if err != nil || info.Mode()&os.ModeSocket == 0 {
+		return nil //nolint:nilerr // Nothing to remove; Listen reports real problems
+	}
+
+	if conn, dialErr := (&net.Dialer{}).DialContext(ctx, "unix", path); dialErr == nil {
+		_ = conn.Close()
+		return nil // In use by another server
+	}
+
+	if err = os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("remove stale socket: %w", err)
+	}
+
+	return nil
+}
+
+// validateListeners checks the listeners passed to [App.Start] before any
+// hook runs.
+func (a *App) validateListeners(listeners []Listener) error {
+	if a.config.server.http3 || a.config.server.httpsRedirect {
+		return errors.New("WithHTTP3 and WithHTTPSRedirect cannot be used with listeners passed to Start")
+	}
+	for _, l := range listeners {
+		if l.err != nil {
+			return l.err
+		}
+	}
+
+	return nil
+}
+
+// startListeners runs the server on listeners instead of the configured address.
+func (a *App) startListeners(ctx context.Context, server *http.Server, listeners []Listener) error {
+	appTLS, err := a.serverTLSConfig()
+	if err != nil {
+		return err
+	}
+
+	lns := make([]net.Listener, 0, len(listeners))
+	addrs := make([]listenAddr, 0, len(listeners))
+	closeAll := func() {
+		for _, ln := range lns {
+			if closeErr := ln.Close(); closeErr != nil {
+				a.logLifecycleEvent(ctx, slog.LevelError, "failed to close listener", "error", closeErr)
+			}
+		}
+	}
+	for _, l := range listeners {
+		ln, listenErr := l.listen(ctx)
+		if listenErr != nil {
+			closeAll()
+			return listenErr
+		}
+
+		tlsConfig := appTLS
+		if l.tlsSet {
+			tlsConfig = l.tlsConfig
+		}
+		if tlsConfig != nil {
+			ln = tls.NewListener(ln, listenerTLSConfig(tlsConfig))
+		}
+		lns = append(lns, ln)
+		addrs = append(addrs, listenAddr{addr: l.String(), secure: tlsConfig != nil})
+	}
+
+	// The first listener runs as the main server, the others as sidecars;
+	// server.Shutdown closes all of them.
+	sidecars := make([]sidecarServer, 0, len(lns)-1)
+	for _, ln := range lns[1:] {
+		sidecars = append(sidecars, sidecarServer{
+			serve: func() error {
+				return server.Serve(ln)
+			},
+			shutdown: func(context.Context) error {
+				return nil
+			},
+		})
+	}
+
+	protocol := "HTTP"
+	if appTLS != nil {
+		protocol = a.serverProtocol()
+	}
+
+	return a.runServer(ctx, server, func() error {
+		return server.Serve(lns[0])
+	}, protocol, addrs, sidecars...)
+}
+
+// listenerTLSConfig returns tlsConfig with HTTP/2 enabled, as
+// [http.Server.ListenAndServeTLS] does, unless it sets its own protocols.
+func listenerTLSConfig(tlsConfig *tls.Config) *tls.Config {
+	if len(tlsConfig.NextProtos) > 0 {
+		return tlsConfig
+	}
+	tlsConfig = tlsConfig.Clone()
+	tlsConfig.NextProtos = []string{"h2", "http/1.1"}
+
+	return tlsConfig
+}
+
+// serverTLSConfig returns the TLS configuration of [WithTLS], [WithMTLS], or
+// [WithAutoTLS], or nil for plain HTTP.
+func (a *App) serverTLSConfig() (*tls.Config, error) {
+	switch {
+	case a.config.server.autoTLS.enabled():
+		return newAutoTLSConfig(a.config.server.autoTLS.manager()), nil
+	case a.config.server.tlsCertFile != "":
+		return a.certFileTLSConfig()
+	case len(a.config.server.mtlsServerCert.Certificate) > 0:
+		cfg := newMTLSConfig(a.config.server.mtlsServerCert, a.config.server.mtlsOpts...)
+		tlsConfig := cfg.buildTLSConfig()
+		if cfg.authorize != nil {
+			// Listeners share the server's ConnState, so authorize during the handshake
+			tlsConfig.VerifyConnection = func(cs tls.ConnectionState) error {
+				if len(cs.PeerCertificates) > 0 {
+					if _, allowed := cfg.authorize(cs.PeerCertificates[0]); !allowed {
+						return errors.New("client certificate not authorized")
+					}
+				}
+				return nil
+			}
+		}
+
+		return tlsConfig, nil
+	}
+
+	return nil, nil
+}
+
+// serverProtocol returns the protocol name of the TLS configuration.
+func (a *App) serverProtocol() string {
+	switch {
+	case a.config.server.autoTLS.enabled():
+		return "AutoTLS"
+	case len(a.config.server.mtlsServerCert.Certificate) > 0:
+		return "mTLS"
+	}
+
+	return "HTTPS"
+}
@@ -0,0 +1,240 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package app
+
+import (
+	"context"
+	"crypto/tls"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListen(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		addr    string
+		network string
+		address string
+		str     string
+	}{
+		{addr: ":8080", network: "tcp", address: ":8080", str: ":8080"},
+		{addr: "tcp://127.0.0.1:8080", network: "tcp", address: "127.0.0.1:8080", str: "127.0.0.1:8080"},
+		{addr: "unix:///run/api.sock", network: "unix", address: "/run/api.sock", str: "unix:///run/api.sock"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			t.Parallel()
+
+			l := Listen(tt.addr)
+			require.NoError(t, l.err)
+			assert.Equal(t, tt.network, l.network)
+			assert.Equal(t, tt.address, l.address)
+			assert.Equal(t, tt.str, l.String())
+			assert.False(t, l.tlsSet)
+		})
+	}
+
+	require.Error(t, Listen("unix://").err)
+	require.Error(t, FromListener(nil).err)
+}
+
+func TestListenerTLSOptions(t *testing.T) {
+	t.Parallel()
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS13}
+	l := Listen(":9443", WithListenerTLS(tlsConfig))
+	assert.True(t, l.tlsSet)
+	assert.Same(t, tlsConfig, l.tlsConfig)
+
+	l = Listen(":9443", WithoutListenerTLS())
+	assert.True(t, l.tlsSet)
+	assert.Nil(t, l.tlsConfig)
+
+	withProtos := listenerTLSConfig(tlsConfig)
+	assert.Equal(t, []string{"h2", "http/1.1"}, withProtos.NextProtos)
+	assert.Empty(t, tlsConfig.NextProtos, "must not modify the given config")
+}
+
+func TestStart_ListenersRejectHTTP3(t *testing.T) {
+	t.Parallel()
+
+	a := MustNew(
+		WithServiceName("test"),
+		WithServiceVersion("1.0.0"),
+		WithTLS("server.crt", "server.key"),
+		WithHTTP3(),
+	)
+	started := false
+	a.OnStart(func(context.Context) error {
+		started = true
+		return nil
+	})
+
+	err := a.Start(t.Context(), Listen(":0"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot be used with listeners")
+	assert.False(t, started, "hooks must not run")
+}
+
+func TestStart_Listeners(t *testing.T) {
+	t.Parallel()
+
+	a := MustNew(WithServiceName("test"), WithServiceVersion("1.0.0"))
+	a.GET("/proto", func(c *Context) {
+		proto := "http"
+		if c.Request.TLS != nil {
+			proto = "https"
+		}
+		_ = c.String(http.StatusOK, proto)
+	})
+
+	tcp, err := (&net.ListenConfig{}).Listen(t.Context(), "tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	secure, err := (&net.ListenConfig{}).Listen(t.Context(), "tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	// Unix socket paths are limited to about 100 bytes, so avoid long test directories
+	dir, err := os.MkdirTemp("", "rivaas")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+	socket := filepath.Join(dir, "api.sock")
+
+	cert, _ := mustGenServerCert(t)
+	ctx, cancel := context.WithCancel(t.Context())
+	done := make(chan error, 1)
+	go func() {
+		done <- a.Start(ctx,
+			FromListener(tcp),
+			FromListener(secure, WithListenerTLS(&tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12})),
+			Listen("unix://"+socket),
+		)
+	}()
+
+	get := func(client *http.Client, url string) string {
+		t.Helper()
+		var body []byte
+		require.EventuallyWithT(t, func(c *assert.CollectT) {
+			resp, getErr := client.Get(url)
+			if !assert.NoError(c, getErr) {
+				return
+			}
+			defer resp.Body.Close()
+			body, getErr = io.ReadAll(resp.Body)
+			assert.NoError(c, getErr)
+		}, 2*time.Second, 10*time.Millisecond)
+		return string(body)
+	}
+
+	assert.Equal(t, "http", get(http.DefaultClient, "http://"+tcp.Addr().String()+"/proto"))
+
+	tlsClient := &http.Client{Transport: &http.Transport{
+		TLSClientConfig: &tls.Config{InsecureSkipVerify: true}, //nolint:gosec // Self-signed test certificate
+	}}
+	assert.Equal(t, "https", get(tlsClient, "https://"+secure.Addr().String()+"/proto"))
+
+	unixClient := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "unix", socket)
+		},
+	}}
+	assert.Equal(t, "http", get(unixClient, "http://unix/proto"))
+
+	cancel()
+	require.NoError(t, <-done)
+	_, err = os.Stat(socket)
+	assert.True(t, os.IsNotExist(err), "socket must be removed on shutdown")
+}
+
+func TestStart_StaleUnixSocket(t *testing.T) {
+	t.Parallel()
+
+	// Unix socket paths are limited to about 100 bytes, so avoid long test directories
+	dir, err := os.MkdirTemp("", "rivaas")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+	socket := filepath.Join(dir, "api.sock")
+
+	// An unclean exit leaves the socket file behind
+	stale, err := (&net.ListenConfig{}).Listen(t.Context(), "unix", socket)
+	require.NoError(t, err)
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	require.NoError(t, stale.Close())
+	_, err = os.Stat(socket)
+	require.NoError(t, err, "the stale socket must exist")
+
+	client := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "unix", socket)
+		},
+	}}
+
+	for range 2 {
+		a := MustNew(WithServiceName("test"), WithServiceVersion("1.0.0"))
+		a.GET("/ping", func(c *Context) {
+			_ = c.String(http.StatusOK, "pong")
+		})
+
+		ctx, cancel := context.WithCancel(t.Context())
+		done := make(chan error, 1)
+		go func() {
+			done <- a.Start(ctx, Listen("unix://"+socket))
+		}()
+
+		assert.EventuallyWithT(t, func(c *assert.CollectT) {
+			resp, getErr := client.Get("http://unix/ping")
+			if !assert.NoError(c, getErr) {
+				return
+			}
+			_ = resp.Body.Close()
+			assert.Equal(c, http.StatusOK, resp.StatusCode)
+		}, 2*time.Second, 10*time.Millisecond)
+
+		cancel()
+		require.NoError(t, <-done)
+	}
+}
+
+func TestRemoveStaleSocket_KeepsOtherFiles(t *testing.T) {
+	t.Parallel()
+
+	dir, err := os.MkdirTemp("", "rivaas")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+
+	file := filepath.Join(dir, "api.sock")
+	require.NoError(t, os.WriteFile(file, []byte("data"), 0o600))
+	require.NoError(t, removeStaleSocket(t.Context(), file))
+	_, err = os.Stat(file)
+	require.NoError(t, err, "regular files are not removed")
+
+	live := filepath.Join(dir, "live.sock")
+	ln, err := (&net.ListenConfig{}).Listen(t.Context(), "unix", live)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ln.Close() })
+	require.NoError(t, removeStaleSocket(t.Context(), live))
+	_, err = os.Stat(live)
+	require.NoError(t, err, "sockets in use are not removed")
+}
@@ -0,0 +1,264 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"rivaas.dev/openapi/contract"
+)
+
+// defaultMaxCapturedResponseBytes caps how much of each response body is buffered for validation.
+const defaultMaxCapturedResponseBytes = 1 << 20 // 1MB
+
+// OpenAPIValidationOption configures runtime validation of traffic against the generated spec.
+type OpenAPIValidationOption func(*openapiValidationSettings)
+
+// openapiValidationSettings holds runtime contract validation configuration.
+type openapiValidationSettings struct {
+	validateResponses bool
+	reportOnly        bool
+	onViolation       func(c *Context, err *contract.Error)
+	contractOptions   []contract.Option
+}
+
+// WithOpenAPIValidation validates requests (and optionally responses) against the
+// OpenAPI specification generated from the app's documented routes.
+//
+// Requires [WithOpenAPI]. Routes without documentation are not validated.
+// By default, requests that violate the spec are rejected with 400 through the
+// configured error formatter. Use [WithValidationReportOnly] to log violations
+// without rejecting, which is useful for catching drift in staging or production.
+//
+// Example:
+//
+//	app.New(
+//	    app.WithOpenAPI(openapi.WithTitle("My API", "1.0.0")),
+//	    app.WithOpenAPIValidation(
+//	        app.WithResponseValidation(),
+//	        app.WithValidationReportOnly(),
+//	    ),
+//	)
+func WithOpenAPIValidation(opts ...OpenAPIValidationOption) Option {
+	return func(c *config) {
+		s := &openapiValidationSettings{}
+		for i, opt := range opts {
+			if opt == nil {
+				c.validationErrors = append(c.validationErrors, fmt.Errorf("app: openapi validation option at index %d cannot be nil", i))
+				continue
+			}
+			opt(s)
+		}
+		c.openapiValidation = s
+	}
+}
+
+// WithResponseValidation also validates responses (status code, content type, and
+// JSON body) against the spec. Responses have already been sent when they are
+// validated, so violations are always reported rather than rejected.
+//
+// Example:
+//
+//	app.WithOpenAPIValidation(app.WithResponseValidation())
+func WithResponseValidation() OpenAPIValidationOption {
+	return func(s *openapiValidationSettings) {
+		s.validateResponses = true
+	}
+}
+
+// WithValidationReportOnly logs request violations instead of rejecting the request.
+//
+// Example:
+//
+//	app.WithOpenAPIValidation(app.WithValidationReportOnly())
+func WithValidationReportOnly() OpenAPIValidationOption {
+	return func(s *openapiValidationSettings) {
+		s.reportOnly = true
+	}
+}
+
+// WithViolationHandler sets a callback invoked for every request or response violation,
+// in addition to logging. Use it to emit metrics or forward drift reports.
+// The callback must not write to the response.
+//
+// Example:
+//
+//	app.WithOpenAPIValidation(
+//	    app.WithViolationHandler(func(c *app.Context, err *contract.Error) {
+//	        c.IncrementCounter("contract_violations_total",
+//	            attribute.String("direction", string(err.Direction)))
+//	    }),
+//	)
+func WithViolationHandler(fn func(c *Context, err *contract.Error)) OpenAPIValidationOption {
+	return func(s *openapiValidationSettings) {
+		s.onViolation = fn
+	}
+}
+
+// WithContractOptions passes options to the underlying [contract.Validator].
+//
+// Example:
+//
+//	app.WithOpenAPIValidation(
+//	    app.WithContractOptions(contract.WithFormatAssertions(false)),
+//	)
+func WithContractOptions(opts ...contract.Option) OpenAPIValidationOption {
+	return func(s *openapiValidationSettings) {
+		s.contractOptions = append(s.contractOptions, opts...)
+	}
+}
+
+// contractValidatorCache builds a contract.Validator from the current spec and
+// rebuilds it whenever the spec changes (tracked by ETag).
+type contractValidatorCache struct {
+	state *openapiState
+	opts  []contract.Option
+
+	mu        sync.Mutex
+	etag      string
+	validator *contract.Validator
+}
+
+// get returns a validator for the current spec.
+func (cc *contractValidatorCache) get(ctx context.Context) (*contract.Validator, error) {
+	spec, etag, err := cc.state.GenerateSpec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	if cc.validator != nil && cc.etag == etag {
+		return cc.validator, nil
+	}
+	v, err := contract.New(spec, cc.opts...)
+	if err != nil {
+		return nil, err
+	}
+	cc.validator = v
+	cc.etag = etag
+	return v, nil
+}
+
+// openapiValidationMiddleware returns middleware enforcing the spec on documented routes.
+func (a *App) openapiValidationMiddleware(s *openapiValidationSettings) HandlerFunc {
+	cache := &contractValidatorCache{state: a.openapi, opts: s.contractOptions}
+
+	return func(c *Context) {
+		v, err := cache.get(c.RequestContext())
+		if err != nil {
+			a.BaseLogger().ErrorContext(c.RequestContext(), "openapi validation disabled for request: spec unavailable", "error", err)
+			c.Next()
+			return
+		}
+
+		var cerr *contract.Error
+		if reqErr := v.ValidateRequest(c.Request); errors.As(reqErr, &cerr) {
+			a.reportViolation(c, s, cerr)
+			if !s.reportOnly {
+				c.Fail(cerr)
+				return
+			}
+		}
+
+		if !s.validateResponses {
+			c.Next()
+			return
+		}
+
+		capture := &responseCapture{ResponseWriter: c.Response, status: http.StatusOK, limit: defaultMaxCapturedResponseBytes}
+		c.Response = capture
+		defer func() { c.Response = capture.ResponseWriter }()
+
+		c.Next()
+
+		body := capture.body.Bytes()
+		if capture.truncated {
+			body = nil
+		}
+		if respErr := v.ValidateResponse(c.Request, capture.status, capture.Header(), body); errors.As(respErr, &cerr) {
+			a.reportViolation(c, s, cerr)
+		}
+	}
+}
+
+// reportViolation logs a contract violation and invokes the user callback.
+func (a *App) reportViolation(c *Context, s *openapiValidationSettings, err *contract.Error) {
+	violations := make([]string, 0, len(err.Violations))
+	for _, v := range err.Violations {
+		violations = append(violations, v.String())
+	}
+	a.BaseLogger().WarnContext(c.RequestContext(), "openapi contract violation",
+		"direction", string(err.Direction),
+		"method", err.Method,
+		"operation", err.Path,
+		"path", c.Request.URL.Path,
+		"violations", violations,
+		"report_only", s.reportOnly || err.Direction == contract.DirectionResponse,
+	)
+	if s.onViolation != nil {
+		s.onViolation(c, err)
+	}
+}
+
+// responseCapture tees the response body into a bounded buffer for validation.
+type responseCapture struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+	limit       int
+	truncated   bool
+}
+
+// WriteHeader records the status code.
+func (rc *responseCapture) WriteHeader(code int) {
+	if !rc.wroteHeader {
+		rc.status = code
+		rc.wroteHeader = true
+	}
+	rc.ResponseWriter.WriteHeader(code)
+}
+
+// Write records up to limit bytes of the body and forwards the write.
+func (rc *responseCapture) Write(b []byte) (int, error) {
+	rc.wroteHeader = true
+	if !rc.truncated {
+		if rc.body.Len()+len(b) > rc.limit {
+			rc.truncated = true
+			rc.body.Reset()
+		} else {
+			rc.body.Write(b)
+		}
+	}
+	return rc.ResponseWriter.Write(b)
+}
+
+// Flush forwards to the underlying writer when it supports flushing.
+func (rc *responseCapture) Flush() {
+	if f, ok := rc.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap returns the underlying writer for http.ResponseController.
+func (rc *responseCapture) Unwrap() http.ResponseWriter {
+	return rc.ResponseWriter
+}
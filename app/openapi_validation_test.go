@@ -0,0 +1,174 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package app
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"rivaas.dev/openapi"
+	"rivaas.dev/openapi/contract"
+)
+
+type validationUserRequest struct {
+	Name string `json:"name" validate:"required"`
+}
+
+type validationUser struct {
+	ID   int    `json:"id" validate:"required"`
+	Name string `json:"name" validate:"required"`
+}
+
+func newValidationTestApp(t *testing.T, opts ...OpenAPIValidationOption) *App {
+	t.Helper()
+	a, err := New(
+		WithServiceName("test"),
+		WithServiceVersion("1.0.0"),
+		WithOpenAPI(openapi.WithTitle("test-api", "1.0.0")),
+		WithOpenAPIValidation(opts...),
+	)
+	require.NoError(t, err)
+
+	a.POST("/users", func(c *Context) {
+		_ = c.JSON(http.StatusCreated, map[string]any{"id": 1, "name": "Ada"})
+	}, WithDoc(openapi.WithRequest(validationUserRequest{}), openapi.WithResponse(http.StatusCreated, validationUser{})))
+
+	a.GET("/users/:id", func(c *Context) {
+		_ = c.JSON(http.StatusOK, map[string]any{"id": "not-a-number"})
+	}, WithDoc(openapi.WithResponse(http.StatusOK, validationUser{})))
+
+	a.GET("/undocumented", func(c *Context) {
+		_ = c.String(http.StatusOK, "ok")
+	}, WithoutDoc())
+
+	return a
+}
+
+func newJSONRequest(method, target, body string) *http.Request {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	return req
+}
+
+func TestWithOpenAPIValidation_RejectsInvalidRequest(t *testing.T) {
+	t.Parallel()
+
+	a := newValidationTestApp(t)
+
+	resp, err := a.Test(newJSONRequest(http.MethodPost, "/users", `{}`))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp, err = a.Test(newJSONRequest(http.MethodPost, "/users", `{"name":"Ada"}`))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+}
+
+func TestWithOpenAPIValidation_SkipsUndocumentedRoutes(t *testing.T) {
+	t.Parallel()
+
+	a := newValidationTestApp(t)
+
+	resp, err := a.Test(httptest.NewRequest(http.MethodGet, "/undocumented", nil))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestWithOpenAPIValidation_ReportOnly(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	var reported []*contract.Error
+	a := newValidationTestApp(t,
+		WithValidationReportOnly(),
+		WithViolationHandler(func(_ *Context, err *contract.Error) {
+			mu.Lock()
+			defer mu.Unlock()
+			reported = append(reported, err)
+		}),
+	)
+
+	resp, err := a.Test(newJSONRequest(http.MethodPost, "/users", `{}`))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, reported, 1)
+	assert.Equal(t, contract.DirectionRequest, reported[0].Direction)
+}
+
+func TestWithOpenAPIValidation_ReportsResponseViolations(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	var reported []*contract.Error
+	a := newValidationTestApp(t,
+		WithResponseValidation(),
+		WithViolationHandler(func(_ *Context, err *contract.Error) {
+			mu.Lock()
+			defer mu.Unlock()
+			reported = append(reported, err)
+		}),
+	)
+
+	resp, err := a.Test(httptest.NewRequest(http.MethodGet, "/users/42", nil))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "response violations must not alter the response")
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, reported, 1)
+	assert.Equal(t, contract.DirectionResponse, reported[0].Direction)
+	assert.Equal(t, "/users/{id}", reported[0].Path)
+}
+
+func TestWithOpenAPIValidation_RequiresOpenAPI(t *testing.T) {
+	t.Parallel()
+
+	_, err := New(
+		WithServiceName("test"),
+		WithServiceVersion("1.0.0"),
+		WithOpenAPIValidation(),
+	)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "openapiValidation")
+}
+
+func TestWithOpenAPIValidation_NilOption(t *testing.T) {
+	t.Parallel()
+
+	_, err := New(
+		WithServiceName("test"),
+		WithServiceVersion("1.0.0"),
+		WithOpenAPI(openapi.WithTitle("test-api", "1.0.0")),
+		WithOpenAPIValidation(nil),
+	)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "openapi validation option at index 0 cannot be nil")
+}
@@ -72,7 +72,8 @@ func WithEnvironment(env string) Option {
 }
 
 // WithPort sets the server listen port.
-// Default is 8080 for HTTP; when using [WithTLS] or [WithMTLS] the default is 8443.
+// Default is 8080 for HTTP; when using [WithTLS] or [WithMTLS] the default is 8443,
+// and when using [WithAutoTLS] it is 443.
 // Override with WithPort(n) in all cases. Can be overridden by RIVAAS_PORT when [WithEnv] is used.
 //
 // Example:
@@ -456,9 +457,40 @@ func WithErrorFormatterFor(mediaType string, opts ...errors.Option) Option {
 	}
 }
 
+// WithErrorHandler sets the formatter that turns every handler error into a response:
+// errors passed to [Context.Fail] and its shortcuts, returned by [HandlerFuncE] handlers,
+// and returned by [Handle] functions. It takes a pre-built formatter, such as RFC 9457
+// problem details or JSON:API errors, and is the single-formatter counterpart of
+// [WithErrorFormatters]; the two cannot be mixed.
+//
+// Example:
+//
+//	app.New(
+//	    app.WithServiceName("my-service"),
+//	    app.WithErrorHandler(errors.MustNew(errors.WithRFC9457("https://api.example.com/problems"))),
+//	)
+func WithErrorHandler(formatter errors.Formatter) Option {
+	return func(c *config) {
+		if c.errors == nil {
+			c.errors = &errorsConfig{}
+		}
+		if formatter == nil {
+			c.errors.initErr = fmt.Errorf("error handler formatter cannot be nil")
+			return
+		}
+		if len(c.errors.formatters) > 0 {
+			c.errors.modeErr = fmt.Errorf("cannot use single error formatter when content-negotiated formatters are configured")
+			return
+		}
+		c.errors.formatter = formatter
+		c.errors.singleFormatterExplicitlySet = true
+	}
+}
+
 // WithErrorFormatters configures multiple error formatters with content negotiation by Accept header.
 // Advanced: use when you need to pass pre-built or custom formatters. Prefer [WithErrorFormatterFor]
-// for option-based configuration.
+// for option-based configuration. It cannot be combined with a single formatter from
+// [WithErrorHandler] or [WithErrorFormatterFor] with an empty media type, in either order.
 //
 // Example:
 //
@@ -475,9 +507,12 @@ func WithErrorFormatters(formatters map[string]errors.Formatter) Option {
 		if c.errors == nil {
 			c.errors = &errorsConfig{}
 		}
+		if c.errors.singleFormatterExplicitlySet {
+			c.errors.modeErr = fmt.Errorf("cannot use content-negotiated formatters when single error formatter is configured")
+			return
+		}
 		c.errors.formatters = formatters
 		c.errors.formatter = nil
-		c.errors.singleFormatterExplicitlySet = false
 	}
 }
 
@@ -83,6 +83,32 @@ func TestWithErrorFormatterFor_ContentNegotiation_Accumulates(t *testing.T) {
 	assert.True(t, strings.Contains(ct, "application/json") || strings.Contains(ct, "application/problem+json"), "Content-Type should be JSON or problem+json, got %q", ct)
 }
 
+func TestWithErrorFormatterFor_NegotiatingFormatter_XML(t *testing.T) {
+	t.Parallel()
+
+	a, err := New(
+		WithServiceName("test"),
+		WithServiceVersion("1.0.0"),
+		WithErrorFormatterFor("", riverrors.WithContentNegotiation("https://api.example.com/problems")),
+	)
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	req.Header.Set("Accept", "application/problem+xml")
+	c := a.contextPool.Get()
+	c.Context = &router.Context{Request: req, Response: rec}
+	c.app = a
+
+	c.Fail(riverrors.WithStatus(errors.New("user not found"), http.StatusNotFound))
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, "application/problem+xml; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.Equal(t, "Accept", rec.Header().Get("Vary"))
+	assert.Contains(t, rec.Body.String(), `<problem xmlns="urn:ietf:rfc:7807">`)
+	assert.Contains(t, rec.Body.String(), "<detail>user not found</detail>")
+}
+
 func TestWithErrorFormatterFor_SingleThenContentNegotiated_ValidationFails(t *testing.T) {
 	t.Parallel()
 
@@ -131,3 +157,54 @@ func TestWithErrorFormatterFor_InvalidOptions_ValidationFails(t *testing.T) {
 	}
 	assert.True(t, found, "expected errors field in validation result")
 }
+
+func TestWithErrorHandler(t *testing.T) {
+	t.Parallel()
+
+	a, err := New(
+		WithServiceName("test"),
+		WithServiceVersion("1.0.0"),
+		WithErrorHandler(riverrors.MustNew(riverrors.WithSimple())),
+	)
+	require.NoError(t, err)
+	a.GET("/users/:id", E(func(*Context) error {
+		return riverrors.WithStatus(errors.New("user not found"), http.StatusNotFound)
+	}))
+
+	resp, err := a.Test(httptest.NewRequest(http.MethodGet, "/users/1", nil))
+	require.NoError(t, err)
+	var body map[string]any
+	ExpectJSON(t, resp, http.StatusNotFound, &body)
+	assert.Equal(t, "user not found", body["error"])
+	assert.NotContains(t, body, "type", "must not use the default RFC 9457 formatter")
+}
+
+func TestWithErrorHandler_ValidationFails(t *testing.T) {
+	t.Parallel()
+
+	_, err := New(
+		WithServiceName("test"),
+		WithServiceVersion("1.0.0"),
+		WithErrorHandler(nil),
+	)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "error handler formatter cannot be nil")
+
+	_, err = New(
+		WithServiceName("test"),
+		WithServiceVersion("1.0.0"),
+		WithErrorFormatterFor("application/json", riverrors.WithSimple()),
+		WithErrorHandler(riverrors.MustNew()),
+	)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot use single error formatter")
+
+	_, err = New(
+		WithServiceName("test"),
+		WithServiceVersion("1.0.0"),
+		WithErrorHandler(riverrors.MustNew()),
+		WithErrorFormatters(map[string]riverrors.Formatter{"application/json": riverrors.MustNew(riverrors.WithSimple())}),
+	)
+	require.Error(t, err, "the handler must not be replaced silently")
+	assert.Contains(t, err.Error(), "cannot use content-negotiated formatters")
+}
@@ -0,0 +1,104 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// WithHTTPSRedirect starts a plain HTTP listener next to the HTTPS server that
+// redirects every request to HTTPS with 308 Permanent Redirect. With
+// [WithAutoTLS], it also answers ACME HTTP-01 challenges. It listens on port
+// 80 unless overridden by [WithRedirectPort].
+//
+// HTTPS redirects require [WithTLS], [WithMTLS], or [WithAutoTLS].
+//
+// Example:
+//
+//	app.New(
+//	    app.WithServiceName("my-api"),
+//	    app.WithTLS("server.crt", "server.key"),
+//	    app.WithPort(443),
+//	    app.WithHTTPSRedirect(),
+//	)
+func WithHTTPSRedirect() Option {
+	return func(c *config) {
+		c.server.httpsRedirect = true
+	}
+}
+
+// WithRedirectPort sets the port of the plain HTTP listener started by
+// [WithHTTPSRedirect]. Default is 80.
+//
+// Example:
+//
+//	app.New(
+//	    app.WithTLS("server.crt", "server.key"), // HTTPS on 8443
+//	    app.WithHTTPSRedirect(),
+//	    app.WithRedirectPort(8080),
+//	)
+func WithRedirectPort(port int) Option {
+	return func(c *config) {
+		c.server.redirectPort = port
+	}
+}
+
+// newRedirectSidecar returns the plain HTTP server of [WithHTTPSRedirect].
+// challenge, if not nil, wraps the redirect handler to answer ACME HTTP-01
+// challenges.
+func (a *App) newRedirectSidecar(challenge func(fallback http.Handler) http.Handler) sidecarServer {
+	handler := httpsRedirectHandler(a.config.server.port)
+	if challenge != nil {
+		handler = challenge(handler)
+	}
+
+	redirect := &http.Server{
+		Addr:              fmt.Sprintf("%s:%d", a.config.server.host, a.config.server.redirectPort),
+		Handler:           handler,
+		ReadTimeout:       a.config.server.readTimeout,
+		WriteTimeout:      a.config.server.writeTimeout,
+		IdleTimeout:       a.config.server.idleTimeout,
+		ReadHeaderTimeout: a.config.server.readHeaderTimeout,
+		MaxHeaderBytes:    a.config.server.maxHeaderBytes,
+	}
+
+	return sidecarServer{
+		serve:    redirect.ListenAndServe,
+		shutdown: redirect.Shutdown,
+	}
+}
+
+// httpsRedirectHandler redirects requests to the same host and URI over HTTPS
+// on port. The port is omitted from the location when it is 443.
+func httpsRedirectHandler(port int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host := r.Host
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		host = strings.TrimSuffix(strings.TrimPrefix(host, "["), "]")
+		if port != 443 {
+			host = net.JoinHostPort(host, strconv.Itoa(port))
+		} else if strings.Contains(host, ":") {
+			host = "[" + host + "]"
+		}
+
+		http.Redirect(w, r, "https://"+host+r.URL.RequestURI(), http.StatusPermanentRedirect)
+	})
+}
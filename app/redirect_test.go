@@ -0,0 +1,86 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package app
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPSRedirectHandler(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		port   int
+		target string
+		want   string
+	}{
+		{name: "default port", port: 443, target: "http://example.com/users?page=2", want: "https://example.com/users?page=2"},
+		{name: "strips HTTP port", port: 443, target: "http://example.com:80/", want: "https://example.com/"},
+		{name: "custom port", port: 8443, target: "http://example.com:8080/a", want: "https://example.com:8443/a"},
+		{name: "IPv6 host", port: 443, target: "http://[::1]:80/", want: "https://[::1]/"},
+		{name: "IPv6 host with custom port", port: 8443, target: "http://[::1]/", want: "https://[::1]:8443/"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			httpsRedirectHandler(tt.port).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, tt.target, nil))
+
+			assert.Equal(t, http.StatusPermanentRedirect, rec.Code)
+			assert.Equal(t, tt.want, rec.Header().Get("Location"))
+		})
+	}
+}
+
+func TestWithHTTPSRedirect_Validation(t *testing.T) {
+	t.Parallel()
+
+	_, err := New(
+		WithServiceName("test"),
+		WithServiceVersion("1.0.0"),
+		WithHTTPSRedirect(),
+	)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "WithHTTPSRedirect requires WithTLS, WithMTLS, or WithAutoTLS")
+
+	_, err = New(
+		WithServiceName("test"),
+		WithServiceVersion("1.0.0"),
+		WithTLS("server.crt", "server.key"),
+		WithHTTPSRedirect(),
+		WithRedirectPort(DefaultTLSPort),
+	)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "must differ from the server port")
+
+	a, err := New(
+		WithServiceName("test"),
+		WithServiceVersion("1.0.0"),
+		WithTLS("server.crt", "server.key"),
+		WithHTTPSRedirect(),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultRedirectPort, a.config.server.redirectPort)
+}
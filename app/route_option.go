@@ -15,6 +15,8 @@
 package app
 
 import (
+	"time"
+
 	"rivaas.dev/openapi"
 )
 
@@ -29,6 +31,14 @@ type routeConfig struct {
 	after   []HandlerFunc
 	docOpts []openapi.OperationOption
 	skipDoc bool // Set to true to explicitly skip documentation
+
+	drainTimeout time.Duration // Drain window during shutdown; zero uses the group's or the server's
+
+	successStatus int // Status of successful responses of Handle; zero is 200
+
+	// Handler name and location for the route table, set by handlers that wrap the user's function
+	handlerName    string
+	callerLocation string
 }
 
 // WithBefore adds pre-handler middleware to the route.
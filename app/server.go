@@ -110,18 +110,21 @@ func (a *App) shutdownObservability(ctx context.Context) {
 // Unlike stdlib's http.Server, which uses separate Shutdown() call, this method combines
 // serving and lifecycle management for a simpler API. Users should pass a context
 // configured with signal.NotifyContext for graceful shutdown on OS signals.
-func (a *App) runServer(ctx context.Context, server *http.Server, startFunc serverStartFunc, protocol string) error {
+//
+// The addresses are shown in the startup banner and logs. Sidecar servers, such as
+// the HTTP/3 server, start and shut down together with server.
+func (a *App) runServer(ctx context.Context, server *http.Server, startFunc serverStartFunc, protocol string, addrs []listenAddr, sidecars ...sidecarServer) error {
 	// Start a server in a goroutine
-	serverErr := make(chan error, 1)
+	serverErr := make(chan error, 1+len(sidecars))
 	serverReady := make(chan struct{})
 	go func() {
-		a.printStartupBanner(server.Addr, protocol)
+		a.printStartupBanner(addrs)
 
 		// Flush any buffered startup logs after the banner is printed.
 		// This ensures all initialization logs appear after the banner for cleaner DX.
 		a.flushStartupLogs()
 
-		a.logStartupInfo(ctx, server.Addr, protocol)
+		a.logStartupInfo(ctx, joinListenAddrs(addrs), protocol)
 		// Routes are now displayed as part of the startup banner
 
 		// Signal that the server is ready to accept connections
@@ -131,6 +134,13 @@ func (a *App) runServer(ctx context.Context, server *http.Server, startFunc serv
 			serverErr <- fmt.Errorf("%s server failed to start: %w", protocol, err)
 		}
 	}()
+	for _, sidecar := range sidecars {
+		go func() {
+			if err := sidecar.serve(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				serverErr <- fmt.Errorf("%s server failed to start: %w", protocol, err)
+			}
+		}()
+	}
 
 	// Wait for the server to be ready, then execute OnReady hooks
 	<-serverReady
@@ -171,14 +181,24 @@ shutdown:
 	// We use context.WithoutCancel() to preserve context values (tracing, logging) while ignoring
 	// the parent's cancellation. The parent ctx is already canceled (that's what triggered shutdown),
 	// but we want to keep its values for observability during shutdown operations.
-	shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), a.config.server.shutdownTimeout)
+	// Routes with a longer drain timeout extend the deadline while their requests are in flight.
+	shutdownCtx, cancel := a.drain.shutdownContext(context.WithoutCancel(ctx), a.config.server.shutdownTimeout)
 	defer cancel()
 
 	// Execute OnShutdown hooks (LIFO order)
 	a.executeShutdownHooks(shutdownCtx)
 
-	// Shutdown the server
-	if err := server.Shutdown(shutdownCtx); err != nil {
+	// Shutdown the server and its sidecars
+	err := server.Shutdown(shutdownCtx)
+	for _, sidecar := range sidecars {
+		if sidecarErr := sidecar.shutdown(shutdownCtx); err == nil {
+			err = sidecarErr
+		}
+	}
+	if err != nil {
+		if cause := context.Cause(shutdownCtx); cause != nil {
+			err = cause
+		}
 		return fmt.Errorf("%s server forced to shutdown: %w", protocol, err)
 	}
 
@@ -274,13 +294,18 @@ func (a *App) registerOpenAPIEndpoints() {
 
 // Start starts the server with graceful shutdown.
 // Start automatically freezes the router before starting, making routes immutable.
-// The server runs HTTP, HTTPS, or mTLS depending on configuration: use [WithTLS] or
-// [WithMTLS] at construction to serve over TLS; otherwise plain HTTP is used.
+// The server runs HTTP, HTTPS, or mTLS depending on configuration: use [WithTLS],
+// [WithMTLS], or [WithAutoTLS] at construction to serve over TLS; otherwise plain HTTP is used.
 //
 // The server listens on the address configured via [WithPort] and [WithHost].
 // Default is :8080 for HTTP and :8443 when using [WithTLS] or [WithMTLS], overridable by
 // [WithPort] and by RIVAAS_PORT and RIVAAS_HOST when [WithEnv] is used.
 //
+// Pass listeners to serve on them instead, such as several addresses, Unix sockets
+// ([Listen]), or sockets from systemd socket activation ([FromListener]). Each one uses
+// the app's TLS configuration unless it sets its own with [WithListenerTLS] or
+// [WithoutListenerTLS]. [WithHTTP3] and [WithHTTPSRedirect] need the configured address.
+//
 // The context controls the application lifecycle - when canceled, it triggers
 // graceful shutdown of the server and all observability components (metrics, tracing).
 //
@@ -307,8 +332,20 @@ func (a *App) registerOpenAPIEndpoints() {
 //	    app.WithTLS("server.crt", "server.key"),
 //	)
 //	if err := app.Start(ctx); err != nil { ... }
-func (a *App) Start(ctx context.Context) error {
+//
+// Listeners example:
+//
+//	err := app.Start(ctx,
+//	    app.Listen(":8443"),
+//	    app.Listen("unix:///run/my-service/api.sock", app.WithoutListenerTLS()),
+//	)
+func (a *App) Start(ctx context.Context, listeners ...Listener) error {
 	addr := a.config.server.ListenAddr()
+	if len(listeners) > 0 {
+		if err := a.validateListeners(listeners); err != nil {
+			return err
+		}
+	}
 
 	// Start observability servers (metrics, etc.)
 	if err := a.startObservability(ctx); err != nil {
@@ -337,16 +374,20 @@ func (a *App) Start(ctx context.Context) error {
 		MaxHeaderBytes:    a.config.server.maxHeaderBytes,
 	}
 
-	// Branch on transport: TLS (HTTPS), mTLS, or plain HTTP
+	// Branch on transport: listeners, automatic TLS, TLS (HTTPS), mTLS, or plain HTTP
+	if len(listeners) > 0 {
+		return a.startListeners(ctx, server, listeners)
+	}
+	if a.config.server.autoTLS.enabled() {
+		return a.startAutoTLS(ctx, server)
+	}
 	if a.config.server.tlsCertFile != "" {
-		return a.runServer(ctx, server, func() error {
-			return server.ListenAndServeTLS(a.config.server.tlsCertFile, a.config.server.tlsKeyFile)
-		}, "HTTPS")
+		return a.startTLS(ctx, server)
 	}
 	if len(a.config.server.mtlsServerCert.Certificate) > 0 {
 		return a.startMTLS(ctx, server, addr)
 	}
-	return a.runServer(ctx, server, server.ListenAndServe, "HTTP")
+	return a.runServer(ctx, server, server.ListenAndServe, "HTTP", []listenAddr{{addr: addr}})
 }
 
 // startMTLS runs the server with mTLS using config from a.config.server.
@@ -361,6 +402,23 @@ func (a *App) startMTLS(ctx context.Context, server *http.Server, addr string) e
 	tlsListener := tls.NewListener(listener, tlsConfig)
 
 	server.TLSConfig = tlsConfig
+
+	handler := server.Handler
+	sidecars, err := a.tlsSidecars(ctx, server, tlsConfig, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// QUIC connections bypass ConnState, so authorize each request
+		if !authorizeMTLSRequest(r, cfg) {
+			http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	}), nil)
+	if err != nil {
+		if closeErr := listener.Close(); closeErr != nil {
+			a.logLifecycleEvent(ctx, slog.LevelError, "failed to close listener", "error", closeErr)
+		}
+		return err
+	}
+
 	originalConnState := server.ConnState
 	server.ConnState = func(conn net.Conn, state http.ConnState) {
 		if state == http.StateActive && !authorizeMTLSConnection(conn, cfg) {
@@ -376,7 +434,90 @@ func (a *App) startMTLS(ctx context.Context, server *http.Server, addr string) e
 
 	return a.runServer(ctx, server, func() error {
 		return server.Serve(tlsListener)
-	}, "mTLS")
+	}, a.tlsProtocol("mTLS"), []listenAddr{{addr: addr, secure: true}}, sidecars...)
+}
+
+// startTLS runs the server with TLS using the certificate files from a.config.server.
+func (a *App) startTLS(ctx context.Context, server *http.Server) error {
+	tlsConfig, err := a.certFileTLSConfig()
+	if err != nil {
+		return err
+	}
+
+	return a.serveTLS(ctx, server, tlsConfig, nil, "HTTPS")
+}
+
+// certFileTLSConfig returns the TLS configuration for the certificate files of [WithTLS].
+func (a *App) certFileTLSConfig() (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(a.config.server.tlsCertFile, a.config.server.tlsKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load TLS certificate: %w", err)
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+// startAutoTLS runs the server with TLS using certificates from ACME.
+func (a *App) startAutoTLS(ctx context.Context, server *http.Server) error {
+	m := a.config.server.autoTLS.manager()
+
+	return a.serveTLS(ctx, server, newAutoTLSConfig(m), m.HTTPHandler, "AutoTLS")
+}
+
+// serveTLS runs the server with tlsConfig on its own TCP listener, together with its sidecars.
+func (a *App) serveTLS(ctx context.Context, server *http.Server, tlsConfig *tls.Config, challenge func(http.Handler) http.Handler, protocol string) error {
+	sidecars, err := a.tlsSidecars(ctx, server, tlsConfig, server.Handler, challenge)
+	if err != nil {
+		return err
+	}
+	server.TLSConfig = tlsConfig
+
+	return a.runServer(ctx, server, func() error {
+		return server.ListenAndServeTLS("", "")
+	}, a.tlsProtocol(protocol), []listenAddr{{addr: server.Addr, secure: true}}, sidecars...)
+}
+
+// tlsSidecars returns the servers that run next to a TLS server: the HTTP/3
+// server of [WithHTTP3], which serves h3Handler, and the redirect server of
+// [WithHTTPSRedirect], which answers ACME HTTP-01 challenges with challenge.
+func (a *App) tlsSidecars(ctx context.Context, server *http.Server, tlsConfig *tls.Config, h3Handler http.Handler, challenge func(http.Handler) http.Handler) ([]sidecarServer, error) {
+	var sidecars []sidecarServer
+	if a.config.server.http3 {
+		h3, err := a.newHTTP3Sidecar(ctx, server, tlsConfig, h3Handler)
+		if err != nil {
+			return nil, err
+		}
+		sidecars = append(sidecars, h3)
+	}
+	if a.config.server.httpsRedirect {
+		sidecars = append(sidecars, a.newRedirectSidecar(challenge))
+	}
+
+	return sidecars, nil
+}
+
+// tlsProtocol returns the protocol name for the startup banner and logs.
+func (a *App) tlsProtocol(protocol string) string {
+	if a.config.server.http3 {
+		return protocol + "+HTTP/3"
+	}
+
+	return protocol
+}
+
+// authorizeMTLSRequest checks if the client certificate of an HTTP/3 request
+// is authorized, like [authorizeMTLSConnection] does for TCP connections.
+func authorizeMTLSRequest(r *http.Request, cfg *mtlsConfig) bool {
+	if cfg.authorize == nil || r.TLS == nil || len(r.TLS.PeerCertificates) == 0 {
+		return true
+	}
+
+	_, allowed := cfg.authorize(r.TLS.PeerCertificates[0])
+
+	return allowed
 }
 
 // authorizeMTLSConnection checks if the TLS connection is authorized.
@@ -0,0 +1,49 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errors
+
+// Catalog maps error codes to the stable URLs of their documentation. RFC 9457
+// formatters with a catalog use these URLs as the problem "type", so clients
+// can look up what a code means.
+//
+// Example:
+//
+//	catalog := errors.Catalog{
+//		"not-found":        "https://docs.example.com/errors/not-found",
+//		"validation_error": "https://docs.example.com/errors/validation",
+//	}
+//	formatter := errors.MustNew(errors.WithErrorCatalog(catalog))
+type Catalog map[string]string
+
+// NewCatalog creates a [Catalog] with a URL for each code under baseURL,
+// such as "https://docs.example.com/errors/not-found" for "not-found".
+//
+// Example:
+//
+//	catalog := errors.NewCatalog("https://docs.example.com/errors", "not-found", "conflict")
+func NewCatalog(baseURL string, codes ...string) Catalog {
+	c := make(Catalog, len(codes))
+	for _, code := range codes {
+		c[code] = baseURL + "/" + code
+	}
+
+	return c
+}
+
+// URL returns the documentation URL of code, if it is in the catalog.
+func (c Catalog) URL(code string) (string, bool) {
+	url, ok := c[code]
+	return url, ok && url != ""
+}
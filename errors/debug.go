@@ -0,0 +1,123 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+)
+
+// maxDebugChain limits the causes listed in debug output, in case of cycles.
+const maxDebugChain = 32
+
+// StackTracer allows errors to provide the stack where they were created.
+// Errors wrapped with [WithStack] implement it.
+type StackTracer interface {
+	error
+	// StackTrace returns the call stack, innermost frame first.
+	StackTrace() []runtime.Frame
+}
+
+// WithStack wraps err with the stack of its caller, included in responses by
+// formatters in debug mode (see [WithDebug]). It returns nil if err is nil.
+//
+// Example:
+//
+//	if err := repo.Save(ctx, order); err != nil {
+//		return errors.WithStack(err)
+//	}
+func WithStack(err error) error {
+	if err == nil {
+		return nil
+	}
+	pcs := make([]uintptr, 32) //nolint:makezero // runtime.Callers requires pre-allocated buffer
+	n := runtime.Callers(2, pcs)
+
+	return &stackError{err: err, pcs: pcs[:n]}
+}
+
+// stackError wraps an error with the program counters of its stack.
+type stackError struct {
+	err error
+	pcs []uintptr
+}
+
+func (e *stackError) Error() string {
+	return e.err.Error()
+}
+
+func (e *stackError) Unwrap() error {
+	return e.err
+}
+
+func (e *stackError) StackTrace() []runtime.Frame {
+	frames := runtime.CallersFrames(e.pcs)
+	stack := make([]runtime.Frame, 0, len(e.pcs))
+	for {
+		frame, more := frames.Next()
+		stack = append(stack, frame)
+		if !more {
+			break
+		}
+	}
+
+	return stack
+}
+
+// debugInfo is the debug member of error responses in debug mode.
+type debugInfo struct {
+	Chain []debugCause `json:"chain"`
+	Stack []string     `json:"stack,omitempty"`
+}
+
+// debugCause is an error of the chain in debug output.
+type debugCause struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+}
+
+// newDebugInfo describes err and its causes, and the stack of the first
+// StackTracer in its chain.
+func newDebugInfo(err error) debugInfo {
+	var info debugInfo
+
+	// Walk the chain depth first, including the errors of errors.Join
+	queue := []error{err}
+	for len(queue) > 0 && len(info.Chain) < maxDebugChain {
+		e := queue[0]
+		queue = queue[1:]
+		if e == nil {
+			continue
+		}
+		info.Chain = append(info.Chain, debugCause{Type: fmt.Sprintf("%T", e), Message: e.Error()})
+
+		switch u := e.(type) {
+		case interface{ Unwrap() error }:
+			queue = append([]error{u.Unwrap()}, queue...)
+		case interface{ Unwrap() []error }:
+			queue = append(u.Unwrap(), queue...)
+		}
+	}
+
+	var traced StackTracer
+	if errors.As(err, &traced) {
+		for _, frame := range traced.StackTrace() {
+			info.Stack = append(info.Stack, fmt.Sprintf("%s (%s:%d)", frame.Function, frame.File, frame.Line))
+		}
+	}
+
+	return info
+}
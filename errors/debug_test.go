@@ -0,0 +1,160 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package errors
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithStack(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, WithStack(nil))
+
+	base := errors.New("disk full")
+	err := WithStack(base)
+	assert.Equal(t, "disk full", err.Error())
+	require.ErrorIs(t, err, base)
+
+	var traced StackTracer
+	require.ErrorAs(t, err, &traced)
+	stack := traced.StackTrace()
+	require.NotEmpty(t, stack)
+	assert.Equal(t, "rivaas.dev/errors.TestWithStack", stack[0].Function)
+}
+
+func TestNewDebugInfo(t *testing.T) {
+	t.Parallel()
+
+	base := errors.New("connection refused")
+	err := fmt.Errorf("save order: %w", errors.Join(base, errors.New("rollback failed")))
+
+	info := newDebugInfo(err)
+	require.Len(t, info.Chain, 4)
+	assert.Equal(t, debugCause{Type: "*fmt.wrapError", Message: err.Error()}, info.Chain[0])
+	assert.Equal(t, "*errors.joinError", info.Chain[1].Type)
+	assert.Equal(t, "connection refused", info.Chain[2].Message)
+	assert.Equal(t, "rollback failed", info.Chain[3].Message)
+	assert.Empty(t, info.Stack)
+
+	info = newDebugInfo(fmt.Errorf("wrap: %w", WithStack(base)))
+	require.NotEmpty(t, info.Stack)
+	assert.True(t, strings.HasPrefix(info.Stack[0], "rivaas.dev/errors.TestNewDebugInfo ("), info.Stack[0])
+}
+
+func TestWithDebug(t *testing.T) {
+	t.Parallel()
+
+	err := fmt.Errorf("load user: %w", WithStack(errors.New("connection refused")))
+	req := httptest.NewRequest(http.MethodGet, "/users/42", nil)
+
+	tests := []struct {
+		name  string
+		opts  []Option
+		debug func(t *testing.T, body map[string]any) any
+	}{
+		{
+			name:  "RFC9457",
+			opts:  []Option{WithRFC9457("")},
+			debug: func(_ *testing.T, body map[string]any) any { return body["debug"] },
+		},
+		{
+			name:  "Simple",
+			opts:  []Option{WithSimple()},
+			debug: func(_ *testing.T, body map[string]any) any { return body["debug"] },
+		},
+		{
+			name: "JSONAPI",
+			opts: []Option{WithJSONAPI()},
+			debug: func(t *testing.T, body map[string]any) any {
+				t.Helper()
+				meta, _ := body["meta"].(map[string]any)
+				return meta["debug"]
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			body := formatToMap(t, MustNew(tt.opts...).Format(req, err))
+			assert.Nil(t, tt.debug(t, body), "debug output is off by default")
+
+			body = formatToMap(t, MustNew(append(tt.opts, WithDebug(true))...).Format(req, err))
+			debug, ok := tt.debug(t, body).(map[string]any)
+			require.True(t, ok, "debug output is present")
+			assert.Len(t, debug["chain"], 3)
+			assert.NotEmpty(t, debug["stack"])
+		})
+	}
+}
+
+func TestWithErrorCatalog(t *testing.T) {
+	t.Parallel()
+
+	catalog := NewCatalog("https://docs.example.com/errors", "not-found")
+	catalog["conflict"] = "https://docs.example.com/errors/conflicts#versions"
+	formatter := MustNew(WithRFC9457("https://api.example.com/problems"), WithErrorCatalog(catalog))
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	tests := []struct {
+		code string
+		want string
+	}{
+		{code: "not-found", want: "https://docs.example.com/errors/not-found"},
+		{code: "conflict", want: "https://docs.example.com/errors/conflicts#versions"},
+		{code: "undocumented", want: "https://api.example.com/problems/undocumented"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			t.Parallel()
+
+			response := formatter.Format(req, &testErrorWithCode{message: "boom", code: tt.code})
+			body, ok := response.Body.(ProblemDetail)
+			require.True(t, ok)
+			assert.Equal(t, tt.want, body.Type)
+		})
+	}
+
+	url, ok := catalog.URL("missing")
+	assert.False(t, ok)
+	assert.Empty(t, url)
+}
+
+// formatToMap returns the JSON form of a response body.
+func formatToMap(t *testing.T, response Response) map[string]any {
+	t.Helper()
+
+	data, err := json.Marshal(response.Body)
+	require.NoError(t, err)
+
+	var body map[string]any
+	require.NoError(t, json.Unmarshal(data, &body))
+
+	return body
+}
@@ -15,15 +15,18 @@
 // Package errors provides framework-agnostic error formatting for HTTP responses.
 //
 // Create a formatter with [New] or [MustNew] and functional options. The default (no options)
-// is RFC 9457 with empty base URL. Use [WithRFC9457], [WithJSONAPI], or [WithSimple] to choose
-// the format. The package defines a [Formatter] interface and concrete implementations:
+// is RFC 9457 with empty base URL. Use [WithRFC9457], [WithJSONAPI], [WithSimple], [WithXML], or
+// [WithContentNegotiation] to choose the format. The package defines a [Formatter] interface and concrete implementations:
 //   - RFC9457: RFC 9457 Problem Details (application/problem+json)
 //   - JSONAPI: JSON:API error responses (application/vnd.api+json)
 //   - Simple: Simple JSON error responses (application/json)
+//   - XML: RFC 9457 Problem Details in XML (application/problem+xml)
+//   - Negotiator: Any of the above, selected by the Accept header
 //
 // The package is independent of any HTTP framework and can be used with any
 // HTTP handler. Domain errors can implement optional interfaces (ErrorType,
-// ErrorDetails, ErrorCode) to control status codes and provide structured details.
+// ErrorDetails, ErrorCode, ErrorExtensions) to control status codes and
+// provide structured details.
 //
 // # Quick Start
 //
@@ -63,6 +66,13 @@
 //	formatter := errors.MustNew(errors.WithSimple())
 //	response := formatter.Format(r, err)
 //
+// Content negotiation and localized titles and details:
+//
+//	formatter := errors.MustNew(
+//		errors.WithContentNegotiation("https://api.example.com/problems"),
+//		errors.WithTranslator(translator),
+//	)
+//
 // # Error Interfaces
 //
 // Domain errors can implement optional interfaces to provide additional information:
@@ -70,6 +80,7 @@
 //   - ErrorType: Declare HTTP status code
 //   - ErrorDetails: Provide structured details (e.g., field-level validation errors)
 //   - ErrorCode: Provide machine-readable error codes
+//   - ErrorExtensions: Add members to RFC 9457 problem details or JSON:API meta
 //
 // Example error with all interfaces:
 //
@@ -95,6 +106,28 @@
 //		return e.Code
 //	}
 //
+// # Error Registry
+//
+// Errors that can't implement these interfaces, such as sql.ErrNoRows or
+// errors from other libraries, can be mapped to a status and code with
+// Register and RegisterType. All formatters consult the registry after the
+// error's own interfaces; WithRegistry sets a registry other than the
+// package-level one:
+//
+//	errors.Register(sql.ErrNoRows, http.StatusNotFound, "not-found")
+//	errors.RegisterType[*json.SyntaxError](http.StatusBadRequest, "malformed-json")
+//
+// # Debug Mode and Error Catalog
+//
+// WithDebug adds the error chain, and the stack of errors wrapped with
+// WithStack, to responses; it is off by default and meant for development.
+// WithErrorCatalog uses the documentation URLs of a Catalog as problem types:
+//
+//	formatter := errors.MustNew(
+//		errors.WithDebug(os.Getenv("APP_DEBUG") == "true"),
+//		errors.WithErrorCatalog(errors.NewCatalog("https://docs.example.com/errors", "not-found")),
+//	)
+//
 // # Examples
 //
 // See the example_test.go file for complete working examples.
@@ -145,9 +145,34 @@ type ErrorCode interface {
 	Code() string
 }
 
+// ErrorExtensions allows errors to add members to RFC 9457 problem details.
+// Extensions replace the members derived from ErrorDetails and ErrorCode,
+// which lets an error control their shape, e.g. to report invalid fields
+// with JSON Pointers. rivaas.dev/validation.Error implements it. The JSON:API
+// formatter adds them to the meta of errors without ErrorDetails.
+//
+// Example:
+//
+//	type QuotaError struct {
+//		Remaining int
+//	}
+//
+//	func (e QuotaError) Error() string {
+//		return "quota exceeded"
+//	}
+//
+//	func (e QuotaError) Extensions() map[string]any {
+//		return map[string]any{"remaining": e.Remaining}
+//	}
+type ErrorExtensions interface {
+	error
+	// Extensions returns the members to add to the problem detail.
+	Extensions() map[string]any
+}
+
 // New creates a new Formatter with the given options.
 // Default (no options) is RFC9457 with empty base URL.
-// Exactly one of WithRFC9457, WithJSONAPI, or WithSimple must be implied (default or explicit); passing multiple formatter types returns an error.
+// Exactly one of WithRFC9457, WithJSONAPI, WithSimple, WithXML, or WithContentNegotiation must be implied (default or explicit); passing multiple formatter types returns an error.
 //
 // Example:
 //
@@ -187,23 +212,52 @@ func MustNew(opts ...Option) Formatter {
 func formatterFromConfig(cfg *config) Formatter {
 	switch cfg.kind {
 	case kindJSONAPI:
-		return &JSONAPI{
-			StatusResolver: cfg.statusResolver,
-		}
+		return jsonAPIFromConfig(cfg)
 	case kindSimple:
-		return &Simple{
-			StatusResolver: cfg.statusResolver,
-		}
+		return simpleFromConfig(cfg)
+	case kindXML:
+		return &XML{RFC9457: *rfc9457FromConfig(cfg)}
+	case kindNegotiated:
+		return negotiatorFromConfig(cfg)
 	case kindRFC9457, 0:
 		fallthrough
 	default:
-		return &RFC9457{
-			BaseURL:          cfg.rfc9457BaseURL,
-			TypeResolver:     cfg.typeResolver,
-			StatusResolver:   cfg.statusResolver,
-			ErrorIDGenerator: cfg.errorIDGenerator,
-			DisableErrorID:   cfg.disableErrorID,
-		}
+		return rfc9457FromConfig(cfg)
+	}
+}
+
+// jsonAPIFromConfig builds a JSONAPI formatter from validated config.
+func jsonAPIFromConfig(cfg *config) *JSONAPI {
+	return &JSONAPI{
+		StatusResolver: cfg.statusResolver,
+		Registry:       cfg.registry,
+		Translator:     cfg.translator,
+		Debug:          cfg.debug,
+	}
+}
+
+// simpleFromConfig builds a Simple formatter from validated config.
+func simpleFromConfig(cfg *config) *Simple {
+	return &Simple{
+		StatusResolver: cfg.statusResolver,
+		Registry:       cfg.registry,
+		Translator:     cfg.translator,
+		Debug:          cfg.debug,
+	}
+}
+
+// rfc9457FromConfig builds an RFC9457 formatter from validated config.
+func rfc9457FromConfig(cfg *config) *RFC9457 {
+	return &RFC9457{
+		BaseURL:          cfg.rfc9457BaseURL,
+		TypeResolver:     cfg.typeResolver,
+		StatusResolver:   cfg.statusResolver,
+		ErrorIDGenerator: cfg.errorIDGenerator,
+		DisableErrorID:   cfg.disableErrorID,
+		Registry:         cfg.registry,
+		Translator:       cfg.translator,
+		Debug:            cfg.debug,
+		Catalog:          cfg.catalog,
 	}
 }
 
@@ -0,0 +1,40 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package grpcstatus converts between gRPC status codes and the HTTP problem
+// responses of rivaas.dev/errors.
+//
+// Gateway services that call gRPC backends use [FromError] to turn upstream
+// failures into errors the formatters understand, so clients get the same
+// RFC 9457 bodies as for local errors. [FromProblem] goes the other way, for
+// gRPC services that call HTTP APIs.
+//
+// # Basic Usage
+//
+//	resp, err := client.GetUser(ctx, req)
+//	if err != nil {
+//	    response := formatter.Format(r, grpcstatus.FromError(err))
+//	    // NotFound becomes 404 with code "not_found"
+//	}
+//
+// BadRequest field violations in the status details are reported as
+// invalid fields with JSON Pointers, and the reason, domain, and metadata
+// of an ErrorInfo detail are added to the problem.
+//
+// # Code Mapping
+//
+// [HTTPStatus] and [Code] map codes following the HTTP mapping of
+// google.rpc.Code, e.g. NotFound to 404, Unavailable to 503, and
+// DeadlineExceeded to 504.
+package grpcstatus
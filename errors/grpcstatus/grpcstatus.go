@@ -0,0 +1,299 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpcstatus
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"net/http"
+	"strings"
+	"unicode"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+
+	"rivaas.dev/errors"
+)
+
+// httpStatuses maps gRPC codes to HTTP status codes.
+var httpStatuses = map[codes.Code]int{
+	codes.OK:                 http.StatusOK,
+	codes.Canceled:           499, // Client Closed Request
+	codes.Unknown:            http.StatusInternalServerError,
+	codes.InvalidArgument:    http.StatusBadRequest,
+	codes.DeadlineExceeded:   http.StatusGatewayTimeout,
+	codes.NotFound:           http.StatusNotFound,
+	codes.AlreadyExists:      http.StatusConflict,
+	codes.PermissionDenied:   http.StatusForbidden,
+	codes.ResourceExhausted:  http.StatusTooManyRequests,
+	codes.FailedPrecondition: http.StatusBadRequest,
+	codes.Aborted:            http.StatusConflict,
+	codes.OutOfRange:         http.StatusBadRequest,
+	codes.Unimplemented:      http.StatusNotImplemented,
+	codes.Internal:           http.StatusInternalServerError,
+	codes.Unavailable:        http.StatusServiceUnavailable,
+	codes.DataLoss:           http.StatusInternalServerError,
+	codes.Unauthenticated:    http.StatusUnauthorized,
+}
+
+// grpcCodes maps HTTP status codes to gRPC codes. Where several codes share
+// an HTTP status, it picks the most general one.
+var grpcCodes = map[int]codes.Code{
+	http.StatusBadRequest:                   codes.InvalidArgument,
+	http.StatusUnauthorized:                 codes.Unauthenticated,
+	http.StatusForbidden:                    codes.PermissionDenied,
+	http.StatusNotFound:                     codes.NotFound,
+	http.StatusMethodNotAllowed:             codes.Unimplemented,
+	http.StatusConflict:                     codes.Aborted,
+	http.StatusPreconditionFailed:           codes.FailedPrecondition,
+	http.StatusRequestedRangeNotSatisfiable: codes.OutOfRange,
+	http.StatusUnprocessableEntity:          codes.InvalidArgument,
+	http.StatusTooManyRequests:              codes.ResourceExhausted,
+	499:                                     codes.Canceled,
+	http.StatusNotImplemented:               codes.Unimplemented,
+	http.StatusServiceUnavailable:           codes.Unavailable,
+	http.StatusGatewayTimeout:               codes.DeadlineExceeded,
+}
+
+// HTTPStatus returns the HTTP status code for a gRPC code. Unknown codes map
+// to 500.
+func HTTPStatus(c codes.Code) int {
+	if s, ok := httpStatuses[c]; ok {
+		return s
+	}
+
+	return http.StatusInternalServerError
+}
+
+// Code returns the gRPC code for an HTTP status code. Other 2xx statuses map
+// to OK, other 4xx statuses to FailedPrecondition, and other statuses to
+// Unknown (or Internal for 5xx).
+func Code(httpStatus int) codes.Code {
+	if c, ok := grpcCodes[httpStatus]; ok {
+		return c
+	}
+
+	switch {
+	case httpStatus >= 200 && httpStatus < 300:
+		return codes.OK
+	case httpStatus >= 400 && httpStatus < 500:
+		return codes.FailedPrecondition
+	case httpStatus >= 500 && httpStatus < 600:
+		return codes.Internal
+	default:
+		return codes.Unknown
+	}
+}
+
+// Error is a gRPC status error that implements the optional interfaces of
+// rivaas.dev/errors (ErrorType, ErrorCode, and ErrorExtensions), so the
+// formatters turn it into an HTTP error response.
+type Error struct {
+	status *status.Status
+}
+
+// FromError converts a gRPC status error, such as one returned by a client
+// stub, into an [*Error]. Wrapped status errors are found with errors.As.
+// It returns err unchanged if it has no gRPC status, and nil if err is nil.
+//
+// Example:
+//
+//	if err != nil {
+//	    c.Error(grpcstatus.FromError(err))
+//	}
+func FromError(err error) error {
+	if err == nil {
+		return nil
+	}
+	// Use the upstream status itself, so wrapping doesn't change its message
+	var grpcErr interface{ GRPCStatus() *status.Status }
+	if !stderrors.As(err, &grpcErr) || grpcErr.GRPCStatus() == nil {
+		return err
+	}
+
+	return &Error{status: grpcErr.GRPCStatus()}
+}
+
+// Error returns the status message.
+func (e *Error) Error() string {
+	return e.status.Message()
+}
+
+// GRPCStatus returns the gRPC status, so status.FromError and status.Code
+// still work on the error.
+func (e *Error) GRPCStatus() *status.Status {
+	return e.status
+}
+
+// HTTPStatus implements rivaas.dev/errors.ErrorType.
+func (e *Error) HTTPStatus() int {
+	return HTTPStatus(e.status.Code())
+}
+
+// Code implements rivaas.dev/errors.ErrorCode. It returns the gRPC code in
+// snake case, such as "not_found" or "invalid_argument".
+func (e *Error) Code() string {
+	return snakeCase(e.status.Code().String())
+}
+
+// Extensions implements rivaas.dev/errors.ErrorExtensions. It reports the
+// field violations of a BadRequest detail as "errors" with JSON Pointers,
+// and the reason, domain, and metadata of an ErrorInfo detail.
+func (e *Error) Extensions() map[string]any {
+	ext := map[string]any{"grpc_code": e.status.Code().String()}
+	for _, detail := range e.status.Details() {
+		switch d := detail.(type) {
+		case *errdetails.BadRequest:
+			fields := make([]map[string]string, 0, len(d.GetFieldViolations()))
+			for _, v := range d.GetFieldViolations() {
+				fields = append(fields, map[string]string{
+					"pointer": fieldPointer(v.GetField()),
+					"detail":  v.GetDescription(),
+				})
+			}
+			ext["errors"] = fields
+		case *errdetails.ErrorInfo:
+			ext["reason"] = d.GetReason()
+			if d.GetDomain() != "" {
+				ext["domain"] = d.GetDomain()
+			}
+			if len(d.GetMetadata()) > 0 {
+				ext["metadata"] = d.GetMetadata()
+			}
+		}
+	}
+
+	return ext
+}
+
+// FromProblem converts an RFC 9457 problem detail, such as one returned by an
+// upstream HTTP API, into a gRPC status. The problem's "code" becomes the
+// reason of an ErrorInfo detail, and invalid fields listed in "errors" with
+// "pointer" and "detail" members become a BadRequest detail.
+//
+// Example:
+//
+//	var p errors.ProblemDetail
+//	if err := json.Unmarshal(body, &p); err == nil {
+//	    return nil, grpcstatus.FromProblem(p).Err()
+//	}
+func FromProblem(p errors.ProblemDetail) *status.Status {
+	msg := p.Detail
+	if msg == "" {
+		msg = p.Title
+	}
+	st := status.New(Code(p.Status), msg)
+
+	var details []protoadapt.MessageV1
+	if code, ok := p.Extensions["code"].(string); ok && code != "" {
+		details = append(details, &errdetails.ErrorInfo{Reason: code})
+	}
+	if violations := problemViolations(p.Extensions["errors"]); len(violations) > 0 {
+		details = append(details, &errdetails.BadRequest{FieldViolations: violations})
+	}
+	if len(details) == 0 {
+		return st
+	}
+
+	withDetails, err := st.WithDetails(details...)
+	if err != nil {
+		return st
+	}
+
+	return withDetails
+}
+
+// problemViolations converts the "errors" member of a problem detail into
+// field violations. Entries of any type are read through their JSON form.
+func problemViolations(v any) []*errdetails.BadRequest_FieldViolation {
+	if v == nil {
+		return nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	var entries []struct {
+		Pointer string `json:"pointer"`
+		Detail  string `json:"detail"`
+	}
+	if err = json.Unmarshal(data, &entries); err != nil {
+		return nil
+	}
+
+	violations := make([]*errdetails.BadRequest_FieldViolation, 0, len(entries))
+	for _, e := range entries {
+		if e.Pointer == "" && e.Detail == "" {
+			continue
+		}
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       pointerField(e.Pointer),
+			Description: e.Detail,
+		})
+	}
+
+	return violations
+}
+
+// fieldPointer converts a field path of a BadRequest violation, such as
+// "items[2].price" or "items.2.price", into a JSON Pointer.
+func fieldPointer(field string) string {
+	if field == "" {
+		return ""
+	}
+	field = strings.NewReplacer("[", ".", "]", "").Replace(field)
+	escaper := strings.NewReplacer("~", "~0", "/", "~1")
+
+	var b strings.Builder
+	for part := range strings.SplitSeq(field, ".") {
+		b.WriteByte('/')
+		b.WriteString(escaper.Replace(part))
+	}
+
+	return b.String()
+}
+
+// pointerField converts a JSON Pointer into a dotted field path.
+func pointerField(pointer string) string {
+	pointer = strings.TrimPrefix(strings.TrimPrefix(pointer, "#"), "/")
+	if pointer == "" {
+		return ""
+	}
+	unescaper := strings.NewReplacer("~1", "/", "~0", "~")
+	parts := strings.Split(pointer, "/")
+	for i, part := range parts {
+		parts[i] = unescaper.Replace(part)
+	}
+
+	return strings.Join(parts, ".")
+}
+
+// snakeCase converts a code name such as "NotFound" to "not_found".
+func snakeCase(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) {
+			if i > 0 && !unicode.IsUpper(rune(name[i-1])) {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
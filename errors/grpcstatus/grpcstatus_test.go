@@ -0,0 +1,177 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package grpcstatus
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"rivaas.dev/errors"
+)
+
+func TestHTTPStatus(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		code codes.Code
+		want int
+	}{
+		{codes.OK, http.StatusOK},
+		{codes.Canceled, 499},
+		{codes.InvalidArgument, http.StatusBadRequest},
+		{codes.NotFound, http.StatusNotFound},
+		{codes.AlreadyExists, http.StatusConflict},
+		{codes.ResourceExhausted, http.StatusTooManyRequests},
+		{codes.Unauthenticated, http.StatusUnauthorized},
+		{codes.Unavailable, http.StatusServiceUnavailable},
+		{codes.DeadlineExceeded, http.StatusGatewayTimeout},
+		{codes.Code(99), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code.String(), func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, HTTPStatus(tt.code))
+		})
+	}
+}
+
+func TestCode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		status int
+		want   codes.Code
+	}{
+		{http.StatusOK, codes.OK},
+		{http.StatusCreated, codes.OK},
+		{http.StatusBadRequest, codes.InvalidArgument},
+		{http.StatusUnprocessableEntity, codes.InvalidArgument},
+		{http.StatusNotFound, codes.NotFound},
+		{http.StatusConflict, codes.Aborted},
+		{http.StatusTeapot, codes.FailedPrecondition},
+		{http.StatusTooManyRequests, codes.ResourceExhausted},
+		{http.StatusBadGateway, codes.Internal},
+		{http.StatusServiceUnavailable, codes.Unavailable},
+		{http.StatusMovedPermanently, codes.Unknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.status), func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, Code(tt.status))
+		})
+	}
+}
+
+func TestFromError(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, FromError(nil))
+
+	plain := stderrors.New("plain")
+	assert.Same(t, plain, FromError(plain))
+
+	err := FromError(fmt.Errorf("get user: %w", status.Error(codes.NotFound, "user 42 not found")))
+	var grpcErr *Error
+	require.ErrorAs(t, err, &grpcErr)
+	assert.Equal(t, "user 42 not found", grpcErr.Error())
+	assert.Equal(t, http.StatusNotFound, grpcErr.HTTPStatus())
+	assert.Equal(t, "not_found", grpcErr.Code())
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestFromError_Problem(t *testing.T) {
+	t.Parallel()
+
+	st, err := status.New(codes.InvalidArgument, "invalid user").WithDetails(
+		&errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{Field: "user.emails[1]", Description: "must be an email"},
+		}},
+		&errdetails.ErrorInfo{Reason: "INVALID_USER", Domain: "users.example.com", Metadata: map[string]string{"id": "42"}},
+	)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/users", nil)
+	response := errors.MustNew(errors.WithRFC9457("")).Format(req, FromError(st.Err()))
+	assert.Equal(t, http.StatusBadRequest, response.Status)
+
+	data, err := json.Marshal(response.Body)
+	require.NoError(t, err)
+
+	var body map[string]any
+	require.NoError(t, json.Unmarshal(data, &body))
+	assert.Equal(t, "invalid_argument", body["type"])
+	assert.Equal(t, "invalid user", body["detail"])
+	assert.Equal(t, "invalid_argument", body["code"])
+	assert.Equal(t, "InvalidArgument", body["grpc_code"])
+	assert.Equal(t, "INVALID_USER", body["reason"])
+	assert.Equal(t, "users.example.com", body["domain"])
+	assert.Equal(t, map[string]any{"id": "42"}, body["metadata"])
+	assert.Equal(t, []any{map[string]any{"pointer": "/user/emails/1", "detail": "must be an email"}}, body["errors"])
+}
+
+func TestFromProblem(t *testing.T) {
+	t.Parallel()
+
+	var p errors.ProblemDetail
+	require.NoError(t, json.Unmarshal([]byte(`{"type":"about:blank","title":"Unprocessable Entity","status":422}`), &p))
+	st := FromProblem(p)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	assert.Equal(t, "Unprocessable Entity", st.Message())
+	assert.Empty(t, st.Details())
+
+	st = FromProblem(errors.ProblemDetail{
+		Status: http.StatusUnprocessableEntity,
+		Detail: "validation failed",
+		Extensions: map[string]any{
+			"code":   "validation_error",
+			"errors": []map[string]any{{"pointer": "/items/2/a~1b", "detail": "is required"}},
+		},
+	})
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	assert.Equal(t, "validation failed", st.Message())
+
+	details := st.Details()
+	require.Len(t, details, 2)
+	info, ok := details[0].(*errdetails.ErrorInfo)
+	require.True(t, ok)
+	assert.Equal(t, "validation_error", info.GetReason())
+	bad, ok := details[1].(*errdetails.BadRequest)
+	require.True(t, ok)
+	require.Len(t, bad.GetFieldViolations(), 1)
+	assert.Equal(t, "items.2.a/b", bad.GetFieldViolations()[0].GetField())
+	assert.Equal(t, "is required", bad.GetFieldViolations()[0].GetDescription())
+}
+
+func TestSnakeCase(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "ok", snakeCase(codes.OK.String()))
+	assert.Equal(t, "deadline_exceeded", snakeCase(codes.DeadlineExceeded.String()))
+	assert.Equal(t, "unauthenticated", snakeCase(codes.Unauthenticated.String()))
+}
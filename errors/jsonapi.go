@@ -17,6 +17,7 @@ package errors
 import (
 	"encoding/json"
 	"errors"
+	"maps"
 	"net/http"
 	"strconv"
 	"strings"
@@ -35,8 +36,18 @@ import (
 //	json.NewEncoder(w).Encode(response.Body)
 type JSONAPI struct {
 	// StatusResolver determines HTTP status from error.
-	// If nil, uses ErrorType interface or defaults to 500.
+	// If nil, uses ErrorType interface, then the Registry, or defaults to 500.
 	StatusResolver func(err error) int
+
+	// Registry maps errors to status codes and codes when they don't
+	// implement ErrorType or ErrorCode. If nil, the package-level registry
+	// (see Register) is used.
+	Registry *Registry
+	// Translator localizes titles and details. If nil, they are not translated.
+	Translator Translator
+	// Debug includes the error chain and stack in responses. Never enable it
+	// in production: it exposes internal details.
+	Debug bool
 }
 
 // jsonAPIError represents a single error in JSON:API format.
@@ -60,6 +71,7 @@ type jsonAPISource struct {
 // jsonAPIErrorResponse wraps errors in JSON:API format.
 type jsonAPIErrorResponse struct {
 	Errors []jsonAPIError `json:"errors"`
+	Meta   map[string]any `json:"meta,omitempty"` // Debug details, in debug mode
 }
 
 // Format converts an error into a JSON:API error response.
@@ -75,12 +87,15 @@ type jsonAPIErrorResponse struct {
 //	json.NewEncoder(w).Encode(response.Body)
 //
 // Parameters:
-//   - req: HTTP request (currently unused, reserved for future use)
+//   - req: HTTP request (passed to the Translator)
 //   - err: Error to format
 //
 // Returns a Response with JSON:API formatted error.
 func (f *JSONAPI) Format(req *http.Request, err error) Response {
 	status := f.determineStatus(err)
+	code, hasCode := resolveCode(err, f.Registry)
+	title := translate(f.Translator, req, TextTitle, status, code, http.StatusText(status), err)
+	detail := translate(f.Translator, req, TextDetail, status, code, err.Error(), err)
 
 	var apiErrors []jsonAPIError
 
@@ -101,7 +116,7 @@ func (f *JSONAPI) Format(req *http.Request, err error) Response {
 						apiErr := jsonAPIError{
 							ID:     generateErrorID(),
 							Status: strconv.Itoa(status),
-							Title:  http.StatusText(status),
+							Title:  title,
 						}
 
 						// Extract field information from map
@@ -115,8 +130,8 @@ func (f *JSONAPI) Format(req *http.Request, err error) Response {
 									Pointer: pointer,
 								}
 							}
-							if code, codeOk := fieldMap["code"].(string); codeOk && code != "" {
-								apiErr.Code = code
+							if fieldCode, codeOk := fieldMap["code"].(string); codeOk && fieldCode != "" {
+								apiErr.Code = fieldCode
 							}
 							if message, messageOk := fieldMap["message"].(string); messageOk && message != "" {
 								apiErr.Detail = message
@@ -128,7 +143,7 @@ func (f *JSONAPI) Format(req *http.Request, err error) Response {
 
 						// Ensure we have at least a detail message
 						if apiErr.Detail == "" {
-							apiErr.Detail = err.Error()
+							apiErr.Detail = detail
 						}
 
 						apiErrors = append(apiErrors, apiErr)
@@ -142,8 +157,8 @@ func (f *JSONAPI) Format(req *http.Request, err error) Response {
 			apiErrors = []jsonAPIError{{
 				ID:     generateErrorID(),
 				Status: strconv.Itoa(status),
-				Title:  http.StatusText(status),
-				Detail: err.Error(),
+				Title:  title,
+				Detail: detail,
 				Meta:   map[string]any{"details": details},
 			}}
 		}
@@ -152,14 +167,21 @@ func (f *JSONAPI) Format(req *http.Request, err error) Response {
 		apiErr := jsonAPIError{
 			ID:     generateErrorID(),
 			Status: strconv.Itoa(status),
-			Title:  http.StatusText(status),
-			Detail: err.Error(),
+			Title:  title,
+			Detail: detail,
 		}
 
 		// Add code if available
-		var coded ErrorCode
-		if errors.As(err, &coded) {
-			apiErr.Code = coded.Code()
+		if hasCode {
+			apiErr.Code = code
+		}
+
+		// Let the error add its own members as meta-information
+		var extended ErrorExtensions
+		if errors.As(err, &extended) {
+			if extensions := extended.Extensions(); len(extensions) > 0 {
+				apiErr.Meta = maps.Clone(extensions)
+			}
 		}
 
 		apiErrors = []jsonAPIError{apiErr}
@@ -170,36 +192,34 @@ func (f *JSONAPI) Format(req *http.Request, err error) Response {
 		apiErrors = []jsonAPIError{{
 			ID:     generateErrorID(),
 			Status: strconv.Itoa(status),
-			Title:  http.StatusText(status),
-			Detail: err.Error(),
+			Title:  title,
+			Detail: detail,
 		}}
 	}
 
+	body := jsonAPIErrorResponse{Errors: apiErrors}
+
+	// Internal details, only in debug mode
+	if f.Debug {
+		body.Meta = map[string]any{"debug": newDebugInfo(err)}
+	}
+
 	return Response{
 		Status:      status,
 		ContentType: "application/vnd.api+json; charset=utf-8",
-		Body:        jsonAPIErrorResponse{Errors: apiErrors},
+		Body:        body,
 	}
 }
 
 // determineStatus determines the HTTP status code for an error.
-// It checks StatusResolver first, then ErrorType interface, then defaults to 500.
+// It checks StatusResolver first, then ErrorType interface, then the Registry, then defaults to 500.
 //
 // Parameters:
 //   - err: Error to determine status for
 //
 // Returns the HTTP status code.
 func (f *JSONAPI) determineStatus(err error) int {
-	if f.StatusResolver != nil {
-		return f.StatusResolver(err)
-	}
-
-	var typed ErrorType
-	if errors.As(err, &typed) {
-		return typed.HTTPStatus()
-	}
-
-	return http.StatusInternalServerError
+	return resolveStatus(err, f.StatusResolver, f.Registry)
 }
 
 // convertPathToPointer converts a field path to JSON Pointer format.
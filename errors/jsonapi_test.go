@@ -128,6 +128,24 @@ func TestJSONAPI_Format_WithDetails(t *testing.T) {
 	assert.Equal(t, "/data/attributes/items/0/price", secondErr.Source.Pointer, "Source.Pointer")
 }
 
+func TestJSONAPI_Format_WithExtensions(t *testing.T) {
+	t.Parallel()
+
+	formatter := MustNew(WithJSONAPI())
+	err := &testErrorWithMeta{
+		testError:  testError{message: "internal error"},
+		extensions: map[string]any{"request_id": "req-1"},
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	response := formatter.Format(req, err)
+
+	body, ok := response.Body.(jsonAPIErrorResponse)
+	require.True(t, ok, "Body is not jsonAPIErrorResponse, got %T", response.Body)
+	require.Len(t, body.Errors, 1, "Errors length")
+	assert.Equal(t, map[string]any{"request_id": "req-1"}, body.Errors[0].Meta, "Meta")
+}
+
 func TestConvertPathToPointer(t *testing.T) {
 	t.Parallel()
 
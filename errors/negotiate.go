@@ -0,0 +1,156 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errors
+
+import (
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Media types of the built-in formatters, used by [WithContentNegotiation].
+const (
+	MediaTypeProblemJSON = "application/problem+json"
+	MediaTypeProblemXML  = "application/problem+xml"
+	MediaTypeJSONAPI     = "application/vnd.api+json"
+	MediaTypeJSON        = "application/json"
+)
+
+// MediaTypeFormatter is a formatter offered by a [Negotiator] for a media type.
+type MediaTypeFormatter struct {
+	MediaType string
+	Formatter Formatter
+}
+
+// Negotiator selects a formatter by the request's Accept header, so one
+// application can answer each client in the format it asks for.
+// Responses carry "Vary: Accept".
+//
+// Example:
+//
+//	formatter := &errors.Negotiator{Formatters: []errors.MediaTypeFormatter{
+//		{MediaType: errors.MediaTypeProblemJSON, Formatter: &errors.RFC9457{}},
+//		{MediaType: errors.MediaTypeJSON, Formatter: &errors.Simple{}},
+//	}}
+type Negotiator struct {
+	// Formatters lists the offered formatters in order of preference. The
+	// first is used when the request has no Accept header or accepts none
+	// of the media types.
+	Formatters []MediaTypeFormatter
+}
+
+// Format formats err with the formatter that best matches the request's
+// Accept header.
+//
+// Parameters:
+//   - req: HTTP request, whose Accept header selects the formatter
+//   - err: Error to format
+//
+// Returns the Response of the selected formatter.
+func (n *Negotiator) Format(req *http.Request, err error) Response {
+	var formatter Formatter = &RFC9457{}
+	if len(n.Formatters) > 0 {
+		formatter = n.Formatters[n.selectFormatter(req.Header.Get("Accept"))].Formatter
+	}
+
+	response := formatter.Format(req, err)
+	if response.Headers == nil {
+		response.Headers = make(http.Header)
+	}
+	response.Headers.Add("Vary", "Accept")
+
+	return response
+}
+
+// selectFormatter returns the index of the formatter with the highest quality
+// in the Accept header; ties go to the earlier formatter.
+func (n *Negotiator) selectFormatter(accept string) int {
+	if accept == "" {
+		return 0
+	}
+
+	ranges := parseAccept(accept)
+	best, bestQuality := 0, 0.0
+	for i, f := range n.Formatters {
+		if q := acceptQuality(ranges, f.MediaType); q > bestQuality {
+			best, bestQuality = i, q
+		}
+	}
+
+	return best
+}
+
+// mediaRange is a media range of an Accept header with its quality.
+type mediaRange struct {
+	mediaType string
+	quality   float64
+}
+
+// parseAccept parses an Accept header into media ranges. Invalid ranges are skipped.
+func parseAccept(accept string) []mediaRange {
+	var ranges []mediaRange
+	for part := range strings.SplitSeq(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		quality := 1.0
+		if q, ok := params["q"]; ok {
+			if quality, err = strconv.ParseFloat(q, 64); err != nil {
+				continue
+			}
+		}
+		ranges = append(ranges, mediaRange{mediaType: mediaType, quality: quality})
+	}
+
+	return ranges
+}
+
+// acceptQuality returns the quality of mediaType given by the most specific
+// matching media range, or 0 if none matches.
+func acceptQuality(ranges []mediaRange, mediaType string) float64 {
+	mainType, _, _ := strings.Cut(mediaType, "/")
+	quality, specificity := 0.0, -1
+	for _, r := range ranges {
+		s := -1
+		switch r.mediaType {
+		case mediaType:
+			s = 2
+		case mainType + "/*":
+			s = 1
+		case "*/*":
+			s = 0
+		}
+		if s > specificity {
+			quality, specificity = r.quality, s
+		}
+	}
+
+	return quality
+}
+
+// negotiatorFromConfig builds a [Negotiator] offering the built-in formatters,
+// RFC 9457 JSON first, configured from cfg.
+func negotiatorFromConfig(cfg *config) *Negotiator {
+	problem := rfc9457FromConfig(cfg)
+
+	return &Negotiator{Formatters: []MediaTypeFormatter{
+		{MediaType: MediaTypeProblemJSON, Formatter: problem},
+		{MediaType: MediaTypeProblemXML, Formatter: &XML{RFC9457: *problem}},
+		{MediaType: MediaTypeJSONAPI, Formatter: jsonAPIFromConfig(cfg)},
+		{MediaType: MediaTypeJSON, Formatter: simpleFromConfig(cfg)},
+	}}
+}
@@ -0,0 +1,95 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package errors
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNegotiator_Format(t *testing.T) {
+	t.Parallel()
+
+	formatter := MustNew(WithContentNegotiation("https://api.example.com/problems"))
+	err := &testErrorFull{message: "user not found", code: "not-found", status: http.StatusNotFound}
+
+	tests := []struct {
+		name            string
+		accept          string
+		wantContentType string
+	}{
+		{name: "no accept header", accept: "", wantContentType: "application/problem+json; charset=utf-8"},
+		{name: "any", accept: "*/*", wantContentType: "application/problem+json; charset=utf-8"},
+		{name: "problem json", accept: "application/problem+json", wantContentType: "application/problem+json; charset=utf-8"},
+		{name: "problem xml", accept: "application/problem+xml", wantContentType: "application/problem+xml; charset=utf-8"},
+		{name: "json api", accept: "application/vnd.api+json", wantContentType: "application/vnd.api+json; charset=utf-8"},
+		{name: "plain json", accept: "application/json", wantContentType: "application/json; charset=utf-8"},
+		{name: "quality", accept: "application/json;q=0.5, application/problem+xml", wantContentType: "application/problem+xml; charset=utf-8"},
+		{name: "specific range wins", accept: "application/*;q=0.1, application/json;q=0.9, */*;q=0.5", wantContentType: "application/json; charset=utf-8"},
+		{name: "excluded", accept: "application/problem+json;q=0, application/*", wantContentType: "application/problem+xml; charset=utf-8"},
+		{name: "unsupported", accept: "text/html", wantContentType: "application/problem+json; charset=utf-8"},
+		{name: "invalid", accept: "not a media type", wantContentType: "application/problem+json; charset=utf-8"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(http.MethodGet, "/users/42", nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+
+			response := formatter.Format(req, err)
+			assert.Equal(t, tt.wantContentType, response.ContentType)
+			assert.Equal(t, http.StatusNotFound, response.Status)
+			assert.Equal(t, "Accept", response.Headers.Get("Vary"))
+		})
+	}
+}
+
+func TestNegotiator_Custom(t *testing.T) {
+	t.Parallel()
+
+	n := &Negotiator{Formatters: []MediaTypeFormatter{
+		{MediaType: MediaTypeJSON, Formatter: &Simple{}},
+		{MediaType: MediaTypeProblemJSON, Formatter: &RFC9457{DisableErrorID: true}},
+	}}
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept", "*/*")
+
+	response := n.Format(req, errors.New("boom"))
+	assert.Equal(t, "application/json; charset=utf-8", response.ContentType, "first formatter is the default")
+
+	empty := &Negotiator{}
+	response = empty.Format(req, errors.New("boom"))
+	_, ok := response.Body.(ProblemDetail)
+	require.True(t, ok, "empty negotiator falls back to RFC 9457")
+}
+
+func TestWithContentNegotiation_Conflict(t *testing.T) {
+	t.Parallel()
+
+	_, err := New(WithContentNegotiation(""), WithSimple())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "multiple formatter types")
+}
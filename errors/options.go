@@ -27,12 +27,17 @@ const (
 	kindRFC9457 formatterKind = iota + 1
 	kindJSONAPI
 	kindSimple
+	kindXML
+	kindNegotiated
 )
 
 // config holds formatter configuration. Options mutate config; New builds a Formatter from it.
 type config struct {
-	kind     formatterKind
-	conflict bool // true if more than one formatter type option was applied
+	kind       formatterKind
+	conflict   bool // true if more than one formatter type option was applied
+	registry   *Registry
+	translator Translator
+	debug      bool
 
 	// RFC9457-specific
 	rfc9457BaseURL   string
@@ -40,6 +45,7 @@ type config struct {
 	statusResolver   func(error) int
 	errorIDGenerator func() string
 	disableErrorID   bool
+	catalog          Catalog
 }
 
 // defaultConfig returns config with no formatter type set; New treats "unset" as RFC9457 with empty base URL.
@@ -53,7 +59,7 @@ func defaultConfig() *config {
 // validate returns an error if config is invalid (e.g. multiple formatter types specified).
 func (c *config) validate() error {
 	if c.conflict {
-		return fmt.Errorf("errors: multiple formatter types specified (exactly one of WithRFC9457, WithJSONAPI, WithSimple required)")
+		return fmt.Errorf("errors: multiple formatter types specified (exactly one of WithRFC9457, WithJSONAPI, WithSimple, WithXML, WithContentNegotiation required)")
 	}
 	return nil
 }
@@ -102,8 +108,45 @@ func WithSimple() Option {
 	}
 }
 
+// WithXML selects the RFC 9457 Problem Details formatter in XML and sets the
+// base URL for problem type URIs. Empty base URL is allowed (relative URIs).
+//
+// Example:
+//
+//	formatter := errors.MustNew(errors.WithXML("https://api.example.com/problems"))
+func WithXML(baseURL string) Option {
+	return func(c *config) {
+		if c.kind != 0 && c.kind != kindXML {
+			c.conflict = true
+		}
+		c.kind = kindXML
+		c.rfc9457BaseURL = baseURL
+	}
+}
+
+// WithContentNegotiation selects a [Negotiator] that formats each error in the
+// format the request's Accept header asks for: RFC 9457 JSON (the default),
+// RFC 9457 XML, JSON:API, or Simple JSON for "application/json". baseURL is
+// the base URL for problem type URIs.
+//
+// Example:
+//
+//	formatter := errors.MustNew(
+//		errors.WithContentNegotiation("https://api.example.com/problems"),
+//		errors.WithTranslator(translator),
+//	)
+func WithContentNegotiation(baseURL string) Option {
+	return func(c *config) {
+		if c.kind != 0 && c.kind != kindNegotiated {
+			c.conflict = true
+		}
+		c.kind = kindNegotiated
+		c.rfc9457BaseURL = baseURL
+	}
+}
+
 // WithProblemTypeResolver sets the TypeResolver for the RFC9457 formatter.
-// Only applies when using WithRFC9457. If nil, default mapping is used.
+// Only applies to RFC 9457 formatters (WithRFC9457, WithXML, WithContentNegotiation). If nil, default mapping is used.
 func WithProblemTypeResolver(fn func(error) string) Option {
 	return func(c *config) {
 		c.typeResolver = fn
@@ -111,7 +154,7 @@ func WithProblemTypeResolver(fn func(error) string) Option {
 }
 
 // WithProblemStatusResolver sets the StatusResolver for the RFC9457 formatter.
-// Only applies when using WithRFC9457. If nil, default logic (ErrorType interface, then 500) is used.
+// Only applies to RFC 9457 formatters (WithRFC9457, WithXML, WithContentNegotiation). If nil, default logic (ErrorType interface, then 500) is used.
 func WithProblemStatusResolver(fn func(error) int) Option {
 	return func(c *config) {
 		c.statusResolver = fn
@@ -119,7 +162,7 @@ func WithProblemStatusResolver(fn func(error) int) Option {
 }
 
 // WithProblemErrorIDGenerator sets the ErrorIDGenerator for the RFC9457 formatter.
-// Only applies when using WithRFC9457. If nil, default UUID-based generation is used.
+// Only applies to RFC 9457 formatters (WithRFC9457, WithXML, WithContentNegotiation). If nil, default UUID-based generation is used.
 func WithProblemErrorIDGenerator(fn func() string) Option {
 	return func(c *config) {
 		c.errorIDGenerator = fn
@@ -127,7 +170,7 @@ func WithProblemErrorIDGenerator(fn func() string) Option {
 }
 
 // WithDisableProblemErrorID disables automatic error ID generation for the RFC9457 formatter.
-// Only applies when using WithRFC9457.
+// Only applies to RFC 9457 formatters (WithRFC9457, WithXML, WithContentNegotiation).
 func WithDisableProblemErrorID() Option {
 	return func(c *config) {
 		c.disableErrorID = true
@@ -141,3 +184,60 @@ func WithStatusResolver(fn func(error) int) Option {
 		c.statusResolver = fn
 	}
 }
+
+// WithRegistry sets the [Registry] consulted for errors that don't implement
+// ErrorType or ErrorCode, for formatters of all types. If nil, the package-level
+// registry (see [Register]) is used.
+//
+// Example:
+//
+//	registry := errors.NewRegistry()
+//	registry.Register(sql.ErrNoRows, http.StatusNotFound, "not-found")
+//	formatter := errors.MustNew(errors.WithRFC9457(baseURL), errors.WithRegistry(registry))
+func WithRegistry(r *Registry) Option {
+	return func(c *config) {
+		c.registry = r
+	}
+}
+
+// WithTranslator sets the [Translator] that localizes titles and details, for
+// formatters of all types. If nil, they are not translated.
+//
+// Example:
+//
+//	formatter := errors.MustNew(errors.WithTranslator(func(req *http.Request, t errors.Text) string {
+//		return catalog.Translate(req.Context(), t.Value)
+//	}))
+func WithTranslator(tr Translator) Option {
+	return func(c *config) {
+		c.translator = tr
+	}
+}
+
+// WithDebug includes the error chain, and the stack of errors wrapped with
+// [WithStack], in responses of formatters of all types. It is off by default;
+// never enable it in production, as it exposes internal details.
+//
+// Example:
+//
+//	formatter := errors.MustNew(errors.WithDebug(os.Getenv("APP_DEBUG") == "true"))
+func WithDebug(enabled bool) Option {
+	return func(c *config) {
+		c.debug = enabled
+	}
+}
+
+// WithErrorCatalog sets the [Catalog] of documentation URLs used as problem
+// types by RFC 9457 formatters (WithRFC9457, WithXML, WithContentNegotiation).
+//
+// Example:
+//
+//	formatter := errors.MustNew(
+//		errors.WithRFC9457("https://api.example.com/problems"),
+//		errors.WithErrorCatalog(errors.NewCatalog("https://docs.example.com/errors", "not-found", "conflict")),
+//	)
+func WithErrorCatalog(c Catalog) Option {
+	return func(cfg *config) {
+		cfg.catalog = c
+	}
+}
@@ -0,0 +1,183 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errors
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+)
+
+// Mapping is the HTTP status and machine-readable code of a registered error.
+// A zero Status or empty Code leaves that part to the formatter's defaults.
+type Mapping struct {
+	Status int
+	Code   string
+}
+
+// Registry maps sentinel errors and error types to a [Mapping], so domain
+// errors don't have to implement ErrorType or ErrorCode. Formatters consult it
+// after the error's own interfaces; when several entries match, the first
+// registered wins.
+//
+// Registry is safe for concurrent use. Most applications use the package-level
+// registry through [Register] and [RegisterType].
+//
+// Example:
+//
+//	registry := errors.NewRegistry()
+//	registry.Register(sql.ErrNoRows, http.StatusNotFound, "not-found")
+//	formatter := errors.MustNew(errors.WithRegistry(registry))
+type Registry struct {
+	mu      sync.RWMutex
+	entries []registryEntry
+}
+
+// registryEntry is a matcher with its mapping.
+type registryEntry struct {
+	match   func(error) bool
+	mapping Mapping
+}
+
+// defaultRegistry is the registry used by formatters without one.
+var defaultRegistry = NewRegistry()
+
+// NewRegistry creates an empty [Registry].
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Register maps errors matching target with errors.Is to status and code.
+// It panics if target is nil.
+//
+// Example:
+//
+//	registry.Register(sql.ErrNoRows, http.StatusNotFound, "not-found")
+func (r *Registry) Register(target error, status int, code string) {
+	if target == nil {
+		panic("errors: Register target cannot be nil")
+	}
+	r.RegisterFunc(func(err error) bool { return errors.Is(err, target) }, status, code)
+}
+
+// RegisterFunc maps errors for which match returns true to status and code.
+// It panics if match is nil.
+//
+// Example:
+//
+//	registry.RegisterFunc(func(err error) bool {
+//		var pgErr *pgconn.PgError
+//		return errors.As(err, &pgErr) && pgErr.Code == "23505"
+//	}, http.StatusConflict, "conflict")
+func (r *Registry) RegisterFunc(match func(error) bool, status int, code string) {
+	if match == nil {
+		panic("errors: RegisterFunc match cannot be nil")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries = append(r.entries, registryEntry{match: match, mapping: Mapping{Status: status, Code: code}})
+}
+
+// Lookup returns the mapping of the first entry matching err.
+func (r *Registry) Lookup(err error) (Mapping, bool) {
+	if err == nil {
+		return Mapping{}, false
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, e := range r.entries {
+		if e.match(err) {
+			return e.mapping, true
+		}
+	}
+
+	return Mapping{}, false
+}
+
+// Register maps errors matching target with errors.Is to status and code in
+// the package-level registry, used by formatters without their own.
+// It panics if target is nil.
+//
+// Example:
+//
+//	func init() {
+//		errors.Register(sql.ErrNoRows, http.StatusNotFound, "not-found")
+//	}
+func Register(target error, status int, code string) {
+	defaultRegistry.Register(target, status, code)
+}
+
+// RegisterType maps errors with an error of type T in their chain, as found
+// by errors.As, to status and code in the package-level registry.
+//
+// Example:
+//
+//	errors.RegisterType[*json.SyntaxError](http.StatusBadRequest, "malformed-json")
+func RegisterType[T error](status int, code string) {
+	defaultRegistry.RegisterFunc(func(err error) bool {
+		var target T
+		return errors.As(err, &target)
+	}, status, code)
+}
+
+// lookupMapping returns the mapping of err in registry, or in the
+// package-level registry when registry is nil.
+func lookupMapping(registry *Registry, err error) (Mapping, bool) {
+	if registry == nil {
+		registry = defaultRegistry
+	}
+
+	return registry.Lookup(err)
+}
+
+// resolveStatus determines the HTTP status code for an error.
+// It checks resolver first, then the ErrorType interface, then the registry,
+// and defaults to 500.
+func resolveStatus(err error, resolver func(error) int, registry *Registry) int {
+	// Custom resolver takes precedence
+	if resolver != nil {
+		return resolver(err)
+	}
+
+	// Check if error declares its own status
+	var typed ErrorType
+	if errors.As(err, &typed) {
+		return typed.HTTPStatus()
+	}
+
+	// Check registered mappings
+	if m, ok := lookupMapping(registry, err); ok && m.Status != 0 {
+		return m.Status
+	}
+
+	return http.StatusInternalServerError
+}
+
+// resolveCode returns the machine-readable code for an error from the
+// ErrorCode interface, then the registry.
+func resolveCode(err error, registry *Registry) (string, bool) {
+	var coded ErrorCode
+	if errors.As(err, &coded) {
+		return coded.Code(), true
+	}
+
+	if m, ok := lookupMapping(registry, err); ok && m.Code != "" {
+		return m.Code, true
+	}
+
+	return "", false
+}
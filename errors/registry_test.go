@@ -0,0 +1,164 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type registryTestError struct {
+	id string
+}
+
+func (e *registryTestError) Error() string {
+	return e.id + " not found"
+}
+
+func TestRegistry_Lookup(t *testing.T) {
+	t.Parallel()
+
+	errMissing := errors.New("missing")
+	errGone := errors.New("gone")
+
+	r := NewRegistry()
+	r.Register(errMissing, http.StatusNotFound, "not-found")
+	r.Register(errGone, http.StatusGone, "")
+	r.RegisterFunc(func(err error) bool {
+		var target *registryTestError
+		return errors.As(err, &target)
+	}, http.StatusNotFound, "resource-not-found")
+	r.Register(errMissing, http.StatusTeapot, "shadowed")
+
+	tests := []struct {
+		name   string
+		err    error
+		want   Mapping
+		wantOK bool
+	}{
+		{name: "sentinel", err: errMissing, want: Mapping{Status: http.StatusNotFound, Code: "not-found"}, wantOK: true},
+		{name: "wrapped sentinel", err: fmt.Errorf("load user: %w", errMissing), want: Mapping{Status: http.StatusNotFound, Code: "not-found"}, wantOK: true},
+		{name: "status only", err: errGone, want: Mapping{Status: http.StatusGone}, wantOK: true},
+		{name: "type", err: fmt.Errorf("wrap: %w", &registryTestError{id: "42"}), want: Mapping{Status: http.StatusNotFound, Code: "resource-not-found"}, wantOK: true},
+		{name: "unregistered", err: errors.New("other")},
+		{name: "nil", err: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := r.Lookup(tt.err)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRegistry_Panics(t *testing.T) {
+	t.Parallel()
+
+	r := NewRegistry()
+	assert.Panics(t, func() { r.Register(nil, http.StatusNotFound, "") })
+	assert.Panics(t, func() { r.RegisterFunc(nil, http.StatusNotFound, "") })
+}
+
+func TestRegistry_Formatters(t *testing.T) {
+	t.Parallel()
+
+	errMissing := errors.New("missing")
+	r := NewRegistry()
+	r.Register(errMissing, http.StatusNotFound, "not-found")
+	err := fmt.Errorf("load user: %w", errMissing)
+	req := httptest.NewRequest(http.MethodGet, "/users/42", nil)
+
+	t.Run("RFC9457", func(t *testing.T) {
+		t.Parallel()
+
+		response := MustNew(WithRFC9457("https://api.example.com/problems"), WithRegistry(r)).Format(req, err)
+		assert.Equal(t, http.StatusNotFound, response.Status)
+
+		body, ok := response.Body.(ProblemDetail)
+		require.True(t, ok)
+		assert.Equal(t, "https://api.example.com/problems/not-found", body.Type)
+		assert.Equal(t, "not-found", body.Extensions["code"])
+	})
+
+	t.Run("JSONAPI", func(t *testing.T) {
+		t.Parallel()
+
+		response := MustNew(WithJSONAPI(), WithRegistry(r)).Format(req, err)
+		assert.Equal(t, http.StatusNotFound, response.Status)
+
+		body, ok := response.Body.(jsonAPIErrorResponse)
+		require.True(t, ok)
+		require.Len(t, body.Errors, 1)
+		assert.Equal(t, "not-found", body.Errors[0].Code)
+		assert.Equal(t, "404", body.Errors[0].Status)
+	})
+
+	t.Run("Simple", func(t *testing.T) {
+		t.Parallel()
+
+		response := MustNew(WithSimple(), WithRegistry(r)).Format(req, err)
+		assert.Equal(t, http.StatusNotFound, response.Status)
+
+		body, ok := response.Body.(map[string]any)
+		require.True(t, ok)
+		assert.Equal(t, "not-found", body["code"])
+	})
+
+	t.Run("error interfaces take precedence", func(t *testing.T) {
+		t.Parallel()
+
+		response := MustNew(WithSimple(), WithRegistry(r)).Format(req, WithStatus(err, http.StatusForbidden))
+		assert.Equal(t, http.StatusForbidden, response.Status)
+	})
+
+	t.Run("status resolver takes precedence", func(t *testing.T) {
+		t.Parallel()
+
+		f := MustNew(WithSimple(), WithRegistry(r), WithStatusResolver(func(error) int { return http.StatusBadGateway }))
+		assert.Equal(t, http.StatusBadGateway, f.Format(req, err).Status)
+	})
+}
+
+func TestRegister_PackageLevel(t *testing.T) {
+	t.Parallel()
+
+	errLocked := errors.New("locked")
+	Register(errLocked, http.StatusLocked, "locked")
+	RegisterType[*registryTestError](http.StatusNotFound, "resource-not-found")
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	f := MustNew(WithSimple())
+
+	response := f.Format(req, errLocked)
+	assert.Equal(t, http.StatusLocked, response.Status)
+
+	response = f.Format(req, &registryTestError{id: "7"})
+	assert.Equal(t, http.StatusNotFound, response.Status)
+	body, ok := response.Body.(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, "resource-not-found", body["code"])
+}
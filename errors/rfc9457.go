@@ -20,6 +20,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 	"net/http"
 	"time"
 )
@@ -44,7 +45,7 @@ type RFC9457 struct {
 	TypeResolver func(err error) string
 
 	// StatusResolver determines HTTP status from error.
-	// If nil, uses default logic (ErrorType interface, then 500).
+	// If nil, uses default logic (ErrorType interface, then Registry, then 500).
 	StatusResolver func(err error) int
 
 	// ErrorIDGenerator generates unique IDs for error tracking.
@@ -53,6 +54,20 @@ type RFC9457 struct {
 
 	// DisableErrorID disables automatic error ID generation.
 	DisableErrorID bool
+
+	// Catalog maps error codes to documentation URLs used as problem types.
+	// Codes not in the catalog use BaseURL.
+	Catalog Catalog
+
+	// Registry maps errors to status codes and codes when they don't
+	// implement ErrorType or ErrorCode. If nil, the package-level registry
+	// (see Register) is used.
+	Registry *Registry
+	// Translator localizes titles and details. If nil, they are not translated.
+	Translator Translator
+	// Debug includes the error chain and stack in responses. Never enable it
+	// in production: it exposes internal details.
+	Debug bool
 }
 
 // ProblemDetail represents an RFC 9457 problem detail.
@@ -109,6 +124,7 @@ func (p ProblemDetail) MarshalJSON() ([]byte, error) {
 // Format converts an error into an RFC 9457 Problem Details response.
 // It determines the status code, problem type, and builds the problem detail structure.
 // If the error implements ErrorDetails or ErrorCode interfaces, those are included as extensions.
+// Members from ErrorExtensions are added last and take precedence.
 //
 // Example:
 //
@@ -126,12 +142,13 @@ func (p ProblemDetail) MarshalJSON() ([]byte, error) {
 func (f *RFC9457) Format(req *http.Request, err error) Response {
 	status := f.determineStatus(err)
 	problemType := f.determineType(err)
+	code, hasCode := resolveCode(err, f.Registry)
 
 	p := ProblemDetail{
 		Type:       problemType,
-		Title:      http.StatusText(status),
+		Title:      translate(f.Translator, req, TextTitle, status, code, http.StatusText(status), err),
 		Status:     status,
-		Detail:     err.Error(),
+		Detail:     translate(f.Translator, req, TextDetail, status, code, err.Error(), err),
 		Instance:   req.URL.Path,
 		Extensions: make(map[string]any),
 	}
@@ -154,9 +171,19 @@ func (f *RFC9457) Format(req *http.Request, err error) Response {
 	}
 
 	// Add code if available
-	var coded ErrorCode
-	if errors.As(err, &coded) {
-		p.Extensions["code"] = coded.Code()
+	if hasCode {
+		p.Extensions["code"] = code
+	}
+
+	// Let the error shape its own members
+	var extended ErrorExtensions
+	if errors.As(err, &extended) {
+		maps.Copy(p.Extensions, extended.Extensions())
+	}
+
+	// Internal details, only in debug mode
+	if f.Debug {
+		p.Extensions["debug"] = newDebugInfo(err)
 	}
 
 	return Response{
@@ -167,30 +194,19 @@ func (f *RFC9457) Format(req *http.Request, err error) Response {
 }
 
 // determineStatus determines the HTTP status code for an error.
-// It checks StatusResolver first, then ErrorType interface, then defaults to 500.
+// It checks StatusResolver first, then ErrorType interface, then the Registry, then defaults to 500.
 //
 // Parameters:
 //   - err: Error to determine status for
 //
 // Returns the HTTP status code.
 func (f *RFC9457) determineStatus(err error) int {
-	// Custom resolver takes precedence
-	if f.StatusResolver != nil {
-		return f.StatusResolver(err)
-	}
-
-	// Check if error declares its own status
-	var typed ErrorType
-	if errors.As(err, &typed) {
-		return typed.HTTPStatus()
-	}
-
-	// Default to 500
-	return http.StatusInternalServerError
+	return resolveStatus(err, f.StatusResolver, f.Registry)
 }
 
 // determineType determines the problem type URI for an error.
-// It checks TypeResolver first, then ErrorCode interface, then defaults to "about:blank".
+// It checks TypeResolver first, then the code from the ErrorCode interface or the Registry
+// (its Catalog URL, or BaseURL + code), then defaults to "about:blank".
 //
 // Parameters:
 //   - err: Error to determine type for
@@ -202,10 +218,11 @@ func (f *RFC9457) determineType(err error) string {
 		return f.TypeResolver(err)
 	}
 
-	// Check if error has a code
-	var coded ErrorCode
-	if errors.As(err, &coded) {
-		code := coded.Code()
+	// Check if error has a code, or a registered one
+	if code, ok := resolveCode(err, f.Registry); ok {
+		if url, documented := f.Catalog.URL(code); documented {
+			return url
+		}
 		if f.BaseURL != "" {
 			return f.BaseURL + "/" + code
 		}
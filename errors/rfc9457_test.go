@@ -18,6 +18,7 @@ package errors
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -175,6 +176,31 @@ func TestRFC9457_Format_WithDetails(t *testing.T) {
 	assert.Equal(t, "error1", errors["field1"], "errors[field1]")
 }
 
+func TestRFC9457_Format_WithExtensions(t *testing.T) {
+	t.Parallel()
+
+	formatter := MustNew(WithRFC9457(""))
+	err := &testErrorWithExtensions{
+		testErrorWithDetails: testErrorWithDetails{
+			message: "validation failed",
+			details: map[string]any{"field1": "error1"},
+		},
+		extensions: map[string]any{
+			"errors":    []map[string]any{{"pointer": "/field1", "detail": "error1"}},
+			"truncated": true,
+		},
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/test", nil)
+	response := formatter.Format(req, fmt.Errorf("bind: %w", err))
+
+	body, ok := response.Body.(ProblemDetail)
+	require.True(t, ok, "Body is not ProblemDetail, got %T", response.Body)
+	assert.Equal(t, []map[string]any{{"pointer": "/field1", "detail": "error1"}}, body.Extensions["errors"], "extensions replace details")
+	assert.Equal(t, true, body.Extensions["truncated"])
+	assert.NotEmpty(t, body.Extensions["error_id"])
+}
+
 func TestRFC9457_MarshalJSON(t *testing.T) {
 	t.Parallel()
 
@@ -32,8 +32,18 @@ import (
 //	json.NewEncoder(w).Encode(response.Body)
 type Simple struct {
 	// StatusResolver determines HTTP status from error.
-	// If nil, uses ErrorType interface or defaults to 500.
+	// If nil, uses ErrorType interface, then the Registry, or defaults to 500.
 	StatusResolver func(err error) int
+
+	// Registry maps errors to status codes and codes when they don't
+	// implement ErrorType or ErrorCode. If nil, the package-level registry
+	// (see Register) is used.
+	Registry *Registry
+	// Translator localizes titles and details. If nil, they are not translated.
+	Translator Translator
+	// Debug includes the error chain and stack in responses. Never enable it
+	// in production: it exposes internal details.
+	Debug bool
 }
 
 // Format converts an error into a simple JSON response.
@@ -48,15 +58,16 @@ type Simple struct {
 //	json.NewEncoder(w).Encode(response.Body)
 //
 // Parameters:
-//   - req: HTTP request (currently unused, reserved for future use)
+//   - req: HTTP request (passed to the Translator)
 //   - err: Error to format
 //
 // Returns a Response with simple JSON formatted error.
 func (f *Simple) Format(req *http.Request, err error) Response {
 	status := f.determineStatus(err)
+	code, hasCode := resolveCode(err, f.Registry)
 
 	body := map[string]any{
-		"error": err.Error(),
+		"error": translate(f.Translator, req, TextDetail, status, code, err.Error(), err),
 	}
 
 	// Add details if available
@@ -66,9 +77,13 @@ func (f *Simple) Format(req *http.Request, err error) Response {
 	}
 
 	// Add code if available
-	var coded ErrorCode
-	if errors.As(err, &coded) {
-		body["code"] = coded.Code()
+	if hasCode {
+		body["code"] = code
+	}
+
+	// Internal details, only in debug mode
+	if f.Debug {
+		body["debug"] = newDebugInfo(err)
 	}
 
 	return Response{
@@ -79,21 +94,12 @@ func (f *Simple) Format(req *http.Request, err error) Response {
 }
 
 // determineStatus determines the HTTP status code for an error.
-// It checks StatusResolver first, then ErrorType interface, then defaults to 500.
+// It checks StatusResolver first, then ErrorType interface, then the Registry, then defaults to 500.
 //
 // Parameters:
 //   - err: Error to determine status for
 //
 // Returns the HTTP status code.
 func (f *Simple) determineStatus(err error) int {
-	if f.StatusResolver != nil {
-		return f.StatusResolver(err)
-	}
-
-	var typed ErrorType
-	if errors.As(err, &typed) {
-		return typed.HTTPStatus()
-	}
-
-	return http.StatusInternalServerError
+	return resolveStatus(err, f.StatusResolver, f.Registry)
 }
@@ -93,3 +93,23 @@ func (e *testErrorWithDetailsSlice) Error() string {
 func (e *testErrorWithDetailsSlice) Details() any {
 	return e.details
 }
+
+type testErrorWithExtensions struct {
+	testErrorWithDetails
+
+	extensions map[string]any
+}
+
+func (e *testErrorWithExtensions) Extensions() map[string]any {
+	return e.extensions
+}
+
+type testErrorWithMeta struct {
+	testError
+
+	extensions map[string]any
+}
+
+func (e *testErrorWithMeta) Extensions() map[string]any {
+	return e.extensions
+}
@@ -0,0 +1,61 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errors
+
+import "net/http"
+
+// TextKind identifies which text of an error response a [Translator] localizes.
+type TextKind int
+
+const (
+	// TextTitle is the short summary of the status, such as "Not Found".
+	TextTitle TextKind = iota + 1
+
+	// TextDetail is the explanation of the error, from error.Error().
+	TextDetail
+)
+
+// Text is a text of an error response passed to a [Translator].
+type Text struct {
+	Kind   TextKind
+	Status int    // HTTP status code of the response
+	Code   string // Error code from ErrorCode or the Registry, if any
+	Value  string // Untranslated text
+	Err    error  // Error being formatted
+}
+
+// Translator localizes the title and detail of error responses for the
+// request, e.g. in the language negotiated by locale middleware. It returns
+// t.Value when it has no translation.
+//
+// Example:
+//
+//	translator := func(req *http.Request, t errors.Text) string {
+//		if msg, ok := catalog.Lookup(locale.FromContext(req.Context()), t.Code, t.Kind); ok {
+//			return msg
+//		}
+//		return t.Value
+//	}
+//	formatter := errors.MustNew(errors.WithTranslator(translator))
+type Translator func(req *http.Request, t Text) string
+
+// translate returns the text translated by tr, or value when tr is nil.
+func translate(tr Translator, req *http.Request, kind TextKind, status int, code, value string, err error) string {
+	if tr == nil {
+		return value
+	}
+
+	return tr(req, Text{Kind: kind, Status: status, Code: code, Value: value, Err: err})
+}
@@ -0,0 +1,101 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package errors
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// germanTranslator translates the texts of not-found errors for requests in German.
+func germanTranslator(req *http.Request, t Text) string {
+	if req.Header.Get("Accept-Language") != "de" {
+		return t.Value
+	}
+	switch {
+	case t.Kind == TextTitle && t.Status == http.StatusNotFound:
+		return "Nicht gefunden"
+	case t.Kind == TextDetail && t.Code == "not-found":
+		return "Die Ressource wurde nicht gefunden"
+	}
+
+	return t.Value
+}
+
+func TestTranslator(t *testing.T) {
+	t.Parallel()
+
+	err := &testErrorFull{message: "user not found", code: "not-found", status: http.StatusNotFound}
+	req := httptest.NewRequest(http.MethodGet, "/users/42", nil)
+	req.Header.Set("Accept-Language", "de")
+
+	t.Run("RFC9457", func(t *testing.T) {
+		t.Parallel()
+
+		response := MustNew(WithRFC9457(""), WithTranslator(germanTranslator)).Format(req, err)
+		body, ok := response.Body.(ProblemDetail)
+		require.True(t, ok)
+		assert.Equal(t, "Nicht gefunden", body.Title)
+		assert.Equal(t, "Die Ressource wurde nicht gefunden", body.Detail)
+	})
+
+	t.Run("JSONAPI", func(t *testing.T) {
+		t.Parallel()
+
+		response := MustNew(WithJSONAPI(), WithTranslator(germanTranslator)).Format(req, err)
+		body, ok := response.Body.(jsonAPIErrorResponse)
+		require.True(t, ok)
+		require.Len(t, body.Errors, 1)
+		assert.Equal(t, "Nicht gefunden", body.Errors[0].Title)
+		assert.Equal(t, "Die Ressource wurde nicht gefunden", body.Errors[0].Detail)
+	})
+
+	t.Run("Simple", func(t *testing.T) {
+		t.Parallel()
+
+		response := MustNew(WithSimple(), WithTranslator(germanTranslator)).Format(req, err)
+		body, ok := response.Body.(map[string]any)
+		require.True(t, ok)
+		assert.Equal(t, "Die Ressource wurde nicht gefunden", body["error"])
+	})
+
+	t.Run("negotiated", func(t *testing.T) {
+		t.Parallel()
+
+		xmlReq := req.Clone(req.Context())
+		xmlReq.Header.Set("Accept", MediaTypeProblemXML)
+		response := MustNew(WithContentNegotiation(""), WithTranslator(germanTranslator)).Format(xmlReq, err)
+		body, ok := response.Body.(ProblemDetail)
+		require.True(t, ok)
+		assert.Equal(t, "Nicht gefunden", body.Title)
+	})
+
+	t.Run("untranslated", func(t *testing.T) {
+		t.Parallel()
+
+		enReq := httptest.NewRequest(http.MethodGet, "/users/42", nil)
+		response := MustNew(WithTranslator(germanTranslator)).Format(enReq, err)
+		body, ok := response.Body.(ProblemDetail)
+		require.True(t, ok)
+		assert.Equal(t, "Not Found", body.Title)
+		assert.Equal(t, "user not found", body.Detail)
+	})
+}
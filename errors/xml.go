@@ -0,0 +1,145 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errors
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+)
+
+// problemXMLNamespace is the XML namespace of problem details (RFC 9457, Appendix B).
+const problemXMLNamespace = "urn:ietf:rfc:7807"
+
+// XML formats errors as RFC 9457 Problem Details in XML.
+// It produces responses with Content-Type "application/problem+xml", with the
+// same members as [RFC9457]; the body is a [ProblemDetail], which implements
+// xml.Marshaler.
+//
+// Example:
+//
+//	formatter := errors.MustNew(errors.WithXML("https://api.example.com/problems"))
+//	response := formatter.Format(req, err)
+//	w.Header().Set("Content-Type", response.ContentType)
+//	w.WriteHeader(response.Status)
+//	xml.NewEncoder(w).Encode(response.Body)
+type XML struct {
+	RFC9457
+}
+
+// Format converts an error into an RFC 9457 Problem Details response in XML.
+//
+// Parameters:
+//   - req: HTTP request (used for instance URI)
+//   - err: Error to format
+//
+// Returns a Response with RFC 9457 formatted error.
+func (f *XML) Format(req *http.Request, err error) Response {
+	response := f.RFC9457.Format(req, err)
+	response.ContentType = "application/problem+xml; charset=utf-8"
+
+	return response
+}
+
+// MarshalXML implements xml.Marshaler, encoding the problem detail as
+// described in RFC 9457, Appendix B. Extension members become elements, and
+// array items are encoded as <i> elements.
+func (p ProblemDetail) MarshalXML(enc *xml.Encoder, _ xml.StartElement) error {
+	root := xml.StartElement{Name: xml.Name{Local: "problem"}, Attr: []xml.Attr{{Name: xml.Name{Local: "xmlns"}, Value: problemXMLNamespace}}}
+	if err := enc.EncodeToken(root); err != nil {
+		return err
+	}
+
+	members := [][2]string{{"type", p.Type}, {"title", p.Title}, {"status", strconv.Itoa(p.Status)}}
+	if p.Detail != "" {
+		members = append(members, [2]string{"detail", p.Detail})
+	}
+	if p.Instance != "" {
+		members = append(members, [2]string{"instance", p.Instance})
+	}
+	for _, m := range members {
+		if err := enc.EncodeElement(m[1], xml.StartElement{Name: xml.Name{Local: m[0]}}); err != nil {
+			return err
+		}
+	}
+
+	// Extensions, through their JSON form so any value encodes the same way
+	if len(p.Extensions) > 0 {
+		data, err := json.Marshal(p.Extensions)
+		if err != nil {
+			return fmt.Errorf("marshal problem extensions: %w", err)
+		}
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.UseNumber()
+		var extensions map[string]any
+		if err = dec.Decode(&extensions); err != nil {
+			return fmt.Errorf("unmarshal problem extensions: %w", err)
+		}
+		for _, name := range slices.Sorted(maps.Keys(extensions)) {
+			switch name {
+			case "type", "title", "status", "detail", "instance":
+				continue // Reserved, like in MarshalJSON
+			}
+			if err = encodeXMLValue(enc, name, extensions[name]); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := enc.EncodeToken(root.End()); err != nil {
+		return err
+	}
+
+	return enc.Flush()
+}
+
+// encodeXMLValue encodes a JSON value as an element called name.
+func encodeXMLValue(enc *xml.Encoder, name string, v any) error {
+	start := xml.StartElement{Name: xml.Name{Local: name}}
+
+	switch v := v.(type) {
+	case map[string]any:
+		if err := enc.EncodeToken(start); err != nil {
+			return err
+		}
+		for _, key := range slices.Sorted(maps.Keys(v)) {
+			if err := encodeXMLValue(enc, key, v[key]); err != nil {
+				return err
+			}
+		}
+
+		return enc.EncodeToken(start.End())
+	case []any:
+		if err := enc.EncodeToken(start); err != nil {
+			return err
+		}
+		for _, item := range v {
+			if err := encodeXMLValue(enc, "i", item); err != nil {
+				return err
+			}
+		}
+
+		return enc.EncodeToken(start.End())
+	case nil:
+		return enc.EncodeElement("", start)
+	default:
+		return enc.EncodeElement(fmt.Sprint(v), start)
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package errors
+
+import (
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestXML_Format(t *testing.T) {
+	t.Parallel()
+
+	formatter := MustNew(WithXML("https://api.example.com/problems"), WithDisableProblemErrorID())
+	err := &testErrorFull{message: "user <42> not found", code: "not-found", status: http.StatusNotFound}
+
+	req := httptest.NewRequest(http.MethodGet, "/users/42", nil)
+	response := formatter.Format(req, err)
+	assert.Equal(t, http.StatusNotFound, response.Status)
+	assert.Equal(t, "application/problem+xml; charset=utf-8", response.ContentType)
+
+	data, marshalErr := xml.Marshal(response.Body)
+	require.NoError(t, marshalErr)
+	assert.Equal(t, `<problem xmlns="urn:ietf:rfc:7807">`+
+		`<type>https://api.example.com/problems/not-found</type>`+
+		`<title>Not Found</title>`+
+		`<status>404</status>`+
+		`<detail>user &lt;42&gt; not found</detail>`+
+		`<instance>/users/42</instance>`+
+		`<code>not-found</code>`+
+		`</problem>`, string(data))
+}
+
+func TestProblemDetail_MarshalXML_Extensions(t *testing.T) {
+	t.Parallel()
+
+	p := ProblemDetail{
+		Type:   "about:blank",
+		Title:  "Unprocessable Entity",
+		Status: http.StatusUnprocessableEntity,
+		Extensions: map[string]any{
+			"errors": []map[string]any{
+				{"pointer": "/email", "detail": "is required"},
+				{"pointer": "/age", "detail": "must be at least 18", "meta": map[string]any{"min": 18}},
+			},
+			"truncated": false,
+			"retry":     nil,
+			"title":     "ignored",
+		},
+	}
+
+	data, err := xml.Marshal(p)
+	require.NoError(t, err)
+	assert.Equal(t, `<problem xmlns="urn:ietf:rfc:7807">`+
+		`<type>about:blank</type>`+
+		`<title>Unprocessable Entity</title>`+
+		`<status>422</status>`+
+		`<errors>`+
+		`<i><detail>is required</detail><pointer>/email</pointer></i>`+
+		`<i><detail>must be at least 18</detail><meta><min>18</min></meta><pointer>/age</pointer></i>`+
+		`</errors>`+
+		`<retry></retry>`+
+		`<truncated>false</truncated>`+
+		`</problem>`, string(data))
+}
@@ -14,10 +14,16 @@
 
 package logging
 
-// Semantic convention field names for trace correlation.
-// Used by [contextHandler] to inject trace_id and span_id from
-// the OpenTelemetry span in context into log records.
+// Trace correlation field names, as specified by OpenTelemetry for trace
+// context in non-OTLP log formats. Loggers created by [New] and handlers
+// wrapped with [NewTraceHandler] add them to records whose context carries
+// an active span.
 const (
-	fieldTraceID = "trace_id"
-	fieldSpanID  = "span_id"
+	// TraceIDKey is the key of the hex-encoded trace ID.
+	TraceIDKey = "trace_id"
+	// SpanIDKey is the key of the hex-encoded span ID.
+	SpanIDKey = "span_id"
+
+	// fieldBaggagePrefix prefixes baggage entries added with [WithBaggageKeys].
+	fieldBaggagePrefix = "baggage."
 )
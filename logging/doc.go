@@ -77,6 +77,28 @@
 //	logger.SetLevel(logging.LevelDebug)  // Enable debug logging
 //	logger.SetLevel(logging.LevelWarn)   // Reduce to warnings only
 //
+// The level applies immediately to every logger derived from the Logger.
+// SetLevelFor restores the previous level after a duration, and operators
+// can change the level without a restart through an admin endpoint, SIGHUP,
+// or a configuration watch:
+//
+//	logger.SetLevelFor(logging.LevelDebug, 10*time.Minute)
+//	admin.Handle("/admin/log-level", logger.LevelHandler()) // GET, or PUT {"level":"debug","duration":"10m"}
+//	logger := logging.MustNew(logging.WithLevelSignal())      // kill -HUP toggles debug
+//	go logger.WatchLevel(ctx, levelNames)                    // e.g. fed by a config watcher
+//
+// # Processors
+//
+// WithProcessor adds hooks that enrich or filter records before they are
+// written, e.g. to add deployment metadata or drop a noisy component:
+//
+//	logger := logging.MustNew(
+//	    logging.WithProcessor(func(ctx context.Context, r *logging.Record) bool {
+//	        r.AddAttrs(slog.String("pod", podName))
+//	        return true // false drops the record
+//	    }),
+//	)
+//
 // # Global Logger Registration
 //
 // To register as the global slog default (for use with slog.Info(), etc.):
@@ -104,5 +126,29 @@
 //	slog.InfoContext(ctx, "processing request", "user_id", userID)
 //	// Automatically includes trace_id and span_id if context has active span
 //
+// WithBaggageKeys also adds selected OpenTelemetry baggage entries of the
+// context, such as a tenant ID set upstream:
+//
+//	logger := logging.MustNew(logging.WithBaggageKeys("tenant.id"))
+//	// Records include baggage.tenant.id when the context carries it
+//
+// NewTraceHandler adds the same fields to any slog handler, such as one
+// built by another library, so there's no need to add them by hand:
+//
+//	slog.SetDefault(slog.New(logging.NewTraceHandler(slog.NewJSONHandler(os.Stdout, nil))))
+//
+// # OTLP Export
+//
+// WithOTLP sends logs to an OpenTelemetry collector over OTLP HTTP, next to
+// traces and metrics. Records logged with a span in the context carry its
+// trace and span IDs, and the service name, version, and environment are
+// resource attributes. Shutdown exports the pending records:
+//
+//	logger := logging.MustNew(
+//	    logging.WithOTLP("http://otel-collector:4318"),
+//	    logging.WithServiceName("orders-api"),
+//	)
+//	defer logger.Shutdown(context.Background())
+//
 // See the README for more examples and configuration options.
 package logging
@@ -38,7 +38,7 @@ var (
 	ErrNilLogger = errors.New("custom logger is nil")
 
 	// ErrInvalidHandler indicates an unsupported handler type was specified.
-	// Valid types: JSONHandler, TextHandler, ConsoleHandler.
+	// Valid types: JSONHandler, TextHandler, ConsoleHandler, OTLPHandler.
 	ErrInvalidHandler = errors.New("invalid handler type")
 
 	// ErrLoggerShutdown indicates the logger has been shut down via [Logger.Shutdown].
@@ -25,6 +25,7 @@ import (
 	"sync"
 	"time"
 
+	"go.opentelemetry.io/otel/baggage"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -243,7 +244,8 @@ func (h *consoleHandler) appendAttr(b *strings.Builder, a slog.Attr) {
 }
 
 // contextHandler wraps any [slog.Handler] to automatically inject OpenTelemetry
-// trace correlation fields (trace_id, span_id) from the context.
+// trace correlation fields (trace_id, span_id) and selected baggage entries
+// from the context.
 //
 // When a log record is emitted via slog.InfoContext (or similar *Context methods),
 // the handler checks the context for an active OTel span. If found, it adds
@@ -254,7 +256,29 @@ func (h *consoleHandler) appendAttr(b *strings.Builder, a slog.Attr) {
 //
 // Thread-safe: Safe for concurrent use by multiple goroutines.
 type contextHandler struct {
-	underlying slog.Handler
+	underlying   slog.Handler
+	baggageKeys  []string // Baggage entries added as baggage.{key}
+	omitTraceIDs bool     // The underlying handler takes the trace context from ctx itself
+}
+
+// NewTraceHandler wraps h to add [TraceIDKey] and [SpanIDKey] to every
+// record whose context carries an active OpenTelemetry span, and the given
+// baggage entries as "baggage.{key}", as loggers created by [New] do.
+// Use it to get trace correlation with a handler built outside this package:
+//
+//	handler := logging.NewTraceHandler(slog.NewJSONHandler(os.Stdout, nil))
+//	slog.SetDefault(slog.New(handler))
+//
+//	slog.InfoContext(ctx, "order placed") // includes trace_id and span_id
+//
+// Records only carry the IDs when logged with a *Context method. Wrapping a
+// handler that is already a trace handler returns it unchanged.
+func NewTraceHandler(h slog.Handler, baggageKeys ...string) slog.Handler {
+	if ch, ok := h.(*contextHandler); ok && !ch.omitTraceIDs && len(baggageKeys) == 0 {
+		return h
+	}
+
+	return &contextHandler{underlying: h, baggageKeys: baggageKeys}
 }
 
 // Enabled delegates to the underlying handler.
@@ -263,15 +287,23 @@ func (h *contextHandler) Enabled(ctx context.Context, level slog.Level) bool {
 }
 
 // Handle injects trace_id and span_id from the context's OTel span (if present)
-// before delegating to the underlying handler.
+// and the configured baggage entries before delegating to the underlying handler.
 func (h *contextHandler) Handle(ctx context.Context, r slog.Record) error {
-	if span := trace.SpanFromContext(ctx); span.SpanContext().IsValid() {
+	if span := trace.SpanFromContext(ctx); !h.omitTraceIDs && span.SpanContext().IsValid() {
 		sc := span.SpanContext()
 		r.AddAttrs(
-			slog.String(fieldTraceID, sc.TraceID().String()),
-			slog.String(fieldSpanID, sc.SpanID().String()),
+			slog.String(TraceIDKey, sc.TraceID().String()),
+			slog.String(SpanIDKey, sc.SpanID().String()),
 		)
 	}
+	if len(h.baggageKeys) > 0 {
+		bag := baggage.FromContext(ctx)
+		for _, key := range h.baggageKeys {
+			if member := bag.Member(key); member.Key() != "" {
+				r.AddAttrs(slog.String(fieldBaggagePrefix+key, member.Value()))
+			}
+		}
+	}
 
 	return h.underlying.Handle(ctx, r)
 }
@@ -279,14 +311,18 @@ func (h *contextHandler) Handle(ctx context.Context, r slog.Record) error {
 // WithAttrs returns a new contextHandler wrapping the underlying handler with additional attributes.
 func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
 	return &contextHandler{
-		underlying: h.underlying.WithAttrs(attrs),
+		underlying:   h.underlying.WithAttrs(attrs),
+		baggageKeys:  h.baggageKeys,
+		omitTraceIDs: h.omitTraceIDs,
 	}
 }
 
 // WithGroup returns a new contextHandler wrapping the underlying handler with a group name.
 func (h *contextHandler) WithGroup(name string) slog.Handler {
 	return &contextHandler{
-		underlying: h.underlying.WithGroup(name),
+		underlying:   h.underlying.WithGroup(name),
+		baggageKeys:  h.baggageKeys,
+		omitTraceIDs: h.omitTraceIDs,
 	}
 }
 
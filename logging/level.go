@@ -0,0 +1,251 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logging
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// ParseLevel parses a level name such as "debug", "INFO", or "warn+2", as
+// used in configuration files and by [Logger.LevelHandler].
+// Returns [ErrInvalidLevel] if s isn't a level.
+func ParseLevel(s string) (Level, error) {
+	var level Level
+	if err := level.UnmarshalText([]byte(strings.TrimSpace(s))); err != nil {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidLevel, s)
+	}
+
+	return level, nil
+}
+
+// SetLevelFor changes the minimum log level for d, then restores the level
+// in effect before the call. Another SetLevel or SetLevelFor call cancels
+// the pending restore.
+//
+// Example:
+//
+//	// Debug for ten minutes while reproducing an issue
+//	logger.SetLevelFor(logging.LevelDebug, 10*time.Minute)
+//
+// Thread-safe: Safe to call concurrently.
+func (l *Logger) SetLevelFor(level Level, d time.Duration) error {
+	if d <= 0 {
+		return fmt.Errorf("level duration must be positive, got %s", d)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.useCustom {
+		return ErrCannotChangeLevel
+	}
+
+	l.cancelLevelRevert()
+	previous := l.level.Level()
+	l.level.Set(level)
+
+	var timer *time.Timer
+	timer = time.AfterFunc(d, func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		// Superseded by a later change
+		if l.levelRevert != timer {
+			return
+		}
+		l.levelRevert = nil
+		l.level.Set(previous)
+	})
+	l.levelRevert = timer
+
+	return nil
+}
+
+// cancelLevelRevert stops the pending restore of SetLevelFor (must be called
+// with lock held).
+func (l *Logger) cancelLevelRevert() {
+	if l.levelRevert != nil {
+		l.levelRevert.Stop()
+		l.levelRevert = nil
+	}
+}
+
+// toggleDebugLevel switches to the debug level, or back to the level in
+// effect before the previous switch.
+func (l *Logger) toggleDebugLevel() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.cancelLevelRevert()
+	if current := l.level.Level(); current != LevelDebug {
+		l.toggledFrom = current
+		l.level.Set(LevelDebug)
+
+		return
+	}
+	l.level.Set(l.toggledFrom)
+}
+
+// listenLevelSignal toggles the debug level on each signal until stop is
+// closed, then calls cleanup.
+func (l *Logger) listenLevelSignal(signals <-chan os.Signal, cleanup func(), stop <-chan struct{}) {
+	defer cleanup()
+
+	for {
+		select {
+		case <-signals:
+			l.toggleDebugLevel()
+		case <-stop:
+			return
+		}
+	}
+}
+
+// stopLevelChanges stops the signal listener and any pending restore.
+func (l *Logger) stopLevelChanges() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.cancelLevelRevert()
+	if l.levelStop != nil {
+		close(l.levelStop)
+		l.levelStop = nil
+	}
+}
+
+// WatchLevel applies each level name received from updates, such as the
+// log level of a watched configuration file, until ctx is done or updates
+// is closed. Invalid names are logged and ignored.
+//
+// Example:
+//
+//	levels := make(chan string)
+//	cfg.OnChange(func(c *Config) { levels <- c.LogLevel })
+//	go logger.WatchLevel(ctx, levels)
+func (l *Logger) WatchLevel(ctx context.Context, updates <-chan string) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case name, ok := <-updates:
+			if !ok {
+				return
+			}
+			level, err := ParseLevel(name)
+			if err == nil {
+				err = l.SetLevel(level)
+			}
+			if err != nil {
+				l.Warn("ignoring log level update", "level", name, "error", err)
+			}
+		}
+	}
+}
+
+// maxLevelRequestSize is the maximum size of a LevelHandler request body.
+const maxLevelRequestSize = 1 << 10
+
+// levelRequest is the body of a level change sent to LevelHandler.
+type levelRequest struct {
+	Level    string `json:"level"`
+	Duration string `json:"duration,omitempty"`
+}
+
+// levelResponse is the body of LevelHandler responses.
+type levelResponse struct {
+	Level string `json:"level,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// LevelHandler returns an HTTP handler for an admin endpoint that reports
+// and changes the minimum log level.
+//
+//   - GET returns the current level: {"level":"INFO"}
+//   - PUT or POST with {"level":"debug"} changes it, and with
+//     {"level":"debug","duration":"10m"} changes it for that long only
+//
+// The handler performs no authentication; mount it on an internal admin
+// listener or behind authentication middleware.
+//
+// Example:
+//
+//	admin := http.NewServeMux()
+//	admin.Handle("/admin/log-level", logger.LevelHandler())
+func (l *Logger) LevelHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet, http.MethodHead:
+		case http.MethodPut, http.MethodPost:
+			if status, err := l.applyLevelRequest(r); err != nil {
+				writeLevelResponse(w, status, levelResponse{Error: err.Error()})
+				return
+			}
+		default:
+			w.Header().Set("Allow", "GET, HEAD, PUT, POST")
+			writeLevelResponse(w, http.StatusMethodNotAllowed, levelResponse{Error: "method not allowed"})
+
+			return
+		}
+
+		writeLevelResponse(w, http.StatusOK, levelResponse{Level: l.Level().String()})
+	})
+}
+
+// applyLevelRequest decodes and applies a level change, and returns the
+// status code of the failure, if any.
+func (l *Logger) applyLevelRequest(r *http.Request) (int, error) {
+	var req levelRequest
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxLevelRequestSize)).Decode(&req); err != nil {
+		return http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err)
+	}
+	level, err := ParseLevel(req.Level)
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	if req.Duration == "" {
+		err = l.SetLevel(level)
+	} else {
+		var d time.Duration
+		if d, err = time.ParseDuration(req.Duration); err != nil {
+			return http.StatusBadRequest, fmt.Errorf("invalid duration: %w", err)
+		}
+		err = l.SetLevelFor(level, d)
+	}
+	if errors.Is(err, ErrCannotChangeLevel) {
+		return http.StatusConflict, err
+	}
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	return http.StatusOK, nil
+}
+
+// writeLevelResponse writes resp as JSON with status.
+func writeLevelResponse(w http.ResponseWriter, status int, resp levelResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(resp)
+}
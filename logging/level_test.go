@@ -0,0 +1,177 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package logging
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"runtime"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLevel(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input string
+		want  Level
+	}{
+		{"debug", LevelDebug},
+		{"INFO", LevelInfo},
+		{" warn ", LevelWarn},
+		{"error", LevelError},
+		{"info+2", LevelInfo + 2},
+	}
+	for _, tt := range tests {
+		level, err := ParseLevel(tt.input)
+		require.NoError(t, err, tt.input)
+		assert.Equal(t, tt.want, level, tt.input)
+	}
+
+	_, err := ParseLevel("verbose")
+	assert.ErrorIs(t, err, ErrInvalidLevel)
+}
+
+func TestLogger_SetLevel_DerivedLoggers(t *testing.T) {
+	t.Parallel()
+
+	th := NewTestHelper(t, WithLevel(LevelInfo))
+	derived := th.Logger.Logger().With("component", "db")
+
+	require.NoError(t, th.Logger.SetLevel(LevelDebug))
+	derived.Debug("query")
+
+	assert.True(t, th.ContainsLog("query"), "loggers derived before SetLevel follow the new level")
+}
+
+func TestLogger_SetLevelFor(t *testing.T) {
+	t.Parallel()
+
+	logger := MustNew(WithOutput(io.Discard), WithLevel(LevelWarn))
+
+	require.NoError(t, logger.SetLevelFor(LevelDebug, 20*time.Millisecond))
+	assert.Equal(t, LevelDebug, logger.Level())
+	assert.Eventually(t, func() bool { return logger.Level() == LevelWarn }, time.Second, 5*time.Millisecond)
+
+	// A later SetLevel cancels the restore
+	require.NoError(t, logger.SetLevelFor(LevelDebug, 20*time.Millisecond))
+	require.NoError(t, logger.SetLevel(LevelError))
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, LevelError, logger.Level())
+
+	require.Error(t, logger.SetLevelFor(LevelDebug, 0))
+
+	custom := MustNew(WithCustomLogger(slog.New(slog.NewJSONHandler(io.Discard, nil))))
+	assert.ErrorIs(t, custom.SetLevelFor(LevelDebug, time.Minute), ErrCannotChangeLevel)
+}
+
+func TestLogger_LevelHandler(t *testing.T) {
+	t.Parallel()
+
+	logger := MustNew(WithOutput(io.Discard), WithLevel(LevelInfo))
+	handler := logger.LevelHandler()
+
+	serve := func(method, body string) (int, levelResponse) {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(method, "/admin/log-level", strings.NewReader(body)))
+		var resp levelResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+		return rec.Code, resp
+	}
+
+	code, resp := serve(http.MethodGet, "")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "INFO", resp.Level)
+
+	code, resp = serve(http.MethodPut, `{"level":"debug"}`)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "DEBUG", resp.Level)
+	assert.Equal(t, LevelDebug, logger.Level())
+
+	code, resp = serve(http.MethodPost, `{"level":"error","duration":"20ms"}`)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "ERROR", resp.Level)
+	assert.Eventually(t, func() bool { return logger.Level() == LevelDebug }, time.Second, 5*time.Millisecond)
+
+	for _, body := range []string{`{"level":"verbose"}`, `{"level":"debug","duration":"soon"}`, `not json`} {
+		code, resp = serve(http.MethodPut, body)
+		assert.Equal(t, http.StatusBadRequest, code, body)
+		assert.NotEmpty(t, resp.Error, body)
+	}
+
+	code, _ = serve(http.MethodDelete, "")
+	assert.Equal(t, http.StatusMethodNotAllowed, code)
+
+	custom := MustNew(WithCustomLogger(slog.New(slog.NewJSONHandler(io.Discard, nil))))
+	rec := httptest.NewRecorder()
+	custom.LevelHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/", strings.NewReader(`{"level":"debug"}`)))
+	assert.Equal(t, http.StatusConflict, rec.Code)
+}
+
+func TestLogger_WatchLevel(t *testing.T) {
+	t.Parallel()
+
+	th := NewTestHelper(t, WithLevel(LevelInfo))
+	updates := make(chan string)
+	done := make(chan struct{})
+	go func() {
+		th.Logger.WatchLevel(context.Background(), updates)
+		close(done)
+	}()
+
+	updates <- "warn"
+	updates <- "verbose"
+	updates <- "debug"
+	close(updates)
+	<-done
+
+	assert.Equal(t, LevelDebug, th.Logger.Level())
+	assert.True(t, th.ContainsLog("ignoring log level update"), "invalid updates are logged")
+}
+
+//nolint:paralleltest // Sends SIGHUP to the test process
+func TestWithLevelSignal(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("SIGHUP is not supported on Windows")
+	}
+
+	logger := MustNew(WithOutput(io.Discard), WithLevel(LevelWarn), WithLevelSignal())
+	t.Cleanup(func() { _ = logger.Shutdown(context.Background()) })
+
+	process, err := os.FindProcess(os.Getpid())
+	require.NoError(t, err)
+
+	require.NoError(t, process.Signal(syscall.SIGHUP))
+	assert.Eventually(t, func() bool { return logger.Level() == LevelDebug }, time.Second, 5*time.Millisecond)
+
+	require.NoError(t, process.Signal(syscall.SIGHUP))
+	assert.Eventually(t, func() bool { return logger.Level() == LevelWarn }, time.Second, 5*time.Millisecond)
+}
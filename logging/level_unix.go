@@ -0,0 +1,33 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !windows
+
+package logging
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// notifyLevelSignal sets up SIGHUP handling for WithLevelSignal.
+// Returns a channel that receives SIGHUP signals and a cleanup function that
+// stops signal notifications.
+func notifyLevelSignal() (<-chan os.Signal, func()) {
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, syscall.SIGHUP)
+
+	return ch, func() { signal.Stop(ch) }
+}
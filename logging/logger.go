@@ -21,9 +21,12 @@ import (
 	"io"
 	"log/slog"
 	"os"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"time"
+
+	sdklog "go.opentelemetry.io/otel/sdk/log"
 )
 
 // HandlerType represents the type of logging handler.
@@ -36,6 +39,8 @@ const (
 	TextHandler HandlerType = "text"
 	// ConsoleHandler outputs human-readable colored logs.
 	ConsoleHandler HandlerType = "console"
+	// OTLPHandler sends logs to an OpenTelemetry collector over OTLP.
+	OTLPHandler HandlerType = "otlp"
 )
 
 // Level represents log level.
@@ -73,7 +78,13 @@ type Logger struct {
 	// Handler configuration
 	handlerType HandlerType
 	output      io.Writer
-	level       Level
+	level       slog.LevelVar // Read by the handlers on every record; see SetLevel
+
+	// Runtime level changes
+	toggleSignal bool          // Toggle debug level on SIGHUP; see WithLevelSignal
+	levelStop    chan struct{} // Stops the signal listener
+	levelRevert  *time.Timer   // Pending revert of SetLevelFor
+	toggledFrom  Level         // Level restored when debug is toggled off
 
 	// Service information (immutable after initialization)
 	// These are automatically added to every log entry
@@ -85,6 +96,8 @@ type Logger struct {
 	addSource   bool
 	debugMode   bool
 	replaceAttr func(groups []string, a slog.Attr) slog.Attr
+	baggageKeys []string
+	processors  []Processor
 
 	// Sampling
 	samplingConfig *samplingConfig
@@ -96,6 +109,10 @@ type Logger struct {
 	customLogger *slog.Logger
 	useCustom    bool
 
+	// OTLP export
+	otlpEndpoint   string
+	loggerProvider *sdklog.LoggerProvider // Created once; survives SetLevel
+
 	// Internal state
 	slogger        atomic.Pointer[slog.Logger] // Lock-free slog.Logger access
 	mu             sync.Mutex                  // Protects initialization/reconfiguration only
@@ -120,10 +137,14 @@ type config struct {
 	addSource      bool
 	debugMode      bool
 	replaceAttr    func(groups []string, a slog.Attr) slog.Attr
+	baggageKeys    []string
+	processors     []Processor
 	samplingConfig *samplingConfig
 	customLogger   *slog.Logger
 	useCustom      bool
 	registerGlobal bool
+	otlpEndpoint   string
+	toggleSignal   bool
 }
 
 // defaultConfig returns a config with default values.
@@ -146,6 +167,9 @@ func (c *config) validate() error {
 	if c.useCustom && c.customLogger == nil {
 		return ErrNilLogger
 	}
+	if slices.ContainsFunc(c.processors, func(p Processor) bool { return p == nil }) {
+		return errors.New("processor cannot be nil")
+	}
 	if c.samplingConfig != nil {
 		if c.samplingConfig.Initial < 0 || c.samplingConfig.Thereafter < 0 {
 			return errors.New("sampling config values must be non-negative")
@@ -159,18 +183,23 @@ func newLoggerFromConfig(cfg *config) (*Logger, error) {
 	l := &Logger{
 		handlerType:    cfg.handlerType,
 		output:         cfg.output,
-		level:          cfg.level,
 		serviceName:    cfg.serviceName,
 		serviceVersion: cfg.serviceVersion,
 		environment:    cfg.environment,
 		addSource:      cfg.addSource,
 		debugMode:      cfg.debugMode,
 		replaceAttr:    cfg.replaceAttr,
+		baggageKeys:    cfg.baggageKeys,
+		processors:     cfg.processors,
 		samplingConfig: cfg.samplingConfig,
 		customLogger:   cfg.customLogger,
 		useCustom:      cfg.useCustom,
 		registerGlobal: cfg.registerGlobal,
+		otlpEndpoint:   cfg.otlpEndpoint,
+		toggleSignal:   cfg.toggleSignal,
+		toggledFrom:    cfg.level,
 	}
+	l.level.Set(cfg.level)
 	if err := l.initialize(); err != nil {
 		return nil, err
 	}
@@ -214,6 +243,19 @@ func (l *Logger) initialize() error {
 	l.mu.Lock()
 	defer l.mu.Unlock()
 
+	// The OTLP provider is created once, not each time the handler is rebuilt
+	if l.handlerType == OTLPHandler && !l.useCustom {
+		endpoint := l.otlpEndpoint
+		if endpoint == "" {
+			endpoint = DefaultOTLPEndpoint
+		}
+		provider, err := newOTLPLoggerProvider(endpoint, l.serviceName, l.serviceVersion, l.environment)
+		if err != nil {
+			return err
+		}
+		l.loggerProvider = provider
+	}
+
 	if err := l.initializeHandler(); err != nil {
 		return err
 	}
@@ -225,6 +267,13 @@ func (l *Logger) initialize() error {
 		go l.samplingResetter()
 	}
 
+	if l.toggleSignal && !l.useCustom {
+		// Register before returning, so a signal sent right after New is handled
+		signals, cleanup := notifyLevelSignal()
+		l.levelStop = make(chan struct{})
+		go l.listenLevelSignal(signals, cleanup, l.levelStop)
+	}
+
 	return nil
 }
 
@@ -291,7 +340,7 @@ func (l *Logger) initializeHandler() error {
 	}
 
 	opts := &slog.HandlerOptions{
-		Level:       l.level,
+		Level:       &l.level,
 		AddSource:   l.addSource,
 		ReplaceAttr: l.buildReplaceAttr(),
 	}
@@ -304,18 +353,30 @@ func (l *Logger) initializeHandler() error {
 		handler = slog.NewTextHandler(l.output, opts)
 	case ConsoleHandler:
 		handler = newConsoleHandler(l.output, opts)
+	case OTLPHandler:
+		handler = newOTLPHandler(l.loggerProvider, opts)
 	default:
 		return fmt.Errorf("%w: %s", ErrInvalidHandler, l.handlerType)
 	}
 
+	if len(l.processors) > 0 {
+		handler = &processorHandler{underlying: handler, processors: l.processors}
+	}
+
 	// Wrap with context-aware handler for automatic trace correlation.
 	// This injects trace_id and span_id from the OTel span in context
 	// whenever slog.*Context(ctx, ...) is used with a request context.
-	handler = &contextHandler{underlying: handler}
+	// With OTLP, records carry the trace context natively.
+	handler = &contextHandler{
+		underlying:   handler,
+		baggageKeys:  l.baggageKeys,
+		omitTraceIDs: l.handlerType == OTLPHandler,
+	}
 
 	newLogger := slog.New(handler)
 
-	// Add service metadata as default attributes if configured
+	// Add service metadata as default attributes if configured.
+	// With OTLP, they are resource attributes instead.
 	var attrs []any
 	if l.serviceName != "" {
 		attrs = append(attrs, "service", l.serviceName)
@@ -326,7 +387,7 @@ func (l *Logger) initializeHandler() error {
 	if l.environment != "" {
 		attrs = append(attrs, "env", l.environment)
 	}
-	if len(attrs) > 0 {
+	if len(attrs) > 0 && l.handlerType != OTLPHandler {
 		newLogger = newLogger.With(attrs...)
 	}
 
@@ -425,7 +486,8 @@ func (l *Logger) Error(msg string, args ...any) {
 }
 
 // Shutdown gracefully shuts down the logger.
-func (l *Logger) Shutdown(_ context.Context) error {
+// With [OTLPHandler], it exports the pending records first, within ctx.
+func (l *Logger) Shutdown(ctx context.Context) error {
 	l.isShuttingDown.Store(true)
 
 	// Stop sampling ticker if running
@@ -434,6 +496,14 @@ func (l *Logger) Shutdown(_ context.Context) error {
 		close(l.sampleStop)
 	}
 
+	l.stopLevelChanges()
+
+	if l.loggerProvider != nil {
+		if err := l.loggerProvider.Shutdown(ctx); err != nil {
+			return fmt.Errorf("logger provider shutdown: %w", err)
+		}
+	}
+
 	logger := l.Logger()
 	if logger != nil {
 		if flusher, ok := logger.Handler().(interface{ Flush() error }); ok {
@@ -445,6 +515,8 @@ func (l *Logger) Shutdown(_ context.Context) error {
 }
 
 // SetLevel dynamically changes the minimum log level at runtime.
+// The change takes effect immediately for all loggers derived from this one,
+// and cancels a pending revert of [Logger.SetLevelFor].
 //
 // Use cases:
 //   - Enable debug logging temporarily for troubleshooting
@@ -453,9 +525,8 @@ func (l *Logger) Shutdown(_ context.Context) error {
 //
 // Limitations:
 //   - Not supported with custom loggers (returns [ErrCannotChangeLevel])
-//   - Brief initialization window where old/new levels may race
 //
-// Thread-safe: Safe to call concurrently, but multiple SetLevel calls will serialize.
+// Thread-safe: Safe to call concurrently.
 func (l *Logger) SetLevel(level Level) error {
 	l.mu.Lock()
 	defer l.mu.Unlock()
@@ -464,25 +535,15 @@ func (l *Logger) SetLevel(level Level) error {
 		return ErrCannotChangeLevel
 	}
 
-	oldLevel := l.level
-	l.level = level
-
-	// Reinitialize handler with new level
-	if err := l.initializeHandler(); err != nil {
-		l.level = oldLevel // Rollback on error
-		return err
-	}
+	l.cancelLevelRevert()
+	l.level.Set(level)
 
 	return nil
 }
 
 // Level returns the current minimum log level.
-// Note: This requires a lock because level can be changed dynamically via SetLevel.
 func (l *Logger) Level() Level {
-	l.mu.Lock()
-	defer l.mu.Unlock()
-
-	return l.level
+	return l.level.Level()
 }
 
 // ServiceName returns the service name.
@@ -515,7 +576,7 @@ func (l *Logger) DebugInfo() map[string]any {
 
 	info := map[string]any{
 		"handler_type":    string(l.handlerType),
-		"level":           l.level.String(),
+		"level":           l.level.Level().String(),
 		"service_name":    l.serviceName,
 		"service_version": l.serviceVersion,
 		"environment":     l.environment,
@@ -19,6 +19,7 @@ package logging
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -30,6 +31,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/baggage"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -414,6 +416,85 @@ func TestContextHandler_MultipleLevels(t *testing.T) {
 	}
 }
 
+// TestContextHandler_BaggageKeys tests that WithBaggageKeys adds the selected
+// baggage entries of the context, also to loggers derived with With.
+func TestContextHandler_BaggageKeys(t *testing.T) {
+	t.Parallel()
+
+	tenant, err := baggage.NewMember("tenant.id", "acme")
+	require.NoError(t, err)
+	secret, err := baggage.NewMember("session", "s3cr3t")
+	require.NoError(t, err)
+	bag, err := baggage.New(tenant, secret)
+	require.NoError(t, err)
+	ctx := baggage.ContextWithBaggage(context.Background(), bag)
+
+	th := NewTestHelper(t, WithBaggageKeys("tenant.id", "user.tier"))
+	th.Logger.Logger().InfoContext(ctx, "with baggage")
+	th.Logger.Logger().With("component", "orders").InfoContext(ctx, "derived logger")
+	th.Logger.Logger().InfoContext(context.Background(), "without baggage")
+
+	entries, err := th.Logs()
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+
+	for _, e := range entries[:2] {
+		assert.Equal(t, "acme", e.Attrs["baggage.tenant.id"])
+		assert.NotContains(t, e.Attrs, "baggage.session", "only selected keys are logged")
+		assert.NotContains(t, e.Attrs, "baggage.user.tier", "missing keys are skipped")
+	}
+	assert.NotContains(t, entries[2].Attrs, "baggage.tenant.id")
+}
+
+// TestNewTraceHandler tests that NewTraceHandler adds trace correlation and
+// baggage to a handler built outside the package.
+func TestNewTraceHandler(t *testing.T) {
+	t.Parallel()
+
+	tid, err := trace.TraceIDFromHex("00000000000000000000000000000003")
+	require.NoError(t, err)
+	sid, err := trace.SpanIDFromHex("0000000000000003")
+	require.NoError(t, err)
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{TraceID: tid, SpanID: sid}))
+	member, err := baggage.NewMember("tenant.id", "acme")
+	require.NoError(t, err)
+	bag, err := baggage.New(member)
+	require.NoError(t, err)
+	ctx = baggage.ContextWithBaggage(ctx, bag)
+
+	var buf bytes.Buffer
+	logger := slog.New(NewTraceHandler(slog.NewJSONHandler(&buf, nil), "tenant.id")).With("component", "orders")
+
+	logger.InfoContext(ctx, "with span")
+	logger.InfoContext(context.Background(), "without span")
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	require.Len(t, lines, 2)
+
+	var withSpan, withoutSpan map[string]any
+	require.NoError(t, json.Unmarshal(lines[0], &withSpan))
+	require.NoError(t, json.Unmarshal(lines[1], &withoutSpan))
+
+	assert.Equal(t, tid.String(), withSpan[TraceIDKey])
+	assert.Equal(t, sid.String(), withSpan[SpanIDKey])
+	assert.Equal(t, "acme", withSpan["baggage.tenant.id"])
+	assert.Equal(t, "orders", withSpan["component"])
+	assert.NotContains(t, withoutSpan, TraceIDKey)
+	assert.NotContains(t, withoutSpan, SpanIDKey)
+}
+
+// TestNewTraceHandler_AlreadyWrapped tests that wrapping a trace handler
+// again doesn't add the IDs twice.
+func TestNewTraceHandler_AlreadyWrapped(t *testing.T) {
+	t.Parallel()
+
+	handler := NewTraceHandler(slog.NewJSONHandler(io.Discard, nil))
+	assert.Same(t, handler, NewTraceHandler(handler))
+
+	th := NewTestHelper(t)
+	assert.Same(t, th.Logger.Logger().Handler(), NewTraceHandler(th.Logger.Logger().Handler()))
+}
+
 // TestWithSampling_ConfigApplied tests that WithSampling option applies sampling config.
 func TestWithSampling_ConfigApplied(t *testing.T) {
 	t.Parallel()
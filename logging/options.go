@@ -40,6 +40,53 @@ func WithConsoleHandler() Option {
 	return WithHandlerType(ConsoleHandler)
 }
 
+// WithOTLP sends logs to the OpenTelemetry collector at endpoint over OTLP
+// HTTP, such as "http://localhost:4318", so logs flow to the same collector
+// as traces and metrics. An empty endpoint means [DefaultOTLPEndpoint].
+//
+// Records logged with a context that carries a span, e.g. slog.InfoContext,
+// are correlated with the trace. The service name, version, and environment
+// are sent as resource attributes. Call [Logger.Shutdown] before exiting to
+// export pending records.
+//
+// Example:
+//
+//	logger := logging.MustNew(
+//	    logging.WithOTLP("http://otel-collector:4318"),
+//	    logging.WithServiceName("orders-api"),
+//	)
+//	defer logger.Shutdown(context.Background())
+func WithOTLP(endpoint string) Option {
+	return func(c *config) {
+		if endpoint == "" {
+			endpoint = DefaultOTLPEndpoint
+		}
+		c.handlerType = OTLPHandler
+		c.otlpEndpoint = endpoint
+	}
+}
+
+// WithProcessor adds a processor that enriches or filters every record
+// before it is written. Processors run in the order they are added; one
+// that returns false drops the record. They don't apply to a custom logger.
+//
+// Example:
+//
+//	logger := logging.MustNew(
+//	    logging.WithProcessor(func(ctx context.Context, r *logging.Record) bool {
+//	        r.AddAttrs(slog.String("region", os.Getenv("REGION")))
+//	        return true
+//	    }),
+//	    logging.WithProcessor(func(ctx context.Context, r *logging.Record) bool {
+//	        // Drop debug logs of a noisy component
+//	        component, _ := r.Attr("component")
+//	        return r.Level > logging.LevelDebug || component.String() != "cache"
+//	    }),
+//	)
+func WithProcessor(p Processor) Option {
+	return func(c *config) { c.processors = append(c.processors, p) }
+}
+
 // WithOutput sets the output writer.
 func WithOutput(w io.Writer) Option {
 	return func(c *config) { c.output = w }
@@ -50,6 +97,23 @@ func WithLevel(level Level) Option {
 	return func(c *config) { c.level = level }
 }
 
+// WithLevelSignal toggles the debug level when the process receives SIGHUP,
+// so operators can turn on debug logs with kill -HUP and turn them off
+// again without restarting. The second signal restores the level in effect
+// before the first one. It has no effect on Windows or with a custom logger.
+//
+// The app package uses SIGHUP for reloads and ignores it when no reload
+// hooks are registered; in an app, prefer [Logger.LevelHandler] instead.
+//
+// Example:
+//
+//	logger := logging.MustNew(logging.WithLevelSignal())
+//	// kill -HUP <pid>  → debug
+//	// kill -HUP <pid>  → back to info
+func WithLevelSignal() Option {
+	return func(c *config) { c.toggleSignal = true }
+}
+
 // WithDebugLevel enables debug logging.
 func WithDebugLevel() Option {
 	return WithLevel(LevelDebug)
@@ -95,6 +159,13 @@ func WithDebugMode(enabled bool) Option {
 	}
 }
 
+// WithBaggageKeys adds the given OpenTelemetry baggage entries of the context
+// to log records, as "baggage.{key}" attributes, next to trace_id and span_id.
+// Baggage is only read when logging with a context, e.g. slog.InfoContext.
+func WithBaggageKeys(keys ...string) Option {
+	return func(c *config) { c.baggageKeys = append(c.baggageKeys, keys...) }
+}
+
 // WithReplaceAttr sets a custom attribute replacer function.
 // The function receives groups and an [slog.Attr], and returns a modified attribute.
 // Return an empty [slog.Attr] to drop the attribute from output.
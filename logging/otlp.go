@@ -0,0 +1,179 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logging
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"go.opentelemetry.io/contrib/bridges/otelslog"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+)
+
+// DefaultOTLPEndpoint is the OTLP collector endpoint used by [WithOTLP] when
+// none is given.
+const DefaultOTLPEndpoint = "http://localhost:4318"
+
+// otlpScope is the instrumentation scope of the log records sent over OTLP.
+const otlpScope = "rivaas.dev/logging"
+
+// newOTLPLoggerProvider creates a logger provider that batches records and
+// exports them to the OTLP collector at endpoint over HTTP. The service
+// name, version, and environment are resource attributes, as for traces
+// and metrics, rather than attributes of every record.
+func newOTLPLoggerProvider(endpoint, serviceName, serviceVersion, environment string) (*sdklog.LoggerProvider, error) {
+	var opts []otlploghttp.Option
+
+	// Remove the scheme and any path; logs are always sent to /v1/logs
+	isHTTP := false
+	if trimmed, ok := strings.CutPrefix(endpoint, "http://"); ok {
+		endpoint = trimmed
+		isHTTP = true
+	} else if trimmed, ok := strings.CutPrefix(endpoint, "https://"); ok {
+		endpoint = trimmed
+	}
+	if idx := strings.Index(endpoint, "/"); idx != -1 {
+		endpoint = endpoint[:idx]
+	}
+
+	opts = append(opts, otlploghttp.WithEndpoint(endpoint))
+	if isHTTP {
+		opts = append(opts, otlploghttp.WithInsecure())
+	}
+
+	exporter, err := otlploghttp.New(context.Background(), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP log exporter: %w", err)
+	}
+
+	attrs := []attribute.KeyValue{}
+	if serviceName != "" {
+		attrs = append(attrs, semconv.ServiceName(serviceName))
+	}
+	if serviceVersion != "" {
+		attrs = append(attrs, semconv.ServiceVersion(serviceVersion))
+	}
+	if environment != "" {
+		attrs = append(attrs, semconv.DeploymentEnvironment(environment))
+	}
+
+	return sdklog.NewLoggerProvider(
+		sdklog.WithResource(resource.NewWithAttributes(semconv.SchemaURL, attrs...)),
+		sdklog.WithProcessor(sdklog.NewBatchProcessor(exporter)),
+	), nil
+}
+
+// otlpHandler applies the level and attribute replacement of the Logger to
+// records before handing them to the OpenTelemetry slog bridge, which
+// doesn't support [slog.HandlerOptions]. The bridge takes the trace and span
+// IDs from the context, so records are correlated with traces natively.
+//
+// Thread-safe: Safe for concurrent use by multiple goroutines.
+type otlpHandler struct {
+	underlying  slog.Handler
+	level       slog.Leveler
+	replaceAttr func(groups []string, a slog.Attr) slog.Attr
+	groups      []string
+}
+
+// newOTLPHandler returns an otlpHandler that emits records to provider.
+func newOTLPHandler(provider *sdklog.LoggerProvider, opts *slog.HandlerOptions) *otlpHandler {
+	return &otlpHandler{
+		underlying:  otelslog.NewHandler(otlpScope, otelslog.WithLoggerProvider(provider)),
+		level:       opts.Level,
+		replaceAttr: opts.ReplaceAttr,
+	}
+}
+
+// Enabled reports whether level is at least the minimum level.
+func (h *otlpHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level.Level()
+}
+
+// Handle replaces the attributes of r and emits it.
+func (h *otlpHandler) Handle(ctx context.Context, r slog.Record) error {
+	if h.replaceAttr == nil {
+		return h.underlying.Handle(ctx, r)
+	}
+
+	replaced := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		if a = h.replace(h.groups, a); a.Key != "" {
+			replaced.AddAttrs(a)
+		}
+		return true
+	})
+
+	return h.underlying.Handle(ctx, replaced)
+}
+
+// WithAttrs returns a new otlpHandler with the replaced attributes added.
+func (h *otlpHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if h.replaceAttr != nil {
+		replaced := make([]slog.Attr, 0, len(attrs))
+		for _, a := range attrs {
+			if a = h.replace(h.groups, a); a.Key != "" {
+				replaced = append(replaced, a)
+			}
+		}
+		attrs = replaced
+	}
+
+	clone := *h
+	clone.underlying = h.underlying.WithAttrs(attrs)
+
+	return &clone
+}
+
+// WithGroup returns a new otlpHandler with the group name opened.
+func (h *otlpHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	clone := *h
+	clone.underlying = h.underlying.WithGroup(name)
+	clone.groups = append(slices.Clip(h.groups), name)
+
+	return &clone
+}
+
+// replace calls replaceAttr on a, or on each attribute of a group, with the
+// enclosing groups, as [slog.HandlerOptions.ReplaceAttr] does.
+func (h *otlpHandler) replace(groups []string, a slog.Attr) slog.Attr {
+	a.Value = a.Value.Resolve()
+	if a.Value.Kind() != slog.KindGroup {
+		return h.replaceAttr(groups, a)
+	}
+
+	members := a.Value.Group()
+	replaced := make([]slog.Attr, 0, len(members))
+	inner := append(slices.Clip(groups), a.Key)
+	for _, member := range members {
+		if member = h.replace(inner, member); member.Key != "" {
+			replaced = append(replaced, member)
+		}
+	}
+
+	return slog.Attr{Key: a.Key, Value: slog.GroupValue(replaced...)}
+}
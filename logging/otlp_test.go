@@ -0,0 +1,192 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/proto"
+
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+)
+
+// logCollector is an OTLP HTTP collector that keeps the exported logs.
+type logCollector struct {
+	mu       sync.Mutex
+	requests []*collogspb.ExportLogsServiceRequest
+}
+
+// newLogCollector starts a logCollector and returns it with its URL.
+func newLogCollector(t *testing.T) (*logCollector, string) {
+	t.Helper()
+
+	c := &logCollector{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/logs" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		req := &collogspb.ExportLogsServiceRequest{}
+		if err = proto.Unmarshal(body, req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		c.mu.Lock()
+		c.requests = append(c.requests, req)
+		c.mu.Unlock()
+		w.Header().Set("Content-Type", "application/x-protobuf")
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	return c, server.URL
+}
+
+// records returns the exported log records, and the resource attributes of
+// the last export.
+func (c *logCollector) records() ([]*logspb.LogRecord, map[string]string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var records []*logspb.LogRecord
+	resource := map[string]string{}
+	for _, req := range c.requests {
+		for _, rl := range req.GetResourceLogs() {
+			for _, kv := range rl.GetResource().GetAttributes() {
+				resource[kv.GetKey()] = kv.GetValue().GetStringValue()
+			}
+			for _, sl := range rl.GetScopeLogs() {
+				records = append(records, sl.GetLogRecords()...)
+			}
+		}
+	}
+
+	return records, resource
+}
+
+// recordAttr returns the attribute key of record.
+func recordAttr(record *logspb.LogRecord, key string) *commonpb.AnyValue {
+	for _, kv := range record.GetAttributes() {
+		if kv.GetKey() == key {
+			return kv.GetValue()
+		}
+	}
+
+	return nil
+}
+
+func TestWithOTLP_ExportsRecords(t *testing.T) {
+	t.Parallel()
+
+	collector, url := newLogCollector(t)
+	logger := MustNew(
+		WithOTLP(url),
+		WithServiceName("orders-api"),
+		WithServiceVersion("v1.2.3"),
+		WithEnvironment("staging"),
+		WithLevel(LevelInfo),
+	)
+
+	traceID, err := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	require.NoError(t, err)
+	spanID, err := trace.SpanIDFromHex("00f067aa0ba902b7")
+	require.NoError(t, err)
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	}))
+
+	logger.Logger().DebugContext(ctx, "filtered")
+	logger.Logger().InfoContext(ctx, "order placed", "order_id", "o-1", "password", "hunter2")
+	require.NoError(t, logger.Shutdown(context.Background()))
+
+	records, resource := collector.records()
+	require.Len(t, records, 1)
+	record := records[0]
+
+	assert.Equal(t, "order placed", record.GetBody().GetStringValue())
+	assert.Equal(t, logspb.SeverityNumber_SEVERITY_NUMBER_INFO, record.GetSeverityNumber())
+	assert.Equal(t, traceID[:], record.GetTraceId())
+	assert.Equal(t, spanID[:], record.GetSpanId())
+	assert.Equal(t, "o-1", recordAttr(record, "order_id").GetStringValue())
+	assert.Equal(t, "***REDACTED***", recordAttr(record, "password").GetStringValue())
+	assert.Nil(t, recordAttr(record, "trace_id"), "trace context is sent natively")
+	assert.Nil(t, recordAttr(record, "service"), "service is a resource attribute")
+
+	assert.Equal(t, "orders-api", resource["service.name"])
+	assert.Equal(t, "v1.2.3", resource["service.version"])
+	assert.Equal(t, "staging", resource["deployment.environment"])
+}
+
+func TestWithOTLP_GroupsAndSetLevel(t *testing.T) {
+	t.Parallel()
+
+	collector, url := newLogCollector(t)
+	logger := MustNew(WithOTLP(url), WithLevel(LevelWarn))
+
+	logger.Logger().Info("filtered")
+	require.NoError(t, logger.SetLevel(LevelDebug))
+	logger.Logger().
+		With("token", "abc").
+		WithGroup("user").
+		Debug("login", slog.String("id", "u-1"), slog.Group("auth", slog.String("secret", "s")))
+	require.NoError(t, logger.Shutdown(context.Background()))
+
+	records, _ := collector.records()
+	require.Len(t, records, 1, "provider survives SetLevel")
+	record := records[0]
+
+	assert.Equal(t, "login", record.GetBody().GetStringValue())
+	assert.Equal(t, "***REDACTED***", recordAttr(record, "token").GetStringValue())
+
+	user := recordAttr(record, "user").GetKvlistValue()
+	require.NotNil(t, user)
+	values := map[string]*commonpb.AnyValue{}
+	for _, kv := range user.GetValues() {
+		values[kv.GetKey()] = kv.GetValue()
+	}
+	assert.Equal(t, "u-1", values["id"].GetStringValue())
+	require.NotNil(t, values["auth"])
+	assert.Equal(t, "***REDACTED***", values["auth"].GetKvlistValue().GetValues()[0].GetValue().GetStringValue())
+}
+
+func TestWithOTLP_DefaultEndpoint(t *testing.T) {
+	t.Parallel()
+
+	cfg := defaultConfig()
+	WithOTLP("")(cfg)
+
+	assert.Equal(t, OTLPHandler, cfg.handlerType)
+	assert.Equal(t, DefaultOTLPEndpoint, cfg.otlpEndpoint)
+}
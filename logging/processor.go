@@ -0,0 +1,158 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"strings"
+)
+
+// Processor enriches or filters a log record before it reaches the handler.
+// It may add attributes to r, or replace them with [Record.ReplaceAttrs],
+// and returns false to drop the record. Processors run after trace
+// correlation fields are added and before redaction and formatting.
+type Processor func(ctx context.Context, r *Record) bool
+
+// Record is a log record passed to a [Processor].
+// Its attributes don't include the logger attributes added with With;
+// use [Record.Attr] to look up either kind.
+type Record struct {
+	slog.Record
+
+	loggerAttrs []slog.Attr
+}
+
+// Attr returns the value of the attribute key of the record or, failing
+// that, of the logger, and whether it was found. Attributes in groups are
+// looked up by their dotted path, such as "http.method". Logger attributes
+// added after WithGroup include the group in their path; record attributes
+// don't.
+func (r *Record) Attr(key string) (slog.Value, bool) {
+	var (
+		value slog.Value
+		found bool
+	)
+	r.Attrs(func(a slog.Attr) bool {
+		value, found = lookupAttr(a, key)
+		return !found
+	})
+	if found {
+		return value, true
+	}
+
+	for _, a := range r.loggerAttrs {
+		if value, found = lookupAttr(a, key); found {
+			return value, true
+		}
+	}
+
+	return slog.Value{}, false
+}
+
+// ReplaceAttrs calls fn on each attribute of the record and keeps the
+// returned attribute, or drops it if its key is empty. Logger attributes
+// are left unchanged.
+func (r *Record) ReplaceAttrs(fn func(slog.Attr) slog.Attr) {
+	replaced := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		if a = fn(a); a.Key != "" {
+			replaced.AddAttrs(a)
+		}
+		return true
+	})
+	r.Record = replaced
+}
+
+// lookupAttr returns the value at the dotted path key within a.
+func lookupAttr(a slog.Attr, key string) (slog.Value, bool) {
+	if a.Key == key {
+		return a.Value.Resolve(), true
+	}
+	rest, ok := strings.CutPrefix(key, a.Key+".")
+	if !ok || a.Value.Kind() != slog.KindGroup {
+		return slog.Value{}, false
+	}
+	for _, member := range a.Value.Group() {
+		if value, found := lookupAttr(member, rest); found {
+			return value, true
+		}
+	}
+
+	return slog.Value{}, false
+}
+
+// processorHandler runs the processors on each record before delegating to
+// the underlying handler. It keeps the attributes added with WithAttrs, with
+// their group path, so processors can see them.
+//
+// Thread-safe: Safe for concurrent use by multiple goroutines.
+type processorHandler struct {
+	underlying  slog.Handler
+	processors  []Processor
+	loggerAttrs []slog.Attr
+	groups      []string
+}
+
+// Enabled delegates to the underlying handler.
+func (h *processorHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.underlying.Enabled(ctx, level)
+}
+
+// Handle runs the processors and delegates the record unless one drops it.
+func (h *processorHandler) Handle(ctx context.Context, r slog.Record) error {
+	record := &Record{Record: r, loggerAttrs: h.loggerAttrs}
+	for _, process := range h.processors {
+		if !process(ctx, record) {
+			return nil
+		}
+	}
+
+	return h.underlying.Handle(ctx, record.Record)
+}
+
+// WithAttrs returns a new processorHandler that also keeps attrs.
+func (h *processorHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	kept := slices.Clip(h.loggerAttrs)
+	for _, a := range attrs {
+		// Nest in the open groups, so lookups use the full path
+		for _, group := range slices.Backward(h.groups) {
+			a = slog.Attr{Key: group, Value: slog.GroupValue(a)}
+		}
+		kept = append(kept, a)
+	}
+
+	return &processorHandler{
+		underlying:  h.underlying.WithAttrs(attrs),
+		processors:  h.processors,
+		loggerAttrs: kept,
+		groups:      h.groups,
+	}
+}
+
+// WithGroup returns a new processorHandler with the group name opened.
+func (h *processorHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	return &processorHandler{
+		underlying:  h.underlying.WithGroup(name),
+		processors:  h.processors,
+		loggerAttrs: h.loggerAttrs,
+		groups:      append(slices.Clip(h.groups), name),
+	}
+}
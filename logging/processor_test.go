@@ -0,0 +1,133 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestWithProcessor_EnrichesRecords(t *testing.T) {
+	t.Parallel()
+
+	th := NewTestHelper(t,
+		WithProcessor(func(_ context.Context, r *Record) bool {
+			r.AddAttrs(slog.String("region", "eu-west-1"))
+			return true
+		}),
+		WithProcessor(func(_ context.Context, r *Record) bool {
+			region, ok := r.Attr("region")
+			require.True(t, ok, "processors run in order")
+			r.AddAttrs(slog.String("pod", "api-"+region.String()))
+			return true
+		}),
+	)
+
+	th.Logger.Info("started")
+
+	th.AssertLog(t, "INFO", "started", map[string]any{
+		"region": "eu-west-1",
+		"pod":    "api-eu-west-1",
+	})
+}
+
+func TestWithProcessor_DropsRecords(t *testing.T) {
+	t.Parallel()
+
+	th := NewTestHelper(t, WithProcessor(func(_ context.Context, r *Record) bool {
+		component, _ := r.Attr("component")
+		return r.Level > LevelDebug || component.String() != "cache"
+	}))
+
+	cache := th.Logger.Logger().With("component", "cache")
+	cache.Debug("cache hit")
+	cache.Info("cache resized")
+	th.Logger.Debug("other debug")
+
+	assert.False(t, th.ContainsLog("cache hit"), "noisy debug record is dropped")
+	assert.True(t, th.ContainsLog("cache resized"))
+	assert.True(t, th.ContainsLog("other debug"))
+}
+
+func TestWithProcessor_ReplaceAttrs(t *testing.T) {
+	t.Parallel()
+
+	th := NewTestHelper(t, WithProcessor(func(_ context.Context, r *Record) bool {
+		r.ReplaceAttrs(func(a slog.Attr) slog.Attr {
+			switch a.Key {
+			case "card_number":
+				return slog.Attr{}
+			case "email":
+				return slog.String(a.Key, "***")
+			}
+			return a
+		})
+		return true
+	}))
+
+	th.Logger.Info("payment", "card_number", "4111111111111111", "email", "a@example.com", "amount", 10)
+
+	entry, err := th.LastLog()
+	require.NoError(t, err)
+	assert.NotContains(t, entry.Attrs, "card_number")
+	assert.Equal(t, "***", entry.Attrs["email"])
+	assert.InDelta(t, 10, entry.Attrs["amount"], 0)
+}
+
+func TestWithProcessor_SeesTraceAndGroupedAttrs(t *testing.T) {
+	t.Parallel()
+
+	tid, err := trace.TraceIDFromHex("00000000000000000000000000000004")
+	require.NoError(t, err)
+	sid, err := trace.SpanIDFromHex("0000000000000004")
+	require.NoError(t, err)
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{TraceID: tid, SpanID: sid}))
+
+	var traceID, method, user string
+	th := NewTestHelper(t, WithProcessor(func(_ context.Context, r *Record) bool {
+		if v, ok := r.Attr(TraceIDKey); ok {
+			traceID = v.String()
+		}
+		if v, ok := r.Attr("http.method"); ok {
+			method = v.String()
+		}
+		if v, ok := r.Attr("user.id"); ok {
+			user = v.String()
+		}
+		return true
+	}))
+
+	th.Logger.Logger().
+		WithGroup("http").With("method", "GET").
+		InfoContext(ctx, "request", slog.Group("user", slog.String("id", "u-1")))
+
+	assert.Equal(t, tid.String(), traceID)
+	assert.Equal(t, "GET", method)
+	assert.Equal(t, "u-1", user)
+}
+
+func TestWithProcessor_Nil(t *testing.T) {
+	t.Parallel()
+
+	_, err := New(WithProcessor(nil))
+	require.Error(t, err)
+}
@@ -0,0 +1,174 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"log/slog"
+	"sync"
+	"unicode/utf8"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// CardinalityAction is what a [Recorder] does with a measurement that would
+// create a new time series once a metric has reached its series limit.
+type CardinalityAction int
+
+const (
+	// CardinalityWarn records the measurement and logs a warning, once per metric.
+	CardinalityWarn CardinalityAction = iota
+	// CardinalityDrop drops the measurement and logs a warning, once per metric.
+	// Measurements for time series that already exist are still recorded.
+	CardinalityDrop
+)
+
+// String returns the name of the action.
+func (a CardinalityAction) String() string {
+	switch a {
+	case CardinalityWarn:
+		return "warn"
+	case CardinalityDrop:
+		return "drop"
+	default:
+		return "unknown"
+	}
+}
+
+// labelLimiter applies the label allowlists, value truncation, and series
+// limits of a Recorder to the attributes of each measurement.
+// It is nil when no label controls are configured.
+type labelLimiter struct {
+	allowlists     map[string]map[attribute.Key]bool // Metric name -> allowed keys
+	maxValueLength int                               // 0 means no truncation
+	maxSeries      int                               // 0 means no limit
+	action         CardinalityAction
+	logger         *slog.Logger
+
+	mu     sync.Mutex
+	series map[string]map[attribute.Distinct]struct{} // Metric name -> seen attribute sets
+	warned map[string]bool
+}
+
+// newLabelLimiter returns a labelLimiter for cfg, or nil if cfg has no
+// label controls.
+func newLabelLimiter(cfg *config, logger *slog.Logger) *labelLimiter {
+	if len(cfg.labelAllowlists) == 0 && cfg.maxLabelValueLength == 0 && cfg.maxSeries == 0 {
+		return nil
+	}
+
+	allowlists := make(map[string]map[attribute.Key]bool, len(cfg.labelAllowlists))
+	for name, keys := range cfg.labelAllowlists {
+		allowed := make(map[attribute.Key]bool, len(keys))
+		for _, key := range keys {
+			allowed[attribute.Key(key)] = true
+		}
+		allowlists[name] = allowed
+	}
+
+	return &labelLimiter{
+		allowlists:     allowlists,
+		maxValueLength: cfg.maxLabelValueLength,
+		maxSeries:      cfg.maxSeries,
+		action:         cfg.cardinalityAction,
+		logger:         logger,
+		series:         make(map[string]map[attribute.Distinct]struct{}),
+		warned:         make(map[string]bool),
+	}
+}
+
+// apply returns the attributes to record for a measurement of the metric
+// name, and false if the measurement must be dropped.
+// A nil labelLimiter returns attrs unchanged.
+func (l *labelLimiter) apply(name string, attrs []attribute.KeyValue) ([]attribute.KeyValue, bool) {
+	if l == nil {
+		return attrs, true
+	}
+
+	if allowed, ok := l.allowlists[name]; ok {
+		filtered := make([]attribute.KeyValue, 0, len(attrs))
+		for _, kv := range attrs {
+			if allowed[kv.Key] {
+				filtered = append(filtered, kv)
+			}
+		}
+		attrs = filtered
+	}
+
+	if l.maxValueLength > 0 {
+		attrs = l.truncate(attrs)
+	}
+
+	if l.maxSeries > 0 && !l.admit(name, attrs) {
+		return nil, false
+	}
+
+	return attrs, true
+}
+
+// truncate shortens string values longer than maxValueLength runes.
+// attrs is copied before the first change, so the caller's slice is left as is.
+func (l *labelLimiter) truncate(attrs []attribute.KeyValue) []attribute.KeyValue {
+	copied := false
+	for i, kv := range attrs {
+		if kv.Value.Type() != attribute.STRING {
+			continue
+		}
+		value := kv.Value.AsString()
+		if utf8.RuneCountInString(value) <= l.maxValueLength {
+			continue
+		}
+		if !copied {
+			attrs = append([]attribute.KeyValue(nil), attrs...)
+			copied = true
+		}
+		attrs[i] = kv.Key.String(string([]rune(value)[:l.maxValueLength]))
+	}
+
+	return attrs
+}
+
+// admit reports whether the attribute set may be recorded for the metric
+// name, tracking the distinct sets seen so far.
+func (l *labelLimiter) admit(name string, attrs []attribute.KeyValue) bool {
+	set := attribute.NewSet(attrs...)
+	key := set.Equivalent()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	seen, ok := l.series[name]
+	if !ok {
+		seen = make(map[attribute.Distinct]struct{})
+		l.series[name] = seen
+	}
+	if _, exists := seen[key]; exists {
+		return true
+	}
+	if len(seen) < l.maxSeries {
+		seen[key] = struct{}{}
+		return true
+	}
+
+	if !l.warned[name] {
+		l.warned[name] = true
+		l.logger.Warn("metric exceeded its time series limit; check for labels with unbounded values such as IDs",
+			"metric", name,
+			"limit", l.maxSeries,
+			"action", l.action.String(),
+			"attributes", set.Encoded(attribute.DefaultEncoder()))
+	}
+
+	return l.action == CardinalityWarn
+}
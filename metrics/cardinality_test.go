@@ -0,0 +1,203 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package metrics
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// seriesLines returns the sample lines of the metric name in a Prometheus
+// text exposition.
+func seriesLines(body, name string) []string {
+	var lines []string
+	for _, line := range strings.Split(body, "\n") {
+		if strings.HasPrefix(line, name+"{") || strings.HasPrefix(line, name+" ") {
+			lines = append(lines, line)
+		}
+	}
+
+	return lines
+}
+
+// logBuffer is a bytes.Buffer that is safe for concurrent use, since the
+// metrics server may log while the test reads it.
+type logBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *logBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.Write(p)
+}
+
+func (b *logBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.String()
+}
+
+// serveRawPaths sends a request for each path through the standalone
+// middleware, which records the raw path as the route.
+func serveRawPaths(t *testing.T, recorder *Recorder, paths ...string) {
+	t.Helper()
+
+	handler := Middleware(recorder)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	for _, path := range paths {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+}
+
+func TestWithLabelAllowlist(t *testing.T) {
+	t.Parallel()
+
+	recorder := TestingRecorderWithPrometheus(t, "allowlist-test",
+		WithLabelAllowlist(metricRequestCount, "http.method", "http.status_code"),
+	)
+	serveRawPaths(t, recorder, "/users/1", "/users/2", "/users/3")
+
+	body := scrape(t, recorder)
+	counts := seriesLines(body, "http_requests_total")
+	require.Len(t, counts, 1, "raw paths should collapse into a single series")
+	assert.Contains(t, counts[0], `http_method="GET"`)
+	assert.NotContains(t, counts[0], "http_route")
+	assert.Contains(t, counts[0], "} 3")
+
+	assert.Len(t, seriesLines(body, "http_request_duration_seconds_count"), 3,
+		"metrics without an allowlist should keep all labels")
+}
+
+func TestWithLabelAllowlist_CustomMetric(t *testing.T) {
+	t.Parallel()
+
+	recorder := TestingRecorderWithPrometheus(t, "allowlist-custom-test",
+		WithLabelAllowlist("orders", "status"),
+	)
+	ctx := t.Context()
+
+	require.NoError(t, recorder.IncrementCounter(ctx, "orders",
+		attribute.String("status", "paid"), attribute.String("user_id", "42")))
+
+	counts := seriesLines(scrape(t, recorder), "orders_total")
+	require.Len(t, counts, 1)
+	assert.Contains(t, counts[0], `status="paid"`)
+	assert.NotContains(t, counts[0], "user_id")
+}
+
+func TestWithMaxLabelValueLength(t *testing.T) {
+	t.Parallel()
+
+	recorder := TestingRecorderWithPrometheus(t, "truncate-test", WithMaxLabelValueLength(5))
+	ctx := t.Context()
+
+	attrs := []attribute.KeyValue{attribute.String("query", "abcdefghij"), attribute.Int("shard", 123456789)}
+	require.NoError(t, recorder.IncrementCounter(ctx, "searches", attrs...))
+
+	counts := seriesLines(scrape(t, recorder), "searches_total")
+	require.Len(t, counts, 1)
+	assert.Contains(t, counts[0], `query="abcde"`)
+	assert.Contains(t, counts[0], `shard="123456789"`, "non-string values should not be truncated")
+	assert.Equal(t, "abcdefghij", attrs[0].Value.AsString(), "caller's attributes should not be modified")
+}
+
+func TestWithCardinalityLimit(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		action     CardinalityAction
+		wantSeries int
+	}{
+		{name: "drop", action: CardinalityDrop, wantSeries: 2},
+		{name: "warn", action: CardinalityWarn, wantSeries: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var logs logBuffer
+			recorder := TestingRecorderWithPrometheus(t, "cardinality-test",
+				WithCardinalityLimit(2, tt.action),
+				WithLogger(slog.New(slog.NewTextHandler(&logs, nil))),
+			)
+			serveRawPaths(t, recorder, "/users/1", "/users/2", "/users/3", "/users/4", "/users/1")
+
+			counts := seriesLines(scrape(t, recorder), "http_requests_total")
+			assert.Len(t, counts, tt.wantSeries)
+			assert.Equal(t, 1, strings.Count(logs.String(), "metric=http_requests_total"),
+				"the warning should be logged once per metric")
+			assert.Contains(t, logs.String(), "action="+tt.name)
+
+			if tt.action == CardinalityDrop {
+				require.NotEmpty(t, counts)
+				assert.Contains(t, counts[0], `http_route="/users/1"`)
+				assert.True(t, strings.HasSuffix(counts[0], " 2"), "existing series should still be recorded")
+			}
+		})
+	}
+}
+
+func TestLabelControls_Validation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		opt     Option
+		wantErr string
+	}{
+		{name: "negative value length", opt: WithMaxLabelValueLength(-1), wantErr: "max label value length"},
+		{name: "negative series limit", opt: WithCardinalityLimit(-1, CardinalityWarn), wantErr: "cardinality limit"},
+		{name: "unknown action", opt: WithCardinalityLimit(10, CardinalityAction(9)), wantErr: "unsupported cardinality action"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := New(WithStdout(), tt.opt)
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestLabelLimiter_NotConfigured(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, newLabelLimiter(defaultConfig(), slog.New(slog.DiscardHandler)))
+
+	var limiter *labelLimiter
+	attrs := []attribute.KeyValue{attribute.String("key", "value")}
+	got, ok := limiter.apply("any", attrs)
+	assert.True(t, ok)
+	assert.Equal(t, attrs, got)
+}
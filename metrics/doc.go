@@ -50,18 +50,60 @@
 //
 // # Providers
 //
-// Three providers are supported:
+// Four providers are supported:
 //   - [PrometheusProvider] (default): Exposes metrics via HTTP endpoint
 //   - [OTLPProvider]: Sends metrics to OTLP collector
 //   - [StdoutProvider]: Prints metrics to stdout (for development/testing)
+//   - [PushgatewayProvider]: Pushes metrics to a Prometheus Pushgateway
 //
 // Provider initialization timing:
 //   - Prometheus: Initialized in New(), server starts in Start()
 //   - OTLP: Deferred to Start() for lifecycle context
 //   - Stdout: Initialized in New(), works without Start()
+//   - Pushgateway: Initialized in New(), periodic pushes start in Start()
 //
 // For OTLP provider, you must call Start(ctx) before recording metrics.
 //
+// [WithAdditionalOTLP] exports to OTLP collectors besides the provider, such
+// as a central collector next to a local Prometheus scrape. Each collector
+// starts exporting with Start(ctx), independently of the provider:
+//
+//	recorder := metrics.MustNew(
+//	    metrics.WithPrometheus(":9090", "/metrics"),
+//	    metrics.WithAdditionalOTLP("http://otel-collector:4318"),
+//	)
+//
+// # Short-lived Jobs
+//
+// CLI commands and cron tasks may exit before they are ever scraped. Push
+// their metrics to a Pushgateway instead; Shutdown pushes the final metrics:
+//
+//	recorder := metrics.MustNew(metrics.WithPushgateway("http://pushgateway:9091", "nightly-report"))
+//	defer recorder.Shutdown(context.Background())
+//
+// With OTLP, set a short export interval for long-running jobs; Shutdown
+// exports the final metrics as well. ForceFlush pushes or exports at any
+// time, such as between the steps of a job.
+//
+// # Exemplars
+//
+// Measurements made with a context that carries a sampled span, such as the
+// request duration recorded by Finish, keep its trace ID as an exemplar, so
+// dashboards can jump from a slow histogram bucket to a matching trace. OTLP
+// exports exemplars as they are; the Prometheus handler serves them in the
+// OpenMetrics format, which Prometheus stores when started with
+// --enable-feature=exemplar-storage. Use [WithoutExemplars] to turn them off.
+//
+// # HTTP Middleware
+//
+// [Middleware] instruments any [http.Handler], so handlers that don't use
+// rivaas share the same [Recorder]. Requests are labeled with the pattern
+// matched by [http.ServeMux]; [WithRouteFunc] reads it from other routers:
+//
+//	handler := metrics.Middleware(recorder,
+//	    metrics.WithExcludePaths("/health"),
+//	)(mux)
+//
 // # Custom Metrics
 //
 // Record custom metrics using the provided methods. All methods return errors
@@ -79,11 +121,61 @@
 //	_ = recorder.SetGauge(ctx, "active_connections", 42)
 //
 // See [Recorder.RecordHistogram], [Recorder.IncrementCounter], and [Recorder.SetGauge]
-// for custom metric recording.
+// for custom metric recording. [Recorder.AddUpDownCounter] records values that
+// go up and down, such as jobs in progress.
+//
+// Values the application can look up at any time, such as pool or queue sizes,
+// are better reported by callbacks that are polled on each collection:
+//
+//	unregister, err := recorder.RegisterObservableGauge("db_pool_connections",
+//	    func(ctx context.Context, observe func(float64, ...attribute.KeyValue)) error {
+//	        observe(float64(db.Stats().InUse), attribute.String("state", "in_use"))
+//	        return nil
+//	    })
+//	defer unregister()
+//
+// See [Recorder.RegisterObservableGauge] and [Recorder.RegisterObservableCounter].
 //
 // When using the app package, record custom metrics via [app.Context]:
 // IncrementCounter, AddCounter, RecordHistogram, and SetGauge.
 //
+// # Label Cardinality
+//
+// Every distinct set of labels is a time series, so a label with unbounded
+// values, such as a raw path with IDs, can make the metrics endpoint grow
+// without bounds. Keep labels in check with allowlists, value truncation,
+// and a per-metric series limit:
+//
+//	recorder := metrics.MustNew(
+//	    metrics.WithLabelAllowlist("http_requests_total", "http.method", "http.route", "http.status_code"),
+//	    metrics.WithMaxLabelValueLength(64),
+//	    metrics.WithCardinalityLimit(1000, metrics.CardinalityDrop),
+//	)
+//
+// # SLOs
+//
+// [WithSLO] counts, for each objective, the HTTP requests it covers and those
+// that met it. [SLO.PrometheusRules] generates the matching burn-rate
+// recording rules and multi-window alerts:
+//
+//	slo := metrics.SLO{Name: "checkout", Target: 0.999, LatencyThreshold: 300 * time.Millisecond}
+//	recorder := metrics.MustNew(metrics.WithSLO(slo))
+//	fmt.Print(slo.PrometheusRules())
+//
+// # Views
+//
+// Tune what is exported without changing instrumentation code: drop noisy
+// metrics, rename metrics to the conventions of your organization, or
+// report deltas to backends that expect them. [WithView] accepts any
+// OpenTelemetry view, such as one that changes histogram buckets:
+//
+//	recorder := metrics.MustNew(
+//	    metrics.WithOTLP("http://localhost:4318"),
+//	    metrics.WithDroppedMetrics("http_request_size_bytes"),
+//	    metrics.WithRenamedMetric("http_requests_total", "api_requests_total"),
+//	    metrics.WithTemporality(metrics.DeltaTemporality),
+//	)
+//
 // # Lifecycle Management
 //
 // For proper initialization and shutdown:
@@ -0,0 +1,117 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"context"
+	"sync"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// DefaultOTLPEndpoint is the OTLP collector endpoint used when none is given.
+const DefaultOTLPEndpoint = "http://localhost:4318"
+
+// deferredExporter is a [sdkmetric.Exporter] for an additional OTLP
+// collector. The meter provider is built in New, but the OTLP exporter is
+// only created in Start with the lifecycle context, so until then exports
+// are dropped. Cumulative metrics lose nothing, since the first export
+// after Start carries the totals.
+type deferredExporter struct {
+	endpoint    string
+	temporality sdkmetric.TemporalitySelector
+
+	mu       sync.RWMutex
+	exporter sdkmetric.Exporter // nil until started
+}
+
+// Ensure deferredExporter implements the exporter interface
+var _ sdkmetric.Exporter = (*deferredExporter)(nil)
+
+// newDeferredExporter returns a deferredExporter for endpoint.
+func newDeferredExporter(endpoint string, temporality Temporality) *deferredExporter {
+	return &deferredExporter{
+		endpoint:    endpoint,
+		temporality: temporality.selector(),
+	}
+}
+
+// start sets the exporter that exports are sent to from now on.
+func (e *deferredExporter) start(exporter sdkmetric.Exporter) {
+	e.mu.Lock()
+	e.exporter = exporter
+	e.mu.Unlock()
+}
+
+// current returns the started exporter, or nil.
+func (e *deferredExporter) current() sdkmetric.Exporter {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return e.exporter
+}
+
+// Temporality implements [sdkmetric.Exporter].
+func (e *deferredExporter) Temporality(kind sdkmetric.InstrumentKind) metricdata.Temporality {
+	return e.temporality(kind)
+}
+
+// Aggregation implements [sdkmetric.Exporter].
+func (e *deferredExporter) Aggregation(kind sdkmetric.InstrumentKind) sdkmetric.Aggregation {
+	return sdkmetric.DefaultAggregationSelector(kind)
+}
+
+// Export implements [sdkmetric.Exporter]. Data is dropped until started.
+func (e *deferredExporter) Export(ctx context.Context, rm *metricdata.ResourceMetrics) error {
+	if exporter := e.current(); exporter != nil {
+		return exporter.Export(ctx, rm)
+	}
+
+	return nil
+}
+
+// ForceFlush implements [sdkmetric.Exporter].
+func (e *deferredExporter) ForceFlush(ctx context.Context) error {
+	if exporter := e.current(); exporter != nil {
+		return exporter.ForceFlush(ctx)
+	}
+
+	return nil
+}
+
+// Shutdown implements [sdkmetric.Exporter].
+func (e *deferredExporter) Shutdown(ctx context.Context) error {
+	if exporter := e.current(); exporter != nil {
+		return exporter.Shutdown(ctx)
+	}
+
+	return nil
+}
+
+// startAdditionalExporters creates the exporters of the additional OTLP
+// collectors with the lifecycle context. A collector that fails doesn't
+// keep the others, or the primary provider, from working.
+func (r *Recorder) startAdditionalExporters(ctx context.Context) {
+	for _, deferred := range r.additionalOTLP {
+		exporter, err := r.newOTLPExporter(ctx, deferred.endpoint)
+		if err != nil {
+			r.logger.Error("Failed to start additional OTLP exporter", "error", err, "endpoint", deferred.endpoint)
+			continue
+		}
+		deferred.start(exporter)
+		r.logger.Debug("Additional OTLP exporter started", "endpoint", deferred.endpoint)
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newCollector returns a fake OTLP collector and the number of exports it
+// received.
+func newCollector(t *testing.T) (*httptest.Server, *atomic.Int64) {
+	t.Helper()
+
+	var exports atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/metrics" {
+			exports.Add(1)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &exports
+}
+
+func TestWithAdditionalOTLP(t *testing.T) {
+	t.Parallel()
+
+	collector, exports := newCollector(t)
+	recorder := MustNew(
+		WithPrometheus(":0", "/metrics"),
+		WithServerDisabled(),
+		WithAdditionalOTLP(collector.URL),
+	)
+	ctx := t.Context()
+	require.NoError(t, recorder.IncrementCounter(ctx, "orders"))
+
+	// Prometheus works before Start; the collector receives nothing yet
+	assert.Contains(t, scrape(t, recorder), "orders_total")
+	require.NoError(t, recorder.ForceFlush(ctx))
+	assert.Zero(t, exports.Load())
+
+	require.NoError(t, recorder.Start(ctx))
+	require.NoError(t, recorder.ForceFlush(ctx))
+	assert.Equal(t, int64(1), exports.Load())
+	assert.Equal(t, PrometheusProvider, recorder.Provider())
+
+	require.NoError(t, recorder.Shutdown(ctx))
+	assert.Greater(t, exports.Load(), int64(1), "Shutdown should export the final metrics")
+}
+
+func TestWithAdditionalOTLP_SeveralCollectors(t *testing.T) {
+	t.Parallel()
+
+	first, firstExports := newCollector(t)
+	second, secondExports := newCollector(t)
+	recorder := MustNew(
+		WithStdout(),
+		WithAdditionalOTLP(first.URL),
+		WithAdditionalOTLP(second.URL),
+		WithTemporality(DeltaTemporality),
+	)
+	ctx := t.Context()
+	require.NoError(t, recorder.Start(ctx))
+	require.NoError(t, recorder.IncrementCounter(ctx, "orders"))
+
+	require.NoError(t, recorder.Shutdown(ctx))
+	assert.Positive(t, firstExports.Load())
+	assert.Positive(t, secondExports.Load())
+}
+
+func TestWithAdditionalOTLP_Validation(t *testing.T) {
+	t.Parallel()
+
+	_, err := New(
+		WithMeterProvider(newDefaultRecorder().meterProvider),
+		WithAdditionalOTLP(""),
+	)
+	require.ErrorContains(t, err, "WithAdditionalOTLP cannot be used with WithMeterProvider")
+
+	recorder, err := New(
+		WithPrometheus(":0", "/metrics"),
+		WithServerDisabled(),
+		WithAdditionalOTLP(""),
+		WithTemporality(DeltaTemporality),
+	)
+	require.NoError(t, err, "delta temporality applies to the additional collector")
+	require.Len(t, recorder.additionalOTLP, 1)
+	assert.Equal(t, DefaultOTLPEndpoint, recorder.additionalOTLP[0].endpoint)
+}
@@ -27,6 +27,7 @@ import (
 	"go.opentelemetry.io/otel/metric"
 
 	promclient "github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/push"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 )
 
@@ -54,6 +55,9 @@ const (
 	OTLPProvider Provider = "otlp"
 	// StdoutProvider uses stdout exporter for metrics (development/testing).
 	StdoutProvider Provider = "stdout"
+	// PushgatewayProvider pushes metrics to a Prometheus Pushgateway, for
+	// short-lived jobs that don't live long enough to be scraped.
+	PushgatewayProvider Provider = "pushgateway"
 )
 
 // sensitiveHeaders contains header names that should never be recorded in metrics.
@@ -80,7 +84,11 @@ type Recorder struct {
 	prometheusHandler  http.Handler
 	prometheusRegistry *promclient.Registry // Custom Prometheus registry to avoid conflicts
 	metricsServer      *http.Server
-	logger             *slog.Logger // Logger for internal operational events; never nil (uses DiscardHandler when not set)
+	logger             *slog.Logger  // Logger for internal operational events; never nil (uses DiscardHandler when not set)
+	labels             *labelLimiter // Label allowlists, truncation, and series limits; nil when not configured
+	views              []sdkmetric.View
+	temporality        Temporality
+	additionalOTLP     []*deferredExporter // Exporters to OTLP collectors besides the provider
 
 	// Built-in HTTP metrics
 	requestDuration      metric.Float64Histogram
@@ -91,11 +99,18 @@ type Recorder struct {
 	errorCount           metric.Int64Counter
 	customMetricFailures metric.Int64Counter
 
+	// SLO metrics, created only when SLOs are configured
+	slos            []SLO
+	sloRequests     metric.Int64Counter
+	sloGoodRequests metric.Int64Counter
+
 	// Custom metrics storage (protected by RWMutex)
 	customMu          sync.RWMutex
 	customCounters    map[string]metric.Int64Counter
 	customHistograms  map[string]metric.Float64Histogram
 	customGauges      map[string]metric.Float64Gauge
+	customUpDowns     map[string]metric.Int64UpDownCounter
+	customObservables map[string]metric.Observable
 	customMetricCount int
 
 	// Histogram bucket configuration
@@ -115,6 +130,14 @@ type Recorder struct {
 
 	serverMutex sync.Mutex // Protects metricsServer access
 
+	// Pushgateway provider
+	pushgatewayURL string
+	pushgatewayJob string
+	pusher         *push.Pusher
+	pushMu         sync.Mutex    // Protects pushStop and pushDone
+	pushStop       chan struct{} // Closed to stop the periodic push
+	pushDone       chan struct{} // Closed when the periodic push has stopped
+
 	maxCustomMetrics int // Maximum number of custom metrics
 
 	provider            Provider
@@ -130,6 +153,7 @@ type Recorder struct {
 	registerGlobal      bool // If true, sets otel.SetMeterProvider()
 	withoutScopeInfo    bool // If true, omit otel_scope_* labels from Prometheus output
 	withoutTargetInfo   bool // If true, omit target_info metric from Prometheus output
+	withoutExemplars    bool // If true, record no exemplars
 }
 
 // New creates a new [Recorder] with the given options.
@@ -182,7 +206,7 @@ func (c *config) validate() error {
 		return fmt.Errorf("configuration errors: %v", c.validationErrors)
 	}
 	if c.providerSetCount > 1 {
-		return errors.New("conflicting provider options: only one of WithPrometheus, WithOTLP, or WithStdout can be used")
+		return errors.New("conflicting provider options: only one of WithPrometheus, WithOTLP, WithStdout, or WithPushgateway can be used")
 	}
 	if c.serviceName == "" {
 		return errors.New("service name cannot be empty")
@@ -193,6 +217,28 @@ func (c *config) validate() error {
 	if c.maxCustomMetrics < 1 {
 		return fmt.Errorf("maxCustomMetrics must be at least 1, got %d", c.maxCustomMetrics)
 	}
+	if c.maxLabelValueLength < 0 {
+		return fmt.Errorf("max label value length cannot be negative, got %d", c.maxLabelValueLength)
+	}
+	if c.maxSeries < 0 {
+		return fmt.Errorf("cardinality limit cannot be negative, got %d", c.maxSeries)
+	}
+	if c.cardinalityAction != CardinalityWarn && c.cardinalityAction != CardinalityDrop {
+		return fmt.Errorf("unsupported cardinality action: %d", c.cardinalityAction)
+	}
+	if c.temporality != CumulativeTemporality && c.temporality != DeltaTemporality {
+		return fmt.Errorf("unsupported temporality: %d", c.temporality)
+	}
+	if c.temporality == DeltaTemporality && len(c.additionalOTLPEndpoints) == 0 &&
+		(c.provider == PrometheusProvider || c.provider == PushgatewayProvider) {
+		return fmt.Errorf("delta temporality is not supported by the %s provider", c.provider)
+	}
+	if err := validateSLOs(c.slos); err != nil {
+		return err
+	}
+	if len(c.additionalOTLPEndpoints) > 0 && c.customMeterProvider {
+		return errors.New("WithAdditionalOTLP cannot be used with WithMeterProvider: add the exporter to your meter provider instead")
+	}
 	switch c.provider {
 	case PrometheusProvider:
 		if c.metricsPort == "" {
@@ -203,9 +249,19 @@ func (c *config) validate() error {
 		}
 	case OTLPProvider:
 		if c.otlpEndpoint == "" {
-			c.otlpEndpoint = "http://localhost:4318"
+			c.otlpEndpoint = DefaultOTLPEndpoint
 		}
 	case StdoutProvider:
+	case PushgatewayProvider:
+		if c.pushgatewayURL == "" {
+			return errors.New("pushgateway URL cannot be empty")
+		}
+		if c.pushgatewayJob == "" {
+			return errors.New("pushgateway job cannot be empty")
+		}
+		if c.exportInterval <= 0 {
+			return fmt.Errorf("export interval must be positive for Pushgateway provider, got %s", c.exportInterval)
+		}
 	default:
 		return fmt.Errorf("unsupported metrics provider: %s", c.provider)
 	}
@@ -229,19 +285,31 @@ func newRecorderFromConfig(cfg *config) (*Recorder, error) {
 		strictPort:          cfg.strictPort,
 		maxCustomMetrics:    cfg.maxCustomMetrics,
 		logger:              logger,
+		labels:              newLabelLimiter(cfg, logger),
+		views:               cfg.views,
+		slos:                cfg.slos,
+		temporality:         cfg.temporality,
 		registerGlobal:      cfg.registerGlobal,
 		withoutScopeInfo:    cfg.withoutScopeInfo,
 		withoutTargetInfo:   cfg.withoutTargetInfo,
+		withoutExemplars:    cfg.withoutExemplars,
 		provider:            cfg.provider,
 		providerSetCount:    cfg.providerSetCount,
 		metricsPort:         cfg.metricsPort,
 		metricsPath:         cfg.metricsPath,
 		otlpEndpoint:        cfg.otlpEndpoint,
+		pushgatewayURL:      cfg.pushgatewayURL,
+		pushgatewayJob:      cfg.pushgatewayJob,
 		customMeterProvider: cfg.customMeterProvider,
 		enabled:             true,
 		customCounters:      make(map[string]metric.Int64Counter),
 		customHistograms:    make(map[string]metric.Float64Histogram),
 		customGauges:        make(map[string]metric.Float64Gauge),
+		customUpDowns:       make(map[string]metric.Int64UpDownCounter),
+		customObservables:   make(map[string]metric.Observable),
+	}
+	for _, endpoint := range cfg.additionalOTLPEndpoints {
+		r.additionalOTLP = append(r.additionalOTLP, newDeferredExporter(endpoint, cfg.temporality))
 	}
 	if r.exportInterval > 0 && r.exportInterval < time.Second {
 		r.logger.Warn("Export interval is very low, may cause high CPU usage", "interval", r.exportInterval)
@@ -256,22 +324,24 @@ func newRecorderFromConfig(cfg *config) (*Recorder, error) {
 // It is used by tests that need a Recorder without going through the config path.
 func newDefaultRecorder() *Recorder {
 	recorder := &Recorder{
-		enabled:          true,
-		serviceName:      "rivaas-service",
-		serviceVersion:   "1.0.0",
-		provider:         PrometheusProvider,
-		exportInterval:   30 * time.Second,
-		metricsPort:      ":9090",
-		metricsPath:      "/metrics",
-		autoStartServer:  true,
-		maxCustomMetrics: 1000,  // Limit to prevent unbounded metric creation
-		registerGlobal:   false, // Default: no global registration
-		logger:           slog.New(slog.DiscardHandler),
-		durationBuckets:  DefaultDurationBuckets,
-		sizeBuckets:      DefaultSizeBuckets,
-		customCounters:   make(map[string]metric.Int64Counter),
-		customHistograms: make(map[string]metric.Float64Histogram),
-		customGauges:     make(map[string]metric.Float64Gauge),
+		enabled:           true,
+		serviceName:       "rivaas-service",
+		serviceVersion:    "1.0.0",
+		provider:          PrometheusProvider,
+		exportInterval:    30 * time.Second,
+		metricsPort:       ":9090",
+		metricsPath:       "/metrics",
+		autoStartServer:   true,
+		maxCustomMetrics:  1000,  // Limit to prevent unbounded metric creation
+		registerGlobal:    false, // Default: no global registration
+		logger:            slog.New(slog.DiscardHandler),
+		durationBuckets:   DefaultDurationBuckets,
+		sizeBuckets:       DefaultSizeBuckets,
+		customCounters:    make(map[string]metric.Int64Counter),
+		customHistograms:  make(map[string]metric.Float64Histogram),
+		customGauges:      make(map[string]metric.Float64Gauge),
+		customUpDowns:     make(map[string]metric.Int64UpDownCounter),
+		customObservables: make(map[string]metric.Observable),
 	}
 
 	return recorder
@@ -377,11 +447,19 @@ func (r *Recorder) Start(ctx context.Context) error {
 		r.providerDeferred.Store(false) // Initialization complete
 	}
 
+	// Start exporting to additional OTLP collectors
+	r.startAdditionalExporters(ctx)
+
 	// Start the metrics server if auto-start is enabled and using Prometheus
 	if r.autoStartServer && r.provider == PrometheusProvider {
 		r.startMetricsServer(ctx)
 	}
 
+	// Push metrics periodically for the Pushgateway
+	if r.pusher != nil {
+		r.startPushLoop(ctx)
+	}
+
 	return nil
 }
 
@@ -407,6 +485,13 @@ func (r *Recorder) Shutdown(ctx context.Context) error {
 		errs = append(errs, err)
 	}
 
+	// Push the final metrics before the meter provider is shut down
+	if r.pusher != nil {
+		if err := r.stopPushLoop(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
 	// Flush and shutdown the meter provider if it supports it and is NOT a custom provider
 	// User-provided providers should be managed by the user
 	if r.customMeterProvider {
@@ -457,7 +542,8 @@ func (r *Recorder) shutdownSDKMeterProvider(ctx context.Context) error {
 // metrics are exported without shutting down the recorder (e.g., before a deployment,
 // at checkpoints, or during long-running operations).
 // For pull-based providers (Prometheus), this is typically a no-op as metrics are
-// collected on-demand when scraped.
+// collected on-demand when scraped. With [PushgatewayProvider], it pushes the
+// current metrics to the Pushgateway.
 // Returns an error if the flush fails or if the recorder is disabled.
 func (r *Recorder) ForceFlush(ctx context.Context) error {
 	if !r.enabled {
@@ -477,6 +563,12 @@ func (r *Recorder) ForceFlush(ctx context.Context) error {
 		r.logger.Debug("Metrics force flushed successfully")
 	}
 
+	if r.pusher != nil {
+		if err := r.push(ctx); err != nil {
+			return fmt.Errorf("metrics force flush: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -42,12 +42,14 @@ type middlewareConfig struct {
 	pathFilter       *pathFilter
 	recordHeaders    []string
 	recordHeadersLow []string // Pre-lowercased for efficient lookup
+	routeFunc        func(*http.Request) string
 }
 
 // newMiddlewareConfig creates a default middleware configuration.
 func newMiddlewareConfig() *middlewareConfig {
 	return &middlewareConfig{
 		pathFilter: newPathFilter(),
+		routeFunc:  routePattern,
 	}
 }
 
@@ -138,13 +140,57 @@ func WithHeaders(headers ...string) MiddlewareOption {
 	}
 }
 
+// WithRouteFunc sets the function that returns the route label of a request,
+// such as "/users/{id}", to keep raw IDs out of the metric labels. It is
+// called after the handler has run, so it can read what the router stored on
+// the request or its context. A nil function keeps the default, which uses
+// the pattern matched by [http.ServeMux] and falls back to the raw path.
+//
+// Example:
+//
+//	handler := metrics.Middleware(recorder,
+//	    metrics.WithRouteFunc(func(r *http.Request) string {
+//	        return chi.RouteContext(r.Context()).RoutePattern()
+//	    }),
+//	)(router)
+func WithRouteFunc(fn func(r *http.Request) string) MiddlewareOption {
+	return func(c *middlewareConfig) {
+		if fn != nil {
+			c.routeFunc = fn
+		}
+	}
+}
+
+// routePattern returns the path of the pattern matched by [http.ServeMux],
+// without its method and host, or the raw path if no pattern matched.
+func routePattern(r *http.Request) string {
+	pattern := r.Pattern
+	if pattern == "" {
+		return r.URL.Path
+	}
+	// Patterns are "[METHOD ][HOST]/[PATH]"
+	if _, path, found := strings.Cut(pattern, " "); found {
+		pattern = strings.TrimLeft(path, " \t")
+	}
+	if i := strings.IndexByte(pattern, '/'); i > 0 {
+		pattern = pattern[i:]
+	}
+
+	return pattern
+}
+
 // Middleware creates a middleware function for standalone HTTP integration.
 // This is useful when you want to add metrics to an existing router
 // without using the app package.
 //
+// It records the same request count, duration, size, and error metrics as the
+// app package, so handlers that don't use rivaas share the [Recorder] with
+// those that do. Requests are labeled with the pattern matched by
+// [http.ServeMux], such as "/users/{id}"; use [WithRouteFunc] for other routers.
+//
 // Path filtering and header recording are configured via [MiddlewareOption].
-// Use [WithExcludePaths], [WithExcludePrefixes], [WithExcludePatterns], or [WithHeaders]
-// to customize behavior.
+// Use [WithExcludePaths], [WithExcludePrefixes], [WithExcludePatterns], [WithHeaders],
+// or [WithRouteFunc] to customize behavior.
 //
 // Example:
 //
@@ -159,6 +205,11 @@ func WithHeaders(headers ...string) MiddlewareOption {
 //	)(mux)
 //
 //	http.ListenAndServe(":8080", handler)
+//
+// Request durations carry exemplars of sampled traces when the request
+// context already has a span, so put the tracing middleware outside:
+//
+//	handler := tracing.MustMiddleware(tracer)(metrics.Middleware(recorder)(mux))
 func Middleware(recorder *Recorder, opts ...MiddlewareOption) func(http.Handler) http.Handler {
 	cfg := newMiddlewareConfig()
 	for _, opt := range opts {
@@ -178,6 +229,14 @@ func Middleware(recorder *Recorder, opts ...MiddlewareOption) func(http.Handler)
 				return
 			}
 
+			// Check if already wrapped to prevent double-wrapping
+			if _, ok := w.(observabilityWrappedWriter); ok {
+				// Already wrapped, use as-is
+				next.ServeHTTP(w, r)
+				// Can't extract metrics reliably from outer wrapper
+				return
+			}
+
 			ctx := r.Context()
 
 			// Start metrics collection
@@ -209,22 +268,14 @@ func Middleware(recorder *Recorder, opts ...MiddlewareOption) func(http.Handler)
 			}
 
 			// Wrap response writer to capture status code and size
-			// Check if already wrapped to prevent double-wrapping
-			if _, ok := w.(observabilityWrappedWriter); ok {
-				// Already wrapped, use as-is
-				next.ServeHTTP(w, r)
-				// Can't extract metrics reliably from outer wrapper
-				return
-			}
-
 			rw := newResponseWriter(w)
 
 			// Execute the next handler
 			next.ServeHTTP(rw, r)
 
 			// Finish metrics collection
-			// Use raw path as route pattern since middleware cannot determine actual route template
-			recorder.Finish(ctx, m, rw.StatusCode(), int64(rw.Size()), r.URL.Path)
+			// The route is read after the handler, once the router has matched it
+			recorder.Finish(ctx, m, rw.StatusCode(), int64(rw.Size()), cfg.routeFunc(r))
 		})
 	}
 }
@@ -245,3 +245,69 @@ func (m *mockWrappedWriter) IsObservabilityWrapped() bool {
 func (m *mockWrappedWriter) Unwrap() http.ResponseWriter {
 	return m.ResponseWriter
 }
+
+func TestMiddleware_ServeMuxRoutePattern(t *testing.T) {
+	t.Parallel()
+
+	recorder := TestingRecorderWithPrometheus(t, "route-pattern-test")
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /users/{id}", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	handler := Middleware(recorder)(mux)
+
+	for _, path := range []string{"/users/1", "/users/2", "/unknown"} {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	counts := seriesLines(scrape(t, recorder), "http_requests_total")
+	require.Len(t, counts, 2)
+	body := counts[0] + counts[1]
+	assert.Contains(t, body, `http_route="/users/{id}"`)
+	assert.NotContains(t, body, `http_route="/users/1"`)
+}
+
+func TestMiddleware_WithRouteFunc(t *testing.T) {
+	t.Parallel()
+
+	recorder := TestingRecorderWithPrometheus(t, "route-func-test")
+
+	handler := Middleware(recorder,
+		WithRouteFunc(func(*http.Request) string { return "/orders/:id" }),
+	)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	}))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/orders/42", nil))
+
+	counts := seriesLines(scrape(t, recorder), "http_requests_total")
+	require.Len(t, counts, 1)
+	assert.Contains(t, counts[0], `http_route="/orders/:id"`)
+	assert.Contains(t, counts[0], `http_status_code="201"`)
+}
+
+func TestRoutePattern(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		pattern string
+		path    string
+		want    string
+	}{
+		{pattern: "", path: "/raw/1", want: "/raw/1"},
+		{pattern: "/users/{id}", path: "/users/1", want: "/users/{id}"},
+		{pattern: "GET /users/{id}", path: "/users/1", want: "/users/{id}"},
+		{pattern: "GET example.com/files/{path...}", path: "/files/a/b", want: "/files/{path...}"},
+		{pattern: "api.example.com/", path: "/anything", want: "/"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			req.Pattern = tt.pattern
+			assert.Equal(t, tt.want, routePattern(req))
+		})
+	}
+}
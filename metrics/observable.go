@@ -0,0 +1,172 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// errNotStarted is returned when a metric is registered before the
+// provider is initialized.
+var errNotStarted = errors.New("metrics provider not initialized: call Start first")
+
+// GaugeCallback reports the current values of an observable gauge by
+// calling observe once per attribute set. It is called at collection time,
+// on every scrape or export, and must be safe for concurrent use.
+type GaugeCallback func(ctx context.Context, observe func(value float64, attributes ...attribute.KeyValue)) error
+
+// CounterCallback reports the current totals of an observable counter by
+// calling observe once per attribute set. Totals must never decrease.
+// It is called at collection time and must be safe for concurrent use.
+type CounterCallback func(ctx context.Context, observe func(value int64, attributes ...attribute.KeyValue)) error
+
+// RegisterObservableGauge registers a custom gauge whose values are read by
+// callback when metrics are collected, for values the application can
+// look up at any time, such as queue depths, pool sizes, or cache sizes.
+// Call unregister to stop reporting values, for example when the pool is
+// closed. Returns an error if the metric name is invalid, the limit of
+// custom metrics is reached, or the provider isn't started yet.
+//
+// Example:
+//
+//	unregister, err := recorder.RegisterObservableGauge("db_pool_connections",
+//	    func(ctx context.Context, observe func(float64, ...attribute.KeyValue)) error {
+//	        stats := db.Stats()
+//	        observe(float64(stats.InUse), attribute.String("state", "in_use"))
+//	        observe(float64(stats.Idle), attribute.String("state", "idle"))
+//	        return nil
+//	    })
+//	if err != nil {
+//	    return err
+//	}
+//	defer unregister()
+func (r *Recorder) RegisterObservableGauge(name string, callback GaugeCallback) (unregister func() error, err error) {
+	if !r.enabled {
+		return func() error { return nil }, nil
+	}
+	if callback == nil {
+		return nil, fmt.Errorf("register observable gauge %q: callback cannot be nil", name)
+	}
+
+	instrument, err := r.getOrCreateObservable(name, func() (metric.Observable, error) {
+		return r.meter.Float64ObservableGauge(name, metric.WithDescription("Custom observable gauge metric"))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("register observable gauge %q: %w", name, err)
+	}
+	gauge, ok := instrument.(metric.Float64ObservableGauge)
+	if !ok {
+		return nil, fmt.Errorf("register observable gauge %q: name already used by an observable counter", name)
+	}
+
+	registration, err := r.meter.RegisterCallback(func(ctx context.Context, o metric.Observer) error {
+		return callback(ctx, func(value float64, attributes ...attribute.KeyValue) {
+			if attrs, ok := r.labels.apply(name, attributes); ok {
+				o.ObserveFloat64(gauge, value, metric.WithAttributes(attrs...))
+			}
+		})
+	}, gauge)
+	if err != nil {
+		return nil, fmt.Errorf("register observable gauge %q: %w", name, err)
+	}
+
+	return registration.Unregister, nil
+}
+
+// RegisterObservableCounter registers a custom counter whose totals are read
+// by callback when metrics are collected, for totals the application
+// already keeps, such as the hits of a cache library. Call unregister to
+// stop reporting totals. Returns an error if the metric name is invalid, the
+// limit of custom metrics is reached, or the provider isn't started yet.
+//
+// Example:
+//
+//	unregister, err := recorder.RegisterObservableCounter("cache_hits",
+//	    func(ctx context.Context, observe func(int64, ...attribute.KeyValue)) error {
+//	        observe(int64(cache.Stats().Hits))
+//	        return nil
+//	    })
+func (r *Recorder) RegisterObservableCounter(name string, callback CounterCallback) (unregister func() error, err error) {
+	if !r.enabled {
+		return func() error { return nil }, nil
+	}
+	if callback == nil {
+		return nil, fmt.Errorf("register observable counter %q: callback cannot be nil", name)
+	}
+
+	instrument, err := r.getOrCreateObservable(name, func() (metric.Observable, error) {
+		return r.meter.Int64ObservableCounter(name, metric.WithDescription("Custom observable counter metric"))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("register observable counter %q: %w", name, err)
+	}
+	counter, ok := instrument.(metric.Int64ObservableCounter)
+	if !ok {
+		return nil, fmt.Errorf("register observable counter %q: name already used by an observable gauge", name)
+	}
+
+	registration, err := r.meter.RegisterCallback(func(ctx context.Context, o metric.Observer) error {
+		return callback(ctx, func(value int64, attributes ...attribute.KeyValue) {
+			if attrs, ok := r.labels.apply(name, attributes); ok {
+				o.ObserveInt64(counter, value, metric.WithAttributes(attrs...))
+			}
+		})
+	}, counter)
+	if err != nil {
+		return nil, fmt.Errorf("register observable counter %q: %w", name, err)
+	}
+
+	return registration.Unregister, nil
+}
+
+// getOrCreateObservable gets or creates a custom observable instrument, so
+// that a name can be registered again after unregistering.
+// This method is safe for concurrent use.
+func (r *Recorder) getOrCreateObservable(name string, create func() (metric.Observable, error)) (metric.Observable, error) {
+	if err := validateMetricName(name); err != nil {
+		return nil, err
+	}
+
+	r.customMu.Lock()
+	defer r.customMu.Unlock()
+
+	if instrument, exists := r.customObservables[name]; exists {
+		return instrument, nil
+	}
+	if r.meter == nil {
+		return nil, errNotStarted
+	}
+	if r.customMetricCount >= r.maxCustomMetrics {
+		return nil, &limitError{
+			metricName: name,
+			limit:      r.maxCustomMetrics,
+			current:    r.customMetricCount,
+		}
+	}
+
+	instrument, err := create()
+	if err != nil {
+		return nil, err
+	}
+	r.customObservables[name] = instrument
+	r.customMetricCount++
+
+	return instrument, nil
+}
@@ -0,0 +1,148 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package metrics
+
+import (
+	"context"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// scrape returns the Prometheus text exposition of recorder.
+func scrape(t *testing.T, recorder *Recorder) string {
+	t.Helper()
+
+	handler, err := recorder.Handler()
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+
+	return w.Body.String()
+}
+
+func TestAddUpDownCounter(t *testing.T) {
+	t.Parallel()
+
+	recorder := TestingRecorderWithPrometheus(t, "updown-test")
+	ctx := t.Context()
+
+	queue := attribute.String("queue", "emails")
+	require.NoError(t, recorder.AddUpDownCounter(ctx, "jobs_in_progress", 3, queue))
+	require.NoError(t, recorder.AddUpDownCounter(ctx, "jobs_in_progress", -2, queue))
+
+	body := scrape(t, recorder)
+	assert.Contains(t, body, "# TYPE jobs_in_progress gauge")
+	assert.Contains(t, body, `queue="emails"} 1`)
+}
+
+func TestAddUpDownCounter_InvalidName(t *testing.T) {
+	t.Parallel()
+
+	recorder := TestingRecorderWithPrometheus(t, "updown-invalid-test")
+
+	err := recorder.AddUpDownCounter(t.Context(), "__reserved", 1)
+	require.Error(t, err)
+	assert.Equal(t, int64(1), recorder.getAtomicCustomMetricFailures())
+}
+
+func TestRegisterObservableGauge(t *testing.T) {
+	t.Parallel()
+
+	recorder := TestingRecorderWithPrometheus(t, "observable-gauge-test")
+
+	var depth atomic.Int64
+	depth.Store(7)
+	unregister, err := recorder.RegisterObservableGauge("queue_depth",
+		func(_ context.Context, observe func(float64, ...attribute.KeyValue)) error {
+			observe(float64(depth.Load()), attribute.String("queue", "emails"))
+			return nil
+		})
+	require.NoError(t, err)
+
+	assert.Contains(t, scrape(t, recorder), `queue="emails"} 7`)
+
+	depth.Store(4)
+	assert.Contains(t, scrape(t, recorder), `queue="emails"} 4`, "callback should be polled on every collection")
+
+	require.NoError(t, unregister())
+	assert.NotContains(t, scrape(t, recorder), `queue_depth{`)
+}
+
+func TestRegisterObservableCounter(t *testing.T) {
+	t.Parallel()
+
+	recorder := TestingRecorderWithPrometheus(t, "observable-counter-test")
+
+	unregister, err := recorder.RegisterObservableCounter("cache_hits",
+		func(_ context.Context, observe func(int64, ...attribute.KeyValue)) error {
+			observe(42)
+			return nil
+		})
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, unregister()) }()
+
+	body := scrape(t, recorder)
+	assert.Contains(t, body, "# TYPE cache_hits_total counter")
+	assert.Contains(t, body, `otel_scope_version=""} 42`)
+}
+
+func TestRegisterObservable_Errors(t *testing.T) {
+	t.Parallel()
+
+	recorder := TestingRecorderWithPrometheus(t, "observable-errors-test")
+	gauge := func(context.Context, func(float64, ...attribute.KeyValue)) error { return nil }
+	counter := func(context.Context, func(int64, ...attribute.KeyValue)) error { return nil }
+
+	_, err := recorder.RegisterObservableGauge("pool_size", nil)
+	require.ErrorContains(t, err, "callback cannot be nil")
+
+	_, err = recorder.RegisterObservableGauge("__reserved", gauge)
+	require.Error(t, err)
+
+	_, err = recorder.RegisterObservableGauge("pool_size", gauge)
+	require.NoError(t, err)
+	_, err = recorder.RegisterObservableGauge("pool_size", gauge)
+	require.NoError(t, err, "a gauge name can be registered again")
+
+	_, err = recorder.RegisterObservableCounter("pool_size", counter)
+	require.ErrorContains(t, err, "already used by an observable gauge")
+}
+
+func TestRegisterObservable_CountsTowardLimit(t *testing.T) {
+	t.Parallel()
+
+	recorder := MustNew(
+		WithPrometheus(":0", "/metrics"),
+		WithServerDisabled(),
+		WithServiceName("observable-limit-test"),
+		WithMaxCustomMetrics(1),
+	)
+	gauge := func(context.Context, func(float64, ...attribute.KeyValue)) error { return nil }
+
+	_, err := recorder.RegisterObservableGauge("first", gauge)
+	require.NoError(t, err)
+
+	_, err = recorder.RegisterObservableGauge("second", gauge)
+	require.ErrorContains(t, err, "limit")
+	require.Error(t, recorder.AddUpDownCounter(t.Context(), "third", 1))
+}
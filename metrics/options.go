@@ -15,11 +15,15 @@
 package metrics
 
 import (
+	"errors"
+	"fmt"
 	"log/slog"
 	"strings"
 	"time"
 
 	"go.opentelemetry.io/otel/metric"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 )
 
 // Option defines functional options for Recorder configuration.
@@ -28,26 +32,37 @@ type Option func(*config)
 
 // config holds construction-time metrics configuration.
 type config struct {
-	meterProvider       metric.MeterProvider
-	serviceName         string
-	serviceVersion      string
-	exportInterval      time.Duration
-	durationBuckets     []float64
-	sizeBuckets         []float64
-	autoStartServer     bool
-	strictPort          bool
-	maxCustomMetrics    int
-	logger              *slog.Logger
-	registerGlobal      bool
-	withoutScopeInfo    bool
-	withoutTargetInfo   bool
-	provider            Provider
-	providerSetCount    int
-	metricsPort         string
-	metricsPath         string
-	otlpEndpoint        string
-	customMeterProvider bool
-	validationErrors    []error
+	meterProvider           metric.MeterProvider
+	serviceName             string
+	serviceVersion          string
+	exportInterval          time.Duration
+	durationBuckets         []float64
+	sizeBuckets             []float64
+	autoStartServer         bool
+	strictPort              bool
+	maxCustomMetrics        int
+	logger                  *slog.Logger
+	registerGlobal          bool
+	withoutScopeInfo        bool
+	withoutTargetInfo       bool
+	withoutExemplars        bool
+	provider                Provider
+	providerSetCount        int
+	metricsPort             string
+	metricsPath             string
+	otlpEndpoint            string
+	additionalOTLPEndpoints []string
+	pushgatewayURL          string
+	pushgatewayJob          string
+	customMeterProvider     bool
+	labelAllowlists         map[string][]string
+	maxLabelValueLength     int
+	maxSeries               int
+	cardinalityAction       CardinalityAction
+	views                   []sdkmetric.View
+	temporality             Temporality
+	slos                    []SLO
+	validationErrors        []error
 }
 
 // WithMeterProvider allows you to provide a custom OpenTelemetry [metric.MeterProvider].
@@ -224,6 +239,200 @@ func WithoutTargetInfo() Option {
 	}
 }
 
+// WithoutExemplars disables exemplars.
+//
+// By default, measurements made with a context that carries a sampled span,
+// such as request durations, keep the trace and span IDs of a few requests
+// as exemplars. Dashboards like Grafana link from a histogram bucket to those
+// traces. The Prometheus handler then serves the OpenMetrics format, which is
+// the one that carries exemplars, to scrapers that accept it.
+//
+// This option affects all providers, except a custom meter provider.
+//
+// Example:
+//
+//	recorder := metrics.MustNew(
+//	    metrics.WithPrometheus(":9090", "/metrics"),
+//	    metrics.WithoutExemplars(),
+//	)
+func WithoutExemplars() Option {
+	return func(c *config) {
+		c.withoutExemplars = true
+	}
+}
+
+// WithLabelAllowlist keeps only the given attribute keys on the metric
+// name, and drops the others before recording. Use it to keep a metric's
+// labels bounded whatever attributes callers pass. It applies to built-in
+// HTTP metrics, such as "http_requests_total", and custom metrics alike.
+// With no keys, the metric has no labels at all. Calling it again for the
+// same metric replaces its allowlist.
+//
+// Example:
+//
+//	recorder := metrics.MustNew(
+//	    metrics.WithPrometheus(":9090", "/metrics"),
+//	    metrics.WithLabelAllowlist("http_request_duration_seconds",
+//	        "http.method", "http.route", "http.status_code"),
+//	)
+func WithLabelAllowlist(metricName string, keys ...string) Option {
+	return func(c *config) {
+		if c.labelAllowlists == nil {
+			c.labelAllowlists = make(map[string][]string)
+		}
+		c.labelAllowlists[metricName] = keys
+	}
+}
+
+// WithMaxLabelValueLength truncates string attribute values to at most
+// maxLength characters, so long values such as user agents or raw paths
+// don't bloat every time series that carries them.
+//
+// Example:
+//
+//	recorder := metrics.MustNew(
+//	    metrics.WithMaxLabelValueLength(64),
+//	)
+func WithMaxLabelValueLength(maxLength int) Option {
+	return func(c *config) {
+		c.maxLabelValueLength = maxLength
+	}
+}
+
+// WithCardinalityLimit limits each metric to maxSeries distinct attribute
+// sets, that is time series. A route that records raw IDs, or an unbounded
+// user-supplied label, then can't grow the Prometheus endpoint without
+// bounds. When a metric reaches the limit, a warning is logged once for it,
+// and measurements that would create a new time series are recorded anyway
+// ([CardinalityWarn]) or dropped ([CardinalityDrop]).
+//
+// Example:
+//
+//	recorder := metrics.MustNew(
+//	    metrics.WithPrometheus(":9090", "/metrics"),
+//	    metrics.WithCardinalityLimit(1000, metrics.CardinalityDrop),
+//	    metrics.WithLogger(slog.Default()),
+//	)
+func WithCardinalityLimit(maxSeries int, action CardinalityAction) Option {
+	return func(c *config) {
+		c.maxSeries = maxSeries
+		c.cardinalityAction = action
+	}
+}
+
+// WithView adds OpenTelemetry views to the meter provider, to change how
+// instruments are exported: their name, description, buckets, aggregation,
+// or attributes. Views apply to built-in and custom metrics alike, and are
+// ignored with [WithMeterProvider], where the provider is yours to configure.
+//
+// Example:
+//
+//	recorder := metrics.MustNew(
+//	    metrics.WithView(sdkmetric.NewView(
+//	        sdkmetric.Instrument{Name: "http_request_duration_seconds"},
+//	        sdkmetric.Stream{Aggregation: sdkmetric.AggregationBase2ExponentialHistogram{
+//	            MaxSize: 160, MaxScale: 20,
+//	        }},
+//	    )),
+//	)
+func WithView(views ...sdkmetric.View) Option {
+	return func(c *config) {
+		for _, view := range views {
+			if view == nil {
+				c.validationErrors = append(c.validationErrors, errors.New("view cannot be nil"))
+				continue
+			}
+			c.views = append(c.views, view)
+		}
+	}
+}
+
+// WithDroppedMetrics drops the metrics with the given names, so they are
+// never exported. Names may contain the wildcards "*" and "?".
+//
+// Example:
+//
+//	recorder := metrics.MustNew(
+//	    metrics.WithDroppedMetrics("http_request_size_bytes", "http_response_size_bytes"),
+//	)
+func WithDroppedMetrics(names ...string) Option {
+	return func(c *config) {
+		for _, name := range names {
+			if name == "" {
+				c.validationErrors = append(c.validationErrors, errors.New("dropped metric name cannot be empty"))
+				continue
+			}
+			c.views = append(c.views, dropView(name))
+		}
+	}
+}
+
+// WithRenamedMetric exports the metric from under the name to, for example to
+// follow the naming conventions of an organization. Other options, such as
+// [WithLabelAllowlist], still refer to the metric by its original name.
+//
+// Example:
+//
+//	recorder := metrics.MustNew(
+//	    metrics.WithRenamedMetric("http_requests_total", "api_requests_total"),
+//	)
+func WithRenamedMetric(from, to string) Option {
+	return func(c *config) {
+		if from == "" || to == "" {
+			c.validationErrors = append(c.validationErrors,
+				fmt.Errorf("renamed metric names cannot be empty: %q to %q", from, to))
+			return
+		}
+		if strings.ContainsAny(from, "*?") {
+			c.validationErrors = append(c.validationErrors,
+				fmt.Errorf("renamed metric name %q cannot contain wildcards", from))
+			return
+		}
+		c.views = append(c.views, renameView(from, to))
+	}
+}
+
+// WithTemporality sets how the OTLP and stdout providers, and collectors added
+// with [WithAdditionalOTLP], report counters and histograms.
+// [CumulativeTemporality] is the default; use [DeltaTemporality] for backends
+// that expect changes since the last export. Prometheus and the Pushgateway
+// only support cumulative metrics, and keep them alongside delta collectors.
+//
+// Example:
+//
+//	recorder := metrics.MustNew(
+//	    metrics.WithOTLP("http://localhost:4318"),
+//	    metrics.WithTemporality(metrics.DeltaTemporality),
+//	)
+func WithTemporality(temporality Temporality) Option {
+	return func(c *config) {
+		c.temporality = temporality
+	}
+}
+
+// WithSLO adds a service level objective on HTTP requests. The recorder
+// counts the requests it covers, and those that met it, so burn-rate alerts
+// need no bespoke metric code; see [SLO] and [SLO.PrometheusRules].
+// It can be used several times for several SLOs.
+//
+// Example:
+//
+//	recorder := metrics.MustNew(
+//	    metrics.WithPrometheus(":9090", "/metrics"),
+//	    metrics.WithSLO(metrics.SLO{Name: "api-availability", Target: 0.999}),
+//	    metrics.WithSLO(metrics.SLO{
+//	        Name:             "checkout-latency",
+//	        Target:           0.99,
+//	        LatencyThreshold: 300 * time.Millisecond,
+//	        Routes:           []string{"/checkout"},
+//	    }),
+//	)
+func WithSLO(slo SLO) Option {
+	return func(c *config) {
+		c.slos = append(c.slos, slo)
+	}
+}
+
 // WithPrometheus configures Prometheus provider with port and path.
 // This is the recommended way to configure Prometheus metrics.
 //
@@ -264,6 +473,31 @@ func WithOTLP(endpoint string) Option {
 	}
 }
 
+// WithAdditionalOTLP also exports metrics to the OTLP collector at endpoint,
+// besides the provider, for example to serve a local Prometheus scrape and
+// feed a central collector at the same time. It can be used several times
+// for several collectors; an empty endpoint means [DefaultOTLPEndpoint].
+//
+// Each collector has its own lifecycle: exports start with [Recorder.Start],
+// use the export interval, and end with a final flush on [Recorder.Shutdown].
+// A collector that fails to start or export doesn't affect the provider or
+// the other collectors. It can't be combined with [WithMeterProvider].
+//
+// Example:
+//
+//	recorder := metrics.MustNew(
+//	    metrics.WithPrometheus(":9090", "/metrics"),
+//	    metrics.WithAdditionalOTLP("http://otel-collector:4318"),
+//	)
+func WithAdditionalOTLP(endpoint string) Option {
+	return func(c *config) {
+		if endpoint == "" {
+			endpoint = DefaultOTLPEndpoint
+		}
+		c.additionalOTLPEndpoints = append(c.additionalOTLPEndpoints, endpoint)
+	}
+}
+
 // WithStdout configures stdout provider for development/debugging.
 //
 // Example:
@@ -278,3 +512,26 @@ func WithStdout() Option {
 		c.providerSetCount++
 	}
 }
+
+// WithPushgateway configures the Pushgateway provider, for short-lived jobs
+// such as CLI commands and cron tasks that exit before Prometheus can
+// scrape them. Metrics are pushed to the Pushgateway at url, grouped under
+// job, every export interval after [Recorder.Start], when
+// [Recorder.ForceFlush] is called, and one final time on [Recorder.Shutdown].
+// Each push replaces the metrics previously pushed for the job.
+//
+// Example:
+//
+//	recorder := metrics.MustNew(
+//	    metrics.WithPushgateway("http://pushgateway:9091", "nightly-report"),
+//	    metrics.WithServiceName("report-job"),
+//	)
+//	defer recorder.Shutdown(context.Background()) // Pushes the final metrics
+func WithPushgateway(url, job string) Option {
+	return func(c *config) {
+		c.provider = PushgatewayProvider
+		c.providerSetCount++
+		c.pushgatewayURL = url
+		c.pushgatewayJob = job
+	}
+}
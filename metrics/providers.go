@@ -33,6 +33,7 @@ import (
 
 	promclient "github.com/prometheus/client_golang/prometheus"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/exemplar"
 	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
 )
 
@@ -63,6 +64,8 @@ func (r *Recorder) initializeProvider() error {
 		// Stdout doesn't need lifecycle context (no network I/O), so initialize immediately.
 		// This allows simpler debugging use cases without requiring Start().
 		return r.initStdoutProvider()
+	case PushgatewayProvider:
+		return r.initPushgatewayProvider()
 	default:
 		return fmt.Errorf("unsupported metrics provider: %s", r.provider)
 	}
@@ -88,16 +91,15 @@ func (r *Recorder) initPrometheusProvider() error {
 		return fmt.Errorf("failed to create Prometheus exporter: %w", err)
 	}
 
-	res := createResource(r.serviceName, r.serviceVersion)
-	r.meterProvider = sdkmetric.NewMeterProvider(
-		sdkmetric.WithReader(exporter),
-		sdkmetric.WithResource(res),
-	)
+	r.meterProvider = r.newMeterProvider(exporter)
 
 	// Create handler for the custom registry
 	r.prometheusHandler = promhttp.HandlerFor(
 		r.prometheusRegistry,
-		promhttp.HandlerOpts{},
+		promhttp.HandlerOpts{
+			// Exemplars are only part of the OpenMetrics format
+			EnableOpenMetrics: !r.withoutExemplars,
+		},
 	)
 
 	// Set global meter provider only if requested
@@ -126,11 +128,42 @@ func (r *Recorder) initPrometheusProvider() error {
 // This is called from Start(ctx) to use the lifecycle context, enabling proper cancellation
 // during graceful shutdown.
 func (r *Recorder) initOTLPProvider(ctx context.Context) error {
-	opts := []otlpmetrichttp.Option{}
+	exporter, err := r.newOTLPExporter(ctx, r.otlpEndpoint)
+	if err != nil {
+		return err
+	}
+
+	reader := sdkmetric.NewPeriodicReader(
+		exporter,
+		sdkmetric.WithInterval(r.exportInterval),
+	)
+
+	r.meterProvider = r.newMeterProvider(reader)
 
-	if r.otlpEndpoint != "" {
+	// Set global meter provider only if requested
+	if r.registerGlobal {
+		r.logger.Debug("Setting global OpenTelemetry meter provider", "provider", "otlp")
+		otel.SetMeterProvider(r.meterProvider)
+	} else {
+		r.logger.Debug("Skipping global meter provider registration", "provider", "otlp")
+	}
+
+	r.meter = r.meterProvider.Meter("rivaas.dev/metrics")
+
+	return r.initializeMetrics()
+}
+
+// newOTLPExporter creates an OTLP HTTP exporter for endpoint.
+// ctx should be the lifecycle context for proper shutdown propagation: when it
+// is canceled (e.g., during graceful shutdown), the exporter will stop its
+// background goroutines and flush pending metrics.
+func (r *Recorder) newOTLPExporter(ctx context.Context, endpoint string) (*otlpmetrichttp.Exporter, error) {
+	opts := []otlpmetrichttp.Option{
+		otlpmetrichttp.WithTemporalitySelector(r.temporality.selector()),
+	}
+
+	if endpoint != "" {
 		// Parse endpoint to extract host:port and determine if HTTP or HTTPS
-		endpoint := r.otlpEndpoint
 		isHTTP := false
 
 		// Remove protocol prefix if present
@@ -152,43 +185,19 @@ func (r *Recorder) initOTLPProvider(ctx context.Context) error {
 		}
 	}
 
-	// Use the lifecycle context for proper shutdown propagation.
-	// When ctx is canceled (e.g., during graceful shutdown), the exporter
-	// will stop its background goroutines and flush pending metrics.
 	exporter, err := otlpmetrichttp.New(ctx, opts...)
 	if err != nil {
-		return fmt.Errorf("failed to create OTLP exporter: %w", err)
+		return nil, fmt.Errorf("failed to create OTLP exporter: %w", err)
 	}
 
-	reader := sdkmetric.NewPeriodicReader(
-		exporter,
-		sdkmetric.WithInterval(r.exportInterval),
-	)
-
-	res := createResource(r.serviceName, r.serviceVersion)
-	r.meterProvider = sdkmetric.NewMeterProvider(
-		sdkmetric.WithReader(reader),
-		sdkmetric.WithResource(res),
-	)
-
-	// Set global meter provider only if requested
-	if r.registerGlobal {
-		r.logger.Debug("Setting global OpenTelemetry meter provider", "provider", "otlp")
-		otel.SetMeterProvider(r.meterProvider)
-	} else {
-		r.logger.Debug("Skipping global meter provider registration", "provider", "otlp")
-	}
-
-	r.meter = r.meterProvider.Meter("rivaas.dev/metrics")
-
-	return r.initializeMetrics()
+	return exporter, nil
 }
 
 // initStdoutProvider initializes the stdout metrics provider.
 // Unlike OTLP, stdout doesn't need a lifecycle context since it doesn't
 // perform network I/O - it just writes to stdout for debugging.
 func (r *Recorder) initStdoutProvider() error {
-	exporter, err := stdoutmetric.New()
+	exporter, err := stdoutmetric.New(stdoutmetric.WithTemporalitySelector(r.temporality.selector()))
 	if err != nil {
 		return fmt.Errorf("failed to create stdout exporter: %w", err)
 	}
@@ -198,11 +207,7 @@ func (r *Recorder) initStdoutProvider() error {
 		sdkmetric.WithInterval(r.exportInterval),
 	)
 
-	res := createResource(r.serviceName, r.serviceVersion)
-	r.meterProvider = sdkmetric.NewMeterProvider(
-		sdkmetric.WithReader(reader),
-		sdkmetric.WithResource(res),
-	)
+	r.meterProvider = r.newMeterProvider(reader)
 
 	// Set global meter provider only if requested
 	if r.registerGlobal {
@@ -360,6 +365,30 @@ func findAvailablePort(ctx context.Context, preferredPort string) (string, error
 	return "", fmt.Errorf("no available port found starting from %s", preferredPort)
 }
 
+// newMeterProvider creates a meter provider with the service resource,
+// reader, additional OTLP exporters, and views. Measurements made with a context that carries a sampled
+// span are offered as exemplars, unless WithoutExemplars is set.
+func (r *Recorder) newMeterProvider(reader sdkmetric.Reader) *sdkmetric.MeterProvider {
+	opts := []sdkmetric.Option{
+		sdkmetric.WithReader(reader),
+		sdkmetric.WithResource(createResource(r.serviceName, r.serviceVersion)),
+	}
+	for _, exporter := range r.additionalOTLP {
+		opts = append(opts, sdkmetric.WithReader(sdkmetric.NewPeriodicReader(
+			exporter,
+			sdkmetric.WithInterval(r.exportInterval),
+		)))
+	}
+	if len(r.views) > 0 {
+		opts = append(opts, sdkmetric.WithView(r.views...))
+	}
+	if r.withoutExemplars {
+		opts = append(opts, sdkmetric.WithExemplarFilter(exemplar.AlwaysOffFilter))
+	}
+
+	return sdkmetric.NewMeterProvider(opts...)
+}
+
 // createResource creates an OpenTelemetry resource with service information.
 func createResource(serviceName, serviceVersion string) *resource.Resource {
 	return resource.NewWithAttributes(
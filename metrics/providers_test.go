@@ -26,6 +26,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // TestProviderInitialization tests provider initialization edge cases
@@ -514,3 +515,71 @@ func TestMetricsWithDisabledState(t *testing.T) {
 	// ServerAddress should return empty string
 	assert.Empty(t, recorder.ServerAddress())
 }
+
+// sampledContext returns a context carrying a sampled span context.
+func sampledContext(t *testing.T) context.Context {
+	t.Helper()
+	traceID, err := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	require.NoError(t, err)
+	spanID, err := trace.SpanIDFromHex("00f067aa0ba902b7")
+	require.NoError(t, err)
+
+	return trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	}))
+}
+
+// scrapeOpenMetrics scrapes the recorder's Prometheus handler in OpenMetrics format.
+func scrapeOpenMetrics(t *testing.T, recorder *Recorder) string {
+	t.Helper()
+	handler, err := recorder.Handler()
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	req.Header.Set("Accept", "application/openmetrics-text; version=1.0.0")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	return w.Body.String()
+}
+
+// TestExemplars tests that request durations link to the sampled trace.
+func TestExemplars(t *testing.T) {
+	t.Parallel()
+
+	t.Run("RecordedForSampledSpans", func(t *testing.T) {
+		t.Parallel()
+		recorder := TestingRecorderWithPrometheus(t, "test-service", WithServerDisabled())
+
+		ctx := sampledContext(t)
+		m := recorder.BeginRequest(ctx)
+		recorder.Finish(ctx, m, http.StatusOK, 10, "/orders")
+
+		body := scrapeOpenMetrics(t, recorder)
+		assert.Contains(t, body, `trace_id="4bf92f3577b34da6a3ce929d0e0e4736"`)
+		assert.Contains(t, body, `span_id="00f067aa0ba902b7"`)
+	})
+
+	t.Run("NotRecordedWithoutSpan", func(t *testing.T) {
+		t.Parallel()
+		recorder := TestingRecorderWithPrometheus(t, "test-service", WithServerDisabled())
+
+		m := recorder.BeginRequest(context.Background())
+		recorder.Finish(context.Background(), m, http.StatusOK, 10, "/orders")
+
+		assert.NotContains(t, scrapeOpenMetrics(t, recorder), "trace_id")
+	})
+
+	t.Run("WithoutExemplars", func(t *testing.T) {
+		t.Parallel()
+		recorder := TestingRecorderWithPrometheus(t, "test-service", WithServerDisabled(), WithoutExemplars())
+
+		ctx := sampledContext(t)
+		m := recorder.BeginRequest(ctx)
+		recorder.Finish(ctx, m, http.StatusOK, 10, "/orders")
+
+		assert.NotContains(t, scrapeOpenMetrics(t, recorder), "trace_id")
+	})
+}
@@ -0,0 +1,101 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/push"
+)
+
+// initPushgatewayProvider initializes the Pushgateway metrics provider.
+// Metrics are collected into a custom Prometheus registry, like with
+// [PrometheusProvider], and pushed from it instead of being scraped.
+func (r *Recorder) initPushgatewayProvider() error {
+	if err := r.initPrometheusProvider(); err != nil {
+		return err
+	}
+
+	// Metrics are pushed, so there is nothing to serve
+	r.prometheusHandler = nil
+	r.pusher = push.New(r.pushgatewayURL, r.pushgatewayJob).Gatherer(r.prometheusRegistry)
+
+	return nil
+}
+
+// push replaces the metrics of the job on the Pushgateway with the current ones.
+func (r *Recorder) push(ctx context.Context) error {
+	if err := r.pusher.PushContext(ctx); err != nil {
+		return fmt.Errorf("push to Pushgateway: %w", err)
+	}
+
+	return nil
+}
+
+// startPushLoop pushes metrics every export interval until ctx is canceled
+// or the recorder shuts down.
+func (r *Recorder) startPushLoop(ctx context.Context) {
+	stop := make(chan struct{})
+	done := make(chan struct{})
+
+	r.pushMu.Lock()
+	r.pushStop = stop
+	r.pushDone = done
+	r.pushMu.Unlock()
+
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(r.exportInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-stop:
+				return
+			case <-ticker.C:
+				if err := r.push(ctx); err != nil {
+					r.logger.Warn("Periodic metrics push failed", "error", err, "url", r.pushgatewayURL)
+				}
+			}
+		}
+	}()
+}
+
+// stopPushLoop stops the periodic push, if running, and pushes the final
+// metrics so measurements made since the last push aren't lost.
+func (r *Recorder) stopPushLoop(ctx context.Context) error {
+	r.pushMu.Lock()
+	stop, done := r.pushStop, r.pushDone
+	r.pushStop, r.pushDone = nil, nil
+	r.pushMu.Unlock()
+
+	if stop != nil {
+		close(stop)
+		select {
+		case <-done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	r.logger.Debug("Pushing final metrics", "url", r.pushgatewayURL)
+
+	return r.push(ctx)
+}
@@ -0,0 +1,158 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package metrics
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pushgateway is a fake Pushgateway that records the pushes it receives.
+type pushgateway struct {
+	*httptest.Server
+
+	mu     sync.Mutex
+	pushes []recordedPush
+}
+
+type recordedPush struct {
+	method string
+	path   string
+	body   string
+}
+
+func newPushgateway(t *testing.T) *pushgateway {
+	t.Helper()
+
+	gw := &pushgateway{}
+	gw.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		gw.mu.Lock()
+		gw.pushes = append(gw.pushes, recordedPush{method: r.Method, path: r.URL.Path, body: string(body)})
+		gw.mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(gw.Close)
+
+	return gw
+}
+
+func (gw *pushgateway) received() []recordedPush {
+	gw.mu.Lock()
+	defer gw.mu.Unlock()
+
+	return append([]recordedPush(nil), gw.pushes...)
+}
+
+func TestPushgateway_FinalPushOnShutdown(t *testing.T) {
+	t.Parallel()
+
+	gw := newPushgateway(t)
+	recorder, err := New(
+		WithPushgateway(gw.URL, "nightly-report"),
+		WithServiceName("report-job"),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, PushgatewayProvider, recorder.Provider())
+
+	require.NoError(t, recorder.AddCounter(t.Context(), "rows_processed", 42))
+	require.NoError(t, recorder.Shutdown(t.Context()))
+
+	pushes := gw.received()
+	require.Len(t, pushes, 1)
+	assert.Equal(t, http.MethodPut, pushes[0].method)
+	assert.Equal(t, "/metrics/job/nightly-report", pushes[0].path)
+	assert.Contains(t, pushes[0].body, "rows_processed_total")
+}
+
+func TestPushgateway_ForceFlush(t *testing.T) {
+	t.Parallel()
+
+	gw := newPushgateway(t)
+	recorder := MustNew(WithPushgateway(gw.URL, "flush-job"))
+	t.Cleanup(func() { _ = recorder.Shutdown(t.Context()) })
+
+	require.NoError(t, recorder.ForceFlush(t.Context()))
+	assert.Len(t, gw.received(), 1)
+}
+
+func TestPushgateway_PeriodicPush(t *testing.T) {
+	t.Parallel()
+
+	gw := newPushgateway(t)
+	recorder := MustNew(
+		WithPushgateway(gw.URL, "periodic-job"),
+		WithExportInterval(20*time.Millisecond),
+	)
+	require.NoError(t, recorder.Start(t.Context()))
+
+	assert.Eventually(t, func() bool { return len(gw.received()) >= 2 },
+		2*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, recorder.Shutdown(t.Context()))
+	count := len(gw.received())
+	time.Sleep(60 * time.Millisecond)
+	assert.Len(t, gw.received(), count, "pushes should stop after shutdown")
+}
+
+func TestPushgateway_PushError(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	t.Cleanup(server.Close)
+
+	recorder := MustNew(WithPushgateway(server.URL, "failing-job"))
+
+	err := recorder.Shutdown(t.Context())
+	require.ErrorContains(t, err, "push to Pushgateway")
+}
+
+func TestPushgateway_Validation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		opts    []Option
+		wantErr string
+	}{
+		{name: "empty URL", opts: []Option{WithPushgateway("", "job")}, wantErr: "pushgateway URL cannot be empty"},
+		{name: "empty job", opts: []Option{WithPushgateway("http://localhost:9091", "")}, wantErr: "pushgateway job cannot be empty"},
+		{
+			name:    "conflicting provider",
+			opts:    []Option{WithPushgateway("http://localhost:9091", "job"), WithStdout()},
+			wantErr: "conflicting provider options",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := New(tt.opts...)
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
@@ -43,6 +43,16 @@ const (
 	maxMetricNameLength = 255
 )
 
+// Names of the built-in HTTP metrics.
+const (
+	metricRequestDuration = "http_request_duration_seconds"
+	metricRequestCount    = "http_requests_total"
+	metricActiveRequests  = "http_requests_active"
+	metricRequestSize     = "http_request_size_bytes"
+	metricResponseSize    = "http_response_size_bytes"
+	metricErrorCount      = "http_errors_total"
+)
+
 // Reserved metric name prefixes that should not be used for custom metrics.
 // These prefixes are reserved by Prometheus, OpenTelemetry, or the metrics package itself.
 var reservedPrefixes = []string{
@@ -92,6 +102,10 @@ func validateMetricName(name string) error {
 type RequestMetrics struct {
 	StartTime  time.Time
 	Attributes []attribute.KeyValue
+
+	// activeAttributes are the attributes of the active requests increment,
+	// so attributes added later don't unbalance the gauge
+	activeAttributes []attribute.KeyValue
 }
 
 // BeginRequest initializes metrics collection for a request.
@@ -123,7 +137,8 @@ func (r *Recorder) BeginRequest(ctx context.Context) *RequestMetrics {
 	m.Attributes = make([]attribute.KeyValue, 0, 8)
 
 	// Increment active requests
-	r.activeRequests.Add(ctx, 1, metric.WithAttributes(m.Attributes...))
+	m.activeAttributes = m.Attributes[:len(m.Attributes):len(m.Attributes)]
+	r.activeRequests.Add(ctx, 1, metric.WithAttributes(m.activeAttributes...))
 
 	return m
 }
@@ -152,25 +167,38 @@ func (r *Recorder) Finish(ctx context.Context, m *RequestMetrics, statusCode int
 	)
 
 	// Record duration
-	r.requestDuration.Record(ctx, duration, metric.WithAttributes(finalAttributes...))
+	if attrs, ok := r.labels.apply(metricRequestDuration, finalAttributes); ok {
+		r.requestDuration.Record(ctx, duration, metric.WithAttributes(attrs...))
+	}
 
 	// Increment request count
-	r.requestCount.Add(ctx, 1, metric.WithAttributes(finalAttributes...))
+	if attrs, ok := r.labels.apply(metricRequestCount, finalAttributes); ok {
+		r.requestCount.Add(ctx, 1, metric.WithAttributes(attrs...))
+	}
 
 	// Decrement active requests
-	// Note: Use m.Attributes (not finalAttributes) to match the increment in BeginRequest().
-	// This ensures increment/decrement target the same time series.
+	// Note: Use the attributes of the increment in BeginRequest(), not those added since
+	// with AddAttributes. This ensures increment/decrement target the same time series.
 	// Active requests gauge tracks only *how many* requests are in flight, not *which routes*.
-	r.activeRequests.Add(ctx, -1, metric.WithAttributes(m.Attributes...))
+	r.activeRequests.Add(ctx, -1, metric.WithAttributes(m.activeAttributes...))
+
+	// Count the request toward the SLOs
+	if len(r.slos) > 0 {
+		r.recordSLOs(ctx, statusCode, duration, route)
+	}
 
 	// Record error if status indicates error
 	if statusCode >= 400 {
-		r.errorCount.Add(ctx, 1, metric.WithAttributes(finalAttributes...))
+		if attrs, ok := r.labels.apply(metricErrorCount, finalAttributes); ok {
+			r.errorCount.Add(ctx, 1, metric.WithAttributes(attrs...))
+		}
 	}
 
 	// Record response size if available
 	if responseSize > 0 {
-		r.responseSize.Record(ctx, responseSize, metric.WithAttributes(finalAttributes...))
+		if attrs, ok := r.labels.apply(metricResponseSize, finalAttributes); ok {
+			r.responseSize.Record(ctx, responseSize, metric.WithAttributes(attrs...))
+		}
 	}
 }
 
@@ -180,7 +208,9 @@ func (r *Recorder) RecordRequestSize(ctx context.Context, m *RequestMetrics, siz
 	if m == nil || size <= 0 {
 		return
 	}
-	r.requestSize.Record(ctx, size, metric.WithAttributes(m.Attributes...))
+	if attrs, ok := r.labels.apply(metricRequestSize, m.Attributes); ok {
+		r.requestSize.Record(ctx, size, metric.WithAttributes(attrs...))
+	}
 }
 
 // AddAttributes adds attributes to the request metrics.
@@ -231,7 +261,9 @@ func (r *Recorder) RecordHistogram(ctx context.Context, name string, value float
 		return fmt.Errorf("record histogram %q: %w", name, err)
 	}
 
-	histogram.Record(ctx, value, metric.WithAttributes(attributes...))
+	if attrs, ok := r.labels.apply(name, attributes); ok {
+		histogram.Record(ctx, value, metric.WithAttributes(attrs...))
+	}
 
 	return nil
 }
@@ -270,7 +302,9 @@ func (r *Recorder) AddCounter(ctx context.Context, name string, value int64, att
 		return fmt.Errorf("add counter %q: %w", name, err)
 	}
 
-	counter.Add(ctx, value, metric.WithAttributes(attributes...))
+	if attrs, ok := r.labels.apply(name, attributes); ok {
+		counter.Add(ctx, value, metric.WithAttributes(attrs...))
+	}
 
 	return nil
 }
@@ -295,7 +329,38 @@ func (r *Recorder) SetGauge(ctx context.Context, name string, value float64, att
 		return fmt.Errorf("set gauge %q: %w", name, err)
 	}
 
-	gauge.Record(ctx, value, metric.WithAttributes(attributes...))
+	if attrs, ok := r.labels.apply(name, attributes); ok {
+		gauge.Record(ctx, value, metric.WithAttributes(attrs...))
+	}
+
+	return nil
+}
+
+// AddUpDownCounter adds a value, which may be negative, to a custom
+// up-down counter metric, for values that go up and down such as the number
+// of items in a queue. Returns an error if the metric name is invalid or
+// creation fails.
+//
+// Example:
+//
+//	_ = recorder.AddUpDownCounter(ctx, "jobs_in_progress", 1, attribute.String("queue", "emails"))
+//	defer recorder.AddUpDownCounter(ctx, "jobs_in_progress", -1, attribute.String("queue", "emails"))
+func (r *Recorder) AddUpDownCounter(ctx context.Context, name string, value int64, attributes ...attribute.KeyValue) error {
+	if !r.enabled {
+		return nil
+	}
+
+	counter, err := r.getOrCreateUpDownCounter(name)
+	if err != nil {
+		atomic.AddInt64(&r.atomicCustomMetricFailures, 1)
+		r.customMetricFailures.Add(ctx, 1)
+
+		return fmt.Errorf("add up-down counter %q: %w", name, err)
+	}
+
+	if attrs, ok := r.labels.apply(name, attributes); ok {
+		counter.Add(ctx, value, metric.WithAttributes(attrs...))
+	}
 
 	return nil
 }
@@ -306,7 +371,7 @@ func (r *Recorder) initializeMetrics() error {
 
 	// Request duration histogram with configurable buckets
 	r.requestDuration, err = r.meter.Float64Histogram(
-		"http_request_duration_seconds",
+		metricRequestDuration,
 		metric.WithDescription("Duration of HTTP requests in seconds"),
 		metric.WithUnit("s"),
 		metric.WithExplicitBucketBoundaries(r.durationBuckets...),
@@ -317,7 +382,7 @@ func (r *Recorder) initializeMetrics() error {
 
 	// Request count counter
 	r.requestCount, err = r.meter.Int64Counter(
-		"http_requests_total",
+		metricRequestCount,
 		metric.WithDescription("Total number of HTTP requests"),
 	)
 	if err != nil {
@@ -326,7 +391,7 @@ func (r *Recorder) initializeMetrics() error {
 
 	// Active requests gauge
 	r.activeRequests, err = r.meter.Int64UpDownCounter(
-		"http_requests_active",
+		metricActiveRequests,
 		metric.WithDescription("Number of active HTTP requests"),
 	)
 	if err != nil {
@@ -335,7 +400,7 @@ func (r *Recorder) initializeMetrics() error {
 
 	// Request size histogram with configurable buckets
 	r.requestSize, err = r.meter.Int64Histogram(
-		"http_request_size_bytes",
+		metricRequestSize,
 		metric.WithDescription("Size of HTTP request bodies in bytes"),
 		metric.WithUnit("By"),
 		metric.WithExplicitBucketBoundaries(r.sizeBuckets...),
@@ -346,7 +411,7 @@ func (r *Recorder) initializeMetrics() error {
 
 	// Response size histogram with configurable buckets
 	r.responseSize, err = r.meter.Int64Histogram(
-		"http_response_size_bytes",
+		metricResponseSize,
 		metric.WithDescription("Size of HTTP response bodies in bytes"),
 		metric.WithUnit("By"),
 		metric.WithExplicitBucketBoundaries(r.sizeBuckets...),
@@ -357,7 +422,7 @@ func (r *Recorder) initializeMetrics() error {
 
 	// Error count counter
 	r.errorCount, err = r.meter.Int64Counter(
-		"http_errors_total",
+		metricErrorCount,
 		metric.WithDescription("Total number of HTTP errors"),
 	)
 	if err != nil {
@@ -373,7 +438,7 @@ func (r *Recorder) initializeMetrics() error {
 		return fmt.Errorf("failed to create custom metric failures counter: %w", err)
 	}
 
-	return nil
+	return r.initializeSLOMetrics()
 }
 
 // getOrCreateCounter gets or creates a custom counter metric.
@@ -523,6 +588,55 @@ func (r *Recorder) getOrCreateGauge(name string) (metric.Float64Gauge, error) {
 	return gauge, nil
 }
 
+// getOrCreateUpDownCounter gets or creates a custom up-down counter metric.
+// This method is safe for concurrent use.
+func (r *Recorder) getOrCreateUpDownCounter(name string) (metric.Int64UpDownCounter, error) {
+	// Fast path: read lock
+	r.customMu.RLock()
+	if counter, exists := r.customUpDowns[name]; exists {
+		r.customMu.RUnlock()
+		return counter, nil
+	}
+	r.customMu.RUnlock()
+
+	// Validate metric name only when creating new metric
+	if err := validateMetricName(name); err != nil {
+		return nil, err
+	}
+
+	// Slow path: write lock
+	r.customMu.Lock()
+	defer r.customMu.Unlock()
+
+	// Double-check after acquiring write lock
+	if counter, exists := r.customUpDowns[name]; exists {
+		return counter, nil
+	}
+
+	// Check limit
+	if r.customMetricCount >= r.maxCustomMetrics {
+		return nil, &limitError{
+			metricName: name,
+			limit:      r.maxCustomMetrics,
+			current:    r.customMetricCount,
+		}
+	}
+
+	// Create the metric
+	counter, err := r.meter.Int64UpDownCounter(
+		name,
+		metric.WithDescription("Custom up-down counter metric"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	r.customUpDowns[name] = counter
+	r.customMetricCount++
+
+	return counter, nil
+}
+
 // getAtomicCustomMetricFailures returns the atomic custom metric failures counter (for testing).
 func (r *Recorder) getAtomicCustomMetricFailures() int64 {
 	return atomic.LoadInt64(&r.atomicCustomMetricFailures)
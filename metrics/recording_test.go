@@ -23,6 +23,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // TestActiveRequestsReturnsToZero verifies that the http_requests_active gauge
@@ -73,6 +74,37 @@ func TestActiveRequestsReturnsToZero(t *testing.T) {
 	assert.NotContains(t, activeRequestsValues[0], "http_status_code", "Active requests should not have status code labels")
 }
 
+// TestActiveRequestsWithAddedAttributes verifies that attributes added with
+// AddAttributes after BeginRequest don't split the http_requests_active gauge.
+func TestActiveRequestsWithAddedAttributes(t *testing.T) {
+	t.Parallel()
+
+	recorder := TestingRecorderWithPrometheus(t, "active-requests-attributes-test")
+
+	ctx := t.Context()
+	for _, method := range []string{"GET", "POST", "GET"} {
+		m := recorder.BeginRequest(ctx)
+		require.NotNil(t, m)
+		m.AddAttributes(attribute.String("http.method", method))
+		recorder.Finish(ctx, m, 200, 100, "/test")
+	}
+
+	handler, err := recorder.Handler()
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+
+	var activeRequestsValues []string
+	for line := range strings.SplitSeq(w.Body.String(), "\n") {
+		if strings.HasPrefix(line, "http_requests_active") {
+			activeRequestsValues = append(activeRequestsValues, line)
+		}
+	}
+	require.Len(t, activeRequestsValues, 1, "Expected exactly one http_requests_active series")
+	assert.Contains(t, activeRequestsValues[0], " 0")
+	assert.NotContains(t, activeRequestsValues[0], "http_method")
+}
+
 // TestActiveRequestsDuringInflight verifies that http_requests_active accurately
 // tracks in-flight requests.
 func TestActiveRequestsDuringInflight(t *testing.T) {
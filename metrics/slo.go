@@ -0,0 +1,224 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// Names of the SLO metrics.
+const (
+	metricSLORequests     = "slo_requests_total"
+	metricSLOGoodRequests = "slo_requests_good_total"
+	metricSLOObjective    = "slo_objective_ratio"
+)
+
+// sloNameRegex validates SLO names, which are used as label values and in
+// Prometheus rule names.
+var sloNameRegex = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_-]*$`)
+
+// SLO is a service level objective on the HTTP requests recorded by a
+// [Recorder]. A request is good when it doesn't fail with a 5xx status and,
+// if LatencyThreshold is set, completes within it.
+//
+// For each SLO, the Recorder maintains the counters slo_requests_total and
+// slo_requests_good_total, and the gauge slo_objective_ratio, all labeled
+// with slo="{Name}". [SLO.PrometheusRules] turns them into burn-rate
+// recording rules and multi-window alerts.
+type SLO struct {
+	// Name identifies the SLO, such as "checkout-latency".
+	Name string
+	// Target is the objective ratio of good requests, such as 0.999.
+	Target float64
+	// LatencyThreshold is the duration within which a request must complete
+	// to be good. Zero makes the SLO about availability only.
+	LatencyThreshold time.Duration
+	// Routes limits the SLO to requests with these route patterns, such as
+	// "/checkout". Empty means all requests.
+	Routes []string
+}
+
+// validate checks the SLO fields.
+func (s SLO) validate() error {
+	if !sloNameRegex.MatchString(s.Name) {
+		return fmt.Errorf("invalid SLO name %q: must start with a letter and contain only alphanumeric, underscore, or hyphen", s.Name)
+	}
+	if s.Target <= 0 || s.Target >= 1 {
+		return fmt.Errorf("SLO %q target must be between 0 and 1 (exclusive), got %v", s.Name, s.Target)
+	}
+	if s.LatencyThreshold < 0 {
+		return fmt.Errorf("SLO %q latency threshold cannot be negative, got %s", s.Name, s.LatencyThreshold)
+	}
+
+	return nil
+}
+
+// validateSLOs checks each SLO and that their names are unique.
+func validateSLOs(slos []SLO) error {
+	names := make(map[string]bool, len(slos))
+	for _, slo := range slos {
+		if err := slo.validate(); err != nil {
+			return err
+		}
+		if names[slo.Name] {
+			return fmt.Errorf("duplicate SLO name %q", slo.Name)
+		}
+		names[slo.Name] = true
+	}
+
+	return nil
+}
+
+// isGood reports whether a request with statusCode and duration in seconds
+// meets the SLO.
+func (s SLO) isGood(statusCode int, duration float64) bool {
+	if statusCode >= 500 {
+		return false
+	}
+
+	return s.LatencyThreshold == 0 || duration <= s.LatencyThreshold.Seconds()
+}
+
+// covers reports whether the SLO applies to requests for route.
+func (s SLO) covers(route string) bool {
+	return len(s.Routes) == 0 || slices.Contains(s.Routes, route)
+}
+
+// burnRateAlert is one multi-window burn-rate alert: it fires when the error
+// budget burns burnRate times too fast over both the long and short window.
+type burnRateAlert struct {
+	long, short string
+	burnRate    float64
+	severity    string
+}
+
+// burnRateAlerts are the multi-window, multi-burn-rate alerts recommended by
+// the Google SRE workbook for a 30-day SLO window.
+var burnRateAlerts = []burnRateAlert{
+	{long: "1h", short: "5m", burnRate: 14.4, severity: "page"},
+	{long: "6h", short: "30m", burnRate: 6, severity: "page"},
+	{long: "1d", short: "2h", burnRate: 3, severity: "ticket"},
+	{long: "3d", short: "6h", burnRate: 1, severity: "ticket"},
+}
+
+// PrometheusRules returns a Prometheus rule group, in YAML, with a recording
+// rule for the error ratio of the SLO over each window used by the
+// multi-window burn-rate alerts, and those alerts: two that page when the
+// error budget burns fast, and two that open a ticket when it burns slowly.
+//
+// Example:
+//
+//	slo := metrics.SLO{Name: "checkout", Target: 0.999, LatencyThreshold: 300 * time.Millisecond}
+//	recorder := metrics.MustNew(metrics.WithSLO(slo))
+//	os.WriteFile("checkout-slo.rules.yml", []byte(slo.PrometheusRules()), 0o644)
+func (s SLO) PrometheusRules() string {
+	budget := strconv.FormatFloat(1-s.Target, 'g', 6, 64)
+	selector := fmt.Sprintf(`{slo=%q}`, s.Name)
+
+	var windows []string
+	for _, alert := range burnRateAlerts {
+		for _, window := range []string{alert.short, alert.long} {
+			if !slices.Contains(windows, window) {
+				windows = append(windows, window)
+			}
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "groups:\n  - name: slo-%s\n    rules:\n", s.Name)
+	for _, window := range windows {
+		fmt.Fprintf(&b, "      - record: slo:error_ratio:rate%s\n", window)
+		fmt.Fprintf(&b, "        expr: 1 - (sum(rate(%s%s[%s])) / sum(rate(%s%s[%s])))\n",
+			metricSLOGoodRequests, selector, window, metricSLORequests, selector, window)
+		fmt.Fprintf(&b, "        labels:\n          slo: %s\n", s.Name)
+	}
+	for _, alert := range burnRateAlerts {
+		threshold := strconv.FormatFloat(alert.burnRate, 'g', -1, 64) + " * " + budget
+		fmt.Fprintf(&b, "      - alert: SLOErrorBudgetBurn\n")
+		fmt.Fprintf(&b, "        expr: slo:error_ratio:rate%s%s > (%s) and slo:error_ratio:rate%s%s > (%s)\n",
+			alert.long, selector, threshold, alert.short, selector, threshold)
+		fmt.Fprintf(&b, "        labels:\n          slo: %s\n          severity: %s\n          window: %s\n",
+			s.Name, alert.severity, alert.long)
+		fmt.Fprintf(&b, "        annotations:\n          summary: SLO %s is burning its error budget %sx too fast over %s\n",
+			s.Name, strconv.FormatFloat(alert.burnRate, 'g', -1, 64), alert.long)
+	}
+
+	return b.String()
+}
+
+// initializeSLOMetrics creates the SLO instruments, if any SLO is configured.
+func (r *Recorder) initializeSLOMetrics() error {
+	if len(r.slos) == 0 {
+		return nil
+	}
+
+	var err error
+	r.sloRequests, err = r.meter.Int64Counter(
+		metricSLORequests,
+		metric.WithDescription("Total number of HTTP requests covered by an SLO"),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create SLO requests counter: %w", err)
+	}
+
+	r.sloGoodRequests, err = r.meter.Int64Counter(
+		metricSLOGoodRequests,
+		metric.WithDescription("Number of HTTP requests that met their SLO"),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create SLO good requests counter: %w", err)
+	}
+
+	objective, err := r.meter.Float64ObservableGauge(
+		metricSLOObjective,
+		metric.WithDescription("Objective ratio of good requests of an SLO"),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create SLO objective gauge: %w", err)
+	}
+	if _, err = r.meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		for _, slo := range r.slos {
+			o.ObserveFloat64(objective, slo.Target, metric.WithAttributes(attribute.String("slo", slo.Name)))
+		}
+		return nil
+	}, objective); err != nil {
+		return fmt.Errorf("failed to register SLO objective callback: %w", err)
+	}
+
+	return nil
+}
+
+// recordSLOs counts a finished request toward each SLO that covers route.
+func (r *Recorder) recordSLOs(ctx context.Context, statusCode int, duration float64, route string) {
+	for _, slo := range r.slos {
+		if !slo.covers(route) {
+			continue
+		}
+		attrs := metric.WithAttributes(attribute.String("slo", slo.Name))
+		r.sloRequests.Add(ctx, 1, attrs)
+		if slo.isGood(statusCode, duration) {
+			r.sloGoodRequests.Add(ctx, 1, attrs)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithSLO(t *testing.T) {
+	t.Parallel()
+
+	recorder := TestingRecorderWithPrometheus(t, "slo-test",
+		WithSLO(SLO{Name: "availability", Target: 0.999}),
+		WithSLO(SLO{Name: "checkout-latency", Target: 0.99, LatencyThreshold: time.Hour, Routes: []string{"/checkout"}}),
+	)
+
+	handler := Middleware(recorder)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/fail":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case "/missing":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	for _, path := range []string{"/checkout", "/checkout", "/fail", "/missing"} {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	body := scrape(t, recorder)
+	assertSeries(t, body, "slo_requests_total", `slo="availability"`, "4")
+	assertSeries(t, body, "slo_requests_good_total", `slo="availability"`, "3")
+	assertSeries(t, body, "slo_requests_total", `slo="checkout-latency"`, "2")
+	assertSeries(t, body, "slo_requests_good_total", `slo="checkout-latency"`, "2")
+	assertSeries(t, body, "slo_objective_ratio", `slo="availability"`, "0.999")
+}
+
+// assertSeries asserts that the metric name has a series with label and value.
+func assertSeries(t *testing.T, body, name, label, value string) {
+	t.Helper()
+
+	for _, line := range seriesLines(body, name) {
+		if strings.Contains(line, label) {
+			assert.True(t, strings.HasSuffix(line, " "+value), "%s: got %q, want value %s", name, line, value)
+			return
+		}
+	}
+	t.Errorf("%s: no series with %s", name, label)
+}
+
+func TestSLO_IsGood(t *testing.T) {
+	t.Parallel()
+
+	latency := SLO{Name: "latency", Target: 0.99, LatencyThreshold: 300 * time.Millisecond}
+	availability := SLO{Name: "availability", Target: 0.99}
+
+	assert.True(t, latency.isGood(http.StatusOK, 0.3))
+	assert.False(t, latency.isGood(http.StatusOK, 0.31))
+	assert.True(t, latency.isGood(http.StatusNotFound, 0.1), "client errors count as good")
+	assert.False(t, latency.isGood(http.StatusInternalServerError, 0.1))
+	assert.True(t, availability.isGood(http.StatusOK, 60))
+}
+
+func TestSLO_Validation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		slos    []SLO
+		wantErr string
+	}{
+		{name: "empty name", slos: []SLO{{Target: 0.99}}, wantErr: "invalid SLO name"},
+		{name: "invalid name", slos: []SLO{{Name: "a b", Target: 0.99}}, wantErr: "invalid SLO name"},
+		{name: "target of 1", slos: []SLO{{Name: "api", Target: 1}}, wantErr: "target must be between 0 and 1"},
+		{name: "zero target", slos: []SLO{{Name: "api"}}, wantErr: "target must be between 0 and 1"},
+		{name: "negative threshold", slos: []SLO{{Name: "api", Target: 0.9, LatencyThreshold: -time.Second}}, wantErr: "cannot be negative"},
+		{name: "duplicate", slos: []SLO{{Name: "api", Target: 0.9}, {Name: "api", Target: 0.99}}, wantErr: "duplicate SLO name"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			opts := []Option{WithStdout()}
+			for _, slo := range tt.slos {
+				opts = append(opts, WithSLO(slo))
+			}
+			_, err := New(opts...)
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestSLO_PrometheusRules(t *testing.T) {
+	t.Parallel()
+
+	rules := SLO{Name: "checkout", Target: 0.999}.PrometheusRules()
+
+	assert.True(t, strings.HasPrefix(rules, "groups:\n  - name: slo-checkout\n"))
+	for _, window := range []string{"5m", "30m", "1h", "2h", "6h", "1d", "3d"} {
+		assert.Equal(t, 1, strings.Count(rules, "record: slo:error_ratio:rate"+window+"\n"), "window %s", window)
+	}
+	assert.Contains(t, rules,
+		`expr: 1 - (sum(rate(slo_requests_good_total{slo="checkout"}[5m])) / sum(rate(slo_requests_total{slo="checkout"}[5m])))`)
+	assert.Contains(t, rules,
+		`expr: slo:error_ratio:rate1h{slo="checkout"} > (14.4 * 0.001) and slo:error_ratio:rate5m{slo="checkout"} > (14.4 * 0.001)`)
+	assert.Equal(t, 4, strings.Count(rules, "alert: SLOErrorBudgetBurn"))
+	assert.Equal(t, 2, strings.Count(rules, "severity: page"))
+}
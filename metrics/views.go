@@ -0,0 +1,77 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// Temporality is how push providers report sums and histograms: as totals
+// since the start of the process, or as changes since the last export.
+type Temporality int
+
+const (
+	// CumulativeTemporality reports totals since the process started (default).
+	CumulativeTemporality Temporality = iota
+	// DeltaTemporality reports changes since the last export for counters and
+	// histograms, as some backends such as Datadog expect. Up-down counters
+	// stay cumulative, since their changes alone are meaningless.
+	DeltaTemporality
+)
+
+// String returns the name of the temporality.
+func (t Temporality) String() string {
+	switch t {
+	case CumulativeTemporality:
+		return "cumulative"
+	case DeltaTemporality:
+		return "delta"
+	default:
+		return "unknown"
+	}
+}
+
+// selector returns the temporality selector for exporters.
+func (t Temporality) selector() sdkmetric.TemporalitySelector {
+	if t != DeltaTemporality {
+		return sdkmetric.DefaultTemporalitySelector
+	}
+
+	return func(kind sdkmetric.InstrumentKind) metricdata.Temporality {
+		switch kind {
+		case sdkmetric.InstrumentKindUpDownCounter, sdkmetric.InstrumentKindObservableUpDownCounter:
+			return metricdata.CumulativeTemporality
+		default:
+			return metricdata.DeltaTemporality
+		}
+	}
+}
+
+// dropView returns a view that drops the instruments matching name.
+func dropView(name string) sdkmetric.View {
+	return sdkmetric.NewView(
+		sdkmetric.Instrument{Name: name},
+		sdkmetric.Stream{Aggregation: sdkmetric.AggregationDrop{}},
+	)
+}
+
+// renameView returns a view that exports the instrument from as to.
+func renameView(from, to string) sdkmetric.View {
+	return sdkmetric.NewView(
+		sdkmetric.Instrument{Name: from},
+		sdkmetric.Stream{Name: to},
+	)
+}
@@ -0,0 +1,135 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestWithDroppedMetrics(t *testing.T) {
+	t.Parallel()
+
+	recorder := TestingRecorderWithPrometheus(t, "drop-test",
+		WithDroppedMetrics("http_request_size_bytes", "debug_*"),
+	)
+	ctx := t.Context()
+
+	serveRawPaths(t, recorder, "/")
+	require.NoError(t, recorder.IncrementCounter(ctx, "debug_cache_misses"))
+	require.NoError(t, recorder.IncrementCounter(ctx, "orders"))
+
+	body := scrape(t, recorder)
+	assert.NotContains(t, body, "http_request_size_bytes")
+	assert.NotContains(t, body, "debug_cache_misses")
+	assert.Contains(t, body, "orders_total")
+	assert.Contains(t, body, "http_requests_total")
+}
+
+func TestWithRenamedMetric(t *testing.T) {
+	t.Parallel()
+
+	recorder := TestingRecorderWithPrometheus(t, "rename-test",
+		WithRenamedMetric("http_requests_total", "api_requests_total"),
+		WithLabelAllowlist("http_requests_total", "http.method"),
+	)
+	serveRawPaths(t, recorder, "/")
+
+	body := scrape(t, recorder)
+	assert.NotContains(t, body, "\nhttp_requests_total{")
+	counts := seriesLines(body, "api_requests_total")
+	require.Len(t, counts, 1)
+	assert.NotContains(t, counts[0], "http_route", "options should still refer to the original name")
+}
+
+func TestWithView(t *testing.T) {
+	t.Parallel()
+
+	recorder := TestingRecorderWithPrometheus(t, "view-test",
+		WithView(sdkmetric.NewView(
+			sdkmetric.Instrument{Name: "job_duration"},
+			sdkmetric.Stream{Aggregation: sdkmetric.AggregationExplicitBucketHistogram{
+				Boundaries: []float64{1, 60},
+			}},
+		)),
+	)
+	require.NoError(t, recorder.RecordHistogram(t.Context(), "job_duration", 30))
+
+	buckets := seriesLines(scrape(t, recorder), "job_duration_bucket")
+	assert.Len(t, buckets, 3, "two boundaries and +Inf")
+}
+
+func TestTemporality_Selector(t *testing.T) {
+	t.Parallel()
+
+	cumulative := CumulativeTemporality.selector()
+	delta := DeltaTemporality.selector()
+
+	for _, kind := range []sdkmetric.InstrumentKind{
+		sdkmetric.InstrumentKindCounter,
+		sdkmetric.InstrumentKindHistogram,
+		sdkmetric.InstrumentKindObservableCounter,
+	} {
+		assert.Equal(t, metricdata.CumulativeTemporality, cumulative(kind))
+		assert.Equal(t, metricdata.DeltaTemporality, delta(kind))
+	}
+	assert.Equal(t, metricdata.CumulativeTemporality, delta(sdkmetric.InstrumentKindUpDownCounter))
+	assert.Equal(t, metricdata.CumulativeTemporality, delta(sdkmetric.InstrumentKindObservableUpDownCounter))
+
+	assert.Equal(t, "delta", DeltaTemporality.String())
+}
+
+func TestWithTemporality_Stdout(t *testing.T) {
+	t.Parallel()
+
+	recorder := TestingRecorder(t, "delta-test", WithTemporality(DeltaTemporality))
+	require.NoError(t, recorder.IncrementCounter(t.Context(), "events"))
+}
+
+func TestViews_Validation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		opts    []Option
+		wantErr string
+	}{
+		{name: "nil view", opts: []Option{WithView(nil)}, wantErr: "view cannot be nil"},
+		{name: "empty dropped name", opts: []Option{WithDroppedMetrics("")}, wantErr: "dropped metric name cannot be empty"},
+		{name: "empty rename", opts: []Option{WithRenamedMetric("orders", "")}, wantErr: "renamed metric names cannot be empty"},
+		{name: "wildcard rename", opts: []Option{WithRenamedMetric("orders_*", "sales")}, wantErr: "cannot contain wildcards"},
+		{
+			name:    "delta with Prometheus",
+			opts:    []Option{WithPrometheus(":0", "/metrics"), WithTemporality(DeltaTemporality)},
+			wantErr: "delta temporality is not supported by the prometheus provider",
+		},
+		{name: "unknown temporality", opts: []Option{WithStdout(), WithTemporality(Temporality(7))}, wantErr: "unsupported temporality"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := New(tt.opts...)
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
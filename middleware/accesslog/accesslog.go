@@ -17,10 +17,16 @@ package accesslog
 import (
 	"crypto/sha256"
 	"encoding/binary"
+	"log/slog"
 	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
+	"go.opentelemetry.io/otel/trace"
+
 	"rivaas.dev/router"
 )
 
@@ -53,6 +59,35 @@ func New(opts ...Option) router.HandlerFunc {
 		opt(cfg)
 	}
 
+	// Validate status sample rate keys
+	for status := range cfg.statusSampleRates {
+		if (status < 1 || status > 5) && (status < 100 || status > 599) {
+			panic("accesslog: invalid status or status class " + strconv.Itoa(status))
+		}
+	}
+
+	// Set up body capture
+	var capture *bodyCapture
+	if cfg.bodyCaptureMax > 0 {
+		contentTypes := cfg.bodyContentTypes
+		if contentTypes == nil {
+			contentTypes = defaultBodyContentTypes
+		}
+		capture = newBodyCapture(contentTypes, append(slices.Clone(defaultRedactFields), cfg.redactFields...))
+	}
+
+	redact := newRedactor(cfg)
+
+	// Compile the line format
+	var format *lineFormat
+	if cfg.formatWriter != nil {
+		segments, err := compileFormat(cfg.format)
+		if err != nil {
+			panic("accesslog: " + err.Error())
+		}
+		format = &lineFormat{segments: segments, w: cfg.formatWriter}
+	}
+
 	return func(c *router.Context) {
 		path := c.Request.URL.Path
 
@@ -84,6 +119,18 @@ func New(opts ...Option) router.HandlerFunc {
 			ss = wrapped
 		}
 
+		// Record the first bytes of the bodies, for error responses
+		var reqBody *captureReader
+		var respBody *captureWriter
+		if capture != nil {
+			if c.Request.Body != nil && c.Request.Body != http.NoBody && capture.allows(c.Request.Header) {
+				reqBody = &captureReader{ReadCloser: c.Request.Body, body: capturedBody{max: cfg.bodyCaptureMax}}
+				c.Request.Body = reqBody
+			}
+			respBody = &captureWriter{ResponseWriter: c.Response, body: capturedBody{max: cfg.bodyCaptureMax}}
+			c.Response = respBody
+		}
+
 		// CRITICAL FIX: Execute handler FIRST
 		c.Next()
 
@@ -98,18 +145,22 @@ func New(opts ...Option) router.HandlerFunc {
 		isError := status >= 400
 		isSlow := cfg.slowThreshold > 0 && duration >= cfg.slowThreshold
 
-		if !isError && !isSlow {
-			// Normal request - apply filters
-			if cfg.logErrorsOnly {
-				shouldLog = false
-			} else if cfg.sampleRate < 1.0 {
-				if cfg.requestIDFunc != nil {
-					// Deterministic: hash-based sampling by request ID
-					shouldLog = sampleByHash(cfg.requestIDFunc(c), cfg.sampleRate)
-				} else {
-					// Random: probabilistic sampling (not security-sensitive)
-					//nolint:gosec // G404: Using math/rand/v2 for sampling is appropriate here
-					shouldLog = rand.Float64() < cfg.sampleRate
+		switch {
+		case cfg.condition != nil && !cfg.condition(c, status, duration):
+			// The condition has the final say
+			shouldLog = false
+		case isSlow:
+			// Slow requests are always logged
+		default:
+			if rate, ok := cfg.statusSampleRate(status); ok {
+				// Explicit rate for the status or its class
+				shouldLog = cfg.sample(c, rate)
+			} else if !isError {
+				// Normal request - apply filters
+				if cfg.logErrorsOnly {
+					shouldLog = false
+				} else if cfg.sampleRate < 1.0 {
+					shouldLog = cfg.sample(c, cfg.sampleRate)
 				}
 			}
 		}
@@ -118,47 +169,200 @@ func New(opts ...Option) router.HandlerFunc {
 			return
 		}
 
-		// Get logger from config (returns nil if not configured)
-		logger := cfg.logger
-		if logger == nil {
-			// No logger configured, skip logging
+		// No logger configured, skip logging
+		if cfg.logger == nil && cfg.otelLogger == nil && format == nil {
 			return
 		}
 
-		// Build log fields
-		fields := []any{
-			"method", c.Request.Method,
-			"path", path,
-			"status", status,
-			"duration_ms", duration.Milliseconds(),
-			"bytes_sent", ss.Size(),
-			"user_agent", c.Request.UserAgent(),
-			"client_ip", c.ClientIP(),
-			"host", c.Request.Host,
-			"proto", c.Request.Proto,
+		// Redact sensitive parts before the record is emitted
+		route := c.RoutePattern()
+		query := redact.query(c.Request.URL.RawQuery)
+		requestURI := redact.path(c.Request.URL.EscapedPath(), route)
+		if query != "" {
+			requestURI += "?" + query
 		}
 
-		// Add route pattern (including sentinels)
-		if routePattern := c.RoutePattern(); routePattern != "" {
-			fields = append(fields, "route", routePattern)
+		e := entry{
+			start:      start,
+			request:    c.Request,
+			method:     c.Request.Method,
+			path:       redact.path(path, route),
+			route:      route,
+			requestURI: requestURI,
+			query:      query,
+			status:     status,
+			duration:   duration,
+			bytesSent:  ss.Size(),
+			userAgent:  c.Request.UserAgent(),
+			clientIP:   redact.clientIP(c.ClientIP()),
+			host:       c.Request.Host,
+			proto:      c.Request.Proto,
+			slow:       isSlow,
+			redact:     redact,
+
+			responseHeader: c.Response.Header(),
 		}
 
-		if isSlow {
-			fields = append(fields, "slow", true)
+		// Correlate with the request ID and the trace
+		if cfg.requestIDFunc != nil {
+			e.requestID = cfg.requestIDFunc(c)
+		}
+		if sc := trace.SpanContextFromContext(c.Request.Context()); sc.IsValid() {
+			e.traceID = sc.TraceID().String()
+			e.spanID = sc.SpanID().String()
 		}
 
-		// Log at appropriate level
-		switch {
-		case status >= 500:
-			logger.Error("http request", fields...)
-		case status >= 400:
-			logger.Warn("http request", fields...)
-		case isSlow:
-			logger.Warn("http request", fields...)
-		default:
-			logger.Info("http request", fields...)
+		if isError && capture != nil {
+			if reqBody != nil && len(reqBody.body.data) > 0 {
+				e.requestBody = capture.redact(c.Request.Header, reqBody.body.data)
+				e.requestBodyTruncated = reqBody.body.truncated
+			}
+			if len(respBody.body.data) > 0 && capture.allows(respBody.Header()) {
+				e.responseBody = capture.redact(respBody.Header(), respBody.body.data)
+				e.responseBodyTruncated = respBody.body.truncated
+			}
+		}
+
+		if cfg.logger != nil {
+			logSlog(cfg.logger, e)
+		}
+		if cfg.otelLogger != nil {
+			emitRecord(c.Request.Context(), cfg.otelLogger, e)
+		}
+		if format != nil {
+			format.write(&e)
+		}
+	}
+}
+
+// entry holds the fields of one access log record.
+type entry struct {
+	start      time.Time
+	request    *http.Request
+	method     string
+	path       string // Redacted
+	route      string // Route pattern, including sentinels; may be empty
+	requestURI string // Redacted
+	query      string // Redacted raw query
+	status     int
+	duration   time.Duration
+	bytesSent  int64
+	userAgent  string
+	clientIP   string // Anonymized if enabled
+	host       string
+	proto      string
+	slow       bool
+	requestID  string // From WithRequestIDFunc; may be empty
+	traceID    string // Of the active span; may be empty
+	spanID     string
+
+	responseHeader http.Header
+	redact         *redactor
+
+	// Captured bodies of error responses, redacted
+	requestBody           string
+	requestBodyTruncated  bool
+	responseBody          string
+	responseBodyTruncated bool
+}
+
+// level returns the log level for e: error for 5xx, warn for 4xx and slow
+// requests, info otherwise.
+func (e *entry) level() slog.Level {
+	switch {
+	case e.status >= 500:
+		return slog.LevelError
+	case e.status >= 400, e.slow:
+		return slog.LevelWarn
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// logSlog writes e to logger.
+func logSlog(logger *slog.Logger, e entry) {
+	// Build log fields
+	fields := []any{
+		"method", e.method,
+		"path", e.path,
+		"status", e.status,
+		"duration_ms", e.duration.Milliseconds(),
+		"bytes_sent", e.bytesSent,
+		"user_agent", e.userAgent,
+		"client_ip", e.clientIP,
+		"host", e.host,
+		"proto", e.proto,
+	}
+
+	// Add route pattern (including sentinels)
+	if e.route != "" {
+		fields = append(fields, "route", e.route)
+	}
+
+	if e.slow {
+		fields = append(fields, "slow", true)
+	}
+
+	if e.requestID != "" {
+		fields = append(fields, "request_id", e.requestID)
+	}
+	if e.traceID != "" {
+		fields = append(fields, "trace_id", e.traceID, "span_id", e.spanID)
+	}
+
+	if e.requestBody != "" {
+		fields = append(fields, "request_body", e.requestBody)
+		if e.requestBodyTruncated {
+			fields = append(fields, "request_body_truncated", true)
+		}
+	}
+	if e.responseBody != "" {
+		fields = append(fields, "response_body", e.responseBody)
+		if e.responseBodyTruncated {
+			fields = append(fields, "response_body_truncated", true)
 		}
 	}
+
+	// Log at appropriate level
+	switch e.level() {
+	case slog.LevelError:
+		logger.Error("http request", fields...)
+	case slog.LevelWarn:
+		logger.Warn("http request", fields...)
+	default:
+		logger.Info("http request", fields...)
+	}
+}
+
+// statusSampleRate returns the sample rate set for status with
+// WithStatusSampleRates: for the exact code, or else for its class.
+func (cfg *config) statusSampleRate(status int) (float64, bool) {
+	if len(cfg.statusSampleRates) == 0 {
+		return 0, false
+	}
+	if rate, ok := cfg.statusSampleRates[status]; ok {
+		return rate, true
+	}
+	rate, ok := cfg.statusSampleRates[status/100]
+
+	return rate, ok
+}
+
+// sample makes a sampling decision at rate for the request of c.
+func (cfg *config) sample(c *router.Context, rate float64) bool {
+	switch {
+	case rate >= 1:
+		return true
+	case rate <= 0:
+		return false
+	case cfg.requestIDFunc != nil:
+		// Deterministic: hash-based sampling by request ID
+		return sampleByHash(cfg.requestIDFunc(c), rate)
+	default:
+		// Random: probabilistic sampling (not security-sensitive)
+		//nolint:gosec // G404: Using math/rand/v2 for sampling is appropriate here
+		return rand.Float64() < rate
+	}
 }
 
 // sampleByHash provides deterministic sampling based on a hash of the ID.
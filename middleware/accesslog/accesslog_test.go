@@ -21,6 +21,7 @@ import (
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"sync"
 	"testing"
 	"time"
@@ -921,3 +922,98 @@ func TestAccessLog_ResponseWriterHijack(t *testing.T) {
 	r.ServeHTTP(w, req)
 	assert.Error(t, <-hijackErr)
 }
+
+func TestAccessLog_StatusSampleRates(t *testing.T) {
+	t.Parallel()
+	handler := newTestHandler()
+	r := router.MustNew()
+	r.Use(New(
+		WithLogger(slog.New(handler)),
+		WithStatusSampleRates(map[int]float64{
+			2:   0.0, // No 2xx
+			201: 1.0, // Except 201
+			404: 0.0, // No 404s, although errors are otherwise forced
+		}),
+	))
+	r.GET("/status/:code", func(c *router.Context) {
+		code, _ := strconv.Atoi(c.Param("code")) //nolint:errcheck // Test handler
+		c.Status(code)
+	})
+
+	for _, code := range []int{200, 201, 204, 301, 404, 500} {
+		req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/status/"+strconv.Itoa(code), nil)
+		r.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	var logged []any
+	for _, level := range []slog.Level{slog.LevelInfo, slog.LevelWarn, slog.LevelError} {
+		for _, rec := range handler.getRecords(level) {
+			logged = append(logged, rec.attrs["status"])
+		}
+	}
+	// 301 has no rate and uses the default of logging everything
+	assert.ElementsMatch(t, []any{int64(201), int64(301), int64(500)}, logged)
+}
+
+func TestAccessLog_StatusSampleRatesDeterministic(t *testing.T) {
+	t.Parallel()
+	handler := newTestHandler()
+	r := router.MustNew()
+	r.Use(New(
+		WithLogger(slog.New(handler)),
+		WithStatusSampleRates(map[int]float64{2: 0.5}),
+		WithRequestIDFunc(func(c *router.Context) string { return c.Request.Header.Get("X-Request-ID") }),
+	))
+	r.GET("/test", func(c *router.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	ids := []string{"a", "b", "c", "d", "e", "f", "g", "h"}
+	var want int
+	for _, id := range ids {
+		if sampleByHash(id, 0.5) {
+			want++
+		}
+		req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/test", nil)
+		req.Header.Set("X-Request-ID", id)
+		r.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	assert.Len(t, handler.getRecords(slog.LevelInfo), want)
+}
+
+func TestAccessLog_StatusSampleRatesInvalid(t *testing.T) {
+	t.Parallel()
+	for _, status := range []int{0, 6, 99, 600} {
+		assert.Panics(t, func() {
+			New(WithStatusSampleRates(map[int]float64{status: 1}))
+		}, status)
+	}
+}
+
+func TestAccessLog_Condition(t *testing.T) { //nolint:paralleltest // Uses time.Sleep
+	handler := newTestHandler()
+	r := router.MustNew()
+	r.Use(New(
+		WithLogger(slog.New(handler)),
+		WithSlowThreshold(10*time.Millisecond),
+		WithCondition(func(c *router.Context, _ int, _ time.Duration) bool {
+			return c.Request.UserAgent() != "probe"
+		}),
+	))
+	r.GET("/slow", func(c *router.Context) {
+		time.Sleep(20 * time.Millisecond)
+		c.Status(http.StatusInternalServerError)
+	})
+
+	for _, userAgent := range []string{"probe", "browser"} {
+		req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/slow", nil)
+		req.Header.Set("User-Agent", userAgent)
+		r.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	// The condition filters even slow errors
+	records := handler.getRecords(slog.LevelError)
+	require.Len(t, records, 1)
+	assert.Equal(t, "browser", records[0].attrs["user_agent"])
+}
@@ -0,0 +1,231 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accesslog
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"path"
+	"regexp"
+	"strings"
+
+	"rivaas.dev/router"
+)
+
+// redacted replaces the values of redacted fields.
+const redacted = "[REDACTED]"
+
+// defaultBodyContentTypes are the media types whose bodies are captured by
+// default. Patterns use path.Match syntax.
+var defaultBodyContentTypes = []string{
+	"application/json",
+	"application/*+json",
+	"application/x-www-form-urlencoded",
+	"application/xml",
+	"text/*",
+}
+
+// defaultRedactFields are the JSON and form fields redacted by default.
+var defaultRedactFields = []string{
+	"password", "passwd", "secret", "client_secret",
+	"token", "access_token", "refresh_token", "id_token",
+	"api_key", "apikey", "authorization",
+	"credit_card", "card_number", "cvv", "ssn",
+}
+
+// capturedBody is the first bytes of a request or response body.
+type capturedBody struct {
+	data      []byte
+	max       int
+	truncated bool
+}
+
+// capture appends p to the body, up to the size cap.
+func (b *capturedBody) capture(p []byte) {
+	keep := min(len(p), b.max-len(b.data))
+	b.data = append(b.data, p[:keep]...)
+	if keep < len(p) {
+		b.truncated = true
+	}
+}
+
+// captureReader records what the handler reads from the request body.
+type captureReader struct {
+	io.ReadCloser
+	body capturedBody
+}
+
+func (r *captureReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.body.capture(p[:n])
+
+	return n, err
+}
+
+// captureWriter records what the handler writes to the response body. It
+// forwards the optional interfaces the router and other middleware use.
+type captureWriter struct {
+	http.ResponseWriter
+	body capturedBody
+}
+
+func (w *captureWriter) Write(p []byte) (int, error) {
+	n, err := w.ResponseWriter.Write(p)
+	w.body.capture(p[:n])
+
+	return n, err
+}
+
+// Written implements router.WrittenChecker.
+func (w *captureWriter) Written() bool {
+	if wc, ok := w.ResponseWriter.(router.WrittenChecker); ok {
+		return wc.Written()
+	}
+
+	return false
+}
+
+// Hijack implements http.Hijacker.
+func (w *captureWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if hijacker, ok := w.ResponseWriter.(http.Hijacker); ok {
+		return hijacker.Hijack()
+	}
+
+	return nil, nil, router.ErrResponseWriterNotHijacker
+}
+
+// Flush implements http.Flusher.
+func (w *captureWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap returns the underlying writer for http.ResponseController.
+func (w *captureWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// bodyCapture holds the body capture settings.
+type bodyCapture struct {
+	contentTypes []string
+	fields       map[string]bool // lowercase
+	formField    *regexp.Regexp  // matches "field=value" in form bodies
+	jsonField    *regexp.Regexp  // matches "field": value in truncated JSON
+}
+
+func newBodyCapture(contentTypes, fields []string) *bodyCapture {
+	bc := &bodyCapture{
+		contentTypes: contentTypes,
+		fields:       make(map[string]bool, len(fields)),
+	}
+	if len(fields) == 0 {
+		return bc
+	}
+
+	quoted := make([]string, len(fields))
+	for i, field := range fields {
+		bc.fields[strings.ToLower(field)] = true
+		quoted[i] = regexp.QuoteMeta(field)
+	}
+	names := strings.Join(quoted, "|")
+	bc.formField = regexp.MustCompile(`(?i)((?:^|&)(?:` + names + `)=)[^&]*`)
+	bc.jsonField = regexp.MustCompile(`(?i)("(?:` + names + `)"\s*:\s*)(?:"(?:[^"\\]|\\.)*"?|[^,}\]\s{\[]+)`)
+
+	return bc
+}
+
+// allows reports whether bodies with the headers' content type are captured.
+// Encoded (e.g. compressed) bodies are not.
+func (bc *bodyCapture) allows(header http.Header) bool {
+	if header.Get("Content-Encoding") != "" {
+		return false
+	}
+	mediaType := mediaTypeOf(header)
+	if mediaType == "" {
+		return false
+	}
+	for _, pattern := range bc.contentTypes {
+		if ok, _ := path.Match(pattern, mediaType); ok { //nolint:errcheck // Invalid patterns never match
+			return true
+		}
+	}
+
+	return false
+}
+
+// redact returns the body as a string with the values of redacted fields
+// replaced.
+func (bc *bodyCapture) redact(header http.Header, body []byte) string {
+	if len(bc.fields) == 0 || len(body) == 0 {
+		return string(body)
+	}
+
+	mediaType := mediaTypeOf(header)
+	switch {
+	case mediaType == "application/x-www-form-urlencoded":
+		return bc.formField.ReplaceAllString(string(body), "${1}"+redacted)
+
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		var v any
+		dec := json.NewDecoder(bytes.NewReader(body))
+		dec.UseNumber()
+		if err := dec.Decode(&v); err == nil && !dec.More() {
+			if out, err := json.Marshal(bc.redactJSON(v)); err == nil {
+				return string(out)
+			}
+		}
+		// Truncated or invalid JSON: redact field by field
+		return bc.jsonField.ReplaceAllString(string(body), `${1}"`+redacted+`"`)
+
+	default:
+		return string(body)
+	}
+}
+
+// redactJSON replaces the values of redacted fields at any depth of v.
+func (bc *bodyCapture) redactJSON(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			if bc.fields[strings.ToLower(key)] {
+				v[key] = redacted
+			} else {
+				v[key] = bc.redactJSON(value)
+			}
+		}
+	case []any:
+		for i, value := range v {
+			v[i] = bc.redactJSON(value)
+		}
+	}
+
+	return v
+}
+
+// mediaTypeOf returns the lowercase media type of the Content-Type header.
+func mediaTypeOf(header http.Header) string {
+	mediaType, _, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		return ""
+	}
+
+	return mediaType
+}
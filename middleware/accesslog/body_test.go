@@ -0,0 +1,163 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package accesslog
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"rivaas.dev/router"
+)
+
+// newBodyCaptureRouter returns a router that echoes the request body with
+// the status from the "status" query parameter.
+func newBodyCaptureRouter(t *testing.T, handler *testHandler, opts ...Option) *router.Router {
+	t.Helper()
+	r := router.MustNew()
+	r.Use(New(append([]Option{WithLogger(slog.New(handler))}, opts...)...))
+	r.POST("/echo", func(c *router.Context) {
+		body, err := io.ReadAll(c.Request.Body)
+		require.NoError(t, err)
+		status := http.StatusOK
+		if c.Query("status") == "400" {
+			status = http.StatusBadRequest
+		}
+		c.Response.Header().Set("Content-Type", c.Request.Header.Get("Content-Type"))
+		c.Response.WriteHeader(status)
+		//nolint:errcheck // Test handler
+		c.Response.Write(body)
+	})
+
+	return r
+}
+
+func post(t *testing.T, r *router.Router, url, contentType, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequestWithContext(t.Context(), http.MethodPost, url, strings.NewReader(body))
+	req.Header.Set("Content-Type", contentType)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	return w
+}
+
+func TestAccessLog_BodyCapture(t *testing.T) {
+	t.Parallel()
+	handler := newTestHandler()
+	r := newBodyCaptureRouter(t, handler, WithBodyCapture(1024), WithRedactFields("iban"))
+
+	body := `{"user":"alice","password":"hunter2","account":{"IBAN":"DE89370400440532013000","api_key":"k"},"tags":["a"]}`
+	w := post(t, r, "/echo?status=400", "application/json; charset=utf-8", body)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, body, w.Body.String(), "the client gets the unredacted response")
+
+	fields := handler.getFields(slog.LevelWarn)
+	require.NotNil(t, fields)
+	want := `{"account":{"IBAN":"[REDACTED]","api_key":"[REDACTED]"},"password":"[REDACTED]","tags":["a"],"user":"alice"}`
+	assert.JSONEq(t, want, fields["request_body"].(string))
+	assert.JSONEq(t, want, fields["response_body"].(string))
+	assert.NotContains(t, fields, "request_body_truncated")
+}
+
+func TestAccessLog_BodyCaptureOnlyErrors(t *testing.T) {
+	t.Parallel()
+	handler := newTestHandler()
+	r := newBodyCaptureRouter(t, handler, WithBodyCapture(1024))
+
+	post(t, r, "/echo", "application/json", `{"user":"alice"}`)
+
+	fields := handler.getFields(slog.LevelInfo)
+	require.NotNil(t, fields)
+	assert.NotContains(t, fields, "request_body")
+	assert.NotContains(t, fields, "response_body")
+}
+
+func TestAccessLog_BodyCaptureTruncated(t *testing.T) {
+	t.Parallel()
+	handler := newTestHandler()
+	r := newBodyCaptureRouter(t, handler, WithBodyCapture(40))
+
+	body := `{"user":"alice","token":"abcdefghijklmnopqrstuvwxyz","note":"long"}`
+	w := post(t, r, "/echo?status=400", "application/json", body)
+	assert.Equal(t, body, w.Body.String())
+
+	fields := handler.getFields(slog.LevelWarn)
+	require.NotNil(t, fields)
+	// Truncated JSON is redacted field by field
+	assert.Equal(t, `{"user":"alice","token":"[REDACTED]"`, fields["request_body"])
+	assert.Equal(t, true, fields["request_body_truncated"])
+	assert.Equal(t, true, fields["response_body_truncated"])
+}
+
+func TestAccessLog_BodyCaptureForm(t *testing.T) {
+	t.Parallel()
+	handler := newTestHandler()
+	r := newBodyCaptureRouter(t, handler, WithBodyCapture(1024))
+
+	post(t, r, "/echo?status=400", "application/x-www-form-urlencoded", "user=alice&Password=hunter2&remember=1")
+
+	fields := handler.getFields(slog.LevelWarn)
+	require.NotNil(t, fields)
+	assert.Equal(t, "user=alice&Password=[REDACTED]&remember=1", fields["request_body"])
+}
+
+func TestAccessLog_BodyCaptureContentTypes(t *testing.T) {
+	t.Parallel()
+	handler := newTestHandler()
+	r := newBodyCaptureRouter(t, handler, WithBodyCapture(1024), WithBodyContentTypes("application/*+json"))
+
+	post(t, r, "/echo?status=400", "application/octet-stream", "\x00\x01binary")
+	post(t, r, "/echo?status=400", "application/json", `{"a":1}`)
+	post(t, r, "/echo?status=400", "application/problem+json", `{"title":"bad"}`)
+
+	records := handler.getRecords(slog.LevelWarn)
+	require.Len(t, records, 3)
+	assert.NotContains(t, records[0].attrs, "request_body")
+	assert.NotContains(t, records[1].attrs, "request_body")
+	assert.Equal(t, `{"title":"bad"}`, records[2].attrs["request_body"])
+}
+
+func TestAccessLog_BodyCaptureKeepsWriterInterfaces(t *testing.T) {
+	t.Parallel()
+	r := router.MustNew()
+	r.Use(New(WithBodyCapture(1024)))
+	r.GET("/test", func(c *router.Context) {
+		_, isFlusher := c.Response.(http.Flusher)
+		_, isHijacker := c.Response.(http.Hijacker)
+		wc, isWrittenChecker := c.Response.(router.WrittenChecker)
+		assert.True(t, isFlusher)
+		assert.True(t, isHijacker)
+		require.True(t, isWrittenChecker)
+		assert.False(t, wc.Written())
+
+		//nolint:errcheck // Test handler
+		c.String(http.StatusOK, "ok")
+		assert.True(t, wc.Written())
+	})
+
+	req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/test", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, "ok", w.Body.String())
+}
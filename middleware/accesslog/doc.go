@@ -41,7 +41,12 @@
 //   - ExcludePaths: Paths to exclude from logging (e.g., /health, /metrics)
 //   - Fields: Custom fields to include in logs
 //   - Sampling: Rate-based sampling to reduce log volume
+//   - StatusSampleRates: Sample rates per status code or class
+//   - Condition: Custom predicate deciding whether to log a request
+//   - BodyCapture: Record redacted request/response bodies of errors
 //   - IPAnonymization: Anonymize IP addresses for privacy compliance
+//   - Redaction: Redact or hash sensitive headers, query parameters, and path segments
+//   - LoggerProvider: Emit OpenTelemetry log records, e.g. via OTLP
 //
 // # Log Fields
 //
@@ -51,6 +56,29 @@
 //   - Duration: Request processing time
 //   - ClientIP: Real client IP (handles proxies)
 //   - UserAgent: Client user agent string
-//   - RequestID: Correlation ID from requestid middleware
+//   - RequestID: Correlation ID from requestid middleware, via [WithRequestIDFunc]
+//   - TraceID, SpanID: IDs of the active span, if the request is traced
 //   - Custom fields: User-defined additional fields
+//
+// # Text Formats
+//
+// [WithFormat] writes Apache or nginx style lines, compiled once from a
+// format string. [CommonLogFormat] and [CombinedLogFormat] are predefined:
+//
+//	r.Use(accesslog.New(
+//	    accesslog.WithFormat(`%h %t "%r" %>s %b %{X-Request-ID}i %Dus`, os.Stdout),
+//	))
+//
+// # OpenTelemetry Logs
+//
+// [WithLoggerProvider] also emits each record through an OpenTelemetry
+// logger provider, such as one with an OTLP exporter. Records carry the
+// trace and span IDs of the request context and the provider's resource,
+// and use the OpenTelemetry HTTP semantic conventions for attribute names:
+//
+//	provider := sdklog.NewLoggerProvider(
+//	    sdklog.WithResource(res),
+//	    sdklog.WithProcessor(sdklog.NewBatchProcessor(exporter)),
+//	)
+//	r.Use(accesslog.New(accesslog.WithLoggerProvider(provider)))
 package accesslog
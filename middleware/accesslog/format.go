@@ -0,0 +1,442 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accesslog
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Predefined formats for [WithFormat].
+const (
+	// CommonLogFormat is the Common Log Format of Apache and nginx.
+	CommonLogFormat = `%h %l %u %t "%r" %>s %b`
+
+	// CombinedLogFormat is the Common Log Format with referer and user agent,
+	// the default format of nginx.
+	CombinedLogFormat = `%h %l %u %t "%r" %>s %b "%{Referer}i" "%{User-Agent}i"`
+)
+
+// clfTimeLayout is the time layout of %t and $time_local.
+const clfTimeLayout = "02/Jan/2006:15:04:05 -0700"
+
+// ErrInvalidFormat is returned for format strings with unknown directives or
+// variables.
+var ErrInvalidFormat = errors.New("invalid access log format")
+
+// segment appends one part of a formatted line to buf.
+type segment func(buf []byte, e *entry) []byte
+
+// lineFormat is a compiled format string that writes lines to a writer.
+type lineFormat struct {
+	segments []segment
+
+	mu sync.Mutex // serializes writes to w
+	w  io.Writer
+}
+
+// linePool holds line buffers.
+var linePool = sync.Pool{
+	New: func() any {
+		b := make([]byte, 0, 256)
+		return &b
+	},
+}
+
+// write formats e and writes it to the writer as one line.
+func (f *lineFormat) write(e *entry) {
+	bp, _ := linePool.Get().(*[]byte) //nolint:errcheck // Pool only holds *[]byte
+	buf := (*bp)[:0]
+	for _, seg := range f.segments {
+		buf = seg(buf, e)
+	}
+	buf = append(buf, '\n')
+
+	f.mu.Lock()
+	//nolint:errcheck // Access logging is best effort
+	f.w.Write(buf)
+	f.mu.Unlock()
+
+	*bp = buf
+	linePool.Put(bp)
+}
+
+// compileFormat compiles format into segments. It understands Apache
+// mod_log_config directives ("%h", "%{User-Agent}i") and nginx variables
+// ("$remote_addr", "$http_user_agent").
+func compileFormat(format string) ([]segment, error) {
+	var segments []segment
+	var literal strings.Builder
+	flush := func() {
+		if literal.Len() > 0 {
+			text := literal.String()
+			segments = append(segments, func(buf []byte, _ *entry) []byte {
+				return append(buf, text...)
+			})
+			literal.Reset()
+		}
+	}
+
+	for i := 0; i < len(format); i++ {
+		switch format[i] {
+		case '%':
+			seg, n, err := parseDirective(format[i+1:])
+			if err != nil {
+				return nil, fmt.Errorf("%w: %w at offset %d", ErrInvalidFormat, err, i)
+			}
+			if seg == nil {
+				// "%%"
+				literal.WriteByte('%')
+			} else {
+				flush()
+				segments = append(segments, seg)
+			}
+			i += n
+
+		case '$':
+			seg, n, err := parseVariable(format[i+1:])
+			if err != nil {
+				return nil, fmt.Errorf("%w: %w at offset %d", ErrInvalidFormat, err, i)
+			}
+			if seg == nil {
+				// A '$' not followed by a name
+				literal.WriteByte('$')
+			} else {
+				flush()
+				segments = append(segments, seg)
+			}
+			i += n
+
+		default:
+			literal.WriteByte(format[i])
+		}
+	}
+	flush()
+
+	return segments, nil
+}
+
+// parseDirective parses an Apache directive after the '%'. It returns the
+// segment (nil for "%%") and the number of bytes consumed.
+func parseDirective(s string) (segment, int, error) {
+	n := 0
+	var arg string
+	if n < len(s) && s[n] == '{' {
+		end := strings.IndexByte(s, '}')
+		if end < 0 {
+			return nil, 0, errors.New("unterminated %{")
+		}
+		arg = s[1:end]
+		n = end + 1
+	}
+	// Skip the original/final request modifiers of %<s and %>s
+	for n < len(s) && (s[n] == '<' || s[n] == '>') {
+		n++
+	}
+	if n >= len(s) {
+		return nil, 0, errors.New("incomplete directive")
+	}
+	directive := s[n]
+	n++
+
+	if arg != "" {
+		switch directive {
+		case 'i':
+			return requestHeader(arg), n, nil
+		case 'o':
+			return responseHeader(arg), n, nil
+		case 't':
+			seg, err := timeDirective(arg)
+			return seg, n, err
+		case 'T':
+			seg, err := durationDirective(arg)
+			return seg, n, err
+		default:
+			return nil, 0, fmt.Errorf("unknown directive %%{%s}%c", arg, directive)
+		}
+	}
+
+	switch directive {
+	case '%':
+		return nil, n, nil
+	case 'a', 'h':
+		return appendClientIP, n, nil
+	case 'l':
+		return appendDash, n, nil
+	case 'u':
+		return appendRemoteUser, n, nil
+	case 't':
+		return appendTimeLocal, n, nil
+	case 'r':
+		return appendRequestLine, n, nil
+	case 's':
+		return appendStatus, n, nil
+	case 'b':
+		return appendBytesCLF, n, nil
+	case 'B':
+		return appendBytes, n, nil
+	case 'D':
+		return appendMicros, n, nil
+	case 'T':
+		return appendSeconds, n, nil
+	case 'm':
+		return appendMethod, n, nil
+	case 'U':
+		return appendPath, n, nil
+	case 'q':
+		return appendQuery, n, nil
+	case 'H':
+		return appendProto, n, nil
+	case 'v', 'V':
+		return appendHost, n, nil
+	default:
+		return nil, 0, fmt.Errorf("unknown directive %%%c", directive)
+	}
+}
+
+// timeDirective returns the segment of %{sec}t, %{msec}t, or %{usec}t.
+func timeDirective(arg string) (segment, error) {
+	switch arg {
+	case "sec":
+		return func(buf []byte, e *entry) []byte { return strconv.AppendInt(buf, e.start.Unix(), 10) }, nil
+	case "msec":
+		return func(buf []byte, e *entry) []byte { return strconv.AppendInt(buf, e.start.UnixMilli(), 10) }, nil
+	case "usec":
+		return func(buf []byte, e *entry) []byte { return strconv.AppendInt(buf, e.start.UnixMicro(), 10) }, nil
+	default:
+		return nil, fmt.Errorf("unsupported time format %%{%s}t", arg)
+	}
+}
+
+// durationDirective returns the segment of %{s}T, %{ms}T, or %{us}T.
+func durationDirective(arg string) (segment, error) {
+	switch arg {
+	case "s":
+		return appendSeconds, nil
+	case "ms":
+		return func(buf []byte, e *entry) []byte { return strconv.AppendInt(buf, e.duration.Milliseconds(), 10) }, nil
+	case "us":
+		return appendMicros, nil
+	default:
+		return nil, fmt.Errorf("unsupported duration unit %%{%s}T", arg)
+	}
+}
+
+// nginxVariables are the supported nginx variables without a name suffix.
+var nginxVariables = map[string]segment{
+	"remote_addr":     appendClientIP,
+	"remote_user":     appendRemoteUser,
+	"time_local":      appendTimeLocalBare,
+	"time_iso8601":    appendTimeISO8601,
+	"msec":            appendUnixSeconds,
+	"request":         appendRequestLine,
+	"status":          appendStatus,
+	"body_bytes_sent": appendBytes,
+	"request_time":    appendRequestTime,
+	"request_method":  appendMethod,
+	"request_uri":     appendRequestURI,
+	"uri":             appendPath,
+	"args":            appendArgs,
+	"query_string":    appendArgs,
+	"server_protocol": appendProto,
+	"host":            appendHost,
+}
+
+// parseVariable parses an nginx variable after the '$', either "$name" or
+// "${name}". It returns nil and no error if no name follows.
+func parseVariable(s string) (segment, int, error) {
+	var name string
+	var n int
+	if strings.HasPrefix(s, "{") {
+		end := strings.IndexByte(s, '}')
+		if end < 0 {
+			return nil, 0, errors.New("unterminated ${")
+		}
+		name, n = s[1:end], end+1
+	} else {
+		// Names start with a letter or underscore
+		for n < len(s) && isVariableByte(s[n]) && (n > 0 || s[n] > '9') {
+			n++
+		}
+		name = s[:n]
+	}
+	if name == "" {
+		return nil, n, nil
+	}
+
+	if seg, ok := nginxVariables[name]; ok {
+		return seg, n, nil
+	}
+	if header, ok := strings.CutPrefix(name, "http_"); ok && header != "" {
+		return requestHeader(strings.ReplaceAll(header, "_", "-")), n, nil
+	}
+	if header, ok := strings.CutPrefix(name, "sent_http_"); ok && header != "" {
+		return responseHeader(strings.ReplaceAll(header, "_", "-")), n, nil
+	}
+
+	return nil, 0, fmt.Errorf("unknown variable $%s", name)
+}
+
+func isVariableByte(b byte) bool {
+	return b == '_' || '0' <= b && b <= '9' || 'a' <= b && b <= 'z' || 'A' <= b && b <= 'Z'
+}
+
+// requestHeader returns a segment for the request header name, or "-".
+func requestHeader(name string) segment {
+	name = http.CanonicalHeaderKey(name)
+	return func(buf []byte, e *entry) []byte {
+		return appendValue(buf, e.redact.header(e.request.Header, name))
+	}
+}
+
+// responseHeader returns a segment for the response header name, or "-".
+func responseHeader(name string) segment {
+	name = http.CanonicalHeaderKey(name)
+	return func(buf []byte, e *entry) []byte {
+		return appendValue(buf, e.redact.header(e.responseHeader, name))
+	}
+}
+
+func appendDash(buf []byte, _ *entry) []byte {
+	return append(buf, '-')
+}
+
+func appendClientIP(buf []byte, e *entry) []byte {
+	return appendValue(buf, e.clientIP)
+}
+
+func appendRemoteUser(buf []byte, e *entry) []byte {
+	user, _, _ := e.request.BasicAuth()
+	return appendValue(buf, user)
+}
+
+func appendTimeLocal(buf []byte, e *entry) []byte {
+	buf = append(buf, '[')
+	buf = e.start.AppendFormat(buf, clfTimeLayout)
+	return append(buf, ']')
+}
+
+func appendTimeLocalBare(buf []byte, e *entry) []byte {
+	return e.start.AppendFormat(buf, clfTimeLayout)
+}
+
+func appendTimeISO8601(buf []byte, e *entry) []byte {
+	return e.start.AppendFormat(buf, time.RFC3339)
+}
+
+func appendUnixSeconds(buf []byte, e *entry) []byte {
+	return strconv.AppendFloat(buf, float64(e.start.UnixMilli())/1e3, 'f', 3, 64)
+}
+
+func appendRequestLine(buf []byte, e *entry) []byte {
+	buf = appendEscaped(buf, e.method)
+	buf = append(buf, ' ')
+	buf = appendEscaped(buf, e.requestURI)
+	buf = append(buf, ' ')
+	return appendEscaped(buf, e.proto)
+}
+
+func appendStatus(buf []byte, e *entry) []byte {
+	return strconv.AppendInt(buf, int64(e.status), 10)
+}
+
+func appendBytesCLF(buf []byte, e *entry) []byte {
+	if e.bytesSent == 0 {
+		return append(buf, '-')
+	}
+	return strconv.AppendInt(buf, e.bytesSent, 10)
+}
+
+func appendBytes(buf []byte, e *entry) []byte {
+	return strconv.AppendInt(buf, e.bytesSent, 10)
+}
+
+func appendMicros(buf []byte, e *entry) []byte {
+	return strconv.AppendInt(buf, e.duration.Microseconds(), 10)
+}
+
+func appendSeconds(buf []byte, e *entry) []byte {
+	return strconv.AppendInt(buf, int64(e.duration/time.Second), 10)
+}
+
+func appendRequestTime(buf []byte, e *entry) []byte {
+	return strconv.AppendFloat(buf, e.duration.Seconds(), 'f', 3, 64)
+}
+
+func appendMethod(buf []byte, e *entry) []byte {
+	return appendEscaped(buf, e.method)
+}
+
+func appendPath(buf []byte, e *entry) []byte {
+	return appendEscaped(buf, e.path)
+}
+
+func appendRequestURI(buf []byte, e *entry) []byte {
+	return appendEscaped(buf, e.requestURI)
+}
+
+// appendQuery appends the query string with a leading '?', or nothing.
+func appendQuery(buf []byte, e *entry) []byte {
+	if e.query == "" {
+		return buf
+	}
+	buf = append(buf, '?')
+	return appendEscaped(buf, e.query)
+}
+
+// appendArgs appends the query string, or "-".
+func appendArgs(buf []byte, e *entry) []byte {
+	return appendValue(buf, e.query)
+}
+
+func appendProto(buf []byte, e *entry) []byte {
+	return appendEscaped(buf, e.proto)
+}
+
+func appendHost(buf []byte, e *entry) []byte {
+	return appendValue(buf, e.host)
+}
+
+// appendValue appends s escaped, or "-" if s is empty.
+func appendValue(buf []byte, s string) []byte {
+	if s == "" {
+		return append(buf, '-')
+	}
+	return appendEscaped(buf, s)
+}
+
+// appendEscaped appends s with quotes, backslashes, and control characters
+// escaped as in Apache logs, so that clients cannot forge log lines.
+func appendEscaped(buf []byte, s string) []byte {
+	const hex = "0123456789abcdef"
+	for i := range len(s) {
+		b := s[i]
+		switch {
+		case b == '"' || b == '\\':
+			buf = append(buf, '\\', b)
+		case b < 0x20 || b == 0x7f:
+			buf = append(buf, '\\', 'x', hex[b>>4], hex[b&0xf])
+		default:
+			buf = append(buf, b)
+		}
+	}
+	return buf
+}
@@ -0,0 +1,186 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package accesslog
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"rivaas.dev/router"
+)
+
+// testEntry returns an entry for a fixed request.
+func testEntry() *entry {
+	req := httptest.NewRequest(http.MethodGet, "/orders/42?expand=items", nil)
+	req.Header.Set("User-Agent", "curl/8.5.0")
+	req.Header.Set("Referer", "https://example.com/")
+	req.Header.Set("X-Request-Id", "abc-123")
+	req.SetBasicAuth("alice", "secret")
+
+	return &entry{
+		start:          time.Date(2025, time.March, 7, 14, 5, 9, 123_000_000, time.FixedZone("", 3600)),
+		request:        req,
+		method:         req.Method,
+		path:           req.URL.Path,
+		requestURI:     req.RequestURI,
+		query:          req.URL.RawQuery,
+		status:         http.StatusOK,
+		duration:       1534 * time.Microsecond,
+		bytesSent:      2326,
+		userAgent:      req.UserAgent(),
+		clientIP:       "192.0.2.10",
+		host:           "api.example.com",
+		proto:          "HTTP/1.1",
+		responseHeader: http.Header{"Content-Type": []string{"application/json"}},
+		redact:         newRedactor(defaultConfig()),
+	}
+}
+
+func format(t *testing.T, f string, e *entry) string {
+	t.Helper()
+	segments, err := compileFormat(f)
+	require.NoError(t, err)
+	var buf []byte
+	for _, seg := range segments {
+		buf = seg(buf, e)
+	}
+	return string(buf)
+}
+
+func TestCompileFormat(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		format string
+		want   string
+	}{
+		{
+			name:   "common log format",
+			format: CommonLogFormat,
+			want:   `192.0.2.10 - alice [07/Mar/2025:14:05:09 +0100] "GET /orders/42?expand=items HTTP/1.1" 200 2326`,
+		},
+		{
+			name:   "combined log format",
+			format: CombinedLogFormat,
+			want: `192.0.2.10 - alice [07/Mar/2025:14:05:09 +0100] "GET /orders/42?expand=items HTTP/1.1" 200 2326 ` +
+				`"https://example.com/" "curl/8.5.0"`,
+		},
+		{
+			name:   "headers and durations",
+			format: `%h %t "%r" %>s %b %{X-Request-ID}i %Dus %{ms}Tms %Ts`,
+			want:   `192.0.2.10 [07/Mar/2025:14:05:09 +0100] "GET /orders/42?expand=items HTTP/1.1" 200 2326 abc-123 1534us 1ms 0s`,
+		},
+		{
+			name:   "request parts",
+			format: `%m %U%q %H %v %{Content-Type}o %{X-Missing}i %B`,
+			want:   `GET /orders/42?expand=items HTTP/1.1 api.example.com application/json - 2326`,
+		},
+		{
+			name:   "unix times",
+			format: `%{sec}t %{msec}t %{usec}t`,
+			want:   `1741352709 1741352709123 1741352709123000`,
+		},
+		{
+			name:   "literal percent and dollar",
+			format: `100%% $ $1x`,
+			want:   `100% $ $1x`,
+		},
+		{
+			name:   "nginx combined",
+			format: `$remote_addr - $remote_user [$time_local] "$request" $status $body_bytes_sent "$http_referer" "$http_user_agent"`,
+			want: `192.0.2.10 - alice [07/Mar/2025:14:05:09 +0100] "GET /orders/42?expand=items HTTP/1.1" 200 2326 ` +
+				`"https://example.com/" "curl/8.5.0"`,
+		},
+		{
+			name:   "nginx variables",
+			format: `$request_method $uri?$args ${request_time}s $http_x_request_id $sent_http_content_type $time_iso8601 $msec`,
+			want:   `GET /orders/42?expand=items 0.002s abc-123 application/json 2025-03-07T14:05:09+01:00 1741352709.123`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, format(t, tt.format, testEntry()))
+		})
+	}
+}
+
+func TestCompileFormat_Invalid(t *testing.T) {
+	t.Parallel()
+	for _, f := range []string{"%", "%Z", "%{X-Foo", "%{X}z", "%{weeks}T", "%{%d/%m}t", "$unknown", "${host"} {
+		_, err := compileFormat(f)
+		require.ErrorIs(t, err, ErrInvalidFormat, f)
+	}
+
+	assert.Panics(t, func() { New(WithFormat("%Z", &bytes.Buffer{})) })
+}
+
+func TestCompileFormat_Escaping(t *testing.T) {
+	t.Parallel()
+	e := testEntry()
+	e.request.Header.Set("User-Agent", "evil\" 200 0\n192.0.2.99 \\x")
+	e.bytesSent = 0
+
+	assert.Equal(t, `"evil\" 200 0\x0a192.0.2.99 \\x" -`, format(t, `"%{User-Agent}i" %b`, e))
+}
+
+func TestAccessLog_Format(t *testing.T) {
+	t.Parallel()
+	var out bytes.Buffer
+	r := router.MustNew()
+	r.Use(New(WithFormat(`%m %U %>s %b`, &out), WithExcludePaths("/health")))
+	r.GET("/test", func(c *router.Context) {
+		//nolint:errcheck // Test handler
+		c.String(http.StatusCreated, "created")
+	})
+	r.GET("/health", func(c *router.Context) {
+		//nolint:errcheck // Test handler
+		c.String(http.StatusOK, "ok")
+	})
+
+	for _, path := range []string{"/test", "/health", "/test"} {
+		req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, path, nil)
+		r.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	assert.Equal(t, []string{"GET /test 201 7", "GET /test 201 7"}, lines)
+}
+
+func BenchmarkFormat_Combined(b *testing.B) {
+	segments, err := compileFormat(CombinedLogFormat)
+	require.NoError(b, err)
+	f := &lineFormat{segments: segments, w: nopWriter{}}
+	e := testEntry()
+
+	b.ReportAllocs()
+	for b.Loop() {
+		f.write(e)
+	}
+}
+
+type nopWriter struct{}
+
+func (nopWriter) Write(p []byte) (int, error) { return len(p), nil }
@@ -15,9 +15,12 @@
 package accesslog
 
 import (
+	"io"
 	"log/slog"
 	"time"
 
+	"go.opentelemetry.io/otel/log"
+
 	"rivaas.dev/router"
 )
 
@@ -29,6 +32,35 @@ type config struct {
 	// logger is the structured logger for access logs (slog from standard library)
 	logger *slog.Logger
 
+	// otelLogger emits access logs as OpenTelemetry log records
+	otelLogger log.Logger
+
+	// bodyCaptureMax is the number of body bytes captured; 0 disables capture
+	bodyCaptureMax int
+
+	// bodyContentTypes are the media type patterns whose bodies are captured;
+	// nil uses the defaults
+	bodyContentTypes []string
+
+	// redactFields are redacted in captured bodies, besides the defaults
+	redactFields []string
+
+	// redactHeaders, redactQueryParams, and redactPathParams are redacted
+	// besides the defaults
+	redactHeaders     []string
+	redactQueryParams []string
+	redactPathParams  []string
+
+	// redactHashKey replaces redacted values with keyed hashes when set
+	redactHashKey []byte
+
+	// anonymizeIP zeroes the host part of client IPs
+	anonymizeIP bool
+
+	// format is an Apache/nginx-style line format written to formatWriter
+	format       string
+	formatWriter io.Writer
+
 	// excludePaths are exact paths to skip
 	excludePaths map[string]bool
 
@@ -38,9 +70,16 @@ type config struct {
 	// sampleRate samples access logs (1.0 = all, 0.1 = 10%)
 	sampleRate float64
 
-	// requestIDFunc extracts a request ID for deterministic sampling. When nil and sampleRate < 1, random sampling is used.
+	// requestIDFunc extracts a request ID to log and for deterministic sampling. When nil and sampleRate < 1, random sampling is used.
 	requestIDFunc func(*router.Context) string
 
+	// statusSampleRates maps status codes (e.g. 404) and classes (e.g. 2 for
+	// 2xx) to sample rates, overriding sampleRate and forced error logging
+	statusSampleRates map[int]float64
+
+	// condition decides whether to log a request; evaluated before sampling
+	condition func(c *router.Context, status int, duration time.Duration) bool
+
 	// logErrorsOnly only logs requests with status >= 400
 	logErrorsOnly bool
 
@@ -100,8 +139,11 @@ func WithSampleRate(rate float64) Option {
 	}
 }
 
-// WithRequestIDFunc sets a function to extract the request ID for deterministic sampling.
-// When set with WithSampleRate, the same request ID always gets the same sampling decision across replicas.
+// WithRequestIDFunc sets a function to extract the request ID. The ID is logged
+// as "request_id" ("request.id" in OpenTelemetry records), next to the
+// "trace_id" and "span_id" of the active span, so a request ID reported by a
+// client leads to its trace. It is also used for deterministic sampling:
+// when set with WithSampleRate, the same request ID always gets the same sampling decision across replicas.
 // When not set, WithSampleRate uses random sampling.
 //
 // Example:
@@ -118,6 +160,56 @@ func WithRequestIDFunc(fn func(*router.Context) string) Option {
 	}
 }
 
+// WithStatusSampleRates sets sample rates by response status, so that
+// high-traffic services keep the signal while cutting volume. Keys are exact
+// status codes (e.g. 404) or status classes from 1 to 5 (e.g. 2 for 2xx); an
+// exact code takes precedence over its class. Rates are clamped to [0.0, 1.0].
+//
+// A rate set here overrides [WithSampleRate], [WithErrorsOnly], and the
+// forced logging of errors for matching statuses; slow requests (see
+// [WithSlowThreshold]) are still always logged. Statuses without a rate keep
+// the default behavior. New panics on keys that are neither a status code
+// nor a class.
+//
+// Example:
+//
+//	accesslog.New(
+//		accesslog.WithStatusSampleRates(map[int]float64{
+//			5:   1.0,  // All 5xx
+//			404: 0.1,  // 10% of 404s
+//			2:   0.01, // 1% of 2xx
+//		}),
+//	)
+func WithStatusSampleRates(rates map[int]float64) Option {
+	return func(c *config) {
+		if c.statusSampleRates == nil {
+			c.statusSampleRates = make(map[int]float64, len(rates))
+		}
+		for status, rate := range rates {
+			c.statusSampleRates[status] = max(0.0, min(rate, 1.0))
+		}
+	}
+}
+
+// WithCondition sets a predicate that decides, after the handler has run,
+// whether a request is logged at all. Requests for which it returns false
+// are never logged, even errors and slow requests; for those it accepts,
+// sampling applies as usual.
+//
+// Example:
+//
+//	// Skip successful requests from the load balancer's probes
+//	accesslog.New(
+//		accesslog.WithCondition(func(c *router.Context, status int, _ time.Duration) bool {
+//			return status >= 400 || c.Request.UserAgent() != "ELB-HealthChecker/2.0"
+//		}),
+//	)
+func WithCondition(fn func(c *router.Context, status int, duration time.Duration) bool) Option {
+	return func(c *config) {
+		c.condition = fn
+	}
+}
+
 // WithErrorsOnly only logs requests with errors (status >= 400).
 // This is useful for reducing log volume in production while still
 // capturing all errors for debugging.
@@ -176,3 +268,214 @@ func WithLogger(logger *slog.Logger) Option {
 		c.logger = logger
 	}
 }
+
+// WithLoggerProvider emits access logs as OpenTelemetry log records through
+// provider, in addition to the slog logger (if any). Records carry the trace
+// and span IDs of the request's span, when the tracing middleware runs
+// before this one, and the resource attributes of the provider, so access
+// logs land next to traces in the same backend. Attribute names follow the
+// OpenTelemetry HTTP semantic conventions.
+//
+// Example:
+//
+//	import (
+//	    "go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
+//	    sdklog "go.opentelemetry.io/otel/sdk/log"
+//	)
+//
+//	exporter, err := otlploghttp.New(ctx)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	provider := sdklog.NewLoggerProvider(
+//	    sdklog.WithResource(res),
+//	    sdklog.WithProcessor(sdklog.NewBatchProcessor(exporter)),
+//	)
+//	defer provider.Shutdown(ctx)
+//
+//	r.Use(accesslog.New(accesslog.WithLoggerProvider(provider)))
+func WithLoggerProvider(provider log.LoggerProvider) Option {
+	return func(c *config) {
+		if provider == nil {
+			c.otelLogger = nil
+			return
+		}
+		c.otelLogger = provider.Logger(scopeName)
+	}
+}
+
+// WithFormat writes access logs as text lines in an Apache or nginx style
+// format to w, in addition to the slog logger (if any). The format is
+// compiled once; New panics if it is invalid. Lines are written with a single
+// Write call each, serialized across requests. Use [CommonLogFormat] or
+// [CombinedLogFormat] for the standard formats.
+//
+// Apache mod_log_config directives:
+//
+//	%h, %a      Client IP
+//	%l          "-" (remote logname)
+//	%u          Basic auth username, or "-"
+//	%t          Request start time, "[02/Jan/2006:15:04:05 -0700]"
+//	%{sec}t     Request start as Unix time; also msec, usec
+//	%r          Request line, "GET /path?q=1 HTTP/1.1"
+//	%s, %>s     Status code
+//	%b          Response body size, or "-" if empty
+//	%B          Response body size
+//	%D          Duration in microseconds
+//	%T          Duration in seconds; %{ms}T and %{us}T for other units
+//	%m, %U, %q  Method, path, query string with "?"
+//	%H          Protocol
+//	%v, %V      Host
+//	%{Name}i    Request header, or "-"
+//	%{Name}o    Response header, or "-"
+//	%%          A literal "%"
+//
+// nginx variables: $remote_addr, $remote_user, $time_local, $time_iso8601,
+// $msec, $request, $status, $body_bytes_sent, $request_time, $request_method,
+// $request_uri, $uri, $args, $query_string, $server_protocol, $host,
+// $http_<name> (request header), and $sent_http_<name> (response header).
+// Write ${name} to separate a variable from following text.
+//
+// Values from the request are escaped, so that clients cannot forge lines.
+//
+// Example:
+//
+//	accesslog.New(
+//		accesslog.WithFormat(`%h %t "%r" %>s %b %{X-Request-ID}i %Dus`, os.Stdout),
+//	)
+func WithFormat(format string, w io.Writer) Option {
+	return func(c *config) {
+		c.format = format
+		c.formatWriter = w
+	}
+}
+
+// WithBodyCapture records the first maxBytes of the request and response
+// bodies of error responses (status >= 400), for debugging without a
+// separate proxy. Bodies are logged as "request_body" and "response_body",
+// with "request_body_truncated" and "response_body_truncated" set if they
+// were cut off. Only what the handler reads from the request body is
+// recorded. Bodies are captured only for the content types of
+// [WithBodyContentTypes] and when not compressed, and sensitive fields are
+// redacted (see [WithRedactFields]).
+//
+// Capturing copies up to maxBytes per body on every logged path, so keep it
+// small. Captured bodies can still contain personal data; enable with care.
+//
+// Example:
+//
+//	accesslog.New(
+//		accesslog.WithLogger(logger),
+//		accesslog.WithBodyCapture(4096),
+//	)
+func WithBodyCapture(maxBytes int) Option {
+	return func(c *config) {
+		c.bodyCaptureMax = max(maxBytes, 0)
+	}
+}
+
+// WithBodyContentTypes sets the media types whose bodies [WithBodyCapture]
+// records, replacing the defaults: application/json, application/*+json,
+// application/x-www-form-urlencoded, application/xml, and text/*. Patterns
+// use [path.Match] syntax.
+//
+// Example:
+//
+//	accesslog.New(
+//		accesslog.WithBodyCapture(4096),
+//		accesslog.WithBodyContentTypes("application/json", "application/*+json"),
+//	)
+func WithBodyContentTypes(patterns ...string) Option {
+	return func(c *config) {
+		c.bodyContentTypes = append([]string{}, patterns...)
+	}
+}
+
+// WithRedactFields adds fields whose values are replaced with "[REDACTED]"
+// in bodies recorded by [WithBodyCapture]. Field names match JSON object keys
+// at any depth and form fields, case-insensitively. Common secrets such as
+// password, token, api_key, and authorization are always redacted.
+//
+// Example:
+//
+//	accesslog.New(
+//		accesslog.WithBodyCapture(4096),
+//		accesslog.WithRedactFields("iban", "date_of_birth"),
+//	)
+func WithRedactFields(fields ...string) Option {
+	return func(c *config) {
+		c.redactFields = append(c.redactFields, fields...)
+	}
+}
+
+// WithRedactHeaders adds request and response headers whose values are
+// replaced with "[REDACTED]" (or a hash, see [WithRedactionHash]) wherever
+// they are logged, such as %{Name}i in [WithFormat]. Authorization,
+// Proxy-Authorization, Cookie, Set-Cookie, and X-Api-Key are always redacted.
+//
+// Example:
+//
+//	accesslog.New(accesslog.WithRedactHeaders("X-Session-Token"))
+func WithRedactHeaders(names ...string) Option {
+	return func(c *config) {
+		c.redactHeaders = append(c.redactHeaders, names...)
+	}
+}
+
+// WithRedactQueryParams adds query parameters whose values are redacted in
+// logged URLs and query strings. Names match case-insensitively. Common
+// credentials such as token, access_token, api_key, password, and signature
+// (including pre-signed S3 URL parameters) are always redacted.
+//
+// Example:
+//
+//	accesslog.New(accesslog.WithRedactQueryParams("invite_code"))
+//	// GET /join?invite_code=s3cr3t&ref=mail logs as /join?invite_code=[REDACTED]&ref=mail
+func WithRedactQueryParams(names ...string) Option {
+	return func(c *config) {
+		c.redactQueryParams = append(c.redactQueryParams, names...)
+	}
+}
+
+// WithRedactPathParams redacts path segments that match the named route
+// parameters, for routes that carry secrets in the URL. The route pattern
+// itself is logged unchanged.
+//
+// Example:
+//
+//	r.GET("/password-reset/:token", resetHandler)
+//	r.Use(accesslog.New(accesslog.WithRedactPathParams("token")))
+//	// /password-reset/8f14e45f logs as /password-reset/[REDACTED]
+func WithRedactPathParams(names ...string) Option {
+	return func(c *config) {
+		c.redactPathParams = append(c.redactPathParams, names...)
+	}
+}
+
+// WithRedactionHash replaces redacted header, query parameter, and path
+// values with "hmac:" and the first 16 hex digits of their HMAC-SHA256 under
+// key, instead of "[REDACTED]". Equal values get equal hashes, so requests
+// with the same token can be correlated without logging the token. Keep the
+// key secret; without it, short values could be guessed from their hashes.
+//
+// Example:
+//
+//	accesslog.New(accesslog.WithRedactionHash([]byte(os.Getenv("LOG_HASH_KEY"))))
+func WithRedactionHash(key []byte) Option {
+	return func(c *config) {
+		c.redactHashKey = append([]byte{}, key...)
+	}
+}
+
+// WithAnonymizeIP zeroes the host part of logged client IPs: the last octet
+// of IPv4 addresses (192.0.2.10 becomes 192.0.2.0) and the last 80 bits of
+// IPv6 addresses, for privacy regulations such as the GDPR.
+//
+// Example:
+//
+//	accesslog.New(accesslog.WithAnonymizeIP())
+func WithAnonymizeIP() Option {
+	return func(c *config) {
+		c.anonymizeIP = true
+	}
+}
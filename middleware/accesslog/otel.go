@@ -0,0 +1,93 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accesslog
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/log"
+)
+
+// scopeName is the instrumentation scope of access log records.
+const scopeName = "rivaas.dev/middleware/accesslog"
+
+// eventName is the event name of access log records.
+const eventName = "http.server.request"
+
+// emitRecord emits e as an OpenTelemetry log record. The trace and span IDs
+// are taken from the span in ctx, if any; resource attributes are added by
+// the logger provider.
+func emitRecord(ctx context.Context, logger log.Logger, e entry) {
+	var record log.Record
+	record.SetTimestamp(time.Now())
+	record.SetEventName(eventName)
+	record.SetBody(log.StringValue("http request"))
+
+	switch e.level() {
+	case slog.LevelError:
+		record.SetSeverity(log.SeverityError)
+		record.SetSeverityText("ERROR")
+	case slog.LevelWarn:
+		record.SetSeverity(log.SeverityWarn)
+		record.SetSeverityText("WARN")
+	default:
+		record.SetSeverity(log.SeverityInfo)
+		record.SetSeverityText("INFO")
+	}
+
+	// Attribute names follow the OpenTelemetry HTTP semantic conventions
+	record.AddAttributes(
+		log.String("http.request.method", e.method),
+		log.String("url.path", e.path),
+		log.Int("http.response.status_code", e.status),
+		log.Float64("http.server.request.duration", e.duration.Seconds()),
+		log.Int64("http.response.body.size", e.bytesSent),
+		log.String("user_agent.original", e.userAgent),
+		log.String("client.address", e.clientIP),
+		log.String("server.address", e.host),
+	)
+	if name, version, ok := strings.Cut(e.proto, "/"); ok {
+		record.AddAttributes(
+			log.String("network.protocol.name", strings.ToLower(name)),
+			log.String("network.protocol.version", version),
+		)
+	}
+	if e.route != "" {
+		record.AddAttributes(log.String("http.route", e.route))
+	}
+	if e.slow {
+		record.AddAttributes(log.Bool("slow", true))
+	}
+	if e.requestID != "" {
+		record.AddAttributes(log.String("request.id", e.requestID))
+	}
+	if e.requestBody != "" {
+		record.AddAttributes(
+			log.String("http.request.body", e.requestBody),
+			log.Bool("http.request.body.truncated", e.requestBodyTruncated),
+		)
+	}
+	if e.responseBody != "" {
+		record.AddAttributes(
+			log.String("http.response.body", e.responseBody),
+			log.Bool("http.response.body.truncated", e.responseBodyTruncated),
+		)
+	}
+
+	logger.Emit(ctx, record)
+}
@@ -0,0 +1,216 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package accesslog
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/trace"
+
+	"rivaas.dev/router"
+)
+
+// recordingExporter keeps exported log records in memory.
+type recordingExporter struct {
+	mu      sync.Mutex
+	records []sdklog.Record
+}
+
+func (e *recordingExporter) Export(_ context.Context, records []sdklog.Record) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, r := range records {
+		e.records = append(e.records, r.Clone())
+	}
+	return nil
+}
+
+func (e *recordingExporter) Shutdown(context.Context) error   { return nil }
+func (e *recordingExporter) ForceFlush(context.Context) error { return nil }
+
+func (e *recordingExporter) Records() []sdklog.Record {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.records
+}
+
+// attributes returns the attributes of r as a map.
+func attributes(r sdklog.Record) map[string]log.Value {
+	attrs := make(map[string]log.Value)
+	r.WalkAttributes(func(kv log.KeyValue) bool {
+		attrs[kv.Key] = kv.Value
+		return true
+	})
+	return attrs
+}
+
+func TestAccessLog_LoggerProvider(t *testing.T) {
+	t.Parallel()
+	exporter := &recordingExporter{}
+	provider := sdklog.NewLoggerProvider(
+		sdklog.WithResource(resource.NewSchemaless(attribute.String("service.name", "orders"))),
+		sdklog.WithProcessor(sdklog.NewSimpleProcessor(exporter)),
+	)
+	t.Cleanup(func() {
+		//nolint:errcheck // Test cleanup
+		provider.Shutdown(context.Background())
+	})
+
+	traceID := trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36}
+	spanID := trace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7}
+
+	r := router.MustNew()
+	r.Use(func(c *router.Context) {
+		// Stands in for the tracing middleware
+		sc := trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    traceID,
+			SpanID:     spanID,
+			TraceFlags: trace.FlagsSampled,
+		})
+		c.Request = c.Request.WithContext(trace.ContextWithSpanContext(c.Request.Context(), sc))
+		c.Next()
+	})
+	r.Use(New(WithLoggerProvider(provider)))
+	r.GET("/orders/:id", func(c *router.Context) {
+		//nolint:errcheck // Test handler
+		c.String(http.StatusNotFound, "not found")
+	})
+
+	req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/orders/42", nil)
+	req.Header.Set("User-Agent", "test-agent")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	records := exporter.Records()
+	require.Len(t, records, 1)
+	record := records[0]
+
+	assert.Equal(t, traceID, record.TraceID())
+	assert.Equal(t, spanID, record.SpanID())
+	assert.Equal(t, scopeName, record.InstrumentationScope().Name)
+	assert.Equal(t, "http.server.request", record.EventName())
+	assert.Equal(t, log.SeverityWarn, record.Severity())
+	assert.Equal(t, "WARN", record.SeverityText())
+	assert.Equal(t, "http request", record.Body().AsString())
+	assert.False(t, record.Timestamp().IsZero())
+
+	serviceName, ok := record.Resource().Set().Value("service.name")
+	require.True(t, ok)
+	assert.Equal(t, "orders", serviceName.AsString())
+
+	attrs := attributes(record)
+	assert.Equal(t, "GET", attrs["http.request.method"].AsString())
+	assert.Equal(t, "/orders/42", attrs["url.path"].AsString())
+	assert.Equal(t, "/orders/:id", attrs["http.route"].AsString())
+	assert.Equal(t, int64(http.StatusNotFound), attrs["http.response.status_code"].AsInt64())
+	assert.Equal(t, int64(len("not found")), attrs["http.response.body.size"].AsInt64())
+	assert.Equal(t, "test-agent", attrs["user_agent.original"].AsString())
+	assert.Equal(t, "http", attrs["network.protocol.name"].AsString())
+	assert.Equal(t, "1.1", attrs["network.protocol.version"].AsString())
+	assert.Contains(t, attrs, "http.server.request.duration")
+	assert.Contains(t, attrs, "client.address")
+}
+
+func TestAccessLog_LoggerProviderWithSlog(t *testing.T) {
+	t.Parallel()
+	exporter := &recordingExporter{}
+	provider := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(exporter)))
+	handler := newTestHandler()
+
+	r := router.MustNew()
+	r.Use(New(
+		WithLogger(slog.New(handler)),
+		WithLoggerProvider(provider),
+		WithExcludePaths("/health"),
+	))
+	r.GET("/test", func(c *router.Context) {
+		//nolint:errcheck // Test handler
+		c.String(http.StatusOK, "ok")
+	})
+	r.GET("/health", func(c *router.Context) {
+		//nolint:errcheck // Test handler
+		c.String(http.StatusOK, "ok")
+	})
+
+	for _, path := range []string{"/test", "/health"} {
+		req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, path, nil)
+		r.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	// Both outputs get the same, filtered records
+	records := exporter.Records()
+	require.Len(t, records, 1)
+	assert.Equal(t, log.SeverityInfo, records[0].Severity())
+	assert.False(t, records[0].TraceID().IsValid())
+	assert.Len(t, handler.getRecords(slog.LevelInfo), 1)
+}
+
+func TestAccessLog_TraceCorrelation(t *testing.T) {
+	t.Parallel()
+	exporter := &recordingExporter{}
+	provider := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(exporter)))
+	handler := newTestHandler()
+
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+		SpanID:     trace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+		TraceFlags: trace.FlagsSampled,
+	})
+	r := router.MustNew()
+	r.Use(New(
+		WithLogger(slog.New(handler)),
+		WithLoggerProvider(provider),
+		WithRequestIDFunc(func(c *router.Context) string { return c.Request.Header.Get("X-Request-ID") }),
+	))
+	r.GET("/test", func(c *router.Context) {
+		//nolint:errcheck // Test handler
+		c.String(http.StatusOK, "ok")
+	})
+
+	req := httptest.NewRequestWithContext(trace.ContextWithSpanContext(t.Context(), sc), http.MethodGet, "/test", nil)
+	req.Header.Set("X-Request-ID", "ticket-4711")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	fields := handler.getFields(slog.LevelInfo)
+	require.NotNil(t, fields)
+	assert.Equal(t, "ticket-4711", fields["request_id"])
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", fields["trace_id"])
+	assert.Equal(t, "00f067aa0ba902b7", fields["span_id"])
+
+	records := exporter.Records()
+	require.Len(t, records, 1)
+	assert.Equal(t, sc.TraceID(), records[0].TraceID())
+	assert.Equal(t, "ticket-4711", attributes(records[0])["request.id"].AsString())
+
+	// Untraced requests without a request ID get neither field
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/test", nil))
+	infos := handler.getRecords(slog.LevelInfo)
+	require.Len(t, infos, 2)
+	assert.NotContains(t, infos[1].attrs, "request_id")
+	assert.NotContains(t, infos[1].attrs, "trace_id")
+}
@@ -0,0 +1,167 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accesslog
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// defaultRedactHeaders are the headers redacted by default.
+var defaultRedactHeaders = []string{
+	"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie", "X-Api-Key",
+}
+
+// defaultRedactQueryParams are the query parameters redacted by default.
+var defaultRedactQueryParams = []string{
+	"token", "access_token", "refresh_token", "id_token",
+	"api_key", "apikey", "key", "password", "secret", "client_secret",
+	"signature", "sig", "x-amz-signature", "x-amz-credential", "x-amz-security-token",
+}
+
+// redactor replaces sensitive values in headers, query strings, and paths
+// before records are emitted.
+type redactor struct {
+	headers     map[string]bool // canonical header names
+	queryParams map[string]bool // lowercase
+	pathParams  map[string]bool // route parameter names
+	hashKey     []byte          // nil replaces values with "[REDACTED]"
+	anonymizeIP bool
+}
+
+func newRedactor(cfg *config) *redactor {
+	r := &redactor{
+		headers:     make(map[string]bool),
+		queryParams: make(map[string]bool),
+		pathParams:  make(map[string]bool),
+		hashKey:     cfg.redactHashKey,
+		anonymizeIP: cfg.anonymizeIP,
+	}
+	for _, name := range slices.Concat(defaultRedactHeaders, cfg.redactHeaders) {
+		r.headers[http.CanonicalHeaderKey(name)] = true
+	}
+	for _, name := range slices.Concat(defaultRedactQueryParams, cfg.redactQueryParams) {
+		r.queryParams[strings.ToLower(name)] = true
+	}
+	for _, name := range cfg.redactPathParams {
+		r.pathParams[strings.TrimPrefix(name, ":")] = true
+	}
+
+	return r
+}
+
+// replacement returns what a sensitive value is replaced with: a keyed hash,
+// so that equal values can be correlated, or "[REDACTED]".
+func (r *redactor) replacement(value string) string {
+	if r.hashKey == nil {
+		return redacted
+	}
+	mac := hmac.New(sha256.New, r.hashKey)
+	mac.Write([]byte(value))
+
+	return "hmac:" + hex.EncodeToString(mac.Sum(nil)[:8])
+}
+
+// header returns the value of the header name, redacted if it is sensitive.
+func (r *redactor) header(header http.Header, name string) string {
+	value := header.Get(name)
+	if value == "" || !r.headers[name] {
+		return value
+	}
+
+	return r.replacement(value)
+}
+
+// query returns rawQuery with the values of sensitive parameters replaced.
+// Other parameters are kept as they are.
+func (r *redactor) query(rawQuery string) string {
+	if rawQuery == "" {
+		return ""
+	}
+
+	var b strings.Builder
+	b.Grow(len(rawQuery))
+	for i, pair := range strings.Split(rawQuery, "&") {
+		if i > 0 {
+			b.WriteByte('&')
+		}
+		key, value, hasValue := strings.Cut(pair, "=")
+		name, err := url.QueryUnescape(key)
+		if err != nil {
+			name = key
+		}
+		if !hasValue || !r.queryParams[strings.ToLower(name)] {
+			b.WriteString(pair)
+			continue
+		}
+		if unescaped, err := url.QueryUnescape(value); err == nil {
+			value = unescaped
+		}
+		b.WriteString(key)
+		b.WriteByte('=')
+		b.WriteString(r.replacement(value))
+	}
+
+	return b.String()
+}
+
+// path returns path with the segments that match sensitive route parameters
+// of pattern (e.g. ":token" in "/reset/:token") replaced.
+func (r *redactor) path(path, pattern string) string {
+	if len(r.pathParams) == 0 || !strings.Contains(pattern, ":") {
+		return path
+	}
+
+	patternSegments := strings.Split(pattern, "/")
+	segments := strings.Split(path, "/")
+	if len(segments) != len(patternSegments) {
+		return path
+	}
+	changed := false
+	for i, seg := range patternSegments {
+		if name, ok := strings.CutPrefix(seg, ":"); ok && r.pathParams[name] && segments[i] != "" {
+			segments[i] = r.replacement(segments[i])
+			changed = true
+		}
+	}
+	if !changed {
+		return path
+	}
+
+	return strings.Join(segments, "/")
+}
+
+// clientIP returns ip, anonymized if enabled: the last octet of IPv4 and the
+// last 80 bits of IPv6 addresses are zeroed.
+func (r *redactor) clientIP(ip string) string {
+	if !r.anonymizeIP {
+		return ip
+	}
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return ip
+	}
+	if v4 := parsed.To4(); v4 != nil {
+		return v4.Mask(net.CIDRMask(24, 32)).String()
+	}
+
+	return parsed.Mask(net.CIDRMask(48, 128)).String()
+}
@@ -0,0 +1,134 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package accesslog
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"rivaas.dev/router"
+)
+
+func TestRedactor_Query(t *testing.T) {
+	t.Parallel()
+	cfg := defaultConfig()
+	WithRedactQueryParams("invite_code")(cfg)
+	r := newRedactor(cfg)
+
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{query: "", want: ""},
+		{query: "page=2&sort=name", want: "page=2&sort=name"},
+		{query: "invite_code=s3cr3t&ref=mail", want: "invite_code=[REDACTED]&ref=mail"},
+		{query: "Access_Token=abc&flag", want: "Access_Token=[REDACTED]&flag"},
+		{query: "X-Amz-Signature=f00&X-Amz-Date=20250101", want: "X-Amz-Signature=[REDACTED]&X-Amz-Date=20250101"},
+		{query: "api%5Fkey=k&token=", want: "api%5Fkey=[REDACTED]&token=[REDACTED]"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, r.query(tt.query), tt.query)
+	}
+}
+
+func TestRedactor_Path(t *testing.T) {
+	t.Parallel()
+	cfg := defaultConfig()
+	WithRedactPathParams("token", ":key")(cfg)
+	r := newRedactor(cfg)
+
+	assert.Equal(t, "/reset/[REDACTED]", r.path("/reset/8f14e45f", "/reset/:token"))
+	assert.Equal(t, "/keys/[REDACTED]/usage", r.path("/keys/k-123/usage", "/keys/:key/usage"))
+	assert.Equal(t, "/users/42", r.path("/users/42", "/users/:id"))
+	assert.Equal(t, "/files/a/b", r.path("/files/a/b", "/files/*path"))
+	assert.Equal(t, "/reset/x", r.path("/reset/x", ""))
+}
+
+func TestRedactor_Hash(t *testing.T) {
+	t.Parallel()
+	cfg := defaultConfig()
+	WithRedactionHash([]byte("key"))(cfg)
+	r := newRedactor(cfg)
+
+	a := r.query("token=abc")
+	assert.True(t, strings.HasPrefix(a, "token=hmac:"), a)
+	assert.Len(t, strings.TrimPrefix(a, "token=hmac:"), 16)
+	assert.Equal(t, a, r.query("token=abc"), "equal values get equal hashes")
+	assert.NotEqual(t, a, r.query("token=abd"))
+
+	other := defaultConfig()
+	WithRedactionHash([]byte("other key"))(other)
+	assert.NotEqual(t, a, newRedactor(other).query("token=abc"), "hashes depend on the key")
+}
+
+func TestRedactor_ClientIP(t *testing.T) {
+	t.Parallel()
+	cfg := defaultConfig()
+	assert.Equal(t, "192.0.2.10", newRedactor(cfg).clientIP("192.0.2.10"))
+
+	WithAnonymizeIP()(cfg)
+	r := newRedactor(cfg)
+	assert.Equal(t, "192.0.2.0", r.clientIP("192.0.2.10"))
+	assert.Equal(t, "2001:db8:85a3::", r.clientIP("2001:db8:85a3:8d3:1319:8a2e:370:7348"))
+	assert.Equal(t, "unknown", r.clientIP("unknown"))
+}
+
+func TestAccessLog_Redaction(t *testing.T) {
+	t.Parallel()
+	handler := newTestHandler()
+	var out bytes.Buffer
+	r := router.MustNew()
+	r.Use(New(
+		WithLogger(slog.New(handler)),
+		WithFormat(`%h "%r" %U%q "%{Authorization}i" "%{X-Session}i" "%{Set-Cookie}o" $args`, &out),
+		WithRedactHeaders("X-Session"),
+		WithRedactPathParams("token"),
+		WithAnonymizeIP(),
+	))
+	r.GET("/reset/:token", func(c *router.Context) {
+		c.Response.Header().Set("Set-Cookie", "session=abc")
+		//nolint:errcheck // Test handler
+		c.String(http.StatusOK, "ok")
+	})
+
+	req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/reset/8f14e45f?token=abc&lang=en", nil)
+	req.RemoteAddr = "198.51.100.23:4321"
+	req.Header.Set("Authorization", "Bearer xyz")
+	req.Header.Set("X-Session", "s-1")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	assert.Equal(t,
+		`198.51.100.0 "GET /reset/[REDACTED]?token=[REDACTED]&lang=en HTTP/1.1" /reset/[REDACTED]?token=[REDACTED]&lang=en `+
+			`"[REDACTED]" "[REDACTED]" "[REDACTED]" token=[REDACTED]&lang=en`+"\n",
+		out.String())
+
+	fields := handler.getFields(slog.LevelInfo)
+	require.NotNil(t, fields)
+	assert.Equal(t, "/reset/[REDACTED]", fields["path"])
+	assert.Equal(t, "/reset/:token", fields["route"])
+	assert.Equal(t, "198.51.100.0", fields["client_ip"])
+}
@@ -0,0 +1,233 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apikey
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"go.opentelemetry.io/otel/metric"
+
+	"rivaas.dev/router"
+)
+
+// Errors passed to the error handler when a request is rejected.
+var (
+	// ErrKeyMissing is returned when the request carries no API key.
+	ErrKeyMissing = errors.New("apikey: key missing")
+
+	// ErrKeyInvalid is returned when the store does not know the API key.
+	ErrKeyInvalid = errors.New("apikey: key invalid")
+)
+
+type contextKey struct{}
+
+// Option defines functional options for apikey middleware configuration.
+type Option func(*config)
+
+// config holds the configuration for the apikey middleware.
+type config struct {
+	// store looks up keys
+	store Store
+
+	// headerName is the request header carrying the key; empty disables it
+	headerName string
+
+	// queryParam is the query parameter carrying the key; empty disables it
+	queryParam string
+
+	// cookieName is the cookie carrying the key; empty disables it
+	cookieName string
+
+	// errorHandler is called when a request is rejected
+	errorHandler func(c *router.Context, err error)
+
+	// skipPaths are paths that should bypass authentication
+	skipPaths map[string]bool
+
+	// meterProvider provides the meter for authentication metrics
+	meterProvider metric.MeterProvider
+}
+
+// defaultConfig returns the default configuration for apikey middleware.
+func defaultConfig() *config {
+	return &config{
+		headerName:   "X-API-Key",
+		errorHandler: defaultErrorHandler,
+		skipPaths:    make(map[string]bool),
+	}
+}
+
+// defaultErrorHandler sends a 401 Unauthorized response, or 500 Internal
+// Server Error if the store failed.
+func defaultErrorHandler(c *router.Context, err error) {
+	if errors.Is(err, ErrKeyMissing) || errors.Is(err, ErrKeyInvalid) {
+		//nolint:errcheck // Best-effort error response
+		c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "Unauthorized",
+			"code":  "UNAUTHORIZED",
+		})
+		return
+	}
+	c.WriteErrorResponse(http.StatusInternalServerError, "authentication unavailable")
+}
+
+// New returns a middleware that authenticates requests with API keys.
+//
+// The key is read from the X-API-Key header, and from a query parameter or
+// cookie if enabled with [WithQuery] or [WithCookie]. It is looked up in the
+// configured [Store]; requests without a valid key are rejected with 401
+// Unauthorized. On success, the key's description is available through
+// [Info], [Owner], and [Tier].
+//
+// Every authentication attempt is counted in the apikey.authentications
+// metric, by outcome and, for valid keys, tier.
+//
+// New panics if no store is configured.
+//
+// Basic usage:
+//
+//	r := router.MustNew()
+//	r.Use(apikey.New(apikey.WithStore(apikey.StaticKeys(map[string]apikey.Key{
+//	    os.Getenv("PARTNER_API_KEY"): {ID: "partner", Owner: "acme", Tier: "pro"},
+//	}))))
+//
+// With a remote store, cached:
+//
+//	r.Use(apikey.New(
+//	    apikey.WithStore(apikey.NewCachedStore(remoteStore)),
+//	))
+func New(opts ...Option) router.HandlerFunc {
+	cfg := defaultConfig()
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	if cfg.store == nil {
+		panic("apikey: a store is required, use WithStore")
+	}
+
+	m := newAuthMetrics(cfg)
+
+	return func(c *router.Context) {
+		if cfg.skipPaths[c.Request.URL.Path] {
+			c.Next()
+			return
+		}
+
+		ctx := c.Request.Context()
+		secret := cfg.extractKey(c.Request)
+		if secret == "" {
+			m.record(ctx, outcomeMissing, nil)
+			cfg.errorHandler(c, ErrKeyMissing)
+			c.Abort()
+
+			return
+		}
+
+		key, err := cfg.store.Lookup(ctx, secret)
+		if err != nil {
+			m.record(ctx, outcomeError, nil)
+			cfg.errorHandler(c, fmt.Errorf("apikey: lookup: %w", err))
+			c.Abort()
+
+			return
+		}
+		if key == nil {
+			m.record(ctx, outcomeInvalid, nil)
+			cfg.errorHandler(c, ErrKeyInvalid)
+			c.Abort()
+
+			return
+		}
+
+		m.record(ctx, outcomeSuccess, key)
+		c.Request = c.Request.WithContext(context.WithValue(ctx, contextKey{}, key))
+		c.Next()
+	}
+}
+
+// extractKey returns the API key of r from the header, query parameter, or
+// cookie, in that order, or an empty string if there is none.
+func (cfg *config) extractKey(r *http.Request) string {
+	if cfg.headerName != "" {
+		if key := r.Header.Get(cfg.headerName); key != "" {
+			return key
+		}
+	}
+	if cfg.queryParam != "" {
+		if key := r.URL.Query().Get(cfg.queryParam); key != "" {
+			return key
+		}
+	}
+	if cfg.cookieName != "" {
+		if cookie, err := r.Cookie(cfg.cookieName); err == nil {
+			return cookie.Value
+		}
+	}
+
+	return ""
+}
+
+// Info returns the description of the authenticated request's API key, or
+// nil if the request was not authenticated.
+//
+// Example:
+//
+//	func handler(c *router.Context) {
+//	    if key := apikey.Info(c); key != nil {
+//	        log.Printf("request by key %s", key.ID)
+//	    }
+//	}
+func Info(c *router.Context) *Key {
+	key, _ := c.Request.Context().Value(contextKey{}).(*Key)
+	return key
+}
+
+// Owner returns the owner of the authenticated request's API key, or an
+// empty string if the request was not authenticated.
+//
+// Example:
+//
+//	func handler(c *router.Context) {
+//	    orders := store.OrdersOf(apikey.Owner(c))
+//	}
+func Owner(c *router.Context) string {
+	if key := Info(c); key != nil {
+		return key.Owner
+	}
+
+	return ""
+}
+
+// Tier returns the tier of the authenticated request's API key, or an empty
+// string if the request was not authenticated. It can key per-tier rate
+// limits.
+//
+// Example:
+//
+//	if apikey.Tier(c) != "pro" {
+//	    c.WriteErrorResponse(http.StatusForbidden, "upgrade required")
+//	    return
+//	}
+func Tier(c *router.Context) string {
+	if key := Info(c); key != nil {
+		return key.Tier
+	}
+
+	return ""
+}
@@ -0,0 +1,243 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package apikey
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"rivaas.dev/router"
+)
+
+var testKeys = StaticKeys(map[string]Key{
+	"secret-pro":  {ID: "k1", Owner: "acme", Tier: "pro", Metadata: map[string]string{"region": "eu"}},
+	"secret-free": {ID: "k2", Owner: "globex", Tier: "free"},
+})
+
+// echoKey responds with the owner and tier of the key.
+func echoKey(c *router.Context) {
+	//nolint:errcheck // Test handler
+	c.String(http.StatusOK, Owner(c)+"/"+Tier(c))
+}
+
+func TestAPIKey_Header(t *testing.T) {
+	t.Parallel()
+	r := router.MustNew()
+	r.Use(New(WithStore(testKeys)))
+	r.GET("/me", echoKey)
+
+	tests := []struct {
+		name       string
+		key        string
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "pro key", key: "secret-pro", wantStatus: http.StatusOK, wantBody: "acme/pro"},
+		{name: "free key", key: "secret-free", wantStatus: http.StatusOK, wantBody: "globex/free"},
+		{name: "no key", wantStatus: http.StatusUnauthorized},
+		{name: "unknown key", key: "secret-unknown", wantStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/me", nil)
+			if tt.key != "" {
+				req.Header.Set("X-API-Key", tt.key)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantBody != "" {
+				assert.Equal(t, tt.wantBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestAPIKey_Sources(t *testing.T) {
+	t.Parallel()
+	r := router.MustNew()
+	r.Use(New(WithStore(testKeys), WithHeader("Api-Key"), WithQuery("api_key"), WithCookie("api_key")))
+	r.GET("/me", echoKey)
+
+	tests := []struct {
+		name     string
+		prepare  func(req *http.Request)
+		wantBody string
+	}{
+		{name: "header", prepare: func(req *http.Request) { req.Header.Set("Api-Key", "secret-pro") }, wantBody: "acme/pro"},
+		{name: "query", prepare: func(req *http.Request) { req.URL.RawQuery = "api_key=secret-pro" }, wantBody: "acme/pro"},
+		{name: "cookie", prepare: func(req *http.Request) {
+			req.AddCookie(&http.Cookie{Name: "api_key", Value: "secret-pro"})
+		}, wantBody: "acme/pro"},
+		{name: "header before query", prepare: func(req *http.Request) {
+			req.Header.Set("Api-Key", "secret-free")
+			req.URL.RawQuery = "api_key=secret-pro"
+		}, wantBody: "globex/free"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/me", nil)
+			tt.prepare(req)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, tt.wantBody, w.Body.String())
+		})
+	}
+
+	// The default header is replaced
+	req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/me", nil)
+	req.Header.Set("X-API-Key", "secret-pro")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestAPIKey_Info(t *testing.T) {
+	t.Parallel()
+	r := router.MustNew()
+	r.Use(New(WithStore(testKeys)))
+	var got *Key
+	r.GET("/me", func(c *router.Context) {
+		got = Info(c)
+		c.NoContent()
+	})
+
+	req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/me", nil)
+	req.Header.Set("X-API-Key", "secret-pro")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+	require.NotNil(t, got)
+	assert.Equal(t, "k1", got.ID)
+	assert.Equal(t, "eu", got.Metadata["region"])
+
+	c := router.NewContext(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Nil(t, Info(c))
+	assert.Empty(t, Owner(c))
+	assert.Empty(t, Tier(c))
+}
+
+func TestAPIKey_ErrorHandler(t *testing.T) {
+	t.Parallel()
+	var gotErr error
+	handler := WithErrorHandler(func(c *router.Context, err error) {
+		gotErr = err
+		c.WriteErrorResponse(http.StatusTeapot, "")
+	})
+	r := router.MustNew()
+	r.Use(New(WithStore(testKeys), handler))
+	r.GET("/me", echoKey)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/me", nil))
+	assert.Equal(t, http.StatusTeapot, w.Code)
+	require.ErrorIs(t, gotErr, ErrKeyMissing)
+
+	req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/me", nil)
+	req.Header.Set("X-API-Key", "wrong")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+	require.ErrorIs(t, gotErr, ErrKeyInvalid)
+
+	// Store failures are not the client's fault
+	errDown := errors.New("database down")
+	r = router.MustNew()
+	r.Use(New(WithStore(StoreFunc(func(context.Context, string) (*Key, error) {
+		return nil, errDown
+	}))))
+	r.GET("/me", func(c *router.Context) { c.NoContent() })
+	req = httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/me", nil)
+	req.Header.Set("X-API-Key", "secret-pro")
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestAPIKey_SkipPaths(t *testing.T) {
+	t.Parallel()
+	r := router.MustNew()
+	r.Use(New(WithStore(testKeys), WithSkipPaths("/health")))
+	r.GET("/me", echoKey)
+	r.GET("/health", func(c *router.Context) {
+		//nolint:errcheck // Test handler
+		c.String(http.StatusOK, "ok")
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/health", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/me", nil))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestAPIKey_Metrics(t *testing.T) {
+	t.Parallel()
+	reader := sdkmetric.NewManualReader()
+	r := router.MustNew()
+	r.Use(New(WithStore(testKeys), WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))))
+	r.GET("/me", echoKey)
+
+	for _, key := range []string{"secret-pro", "secret-pro", "secret-free", "wrong", ""} {
+		req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/me", nil)
+		if key != "" {
+			req.Header.Set("X-API-Key", key)
+		}
+		r.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(t.Context(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	assert.Equal(t, meterName, rm.ScopeMetrics[0].Scope.Name)
+	require.Len(t, rm.ScopeMetrics[0].Metrics, 1)
+	assert.Equal(t, "apikey.authentications", rm.ScopeMetrics[0].Metrics[0].Name)
+	sum, ok := rm.ScopeMetrics[0].Metrics[0].Data.(metricdata.Sum[int64])
+	require.True(t, ok)
+
+	counts := make(map[string]int64)
+	for _, dp := range sum.DataPoints {
+		outcome, _ := dp.Attributes.Value(attribute.Key("apikey.outcome"))
+		tier, _ := dp.Attributes.Value(attribute.Key("apikey.tier"))
+		counts[outcome.AsString()+"/"+tier.AsString()] = dp.Value
+	}
+	assert.Equal(t, map[string]int64{
+		"success/pro":  2,
+		"success/free": 1,
+		"invalid/":     1,
+		"missing/":     1,
+	}, counts)
+}
+
+func TestNew_PanicsWithoutStore(t *testing.T) {
+	t.Parallel()
+	assert.Panics(t, func() { New() })
+}
@@ -0,0 +1,69 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package apikey provides middleware that authenticates requests with API
+// keys.
+//
+// The middleware reads the key from the X-API-Key header, looks it up in a
+// [Store], and rejects requests without a valid key with 401 Unauthorized.
+// Handlers learn who is calling from the key's description.
+//
+// # Basic Usage
+//
+//	import "rivaas.dev/middleware/apikey"
+//
+//	r := router.MustNew()
+//	r.Use(apikey.New(apikey.WithStore(apikey.StaticKeys(map[string]apikey.Key{
+//	    os.Getenv("PARTNER_API_KEY"): {ID: "partner", Owner: "acme", Tier: "pro"},
+//	}))))
+//
+//	r.GET("/orders", func(c *router.Context) {
+//	    orders := store.OrdersOf(apikey.Owner(c))
+//	})
+//
+// # Key Extraction
+//
+// Keys are read from the X-API-Key header ([WithHeader]), and optionally from
+// a query parameter ([WithQuery]) or a cookie ([WithCookie]), in that order.
+//
+// # Stores
+//
+//   - [StaticKeys]: A fixed set of keys, e.g. from configuration
+//   - [StoreFunc]: Any lookup, such as a database query
+//   - [NewCachedStore]: Caches the lookups of another store, such as a
+//     remote key service, for valid and invalid keys separately
+//
+// # Key Metadata
+//
+// A valid key's [Key] description, with its ID, owner, tier, and metadata,
+// is stored in the request context. Read it with [Info], [Owner], and
+// [Tier].
+//
+// # Metrics
+//
+// Every authentication attempt is counted in the apikey.authentications
+// counter, with an apikey.outcome attribute (success, missing, invalid, or
+// error) and, for valid keys, an apikey.tier attribute. The global meter
+// provider is used unless [WithMeterProvider] is set.
+//
+// # Configuration Options
+//
+//   - [WithStore]: Key store (required)
+//   - [WithHeader]: Request header carrying the key (default: X-API-Key)
+//   - [WithQuery]: Also read the key from a query parameter
+//   - [WithCookie]: Also read the key from a cookie
+//   - [WithErrorHandler]: Custom response for rejected requests
+//   - [WithSkipPaths]: Paths that bypass authentication
+//   - [WithMeterProvider]: Meter provider for the authentication counter
+package apikey
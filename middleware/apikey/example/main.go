@@ -0,0 +1,69 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package main demonstrates how to use the API key middleware
+// to authenticate clients and read the owner and tier of their key.
+package main
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"rivaas.dev/middleware/apikey"
+	"rivaas.dev/router"
+)
+
+func main() {
+	r := router.MustNew()
+
+	// In production, load keys from a database with apikey.StoreFunc
+	keys := apikey.NewCachedStore(apikey.StaticKeys(map[string]apikey.Key{
+		"demo-pro-key":  {ID: "key-1", Owner: "acme", Tier: "pro"},
+		"demo-free-key": {ID: "key-2", Owner: "globex", Tier: "free"},
+	}), apikey.WithTTL(time.Minute))
+
+	r.Use(apikey.New(
+		apikey.WithStore(keys),
+		apikey.WithQuery("api_key"),
+		apikey.WithSkipPaths("/health"),
+	))
+
+	r.GET("/health", func(c *router.Context) {
+		c.JSON(http.StatusOK, map[string]string{"status": "ok"})
+	})
+
+	r.GET("/me", func(c *router.Context) {
+		key := apikey.Info(c)
+		c.JSON(http.StatusOK, map[string]string{
+			"key_id": key.ID,
+			"owner":  key.Owner,
+			"tier":   key.Tier,
+		})
+	})
+
+	r.GET("/reports", func(c *router.Context) {
+		if apikey.Tier(c) != "pro" {
+			c.JSON(http.StatusForbidden, map[string]string{"error": "reports require the pro tier"})
+			return
+		}
+		c.JSON(http.StatusOK, map[string]string{"report": "..."})
+	})
+
+	log.Println("Server starting on http://localhost:8080")
+	log.Println("Valid key:   curl -H 'X-API-Key: demo-pro-key' http://localhost:8080/me")
+	log.Println("Free tier:   curl -H 'X-API-Key: demo-free-key' http://localhost:8080/reports (403)")
+	log.Println("Invalid key: curl -H 'X-API-Key: wrong' http://localhost:8080/me (401)")
+	log.Fatal(http.ListenAndServe(":8080", r))
+}
@@ -0,0 +1,72 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apikey
+
+import (
+	"context"
+	"log/slog"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// meterName is the instrumentation scope of the middleware's metrics.
+const meterName = "rivaas.dev/middleware/apikey"
+
+// Outcomes of an authentication attempt, recorded in the apikey.outcome
+// attribute.
+const (
+	outcomeSuccess = "success"
+	outcomeMissing = "missing"
+	outcomeInvalid = "invalid"
+	outcomeError   = "error"
+)
+
+// authMetrics counts authentication attempts.
+type authMetrics struct {
+	counter metric.Int64Counter // nil if the instrument could not be created
+}
+
+// newAuthMetrics creates the authentication counter.
+func newAuthMetrics(cfg *config) *authMetrics {
+	mp := cfg.meterProvider
+	if mp == nil {
+		mp = otel.GetMeterProvider()
+	}
+	counter, err := mp.Meter(meterName).Int64Counter("apikey.authentications",
+		metric.WithDescription("API key authentication attempts"),
+		metric.WithUnit("{request}"),
+	)
+	if err != nil {
+		counter = nil
+		slog.Warn("apikey: authentication counter unavailable", "error", err)
+	}
+
+	return &authMetrics{counter: counter}
+}
+
+// record counts an attempt with outcome. The tier of valid keys is recorded
+// too; owners and key IDs are not, to keep cardinality bounded.
+func (m *authMetrics) record(ctx context.Context, outcome string, key *Key) {
+	if m.counter == nil {
+		return
+	}
+	attrs := []attribute.KeyValue{attribute.String("apikey.outcome", outcome)}
+	if key != nil && key.Tier != "" {
+		attrs = append(attrs, attribute.String("apikey.tier", key.Tier))
+	}
+	m.counter.Add(ctx, 1, metric.WithAttributes(attrs...))
+}
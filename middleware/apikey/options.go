@@ -0,0 +1,113 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apikey
+
+import (
+	"go.opentelemetry.io/otel/metric"
+
+	"rivaas.dev/router"
+)
+
+// WithStore sets the store that API keys are looked up in. A store is
+// required.
+//
+// Example:
+//
+//	apikey.New(apikey.WithStore(apikey.NewCachedStore(remoteStore)))
+func WithStore(store Store) Option {
+	return func(cfg *config) {
+		cfg.store = store
+	}
+}
+
+// WithHeader sets the request header carrying the key. Set to an empty
+// string to not read keys from headers.
+// Default: "X-API-Key"
+//
+// Example:
+//
+//	apikey.New(apikey.WithHeader("Api-Key"))
+func WithHeader(name string) Option {
+	return func(cfg *config) {
+		cfg.headerName = name
+	}
+}
+
+// WithQuery also reads the key from the named query parameter if the header
+// is not set. Keys in URLs end up in access logs and browser histories;
+// prefer the header where clients allow it.
+//
+// Example:
+//
+//	apikey.New(apikey.WithQuery("api_key"))
+func WithQuery(param string) Option {
+	return func(cfg *config) {
+		cfg.queryParam = param
+	}
+}
+
+// WithCookie also reads the key from the named cookie if neither the header
+// nor the query parameter is set.
+//
+// Example:
+//
+//	apikey.New(apikey.WithCookie("api_key"))
+func WithCookie(name string) Option {
+	return func(cfg *config) {
+		cfg.cookieName = name
+	}
+}
+
+// WithErrorHandler sets the function that responds to rejected requests. The
+// error is [ErrKeyMissing], [ErrKeyInvalid], or an error of the store. The
+// middleware aborts the chain after the handler returns.
+// Default: 401 Unauthorized for missing or invalid keys, 500 for store errors
+//
+// Example:
+//
+//	apikey.New(apikey.WithErrorHandler(func(c *router.Context, err error) {
+//	    c.JSON(http.StatusUnauthorized, map[string]string{"error": "invalid API key"})
+//	}))
+func WithErrorHandler(handler func(c *router.Context, err error)) Option {
+	return func(cfg *config) {
+		cfg.errorHandler = handler
+	}
+}
+
+// WithSkipPaths sets paths that bypass authentication.
+//
+// Example:
+//
+//	apikey.New(apikey.WithSkipPaths("/health", "/metrics"))
+func WithSkipPaths(paths ...string) Option {
+	return func(cfg *config) {
+		for _, path := range paths {
+			cfg.skipPaths[path] = true
+		}
+	}
+}
+
+// WithMeterProvider sets the OpenTelemetry meter provider for the
+// apikey.authentications counter.
+// Default: the global provider from otel.GetMeterProvider().
+//
+// Example:
+//
+//	apikey.New(apikey.WithMeterProvider(meterProvider))
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return func(cfg *config) {
+		cfg.meterProvider = mp
+	}
+}
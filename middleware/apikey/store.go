@@ -0,0 +1,222 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apikey
+
+import (
+	"context"
+	"crypto/sha256"
+	"sync"
+	"time"
+)
+
+// Key describes a valid API key. It never holds the secret key itself.
+type Key struct {
+	// ID identifies the key in logs and metrics, e.g. "key_2Vx9"
+	ID string
+
+	// Owner is the user, team, or client the key was issued to
+	Owner string
+
+	// Tier is the plan or access level of the key, e.g. "free" or "pro"
+	Tier string
+
+	// Metadata holds any other attributes of the key
+	Metadata map[string]string
+}
+
+// Store looks up API keys. Lookup returns the key's description, or nil if
+// the key is not valid; it returns an error only if the lookup itself
+// failed, such as when a database is unreachable.
+type Store interface {
+	Lookup(ctx context.Context, key string) (*Key, error)
+}
+
+// StoreFunc adapts a function to a [Store], for custom lookups such as a
+// database.
+//
+// Example:
+//
+//	apikey.WithStore(apikey.StoreFunc(func(ctx context.Context, key string) (*apikey.Key, error) {
+//	    row, err := db.APIKeyByHash(ctx, sha256.Sum256([]byte(key)))
+//	    if errors.Is(err, sql.ErrNoRows) {
+//	        return nil, nil
+//	    }
+//	    if err != nil {
+//	        return nil, err
+//	    }
+//	    return &apikey.Key{ID: row.ID, Owner: row.Owner, Tier: row.Tier}, nil
+//	}))
+type StoreFunc func(ctx context.Context, key string) (*Key, error)
+
+// Lookup implements [Store].
+func (f StoreFunc) Lookup(ctx context.Context, key string) (*Key, error) {
+	return f(ctx, key)
+}
+
+// staticStore is the [Store] of [StaticKeys]. Keys are indexed by their
+// SHA-256 digest, so that lookups do not compare secrets byte by byte.
+type staticStore map[[sha256.Size]byte]*Key
+
+// StaticKeys returns a store of a fixed set of keys, mapping each secret key
+// to its description.
+//
+// Example:
+//
+//	apikey.StaticKeys(map[string]apikey.Key{
+//	    os.Getenv("PARTNER_API_KEY"): {ID: "partner", Owner: "acme", Tier: "pro"},
+//	})
+func StaticKeys(keys map[string]Key) Store {
+	s := make(staticStore, len(keys))
+	for secret, key := range keys {
+		s[sha256.Sum256([]byte(secret))] = &key
+	}
+
+	return s
+}
+
+// Lookup implements [Store].
+func (s staticStore) Lookup(_ context.Context, key string) (*Key, error) {
+	return s[sha256.Sum256([]byte(key))], nil
+}
+
+// CachedStore caches the lookups of another store, typically a remote
+// service or database, so that not every request waits for it. Valid and
+// invalid keys are cached for separate durations; failed lookups are not
+// cached.
+type CachedStore struct {
+	store       Store
+	ttl         time.Duration
+	negativeTTL time.Duration
+	maxEntries  int
+	now         func() time.Time
+
+	mu      sync.Mutex
+	entries map[[sha256.Size]byte]cacheEntry
+}
+
+// cacheEntry is a cached lookup result; key is nil for invalid keys.
+type cacheEntry struct {
+	key     *Key
+	expires time.Time
+}
+
+// CacheOption configures a [CachedStore].
+type CacheOption func(*CachedStore)
+
+// WithTTL sets how long valid keys are cached. Revoked keys keep working
+// for up to this long.
+// Default: 5 minutes
+func WithTTL(d time.Duration) CacheOption {
+	return func(s *CachedStore) {
+		s.ttl = d
+	}
+}
+
+// WithNegativeTTL sets how long invalid keys are cached, which shields the
+// store from repeated requests with the same wrong key. New keys are
+// rejected for up to this long if they were tried before being created.
+// Default: 30 seconds
+func WithNegativeTTL(d time.Duration) CacheOption {
+	return func(s *CachedStore) {
+		s.negativeTTL = d
+	}
+}
+
+// WithMaxEntries sets the maximum number of cached keys.
+// Default: 10000
+func WithMaxEntries(n int) CacheOption {
+	return func(s *CachedStore) {
+		s.maxEntries = n
+	}
+}
+
+// NewCachedStore returns a store that caches the lookups of store.
+//
+// Example:
+//
+//	keys := apikey.NewCachedStore(remoteStore, apikey.WithTTL(time.Minute))
+//	r.Use(apikey.New(apikey.WithStore(keys)))
+func NewCachedStore(store Store, opts ...CacheOption) *CachedStore {
+	s := &CachedStore{
+		store:       store,
+		ttl:         5 * time.Minute,
+		negativeTTL: 30 * time.Second,
+		maxEntries:  10000,
+		now:         time.Now,
+		entries:     make(map[[sha256.Size]byte]cacheEntry),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// Lookup implements [Store].
+func (s *CachedStore) Lookup(ctx context.Context, key string) (*Key, error) {
+	digest := sha256.Sum256([]byte(key))
+	now := s.now()
+
+	s.mu.Lock()
+	entry, ok := s.entries[digest]
+	s.mu.Unlock()
+	if ok && now.Before(entry.expires) {
+		return entry.key, nil
+	}
+
+	found, err := s.store.Lookup(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	ttl := s.ttl
+	if found == nil {
+		ttl = s.negativeTTL
+	}
+	if ttl > 0 {
+		s.mu.Lock()
+		s.evict(now)
+		s.entries[digest] = cacheEntry{key: found, expires: now.Add(ttl)}
+		s.mu.Unlock()
+	}
+
+	return found, nil
+}
+
+// Invalidate removes key from the cache, e.g. after it was revoked.
+func (s *CachedStore) Invalidate(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.entries, sha256.Sum256([]byte(key)))
+}
+
+// evict makes room for a new entry by removing expired entries, or an
+// arbitrary one if none has expired. It must be called with s.mu held.
+func (s *CachedStore) evict(now time.Time) {
+	if s.maxEntries <= 0 || len(s.entries) < s.maxEntries {
+		return
+	}
+	for digest, entry := range s.entries {
+		if !now.Before(entry.expires) {
+			delete(s.entries, digest)
+		}
+	}
+	for digest := range s.entries {
+		if len(s.entries) < s.maxEntries {
+			break
+		}
+		delete(s.entries, digest)
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package apikey
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStaticKeys(t *testing.T) {
+	t.Parallel()
+	key, err := testKeys.Lookup(t.Context(), "secret-pro")
+	require.NoError(t, err)
+	require.NotNil(t, key)
+	assert.Equal(t, "acme", key.Owner)
+
+	key, err = testKeys.Lookup(t.Context(), "secret-pr")
+	require.NoError(t, err)
+	assert.Nil(t, key)
+}
+
+// countingStore counts lookups of a static store and can be made to fail.
+type countingStore struct {
+	mu      sync.Mutex
+	lookups int
+	err     error
+}
+
+func (s *countingStore) Lookup(ctx context.Context, key string) (*Key, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lookups++
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	return testKeys.Lookup(ctx, key)
+}
+
+func (s *countingStore) count() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lookups
+}
+
+func TestCachedStore(t *testing.T) {
+	t.Parallel()
+	remote := &countingStore{}
+	now := time.Now()
+	cache := NewCachedStore(remote, WithTTL(time.Minute), WithNegativeTTL(10*time.Second))
+	cache.now = func() time.Time { return now }
+	ctx := t.Context()
+
+	for range 3 {
+		key, err := cache.Lookup(ctx, "secret-pro")
+		require.NoError(t, err)
+		assert.Equal(t, "acme", key.Owner)
+	}
+	assert.Equal(t, 1, remote.count(), "valid keys are cached")
+
+	for range 3 {
+		key, err := cache.Lookup(ctx, "wrong")
+		require.NoError(t, err)
+		assert.Nil(t, key)
+	}
+	assert.Equal(t, 2, remote.count(), "invalid keys are cached")
+
+	// Invalid keys expire first
+	now = now.Add(30 * time.Second)
+	_, err := cache.Lookup(ctx, "secret-pro")
+	require.NoError(t, err)
+	_, err = cache.Lookup(ctx, "wrong")
+	require.NoError(t, err)
+	assert.Equal(t, 3, remote.count())
+
+	// Failed lookups are not cached
+	now = now.Add(time.Hour)
+	remote.err = errors.New("remote down")
+	_, err = cache.Lookup(ctx, "secret-pro")
+	require.Error(t, err)
+	remote.err = nil
+	_, err = cache.Lookup(ctx, "secret-pro")
+	require.NoError(t, err)
+	assert.Equal(t, 5, remote.count())
+
+	cache.Invalidate("secret-pro")
+	_, err = cache.Lookup(ctx, "secret-pro")
+	require.NoError(t, err)
+	assert.Equal(t, 6, remote.count())
+}
+
+func TestCachedStore_MaxEntries(t *testing.T) {
+	t.Parallel()
+	cache := NewCachedStore(&countingStore{}, WithMaxEntries(2))
+	for _, key := range []string{"a", "b", "c", "d"} {
+		_, err := cache.Lookup(t.Context(), key)
+		require.NoError(t, err)
+	}
+	assert.Len(t, cache.entries, 2)
+}
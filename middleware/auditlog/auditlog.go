@@ -0,0 +1,306 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditlog
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"rivaas.dev/router"
+)
+
+type contextKey struct{}
+
+// Option defines functional options for auditlog middleware configuration.
+type Option func(*config)
+
+// config holds the configuration for the auditlog middleware.
+type config struct {
+	// sink stores the events
+	sink Sink
+
+	// methods are the audited request methods
+	methods []string
+
+	// routes are the audited route patterns; empty audits all routes
+	routes map[string]bool
+
+	// actorFunc returns the actor of a request
+	actorFunc func(c *router.Context) string
+
+	// previousHash is the hash the chain continues from
+	previousHash string
+
+	// skipPaths are paths that are never audited
+	skipPaths map[string]bool
+
+	// logger logs sink errors
+	logger *slog.Logger
+}
+
+// defaultConfig returns the default configuration for auditlog middleware.
+func defaultConfig() *config {
+	return &config{
+		methods:   []string{http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete},
+		routes:    make(map[string]bool),
+		actorFunc: func(*router.Context) string { return "" },
+		skipPaths: make(map[string]bool),
+		logger:    slog.Default(),
+	}
+}
+
+// chain links events by hash and writes them to the sink in order.
+type chain struct {
+	mu   sync.Mutex
+	last string
+	sink Sink
+}
+
+// append links event to the chain and writes it to the sink.
+func (ch *chain) append(ctx context.Context, event Event) error {
+	ch.mu.Lock()
+	defer ch.mu.Unlock()
+
+	event.PrevHash = ch.last
+	hash, err := event.computeHash()
+	if err != nil {
+		return err
+	}
+	event.Hash = hash
+	if err := ch.sink.Write(ctx, event); err != nil {
+		return err
+	}
+	ch.last = hash
+
+	return nil
+}
+
+// record is the event of a request, as the handler annotates it.
+type record struct {
+	mu    sync.Mutex
+	event Event
+}
+
+// New returns a middleware that writes an audit event to the sink for each
+// audited request: by default every POST, PUT, PATCH, and DELETE request.
+//
+// Each event records the actor (see [WithActor]), the action (method and
+// route, unless set with [SetAction]), the resource (the path, unless set
+// with [SetResource]), the outcome, and the before and after state that the
+// handler provides with [SetBefore] and [SetAfter].
+//
+// Events are linked in a hash chain: each carries the hash of the previous
+// one, so [Verify] detects events that were modified, removed, or inserted.
+// Audit logging is separate from access logging; events are written after
+// the response, and sink errors are logged, not sent to the client.
+//
+// New panics if no sink is configured.
+//
+// Example:
+//
+//	r := router.MustNew()
+//	r.Use(auditlog.New(
+//	    auditlog.WithSink(sink),
+//	    auditlog.WithActor(func(c *router.Context) string { return jwtauth.Subject(c) }),
+//	))
+func New(opts ...Option) router.HandlerFunc {
+	cfg := defaultConfig()
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	if cfg.sink == nil {
+		panic("auditlog: a sink is required, use WithSink")
+	}
+	ch := &chain{last: cfg.previousHash, sink: cfg.sink}
+
+	return func(c *router.Context) {
+		if !cfg.audits(c) {
+			c.Next()
+			return
+		}
+
+		rec := &record{event: Event{
+			Time:     time.Now().UTC(),
+			Method:   c.Request.Method,
+			Path:     c.Request.URL.Path,
+			Route:    c.RoutePattern(),
+			ClientIP: c.ClientIP(),
+		}}
+		c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), contextKey{}, rec))
+
+		sc, ok := c.Response.(statusCoder)
+		if !ok {
+			wrapped := router.NewResponseWriterWrapper(c.Response)
+			c.Response = wrapped
+			sc = wrapped
+		}
+
+		panicked := true
+		defer func() {
+			event := cfg.complete(c, rec, sc.StatusCode(), panicked)
+			ctx := context.WithoutCancel(c.Request.Context())
+			if err := ch.append(ctx, event); err != nil {
+				cfg.logger.ErrorContext(ctx, "auditlog: failed to write event",
+					"error", err, "action", event.Action, "resource", event.Resource)
+			}
+		}()
+
+		c.Next()
+		panicked = false
+	}
+}
+
+// statusCoder is implemented by response writers that track the status code.
+type statusCoder interface {
+	StatusCode() int
+}
+
+// audits reports whether the request of c is audited.
+func (cfg *config) audits(c *router.Context) bool {
+	if cfg.skipPaths[c.Request.URL.Path] || !slices.Contains(cfg.methods, c.Request.Method) {
+		return false
+	}
+
+	return len(cfg.routes) == 0 || cfg.routes[c.RoutePattern()]
+}
+
+// complete fills in the fields of the event that are known once the
+// handler returned, and returns a copy of it.
+func (cfg *config) complete(c *router.Context, rec *record, status int, panicked bool) Event {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+
+	event := rec.event.clone()
+	event.Status = status
+	switch {
+	case panicked:
+		event.Outcome = OutcomeError
+		event.Status = http.StatusInternalServerError
+	case status >= http.StatusBadRequest:
+		event.Outcome = OutcomeFailure
+	default:
+		event.Outcome = OutcomeSuccess
+	}
+	if event.Actor == "" {
+		event.Actor = cfg.actorFunc(c)
+	}
+	if event.Action == "" {
+		event.Action = event.Method + " " + event.Route
+	}
+	if event.Resource == "" {
+		event.Resource = event.Path
+	}
+	event.RequestID = c.Response.Header().Get("X-Request-ID")
+	if event.RequestID == "" {
+		event.RequestID = c.Request.Header.Get("X-Request-ID")
+	}
+
+	return event
+}
+
+// update calls fn with the event of the request of c, if it is audited.
+func update(c *router.Context, fn func(event *Event)) {
+	rec, ok := c.Request.Context().Value(contextKey{}).(*record)
+	if !ok {
+		return
+	}
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	fn(&rec.event)
+}
+
+// SetActor sets the actor of the audit event, overriding [WithActor], for
+// example after a login handler authenticated the user.
+//
+// Example:
+//
+//	auditlog.SetActor(c, user.ID)
+func SetActor(c *router.Context, actor string) {
+	update(c, func(event *Event) { event.Actor = actor })
+}
+
+// SetAction sets the action of the audit event, replacing the default of
+// method and route.
+//
+// Example:
+//
+//	auditlog.SetAction(c, "order.cancel")
+func SetAction(c *router.Context, action string) {
+	update(c, func(event *Event) { event.Action = action })
+}
+
+// SetResource sets the resource of the audit event, replacing the default
+// of the request path.
+//
+// Example:
+//
+//	auditlog.SetResource(c, "order:"+c.Param("id"))
+func SetResource(c *router.Context, resource string) {
+	update(c, func(event *Event) { event.Resource = resource })
+}
+
+// SetBefore records the state of the resource before the request changed
+// it. v is encoded as JSON when SetBefore is called, so later changes to v
+// don't affect the event. Leave out secrets and personal data that the
+// audit log must not hold.
+//
+// Example:
+//
+//	order := store.Order(id)
+//	auditlog.SetBefore(c, order)
+//	order.Status = "cancelled"
+//	auditlog.SetAfter(c, order)
+func SetBefore(c *router.Context, v any) {
+	data := encode(v)
+	update(c, func(event *Event) { event.Before = data })
+}
+
+// SetAfter records the state of the resource after the request changed it,
+// like [SetBefore].
+func SetAfter(c *router.Context, v any) {
+	data := encode(v)
+	update(c, func(event *Event) { event.After = data })
+}
+
+// Set adds a metadata attribute to the audit event.
+//
+// Example:
+//
+//	auditlog.Set(c, "reason", req.Reason)
+func Set(c *router.Context, key, value string) {
+	update(c, func(event *Event) {
+		if event.Metadata == nil {
+			event.Metadata = make(map[string]string)
+		}
+		event.Metadata[key] = value
+	})
+}
+
+// encode returns v as JSON, or a JSON string describing the error if v
+// can't be encoded.
+func encode(v any) json.RawMessage {
+	data, err := json.Marshal(v)
+	if err != nil {
+		data, _ = json.Marshal("unencodable: " + err.Error()) //nolint:errcheck // Strings always encode
+	}
+
+	return data
+}
@@ -0,0 +1,270 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package auditlog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"rivaas.dev/router"
+)
+
+// memorySink collects events in memory.
+type memorySink struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+func (s *memorySink) Write(_ context.Context, event Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, event)
+
+	return nil
+}
+
+func (s *memorySink) all() []Event {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Event(nil), s.events...)
+}
+
+type order struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+}
+
+// actorHeader takes the actor from the X-User header that serve sets.
+var actorHeader = WithActor(func(c *router.Context) string { return c.Request.Header.Get("X-User") })
+
+// addOrderRoutes registers order routes that use the audit helpers.
+func addOrderRoutes(r *router.Router) {
+	r.GET("/orders/:id", func(c *router.Context) {
+		//nolint:errcheck // Test handler
+		c.String(http.StatusOK, c.Param("id"))
+	})
+	r.DELETE("/orders/:id", func(c *router.Context) {
+		o := &order{ID: c.Param("id"), Status: "open"}
+		SetBefore(c, o)
+		o.Status = "cancelled"
+		SetAfter(c, o)
+		SetResource(c, "order:"+o.ID)
+		c.NoContent()
+	})
+	r.POST("/orders", func(c *router.Context) {
+		SetAction(c, "order.create")
+		Set(c, "channel", "web")
+		c.WriteErrorResponse(http.StatusUnprocessableEntity, "invalid order")
+	})
+	r.POST("/login", func(c *router.Context) {
+		SetActor(c, "alice")
+		c.NoContent()
+	})
+	r.POST("/panic", func(*router.Context) {
+		panic("boom")
+	})
+}
+
+func serve(t *testing.T, r http.Handler, method, path string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequestWithContext(t.Context(), method, path, nil)
+	req.Header.Set("X-User", "bob")
+	req.Header.Set("X-Request-ID", "req-1")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	return w
+}
+
+func TestAuditLog_Event(t *testing.T) {
+	t.Parallel()
+	sink := &memorySink{}
+	r := router.MustNew()
+	r.Use(New(WithSink(sink), actorHeader))
+	addOrderRoutes(r)
+
+	w := serve(t, r, http.MethodDelete, "/orders/42")
+	assert.Equal(t, http.StatusNoContent, w.Code)
+
+	events := sink.all()
+	require.Len(t, events, 1)
+	event := events[0]
+	assert.Equal(t, "bob", event.Actor)
+	assert.Equal(t, "DELETE /orders/:id", event.Action)
+	assert.Equal(t, "order:42", event.Resource)
+	assert.Equal(t, OutcomeSuccess, event.Outcome)
+	assert.Equal(t, http.StatusNoContent, event.Status)
+	assert.Equal(t, "/orders/42", event.Path)
+	assert.Equal(t, "/orders/:id", event.Route)
+	assert.Equal(t, "req-1", event.RequestID)
+	assert.NotEmpty(t, event.ClientIP)
+	assert.False(t, event.Time.IsZero())
+	assert.JSONEq(t, `{"id":"42","status":"open"}`, string(event.Before), "encoded when set")
+	assert.JSONEq(t, `{"id":"42","status":"cancelled"}`, string(event.After))
+	assert.Empty(t, event.PrevHash)
+	assert.NotEmpty(t, event.Hash)
+}
+
+func TestAuditLog_Outcomes(t *testing.T) {
+	t.Parallel()
+	sink := &memorySink{}
+	r := router.MustNew()
+	r.Use(New(WithSink(sink), actorHeader))
+	addOrderRoutes(r)
+
+	serve(t, r, http.MethodPost, "/orders")
+	serve(t, r, http.MethodPost, "/login")
+	assert.Panics(t, func() { serve(t, r, http.MethodPost, "/panic") }, "panics are not swallowed")
+
+	events := sink.all()
+	require.Len(t, events, 3)
+
+	assert.Equal(t, "order.create", events[0].Action)
+	assert.Equal(t, OutcomeFailure, events[0].Outcome)
+	assert.Equal(t, http.StatusUnprocessableEntity, events[0].Status)
+	assert.Equal(t, map[string]string{"channel": "web"}, events[0].Metadata)
+	assert.Equal(t, "/orders", events[0].Resource)
+
+	assert.Equal(t, "alice", events[1].Actor, "SetActor overrides WithActor")
+	assert.Equal(t, OutcomeSuccess, events[1].Outcome)
+
+	assert.Equal(t, OutcomeError, events[2].Outcome)
+	assert.Equal(t, http.StatusInternalServerError, events[2].Status)
+}
+
+func TestAuditLog_Filters(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		opts    []Option
+		method  string
+		path    string
+		audited bool
+	}{
+		{name: "GET not audited by default", method: http.MethodGet, path: "/orders/1"},
+		{name: "DELETE audited by default", method: http.MethodDelete, path: "/orders/1", audited: true},
+		{name: "custom methods", opts: []Option{WithMethods("get")}, method: http.MethodGet, path: "/orders/1", audited: true},
+		{name: "custom methods replace defaults", opts: []Option{WithMethods("GET")}, method: http.MethodDelete, path: "/orders/1"},
+		{name: "route audited", opts: []Option{WithRoutes("/orders/:id")}, method: http.MethodDelete, path: "/orders/1", audited: true},
+		{name: "route not audited", opts: []Option{WithRoutes("/orders/:id")}, method: http.MethodPost, path: "/login"},
+		{name: "skip path", opts: []Option{WithSkipPaths("/login")}, method: http.MethodPost, path: "/login"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			sink := &memorySink{}
+			r := router.MustNew()
+			r.Use(New(append([]Option{WithSink(sink), actorHeader}, tt.opts...)...))
+			addOrderRoutes(r)
+			serve(t, r, tt.method, tt.path)
+
+			if tt.audited {
+				assert.Len(t, sink.all(), 1)
+			} else {
+				assert.Empty(t, sink.all())
+			}
+		})
+	}
+}
+
+func TestAuditLog_Chain(t *testing.T) {
+	t.Parallel()
+	sink := &memorySink{}
+	r := router.MustNew()
+	r.Use(New(WithSink(sink), actorHeader, WithPreviousHash("genesis")))
+	addOrderRoutes(r)
+
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Go(func() { serve(t, r, http.MethodDelete, "/orders/1") })
+	}
+	wg.Wait()
+
+	events := sink.all()
+	require.Len(t, events, 20)
+	assert.Equal(t, "genesis", events[0].PrevHash)
+	require.NoError(t, Verify(events, "genesis"))
+}
+
+func TestAuditLog_SinkError(t *testing.T) {
+	t.Parallel()
+	var logs bytes.Buffer
+	fail := true
+	sink := &memorySink{}
+	r := router.MustNew()
+	r.Use(New(WithSink(SinkFunc(func(ctx context.Context, event Event) error {
+		if fail {
+			return errors.New("queue unavailable")
+		}
+		return sink.Write(ctx, event)
+	})), actorHeader, WithLogger(slog.New(slog.NewTextHandler(&logs, nil)))))
+	addOrderRoutes(r)
+
+	w := serve(t, r, http.MethodDelete, "/orders/1")
+	assert.Equal(t, http.StatusNoContent, w.Code, "the response is not affected")
+	assert.Contains(t, logs.String(), "queue unavailable")
+
+	// An event that wasn't written is not part of the chain
+	fail = false
+	serve(t, r, http.MethodDelete, "/orders/2")
+	events := sink.all()
+	require.Len(t, events, 1)
+	assert.Empty(t, events[0].PrevHash)
+	require.NoError(t, Verify(events, ""))
+}
+
+func TestAuditLog_HelpersWithoutMiddleware(t *testing.T) {
+	t.Parallel()
+	c := router.NewContext(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/", nil))
+
+	assert.NotPanics(t, func() {
+		SetActor(c, "alice")
+		SetAction(c, "action")
+		SetResource(c, "resource")
+		SetBefore(c, 1)
+		SetAfter(c, 2)
+		Set(c, "key", "value")
+	})
+}
+
+func TestEncode(t *testing.T) {
+	t.Parallel()
+	assert.JSONEq(t, `{"a":1}`, string(encode(map[string]int{"a": 1})))
+
+	var s string
+	require.NoError(t, json.Unmarshal(encode(make(chan int)), &s))
+	assert.True(t, strings.HasPrefix(s, "unencodable: "))
+}
+
+func TestNew_PanicsWithoutSink(t *testing.T) {
+	t.Parallel()
+	assert.Panics(t, func() { New() })
+}
@@ -0,0 +1,72 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package auditlog provides middleware that writes an audit event for each
+// request that changes state: who did what to which resource, with which
+// outcome, and the state before and after.
+//
+// Audit events are separate from access logs: they are written only for
+// configured methods and routes, carry business-level actions and
+// resources, and are linked in a hash chain so tampering can be detected.
+//
+// # Basic Usage
+//
+//	import "rivaas.dev/middleware/auditlog"
+//
+//	sink, err := auditlog.NewFileSink("/var/log/app/audit.jsonl")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer sink.Close()
+//
+//	r := router.MustNew()
+//	r.Use(auditlog.New(
+//	    auditlog.WithSink(sink),
+//	    auditlog.WithActor(func(c *router.Context) string { return jwtauth.Subject(c) }),
+//	))
+//
+//	r.DELETE("/orders/:id", func(c *router.Context) {
+//	    order := store.Order(c.Param("id"))
+//	    auditlog.SetBefore(c, order)
+//	    auditlog.SetResource(c, "order:"+order.ID)
+//	    store.Cancel(order)
+//	    auditlog.SetAfter(c, order)
+//	    c.NoContent()
+//	})
+//
+// # Events
+//
+// By default, POST, PUT, PATCH, and DELETE requests are audited;
+// [WithMethods] and [WithRoutes] change that. The action defaults to method
+// and route, the resource to the path, and the outcome follows the status
+// code: [OutcomeSuccess] below 400, [OutcomeFailure] from 400, and
+// [OutcomeError] when the handler panicked. Handlers refine the event with
+// [SetAction], [SetResource], [SetActor], [SetBefore], [SetAfter], and [Set].
+//
+// # Sinks
+//
+// A [Sink] stores events. [NewFileSink] appends JSON lines to a file,
+// [NewWriterSink] writes them to any io.Writer, and [NewSlogSink] logs them,
+// which exports them over OTLP with the OpenTelemetry slog bridge. Custom
+// sinks, such as message queues, implement [Sink] or use [SinkFunc].
+// Events are written after the response, one at a time; sink errors are
+// logged and don't affect the response.
+//
+// # Tamper Evidence
+//
+// Each event carries the hash of the previous event and its own SHA-256
+// hash. [Verify] checks a sequence of events, as read by [ReadEvents], and
+// returns [ErrChainBroken] if an event was modified, removed, inserted, or
+// reordered. [WithPreviousHash] continues a chain after a restart.
+package auditlog
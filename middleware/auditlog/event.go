@@ -0,0 +1,135 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditlog
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"time"
+)
+
+// ErrChainBroken is returned by [Verify] when an event was modified,
+// removed, or inserted.
+var ErrChainBroken = errors.New("auditlog: hash chain broken")
+
+// Outcomes of an audited request.
+const (
+	OutcomeSuccess = "success" // The response status was below 400
+	OutcomeFailure = "failure" // The response status was 400 or above
+	OutcomeError   = "error"   // The handler panicked
+)
+
+// Event is an audit event: who did what to which resource, and with which
+// outcome. Events are values; sinks receive a copy that they must not
+// modify, since changes would break the hash chain.
+type Event struct {
+	// Time is when the request was received, in UTC
+	Time time.Time `json:"time"`
+
+	// Actor is the user or client that made the request
+	Actor string `json:"actor,omitempty"`
+
+	// Action is what was done, e.g. "DELETE /orders/:id" or "order.cancel"
+	Action string `json:"action"`
+
+	// Resource is what it was done to, e.g. "order:42"
+	Resource string `json:"resource,omitempty"`
+
+	// Outcome is OutcomeSuccess, OutcomeFailure, or OutcomeError
+	Outcome string `json:"outcome"`
+
+	// Status is the response status code
+	Status int `json:"status"`
+
+	// Method, Path, and Route describe the request
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	Route  string `json:"route,omitempty"`
+
+	// ClientIP is the IP address of the client
+	ClientIP string `json:"client_ip,omitempty"`
+
+	// RequestID correlates the event with logs and traces
+	RequestID string `json:"request_id,omitempty"`
+
+	// Before and After are the state of the resource before and after the
+	// request, as set by the handler with [SetBefore] and [SetAfter]
+	Before json.RawMessage `json:"before,omitempty"`
+	After  json.RawMessage `json:"after,omitempty"`
+
+	// Metadata holds other attributes set by the handler with [Set]
+	Metadata map[string]string `json:"metadata,omitempty"`
+
+	// PrevHash is the hash of the previous event in the chain
+	PrevHash string `json:"prev_hash,omitempty"`
+
+	// Hash is the SHA-256 hash of the event and PrevHash, hex encoded
+	Hash string `json:"hash,omitempty"`
+}
+
+// computeHash returns the hash of the event, which covers all fields but
+// Hash itself, including PrevHash.
+func (e Event) computeHash() (string, error) {
+	e.Hash = ""
+	data, err := json.Marshal(e)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// clone returns a copy of the event that shares no maps with e.
+func (e Event) clone() Event {
+	e.Metadata = maps.Clone(e.Metadata)
+	return e
+}
+
+// Verify checks the hash chain of events, in the order they were written.
+// previous is the hash the chain started from (see [WithPreviousHash]), or
+// an empty string for a new chain. It returns an error wrapping
+// [ErrChainBroken] at the first event that doesn't match.
+//
+// Example:
+//
+//	events, err := auditlog.ReadEvents(file)
+//	if err != nil {
+//	    return err
+//	}
+//	if err := auditlog.Verify(events, ""); err != nil {
+//	    log.Printf("audit log tampered with: %v", err)
+//	}
+func Verify(events []Event, previous string) error {
+	for i, event := range events {
+		if event.PrevHash != previous {
+			return fmt.Errorf("%w: event %d doesn't follow the previous event", ErrChainBroken, i)
+		}
+		hash, err := event.computeHash()
+		if err != nil {
+			return fmt.Errorf("auditlog: event %d: %w", i, err)
+		}
+		if hash != event.Hash {
+			return fmt.Errorf("%w: event %d was modified", ErrChainBroken, i)
+		}
+		previous = event.Hash
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package auditlog
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newChain returns n linked events.
+func newChain(t *testing.T, n int) []Event {
+	t.Helper()
+	sink := &memorySink{}
+	ch := &chain{sink: sink}
+	for i := range n {
+		err := ch.append(t.Context(), Event{
+			Time:     time.Date(2025, 1, 1, 0, 0, i, 0, time.UTC),
+			Action:   "order.update",
+			Resource: "order:1",
+			Outcome:  OutcomeSuccess,
+			After:    json.RawMessage(`{"total":10}`),
+			Metadata: map[string]string{"step": string(rune('a' + i))},
+		})
+		require.NoError(t, err)
+	}
+
+	return sink.all()
+}
+
+func TestVerify(t *testing.T) {
+	t.Parallel()
+	events := newChain(t, 5)
+	require.NoError(t, Verify(events, ""))
+	require.NoError(t, Verify(nil, ""))
+	assert.Equal(t, events[0].Hash, events[1].PrevHash)
+	require.ErrorIs(t, Verify(events, "other"), ErrChainBroken, "wrong starting hash")
+
+	tests := []struct {
+		name   string
+		tamper func(events []Event) []Event
+	}{
+		{name: "modified field", tamper: func(events []Event) []Event {
+			events[2].Actor = "mallory"
+			return events
+		}},
+		{name: "modified state", tamper: func(events []Event) []Event {
+			events[1].After = json.RawMessage(`{"total":1000}`)
+			return events
+		}},
+		{name: "rehashed event", tamper: func(events []Event) []Event {
+			events[2].Outcome = OutcomeFailure
+			events[2].Hash, _ = events[2].computeHash()
+			return events
+		}},
+		{name: "removed event", tamper: func(events []Event) []Event {
+			return append(events[:2], events[3:]...)
+		}},
+		{name: "reordered events", tamper: func(events []Event) []Event {
+			events[1], events[2] = events[2], events[1]
+			return events
+		}},
+		{name: "removed first event", tamper: func(events []Event) []Event {
+			return events[1:]
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := Verify(tt.tamper(newChain(t, 5)), "")
+			require.ErrorIs(t, err, ErrChainBroken)
+		})
+	}
+}
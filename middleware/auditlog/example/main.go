@@ -0,0 +1,86 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package main demonstrates how to use the auditlog middleware
+// to audit changes to orders.
+package main
+
+import (
+	"log"
+	"net/http"
+	"os"
+	"sync"
+
+	"rivaas.dev/middleware/auditlog"
+	"rivaas.dev/router"
+)
+
+type order struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+}
+
+var (
+	mu     sync.Mutex
+	orders = map[string]*order{
+		"1": {ID: "1", Status: "open"},
+		"2": {ID: "2", Status: "open"},
+	}
+)
+
+func main() {
+	r := router.MustNew()
+
+	// Events are written to stdout as JSON lines
+	r.Use(auditlog.New(
+		auditlog.WithSink(auditlog.NewWriterSink(os.Stdout)),
+		auditlog.WithActor(func(c *router.Context) string {
+			return c.Request.Header.Get("X-User")
+		}),
+	))
+
+	r.GET("/orders/:id", func(c *router.Context) {
+		mu.Lock()
+		defer mu.Unlock()
+		o, ok := orders[c.Param("id")]
+		if !ok {
+			c.NotFound()
+			return
+		}
+		if err := c.JSON(http.StatusOK, o); err != nil {
+			log.Printf("write response: %v", err)
+		}
+	})
+
+	r.POST("/orders/:id/cancel", func(c *router.Context) {
+		mu.Lock()
+		defer mu.Unlock()
+		o, ok := orders[c.Param("id")]
+		if !ok {
+			c.NotFound()
+			return
+		}
+		auditlog.SetAction(c, "order.cancel")
+		auditlog.SetResource(c, "order:"+o.ID)
+		auditlog.SetBefore(c, o)
+		o.Status = "cancelled"
+		auditlog.SetAfter(c, o)
+		c.NoContent()
+	})
+
+	log.Println("Server starting on :8080")
+	log.Println("  curl -i -X POST -H 'X-User: alice' http://localhost:8080/orders/1/cancel")
+	log.Println("  curl -i -X POST -H 'X-User: bob' http://localhost:8080/orders/9/cancel")
+	log.Fatal(http.ListenAndServe(":8080", r))
+}
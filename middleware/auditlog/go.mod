@@ -0,0 +1,19 @@
+module rivaas.dev/middleware/auditlog
+
+go 1.25.0
+
+require (
+	github.com/stretchr/testify v1.11.1
+	rivaas.dev/router v0.15.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.2-0.20180830191138-d8f796af33cc // indirect
+	github.com/kr/pretty v0.3.1 // indirect
+	github.com/pmezard/go-difflib v1.0.1-0.20181226105442-5d4384ee4fb2 // indirect
+	golang.org/x/net v0.52.0 // indirect
+	golang.org/x/text v0.35.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace rivaas.dev/router => ../../router/
@@ -0,0 +1,119 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditlog
+
+import (
+	"log/slog"
+	"strings"
+
+	"rivaas.dev/router"
+)
+
+// WithSink sets the sink that stores audit events: a [FileSink], a sink
+// from [NewWriterSink] or [NewSlogSink], or a custom [Sink] such as a
+// message queue. A sink is required.
+//
+// Example:
+//
+//	auditlog.New(auditlog.WithSink(auditlog.NewWriterSink(os.Stdout)))
+func WithSink(sink Sink) Option {
+	return func(cfg *config) {
+		cfg.sink = sink
+	}
+}
+
+// WithMethods sets the request methods that are audited.
+// Default: POST, PUT, PATCH, DELETE
+//
+// Example:
+//
+//	auditlog.New(auditlog.WithSink(sink), auditlog.WithMethods("POST", "PUT", "PATCH", "DELETE", "GET"))
+func WithMethods(methods ...string) Option {
+	return func(cfg *config) {
+		cfg.methods = cfg.methods[:0]
+		for _, method := range methods {
+			cfg.methods = append(cfg.methods, strings.ToUpper(method))
+		}
+	}
+}
+
+// WithRoutes limits auditing to the given route patterns, such as
+// "/orders/:id". Without it, all routes are audited.
+//
+// Example:
+//
+//	auditlog.New(auditlog.WithSink(sink), auditlog.WithRoutes("/users/:id", "/users/:id/roles"))
+func WithRoutes(patterns ...string) Option {
+	return func(cfg *config) {
+		for _, pattern := range patterns {
+			cfg.routes[pattern] = true
+		}
+	}
+}
+
+// WithActor sets the function that returns the actor of a request, such as
+// the authenticated user. Register the middleware after the authentication
+// middleware.
+// Default: no actor, unless the handler calls [SetActor]
+//
+// Example:
+//
+//	auditlog.New(auditlog.WithSink(sink), auditlog.WithActor(func(c *router.Context) string {
+//	    return basicauth.Username(c)
+//	}))
+func WithActor(fn func(c *router.Context) string) Option {
+	return func(cfg *config) {
+		cfg.actorFunc = fn
+	}
+}
+
+// WithPreviousHash continues the hash chain from the hash of the last event
+// written before, for example by a previous process, so the whole log
+// verifies as one chain.
+//
+// Example:
+//
+//	auditlog.New(auditlog.WithSink(sink), auditlog.WithPreviousHash(lastEvent.Hash))
+func WithPreviousHash(hash string) Option {
+	return func(cfg *config) {
+		cfg.previousHash = hash
+	}
+}
+
+// WithSkipPaths sets paths that are never audited.
+//
+// Example:
+//
+//	auditlog.New(auditlog.WithSink(sink), auditlog.WithSkipPaths("/login"))
+func WithSkipPaths(paths ...string) Option {
+	return func(cfg *config) {
+		for _, path := range paths {
+			cfg.skipPaths[path] = true
+		}
+	}
+}
+
+// WithLogger sets the logger for events that couldn't be written to the
+// sink.
+// Default: slog.Default()
+//
+// Example:
+//
+//	auditlog.New(auditlog.WithSink(sink), auditlog.WithLogger(logger))
+func WithLogger(logger *slog.Logger) Option {
+	return func(cfg *config) {
+		cfg.logger = logger
+	}
+}
@@ -0,0 +1,189 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditlog
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"sync"
+)
+
+// Sink stores audit events. Write is called once per event, in chain order,
+// and never concurrently.
+type Sink interface {
+	Write(ctx context.Context, event Event) error
+}
+
+// SinkFunc adapts a function to a [Sink], for example to publish events to
+// a message queue.
+//
+// Example:
+//
+//	auditlog.SinkFunc(func(ctx context.Context, event auditlog.Event) error {
+//	    data, err := json.Marshal(event)
+//	    if err != nil {
+//	        return err
+//	    }
+//	    return producer.Publish(ctx, "audit", data)
+//	})
+type SinkFunc func(ctx context.Context, event Event) error
+
+// Write implements [Sink].
+func (f SinkFunc) Write(ctx context.Context, event Event) error {
+	return f(ctx, event)
+}
+
+// writerSink is the [Sink] of [NewWriterSink].
+type writerSink struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+// NewWriterSink returns a sink that writes events to w as JSON lines.
+//
+// Example:
+//
+//	auditlog.WithSink(auditlog.NewWriterSink(os.Stdout))
+func NewWriterSink(w io.Writer) Sink {
+	return &writerSink{enc: json.NewEncoder(w)}
+}
+
+// Write implements [Sink].
+func (s *writerSink) Write(_ context.Context, event Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.enc.Encode(event)
+}
+
+// FileSink is a [Sink] that appends events to a file as JSON lines.
+type FileSink struct {
+	writerSink
+	file *os.File
+}
+
+// NewFileSink opens the file at path for appending, creating it with
+// permissions 0600 if needed, and returns a sink writing to it. Each event
+// is synced to disk before Write returns. Close the sink on shutdown.
+//
+// Example:
+//
+//	sink, err := auditlog.NewFileSink("/var/log/app/audit.jsonl")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer sink.Close()
+func NewFileSink(path string) (*FileSink, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("auditlog: open %s: %w", path, err)
+	}
+
+	return &FileSink{writerSink: writerSink{enc: json.NewEncoder(file)}, file: file}, nil
+}
+
+// Write implements [Sink].
+func (s *FileSink) Write(ctx context.Context, event Event) error {
+	if err := s.writerSink.Write(ctx, event); err != nil {
+		return err
+	}
+
+	return s.file.Sync()
+}
+
+// Close closes the file.
+func (s *FileSink) Close() error {
+	return s.file.Close()
+}
+
+// slogSink is the [Sink] of [NewSlogSink].
+type slogSink struct {
+	logger *slog.Logger
+}
+
+// NewSlogSink returns a sink that logs events with logger, at level Info
+// with the message "audit". With a logger whose handler is the
+// OpenTelemetry slog bridge, events are exported over OTLP.
+//
+// Example:
+//
+//	auditlog.WithSink(auditlog.NewSlogSink(slog.New(otelslog.NewHandler("audit"))))
+func NewSlogSink(logger *slog.Logger) Sink {
+	return &slogSink{logger: logger}
+}
+
+// Write implements [Sink].
+func (s *slogSink) Write(ctx context.Context, event Event) error {
+	attrs := []slog.Attr{
+		slog.Time("time", event.Time),
+		slog.String("actor", event.Actor),
+		slog.String("action", event.Action),
+		slog.String("resource", event.Resource),
+		slog.String("outcome", event.Outcome),
+		slog.Int("status", event.Status),
+		slog.String("method", event.Method),
+		slog.String("path", event.Path),
+		slog.String("route", event.Route),
+		slog.String("client_ip", event.ClientIP),
+		slog.String("request_id", event.RequestID),
+	}
+	if event.Before != nil {
+		attrs = append(attrs, slog.String("before", string(event.Before)))
+	}
+	if event.After != nil {
+		attrs = append(attrs, slog.String("after", string(event.After)))
+	}
+	for key, value := range event.Metadata {
+		attrs = append(attrs, slog.String("metadata."+key, value))
+	}
+	attrs = append(attrs, slog.String("prev_hash", event.PrevHash), slog.String("hash", event.Hash))
+	s.logger.LogAttrs(ctx, slog.LevelInfo, "audit", attrs...)
+
+	return nil
+}
+
+// ReadEvents reads events written as JSON lines, such as by a [FileSink],
+// for [Verify].
+//
+// Example:
+//
+//	file, err := os.Open("/var/log/app/audit.jsonl")
+//	...
+//	events, err := auditlog.ReadEvents(file)
+func ReadEvents(r io.Reader) ([]Event, error) {
+	var events []Event
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 16<<20)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var event Event
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			return nil, fmt.Errorf("auditlog: event %d: %w", len(events), err)
+		}
+		events = append(events, event)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("auditlog: read events: %w", err)
+	}
+
+	return events, nil
+}
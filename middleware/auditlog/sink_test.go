@@ -0,0 +1,99 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package auditlog
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriterSink(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	sink := NewWriterSink(&buf)
+	events := newChain(t, 3)
+	for _, event := range events {
+		require.NoError(t, sink.Write(t.Context(), event))
+	}
+
+	assert.Equal(t, 3, strings.Count(buf.String(), "\n"), "one event per line")
+	read, err := ReadEvents(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, events, read)
+	require.NoError(t, Verify(read, ""))
+}
+
+func TestFileSink(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	events := newChain(t, 4)
+
+	// Events are appended across reopens
+	for _, batch := range [][]Event{events[:2], events[2:]} {
+		sink, err := NewFileSink(path)
+		require.NoError(t, err)
+		for _, event := range batch {
+			require.NoError(t, sink.Write(t.Context(), event))
+		}
+		require.NoError(t, sink.Close())
+	}
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close() //nolint:errcheck // Test cleanup
+	read, err := ReadEvents(file)
+	require.NoError(t, err)
+	require.NoError(t, Verify(read, ""))
+	assert.Len(t, read, 4)
+
+	_, err = NewFileSink(filepath.Join(t.TempDir(), "missing", "audit.jsonl"))
+	require.Error(t, err)
+}
+
+func TestSlogSink(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	sink := NewSlogSink(slog.New(slog.NewJSONHandler(&buf, nil)))
+	event := newChain(t, 1)[0]
+	require.NoError(t, sink.Write(t.Context(), event))
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.Equal(t, "audit", record["msg"])
+	assert.Equal(t, "order.update", record["action"])
+	assert.Equal(t, `{"total":10}`, record["after"])
+	assert.Equal(t, "a", record["metadata.step"])
+	assert.Equal(t, event.Hash, record["hash"])
+}
+
+func TestReadEvents_Invalid(t *testing.T) {
+	t.Parallel()
+	_, err := ReadEvents(strings.NewReader("{\"action\":\"a\"}\n\nnot json\n"))
+	require.ErrorContains(t, err, "event 1")
+}
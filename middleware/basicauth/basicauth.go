@@ -19,7 +19,9 @@ import (
 	"crypto/subtle"
 	"encoding/base64"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"rivaas.dev/router"
 )
@@ -40,11 +42,28 @@ type config struct {
 	// validator is a custom validation function
 	validator func(username, password string) bool
 
+	// store verifies credentials; takes precedence over validator and users
+	store CredentialStore
+
 	// unauthorizedHandler is called when authentication fails
 	unauthorizedHandler func(c *router.Context)
 
 	// skipPaths are paths that should bypass authentication
 	skipPaths map[string]bool
+
+	// maxFailures is the number of consecutive failures before a lockout;
+	// 0 disables lockouts
+	maxFailures int
+
+	// lockoutBase and lockoutMax bound the exponential lockout delay
+	lockoutBase time.Duration
+	lockoutMax  time.Duration
+
+	// onFailure is called for each failed attempt
+	onFailure func(c *router.Context, event FailureEvent)
+
+	// now returns the current time; replaced in tests
+	now func() time.Time
 }
 
 // defaultConfig returns the default configuration for basicauth middleware.
@@ -55,6 +74,7 @@ func defaultConfig() *config {
 		validator:           nil,
 		unauthorizedHandler: defaultUnauthorizedHandler,
 		skipPaths:           make(map[string]bool),
+		now:                 time.Now,
 	}
 }
 
@@ -73,6 +93,9 @@ func defaultUnauthorizedHandler(c *router.Context) {
 // Security considerations:
 //   - Always use HTTPS in production - Basic Auth transmits credentials in base64 (not encrypted)
 //   - Uses constant-time comparison to prevent timing attacks
+//   - Prefer a [CredentialStore] with hashed passwords over plaintext users
+//   - Credential store errors result in 500 Internal Server Error
+//   - [WithLockout] locks out client IPs and usernames after repeated failures
 //   - Does not cache credentials - validates on every request
 //   - Realm is shown to users in browser authentication prompts
 //
@@ -92,6 +115,14 @@ func defaultUnauthorizedHandler(c *router.Context) {
 //	    basicauth.WithRealm("Admin Panel"),
 //	))
 //
+// With hashed passwords from an htpasswd file:
+//
+//	users, err := basicauth.LoadHtpasswd("/etc/myapp/htpasswd")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	r.Use(basicauth.New(basicauth.WithCredentialStore(users)))
+//
 // With custom validator (database lookup):
 //
 //	r.Use(basicauth.New(
@@ -104,6 +135,18 @@ func defaultUnauthorizedHandler(c *router.Context) {
 //	    }),
 //	))
 //
+// With brute-force protection and alerting:
+//
+//	r.Use(basicauth.New(
+//	    basicauth.WithCredentialStore(users),
+//	    basicauth.WithLockout(5, time.Second, 15*time.Minute),
+//	    basicauth.WithOnFailure(func(c *router.Context, e basicauth.FailureEvent) {
+//	        if e.LockedFor > 0 {
+//	            logger.Warn("login locked out", "user", e.Username, "ip", e.ClientIP)
+//	        }
+//	    }),
+//	))
+//
 // Skip authentication for certain paths:
 //
 //	r.Use(basicauth.New(
@@ -128,6 +171,25 @@ func New(opts ...Option) router.HandlerFunc {
 	// Pre-compute the WWW-Authenticate header
 	authenticateHeader := `Basic realm="` + cfg.realm + `"`
 
+	// Resolve the credential store
+	store := cfg.store
+	if store == nil {
+		if cfg.validator != nil {
+			validator := cfg.validator
+			store = CredentialStoreFunc(func(_ context.Context, username, password string) (bool, error) {
+				return validator(username, password), nil
+			})
+		} else {
+			store = plainUsers(cfg.users)
+		}
+	}
+
+	// Track failures for lockouts and the failure hook
+	var tracker *attemptTracker
+	if cfg.maxFailures > 0 || cfg.onFailure != nil {
+		tracker = newAttemptTracker(cfg.maxFailures, cfg.lockoutBase, cfg.lockoutMax, cfg.now)
+	}
+
 	return func(c *router.Context) {
 		// Check if path should be skipped
 		if cfg.skipPaths[c.Request.URL.Path] {
@@ -179,24 +241,47 @@ func New(opts ...Option) router.HandlerFunc {
 		username := before
 		password := after
 
-		// Validate credentials
-		var authenticated bool
-		if cfg.validator != nil {
-			// Use custom validator
-			authenticated = cfg.validator(username, password)
-		} else {
-			// Use static users map
-			expectedPassword, exists := cfg.users[username]
-			if exists {
-				// Use constant-time comparison to prevent timing attacks
-				authenticated = subtle.ConstantTimeCompare(
-					[]byte(password),
-					[]byte(expectedPassword),
-				) == 1
+		// Reject locked-out clients and usernames without checking the password
+		if tracker != nil {
+			if locked, failures := tracker.lockedFor(c.ClientIP(), username); locked > 0 {
+				if cfg.onFailure != nil {
+					cfg.onFailure(c, FailureEvent{
+						Username:  username,
+						ClientIP:  c.ClientIP(),
+						Failures:  failures,
+						LockedFor: locked,
+						Rejected:  true,
+					})
+				}
+				c.Header("Retry-After", strconv.FormatInt(int64((locked+time.Second-1)/time.Second), 10))
+				c.WriteErrorResponse(http.StatusTooManyRequests, "too many failed attempts")
+				c.Abort()
+
+				return
 			}
 		}
 
+		// Validate credentials
+		authenticated, err := store.Verify(c.Request.Context(), username, password)
+		if err != nil {
+			c.WriteErrorResponse(http.StatusInternalServerError, "authentication unavailable")
+			c.Abort()
+
+			return
+		}
+
 		if !authenticated {
+			if tracker != nil {
+				failures, locked := tracker.fail(c.ClientIP(), username)
+				if cfg.onFailure != nil {
+					cfg.onFailure(c, FailureEvent{
+						Username:  username,
+						ClientIP:  c.ClientIP(),
+						Failures:  failures,
+						LockedFor: locked,
+					})
+				}
+			}
 			c.Response.Header().Set("WWW-Authenticate", authenticateHeader)
 			cfg.unauthorizedHandler(c)
 			c.Abort()
@@ -204,6 +289,12 @@ func New(opts ...Option) router.HandlerFunc {
 			return
 		}
 
+		// A successful login clears the failures of the username; those of
+		// the client IP remain, so one valid account cannot reset them
+		if tracker != nil {
+			tracker.resetUser(username)
+		}
+
 		// Authentication successful - store username in request context for later use
 		ctx := context.WithValue(c.Request.Context(), contextKey{}, username)
 		c.Request = c.Request.WithContext(ctx)
@@ -227,3 +318,18 @@ func Username(c *router.Context) string {
 
 	return ""
 }
+
+// plainUsers is the [CredentialStore] of [WithUsers]: plaintext passwords
+// compared in constant time.
+type plainUsers map[string]string
+
+// Verify implements [CredentialStore].
+func (u plainUsers) Verify(_ context.Context, username, password string) (bool, error) {
+	expectedPassword, exists := u[username]
+	if !exists {
+		return false, nil
+	}
+
+	// Use constant-time comparison to prevent timing attacks
+	return subtle.ConstantTimeCompare([]byte(password), []byte(expectedPassword)) == 1, nil
+}
@@ -35,9 +35,42 @@
 // # Configuration Options
 //
 //   - Validator: Function to validate username/password credentials
+//   - CredentialStore: Pluggable verification with hashed passwords
+//   - Lockout: Brute-force protection with exponential backoff
 //   - Realm: Authentication realm name (displayed in browser prompt)
 //   - SkipPaths: Paths to skip authentication (e.g., /health, /public)
 //
+// # Credential Stores
+//
+// A [CredentialStore] verifies credentials without comparing plaintext
+// passwords. [HashedUsers] maps usernames to bcrypt or Apache MD5 (apr1)
+// hashes, [LoadHtpasswd] reads them from an htpasswd file, and
+// [CredentialStoreFunc] adapts a custom lookup:
+//
+//	users, err := basicauth.LoadHtpasswd("/etc/myapp/htpasswd")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	r.Use(basicauth.New(basicauth.WithCredentialStore(users)))
+//
+// Unknown users take as long to reject as known users. If the store returns
+// an error, the request fails with 500 Internal Server Error.
+//
+// # Brute-Force Protection
+//
+// [WithLockout] counts consecutive failed logins per client IP and per
+// username and rejects further attempts with 429 Too Many Requests for an
+// exponentially growing delay. [WithOnFailure] reports each failure, for
+// logging or alerting:
+//
+//	r.Use(basicauth.New(
+//	    basicauth.WithCredentialStore(users),
+//	    basicauth.WithLockout(5, time.Second, 15*time.Minute),
+//	    basicauth.WithOnFailure(func(c *router.Context, e basicauth.FailureEvent) {
+//	        logger.Warn("failed login", "user", e.Username, "ip", e.ClientIP, "failures", e.Failures)
+//	    }),
+//	))
+//
 // # Accessing Authenticated User
 //
 // The authenticated username is stored in the request context and can be
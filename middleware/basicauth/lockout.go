@@ -0,0 +1,199 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package basicauth
+
+import (
+	"sync"
+	"time"
+)
+
+// minFailureTTL is the minimum time failures are remembered after the last
+// one. It is longer if the maximum lockout delay is longer.
+const minFailureTTL = 15 * time.Minute
+
+// sweepInterval is how often forgotten entries are removed.
+const sweepInterval = time.Minute
+
+// FailureEvent describes a failed authentication attempt, passed to the hook
+// of [WithOnFailure].
+type FailureEvent struct {
+	// Username from the request
+	Username string
+
+	// ClientIP of the request
+	ClientIP string
+
+	// Failures is the number of consecutive failures for the client IP or
+	// the username, whichever is higher
+	Failures int
+
+	// LockedFor is how long further attempts are rejected; zero if not locked
+	LockedFor time.Duration
+
+	// Rejected reports that the request was rejected because of an earlier
+	// lockout, without checking its credentials
+	Rejected bool
+}
+
+// attempts is the failure state of a client IP or username.
+type attempts struct {
+	failures    int
+	last        time.Time
+	lockedUntil time.Time
+}
+
+// maxTrackedUsernames limits the usernames with recorded failures. Usernames
+// come from the request, so without a limit a client could grow the tracker
+// without bound. Client IPs are always tracked.
+const maxTrackedUsernames = 100_000
+
+// attemptTracker counts consecutive failed attempts per client IP and per
+// username and locks them out with exponential backoff. It is safe for
+// concurrent use.
+type attemptTracker struct {
+	maxFailures int // 0 never locks
+	baseDelay   time.Duration
+	maxDelay    time.Duration
+	ttl         time.Duration
+	maxUsers    int
+	now         func() time.Time
+
+	mu        sync.Mutex
+	ips       map[string]*attempts // By client IP
+	users     map[string]*attempts // By username, at most maxUsers
+	lastSweep time.Time
+}
+
+func newAttemptTracker(maxFailures int, baseDelay, maxDelay time.Duration, now func() time.Time) *attemptTracker {
+	return &attemptTracker{
+		maxFailures: maxFailures,
+		baseDelay:   baseDelay,
+		maxDelay:    maxDelay,
+		ttl:         max(maxDelay, minFailureTTL),
+		maxUsers:    maxTrackedUsernames,
+		now:         now,
+		ips:         make(map[string]*attempts),
+		users:       make(map[string]*attempts),
+	}
+}
+
+// lockedFor returns how long the more restricted of ip and username is still
+// locked. It also returns the higher failure count of the two.
+func (t *attemptTracker) lockedFor(ip, username string) (time.Duration, int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	var locked time.Duration
+	var failures int
+	for _, a := range []*attempts{t.ips[ip], t.users[username]} {
+		if a != nil {
+			locked = max(locked, a.lockedUntil.Sub(now))
+			failures = max(failures, a.failures)
+		}
+	}
+
+	return locked, failures
+}
+
+// fail records a failure for ip and username. It returns the higher failure
+// count of the two and the longer resulting lockout. Once maxUsers usernames
+// are tracked, failures of other usernames count for the client IP only.
+func (t *attemptTracker) fail(ip, username string) (int, time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	t.sweep(now)
+
+	failures, locked := t.record(t.ips, ip, now)
+	if _, ok := t.users[username]; ok || len(t.users) < t.maxUsers {
+		userFailures, userLocked := t.record(t.users, username, now)
+		failures = max(failures, userFailures)
+		locked = max(locked, userLocked)
+	}
+
+	return failures, locked
+}
+
+// record records a failure for key in entries. It returns the failure count
+// of key and the resulting lockout. The caller must hold t.mu.
+func (t *attemptTracker) record(entries map[string]*attempts, key string, now time.Time) (int, time.Duration) {
+	a, ok := entries[key]
+	if !ok {
+		a = &attempts{}
+		entries[key] = a
+	} else if t.forgotten(a, now) {
+		// Not yet swept, but forgotten
+		*a = attempts{}
+	}
+	a.failures++
+	a.last = now
+
+	var locked time.Duration
+	if delay := t.delay(a.failures); delay > 0 {
+		a.lockedUntil = now.Add(delay)
+		locked = delay
+	}
+
+	return a.failures, locked
+}
+
+// resetUser forgets the failures of username.
+func (t *attemptTracker) resetUser(username string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.users, username)
+}
+
+// delay returns the lockout after the given number of consecutive failures:
+// baseDelay at maxFailures, doubling with each further failure, capped at
+// maxDelay.
+func (t *attemptTracker) delay(failures int) time.Duration {
+	if t.maxFailures <= 0 || failures < t.maxFailures {
+		return 0
+	}
+
+	// Beyond 30 doublings any useful base delay exceeds the cap
+	shift := failures - t.maxFailures
+	if shift > 30 {
+		return t.maxDelay
+	}
+
+	return min(t.baseDelay<<shift, t.maxDelay)
+}
+
+// sweep removes entries without failures in the TTL whose lockout has
+// expired, at most once per sweepInterval. The caller must hold t.mu.
+func (t *attemptTracker) sweep(now time.Time) {
+	if now.Sub(t.lastSweep) < sweepInterval {
+		return
+	}
+	t.lastSweep = now
+
+	for _, entries := range []map[string]*attempts{t.ips, t.users} {
+		for key, a := range entries {
+			if t.forgotten(a, now) {
+				delete(entries, key)
+			}
+		}
+	}
+}
+
+// forgotten reports whether a has no failures in the TTL and its lockout has
+// expired.
+func (t *attemptTracker) forgotten(a *attempts, now time.Time) bool {
+	return now.Sub(a.last) >= t.ttl && !now.Before(a.lockedUntil)
+}
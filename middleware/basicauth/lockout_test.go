@@ -0,0 +1,243 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package basicauth
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"rivaas.dev/router"
+)
+
+// fakeClock is a manually advanced clock.
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (f *fakeClock) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+func (f *fakeClock) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = f.now.Add(d)
+}
+
+// newLockoutRouter returns a router protected with lockouts after 3
+// failures, and the events passed to the failure hook.
+func newLockoutRouter(t *testing.T, clock *fakeClock) (*router.Router, *[]FailureEvent) {
+	t.Helper()
+	var events []FailureEvent
+	r := router.MustNew()
+	r.Use(New(
+		WithUsers(map[string]string{"admin": "secret", "alice": "wonderland"}),
+		WithLockout(3, time.Second, 10*time.Second),
+		WithOnFailure(func(_ *router.Context, e FailureEvent) {
+			events = append(events, e)
+		}),
+		func(cfg *config) { cfg.now = clock.Now },
+	))
+	r.GET("/test", func(c *router.Context) {
+		//nolint:errcheck // Test handler
+		c.String(http.StatusOK, "ok")
+	})
+
+	return r, &events
+}
+
+func login(t *testing.T, r *router.Router, remoteAddr, username, password string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/test", nil)
+	req.RemoteAddr = remoteAddr
+	req.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(username+":"+password)))
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	return w
+}
+
+func TestBasicAuth_Lockout(t *testing.T) {
+	t.Parallel()
+	clock := &fakeClock{now: time.Unix(1_700_000_000, 0)}
+	r, events := newLockoutRouter(t, clock)
+	const ip = "192.0.2.1:1234"
+
+	for range 2 {
+		assert.Equal(t, http.StatusUnauthorized, login(t, r, ip, "admin", "wrong").Code)
+	}
+	// The third failure locks out for the base delay
+	assert.Equal(t, http.StatusUnauthorized, login(t, r, ip, "admin", "wrong").Code)
+
+	// Correct credentials are rejected during the lockout
+	w := login(t, r, ip, "admin", "secret")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "1", w.Header().Get("Retry-After"))
+
+	require.Len(t, *events, 4)
+	assert.Equal(t, FailureEvent{Username: "admin", ClientIP: "192.0.2.1", Failures: 1}, (*events)[0])
+	assert.Equal(t, FailureEvent{Username: "admin", ClientIP: "192.0.2.1", Failures: 3, LockedFor: time.Second}, (*events)[2])
+	assert.Equal(t, FailureEvent{
+		Username: "admin", ClientIP: "192.0.2.1", Failures: 3, LockedFor: time.Second, Rejected: true,
+	}, (*events)[3])
+
+	// The next failure doubles the lockout
+	clock.Advance(time.Second)
+	assert.Equal(t, http.StatusUnauthorized, login(t, r, ip, "admin", "wrong").Code)
+	assert.Equal(t, 2*time.Second, (*events)[4].LockedFor)
+	w = login(t, r, ip, "admin", "secret")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "2", w.Header().Get("Retry-After"))
+
+	// After the lockout, a successful login passes
+	clock.Advance(2 * time.Second)
+	assert.Equal(t, http.StatusOK, login(t, r, ip, "admin", "secret").Code)
+}
+
+func TestBasicAuth_LockoutPerUsername(t *testing.T) {
+	t.Parallel()
+	clock := &fakeClock{now: time.Unix(1_700_000_000, 0)}
+	r, _ := newLockoutRouter(t, clock)
+
+	// Failures for one username from different IPs
+	for _, ip := range []string{"192.0.2.1:1234", "192.0.2.2:1234", "192.0.2.3:1234"} {
+		login(t, r, ip, "admin", "wrong")
+	}
+
+	// The username is locked from any IP, other usernames are not
+	assert.Equal(t, http.StatusTooManyRequests, login(t, r, "198.51.100.7:1234", "admin", "secret").Code)
+	assert.Equal(t, http.StatusOK, login(t, r, "198.51.100.7:1234", "alice", "wonderland").Code)
+}
+
+func TestBasicAuth_LockoutPerIP(t *testing.T) {
+	t.Parallel()
+	clock := &fakeClock{now: time.Unix(1_700_000_000, 0)}
+	r, _ := newLockoutRouter(t, clock)
+	const ip = "192.0.2.1:1234"
+
+	// Guessing different usernames from one IP
+	for _, username := range []string{"root", "test", "guest"} {
+		login(t, r, ip, username, "password")
+	}
+
+	assert.Equal(t, http.StatusTooManyRequests, login(t, r, ip, "alice", "wonderland").Code)
+	assert.Equal(t, http.StatusOK, login(t, r, "198.51.100.7:1234", "alice", "wonderland").Code)
+}
+
+func TestBasicAuth_LockoutResetOnSuccess(t *testing.T) {
+	t.Parallel()
+	clock := &fakeClock{now: time.Unix(1_700_000_000, 0)}
+	r, events := newLockoutRouter(t, clock)
+
+	login(t, r, "192.0.2.1:1234", "admin", "wrong")
+	login(t, r, "192.0.2.2:1234", "admin", "wrong")
+	assert.Equal(t, http.StatusOK, login(t, r, "192.0.2.3:1234", "admin", "secret").Code)
+
+	// The username counter starts over
+	login(t, r, "192.0.2.4:1234", "admin", "wrong")
+	assert.Equal(t, 1, (*events)[len(*events)-1].Failures)
+
+	// Failures are forgotten after a quiet period
+	login(t, r, "192.0.2.4:1234", "admin", "wrong")
+	clock.Advance(minFailureTTL)
+	login(t, r, "192.0.2.4:1234", "admin", "wrong")
+	assert.Equal(t, 1, (*events)[len(*events)-1].Failures)
+}
+
+func TestBasicAuth_OnFailureWithoutLockout(t *testing.T) {
+	t.Parallel()
+	var failures []int
+	r := router.MustNew()
+	r.Use(New(
+		WithUsers(map[string]string{"admin": "secret"}),
+		WithOnFailure(func(_ *router.Context, e FailureEvent) {
+			failures = append(failures, e.Failures)
+		}),
+	))
+	r.GET("/test", func(c *router.Context) {
+		//nolint:errcheck // Test handler
+		c.String(http.StatusOK, "ok")
+	})
+
+	for range 5 {
+		assert.Equal(t, http.StatusUnauthorized, login(t, r, "192.0.2.1:1234", "admin", "wrong").Code)
+	}
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, failures)
+}
+
+func TestAttemptTracker_Delay(t *testing.T) {
+	t.Parallel()
+	tracker := newAttemptTracker(3, time.Second, time.Minute, time.Now)
+
+	assert.Zero(t, tracker.delay(2))
+	assert.Equal(t, time.Second, tracker.delay(3))
+	assert.Equal(t, 4*time.Second, tracker.delay(5))
+	assert.Equal(t, time.Minute, tracker.delay(10))
+	assert.Equal(t, time.Minute, tracker.delay(1000))
+
+	assert.Zero(t, newAttemptTracker(0, time.Second, time.Minute, time.Now).delay(1000))
+}
+
+func TestAttemptTracker_Sweep(t *testing.T) {
+	t.Parallel()
+	clock := &fakeClock{now: time.Unix(1_700_000_000, 0)}
+	tracker := newAttemptTracker(3, time.Second, time.Minute, clock.Now)
+
+	tracker.fail("192.0.2.1", "alice")
+	clock.Advance(minFailureTTL)
+	tracker.fail("192.0.2.2", "bob")
+
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+	assert.Len(t, tracker.ips, 1)
+	assert.Contains(t, tracker.ips, "192.0.2.2")
+	assert.Len(t, tracker.users, 1)
+	assert.Contains(t, tracker.users, "bob")
+}
+
+func TestAttemptTracker_MaxUsers(t *testing.T) {
+	t.Parallel()
+	clock := &fakeClock{now: time.Unix(1_700_000_000, 0)}
+	tracker := newAttemptTracker(3, time.Second, time.Minute, clock.Now)
+	tracker.maxUsers = 2
+
+	for i := range 10 {
+		tracker.fail("192.0.2.1", "user"+strconv.Itoa(i))
+	}
+	failures, locked := tracker.fail("192.0.2.2", "user0")
+	assert.Equal(t, 2, failures, "tracked usernames keep counting")
+	assert.Zero(t, locked)
+
+	tracker.mu.Lock()
+	assert.Len(t, tracker.users, 2, "new usernames are not tracked past the limit")
+	tracker.mu.Unlock()
+
+	locked, failures = tracker.lockedFor("192.0.2.1", "user9")
+	assert.Positive(t, locked, "the client IP is still locked out")
+	assert.Equal(t, 10, failures)
+}
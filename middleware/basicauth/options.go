@@ -14,10 +14,15 @@
 
 package basicauth
 
-import "rivaas.dev/router"
+import (
+	"time"
+
+	"rivaas.dev/router"
+)
 
 // WithUsers sets the allowed username/password pairs.
 // Passwords are compared using constant-time comparison to prevent timing attacks.
+// They are kept in plaintext; prefer [WithCredentialStore] with [HashedUsers].
 //
 // Example:
 //
@@ -59,6 +64,23 @@ func WithValidator(validator func(username, password string) bool) Option {
 	}
 }
 
+// WithCredentialStore sets the store that verifies credentials, such as
+// [HashedUsers], an htpasswd file from [LoadHtpasswd], or a
+// [CredentialStoreFunc] for custom lookups. It takes precedence over
+// [WithValidator] and [WithUsers]. If the store returns an error, the
+// request fails with 500 Internal Server Error.
+//
+// Example:
+//
+//	basicauth.New(basicauth.WithCredentialStore(basicauth.HashedUsers{
+//	    "admin": "$2y$10$...", // htpasswd -nB admin
+//	}))
+func WithCredentialStore(store CredentialStore) Option {
+	return func(cfg *config) {
+		cfg.store = store
+	}
+}
+
 // WithUnauthorizedHandler sets a custom handler for unauthorized requests.
 // This allows custom error responses or redirects.
 //
@@ -86,3 +108,48 @@ func WithSkipPaths(paths ...string) Option {
 		}
 	}
 }
+
+// WithLockout enables brute-force protection. Failed attempts are counted per
+// client IP and per username; after maxFailures consecutive failures of
+// either, further attempts are rejected with 429 Too Many Requests and a
+// Retry-After header, without checking their credentials. The lockout lasts
+// baseDelay and doubles with each further failure, up to maxDelay.
+//
+// A successful login clears the failures of the username, but not those of
+// the client IP. Failures are forgotten 15 minutes (or maxDelay, if longer)
+// after the last one. At most 100,000 usernames are tracked at a time; beyond
+// that, failures of new usernames count for the client IP only. Counters are
+// kept in memory per middleware instance, and client IPs come from
+// [router.Context.ClientIP], so configure trusted proxies on the router.
+//
+// Note that locking out a username lets anyone deny its owner access for a
+// while; keep maxDelay short.
+//
+// Example:
+//
+//	// Lock out for 1s after 5 failures, then 2s, 4s, ... up to 15 minutes
+//	basicauth.New(basicauth.WithLockout(5, time.Second, 15*time.Minute))
+func WithLockout(maxFailures int, baseDelay, maxDelay time.Duration) Option {
+	return func(cfg *config) {
+		cfg.maxFailures = maxFailures
+		cfg.lockoutBase = baseDelay
+		cfg.lockoutMax = maxDelay
+	}
+}
+
+// WithOnFailure sets a hook that is called for each failed login and for each
+// request rejected because of a lockout, for logging or alerting on repeated
+// failures. It is called before the response is written.
+//
+// Example:
+//
+//	basicauth.New(basicauth.WithOnFailure(func(c *router.Context, e basicauth.FailureEvent) {
+//	    if e.Failures >= 10 {
+//	        alerts.Notify("possible brute-force attack", e.ClientIP, e.Username)
+//	    }
+//	}))
+func WithOnFailure(fn func(c *router.Context, event FailureEvent)) Option {
+	return func(cfg *config) {
+		cfg.onFailure = fn
+	}
+}
@@ -0,0 +1,253 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package basicauth
+
+import (
+	"bufio"
+	"context"
+	"crypto/md5" //nolint:gosec // Required by the Apache MD5 (apr1) htpasswd format
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// ErrUnsupportedHash is returned for password hashes in a format other than
+// bcrypt or Apache MD5 (apr1).
+var ErrUnsupportedHash = errors.New("unsupported password hash format")
+
+// CredentialStore verifies usernames and passwords. Verify reports whether
+// password is valid for username; it returns an error only if the check
+// itself failed, such as when a database is unreachable.
+type CredentialStore interface {
+	Verify(ctx context.Context, username, password string) (bool, error)
+}
+
+// CredentialStoreFunc adapts a function to a [CredentialStore], for custom
+// lookups such as a database or LDAP.
+//
+// Example:
+//
+//	basicauth.WithCredentialStore(basicauth.CredentialStoreFunc(
+//	    func(ctx context.Context, username, password string) (bool, error) {
+//	        hash, err := db.PasswordHash(ctx, username)
+//	        if errors.Is(err, sql.ErrNoRows) {
+//	            return false, nil
+//	        }
+//	        if err != nil {
+//	            return false, err
+//	        }
+//	        return bcrypt.CompareHashAndPassword(hash, []byte(password)) == nil, nil
+//	    },
+//	))
+type CredentialStoreFunc func(ctx context.Context, username, password string) (bool, error)
+
+// Verify implements [CredentialStore].
+func (f CredentialStoreFunc) Verify(ctx context.Context, username, password string) (bool, error) {
+	return f(ctx, username, password)
+}
+
+// HashedUsers is a [CredentialStore] that maps usernames to password hashes
+// in htpasswd formats: bcrypt ("$2y$", "$2a$", "$2b$") and Apache MD5
+// ("$apr1$"). Prefer bcrypt; apr1 is supported for existing files. Plaintext
+// passwords are never stored or compared.
+//
+// Example:
+//
+//	basicauth.WithCredentialStore(basicauth.HashedUsers{
+//	    "admin": "$2y$10$T9y5z3...", // htpasswd -nB admin
+//	})
+type HashedUsers map[string]string
+
+// dummyHash is verified for unknown users, so that they take as long as
+// known users and cannot be told apart by timing.
+var dummyHash = sync.OnceValue(func() []byte {
+	hash, err := bcrypt.GenerateFromPassword([]byte("dummy password"), bcrypt.DefaultCost)
+	if err != nil {
+		panic(err)
+	}
+	return hash
+})
+
+// Verify implements [CredentialStore]. It returns [ErrUnsupportedHash] if
+// the hash of username has an unknown format.
+func (h HashedUsers) Verify(_ context.Context, username, password string) (bool, error) {
+	hash, ok := h[username]
+	if !ok {
+		//nolint:errcheck // Only spends the time of a real comparison
+		bcrypt.CompareHashAndPassword(dummyHash(), []byte(password))
+		return false, nil
+	}
+
+	return verifyHash(hash, password)
+}
+
+// verifyHash compares password with hash in constant time.
+func verifyHash(hash, password string) (bool, error) {
+	switch {
+	case strings.HasPrefix(hash, "$2y$"), strings.HasPrefix(hash, "$2a$"), strings.HasPrefix(hash, "$2b$"):
+		err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return false, nil
+		}
+		if err != nil {
+			return false, fmt.Errorf("basicauth: %w", err)
+		}
+		return true, nil
+
+	case strings.HasPrefix(hash, apr1Magic):
+		salt, _, ok := strings.Cut(hash[len(apr1Magic):], "$")
+		if !ok {
+			return false, fmt.Errorf("basicauth: malformed apr1 hash: %w", ErrUnsupportedHash)
+		}
+		return subtle.ConstantTimeCompare([]byte(apr1(password, salt)), []byte(hash)) == 1, nil
+
+	default:
+		return false, fmt.Errorf("basicauth: %w", ErrUnsupportedHash)
+	}
+}
+
+// LoadHtpasswd reads an htpasswd file (as written by Apache's htpasswd -B
+// or -m) into a [HashedUsers] store. It fails on entries with hashes other
+// than bcrypt and apr1, such as crypt, SHA-1, or plaintext.
+//
+// Example:
+//
+//	users, err := basicauth.LoadHtpasswd("/etc/myapp/htpasswd")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	r.Use(basicauth.New(basicauth.WithCredentialStore(users)))
+func LoadHtpasswd(path string) (HashedUsers, error) {
+	f, err := os.Open(path) //nolint:gosec // Path is chosen by the application
+	if err != nil {
+		return nil, fmt.Errorf("basicauth: %w", err)
+	}
+	defer f.Close() //nolint:errcheck // Read-only file
+
+	return ParseHtpasswd(f)
+}
+
+// ParseHtpasswd parses htpasswd content: one "username:hash" entry per line.
+// Empty lines and lines starting with '#' are ignored. See [LoadHtpasswd].
+func ParseHtpasswd(r io.Reader) (HashedUsers, error) {
+	users := make(HashedUsers)
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		entry := strings.TrimSpace(scanner.Text())
+		if entry == "" || strings.HasPrefix(entry, "#") {
+			continue
+		}
+
+		username, hash, ok := strings.Cut(entry, ":")
+		if !ok || username == "" {
+			return nil, fmt.Errorf("basicauth: htpasswd line %d: missing username or hash", line)
+		}
+		if !isSupportedHash(hash) {
+			return nil, fmt.Errorf("basicauth: htpasswd line %d (user %q): %w", line, username, ErrUnsupportedHash)
+		}
+		users[username] = hash
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("basicauth: %w", err)
+	}
+
+	return users, nil
+}
+
+// isSupportedHash reports whether hash has a format verifyHash understands.
+func isSupportedHash(hash string) bool {
+	for _, prefix := range [...]string{"$2y$", "$2a$", "$2b$", apr1Magic} {
+		if strings.HasPrefix(hash, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// apr1Magic prefixes Apache MD5 hashes.
+const apr1Magic = "$apr1$"
+
+// apr1 computes the Apache variant of the MD5-based crypt for password and
+// salt, as in "$apr1$salt$hash".
+func apr1(password, salt string) string {
+	if len(salt) > 8 {
+		salt = salt[:8]
+	}
+	pw := []byte(password)
+
+	//nolint:gosec // Required by the format
+	ctx := md5.New()
+	ctx.Write(pw)
+	ctx.Write([]byte(apr1Magic))
+	ctx.Write([]byte(salt))
+
+	//nolint:gosec // Required by the format
+	alt := md5.Sum([]byte(password + salt + password))
+	for i := len(pw); i > 0; i -= 16 {
+		ctx.Write(alt[:min(i, 16)])
+	}
+	for i := len(pw); i > 0; i >>= 1 {
+		if i&1 != 0 {
+			ctx.Write([]byte{0})
+		} else {
+			ctx.Write(pw[:1])
+		}
+	}
+	final := ctx.Sum(nil)
+
+	// Deliberately slow the computation down
+	for i := range 1000 {
+		//nolint:gosec // Required by the format
+		round := md5.New()
+		if i&1 != 0 {
+			round.Write(pw)
+		} else {
+			round.Write(final)
+		}
+		if i%3 != 0 {
+			round.Write([]byte(salt))
+		}
+		if i%7 != 0 {
+			round.Write(pw)
+		}
+		if i&1 != 0 {
+			round.Write(final)
+		} else {
+			round.Write(pw)
+		}
+		final = round.Sum(nil)
+	}
+
+	const itoa64 = "./0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	out := make([]byte, 0, 22)
+	encode := func(v uint32, n int) {
+		for ; n > 0; n-- {
+			out = append(out, itoa64[v&0x3f])
+			v >>= 6
+		}
+	}
+	for _, idx := range [...][3]int{{0, 6, 12}, {1, 7, 13}, {2, 8, 14}, {3, 9, 15}, {4, 10, 5}} {
+		encode(uint32(final[idx[0]])<<16|uint32(final[idx[1]])<<8|uint32(final[idx[2]]), 4)
+	}
+	encode(uint32(final[11]), 2)
+
+	return apr1Magic + salt + "$" + string(out)
+}
@@ -0,0 +1,168 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package basicauth
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	"rivaas.dev/router"
+)
+
+func TestApr1(t *testing.T) {
+	t.Parallel()
+	// Vectors from openssl passwd -apr1
+	assert.Equal(t, "$apr1$ZjTqBB3f$kXd3pVNixJ/A.AKl0/Ew1.", apr1("password", "ZjTqBB3f"))
+	assert.Equal(t, "$apr1$abc$uBOMrTyDFIo2v7CWwIuDC/", apr1("a longer password with 20+ chars", "abc"))
+}
+
+func TestHashedUsers(t *testing.T) {
+	t.Parallel()
+	bcryptHash, err := bcrypt.GenerateFromPassword([]byte("s3cret"), bcrypt.MinCost)
+	require.NoError(t, err)
+	// htpasswd -B writes the $2y$ prefix
+	htpasswdHash := "$2y$" + strings.TrimPrefix(string(bcryptHash), "$2a$")
+
+	users := HashedUsers{
+		"alice": string(bcryptHash),
+		"bob":   htpasswdHash,
+		"carol": "$apr1$ZjTqBB3f$kXd3pVNixJ/A.AKl0/Ew1.",
+		"dave":  "plaintext",
+	}
+
+	tests := []struct {
+		name     string
+		username string
+		password string
+		want     bool
+		wantErr  error
+	}{
+		{name: "bcrypt", username: "alice", password: "s3cret", want: true},
+		{name: "bcrypt wrong password", username: "alice", password: "wrong"},
+		{name: "bcrypt $2y$", username: "bob", password: "s3cret", want: true},
+		{name: "apr1", username: "carol", password: "password", want: true},
+		{name: "apr1 wrong password", username: "carol", password: "Password"},
+		{name: "unknown user", username: "mallory", password: "s3cret"},
+		{name: "unsupported hash", username: "dave", password: "plaintext", wantErr: ErrUnsupportedHash},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ok, err := users.Verify(t.Context(), tt.username, tt.password)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, ok)
+		})
+	}
+}
+
+func TestParseHtpasswd(t *testing.T) {
+	t.Parallel()
+	users, err := ParseHtpasswd(strings.NewReader(`# users
+carol:$apr1$ZjTqBB3f$kXd3pVNixJ/A.AKl0/Ew1.
+
+  erin:$apr1$abc$uBOMrTyDFIo2v7CWwIuDC/
+`))
+	require.NoError(t, err)
+	assert.Equal(t, HashedUsers{
+		"carol": "$apr1$ZjTqBB3f$kXd3pVNixJ/A.AKl0/Ew1.",
+		"erin":  "$apr1$abc$uBOMrTyDFIo2v7CWwIuDC/",
+	}, users)
+
+	_, err = ParseHtpasswd(strings.NewReader("carol:$apr1$ZjTqBB3f$kXd3pVNixJ/A.AKl0/Ew1.\nno-hash\n"))
+	require.ErrorContains(t, err, "line 2")
+
+	// SHA-1 entries written by htpasswd -s
+	_, err = ParseHtpasswd(strings.NewReader("frank:{SHA}W6ph5Mm5Pz8GgiULbPgzG37mj9g=\n"))
+	require.ErrorIs(t, err, ErrUnsupportedHash)
+}
+
+func TestLoadHtpasswd(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "htpasswd")
+	require.NoError(t, os.WriteFile(path, []byte("carol:$apr1$ZjTqBB3f$kXd3pVNixJ/A.AKl0/Ew1.\n"), 0o600))
+
+	users, err := LoadHtpasswd(path)
+	require.NoError(t, err)
+	ok, err := users.Verify(t.Context(), "carol", "password")
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	_, err = LoadHtpasswd(filepath.Join(t.TempDir(), "missing"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestBasicAuth_CredentialStore(t *testing.T) {
+	t.Parallel()
+	errBackend := errors.New("backend down")
+	store := CredentialStoreFunc(func(_ context.Context, username, password string) (bool, error) {
+		switch username {
+		case "broken":
+			return false, errBackend
+		case "admin":
+			return password == "from-store", nil
+		default:
+			return false, nil
+		}
+	})
+
+	r := router.MustNew()
+	r.Use(New(
+		WithCredentialStore(store),
+		// The store takes precedence over plaintext users
+		WithUsers(map[string]string{"admin": "from-users"}),
+	))
+	r.GET("/test", func(c *router.Context) {
+		//nolint:errcheck // Test handler
+		c.String(http.StatusOK, "user="+Username(c))
+	})
+
+	serve := func(username, password string) *httptest.ResponseRecorder {
+		req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/test", nil)
+		req.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(username+":"+password)))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := serve("admin", "from-store")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "user=admin", w.Body.String())
+
+	w = serve("admin", "from-users")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	w = serve("broken", "anything")
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.NotContains(t, w.Body.String(), "user=")
+	assert.Empty(t, w.Header().Get("WWW-Authenticate"))
+}
@@ -0,0 +1,264 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"rivaas.dev/router"
+)
+
+// Option defines functional options for cache middleware configuration.
+type Option func(*config)
+
+// config holds the configuration for the cache middleware.
+type config struct {
+	// store keeps the responses
+	store Store
+
+	// ttl is how long responses are cached
+	ttl time.Duration
+
+	// keyFunc returns the cache key of a request; nil uses the method, path,
+	// and query
+	keyFunc func(c *router.Context) string
+
+	// keyHeaders are request headers added to the key
+	keyHeaders []string
+
+	// methods are the request methods whose responses are cached
+	methods map[string]bool
+
+	// statuses are the status codes of cacheable responses
+	statuses map[int]bool
+
+	// maxBodySize is the largest body that is cached
+	maxBodySize int
+
+	// cacheControl sets Cache-Control on cacheable responses without one
+	cacheControl bool
+
+	// skipPaths are paths the middleware does not apply to
+	skipPaths map[string]bool
+
+	// logger logs store failures; nil disables logging
+	logger *slog.Logger
+}
+
+// defaultConfig returns the default configuration for cache middleware.
+func defaultConfig() *config {
+	return &config{
+		ttl:          time.Minute,
+		methods:      map[string]bool{http.MethodGet: true, http.MethodHead: true},
+		statuses:     map[int]bool{http.StatusOK: true},
+		maxBodySize:  1 << 20,
+		cacheControl: true,
+		skipPaths:    make(map[string]bool),
+		logger:       slog.Default(),
+	}
+}
+
+// New returns a middleware that caches responses.
+//
+// The first request for a key runs the handler and stores its response;
+// later requests get the stored response, with the Age and X-Cache: HIT
+// headers, until it expires. The key is the method, path, and query by
+// default. Responses are stored per value of the request headers listed in
+// their Vary header.
+//
+// Only GET and HEAD requests with a 200 OK response are cached by default,
+// for one minute. Responses are not cached if they set cookies, are larger
+// than 1 MiB, are streamed with Flush, or have a Cache-Control of no-store,
+// no-cache, or private. Requests with Cache-Control: no-cache skip the
+// cache lookup, and no-store skips the cache entirely.
+//
+// Cacheable responses without a Cache-Control header get
+// "public, max-age=<ttl>", so browsers and CDNs can cache them too.
+//
+// Example:
+//
+//	r := router.MustNew()
+//	r.GET("/products", listProducts, cache.New(cache.WithTTL(5*time.Minute)))
+func New(opts ...Option) router.HandlerFunc {
+	cfg := defaultConfig()
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	if cfg.store == nil {
+		cfg.store = NewMemoryStore(0)
+	}
+
+	return func(c *router.Context) {
+		if !cfg.methods[c.Request.Method] || cfg.skipPaths[c.Request.URL.Path] {
+			c.Next()
+			return
+		}
+
+		directives := c.Request.Header.Get("Cache-Control")
+		if hasDirective(directives, "no-store") {
+			c.Next()
+			return
+		}
+
+		key := cfg.key(c)
+		if !hasDirective(directives, "no-cache") {
+			if entry := cfg.lookup(c.Request, key); entry != nil {
+				serve(c, entry)
+				c.Abort()
+				return
+			}
+		}
+
+		cfg.run(c, key)
+	}
+}
+
+// key returns the cache key of the request.
+func (cfg *config) key(c *router.Context) string {
+	var b strings.Builder
+	if cfg.keyFunc != nil {
+		b.WriteString(cfg.keyFunc(c))
+	} else {
+		// HEAD is served from GET responses
+		b.WriteString(http.MethodGet + " " + c.Request.URL.Path)
+		if query := c.Request.URL.Query(); len(query) > 0 {
+			b.WriteString("?" + query.Encode()) // Encode sorts by key
+		}
+	}
+	for _, name := range cfg.keyHeaders {
+		b.WriteString("\n" + strings.ToLower(name) + ": " + strings.Join(c.Request.Header.Values(name), ","))
+	}
+
+	return b.String()
+}
+
+// lookup returns the cached response for the request, or nil.
+func (cfg *config) lookup(req *http.Request, key string) *Entry {
+	ctx := req.Context()
+	entry, err := cfg.store.Get(ctx, key)
+	if err != nil {
+		cfg.log("get", err)
+		return nil
+	}
+	if entry != nil && entry.Status == 0 && len(entry.Vary) > 0 {
+		if entry, err = cfg.store.Get(ctx, variantKey(key, entry.Vary, req)); err != nil {
+			cfg.log("get", err)
+			return nil
+		}
+	}
+	if entry == nil || entry.Status == 0 {
+		return nil
+	}
+
+	return entry
+}
+
+// run calls the next handlers and stores the response under key.
+func (cfg *config) run(c *router.Context, key string) {
+	original := c.Response
+	w := &recordWriter{ResponseWriter: original, cfg: cfg, before: original.Header().Clone()}
+	original.Header().Set("X-Cache", "MISS")
+	c.Response = w
+	defer func() { c.Response = original }()
+
+	c.Next()
+	// HEAD responses have no body to serve GET requests with
+	if c.Request.Method == http.MethodHead || !w.cacheable() {
+		return
+	}
+
+	// Storing must not fail because the client went away
+	ctx := context.WithoutCancel(c.Request.Context())
+	entry := w.entry()
+	if vary := varyHeaders(entry.Header); len(vary) > 0 {
+		// The key points to the variants, which are keyed by the Vary headers
+		if err := cfg.store.Set(ctx, key, &Entry{Stored: entry.Stored, Vary: vary}, cfg.ttl); err != nil {
+			cfg.log("set", err)
+			return
+		}
+		key = variantKey(key, vary, c.Request)
+	}
+	if err := cfg.store.Set(ctx, key, entry, cfg.ttl); err != nil {
+		cfg.log("set", err)
+	}
+}
+
+// log logs a failed store operation.
+func (cfg *config) log(op string, err error) {
+	if cfg.logger != nil {
+		cfg.logger.Error("cache: "+op+" failed", "error", err)
+	}
+}
+
+// serve sends a cached response.
+func serve(c *router.Context, entry *Entry) {
+	header := c.Response.Header()
+	for name, values := range entry.Header {
+		header[name] = slices.Clone(values)
+	}
+	age := max(time.Since(entry.Stored), 0)
+	header.Set("Age", strconv.FormatInt(int64(age/time.Second), 10))
+	header.Set("X-Cache", "HIT")
+	c.Response.WriteHeader(entry.Status)
+	if c.Request.Method != http.MethodHead {
+		_, _ = c.Response.Write(entry.Body) //nolint:errcheck // The client went away
+	}
+}
+
+// variantKey returns the key of the response variant that matches the
+// request's values of the vary headers.
+func variantKey(key string, vary []string, req *http.Request) string {
+	var b strings.Builder
+	b.WriteString(key)
+	b.WriteString("\nvary")
+	for _, name := range vary {
+		b.WriteString("\n" + name + ": " + strings.Join(req.Header.Values(name), ","))
+	}
+
+	return b.String()
+}
+
+// varyHeaders returns the sorted, lower-case header names of the Vary header.
+func varyHeaders(header http.Header) []string {
+	var names []string
+	for _, value := range header.Values("Vary") {
+		for name := range strings.SplitSeq(value, ",") {
+			if name = strings.ToLower(strings.TrimSpace(name)); name != "" && !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+	slices.Sort(names)
+
+	return names
+}
+
+// hasDirective reports whether the Cache-Control value contains directive.
+func hasDirective(cacheControl, directive string) bool {
+	for part := range strings.SplitSeq(cacheControl, ",") {
+		name, _, _ := strings.Cut(strings.TrimSpace(part), "=")
+		if strings.EqualFold(name, directive) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,363 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"rivaas.dev/router"
+)
+
+func TestCache_HitAndMiss(t *testing.T) {
+	t.Parallel()
+	var calls atomic.Int32
+	r := router.MustNew()
+	r.Use(func(c *router.Context) {
+		c.Response.Header().Set("X-Request-ID", c.Request.Header.Get("X-Test-ID"))
+		c.Next()
+	})
+	r.Use(New())
+	products := func(c *router.Context) {
+		calls.Add(1)
+		c.Response.Header().Set("Content-Type", "text/plain")
+		c.String(http.StatusOK, "products") //nolint:errcheck // Test handler
+	}
+	r.GET("/products", products)
+	r.HEAD("/products", products)
+
+	req := httptest.NewRequest(http.MethodGet, "/products", nil)
+	req.Header.Set("X-Test-ID", "1")
+	first := httptest.NewRecorder()
+	r.ServeHTTP(first, req)
+	require.Equal(t, http.StatusOK, first.Code)
+	assert.Equal(t, "MISS", first.Header().Get("X-Cache"))
+	assert.Equal(t, "public, max-age=60", first.Header().Get("Cache-Control"))
+	assert.Empty(t, first.Header().Get("Age"))
+
+	req = httptest.NewRequest(http.MethodGet, "/products", nil)
+	req.Header.Set("X-Test-ID", "2")
+	second := httptest.NewRecorder()
+	r.ServeHTTP(second, req)
+	assert.Equal(t, "HIT", second.Header().Get("X-Cache"))
+	assert.Equal(t, "0", second.Header().Get("Age"))
+	assert.Equal(t, "products", second.Body.String())
+	assert.Equal(t, "text/plain", second.Header().Get("Content-Type"))
+	assert.Equal(t, "public, max-age=60", second.Header().Get("Cache-Control"))
+	assert.Equal(t, "2", second.Header().Get("X-Request-ID"), "headers of outer middleware are not cached")
+	assert.Equal(t, int32(1), calls.Load())
+
+	head := httptest.NewRecorder()
+	r.ServeHTTP(head, httptest.NewRequest(http.MethodHead, "/products", nil))
+	assert.Equal(t, "HIT", head.Header().Get("X-Cache"))
+	assert.Empty(t, head.Body.String())
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestCache_Key(t *testing.T) {
+	t.Parallel()
+	var calls atomic.Int32
+	r := router.MustNew()
+	r.Use(New())
+	r.GET("/products", func(c *router.Context) {
+		calls.Add(1)
+		c.String(http.StatusOK, c.Request.URL.RawQuery) //nolint:errcheck // Test handler
+	})
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/products?b=2&a=1", nil))
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/products?a=1&b=2", nil))
+	assert.Equal(t, "HIT", w.Header().Get("X-Cache"), "query parameter order doesn't matter")
+	assert.Equal(t, "b=2&a=1", w.Body.String())
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/products?a=2", nil))
+	assert.Equal(t, "MISS", w.Header().Get("X-Cache"))
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestCache_KeyFuncAndHeaders(t *testing.T) {
+	t.Parallel()
+	r := router.MustNew()
+	r.Use(New(
+		WithKeyFunc(func(c *router.Context) string { return c.Request.URL.Path }),
+		WithKeyHeaders("X-Tenant"),
+	))
+	r.GET("/products", func(c *router.Context) {
+		c.String(http.StatusOK, "products") //nolint:errcheck // Test handler
+	})
+
+	tests := []struct {
+		name   string
+		target string
+		tenant string
+		want   string
+	}{
+		{name: "first request", target: "/products?page=1", tenant: "a", want: "MISS"},
+		{name: "the key func ignores the query", target: "/products?page=2", tenant: "a", want: "HIT"},
+		{name: "key headers are part of the key", target: "/products?page=1", tenant: "b", want: "MISS"},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+		req.Header.Set("X-Tenant", tt.tenant)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		assert.Equal(t, tt.want, w.Header().Get("X-Cache"), tt.name)
+	}
+}
+
+func TestCache_Vary(t *testing.T) {
+	t.Parallel()
+	var calls atomic.Int32
+	r := router.MustNew()
+	r.Use(New())
+	r.GET("/greeting", func(c *router.Context) {
+		calls.Add(1)
+		c.Response.Header().Set("Vary", "Accept-Language")
+		c.Stringf(http.StatusOK, "hello in %s", c.Request.Header.Get("Accept-Language")) //nolint:errcheck // Test handler
+	})
+
+	tests := []struct {
+		lang string
+		want string
+	}{
+		{lang: "de", want: "MISS"},
+		{lang: "en", want: "MISS"},
+		{lang: "de", want: "HIT"},
+		{lang: "en", want: "HIT"},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/greeting", nil)
+		req.Header.Set("Accept-Language", tt.lang)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		assert.Equal(t, tt.want, w.Header().Get("X-Cache"), tt.lang)
+		assert.Equal(t, "hello in "+tt.lang, w.Body.String())
+	}
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestCache_NotCached(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		handler router.HandlerFunc
+		// cacheControl is whether Cache-Control is added, which happens
+		// before the body is written
+		cacheControl bool
+	}{
+		{
+			name: "status",
+			handler: func(c *router.Context) {
+				c.WriteErrorResponse(http.StatusNotFound, "not found")
+			},
+		},
+		{
+			name: "private",
+			handler: func(c *router.Context) {
+				c.Response.Header().Set("Cache-Control", "private, max-age=60")
+				c.String(http.StatusOK, "mine") //nolint:errcheck // Test handler
+			},
+		},
+		{
+			name: "cookie",
+			handler: func(c *router.Context) {
+				c.Response.Header().Set("Set-Cookie", "session=abc")
+				c.String(http.StatusOK, "cookie") //nolint:errcheck // Test handler
+			},
+		},
+		{
+			name: "too large",
+			handler: func(c *router.Context) {
+				c.String(http.StatusOK, strings.Repeat("x", 100)) //nolint:errcheck // Test handler
+			},
+			cacheControl: true,
+		},
+		{
+			name: "flushed",
+			handler: func(c *router.Context) {
+				c.String(http.StatusOK, "chunk")               //nolint:errcheck // Test handler
+				http.NewResponseController(c.Response).Flush() //nolint:errcheck // Test handler
+			},
+			cacheControl: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var calls atomic.Int32
+			r := router.MustNew()
+			r.Use(New(WithMaxBodySize(50)))
+			r.GET("/test", func(c *router.Context) {
+				calls.Add(1)
+				tt.handler(c)
+			})
+
+			first := httptest.NewRecorder()
+			r.ServeHTTP(first, httptest.NewRequest(http.MethodGet, "/test", nil))
+			assert.Equal(t, tt.cacheControl, first.Header().Get("Cache-Control") == "public, max-age=60")
+			second := httptest.NewRecorder()
+			r.ServeHTTP(second, httptest.NewRequest(http.MethodGet, "/test", nil))
+			assert.Equal(t, "MISS", second.Header().Get("X-Cache"))
+			assert.Equal(t, int32(2), calls.Load())
+		})
+	}
+}
+
+func TestCache_RequestCacheControl(t *testing.T) {
+	t.Parallel()
+	var calls atomic.Int32
+	r := router.MustNew()
+	r.Use(New())
+	r.GET("/products", func(c *router.Context) {
+		c.Stringf(http.StatusOK, "call %d", calls.Add(1)) //nolint:errcheck // Test handler
+	})
+
+	tests := []struct {
+		name         string
+		cacheControl string
+		wantCache    string
+		wantBody     string
+	}{
+		{name: "first request", wantCache: "MISS", wantBody: "call 1"},
+		{name: "no-cache", cacheControl: "no-cache", wantCache: "MISS", wantBody: "call 2"},
+		{name: "no-cache refreshes the entry", wantCache: "HIT", wantBody: "call 2"},
+		{name: "no-store", cacheControl: "no-store", wantBody: "call 3"},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/products", nil)
+		if tt.cacheControl != "" {
+			req.Header.Set("Cache-Control", tt.cacheControl)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		assert.Equal(t, tt.wantCache, w.Header().Get("X-Cache"), tt.name)
+		assert.Equal(t, tt.wantBody, w.Body.String(), tt.name)
+	}
+}
+
+func TestCache_Options(t *testing.T) {
+	t.Parallel()
+
+	t.Run("TTL and Cache-Control", func(t *testing.T) {
+		t.Parallel()
+		store := NewMemoryStore(0)
+		r := router.MustNew()
+		r.Use(New(WithStore(store), WithTTL(10*time.Minute)))
+		r.GET("/products", func(c *router.Context) {
+			c.String(http.StatusOK, "products") //nolint:errcheck // Test handler
+		})
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/products", nil))
+		assert.Equal(t, "public, max-age=600", w.Header().Get("Cache-Control"))
+		assert.Equal(t, 1, store.Len())
+
+		r = router.MustNew()
+		r.Use(New(WithCacheControl(false)))
+		r.GET("/products", func(c *router.Context) {
+			c.String(http.StatusOK, "products") //nolint:errcheck // Test handler
+		})
+
+		w = httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/products", nil))
+		assert.Empty(t, w.Header().Get("Cache-Control"))
+	})
+
+	t.Run("methods, status codes, and skip paths", func(t *testing.T) {
+		t.Parallel()
+		r := router.MustNew()
+		r.Use(New(
+			WithMethods(http.MethodGet),
+			WithStatusCodes(http.StatusOK, http.StatusNotFound),
+			WithSkipPaths("/products"),
+		))
+		r.GET("/missing", func(c *router.Context) {
+			c.WriteErrorResponse(http.StatusNotFound, "not found")
+		})
+		r.GET("/products", func(c *router.Context) {
+			c.String(http.StatusOK, "products") //nolint:errcheck // Test handler
+		})
+		r.HEAD("/products", func(c *router.Context) {
+			c.Status(http.StatusOK)
+		})
+
+		tests := []struct {
+			method string
+			path   string
+			want   string
+		}{
+			{method: http.MethodGet, path: "/missing", want: "MISS"},
+			{method: http.MethodGet, path: "/missing", want: "HIT"},
+			{method: http.MethodGet, path: "/products"},
+			{method: http.MethodHead, path: "/products"},
+		}
+
+		for _, tt := range tests {
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, nil))
+			assert.Equal(t, tt.want, w.Header().Get("X-Cache"), "%s %s", tt.method, tt.path)
+		}
+	})
+}
+
+// failingStore is a Store whose operations fail.
+type failingStore struct{}
+
+func (failingStore) Get(context.Context, string) (*Entry, error) {
+	return nil, errors.New("store down")
+}
+
+func (failingStore) Set(context.Context, string, *Entry, time.Duration) error {
+	return errors.New("store down")
+}
+
+func (failingStore) Delete(context.Context, string) error {
+	return errors.New("store down")
+}
+
+func TestCache_StoreError(t *testing.T) {
+	t.Parallel()
+	var calls atomic.Int32
+	r := router.MustNew()
+	r.Use(New(WithStore(failingStore{}), WithLogger(nil)))
+	r.GET("/products", func(c *router.Context) {
+		c.Stringf(http.StatusOK, "call %d", calls.Add(1)) //nolint:errcheck // Test handler
+	})
+
+	for i := range 2 {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/products", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, fmt.Sprintf("call %d", i+1), w.Body.String())
+	}
+}
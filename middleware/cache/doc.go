@@ -0,0 +1,68 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cache provides middleware that caches responses on the server,
+// per route or for a whole router.
+//
+// The first request for a resource runs the handler and stores the
+// response; later requests get the stored response until it expires:
+//
+//	HTTP/1.1 200 OK
+//	Age: 12
+//	Cache-Control: public, max-age=60
+//	X-Cache: HIT
+//
+// # Basic Usage
+//
+//	import "rivaas.dev/middleware/cache"
+//
+//	r := router.MustNew()
+//	r.GET("/products", listProducts, cache.New(cache.WithTTL(5*time.Minute)))
+//
+// # Semantics
+//
+//   - Only GET and HEAD requests are cached ([WithMethods]); HEAD requests
+//     are served from cached GET responses
+//   - Only 200 OK responses are cached ([WithStatusCodes]), for one minute
+//     ([WithTTL])
+//   - Responses that set cookies, are larger than 1 MiB ([WithMaxBodySize]),
+//     are flushed, or have Cache-Control no-store, no-cache, or private are
+//     not cached
+//   - Requests with Cache-Control: no-cache bypass the lookup and refresh
+//     the entry; no-store bypasses the cache
+//   - Cached responses get an Age header and X-Cache: HIT; others get
+//     X-Cache: MISS
+//   - Cacheable responses without Cache-Control get
+//     "public, max-age=<ttl>" ([WithCacheControl])
+//
+// # Keys and Vary
+//
+// The key is the path and the query, with parameters in sorted order.
+// [WithKeyFunc] replaces it, and [WithKeyHeaders] adds request headers to
+// it. Responses with a Vary header are stored per value of the listed
+// request headers, so a response negotiated for Accept-Language: de is not
+// served to a client asking for English. Vary: * is never cached.
+//
+// # Stores
+//
+// [NewMemoryStore] keeps responses in memory and evicts the least recently
+// used ones; it is the default. [NewRedisStore] shares them between
+// replicas. Any other backend can implement [Store]. Store failures are
+// logged and the request is served without the cache.
+//
+// # Invalidation
+//
+// Entries expire after their TTL. To remove them earlier, delete their key
+// from the store, or call [MemoryStore.Clear].
+package cache
@@ -0,0 +1,48 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package main demonstrates how to use the cache middleware
+// for a slow endpoint whose response changes rarely.
+package main
+
+import (
+	"log"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"rivaas.dev/middleware/cache"
+	"rivaas.dev/router"
+)
+
+func main() {
+	r := router.MustNew()
+
+	var calls atomic.Int64
+	r.GET("/products", func(c *router.Context) {
+		// Simulate a slow database query
+		time.Sleep(time.Second)
+
+		n := calls.Add(1)
+		log.Printf("loaded products (%d)", n)
+		if err := c.JSON(http.StatusOK, map[string]any{"products": []string{"tea", "coffee"}, "loaded": n}); err != nil {
+			log.Printf("write response: %v", err)
+		}
+	}, cache.New(cache.WithTTL(30*time.Second)))
+
+	log.Println("Server starting on :8080")
+	log.Println("  curl -i http://localhost:8080/products")
+	log.Println("  Run it twice: the second response is a cache HIT and returns at once.")
+	log.Fatal(http.ListenAndServe(":8080", r))
+}
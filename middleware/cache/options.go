@@ -0,0 +1,158 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"log/slog"
+	"time"
+
+	"rivaas.dev/router"
+)
+
+// WithStore sets the store for cached responses. Use a shared store such as
+// [RedisStore] when running several replicas.
+// Default: a [MemoryStore] with up to 10000 entries
+//
+// Example:
+//
+//	cache.New(cache.WithStore(cache.NewRedisStore(client, "")))
+func WithStore(store Store) Option {
+	return func(cfg *config) {
+		cfg.store = store
+	}
+}
+
+// WithTTL sets how long responses are cached. It is also the max-age of the
+// Cache-Control header added to responses without one.
+// Default: 1 minute
+//
+// Example:
+//
+//	cache.New(cache.WithTTL(10 * time.Minute))
+func WithTTL(ttl time.Duration) Option {
+	return func(cfg *config) {
+		cfg.ttl = ttl
+	}
+}
+
+// WithKeyFunc sets the function that returns the cache key of a request,
+// replacing the method, path, and query. Requests with the same key get the
+// same response, so include everything the response depends on.
+//
+// Example:
+//
+//	cache.New(cache.WithKeyFunc(func(c *router.Context) string {
+//	    return c.Request.URL.Path + "?page=" + c.Request.URL.Query().Get("page")
+//	}))
+func WithKeyFunc(fn func(c *router.Context) string) Option {
+	return func(cfg *config) {
+		cfg.keyFunc = fn
+	}
+}
+
+// WithKeyHeaders adds the values of request headers to the cache key, for
+// responses that depend on headers they don't list in Vary.
+//
+// Example:
+//
+//	cache.New(cache.WithKeyHeaders("X-Tenant-ID"))
+func WithKeyHeaders(names ...string) Option {
+	return func(cfg *config) {
+		cfg.keyHeaders = append(cfg.keyHeaders, names...)
+	}
+}
+
+// WithMethods sets the request methods whose responses are cached.
+// Default: GET, HEAD
+//
+// Example:
+//
+//	cache.New(cache.WithMethods(http.MethodGet))
+func WithMethods(methods ...string) Option {
+	return func(cfg *config) {
+		cfg.methods = make(map[string]bool, len(methods))
+		for _, method := range methods {
+			cfg.methods[method] = true
+		}
+	}
+}
+
+// WithStatusCodes sets the status codes of responses that are cached.
+// Default: 200
+//
+// Example:
+//
+//	cache.New(cache.WithStatusCodes(http.StatusOK, http.StatusNotFound))
+func WithStatusCodes(codes ...int) Option {
+	return func(cfg *config) {
+		cfg.statuses = make(map[int]bool, len(codes))
+		for _, code := range codes {
+			cfg.statuses[code] = true
+		}
+	}
+}
+
+// WithMaxBodySize sets the size of the largest response body that is
+// cached, in bytes. Larger responses are sent but not cached.
+// Default: 1 MiB
+//
+// Example:
+//
+//	cache.New(cache.WithMaxBodySize(256 << 10))
+func WithMaxBodySize(size int) Option {
+	return func(cfg *config) {
+		cfg.maxBodySize = size
+	}
+}
+
+// WithCacheControl enables or disables adding "public, max-age=<ttl>" to
+// cacheable responses without a Cache-Control header. Disable it to cache
+// responses on the server only.
+// Default: enabled
+//
+// Example:
+//
+//	cache.New(cache.WithCacheControl(false))
+func WithCacheControl(enabled bool) Option {
+	return func(cfg *config) {
+		cfg.cacheControl = enabled
+	}
+}
+
+// WithSkipPaths sets paths the middleware does not apply to.
+//
+// Example:
+//
+//	cache.New(cache.WithSkipPaths("/health"))
+func WithSkipPaths(paths ...string) Option {
+	return func(cfg *config) {
+		for _, path := range paths {
+			cfg.skipPaths[path] = true
+		}
+	}
+}
+
+// WithLogger sets the logger for store failures. Requests are served
+// without the cache when the store fails. Set to nil to disable logging.
+// Default: slog.Default()
+//
+// Example:
+//
+//	cache.New(cache.WithLogger(logger))
+func WithLogger(logger *slog.Logger) Option {
+	return func(cfg *config) {
+		cfg.logger = logger
+	}
+}
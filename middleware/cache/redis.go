@@ -0,0 +1,88 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisStore is a [Store] backed by Redis, so every replica that uses the
+// same Redis deployment shares the cache. Entries expire with the Redis key
+// TTL; configure an eviction policy such as allkeys-lru to bound memory.
+type RedisStore struct {
+	client redis.Cmdable
+	prefix string
+}
+
+// NewRedisStore creates a store that keeps entries under prefix + key. An
+// empty prefix defaults to "cache:". client can be a *redis.Client,
+// *redis.ClusterClient, or *redis.Ring.
+//
+// Example:
+//
+//	client := redis.NewClient(&redis.Options{Addr: "localhost:6379"})
+//	r.Use(cache.New(cache.WithStore(cache.NewRedisStore(client, "myapp:cache:"))))
+func NewRedisStore(client redis.Cmdable, prefix string) *RedisStore {
+	if prefix == "" {
+		prefix = "cache:"
+	}
+	return &RedisStore{client: client, prefix: prefix}
+}
+
+// Get implements [Store].
+func (s *RedisStore) Get(ctx context.Context, key string) (*Entry, error) {
+	data, err := s.client.Get(ctx, s.prefix+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cache: redis: %w", err)
+	}
+
+	var entry Entry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, fmt.Errorf("cache: redis: decode entry: %w", err)
+	}
+
+	return &entry, nil
+}
+
+// Set implements [Store].
+func (s *RedisStore) Set(ctx context.Context, key string, entry *Entry, ttl time.Duration) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("cache: redis: %w", err)
+	}
+	if err := s.client.Set(ctx, s.prefix+key, data, ttl).Err(); err != nil {
+		return fmt.Errorf("cache: redis: %w", err)
+	}
+
+	return nil
+}
+
+// Delete implements [Store].
+func (s *RedisStore) Delete(ctx context.Context, key string) error {
+	if err := s.client.Del(ctx, s.prefix+key).Err(); err != nil {
+		return fmt.Errorf("cache: redis: %w", err)
+	}
+
+	return nil
+}
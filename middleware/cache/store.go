@@ -0,0 +1,169 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"container/list"
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Entry is a cached response.
+type Entry struct {
+	// Status is the response status code.
+	Status int `json:"status,omitempty"`
+
+	// Header holds the response headers.
+	Header http.Header `json:"header,omitempty"`
+
+	// Body is the response body.
+	Body []byte `json:"body,omitempty"`
+
+	// Stored is when the response was stored; it sets the Age header.
+	Stored time.Time `json:"stored"`
+
+	// Vary lists the request headers the response varies by. An entry with
+	// Vary and no Status only points to the variants of a resource.
+	Vary []string `json:"vary,omitempty"`
+}
+
+// Store keeps cached responses.
+type Store interface {
+	// Get returns the entry of key, or nil if there is none or it expired.
+	Get(ctx context.Context, key string) (*Entry, error)
+
+	// Set stores the entry of key for ttl.
+	Set(ctx context.Context, key string, entry *Entry, ttl time.Duration) error
+
+	// Delete removes the entry of key.
+	Delete(ctx context.Context, key string) error
+}
+
+// memoryEntry is an entry kept by a [MemoryStore].
+type memoryEntry struct {
+	key     string
+	entry   Entry
+	expires time.Time
+}
+
+// MemoryStore is a [Store] that keeps entries in memory, evicting the least
+// recently used entry when it is full. Entries are not shared between
+// replicas, so it suits single-instance deployments and tests.
+type MemoryStore struct {
+	mu         sync.Mutex
+	maxEntries int
+	entries    map[string]*list.Element
+	lru        *list.List // Front is the most recently used
+	now        func() time.Time
+}
+
+// NewMemoryStore returns an empty in-memory store that holds at most
+// maxEntries entries. A maxEntries of zero or less defaults to 10000.
+//
+// Example:
+//
+//	r.Use(cache.New(cache.WithStore(cache.NewMemoryStore(1000))))
+func NewMemoryStore(maxEntries int) *MemoryStore {
+	if maxEntries <= 0 {
+		maxEntries = 10000
+	}
+
+	return &MemoryStore{
+		maxEntries: maxEntries,
+		entries:    make(map[string]*list.Element),
+		lru:        list.New(),
+		now:        time.Now,
+	}
+}
+
+// Get implements [Store].
+func (s *MemoryStore) Get(_ context.Context, key string) (*Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	elem, ok := s.entries[key]
+	if !ok {
+		return nil, nil
+	}
+	me := elem.Value.(*memoryEntry) //nolint:errcheck,forcetypeassert // only *memoryEntry is stored
+	if !s.now().Before(me.expires) {
+		s.remove(elem)
+		return nil, nil
+	}
+	s.lru.MoveToFront(elem)
+	entry := me.entry
+
+	return &entry, nil
+}
+
+// Set implements [Store].
+func (s *MemoryStore) Set(_ context.Context, key string, entry *Entry, ttl time.Duration) error {
+	expires := s.now().Add(ttl)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if elem, ok := s.entries[key]; ok {
+		me := elem.Value.(*memoryEntry) //nolint:errcheck,forcetypeassert // only *memoryEntry is stored
+		me.entry, me.expires = *entry, expires
+		s.lru.MoveToFront(elem)
+		return nil
+	}
+
+	s.entries[key] = s.lru.PushFront(&memoryEntry{key: key, entry: *entry, expires: expires})
+	for s.lru.Len() > s.maxEntries {
+		s.remove(s.lru.Back())
+	}
+
+	return nil
+}
+
+// Delete implements [Store].
+func (s *MemoryStore) Delete(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if elem, ok := s.entries[key]; ok {
+		s.remove(elem)
+	}
+
+	return nil
+}
+
+// Clear removes all entries.
+func (s *MemoryStore) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	clear(s.entries)
+	s.lru.Init()
+}
+
+// Len returns the number of entries, including expired entries that have
+// not been removed yet.
+func (s *MemoryStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.lru.Len()
+}
+
+// remove removes elem. It must be called with s.mu held.
+func (s *MemoryStore) remove(elem *list.Element) {
+	me := s.lru.Remove(elem).(*memoryEntry) //nolint:errcheck,forcetypeassert // only *memoryEntry is stored
+	delete(s.entries, me.key)
+}
@@ -0,0 +1,142 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package cache
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore(t *testing.T) {
+	t.Parallel()
+	store := NewMemoryStore(0)
+	now := time.Now()
+	store.now = func() time.Time { return now }
+	ctx := t.Context()
+
+	entry, err := store.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Nil(t, entry, "missing")
+
+	want := &Entry{
+		Status: http.StatusOK,
+		Header: http.Header{"Content-Type": {"application/json"}},
+		Body:   []byte(`{"id":1}`),
+		Stored: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+		Vary:   []string{"accept"},
+	}
+	require.NoError(t, store.Set(ctx, "key", want, time.Minute))
+	entry, err = store.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, want, entry)
+
+	now = now.Add(time.Minute)
+	entry, err = store.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Nil(t, entry, "expired")
+
+	require.NoError(t, store.Set(ctx, "key", want, time.Minute))
+	require.NoError(t, store.Delete(ctx, "key"))
+	entry, err = store.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Nil(t, entry, "deleted")
+}
+
+func TestMemoryStore_LRU(t *testing.T) {
+	t.Parallel()
+	store := NewMemoryStore(2)
+	ctx := t.Context()
+
+	require.NoError(t, store.Set(ctx, "a", &Entry{Status: http.StatusOK}, time.Minute))
+	require.NoError(t, store.Set(ctx, "b", &Entry{Status: http.StatusOK}, time.Minute))
+	_, err := store.Get(ctx, "a") // a is now the most recently used
+	require.NoError(t, err)
+	require.NoError(t, store.Set(ctx, "c", &Entry{Status: http.StatusOK}, time.Minute))
+
+	assert.Equal(t, 2, store.Len())
+	entry, err := store.Get(ctx, "b")
+	require.NoError(t, err)
+	assert.Nil(t, entry, "least recently used entry is evicted")
+	entry, err = store.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.NotNil(t, entry)
+
+	store.Clear()
+	assert.Equal(t, 0, store.Len())
+}
+
+func TestRedisStore(t *testing.T) {
+	t.Parallel()
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr(), MaxRetries: -1})
+	t.Cleanup(func() { _ = client.Close() })
+	store := NewRedisStore(client, "")
+	ctx := t.Context()
+
+	entry, err := store.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Nil(t, entry, "missing")
+
+	want := &Entry{
+		Status: http.StatusOK,
+		Header: http.Header{"Content-Type": {"application/json"}},
+		Body:   []byte(`{"id":1}`),
+		Stored: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+		Vary:   []string{"accept"},
+	}
+	require.NoError(t, store.Set(ctx, "key", want, time.Minute))
+	assert.Equal(t, time.Minute, mr.TTL("cache:key"), "entries use the default prefix")
+	entry, err = store.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, want, entry)
+
+	mr.FastForward(time.Minute)
+	entry, err = store.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Nil(t, entry, "expired")
+
+	require.NoError(t, store.Set(ctx, "key", want, time.Minute))
+	require.NoError(t, store.Delete(ctx, "key"))
+	entry, err = store.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Nil(t, entry, "deleted")
+}
+
+func TestRedisStore_Error(t *testing.T) {
+	t.Parallel()
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr(), MaxRetries: -1})
+	t.Cleanup(func() { _ = client.Close() })
+	store := NewRedisStore(client, "")
+	ctx := t.Context()
+
+	require.NoError(t, mr.Set("cache:bad", "not json"))
+	_, err := store.Get(ctx, "bad")
+	require.ErrorContains(t, err, "decode entry")
+
+	mr.Close()
+	_, err = store.Get(ctx, "key")
+	require.Error(t, err)
+	require.Error(t, store.Set(ctx, "key", &Entry{}, time.Minute))
+	require.Error(t, store.Delete(ctx, "key"))
+}
@@ -0,0 +1,146 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"bufio"
+	"bytes"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"rivaas.dev/router"
+)
+
+// recordWriter records the response while sending it. It forwards the
+// optional interfaces the router and other middleware use.
+type recordWriter struct {
+	http.ResponseWriter
+	cfg      *config
+	before   http.Header // Headers set before the handler ran
+	status   int
+	header   http.Header
+	body     bytes.Buffer
+	stored   time.Time
+	overflow bool // The body exceeded the maximum size
+	flushed  bool
+	hijacked bool
+}
+
+func (w *recordWriter) WriteHeader(code int) {
+	if w.status == 0 {
+		w.status = code
+		w.stored = time.Now()
+		if w.cfg.cacheControl && w.Header().Get("Cache-Control") == "" && w.storable() {
+			w.Header().Set("Cache-Control", "public, max-age="+strconv.FormatInt(int64(w.cfg.ttl/time.Second), 10))
+		}
+		w.header = w.Header().Clone()
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *recordWriter) Write(p []byte) (int, error) {
+	if w.status == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+	if !w.overflow {
+		if w.body.Len()+len(p) > w.cfg.maxBodySize {
+			w.overflow = true
+			w.body.Reset()
+		} else {
+			w.body.Write(p) //nolint:errcheck // bytes.Buffer.Write never fails
+		}
+	}
+
+	return w.ResponseWriter.Write(p)
+}
+
+// storable reports whether the response, judged by its status and headers,
+// may be cached.
+func (w *recordWriter) storable() bool {
+	header := w.Header()
+	cacheControl := header.Get("Cache-Control")
+
+	return w.cfg.statuses[w.status] &&
+		header.Get("Set-Cookie") == "" &&
+		!slices.Contains(varyHeaders(header), "*") &&
+		!hasDirective(cacheControl, "no-store") &&
+		!hasDirective(cacheControl, "no-cache") &&
+		!hasDirective(cacheControl, "private")
+}
+
+// cacheable reports whether the complete response may be cached.
+func (w *recordWriter) cacheable() bool {
+	if w.status == 0 {
+		// Nothing was written; net/http sends an empty 200 OK
+		w.status, w.stored, w.header = http.StatusOK, time.Now(), w.Header().Clone()
+	}
+
+	return !w.overflow && !w.flushed && !w.hijacked && w.storable()
+}
+
+// entry returns the recorded response. Headers that outer middleware set
+// before the handler ran, such as a request ID, are left out, as they belong
+// to the request and not to the response.
+func (w *recordWriter) entry() *Entry {
+	entry := &Entry{
+		Status: w.status,
+		Header: make(http.Header, len(w.header)),
+		Body:   bytes.Clone(w.body.Bytes()),
+		Stored: w.stored,
+	}
+	for name, values := range w.header {
+		if name != "X-Cache" && !slices.Equal(values, w.before[name]) {
+			entry.Header[name] = slices.Clone(values)
+		}
+	}
+
+	return entry
+}
+
+// Written implements router.WrittenChecker.
+func (w *recordWriter) Written() bool {
+	if wc, ok := w.ResponseWriter.(router.WrittenChecker); ok {
+		return wc.Written()
+	}
+
+	return w.status != 0
+}
+
+// Hijack implements http.Hijacker.
+func (w *recordWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if hijacker, ok := w.ResponseWriter.(http.Hijacker); ok {
+		w.hijacked = true
+		return hijacker.Hijack()
+	}
+
+	return nil, nil, router.ErrResponseWriterNotHijacker
+}
+
+// Flush implements http.Flusher. Flushed responses are streamed, so they are
+// not cached.
+func (w *recordWriter) Flush() {
+	w.flushed = true
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap returns the underlying writer for http.ResponseController.
+func (w *recordWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,150 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package circuitbreaker
+
+import (
+	"sync"
+	"time"
+)
+
+// State is the state of a circuit breaker.
+type State int
+
+const (
+	// StateClosed lets requests through and counts their failures.
+	StateClosed State = iota
+
+	// StateOpen rejects requests until the open timeout has passed.
+	StateOpen
+
+	// StateHalfOpen lets a few probe requests through to test whether the
+	// failures have stopped.
+	StateHalfOpen
+)
+
+// String returns the name of the state: "closed", "open", or "half-open".
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// transition is a state change, reported after the breaker's lock is
+// released.
+type transition struct {
+	from, to State
+}
+
+// breaker is the circuit breaker of one key.
+type breaker struct {
+	cfg *config
+
+	mu          sync.Mutex
+	state       State
+	generation  uint64    // Incremented on every state change
+	windowStart time.Time // Start of the closed state's counting window
+	requests    int       // Completed requests in the window or half-open state
+	failures    int       // Failed requests in the window
+	openedAt    time.Time
+	probes      int // Requests admitted in the half-open state
+}
+
+// allow reports whether a request may pass. It returns the generation to
+// pass to done, or how long to wait if the request is rejected.
+func (b *breaker) allow(now time.Time) (generation uint64, retryAfter time.Duration, ok bool, t *transition) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case StateClosed:
+		if now.Sub(b.windowStart) >= b.cfg.window {
+			b.windowStart = now
+			b.requests, b.failures = 0, 0
+		}
+	case StateOpen:
+		if wait := b.openedAt.Add(b.cfg.openTimeout).Sub(now); wait > 0 {
+			return 0, wait, false, nil
+		}
+		t = b.setState(StateHalfOpen, now)
+		fallthrough
+	case StateHalfOpen:
+		if b.probes >= b.cfg.halfOpenRequests {
+			return 0, time.Second, false, t
+		}
+		b.probes++
+	}
+
+	return b.generation, 0, true, t
+}
+
+// done records the outcome of a request that allow let pass. Outcomes of
+// requests admitted before the last state change are ignored.
+func (b *breaker) done(generation uint64, failed bool, now time.Time) *transition {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if generation != b.generation {
+		return nil
+	}
+
+	switch b.state {
+	case StateClosed:
+		b.requests++
+		if failed {
+			b.failures++
+		}
+		if b.requests >= b.cfg.minRequests && float64(b.failures) >= b.cfg.failureRatio*float64(b.requests) {
+			return b.setState(StateOpen, now)
+		}
+	case StateHalfOpen:
+		if failed {
+			return b.setState(StateOpen, now)
+		}
+		b.requests++
+		if b.requests >= b.cfg.halfOpenRequests {
+			return b.setState(StateClosed, now)
+		}
+	case StateOpen:
+		// Only requests admitted in another state get here, and their
+		// generation doesn't match
+	}
+
+	return nil
+}
+
+// setState changes the state and resets the counters. It must be called with
+// b.mu held.
+func (b *breaker) setState(state State, now time.Time) *transition {
+	t := &transition{from: b.state, to: state}
+	b.state = state
+	b.generation++
+	b.requests, b.failures, b.probes = 0, 0, 0
+	switch state {
+	case StateClosed:
+		b.windowStart = now
+	case StateOpen:
+		b.openedAt = now
+	case StateHalfOpen:
+	}
+
+	return t
+}
@@ -0,0 +1,137 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package circuitbreaker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestBreaker(opts ...Option) (*breaker, time.Time) {
+	cfg := defaultConfig()
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	now := time.Unix(1_700_000_000, 0)
+
+	return &breaker{cfg: cfg, windowStart: now}, now
+}
+
+// run lets n requests through b and records them as failed or not.
+func run(t *testing.T, b *breaker, now time.Time, n int, failed bool) []*transition {
+	t.Helper()
+	var transitions []*transition
+	for range n {
+		generation, _, ok, tr := b.allow(now)
+		require.True(t, ok)
+		if tr != nil {
+			transitions = append(transitions, tr)
+		}
+		if tr := b.done(generation, failed, now); tr != nil {
+			transitions = append(transitions, tr)
+		}
+	}
+
+	return transitions
+}
+
+func TestBreaker_Opens(t *testing.T) {
+	t.Parallel()
+	b, now := newTestBreaker(WithMinRequests(10), WithFailureRatio(0.5))
+
+	assert.Empty(t, run(t, b, now, 5, false))
+	assert.Empty(t, run(t, b, now, 4, true), "below the minimum number of requests")
+	assert.Equal(t, []*transition{{StateClosed, StateOpen}}, run(t, b, now, 1, true))
+
+	_, retryAfter, ok, _ := b.allow(now.Add(10 * time.Second))
+	assert.False(t, ok)
+	assert.Equal(t, 20*time.Second, retryAfter)
+}
+
+func TestBreaker_Window(t *testing.T) {
+	t.Parallel()
+	b, now := newTestBreaker(WithMinRequests(10), WithWindow(time.Minute))
+
+	run(t, b, now, 9, true)
+	now = now.Add(time.Minute)
+	assert.Empty(t, run(t, b, now, 1, true), "the counts start over")
+	assert.Equal(t, StateClosed, b.state)
+}
+
+func TestBreaker_HalfOpen(t *testing.T) {
+	t.Parallel()
+	b, now := newTestBreaker(WithMinRequests(1), WithOpenTimeout(30*time.Second), WithHalfOpenRequests(2))
+	run(t, b, now, 1, true)
+	require.Equal(t, StateOpen, b.state)
+
+	// The first request after the timeout probes
+	now = now.Add(30 * time.Second)
+	g1, _, ok, tr := b.allow(now)
+	require.True(t, ok)
+	assert.Equal(t, &transition{StateOpen, StateHalfOpen}, tr)
+	g2, _, ok, _ := b.allow(now)
+	require.True(t, ok)
+	_, retryAfter, ok, _ := b.allow(now)
+	assert.False(t, ok, "only two probes")
+	assert.Equal(t, time.Second, retryAfter)
+
+	assert.Nil(t, b.done(g1, false, now))
+	assert.Equal(t, &transition{StateHalfOpen, StateClosed}, b.done(g2, false, now))
+}
+
+func TestBreaker_HalfOpenFailure(t *testing.T) {
+	t.Parallel()
+	b, now := newTestBreaker(WithMinRequests(1), WithOpenTimeout(30*time.Second))
+	run(t, b, now, 1, true)
+
+	now = now.Add(30 * time.Second)
+	generation, _, ok, _ := b.allow(now)
+	require.True(t, ok)
+	assert.Equal(t, &transition{StateHalfOpen, StateOpen}, b.done(generation, true, now))
+	_, retryAfter, ok, _ := b.allow(now)
+	assert.False(t, ok)
+	assert.Equal(t, 30*time.Second, retryAfter, "the timeout starts over")
+}
+
+func TestBreaker_StaleOutcome(t *testing.T) {
+	t.Parallel()
+	b, now := newTestBreaker(WithMinRequests(1), WithOpenTimeout(time.Second), WithHalfOpenRequests(1))
+
+	// A slow request admitted while closed completes after the circuit opened
+	slow, _, ok, _ := b.allow(now)
+	require.True(t, ok)
+	run(t, b, now, 1, true)
+	now = now.Add(time.Second)
+	probe, _, ok, _ := b.allow(now)
+	require.True(t, ok)
+
+	assert.Nil(t, b.done(slow, false, now), "outcomes of older states are ignored")
+	assert.Equal(t, StateHalfOpen, b.state)
+	assert.NotNil(t, b.done(probe, false, now))
+	assert.Equal(t, StateClosed, b.state)
+}
+
+func TestState_String(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "closed", StateClosed.String())
+	assert.Equal(t, "open", StateOpen.String())
+	assert.Equal(t, "half-open", StateHalfOpen.String())
+	assert.Equal(t, "unknown", State(42).String())
+}
@@ -0,0 +1,215 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package circuitbreaker
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/metric"
+
+	"rivaas.dev/router"
+)
+
+// ErrOpen is passed to the error handler when a request is rejected because
+// the circuit is open.
+var ErrOpen = errors.New("circuitbreaker: circuit open")
+
+// Option defines functional options for circuitbreaker middleware configuration.
+type Option func(*config)
+
+// config holds the configuration for the circuitbreaker middleware.
+type config struct {
+	// keyFunc returns the key of the breaker for a request
+	keyFunc func(c *router.Context) string
+
+	// failureRatio is the failure ratio at which the circuit opens
+	failureRatio float64
+
+	// minRequests is the number of requests in the window before the
+	// failure ratio is evaluated
+	minRequests int
+
+	// window is the period over which failures are counted
+	window time.Duration
+
+	// slowThreshold makes slower requests count as failures; 0 disables it
+	slowThreshold time.Duration
+
+	// openTimeout is how long the circuit stays open
+	openTimeout time.Duration
+
+	// halfOpenRequests is the number of probes in the half-open state
+	halfOpenRequests int
+
+	// isFailure reports whether a response is a failure
+	isFailure func(c *router.Context, status int) bool
+
+	// onStateChange is called when a breaker changes its state
+	onStateChange func(key string, from, to State)
+
+	// errorHandler is called when a request is rejected
+	errorHandler func(c *router.Context, err error)
+
+	// skipPaths are paths that bypass the circuit breaker
+	skipPaths map[string]bool
+
+	// meterProvider provides the meter for the breaker metrics
+	meterProvider metric.MeterProvider
+
+	// now returns the current time; replaced in tests
+	now func() time.Time
+}
+
+// defaultConfig returns the default configuration for circuitbreaker middleware.
+func defaultConfig() *config {
+	return &config{
+		keyFunc:          routeKey,
+		failureRatio:     0.5,
+		minRequests:      20,
+		window:           time.Minute,
+		openTimeout:      30 * time.Second,
+		halfOpenRequests: 3,
+		isFailure:        serverError,
+		errorHandler:     defaultErrorHandler,
+		skipPaths:        make(map[string]bool),
+		now:              time.Now,
+	}
+}
+
+// routeKey returns the route pattern, so that each route has its own breaker.
+func routeKey(c *router.Context) string {
+	return c.Request.Method + " " + c.RoutePattern()
+}
+
+// serverError reports 5xx responses as failures.
+func serverError(_ *router.Context, status int) bool {
+	return status >= http.StatusInternalServerError
+}
+
+// defaultErrorHandler sends 503 Service Unavailable.
+func defaultErrorHandler(c *router.Context, _ error) {
+	c.WriteErrorResponse(http.StatusServiceUnavailable, "service unavailable")
+}
+
+// statusCoder is implemented by response writers that track the status code.
+type statusCoder interface {
+	StatusCode() int
+}
+
+// New returns a middleware that stops sending requests to failing routes.
+//
+// Each route has its own circuit breaker (see [WithKeyFunc] for other keys).
+// A breaker starts closed and counts the requests and failures (5xx
+// responses, panics, and with [WithSlowThreshold] slow requests) in a time
+// window. Once at least 20 requests were seen and half of them failed, the
+// circuit opens: requests are rejected with 503 Service Unavailable and a
+// Retry-After header for 30 seconds, giving the failing dependency time to
+// recover. The breaker then turns half-open and lets 3 probe requests
+// through. If they all succeed, the circuit closes; if one fails, it opens
+// again.
+//
+// Example:
+//
+//	r := router.MustNew()
+//	r.Use(circuitbreaker.New(
+//	    circuitbreaker.WithFailureRatio(0.25),
+//	    circuitbreaker.WithSlowThreshold(2*time.Second),
+//	    circuitbreaker.WithOnStateChange(func(key string, from, to circuitbreaker.State) {
+//	        slog.Warn("circuit breaker", "key", key, "from", from, "to", to)
+//	    }),
+//	))
+func New(opts ...Option) router.HandlerFunc {
+	cfg := defaultConfig()
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	metrics := newBreakerMetrics(cfg)
+
+	var mu sync.Mutex
+	breakers := make(map[string]*breaker)
+	get := func(key string) *breaker {
+		mu.Lock()
+		defer mu.Unlock()
+		b, ok := breakers[key]
+		if !ok {
+			b = &breaker{cfg: cfg, windowStart: cfg.now()}
+			breakers[key] = b
+		}
+		return b
+	}
+
+	return func(c *router.Context) {
+		if cfg.skipPaths[c.Request.URL.Path] {
+			c.Next()
+			return
+		}
+
+		ctx := c.Request.Context()
+		key := cfg.keyFunc(c)
+		b := get(key)
+		start := cfg.now()
+		generation, retryAfter, ok, t := b.allow(start)
+		cfg.report(c, metrics, key, t)
+		if !ok {
+			metrics.request(ctx, key, outcomeRejected)
+			seconds := int(math.Ceil(retryAfter.Seconds()))
+			c.Response.Header().Set("Retry-After", strconv.Itoa(seconds))
+			cfg.errorHandler(c, ErrOpen)
+			c.Abort()
+
+			return
+		}
+
+		sc, ok := c.Response.(statusCoder)
+		if !ok {
+			wrapped := router.NewResponseWriterWrapper(c.Response)
+			c.Response = wrapped
+			sc = wrapped
+		}
+
+		failed := true // Until the handler returns without panicking
+		defer func() {
+			end := cfg.now()
+			if cfg.slowThreshold > 0 && end.Sub(start) >= cfg.slowThreshold {
+				failed = true
+			}
+			outcome := outcomeSuccess
+			if failed {
+				outcome = outcomeFailure
+			}
+			metrics.request(ctx, key, outcome)
+			cfg.report(c, metrics, key, b.done(generation, failed, end))
+		}()
+
+		c.Next()
+		failed = cfg.isFailure(c, sc.StatusCode())
+	}
+}
+
+// report announces a state change of the breaker of key.
+func (cfg *config) report(c *router.Context, metrics *breakerMetrics, key string, t *transition) {
+	if t == nil {
+		return
+	}
+	metrics.stateChange(c.Request.Context(), key, t.to)
+	if cfg.onStateChange != nil {
+		cfg.onStateChange(key, t.from, t.to)
+	}
+}
@@ -0,0 +1,236 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package circuitbreaker
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"rivaas.dev/router"
+)
+
+// clock is a settable time source.
+type clock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func withClock(c *clock) Option {
+	return func(cfg *config) {
+		cfg.now = c.Now
+	}
+}
+
+func TestCircuitBreaker_OpensAndRecovers(t *testing.T) {
+	t.Parallel()
+	var changes []string
+	var failing atomic.Bool
+	clk := &clock{now: time.Unix(1_700_000_000, 0)}
+	r := router.MustNew()
+	r.Use(New(
+		withClock(clk),
+		WithMinRequests(4),
+		WithOnStateChange(func(key string, from, to State) {
+			changes = append(changes, key+": "+from.String()+" -> "+to.String())
+		}),
+	))
+	r.GET("/users/:id", func(c *router.Context) {
+		if failing.Load() {
+			c.WriteErrorResponse(http.StatusBadGateway, "upstream failed")
+			return
+		}
+		c.String(http.StatusOK, "user "+c.Param("id")) //nolint:errcheck // Test handler
+	})
+	r.GET("/health", func(c *router.Context) {
+		c.NoContent()
+	})
+
+	failing.Store(true)
+	for range 4 {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users/1", nil))
+		assert.Equal(t, http.StatusBadGateway, w.Code)
+	}
+
+	// Open: rejected without calling the handler, for every user
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users/42", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Equal(t, "30", w.Header().Get("Retry-After"))
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health", nil))
+	assert.Equal(t, http.StatusNoContent, w.Code, "other routes are not affected")
+
+	// Half-open: probes go through and close the circuit
+	failing.Store(false)
+	clk.Advance(30 * time.Second)
+	for range 4 {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users/1", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+	}
+
+	assert.Equal(t, []string{
+		"GET /users/:id: closed -> open",
+		"GET /users/:id: open -> half-open",
+		"GET /users/:id: half-open -> closed",
+	}, changes)
+}
+
+func TestCircuitBreaker_SlowRequests(t *testing.T) {
+	t.Parallel()
+	clk := &clock{now: time.Unix(1_700_000_000, 0)}
+	r := router.MustNew()
+	r.Use(New(withClock(clk), WithMinRequests(4), WithSlowThreshold(2*time.Second)))
+	r.GET("/slow", func(c *router.Context) {
+		clk.Advance(3 * time.Second)
+		c.NoContent()
+	})
+
+	for range 4 {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/slow", nil))
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/slow", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+}
+
+func TestCircuitBreaker_Panics(t *testing.T) {
+	t.Parallel()
+	r := router.MustNew()
+	r.Use(New(WithMinRequests(4)))
+	r.GET("/panic", func(*router.Context) {
+		panic("boom")
+	})
+
+	for range 4 {
+		assert.Panics(t, func() {
+			r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/panic", nil))
+		})
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/panic", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+}
+
+func TestCircuitBreaker_Options(t *testing.T) {
+	t.Parallel()
+	var rejected error
+	r := router.MustNew()
+	r.Use(New(
+		WithMinRequests(4),
+		WithKeyFunc(func(c *router.Context) string { return c.Request.Header.Get("X-Upstream") }),
+		WithFailureFunc(func(_ *router.Context, status int) bool { return status == http.StatusNoContent }),
+		WithSkipPaths("/users/1"),
+		WithErrorHandler(func(c *router.Context, err error) {
+			rejected = err
+			c.WriteErrorResponse(http.StatusTooManyRequests, "later")
+		}),
+	))
+	r.GET("/users/:id", func(c *router.Context) {
+		c.String(http.StatusOK, "user "+c.Param("id")) //nolint:errcheck // Test handler
+	})
+	r.GET("/health", func(c *router.Context) {
+		c.NoContent()
+	})
+
+	tests := []struct {
+		name string
+		path string
+		want int
+	}{
+		{name: "failure 1", path: "/health", want: http.StatusNoContent},
+		{name: "failure 2", path: "/health", want: http.StatusNoContent},
+		{name: "failure 3", path: "/health", want: http.StatusNoContent},
+		{name: "failure 4", path: "/health", want: http.StatusNoContent},
+		{name: "all routes share the key", path: "/users/2", want: http.StatusTooManyRequests},
+		{name: "skipped", path: "/users/1", want: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+		assert.Equal(t, tt.want, w.Code, tt.name)
+	}
+	require.ErrorIs(t, rejected, ErrOpen)
+}
+
+func TestCircuitBreaker_Metrics(t *testing.T) {
+	t.Parallel()
+	reader := sdkmetric.NewManualReader()
+	r := router.MustNew()
+	r.Use(New(WithMinRequests(4), WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))))
+	r.GET("/users/:id", func(c *router.Context) {
+		if c.Param("id") == "0" {
+			c.NoContent()
+			return
+		}
+		c.WriteErrorResponse(http.StatusBadGateway, "upstream failed")
+	})
+
+	for _, path := range []string{"/users/0", "/users/1", "/users/2", "/users/3", "/users/0"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(t.Context(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	assert.Equal(t, meterName, rm.ScopeMetrics[0].Scope.Name)
+
+	counts := make(map[string]int64)
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		sum, ok := m.Data.(metricdata.Sum[int64])
+		require.True(t, ok)
+		for _, dp := range sum.DataPoints {
+			key, _ := dp.Attributes.Value(attribute.Key("circuitbreaker.key"))
+			assert.Equal(t, "GET /users/:id", key.AsString())
+			outcome, _ := dp.Attributes.Value(attribute.Key("circuitbreaker.outcome"))
+			state, _ := dp.Attributes.Value(attribute.Key("circuitbreaker.state"))
+			counts[m.Name+" "+outcome.AsString()+state.AsString()] = dp.Value
+		}
+	}
+	assert.Equal(t, map[string]int64{
+		"circuitbreaker.requests success":   1,
+		"circuitbreaker.requests failure":   3,
+		"circuitbreaker.requests rejected":  1,
+		"circuitbreaker.state_changes open": 1,
+	}, counts)
+}
@@ -0,0 +1,59 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package circuitbreaker provides middleware that stops sending requests to
+// routes that keep failing, giving their dependencies time to recover.
+//
+// When a database or upstream service is down, every request to a route
+// that needs it waits for a timeout and fails. A circuit breaker notices the
+// failures and rejects further requests immediately with 503 Service
+// Unavailable, which frees resources and lets clients back off.
+//
+// # Basic Usage
+//
+//	import "rivaas.dev/middleware/circuitbreaker"
+//
+//	r := router.MustNew()
+//	r.Use(circuitbreaker.New())
+//
+// # States
+//
+//   - Closed: requests pass; failures are counted per time window
+//     ([WithWindow]). When at least [WithMinRequests] requests were seen
+//     and the failure ratio reaches [WithFailureRatio], the circuit opens.
+//   - Open: requests are rejected with 503 and a Retry-After header until
+//     [WithOpenTimeout] has passed.
+//   - Half-open: [WithHalfOpenRequests] probe requests pass, others are
+//     rejected. If all probes succeed, the circuit closes; if one fails, it
+//     opens again.
+//
+// # Failures
+//
+// Responses with status 500 and above and panics count as failures by
+// default. [WithFailureFunc] changes the status codes; [WithSlowThreshold]
+// also counts slow requests.
+//
+// # Keys
+//
+// Each route (method and pattern) has its own breaker, so one failing route
+// doesn't take down the others. [WithKeyFunc] groups requests differently,
+// e.g. by the upstream service they call.
+//
+// # Observability
+//
+// [WithOnStateChange] is called when a breaker opens, turns half-open, or
+// closes. The circuitbreaker.requests counter records requests by outcome
+// (success, failure, rejected), and circuitbreaker.state_changes records the
+// state changes; both have a circuitbreaker.key attribute.
+package circuitbreaker
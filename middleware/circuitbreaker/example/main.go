@@ -0,0 +1,67 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package main demonstrates how to use the circuitbreaker middleware
+// with an upstream that can be switched off.
+package main
+
+import (
+	"log"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"rivaas.dev/middleware/circuitbreaker"
+	"rivaas.dev/router"
+)
+
+func main() {
+	r := router.MustNew()
+
+	var upstreamDown atomic.Bool
+
+	r.Use(circuitbreaker.New(
+		circuitbreaker.WithSkipPaths("/upstream/down", "/upstream/up"),
+		circuitbreaker.WithMinRequests(5),
+		circuitbreaker.WithOpenTimeout(10*time.Second),
+		circuitbreaker.WithOnStateChange(func(key string, from, to circuitbreaker.State) {
+			log.Printf("circuit %q: %s -> %s", key, from, to)
+		}),
+	))
+
+	r.GET("/quote", func(c *router.Context) {
+		if upstreamDown.Load() {
+			c.WriteErrorResponse(http.StatusBadGateway, "quote service unavailable")
+			return
+		}
+		if err := c.String(http.StatusOK, "Simplicity is prerequisite for reliability."); err != nil {
+			log.Printf("write response: %v", err)
+		}
+	})
+
+	r.POST("/upstream/down", func(c *router.Context) {
+		upstreamDown.Store(true)
+		c.NoContent()
+	})
+	r.POST("/upstream/up", func(c *router.Context) {
+		upstreamDown.Store(false)
+		c.NoContent()
+	})
+
+	log.Println("Server starting on :8080")
+	log.Println("  curl -X POST http://localhost:8080/upstream/down")
+	log.Println("  for i in $(seq 6); do curl -si http://localhost:8080/quote | head -1; done")
+	log.Println("  curl -X POST http://localhost:8080/upstream/up  # then wait 10s and retry")
+	log.Fatal(http.ListenAndServe(":8080", r))
+}
@@ -0,0 +1,90 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package circuitbreaker
+
+import (
+	"context"
+	"log/slog"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// meterName is the instrumentation scope of the middleware's metrics.
+const meterName = "rivaas.dev/middleware/circuitbreaker"
+
+// Outcomes of a request, recorded in the circuitbreaker.outcome attribute.
+const (
+	outcomeSuccess  = "success"
+	outcomeFailure  = "failure"
+	outcomeRejected = "rejected"
+)
+
+// breakerMetrics counts requests and state changes.
+type breakerMetrics struct {
+	requests     metric.Int64Counter // nil if the instrument could not be created
+	stateChanges metric.Int64Counter // nil if the instrument could not be created
+}
+
+// newBreakerMetrics creates the breaker counters.
+func newBreakerMetrics(cfg *config) *breakerMetrics {
+	mp := cfg.meterProvider
+	if mp == nil {
+		mp = otel.GetMeterProvider()
+	}
+	meter := mp.Meter(meterName)
+
+	requests, err := meter.Int64Counter("circuitbreaker.requests",
+		metric.WithDescription("Requests seen by the circuit breaker, by outcome"),
+		metric.WithUnit("{request}"),
+	)
+	if err != nil {
+		requests = nil
+		slog.Warn("circuitbreaker: request counter unavailable", "error", err)
+	}
+	stateChanges, err := meter.Int64Counter("circuitbreaker.state_changes",
+		metric.WithDescription("Circuit breaker state changes, by new state"),
+		metric.WithUnit("{change}"),
+	)
+	if err != nil {
+		stateChanges = nil
+		slog.Warn("circuitbreaker: state change counter unavailable", "error", err)
+	}
+
+	return &breakerMetrics{requests: requests, stateChanges: stateChanges}
+}
+
+// request counts a request of the breaker of key with outcome.
+func (m *breakerMetrics) request(ctx context.Context, key, outcome string) {
+	if m.requests == nil {
+		return
+	}
+	m.requests.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("circuitbreaker.key", key),
+		attribute.String("circuitbreaker.outcome", outcome),
+	))
+}
+
+// stateChange counts a change of the breaker of key to state.
+func (m *breakerMetrics) stateChange(ctx context.Context, key string, state State) {
+	if m.stateChanges == nil {
+		return
+	}
+	m.stateChanges.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("circuitbreaker.key", key),
+		attribute.String("circuitbreaker.state", state.String()),
+	))
+}
@@ -0,0 +1,193 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package circuitbreaker
+
+import (
+	"time"
+
+	"go.opentelemetry.io/otel/metric"
+
+	"rivaas.dev/router"
+)
+
+// WithKeyFunc sets the function that returns the key of the breaker for a
+// request. Requests with the same key share a breaker. Keep the number of
+// keys bounded; each key holds a breaker in memory and appears in metrics.
+// Default: the request method and route pattern, e.g. "GET /users/:id"
+//
+// Example:
+//
+//	// One breaker per downstream service
+//	circuitbreaker.New(circuitbreaker.WithKeyFunc(func(c *router.Context) string {
+//	    return c.Param("service")
+//	}))
+func WithKeyFunc(fn func(c *router.Context) string) Option {
+	return func(cfg *config) {
+		cfg.keyFunc = fn
+	}
+}
+
+// WithFailureRatio sets the ratio of failed requests, between 0 and 1, at
+// which the circuit opens.
+// Default: 0.5
+//
+// Example:
+//
+//	circuitbreaker.New(circuitbreaker.WithFailureRatio(0.25))
+func WithFailureRatio(ratio float64) Option {
+	return func(cfg *config) {
+		cfg.failureRatio = ratio
+	}
+}
+
+// WithMinRequests sets how many requests a window needs before the failure
+// ratio is evaluated, so that a few early failures don't open the circuit.
+// Default: 20
+//
+// Example:
+//
+//	circuitbreaker.New(circuitbreaker.WithMinRequests(50))
+func WithMinRequests(n int) Option {
+	return func(cfg *config) {
+		cfg.minRequests = n
+	}
+}
+
+// WithWindow sets the period over which requests and failures are counted
+// while the circuit is closed. The counts start over with every window.
+// Default: 1 minute
+//
+// Example:
+//
+//	circuitbreaker.New(circuitbreaker.WithWindow(30 * time.Second))
+func WithWindow(window time.Duration) Option {
+	return func(cfg *config) {
+		cfg.window = window
+	}
+}
+
+// WithSlowThreshold makes requests that take at least threshold count as
+// failures, so that the circuit also opens when a dependency becomes slow.
+// Default: disabled
+//
+// Example:
+//
+//	circuitbreaker.New(circuitbreaker.WithSlowThreshold(2 * time.Second))
+func WithSlowThreshold(threshold time.Duration) Option {
+	return func(cfg *config) {
+		cfg.slowThreshold = threshold
+	}
+}
+
+// WithOpenTimeout sets how long the circuit stays open before probe
+// requests are let through.
+// Default: 30 seconds
+//
+// Example:
+//
+//	circuitbreaker.New(circuitbreaker.WithOpenTimeout(time.Minute))
+func WithOpenTimeout(timeout time.Duration) Option {
+	return func(cfg *config) {
+		cfg.openTimeout = timeout
+	}
+}
+
+// WithHalfOpenRequests sets how many probe requests are let through in the
+// half-open state. The circuit closes when all of them succeed. Other
+// requests are rejected meanwhile.
+// Default: 3
+//
+// Example:
+//
+//	circuitbreaker.New(circuitbreaker.WithHalfOpenRequests(1))
+func WithHalfOpenRequests(n int) Option {
+	return func(cfg *config) {
+		cfg.halfOpenRequests = max(n, 1)
+	}
+}
+
+// WithFailureFunc sets the function that decides whether a response counts
+// as a failure. Panics always count as failures.
+// Default: status codes 500 and above
+//
+// Example:
+//
+//	// Gateway errors only; 500s are bugs, not outages
+//	circuitbreaker.New(circuitbreaker.WithFailureFunc(func(_ *router.Context, status int) bool {
+//	    return status == http.StatusBadGateway || status == http.StatusGatewayTimeout
+//	}))
+func WithFailureFunc(fn func(c *router.Context, status int) bool) Option {
+	return func(cfg *config) {
+		cfg.isFailure = fn
+	}
+}
+
+// WithOnStateChange sets a function that is called when a breaker changes
+// its state, for example to log or alert. It is called synchronously by the
+// request that caused the change.
+//
+// Example:
+//
+//	circuitbreaker.New(circuitbreaker.WithOnStateChange(func(key string, from, to circuitbreaker.State) {
+//	    slog.Warn("circuit breaker state changed", "key", key, "from", from, "to", to)
+//	}))
+func WithOnStateChange(fn func(key string, from, to State)) Option {
+	return func(cfg *config) {
+		cfg.onStateChange = fn
+	}
+}
+
+// WithErrorHandler sets the function that responds to requests rejected
+// while the circuit is open. The error is [ErrOpen]. The Retry-After header
+// is already set, and the middleware aborts the chain after the handler
+// returns.
+// Default: 503 Service Unavailable
+//
+// Example:
+//
+//	circuitbreaker.New(circuitbreaker.WithErrorHandler(func(c *router.Context, err error) {
+//	    c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "try again later"})
+//	}))
+func WithErrorHandler(handler func(c *router.Context, err error)) Option {
+	return func(cfg *config) {
+		cfg.errorHandler = handler
+	}
+}
+
+// WithSkipPaths sets paths that bypass the circuit breaker.
+//
+// Example:
+//
+//	circuitbreaker.New(circuitbreaker.WithSkipPaths("/health", "/metrics"))
+func WithSkipPaths(paths ...string) Option {
+	return func(cfg *config) {
+		for _, path := range paths {
+			cfg.skipPaths[path] = true
+		}
+	}
+}
+
+// WithMeterProvider sets the OpenTelemetry meter provider for the
+// circuitbreaker.requests and circuitbreaker.state_changes counters.
+// Default: the global provider from otel.GetMeterProvider().
+//
+// Example:
+//
+//	circuitbreaker.New(circuitbreaker.WithMeterProvider(meterProvider))
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return func(cfg *config) {
+		cfg.meterProvider = mp
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"sync"
 
 	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
 
 	"rivaas.dev/router"
 )
@@ -43,6 +44,9 @@ type config struct {
 	// For dynamic content (JSON/text), use 4-5. Higher levels are CPU-expensive.
 	brotliLevel int
 
+	// zstdLevel is the zstd compression level (1-22, mapped to the encoder's speed presets)
+	zstdLevel int
+
 	// minSize is the minimum response size to compress (in bytes)
 	minSize int
 
@@ -52,6 +56,9 @@ type config struct {
 	// enableBrotli enables Brotli compression
 	enableBrotli bool
 
+	// enableZstd enables zstd compression
+	enableZstd bool
+
 	// excludePaths are paths that should not be compressed
 	excludePaths map[string]bool
 
@@ -60,6 +67,15 @@ type config struct {
 
 	// excludeContentTypes are content types that should not be compressed
 	excludeContentTypes map[string]bool
+
+	// maxDecompressedSize is the maximum decompressed request body size for Decompress
+	maxDecompressedSize int64
+
+	// precompressed are static file roots whose precompressed siblings are served
+	precompressed []precompressedRoot
+
+	// compressEventStream enables compression of text/event-stream responses
+	compressEventStream bool
 }
 
 // defaultConfig returns the default configuration for compression middleware.
@@ -67,12 +83,15 @@ func defaultConfig() *config {
 	return &config{
 		gzipLevel:           gzip.DefaultCompression,
 		brotliLevel:         4, // Conservative for dynamic content
+		zstdLevel:           3, // zstd's own default; fast enough for dynamic content
 		minSize:             0, // 0 = no threshold, compress all supported responses
 		enableGzip:          true,
 		enableBrotli:        true,
+		enableZstd:          true,
 		excludePaths:        make(map[string]bool),
 		excludeExtensions:   make(map[string]bool),
 		excludeContentTypes: make(map[string]bool),
+		maxDecompressedSize: defaultMaxDecompressedSize,
 	}
 }
 
@@ -85,6 +104,7 @@ type compressWriter struct {
 	pool                *sync.Pool
 	encoding            string
 	excludeContentTypes map[string]bool
+	compressEventStream bool
 	threshold           int
 
 	buffer      []byte // Buffer for threshold check
@@ -109,6 +129,9 @@ func (cw *compressWriter) Write(data []byte) (int, error) {
 	// If threshold is 0, compress immediately without buffering
 	if cw.threshold == 0 {
 		cw.decided = true
+		if !cw.compressible() {
+			return cw.writeUncompressed(data)
+		}
 		cw.compress = true
 		cw.initCompression()
 
@@ -129,7 +152,7 @@ func (cw *compressWriter) Write(data []byte) (int, error) {
 	if cw.bufferUsed >= cw.threshold || len(data) > 0 {
 		cw.decided = true
 
-		if cw.bufferUsed >= cw.threshold {
+		if cw.bufferUsed >= cw.threshold && cw.compressible() {
 			return cw.writeCompressed(data)
 		}
 
@@ -179,6 +202,16 @@ func (cw *compressWriter) flushBufferAndWrite(w io.Writer, data []byte) (int, er
 	return written, nil
 }
 
+// compressible reports whether the status and Content-Type allow compression.
+// WriteHeader checks them too, but handlers may write or flush without calling
+// it, e.g. a Server-Sent Events handler that only sets the Content-Type.
+func (cw *compressWriter) compressible() bool {
+	contentType := cw.ResponseWriter.Header().Get("Content-Type")
+
+	return !shouldSkipStatus(cw.statusCode) &&
+		!shouldSkipContentType(contentType, cw.excludeContentTypes, cw.compressEventStream)
+}
+
 // WriteHeader captures the status code and checks if compression should be skipped.
 func (cw *compressWriter) WriteHeader(code int) {
 	if cw.headersSent {
@@ -199,7 +232,7 @@ func (cw *compressWriter) WriteHeader(code int) {
 
 	// Check content type
 	contentType := cw.ResponseWriter.Header().Get("Content-Type")
-	if shouldSkipContentType(contentType, cw.excludeContentTypes) {
+	if shouldSkipContentType(contentType, cw.excludeContentTypes, cw.compressEventStream) {
 		cw.compress = false
 		cw.decided = true
 		cw.ResponseWriter.WriteHeader(code)
@@ -219,6 +252,7 @@ func (cw *compressWriter) initCompression() {
 	cw.ResponseWriter.Header().Del("Content-Length")
 	cw.ResponseWriter.Header().Set("Content-Encoding", cw.encoding)
 	cw.ResponseWriter.Header().Set("Vary", "Accept-Encoding")
+	cw.tagETag()
 
 	if !cw.headersSent {
 		cw.ResponseWriter.WriteHeader(cw.statusCode)
@@ -227,6 +261,13 @@ func (cw *compressWriter) initCompression() {
 
 	// Get writer from pool
 	switch cw.encoding {
+	case "zstd":
+		w, ok := cw.pool.Get().(*zstd.Encoder)
+		if !ok {
+			return // Invalid writer type from pool
+		}
+		w.Reset(cw.ResponseWriter)
+		cw.writer = w
 	case "br":
 		w, ok := cw.pool.Get().(*brotli.Writer)
 		if !ok {
@@ -244,6 +285,39 @@ func (cw *compressWriter) initCompression() {
 	}
 }
 
+// Flush sends buffered data to the client, so streaming responses such as
+// Server-Sent Events are compressed incrementally. A flush before the size
+// threshold is reached starts compression, since the response is streamed,
+// unless the status or Content-Type excludes the response from compression.
+func (cw *compressWriter) Flush() {
+	if !cw.decided {
+		cw.decided = true
+		write := cw.writeCompressed
+		if !cw.compressible() {
+			write = cw.writeUncompressed
+		}
+		if _, err := write(nil); err != nil {
+			return
+		}
+	}
+
+	if cw.compress && cw.writer != nil {
+		if f, ok := cw.writer.(interface{ Flush() error }); ok {
+			if err := f.Flush(); err != nil {
+				return
+			}
+		}
+	}
+
+	//nolint:errcheck // http.Flusher has no way to report errors
+	http.NewResponseController(cw.ResponseWriter).Flush()
+}
+
+// Unwrap returns the underlying response writer for http.ResponseController.
+func (cw *compressWriter) Unwrap() http.ResponseWriter {
+	return cw.ResponseWriter
+}
+
 // Close finalizes compression and returns writers to pools.
 func (cw *compressWriter) Close() error {
 	if !cw.decided {
@@ -267,6 +341,8 @@ func (cw *compressWriter) Close() error {
 		err := cw.writer.Close()
 		// Reset before returning to pool to reduce holding references
 		switch w := cw.writer.(type) {
+		case *zstd.Encoder:
+			w.Reset(nil)
 		case *brotli.Writer:
 			w.Reset(nil)
 		case *gzip.Writer:
@@ -288,14 +364,15 @@ func shouldSkipStatus(code int) bool {
 }
 
 // shouldSkipContentType returns true if the content type should not be compressed.
-func shouldSkipContentType(ct string, excludes map[string]bool) bool {
+// Server-Sent Events are skipped unless eventStream is set.
+func shouldSkipContentType(ct string, excludes map[string]bool, eventStream bool) bool {
 	if ct == "" {
 		return false
 	}
 
 	// Always skip these
 	ctLower := strings.ToLower(ct)
-	if strings.Contains(ctLower, "text/event-stream") ||
+	if (!eventStream && strings.Contains(ctLower, "text/event-stream")) ||
 		strings.Contains(ctLower, "application/grpc") ||
 		strings.Contains(ctLower, "application/octet-stream") {
 
@@ -316,9 +393,14 @@ func shouldSkipContentType(ct string, excludes map[string]bool) bool {
 var (
 	gzipWriterPools   = make(map[int]*sync.Pool)
 	brotliWriterPools = make(map[int]*sync.Pool)
+	zstdEncoderPools  = make(map[int]*sync.Pool)
 	poolsMutex        sync.RWMutex
 )
 
+// zstdWindowSize is the largest window browsers accept for the "zstd" content
+// coding (RFC 9659).
+const zstdWindowSize = 8 << 20
+
 // getGzipWriterPool returns a pool for the specified compression level.
 func getGzipWriterPool(level int) *sync.Pool {
 	poolsMutex.RLock()
@@ -380,8 +462,53 @@ func getBrotliWriterPool(level int) *sync.Pool {
 	return pool
 }
 
+// getZstdEncoderPool returns a pool for the specified zstd compression level.
+func getZstdEncoderPool(level int) *sync.Pool {
+	poolsMutex.RLock()
+	pool, exists := zstdEncoderPools[level]
+	poolsMutex.RUnlock()
+
+	if exists {
+		return pool
+	}
+
+	poolsMutex.Lock()
+	defer poolsMutex.Unlock()
+
+	// Double-check after acquiring write lock
+	if existingPool, poolExists := zstdEncoderPools[level]; poolExists {
+		return existingPool
+	}
+
+	pool = &sync.Pool{
+		New: func() any {
+			//nolint:errcheck // Options are valid constants; NewWriter only fails on invalid options
+			w, _ := zstd.NewWriter(nil,
+				zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(level)),
+				zstd.WithEncoderConcurrency(1), // One response per encoder; no background goroutines
+				zstd.WithWindowSize(zstdWindowSize),
+			)
+			return w
+		},
+	}
+	zstdEncoderPools[level] = pool
+
+	return pool
+}
+
+// tagETag appends the encoding to a strong ETag, since the compressed body is
+// a different representation than the uncompressed one (RFC 9110, 8.8.3.3).
+// Weak ETags are left as they are.
+func (cw *compressWriter) tagETag() {
+	etag := cw.ResponseWriter.Header().Get("ETag")
+	if len(etag) < 2 || etag[0] != '"' || etag[len(etag)-1] != '"' {
+		return
+	}
+	cw.ResponseWriter.Header().Set("ETag", etag[:len(etag)-1]+"-"+cw.encoding+`"`)
+}
+
 // chooseEncoding selects the best encoding based on Accept-Encoding header.
-// Respects q-values and prefers Brotli over gzip if both are available.
+// Respects q-values; on equal quality it prefers zstd, then Brotli, then gzip.
 func chooseEncoding(acceptEncoding string, cfg *config) string {
 	if acceptEncoding == "" {
 		return ""
@@ -389,24 +516,28 @@ func chooseEncoding(acceptEncoding string, cfg *config) string {
 
 	ae := strings.ToLower(acceptEncoding)
 
-	brQ := parseQValue(ae, "br")
-	gzipQ := parseQValue(ae, "gzip")
-
-	// Explicit q=0 means not acceptable
-	if brQ == 0 && gzipQ == 0 {
-		return ""
-	}
-
-	// Prefer Brotli if enabled and quality is equal or better
-	if cfg.enableBrotli && brQ > 0 && brQ >= gzipQ {
-		return "br"
+	// In order of preference
+	candidates := [...]struct {
+		name    string
+		enabled bool
+	}{
+		{"zstd", cfg.enableZstd},
+		{"br", cfg.enableBrotli},
+		{"gzip", cfg.enableGzip},
 	}
 
-	if cfg.enableGzip && gzipQ > 0 {
-		return "gzip"
+	best, bestQ := "", 0.0
+	for _, cand := range candidates {
+		if !cand.enabled {
+			continue
+		}
+		// Explicit q=0 means not acceptable
+		if q := parseQValue(ae, cand.name); q > bestQ {
+			best, bestQ = cand.name, q
+		}
 	}
 
-	return ""
+	return best
 }
 
 // parseQValue returns -1 if not present, 0 if q=0, or the parsed quality value.
@@ -436,19 +567,21 @@ func parseQValue(accept, encoding string) float64 {
 	return q
 }
 
-// New returns a middleware that compresses HTTP responses using gzip and/or Brotli.
+// New returns a middleware that compresses HTTP responses using zstd, Brotli, and/or gzip.
 // It automatically detects client support and selects the best encoding based on
 // Accept-Encoding header with q-value negotiation.
 //
 // Features:
-//   - Automatic gzip and Brotli compression with quality-value negotiation
-//   - Configurable compression levels for both algorithms
+//   - Automatic zstd, Brotli, and gzip compression with quality-value negotiation
+//   - Configurable compression levels for each algorithm
 //   - Minimum size threshold with buffering to avoid compressing small responses
 //   - Path and content-type exclusions
 //   - Writer pooling for reduced allocations
 //   - Skips compression for 204, 304, 206, SSE, and gRPC
+//   - Passes flushes through, so streamed responses are compressed incrementally
 //   - Sets Vary: Accept-Encoding header
 //   - Respects existing Content-Encoding headers (proxying)
+//   - Serves precompressed .zst, .br, and .gz static files ([WithPrecompressed])
 //
 // Basic usage:
 //
@@ -509,6 +642,12 @@ func New(opts ...Option) router.HandlerFunc {
 			return
 		}
 
+		// Early exit: precompressed static file served
+		if len(cfg.precompressed) > 0 && cfg.servePrecompressed(c) {
+			c.Abort()
+			return
+		}
+
 		// Early exit: no client support
 		encoding := chooseEncoding(c.Request.Header.Get("Accept-Encoding"), cfg)
 		if encoding == "" {
@@ -519,6 +658,8 @@ func New(opts ...Option) router.HandlerFunc {
 		// Get appropriate pool
 		var pool *sync.Pool
 		switch encoding {
+		case "zstd":
+			pool = getZstdEncoderPool(cfg.zstdLevel)
 		case "br":
 			pool = getBrotliWriterPool(cfg.brotliLevel)
 		case "gzip":
@@ -538,9 +679,11 @@ func New(opts ...Option) router.HandlerFunc {
 			ResponseWriter:      c.Response,
 			encoding:            encoding,
 			excludeContentTypes: cfg.excludeContentTypes,
+			compressEventStream: cfg.compressEventStream,
 			threshold:           cfg.minSize,
 			buffer:              buf,
 			pool:                pool,
+			statusCode:          http.StatusOK,
 		}
 
 		originalWriter := c.Response
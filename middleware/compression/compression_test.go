@@ -27,6 +27,7 @@ import (
 	"testing"
 
 	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -314,6 +315,38 @@ func TestCompression_ContentLengthRemoved(t *testing.T) {
 	assert.NotEqual(t, "100", w.Header().Get("Content-Length"))
 }
 
+func TestCompression_ETagPerEncoding(t *testing.T) {
+	t.Parallel()
+	r := router.MustNew()
+	r.Use(New())
+	r.GET("/strong", func(c *router.Context) {
+		c.Response.Header().Set("ETag", `"v1"`)
+		//nolint:errcheck // Test handler
+		c.String(http.StatusOK, strings.Repeat("data ", 100))
+	})
+	r.GET("/weak", func(c *router.Context) {
+		c.Response.Header().Set("ETag", `W/"v1"`)
+		//nolint:errcheck // Test handler
+		c.String(http.StatusOK, strings.Repeat("data ", 100))
+	})
+
+	tests := []struct {
+		path, acceptEncoding, want string
+	}{
+		{"/strong", "gzip", `"v1-gzip"`},
+		{"/strong", "br", `"v1-br"`},
+		{"/strong", "identity", `"v1"`},
+		{"/weak", "gzip", `W/"v1"`},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+		req.Header.Set("Accept-Encoding", tt.acceptEncoding)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		assert.Equal(t, tt.want, w.Header().Get("ETag"), "%s with %s", tt.path, tt.acceptEncoding)
+	}
+}
+
 //nolint:paralleltest // Tests Brotli compression path
 func TestCompression_Brotli(t *testing.T) {
 	r := router.MustNew()
@@ -420,3 +453,195 @@ func TestCompression_WriteUncompressed(t *testing.T) {
 	assert.Empty(t, w.Header().Get("Content-Encoding"))
 	assert.Equal(t, "fake image data", w.Body.String())
 }
+
+func TestCompression_Zstd(t *testing.T) {
+	t.Parallel()
+
+	for _, level := range []int{1, 3, 9, 22} {
+		t.Run(fmt.Sprintf("level %d", level), func(t *testing.T) {
+			t.Parallel()
+
+			r := router.MustNew()
+			r.Use(New(WithZstdLevel(level)))
+			r.GET("/test", func(c *router.Context) {
+				//nolint:errcheck // Test handler
+				c.String(http.StatusOK, strings.Repeat("Hello zstd ", 1000))
+			})
+
+			for range 2 { // The second request reuses a pooled encoder
+				req := httptest.NewRequest(http.MethodGet, "/test", nil)
+				req.Header.Set("Accept-Encoding", "gzip, br, zstd")
+				w := httptest.NewRecorder()
+				r.ServeHTTP(w, req)
+
+				assert.Equal(t, "zstd", w.Header().Get("Content-Encoding"), "zstd is preferred")
+				assert.Equal(t, "Accept-Encoding", w.Header().Get("Vary"))
+
+				zr, err := zstd.NewReader(w.Body)
+				require.NoError(t, err)
+				decompressed, err := io.ReadAll(zr)
+				zr.Close()
+				require.NoError(t, err)
+				assert.Equal(t, strings.Repeat("Hello zstd ", 1000), string(decompressed))
+			}
+		})
+	}
+}
+
+func TestChooseEncoding(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		accept string
+		opts   []Option
+		want   string
+	}{
+		{accept: "", want: ""},
+		{accept: "identity", want: ""},
+		{accept: "gzip, deflate, br, zstd", want: "zstd"},
+		{accept: "zstd;q=0.5, br", want: "br"},
+		{accept: "zstd;q=0, gzip", want: "gzip"},
+		{accept: "gzip;q=1.0, zstd;q=0.9", want: "gzip"},
+		{accept: "br, zstd", opts: []Option{WithZstdDisabled()}, want: "br"},
+		{accept: "zstd", opts: []Option{WithZstdDisabled()}, want: ""},
+	}
+
+	for _, tt := range tests {
+		cfg := defaultConfig()
+		for _, opt := range tt.opts {
+			opt(cfg)
+		}
+		assert.Equal(t, tt.want, chooseEncoding(tt.accept, cfg), "Accept-Encoding: %q", tt.accept)
+	}
+}
+
+// readPartialGzip decompresses a gzip stream that may not be finished yet.
+func readPartialGzip(t *testing.T, data []byte) string {
+	t.Helper()
+
+	gr, err := gzip.NewReader(strings.NewReader(string(data)))
+	require.NoError(t, err)
+	//nolint:errcheck // An unfinished stream ends with io.ErrUnexpectedEOF
+	out, _ := io.ReadAll(gr)
+
+	return string(out)
+}
+
+func TestCompression_Flush(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		opts        []Option
+		contentType string
+		compressed  bool
+	}{
+		{"chunked", nil, "text/plain", true},
+		{"event stream", nil, "text/event-stream", false},
+		{"event stream enabled", []Option{WithEventStreamCompression()}, "text/event-stream", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			w := httptest.NewRecorder()
+			var flushed string
+			r := router.MustNew()
+			r.Use(New(tt.opts...))
+			r.GET("/stream", func(c *router.Context) {
+				c.Response.Header().Set("Content-Type", tt.contentType)
+				c.Response.WriteHeader(http.StatusOK)
+				_, _ = c.Response.Write([]byte("data: one\n\n")) //nolint:errcheck // Test handler
+				assert.NoError(t, http.NewResponseController(c.Response).Flush())
+
+				// What the client has received so far
+				if tt.compressed {
+					flushed = readPartialGzip(t, w.Body.Bytes())
+				} else {
+					flushed = w.Body.String()
+				}
+				_, _ = c.Response.Write([]byte("data: two\n\n")) //nolint:errcheck // Test handler
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/stream", nil)
+			req.Header.Set("Accept-Encoding", "gzip")
+			r.ServeHTTP(w, req)
+
+			assert.True(t, w.Flushed)
+			assert.Equal(t, "data: one\n\n", flushed, "the first event reaches the client before the handler returns")
+			if tt.compressed {
+				assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+				assert.Equal(t, "data: one\n\ndata: two\n\n", readPartialGzip(t, w.Body.Bytes()))
+			} else {
+				assert.Empty(t, w.Header().Get("Content-Encoding"))
+				assert.Equal(t, "data: one\n\ndata: two\n\n", w.Body.String())
+			}
+		})
+	}
+}
+
+func TestCompression_FlushWithoutWriteHeader(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		opts        []Option
+		contentType string
+		compressed  bool
+	}{
+		{"event stream", nil, "text/event-stream", false},
+		{"event stream enabled", []Option{WithEventStreamCompression()}, "text/event-stream", true},
+		{"excluded content type", []Option{WithExcludeContentTypes("application/x-ndjson")}, "application/x-ndjson", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := router.MustNew()
+			r.Use(New(tt.opts...))
+			r.GET("/stream", func(c *router.Context) {
+				c.Response.Header().Set("Content-Type", tt.contentType)
+				assert.NoError(t, http.NewResponseController(c.Response).Flush())
+				_, _ = c.Response.Write([]byte("data: one\n\n")) //nolint:errcheck // Test handler
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/stream", nil)
+			req.Header.Set("Accept-Encoding", "gzip")
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.True(t, w.Flushed)
+			if tt.compressed {
+				assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+				assert.Equal(t, "data: one\n\n", readPartialGzip(t, w.Body.Bytes()))
+			} else {
+				assert.Empty(t, w.Header().Get("Content-Encoding"))
+				assert.Equal(t, "data: one\n\n", w.Body.String())
+			}
+		})
+	}
+}
+
+func TestCompression_FlushBeforeWrite(t *testing.T) {
+	t.Parallel()
+
+	r := router.MustNew()
+	r.Use(New(WithMinSize(1024)))
+	r.GET("/stream", func(c *router.Context) {
+		assert.NoError(t, http.NewResponseController(c.Response).Flush())
+		_, _ = c.Response.Write([]byte("late")) //nolint:errcheck // Test handler
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/stream", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.True(t, w.Flushed)
+	assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"), "flushing streams the response, so it is compressed")
+	assert.Equal(t, "late", readPartialGzip(t, w.Body.Bytes()))
+}
@@ -0,0 +1,222 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compression
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+
+	"rivaas.dev/router"
+)
+
+// ErrDecompressedTooLarge is returned when reading a request body whose
+// decompressed size exceeds the limit set with [WithMaxDecompressedSize].
+var ErrDecompressedTooLarge = errors.New("decompressed request body exceeds limit")
+
+// defaultMaxDecompressedSize is the default limit of a decompressed request body.
+const defaultMaxDecompressedSize = 10 << 20 // 10MB
+
+// WithMaxDecompressedSize sets the maximum size of a request body after
+// decompression by [Decompress]. Reading past it fails with
+// [ErrDecompressedTooLarge], which protects against decompression bombs.
+// Values <= 0 are ignored.
+// Default: 10MB
+//
+// Example:
+//
+//	compression.Decompress(compression.WithMaxDecompressedSize(50 << 20))
+func WithMaxDecompressedSize(size int64) Option {
+	return func(cfg *config) {
+		if size > 0 {
+			cfg.maxDecompressedSize = size
+		}
+	}
+}
+
+// gzipReaderPool reuses gzip readers across requests.
+var gzipReaderPool sync.Pool
+
+// decompressReader decompresses a request body and enforces the size limit.
+type decompressReader struct {
+	body    io.ReadCloser // Original, compressed body
+	reader  io.Reader     // Decompressed stream
+	release func()        // Returns the decoder to its pool; may be nil
+	limit   int64
+	read    int64
+}
+
+// Read reads decompressed data and fails once more than limit bytes were produced.
+func (d *decompressReader) Read(p []byte) (int, error) {
+	if d.read > d.limit {
+		return 0, fmt.Errorf("%w: %d bytes", ErrDecompressedTooLarge, d.limit)
+	}
+
+	// Read at most one byte past the limit to detect an oversized body
+	if remaining := d.limit - d.read + 1; int64(len(p)) > remaining {
+		p = p[:remaining]
+	}
+
+	n, err := d.reader.Read(p)
+	d.read += int64(n)
+	if d.read > d.limit {
+		return n - int(d.read-d.limit), fmt.Errorf("%w: %d bytes", ErrDecompressedTooLarge, d.limit)
+	}
+	if err == io.EOF {
+		d.releaseDecoder()
+	}
+
+	return n, err
+}
+
+// Close returns the decoder to its pool and closes the original body.
+func (d *decompressReader) Close() error {
+	d.releaseDecoder()
+
+	return d.body.Close()
+}
+
+// releaseDecoder returns the decoder to its pool. Later reads return io.EOF.
+// It is safe to call more than once.
+func (d *decompressReader) releaseDecoder() {
+	if d.release == nil {
+		return
+	}
+	d.release()
+	d.release = nil
+	d.reader = http.NoBody
+}
+
+// Decompress returns a middleware that decompresses request bodies sent with
+// a Content-Encoding of gzip, deflate, or zstd, for clients that upload
+// compressed payloads. Handlers read the decompressed body as usual; the
+// Content-Encoding and Content-Length request headers are removed.
+//
+// Reading more than the size set with [WithMaxDecompressedSize] fails with
+// [ErrDecompressedTooLarge]. Use it together with bodylimit, which limits the
+// compressed size. Bodies with a malformed header are rejected with 400 Bad
+// Request. Unsupported or stacked codings are rejected with 415 Unsupported
+// Media Type and an Accept-Encoding header listing the supported ones.
+// [WithGzipDisabled], [WithZstdDisabled], and [WithExcludePaths] apply here
+// as well.
+//
+// Basic usage:
+//
+//	r := router.MustNew()
+//	r.Use(compression.Decompress())
+//
+// With a custom limit:
+//
+//	r.Use(compression.Decompress(
+//	    compression.WithMaxDecompressedSize(50 << 20), // 50MB
+//	))
+func Decompress(opts ...Option) router.HandlerFunc {
+	// Apply options to default config
+	cfg := defaultConfig()
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	supported := []string{"deflate"}
+	if cfg.enableGzip {
+		supported = append([]string{"gzip"}, supported...)
+	}
+	if cfg.enableZstd {
+		supported = append([]string{"zstd"}, supported...)
+	}
+	acceptEncoding := strings.Join(supported, ", ")
+
+	zstdDecoderPool := &sync.Pool{
+		New: func() any {
+			//nolint:errcheck // Options are valid constants; NewReader only fails on invalid options
+			d, _ := zstd.NewReader(nil,
+				zstd.WithDecoderConcurrency(1), // Synchronous; no background goroutines
+				// A window larger than the body limit cannot be needed
+				zstd.WithDecoderMaxWindow(uint64(min(max(cfg.maxDecompressedSize, zstd.MinWindowSize), zstd.MaxWindowSize))),
+			)
+			return d
+		},
+	}
+
+	return func(c *router.Context) {
+		if cfg.excludePaths[c.Request.URL.Path] {
+			c.Next()
+			return
+		}
+
+		encoding := strings.ToLower(strings.TrimSpace(c.Request.Header.Get("Content-Encoding")))
+		if encoding == "" || encoding == "identity" || c.Request.Body == nil || c.Request.Body == http.NoBody {
+			c.Next()
+			return
+		}
+
+		body := &decompressReader{body: c.Request.Body, limit: cfg.maxDecompressedSize}
+		var err error
+		switch {
+		case (encoding == "gzip" || encoding == "x-gzip") && cfg.enableGzip:
+			zr, ok := gzipReaderPool.Get().(*gzip.Reader)
+			if !ok {
+				zr = new(gzip.Reader)
+			}
+			err = zr.Reset(c.Request.Body)
+			body.reader = zr
+			body.release = func() { gzipReaderPool.Put(zr) }
+		case encoding == "deflate":
+			body.reader, err = zlib.NewReader(c.Request.Body)
+		case encoding == "zstd" && cfg.enableZstd:
+			zr, ok := zstdDecoderPool.Get().(*zstd.Decoder)
+			if !ok {
+				c.Next() // Invalid decoder type from pool
+				return
+			}
+			err = zr.Reset(c.Request.Body)
+			body.reader = zr
+			body.release = func() {
+				//nolint:errcheck // Reset(nil) only releases the input
+				zr.Reset(nil)
+				zstdDecoderPool.Put(zr)
+			}
+		default:
+			c.Response.Header().Set("Accept-Encoding", acceptEncoding)
+			c.WriteErrorResponse(http.StatusUnsupportedMediaType, "unsupported content encoding")
+			c.Abort()
+
+			return
+		}
+		if err != nil {
+			body.releaseDecoder()
+			c.WriteErrorResponse(http.StatusBadRequest, "malformed "+encoding+" request body")
+			c.Abort()
+
+			return
+		}
+
+		c.Request.Body = body
+		c.Request.Header.Del("Content-Encoding")
+		c.Request.Header.Del("Content-Length")
+		c.Request.ContentLength = -1
+
+		// Handlers need not read the body to the end or close it
+		defer body.releaseDecoder()
+		c.Next()
+	}
+}
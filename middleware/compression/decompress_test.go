@@ -0,0 +1,252 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package compression
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"rivaas.dev/router"
+)
+
+// compressBody compresses data with the given content coding.
+func compressBody(t *testing.T, encoding string, data []byte) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	var w io.WriteCloser
+	switch encoding {
+	case "gzip":
+		w = gzip.NewWriter(&buf)
+	case "deflate":
+		w = zlib.NewWriter(&buf)
+	case "zstd":
+		zw, err := zstd.NewWriter(&buf)
+		require.NoError(t, err)
+		w = zw
+	default:
+		t.Fatalf("unknown encoding %q", encoding)
+	}
+	_, err := w.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	return buf.Bytes()
+}
+
+// newEchoRouter returns a router whose POST /echo handler responds with the
+// request body, or with 413 and the read error if reading fails.
+func newEchoRouter(opts ...Option) *router.Router {
+	r := router.MustNew()
+	r.Use(Decompress(opts...))
+	r.POST("/echo", func(c *router.Context) {
+		body, err := io.ReadAll(c.Request.Body)
+		if errors.Is(err, ErrDecompressedTooLarge) {
+			c.WriteErrorResponse(http.StatusRequestEntityTooLarge, err.Error())
+			return
+		}
+		if err != nil {
+			c.WriteErrorResponse(http.StatusBadRequest, err.Error())
+			return
+		}
+		c.Header("X-Content-Encoding", c.Request.Header.Get("Content-Encoding"))
+		c.Header("X-Content-Length", strconv.FormatInt(c.Request.ContentLength, 10))
+		//nolint:errcheck // Test handler
+		c.String(http.StatusOK, string(body))
+	})
+
+	return r
+}
+
+//nolint:paralleltest // Tests compression behavior
+func TestDecompress(t *testing.T) {
+	payload := []byte(strings.Repeat(`{"message":"hello"}`, 100))
+
+	for _, encoding := range []string{"gzip", "deflate", "zstd"} {
+		t.Run(encoding, func(t *testing.T) {
+			r := newEchoRouter()
+
+			body := compressBody(t, encoding, payload)
+			req := httptest.NewRequest(http.MethodPost, "/echo", bytes.NewReader(body))
+			req.Header.Set("Content-Encoding", encoding)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, string(payload), w.Body.String())
+			assert.Empty(t, w.Header().Get("X-Content-Encoding"))
+			assert.Equal(t, "-1", w.Header().Get("X-Content-Length"))
+		})
+	}
+}
+
+//nolint:paralleltest // Tests compression behavior
+func TestDecompress_Identity(t *testing.T) {
+	r := newEchoRouter()
+
+	req := httptest.NewRequest(http.MethodPost, "/echo", strings.NewReader("plain"))
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "plain", w.Body.String())
+	assert.Equal(t, "5", w.Header().Get("X-Content-Length"))
+}
+
+//nolint:paralleltest // Tests compression behavior
+func TestDecompress_SizeLimit(t *testing.T) {
+	payload := bytes.Repeat([]byte("a"), 1000)
+
+	for _, encoding := range []string{"gzip", "deflate", "zstd"} {
+		t.Run(encoding, func(t *testing.T) {
+			body := compressBody(t, encoding, payload)
+
+			// Exactly at the limit
+			req := httptest.NewRequest(http.MethodPost, "/echo", bytes.NewReader(body))
+			req.Header.Set("Content-Encoding", encoding)
+			w := httptest.NewRecorder()
+			newEchoRouter(WithMaxDecompressedSize(1000)).ServeHTTP(w, req)
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Len(t, w.Body.String(), 1000)
+
+			// One byte over
+			req = httptest.NewRequest(http.MethodPost, "/echo", bytes.NewReader(body))
+			req.Header.Set("Content-Encoding", encoding)
+			w = httptest.NewRecorder()
+			newEchoRouter(WithMaxDecompressedSize(999)).ServeHTTP(w, req)
+			assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+			assert.Contains(t, w.Body.String(), ErrDecompressedTooLarge.Error())
+		})
+	}
+}
+
+//nolint:paralleltest // Tests compression behavior
+func TestDecompress_Rejected(t *testing.T) {
+	tests := []struct {
+		name               string
+		encoding           string
+		body               []byte
+		opts               []Option
+		wantStatus         int
+		wantAcceptEncoding string
+	}{
+		{
+			name:               "unsupported coding",
+			encoding:           "br",
+			body:               []byte("data"),
+			wantStatus:         http.StatusUnsupportedMediaType,
+			wantAcceptEncoding: "zstd, gzip, deflate",
+		},
+		{
+			name:               "stacked codings",
+			encoding:           "gzip, zstd",
+			body:               []byte("data"),
+			wantStatus:         http.StatusUnsupportedMediaType,
+			wantAcceptEncoding: "zstd, gzip, deflate",
+		},
+		{
+			name:               "disabled coding",
+			encoding:           "zstd",
+			body:               []byte("data"),
+			opts:               []Option{WithZstdDisabled()},
+			wantStatus:         http.StatusUnsupportedMediaType,
+			wantAcceptEncoding: "gzip, deflate",
+		},
+		{
+			name:       "malformed gzip",
+			encoding:   "gzip",
+			body:       []byte("not gzip"),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "malformed deflate",
+			encoding:   "deflate",
+			body:       []byte("not deflate"),
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newEchoRouter(tt.opts...)
+
+			req := httptest.NewRequest(http.MethodPost, "/echo", bytes.NewReader(tt.body))
+			req.Header.Set("Content-Encoding", tt.encoding)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantAcceptEncoding, w.Header().Get("Accept-Encoding"))
+		})
+	}
+}
+
+//nolint:paralleltest // Tests compression behavior
+func TestDecompress_ExcludePaths(t *testing.T) {
+	body := compressBody(t, "gzip", []byte("hello"))
+
+	req := httptest.NewRequest(http.MethodPost, "/echo", bytes.NewReader(body))
+	req.Header.Set("Content-Encoding", "gzip")
+	w := httptest.NewRecorder()
+	newEchoRouter(WithExcludePaths("/echo")).ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, body, w.Body.Bytes())
+	assert.Equal(t, "gzip", w.Header().Get("X-Content-Encoding"))
+}
+
+//nolint:paralleltest // Tests compression behavior
+func TestDecompress_ReusesDecoder(t *testing.T) {
+	body := compressBody(t, "zstd", []byte(strings.Repeat("hello ", 100)))
+
+	// The handler reads only part of the body and does not close it
+	seen := make(map[io.Reader]int)
+	r := router.MustNew()
+	r.Use(Decompress())
+	r.POST("/upload", func(c *router.Context) {
+		d, ok := c.Request.Body.(*decompressReader)
+		require.True(t, ok)
+		seen[d.reader]++
+		_, err := c.Request.Body.Read(make([]byte, 8))
+		require.NoError(t, err)
+		c.NoContent()
+	})
+
+	const requests = 10
+	for range requests {
+		req := httptest.NewRequest(http.MethodPost, "/upload", bytes.NewReader(body))
+		req.Header.Set("Content-Encoding", "zstd")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		require.Equal(t, http.StatusNoContent, w.Code)
+	}
+
+	// sync.Pool may drop entries, so require reuse rather than one decoder
+	assert.Less(t, len(seen), requests, "decoders are returned to the pool")
+}
@@ -14,8 +14,8 @@
 
 // Package compression provides middleware for HTTP response compression.
 //
-// This middleware automatically compresses HTTP responses using gzip, deflate,
-// or brotli compression algorithms based on client Accept-Encoding headers.
+// This middleware automatically compresses HTTP responses using zstd, brotli,
+// or gzip compression algorithms based on client Accept-Encoding headers.
 // It reduces bandwidth usage and improves response times for text-based content.
 //
 // # Basic Usage
@@ -32,9 +32,49 @@
 //   - gzip: Standard gzip compression (widely supported)
 //   - deflate: Deflate compression (legacy support)
 //   - brotli: Brotli compression (better compression ratio, modern browsers)
+//   - zstd: Zstandard compression (fast, requested by modern browsers, CDNs, and CLIs)
 //
 // The middleware automatically selects the best algorithm based on client
-// Accept-Encoding headers and configured preferences.
+// Accept-Encoding headers and configured preferences. When the client accepts
+// several with the same quality, zstd is preferred over brotli, and brotli over
+// gzip. Encoders are pooled per compression level.
+//
+// # Precompressed Static Files
+//
+// With WithPrecompressed, static files that have a precompressed sibling
+// (app.js.zst, app.js.br, app.js.gz) are served from the sibling instead of
+// being compressed on every request. Use the same prefix and file system as
+// the router's Static or StaticFS route:
+//
+//	r.Use(compression.New(
+//	    compression.WithPrecompressed("/assets", http.Dir("./public")),
+//	))
+//	r.Static("/assets", "./public")
+//
+// # ETags
+//
+// When a response with a strong ETag is compressed, the encoding is appended
+// to the ETag ("v1" becomes "v1-gzip"), since the compressed body is a
+// different representation. Weak ETags are left unchanged.
+//
+// # Streaming
+//
+// Flushes are passed through: buffered data is compressed, flushed from the
+// encoder, and sent to the client, so chunked responses stream as they are
+// written. Server-Sent Events (text/event-stream) are not compressed unless
+// WithEventStreamCompression is set:
+//
+//	r.Use(compression.New(compression.WithEventStreamCompression()))
+//
+// # Request Decompression
+//
+// Decompress is a separate middleware that decompresses request bodies sent
+// with Content-Encoding gzip, deflate, or zstd. The decompressed size is
+// limited (10MB by default) to protect against decompression bombs:
+//
+//	r.Use(compression.Decompress(
+//	    compression.WithMaxDecompressedSize(50 << 20),
+//	))
 //
 // # Configuration Options
 //
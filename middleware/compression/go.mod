@@ -4,6 +4,7 @@ go 1.25.0
 
 require (
 	github.com/andybalholm/brotli v1.2.0
+	github.com/klauspost/compress v1.18.4
 	github.com/stretchr/testify v1.11.1
 	rivaas.dev/router v0.15.0
 )
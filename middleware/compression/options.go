@@ -44,6 +44,34 @@ func WithBrotliLevel(level int) Option {
 	}
 }
 
+// WithZstdLevel sets the zstd compression level.
+// Valid values: 1 (fastest) to 22 (best compression); levels are mapped to the
+// encoder's speed presets (1, 2-5, 6-9, 10+).
+// Default: 3
+//
+// Example:
+//
+//	compression.New(compression.WithZstdLevel(6))
+func WithZstdLevel(level int) Option {
+	return func(cfg *config) {
+		// Clamp to valid zstd level range [1, 22]
+		cfg.zstdLevel = max(1, min(level, 22))
+	}
+}
+
+// WithZstdDisabled disables zstd compression.
+// By default zstd is preferred when the client accepts it with the same
+// quality as Brotli or gzip.
+//
+// Example:
+//
+//	compression.New(compression.WithZstdDisabled())
+func WithZstdDisabled() Option {
+	return func(cfg *config) {
+		cfg.enableZstd = false
+	}
+}
+
 // WithBrotliDisabled disables Brotli compression (gzip only).
 //
 // Example:
@@ -141,6 +169,21 @@ func WithExcludeContentTypes(contentTypes ...string) Option {
 	}
 }
 
+// WithEventStreamCompression enables compression of Server-Sent Events
+// (text/event-stream). Each flush sends the events written so far as a
+// compressed block, so clients receive them without delay. Some proxies and
+// clients buffer compressed streams; enable it only when yours don't.
+// Default: event streams are not compressed
+//
+// Example:
+//
+//	compression.New(compression.WithEventStreamCompression())
+func WithEventStreamCompression() Option {
+	return func(cfg *config) {
+		cfg.compressEventStream = true
+	}
+}
+
 // WithLogger sets the slog.Logger for error logging.
 // If not provided, errors will be silently ignored.
 //
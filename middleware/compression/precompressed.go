@@ -0,0 +1,164 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compression
+
+import (
+	"cmp"
+	"mime"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+
+	"rivaas.dev/router"
+)
+
+// precompressedExtensions maps content codings to the file extensions of their
+// precompressed siblings.
+var precompressedExtensions = map[string]string{
+	"zstd": ".zst",
+	"br":   ".br",
+	"gzip": ".gz",
+}
+
+// precompressedRoot is a file system whose precompressed files are served
+// for requests under prefix (without a trailing slash).
+type precompressedRoot struct {
+	prefix string
+	fs     http.FileSystem
+}
+
+// WithPrecompressed serves precompressed siblings of static files instead of
+// compressing them on the fly. For a GET or HEAD request under urlPrefix, the
+// middleware looks for the requested file with a ".zst", ".br", or ".gz"
+// suffix in fsys, picks the best one the client accepts, and serves it with
+// Content-Encoding and Vary: Accept-Encoding set. The Content-Type is derived
+// from the original file name. Requests without a matching sibling are passed
+// on and compressed as usual.
+//
+// urlPrefix and fsys should match a route registered with the router's static
+// file methods. Disabled encodings are not served. Can be passed multiple
+// times for different prefixes.
+//
+// Example:
+//
+//	r.Use(compression.New(
+//	    compression.WithPrecompressed("/assets", http.Dir("./public")),
+//	))
+//	r.Static("/assets", "./public") // app.js is served from app.js.br if accepted
+func WithPrecompressed(urlPrefix string, fsys http.FileSystem) Option {
+	return func(cfg *config) {
+		cfg.precompressed = append(cfg.precompressed, precompressedRoot{
+			prefix: strings.TrimSuffix("/"+strings.Trim(urlPrefix, "/*"), "/"),
+			fs:     fsys,
+		})
+	}
+}
+
+// servePrecompressed serves a precompressed sibling of the requested file if
+// one exists and is acceptable to the client. It reports whether it wrote a
+// response.
+func (cfg *config) servePrecompressed(c *router.Context) bool {
+	if c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead {
+		return false
+	}
+
+	for _, root := range cfg.precompressed {
+		name, ok := strings.CutPrefix(c.Request.URL.Path, root.prefix)
+		if !ok || !strings.HasPrefix(name, "/") || strings.HasSuffix(name, "/") {
+			continue
+		}
+		name = path.Clean(name)
+
+		// The response depends on Accept-Encoding whether or not a sibling is found
+		c.Response.Header().Set("Vary", "Accept-Encoding")
+
+		for _, encoding := range acceptedEncodings(c.Request.Header.Get("Accept-Encoding"), cfg) {
+			if servePrecompressedFile(c, root.fs, name, encoding) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// servePrecompressedFile serves name plus the extension of encoding from fsys.
+// It reports false if no such regular file exists.
+func servePrecompressedFile(c *router.Context, fsys http.FileSystem, name, encoding string) bool {
+	f, err := fsys.Open(name + precompressedExtensions[encoding])
+	if err != nil {
+		return false
+	}
+	defer f.Close() //nolint:errcheck // Read-only file
+
+	fi, err := f.Stat()
+	if err != nil || !fi.Mode().IsRegular() {
+		return false
+	}
+
+	h := c.Response.Header()
+	h.Set("Content-Encoding", encoding)
+	if h.Get("Content-Type") == "" {
+		// Content sniffing would see compressed bytes
+		ct := mime.TypeByExtension(path.Ext(name))
+		if ct == "" {
+			ct = "application/octet-stream"
+		}
+		h.Set("Content-Type", ct)
+	}
+	http.ServeContent(c.Response, c.Request, name, fi.ModTime(), f)
+
+	return true
+}
+
+// acceptedEncodings returns the enabled encodings the client accepts, best
+// first. Like chooseEncoding, it prefers zstd, then Brotli, then gzip on
+// equal quality.
+func acceptedEncodings(acceptEncoding string, cfg *config) []string {
+	if acceptEncoding == "" {
+		return nil
+	}
+
+	ae := strings.ToLower(acceptEncoding)
+
+	type accepted struct {
+		name string
+		q    float64
+	}
+	candidates := make([]accepted, 0, 3)
+	for _, cand := range [...]struct {
+		name    string
+		enabled bool
+	}{
+		{"zstd", cfg.enableZstd},
+		{"br", cfg.enableBrotli},
+		{"gzip", cfg.enableGzip},
+	} {
+		if q := parseQValue(ae, cand.name); cand.enabled && q > 0 {
+			candidates = append(candidates, accepted{cand.name, q})
+		}
+	}
+	slices.SortStableFunc(candidates, func(a, b accepted) int {
+		return cmp.Compare(b.q, a.q)
+	})
+
+	encodings := make([]string, len(candidates))
+	for i, cand := range candidates {
+		encodings[i] = cand.name
+	}
+
+	return encodings
+}
@@ -0,0 +1,227 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package compression
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"rivaas.dev/router"
+)
+
+// newPrecompressedRouter serves a static file system with precompressed
+// siblings under /assets.
+func newPrecompressedRouter(t *testing.T, opts ...Option) *router.Router {
+	t.Helper()
+
+	fsys := http.FS(fstest.MapFS{
+		"app.js":        {Data: []byte("console.log('plain')")},
+		"app.js.br":     {Data: []byte("br-bytes")},
+		"app.js.gz":     {Data: []byte("gzip-bytes")},
+		"style.css":     {Data: []byte("body{}")},
+		"style.css.zst": {Data: []byte("zstd-bytes")},
+		"data.bin":      {Data: []byte("plain")},
+		"data.bin.gz":   {Data: []byte("gzip-bytes")},
+		"dir/x":         {Data: []byte("x")},
+	})
+
+	r := router.MustNew()
+	r.Use(New(append([]Option{WithPrecompressed("/assets", fsys)}, opts...)...))
+	r.StaticFS("/assets", fsys)
+
+	return r
+}
+
+//nolint:paralleltest // Tests compression behavior
+func TestPrecompressed(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		acceptEncoding string
+		opts           []Option
+		wantEncoding   string
+		wantBody       string
+		wantType       string
+	}{
+		{
+			name:           "prefers brotli over gzip",
+			path:           "/assets/app.js",
+			acceptEncoding: "gzip, br",
+			wantEncoding:   "br",
+			wantBody:       "br-bytes",
+			wantType:       "text/javascript; charset=utf-8",
+		},
+		{
+			name:           "respects q-values",
+			path:           "/assets/app.js",
+			acceptEncoding: "br;q=0.5, gzip",
+			wantEncoding:   "gzip",
+			wantBody:       "gzip-bytes",
+			wantType:       "text/javascript; charset=utf-8",
+		},
+		{
+			name:           "falls back to the next accepted sibling",
+			path:           "/assets/app.js",
+			acceptEncoding: "zstd, gzip",
+			wantEncoding:   "gzip",
+			wantBody:       "gzip-bytes",
+		},
+		{
+			name:           "zstd sibling",
+			path:           "/assets/style.css",
+			acceptEncoding: "zstd",
+			wantEncoding:   "zstd",
+			wantBody:       "zstd-bytes",
+			wantType:       "text/css; charset=utf-8",
+		},
+		{
+			name:           "unknown extension",
+			path:           "/assets/data.bin",
+			acceptEncoding: "gzip",
+			wantEncoding:   "gzip",
+			wantBody:       "gzip-bytes",
+			wantType:       "application/octet-stream",
+		},
+		{
+			name:           "disabled encoding is not served",
+			path:           "/assets/app.js",
+			acceptEncoding: "br",
+			opts:           []Option{WithBrotliDisabled(), WithZstdDisabled(), WithGzipDisabled()},
+			wantBody:       "console.log('plain')",
+		},
+		{
+			name:     "no accepted encoding",
+			path:     "/assets/app.js",
+			wantBody: "console.log('plain')",
+		},
+		{
+			name:           "HEAD",
+			method:         http.MethodHead,
+			path:           "/assets/app.js",
+			acceptEncoding: "br",
+			wantEncoding:   "br",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newPrecompressedRouter(t, tt.opts...)
+
+			method := tt.method
+			if method == "" {
+				method = http.MethodGet
+			}
+			req := httptest.NewRequest(method, tt.path, nil)
+			if tt.acceptEncoding != "" {
+				req.Header.Set("Accept-Encoding", tt.acceptEncoding)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, tt.wantEncoding, w.Header().Get("Content-Encoding"))
+			assert.Equal(t, "Accept-Encoding", w.Header().Get("Vary"))
+			assert.Equal(t, tt.wantBody, w.Body.String())
+			if tt.wantType != "" {
+				assert.Equal(t, tt.wantType, w.Header().Get("Content-Type"))
+			}
+		})
+	}
+}
+
+//nolint:paralleltest // Tests compression behavior
+func TestPrecompressed_NoSibling(t *testing.T) {
+	r := newPrecompressedRouter(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/assets/dir/x", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	// Compressed on the fly instead
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+	assert.Equal(t, "Accept-Encoding", w.Header().Get("Vary"))
+}
+
+//nolint:paralleltest // Tests compression behavior
+func TestPrecompressed_Range(t *testing.T) {
+	r := newPrecompressedRouter(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/assets/app.js", nil)
+	req.Header.Set("Accept-Encoding", "br")
+	req.Header.Set("Range", "bytes=0-1")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	// Ranges apply to the encoded representation
+	assert.Equal(t, http.StatusPartialContent, w.Code)
+	assert.Equal(t, "br", w.Header().Get("Content-Encoding"))
+	assert.Equal(t, "br", w.Body.String())
+}
+
+//nolint:paralleltest // Tests compression behavior
+func TestPrecompressed_OutsidePrefix(t *testing.T) {
+	fsys := http.FS(fstest.MapFS{
+		"app.js":    {Data: []byte("plain")},
+		"app.js.br": {Data: []byte("br-bytes")},
+	})
+
+	r := router.MustNew()
+	r.Use(New(WithPrecompressed("/assets/", fsys)))
+	handler := func(c *router.Context) {
+		// Not compressed on the fly
+		c.Response.Header().Set("Content-Type", "application/octet-stream")
+		c.Response.WriteHeader(http.StatusOK)
+		//nolint:errcheck // Test handler
+		c.Response.Write([]byte("handler"))
+	}
+	r.GET("/assetsx/app.js", handler)
+	r.POST("/assets/app.js", handler)
+
+	for _, method := range []string{http.MethodGet, http.MethodPost} {
+		path := "/assets/app.js"
+		if method == http.MethodGet {
+			path = "/assetsx/app.js"
+		}
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set("Accept-Encoding", "br")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, "handler", w.Body.String(), method)
+		assert.Empty(t, w.Header().Get("Content-Encoding"), method)
+	}
+}
+
+func TestAcceptedEncodings(t *testing.T) {
+	t.Parallel()
+
+	cfg := defaultConfig()
+	assert.Nil(t, acceptedEncodings("", cfg))
+	assert.Equal(t, []string{"zstd", "br", "gzip"}, acceptedEncodings("gzip, br, zstd", cfg))
+	assert.Equal(t, []string{"gzip", "br"}, acceptedEncodings("zstd;q=0, br;q=0.8, gzip", cfg))
+
+	cfg.enableZstd = false
+	assert.Equal(t, []string{"br"}, acceptedEncodings("zstd, br", cfg))
+}
@@ -50,7 +50,11 @@ type config struct {
 	allowAllOrigins bool
 
 	// allowOriginFunc is a custom function to validate origins
-	allowOriginFunc func(origin string) bool
+	allowOriginFunc func(origin string, c *router.Context) bool
+
+	// routePolicies are the options of policies that replace this one for a
+	// route pattern ("/public/:id"), "METHOD pattern", or prefix ("/public/*")
+	routePolicies map[string][]Option
 }
 
 // defaultConfig returns the default configuration for cors middleware.
@@ -104,6 +108,13 @@ func defaultConfig() *config {
 //	        return strings.HasSuffix(origin, ".example.com")
 //	    }),
 //	))
+//
+// A different policy for some routes:
+//
+//	r.Use(cors.New(
+//	    cors.WithAllowedOrigins("https://app.example.com"),
+//	    cors.WithRoutePolicy("/public/*", cors.WithAllowAllOrigins(true)),
+//	))
 func New(opts ...Option) router.HandlerFunc {
 	// Apply options to default config
 	cfg := defaultConfig()
@@ -111,14 +122,15 @@ func New(opts ...Option) router.HandlerFunc {
 		opt(cfg)
 	}
 
-	// Pre-compute common headers for performance
-	allowedMethodsHeader := strings.Join(cfg.allowedMethods, ", ")
-	allowedHeadersHeader := strings.Join(cfg.allowedHeaders, ", ")
-	exposedHeadersHeader := ""
-	if len(cfg.exposedHeaders) > 0 {
-		exposedHeadersHeader = strings.Join(cfg.exposedHeaders, ", ")
+	global := newPolicy(cfg)
+	routes := make(map[string]*policy, len(cfg.routePolicies))
+	for route, routeOpts := range cfg.routePolicies {
+		routeCfg := defaultConfig()
+		for _, opt := range routeOpts {
+			opt(routeCfg)
+		}
+		routes[route] = newPolicy(routeCfg)
 	}
-	maxAgeHeader := strconv.Itoa(cfg.maxAge)
 
 	return func(c *router.Context) {
 		origin := c.Request.Header.Get("Origin")
@@ -129,58 +141,135 @@ func New(opts ...Option) router.HandlerFunc {
 			return
 		}
 
-		// Determine if origin is allowed
-		allowedOrigin := ""
-		if cfg.allowAllOrigins {
-			allowedOrigin = "*"
-		} else if cfg.allowOriginFunc != nil {
-			if cfg.allowOriginFunc(origin) {
-				allowedOrigin = origin
-			}
-		} else {
-			// Check if origin is in allowed list
-			if slices.Contains(cfg.allowedOrigins, origin) {
-				allowedOrigin = origin
+		p := global
+		if len(routes) > 0 {
+			if rp := routePolicy(c, routes); rp != nil {
+				p = rp
 			}
 		}
 
-		// If origin is not allowed, continue without CORS headers
-		if allowedOrigin == "" {
-			c.Next()
-			return
+		p.handle(c, origin)
+	}
+}
+
+// policy is a CORS configuration with its response headers pre-computed.
+type policy struct {
+	cfg *config
+
+	allowedMethodsHeader string
+	allowedHeadersHeader string
+	exposedHeadersHeader string
+	maxAgeHeader         string
+}
+
+// newPolicy pre-computes the headers of cfg.
+func newPolicy(cfg *config) *policy {
+	return &policy{
+		cfg:                  cfg,
+		allowedMethodsHeader: strings.Join(cfg.allowedMethods, ", "),
+		allowedHeadersHeader: strings.Join(cfg.allowedHeaders, ", "),
+		exposedHeadersHeader: strings.Join(cfg.exposedHeaders, ", "),
+		maxAgeHeader:         strconv.Itoa(cfg.maxAge),
+	}
+}
+
+// routePolicy returns the policy registered for the route of c, or nil. For
+// preflight requests the method in Access-Control-Request-Method is used. An
+// exact pattern wins over the longest matching prefix ("/public/*").
+func routePolicy(c *router.Context, routes map[string]*policy) *policy {
+	pattern := c.RoutePattern()
+	method := c.Request.Method
+	if method == http.MethodOptions {
+		if requested := c.Request.Header.Get("Access-Control-Request-Method"); requested != "" {
+			method = requested
 		}
+	}
 
-		// Set CORS headers
-		// Handle credentials + wildcard incompatibility first
-		if cfg.allowCredentials && allowedOrigin == "*" {
-			// Cannot use wildcard with credentials - use specific origin instead
-			c.Response.Header().Set("Access-Control-Allow-Origin", origin)
-			c.Response.Header().Set("Access-Control-Allow-Credentials", "true")
-		} else {
-			// Normal case: set allowed origin
-			c.Response.Header().Set("Access-Control-Allow-Origin", allowedOrigin)
-			if cfg.allowCredentials {
-				c.Response.Header().Set("Access-Control-Allow-Credentials", "true")
-			}
+	for _, route := range [...]string{method + " " + pattern, pattern} {
+		if p, ok := routes[route]; ok {
+			return p
 		}
+	}
 
-		if exposedHeadersHeader != "" {
-			c.Response.Header().Set("Access-Control-Expose-Headers", exposedHeadersHeader)
+	var best *policy
+	bestLen := -1
+	for route, p := range routes {
+		// Method-specific prefixes are matched after stripping the method
+		if m, rest, ok := strings.Cut(route, " "); ok {
+			if m != method {
+				continue
+			}
+			route = rest
+		}
+		prefix, ok := strings.CutSuffix(route, "*")
+		if ok && strings.HasPrefix(pattern, prefix) && len(prefix) > bestLen {
+			best, bestLen = p, len(prefix)
 		}
+	}
 
-		// Handle preflight requests
-		if c.Request.Method == http.MethodOptions {
-			c.Response.Header().Set("Access-Control-Allow-Methods", allowedMethodsHeader)
-			c.Response.Header().Set("Access-Control-Allow-Headers", allowedHeadersHeader)
-			c.Response.Header().Set("Access-Control-Max-Age", maxAgeHeader)
+	return best
+}
 
-			// Preflight successful, return 204 No Content
-			c.Response.WriteHeader(http.StatusNoContent)
+// handle applies the policy to a CORS request from origin.
+func (p *policy) handle(c *router.Context, origin string) {
+	cfg := p.cfg
 
-			return
+	// Determine if origin is allowed
+	allowedOrigin := ""
+	if cfg.allowAllOrigins {
+		allowedOrigin = "*"
+	} else if cfg.allowOriginFunc != nil {
+		if cfg.allowOriginFunc(origin, c) {
+			allowedOrigin = origin
 		}
+	} else {
+		// Check if origin is in allowed list
+		if slices.Contains(cfg.allowedOrigins, origin) {
+			allowedOrigin = origin
+		}
+	}
 
-		// Continue with actual request
+	// Responses depend on the origin unless every origin gets "*"
+	if allowedOrigin != "*" || cfg.allowCredentials {
+		c.Response.Header().Add("Vary", "Origin")
+	}
+
+	// If origin is not allowed, continue without CORS headers
+	if allowedOrigin == "" {
 		c.Next()
+		return
 	}
+
+	// Set CORS headers
+	// Handle credentials + wildcard incompatibility first
+	if cfg.allowCredentials && allowedOrigin == "*" {
+		// Cannot use wildcard with credentials - use specific origin instead
+		c.Response.Header().Set("Access-Control-Allow-Origin", origin)
+		c.Response.Header().Set("Access-Control-Allow-Credentials", "true")
+	} else {
+		// Normal case: set allowed origin
+		c.Response.Header().Set("Access-Control-Allow-Origin", allowedOrigin)
+		if cfg.allowCredentials {
+			c.Response.Header().Set("Access-Control-Allow-Credentials", "true")
+		}
+	}
+
+	if p.exposedHeadersHeader != "" {
+		c.Response.Header().Set("Access-Control-Expose-Headers", p.exposedHeadersHeader)
+	}
+
+	// Handle preflight requests
+	if c.Request.Method == http.MethodOptions {
+		c.Response.Header().Set("Access-Control-Allow-Methods", p.allowedMethodsHeader)
+		c.Response.Header().Set("Access-Control-Allow-Headers", p.allowedHeadersHeader)
+		c.Response.Header().Set("Access-Control-Max-Age", p.maxAgeHeader)
+
+		// Preflight successful, return 204 No Content
+		c.Response.WriteHeader(http.StatusNoContent)
+
+		return
+	}
+
+	// Continue with actual request
+	c.Next()
 }
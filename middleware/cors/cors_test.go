@@ -327,3 +327,156 @@ func TestCORS_ActualRequest(t *testing.T) {
 	// Should not have preflight headers on actual request
 	assert.Empty(t, w.Header().Get("Access-Control-Allow-Methods"))
 }
+
+func TestCORS_OriginFunc(t *testing.T) {
+	t.Parallel()
+	tenantOrigins := map[string]string{
+		"acme":   "https://acme.example.com",
+		"globex": "https://globex.example.com",
+	}
+
+	r := router.MustNew()
+	r.Use(New(WithOriginFunc(func(origin string, c *router.Context) bool {
+		return tenantOrigins[c.Request.Header.Get("X-Tenant")] == origin
+	})))
+	r.GET("/test", func(c *router.Context) {
+		//nolint:errcheck // Test handler
+		c.String(http.StatusOK, "ok")
+	})
+
+	tests := []struct {
+		name           string
+		tenant         string
+		origin         string
+		expectedOrigin string
+	}{
+		{"tenant origin allowed", "acme", "https://acme.example.com", "https://acme.example.com"},
+		{"other tenant's origin disallowed", "globex", "https://acme.example.com", ""},
+		{"unknown tenant disallowed", "", "https://acme.example.com", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			req := httptest.NewRequest(http.MethodGet, "/test", nil)
+			req.Header.Set("Origin", tt.origin)
+			req.Header.Set("X-Tenant", tt.tenant)
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedOrigin, w.Header().Get("Access-Control-Allow-Origin"))
+			assert.Equal(t, "Origin", w.Header().Get("Vary"))
+		})
+	}
+}
+
+func TestCORS_RoutePolicy(t *testing.T) {
+	t.Parallel()
+	r := router.MustNew()
+	r.Use(New(
+		WithAllowedOrigins("https://app.example.com"),
+		WithAllowCredentials(true),
+		WithRoutePolicy("/public/*", WithAllowAllOrigins(true)),
+		WithRoutePolicy("/public/private", WithAllowedOrigins("https://admin.example.com")),
+		WithRoutePolicy("POST /upload", WithAllowedOrigins("https://upload.example.com"), WithAllowedMethods("POST")),
+	))
+	handler := func(c *router.Context) {
+		//nolint:errcheck // Test handler
+		c.String(http.StatusOK, "ok")
+	}
+	r.GET("/api/data", handler)
+	r.GET("/public/data/:id", handler)
+	r.GET("/public/private", handler)
+	r.GET("/upload", handler)
+	r.POST("/upload", handler)
+	r.OPTIONS("/upload", handler)
+
+	tests := []struct {
+		name            string
+		method          string
+		path            string
+		origin          string
+		requestMethod   string
+		expectedOrigin  string
+		expectedCreds   string
+		expectedStatus  int
+		expectedMethods string
+	}{
+		{
+			name:           "global policy",
+			method:         http.MethodGet,
+			path:           "/api/data",
+			origin:         "https://app.example.com",
+			expectedOrigin: "https://app.example.com",
+			expectedCreds:  "true",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "global policy rejects other origin",
+			method:         http.MethodGet,
+			path:           "/api/data",
+			origin:         "https://other.example.com",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "prefix policy",
+			method:         http.MethodGet,
+			path:           "/public/data/1",
+			origin:         "https://other.example.com",
+			expectedOrigin: "*",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "exact pattern wins over prefix",
+			method:         http.MethodGet,
+			path:           "/public/private",
+			origin:         "https://other.example.com",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "method-specific policy",
+			method:         http.MethodPost,
+			path:           "/upload",
+			origin:         "https://upload.example.com",
+			expectedOrigin: "https://upload.example.com",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "other method uses global policy",
+			method:         http.MethodGet,
+			path:           "/upload",
+			origin:         "https://upload.example.com",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:            "preflight matches requested method",
+			method:          http.MethodOptions,
+			path:            "/upload",
+			origin:          "https://upload.example.com",
+			requestMethod:   http.MethodPost,
+			expectedOrigin:  "https://upload.example.com",
+			expectedStatus:  http.StatusNoContent,
+			expectedMethods: "POST",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			req.Header.Set("Origin", tt.origin)
+			if tt.requestMethod != "" {
+				req.Header.Set("Access-Control-Request-Method", tt.requestMethod)
+			}
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, tt.expectedOrigin, w.Header().Get("Access-Control-Allow-Origin"))
+			assert.Equal(t, tt.expectedCreds, w.Header().Get("Access-Control-Allow-Credentials"))
+			assert.Equal(t, tt.expectedMethods, w.Header().Get("Access-Control-Allow-Methods"))
+		})
+	}
+}
@@ -42,6 +42,17 @@
 //   - MaxAge: Cache duration for preflight requests
 //   - OptionsPassthrough: Pass preflight requests to next handler
 //
+// # Dynamic Origins and Route Policies
+//
+// WithOriginFunc validates origins with access to the request, for example
+// against origins stored per tenant. WithRoutePolicy replaces the policy for
+// a route pattern, a "METHOD pattern", or a route group prefix ending in "*":
+//
+//	r.Use(cors.New(
+//	    cors.WithAllowedOrigins("https://app.example.com"),
+//	    cors.WithRoutePolicy("/public/*", cors.WithAllowAllOrigins(true)),
+//	))
+//
 // # Security Considerations
 //
 // When using AllowCredentials, you must specify exact origins (no wildcards).
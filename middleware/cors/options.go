@@ -14,6 +14,8 @@
 
 package cors
 
+import "rivaas.dev/router"
+
 // WithAllowedOrigins sets the list of allowed origins.
 // Use this for specific origins like ["https://example.com", "https://app.example.com"].
 //
@@ -109,7 +111,54 @@ func WithMaxAge(seconds int) Option {
 //	    return strings.HasSuffix(origin, ".example.com")
 //	}))
 func WithAllowOriginFunc(fn func(origin string) bool) Option {
+	return func(cfg *config) {
+		cfg.allowOriginFunc = func(origin string, _ *router.Context) bool {
+			return fn(origin)
+		}
+	}
+}
+
+// WithOriginFunc sets a function that validates origins with access to the
+// request, for checks that depend on it, such as origins stored per tenant.
+// It replaces [WithAllowOriginFunc] and takes precedence over
+// [WithAllowedOrigins]. For preflight requests it is called with the OPTIONS
+// request. Responses carry Vary: Origin, so caches keep them apart.
+//
+// Example:
+//
+//	cors.New(cors.WithOriginFunc(func(origin string, c *router.Context) bool {
+//	    return tenants.AllowsOrigin(c.Request.Context(), c.Param("tenant"), origin)
+//	}))
+func WithOriginFunc(fn func(origin string, c *router.Context) bool) Option {
 	return func(cfg *config) {
 		cfg.allowOriginFunc = fn
 	}
 }
+
+// WithRoutePolicy replaces the policy for the routes matching route with one
+// built from opts. The route policy starts from the defaults; it does not
+// inherit the other options passed to [New].
+//
+// route is a route pattern as registered ("/public/:id"), optionally prefixed
+// with a method ("POST /upload"), or a prefix ending in "*" ("/public/*") that
+// covers a route group. Exact patterns take precedence over prefixes, and
+// longer prefixes over shorter ones. For preflight requests the method of
+// Access-Control-Request-Method is matched. Can be passed multiple times for
+// different routes.
+//
+// Example:
+//
+//	r.Use(cors.New(
+//	    cors.WithAllowedOrigins("https://app.example.com"),
+//	    cors.WithAllowCredentials(true),
+//	    cors.WithRoutePolicy("/public/*", cors.WithAllowAllOrigins(true)),
+//	    cors.WithRoutePolicy("POST /webhooks/:id", cors.WithAllowedOrigins("https://hooks.example.com")),
+//	))
+func WithRoutePolicy(route string, opts ...Option) Option {
+	return func(cfg *config) {
+		if cfg.routePolicies == nil {
+			cfg.routePolicies = make(map[string][]Option)
+		}
+		cfg.routePolicies[route] = opts
+	}
+}
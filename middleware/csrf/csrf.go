@@ -0,0 +1,299 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package csrf
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"rivaas.dev/router"
+)
+
+// Errors passed to the error handler when a request is rejected.
+var (
+	// ErrTokenMissing is returned when an unsafe request carries no token,
+	// or the client has no stored token to compare it with.
+	ErrTokenMissing = errors.New("csrf: token missing")
+
+	// ErrTokenInvalid is returned when the submitted token does not match.
+	ErrTokenInvalid = errors.New("csrf: token invalid")
+)
+
+type contextKey struct{}
+
+// state is the CSRF state of a request, stored in its context.
+type state struct {
+	token    []byte
+	verified bool
+	store    TokenStore
+}
+
+// Option defines functional options for csrf middleware configuration.
+type Option func(*config)
+
+// config holds the configuration for the csrf middleware.
+type config struct {
+	// cookie configures the default, cookie-based token store
+	cookie cookieStore
+
+	// store keeps tokens; nil uses cookie
+	store TokenStore
+
+	// headerName is the request header carrying the token
+	headerName string
+
+	// formField is the form field carrying the token
+	formField string
+
+	// errorHandler is called when a request is rejected
+	errorHandler func(c *router.Context, err error)
+
+	// skipPaths are exact paths that are not checked
+	skipPaths map[string]bool
+
+	// skipPrefixes are path prefixes that are not checked
+	skipPrefixes []string
+
+	// skipFunc decides whether a request is not checked
+	skipFunc func(c *router.Context) bool
+}
+
+// defaultConfig returns the default configuration for csrf middleware.
+func defaultConfig() *config {
+	return &config{
+		cookie: cookieStore{
+			name:     "_csrf",
+			path:     "/",
+			maxAge:   12 * time.Hour,
+			secure:   true,
+			sameSite: http.SameSiteLaxMode,
+		},
+		headerName:   "X-CSRF-Token",
+		formField:    "_csrf",
+		errorHandler: defaultErrorHandler,
+		skipPaths:    make(map[string]bool),
+	}
+}
+
+// defaultErrorHandler responds with 403 Forbidden, or 500 Internal Server
+// Error if the token store failed.
+func defaultErrorHandler(c *router.Context, err error) {
+	if errors.Is(err, ErrTokenMissing) || errors.Is(err, ErrTokenInvalid) {
+		c.WriteErrorResponse(http.StatusForbidden, "invalid CSRF token")
+		return
+	}
+	c.WriteErrorResponse(http.StatusInternalServerError, "CSRF protection unavailable")
+}
+
+// New returns a middleware that protects against cross-site request forgery.
+//
+// Every client gets a random token. Unsafe requests (all but GET, HEAD,
+// OPTIONS, and TRACE) must send it back in the X-CSRF-Token header or the
+// _csrf form field, or they are rejected with 403 Forbidden. Handlers embed
+// the token in forms and pages with [Token].
+//
+// By default the token is kept in a SameSite=Lax, HttpOnly, Secure cookie
+// (double-submit cookie pattern). With [WithTokenStore], it is kept
+// server-side, e.g. in the session (synchronizer token pattern).
+//
+// Basic usage:
+//
+//	r := router.MustNew()
+//	r.Use(csrf.New())
+//
+//	r.GET("/profile", func(c *router.Context) {
+//	    c.HTML(http.StatusOK, tmpl, map[string]any{"csrf": csrf.Token(c)})
+//	})
+//
+// With method override, register csrf first so overrides require a valid token:
+//
+//	r.Use(csrf.New())
+//	r.Use(methodoverride.New(methodoverride.WithCSRFVerifier(csrf.Verified)))
+func New(opts ...Option) router.HandlerFunc {
+	cfg := defaultConfig()
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	store := cfg.store
+	if store == nil {
+		store = &cfg.cookie
+	}
+
+	return func(c *router.Context) {
+		if cfg.skip(c) {
+			c.Next()
+			return
+		}
+
+		stored, err := store.LoadToken(c)
+		if err != nil {
+			cfg.errorHandler(c, fmt.Errorf("csrf: load token: %w", err))
+			c.Abort()
+			return
+		}
+
+		// Issue a token to clients without a valid one
+		token, hasToken := decodeToken(stored)
+		if !hasToken {
+			token = newToken()
+			if err = store.SaveToken(c, encodeToken(token)); err != nil {
+				cfg.errorHandler(c, fmt.Errorf("csrf: save token: %w", err))
+				c.Abort()
+				return
+			}
+		}
+
+		st := &state{token: token, store: store}
+		ctx := context.WithValue(c.Request.Context(), contextKey{}, st)
+		c.Request = c.Request.WithContext(ctx)
+
+		if isSafeMethod(c.Request.Method) {
+			c.Next()
+			return
+		}
+
+		// A new token can't have been submitted yet
+		if !hasToken {
+			cfg.errorHandler(c, ErrTokenMissing)
+			c.Abort()
+			return
+		}
+		submitted := cfg.submittedToken(c.Request)
+		if submitted == "" {
+			cfg.errorHandler(c, ErrTokenMissing)
+			c.Abort()
+			return
+		}
+		if got, ok := unmaskToken(submitted); !ok || !tokensEqual(got, token) {
+			cfg.errorHandler(c, ErrTokenInvalid)
+			c.Abort()
+			return
+		}
+
+		st.verified = true
+		c.Next()
+	}
+}
+
+// skip reports whether the request of c is exempt from CSRF protection.
+func (cfg *config) skip(c *router.Context) bool {
+	path := c.Request.URL.Path
+	if cfg.skipPaths[path] {
+		return true
+	}
+	for _, prefix := range cfg.skipPrefixes {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+
+	return cfg.skipFunc != nil && cfg.skipFunc(c)
+}
+
+// submittedToken returns the token sent with r, from the header or, for
+// form submissions, the form field.
+func (cfg *config) submittedToken(r *http.Request) string {
+	if token := r.Header.Get(cfg.headerName); token != "" {
+		return token
+	}
+	if cfg.formField == "" {
+		return ""
+	}
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != "application/x-www-form-urlencoded" && mediaType != "multipart/form-data" {
+		return ""
+	}
+
+	return r.PostFormValue(cfg.formField)
+}
+
+// isSafeMethod reports whether method is safe as defined by RFC 9110 and
+// therefore not checked.
+func isSafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+		return true
+	default:
+		return false
+	}
+}
+
+// getState returns the CSRF state of the request of c, or nil if the
+// middleware did not run.
+func getState(c *router.Context) *state {
+	st, _ := c.Request.Context().Value(contextKey{}).(*state)
+	return st
+}
+
+// Token returns the CSRF token to embed in forms or pages, e.g. in a hidden
+// _csrf field or a meta tag read by JavaScript. The returned value is masked
+// differently on every call, so it can be embedded in compressed responses
+// safely. It returns an empty string if the middleware did not run.
+//
+// Example:
+//
+//	<form method="POST" action="/transfer">
+//	    <input type="hidden" name="_csrf" value="{{ .csrf }}">
+//	</form>
+func Token(c *router.Context) string {
+	st := getState(c)
+	if st == nil {
+		return ""
+	}
+
+	return maskToken(st.token)
+}
+
+// Verified reports whether the request of c is an unsafe request whose CSRF
+// token has been verified. It can be passed to
+// methodoverride.WithCSRFVerifier.
+func Verified(c *router.Context) bool {
+	st := getState(c)
+	return st != nil && st.verified
+}
+
+// Rotate replaces the CSRF token of the client with a new one and returns it,
+// masked like [Token]. Call it when the privileges of the client change, such
+// as on login or logout, so that a token obtained before can't be used after.
+//
+// Example:
+//
+//	r.POST("/login", func(c *router.Context) {
+//	    // ... authenticate ...
+//	    session.Renew(c)
+//	    if _, err := csrf.Rotate(c); err != nil {
+//	        c.WriteErrorResponse(http.StatusInternalServerError, "login failed")
+//	        return
+//	    }
+//	})
+func Rotate(c *router.Context) (string, error) {
+	st := getState(c)
+	if st == nil {
+		return "", errors.New("csrf: middleware not registered")
+	}
+	token := newToken()
+	if err := st.store.SaveToken(c, encodeToken(token)); err != nil {
+		return "", fmt.Errorf("csrf: save token: %w", err)
+	}
+	st.token = token
+
+	return maskToken(token), nil
+}
@@ -0,0 +1,329 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package csrf
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"rivaas.dev/router"
+)
+
+// writeToken responds with the masked token.
+func writeToken(c *router.Context) {
+	//nolint:errcheck // Test handler
+	c.String(http.StatusOK, Token(c))
+}
+
+// reportVerified responds with whether the request was verified.
+func reportVerified(c *router.Context) {
+	if Verified(c) {
+		//nolint:errcheck // Test handler
+		c.String(http.StatusOK, "verified")
+		return
+	}
+	//nolint:errcheck // Test handler
+	c.String(http.StatusOK, "not verified")
+}
+
+// fetchToken gets /form and returns the token cookie and the masked token.
+func fetchToken(t *testing.T, r http.Handler) (*http.Cookie, string) {
+	t.Helper()
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/form", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	cookies := w.Result().Cookies()
+	require.Len(t, cookies, 1)
+
+	return cookies[0], w.Body.String()
+}
+
+func TestCSRF_IssuesCookie(t *testing.T) {
+	t.Parallel()
+	r := router.MustNew()
+	r.Use(New())
+	r.GET("/form", writeToken)
+
+	cookie, token := fetchToken(t, r)
+
+	assert.Equal(t, "_csrf", cookie.Name)
+	assert.Equal(t, "/", cookie.Path)
+	assert.True(t, cookie.HttpOnly)
+	assert.True(t, cookie.Secure)
+	assert.Equal(t, http.SameSiteLaxMode, cookie.SameSite)
+	assert.Equal(t, 12*60*60, cookie.MaxAge)
+	assert.NotEmpty(t, token)
+	assert.NotEqual(t, cookie.Value, token, "the token is masked")
+
+	// Clients with a valid cookie keep it
+	req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/form", nil)
+	req.AddCookie(cookie)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Empty(t, w.Result().Cookies())
+	assert.NotEqual(t, token, w.Body.String(), "masks differ between calls")
+}
+
+func TestCSRF_DoubleSubmit(t *testing.T) {
+	t.Parallel()
+	r := router.MustNew()
+	r.Use(New())
+	r.GET("/form", writeToken)
+	r.POST("/submit", reportVerified)
+
+	cookie, token := fetchToken(t, r)
+
+	tests := []struct {
+		name       string
+		cookie     *http.Cookie
+		header     string
+		form       url.Values
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "masked token in header", cookie: cookie, header: token, wantStatus: http.StatusOK, wantBody: "verified"},
+		{name: "raw cookie value in header", cookie: cookie, header: cookie.Value, wantStatus: http.StatusOK, wantBody: "verified"},
+		{name: "token in form field", cookie: cookie, form: url.Values{"_csrf": {token}}, wantStatus: http.StatusOK, wantBody: "verified"},
+		{name: "no token", cookie: cookie, wantStatus: http.StatusForbidden},
+		{name: "no cookie", header: token, wantStatus: http.StatusForbidden},
+		{name: "wrong token", cookie: cookie, header: encodeToken(newToken()), wantStatus: http.StatusForbidden},
+		{name: "malformed token", cookie: cookie, header: "not-a-token", wantStatus: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var req *http.Request
+			if tt.form != nil {
+				req = httptest.NewRequestWithContext(t.Context(), http.MethodPost, "/submit", strings.NewReader(tt.form.Encode()))
+				req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			} else {
+				req = httptest.NewRequestWithContext(t.Context(), http.MethodPost, "/submit", nil)
+			}
+			if tt.cookie != nil {
+				req.AddCookie(tt.cookie)
+			}
+			if tt.header != "" {
+				req.Header.Set("X-CSRF-Token", tt.header)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantBody != "" {
+				assert.Equal(t, tt.wantBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestCSRF_Skip(t *testing.T) {
+	t.Parallel()
+	r := router.MustNew()
+	r.Use(New(
+		WithSkipPaths("/webhooks/github"),
+		WithSkipPrefix("/api/"),
+	))
+	r.POST("/submit", reportVerified)
+	r.POST("/webhooks/github", reportVerified)
+	r.POST("/api/orders", reportVerified)
+
+	for _, path := range []string{"/webhooks/github", "/api/orders"} {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequestWithContext(t.Context(), http.MethodPost, path, nil))
+		assert.Equal(t, http.StatusOK, w.Code, path)
+		assert.Equal(t, "not verified", w.Body.String(), path)
+	}
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequestWithContext(t.Context(), http.MethodPost, "/submit", nil))
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	r = router.MustNew()
+	r.Use(New(WithSkip(func(c *router.Context) bool {
+		return strings.HasPrefix(c.Request.Header.Get("Authorization"), "Bearer ")
+	})))
+	r.POST("/submit", reportVerified)
+
+	req := httptest.NewRequestWithContext(t.Context(), http.MethodPost, "/submit", nil)
+	req.Header.Set("Authorization", "Bearer abc")
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestCSRF_Options(t *testing.T) {
+	t.Parallel()
+	var gotErr error
+	r := router.MustNew()
+	r.Use(New(
+		WithCookieName("__Host-csrf"),
+		WithCookieMaxAge(0),
+		WithSameSite(http.SameSiteStrictMode),
+		WithInsecureCookie(),
+		WithHeader("X-XSRF-Token"),
+		WithErrorHandler(func(c *router.Context, err error) {
+			gotErr = err
+			c.WriteErrorResponse(http.StatusTeapot, "")
+		}),
+	))
+	r.GET("/form", writeToken)
+	r.POST("/submit", reportVerified)
+
+	cookie, token := fetchToken(t, r)
+	assert.Equal(t, "__Host-csrf", cookie.Name)
+	assert.Zero(t, cookie.MaxAge)
+	assert.False(t, cookie.Secure)
+	assert.Equal(t, http.SameSiteStrictMode, cookie.SameSite)
+
+	req := httptest.NewRequestWithContext(t.Context(), http.MethodPost, "/submit", nil)
+	req.AddCookie(cookie)
+	req.Header.Set("X-XSRF-Token", token)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	req = httptest.NewRequestWithContext(t.Context(), http.MethodPost, "/submit", nil)
+	req.AddCookie(cookie)
+	req.Header.Set("X-CSRF-Token", token)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusTeapot, w.Code)
+	require.ErrorIs(t, gotErr, ErrTokenMissing)
+}
+
+// memoryStore is a TokenStore keyed by a session cookie.
+type memoryStore struct {
+	mu     sync.Mutex
+	tokens map[string]string
+	err    error
+}
+
+func (s *memoryStore) LoadToken(c *router.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return "", s.err
+	}
+	cookie, err := c.Request.Cookie("session")
+	if err != nil {
+		return "", nil //nolint:nilerr // No session
+	}
+
+	return s.tokens[cookie.Value], nil
+}
+
+func (s *memoryStore) SaveToken(c *router.Context, token string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cookie, err := c.Request.Cookie("session")
+	if err != nil {
+		return errors.New("no session")
+	}
+	s.tokens[cookie.Value] = token
+
+	return nil
+}
+
+func TestCSRF_TokenStore(t *testing.T) {
+	t.Parallel()
+	store := &memoryStore{tokens: make(map[string]string)}
+	r := router.MustNew()
+	r.Use(New(WithTokenStore(store)))
+	r.GET("/form", writeToken)
+	r.POST("/submit", reportVerified)
+
+	session := &http.Cookie{Name: "session", Value: "s1"}
+
+	req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/form", nil)
+	req.AddCookie(session)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Result().Cookies(), "no token cookie with a token store")
+	token := w.Body.String()
+	require.Contains(t, store.tokens, "s1")
+
+	req = httptest.NewRequestWithContext(t.Context(), http.MethodPost, "/submit", nil)
+	req.AddCookie(session)
+	req.Header.Set("X-CSRF-Token", token)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, "verified", w.Body.String())
+
+	// The token is bound to the session
+	req = httptest.NewRequestWithContext(t.Context(), http.MethodPost, "/submit", nil)
+	req.AddCookie(&http.Cookie{Name: "session", Value: "s2"})
+	req.Header.Set("X-CSRF-Token", token)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	store.err = errors.New("store down")
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/form", nil))
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestRotate(t *testing.T) {
+	t.Parallel()
+	r := router.MustNew()
+	r.Use(New())
+	r.POST("/login", func(c *router.Context) {
+		token, err := Rotate(c)
+		require.NoError(t, err)
+		//nolint:errcheck // Test handler
+		c.String(http.StatusOK, token)
+	})
+	r.GET("/form", func(c *router.Context) {
+		//nolint:errcheck // Test handler
+		c.String(http.StatusOK, Token(c))
+	})
+
+	oldCookie, oldToken := fetchToken(t, r)
+	req := httptest.NewRequestWithContext(t.Context(), http.MethodPost, "/login", nil)
+	req.AddCookie(oldCookie)
+	req.Header.Set("X-CSRF-Token", oldToken)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	cookies := w.Result().Cookies()
+	require.Len(t, cookies, 1)
+	assert.NotEqual(t, oldCookie.Value, cookies[0].Value)
+	newToken, ok := unmaskToken(w.Body.String())
+	require.True(t, ok)
+	assert.Equal(t, cookies[0].Value, encodeToken(newToken))
+
+	_, err := Rotate(router.NewContext(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil)))
+	assert.Error(t, err)
+}
+
+func TestToken_WithoutMiddleware(t *testing.T) {
+	t.Parallel()
+	c := router.NewContext(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Empty(t, Token(c))
+	assert.False(t, Verified(c))
+}
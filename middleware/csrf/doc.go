@@ -0,0 +1,82 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package csrf provides middleware that protects cookie-authenticated
+// applications against cross-site request forgery (CSRF).
+//
+// Every client gets a random token. Unsafe requests (POST, PUT, PATCH,
+// DELETE, ...) must send the token back, in the X-CSRF-Token header or the
+// _csrf form field. A forged request from another site can't read the token
+// and is rejected with 403 Forbidden.
+//
+// # Basic Usage
+//
+//	import "rivaas.dev/middleware/csrf"
+//
+//	r := router.MustNew()
+//	r.Use(csrf.New())
+//
+// Embed the token in forms with [Token]:
+//
+//	r.GET("/settings", func(c *router.Context) {
+//	    c.HTML(http.StatusOK, tmpl, map[string]any{"csrf": csrf.Token(c)})
+//	})
+//
+//	<form method="POST" action="/settings">
+//	    <input type="hidden" name="_csrf" value="{{ .csrf }}">
+//	</form>
+//
+// JavaScript clients can read the token from a meta tag filled by [Token] and
+// send it in the X-CSRF-Token header.
+//
+// # Token Storage
+//
+// By default, the token is kept in a cookie that must match the submitted
+// token (double-submit cookie pattern). The cookie is SameSite=Lax, HttpOnly,
+// and Secure; use [WithInsecureCookie] for local development over HTTP.
+//
+// With [WithTokenStore], the token is kept server-side, usually in the user's
+// session (synchronizer token pattern). This binds the token to the session,
+// and resists cookie injection from sibling subdomains.
+//
+// # Rotation
+//
+// [Rotate] issues a new token. Call it when privileges change, such as on
+// login, together with renewing the session ID.
+//
+// # Exemptions
+//
+// [WithSkipPaths], [WithSkipPrefix], and [WithSkip] exempt requests that are
+// authenticated by other means, such as webhooks with signatures or APIs with
+// bearer tokens.
+//
+// # Method Override
+//
+// Register csrf before methodoverride, and let methodoverride only override
+// verified requests:
+//
+//	r.Use(csrf.New())
+//	r.Use(methodoverride.New(methodoverride.WithCSRFVerifier(csrf.Verified)))
+//
+// # Configuration Options
+//
+//   - [WithHeader]: Request header carrying the token (default: X-CSRF-Token)
+//   - [WithFormField]: Form field carrying the token (default: _csrf)
+//   - [WithCookieName], [WithCookiePath], [WithCookieDomain], [WithCookieMaxAge]: Token cookie
+//   - [WithSameSite]: SameSite attribute of the cookie (default: Lax)
+//   - [WithInsecureCookie]: Allow the cookie over plain HTTP
+//   - [WithTokenStore]: Keep tokens server-side
+//   - [WithErrorHandler]: Custom response for rejected requests
+//   - [WithSkipPaths], [WithSkipPrefix], [WithSkip]: Exempt requests
+package csrf
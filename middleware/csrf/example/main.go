@@ -0,0 +1,80 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package main demonstrates how to use the CSRF middleware
+// to protect form submissions against cross-site request forgery.
+package main
+
+import (
+	"html/template"
+	"log"
+	"net/http"
+
+	"rivaas.dev/middleware/csrf"
+	"rivaas.dev/middleware/methodoverride"
+	"rivaas.dev/router"
+)
+
+var page = template.Must(template.New("page").Parse(`<!DOCTYPE html>
+<html>
+<body>
+    <form method="POST" action="/notes">
+        <input type="hidden" name="_csrf" value="{{ . }}">
+        <input name="note" placeholder="Note">
+        <button type="submit">Add note</button>
+    </form>
+    <form method="POST" action="/notes?_method=DELETE">
+        <input type="hidden" name="_csrf" value="{{ . }}">
+        <button type="submit">Delete all notes</button>
+    </form>
+</body>
+</html>`))
+
+func main() {
+	r := router.MustNew()
+
+	// Plain HTTP for local development; drop WithInsecureCookie in production
+	r.Use(csrf.New(
+		csrf.WithInsecureCookie(),
+		csrf.WithSkipPaths("/webhook"),
+	))
+	r.Use(methodoverride.New(methodoverride.WithCSRFVerifier(csrf.Verified)))
+
+	r.GET("/", func(c *router.Context) {
+		c.Response.Header().Set("Content-Type", "text/html; charset=utf-8")
+		if err := page.Execute(c.Response, csrf.Token(c)); err != nil {
+			log.Printf("render: %v", err)
+		}
+	})
+
+	r.POST("/notes", func(c *router.Context) {
+		c.JSON(http.StatusCreated, map[string]string{
+			"note": c.Request.PostFormValue("note"),
+		})
+	})
+
+	r.DELETE("/notes", func(c *router.Context) {
+		c.JSON(http.StatusOK, map[string]string{"message": "All notes deleted"})
+	})
+
+	// Exempt from CSRF checks, e.g. authenticated by a signature instead
+	r.POST("/webhook", func(c *router.Context) {
+		c.JSON(http.StatusOK, map[string]string{"message": "Webhook received"})
+	})
+
+	log.Println("Server starting on http://localhost:8080")
+	log.Println("Open http://localhost:8080/ and submit the forms")
+	log.Println("Without a token: curl -X POST http://localhost:8080/notes (403)")
+	log.Fatal(http.ListenAndServe(":8080", r))
+}
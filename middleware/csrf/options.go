@@ -0,0 +1,191 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package csrf
+
+import (
+	"net/http"
+	"time"
+
+	"rivaas.dev/router"
+)
+
+// WithHeader sets the request header carrying the token.
+// Default: "X-CSRF-Token"
+//
+// Example:
+//
+//	csrf.New(csrf.WithHeader("X-XSRF-Token"))
+func WithHeader(name string) Option {
+	return func(cfg *config) {
+		cfg.headerName = name
+	}
+}
+
+// WithFormField sets the form field carrying the token in form submissions.
+// Set to an empty string to accept the header only.
+// Default: "_csrf"
+//
+// Example:
+//
+//	csrf.New(csrf.WithFormField("csrf_token"))
+func WithFormField(name string) Option {
+	return func(cfg *config) {
+		cfg.formField = name
+	}
+}
+
+// WithCookieName sets the name of the token cookie.
+// Default: "_csrf"
+//
+// Example:
+//
+//	csrf.New(csrf.WithCookieName("__Host-csrf"))
+func WithCookieName(name string) Option {
+	return func(cfg *config) {
+		cfg.cookie.name = name
+	}
+}
+
+// WithCookiePath sets the path of the token cookie.
+// Default: "/"
+//
+// Example:
+//
+//	csrf.New(csrf.WithCookiePath("/app"))
+func WithCookiePath(path string) Option {
+	return func(cfg *config) {
+		cfg.cookie.path = path
+	}
+}
+
+// WithCookieDomain sets the domain of the token cookie. By default the
+// cookie is only sent to the host that set it.
+//
+// Example:
+//
+//	csrf.New(csrf.WithCookieDomain("example.com"))
+func WithCookieDomain(domain string) Option {
+	return func(cfg *config) {
+		cfg.cookie.domain = domain
+	}
+}
+
+// WithCookieMaxAge sets how long the token cookie is kept. A value of 0
+// makes it a session cookie.
+// Default: 12 hours
+//
+// Example:
+//
+//	csrf.New(csrf.WithCookieMaxAge(24 * time.Hour))
+func WithCookieMaxAge(maxAge time.Duration) Option {
+	return func(cfg *config) {
+		cfg.cookie.maxAge = max(maxAge, 0)
+	}
+}
+
+// WithSameSite sets the SameSite attribute of the token cookie.
+// Default: http.SameSiteLaxMode
+//
+// Example:
+//
+//	csrf.New(csrf.WithSameSite(http.SameSiteStrictMode))
+func WithSameSite(mode http.SameSite) Option {
+	return func(cfg *config) {
+		cfg.cookie.sameSite = mode
+	}
+}
+
+// WithInsecureCookie drops the Secure attribute of the token cookie, so that
+// it is sent over plain HTTP. Use it for local development only.
+//
+// Example:
+//
+//	csrf.New(csrf.WithInsecureCookie())
+func WithInsecureCookie() Option {
+	return func(cfg *config) {
+		cfg.cookie.secure = false
+	}
+}
+
+// WithTokenStore keeps tokens in store instead of a cookie. A store backed by
+// the user's session implements the synchronizer token pattern, which, unlike
+// the default double-submit cookie, also resists cookie injection from
+// sibling subdomains. The session middleware must run before csrf.
+//
+// Example:
+//
+//	r.Use(session.New(sessionStore))
+//	r.Use(csrf.New(csrf.WithTokenStore(session.CSRFStore())))
+func WithTokenStore(store TokenStore) Option {
+	return func(cfg *config) {
+		cfg.store = store
+	}
+}
+
+// WithErrorHandler sets the function that responds to rejected requests. The
+// error is [ErrTokenMissing], [ErrTokenInvalid], or an error of the token
+// store. The middleware aborts the chain after the handler returns.
+// Default: 403 Forbidden for missing or invalid tokens, 500 for store errors
+//
+// Example:
+//
+//	csrf.New(csrf.WithErrorHandler(func(c *router.Context, err error) {
+//	    c.JSON(http.StatusForbidden, map[string]string{"error": "invalid CSRF token"})
+//	}))
+func WithErrorHandler(handler func(c *router.Context, err error)) Option {
+	return func(cfg *config) {
+		cfg.errorHandler = handler
+	}
+}
+
+// WithSkipPaths sets exact paths that are not protected, such as webhook
+// endpoints authenticated by other means.
+//
+// Example:
+//
+//	csrf.New(csrf.WithSkipPaths("/webhooks/stripe"))
+func WithSkipPaths(paths ...string) Option {
+	return func(cfg *config) {
+		for _, path := range paths {
+			cfg.skipPaths[path] = true
+		}
+	}
+}
+
+// WithSkipPrefix sets path prefixes that are not protected, such as an API
+// authenticated with bearer tokens instead of cookies.
+//
+// Example:
+//
+//	csrf.New(csrf.WithSkipPrefix("/api/"))
+func WithSkipPrefix(prefixes ...string) Option {
+	return func(cfg *config) {
+		cfg.skipPrefixes = append(cfg.skipPrefixes, prefixes...)
+	}
+}
+
+// WithSkip sets a function that decides whether a request is not protected.
+// Return true to skip the request.
+//
+// Example:
+//
+//	csrf.New(csrf.WithSkip(func(c *router.Context) bool {
+//	    return strings.HasPrefix(c.Request.Header.Get("Authorization"), "Bearer ")
+//	}))
+func WithSkip(fn func(c *router.Context) bool) Option {
+	return func(cfg *config) {
+		cfg.skipFunc = fn
+	}
+}
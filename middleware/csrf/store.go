@@ -0,0 +1,81 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package csrf
+
+import (
+	"net/http"
+	"time"
+
+	"rivaas.dev/router"
+)
+
+// TokenStore keeps the CSRF token of a client between requests.
+//
+// By default, the token is kept in a cookie and must be submitted again with
+// each unsafe request (double-submit cookie pattern). A store that keeps the
+// token server-side, in the user's session, implements the synchronizer
+// token pattern; see [WithTokenStore].
+type TokenStore interface {
+	// LoadToken returns the token of the client of c, or an empty string if
+	// it has none.
+	LoadToken(c *router.Context) (string, error)
+
+	// SaveToken stores token for the client of c.
+	SaveToken(c *router.Context, token string) error
+}
+
+// cookieStore keeps tokens in a cookie.
+type cookieStore struct {
+	name     string
+	path     string
+	domain   string
+	maxAge   time.Duration
+	secure   bool
+	sameSite http.SameSite
+}
+
+// LoadToken returns the value of the token cookie.
+func (s *cookieStore) LoadToken(c *router.Context) (string, error) {
+	cookie, err := c.Request.Cookie(s.name)
+	if err != nil {
+		// http.ErrNoCookie: no token yet
+		return "", nil //nolint:nilerr // A missing cookie is not an error
+	}
+
+	return cookie.Value, nil
+}
+
+// SaveToken sets the token cookie on the response.
+func (s *cookieStore) SaveToken(c *router.Context, token string) error {
+	cookie := &http.Cookie{
+		Name:     s.name,
+		Value:    token,
+		Path:     s.path,
+		Domain:   s.domain,
+		Secure:   s.secure,
+		HttpOnly: true,
+		SameSite: s.sameSite,
+	}
+	if s.maxAge > 0 {
+		cookie.MaxAge = int(s.maxAge.Seconds())
+		cookie.Expires = time.Now().Add(s.maxAge)
+	}
+	http.SetCookie(c.Response, cookie)
+
+	// Responses that set a token must not be shared by caches
+	c.Response.Header().Add("Vary", "Cookie")
+
+	return nil
+}
@@ -0,0 +1,87 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package csrf
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+)
+
+// tokenLength is the length of raw tokens in bytes.
+const tokenLength = 32
+
+// newToken returns a new random token.
+func newToken() []byte {
+	token := make([]byte, tokenLength)
+	// crypto/rand.Read never returns an error and always fills the buffer
+	rand.Read(token) //nolint:errcheck // Documented to never fail
+
+	return token
+}
+
+// encodeToken returns the string form of a raw token, as stored in cookies
+// and token stores.
+func encodeToken(token []byte) string {
+	return base64.RawURLEncoding.EncodeToString(token)
+}
+
+// decodeToken returns the raw token of its string form. It reports false if
+// s is not a valid token.
+func decodeToken(s string) ([]byte, bool) {
+	token, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || len(token) != tokenLength {
+		return nil, false
+	}
+
+	return token, true
+}
+
+// maskToken returns token XOR-ed with a random one-time pad, prefixed by the
+// pad. The masked form differs on every call, which protects tokens embedded
+// in compressed responses against BREACH-style attacks.
+func maskToken(token []byte) string {
+	masked := make([]byte, 2*tokenLength)
+	pad := masked[:tokenLength]
+	rand.Read(pad) //nolint:errcheck // Documented to never fail
+	subtle.XORBytes(masked[tokenLength:], token, pad)
+
+	return base64.RawURLEncoding.EncodeToString(masked)
+}
+
+// unmaskToken returns the raw token of a submitted value, which is either
+// masked (as returned by [Token]) or raw (as read from the cookie by
+// JavaScript). It reports false if s is neither.
+func unmaskToken(s string) ([]byte, bool) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, false
+	}
+	switch len(b) {
+	case tokenLength:
+		return b, true
+	case 2 * tokenLength:
+		token := make([]byte, tokenLength)
+		subtle.XORBytes(token, b[tokenLength:], b[:tokenLength])
+		return token, true
+	default:
+		return nil, false
+	}
+}
+
+// tokensEqual reports whether a and b are equal, in constant time.
+func tokensEqual(a, b []byte) bool {
+	return subtle.ConstantTimeCompare(a, b) == 1
+}
@@ -0,0 +1,58 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package csrf
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenEncoding(t *testing.T) {
+	t.Parallel()
+	token := newToken()
+	require.Len(t, token, tokenLength)
+
+	decoded, ok := decodeToken(encodeToken(token))
+	require.True(t, ok)
+	assert.Equal(t, token, decoded)
+
+	_, ok = decodeToken("")
+	assert.False(t, ok)
+	_, ok = decodeToken("c2hvcnQ")
+	assert.False(t, ok, "too short")
+	_, ok = decodeToken("!!!")
+	assert.False(t, ok)
+}
+
+func TestTokenMasking(t *testing.T) {
+	t.Parallel()
+	token := newToken()
+	a, b := maskToken(token), maskToken(token)
+	assert.NotEqual(t, a, b)
+
+	for _, masked := range []string{a, b, encodeToken(token)} {
+		got, ok := unmaskToken(masked)
+		require.True(t, ok)
+		assert.True(t, tokensEqual(token, got))
+	}
+
+	_, ok := unmaskToken("c2hvcnQ")
+	assert.False(t, ok)
+	assert.False(t, tokensEqual(token, newToken()))
+}
@@ -0,0 +1,64 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package etag provides middleware that adds ETags to responses and answers
+// conditional requests with 304 Not Modified.
+//
+// The middleware buffers the body of successful GET and HEAD responses and
+// uses its SHA-256 hash as the ETag. When a client sends the ETag back in
+// If-None-Match and the body hasn't changed, it gets 304 Not Modified without
+// a body.
+//
+// # Basic Usage
+//
+//	import "rivaas.dev/middleware/etag"
+//
+//	r := router.MustNew()
+//	r.Use(etag.New())
+//
+// # Strong and Weak ETags
+//
+// ETags are strong by default, promising byte-identical bodies. [WithWeak]
+// generates weak ETags (W/"..."). If-None-Match always uses weak comparison,
+// so both kinds produce 304 responses.
+//
+// # Handler ETags
+//
+// If the handler sets an ETag header itself, for example from a version
+// column, the body is not buffered or hashed. The middleware only compares
+// the handler's ETag with If-None-Match.
+//
+// # Last-Modified
+//
+// If the handler sets a Last-Modified header, requests with an
+// If-Modified-Since date that is not earlier get 304 Not Modified without
+// buffering the body. As RFC 9110 requires, If-Modified-Since is ignored
+// when the request has If-None-Match.
+//
+// # Large and Streaming Responses
+//
+// Bodies larger than [WithMaxSize] (1 MiB by default) and responses that are
+// flushed are sent as they are, without an ETag, so streaming keeps working.
+//
+// # Compression
+//
+// Register etag before the compression middleware:
+//
+//	r.Use(etag.New())
+//	r.Use(compression.New())
+//
+// The ETag is then computed from the compressed body, so each encoding gets
+// its own ETag. Strong ETags set by handlers get the encoding appended by the
+// compression middleware.
+package etag
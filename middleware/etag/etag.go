@@ -0,0 +1,130 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etag
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"rivaas.dev/router"
+)
+
+// Option defines functional options for etag middleware configuration.
+type Option func(*config)
+
+// config holds the configuration for the etag middleware.
+type config struct {
+	// weak generates weak ETags instead of strong ones
+	weak bool
+
+	// maxSize is the largest body that is buffered to compute an ETag
+	maxSize int
+
+	// skipPaths are paths that get no ETags
+	skipPaths map[string]bool
+}
+
+// defaultConfig returns the default configuration for etag middleware.
+func defaultConfig() *config {
+	return &config{
+		maxSize:   1 << 20, // 1 MiB
+		skipPaths: make(map[string]bool),
+	}
+}
+
+// New returns a middleware that adds ETags to responses and answers
+// conditional requests with 304 Not Modified.
+//
+// The body of successful GET and HEAD responses is buffered and hashed
+// (SHA-256) into an ETag. If the request's If-None-Match header matches the
+// ETag, the body is dropped and 304 Not Modified is sent instead, saving the
+// bandwidth. Responses with an ETag set by the handler are not buffered;
+// only If-None-Match is checked. Bodies larger than the maximum size (1 MiB
+// by default) and flushed responses are streamed without an ETag.
+//
+// Requests without If-None-Match but with If-Modified-Since get 304 Not
+// Modified if the handler set a Last-Modified header that is not later.
+//
+// Register etag before compression, so that ETags are computed from the
+// compressed body and differ per encoding:
+//
+//	r := router.MustNew()
+//	r.Use(etag.New())
+//	r.Use(compression.New())
+func New(opts ...Option) router.HandlerFunc {
+	cfg := defaultConfig()
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return func(c *router.Context) {
+		method := c.Request.Method
+		if (method != http.MethodGet && method != http.MethodHead) || cfg.skipPaths[c.Request.URL.Path] {
+			c.Next()
+			return
+		}
+
+		original := c.Response
+		w := &etagWriter{
+			ResponseWriter: original,
+			cfg:            cfg,
+			ifNoneMatch:    c.Request.Header.Get("If-None-Match"),
+			ifModSince:     c.Request.Header.Get("If-Modified-Since"),
+		}
+		c.Response = w
+
+		c.Next()
+
+		w.finish()
+		c.Response = original
+	}
+}
+
+// matches reports whether the If-None-Match header value matches etag,
+// using weak comparison as required by RFC 9110.
+func matches(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" || etag == "" {
+		return false
+	}
+	etag = strings.TrimPrefix(etag, "W/")
+	for tag := range strings.SplitSeq(ifNoneMatch, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || strings.TrimPrefix(tag, "W/") == etag {
+			return true
+		}
+	}
+
+	return false
+}
+
+// notModifiedSince reports whether a resource last modified at lastModified
+// is unchanged since the If-Modified-Since header value. Both are HTTP dates;
+// invalid dates never match, as required by RFC 9110.
+func notModifiedSince(ifModifiedSince, lastModified string) bool {
+	if ifModifiedSince == "" || lastModified == "" {
+		return false
+	}
+	since, err := http.ParseTime(ifModifiedSince)
+	if err != nil {
+		return false
+	}
+	modified, err := http.ParseTime(lastModified)
+	if err != nil {
+		return false
+	}
+
+	return !modified.Truncate(time.Second).After(since)
+}
@@ -0,0 +1,275 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package etag
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"rivaas.dev/router"
+)
+
+const (
+	body         = "Hello, World!"
+	lastModified = "Wed, 01 Jan 2025 12:00:00 GMT"
+)
+
+// writeBody responds with body.
+func writeBody(c *router.Context) {
+	//nolint:errcheck // Test handler
+	c.String(http.StatusOK, body)
+}
+
+// writeLarge responds with 1000 bytes in 10 writes.
+func writeLarge(c *router.Context) {
+	for range 10 {
+		//nolint:errcheck // Test handler
+		c.Response.Write([]byte(strings.Repeat("x", 100)))
+	}
+}
+
+func do(t *testing.T, r http.Handler, method, path, ifNoneMatch string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(method, path, nil)
+	if ifNoneMatch != "" {
+		req.Header.Set("If-None-Match", ifNoneMatch)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	return w
+}
+
+func TestETag_Strong(t *testing.T) {
+	t.Parallel()
+	r := router.MustNew()
+	r.Use(New())
+	r.GET("/hello", writeBody)
+
+	w := do(t, r, http.MethodGet, "/hello", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, body, w.Body.String())
+	etag := w.Header().Get("ETag")
+	assert.Equal(t, router.StrongETagFromString(body).String(), etag)
+
+	w = do(t, r, http.MethodGet, "/hello", etag)
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Empty(t, w.Body.String())
+	assert.Equal(t, etag, w.Header().Get("ETag"))
+	assert.Empty(t, w.Header().Get("Content-Length"))
+
+	w = do(t, r, http.MethodGet, "/hello", `"other"`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, body, w.Body.String())
+}
+
+func TestETag_Weak(t *testing.T) {
+	t.Parallel()
+	r := router.MustNew()
+	r.Use(New(WithWeak()))
+	r.GET("/hello", writeBody)
+
+	w := do(t, r, http.MethodGet, "/hello", "")
+	etag := w.Header().Get("ETag")
+	assert.True(t, strings.HasPrefix(etag, `W/"`), etag)
+
+	// If-None-Match uses weak comparison
+	w = do(t, r, http.MethodGet, "/hello", strings.TrimPrefix(etag, "W/"))
+	assert.Equal(t, http.StatusNotModified, w.Code)
+}
+
+func TestETag_IfNoneMatchList(t *testing.T) {
+	t.Parallel()
+	r := router.MustNew()
+	r.Use(New())
+	r.GET("/hello", writeBody)
+
+	etag := do(t, r, http.MethodGet, "/hello", "").Header().Get("ETag")
+
+	assert.Equal(t, http.StatusNotModified, do(t, r, http.MethodGet, "/hello", `"a", `+etag+`, "b"`).Code)
+	assert.Equal(t, http.StatusNotModified, do(t, r, http.MethodGet, "/hello", "*").Code)
+}
+
+func TestETag_Head(t *testing.T) {
+	t.Parallel()
+	r := router.MustNew()
+	r.Use(New())
+	r.HEAD("/hello", func(c *router.Context) {
+		//nolint:errcheck // Test handler
+		c.String(http.StatusOK, body)
+	})
+
+	w := do(t, r, http.MethodHead, "/hello", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.NotEmpty(t, w.Header().Get("ETag"))
+}
+
+func TestETag_HandlerETag(t *testing.T) {
+	t.Parallel()
+	r := router.MustNew()
+	r.Use(New())
+	r.GET("/tagged", func(c *router.Context) {
+		c.Response.Header().Set("ETag", `"v1"`)
+		//nolint:errcheck // Test handler
+		c.String(http.StatusOK, body)
+	})
+
+	w := do(t, r, http.MethodGet, "/tagged", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `"v1"`, w.Header().Get("ETag"), "the handler's ETag is kept")
+	assert.Equal(t, body, w.Body.String())
+
+	w = do(t, r, http.MethodGet, "/tagged", `"v1"`)
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Empty(t, w.Body.String())
+}
+
+func TestETag_IfModifiedSince(t *testing.T) {
+	t.Parallel()
+	r := router.MustNew()
+	r.Use(New())
+	r.GET("/hello", writeBody)
+	r.GET("/dated", func(c *router.Context) {
+		c.Response.Header().Set("Last-Modified", lastModified)
+		//nolint:errcheck // Test handler
+		c.String(http.StatusOK, body)
+	})
+
+	tests := []struct {
+		name        string
+		path        string
+		since       string
+		ifNoneMatch bool
+		want        int
+	}{
+		{name: "same date", path: "/dated", since: lastModified, want: http.StatusNotModified},
+		{name: "later date", path: "/dated", since: "Thu, 02 Jan 2025 12:00:00 GMT", want: http.StatusNotModified},
+		{name: "earlier date", path: "/dated", since: "Tue, 31 Dec 2024 12:00:00 GMT", want: http.StatusOK},
+		{name: "invalid date", path: "/dated", since: "yesterday", want: http.StatusOK},
+		{name: "no Last-Modified", path: "/hello", since: lastModified, want: http.StatusOK},
+		{name: "If-None-Match takes precedence", path: "/dated", since: lastModified, ifNoneMatch: true, want: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			req.Header.Set("If-Modified-Since", tt.since)
+			if tt.ifNoneMatch {
+				req.Header.Set("If-None-Match", `"other"`)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.want, w.Code)
+			if tt.want == http.StatusNotModified {
+				assert.Empty(t, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestETag_Skipped(t *testing.T) {
+	t.Parallel()
+	r := router.MustNew()
+	r.Use(New(WithSkipPaths("/hello")))
+	r.GET("/hello", writeBody)
+	r.POST("/hello", writeBody)
+	r.GET("/missing", func(c *router.Context) {
+		c.WriteErrorResponse(http.StatusNotFound, "not found")
+	})
+	r.GET("/empty", func(c *router.Context) {
+		c.NoContent()
+	})
+
+	tests := []struct {
+		name, method, path string
+		status             int
+	}{
+		{"skipped path", http.MethodGet, "/hello", http.StatusOK},
+		{"unsafe method", http.MethodPost, "/hello", http.StatusOK},
+		{"error status", http.MethodGet, "/missing", http.StatusNotFound},
+		{"empty body", http.MethodGet, "/empty", http.StatusNoContent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			w := do(t, r, tt.method, tt.path, "*")
+			assert.Equal(t, tt.status, w.Code)
+			assert.Empty(t, w.Header().Get("ETag"))
+		})
+	}
+}
+
+func TestETag_MaxSize(t *testing.T) {
+	t.Parallel()
+	r := router.MustNew()
+	r.Use(New(WithMaxSize(500)))
+	r.GET("/large", writeLarge)
+
+	w := do(t, r, http.MethodGet, "/large", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, 1000, w.Body.Len(), "the whole body is streamed")
+	assert.Empty(t, w.Header().Get("ETag"))
+
+	r = router.MustNew()
+	r.Use(New())
+	r.GET("/large", writeLarge)
+	w = do(t, r, http.MethodGet, "/large", "")
+	assert.Equal(t, 1000, w.Body.Len())
+	assert.NotEmpty(t, w.Header().Get("ETag"))
+}
+
+func TestETag_Flush(t *testing.T) {
+	t.Parallel()
+	r := router.MustNew()
+	r.Use(New())
+	r.GET("/stream", func(c *router.Context) {
+		//nolint:errcheck // Test handler
+		c.Response.Write([]byte("event 1\n"))
+		c.Response.(http.Flusher).Flush()
+		//nolint:errcheck // Test handler
+		c.Response.Write([]byte("event 2\n"))
+	})
+
+	w := do(t, r, http.MethodGet, "/stream", "*")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "event 1\nevent 2\n", w.Body.String())
+	assert.Empty(t, w.Header().Get("ETag"))
+	assert.True(t, w.Flushed)
+}
+
+func TestETagWriter_Written(t *testing.T) {
+	t.Parallel()
+	rec := httptest.NewRecorder()
+	w := &etagWriter{ResponseWriter: rec, cfg: defaultConfig()}
+
+	assert.False(t, w.Written())
+	//nolint:errcheck // Test write
+	w.Write([]byte(body))
+	assert.True(t, w.Written(), "the handler has written")
+	assert.Empty(t, rec.Body.String(), "the body is buffered")
+	assert.Equal(t, rec, w.Unwrap())
+
+	w.finish()
+	assert.Equal(t, body, rec.Body.String())
+}
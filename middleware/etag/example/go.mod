@@ -0,0 +1,24 @@
+module example-etag
+
+go 1.25.0
+
+require (
+	rivaas.dev/middleware/compression v0.0.0
+	rivaas.dev/middleware/etag v0.0.0
+	rivaas.dev/router v0.15.0
+)
+
+require (
+	github.com/andybalholm/brotli v1.2.0 // indirect
+	github.com/klauspost/compress v1.18.4 // indirect
+	github.com/kr/text v0.2.0 // indirect
+	golang.org/x/net v0.52.0 // indirect
+	golang.org/x/text v0.35.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace (
+	rivaas.dev/middleware/compression => ../../compression
+	rivaas.dev/middleware/etag => ..
+	rivaas.dev/router => ../../../router
+)
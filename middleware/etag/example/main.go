@@ -0,0 +1,66 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package main demonstrates how to use the etag middleware
+// together with compression.
+package main
+
+import (
+	"log"
+	"net/http"
+
+	"rivaas.dev/middleware/compression"
+	"rivaas.dev/middleware/etag"
+	"rivaas.dev/router"
+)
+
+type product struct {
+	ID    int     `json:"id"`
+	Name  string  `json:"name"`
+	Price float64 `json:"price"`
+}
+
+var products = []product{
+	{ID: 1, Name: "Keyboard", Price: 49.90},
+	{ID: 2, Name: "Mouse", Price: 19.90},
+	{ID: 3, Name: "Monitor", Price: 199.00},
+}
+
+func main() {
+	r := router.MustNew()
+
+	// etag first, so ETags are computed from the compressed body
+	r.Use(etag.New())
+	r.Use(compression.New(compression.WithMinSize(0)))
+
+	r.GET("/products", func(c *router.Context) {
+		if err := c.JSON(http.StatusOK, products); err != nil {
+			log.Printf("write response: %v", err)
+		}
+	})
+
+	// The handler knows the version, so the body isn't hashed
+	r.GET("/version", func(c *router.Context) {
+		c.Response.Header().Set("ETag", `"v1.2.0"`)
+		if err := c.String(http.StatusOK, "v1.2.0"); err != nil {
+			log.Printf("write response: %v", err)
+		}
+	})
+
+	log.Println("Server starting on :8080")
+	log.Println("  curl -i http://localhost:8080/products")
+	log.Println(`  curl -i -H 'If-None-Match: "<etag>"' http://localhost:8080/products`)
+	log.Println("  curl -i -H 'Accept-Encoding: gzip' http://localhost:8080/products")
+	log.Fatal(http.ListenAndServe(":8080", r))
+}
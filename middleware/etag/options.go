@@ -0,0 +1,55 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etag
+
+// WithWeak generates weak ETags (W/"..."). Weak ETags promise only that
+// responses are equivalent, not byte-identical, so caches won't use them for
+// range requests.
+//
+// Example:
+//
+//	etag.New(etag.WithWeak())
+func WithWeak() Option {
+	return func(cfg *config) {
+		cfg.weak = true
+	}
+}
+
+// WithMaxSize sets the largest body, in bytes, that is buffered to compute
+// an ETag. Larger bodies are streamed without an ETag.
+// Default: 1 MiB
+//
+// Example:
+//
+//	etag.New(etag.WithMaxSize(256 << 10))
+func WithMaxSize(bytes int) Option {
+	return func(cfg *config) {
+		cfg.maxSize = bytes
+	}
+}
+
+// WithSkipPaths sets paths that get no ETags, such as endpoints whose
+// responses change on every request.
+//
+// Example:
+//
+//	etag.New(etag.WithSkipPaths("/events", "/metrics"))
+func WithSkipPaths(paths ...string) Option {
+	return func(cfg *config) {
+		for _, path := range paths {
+			cfg.skipPaths[path] = true
+		}
+	}
+}
@@ -0,0 +1,182 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etag
+
+import (
+	"bufio"
+	"bytes"
+	"net"
+	"net/http"
+
+	"rivaas.dev/router"
+)
+
+// writerMode is what an etagWriter does with the response.
+type writerMode int
+
+const (
+	modeUndecided   writerMode = iota // Nothing written yet
+	modeBuffering                     // Buffering the body to compute an ETag
+	modePassthrough                   // Forwarding the response unchanged
+	modeNotModified                   // Sent 304, dropping the body
+)
+
+// etagWriter buffers the response body to compute its ETag. It forwards the
+// optional interfaces the router and other middleware use.
+type etagWriter struct {
+	http.ResponseWriter
+	cfg         *config
+	ifNoneMatch string
+	ifModSince  string
+
+	mode        writerMode
+	status      int
+	wroteHeader bool // WriteHeader was called by the handler
+	buf         bytes.Buffer
+}
+
+func (w *etagWriter) WriteHeader(code int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	w.status = code
+	w.decide()
+}
+
+func (w *etagWriter) Write(p []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	switch w.mode {
+	case modeNotModified:
+		return len(p), nil
+	case modeBuffering:
+		if w.buf.Len()+len(p) <= w.cfg.maxSize {
+			return w.buf.Write(p)
+		}
+		// Too large to buffer: stream it without an ETag
+		if err := w.passthrough(); err != nil {
+			return 0, err
+		}
+	}
+
+	return w.ResponseWriter.Write(p)
+}
+
+// decide chooses the mode once the status code is known.
+func (w *etagWriter) decide() {
+	if w.status != http.StatusOK {
+		w.mode = modePassthrough
+		w.ResponseWriter.WriteHeader(w.status)
+		return
+	}
+
+	// If-Modified-Since is only evaluated without If-None-Match (RFC 9110)
+	if w.ifNoneMatch == "" && notModifiedSince(w.ifModSince, w.Header().Get("Last-Modified")) {
+		w.notModified()
+		return
+	}
+
+	// The handler computed its own ETag: no need to buffer
+	if etag := w.Header().Get("ETag"); etag != "" {
+		if matches(w.ifNoneMatch, etag) {
+			w.notModified()
+			return
+		}
+		w.mode = modePassthrough
+		w.ResponseWriter.WriteHeader(w.status)
+		return
+	}
+
+	w.mode = modeBuffering
+}
+
+// passthrough sends the status and the buffered body, and stops buffering.
+func (w *etagWriter) passthrough() error {
+	w.mode = modePassthrough
+	w.ResponseWriter.WriteHeader(w.status)
+	if w.buf.Len() == 0 {
+		return nil
+	}
+	_, err := w.ResponseWriter.Write(w.buf.Bytes())
+	w.buf = bytes.Buffer{}
+
+	return err
+}
+
+// notModified sends 304 Not Modified and drops the body.
+func (w *etagWriter) notModified() {
+	w.mode = modeNotModified
+	w.Header().Del("Content-Length")
+	w.ResponseWriter.WriteHeader(http.StatusNotModified)
+}
+
+// finish computes the ETag of the buffered body and sends the response, or
+// 304 Not Modified if the client has it already.
+func (w *etagWriter) finish() {
+	if w.mode != modeBuffering {
+		return
+	}
+	if w.buf.Len() == 0 {
+		_ = w.passthrough() //nolint:errcheck // Nothing to write
+		return
+	}
+
+	tag := router.StrongETagFromBytes(w.buf.Bytes())
+	tag.Weak = w.cfg.weak
+	etag := tag.String()
+	w.Header().Set("ETag", etag)
+	if matches(w.ifNoneMatch, etag) {
+		w.notModified()
+		return
+	}
+	_ = w.passthrough() //nolint:errcheck // The client went away
+}
+
+// Written implements router.WrittenChecker.
+func (w *etagWriter) Written() bool {
+	return w.wroteHeader
+}
+
+// Hijack implements http.Hijacker.
+func (w *etagWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if hijacker, ok := w.ResponseWriter.(http.Hijacker); ok {
+		w.mode = modePassthrough
+		return hijacker.Hijack()
+	}
+
+	return nil, nil, router.ErrResponseWriterNotHijacker
+}
+
+// Flush implements http.Flusher. Flushing means the handler is streaming, so
+// the response is sent without an ETag.
+func (w *etagWriter) Flush() {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.mode == modeBuffering {
+		_ = w.passthrough() //nolint:errcheck // Reported by the next Write
+	}
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap returns the underlying writer for http.ResponseController.
+func (w *etagWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
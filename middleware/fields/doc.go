@@ -0,0 +1,62 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package fields provides middleware that trims JSON responses to the
+// fields a client asks for, so clients get smaller payloads without
+// per-handler logic.
+//
+// # Basic Usage
+//
+//	import "rivaas.dev/middleware/fields"
+//
+//	r := router.MustNew()
+//	r.Use(fields.New())
+//
+// A client then selects fields in the query string:
+//
+//	GET /users/1?fields=id,name,address.city
+//
+//	{"id":1,"name":"Ada","address":{"city":"London"}}
+//
+// # Field Syntax
+//
+// Fields are separated by commas, and nested fields by dots. A field of an
+// array applies to each element, so "roles.name" keeps the names of all
+// roles. Fields that don't exist are ignored, and members keep the order
+// the handler wrote them in.
+//
+// # Selectors
+//
+// [WithSelector] lets another query parameter select part of the response
+// before the fields filter applies. [Pointer] implements JSON Pointers
+// (RFC 6901); other query languages such as JMESPath can be plugged in as a
+// [SelectorFunc]:
+//
+//	r.Use(fields.New(fields.WithSelector("pointer", fields.Pointer)))
+//
+//	// GET /orders?pointer=/data&fields=id,total
+//
+// # Which Responses Are Filtered
+//
+// Only 2xx responses with a JSON content type (application/json or
+// +json) are filtered, and only when the request asks for it. Bodies larger
+// than [WithMaxSize] and streamed responses are sent unfiltered. Filtering
+// removes an ETag set by the handler, because it no longer describes the
+// body.
+//
+// # Middleware Order
+//
+// Register fields after the compression and etag middleware, so that it
+// sees the uncompressed JSON and ETags are computed from the trimmed body.
+package fields
@@ -0,0 +1,60 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package main demonstrates how to use the fields middleware
+// to trim JSON responses.
+package main
+
+import (
+	"log"
+	"net/http"
+
+	"rivaas.dev/middleware/fields"
+	"rivaas.dev/router"
+)
+
+type address struct {
+	Street string `json:"street"`
+	City   string `json:"city"`
+}
+
+type user struct {
+	ID      int      `json:"id"`
+	Name    string   `json:"name"`
+	Email   string   `json:"email"`
+	Address address  `json:"address"`
+	Roles   []string `json:"roles"`
+}
+
+var users = []user{
+	{ID: 1, Name: "Ada", Email: "ada@example.com", Address: address{Street: "1 Main St", City: "London"}, Roles: []string{"admin"}},
+	{ID: 2, Name: "Alan", Email: "alan@example.com", Address: address{Street: "2 High St", City: "Manchester"}, Roles: []string{"dev"}},
+}
+
+func main() {
+	r := router.MustNew()
+	r.Use(fields.New(fields.WithSelector("pointer", fields.Pointer)))
+
+	r.GET("/users", func(c *router.Context) {
+		if err := c.JSON(http.StatusOK, map[string]any{"data": users, "total": len(users)}); err != nil {
+			log.Printf("write response: %v", err)
+		}
+	})
+
+	log.Println("Server starting on :8080")
+	log.Println("  curl 'http://localhost:8080/users?fields=data.id,data.name,total'")
+	log.Println("  curl 'http://localhost:8080/users?fields=data.address.city'")
+	log.Println("  curl 'http://localhost:8080/users?pointer=/data/0&fields=name,email'")
+	log.Fatal(http.ListenAndServe(":8080", r))
+}
@@ -0,0 +1,303 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fields
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+
+	"rivaas.dev/router"
+)
+
+// ErrInvalidSelection is passed to the error handler when a selector
+// expression can't be applied to the response, such as a JSON Pointer to a
+// member that doesn't exist.
+var ErrInvalidSelection = errors.New("fields: invalid selection")
+
+// SelectorFunc selects part of a JSON document with an expression from the
+// query string, and returns the selected JSON. It returns an error wrapping
+// [ErrInvalidSelection] if the expression doesn't apply to the document.
+type SelectorFunc func(document []byte, expr string) ([]byte, error)
+
+// selector is a selector enabled by a query parameter.
+type selector struct {
+	param string
+	fn    SelectorFunc
+}
+
+// Option defines functional options for fields middleware configuration.
+type Option func(*config)
+
+// config holds the configuration for the fields middleware.
+type config struct {
+	// param is the query parameter listing the fields to keep
+	param string
+
+	// selectors are applied before the fields filter, in order
+	selectors []selector
+
+	// maxSize is the largest response body that is filtered
+	maxSize int
+
+	// errorHandler is called when a selection fails
+	errorHandler func(c *router.Context, err error)
+
+	// skipPaths are paths whose responses are never filtered
+	skipPaths map[string]bool
+}
+
+// defaultConfig returns the default configuration for fields middleware.
+func defaultConfig() *config {
+	return &config{
+		param:        "fields",
+		maxSize:      1 << 20,
+		errorHandler: defaultErrorHandler,
+		skipPaths:    make(map[string]bool),
+	}
+}
+
+// defaultErrorHandler sends 400 Bad Request.
+func defaultErrorHandler(c *router.Context, err error) {
+	c.WriteErrorResponse(http.StatusBadRequest, err.Error())
+}
+
+// New returns a middleware that trims JSON responses to the fields the
+// client asks for in the query string:
+//
+//	GET /users/1?fields=id,name,address.city
+//
+// returns only the id, the name, and the city of the address. Nested fields
+// are separated by dots, and fields of arrays apply to each element. Fields
+// that don't exist are ignored, and members keep their order.
+//
+// Only successful responses with a JSON content type are filtered.
+// Requests without the query parameter are not affected.
+//
+// Example:
+//
+//	r := router.MustNew()
+//	r.Use(fields.New())
+func New(opts ...Option) router.HandlerFunc {
+	cfg := defaultConfig()
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return func(c *router.Context) {
+		if cfg.skipPaths[c.Request.URL.Path] {
+			c.Next()
+			return
+		}
+		sel := cfg.selection(c)
+		if sel == nil {
+			c.Next()
+			return
+		}
+
+		original := c.Response
+		w := &fieldsWriter{ResponseWriter: original, maxSize: cfg.maxSize}
+		c.Response = w
+
+		c.Next()
+
+		c.Response = original
+		if !w.buffering() {
+			return
+		}
+		body, err := sel.apply(w.buf.Bytes())
+		if err != nil {
+			cfg.errorHandler(c, err)
+			return
+		}
+		w.send(body)
+	}
+}
+
+// selection is what the client asked for in the query string.
+type selection struct {
+	// exprs are the expressions of the selectors, by index
+	exprs []string
+
+	// selectors are the selectors with an expression
+	selectors []selector
+
+	// tree is the fields to keep, or nil to keep all
+	tree fieldTree
+}
+
+// selection returns the selection of the request of c, or nil if the
+// client didn't ask for one.
+func (cfg *config) selection(c *router.Context) *selection {
+	query := c.Request.URL.Query()
+	sel := &selection{}
+	for _, s := range cfg.selectors {
+		if expr, ok := query[s.param]; ok && len(expr) > 0 {
+			sel.selectors = append(sel.selectors, s)
+			sel.exprs = append(sel.exprs, expr[0])
+		}
+	}
+	if cfg.param != "" {
+		sel.tree = parseFields(query.Get(cfg.param))
+	}
+	if len(sel.selectors) == 0 && sel.tree == nil {
+		return nil
+	}
+
+	return sel
+}
+
+// apply applies the selectors and the fields filter to body. Bodies that
+// aren't valid JSON are returned unchanged.
+func (sel *selection) apply(body []byte) ([]byte, error) {
+	if !json.Valid(body) {
+		return body, nil
+	}
+	var err error
+	for i, s := range sel.selectors {
+		if body, err = s.fn(body, sel.exprs[i]); err != nil {
+			return nil, fmt.Errorf("%s: %w", s.param, err)
+		}
+	}
+	if sel.tree == nil {
+		return body, nil
+	}
+
+	var buf bytes.Buffer
+	if err := filter(&buf, body, sel.tree); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// fieldTree is a set of fields to keep. A field mapped to nil is kept
+// whole; otherwise only its listed subfields are kept.
+type fieldTree map[string]fieldTree
+
+// parseFields parses a comma-separated list of dotted field paths. It
+// returns nil if the list has no fields.
+func parseFields(list string) fieldTree {
+	var tree fieldTree
+	for path := range strings.SplitSeq(list, ",") {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			continue
+		}
+		if tree == nil {
+			tree = make(fieldTree)
+		}
+		node := tree
+		segments := strings.Split(path, ".")
+		for i, segment := range segments {
+			child, exists := node[segment]
+			if i == len(segments)-1 {
+				node[segment] = nil
+				break
+			}
+			if exists && child == nil {
+				break // The whole field is kept already
+			}
+			if !exists {
+				child = make(fieldTree)
+				node[segment] = child
+			}
+			node = child
+		}
+	}
+
+	return tree
+}
+
+// filter writes the members of the JSON value raw that tree selects to buf.
+// Objects keep the selected members, arrays apply tree to each element, and
+// other values are kept as they are.
+func filter(buf *bytes.Buffer, raw []byte, tree fieldTree) error {
+	raw = bytes.TrimSpace(raw)
+	if tree == nil || len(raw) == 0 {
+		buf.Write(raw)
+		return nil
+	}
+
+	switch raw[0] {
+	case '{':
+		dec := json.NewDecoder(bytes.NewReader(raw))
+		if _, err := dec.Token(); err != nil {
+			return err
+		}
+		buf.WriteByte('{')
+		first := true
+		for dec.More() {
+			token, err := dec.Token()
+			if err != nil {
+				return err
+			}
+			var value json.RawMessage
+			if err := dec.Decode(&value); err != nil {
+				return err
+			}
+			key, _ := token.(string)
+			subtree, ok := tree[key]
+			if !ok {
+				continue
+			}
+			if !first {
+				buf.WriteByte(',')
+			}
+			first = false
+			encodedKey, _ := json.Marshal(key) //nolint:errcheck // Strings always encode
+			buf.Write(encodedKey)
+			buf.WriteByte(':')
+			if err := filter(buf, value, subtree); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	case '[':
+		var items []json.RawMessage
+		if err := json.Unmarshal(raw, &items); err != nil {
+			return err
+		}
+		buf.WriteByte('[')
+		for i, item := range items {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := filter(buf, item, tree); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	default:
+		buf.Write(raw)
+	}
+
+	return nil
+}
+
+// isJSON reports whether contentType is a JSON media type, such as
+// application/json or application/problem+json.
+func isJSON(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
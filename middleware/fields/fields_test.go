@@ -0,0 +1,178 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package fields
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"rivaas.dev/router"
+)
+
+const userJSON = `{"id":1,"name":"Ada","email":"ada@example.com","address":{"street":"1 Main St","city":"London","zip":"N1"},"roles":[{"id":7,"name":"admin"},{"id":8,"name":"dev"}]}`
+
+// writeUser responds with userJSON and an ETag.
+func writeUser(c *router.Context) {
+	c.Response.Header().Set("Content-Type", "application/json; charset=utf-8")
+	c.Response.Header().Set("ETag", `"v1"`)
+	//nolint:errcheck // Test handler
+	c.Response.Write([]byte(userJSON))
+}
+
+func get(r http.Handler, target string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, nil))
+
+	return w
+}
+
+func TestFields(t *testing.T) {
+	t.Parallel()
+	r := router.MustNew()
+	r.Use(New())
+	r.GET("/user", writeUser)
+
+	r.GET("/users", func(c *router.Context) {
+		//nolint:errcheck // Test handler
+		c.JSON(http.StatusOK, []map[string]any{{"id": 1, "name": "Ada"}, {"id": 2, "name": "Alan"}})
+	})
+	r.GET("/missing", func(c *router.Context) {
+		//nolint:errcheck // Test handler
+		c.JSON(http.StatusNotFound, map[string]string{"error": "not found", "code": "NOT_FOUND"})
+	})
+	r.GET("/text", func(c *router.Context) {
+		//nolint:errcheck // Test handler
+		c.String(http.StatusOK, "id,name")
+	})
+
+	tests := []struct {
+		name   string
+		target string
+		want   string
+	}{
+		{name: "no filter", target: "/user", want: userJSON},
+		{name: "empty filter", target: "/user?fields=", want: userJSON},
+		{name: "top level", target: "/user?fields=name,id", want: `{"id":1,"name":"Ada"}`},
+		{name: "nested", target: "/user?fields=id,address.city", want: `{"id":1,"address":{"city":"London"}}`},
+		{name: "whole object wins", target: "/user?fields=address.city,address", want: `{"address":{"street":"1 Main St","city":"London","zip":"N1"}}`},
+		{name: "array elements", target: "/user?fields=roles.name", want: `{"roles":[{"name":"admin"},{"name":"dev"}]}`},
+		{name: "unknown fields", target: "/user?fields=id,nope,address.nope", want: `{"id":1,"address":{}}`},
+		{name: "top-level array", target: "/users?fields=name", want: `[{"name":"Ada"},{"name":"Alan"}]`},
+		{name: "errors unfiltered", target: "/missing?fields=code", want: `{"code":"NOT_FOUND","error":"not found"}`},
+		{name: "non-JSON unfiltered", target: "/text?fields=id", want: "id,name"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			w := get(r, tt.target)
+			if strings.HasPrefix(tt.target, "/user") {
+				assert.Equal(t, tt.want, w.Body.String(), "member order is kept")
+				return
+			}
+			assert.Equal(t, tt.want, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
+
+func TestFields_Headers(t *testing.T) {
+	t.Parallel()
+	r := router.MustNew()
+	r.Use(New())
+	r.GET("/user", writeUser)
+
+	w := get(r, "/user?fields=id")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "8", w.Header().Get("Content-Length"))
+	assert.Empty(t, w.Header().Get("ETag"), "the handler's ETag no longer applies")
+
+	w = get(r, "/user")
+	assert.Equal(t, `"v1"`, w.Header().Get("ETag"))
+}
+
+func TestFields_Selector(t *testing.T) {
+	t.Parallel()
+	r := router.MustNew()
+	r.Use(New(WithSelector("pointer", Pointer)))
+	r.GET("/user", writeUser)
+
+	w := get(r, "/user?pointer=/address")
+	assert.Equal(t, `{"street":"1 Main St","city":"London","zip":"N1"}`, w.Body.String())
+
+	w = get(r, "/user?pointer=/roles&fields=name")
+	assert.Equal(t, `[{"name":"admin"},{"name":"dev"}]`, w.Body.String())
+
+	w = get(r, "/user?pointer=/roles/1/name")
+	assert.Equal(t, `"dev"`, w.Body.String())
+
+	w = get(r, "/user?pointer=/nope")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestFields_ErrorHandler(t *testing.T) {
+	t.Parallel()
+	var gotErr error
+	r := router.MustNew()
+	r.Use(New(WithSelector("pointer", Pointer), WithErrorHandler(func(c *router.Context, err error) {
+		gotErr = err
+		c.WriteErrorResponse(http.StatusUnprocessableEntity, "")
+	})))
+	r.GET("/user", writeUser)
+
+	w := get(r, "/user?pointer=/roles/9")
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	require.ErrorIs(t, gotErr, ErrInvalidSelection)
+}
+
+func TestFields_Options(t *testing.T) {
+	t.Parallel()
+
+	r := router.MustNew()
+	r.Use(New(WithParam("select")))
+	r.GET("/user", writeUser)
+
+	assert.Equal(t, `{"id":1}`, get(r, "/user?select=id").Body.String())
+	assert.Equal(t, userJSON, get(r, "/user?fields=id").Body.String())
+
+	r = router.MustNew()
+	r.Use(New(WithMaxSize(10)))
+	r.GET("/user", writeUser)
+
+	assert.Equal(t, userJSON, get(r, "/user?fields=id").Body.String(), "too large to filter")
+
+	r = router.MustNew()
+	r.Use(New(WithSkipPaths("/user")))
+	r.GET("/user", writeUser)
+
+	assert.Equal(t, userJSON, get(r, "/user?fields=id").Body.String())
+}
+
+func TestParseFields(t *testing.T) {
+	t.Parallel()
+	assert.Nil(t, parseFields(""))
+	assert.Nil(t, parseFields(" , "))
+	assert.Equal(t, fieldTree{
+		"id":      nil,
+		"address": fieldTree{"city": nil, "geo": fieldTree{"lat": nil}},
+		"roles":   nil,
+	}, parseFields("id, address.city,address.geo.lat,roles,roles.name"))
+}
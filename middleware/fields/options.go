@@ -0,0 +1,86 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fields
+
+import "rivaas.dev/router"
+
+// WithParam sets the query parameter that lists the fields to keep. An
+// empty name disables field filtering, leaving only the selectors.
+// Default: "fields"
+//
+// Example:
+//
+//	fields.New(fields.WithParam("select"))
+func WithParam(name string) Option {
+	return func(cfg *config) {
+		cfg.param = name
+	}
+}
+
+// WithSelector lets the named query parameter select part of the response
+// with fn, before the fields filter applies. Use [Pointer] for JSON
+// Pointers, or adapt a query language such as JMESPath.
+//
+// Example:
+//
+//	fields.New(fields.WithSelector("pointer", fields.Pointer))
+//
+//	// GET /orders?pointer=/data&fields=id,total
+func WithSelector(param string, fn SelectorFunc) Option {
+	return func(cfg *config) {
+		cfg.selectors = append(cfg.selectors, selector{param: param, fn: fn})
+	}
+}
+
+// WithMaxSize sets the largest response body, in bytes, that is filtered.
+// Larger responses are sent unfiltered.
+// Default: 1 MiB
+//
+// Example:
+//
+//	fields.New(fields.WithMaxSize(4 << 20))
+func WithMaxSize(size int) Option {
+	return func(cfg *config) {
+		cfg.maxSize = size
+	}
+}
+
+// WithErrorHandler sets the function that responds when a selector fails.
+// The error wraps [ErrInvalidSelection].
+// Default: 400 Bad Request
+//
+// Example:
+//
+//	fields.New(fields.WithErrorHandler(func(c *router.Context, err error) {
+//	    c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+//	}))
+func WithErrorHandler(handler func(c *router.Context, err error)) Option {
+	return func(cfg *config) {
+		cfg.errorHandler = handler
+	}
+}
+
+// WithSkipPaths sets paths whose responses are never filtered.
+//
+// Example:
+//
+//	fields.New(fields.WithSkipPaths("/export"))
+func WithSkipPaths(paths ...string) Option {
+	return func(cfg *config) {
+		for _, path := range paths {
+			cfg.skipPaths[path] = true
+		}
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fields
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Pointer is a [SelectorFunc] that selects the value that a JSON Pointer
+// (RFC 6901) refers to, such as "/data/0/address". The empty pointer
+// selects the whole document.
+//
+// Example:
+//
+//	fields.New(fields.WithSelector("pointer", fields.Pointer))
+func Pointer(document []byte, pointer string) ([]byte, error) {
+	if pointer == "" {
+		return document, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("%w: JSON pointer %q must start with '/'", ErrInvalidSelection, pointer)
+	}
+
+	value := bytes.TrimSpace(document)
+	for token := range strings.SplitSeq(pointer[1:], "/") {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		next, err := child(value, token)
+		if err != nil {
+			return nil, fmt.Errorf("%w: JSON pointer %q: %w", ErrInvalidSelection, pointer, err)
+		}
+		value = bytes.TrimSpace(next)
+	}
+
+	return value, nil
+}
+
+// child returns the member named token of the JSON object raw, or the
+// element at index token of the JSON array raw.
+func child(raw []byte, token string) ([]byte, error) {
+	if len(raw) == 0 {
+		return nil, fmt.Errorf("no value at %q", token)
+	}
+
+	switch raw[0] {
+	case '{':
+		var members map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &members); err != nil {
+			return nil, err
+		}
+		value, ok := members[token]
+		if !ok {
+			return nil, fmt.Errorf("no member %q", token)
+		}
+		return value, nil
+	case '[':
+		var items []json.RawMessage
+		if err := json.Unmarshal(raw, &items); err != nil {
+			return nil, err
+		}
+		index, err := strconv.Atoi(token)
+		if err != nil || index < 0 || index >= len(items) || (len(token) > 1 && token[0] == '0') {
+			return nil, fmt.Errorf("no element %q", token)
+		}
+		return items[index], nil
+	default:
+		return nil, fmt.Errorf("no value at %q", token)
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package fields
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPointer(t *testing.T) {
+	t.Parallel()
+	// The example document of RFC 6901
+	doc := []byte(`{"foo":["bar","baz"],"":0,"a/b":1,"c%d":2,"e^f":3,"g|h":4,"i\\j":5,"k\"l":6," ":7,"m~n":8}`)
+
+	tests := []struct {
+		pointer, want string
+	}{
+		{"", string(doc)},
+		{"/foo", `["bar","baz"]`},
+		{"/foo/0", `"bar"`},
+		{"/", `0`},
+		{"/a~1b", `1`},
+		{"/c%d", `2`},
+		{"/i\\j", `5`},
+		{"/k\"l", `6`},
+		{"/ ", `7`},
+		{"/m~0n", `8`},
+	}
+	for _, tt := range tests {
+		got, err := Pointer(doc, tt.pointer)
+		require.NoError(t, err, tt.pointer)
+		assert.Equal(t, tt.want, string(got), tt.pointer)
+	}
+
+	for _, pointer := range []string{"foo", "/bar", "/foo/2", "/foo/-", "/foo/01", "/foo/0/x"} {
+		_, err := Pointer(doc, pointer)
+		require.ErrorIs(t, err, ErrInvalidSelection, pointer)
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fields
+
+import (
+	"bufio"
+	"bytes"
+	"net"
+	"net/http"
+	"strconv"
+
+	"rivaas.dev/router"
+)
+
+// writerMode is what a fieldsWriter does with the response.
+type writerMode int
+
+const (
+	modeUndecided   writerMode = iota // Nothing written yet
+	modeBuffering                     // Buffering a JSON body to filter it
+	modePassthrough                   // Forwarding the response unchanged
+)
+
+// fieldsWriter buffers JSON response bodies so they can be filtered. It
+// forwards the optional interfaces the router and other middleware use.
+type fieldsWriter struct {
+	http.ResponseWriter
+	maxSize int
+
+	mode        writerMode
+	status      int
+	wroteHeader bool // WriteHeader was called by the handler
+	buf         bytes.Buffer
+}
+
+func (w *fieldsWriter) WriteHeader(code int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	w.status = code
+
+	if code >= 200 && code < 300 && code != http.StatusNoContent && isJSON(w.Header().Get("Content-Type")) {
+		w.mode = modeBuffering
+		return
+	}
+	w.mode = modePassthrough
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *fieldsWriter) Write(p []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.mode == modeBuffering {
+		if w.buf.Len()+len(p) <= w.maxSize {
+			return w.buf.Write(p)
+		}
+		// Too large to buffer: send it unfiltered
+		if err := w.passthrough(); err != nil {
+			return 0, err
+		}
+	}
+
+	return w.ResponseWriter.Write(p)
+}
+
+// buffering reports whether the response is buffered and not sent yet.
+func (w *fieldsWriter) buffering() bool {
+	return w.mode == modeBuffering
+}
+
+// passthrough sends the status and the buffered body, and stops buffering.
+func (w *fieldsWriter) passthrough() error {
+	w.mode = modePassthrough
+	w.ResponseWriter.WriteHeader(w.status)
+	if w.buf.Len() == 0 {
+		return nil
+	}
+	_, err := w.ResponseWriter.Write(w.buf.Bytes())
+	w.buf = bytes.Buffer{}
+
+	return err
+}
+
+// send sends the status with body in place of the buffered body. A
+// validator the handler set no longer describes the body, so it is removed.
+func (w *fieldsWriter) send(body []byte) {
+	w.mode = modePassthrough
+	header := w.Header()
+	if !bytes.Equal(body, w.buf.Bytes()) {
+		header.Del("ETag")
+	}
+	header.Set("Content-Length", strconv.Itoa(len(body)))
+	w.ResponseWriter.WriteHeader(w.status)
+	_, _ = w.ResponseWriter.Write(body) //nolint:errcheck // The client went away
+}
+
+// Written implements router.WrittenChecker.
+func (w *fieldsWriter) Written() bool {
+	return w.wroteHeader
+}
+
+// Hijack implements http.Hijacker.
+func (w *fieldsWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if hijacker, ok := w.ResponseWriter.(http.Hijacker); ok {
+		w.mode = modePassthrough
+		return hijacker.Hijack()
+	}
+
+	return nil, nil, router.ErrResponseWriterNotHijacker
+}
+
+// Flush implements http.Flusher. Flushing means the handler is streaming, so
+// the response is sent unfiltered.
+func (w *fieldsWriter) Flush() {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.mode == modeBuffering {
+		_ = w.passthrough() //nolint:errcheck // Reported by the next Write
+	}
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap returns the underlying writer for http.ResponseController.
+func (w *fieldsWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
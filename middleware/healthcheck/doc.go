@@ -0,0 +1,53 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package healthcheck provides liveness and readiness probe handlers for
+// services that use rivaas.dev/router without rivaas.dev/app. The handlers
+// behave like the endpoints of app.WithHealthEndpoints.
+//
+// # Basic Usage
+//
+//	import "rivaas.dev/middleware/healthcheck"
+//
+//	r := router.MustNew()
+//	err := healthcheck.Register(r,
+//	    healthcheck.WithReadinessCheck("database", db.PingContext),
+//	)
+//
+// This registers GET /livez and GET /readyz. To choose the routes, create
+// the handlers with [New] instead:
+//
+//	health := healthcheck.New(healthcheck.WithReadinessCheck("database", db.PingContext))
+//	r.GET("/health/live", health.Livez)
+//	r.GET("/health/ready", health.Readyz)
+//
+// # Probes
+//
+// The liveness probe responds 200 "ok" and the readiness probe 204 No
+// Content when all their checks pass; both respond 503 Service Unavailable
+// when a check fails. Liveness checks should not depend on other services,
+// since a failing liveness probe restarts the process; readiness checks
+// typically ping databases, caches, and upstream services.
+//
+// # Timeouts
+//
+// Checks run concurrently, each with its own timeout (see [WithTimeout]).
+// A check that doesn't return in time fails, even if it ignores its
+// context, so a probe always responds within the timeout.
+//
+// # JSON Output
+//
+// With [WithJSON], probes respond with a [Report] of each check: its
+// status, error, and duration.
+package healthcheck
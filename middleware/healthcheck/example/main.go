@@ -0,0 +1,59 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package main demonstrates how to use the healthcheck package
+// with liveness and readiness checks.
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"sync/atomic"
+
+	"rivaas.dev/middleware/healthcheck"
+	"rivaas.dev/router"
+)
+
+func main() {
+	r := router.MustNew()
+
+	// Toggle the database with POST /database/down and /database/up
+	var databaseUp atomic.Bool
+	databaseUp.Store(true)
+
+	if err := healthcheck.Register(r,
+		healthcheck.WithJSON(true),
+		healthcheck.WithReadinessCheck("database", func(context.Context) error {
+			if !databaseUp.Load() {
+				return errors.New("connection refused")
+			}
+			return nil
+		}),
+	); err != nil {
+		log.Fatal(err)
+	}
+
+	r.POST("/database/:state", func(c *router.Context) {
+		databaseUp.Store(c.Param("state") == "up")
+		c.NoContent()
+	})
+
+	log.Println("Server starting on :8080")
+	log.Println("  curl -i http://localhost:8080/readyz")
+	log.Println("  curl -i -X POST http://localhost:8080/database/down")
+	log.Println("  curl -i http://localhost:8080/readyz")
+	log.Fatal(http.ListenAndServe(":8080", r))
+}
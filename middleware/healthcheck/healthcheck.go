@@ -0,0 +1,290 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"rivaas.dev/router"
+)
+
+// CheckFunc checks one dependency or condition. It returns nil if the check
+// passes. The context is canceled when the check timeout expires.
+type CheckFunc func(ctx context.Context) error
+
+// Option defines functional options for health check configuration.
+type Option func(*config)
+
+// config holds the configuration for the health check handlers.
+type config struct {
+	// prefix is prepended to the probe paths by Register
+	prefix string
+
+	// livezPath and readyzPath are the probe paths used by Register
+	livezPath  string
+	readyzPath string
+
+	// liveness and readiness are the checks of each probe
+	liveness  map[string]CheckFunc
+	readiness map[string]CheckFunc
+
+	// timeout is the time limit of each check
+	timeout time.Duration
+
+	// json reports the result of each check as JSON
+	json bool
+
+	// logger logs failed checks and write errors
+	logger *slog.Logger
+}
+
+// defaultConfig returns the default configuration for the health check
+// handlers, the same as app.WithHealthEndpoints.
+func defaultConfig() *config {
+	return &config{
+		livezPath:  "/livez",
+		readyzPath: "/readyz",
+		liveness:   make(map[string]CheckFunc),
+		readiness:  make(map[string]CheckFunc),
+		timeout:    time.Second,
+		logger:     slog.Default(),
+	}
+}
+
+// Health serves liveness and readiness probes. Create it with [New].
+type Health struct {
+	cfg *config
+}
+
+// New returns liveness and readiness handlers with the given checks, for
+// routers used without rivaas.dev/app. They behave like the endpoints of
+// app.WithHealthEndpoints:
+//
+//   - [Health.Livez] responds 200 "ok" if all liveness checks pass
+//   - [Health.Readyz] responds 204 No Content if all readiness checks pass
+//
+// and both respond 503 Service Unavailable if a check fails. Checks run
+// concurrently, each with its own timeout. [Register] registers both
+// handlers on a router.
+//
+// Example:
+//
+//	health := healthcheck.New(
+//	    healthcheck.WithReadinessCheck("database", db.PingContext),
+//	)
+//	r.GET("/livez", health.Livez)
+//	r.GET("/readyz", health.Readyz)
+func New(opts ...Option) *Health {
+	cfg := defaultConfig()
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	if cfg.timeout <= 0 {
+		cfg.timeout = time.Second
+	}
+
+	return &Health{cfg: cfg}
+}
+
+// Register registers the liveness and readiness handlers on r at
+// prefix+"/livez" and prefix+"/readyz" (see [WithPrefix], [WithLivezPath],
+// and [WithReadyzPath]). It returns an error if one of the routes is
+// already registered.
+//
+// Example:
+//
+//	r := router.MustNew()
+//	if err := healthcheck.Register(r,
+//	    healthcheck.WithPrefix("/_system"),
+//	    healthcheck.WithReadinessCheck("database", db.PingContext),
+//	); err != nil {
+//	    log.Fatal(err)
+//	}
+func Register(r *router.Router, opts ...Option) error {
+	h := New(opts...)
+	livezPath := h.cfg.prefix + h.cfg.livezPath
+	readyzPath := h.cfg.prefix + h.cfg.readyzPath
+
+	for _, path := range []string{livezPath, readyzPath} {
+		if routeExists(r, path) {
+			return fmt.Errorf("healthcheck: route already registered: GET %s", path)
+		}
+	}
+	r.GET(livezPath, h.Livez)
+	r.GET(readyzPath, h.Readyz)
+
+	return nil
+}
+
+// routeExists reports whether a GET route with path is registered on r,
+// including routes that aren't active yet because r isn't frozen.
+func routeExists(r *router.Router, path string) bool {
+	if r.RouteExists(http.MethodGet, path) {
+		return true
+	}
+	for _, info := range r.Routes() {
+		if info.Method == http.MethodGet && info.Path == path {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Livez is the liveness probe handler. Liveness checks should not depend
+// on other services: a failing liveness probe restarts the process.
+// Without liveness checks, it always responds 200 "ok".
+func (h *Health) Livez(c *router.Context) {
+	h.serve(c, "liveness", h.cfg.liveness, http.StatusOK,
+		"Service Not Healthy: One or more liveness checks failed")
+}
+
+// Readyz is the readiness probe handler. A failing readiness probe takes
+// the instance out of the load balancer until its dependencies recover.
+// Without readiness checks, it always responds 204 No Content.
+func (h *Health) Readyz(c *router.Context) {
+	h.serve(c, "readiness", h.cfg.readiness, http.StatusNoContent,
+		"Service Not Ready: One or more dependencies failed readiness")
+}
+
+// serve runs checks and writes the result of a probe.
+func (h *Health) serve(c *router.Context, probe string, checks map[string]CheckFunc, okStatus int, failure string) {
+	c.Header("Cache-Control", "no-store")
+
+	results := runChecks(c.Request.Context(), checks, h.cfg.timeout)
+	healthy := true
+	for name, result := range results {
+		if result.Error != "" {
+			healthy = false
+			h.cfg.logger.WarnContext(c.RequestContext(), "healthcheck: check failed",
+				"probe", probe, "check", name, "error", result.Error)
+		}
+	}
+
+	var err error
+	switch {
+	case h.cfg.json:
+		report := Report{Status: StatusOK, Checks: results}
+		status := http.StatusOK
+		if !healthy {
+			report.Status = StatusFail
+			status = http.StatusServiceUnavailable
+		}
+		err = c.JSON(status, report)
+	case !healthy:
+		c.WriteErrorResponse(http.StatusServiceUnavailable, failure)
+	case okStatus == http.StatusNoContent:
+		c.NoContent()
+	default:
+		err = c.String(okStatus, "ok")
+	}
+	if err != nil {
+		h.cfg.logger.ErrorContext(c.RequestContext(), "healthcheck: failed to write response",
+			"probe", probe, "error", err)
+	}
+}
+
+// Statuses of a [Report] and a [CheckResult].
+const (
+	StatusOK   = "ok"
+	StatusFail = "fail"
+)
+
+// Report is the JSON response of a probe with [WithJSON].
+type Report struct {
+	// Status is StatusOK if all checks passed, StatusFail otherwise
+	Status string `json:"status"`
+
+	// Checks holds the result of each check by name
+	Checks map[string]CheckResult `json:"checks,omitempty"`
+}
+
+// CheckResult is the result of one check in a [Report].
+type CheckResult struct {
+	// Status is StatusOK or StatusFail
+	Status string `json:"status"`
+
+	// Error is the error of a failed check
+	Error string `json:"error,omitempty"`
+
+	// Duration is how long the check took
+	Duration time.Duration `json:"duration_ns"`
+}
+
+// runChecks runs checks concurrently, each with its own timeout, and
+// returns their results. A check that doesn't return within the timeout,
+// for example because it ignores the context, fails without being waited
+// for.
+func runChecks(ctx context.Context, checks map[string]CheckFunc, timeout time.Duration) map[string]CheckResult {
+	if len(checks) == 0 {
+		return nil
+	}
+
+	type result struct {
+		name string
+		err  error
+		took time.Duration
+	}
+
+	// Buffered, so checks that return late don't block forever
+	results := make(chan result, len(checks))
+	for name, check := range checks {
+		go func() {
+			checkCtx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			start := time.Now()
+			err := check(checkCtx)
+			results <- result{name: name, err: err, took: time.Since(start)}
+		}()
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	report := make(map[string]CheckResult, len(checks))
+	for range len(checks) {
+		select {
+		case r := <-results:
+			report[r.name] = newCheckResult(r.err, r.took)
+		case <-timer.C:
+			for name := range checks {
+				if _, ok := report[name]; !ok {
+					report[name] = newCheckResult(context.DeadlineExceeded, timeout)
+				}
+			}
+			return report
+		}
+	}
+
+	return report
+}
+
+// newCheckResult returns the result of a check that returned err.
+func newCheckResult(err error, took time.Duration) CheckResult {
+	if err == nil {
+		return CheckResult{Status: StatusOK, Duration: took}
+	}
+	msg := err.Error()
+	if errors.Is(err, context.DeadlineExceeded) {
+		msg = "timeout"
+	}
+
+	return CheckResult{Status: StatusFail, Error: msg, Duration: took}
+}
@@ -0,0 +1,181 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package healthcheck
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"rivaas.dev/router"
+)
+
+var quiet = WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+func pass(context.Context) error { return nil }
+
+func fail(context.Context) error { return errors.New("connection refused") }
+
+func get(t *testing.T, r http.Handler, path string) *httptest.ResponseRecorder {
+	t.Helper()
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequestWithContext(t.Context(), http.MethodGet, path, nil))
+
+	return w
+}
+
+func TestHealth_Probes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		opts       []Option
+		path       string
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "livez without checks", path: "/livez", wantStatus: http.StatusOK, wantBody: "ok"},
+		{name: "readyz without checks", path: "/readyz", wantStatus: http.StatusNoContent},
+		{name: "livez passing", opts: []Option{WithLivenessCheck("a", pass)}, path: "/livez", wantStatus: http.StatusOK, wantBody: "ok"},
+		{name: "livez failing", opts: []Option{WithLivenessCheck("a", pass), WithLivenessCheck("b", fail)}, path: "/livez", wantStatus: http.StatusServiceUnavailable},
+		{name: "readyz passing", opts: []Option{WithReadinessCheck("db", pass)}, path: "/readyz", wantStatus: http.StatusNoContent},
+		{name: "readyz failing", opts: []Option{WithReadinessCheck("db", fail)}, path: "/readyz", wantStatus: http.StatusServiceUnavailable},
+		{name: "readiness checks don't affect livez", opts: []Option{WithReadinessCheck("db", fail)}, path: "/livez", wantStatus: http.StatusOK, wantBody: "ok"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := router.MustNew()
+			require.NoError(t, Register(r, append(tt.opts, quiet)...))
+
+			w := get(t, r, tt.path)
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+			if tt.wantBody != "" {
+				assert.Equal(t, tt.wantBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestHealth_JSON(t *testing.T) {
+	t.Parallel()
+	r := router.MustNew()
+	require.NoError(t, Register(r, quiet, WithJSON(true),
+		WithReadinessCheck("db", pass),
+		WithReadinessCheck("cache", fail),
+	))
+
+	w := get(t, r, "/readyz")
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	var report Report
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+	assert.Equal(t, StatusFail, report.Status)
+	require.Len(t, report.Checks, 2)
+	assert.Equal(t, StatusOK, report.Checks["db"].Status)
+	assert.Equal(t, StatusFail, report.Checks["cache"].Status)
+	assert.Equal(t, "connection refused", report.Checks["cache"].Error)
+
+	w = get(t, r, "/livez")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"status":"ok"}`, w.Body.String())
+}
+
+func TestHealth_Timeout(t *testing.T) {
+	t.Parallel()
+	release := make(chan struct{})
+	t.Cleanup(func() { close(release) })
+	r := router.MustNew()
+	require.NoError(t, Register(r, quiet, WithJSON(true), WithTimeout(50*time.Millisecond),
+		WithReadinessCheck("slow", func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}),
+		WithReadinessCheck("stuck", func(context.Context) error {
+			<-release // Ignores the context
+			return nil
+		}),
+		WithReadinessCheck("fast", pass),
+	))
+
+	start := time.Now()
+	w := get(t, r, "/readyz")
+	assert.Less(t, time.Since(start), time.Second, "stuck checks are not waited for")
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+	var report Report
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+	assert.Equal(t, "timeout", report.Checks["slow"].Error)
+	assert.Equal(t, "timeout", report.Checks["stuck"].Error)
+	assert.Equal(t, StatusOK, report.Checks["fast"].Status)
+}
+
+func TestHealth_Concurrent(t *testing.T) {
+	t.Parallel()
+	slow := func(ctx context.Context) error {
+		select {
+		case <-time.After(100 * time.Millisecond):
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	h := New(WithReadinessCheck("a", slow), WithReadinessCheck("b", slow), WithReadinessCheck("c", slow))
+	r := router.MustNew()
+	r.GET("/ready", h.Readyz)
+
+	start := time.Now()
+	w := get(t, r, "/ready")
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Less(t, time.Since(start), 250*time.Millisecond, "checks run concurrently")
+}
+
+func TestRegister_Paths(t *testing.T) {
+	t.Parallel()
+	r := router.MustNew()
+	require.NoError(t, Register(r, WithPrefix("/_system"), WithLivezPath("/live"), WithReadyzPath("/ready")))
+
+	assert.Equal(t, http.StatusOK, get(t, r, "/_system/live").Code)
+	assert.Equal(t, http.StatusNoContent, get(t, r, "/_system/ready").Code)
+	assert.Equal(t, http.StatusNotFound, get(t, r, "/livez").Code)
+}
+
+func TestRegister_Collision(t *testing.T) {
+	t.Parallel()
+	r := router.MustNew()
+	r.GET("/readyz", func(c *router.Context) { c.NoContent() })
+
+	err := Register(r)
+	require.ErrorContains(t, err, "GET /readyz")
+	assert.Len(t, r.Routes(), 1, "nothing is registered")
+}
+
+func TestNew_InvalidTimeout(t *testing.T) {
+	t.Parallel()
+	h := New(WithTimeout(0))
+	assert.Equal(t, time.Second, h.cfg.timeout)
+}
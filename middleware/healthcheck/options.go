@@ -0,0 +1,134 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package healthcheck
+
+import (
+	"log/slog"
+	"time"
+)
+
+// WithPrefix sets the prefix of the probe paths registered by [Register].
+// Default: "" (endpoints at /livez and /readyz)
+//
+// Example:
+//
+//	healthcheck.Register(r, healthcheck.WithPrefix("/_system"))
+//	// Endpoints: /_system/livez, /_system/readyz
+func WithPrefix(prefix string) Option {
+	return func(cfg *config) {
+		cfg.prefix = prefix
+	}
+}
+
+// WithLivezPath sets the path of the liveness probe registered by
+// [Register], after the prefix.
+// Default: "/livez"
+//
+// Example:
+//
+//	healthcheck.Register(r, healthcheck.WithLivezPath("/live"))
+func WithLivezPath(path string) Option {
+	return func(cfg *config) {
+		cfg.livezPath = path
+	}
+}
+
+// WithReadyzPath sets the path of the readiness probe registered by
+// [Register], after the prefix.
+// Default: "/readyz"
+//
+// Example:
+//
+//	healthcheck.Register(r, healthcheck.WithReadyzPath("/ready"))
+func WithReadyzPath(path string) Option {
+	return func(cfg *config) {
+		cfg.readyzPath = path
+	}
+}
+
+// WithTimeout sets the time limit of each check. A check that takes longer
+// fails, so one slow dependency doesn't block the probe.
+// Default: 1 second
+//
+// Example:
+//
+//	healthcheck.New(healthcheck.WithTimeout(500 * time.Millisecond))
+func WithTimeout(d time.Duration) Option {
+	return func(cfg *config) {
+		cfg.timeout = d
+	}
+}
+
+// WithLivenessCheck adds a liveness check. Liveness checks should not
+// depend on other services. A check with the same name is replaced.
+//
+// Example:
+//
+//	healthcheck.New(healthcheck.WithLivenessCheck("goroutines", func(ctx context.Context) error {
+//	    if runtime.NumGoroutine() > 10000 {
+//	        return errors.New("too many goroutines")
+//	    }
+//	    return nil
+//	}))
+func WithLivenessCheck(name string, check CheckFunc) Option {
+	return func(cfg *config) {
+		cfg.liveness[name] = check
+	}
+}
+
+// WithReadinessCheck adds a readiness check, typically of a dependency such
+// as a database or cache. A check with the same name is replaced.
+//
+// Example:
+//
+//	healthcheck.New(
+//	    healthcheck.WithReadinessCheck("database", db.PingContext),
+//	    healthcheck.WithReadinessCheck("cache", func(ctx context.Context) error {
+//	        return redis.Ping(ctx).Err()
+//	    }),
+//	)
+func WithReadinessCheck(name string, check CheckFunc) Option {
+	return func(cfg *config) {
+		cfg.readiness[name] = check
+	}
+}
+
+// WithJSON reports the result of each check as a JSON [Report], with 200 OK
+// or 503 Service Unavailable, instead of the plain responses of
+// app.WithHealthEndpoints. Check errors can reveal internal details, so
+// don't expose JSON probes publicly.
+// Default: false
+//
+// Example:
+//
+//	healthcheck.New(healthcheck.WithJSON(true))
+//	// {"status":"fail","checks":{"database":{"status":"fail","error":"timeout","duration_ns":1000000000}}}
+func WithJSON(enabled bool) Option {
+	return func(cfg *config) {
+		cfg.json = enabled
+	}
+}
+
+// WithLogger sets the logger for failed checks.
+// Default: slog.Default()
+//
+// Example:
+//
+//	healthcheck.New(healthcheck.WithLogger(logger))
+func WithLogger(logger *slog.Logger) Option {
+	return func(cfg *config) {
+		cfg.logger = logger
+	}
+}
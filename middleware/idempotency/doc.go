@@ -0,0 +1,66 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package idempotency provides middleware that makes retries of mutation
+// requests safe, following the Idempotency-Key semantics of payment APIs.
+//
+// A client sends a unique key with a request that changes state:
+//
+//	POST /payments HTTP/1.1
+//	Idempotency-Key: 8e03978e-40d5-43e8-bc93-6894a57f9324
+//
+// The first request with a key runs normally and its response is stored. If
+// the connection drops and the client retries with the same key, it gets the
+// stored response without the payment being made twice.
+//
+// # Basic Usage
+//
+//	import "rivaas.dev/middleware/idempotency"
+//
+//	r := router.MustNew()
+//	r.Use(idempotency.New())
+//
+// # Semantics
+//
+//   - Only POST and PATCH requests with a key are handled ([WithMethods],
+//     [WithRequired])
+//   - Retries get the stored status, headers, and body, with the
+//     Idempotent-Replayed: true header
+//   - Every completed response is stored, including errors
+//   - A retry while the first request is still running gets 409 Conflict
+//   - A key reused for a different method, path, or body gets 422
+//     Unprocessable Entity
+//   - If the handler panics, the key is released and can be retried
+//   - Responses are kept for 24 hours ([WithTTL])
+//
+// # Stores
+//
+// [NewMemoryStore] keeps records in memory and is the default. [NewRedisStore]
+// shares them between replicas. Any other backend can implement [Store]; its
+// Lock method must reserve keys atomically.
+//
+// # Scoping Keys
+//
+// Keys are global by default. [WithScope] makes them unique per client, so
+// one client can't receive another's response by reusing its key:
+//
+//	r.Use(apikey.New(apikey.WithStore(keys)))
+//	r.Use(idempotency.New(idempotency.WithScope(apikey.Owner)))
+//
+// # Request Bodies
+//
+// The body is read to fingerprint the request, then replaced so that
+// handlers can read it. Limit the body size with the bodylimit middleware,
+// registered before idempotency.
+package idempotency
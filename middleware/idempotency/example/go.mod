@@ -0,0 +1,23 @@
+module example-idempotency
+
+go 1.25.0
+
+require (
+	rivaas.dev/middleware/idempotency v0.0.0
+	rivaas.dev/router v0.15.0
+)
+
+require (
+	github.com/cespare/xxhash/v2 v2.3.0 // indirect
+	github.com/dgryski/go-rendezvous v0.0.0-20200823014737-9f7001d12a5f // indirect
+	github.com/kr/text v0.2.0 // indirect
+	github.com/redis/go-redis/v9 v9.17.0 // indirect
+	golang.org/x/net v0.52.0 // indirect
+	golang.org/x/text v0.35.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace (
+	rivaas.dev/middleware/idempotency => ..
+	rivaas.dev/router => ../../../router
+)
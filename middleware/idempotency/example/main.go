@@ -0,0 +1,63 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package main demonstrates how to use the idempotency middleware
+// for a payment endpoint that clients can safely retry.
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"rivaas.dev/middleware/idempotency"
+	"rivaas.dev/router"
+)
+
+type payment struct {
+	ID     int64 `json:"id"`
+	Amount int64 `json:"amount"`
+}
+
+func main() {
+	r := router.MustNew()
+	r.Use(idempotency.New(idempotency.WithRequired()))
+
+	var lastID atomic.Int64
+	r.POST("/payments", func(c *router.Context) {
+		var req struct {
+			Amount int64 `json:"amount"`
+		}
+		if err := json.NewDecoder(c.Request.Body).Decode(&req); err != nil {
+			c.WriteErrorResponse(http.StatusBadRequest, "invalid payment")
+			return
+		}
+
+		// Simulate a slow payment provider
+		time.Sleep(2 * time.Second)
+
+		p := payment{ID: lastID.Add(1), Amount: req.Amount}
+		log.Printf("charged payment %d", p.ID)
+		if err := c.JSON(http.StatusCreated, p); err != nil {
+			log.Printf("write response: %v", err)
+		}
+	})
+
+	log.Println("Server starting on :8080")
+	log.Println(`  curl -i -X POST -H 'Idempotency-Key: abc' -d '{"amount":100}' http://localhost:8080/payments`)
+	log.Println("  Run it twice: the second response is replayed and no new payment is charged.")
+	log.Fatal(http.ListenAndServe(":8080", r))
+}
@@ -0,0 +1,277 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"rivaas.dev/router"
+)
+
+// maxKeyLength is the maximum length of an idempotency key.
+const maxKeyLength = 255
+
+// Errors passed to the error handler when a request is rejected.
+var (
+	// ErrKeyMissing is returned when a request has no idempotency key and
+	// keys are required.
+	ErrKeyMissing = errors.New("idempotency: key missing")
+
+	// ErrKeyInvalid is returned when the idempotency key is longer than 255
+	// characters.
+	ErrKeyInvalid = errors.New("idempotency: key invalid")
+
+	// ErrInFlight is returned when a request with the same key is still
+	// being processed.
+	ErrInFlight = errors.New("idempotency: request with this key in progress")
+
+	// ErrKeyMismatch is returned when the key was used before for a request
+	// with a different method, path, or body.
+	ErrKeyMismatch = errors.New("idempotency: key reused with different request")
+)
+
+// Option defines functional options for idempotency middleware configuration.
+type Option func(*config)
+
+// config holds the configuration for the idempotency middleware.
+type config struct {
+	// store keeps the records
+	store Store
+
+	// header is the request header that carries the key
+	header string
+
+	// ttl is how long responses are kept for replay
+	ttl time.Duration
+
+	// lockTimeout is how long a key stays reserved by a request that does
+	// not complete
+	lockTimeout time.Duration
+
+	// methods are the request methods the middleware applies to
+	methods map[string]bool
+
+	// required rejects requests without a key
+	required bool
+
+	// scope returns the namespace of keys, e.g. the client's ID
+	scope func(c *router.Context) string
+
+	// errorHandler is called when a request is rejected
+	errorHandler func(c *router.Context, err error)
+
+	// skipPaths are paths the middleware does not apply to
+	skipPaths map[string]bool
+
+	// logger logs failures to save responses; nil disables logging
+	logger *slog.Logger
+}
+
+// defaultConfig returns the default configuration for idempotency middleware.
+func defaultConfig() *config {
+	return &config{
+		header:       "Idempotency-Key",
+		ttl:          24 * time.Hour,
+		lockTimeout:  time.Minute,
+		methods:      map[string]bool{http.MethodPost: true, http.MethodPatch: true},
+		errorHandler: defaultErrorHandler,
+		skipPaths:    make(map[string]bool),
+		logger:       slog.Default(),
+	}
+}
+
+// defaultErrorHandler sends 400 Bad Request for missing or invalid keys, 409
+// Conflict while the original request is in flight, 422 Unprocessable
+// Entity for reused keys, and 500 Internal Server Error for store failures.
+func defaultErrorHandler(c *router.Context, err error) {
+	var maxBytesErr *http.MaxBytesError
+	switch {
+	case errors.Is(err, ErrKeyMissing), errors.Is(err, ErrKeyInvalid):
+		c.WriteErrorResponse(http.StatusBadRequest, err.Error())
+	case errors.Is(err, ErrInFlight):
+		c.Response.Header().Set("Retry-After", "1")
+		c.WriteErrorResponse(http.StatusConflict, err.Error())
+	case errors.Is(err, ErrKeyMismatch):
+		c.WriteErrorResponse(http.StatusUnprocessableEntity, err.Error())
+	case errors.As(err, &maxBytesErr):
+		c.WriteErrorResponse(http.StatusRequestEntityTooLarge, "request body too large")
+	default:
+		c.WriteErrorResponse(http.StatusInternalServerError, "idempotency unavailable")
+	}
+}
+
+// New returns a middleware that makes retries of mutation requests safe.
+//
+// Clients send a unique key in the Idempotency-Key header. The first request
+// with a key runs normally and its response is stored. Retries with the same
+// key get the stored response, with the Idempotent-Replayed header set,
+// without running the handler again. A retry that arrives while the first
+// request is still running gets 409 Conflict; a key reused for a different
+// request (method, path, or body) gets 422 Unprocessable Entity.
+//
+// Every completed response is stored, including errors, as a retry would
+// otherwise repeat side effects the first attempt may have had. If the
+// handler panics, the key is released so that the request can be retried.
+//
+// By default the middleware applies to POST and PATCH requests that carry a
+// key, and keeps responses in memory for 24 hours.
+//
+// Example:
+//
+//	r := router.MustNew()
+//	r.Use(idempotency.New(
+//	    idempotency.WithStore(idempotency.NewRedisStore(client, "")),
+//	    idempotency.WithRequired(),
+//	))
+func New(opts ...Option) router.HandlerFunc {
+	cfg := defaultConfig()
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	if cfg.store == nil {
+		cfg.store = NewMemoryStore()
+	}
+
+	return func(c *router.Context) {
+		if !cfg.methods[c.Request.Method] || cfg.skipPaths[c.Request.URL.Path] {
+			c.Next()
+			return
+		}
+
+		key := c.Request.Header.Get(cfg.header)
+		if key == "" {
+			if cfg.required {
+				cfg.reject(c, ErrKeyMissing)
+				return
+			}
+			c.Next()
+			return
+		}
+		if len(key) > maxKeyLength {
+			cfg.reject(c, ErrKeyInvalid)
+			return
+		}
+		if cfg.scope != nil {
+			key = cfg.scope(c) + ":" + key
+		}
+
+		fingerprint, err := fingerprintOf(c.Request)
+		if err != nil {
+			cfg.reject(c, err)
+			return
+		}
+
+		ctx := c.Request.Context()
+		record, err := cfg.store.Lock(ctx, key, fingerprint, cfg.lockTimeout)
+		if err != nil {
+			cfg.reject(c, err)
+			return
+		}
+		if record != nil {
+			switch {
+			case record.Fingerprint != fingerprint:
+				cfg.reject(c, ErrKeyMismatch)
+			case record.InFlight():
+				cfg.reject(c, ErrInFlight)
+			default:
+				replay(c, record)
+				c.Abort()
+			}
+			return
+		}
+
+		cfg.run(c, key, fingerprint)
+	}
+}
+
+// run calls the next handlers and stores the response under key.
+func (cfg *config) run(c *router.Context, key, fingerprint string) {
+	// Saving must not fail because the client went away
+	ctx := context.WithoutCancel(c.Request.Context())
+	original := c.Response
+	w := &recordWriter{ResponseWriter: original, before: original.Header().Clone()}
+	c.Response = w
+
+	completed := false
+	defer func() {
+		c.Response = original
+		if completed && !w.hijacked {
+			return
+		}
+		// The request panicked or took over the connection: release the key
+		if err := cfg.store.Unlock(ctx, key); err != nil {
+			cfg.log("unlock", err)
+		}
+	}()
+
+	c.Next()
+	completed = true
+	if w.hijacked {
+		return
+	}
+	if err := cfg.store.Save(ctx, key, w.record(fingerprint), cfg.ttl); err != nil {
+		cfg.log("save", err)
+	}
+}
+
+// reject calls the error handler and aborts the chain.
+func (cfg *config) reject(c *router.Context, err error) {
+	cfg.errorHandler(c, err)
+	c.Abort()
+}
+
+// log logs a failed store operation.
+func (cfg *config) log(op string, err error) {
+	if cfg.logger != nil {
+		cfg.logger.Error("idempotency: "+op+" failed", "error", err)
+	}
+}
+
+// replay sends a stored response.
+func replay(c *router.Context, record *Record) {
+	header := c.Response.Header()
+	for name, values := range record.Header {
+		header[name] = values
+	}
+	header.Set("Idempotent-Replayed", "true")
+	c.Response.WriteHeader(record.Status)
+	_, _ = c.Response.Write(record.Body) //nolint:errcheck // The client went away
+}
+
+// fingerprintOf returns a hash of the method, path, and body of req. The
+// body is read and replaced, so that handlers can still read it.
+func fingerprintOf(req *http.Request) (string, error) {
+	h := sha256.New()
+	h.Write([]byte(req.Method + " " + req.URL.RequestURI() + "\n"))
+	if req.Body != nil && req.Body != http.NoBody {
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			return "", fmt.Errorf("idempotency: read body: %w", err)
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		h.Write(body)
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,408 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"rivaas.dev/router"
+)
+
+func TestIdempotency_Replay(t *testing.T) {
+	t.Parallel()
+	var calls atomic.Int32
+	r := router.MustNew()
+	r.Use(func(c *router.Context) {
+		c.Response.Header().Set("X-Request-ID", c.Request.Header.Get("X-Test-ID"))
+		c.Next()
+	})
+	r.Use(New())
+	r.POST("/payments", func(c *router.Context) {
+		n := calls.Add(1)
+		body, _ := io.ReadAll(c.Request.Body) //nolint:errcheck // Test handler
+		c.Response.Header().Set("Location", "/payments/1")
+		//nolint:errcheck // Test handler
+		c.JSON(http.StatusCreated, map[string]any{"call": n, "body": string(body)})
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/payments", strings.NewReader(`{"amount":100}`))
+	req.Header.Set("Idempotency-Key", "key-1")
+	first := httptest.NewRecorder()
+	r.ServeHTTP(first, req)
+	require.Equal(t, http.StatusCreated, first.Code)
+	assert.Empty(t, first.Header().Get("Idempotent-Replayed"))
+
+	req = httptest.NewRequest(http.MethodPost, "/payments", strings.NewReader(`{"amount":100}`))
+	req.Header.Set("Idempotency-Key", "key-1")
+	req.Header.Set("X-Test-ID", "retry")
+	retry := httptest.NewRecorder()
+	r.ServeHTTP(retry, req)
+
+	assert.Equal(t, http.StatusCreated, retry.Code)
+	assert.Equal(t, first.Body.String(), retry.Body.String())
+	assert.Equal(t, "/payments/1", retry.Header().Get("Location"))
+	assert.Equal(t, "true", retry.Header().Get("Idempotent-Replayed"))
+	assert.Equal(t, "retry", retry.Header().Get("X-Request-ID"), "headers of outer middleware are not replayed")
+	assert.Equal(t, int32(1), calls.Load())
+
+	// Another key runs the handler again
+	req = httptest.NewRequest(http.MethodPost, "/payments", strings.NewReader(`{"amount":100}`))
+	req.Header.Set("Idempotency-Key", "key-2")
+	other := httptest.NewRecorder()
+	r.ServeHTTP(other, req)
+	assert.Contains(t, other.Body.String(), `"call":2`)
+}
+
+func TestIdempotency_ErrorsAreReplayed(t *testing.T) {
+	t.Parallel()
+	var calls atomic.Int32
+	r := router.MustNew()
+	r.Use(New())
+	r.POST("/payments", func(c *router.Context) {
+		calls.Add(1)
+		c.WriteErrorResponse(http.StatusPaymentRequired, "card declined")
+	})
+
+	tests := []struct {
+		name     string
+		replayed string
+	}{
+		{name: "first request"},
+		{name: "retry", replayed: "true"},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodPost, "/payments", nil)
+		req.Header.Set("Idempotency-Key", "key")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusPaymentRequired, w.Code, tt.name)
+		assert.Equal(t, tt.replayed, w.Header().Get("Idempotent-Replayed"), tt.name)
+	}
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestIdempotency_KeyMismatch(t *testing.T) {
+	t.Parallel()
+	var calls atomic.Int32
+	r := router.MustNew()
+	r.Use(New())
+	r.POST("/payments", func(c *router.Context) {
+		calls.Add(1)
+		c.NoContent()
+	})
+	r.POST("/refunds", func(c *router.Context) {
+		calls.Add(1)
+		c.NoContent()
+	})
+
+	tests := []struct {
+		name string
+		path string
+		body string
+		want int
+	}{
+		{name: "first request", path: "/payments", body: `{"amount":100}`, want: http.StatusNoContent},
+		{name: "other body", path: "/payments", body: `{"amount":999}`, want: http.StatusUnprocessableEntity},
+		{name: "other path", path: "/refunds", body: `{"amount":100}`, want: http.StatusUnprocessableEntity},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body))
+		req.Header.Set("Idempotency-Key", "key")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		assert.Equal(t, tt.want, w.Code, tt.name)
+	}
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestIdempotency_InFlight(t *testing.T) {
+	t.Parallel()
+	started := make(chan struct{})
+	release := make(chan struct{})
+	r := router.MustNew()
+	r.Use(New())
+	r.POST("/slow", func(c *router.Context) {
+		close(started)
+		<-release
+		c.NoContent()
+	})
+
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		req := httptest.NewRequest(http.MethodPost, "/slow", nil)
+		req.Header.Set("Idempotency-Key", "key")
+		r.ServeHTTP(httptest.NewRecorder(), req)
+	})
+	<-started
+
+	req := httptest.NewRequest(http.MethodPost, "/slow", nil)
+	req.Header.Set("Idempotency-Key", "key")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Equal(t, "1", w.Header().Get("Retry-After"))
+
+	close(release)
+	wg.Wait()
+}
+
+func TestIdempotency_PanicReleasesKey(t *testing.T) {
+	t.Parallel()
+	var calls atomic.Int32
+	store := NewMemoryStore()
+	r := router.MustNew()
+	r.Use(New(WithStore(store)))
+	r.POST("/payments", func(*router.Context) {
+		calls.Add(1)
+		panic("boom")
+	})
+
+	for range 2 {
+		req := httptest.NewRequest(http.MethodPost, "/payments", nil)
+		req.Header.Set("Idempotency-Key", "key")
+		assert.Panics(t, func() {
+			r.ServeHTTP(httptest.NewRecorder(), req)
+		})
+		assert.Equal(t, 0, store.Len(), "the key is released")
+	}
+	assert.Equal(t, int32(2), calls.Load(), "the retry runs the handler")
+}
+
+func TestIdempotency_NotApplied(t *testing.T) {
+	t.Parallel()
+	var calls atomic.Int32
+	r := router.MustNew()
+	r.Use(New(WithSkipPaths("/health")))
+	handler := func(c *router.Context) {
+		calls.Add(1)
+		c.NoContent()
+	}
+	r.POST("/payments", handler)
+	r.GET("/payments", handler)
+	r.POST("/health", handler)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		key    string
+	}{
+		{name: "no key", method: http.MethodPost, path: "/payments"},
+		{name: "GET", method: http.MethodGet, path: "/payments", key: "key"},
+		{name: "skipped path", method: http.MethodPost, path: "/health", key: "key"},
+	}
+
+	for _, tt := range tests {
+		calls.Store(0)
+		for range 2 {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.key != "" {
+				req.Header.Set("Idempotency-Key", tt.key)
+			}
+			r.ServeHTTP(httptest.NewRecorder(), req)
+		}
+		assert.Equal(t, int32(2), calls.Load(), tt.name)
+	}
+}
+
+func TestIdempotency_InvalidKey(t *testing.T) {
+	t.Parallel()
+	var calls atomic.Int32
+	r := router.MustNew()
+	r.Use(New(WithRequired()))
+	r.POST("/payments", func(c *router.Context) {
+		calls.Add(1)
+		c.NoContent()
+	})
+
+	for _, key := range []string{"", strings.Repeat("k", 256)} {
+		req := httptest.NewRequest(http.MethodPost, "/payments", nil)
+		if key != "" {
+			req.Header.Set("Idempotency-Key", key)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	}
+	assert.Equal(t, int32(0), calls.Load())
+}
+
+func TestIdempotency_Scope(t *testing.T) {
+	t.Parallel()
+	var calls atomic.Int32
+	r := router.MustNew()
+	r.Use(New(WithScope(func(c *router.Context) string {
+		return c.Request.Header.Get("X-Client")
+	})))
+	r.POST("/payments", func(c *router.Context) {
+		calls.Add(1)
+		c.NoContent()
+	})
+
+	tests := []struct {
+		client   string
+		replayed string
+	}{
+		{client: "alice"},
+		{client: "bob"},
+		{client: "alice", replayed: "true"},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodPost, "/payments", nil)
+		req.Header.Set("Idempotency-Key", "key")
+		req.Header.Set("X-Client", tt.client)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		assert.Equal(t, tt.replayed, w.Header().Get("Idempotent-Replayed"), tt.client)
+	}
+	assert.Equal(t, int32(2), calls.Load(), "keys are per client")
+}
+
+func TestIdempotency_Options(t *testing.T) {
+	t.Parallel()
+	var calls atomic.Int32
+	store := NewMemoryStore()
+	now := time.Now()
+	store.now = func() time.Time { return now }
+	r := router.MustNew()
+	r.Use(New(
+		WithStore(store),
+		WithHeader("X-Request-Key"),
+		WithMethods(http.MethodGet),
+		WithTTL(time.Minute),
+	))
+	handler := func(c *router.Context) {
+		calls.Add(1)
+		c.NoContent()
+	}
+	r.GET("/payments", handler)
+	r.POST("/payments", handler)
+
+	tests := []struct {
+		name      string
+		method    string
+		advance   time.Duration
+		wantCalls int32
+	}{
+		{name: "first request", method: http.MethodGet, wantCalls: 1},
+		{name: "replayed", method: http.MethodGet, wantCalls: 1},
+		{name: "expired", method: http.MethodGet, advance: time.Minute, wantCalls: 2},
+		{name: "POST is not configured", method: http.MethodPost, wantCalls: 3},
+		{name: "POST is not configured", method: http.MethodPost, wantCalls: 4},
+	}
+
+	for _, tt := range tests {
+		now = now.Add(tt.advance)
+		req := httptest.NewRequest(tt.method, "/payments", nil)
+		req.Header.Set("X-Request-Key", "key")
+		r.ServeHTTP(httptest.NewRecorder(), req)
+		assert.Equal(t, tt.wantCalls, calls.Load(), tt.name)
+	}
+}
+
+// failingStore is a Store whose operations fail.
+type failingStore struct{}
+
+func (failingStore) Lock(context.Context, string, string, time.Duration) (*Record, error) {
+	return nil, errors.New("store down")
+}
+
+func (failingStore) Save(context.Context, string, *Record, time.Duration) error {
+	return errors.New("store down")
+}
+
+func (failingStore) Unlock(context.Context, string) error {
+	return errors.New("store down")
+}
+
+func TestIdempotency_StoreError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		errorHandler func(c *router.Context, err error)
+		want         int
+	}{
+		{
+			name: "custom error handler",
+			errorHandler: func(c *router.Context, err error) {
+				c.WriteErrorResponse(http.StatusServiceUnavailable, err.Error())
+			},
+			want: http.StatusServiceUnavailable,
+		},
+		{name: "default error handler", want: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var calls atomic.Int32
+			opts := []Option{WithStore(failingStore{})}
+			if tt.errorHandler != nil {
+				opts = append(opts, WithErrorHandler(tt.errorHandler))
+			}
+			r := router.MustNew()
+			r.Use(New(opts...))
+			r.POST("/payments", func(c *router.Context) {
+				calls.Add(1)
+				c.NoContent()
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/payments", nil)
+			req.Header.Set("Idempotency-Key", "key")
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			assert.Equal(t, tt.want, w.Code)
+			if tt.errorHandler != nil {
+				assert.Contains(t, w.Body.String(), "store down")
+			}
+			assert.Equal(t, int32(0), calls.Load())
+		})
+	}
+}
+
+func TestIdempotency_BodyTooLarge(t *testing.T) {
+	t.Parallel()
+	r := router.MustNew()
+	r.Use(func(c *router.Context) {
+		c.Request.Body = http.MaxBytesReader(c.Response, c.Request.Body, 4)
+		c.Next()
+	})
+	r.Use(New())
+	r.POST("/payments", func(c *router.Context) { c.NoContent() })
+
+	req := httptest.NewRequest(http.MethodPost, "/payments", strings.NewReader("too large"))
+	req.Header.Set("Idempotency-Key", "key")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+}
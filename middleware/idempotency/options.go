@@ -0,0 +1,160 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package idempotency
+
+import (
+	"log/slog"
+	"time"
+
+	"rivaas.dev/router"
+)
+
+// WithStore sets the store for idempotency records. Use a shared store such
+// as [RedisStore] when running several replicas.
+// Default: a [MemoryStore]
+//
+// Example:
+//
+//	idempotency.New(idempotency.WithStore(idempotency.NewRedisStore(client, "")))
+func WithStore(store Store) Option {
+	return func(cfg *config) {
+		cfg.store = store
+	}
+}
+
+// WithHeader sets the request header that carries the idempotency key.
+// Default: "Idempotency-Key"
+//
+// Example:
+//
+//	idempotency.New(idempotency.WithHeader("X-Request-Key"))
+func WithHeader(name string) Option {
+	return func(cfg *config) {
+		cfg.header = name
+	}
+}
+
+// WithTTL sets how long responses are stored for replay. Retries after that
+// run the handler again.
+// Default: 24 hours
+//
+// Example:
+//
+//	idempotency.New(idempotency.WithTTL(48 * time.Hour))
+func WithTTL(ttl time.Duration) Option {
+	return func(cfg *config) {
+		cfg.ttl = ttl
+	}
+}
+
+// WithLockTimeout sets how long a key stays reserved by a request that
+// doesn't complete, for example because the server crashed. Retries get 409
+// Conflict until then. Set it above your longest request duration.
+// Default: 1 minute
+//
+// Example:
+//
+//	idempotency.New(idempotency.WithLockTimeout(5 * time.Minute))
+func WithLockTimeout(timeout time.Duration) Option {
+	return func(cfg *config) {
+		cfg.lockTimeout = timeout
+	}
+}
+
+// WithMethods sets the request methods the middleware applies to.
+// Default: POST, PATCH
+//
+// Example:
+//
+//	idempotency.New(idempotency.WithMethods(http.MethodPost, http.MethodPut, http.MethodDelete))
+func WithMethods(methods ...string) Option {
+	return func(cfg *config) {
+		cfg.methods = make(map[string]bool, len(methods))
+		for _, method := range methods {
+			cfg.methods[method] = true
+		}
+	}
+}
+
+// WithRequired rejects requests without an idempotency key with 400 Bad
+// Request. By default, such requests run without idempotency protection.
+//
+// Example:
+//
+//	r.POST("/payments", createPayment, idempotency.New(idempotency.WithRequired()))
+func WithRequired() Option {
+	return func(cfg *config) {
+		cfg.required = true
+	}
+}
+
+// WithScope sets a function that returns the namespace of keys, such as the
+// authenticated client's ID. Keys are then unique per namespace, so clients
+// can't replay each other's responses by guessing keys. Register the
+// authentication middleware first.
+//
+// Example:
+//
+//	r.Use(apikey.New(apikey.WithStore(keys)))
+//	r.Use(idempotency.New(idempotency.WithScope(apikey.Owner)))
+func WithScope(scope func(c *router.Context) string) Option {
+	return func(cfg *config) {
+		cfg.scope = scope
+	}
+}
+
+// WithErrorHandler sets the function that responds to rejected requests. The
+// error wraps [ErrKeyMissing], [ErrKeyInvalid], [ErrInFlight], or
+// [ErrKeyMismatch], or is an error of the store or of reading the body. The
+// middleware aborts the chain after the handler returns.
+// Default: 400, 409, 422, or 500 with an error message
+//
+// Example:
+//
+//	idempotency.New(idempotency.WithErrorHandler(func(c *router.Context, err error) {
+//	    c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+//	}))
+func WithErrorHandler(handler func(c *router.Context, err error)) Option {
+	return func(cfg *config) {
+		cfg.errorHandler = handler
+	}
+}
+
+// WithSkipPaths sets paths the middleware does not apply to.
+//
+// Example:
+//
+//	idempotency.New(idempotency.WithSkipPaths("/webhooks"))
+func WithSkipPaths(paths ...string) Option {
+	return func(cfg *config) {
+		for _, path := range paths {
+			cfg.skipPaths[path] = true
+		}
+	}
+}
+
+// WithLogger sets the logger for failures to save responses, which happen
+// after the response is sent and can't be reported to the client. Set to
+// nil to disable logging.
+// Default: slog.Default()
+//
+// Example:
+//
+//	idempotency.New(idempotency.WithLogger(logger))
+func WithLogger(logger *slog.Logger) Option {
+	return func(cfg *config) {
+		cfg.logger = logger
+	}
+}
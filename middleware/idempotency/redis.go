@@ -0,0 +1,106 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package idempotency
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisStore is a [Store] backed by Redis, so every replica that uses the
+// same Redis deployment sees the same keys. Keys are locked with SET NX, so
+// concurrent retries on different replicas are serialized too.
+type RedisStore struct {
+	client redis.Cmdable
+	prefix string
+}
+
+// NewRedisStore creates a store that keeps records under prefix + key. An
+// empty prefix defaults to "idempotency:". client can be a *redis.Client,
+// *redis.ClusterClient, or *redis.Ring.
+//
+// Example:
+//
+//	client := redis.NewClient(&redis.Options{Addr: "localhost:6379"})
+//	r.Use(idempotency.New(
+//	    idempotency.WithStore(idempotency.NewRedisStore(client, "myapp:idempotency:")),
+//	))
+func NewRedisStore(client redis.Cmdable, prefix string) *RedisStore {
+	if prefix == "" {
+		prefix = "idempotency:"
+	}
+	return &RedisStore{client: client, prefix: prefix}
+}
+
+// Lock implements [Store].
+func (s *RedisStore) Lock(ctx context.Context, key, fingerprint string, ttl time.Duration) (*Record, error) {
+	data, err := json.Marshal(Record{Fingerprint: fingerprint})
+	if err != nil {
+		return nil, fmt.Errorf("idempotency: redis: %w", err)
+	}
+
+	// The existing record can expire between SETNX and GET, so try again
+	for range 3 {
+		ok, err := s.client.SetNX(ctx, s.prefix+key, data, ttl).Result()
+		if err != nil {
+			return nil, fmt.Errorf("idempotency: redis: %w", err)
+		}
+		if ok {
+			return nil, nil
+		}
+
+		existing, err := s.client.Get(ctx, s.prefix+key).Bytes()
+		if errors.Is(err, redis.Nil) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("idempotency: redis: %w", err)
+		}
+		var record Record
+		if err := json.Unmarshal(existing, &record); err != nil {
+			return nil, fmt.Errorf("idempotency: redis: decode record: %w", err)
+		}
+		return &record, nil
+	}
+
+	return nil, fmt.Errorf("idempotency: redis: lock %q: key keeps expiring", key)
+}
+
+// Save implements [Store].
+func (s *RedisStore) Save(ctx context.Context, key string, record *Record, ttl time.Duration) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("idempotency: redis: %w", err)
+	}
+	if err := s.client.Set(ctx, s.prefix+key, data, ttl).Err(); err != nil {
+		return fmt.Errorf("idempotency: redis: %w", err)
+	}
+
+	return nil
+}
+
+// Unlock implements [Store].
+func (s *RedisStore) Unlock(ctx context.Context, key string) error {
+	if err := s.client.Del(ctx, s.prefix+key).Err(); err != nil {
+		return fmt.Errorf("idempotency: redis: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,152 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package idempotency
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Record is the state of an idempotency key: the fingerprint of the request
+// that first used it and, once that request has completed, its response.
+type Record struct {
+	// Fingerprint identifies the request's method, path, and body.
+	Fingerprint string `json:"fingerprint"`
+
+	// Status is the response status code, or 0 while the request is in
+	// flight.
+	Status int `json:"status,omitempty"`
+
+	// Header holds the response headers.
+	Header http.Header `json:"header,omitempty"`
+
+	// Body is the response body.
+	Body []byte `json:"body,omitempty"`
+}
+
+// InFlight reports whether the request that used the key is still running.
+func (r *Record) InFlight() bool {
+	return r.Status == 0
+}
+
+// Store keeps idempotency records.
+type Store interface {
+	// Lock reserves key for a request with fingerprint for at most ttl. If
+	// key is already reserved or has a stored response, Lock leaves it
+	// unchanged and returns its record; otherwise it returns nil. Lock must
+	// be atomic, so that only one of several concurrent requests gets the
+	// reservation.
+	Lock(ctx context.Context, key, fingerprint string, ttl time.Duration) (*Record, error)
+
+	// Save stores the completed record of key for ttl, replacing the
+	// reservation.
+	Save(ctx context.Context, key string, record *Record, ttl time.Duration) error
+
+	// Unlock removes the reservation of key, so that the request can be
+	// retried.
+	Unlock(ctx context.Context, key string) error
+}
+
+// memoryEntry is a record kept by a [MemoryStore].
+type memoryEntry struct {
+	record  Record
+	expires time.Time
+}
+
+// MemoryStore is a [Store] that keeps records in memory. Records are lost on
+// restart and not shared between replicas, so it suits development, tests,
+// and single-instance deployments.
+type MemoryStore struct {
+	mu        sync.Mutex
+	records   map[string]memoryEntry
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// NewMemoryStore returns an empty in-memory store. Expired records are
+// removed as new keys are locked.
+//
+// Example:
+//
+//	r.Use(idempotency.New(idempotency.WithStore(idempotency.NewMemoryStore())))
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		records: make(map[string]memoryEntry),
+		now:     time.Now,
+	}
+}
+
+// Lock implements [Store].
+func (s *MemoryStore) Lock(_ context.Context, key, fingerprint string, ttl time.Duration) (*Record, error) {
+	now := s.now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sweep(now)
+	if entry, ok := s.records[key]; ok && now.Before(entry.expires) {
+		record := entry.record
+		return &record, nil
+	}
+	s.records[key] = memoryEntry{
+		record:  Record{Fingerprint: fingerprint},
+		expires: now.Add(ttl),
+	}
+
+	return nil, nil
+}
+
+// Save implements [Store].
+func (s *MemoryStore) Save(_ context.Context, key string, record *Record, ttl time.Duration) error {
+	now := s.now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records[key] = memoryEntry{record: *record, expires: now.Add(ttl)}
+
+	return nil
+}
+
+// Unlock implements [Store].
+func (s *MemoryStore) Unlock(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.records, key)
+
+	return nil
+}
+
+// Len returns the number of stored records, including expired records that
+// have not been removed yet.
+func (s *MemoryStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.records)
+}
+
+// sweep removes expired records, at most once a minute. It must be called
+// with s.mu held.
+func (s *MemoryStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < time.Minute {
+		return
+	}
+	s.lastSweep = now
+	for key, entry := range s.records {
+		if !now.Before(entry.expires) {
+			delete(s.records, key)
+		}
+	}
+}
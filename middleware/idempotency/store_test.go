@@ -0,0 +1,131 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package idempotency
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore(t *testing.T) {
+	t.Parallel()
+	store := NewMemoryStore()
+	now := time.Now()
+	store.now = func() time.Time { return now }
+	ctx := t.Context()
+
+	record, err := store.Lock(ctx, "key", "fp", time.Minute)
+	require.NoError(t, err)
+	assert.Nil(t, record, "the first request gets the lock")
+
+	record, err = store.Lock(ctx, "key", "other", time.Minute)
+	require.NoError(t, err)
+	require.NotNil(t, record)
+	assert.Equal(t, "fp", record.Fingerprint)
+	assert.True(t, record.InFlight())
+
+	done := &Record{
+		Fingerprint: "fp",
+		Status:      http.StatusCreated,
+		Header:      http.Header{"Location": {"/payments/1"}},
+		Body:        []byte(`{"id":1}`),
+	}
+	require.NoError(t, store.Save(ctx, "key", done, time.Hour))
+	record, err = store.Lock(ctx, "key", "fp", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, done, record)
+	assert.False(t, record.InFlight())
+
+	now = now.Add(time.Hour)
+	record, err = store.Lock(ctx, "key", "fp", time.Minute)
+	require.NoError(t, err)
+	assert.Nil(t, record, "expired")
+
+	require.NoError(t, store.Unlock(ctx, "key"))
+	record, err = store.Lock(ctx, "key", "fp", time.Minute)
+	require.NoError(t, err)
+	assert.Nil(t, record, "unlocked")
+
+	// Expired records are swept
+	now = now.Add(time.Hour)
+	_, err = store.Lock(ctx, "other", "fp", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, 1, store.Len())
+}
+
+func TestRedisStore(t *testing.T) {
+	t.Parallel()
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr(), MaxRetries: -1})
+	t.Cleanup(func() { _ = client.Close() })
+	store := NewRedisStore(client, "")
+	ctx := t.Context()
+
+	record, err := store.Lock(ctx, "key", "fp", time.Minute)
+	require.NoError(t, err)
+	assert.Nil(t, record, "the first request gets the lock")
+
+	record, err = store.Lock(ctx, "key", "other", time.Minute)
+	require.NoError(t, err)
+	require.NotNil(t, record)
+	assert.Equal(t, "fp", record.Fingerprint)
+	assert.True(t, record.InFlight())
+
+	done := &Record{
+		Fingerprint: "fp",
+		Status:      http.StatusCreated,
+		Header:      http.Header{"Location": {"/payments/1"}},
+		Body:        []byte(`{"id":1}`),
+	}
+	require.NoError(t, store.Save(ctx, "key", done, time.Hour))
+	record, err = store.Lock(ctx, "key", "fp", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, done, record)
+	assert.False(t, record.InFlight())
+
+	assert.Equal(t, time.Hour, mr.TTL("idempotency:key"), "records use the default prefix")
+	mr.FastForward(time.Hour)
+	record, err = store.Lock(ctx, "key", "fp", time.Minute)
+	require.NoError(t, err)
+	assert.Nil(t, record, "expired")
+
+	require.NoError(t, store.Unlock(ctx, "key"))
+	record, err = store.Lock(ctx, "key", "fp", time.Minute)
+	require.NoError(t, err)
+	assert.Nil(t, record, "unlocked")
+}
+
+func TestRedisStore_Error(t *testing.T) {
+	t.Parallel()
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr(), MaxRetries: -1})
+	t.Cleanup(func() { _ = client.Close() })
+	store := NewRedisStore(client, "")
+	mr.Close()
+	ctx := t.Context()
+
+	_, err := store.Lock(ctx, "key", "fp", time.Minute)
+	require.Error(t, err)
+	require.Error(t, store.Save(ctx, "key", &Record{}, time.Minute))
+	require.Error(t, store.Unlock(ctx, "key"))
+}
@@ -0,0 +1,109 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package idempotency
+
+import (
+	"bufio"
+	"bytes"
+	"net"
+	"net/http"
+	"slices"
+
+	"rivaas.dev/router"
+)
+
+// recordWriter records the response while sending it. It forwards the
+// optional interfaces the router and other middleware use.
+type recordWriter struct {
+	http.ResponseWriter
+	before   http.Header // Headers set before the handler ran
+	status   int
+	header   http.Header
+	body     bytes.Buffer
+	hijacked bool
+}
+
+func (w *recordWriter) WriteHeader(code int) {
+	if w.status == 0 {
+		w.status = code
+		w.header = w.Header().Clone()
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *recordWriter) Write(p []byte) (int, error) {
+	if w.status == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+	w.body.Write(p) //nolint:errcheck // bytes.Buffer.Write never fails
+
+	return w.ResponseWriter.Write(p)
+}
+
+// record returns the recorded response. Headers that outer middleware set
+// before the handler ran, such as a request ID, are left out, as they belong
+// to the request and not to the response.
+func (w *recordWriter) record(fingerprint string) *Record {
+	status, header := w.status, w.header
+	if status == 0 {
+		// Nothing was written; net/http sends 200 OK
+		status, header = http.StatusOK, w.Header()
+	}
+
+	record := &Record{
+		Fingerprint: fingerprint,
+		Status:      status,
+		Header:      make(http.Header, len(header)),
+		Body:        w.body.Bytes(),
+	}
+	for name, values := range header {
+		if !slices.Equal(values, w.before[name]) {
+			record.Header[name] = slices.Clone(values)
+		}
+	}
+
+	return record
+}
+
+// Written implements router.WrittenChecker.
+func (w *recordWriter) Written() bool {
+	if wc, ok := w.ResponseWriter.(router.WrittenChecker); ok {
+		return wc.Written()
+	}
+
+	return w.status != 0
+}
+
+// Hijack implements http.Hijacker.
+func (w *recordWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if hijacker, ok := w.ResponseWriter.(http.Hijacker); ok {
+		w.hijacked = true
+		return hijacker.Hijack()
+	}
+
+	return nil, nil, router.ErrResponseWriterNotHijacker
+}
+
+// Flush implements http.Flusher.
+func (w *recordWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap returns the underlying writer for http.ResponseController.
+func (w *recordWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
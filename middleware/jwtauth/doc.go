@@ -0,0 +1,75 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package jwtauth provides middleware that authenticates requests with JSON
+// Web Tokens (JWT) sent as bearer tokens.
+//
+// The middleware reads the token from the Authorization header, verifies its
+// signature, and checks its claims. Requests without a valid token are
+// rejected with 401 Unauthorized and a WWW-Authenticate: Bearer header.
+//
+// # Basic Usage
+//
+//	import "rivaas.dev/middleware/jwtauth"
+//
+//	r := router.MustNew()
+//	r.Use(jwtauth.New(
+//	    jwtauth.WithJWKS("https://auth.example.com/.well-known/jwks.json"),
+//	    jwtauth.WithIssuer("https://auth.example.com/"),
+//	    jwtauth.WithAudience("orders-api"),
+//	))
+//
+// Handlers read the token's claims with [Claims] and [Subject]:
+//
+//	r.GET("/orders", func(c *router.Context) {
+//	    userID := jwtauth.Subject(c)
+//	    scope, _ := jwtauth.Claims(c)["scope"].(string)
+//	})
+//
+// # Keys
+//
+// Signatures are verified with keys from a [KeySource]:
+//
+//   - [NewJWKS] (or [WithJWKS]): Keys of a JSON Web Key Set URL, cached and
+//     refreshed periodically. A token with an unknown key ID triggers an
+//     early, rate-limited refresh, so rotated keys work right away.
+//   - [StaticKey]: One key, such as a shared HMAC secret
+//   - [StaticKeys]: Keys by key ID
+//   - [KeySourceFunc]: Any other source, such as a secret manager
+//
+// # Claims Validation
+//
+// The exp claim is required, and exp, nbf, and iat are checked against the
+// current time, allowing for [WithLeeway]. The iss and aud claims are checked
+// if [WithIssuer] and [WithAudience] are set.
+//
+// # Algorithms
+//
+// Only asymmetric algorithms (RS*, PS*, ES*, EdDSA) are accepted by default.
+// HMAC algorithms must be enabled with [WithAlgorithms]. The key type must
+// fit the algorithm, so a public key can't be abused as an HMAC secret, and
+// unsigned tokens ("alg": "none") are always rejected.
+//
+// # Configuration Options
+//
+//   - [WithKeySource], [WithJWKS]: Verification keys (required)
+//   - [WithAlgorithms]: Accepted signing algorithms
+//   - [WithIssuer]: Required iss claim
+//   - [WithAudience]: Accepted aud claims
+//   - [WithLeeway]: Allowed clock skew
+//   - [WithCookie]: Also read the token from a cookie
+//   - [WithTokenFunc]: Custom token extraction
+//   - [WithErrorHandler]: Custom response for rejected requests
+//   - [WithSkipPaths]: Paths that bypass authentication
+package jwtauth
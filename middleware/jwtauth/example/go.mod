@@ -0,0 +1,21 @@
+module example-jwtauth
+
+go 1.25.0
+
+require (
+	github.com/golang-jwt/jwt/v5 v5.3.0
+	rivaas.dev/middleware/jwtauth v0.0.0
+	rivaas.dev/router v0.15.0
+)
+
+require (
+	github.com/kr/text v0.2.0 // indirect
+	golang.org/x/net v0.52.0 // indirect
+	golang.org/x/text v0.35.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace (
+	rivaas.dev/middleware/jwtauth => ..
+	rivaas.dev/router => ../../../router
+)
@@ -0,0 +1,84 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package main demonstrates how to use the JWT auth middleware
+// to protect an API with bearer tokens verified against a JWKS URL.
+package main
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"rivaas.dev/middleware/jwtauth"
+	"rivaas.dev/router"
+)
+
+const issuer = "http://localhost:8080/"
+
+func main() {
+	// Stand-in for an identity provider: a signing key and its key set
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		log.Fatal(err)
+	}
+	jwks := map[string]any{"keys": []map[string]string{{
+		"kty": "OKP",
+		"crv": "Ed25519",
+		"kid": "example-key",
+		"use": "sig",
+		"x":   base64.RawURLEncoding.EncodeToString(publicKey),
+	}}}
+
+	r := router.MustNew()
+
+	r.GET("/.well-known/jwks.json", func(c *router.Context) {
+		c.JSON(http.StatusOK, jwks)
+	})
+
+	api := r.Group("/api", jwtauth.New(
+		jwtauth.WithJWKS(issuer+".well-known/jwks.json"),
+		jwtauth.WithIssuer(issuer),
+		jwtauth.WithAudience("example-api"),
+	))
+	api.GET("/me", func(c *router.Context) {
+		c.JSON(http.StatusOK, map[string]any{
+			"user":  jwtauth.Subject(c),
+			"scope": jwtauth.Claims(c)["scope"],
+		})
+	})
+
+	token := jwt.NewWithClaims(jwt.SigningMethodEdDSA, jwt.MapClaims{
+		"sub":   "alice",
+		"iss":   issuer,
+		"aud":   "example-api",
+		"scope": "orders:read",
+		"exp":   time.Now().Add(time.Hour).Unix(),
+	})
+	token.Header["kid"] = "example-key"
+	signed, err := token.SignedString(privateKey)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	log.Println("Server starting on http://localhost:8080")
+	log.Println("Without a token: curl -i http://localhost:8080/api/me (401)")
+	log.Printf("With a token: curl -H 'Authorization: Bearer %s' http://localhost:8080/api/me", signed)
+	log.Fatal(http.ListenAndServe(":8080", r))
+}
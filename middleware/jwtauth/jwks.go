@@ -0,0 +1,346 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jwtauth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// maxJWKSSize limits the size of JWKS responses.
+const maxJWKSSize = 1 << 20
+
+// JWKS is a [KeySource] that fetches keys from a JSON Web Key Set URL, such
+// as an identity provider's jwks_uri, and caches them.
+//
+// Keys are refreshed periodically. A token with an unknown key ID triggers an
+// early refresh, rate limited, so that rotated keys are picked up as soon as
+// the provider publishes them. If a refresh fails, the cached keys are kept
+// and served, and the next fetch waits for the minimum refresh interval.
+type JWKS struct {
+	url                string
+	client             *http.Client
+	refreshInterval    time.Duration
+	minRefreshInterval time.Duration
+	now                func() time.Time
+
+	mu          sync.Mutex
+	keys        map[string]any // By key ID
+	fetchedAt   time.Time      // Of the last successful fetch
+	attemptedAt time.Time      // Of the last fetch, successful or not
+	inflight    *jwksFetch     // The fetch in progress, if any
+}
+
+// JWKSOption configures a [JWKS].
+type JWKSOption func(*JWKS)
+
+// WithHTTPClient sets the HTTP client used to fetch the key set.
+// Default: a client with a 10 second timeout
+func WithHTTPClient(client *http.Client) JWKSOption {
+	return func(j *JWKS) {
+		j.client = client
+	}
+}
+
+// WithRefreshInterval sets how long fetched keys are used before the key set
+// is fetched again.
+// Default: 1 hour
+func WithRefreshInterval(d time.Duration) JWKSOption {
+	return func(j *JWKS) {
+		j.refreshInterval = d
+	}
+}
+
+// WithMinRefreshInterval sets the minimum time between two fetches, which
+// limits the fetches triggered by tokens with unknown key IDs.
+// Default: 5 minutes
+func WithMinRefreshInterval(d time.Duration) JWKSOption {
+	return func(j *JWKS) {
+		j.minRefreshInterval = d
+	}
+}
+
+// NewJWKS returns a key source for the JSON Web Key Set at url. Keys are
+// fetched on first use.
+//
+// Example:
+//
+//	jwks := jwtauth.NewJWKS("https://auth.example.com/.well-known/jwks.json")
+//	r.Use(jwtauth.New(
+//	    jwtauth.WithKeySource(jwks),
+//	    jwtauth.WithIssuer("https://auth.example.com/"),
+//	    jwtauth.WithAudience("orders-api"),
+//	))
+func NewJWKS(url string, opts ...JWKSOption) *JWKS {
+	j := &JWKS{
+		url:                url,
+		client:             &http.Client{Timeout: 10 * time.Second},
+		refreshInterval:    time.Hour,
+		minRefreshInterval: 5 * time.Minute,
+		now:                time.Now,
+	}
+	for _, opt := range opts {
+		opt(j)
+	}
+
+	return j
+}
+
+// Key returns the key with the key ID kid. If kid is empty, the key set must
+// contain exactly one key.
+func (j *JWKS) Key(ctx context.Context, kid, _ string) (any, error) {
+	now := j.now()
+
+	j.mu.Lock()
+	cached := j.keys != nil
+	expired := !cached || now.Sub(j.fetchedAt) >= j.refreshInterval
+	j.mu.Unlock()
+
+	if expired {
+		if err := j.refresh(ctx, now); err != nil && !cached {
+			return nil, err
+		}
+	}
+	if key, ok := j.lookup(kid); ok {
+		return key, nil
+	}
+
+	// The key may have been rotated in since the last fetch. If the provider
+	// is unavailable, the cached keys decide.
+	if err := j.refresh(ctx, now); err == nil {
+		if key, ok := j.lookup(kid); ok {
+			return key, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: kid %q", ErrKeyNotFound, kid)
+}
+
+// lookup returns the cached key with the key ID kid, or the only key if kid
+// is empty.
+func (j *JWKS) lookup(kid string) (any, bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if kid == "" {
+		if len(j.keys) != 1 {
+			return nil, false
+		}
+		for _, key := range j.keys {
+			return key, true
+		}
+	}
+	key, ok := j.keys[kid]
+
+	return key, ok
+}
+
+// jwksFetch is a fetch of the key set in progress.
+type jwksFetch struct {
+	done chan struct{}
+	err  error
+}
+
+// refresh fetches the key set and replaces the cached keys. Once keys are
+// cached, fetches are at least minRefreshInterval apart, successful or not,
+// and refresh returns nil without fetching in between. Concurrent callers
+// share one fetch, which runs without j.mu held.
+func (j *JWKS) refresh(ctx context.Context, now time.Time) error {
+	j.mu.Lock()
+	if f := j.inflight; f != nil {
+		j.mu.Unlock()
+		select {
+		case <-f.done:
+			return f.err
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	if j.keys != nil && now.Sub(j.attemptedAt) < j.minRefreshInterval {
+		j.mu.Unlock()
+		return nil
+	}
+	f := &jwksFetch{done: make(chan struct{})}
+	j.inflight = f
+	j.attemptedAt = now
+	j.mu.Unlock()
+
+	keys, err := j.fetch(ctx)
+
+	j.mu.Lock()
+	if err == nil {
+		j.keys = keys
+		j.fetchedAt = now
+	}
+	j.inflight = nil
+	j.mu.Unlock()
+
+	f.err = err
+	close(f.done)
+
+	return err
+}
+
+// fetch downloads and parses the key set.
+func (j *JWKS) fetch(ctx context.Context) (map[string]any, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, j.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("jwtauth: fetch JWKS: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := j.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("jwtauth: fetch JWKS: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck // Read-only body
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("jwtauth: fetch JWKS: unexpected status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxJWKSSize))
+	if err != nil {
+		return nil, fmt.Errorf("jwtauth: fetch JWKS: %w", err)
+	}
+
+	return ParseJWKS(body)
+}
+
+// jwk is a JSON Web Key (RFC 7517) with the members of public RSA, EC, and
+// OKP keys.
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// ParseJWKS parses a JSON Web Key Set and returns its signature keys by key
+// ID. Keys of unsupported types and encryption keys are skipped.
+func ParseJWKS(data []byte) (map[string]any, error) {
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("jwtauth: parse JWKS: %w", err)
+	}
+
+	keys := make(map[string]any, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		key, err := k.publicKey()
+		if errors.Is(err, errUnsupportedKey) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("jwtauth: parse JWKS: key %q: %w", k.Kid, err)
+		}
+		keys[k.Kid] = key
+	}
+
+	return keys, nil
+}
+
+var errUnsupportedKey = errors.New("unsupported key type")
+
+// publicKey returns the public key of k.
+func (k *jwk) publicKey() (any, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() || e.Int64() < 3 || e.Int64() > 1<<31-1 {
+			return nil, errors.New("invalid RSA exponent")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, errUnsupportedKey
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		if !curve.IsOnCurve(x, y) { //nolint:staticcheck // Validates untrusted points before use
+			return nil, errors.New("EC point not on curve")
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, errUnsupportedKey
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid Ed25519 key size")
+		}
+		return ed25519.PublicKey(x), nil
+
+	default:
+		return nil, errUnsupportedKey
+	}
+}
+
+// decodeBigInt decodes a base64url-encoded big-endian integer.
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) == 0 {
+		return nil, errors.New("empty integer")
+	}
+
+	return new(big.Int).SetBytes(b), nil
+}
@@ -0,0 +1,291 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package jwtauth
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"rivaas.dev/router"
+)
+
+func b64(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// rsaJWK returns the JWK of key.
+func rsaJWK(kid string, key *rsa.PublicKey) map[string]string {
+	return map[string]string{
+		"kty": "RSA",
+		"kid": kid,
+		"use": "sig",
+		"n":   b64(key.N.Bytes()),
+		"e":   b64(big.NewInt(int64(key.E)).Bytes()),
+	}
+}
+
+// jwksServer serves a key set that can be replaced, and counts fetches.
+type jwksServer struct {
+	*httptest.Server
+	mu      sync.Mutex
+	keys    []map[string]string
+	fetches atomic.Int32
+	fail    atomic.Bool
+}
+
+func newJWKSServer(t *testing.T, keys ...map[string]string) *jwksServer {
+	t.Helper()
+	s := &jwksServer{keys: keys}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		s.fetches.Add(1)
+		if s.fail.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		//nolint:errcheck // Test handler
+		json.NewEncoder(w).Encode(map[string]any{"keys": s.keys})
+	}))
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+func (s *jwksServer) setKeys(keys ...map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys = keys
+}
+
+func TestJWKS_Middleware(t *testing.T) {
+	t.Parallel()
+	server := newJWKSServer(t, rsaJWK("k1", &testKey.PublicKey))
+	r := router.MustNew()
+	r.Use(New(WithJWKS(server.URL)))
+	r.GET("/me", echoSubject)
+
+	for range 3 {
+		w := get(t, r, "/me", "Bearer "+sign(t, jwt.SigningMethodRS256, testKey, "k1", validClaims()))
+		assert.Equal(t, http.StatusOK, w.Code)
+	}
+	assert.Equal(t, int32(1), server.fetches.Load(), "keys are cached")
+}
+
+func TestJWKS_Rotation(t *testing.T) {
+	t.Parallel()
+	newKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	server := newJWKSServer(t, rsaJWK("k1", &testKey.PublicKey))
+
+	now := time.Now()
+	var mu sync.Mutex
+	jwks := NewJWKS(server.URL, WithMinRefreshInterval(time.Minute))
+	jwks.now = func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return now
+	}
+	advance := func(d time.Duration) {
+		mu.Lock()
+		defer mu.Unlock()
+		now = now.Add(d)
+	}
+	ctx := t.Context()
+
+	key, err := jwks.Key(ctx, "k1", "RS256")
+	require.NoError(t, err)
+	assert.Equal(t, &testKey.PublicKey, key)
+
+	// The provider publishes a new key
+	server.setKeys(rsaJWK("k1", &testKey.PublicKey), rsaJWK("k2", &newKey.PublicKey))
+	_, err = jwks.Key(ctx, "k2", "RS256")
+	require.ErrorIs(t, err, ErrKeyNotFound, "refresh is rate limited")
+	assert.Equal(t, int32(1), server.fetches.Load())
+
+	advance(time.Minute)
+	key, err = jwks.Key(ctx, "k2", "RS256")
+	require.NoError(t, err)
+	assert.Equal(t, &newKey.PublicKey, key)
+	assert.Equal(t, int32(2), server.fetches.Load())
+
+	// Unknown key IDs don't trigger more fetches within the interval
+	for range 5 {
+		_, err = jwks.Key(ctx, "forged", "RS256")
+		require.ErrorIs(t, err, ErrKeyNotFound)
+	}
+	assert.Equal(t, int32(2), server.fetches.Load())
+
+	// Cached keys survive a failing provider
+	server.fail.Store(true)
+	advance(2 * time.Hour)
+	key, err = jwks.Key(ctx, "k1", "RS256")
+	require.NoError(t, err)
+	assert.Equal(t, &testKey.PublicKey, key)
+	assert.Equal(t, int32(3), server.fetches.Load())
+}
+
+func TestJWKS_FailingProvider(t *testing.T) {
+	t.Parallel()
+	server := newJWKSServer(t, rsaJWK("k1", &testKey.PublicKey))
+
+	now := time.Now()
+	var mu sync.Mutex
+	jwks := NewJWKS(server.URL, WithRefreshInterval(time.Hour), WithMinRefreshInterval(time.Minute))
+	jwks.now = func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return now
+	}
+	advance := func(d time.Duration) {
+		mu.Lock()
+		defer mu.Unlock()
+		now = now.Add(d)
+	}
+	ctx := t.Context()
+
+	_, err := jwks.Key(ctx, "k1", "RS256")
+	require.NoError(t, err)
+
+	// The cache expires while the provider is down
+	server.fail.Store(true)
+	advance(2 * time.Hour)
+	for range 5 {
+		key, keyErr := jwks.Key(ctx, "k1", "RS256")
+		require.NoError(t, keyErr, "cached keys are served")
+		assert.Equal(t, &testKey.PublicKey, key)
+
+		_, keyErr = jwks.Key(ctx, "forged", "RS256")
+		require.ErrorIs(t, keyErr, ErrKeyNotFound, "unknown key IDs are rejected, not unavailable")
+	}
+	assert.Equal(t, int32(2), server.fetches.Load(), "failed fetches are rate limited")
+
+	r := router.MustNew()
+	r.Use(New(WithKeySource(jwks)))
+	r.GET("/me", echoSubject)
+
+	w := get(t, r, "/me", "Bearer "+sign(t, jwt.SigningMethodRS256, testKey, "forged", validClaims()))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	advance(time.Minute)
+	_, err = jwks.Key(ctx, "k1", "RS256")
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), server.fetches.Load())
+
+	// The provider recovers
+	server.fail.Store(false)
+	advance(time.Minute)
+	_, err = jwks.Key(ctx, "k1", "RS256")
+	require.NoError(t, err)
+	assert.Equal(t, int32(4), server.fetches.Load())
+	_, err = jwks.Key(ctx, "k1", "RS256")
+	require.NoError(t, err)
+	assert.Equal(t, int32(4), server.fetches.Load(), "fresh keys are cached again")
+}
+
+func TestJWKS_Unavailable(t *testing.T) {
+	t.Parallel()
+	server := newJWKSServer(t)
+	server.fail.Store(true)
+	jwks := NewJWKS(server.URL)
+
+	_, err := jwks.Key(t.Context(), "k1", "RS256")
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, ErrKeyNotFound)
+
+	r := router.MustNew()
+	r.Use(New(WithKeySource(jwks)))
+	r.GET("/me", echoSubject)
+
+	w := get(t, r, "/me", "Bearer "+sign(t, jwt.SigningMethodRS256, testKey, "k1", validClaims()))
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestJWKS_EmptyKeyID(t *testing.T) {
+	t.Parallel()
+	server := newJWKSServer(t, rsaJWK("k1", &testKey.PublicKey))
+	jwks := NewJWKS(server.URL)
+
+	key, err := jwks.Key(t.Context(), "", "RS256")
+	require.NoError(t, err)
+	assert.Equal(t, &testKey.PublicKey, key)
+
+	server.setKeys(rsaJWK("k1", &testKey.PublicKey), rsaJWK("k2", &testKey.PublicKey))
+	jwks = NewJWKS(server.URL)
+	_, err = jwks.Key(t.Context(), "", "RS256")
+	require.ErrorIs(t, err, ErrKeyNotFound, "ambiguous without a key ID")
+}
+
+func TestParseJWKS(t *testing.T) {
+	t.Parallel()
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	edPub, edPriv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	data, err := json.Marshal(map[string]any{"keys": []map[string]string{
+		rsaJWK("rsa", &testKey.PublicKey),
+		{"kty": "EC", "kid": "ec", "crv": "P-256", "x": b64(ecKey.X.Bytes()), "y": b64(ecKey.Y.Bytes())},
+		{"kty": "OKP", "kid": "ed", "crv": "Ed25519", "x": b64(edPub)},
+		{"kty": "RSA", "kid": "enc", "use": "enc", "n": "AQAB", "e": "AQAB"},
+		{"kty": "oct", "kid": "secret", "k": "c2VjcmV0"},
+		{"kty": "OKP", "kid": "x25519", "crv": "X25519", "x": b64(edPub)},
+	}})
+	require.NoError(t, err)
+
+	keys, err := ParseJWKS(data)
+	require.NoError(t, err)
+	assert.Len(t, keys, 3)
+	assert.Equal(t, &testKey.PublicKey, keys["rsa"])
+	assert.True(t, ecKey.PublicKey.Equal(keys["ec"]))
+	assert.Equal(t, edPub, keys["ed"])
+
+	// The parsed keys verify tokens
+	r := router.MustNew()
+	r.Use(New(WithKeySource(StaticKeys(keys))))
+	r.GET("/me", echoSubject)
+
+	assert.Equal(t, http.StatusOK, get(t, r, "/me", "Bearer "+sign(t, jwt.SigningMethodES256, ecKey, "ec", validClaims())).Code)
+	assert.Equal(t, http.StatusOK, get(t, r, "/me", "Bearer "+sign(t, jwt.SigningMethodEdDSA, edPriv, "ed", validClaims())).Code)
+
+	invalid := []string{
+		`not json`,
+		`{"keys":[{"kty":"EC","kid":"bad","crv":"P-256","x":"AQ","y":"AQ"}]}`,
+		`{"keys":[{"kty":"RSA","kid":"bad","n":"AQAB","e":""}]}`,
+		`{"keys":[{"kty":"OKP","kid":"bad","crv":"Ed25519","x":"AQAB"}]}`,
+	}
+	for _, data := range invalid {
+		_, err := ParseJWKS([]byte(data))
+		assert.Error(t, err, data)
+	}
+}
@@ -0,0 +1,266 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jwtauth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"rivaas.dev/router"
+)
+
+// Errors passed to the error handler when a request is rejected.
+var (
+	// ErrTokenMissing is returned when the request carries no bearer token.
+	ErrTokenMissing = errors.New("jwtauth: token missing")
+
+	// ErrTokenInvalid is returned when the token is malformed, its signature
+	// does not verify, or its claims are not valid. The error passed to the
+	// error handler wraps it together with the cause.
+	ErrTokenInvalid = errors.New("jwtauth: token invalid")
+)
+
+type contextKey struct{}
+
+// Option defines functional options for jwtauth middleware configuration.
+type Option func(*config)
+
+// config holds the configuration for the jwtauth middleware.
+type config struct {
+	// keys provides the verification keys
+	keys KeySource
+
+	// algorithms are the accepted signing algorithms
+	algorithms []string
+
+	// issuer is the required iss claim; empty accepts any
+	issuer string
+
+	// audience holds the accepted aud claims; empty accepts any
+	audience []string
+
+	// leeway is the allowed clock skew for exp, nbf, and iat
+	leeway time.Duration
+
+	// cookieName is a cookie to read the token from if there is no header
+	cookieName string
+
+	// tokenFunc extracts the token; overrides header and cookie lookup
+	tokenFunc func(c *router.Context) string
+
+	// errorHandler is called when a request is rejected
+	errorHandler func(c *router.Context, err error)
+
+	// skipPaths are paths that should bypass authentication
+	skipPaths map[string]bool
+
+	// now returns the current time; replaced in tests
+	now func() time.Time
+}
+
+// defaultAlgorithms are the asymmetric algorithms accepted by default. HMAC
+// algorithms must be enabled explicitly with [WithAlgorithms], so that a
+// public key can never be used as an HMAC secret.
+var defaultAlgorithms = []string{
+	"RS256", "RS384", "RS512",
+	"PS256", "PS384", "PS512",
+	"ES256", "ES384", "ES512",
+	"EdDSA",
+}
+
+// defaultConfig returns the default configuration for jwtauth middleware.
+func defaultConfig() *config {
+	return &config{
+		algorithms:   defaultAlgorithms,
+		errorHandler: defaultErrorHandler,
+		skipPaths:    make(map[string]bool),
+		now:          time.Now,
+	}
+}
+
+// defaultErrorHandler sends a 401 Unauthorized response, or 500 Internal
+// Server Error if the key source failed.
+func defaultErrorHandler(c *router.Context, err error) {
+	if errors.Is(err, ErrTokenMissing) || errors.Is(err, ErrTokenInvalid) {
+		//nolint:errcheck // Best-effort error response
+		c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "Unauthorized",
+			"code":  "UNAUTHORIZED",
+		})
+		return
+	}
+	c.WriteErrorResponse(http.StatusInternalServerError, "authentication unavailable")
+}
+
+// New returns a middleware that authenticates requests with JSON Web Tokens
+// sent as bearer tokens (RFC 6750).
+//
+// The token's signature is verified with a key from the configured
+// [KeySource], and its exp claim is required. The iss and aud claims are
+// checked if [WithIssuer] and [WithAudience] are set. Requests without a
+// valid token are rejected with 401 Unauthorized and a WWW-Authenticate
+// header. On success, the claims are available through [Claims].
+//
+// New panics if no key source is configured.
+//
+// Basic usage with an identity provider's key set:
+//
+//	r := router.MustNew()
+//	r.Use(jwtauth.New(
+//	    jwtauth.WithJWKS("https://auth.example.com/.well-known/jwks.json"),
+//	    jwtauth.WithIssuer("https://auth.example.com/"),
+//	    jwtauth.WithAudience("orders-api"),
+//	))
+//
+// With a shared secret:
+//
+//	r.Use(jwtauth.New(
+//	    jwtauth.WithKeySource(jwtauth.StaticKey(secret)),
+//	    jwtauth.WithAlgorithms("HS256"),
+//	))
+func New(opts ...Option) router.HandlerFunc {
+	cfg := defaultConfig()
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	if cfg.keys == nil {
+		panic("jwtauth: a key source is required, use WithKeySource or WithJWKS")
+	}
+
+	parserOpts := []jwt.ParserOption{
+		jwt.WithValidMethods(cfg.algorithms),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(cfg.leeway),
+		jwt.WithTimeFunc(cfg.now),
+	}
+	if cfg.issuer != "" {
+		parserOpts = append(parserOpts, jwt.WithIssuer(cfg.issuer))
+	}
+	if len(cfg.audience) > 0 {
+		parserOpts = append(parserOpts, jwt.WithAudience(cfg.audience...))
+	}
+	parser := jwt.NewParser(parserOpts...)
+
+	return func(c *router.Context) {
+		if cfg.skipPaths[c.Request.URL.Path] {
+			c.Next()
+			return
+		}
+
+		raw := cfg.extractToken(c)
+		if raw == "" {
+			c.Response.Header().Set("WWW-Authenticate", "Bearer")
+			cfg.errorHandler(c, ErrTokenMissing)
+			c.Abort()
+
+			return
+		}
+
+		claims, err := cfg.verify(c.Request.Context(), parser, raw)
+		if err != nil {
+			if errors.Is(err, ErrTokenInvalid) {
+				c.Response.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+			}
+			cfg.errorHandler(c, err)
+			c.Abort()
+
+			return
+		}
+
+		ctx := context.WithValue(c.Request.Context(), contextKey{}, claims)
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
+// verify parses raw and validates its signature and claims. Errors caused by
+// the token wrap [ErrTokenInvalid]; errors of the key source are returned
+// as they are.
+func (cfg *config) verify(ctx context.Context, parser *jwt.Parser, raw string) (jwt.MapClaims, error) {
+	var keyErr error
+	claims := jwt.MapClaims{}
+	_, err := parser.ParseWithClaims(raw, claims, func(token *jwt.Token) (any, error) {
+		kid, _ := token.Header["kid"].(string)
+		key, err := cfg.keys.Key(ctx, kid, token.Method.Alg())
+		if err != nil && !errors.Is(err, ErrKeyNotFound) {
+			keyErr = err
+		}
+
+		return key, err
+	})
+	if keyErr != nil {
+		return nil, fmt.Errorf("jwtauth: get key: %w", keyErr)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrTokenInvalid, err)
+	}
+
+	return claims, nil
+}
+
+// extractToken returns the bearer token of the request of c, or an empty
+// string if there is none.
+func (cfg *config) extractToken(c *router.Context) string {
+	if cfg.tokenFunc != nil {
+		return cfg.tokenFunc(c)
+	}
+
+	const prefix = "Bearer "
+	auth := c.Request.Header.Get("Authorization")
+	if len(auth) > len(prefix) && strings.EqualFold(auth[:len(prefix)], prefix) {
+		return strings.TrimSpace(auth[len(prefix):])
+	}
+	if cfg.cookieName != "" {
+		if cookie, err := c.Request.Cookie(cfg.cookieName); err == nil {
+			return cookie.Value
+		}
+	}
+
+	return ""
+}
+
+// Claims returns the claims of the authenticated request's token, or nil if
+// the request was not authenticated.
+//
+// Example:
+//
+//	func handler(c *router.Context) {
+//	    claims := jwtauth.Claims(c)
+//	    scope, _ := claims["scope"].(string)
+//	}
+func Claims(c *router.Context) jwt.MapClaims {
+	claims, _ := c.Request.Context().Value(contextKey{}).(jwt.MapClaims)
+	return claims
+}
+
+// Subject returns the sub claim of the authenticated request's token, or an
+// empty string if the request was not authenticated.
+//
+// Example:
+//
+//	func handler(c *router.Context) {
+//	    orders := store.OrdersOf(jwtauth.Subject(c))
+//	}
+func Subject(c *router.Context) string {
+	sub, _ := Claims(c).GetSubject()
+	return sub
+}
@@ -0,0 +1,361 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package jwtauth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"rivaas.dev/router"
+)
+
+// testKey is shared by tests; generating RSA keys is slow.
+var testKey = func() *rsa.PrivateKey {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+	return key
+}()
+
+// sign returns a token with claims signed by key using method.
+func sign(t *testing.T, method jwt.SigningMethod, key any, kid string, claims jwt.MapClaims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	raw, err := token.SignedString(key)
+	require.NoError(t, err)
+
+	return raw
+}
+
+// validClaims returns claims that pass the issuer and audience checks of
+// TestJWTAuth_Validation.
+func validClaims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"sub":   "user-1",
+		"iss":   "https://auth.example.com/",
+		"aud":   "orders-api",
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"scope": "orders:read",
+	}
+}
+
+// echoSubject responds with the subject of the token.
+func echoSubject(c *router.Context) {
+	//nolint:errcheck // Test handler
+	c.String(http.StatusOK, Subject(c))
+}
+
+// get requests path with the given Authorization header.
+func get(t *testing.T, r http.Handler, path, auth string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, path, nil)
+	if auth != "" {
+		req.Header.Set("Authorization", auth)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	return w
+}
+
+func TestJWTAuth_Validation(t *testing.T) {
+	t.Parallel()
+	r := router.MustNew()
+	r.Use(New(
+		WithKeySource(StaticKey(&testKey.PublicKey)),
+		WithIssuer("https://auth.example.com/"),
+		WithAudience("orders-api"),
+	))
+	r.GET("/me", echoSubject)
+
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	with := func(key string, value any) jwt.MapClaims {
+		claims := validClaims()
+		if value == nil {
+			delete(claims, key)
+		} else {
+			claims[key] = value
+		}
+		return claims
+	}
+
+	tests := []struct {
+		name       string
+		auth       string
+		wantStatus int
+		wantHeader string
+	}{
+		{
+			name:       "valid token",
+			auth:       "Bearer " + sign(t, jwt.SigningMethodRS256, testKey, "", validClaims()),
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "lowercase scheme",
+			auth:       "bearer " + sign(t, jwt.SigningMethodRS256, testKey, "", validClaims()),
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "no token",
+			wantStatus: http.StatusUnauthorized,
+			wantHeader: "Bearer",
+		},
+		{
+			name:       "basic auth",
+			auth:       "Basic dXNlcjpwYXNz",
+			wantStatus: http.StatusUnauthorized,
+			wantHeader: "Bearer",
+		},
+		{
+			name:       "malformed token",
+			auth:       "Bearer not.a.token",
+			wantStatus: http.StatusUnauthorized,
+			wantHeader: `Bearer error="invalid_token"`,
+		},
+		{
+			name:       "wrong key",
+			auth:       "Bearer " + sign(t, jwt.SigningMethodRS256, otherKey, "", validClaims()),
+			wantStatus: http.StatusUnauthorized,
+			wantHeader: `Bearer error="invalid_token"`,
+		},
+		{
+			name:       "expired",
+			auth:       "Bearer " + sign(t, jwt.SigningMethodRS256, testKey, "", with("exp", time.Now().Add(-time.Minute).Unix())),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "no expiration",
+			auth:       "Bearer " + sign(t, jwt.SigningMethodRS256, testKey, "", with("exp", nil)),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "not yet valid",
+			auth:       "Bearer " + sign(t, jwt.SigningMethodRS256, testKey, "", with("nbf", time.Now().Add(time.Hour).Unix())),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "wrong issuer",
+			auth:       "Bearer " + sign(t, jwt.SigningMethodRS256, testKey, "", with("iss", "https://evil.example.com/")),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "wrong audience",
+			auth:       "Bearer " + sign(t, jwt.SigningMethodRS256, testKey, "", with("aud", "billing-api")),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "audience list",
+			auth:       "Bearer " + sign(t, jwt.SigningMethodRS256, testKey, "", with("aud", []string{"billing-api", "orders-api"})),
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "alg none",
+			auth:       "Bearer " + sign(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, "", validClaims()),
+			wantStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			w := get(t, r, "/me", tt.auth)
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantStatus == http.StatusOK {
+				assert.Equal(t, "user-1", w.Body.String())
+			}
+			if tt.wantHeader != "" {
+				assert.Equal(t, tt.wantHeader, w.Header().Get("WWW-Authenticate"))
+			}
+		})
+	}
+}
+
+func TestJWTAuth_AlgorithmConfusion(t *testing.T) {
+	t.Parallel()
+
+	// HMAC is not accepted by default, even with a []byte key
+	secret := []byte("0123456789abcdef0123456789abcdef")
+	r := router.MustNew()
+	r.Use(New(WithKeySource(StaticKey(secret))))
+	r.GET("/me", echoSubject)
+
+	w := get(t, r, "/me", "Bearer "+sign(t, jwt.SigningMethodHS256, secret, "", validClaims()))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	r = router.MustNew()
+	r.Use(New(WithKeySource(StaticKey(secret)), WithAlgorithms("HS256")))
+	r.GET("/me", echoSubject)
+
+	w = get(t, r, "/me", "Bearer "+sign(t, jwt.SigningMethodHS256, secret, "", validClaims()))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// An RSA public key is never an HMAC secret
+	r = router.MustNew()
+	r.Use(New(WithKeySource(StaticKey(&testKey.PublicKey)), WithAlgorithms("RS256", "HS256")))
+	r.GET("/me", echoSubject)
+
+	w = get(t, r, "/me", "Bearer "+sign(t, jwt.SigningMethodHS256, []byte("forged"), "", validClaims()))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestJWTAuth_Claims(t *testing.T) {
+	t.Parallel()
+	r := router.MustNew()
+	r.Use(New(WithKeySource(StaticKey(&testKey.PublicKey))))
+	var claims jwt.MapClaims
+	r.GET("/me", func(c *router.Context) {
+		claims = Claims(c)
+		c.NoContent()
+	})
+
+	w := get(t, r, "/me", "Bearer "+sign(t, jwt.SigningMethodRS256, testKey, "", validClaims()))
+	require.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, "orders:read", claims["scope"])
+
+	c := router.NewContext(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Nil(t, Claims(c))
+	assert.Empty(t, Subject(c))
+}
+
+func TestJWTAuth_TokenLookup(t *testing.T) {
+	t.Parallel()
+	token := sign(t, jwt.SigningMethodRS256, testKey, "", validClaims())
+
+	r := router.MustNew()
+	r.Use(New(WithKeySource(StaticKey(&testKey.PublicKey)), WithCookie("access_token")))
+	r.GET("/me", echoSubject)
+
+	req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/me", nil)
+	req.AddCookie(&http.Cookie{Name: "access_token", Value: token})
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	r = router.MustNew()
+	r.Use(New(
+		WithKeySource(StaticKey(&testKey.PublicKey)),
+		WithTokenFunc(func(c *router.Context) string { return c.Query("access_token") }),
+	))
+	r.GET("/me", echoSubject)
+
+	w = get(t, r, "/me?access_token="+token, "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = get(t, r, "/me", "Bearer "+token)
+	assert.Equal(t, http.StatusUnauthorized, w.Code, "the token func replaces the header lookup")
+}
+
+func TestJWTAuth_Leeway(t *testing.T) {
+	t.Parallel()
+	claims := validClaims()
+	claims["exp"] = time.Now().Add(-10 * time.Second).Unix()
+	auth := "Bearer " + sign(t, jwt.SigningMethodRS256, testKey, "", claims)
+
+	r := router.MustNew()
+	r.Use(New(WithKeySource(StaticKey(&testKey.PublicKey))))
+	r.GET("/me", echoSubject)
+
+	assert.Equal(t, http.StatusUnauthorized, get(t, r, "/me", auth).Code)
+
+	r = router.MustNew()
+	r.Use(New(WithKeySource(StaticKey(&testKey.PublicKey)), WithLeeway(time.Minute)))
+	r.GET("/me", echoSubject)
+
+	assert.Equal(t, http.StatusOK, get(t, r, "/me", auth).Code)
+}
+
+func TestJWTAuth_ErrorHandler(t *testing.T) {
+	t.Parallel()
+	var gotErr error
+	handler := WithErrorHandler(func(c *router.Context, err error) {
+		gotErr = err
+		c.WriteErrorResponse(http.StatusTeapot, "")
+	})
+
+	r := router.MustNew()
+	r.Use(New(WithKeySource(StaticKey(&testKey.PublicKey)), handler))
+	r.GET("/me", echoSubject)
+
+	assert.Equal(t, http.StatusTeapot, get(t, r, "/me", "").Code)
+	require.ErrorIs(t, gotErr, ErrTokenMissing)
+
+	assert.Equal(t, http.StatusTeapot, get(t, r, "/me", "Bearer x.y.z").Code)
+	require.ErrorIs(t, gotErr, ErrTokenInvalid)
+
+	// Key source failures are not the client's fault
+	errDown := errors.New("key service down")
+	r = router.MustNew()
+	r.Use(New(WithKeySource(KeySourceFunc(func(context.Context, string, string) (any, error) {
+		return nil, errDown
+	}))))
+	r.GET("/me", echoSubject)
+
+	w := get(t, r, "/me", "Bearer "+sign(t, jwt.SigningMethodRS256, testKey, "", validClaims()))
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Empty(t, w.Header().Get("WWW-Authenticate"))
+}
+
+func TestJWTAuth_SkipPaths(t *testing.T) {
+	t.Parallel()
+	r := router.MustNew()
+	r.Use(New(WithKeySource(StaticKey(&testKey.PublicKey)), WithSkipPaths("/health")))
+	r.GET("/me", echoSubject)
+	r.GET("/health", func(c *router.Context) {
+		//nolint:errcheck // Test handler
+		c.String(http.StatusOK, "ok")
+	})
+
+	assert.Equal(t, http.StatusOK, get(t, r, "/health", "").Code)
+	assert.Equal(t, http.StatusUnauthorized, get(t, r, "/me", "").Code)
+}
+
+func TestJWTAuth_StaticKeys(t *testing.T) {
+	t.Parallel()
+	newKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	r := router.MustNew()
+	r.Use(New(WithKeySource(StaticKeys(map[string]any{
+		"old": &testKey.PublicKey,
+		"new": &newKey.PublicKey,
+	}))))
+	r.GET("/me", echoSubject)
+
+	assert.Equal(t, http.StatusOK, get(t, r, "/me", "Bearer "+sign(t, jwt.SigningMethodRS256, testKey, "old", validClaims())).Code)
+	assert.Equal(t, http.StatusOK, get(t, r, "/me", "Bearer "+sign(t, jwt.SigningMethodRS256, newKey, "new", validClaims())).Code)
+	assert.Equal(t, http.StatusUnauthorized, get(t, r, "/me", "Bearer "+sign(t, jwt.SigningMethodRS256, newKey, "old", validClaims())).Code)
+	assert.Equal(t, http.StatusUnauthorized, get(t, r, "/me", "Bearer "+sign(t, jwt.SigningMethodRS256, newKey, "unknown", validClaims())).Code)
+}
+
+func TestNew_PanicsWithoutKeySource(t *testing.T) {
+	t.Parallel()
+	assert.Panics(t, func() { New() })
+}
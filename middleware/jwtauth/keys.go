@@ -0,0 +1,81 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jwtauth
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrKeyNotFound is returned by key sources that have no key for a token.
+var ErrKeyNotFound = errors.New("jwtauth: key not found")
+
+// KeySource provides the keys that token signatures are verified with.
+//
+// Key returns the key for the token header's key ID (kid, which may be
+// empty) and algorithm (alg): a []byte secret for HMAC, or an
+// *rsa.PublicKey, *ecdsa.PublicKey, or ed25519.PublicKey. A key whose type
+// does not fit the algorithm is rejected, which prevents algorithm
+// confusion attacks.
+type KeySource interface {
+	Key(ctx context.Context, kid, alg string) (any, error)
+}
+
+// KeySourceFunc adapts a function to a [KeySource].
+//
+// Example:
+//
+//	jwtauth.KeySourceFunc(func(ctx context.Context, kid, alg string) (any, error) {
+//	    return vault.PublicKey(ctx, kid)
+//	})
+type KeySourceFunc func(ctx context.Context, kid, alg string) (any, error)
+
+// Key calls f.
+func (f KeySourceFunc) Key(ctx context.Context, kid, alg string) (any, error) {
+	return f(ctx, kid, alg)
+}
+
+// StaticKey returns a key source that verifies all tokens with key, e.g. a
+// shared HMAC secret or a public key loaded at startup.
+//
+// Example:
+//
+//	jwtauth.New(
+//	    jwtauth.WithKeySource(jwtauth.StaticKey([]byte(os.Getenv("JWT_SECRET")))),
+//	    jwtauth.WithAlgorithms("HS256"),
+//	)
+func StaticKey(key any) KeySource {
+	return KeySourceFunc(func(context.Context, string, string) (any, error) {
+		return key, nil
+	})
+}
+
+// StaticKeys returns a key source that looks keys up by key ID, e.g. during a
+// manual key rotation.
+//
+// Example:
+//
+//	jwtauth.StaticKeys(map[string]any{
+//	    "2025-01": oldPublicKey,
+//	    "2025-07": newPublicKey,
+//	})
+func StaticKeys(keys map[string]any) KeySource {
+	return KeySourceFunc(func(_ context.Context, kid, _ string) (any, error) {
+		if key, ok := keys[kid]; ok {
+			return key, nil
+		}
+		return nil, ErrKeyNotFound
+	})
+}
@@ -0,0 +1,154 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jwtauth
+
+import (
+	"time"
+
+	"rivaas.dev/router"
+)
+
+// WithKeySource sets the source of the keys that token signatures are
+// verified with. A key source is required.
+//
+// Example:
+//
+//	jwtauth.New(jwtauth.WithKeySource(jwtauth.StaticKey(publicKey)))
+func WithKeySource(keys KeySource) Option {
+	return func(cfg *config) {
+		cfg.keys = keys
+	}
+}
+
+// WithJWKS verifies tokens with the keys of the JSON Web Key Set at url. It
+// is a shorthand for WithKeySource(NewJWKS(url, opts...)).
+//
+// Example:
+//
+//	jwtauth.New(jwtauth.WithJWKS(
+//	    "https://auth.example.com/.well-known/jwks.json",
+//	    jwtauth.WithRefreshInterval(15*time.Minute),
+//	))
+func WithJWKS(url string, opts ...JWKSOption) Option {
+	return WithKeySource(NewJWKS(url, opts...))
+}
+
+// WithAlgorithms sets the accepted signing algorithms. Tokens signed with
+// other algorithms, including "none", are rejected.
+// Default: RS256, RS384, RS512, PS256, PS384, PS512, ES256, ES384, ES512, EdDSA
+//
+// Example:
+//
+//	jwtauth.New(
+//	    jwtauth.WithKeySource(jwtauth.StaticKey(secret)),
+//	    jwtauth.WithAlgorithms("HS256"),
+//	)
+func WithAlgorithms(algorithms ...string) Option {
+	return func(cfg *config) {
+		cfg.algorithms = algorithms
+	}
+}
+
+// WithIssuer requires the iss claim to equal issuer.
+//
+// Example:
+//
+//	jwtauth.New(jwtauth.WithIssuer("https://auth.example.com/"))
+func WithIssuer(issuer string) Option {
+	return func(cfg *config) {
+		cfg.issuer = issuer
+	}
+}
+
+// WithAudience requires the aud claim to contain one of audiences.
+//
+// Example:
+//
+//	jwtauth.New(jwtauth.WithAudience("orders-api"))
+func WithAudience(audiences ...string) Option {
+	return func(cfg *config) {
+		cfg.audience = append(cfg.audience, audiences...)
+	}
+}
+
+// WithLeeway sets the clock skew allowed when checking the exp, nbf, and iat
+// claims.
+// Default: 0
+//
+// Example:
+//
+//	jwtauth.New(jwtauth.WithLeeway(30 * time.Second))
+func WithLeeway(leeway time.Duration) Option {
+	return func(cfg *config) {
+		cfg.leeway = leeway
+	}
+}
+
+// WithCookie also reads the token from the named cookie if the request has
+// no Authorization header, e.g. for browser clients.
+//
+// Example:
+//
+//	jwtauth.New(jwtauth.WithCookie("access_token"))
+func WithCookie(name string) Option {
+	return func(cfg *config) {
+		cfg.cookieName = name
+	}
+}
+
+// WithTokenFunc sets a function that extracts the token from the request,
+// replacing the Authorization header and cookie lookup. Return an empty
+// string if there is no token.
+//
+// Example:
+//
+//	jwtauth.New(jwtauth.WithTokenFunc(func(c *router.Context) string {
+//	    return c.Query("access_token")
+//	}))
+func WithTokenFunc(fn func(c *router.Context) string) Option {
+	return func(cfg *config) {
+		cfg.tokenFunc = fn
+	}
+}
+
+// WithErrorHandler sets the function that responds to rejected requests. The
+// error wraps [ErrTokenMissing] or [ErrTokenInvalid], or is an error of the
+// key source. The WWW-Authenticate header is already set for token errors,
+// and the middleware aborts the chain after the handler returns.
+// Default: 401 Unauthorized for token errors, 500 for key source errors
+//
+// Example:
+//
+//	jwtauth.New(jwtauth.WithErrorHandler(func(c *router.Context, err error) {
+//	    c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+//	}))
+func WithErrorHandler(handler func(c *router.Context, err error)) Option {
+	return func(cfg *config) {
+		cfg.errorHandler = handler
+	}
+}
+
+// WithSkipPaths sets paths that bypass authentication.
+//
+// Example:
+//
+//	jwtauth.New(jwtauth.WithSkipPaths("/health", "/metrics"))
+func WithSkipPaths(paths ...string) Option {
+	return func(cfg *config) {
+		for _, path := range paths {
+			cfg.skipPaths[path] = true
+		}
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadshed
+
+import (
+	"runtime"
+	"sync"
+	"time"
+)
+
+// cpuSampler measures the CPU utilization of the process: the CPU time used
+// since the last sample, relative to the CPU time GOMAXPROCS cores could
+// have used. It samples lazily, at most once per interval, when asked for
+// the utilization.
+type cpuSampler struct {
+	interval time.Duration
+	now      func() time.Time
+	cpuTime  func() (time.Duration, bool)
+
+	mu       sync.Mutex
+	lastWall time.Time
+	lastCPU  time.Duration
+	last     float64
+}
+
+// newCPUSampler returns a sampler of the process CPU time.
+func newCPUSampler(interval time.Duration) *cpuSampler {
+	return &cpuSampler{
+		interval: interval,
+		now:      time.Now,
+		cpuTime:  processCPUTime,
+	}
+}
+
+// usage returns the CPU utilization between 0 and 1, measured over the
+// last interval. It returns 0 where the CPU time can't be read.
+func (s *cpuSampler) usage() float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	elapsed := now.Sub(s.lastWall)
+	if !s.lastWall.IsZero() && elapsed < s.interval {
+		return s.last
+	}
+
+	cpu, ok := s.cpuTime()
+	if !ok {
+		return 0
+	}
+	if !s.lastWall.IsZero() && elapsed > 0 {
+		capacity := float64(elapsed) * float64(runtime.GOMAXPROCS(0))
+		s.last = min(float64(cpu-s.lastCPU)/capacity, 1)
+	}
+	s.lastWall, s.lastCPU = now, cpu
+
+	return s.last
+}
@@ -0,0 +1,86 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package loadshed
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newFakeCPUSampler returns a sampler that measures usage after its first
+// sample.
+func newFakeCPUSampler(usage float64) *cpuSampler {
+	now := time.Now()
+	procs := time.Duration(runtime.GOMAXPROCS(0))
+	calls := 0
+	s := newCPUSampler(time.Second)
+	s.now = func() time.Time {
+		calls++
+		return now.Add(time.Duration(calls) * time.Second)
+	}
+	s.cpuTime = func() (time.Duration, bool) {
+		return time.Duration(float64(time.Duration(calls)*time.Second*procs) * usage), true
+	}
+	s.usage() // First sample
+
+	return s
+}
+
+func TestCPUSampler(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	cpu := time.Duration(0)
+	procs := time.Duration(runtime.GOMAXPROCS(0))
+	s := newCPUSampler(time.Second)
+	s.now = func() time.Time { return now }
+	s.cpuTime = func() (time.Duration, bool) { return cpu, true }
+
+	assert.Zero(t, s.usage(), "no utilization before two samples")
+
+	now = now.Add(2 * time.Second)
+	cpu += procs * time.Second // Half of the capacity
+	assert.InDelta(t, 0.5, s.usage(), 0.001)
+
+	now = now.Add(500 * time.Millisecond)
+	cpu += procs * time.Second
+	assert.InDelta(t, 0.5, s.usage(), 0.001, "sampled at most once per interval")
+
+	now = now.Add(time.Second)
+	cpu += 10 * procs * time.Second
+	assert.InDelta(t, 1.0, s.usage(), 0.001, "capped at 1")
+}
+
+func TestCPUSampler_Unsupported(t *testing.T) {
+	t.Parallel()
+
+	s := newCPUSampler(time.Second)
+	s.cpuTime = func() (time.Duration, bool) { return 0, false }
+	assert.Zero(t, s.usage())
+}
+
+func TestProcessCPUTime(t *testing.T) {
+	t.Parallel()
+
+	cpu, ok := processCPUTime()
+	if ok {
+		assert.Positive(t, cpu)
+	}
+}
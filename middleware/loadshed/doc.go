@@ -0,0 +1,57 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package loadshed provides middleware that rejects requests with 503
+// Service Unavailable and a Retry-After header while the server is
+// overloaded.
+//
+// Under overload, accepting every request makes all of them slow, and
+// clients time out and retry, adding more load. Shedding the excess keeps
+// the latency of the accepted requests low and tells clients when to retry.
+//
+// # Basic Usage
+//
+//	import "rivaas.dev/middleware/loadshed"
+//
+//	r := router.MustNew()
+//	r.Use(loadshed.New(
+//	    loadshed.WithMaxInFlight(500),
+//	    loadshed.WithPriorityPaths("/healthz"),
+//	))
+//
+// # Thresholds
+//
+// A request is shed if any configured threshold is exceeded:
+//
+//   - [WithMaxInFlight]: requests in flight in this middleware
+//   - [WithMaxGoroutines]: goroutines of the process
+//   - [WithMaxCPU]: CPU utilization of the process, measured over
+//     [WithCPUInterval] (Unix only)
+//   - [WithProbe]: a custom check, such as a database pool's wait queue
+//
+// Without thresholds, no request is shed.
+//
+// # Priority Lanes
+//
+// Requests on [WithPriorityPaths] or accepted by [WithPriorityFunc] are
+// never shed. Keep health checks there: a busy instance is still healthy,
+// and restarting it would move its load to the others.
+//
+// # Placement
+//
+// Register loadshed early, after recovery and request IDs but before
+// authentication and body parsing, so shed requests cost as little as
+// possible. The ratelimit middleware limits clients; loadshed protects the
+// instance from the sum of all of them.
+package loadshed
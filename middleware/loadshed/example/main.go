@@ -0,0 +1,50 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package main demonstrates how to use the loadshed middleware
+// to keep a slow endpoint responsive under load.
+package main
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"rivaas.dev/middleware/loadshed"
+	"rivaas.dev/router"
+)
+
+func main() {
+	r := router.MustNew()
+	r.Use(loadshed.New(
+		loadshed.WithMaxInFlight(2),
+		loadshed.WithPriorityPaths("/healthz"),
+	))
+
+	r.GET("/healthz", func(c *router.Context) {
+		c.NoContent()
+	})
+	r.GET("/report", func(c *router.Context) {
+		// Simulate slow work
+		time.Sleep(3 * time.Second)
+		if err := c.String(http.StatusOK, "report ready\n"); err != nil {
+			log.Printf("write response: %v", err)
+		}
+	})
+
+	log.Println("Server starting on :8080")
+	log.Println("  for i in 1 2 3; do curl -s -i http://localhost:8080/report & done")
+	log.Println("  The third request gets 503 with Retry-After; /healthz keeps answering.")
+	log.Fatal(http.ListenAndServe(":8080", r))
+}
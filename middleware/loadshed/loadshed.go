@@ -0,0 +1,187 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadshed
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"runtime"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"rivaas.dev/router"
+)
+
+// ErrOverloaded is passed to the error handler when a request is shed. The
+// error wraps it with the threshold that was exceeded.
+var ErrOverloaded = errors.New("loadshed: server overloaded")
+
+// Probe reports whether a resource the server depends on is overloaded,
+// such as a database connection pool. It is called for every request that
+// can be shed, so it must be fast.
+type Probe func() bool
+
+// Option defines functional options for loadshed middleware configuration.
+type Option func(*config)
+
+// config holds the configuration for the loadshed middleware.
+type config struct {
+	// maxInFlight is the number of concurrent requests above which requests
+	// are shed; 0 disables it
+	maxInFlight int
+
+	// maxGoroutines is the number of goroutines above which requests are
+	// shed; 0 disables it
+	maxGoroutines int
+
+	// maxCPU is the CPU utilization, between 0 and 1, above which requests
+	// are shed; 0 disables it
+	maxCPU float64
+
+	// cpuInterval is how often the CPU utilization is measured
+	cpuInterval time.Duration
+
+	// probes report custom overload conditions
+	probes []namedProbe
+
+	// retryAfter is sent in the Retry-After header of shed requests
+	retryAfter time.Duration
+
+	// priorityPaths are paths that are never shed
+	priorityPaths map[string]bool
+
+	// priorityFunc reports whether a request is never shed
+	priorityFunc func(c *router.Context) bool
+
+	// errorHandler is called when a request is shed
+	errorHandler func(c *router.Context, err error)
+
+	// skipPaths are paths the middleware does not apply to
+	skipPaths map[string]bool
+}
+
+// namedProbe is a [Probe] with the name reported when it sheds requests.
+type namedProbe struct {
+	name  string
+	probe Probe
+}
+
+// defaultConfig returns the default configuration for loadshed middleware.
+func defaultConfig() *config {
+	return &config{
+		cpuInterval:   time.Second,
+		retryAfter:    5 * time.Second,
+		priorityPaths: make(map[string]bool),
+		errorHandler:  defaultErrorHandler,
+		skipPaths:     make(map[string]bool),
+	}
+}
+
+// defaultErrorHandler sends 503 Service Unavailable.
+func defaultErrorHandler(c *router.Context, _ error) {
+	c.WriteErrorResponse(http.StatusServiceUnavailable, "server overloaded")
+}
+
+// New returns a middleware that sheds load: when the server is overloaded,
+// requests are rejected with 503 Service Unavailable and a Retry-After
+// header, so that the requests already in flight can complete quickly
+// instead of all of them slowing down.
+//
+// The server is overloaded when any configured threshold is exceeded: the
+// number of requests in flight ([WithMaxInFlight]), the number of goroutines
+// ([WithMaxGoroutines]), the CPU utilization of the process ([WithMaxCPU]),
+// or a custom [Probe] ([WithProbe]). Without thresholds, no request is shed.
+//
+// Priority requests, such as health checks ([WithPriorityPaths]), are never
+// shed, so orchestrators don't restart an instance because it is busy.
+//
+// Example:
+//
+//	r := router.MustNew()
+//	r.Use(loadshed.New(
+//	    loadshed.WithMaxInFlight(500),
+//	    loadshed.WithMaxCPU(0.9),
+//	    loadshed.WithPriorityPaths("/healthz", "/readyz"),
+//	))
+func New(opts ...Option) router.HandlerFunc {
+	cfg := defaultConfig()
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	var cpu *cpuSampler
+	if cfg.maxCPU > 0 {
+		cpu = newCPUSampler(cfg.cpuInterval)
+	}
+	retryAfter := strconv.Itoa(int(math.Ceil(cfg.retryAfter.Seconds())))
+
+	var inFlight atomic.Int64
+
+	return func(c *router.Context) {
+		if cfg.skipPaths[c.Request.URL.Path] {
+			c.Next()
+			return
+		}
+
+		// Priority requests count towards the load, but are never shed
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+
+		if !cfg.priority(c) {
+			if err := cfg.overloaded(n, cpu); err != nil {
+				c.Response.Header().Set("Retry-After", retryAfter)
+				cfg.errorHandler(c, err)
+				c.Abort()
+
+				return
+			}
+		}
+
+		c.Next()
+	}
+}
+
+// priority reports whether the request is never shed.
+func (cfg *config) priority(c *router.Context) bool {
+	return cfg.priorityPaths[c.Request.URL.Path] || (cfg.priorityFunc != nil && cfg.priorityFunc(c))
+}
+
+// overloaded returns an error wrapping [ErrOverloaded] if a threshold is
+// exceeded with inFlight requests in flight, including this one.
+func (cfg *config) overloaded(inFlight int64, cpu *cpuSampler) error {
+	if cfg.maxInFlight > 0 && inFlight > int64(cfg.maxInFlight) {
+		return fmt.Errorf("%w: %d requests in flight, limit %d", ErrOverloaded, inFlight, cfg.maxInFlight)
+	}
+	if cfg.maxGoroutines > 0 {
+		if n := runtime.NumGoroutine(); n > cfg.maxGoroutines {
+			return fmt.Errorf("%w: %d goroutines, limit %d", ErrOverloaded, n, cfg.maxGoroutines)
+		}
+	}
+	if cpu != nil {
+		if usage := cpu.usage(); usage > cfg.maxCPU {
+			return fmt.Errorf("%w: CPU utilization %.2f, limit %.2f", ErrOverloaded, usage, cfg.maxCPU)
+		}
+	}
+	for _, p := range cfg.probes {
+		if p.probe() {
+			return fmt.Errorf("%w: probe %q", ErrOverloaded, p.name)
+		}
+	}
+
+	return nil
+}
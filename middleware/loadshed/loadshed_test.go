@@ -0,0 +1,202 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package loadshed
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"rivaas.dev/router"
+)
+
+func TestLoadShed_MaxInFlight(t *testing.T) {
+	t.Parallel()
+	started := make(chan struct{})
+	release := make(chan struct{})
+	r := router.MustNew()
+	r.Use(New(WithMaxInFlight(2), WithPriorityPaths("/healthz")))
+	r.GET("/slow", func(c *router.Context) {
+		started <- struct{}{}
+		<-release
+		c.NoContent()
+	})
+	r.GET("/fast", func(c *router.Context) { c.NoContent() })
+	r.GET("/healthz", func(c *router.Context) { c.NoContent() })
+
+	var wg sync.WaitGroup
+	for range 2 {
+		wg.Go(func() {
+			r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/slow", nil))
+		})
+		<-started
+	}
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/fast", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Equal(t, "5", w.Header().Get("Retry-After"))
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	assert.Equal(t, http.StatusNoContent, w.Code, "priority paths are never shed")
+
+	close(release)
+	wg.Wait()
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/fast", nil))
+	assert.Equal(t, http.StatusNoContent, w.Code, "accepted again once the load drops")
+}
+
+func TestLoadShed_SkipPaths(t *testing.T) {
+	t.Parallel()
+	started := make(chan struct{})
+	release := make(chan struct{})
+	r := router.MustNew()
+	r.Use(New(WithMaxInFlight(1), WithSkipPaths("/metrics")))
+	r.GET("/metrics", func(c *router.Context) {
+		started <- struct{}{}
+		<-release
+		c.NoContent()
+	})
+	r.GET("/fast", func(c *router.Context) { c.NoContent() })
+
+	var wg sync.WaitGroup
+	for range 3 {
+		wg.Go(func() {
+			r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/metrics", nil))
+		})
+		<-started
+	}
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/fast", nil))
+	assert.Equal(t, http.StatusNoContent, w.Code, "skipped paths are not counted")
+
+	close(release)
+	wg.Wait()
+}
+
+func TestLoadShed_PriorityFunc(t *testing.T) {
+	t.Parallel()
+	started := make(chan struct{})
+	release := make(chan struct{})
+	r := router.MustNew()
+	r.Use(New(
+		WithMaxInFlight(1),
+		WithPriorityFunc(func(c *router.Context) bool { return c.Request.Header.Get("X-Priority") == "critical" }),
+	))
+	r.GET("/slow", func(c *router.Context) {
+		started <- struct{}{}
+		<-release
+		c.NoContent()
+	})
+	r.GET("/fast", func(c *router.Context) { c.NoContent() })
+
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/slow", nil))
+	})
+	<-started
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/fast", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+	req := httptest.NewRequest(http.MethodGet, "/fast", nil)
+	req.Header.Set("X-Priority", "critical")
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+
+	close(release)
+	wg.Wait()
+}
+
+func TestLoadShed_Thresholds(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		opts    []Option
+		wantErr string
+	}{
+		{name: "no thresholds"},
+		{name: "goroutines", opts: []Option{WithMaxGoroutines(1)}, wantErr: "goroutines"},
+		{name: "goroutines below limit", opts: []Option{WithMaxGoroutines(runtime.NumGoroutine() + 1_000_000)}},
+		{name: "probe", opts: []Option{WithProbe("db", func() bool { return true })}, wantErr: `probe "db"`},
+		{name: "probe ok", opts: []Option{WithProbe("db", func() bool { return false })}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var got error
+			opts := append(tt.opts, WithErrorHandler(func(c *router.Context, err error) {
+				got = err
+				c.WriteErrorResponse(http.StatusTooManyRequests, "busy")
+			}))
+			r := router.MustNew()
+			r.Use(New(opts...))
+			r.GET("/fast", func(c *router.Context) { c.NoContent() })
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/fast", nil))
+			if tt.wantErr == "" {
+				assert.Equal(t, http.StatusNoContent, w.Code)
+				assert.NoError(t, got)
+				return
+			}
+			assert.Equal(t, http.StatusTooManyRequests, w.Code)
+			require.ErrorIs(t, got, ErrOverloaded)
+			assert.Contains(t, got.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestLoadShed_CPU(t *testing.T) {
+	t.Parallel()
+	cfg := defaultConfig()
+	WithMaxCPU(0.5)(cfg)
+	cpu := newFakeCPUSampler(0.75)
+
+	err := cfg.overloaded(1, cpu)
+	require.ErrorIs(t, err, ErrOverloaded)
+	assert.Contains(t, err.Error(), "CPU utilization 0.75")
+
+	cpu = newFakeCPUSampler(0.25)
+	require.NoError(t, cfg.overloaded(1, cpu))
+}
+
+func TestLoadShed_RetryAfter(t *testing.T) {
+	t.Parallel()
+	r := router.MustNew()
+	r.Use(New(WithProbe("always", func() bool { return true }), WithRetryAfter(1500*time.Millisecond)))
+	r.GET("/fast", func(c *router.Context) { c.NoContent() })
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/fast", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Equal(t, "2", w.Header().Get("Retry-After"), "rounded up to whole seconds")
+}
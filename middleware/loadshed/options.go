@@ -0,0 +1,169 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadshed
+
+import (
+	"time"
+
+	"rivaas.dev/router"
+)
+
+// WithMaxInFlight sheds requests while more than n requests are in flight,
+// including priority requests. Set it to about what the instance handles
+// at its target latency. Zero disables it.
+// Default: disabled
+//
+// Example:
+//
+//	loadshed.New(loadshed.WithMaxInFlight(500))
+func WithMaxInFlight(n int) Option {
+	return func(cfg *config) {
+		cfg.maxInFlight = max(n, 0)
+	}
+}
+
+// WithMaxGoroutines sheds requests while the process runs more than n
+// goroutines, which catches work piling up in background goroutines too.
+// Zero disables it.
+// Default: disabled
+//
+// Example:
+//
+//	loadshed.New(loadshed.WithMaxGoroutines(10000))
+func WithMaxGoroutines(n int) Option {
+	return func(cfg *config) {
+		cfg.maxGoroutines = max(n, 0)
+	}
+}
+
+// WithMaxCPU sheds requests while the CPU utilization of the process, as a
+// ratio of the GOMAXPROCS cores between 0 and 1, is above ratio. It is
+// measured over [WithCPUInterval]. Zero disables it. On platforms other
+// than Unix, the utilization can't be measured and no requests are shed.
+// Default: disabled
+//
+// Example:
+//
+//	loadshed.New(loadshed.WithMaxCPU(0.9))
+func WithMaxCPU(ratio float64) Option {
+	return func(cfg *config) {
+		cfg.maxCPU = max(ratio, 0)
+	}
+}
+
+// WithCPUInterval sets the period over which the CPU utilization of
+// [WithMaxCPU] is measured. Shorter periods react faster to load spikes but
+// shed requests on short bursts too.
+// Default: 1 second
+//
+// Example:
+//
+//	loadshed.New(loadshed.WithMaxCPU(0.9), loadshed.WithCPUInterval(5*time.Second))
+func WithCPUInterval(interval time.Duration) Option {
+	return func(cfg *config) {
+		cfg.cpuInterval = interval
+	}
+}
+
+// WithProbe adds a custom overload check. Requests are shed while probe
+// returns true; name identifies it in the error passed to the error
+// handler. Probes are called for every request that can be shed, so they
+// must be fast, e.g. read a value a background goroutine updates.
+//
+// Example:
+//
+//	loadshed.New(loadshed.WithProbe("db", func() bool {
+//	    stats := db.Stats()
+//	    return stats.WaitCount > 100
+//	}))
+func WithProbe(name string, probe Probe) Option {
+	return func(cfg *config) {
+		cfg.probes = append(cfg.probes, namedProbe{name: name, probe: probe})
+	}
+}
+
+// WithRetryAfter sets the delay sent in the Retry-After header of shed
+// requests, rounded up to whole seconds.
+// Default: 5 seconds
+//
+// Example:
+//
+//	loadshed.New(loadshed.WithRetryAfter(10 * time.Second))
+func WithRetryAfter(d time.Duration) Option {
+	return func(cfg *config) {
+		cfg.retryAfter = d
+	}
+}
+
+// WithPriorityPaths sets paths that are never shed, such as health checks,
+// so that orchestrators and load balancers don't take a busy instance out of
+// service. They still count towards [WithMaxInFlight].
+//
+// Example:
+//
+//	loadshed.New(loadshed.WithPriorityPaths("/healthz", "/readyz"))
+func WithPriorityPaths(paths ...string) Option {
+	return func(cfg *config) {
+		for _, path := range paths {
+			cfg.priorityPaths[path] = true
+		}
+	}
+}
+
+// WithPriorityFunc sets a function that reports whether a request is never
+// shed, for example requests of paying customers or internal callers.
+//
+// Example:
+//
+//	loadshed.New(loadshed.WithPriorityFunc(func(c *router.Context) bool {
+//	    return c.Request.Header.Get("X-Priority") == "critical"
+//	}))
+func WithPriorityFunc(fn func(c *router.Context) bool) Option {
+	return func(cfg *config) {
+		cfg.priorityFunc = fn
+	}
+}
+
+// WithErrorHandler sets the function that responds to shed requests. The
+// error wraps [ErrOverloaded] with the exceeded threshold. The Retry-After
+// header is already set, and the middleware aborts the chain after the
+// handler returns.
+// Default: 503 Service Unavailable
+//
+// Example:
+//
+//	loadshed.New(loadshed.WithErrorHandler(func(c *router.Context, err error) {
+//	    slog.Warn("request shed", "error", err)
+//	    c.WriteErrorResponse(http.StatusServiceUnavailable, "busy, try again later")
+//	}))
+func WithErrorHandler(handler func(c *router.Context, err error)) Option {
+	return func(cfg *config) {
+		cfg.errorHandler = handler
+	}
+}
+
+// WithSkipPaths sets paths the middleware does not apply to. Unlike
+// priority paths, they don't count towards [WithMaxInFlight].
+//
+// Example:
+//
+//	loadshed.New(loadshed.WithSkipPaths("/metrics"))
+func WithSkipPaths(paths ...string) Option {
+	return func(cfg *config) {
+		for _, path := range paths {
+			cfg.skipPaths[path] = true
+		}
+	}
+}
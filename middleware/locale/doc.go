@@ -0,0 +1,65 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package locale provides middleware that selects the locale of each request
+// from the locales an application supports.
+//
+// The middleware negotiates the Accept-Language header against the
+// supported locales, lets users override the result with a query parameter
+// or cookie, stores the selected locale in the request context, and sets the
+// Content-Language response header.
+//
+// # Basic Usage
+//
+//	import "rivaas.dev/middleware/locale"
+//
+//	r := router.MustNew()
+//	r.Use(locale.New(locale.WithLocales("en", "de", "fr")))
+//
+//	r.GET("/", func(c *router.Context) {
+//	    c.String(http.StatusOK, greetings[locale.Get(c)])
+//	})
+//
+// The first supported locale is the default for clients whose
+// Accept-Language header matches none of them.
+//
+// # Overrides
+//
+// [WithQueryParam] lets a link select the locale (?lang=de), and [WithCookie]
+// remembers it: a locale selected with the query parameter is stored in the
+// cookie, and the cookie takes precedence over Accept-Language on later
+// requests.
+//
+//	r.Use(locale.New(
+//	    locale.WithLocales("en", "de", "fr"),
+//	    locale.WithQueryParam("lang"),
+//	    locale.WithCookie("lang"),
+//	))
+//
+// # Matching
+//
+// Locales are compared case-insensitively, and a language matches a locale
+// of the same language with another region: a client asking for "de"
+// gets "de-DE" if that is the supported locale, and vice versa.
+//
+// # Caching
+//
+// Responses vary by Accept-Language, and by Cookie if a cookie is
+// configured, so the middleware adds these to the Vary header.
+//
+// # Accessing the Locale
+//
+// Handlers use [Get]. Code that only has a context.Context, such as services
+// and validators, uses [FromContext].
+package locale
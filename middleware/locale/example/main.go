@@ -0,0 +1,53 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package main demonstrates how to use the locale middleware
+// with a language switcher.
+package main
+
+import (
+	"log"
+	"net/http"
+
+	"rivaas.dev/middleware/locale"
+	"rivaas.dev/router"
+)
+
+var greetings = map[string]string{
+	"en": "Hello!",
+	"de": "Hallo!",
+	"fr": "Bonjour !",
+}
+
+func main() {
+	r := router.MustNew()
+
+	r.Use(locale.New(
+		locale.WithLocales("en", "de", "fr"),
+		locale.WithQueryParam("lang"),
+		locale.WithCookie("lang"),
+	))
+
+	r.GET("/", func(c *router.Context) {
+		if err := c.String(http.StatusOK, greetings[locale.Get(c)]); err != nil {
+			log.Printf("write response: %v", err)
+		}
+	})
+
+	log.Println("Server starting on :8080")
+	log.Println("  curl -i -H 'Accept-Language: de-DE,de;q=0.9' http://localhost:8080/")
+	log.Println("  curl -i 'http://localhost:8080/?lang=fr'")
+	log.Println("  curl -i -H 'Cookie: lang=fr' http://localhost:8080/")
+	log.Fatal(http.ListenAndServe(":8080", r))
+}
@@ -0,0 +1,189 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package locale
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"rivaas.dev/router"
+)
+
+type contextKey struct{}
+
+// Option defines functional options for locale middleware configuration.
+type Option func(*config)
+
+// config holds the configuration for the locale middleware.
+type config struct {
+	// locales are the supported locales; the first is the default
+	locales []string
+
+	// queryParam is a query parameter that selects the locale
+	queryParam string
+
+	// cookieName is a cookie that remembers the selected locale
+	cookieName string
+
+	// cookieMaxAge is the lifetime of the locale cookie
+	cookieMaxAge time.Duration
+}
+
+// defaultConfig returns the default configuration for locale middleware.
+func defaultConfig() *config {
+	return &config{
+		cookieMaxAge: 365 * 24 * time.Hour,
+	}
+}
+
+// New returns a middleware that selects the locale of each request from the
+// supported locales, stores it in the request context for [Get], and sets
+// the Content-Language response header.
+//
+// The locale is taken from the first of these that names a supported
+// locale:
+//
+//  1. The query parameter, if [WithQueryParam] is set
+//  2. The cookie, if [WithCookie] is set
+//  3. The Accept-Language header
+//  4. The first supported locale
+//
+// A locale chosen with the query parameter is stored in the cookie, so that
+// it sticks for later requests. A language without region matches a
+// supported locale with region and vice versa: "de" selects "de-DE".
+//
+// New panics if no locales are configured.
+//
+// Example:
+//
+//	r := router.MustNew()
+//	r.Use(locale.New(
+//	    locale.WithLocales("en", "de", "fr"),
+//	    locale.WithQueryParam("lang"),
+//	    locale.WithCookie("lang"),
+//	))
+func New(opts ...Option) router.HandlerFunc {
+	cfg := defaultConfig()
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	if len(cfg.locales) == 0 {
+		panic("locale: supported locales are required, use WithLocales")
+	}
+
+	return func(c *router.Context) {
+		selected := cfg.selectLocale(c)
+
+		c.Response.Header().Set("Content-Language", selected)
+		c.AddVary("Accept-Language")
+		if cfg.cookieName != "" {
+			c.AddVary("Cookie")
+		}
+
+		ctx := context.WithValue(c.Request.Context(), contextKey{}, selected)
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
+// selectLocale returns the locale for the request of c.
+func (cfg *config) selectLocale(c *router.Context) string {
+	if cfg.queryParam != "" {
+		if selected, ok := cfg.match(c.Query(cfg.queryParam)); ok {
+			cfg.remember(c, selected)
+			return selected
+		}
+	}
+	if cfg.cookieName != "" {
+		if cookie, err := c.Request.Cookie(cfg.cookieName); err == nil {
+			if selected, ok := cfg.match(cookie.Value); ok {
+				return selected
+			}
+		}
+	}
+	if selected := c.AcceptsLanguages(cfg.locales...); selected != "" {
+		return selected
+	}
+
+	return cfg.locales[0]
+}
+
+// match returns the supported locale that tag names: the locale itself,
+// compared case-insensitively, or the first supported locale of the same
+// language.
+func (cfg *config) match(tag string) (string, bool) {
+	tag = strings.ReplaceAll(strings.TrimSpace(tag), "_", "-")
+	if tag == "" {
+		return "", false
+	}
+	for _, supported := range cfg.locales {
+		if strings.EqualFold(supported, tag) {
+			return supported, true
+		}
+	}
+	language, _, _ := strings.Cut(tag, "-")
+	for _, supported := range cfg.locales {
+		if supportedLanguage, _, _ := strings.Cut(supported, "-"); strings.EqualFold(supportedLanguage, language) {
+			return supported, true
+		}
+	}
+
+	return "", false
+}
+
+// remember stores the locale in the cookie, if one is configured.
+func (cfg *config) remember(c *router.Context, selected string) {
+	if cfg.cookieName == "" {
+		return
+	}
+	http.SetCookie(c.Response, &http.Cookie{
+		Name:     cfg.cookieName,
+		Value:    selected,
+		Path:     "/",
+		MaxAge:   int(cfg.cookieMaxAge.Seconds()),
+		Secure:   c.Request.TLS != nil,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// Get returns the locale selected for the request, or an empty string if
+// the middleware didn't run.
+//
+// Example:
+//
+//	func handler(c *router.Context) {
+//	    c.String(http.StatusOK, greetings[locale.Get(c)])
+//	}
+func Get(c *router.Context) string {
+	return FromContext(c.Request.Context())
+}
+
+// FromContext returns the locale selected for the request that ctx belongs
+// to, or an empty string. Use it in code that has a context but no
+// router.Context, such as services and validators.
+//
+// Example:
+//
+//	func (s *Service) Notify(ctx context.Context, user User) error {
+//	    return s.mailer.Send(user.Email, s.templates.For(locale.FromContext(ctx)))
+//	}
+func FromContext(ctx context.Context) string {
+	selected, _ := ctx.Value(contextKey{}).(string)
+	return selected
+}
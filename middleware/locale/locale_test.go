@@ -0,0 +1,139 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package locale
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"rivaas.dev/router"
+)
+
+func get(r http.Handler, target string, header http.Header) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	return w
+}
+
+func TestLocale_AcceptLanguage(t *testing.T) {
+	t.Parallel()
+	r := router.MustNew()
+	r.Use(New(WithLocales("en-US", "de-DE", "fr")))
+	r.GET("/", func(c *router.Context) {
+		//nolint:errcheck // Test handler
+		c.String(http.StatusOK, Get(c)+"|"+FromContext(c.Request.Context()))
+	})
+
+	tests := []struct {
+		acceptLanguage, want string
+	}{
+		{"", "en-US"},
+		{"de-DE", "de-DE"},
+		{"fr-CA, fr;q=0.9", "fr"},
+		{"de", "de-DE"},
+		{"es, fr;q=0.5", "fr"},
+		{"ja", "en-US"},
+	}
+	for _, tt := range tests {
+		w := get(r, "/", http.Header{"Accept-Language": {tt.acceptLanguage}})
+		assert.Equal(t, tt.want+"|"+tt.want, w.Body.String(), tt.acceptLanguage)
+		assert.Equal(t, tt.want, w.Header().Get("Content-Language"))
+		assert.Equal(t, "Accept-Language", w.Header().Get("Vary"))
+	}
+}
+
+func TestLocale_Cookie(t *testing.T) {
+	t.Parallel()
+	r := router.MustNew()
+	r.Use(New(WithLocales("en", "de"), WithCookie("lang")))
+	r.GET("/", func(c *router.Context) {
+		c.NoContent()
+	})
+
+	w := get(r, "/", http.Header{"Accept-Language": {"en"}, "Cookie": {"lang=de"}})
+	assert.Equal(t, "de", w.Header().Get("Content-Language"))
+	assert.Equal(t, "Accept-Language, Cookie", w.Header().Get("Vary"))
+
+	w = get(r, "/", http.Header{"Accept-Language": {"de"}, "Cookie": {"lang=xx"}})
+	assert.Equal(t, "de", w.Header().Get("Content-Language"), "unsupported cookie values are ignored")
+}
+
+func TestLocale_QueryParam(t *testing.T) {
+	t.Parallel()
+	r := router.MustNew()
+	r.Use(New(WithLocales("en", "de-DE"), WithQueryParam("lang"), WithCookie("lang")))
+	r.GET("/", func(c *router.Context) {
+		c.NoContent()
+	})
+
+	w := get(r, "/?lang=de_de", http.Header{"Cookie": {"lang=en"}})
+	assert.Equal(t, "de-DE", w.Header().Get("Content-Language"))
+	cookies := w.Result().Cookies()
+	require.Len(t, cookies, 1, "the selection is remembered")
+	assert.Equal(t, "lang", cookies[0].Name)
+	assert.Equal(t, "de-DE", cookies[0].Value)
+	assert.True(t, cookies[0].HttpOnly)
+	assert.Equal(t, 365*24*60*60, cookies[0].MaxAge)
+
+	w = get(r, "/?lang=xx", http.Header{"Cookie": {"lang=de-DE"}})
+	assert.Equal(t, "de-DE", w.Header().Get("Content-Language"))
+	assert.Empty(t, w.Result().Cookies())
+
+	// Without a cookie, the query parameter applies to one request
+	r = router.MustNew()
+	r.Use(New(WithLocales("en", "de"), WithQueryParam("lang")))
+	r.GET("/", func(c *router.Context) {
+		c.NoContent()
+	})
+	w = get(r, "/?lang=de", nil)
+	assert.Equal(t, "de", w.Header().Get("Content-Language"))
+	assert.Empty(t, w.Result().Cookies())
+}
+
+func TestLocale_CookieMaxAge(t *testing.T) {
+	t.Parallel()
+	r := router.MustNew()
+	r.Use(New(WithLocales("en", "de"), WithQueryParam("lang"), WithCookie("lang"), WithCookieMaxAge(time.Hour)))
+	r.GET("/", func(c *router.Context) {
+		c.NoContent()
+	})
+
+	cookies := get(r, "/?lang=de", nil).Result().Cookies()
+	require.Len(t, cookies, 1)
+	assert.Equal(t, 3600, cookies[0].MaxAge)
+}
+
+func TestLocale_NotSet(t *testing.T) {
+	t.Parallel()
+	c := router.NewContext(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Empty(t, Get(c))
+}
+
+func TestNew_PanicsWithoutLocales(t *testing.T) {
+	t.Parallel()
+	assert.Panics(t, func() { New() })
+}
@@ -0,0 +1,73 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package locale
+
+import "time"
+
+// WithLocales sets the supported locales as BCP 47 tags, such as "en",
+// "de-DE", or "pt-BR". The first one is the default, used when the request
+// names no supported locale. At least one locale is required.
+//
+// Example:
+//
+//	locale.New(locale.WithLocales("en-US", "de-DE", "fr-FR"))
+func WithLocales(locales ...string) Option {
+	return func(cfg *config) {
+		cfg.locales = append(cfg.locales, locales...)
+	}
+}
+
+// WithQueryParam lets the named query parameter select the locale, for
+// example from a language switcher: /products?lang=de. It takes precedence
+// over the cookie and the Accept-Language header.
+//
+// Example:
+//
+//	locale.New(locale.WithLocales("en", "de"), locale.WithQueryParam("lang"))
+func WithQueryParam(name string) Option {
+	return func(cfg *config) {
+		cfg.queryParam = name
+	}
+}
+
+// WithCookie lets the named cookie select the locale. It takes precedence
+// over the Accept-Language header. A locale selected with the query
+// parameter is stored in the cookie.
+//
+// Example:
+//
+//	locale.New(locale.WithLocales("en", "de"), locale.WithCookie("lang"))
+func WithCookie(name string) Option {
+	return func(cfg *config) {
+		cfg.cookieName = name
+	}
+}
+
+// WithCookieMaxAge sets the lifetime of the cookie that stores a locale
+// selected with the query parameter.
+// Default: 1 year
+//
+// Example:
+//
+//	locale.New(
+//	    locale.WithLocales("en", "de"),
+//	    locale.WithCookie("lang"),
+//	    locale.WithCookieMaxAge(30*24*time.Hour),
+//	)
+func WithCookieMaxAge(maxAge time.Duration) Option {
+	return func(cfg *config) {
+		cfg.cookieMaxAge = maxAge
+	}
+}
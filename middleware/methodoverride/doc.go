@@ -13,7 +13,7 @@
 // limitations under the License.
 
 // Package methodoverride provides middleware for HTTP method override,
-// allowing clients to use POST requests with a header or form field to
+// allowing clients to use POST requests with a header or query parameter to
 // specify the actual HTTP method (PUT, DELETE, etc.).
 //
 // This middleware enables RESTful APIs to work with clients that don't
@@ -31,15 +31,17 @@
 //
 // The middleware checks for method override in the following order:
 //
-//   - X-HTTP-Method-Override header (default)
-//   - _method form field (for POST requests with form data)
-//   - X-HTTP-Method header (alternative header name)
+//   - X-HTTP-Method-Override header (default, see [WithHeader])
+//   - _method query parameter (default, see [WithQueryParam])
 //
 // # Configuration Options
 //
-//   - HeaderName: Custom header name for method override (default: X-HTTP-Method-Override)
-//   - FormFieldName: Custom form field name (default: _method)
-//   - AllowedMethods: Methods allowed to be overridden (default: PUT, PATCH, DELETE)
+//   - [WithHeader]: Custom header name for method override (default: X-HTTP-Method-Override)
+//   - [WithQueryParam]: Custom query parameter name (default: _method)
+//   - [WithAllow]: Methods allowed to be overridden (default: PUT, PATCH, DELETE)
+//   - [WithOnlyOn]: Request methods that may be overridden (default: POST)
+//   - [WithAllowFrom]: Allowed overrides per original method
+//   - [WithRequireCSRFToken], [WithCSRFVerifier]: Require CSRF verification
 //
 // # Example Usage
 //
@@ -48,16 +50,20 @@
 //	POST /users/123 HTTP/1.1
 //	X-HTTP-Method-Override: DELETE
 //
-// Or using form fields:
+// Or using the query parameter, e.g. from HTML forms:
 //
-//	<form method="POST" action="/users/123">
-//	    <input type="hidden" name="_method" value="DELETE">
+//	<form method="POST" action="/users/123?_method=DELETE">
 //	    <button type="submit">Delete</button>
 //	</form>
 //
 // # Security Considerations
 //
 // Method override should only be used when necessary (e.g., HTML form limitations).
-// Consider CSRF protection when using form-based method override. The middleware
-// can be checked via CSRFVerified(c) when using WithRequireCSRFToken.
+// With [WithRequireCSRFToken], overrides are only applied to requests that a
+// CSRF middleware has verified, either by calling [MarkCSRFVerified] or as
+// reported by the function set with [WithCSRFVerifier]. Other requests keep
+// their original method:
+//
+//	r.Use(csrf.New())
+//	r.Use(methodoverride.New(methodoverride.WithCSRFVerifier(csrf.Verified)))
 package methodoverride
@@ -13,7 +13,7 @@
 // limitations under the License.
 
 // Package main demonstrates how to use the MethodOverride middleware
-// to allow PUT, PATCH, and DELETE via POST with a header or form field.
+// to allow PUT, PATCH, and DELETE via POST with a header or query parameter.
 package main
 
 import (
@@ -55,6 +55,6 @@ func main() {
 	})
 
 	log.Println("Server starting on http://localhost:8080")
-	log.Println("Use POST + X-HTTP-Method-Override: PUT|PATCH|DELETE, or _method in form body")
+	log.Println("Use POST + X-HTTP-Method-Override: PUT|PATCH|DELETE, or ?_method=PUT|PATCH|DELETE")
 	log.Fatal(http.ListenAndServe(":8080", r))
 }
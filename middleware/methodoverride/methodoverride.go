@@ -29,7 +29,8 @@ type (
 // New creates a new HTTP method override middleware.
 //
 // This middleware allows clients to override the HTTP method using a header
-// or query parameter, which is useful for HTML forms that only support GET/POST.
+// or the "_method" query parameter, which is useful for HTML forms that only
+// support GET/POST.
 //
 // SECURITY WARNING: This middleware should only be used when you control
 // the client (e.g., HTML forms). Never enable for public APIs without
@@ -41,13 +42,20 @@ type (
 //
 // With CSRF protection:
 //
-//	r.Use(csrf.Verify()) // Sets CSRF verification flag
+//	r.Use(csrfMiddleware) // Calls methodoverride.MarkCSRFVerified
 //	r.Use(methodoverride.New(
 //	    methodoverride.WithRequireCSRFToken(true),
 //	    methodoverride.WithAllow("PUT", "PATCH", "DELETE"),
 //	    methodoverride.WithOnlyOn("POST"),
 //	))
 //
+// Per original method rules:
+//
+//	r.Use(methodoverride.New(
+//	    methodoverride.WithAllowFrom("POST", "PUT", "PATCH", "DELETE"),
+//	    methodoverride.WithAllowFrom("GET", "HEAD"),
+//	))
+//
 // Custom header:
 //
 //	r.Use(methodoverride.New(
@@ -60,34 +68,38 @@ func New(opts ...Option) router.HandlerFunc {
 		opt(cfg)
 	}
 
-	// Build allow map for fast lookup
-	allowMap := make(map[string]bool, len(cfg.allow))
-	for _, m := range cfg.allow {
-		allowMap[strings.ToUpper(m)] = true
+	// Build the allowed overrides per original method for fast lookup
+	rules := make(map[string]map[string]bool)
+	for _, original := range cfg.onlyOn {
+		allowed := make(map[string]bool, len(cfg.allow))
+		for _, m := range cfg.allow {
+			allowed[strings.ToUpper(m)] = true
+		}
+		rules[strings.ToUpper(original)] = allowed
 	}
-
-	// Build onlyOn map for fast lookup
-	onlyOnMap := make(map[string]bool, len(cfg.onlyOn))
-	for _, m := range cfg.onlyOn {
-		onlyOnMap[strings.ToUpper(m)] = true
+	for original, methods := range cfg.allowFrom {
+		allowed := make(map[string]bool, len(methods))
+		for _, m := range methods {
+			allowed[strings.ToUpper(m)] = true
+		}
+		rules[original] = allowed
 	}
 
 	return func(c *router.Context) {
 		originalMethod := c.Request.Method
 
-		// Check if request method is in OnlyOn list
-		if !onlyOnMap[strings.ToUpper(originalMethod)] {
+		// Check if the request method may be overridden at all
+		allowed := rules[strings.ToUpper(originalMethod)]
+		if len(allowed) == 0 {
 			c.Next()
 			return
 		}
 
 		// Check CSRF requirement
-		if cfg.requireCSRFToken {
-			if verified, ok := c.Request.Context().Value(csrfVerifiedKey{}).(bool); !ok || !verified {
-				// CSRF not verified, skip override
-				c.Next()
-				return
-			}
+		if cfg.requireCSRFToken && (cfg.csrfVerifier == nil || !cfg.csrfVerifier(c)) {
+			// CSRF not verified, skip override
+			c.Next()
+			return
 		}
 
 		// Try to get override method from header first
@@ -105,8 +117,8 @@ func New(opts ...Option) router.HandlerFunc {
 		// Normalize method
 		overrideMethod = strings.ToUpper(strings.TrimSpace(overrideMethod))
 
-		// Check if method is in allowlist
-		if !allowMap[overrideMethod] {
+		// Check if method is allowed for the original method
+		if !allowed[overrideMethod] {
 			c.Next()
 			return
 		}
@@ -139,8 +151,25 @@ func OriginalMethod(c *router.Context) string {
 	return c.Request.Method
 }
 
+// MarkCSRFVerified marks the request as CSRF-verified, so that overrides are
+// allowed with WithRequireCSRFToken(true). CSRF middleware calls it after it
+// has checked the token of the request.
+//
+// Example:
+//
+//	r.Use(func(c *router.Context) {
+//	    if validToken(c) {
+//	        methodoverride.MarkCSRFVerified(c)
+//	    }
+//	    c.Next()
+//	})
+func MarkCSRFVerified(c *router.Context) {
+	ctx := context.WithValue(c.Request.Context(), csrfVerifiedKey{}, true)
+	c.Request = c.Request.WithContext(ctx)
+}
+
 // CSRFVerified returns true if a CSRF verification middleware has set the verified flag in context.
-// Other middleware (e.g., CSRF middleware) should set the flag via context when CSRF is verified.
+// Other middleware (e.g., CSRF middleware) should set the flag with [MarkCSRFVerified] when CSRF is verified.
 func CSRFVerified(c *router.Context) bool {
 	verified, ok := c.Request.Context().Value(csrfVerifiedKey{}).(bool)
 	return ok && verified
@@ -457,3 +457,86 @@ func TestMethodOverride_DefaultConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestMethodOverride_AllowFrom(t *testing.T) {
+	t.Parallel()
+	handler := New(
+		WithAllowFrom("post", "PUT", "DELETE"),
+		WithAllowFrom("GET", "HEAD"),
+	)
+	tests := []struct {
+		originalMethod string
+		override       string
+		expectedMethod string
+	}{
+		{originalMethod: http.MethodPost, override: "DELETE", expectedMethod: "DELETE"},
+		{originalMethod: http.MethodPost, override: "PATCH", expectedMethod: "POST"},
+		{originalMethod: http.MethodGet, override: "head", expectedMethod: "HEAD"},
+		{originalMethod: http.MethodGet, override: "DELETE", expectedMethod: "GET"},
+		{originalMethod: http.MethodPut, override: "DELETE", expectedMethod: "PUT"},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.originalMethod, "/test?_method="+tt.override, nil)
+		c := router.NewContext(httptest.NewRecorder(), req)
+		handler(c)
+
+		assert.Equal(t, tt.expectedMethod, c.Request.Method, "%s to %s", tt.originalMethod, tt.override)
+	}
+
+	// Without methods, override is disabled for the original method
+	handler = New(WithAllowFrom("POST"))
+	req := httptest.NewRequest(http.MethodPost, "/test?_method=DELETE", nil)
+	c := router.NewContext(httptest.NewRecorder(), req)
+	handler(c)
+	assert.Equal(t, http.MethodPost, c.Request.Method)
+}
+
+func TestMethodOverride_MarkCSRFVerified(t *testing.T) {
+	t.Parallel()
+	var method string
+	r := router.MustNew()
+	r.Use(func(c *router.Context) {
+		// Stands in for a CSRF middleware
+		if c.Request.Header.Get("X-Csrf-Token") == "valid" {
+			MarkCSRFVerified(c)
+		}
+		c.Next()
+	})
+	r.Use(New(WithRequireCSRFToken(true)), func(c *router.Context) {
+		method = c.Request.Method
+		c.Next()
+	})
+	r.POST("/test", func(c *router.Context) {
+		c.NoContent()
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/test?_method=DELETE", nil)
+	r.ServeHTTP(httptest.NewRecorder(), req)
+	assert.Equal(t, http.MethodPost, method, "unverified overrides are ignored")
+
+	req = httptest.NewRequest(http.MethodPost, "/test?_method=DELETE", nil)
+	req.Header.Set("X-Csrf-Token", "valid")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+	assert.Equal(t, http.MethodDelete, method)
+}
+
+func TestMethodOverride_CSRFVerifier(t *testing.T) {
+	t.Parallel()
+	handler := New(WithCSRFVerifier(func(c *router.Context) bool {
+		return c.Request.Header.Get("X-Csrf-Token") == "valid"
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/test", nil)
+	req.Header.Set("X-Http-Method-Override", "DELETE")
+	c := router.NewContext(httptest.NewRecorder(), req)
+	handler(c)
+	assert.Equal(t, http.MethodPost, c.Request.Method)
+
+	req = httptest.NewRequest(http.MethodPost, "/test", nil)
+	req.Header.Set("X-Http-Method-Override", "DELETE")
+	req.Header.Set("X-Csrf-Token", "valid")
+	c = router.NewContext(httptest.NewRecorder(), req)
+	handler(c)
+	assert.Equal(t, http.MethodDelete, c.Request.Method)
+}
@@ -14,6 +14,12 @@
 
 package methodoverride
 
+import (
+	"strings"
+
+	"rivaas.dev/router"
+)
+
 // Option defines functional options for method override middleware configuration.
 type Option func(*config)
 
@@ -25,6 +31,8 @@ type config struct {
 	onlyOn           []string
 	respectBody      bool
 	requireCSRFToken bool
+	csrfVerifier     func(c *router.Context) bool
+	allowFrom        map[string][]string // Overrides allowed per original method
 }
 
 // defaultConfig returns the default configuration for method override middleware.
@@ -36,6 +44,7 @@ func defaultConfig() *config {
 		onlyOn:           []string{"POST"},
 		respectBody:      false,
 		requireCSRFToken: false,
+		csrfVerifier:     CSRFVerified,
 	}
 }
 
@@ -104,7 +113,9 @@ func WithRespectBody(required bool) Option {
 
 // WithRequireCSRFToken requires CSRF token verification before allowing method override.
 // When enabled, the middleware expects a CSRF verification middleware to run first
-// and set the context so CSRFVerified(c) returns true.
+// and call [MarkCSRFVerified], so CSRFVerified(c) returns true. Use [WithCSRFVerifier]
+// to ask the CSRF middleware directly instead. Overrides of unverified requests
+// are ignored, and the request keeps its original method.
 // Default: false
 //
 // SECURITY WARNING: This middleware should only be used when you control
@@ -113,10 +124,47 @@ func WithRespectBody(required bool) Option {
 //
 // Example:
 //
-//	r.Use(csrf.Verify()) // Sets CSRF verification flag
+//	r.Use(csrfMiddleware) // Calls methodoverride.MarkCSRFVerified
 //	r.Use(methodoverride.New(methodoverride.WithRequireCSRFToken(true)))
 func WithRequireCSRFToken(required bool) Option {
 	return func(cfg *config) {
 		cfg.requireCSRFToken = required
 	}
 }
+
+// WithCSRFVerifier requires CSRF verification before allowing method override,
+// and sets the function that reports whether the CSRF middleware has verified
+// the request. It implies WithRequireCSRFToken(true).
+// Default: [CSRFVerified]
+//
+// Example:
+//
+//	r.Use(csrf.New())
+//	r.Use(methodoverride.New(methodoverride.WithCSRFVerifier(csrf.Verified)))
+func WithCSRFVerifier(verified func(c *router.Context) bool) Option {
+	return func(cfg *config) {
+		cfg.requireCSRFToken = true
+		cfg.csrfVerifier = verified
+	}
+}
+
+// WithAllowFrom sets which methods a request with the original method may be
+// overridden to. It takes precedence over [WithAllow] and [WithOnlyOn] for that
+// original method, and enables override on it. Calling it without methods
+// disables override for the original method.
+//
+// Example:
+//
+//	// POST may become PUT, PATCH, or DELETE; GET may only become HEAD
+//	methodoverride.New(
+//	    methodoverride.WithAllowFrom("POST", "PUT", "PATCH", "DELETE"),
+//	    methodoverride.WithAllowFrom("GET", "HEAD"),
+//	)
+func WithAllowFrom(original string, methods ...string) Option {
+	return func(cfg *config) {
+		if cfg.allowFrom == nil {
+			cfg.allowFrom = make(map[string][]string)
+		}
+		cfg.allowFrom[strings.ToUpper(original)] = methods
+	}
+}
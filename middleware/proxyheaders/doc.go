@@ -0,0 +1,56 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package proxyheaders provides middleware that applies the forwarding
+// headers of trusted reverse proxies to the request.
+//
+// Behind a load balancer or reverse proxy, r.RemoteAddr is the proxy's
+// address, and the scheme and host are those of the proxy's request. The
+// proxy reports the client's in forwarding headers, which anyone can send.
+// The middleware reads them only from trusted proxies and rewrites the
+// request, so that all downstream middleware agree on the client:
+//
+//   - r.RemoteAddr is the client's IP address
+//   - r.URL.Scheme and X-Forwarded-Proto are the scheme the client used
+//   - r.Host and r.URL.Host are the host the client requested
+//
+// Forwarding headers of requests that don't come from a trusted proxy are
+// removed.
+//
+// # Basic Usage
+//
+//	import "rivaas.dev/middleware/proxyheaders"
+//
+//	r := router.MustNew()
+//	r.Use(proxyheaders.New(
+//	    proxyheaders.WithTrustedProxies("10.0.0.0/8"),
+//	))
+//
+// Register it before any middleware that uses the client's address, such as
+// accesslog and ratelimit.
+//
+// # Header Formats
+//
+// By default the middleware reads X-Forwarded-For, X-Forwarded-Proto, and
+// X-Forwarded-Host. [WithFormat] switches to the Forwarded header of RFC
+// 7239. Use the format your proxies set: headers of the other format are
+// removed, since clients could have sent them.
+//
+// # Proxy Chains
+//
+// With several proxies, the chain is walked from the nearest proxy back to
+// the first address that is not a trusted proxy, which is the client.
+// Addresses a client put in the header itself come before that and are
+// ignored. [Peer] returns the address of the proxy that sent the request.
+package proxyheaders
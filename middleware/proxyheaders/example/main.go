@@ -0,0 +1,51 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package main demonstrates how to use the proxyheaders middleware
+// behind a reverse proxy.
+package main
+
+import (
+	"log"
+	"net/http"
+
+	"rivaas.dev/middleware/proxyheaders"
+	"rivaas.dev/router"
+)
+
+func main() {
+	r := router.MustNew()
+
+	// Trust proxies on this machine; in production, list your load balancers
+	r.Use(proxyheaders.New(
+		proxyheaders.WithTrustedProxies("127.0.0.1", "::1"),
+	))
+
+	r.GET("/", func(c *router.Context) {
+		err := c.JSON(http.StatusOK, map[string]string{
+			"client": c.ClientIP(),
+			"scheme": c.Scheme(),
+			"host":   c.Request.Host,
+			"proxy":  proxyheaders.Peer(c),
+		})
+		if err != nil {
+			log.Printf("write response: %v", err)
+		}
+	})
+
+	log.Println("Server starting on :8080")
+	log.Println("  curl http://localhost:8080/")
+	log.Println("  curl -H 'X-Forwarded-For: 203.0.113.7' -H 'X-Forwarded-Proto: https' http://localhost:8080/")
+	log.Fatal(http.ListenAndServe(":8080", r))
+}
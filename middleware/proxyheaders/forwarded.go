@@ -0,0 +1,137 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxyheaders
+
+import (
+	"net/netip"
+	"strings"
+)
+
+// parseForwarded returns the hops of Forwarded header values (RFC 7239),
+// from the client to the nearest proxy. A malformed header yields no hops,
+// so that it is ignored as a whole.
+func parseForwarded(values []string) []hop {
+	var hops []hop
+	for _, value := range values {
+		elements, ok := splitQuoted(value, ',')
+		if !ok {
+			return nil
+		}
+		for _, element := range elements {
+			h, ok := parseElement(element)
+			if !ok {
+				return nil
+			}
+			hops = append(hops, h)
+		}
+	}
+
+	return hops
+}
+
+// parseElement parses a forwarded-element such as
+// for=192.0.2.60;proto=https;host=example.com.
+func parseElement(element string) (hop, bool) {
+	var h hop
+	pairs, ok := splitQuoted(element, ';')
+	if !ok {
+		return h, false
+	}
+	for _, pair := range pairs {
+		name, value, found := strings.Cut(pair, "=")
+		if !found {
+			return h, false
+		}
+		value, ok := unquote(strings.TrimSpace(value))
+		if !ok {
+			return h, false
+		}
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "for":
+			h.addr = parseNode(value)
+		case "proto":
+			h.proto = value
+		case "host":
+			h.host = value
+		}
+	}
+
+	return h, true
+}
+
+// parseNode returns the IP address of a node such as 192.0.2.43,
+// 192.0.2.43:47011, or [2001:db8:cafe::17]:4711. Obfuscated identifiers and
+// "unknown" yield an invalid address.
+func parseNode(node string) netip.Addr {
+	if addrPort, err := netip.ParseAddrPort(node); err == nil {
+		return addrPort.Addr()
+	}
+	node = strings.TrimSuffix(strings.TrimPrefix(node, "["), "]")
+	addr, _ := netip.ParseAddr(node) //nolint:errcheck // Invalid means unknown
+
+	return addr
+}
+
+// splitQuoted splits s at sep outside quoted strings and trims the parts.
+// It reports false if a quoted string is not terminated.
+func splitQuoted(s string, sep byte) ([]string, bool) {
+	var parts []string
+	quoted, escaped := false, false
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch {
+		case escaped:
+			escaped = false
+		case quoted && s[i] == '\\':
+			escaped = true
+		case s[i] == '"':
+			quoted = !quoted
+		case !quoted && s[i] == sep:
+			if part := strings.TrimSpace(s[start:i]); part != "" {
+				parts = append(parts, part)
+			}
+			start = i + 1
+		}
+	}
+	if quoted {
+		return nil, false
+	}
+	if part := strings.TrimSpace(s[start:]); part != "" {
+		parts = append(parts, part)
+	}
+
+	return parts, true
+}
+
+// unquote returns the value of a token or quoted-string.
+func unquote(value string) (string, bool) {
+	if !strings.HasPrefix(value, `"`) {
+		return value, !strings.ContainsAny(value, "\" \t")
+	}
+	if len(value) < 2 || !strings.HasSuffix(value, `"`) {
+		return "", false
+	}
+
+	var b strings.Builder
+	inner := value[1 : len(value)-1]
+	for i := 0; i < len(inner); i++ {
+		if inner[i] == '\\' && i+1 < len(inner) {
+			i++
+		}
+		b.WriteByte(inner[i])
+	}
+
+	return b.String(), true
+}
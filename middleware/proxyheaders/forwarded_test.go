@@ -0,0 +1,77 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package proxyheaders
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseForwarded(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		values []string
+		want   []string // "addr proto host" of each hop
+	}{
+		{
+			name:   "single",
+			values: []string{"for=192.0.2.60;proto=http;by=203.0.113.43"},
+			want:   []string{"192.0.2.60 http "},
+		},
+		{
+			name:   "case insensitive names",
+			values: []string{"For=192.0.2.60;PROTO=https"},
+			want:   []string{"192.0.2.60 https "},
+		},
+		{
+			name:   "list and multiple values",
+			values: []string{"for=192.0.2.43, for=198.51.100.17", "for=10.0.0.1"},
+			want:   []string{"192.0.2.43  ", "198.51.100.17  ", "10.0.0.1  "},
+		},
+		{
+			name:   "quoted IPv6 with port",
+			values: []string{`for="[2001:db8:cafe::17]:4711"`},
+			want:   []string{"2001:db8:cafe::17  "},
+		},
+		{
+			name:   "quoted comma and escape",
+			values: []string{`for=192.0.2.43;host="a\"b,c"`},
+			want:   []string{`192.0.2.43  a"b,c`},
+		},
+		{
+			name:   "obfuscated",
+			values: []string{"for=_hidden, for=unknown"},
+			want:   []string{"invalid IP  ", "invalid IP  "},
+		},
+		{name: "unterminated quote", values: []string{`for="192.0.2.43`}},
+		{name: "missing value", values: []string{"for"}},
+		{name: "space in token", values: []string{"for=192.0.2.43 x"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var got []string
+			for _, h := range parseForwarded(tt.values) {
+				got = append(got, h.addr.String()+" "+h.proto+" "+h.host)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
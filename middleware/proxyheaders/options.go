@@ -0,0 +1,73 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxyheaders
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+)
+
+// WithTrustedProxies sets the IP addresses and CIDR ranges of the proxies
+// whose forwarding headers are trusted. Only list proxies you operate; a
+// trusted source can claim any client address. New panics on invalid
+// addresses.
+//
+// Example:
+//
+//	proxyheaders.New(proxyheaders.WithTrustedProxies("10.0.0.0/8", "192.0.2.10"))
+func WithTrustedProxies(proxies ...string) Option {
+	return func(cfg *config) {
+		for _, proxy := range proxies {
+			cfg.trusted = append(cfg.trusted, mustParsePrefix(proxy))
+		}
+	}
+}
+
+// WithFormat sets the forwarding headers the trusted proxies set: the
+// X-Forwarded-* headers or the Forwarded header of RFC 7239. Headers of the
+// other format are removed, as clients could have sent them.
+// Default: XForwarded
+//
+// Example:
+//
+//	proxyheaders.New(
+//	    proxyheaders.WithTrustedProxies("10.0.0.0/8"),
+//	    proxyheaders.WithFormat(proxyheaders.Forwarded),
+//	)
+func WithFormat(format Format) Option {
+	return func(cfg *config) {
+		cfg.format = format
+	}
+}
+
+// mustParsePrefix parses an IP address or CIDR range.
+func mustParsePrefix(s string) netip.Prefix {
+	if strings.Contains(s, "/") {
+		prefix, err := netip.ParsePrefix(s)
+		if err != nil {
+			panic(fmt.Sprintf("proxyheaders: invalid trusted proxy %q: %v", s, err))
+		}
+		return prefix.Masked()
+	}
+
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		panic(fmt.Sprintf("proxyheaders: invalid trusted proxy %q: %v", s, err))
+	}
+	addr = addr.Unmap()
+
+	return netip.PrefixFrom(addr, addr.BitLen())
+}
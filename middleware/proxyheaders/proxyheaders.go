@@ -0,0 +1,293 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxyheaders
+
+import (
+	"context"
+	"net/http"
+	"net/netip"
+	"strings"
+
+	"rivaas.dev/router"
+)
+
+// Format selects the forwarding headers the trusted proxies set.
+type Format int
+
+const (
+	// XForwarded uses the de facto standard X-Forwarded-For,
+	// X-Forwarded-Proto, and X-Forwarded-Host headers.
+	XForwarded Format = iota
+
+	// Forwarded uses the Forwarded header of RFC 7239.
+	Forwarded
+)
+
+// PrivateNetworks lists the loopback and private address ranges, for proxies
+// that run in the same network as the application.
+//
+// Example:
+//
+//	proxyheaders.New(proxyheaders.WithTrustedProxies(proxyheaders.PrivateNetworks...))
+var PrivateNetworks = []string{
+	"127.0.0.0/8",
+	"10.0.0.0/8",
+	"172.16.0.0/12",
+	"192.168.0.0/16",
+	"::1/128",
+	"fc00::/7",
+}
+
+// headers are the forwarding headers the middleware reads or removes.
+var headers = []string{
+	"Forwarded",
+	"X-Forwarded-For",
+	"X-Forwarded-Proto",
+	"X-Forwarded-Host",
+	"X-Forwarded-Port",
+	"X-Forwarded-Ssl",
+	"X-Real-Ip",
+}
+
+type contextKey struct{}
+
+// Option defines functional options for proxyheaders middleware configuration.
+type Option func(*config)
+
+// config holds the configuration for the proxyheaders middleware.
+type config struct {
+	// trusted are the networks of the trusted proxies
+	trusted []netip.Prefix
+
+	// format selects the headers to read
+	format Format
+}
+
+// defaultConfig returns the default configuration for proxyheaders middleware.
+func defaultConfig() *config {
+	return &config{
+		format: XForwarded,
+	}
+}
+
+// hop is the information one proxy forwarded about the client or proxy that
+// connected to it.
+type hop struct {
+	addr  netip.Addr // Invalid if unknown or obfuscated
+	proto string
+	host  string
+}
+
+// New returns a middleware that applies the forwarding headers of trusted
+// proxies to the request, so that downstream middleware and handlers see the
+// client's address, scheme, and host instead of the proxy's.
+//
+// If the request comes from a trusted proxy, the middleware walks the
+// forwarded chain from the nearest proxy back to the first address that is
+// not a trusted proxy: the client. It then sets
+//
+//   - r.RemoteAddr to the client's IP address
+//   - r.URL.Scheme and X-Forwarded-Proto to the scheme the client used
+//   - r.Host and r.URL.Host to the host the client requested
+//
+// Forwarding headers of requests from other sources are removed, so that
+// clients can't spoof their address or scheme. Without [WithTrustedProxies],
+// no proxy is trusted and the headers are always removed.
+//
+// Register it first, so that every other middleware sees the rewritten
+// request:
+//
+//	r := router.MustNew()
+//	r.Use(proxyheaders.New(
+//	    proxyheaders.WithTrustedProxies("10.0.0.0/8"),
+//	))
+func New(opts ...Option) router.HandlerFunc {
+	cfg := defaultConfig()
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return func(c *router.Context) {
+		req := c.Request
+		peer := req.RemoteAddr
+		if !cfg.isTrusted(addrOf(peer)) {
+			for _, name := range headers {
+				req.Header.Del(name)
+			}
+			c.Next()
+			return
+		}
+
+		var hops []hop
+		if cfg.format == Forwarded {
+			hops = parseForwarded(req.Header.Values("Forwarded"))
+		} else {
+			hops = parseXForwarded(req.Header)
+		}
+		client, ok := cfg.client(hops)
+
+		// Leave only the headers downstream code should look at
+		for _, name := range headers {
+			if !cfg.keeps(name) {
+				req.Header.Del(name)
+			}
+		}
+		if ok {
+			apply(req, client)
+		}
+
+		ctx := context.WithValue(req.Context(), contextKey{}, peer)
+		c.Request = req.WithContext(ctx)
+		c.Next()
+	}
+}
+
+// client returns the hop of the client: the nearest hop that is not a
+// trusted proxy, or the farthest hop if all are trusted.
+func (cfg *config) client(hops []hop) (hop, bool) {
+	for i := len(hops) - 1; i >= 0; i-- {
+		if i == 0 || !cfg.isTrusted(hops[i].addr) {
+			return hops[i], true
+		}
+	}
+
+	return hop{}, false
+}
+
+// keeps reports whether the header name is left on requests from trusted
+// proxies: the chain of the configured format, for logging. Scheme headers
+// are replaced with the resolved X-Forwarded-Proto.
+func (cfg *config) keeps(name string) bool {
+	if cfg.format == Forwarded {
+		return name == "Forwarded"
+	}
+
+	return name == "X-Forwarded-For" || name == "X-Forwarded-Host"
+}
+
+// isTrusted reports whether addr belongs to a trusted proxy.
+func (cfg *config) isTrusted(addr netip.Addr) bool {
+	if !addr.IsValid() {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, prefix := range cfg.trusted {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// apply rewrites req with the information of the client's hop.
+func apply(req *http.Request, client hop) {
+	if client.addr.IsValid() {
+		req.RemoteAddr = client.addr.Unmap().String()
+	}
+	if proto := strings.ToLower(client.proto); proto == "http" || proto == "https" {
+		req.URL.Scheme = proto
+		req.Header.Set("X-Forwarded-Proto", proto)
+	}
+	if validHost(client.host) {
+		req.Host = client.host
+		req.URL.Host = client.host
+	}
+}
+
+// parseXForwarded returns the hops of the X-Forwarded-* headers. Proxies
+// that append to X-Forwarded-Proto and X-Forwarded-Host as they do to
+// X-Forwarded-For produce lists of the same length; otherwise the last value
+// applies to every hop.
+func parseXForwarded(header http.Header) []hop {
+	addrs := splitList(header.Values("X-Forwarded-For"))
+	protos := splitList(header.Values("X-Forwarded-Proto"))
+	hosts := splitList(header.Values("X-Forwarded-Host"))
+
+	if len(addrs) == 0 {
+		if len(protos) == 0 && len(hosts) == 0 {
+			return nil
+		}
+		return []hop{{proto: last(protos), host: last(hosts)}}
+	}
+
+	hops := make([]hop, len(addrs))
+	for i, addr := range addrs {
+		hops[i].addr = parseNode(addr)
+		hops[i].proto = aligned(protos, i, len(addrs))
+		hops[i].host = aligned(hosts, i, len(addrs))
+	}
+
+	return hops
+}
+
+// aligned returns values[i] if values has n entries, or its last entry.
+func aligned(values []string, i, n int) string {
+	if len(values) == n {
+		return values[i]
+	}
+
+	return last(values)
+}
+
+// last returns the last entry of values, or an empty string.
+func last(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+
+	return values[len(values)-1]
+}
+
+// splitList splits comma-separated header values.
+func splitList(values []string) []string {
+	var list []string
+	for _, value := range values {
+		for item := range strings.SplitSeq(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+	}
+
+	return list
+}
+
+// addrOf returns the IP address of a RemoteAddr.
+func addrOf(remoteAddr string) netip.Addr {
+	if addrPort, err := netip.ParseAddrPort(remoteAddr); err == nil {
+		return addrPort.Addr()
+	}
+	addr, _ := netip.ParseAddr(remoteAddr) //nolint:errcheck // Invalid means untrusted
+
+	return addr
+}
+
+// validHost reports whether host is a plausible Host header value.
+func validHost(host string) bool {
+	return host != "" && !strings.ContainsAny(host, " \t/\\,;@?#")
+}
+
+// Peer returns the address of the proxy that sent the request, as r.RemoteAddr
+// was before the middleware rewrote it. It returns an empty string if the
+// request didn't come from a trusted proxy.
+//
+// Example:
+//
+//	logger.Info("request", "client", c.ClientIP(), "proxy", proxyheaders.Peer(c))
+func Peer(c *router.Context) string {
+	peer, _ := c.Request.Context().Value(contextKey{}).(string)
+	return peer
+}
@@ -0,0 +1,178 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package proxyheaders
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"rivaas.dev/router"
+)
+
+// seen is what the handler saw of a request.
+type seen struct {
+	remoteAddr, scheme, host, clientIP, peer string
+	header                                   http.Header
+}
+
+func serve(t *testing.T, mw router.HandlerFunc, remoteAddr string, header http.Header) seen {
+	t.Helper()
+	var s seen
+	r := router.MustNew()
+	r.Use(mw)
+	r.GET("/", func(c *router.Context) {
+		s = seen{
+			remoteAddr: c.Request.RemoteAddr,
+			scheme:     c.Scheme(),
+			host:       c.Request.Host,
+			clientIP:   c.ClientIP(),
+			peer:       Peer(c),
+			header:     c.Request.Header,
+		}
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "http://app.internal/", nil)
+	req.RemoteAddr = remoteAddr
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	return s
+}
+
+func TestProxyHeaders_XForwarded(t *testing.T) {
+	t.Parallel()
+	mw := New(WithTrustedProxies("10.0.0.0/8"))
+
+	s := serve(t, mw, "10.0.0.2:5000", http.Header{
+		"X-Forwarded-For":   {"203.0.113.7, 10.0.0.1"},
+		"X-Forwarded-Proto": {"https"},
+		"X-Forwarded-Host":  {"api.example.com"},
+		"X-Real-Ip":         {"198.51.100.1"},
+		"Forwarded":         {"for=198.51.100.1"},
+	})
+	assert.Equal(t, "203.0.113.7", s.remoteAddr)
+	assert.Equal(t, "203.0.113.7", s.clientIP)
+	assert.Equal(t, "https", s.scheme)
+	assert.Equal(t, "api.example.com", s.host)
+	assert.Equal(t, "10.0.0.2:5000", s.peer)
+	assert.Equal(t, "203.0.113.7, 10.0.0.1", s.header.Get("X-Forwarded-For"), "the chain is kept")
+	assert.Empty(t, s.header.Get("X-Real-Ip"))
+	assert.Empty(t, s.header.Get("Forwarded"), "the other format is removed")
+}
+
+func TestProxyHeaders_Untrusted(t *testing.T) {
+	t.Parallel()
+	mw := New(WithTrustedProxies("10.0.0.0/8"))
+
+	s := serve(t, mw, "203.0.113.7:5000", http.Header{
+		"X-Forwarded-For":   {"1.2.3.4"},
+		"X-Forwarded-Proto": {"https"},
+		"X-Forwarded-Host":  {"evil.example.com"},
+		"X-Forwarded-Ssl":   {"on"},
+		"Forwarded":         {"for=1.2.3.4;proto=https"},
+	})
+	assert.Equal(t, "203.0.113.7:5000", s.remoteAddr)
+	assert.Equal(t, "http", s.scheme, "spoofed scheme headers are removed")
+	assert.Equal(t, "app.internal", s.host)
+	assert.Empty(t, s.peer)
+	for _, name := range headers {
+		assert.Empty(t, s.header.Get(name), name)
+	}
+
+	// Without trusted proxies, headers are always removed
+	s = serve(t, New(), "10.0.0.2:5000", http.Header{"X-Forwarded-For": {"1.2.3.4"}})
+	assert.Equal(t, "10.0.0.2:5000", s.remoteAddr)
+	assert.Empty(t, s.header.Get("X-Forwarded-For"))
+}
+
+func TestProxyHeaders_SpoofedChain(t *testing.T) {
+	t.Parallel()
+	mw := New(WithTrustedProxies("10.0.0.1"))
+
+	// The client prepended a fake address; the proxy appended the real one
+	s := serve(t, mw, "10.0.0.1:5000", http.Header{
+		"X-Forwarded-For": {"1.2.3.4, 203.0.113.7"},
+	})
+	assert.Equal(t, "203.0.113.7", s.remoteAddr)
+}
+
+func TestProxyHeaders_AlignedLists(t *testing.T) {
+	t.Parallel()
+	mw := New(WithTrustedProxies(PrivateNetworks...))
+
+	// The edge proxy terminated TLS; the internal proxy spoke plain HTTP
+	s := serve(t, mw, "10.0.0.2:5000", http.Header{
+		"X-Forwarded-For":   {"203.0.113.7", "10.0.0.1"},
+		"X-Forwarded-Proto": {"https, http"},
+	})
+	assert.Equal(t, "203.0.113.7", s.remoteAddr)
+	assert.Equal(t, "https", s.scheme)
+	assert.Equal(t, "app.internal", s.host)
+}
+
+func TestProxyHeaders_Forwarded(t *testing.T) {
+	t.Parallel()
+	mw := New(WithTrustedProxies("10.0.0.0/8", "2001:db8::/32"), WithFormat(Forwarded))
+
+	s := serve(t, mw, "10.0.0.2:5000", http.Header{
+		"Forwarded": {
+			`for="[2001:db8:cafe::17]:4711";proto=http, for=198.51.100.1;proto=https;host="api.example.com"`,
+			`for=10.0.0.1;proto=http`,
+		},
+		"X-Forwarded-For": {"1.2.3.4"},
+	})
+	assert.Equal(t, "198.51.100.1", s.remoteAddr)
+	assert.Equal(t, "https", s.scheme)
+	assert.Equal(t, "api.example.com", s.host)
+	assert.Empty(t, s.header.Get("X-Forwarded-For"), "the other format is removed")
+	assert.NotEmpty(t, s.header.Get("Forwarded"))
+
+	// A malformed header is ignored
+	s = serve(t, mw, "10.0.0.2:5000", http.Header{"Forwarded": {`for="198.51.100.1`}})
+	assert.Equal(t, "10.0.0.2:5000", s.remoteAddr)
+}
+
+func TestProxyHeaders_InvalidValues(t *testing.T) {
+	t.Parallel()
+	mw := New(WithTrustedProxies("10.0.0.0/8"))
+
+	s := serve(t, mw, "10.0.0.2:5000", http.Header{
+		"X-Forwarded-For":   {"unknown"},
+		"X-Forwarded-Proto": {"gopher"},
+		"X-Forwarded-Host":  {"evil.example.com/path"},
+	})
+	assert.Equal(t, "10.0.0.2:5000", s.remoteAddr)
+	assert.Equal(t, "http", s.scheme)
+	assert.Equal(t, "app.internal", s.host)
+}
+
+func TestWithTrustedProxies_Invalid(t *testing.T) {
+	t.Parallel()
+	assert.Panics(t, func() { New(WithTrustedProxies("10.0.0.0/33")) })
+	assert.Panics(t, func() { New(WithTrustedProxies("proxy.internal")) })
+
+	cfg := defaultConfig()
+	WithTrustedProxies("::ffff:10.0.0.1", "192.0.2.0/24")(cfg)
+	require.Len(t, cfg.trusted, 2)
+	assert.Equal(t, "10.0.0.1/32", cfg.trusted[0].String())
+}
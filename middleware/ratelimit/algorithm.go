@@ -0,0 +1,199 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Algorithm selects how a [Limit] is enforced.
+type Algorithm string
+
+const (
+	// TokenBucketAlgorithm refills Rate tokens per Period into a bucket that
+	// holds up to Burst tokens (default). It allows short bursts while keeping
+	// a steady average rate.
+	TokenBucketAlgorithm Algorithm = "token-bucket"
+
+	// GCRAAlgorithm is the generic cell rate algorithm. It behaves like the
+	// token bucket but keeps a single timestamp per key, which makes it cheap
+	// to store in a shared backend.
+	GCRAAlgorithm Algorithm = "gcra"
+
+	// FixedWindowAlgorithm allows Rate requests per calendar-aligned Period
+	// (e.g. per clock minute) and resets the count when the window ends. Use
+	// it for strict quotas such as billing limits. Burst is ignored.
+	FixedWindowAlgorithm Algorithm = "fixed-window"
+
+	// SlidingWindowLogAlgorithm allows at most Rate requests in any trailing
+	// Period by remembering the time of each request. It is the most precise
+	// algorithm and uses memory proportional to Rate per key. Burst is ignored.
+	SlidingWindowLogAlgorithm Algorithm = "sliding-window-log"
+)
+
+// valid reports whether a is a known algorithm.
+func (a Algorithm) valid() bool {
+	switch a {
+	case TokenBucketAlgorithm, GCRAAlgorithm, FixedWindowAlgorithm, SlidingWindowLogAlgorithm:
+		return true
+	default:
+		return false
+	}
+}
+
+// errUnknownAlgorithm returns the error stores report for an unknown algorithm.
+func errUnknownAlgorithm(a Algorithm) error {
+	return fmt.Errorf("ratelimit: unknown algorithm %q", a)
+}
+
+// entry is the rate limit state of a single key in a [MemoryStore]. Only the
+// fields of the entry's algorithm are used.
+type entry struct {
+	alg     Algorithm
+	expires time.Time // time the state is equivalent to a fresh entry
+
+	tokens float64   // token bucket: available tokens
+	last   time.Time // token bucket: time of the last refill
+
+	tat time.Time // GCRA: theoretical arrival time
+
+	window time.Time // fixed window: start of the current window
+	count  int       // fixed window: requests in the current window
+
+	log []time.Time // sliding window log: one timestamp per consumed unit, oldest first
+}
+
+// take applies a request of cost to e at now.
+func (e *entry) take(now time.Time, limit Limit, cost int) Result {
+	switch limit.Algorithm {
+	case GCRAAlgorithm:
+		return e.takeGCRA(now, limit, cost)
+	case FixedWindowAlgorithm:
+		return e.takeFixedWindow(now, limit, cost)
+	case SlidingWindowLogAlgorithm:
+		return e.takeSlidingWindowLog(now, limit, cost)
+	default:
+		return e.takeTokenBucket(now, limit, cost)
+	}
+}
+
+func (e *entry) takeTokenBucket(now time.Time, limit Limit, cost int) Result {
+	burst := float64(limit.Burst)
+	perToken := limit.interval()
+
+	if e.last.IsZero() {
+		e.tokens, e.last = burst, now
+	}
+
+	// Refill tokens based on elapsed time
+	if elapsed := now.Sub(e.last); elapsed > 0 {
+		e.tokens = math.Min(burst, e.tokens+float64(elapsed)/perToken)
+		e.last = now
+	}
+
+	res := Result{Limit: limit.Burst}
+	if e.tokens >= float64(cost) {
+		e.tokens -= float64(cost)
+		res.Allowed = true
+	} else {
+		res.RetryAfter = time.Duration((float64(cost) - e.tokens) * perToken)
+	}
+	res.Remaining = int(e.tokens)
+	res.ResetAfter = time.Duration((burst - e.tokens) * perToken)
+	e.expires = now.Add(res.ResetAfter)
+
+	return res
+}
+
+func (e *entry) takeGCRA(now time.Time, limit Limit, cost int) Result {
+	perToken := limit.interval()
+	capacity := time.Duration(float64(limit.Burst) * perToken)
+
+	tat := e.tat
+	if tat.Before(now) {
+		tat = now
+	}
+	next := tat.Add(time.Duration(float64(cost) * perToken))
+
+	res := Result{Limit: limit.Burst}
+	if next.Sub(now) <= capacity {
+		tat = next
+		e.tat = next
+		res.Allowed = true
+	} else {
+		res.RetryAfter = next.Sub(now) - capacity
+	}
+	res.ResetAfter = tat.Sub(now)
+	// One extra nanosecond absorbs the truncation of durations above.
+	res.Remaining = int(float64(capacity-res.ResetAfter+1) / perToken)
+	e.expires = tat
+
+	return res
+}
+
+func (e *entry) takeFixedWindow(now time.Time, limit Limit, cost int) Result {
+	// Windows are aligned to the Unix epoch, like in RedisStore.
+	start := time.Unix(0, now.UnixNano()-now.UnixNano()%int64(limit.Period))
+	if !e.window.Equal(start) {
+		e.window, e.count = start, 0
+	}
+	end := start.Add(limit.Period)
+
+	res := Result{Limit: limit.Rate, ResetAfter: end.Sub(now)}
+	if e.count+cost <= limit.Rate {
+		e.count += cost
+		res.Allowed = true
+	} else {
+		res.RetryAfter = res.ResetAfter
+	}
+	res.Remaining = max(limit.Rate-e.count, 0)
+	e.expires = end
+
+	return res
+}
+
+func (e *entry) takeSlidingWindowLog(now time.Time, limit Limit, cost int) Result {
+	cutoff := now.Add(-limit.Period)
+	drop := 0
+	for drop < len(e.log) && !e.log[drop].After(cutoff) {
+		drop++
+	}
+	e.log = e.log[drop:]
+
+	res := Result{Limit: limit.Rate}
+	switch {
+	case len(e.log)+cost <= limit.Rate:
+		for range cost {
+			e.log = append(e.log, now)
+		}
+		res.Allowed = true
+	case cost > limit.Rate:
+		res.RetryAfter = limit.Period
+	default:
+		// Wait until enough of the oldest requests leave the window.
+		res.RetryAfter = e.log[len(e.log)+cost-limit.Rate-1].Sub(cutoff)
+	}
+	res.Remaining = max(limit.Rate-len(e.log), 0)
+	if n := len(e.log); n > 0 {
+		res.ResetAfter = e.log[n-1].Sub(cutoff)
+		e.expires = e.log[n-1].Add(limit.Period)
+	} else {
+		e.expires = now
+	}
+
+	return res
+}
@@ -0,0 +1,164 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package ratelimit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"rivaas.dev/router"
+)
+
+// testEpoch is the start time of store clocks in tests. It is aligned to the minute.
+var testEpoch = time.Unix(1_699_999_980, 0)
+
+// clockedStore is a store with a controllable clock.
+type clockedStore struct {
+	Store
+	advance func(time.Duration)
+}
+
+// testStores returns every store implementation, each with its own clock.
+func testStores(t *testing.T) map[string]clockedStore {
+	t.Helper()
+
+	memory, clock := newTestMemoryStore()
+	redisStore, mr := newTestRedisStore(t)
+	redisNow := testEpoch
+
+	return map[string]clockedStore{
+		"memory": {Store: memory, advance: clock.advance},
+		"redis": {Store: redisStore, advance: func(d time.Duration) {
+			redisNow = redisNow.Add(d)
+			mr.SetTime(redisNow)
+		}},
+	}
+}
+
+// step is one request in an algorithm scenario.
+type step struct {
+	advance    time.Duration // clock advance before the request
+	cost       int
+	allowed    bool
+	remaining  int
+	retryAfter time.Duration
+}
+
+func TestAlgorithms(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		limit Limit
+		steps []step
+	}{
+		{
+			name:  "GCRA",
+			limit: Limit{Algorithm: GCRAAlgorithm, Rate: 2, Burst: 3},
+			steps: []step{
+				{cost: 1, allowed: true, remaining: 2},
+				{cost: 1, allowed: true, remaining: 1},
+				{cost: 1, allowed: true, remaining: 0},
+				{cost: 1, retryAfter: 500 * time.Millisecond},
+				{advance: 500 * time.Millisecond, cost: 1, allowed: true, remaining: 0},
+				{advance: 2 * time.Second, cost: 3, allowed: true, remaining: 0},
+			},
+		},
+		{
+			name:  "fixed window",
+			limit: Limit{Algorithm: FixedWindowAlgorithm, Rate: 3, Period: time.Minute},
+			steps: []step{
+				{advance: 10 * time.Second, cost: 2, allowed: true, remaining: 1},
+				{cost: 1, allowed: true, remaining: 0},
+				{advance: 40 * time.Second, cost: 1, retryAfter: 10 * time.Second},
+				{advance: 10 * time.Second, cost: 1, allowed: true, remaining: 2},
+			},
+		},
+		{
+			name:  "sliding window log",
+			limit: Limit{Algorithm: SlidingWindowLogAlgorithm, Rate: 3, Period: 10 * time.Second},
+			steps: []step{
+				{cost: 1, allowed: true, remaining: 2},
+				{advance: 4 * time.Second, cost: 2, allowed: true, remaining: 0},
+				{advance: time.Second, cost: 1, retryAfter: 5 * time.Second},
+				{cost: 2, retryAfter: 9 * time.Second},
+				{advance: 5 * time.Second, cost: 1, allowed: true, remaining: 0},
+				{cost: 4, retryAfter: 10 * time.Second},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			for name, store := range testStores(t) {
+				for i, s := range tt.steps {
+					store.advance(s.advance)
+					res, err := store.Take(t.Context(), "k", tt.limit, s.cost)
+					require.NoError(t, err)
+					assert.Equal(t, s.allowed, res.Allowed, "%s: step %d allowed", name, i)
+					assert.Equal(t, s.remaining, res.Remaining, "%s: step %d remaining", name, i)
+					assert.Equal(t, s.retryAfter, res.RetryAfter, "%s: step %d retry after", name, i)
+				}
+			}
+		})
+	}
+}
+
+func TestAlgorithms_Unknown(t *testing.T) {
+	t.Parallel()
+
+	for name, store := range testStores(t) {
+		_, err := store.Take(t.Context(), "k", Limit{Algorithm: "leaky", Rate: 1}, 1)
+		require.Error(t, err, name)
+		assert.Contains(t, err.Error(), `unknown algorithm "leaky"`, name)
+	}
+
+	assert.Panics(t, func() { New(WithAlgorithm("leaky")) })
+}
+
+func TestNew_WithAlgorithm(t *testing.T) {
+	t.Parallel()
+
+	r := router.MustNew()
+	r.Use(New(
+		WithAlgorithm(FixedWindowAlgorithm),
+		WithRate(2, time.Hour),
+		WithBurst(50),
+	))
+	r.GET("/test", func(c *router.Context) {
+		//nolint:errcheck // Test handler
+		c.String(http.StatusOK, "ok")
+	})
+
+	codes := make([]int, 0, 3)
+	var w *httptest.ResponseRecorder
+	for range 3 {
+		w = httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/test", nil))
+		codes = append(codes, w.Code)
+	}
+	assert.Equal(t, []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests}, codes, "burst is ignored by window algorithms")
+	assert.Equal(t, "2", w.Header().Get("RateLimit-Limit"))
+	assert.NotEmpty(t, w.Header().Get("Retry-After"))
+}
@@ -0,0 +1,182 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+var (
+	// errKeyBusy reports that a key has too many requests in flight.
+	errKeyBusy = errors.New("too many concurrent requests for key")
+	// errGlobalBusy reports that the instance has too many requests in flight.
+	errGlobalBusy = errors.New("too many concurrent requests")
+)
+
+// WithConcurrencyLimit bounds the number of requests in flight per key and
+// across all keys, in addition to the rate limit. Rate limits miss slow
+// requests piling up; this bound does not. Zero disables a bound.
+// Default: no bounds
+//
+// Requests over the per-key bound get 429 Too Many Requests, requests over the
+// global bound 503 Service Unavailable; a handler set with [WithHandler] is
+// called instead. Use [WithConcurrencyWait] to queue requests briefly instead
+// of rejecting them. In-flight requests are counted per instance, even with a
+// shared [Store].
+//
+// Example:
+//
+//	ratelimit.New(
+//	    ratelimit.WithConcurrencyLimit(4, 200), // 4 per client, 200 in total
+//	    ratelimit.WithConcurrencyWait(100*time.Millisecond),
+//	)
+func WithConcurrencyLimit(perKey, global int) Option {
+	return func(cfg *config) {
+		cfg.maxInFlightPerKey = max(perKey, 0)
+		cfg.maxInFlight = max(global, 0)
+	}
+}
+
+// WithConcurrencyWait sets how long a request waits for a free slot when a
+// bound from [WithConcurrencyLimit] is reached. The wait also ends when the
+// client goes away. Default: 0 (reject immediately)
+func WithConcurrencyWait(d time.Duration) Option {
+	return func(cfg *config) {
+		cfg.concurrencyWait = max(d, 0)
+	}
+}
+
+// keySlots is the semaphore of a single key. users counts holders and
+// waiters, so the semaphore can be dropped when nobody uses it.
+type keySlots struct {
+	sem   chan struct{}
+	users int
+}
+
+// concurrencyLimiter bounds in-flight requests per key and globally.
+type concurrencyLimiter struct {
+	perKey int
+	global chan struct{} // nil if unbounded
+	wait   time.Duration
+
+	mu   sync.Mutex
+	keys map[string]*keySlots
+}
+
+// newConcurrencyLimiter returns nil if both bounds are disabled.
+func newConcurrencyLimiter(perKey, global int, wait time.Duration) *concurrencyLimiter {
+	if perKey == 0 && global == 0 {
+		return nil
+	}
+
+	l := &concurrencyLimiter{
+		perKey: perKey,
+		wait:   wait,
+		keys:   make(map[string]*keySlots),
+	}
+	if global > 0 {
+		l.global = make(chan struct{}, global)
+	}
+
+	return l
+}
+
+// acquire takes a slot for key and a global slot. It returns a function that
+// releases both, or errKeyBusy or errGlobalBusy.
+func (l *concurrencyLimiter) acquire(ctx context.Context, key string) (func(), error) {
+	var deadline <-chan time.Time
+	if l.wait > 0 {
+		timer := time.NewTimer(l.wait)
+		defer timer.Stop()
+		deadline = timer.C
+	}
+
+	releaseKey := func() {}
+	if l.perKey > 0 {
+		slots := l.join(key)
+		if !take(ctx, slots.sem, deadline) {
+			l.leave(key, slots)
+			return nil, errKeyBusy
+		}
+		releaseKey = func() {
+			<-slots.sem
+			l.leave(key, slots)
+		}
+	}
+
+	if l.global != nil {
+		if !take(ctx, l.global, deadline) {
+			releaseKey()
+			return nil, errGlobalBusy
+		}
+		return func() {
+			<-l.global
+			releaseKey()
+		}, nil
+	}
+
+	return releaseKey, nil
+}
+
+// join returns the semaphore of key and registers the caller as a user.
+func (l *concurrencyLimiter) join(key string) *keySlots {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	slots, ok := l.keys[key]
+	if !ok {
+		slots = &keySlots{sem: make(chan struct{}, l.perKey)}
+		l.keys[key] = slots
+	}
+	slots.users++
+
+	return slots
+}
+
+// leave unregisters a user of key and drops the semaphore when unused.
+func (l *concurrencyLimiter) leave(key string, slots *keySlots) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	slots.users--
+	if slots.users == 0 {
+		delete(l.keys, key)
+	}
+}
+
+// take puts a token into sem, waiting until deadline fires (nil means do not
+// wait) or ctx is done. It reports whether a slot was taken.
+func take(ctx context.Context, sem chan struct{}, deadline <-chan time.Time) bool {
+	select {
+	case sem <- struct{}{}:
+		return true
+	default:
+	}
+	if deadline == nil {
+		return false
+	}
+
+	select {
+	case sem <- struct{}{}:
+		return true
+	case <-deadline:
+		return false
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"rivaas.dev/router"
+)
+
+// newBlockingRouter returns a router whose /slow handler signals started and
+// then blocks until unblock is closed.
+func newBlockingRouter(limiter *Limiter, started chan<- struct{}, unblock <-chan struct{}) *router.Router {
+	r := router.MustNew()
+	r.Use(limiter.Handler())
+	r.GET("/slow", func(c *router.Context) {
+		started <- struct{}{}
+		<-unblock
+		//nolint:errcheck // Test handler
+		c.String(http.StatusOK, "ok")
+	})
+	return r
+}
+
+func TestConcurrencyLimit(t *testing.T) {
+	t.Parallel()
+
+	limiter := NewLimiter(
+		WithConcurrencyLimit(1, 2),
+		WithKeyFunc(func(c *router.Context) string { return c.Request.Header.Get("X-Key") }),
+	)
+	started, unblock := make(chan struct{}), make(chan struct{})
+	r := newBlockingRouter(limiter, started, unblock)
+
+	var wg sync.WaitGroup
+	codes := make(chan int, 2)
+	for _, key := range []string{"a", "b"} {
+		wg.Go(func() {
+			codes <- serve(r, http.MethodGet, "/slow", http.Header{"X-Key": {key}}).Code
+		})
+		<-started
+	}
+
+	w := serve(r, http.MethodGet, "/slow", http.Header{"X-Key": {"a"}})
+	assert.Equal(t, http.StatusTooManyRequests, w.Code, "per-key bound")
+	assert.Equal(t, "1", w.Header().Get("Retry-After"))
+	assert.Equal(t, http.StatusServiceUnavailable, serve(r, http.MethodGet, "/slow", http.Header{"X-Key": {"c"}}).Code, "global bound")
+
+	close(unblock)
+	wg.Wait()
+	close(codes)
+	for code := range codes {
+		assert.Equal(t, http.StatusOK, code)
+	}
+	assert.Empty(t, limiter.inFlight.keys, "unused key semaphores are dropped")
+	assert.Empty(t, limiter.inFlight.global)
+}
+
+func TestConcurrencyLimit_Wait(t *testing.T) {
+	t.Parallel()
+
+	limiter := NewLimiter(WithConcurrencyLimit(1, 0), WithConcurrencyWait(time.Minute))
+	started, unblock := make(chan struct{}, 2), make(chan struct{})
+	r := newBlockingRouter(limiter, started, unblock)
+
+	var wg sync.WaitGroup
+	codes := make(chan int, 2)
+	for range 2 {
+		wg.Go(func() {
+			codes <- serve(r, http.MethodGet, "/slow", nil).Code
+		})
+	}
+
+	<-started
+	select {
+	case <-started:
+		t.Fatal("second request must wait for the first")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(unblock)
+	wg.Wait()
+	close(codes)
+	for code := range codes {
+		assert.Equal(t, http.StatusOK, code, "the waiting request gets the freed slot")
+	}
+}
+
+func TestConcurrencyLimiter_WaitEnds(t *testing.T) {
+	t.Parallel()
+
+	l := newConcurrencyLimiter(1, 0, 20*time.Millisecond)
+	release, err := l.acquire(t.Context(), "k")
+	require.NoError(t, err)
+
+	_, err = l.acquire(t.Context(), "k")
+	require.ErrorIs(t, err, errKeyBusy, "the wait times out")
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	l.wait = time.Minute
+	_, err = l.acquire(ctx, "k")
+	require.ErrorIs(t, err, errKeyBusy, "the wait ends with the request")
+
+	release()
+	release, err = l.acquire(t.Context(), "k")
+	require.NoError(t, err)
+	release()
+
+	assert.Nil(t, newConcurrencyLimiter(0, 0, 0), "no bounds disable the limiter")
+}
@@ -0,0 +1,66 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package ratelimit
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"rivaas.dev/router"
+)
+
+func TestWithCostFunc(t *testing.T) {
+	t.Parallel()
+
+	costs := map[string]int{"/search": 4, "/health": 0, "/weird": -3}
+	r := router.MustNew()
+	r.Use(New(
+		WithRequestsPerSecond(1),
+		WithBurst(10),
+		WithCostFunc(func(c *router.Context) int {
+			if cost, ok := costs[c.RoutePattern()]; ok {
+				return cost
+			}
+			return 1
+		}),
+	))
+	for _, path := range []string{"/search", "/health", "/weird", "/list"} {
+		r.GET(path, func(c *router.Context) {
+			//nolint:errcheck // Test handler
+			c.String(http.StatusOK, "ok")
+		})
+	}
+
+	w := serve(r, http.MethodGet, "/search", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "6", w.Header().Get("RateLimit-Remaining"), "search costs 4 tokens")
+
+	assert.Equal(t, http.StatusOK, serve(r, http.MethodGet, "/search", nil).Code)
+	assert.Equal(t, http.StatusTooManyRequests, serve(r, http.MethodGet, "/search", nil).Code, "2 tokens left")
+
+	w = serve(r, http.MethodGet, "/list", nil)
+	assert.Equal(t, http.StatusOK, w.Code, "cheap requests still fit the shared budget")
+	assert.Equal(t, "1", w.Header().Get("RateLimit-Remaining"))
+
+	for _, path := range []string{"/health", "/weird"} {
+		w = serve(r, http.MethodGet, path, nil)
+		assert.Equal(t, http.StatusOK, w.Code, path)
+		assert.Equal(t, "1", w.Header().Get("RateLimit-Remaining"), "%s is free", path)
+	}
+}
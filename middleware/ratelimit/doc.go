@@ -57,13 +57,103 @@
 //	    }),
 //	))
 //
+// # Tiers
+//
+// [WithTiers] selects a named limit per request with a [TierFunc], e.g. by
+// API key plan. [WithRouteTiers] overrides tier limits for single routes,
+// matched by pattern with an optional method:
+//
+//	r.Use(ratelimit.New(
+//	    ratelimit.WithTiers(planOf, ratelimit.Tiers{
+//	        "free": {Rate: 60, Period: time.Minute},
+//	        "pro":  {Rate: 100, Burst: 200},
+//	    }),
+//	    ratelimit.WithRouteTiers("POST /search", ratelimit.Tiers{"": {Rate: 1}}),
+//	))
+//
+// Patterns that match no route, e.g. after a group prefix changed, are not
+// an error at runtime; [Limiter.CheckRoutes] reports them once routes are
+// registered.
+//
+// # Policies
+//
+// [WithPolicies] sets limits per path or path prefix, so one instance can
+// allow 5 requests per second to /login and 100 to /api. The most specific
+// matching policy applies; other paths use the default limit:
+//
+//	r.Use(ratelimit.New(
+//	    ratelimit.WithRequestsPerSecond(50),
+//	    ratelimit.WithPolicies(ratelimit.Policies{
+//	        "POST /login": {Rate: 5, Burst: 5},
+//	        "/api/*":      {Rate: 100, Burst: 200},
+//	    }),
+//	))
+//
+// # Algorithms
+//
+// [WithAlgorithm] selects how limits are enforced: [TokenBucketAlgorithm]
+// (default), [GCRAAlgorithm], [FixedWindowAlgorithm], or
+// [SlidingWindowLogAlgorithm]. The window algorithms count requests per period
+// and ignore the burst; combine them with [WithRate] for quotas such as 1000
+// requests per minute:
+//
+//	r.Use(ratelimit.New(
+//	    ratelimit.WithAlgorithm(ratelimit.FixedWindowAlgorithm),
+//	    ratelimit.WithRate(1000, time.Minute),
+//	))
+//
+// # Stores
+//
+// Rate limit state lives in a [Store]. The default [MemoryStore] limits each
+// instance separately. [RedisStore] shares limits across replicas: each request
+// runs an atomic Lua script on the Redis server.
+//
+//	client := redis.NewClient(&redis.Options{Addr: "localhost:6379"})
+//	r.Use(ratelimit.New(
+//	    ratelimit.WithRequestsPerSecond(100),
+//	    ratelimit.WithStore(ratelimit.NewRedisStore(client, "myapp:ratelimit:")),
+//	))
+//
+// When the store returns an error, the request is allowed and the error is
+// logged.
+//
+// The older [WithTokenBucket] and [WithSlidingWindow] middleware and their
+// [TokenBucketStore] and [WindowStore] interfaces are deprecated. Use [New]
+// with a [Store] instead.
+//
+// # Request Costs
+//
+// [WithCostFunc] sets how many tokens a request consumes, so expensive
+// endpoints use up more of a client's budget than cheap ones.
+//
+// # Concurrency Limits
+//
+// [WithConcurrencyLimit] bounds requests in flight per key and per instance,
+// which protects against slow requests piling up. [WithConcurrencyWait] lets
+// requests wait briefly for a free slot instead of being rejected.
+//
+// # Introspection
+//
+// [NewLimiter] returns a [Limiter] whose [Limiter.Handler] is the middleware.
+// [Limiter.State] reports the remaining requests of a key, [Limiter.Reset]
+// clears them, and [Limiter.Stats] reports tracked keys and evictions.
+// [Limiter.AdminHandler] serves these operations over HTTP for support tooling.
+//
 // # Rate Limit Headers
 //
-// The middleware sets standard rate limit headers in responses:
+// The middleware sets rate limit headers in responses:
+//
+//   - RateLimit-Limit: Maximum requests allowed at once
+//   - RateLimit-Remaining: Remaining requests
+//   - RateLimit-Reset: Seconds until the full limit is available again
+//
+// [WithHeaderFormat] selects other formats, alone or side by side:
+// [LegacyHeaderFormat] for the X-RateLimit-* headers (with a Unix timestamp
+// as reset), or [DraftHeaderFormat] for the RateLimit and RateLimit-Policy
+// fields of the current IETF draft:
 //
-//   - X-RateLimit-Limit: Maximum requests allowed per window
-//   - X-RateLimit-Remaining: Remaining requests in current window
-//   - X-RateLimit-Reset: Unix timestamp when the rate limit resets
+//	RateLimit-Policy: "default";q=100;w=60
+//	RateLimit: "default";r=42;t=18
 //
 // The token bucket algorithm supports concurrent access.
 package ratelimit
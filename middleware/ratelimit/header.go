@@ -0,0 +1,123 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"rivaas.dev/router"
+)
+
+// HeaderFormat selects which rate limit headers responses carry.
+type HeaderFormat string
+
+const (
+	// SplitHeaderFormat sets RateLimit-Limit, RateLimit-Remaining, and
+	// RateLimit-Reset (seconds until the limit is full again), as in earlier
+	// drafts of the IETF specification (default).
+	SplitHeaderFormat HeaderFormat = "split"
+
+	// LegacyHeaderFormat sets X-RateLimit-Limit, X-RateLimit-Remaining, and
+	// X-RateLimit-Reset (Unix time when the limit is full again), which many
+	// existing clients understand.
+	LegacyHeaderFormat HeaderFormat = "legacy"
+
+	// DraftHeaderFormat sets the RateLimit-Policy and RateLimit structured
+	// fields of the current IETF draft, e.g.:
+	//
+	//	RateLimit-Policy: "default";q=100;w=60
+	//	RateLimit: "default";r=42;t=18
+	//
+	// The policy is named after the limit that applies: "default", the tier,
+	// or the [Policies] pattern.
+	DraftHeaderFormat HeaderFormat = "draft"
+)
+
+// WithHeaderFormat selects the rate limit headers set on responses. Pass
+// several formats to send them side by side while clients migrate, or none to
+// send no rate limit headers. Retry-After is always set on rejected requests.
+// Default: [SplitHeaderFormat]
+//
+// Example:
+//
+//	ratelimit.New(ratelimit.WithHeaderFormat(ratelimit.LegacyHeaderFormat, ratelimit.DraftHeaderFormat))
+func WithHeaderFormat(formats ...HeaderFormat) Option {
+	return func(cfg *config) {
+		cfg.headerFormats = formats
+	}
+}
+
+// setHeaders sets the rate limit headers of every configured format. name is
+// the state namespace of limit, from which draft policy names are derived.
+func (cfg *config) setHeaders(c *router.Context, limit Limit, name string, res Result) {
+	for _, format := range cfg.headerFormats {
+		switch format {
+		case SplitHeaderFormat:
+			c.Header("RateLimit-Limit", strconv.Itoa(res.Limit))
+			c.Header("RateLimit-Remaining", strconv.Itoa(res.Remaining))
+			c.Header("RateLimit-Reset", strconv.Itoa(ceilSeconds(res.ResetAfter)))
+		case LegacyHeaderFormat:
+			reset := time.Now().Add(res.ResetAfter).Add(time.Second - 1).Truncate(time.Second)
+			c.Header("X-RateLimit-Limit", strconv.Itoa(res.Limit))
+			c.Header("X-RateLimit-Remaining", strconv.Itoa(res.Remaining))
+			c.Header("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+		case DraftHeaderFormat:
+			policy := policyName(name)
+			c.Header("RateLimit-Policy", policy+";q="+strconv.Itoa(res.Limit)+";w="+strconv.Itoa(max(ceilSeconds(limit.window()), 1)))
+			c.Header("RateLimit", policy+";r="+strconv.Itoa(res.Remaining)+";t="+strconv.Itoa(ceilSeconds(res.ResetAfter)))
+		}
+	}
+}
+
+// window returns the time it takes for the whole limit to become available:
+// the period for window algorithms, and the time to refill the burst for the
+// others.
+func (l Limit) window() time.Duration {
+	l = l.normalize()
+	switch l.Algorithm {
+	case FixedWindowAlgorithm, SlidingWindowLogAlgorithm:
+		return l.Period
+	default:
+		return time.Duration(l.interval() * float64(l.Burst))
+	}
+}
+
+// policyName returns the draft policy name for a state namespace, as a
+// structured field string.
+func policyName(namespace string) string {
+	name := strings.TrimPrefix(strings.TrimSuffix(namespace, "|"), "policy:")
+	if name == "" {
+		name = "default"
+	}
+
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range name {
+		switch {
+		case r == '"' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < 0x20 || r > 0x7e:
+			b.WriteByte('_') // Not allowed in structured field strings
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+
+	return b.String()
+}
@@ -0,0 +1,126 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package ratelimit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"rivaas.dev/router"
+)
+
+// headersFor sends one request to a router using opts and returns the response headers.
+func headersFor(t *testing.T, path string, opts ...Option) http.Header {
+	t.Helper()
+
+	r := router.MustNew()
+	r.Use(New(opts...))
+	ok := func(c *router.Context) {
+		//nolint:errcheck // Test handler
+		c.String(http.StatusOK, "ok")
+	}
+	r.GET("/data", ok)
+	r.GET("/login", ok)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	return w.Header()
+}
+
+func TestWithHeaderFormat(t *testing.T) {
+	t.Parallel()
+
+	limit := []Option{WithRequestsPerSecond(10), WithBurst(20)}
+
+	t.Run("default", func(t *testing.T) {
+		t.Parallel()
+
+		h := headersFor(t, "/data", limit...)
+		assert.Equal(t, "20", h.Get("RateLimit-Limit"))
+		assert.Equal(t, "19", h.Get("RateLimit-Remaining"))
+		assert.Equal(t, "1", h.Get("RateLimit-Reset"))
+		assert.Empty(t, h.Get("X-RateLimit-Limit"))
+		assert.Empty(t, h.Get("RateLimit"))
+	})
+
+	t.Run("legacy", func(t *testing.T) {
+		t.Parallel()
+
+		before := time.Now().Unix()
+		h := headersFor(t, "/data", append(limit, WithHeaderFormat(LegacyHeaderFormat))...)
+		assert.Equal(t, "20", h.Get("X-RateLimit-Limit"))
+		assert.Equal(t, "19", h.Get("X-RateLimit-Remaining"))
+		reset, err := strconv.ParseInt(h.Get("X-RateLimit-Reset"), 10, 64)
+		require.NoError(t, err)
+		assert.InDelta(t, before+1, reset, 1, "reset is a Unix time")
+		assert.Empty(t, h.Get("RateLimit-Limit"))
+	})
+
+	t.Run("draft", func(t *testing.T) {
+		t.Parallel()
+
+		h := headersFor(t, "/data", append(limit, WithHeaderFormat(DraftHeaderFormat))...)
+		assert.Equal(t, `"default";q=20;w=2`, h.Get("RateLimit-Policy"), "the burst refills in 2s")
+		assert.Equal(t, `"default";r=19;t=1`, h.Get("RateLimit"))
+		assert.Empty(t, h.Get("RateLimit-Limit"))
+	})
+
+	t.Run("draft with policy", func(t *testing.T) {
+		t.Parallel()
+
+		h := headersFor(t, "/login", WithHeaderFormat(DraftHeaderFormat), WithPolicies(Policies{
+			"/login": {Algorithm: FixedWindowAlgorithm, Rate: 5, Period: time.Minute},
+		}))
+		assert.Equal(t, `"/login";q=5;w=60`, h.Get("RateLimit-Policy"))
+		assert.Contains(t, h.Get("RateLimit"), `"/login";r=4;t=`)
+	})
+
+	t.Run("several", func(t *testing.T) {
+		t.Parallel()
+
+		h := headersFor(t, "/data", append(limit, WithHeaderFormat(LegacyHeaderFormat, DraftHeaderFormat))...)
+		assert.Equal(t, "19", h.Get("X-RateLimit-Remaining"))
+		assert.Equal(t, `"default";r=19;t=1`, h.Get("RateLimit"))
+	})
+
+	t.Run("none", func(t *testing.T) {
+		t.Parallel()
+
+		h := headersFor(t, "/data", append(limit, WithHeaderFormat())...)
+		assert.Empty(t, h.Get("RateLimit-Limit"))
+		assert.Empty(t, h.Get("X-RateLimit-Limit"))
+		assert.Empty(t, h.Get("RateLimit"))
+	})
+}
+
+func TestPolicyName(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, `"default"`, policyName(""))
+	assert.Equal(t, `"pro"`, policyName("pro|"))
+	assert.Equal(t, `"POST /search|pro"`, policyName("POST /search|pro|"))
+	assert.Equal(t, `"/api/*"`, policyName("policy:/api/*|"))
+	assert.Equal(t, `"a\"b\\c_"`, policyName("a\"b\\c\n|"))
+}
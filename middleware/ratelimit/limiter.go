@@ -0,0 +1,291 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"rivaas.dev/router"
+)
+
+// Limiter enforces rate limits and gives access to their state at runtime,
+// e.g. to unblock a customer without restarting the service. Create it with
+// [NewLimiter] and install [Limiter.Handler] as middleware.
+type Limiter struct {
+	cfg          *config
+	store        Store
+	keyFunc      KeyFunc
+	defaultLimit Limit
+	inFlight     *concurrencyLimiter // nil without WithConcurrencyLimit
+}
+
+// NewLimiter creates a limiter with the same options and defaults as [New].
+//
+// Example:
+//
+//	limiter := ratelimit.NewLimiter(ratelimit.WithRequestsPerSecond(50))
+//	r.Use(limiter.Handler())
+//
+//	// Later, e.g. from a support tool:
+//	err := limiter.Reset(ctx, "ip:203.0.113.7")
+func NewLimiter(opts ...Option) *Limiter {
+	cfg := &config{
+		requestsPerSecond: 100,
+		burst:             20,
+		cleanupInterval:   time.Minute,
+		limiterTTL:        5 * time.Minute,
+		headerFormats:     []HeaderFormat{SplitHeaderFormat},
+	}
+
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	store := cfg.store
+	if store == nil {
+		store = newMemoryStore(cfg.cleanupInterval, cfg.limiterTTL)
+	}
+
+	keyFunc := KeyFunc(cfg.keyFunc)
+	if keyFunc == nil {
+		keyFunc = func(c *router.Context) string {
+			return "ip:" + c.ClientIP()
+		}
+	}
+
+	if cfg.algorithm != "" && !cfg.algorithm.valid() {
+		panic(errUnknownAlgorithm(cfg.algorithm))
+	}
+
+	cfg.resolveTiers()
+	cfg.resolvePolicies()
+
+	return &Limiter{
+		cfg:      cfg,
+		store:    store,
+		keyFunc:  keyFunc,
+		inFlight: newConcurrencyLimiter(cfg.maxInFlightPerKey, cfg.maxInFlight, cfg.concurrencyWait),
+		defaultLimit: Limit{
+			Algorithm: cfg.algorithm,
+			Rate:      cfg.requestsPerSecond,
+			Period:    cfg.period,
+			Burst:     cfg.burst,
+		},
+	}
+}
+
+// Handler returns the rate limiting middleware.
+func (l *Limiter) Handler() router.HandlerFunc {
+	cfg := l.cfg
+
+	return func(c *router.Context) {
+		key := l.keyFunc(c)
+		limit, namespace := cfg.limitFor(c, l.defaultLimit)
+
+		cost := 1
+		if cfg.costFunc != nil {
+			cost = max(cfg.costFunc(c), 0)
+		}
+
+		res, err := l.store.Take(c.Request.Context(), namespace+key, limit, cost)
+		if err != nil {
+			// Store error - allow request but log
+			if cfg.logger != nil {
+				cfg.logger.Warn("rate limit store error", "error", err, "key", key)
+			}
+			c.Next()
+
+			return
+		}
+
+		cfg.setHeaders(c, limit, namespace, res)
+
+		if !res.Allowed {
+			l.reject(c, http.StatusTooManyRequests, res.RetryAfter)
+			return
+		}
+
+		if l.inFlight != nil {
+			release, err := l.inFlight.acquire(c.Request.Context(), key)
+			if err != nil {
+				status := http.StatusTooManyRequests
+				if errors.Is(err, errGlobalBusy) {
+					status = http.StatusServiceUnavailable
+				}
+				l.reject(c, status, time.Second)
+
+				return
+			}
+			defer release()
+		}
+
+		c.Next()
+	}
+}
+
+// reject aborts c with status, or calls the handler from WithHandler.
+func (l *Limiter) reject(c *router.Context, status int, retryAfter time.Duration) {
+	if l.cfg.onLimitExceeded != nil {
+		l.cfg.onLimitExceeded(c)
+		// The custom handler is responsible for writing the response
+		c.Abort()
+
+		return
+	}
+
+	c.Header("Retry-After", strconv.Itoa(max(ceilSeconds(retryAfter), 1)))
+	c.WriteErrorResponse(status, http.StatusText(status))
+	c.Abort()
+}
+
+// KeyState is the state of one of the limits that apply to a key.
+type KeyState struct {
+	Tier   string // Tier name; empty for the default limit
+	Route  string // Route of a [WithRouteTiers] override; empty if none
+	Policy string // Pattern of a [WithPolicies] policy; empty if none
+	Limit  Limit  // The limit, with defaults filled in
+	Result Result // Current state; Allowed reports whether one more request would pass
+}
+
+// limitEntry is a configured limit with its namespace.
+type limitEntry struct {
+	tier, route, policy, namespace string
+	limit                          Limit
+}
+
+// limits returns every configured limit in a stable order.
+func (l *Limiter) limits() []limitEntry {
+	entries := []limitEntry{{limit: l.defaultLimit}}
+	for _, tier := range slices.Sorted(maps.Keys(l.cfg.tiers)) {
+		entries = append(entries, limitEntry{tier: tier, namespace: tier + "|", limit: l.cfg.tiers[tier]})
+	}
+	for _, route := range slices.Sorted(maps.Keys(l.cfg.routeTiers)) {
+		tiers := l.cfg.routeTiers[route]
+		for _, tier := range slices.Sorted(maps.Keys(tiers)) {
+			entries = append(entries, limitEntry{tier: tier, route: route, namespace: route + "|" + tier + "|", limit: tiers[tier]})
+		}
+	}
+	for _, p := range l.cfg.policyList {
+		entries = append(entries, limitEntry{policy: p.pattern, namespace: policyNamespace(p.pattern), limit: p.limit})
+	}
+	return entries
+}
+
+// State reports the current state of every limit configured for key (the
+// default limit, each tier, each route override, and each policy) without
+// consuming anything. Limits the key has not used yet are reported as full.
+func (l *Limiter) State(ctx context.Context, key string) ([]KeyState, error) {
+	entries := l.limits()
+	states := make([]KeyState, 0, len(entries))
+	for _, e := range entries {
+		res, err := l.store.Take(ctx, e.namespace+key, e.limit, 0)
+		if err != nil {
+			return nil, err
+		}
+		res.Allowed = res.Remaining > 0
+		states = append(states, KeyState{Tier: e.tier, Route: e.route, Policy: e.policy, Limit: e.limit.normalize(), Result: res})
+	}
+	return states, nil
+}
+
+// Reset clears the state of every limit configured for key, so the client
+// starts over with full limits.
+func (l *Limiter) Reset(ctx context.Context, key string) error {
+	var errs []error
+	for _, e := range l.limits() {
+		if err := l.store.Reset(ctx, e.namespace+key); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Stats returns usage statistics of the store. It returns an error wrapping
+// [errors.ErrUnsupported] if the store does not keep statistics; [MemoryStore]
+// does.
+func (l *Limiter) Stats() (Stats, error) {
+	s, ok := l.store.(interface{ Stats() Stats })
+	if !ok {
+		return Stats{}, fmt.Errorf("ratelimit: %T does not report statistics: %w", l.store, errors.ErrUnsupported)
+	}
+	return s.Stats(), nil
+}
+
+// AdminHandler returns a handler for support tooling. Protect it with
+// authentication; it is not meant to be public.
+//
+//   - GET without a "key" query parameter returns [Stats] as JSON.
+//   - GET ?key=... returns the [KeyState] list of the key as JSON.
+//   - DELETE ?key=... resets the key and responds 204 No Content.
+//
+// Example:
+//
+//	admin := r.Group("/admin", basicauth.New(...))
+//	admin.GET("/ratelimit", limiter.AdminHandler())
+//	admin.DELETE("/ratelimit", limiter.AdminHandler())
+//
+//	// curl -X DELETE 'https://api.example.com/admin/ratelimit?key=ip:203.0.113.7'
+func (l *Limiter) AdminHandler() router.HandlerFunc {
+	return func(c *router.Context) {
+		ctx := c.Request.Context()
+		key := c.Query("key")
+
+		switch {
+		case c.Request.Method == http.MethodDelete && key != "":
+			if err := l.Reset(ctx, key); err != nil {
+				c.WriteErrorResponse(http.StatusInternalServerError, err.Error())
+				return
+			}
+			c.NoContent()
+		case c.Request.Method == http.MethodDelete:
+			c.WriteErrorResponse(http.StatusBadRequest, "missing key query parameter")
+		case key != "":
+			states, err := l.State(ctx, key)
+			if err != nil {
+				c.WriteErrorResponse(http.StatusInternalServerError, err.Error())
+				return
+			}
+			limits := make([]map[string]any, 0, len(states))
+			for _, st := range states {
+				limits = append(limits, map[string]any{
+					"tier":                st.Tier,
+					"route":               st.Route,
+					"policy":              st.Policy,
+					"algorithm":           st.Limit.Algorithm,
+					"limit":               st.Result.Limit,
+					"remaining":           st.Result.Remaining,
+					"reset_after_seconds": st.Result.ResetAfter.Seconds(),
+				})
+			}
+			//nolint:errcheck // Best-effort response
+			c.JSON(http.StatusOK, map[string]any{"key": key, "limits": limits})
+		default:
+			stats, err := l.Stats()
+			if errors.Is(err, errors.ErrUnsupported) {
+				c.WriteErrorResponse(http.StatusNotImplemented, err.Error())
+				return
+			}
+			//nolint:errcheck // Best-effort response
+			c.JSON(http.StatusOK, stats)
+		}
+	}
+}
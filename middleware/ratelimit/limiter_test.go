@@ -0,0 +1,159 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package ratelimit
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"rivaas.dev/router"
+)
+
+func newLimiterRouter(t *testing.T, store Store) (*Limiter, *router.Router) {
+	t.Helper()
+
+	limiter := NewLimiter(
+		WithRequestsPerSecond(1),
+		WithBurst(2),
+		WithKeyFunc(func(c *router.Context) string { return c.Request.Header.Get("X-Key") }),
+		WithTiers(
+			func(c *router.Context) string { return c.Request.Header.Get("X-Plan") },
+			Tiers{"pro": {Rate: 10, Burst: 5}},
+		),
+		WithStore(store),
+	)
+
+	r := router.MustNew()
+	r.Use(limiter.Handler())
+	r.GET("/test", func(c *router.Context) {
+		//nolint:errcheck // Test handler
+		c.String(http.StatusOK, "ok")
+	})
+
+	return limiter, r
+}
+
+func serve(r http.Handler, method, target string, header http.Header) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, nil)
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestLimiter_StateAndReset(t *testing.T) {
+	t.Parallel()
+
+	for name, store := range testStores(t) {
+		limiter, r := newLimiterRouter(t, store)
+		customer := http.Header{"X-Key": {"acme"}}
+
+		for range 3 {
+			serve(r, http.MethodGet, "/test", customer)
+		}
+		assert.Equal(t, http.StatusTooManyRequests, serve(r, http.MethodGet, "/test", customer).Code, name)
+
+		states, err := limiter.State(t.Context(), "acme")
+		require.NoError(t, err, name)
+		require.Len(t, states, 2, name)
+		assert.Empty(t, states[0].Tier, name)
+		assert.False(t, states[0].Result.Allowed, name)
+		assert.Equal(t, 0, states[0].Result.Remaining, name)
+		assert.Equal(t, "pro", states[1].Tier, name)
+		assert.True(t, states[1].Result.Allowed, "%s: unused limits are full", name)
+		assert.Equal(t, 5, states[1].Result.Remaining, name)
+
+		// Peeking does not consume.
+		again, err := limiter.State(t.Context(), "acme")
+		require.NoError(t, err, name)
+		assert.Equal(t, states, again, name)
+
+		require.NoError(t, limiter.Reset(t.Context(), "acme"), name)
+		assert.Equal(t, http.StatusOK, serve(r, http.MethodGet, "/test", customer).Code, "%s: reset unblocks the key", name)
+	}
+}
+
+func TestLimiter_Stats(t *testing.T) {
+	t.Parallel()
+
+	store, clock := newTestMemoryStore()
+	limiter, r := newLimiterRouter(t, store)
+	serve(r, http.MethodGet, "/test", http.Header{"X-Key": {"a"}})
+	serve(r, http.MethodGet, "/test", http.Header{"X-Key": {"b"}, "X-Plan": {"pro"}})
+
+	_, err := limiter.State(t.Context(), "c")
+	require.NoError(t, err)
+
+	stats, err := limiter.Stats()
+	require.NoError(t, err)
+	assert.Equal(t, Stats{Keys: 2}, stats, "peeking does not track keys")
+	assert.ElementsMatch(t, []string{"a", "pro|b"}, store.Keys())
+
+	clock.advance(10 * time.Minute)
+	serve(r, http.MethodGet, "/test", http.Header{"X-Key": {"a"}})
+	stats, err = limiter.Stats()
+	require.NoError(t, err)
+	assert.Equal(t, Stats{Keys: 1, Evictions: 2}, stats)
+
+	redisStore, _ := newTestRedisStore(t)
+	_, err = NewLimiter(WithStore(redisStore)).Stats()
+	assert.ErrorIs(t, err, errors.ErrUnsupported)
+}
+
+func TestLimiter_AdminHandler(t *testing.T) {
+	t.Parallel()
+
+	limiter, api := newLimiterRouter(t, NewMemoryStore())
+	customer := http.Header{"X-Key": {"acme"}}
+	serve(api, http.MethodGet, "/test", customer)
+	serve(api, http.MethodGet, "/test", customer)
+
+	r := router.MustNew()
+	r.GET("/admin/ratelimit", limiter.AdminHandler())
+	r.DELETE("/admin/ratelimit", limiter.AdminHandler())
+
+	w := serve(r, http.MethodGet, "/admin/ratelimit", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"keys": 1, "evictions": 0}`, w.Body.String())
+
+	w = serve(r, http.MethodGet, "/admin/ratelimit?key=acme", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var body struct {
+		Key    string           `json:"key"`
+		Limits []map[string]any `json:"limits"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, "acme", body.Key)
+	require.Len(t, body.Limits, 2)
+	assert.InDelta(t, 0, body.Limits[0]["remaining"], 0)
+	assert.Equal(t, "token-bucket", body.Limits[0]["algorithm"])
+
+	assert.Equal(t, http.StatusBadRequest, serve(r, http.MethodDelete, "/admin/ratelimit", nil).Code)
+	assert.Equal(t, http.StatusNoContent, serve(r, http.MethodDelete, "/admin/ratelimit?key=acme", nil).Code)
+
+	w = serve(r, http.MethodGet, "/admin/ratelimit", nil)
+	assert.JSONEq(t, `{"keys": 0, "evictions": 0}`, w.Body.String())
+}
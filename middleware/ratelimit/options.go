@@ -30,11 +30,24 @@ type Option func(*config)
 type config struct {
 	logger            *slog.Logger
 	requestsPerSecond int
+	period            time.Duration
 	burst             int
+	algorithm         Algorithm
 	keyFunc           func(*router.Context) string
 	onLimitExceeded   func(*router.Context)
 	cleanupInterval   time.Duration
 	limiterTTL        time.Duration
+	store             Store
+	tierFunc          TierFunc
+	tiers             Tiers
+	routeTiers        map[string]Tiers // by "METHOD pattern" or pattern
+	policies          Policies
+	policyList        []policy // policies, most specific first
+	maxInFlightPerKey int
+	maxInFlight       int
+	concurrencyWait   time.Duration
+	costFunc          func(*router.Context) int
+	headerFormats     []HeaderFormat
 }
 
 // WithRequestsPerSecond sets the number of requests allowed per second.
@@ -47,10 +60,79 @@ func WithRequestsPerSecond(rps int) Option {
 	return func(cfg *config) {
 		if rps > 0 {
 			cfg.requestsPerSecond = rps
+			cfg.period = time.Second
 		}
 	}
 }
 
+// WithRate sets the number of requests allowed per period, for limits that are
+// not expressed per second (e.g. 1000 per hour). It replaces
+// [WithRequestsPerSecond].
+// Default: 100 requests per second
+//
+// Example:
+//
+//	ratelimit.New(
+//	    ratelimit.WithAlgorithm(ratelimit.FixedWindowAlgorithm),
+//	    ratelimit.WithRate(1000, time.Minute),
+//	)
+func WithRate(requests int, per time.Duration) Option {
+	return func(cfg *config) {
+		if requests > 0 && per > 0 {
+			cfg.requestsPerSecond = requests
+			cfg.period = per
+		}
+	}
+}
+
+// WithAlgorithm sets the rate limiting algorithm.
+// Default: TokenBucketAlgorithm
+//
+// The token bucket and GCRA allow bursts up to [WithBurst] while keeping the
+// average rate. [FixedWindowAlgorithm] and [SlidingWindowLogAlgorithm] count
+// requests per period and ignore the burst, which suits strict quotas.
+//
+// Example:
+//
+//	// At most 60 requests in any 60 seconds
+//	ratelimit.New(
+//	    ratelimit.WithAlgorithm(ratelimit.SlidingWindowLogAlgorithm),
+//	    ratelimit.WithRate(60, time.Minute),
+//	)
+func WithAlgorithm(alg Algorithm) Option {
+	return func(cfg *config) {
+		cfg.algorithm = alg
+	}
+}
+
+// WithCostFunc sets how many tokens a request consumes, so expensive endpoints
+// (search, exports) use up more of a client's budget than cheap ones. A cost of
+// zero lets the request through without consuming anything; negative costs
+// count as zero. A request that costs more than the burst (or the rate, for
+// window algorithms) is never allowed.
+// Default: every request costs 1
+//
+// Example:
+//
+//	ratelimit.New(
+//	    ratelimit.WithRequestsPerSecond(100),
+//	    ratelimit.WithBurst(100),
+//	    ratelimit.WithCostFunc(func(c *router.Context) int {
+//	        switch c.RoutePattern() {
+//	        case "/search":
+//	            return 10
+//	        case "/export":
+//	            return 50
+//	        }
+//	        return 1
+//	    }),
+//	)
+func WithCostFunc(fn func(*router.Context) int) Option {
+	return func(cfg *config) {
+		cfg.costFunc = fn
+	}
+}
+
 // WithBurst sets the maximum burst size.
 // Burst allows clients to make multiple requests instantly up to this limit.
 // Default: 20 requests
@@ -102,6 +184,23 @@ func WithHandler(fn func(*router.Context)) Option {
 	}
 }
 
+// WithStore sets the store that keeps rate limit state.
+// Default: an in-memory store, which limits each instance separately.
+//
+// Use a shared store such as [RedisStore] to enforce one limit across all
+// replicas of a service. [WithCleanupInterval] and [WithLimiterTTL] only apply
+// to the default store.
+//
+// Example:
+//
+//	client := redis.NewClient(&redis.Options{Addr: "localhost:6379"})
+//	ratelimit.New(ratelimit.WithStore(ratelimit.NewRedisStore(client, "")))
+func WithStore(store Store) Option {
+	return func(cfg *config) {
+		cfg.store = store
+	}
+}
+
 // WithCleanupInterval sets how often to clean up expired limiters.
 // Default: 1 minute
 func WithCleanupInterval(interval time.Duration) Option {
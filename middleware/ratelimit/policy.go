@@ -0,0 +1,157 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	"rivaas.dev/router"
+)
+
+// Policies maps path patterns to limits. A pattern is a path ("/login"), a
+// path prefix ending in "/*" ("/api/*"), or "*" for every path, optionally
+// prefixed with a method ("POST /login"). A path also matches the registered
+// route pattern ("/users/:id"). A limit without an Algorithm uses the one set
+// with [WithAlgorithm].
+type Policies map[string]Limit
+
+// WithPolicies sets different limits for groups of routes from one middleware
+// instance. Each request uses the most specific matching policy: exact paths
+// before prefixes, longer prefixes before shorter ones, and patterns with a
+// method before those without. Requests no policy matches use the default
+// limit from [WithRequestsPerSecond], [WithRate], and [WithBurst], or the
+// "*" policy if there is one. Each policy keeps separate state, so requests
+// to /login don't use up the quota of /api.
+//
+// Policies take precedence over [WithTiers]; [WithRouteTiers] takes
+// precedence over policies. Can be passed multiple times; later policies
+// replace earlier ones with the same pattern.
+//
+// Example:
+//
+//	ratelimit.New(
+//	    ratelimit.WithRequestsPerSecond(50), // everything else
+//	    ratelimit.WithPolicies(ratelimit.Policies{
+//	        "POST /login": {Rate: 5, Burst: 5},
+//	        "/api/*":      {Rate: 100, Burst: 200},
+//	        "/exports/*":  {Rate: 10, Period: time.Hour},
+//	    }),
+//	)
+func WithPolicies(policies Policies) Option {
+	return func(cfg *config) {
+		if cfg.policies == nil {
+			cfg.policies = make(Policies, len(policies))
+		}
+		for pattern, l := range policies {
+			cfg.policies[pattern] = l
+		}
+	}
+}
+
+// policy is a parsed [Policies] entry.
+type policy struct {
+	pattern string // As configured, used in the state namespace
+	method  string // Empty for any method
+	path    string // Exact path, or prefix without the trailing "/*"
+	prefix  bool
+	limit   Limit
+}
+
+// matches reports whether the policy applies to c.
+func (p *policy) matches(c *router.Context) bool {
+	if p.method != "" && p.method != c.Request.Method {
+		return false
+	}
+
+	path := c.Request.URL.Path
+	if !p.prefix {
+		return path == p.path || c.RoutePattern() == p.path
+	}
+
+	return p.path == "" || path == p.path || strings.HasPrefix(path, p.path+"/")
+}
+
+// parsePolicy parses pattern into a policy.
+func parsePolicy(pattern string, limit Limit) policy {
+	p := policy{pattern: pattern, limit: limit}
+	path := pattern
+	if method, rest, ok := strings.Cut(pattern, " "); ok {
+		p.method, path = method, strings.TrimSpace(rest)
+	}
+
+	switch {
+	case path == "*":
+		p.prefix = true
+	case strings.HasSuffix(path, "/*"):
+		p.path, p.prefix = strings.TrimSuffix(path, "/*"), true
+	default:
+		p.path = path
+	}
+
+	return p
+}
+
+// resolvePolicies parses the configured policies, fills in the default
+// algorithm, and sorts them from most to least specific. It panics on unknown
+// algorithms, like [New].
+func (cfg *config) resolvePolicies() {
+	cfg.policyList = make([]policy, 0, len(cfg.policies))
+	for pattern, l := range cfg.policies {
+		if l.Algorithm == "" {
+			l.Algorithm = cfg.algorithm
+		}
+		if l.Algorithm != "" && !l.Algorithm.valid() {
+			panic(errUnknownAlgorithm(l.Algorithm))
+		}
+		cfg.policyList = append(cfg.policyList, parsePolicy(pattern, l))
+	}
+
+	slices.SortFunc(cfg.policyList, func(a, b policy) int {
+		if a.prefix != b.prefix {
+			if a.prefix {
+				return 1
+			}
+			return -1
+		}
+		if n := cmp.Compare(len(b.path), len(a.path)); n != 0 {
+			return n
+		}
+		if (a.method == "") != (b.method == "") {
+			if a.method == "" {
+				return 1
+			}
+			return -1
+		}
+		return strings.Compare(a.pattern, b.pattern)
+	})
+}
+
+// policyFor returns the most specific policy that matches c, or nil.
+func (cfg *config) policyFor(c *router.Context) *policy {
+	for i := range cfg.policyList {
+		if cfg.policyList[i].matches(c) {
+			return &cfg.policyList[i]
+		}
+	}
+
+	return nil
+}
+
+// policyNamespace returns the state namespace of the policy with pattern.
+func policyNamespace(pattern string) string {
+	return "policy:" + pattern + "|"
+}
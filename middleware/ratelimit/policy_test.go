@@ -0,0 +1,168 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package ratelimit
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"rivaas.dev/router"
+)
+
+func newPolicyRouter(policies Policies) *router.Router {
+	r := router.MustNew()
+	r.Use(New(
+		WithRequestsPerSecond(1),
+		WithBurst(1),
+		WithPolicies(policies),
+	))
+
+	ok := func(c *router.Context) {
+		//nolint:errcheck // Test handler
+		c.String(http.StatusOK, "ok")
+	}
+	r.GET("/login", ok)
+	r.POST("/login", ok)
+	r.GET("/api", ok)
+	r.GET("/api/users/:id", ok)
+	r.GET("/api/admin/stats", ok)
+	r.GET("/apiary", ok)
+	r.GET("/other", ok)
+	return r
+}
+
+func TestWithPolicies(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		policies Policies
+		method   string
+		path     string
+		n        int
+		want     int
+	}{
+		{"exact path", Policies{"/login": {Rate: 1, Period: time.Hour, Burst: 3}}, http.MethodGet, "/login", 5, 3},
+		{"method", Policies{"POST /login": {Rate: 1, Period: time.Hour, Burst: 3}}, http.MethodPost, "/login", 5, 3},
+		{"other method uses default", Policies{"POST /login": {Rate: 1, Period: time.Hour, Burst: 3}}, http.MethodGet, "/login", 5, 1},
+		{"prefix", Policies{"/api/*": {Rate: 1, Period: time.Hour, Burst: 4}}, http.MethodGet, "/api/users/1", 6, 4},
+		{"prefix matches its root", Policies{"/api/*": {Rate: 1, Period: time.Hour, Burst: 4}}, http.MethodGet, "/api", 6, 4},
+		{"prefix matches whole segments", Policies{"/api/*": {Rate: 1, Period: time.Hour, Burst: 4}}, http.MethodGet, "/apiary", 6, 1},
+		{"route pattern", Policies{"/api/users/:id": {Rate: 1, Period: time.Hour, Burst: 2}}, http.MethodGet, "/api/users/7", 4, 2},
+		{"catch-all", Policies{"*": {Rate: 1, Period: time.Hour, Burst: 3}}, http.MethodGet, "/other", 5, 3},
+		{"no match uses default", Policies{"/api/*": {Rate: 1, Period: time.Hour, Burst: 4}}, http.MethodGet, "/other", 3, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := newPolicyRouter(tt.policies)
+			assert.Equal(t, tt.want, countAllowed(r, tt.method, tt.path, "", tt.n))
+		})
+	}
+}
+
+func TestWithPolicies_AdminHandler(t *testing.T) {
+	t.Parallel()
+
+	limiter := NewLimiter(
+		WithRequestsPerSecond(1),
+		WithBurst(5),
+		WithPolicies(Policies{"POST /login": {Rate: 1, Period: time.Hour, Burst: 2}}),
+		WithKeyFunc(func(*router.Context) string { return "acme" }),
+	)
+	r := router.MustNew()
+	r.Use(limiter.Handler())
+	r.POST("/login", func(c *router.Context) {
+		c.NoContent()
+	})
+	countAllowed(r, http.MethodPost, "/login", "", 2)
+
+	admin := router.MustNew()
+	admin.GET("/admin/ratelimit", limiter.AdminHandler())
+	w := serve(admin, http.MethodGet, "/admin/ratelimit?key=acme", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var body struct {
+		Limits []map[string]any `json:"limits"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+
+	remaining := make(map[string]any)
+	for _, l := range body.Limits {
+		policy, ok := l["policy"].(string)
+		require.True(t, ok, "every limit reports its policy")
+		remaining[policy] = l["remaining"]
+	}
+	assert.Equal(t, map[string]any{"": float64(5), "POST /login": float64(0)}, remaining,
+		"the exhausted limit is the policy, not the default")
+}
+
+func TestWithPolicies_MostSpecificWins(t *testing.T) {
+	t.Parallel()
+
+	r := newPolicyRouter(Policies{
+		"*":                {Rate: 1, Period: time.Hour, Burst: 1},
+		"/api/*":           {Rate: 1, Period: time.Hour, Burst: 2},
+		"/api/admin/*":     {Rate: 1, Period: time.Hour, Burst: 3},
+		"/api/admin/stats": {Rate: 1, Period: time.Hour, Burst: 4},
+		"GET /api/admin/*": {Rate: 1, Period: time.Hour, Burst: 5},
+	})
+
+	assert.Equal(t, 4, countAllowed(r, http.MethodGet, "/api/admin/stats", "", 6), "exact path beats prefixes")
+	assert.Equal(t, 2, countAllowed(r, http.MethodGet, "/api/users/1", "", 4), "longest matching prefix")
+	assert.Equal(t, 1, countAllowed(r, http.MethodGet, "/other", "", 3))
+}
+
+func TestWithPolicies_SeparateState(t *testing.T) {
+	t.Parallel()
+
+	r := newPolicyRouter(Policies{
+		"/login": {Rate: 1, Period: time.Hour, Burst: 2},
+		"/api/*": {Rate: 1, Period: time.Hour, Burst: 3},
+	})
+
+	assert.Equal(t, 2, countAllowed(r, http.MethodGet, "/login", "", 4))
+	assert.Equal(t, 3, countAllowed(r, http.MethodGet, "/api", "", 4), "exhausting /login leaves /api untouched")
+	assert.Equal(t, 1, countAllowed(r, http.MethodGet, "/other", "", 2), "default limit is separate too")
+}
+
+func TestWithPolicies_State(t *testing.T) {
+	t.Parallel()
+
+	l := NewLimiter(
+		WithRequestsPerSecond(10),
+		WithAlgorithm(GCRAAlgorithm),
+		WithPolicies(Policies{"/login": {Rate: 5}}),
+		WithPolicies(Policies{"/api/*": {Rate: 100, Burst: 200}}),
+	)
+
+	states, err := l.State(context.Background(), "client")
+	require.NoError(t, err)
+	require.Len(t, states, 3)
+	assert.Empty(t, states[0].Policy)
+	assert.Equal(t, "/login", states[1].Policy, "exact paths sort first")
+	assert.Equal(t, GCRAAlgorithm, states[1].Limit.Algorithm, "policies use the default algorithm")
+	assert.Equal(t, "/api/*", states[2].Policy)
+	assert.Equal(t, 200, states[2].Limit.Burst)
+}
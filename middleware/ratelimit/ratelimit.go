@@ -41,6 +41,8 @@ type Meta struct {
 }
 
 // CommonOptions contains shared configuration for all rate limiters.
+//
+// Deprecated: Use [New] with functional options instead.
 type CommonOptions struct {
 	Key        KeyFunc                     // Function to derive rate limit key
 	Headers    bool                        // Emit RateLimit-* headers (IETF draft)
@@ -51,6 +53,8 @@ type CommonOptions struct {
 
 // TokenBucket implements token bucket rate limiting.
 // Allows bursts up to Burst size, refills at Rate tokens per second.
+//
+// Deprecated: Use [New] with [WithRequestsPerSecond] and [WithBurst] instead.
 type TokenBucket struct {
 	Rate  int              // Tokens per second
 	Burst int              // Maximum tokens (burst capacity)
@@ -59,6 +63,8 @@ type TokenBucket struct {
 
 // TokenBucketStore provides storage for token bucket rate limiting.
 // This allows custom implementations (e.g., Redis-backed) for distributed systems.
+//
+// Deprecated: Implement [Store] and pass it to [WithStore] instead.
 type TokenBucketStore interface {
 	// Allow checks if a request is allowed for the given key.
 	// Returns (allowed, remaining tokens, reset time in seconds).
@@ -67,6 +73,8 @@ type TokenBucketStore interface {
 
 // SlidingWindow implements sliding window rate limiting.
 // Uses two fixed windows (current + previous) for accurate counting.
+//
+// Deprecated: Use [New] with [WithRate] and [WithAlgorithm] instead.
 type SlidingWindow struct {
 	Window time.Duration // Fixed window duration (e.g., 1 minute)
 	Limit  int           // Requests per window
@@ -74,6 +82,8 @@ type SlidingWindow struct {
 }
 
 // WindowStore provides storage for sliding window rate limiting.
+//
+// Deprecated: Implement [Store] and pass it to [WithStore] instead.
 type WindowStore interface {
 	// GetCounts returns (current count, previous count, window start unix time, error).
 	GetCounts(ctx context.Context, key string, window time.Duration) (int, int, int64, error)
@@ -82,7 +92,11 @@ type WindowStore interface {
 }
 
 // New creates a token bucket rate limiter middleware using functional options.
-// Defaults: 100 requests/second, burst of 20, rate limit by IP.
+// Defaults: 100 requests/second, burst of 20, rate limit by IP, in-memory store.
+//
+// Use [WithStore] to share limits between replicas, e.g. with a [RedisStore].
+// If the store fails, the request is allowed and the error is logged. Use
+// [NewLimiter] instead to also inspect and reset limits at runtime.
 //
 // Example:
 //
@@ -91,42 +105,17 @@ type WindowStore interface {
 //	    ratelimit.WithBurst(10),
 //	))
 func New(opts ...Option) router.HandlerFunc {
-	cfg := &config{
-		requestsPerSecond: 100,
-		burst:             20,
-		cleanupInterval:   time.Minute,
-		limiterTTL:        5 * time.Minute,
-	}
-
-	for _, opt := range opts {
-		opt(cfg)
-	}
-
-	// Build CommonOptions from config
-	commonOpts := CommonOptions{
-		Key:     cfg.keyFunc,
-		Headers: true,
-		Enforce: true,
-		logger:  cfg.logger,
-	}
-
-	// Convert onLimitExceeded handler if provided
-	if cfg.onLimitExceeded != nil {
-		commonOpts.OnExceeded = func(c *router.Context, _ Meta) {
-			cfg.onLimitExceeded(c)
-		}
-	}
-
-	// Create token bucket from config
-	tb := TokenBucket{
-		Rate:  cfg.requestsPerSecond,
-		Burst: cfg.burst,
-	}
+	return NewLimiter(opts...).Handler()
+}
 
-	return WithTokenBucket(tb, commonOpts)
+// ceilSeconds rounds d up to whole seconds.
+func ceilSeconds(d time.Duration) int {
+	return int((d + time.Second - 1) / time.Second)
 }
 
 // WithTokenBucket creates a token bucket rate limiter middleware.
+//
+// Deprecated: Use [New] with [WithRequestsPerSecond] and [WithBurst] instead.
 func WithTokenBucket(tb TokenBucket, opts CommonOptions) router.HandlerFunc {
 	if opts.Key == nil {
 		opts.Key = func(c *router.Context) string {
@@ -196,6 +185,8 @@ func WithTokenBucket(tb TokenBucket, opts CommonOptions) router.HandlerFunc {
 }
 
 // WithSlidingWindow creates a sliding window rate limiter middleware.
+//
+// Deprecated: Use [New] with [WithRate] and [WithAlgorithm] instead.
 func WithSlidingWindow(sw SlidingWindow, opts CommonOptions) router.HandlerFunc {
 	if opts.Key == nil {
 		opts.Key = func(c *router.Context) string {
@@ -0,0 +1,202 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Scripts run atomically on the Redis server. Time comes from the server so
+// that replicas with skewed clocks agree. Timestamps and durations are in
+// microseconds; timestamps are formatted with %.0f because tostring would lose
+// precision.
+//
+// KEYS[1]: state key
+// ARGV: interval (per token), burst, cost, rate, period
+// Returns: {allowed, remaining, retry after, reset after}
+const scriptPrelude = `
+local interval = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local cost = tonumber(ARGV[3])
+local rate = tonumber(ARGV[4])
+local period = tonumber(ARGV[5])
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000000 + tonumber(t[2])
+local allowed = 0
+local retry = 0
+`
+
+var redisScripts = map[Algorithm]*redis.Script{
+	TokenBucketAlgorithm: redis.NewScript(scriptPrelude + `
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1])
+local ts = tonumber(state[2])
+if tokens == nil or ts == nil then
+  tokens = burst
+  ts = now
+end
+if now > ts then
+  tokens = math.min(burst, tokens + (now - ts) / interval)
+  ts = now
+end
+if tokens >= cost then
+  tokens = tokens - cost
+  allowed = 1
+else
+  retry = math.ceil((cost - tokens) * interval)
+end
+local reset = math.ceil((burst - tokens) * interval)
+if cost > 0 then
+  redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', string.format('%.0f', ts))
+  redis.call('PEXPIRE', KEYS[1], math.ceil(reset / 1000) + 1000)
+end
+return {allowed, math.floor(tokens), retry, reset}
+`),
+
+	GCRAAlgorithm: redis.NewScript(scriptPrelude + `
+local capacity = burst * interval
+local tat = tonumber(redis.call('GET', KEYS[1])) or now
+if tat < now then
+  tat = now
+end
+local next = tat + cost * interval
+if next - now <= capacity then
+  tat = next
+  allowed = 1
+  if cost > 0 then
+    redis.call('SET', KEYS[1], string.format('%.0f', tat), 'PX', math.ceil((tat - now) / 1000) + 1)
+  end
+else
+  retry = math.ceil(next - now - capacity)
+end
+local reset = tat - now
+return {allowed, math.floor((capacity - reset) / interval + 1e-9), retry, math.ceil(reset)}
+`),
+
+	FixedWindowAlgorithm: redis.NewScript(scriptPrelude + `
+local start = now - (now % period)
+local state = redis.call('HMGET', KEYS[1], 'window', 'count')
+local count = 0
+if tonumber(state[1]) == start then
+  count = tonumber(state[2]) or 0
+end
+local reset = start + period - now
+if count + cost <= rate then
+  count = count + cost
+  allowed = 1
+  if cost > 0 then
+    redis.call('HSET', KEYS[1], 'window', string.format('%.0f', start), 'count', count)
+    redis.call('PEXPIRE', KEYS[1], math.ceil(reset / 1000) + 1)
+  end
+else
+  retry = reset
+end
+return {allowed, math.max(rate - count, 0), retry, reset}
+`),
+
+	SlidingWindowLogAlgorithm: redis.NewScript(scriptPrelude + `
+local cutoff = now - period
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', string.format('%.0f', cutoff))
+local count = redis.call('ZCARD', KEYS[1])
+if count + cost <= rate then
+  local score = string.format('%.0f', now)
+  for i = 1, cost do
+    redis.call('ZADD', KEYS[1], score, score .. ':' .. (count + i))
+  end
+  count = count + cost
+  allowed = 1
+elseif cost > rate then
+  retry = period
+else
+  local idx = count + cost - rate - 1
+  local oldest = redis.call('ZRANGE', KEYS[1], idx, idx, 'WITHSCORES')
+  retry = tonumber(oldest[2]) - cutoff
+end
+local reset = 0
+if count > 0 then
+  local newest = redis.call('ZRANGE', KEYS[1], -1, -1, 'WITHSCORES')
+  reset = tonumber(newest[2]) - cutoff
+  redis.call('PEXPIRE', KEYS[1], math.ceil(reset / 1000) + 1)
+end
+return {allowed, math.max(rate - count, 0), retry, reset}
+`),
+}
+
+// RedisStore is a [Store] backed by Redis, so every replica that uses the same
+// Redis deployment shares one limit per key. Each Take runs a Lua script that
+// checks and updates the key atomically, using the Redis server clock. It
+// supports every [Algorithm]; keys expire once their state is fresh again.
+// Requires Redis 5.0 or later.
+type RedisStore struct {
+	client redis.Cmdable
+	prefix string
+}
+
+// NewRedisStore creates a store that keeps buckets under prefix + key. An empty
+// prefix defaults to "ratelimit:". client can be a *redis.Client,
+// *redis.ClusterClient, or *redis.Ring.
+//
+// Example:
+//
+//	client := redis.NewClient(&redis.Options{Addr: "localhost:6379"})
+//	r.Use(ratelimit.New(
+//	    ratelimit.WithRequestsPerSecond(100),
+//	    ratelimit.WithStore(ratelimit.NewRedisStore(client, "myapp:ratelimit:")),
+//	))
+func NewRedisStore(client redis.Cmdable, prefix string) *RedisStore {
+	if prefix == "" {
+		prefix = "ratelimit:"
+	}
+	return &RedisStore{client: client, prefix: prefix}
+}
+
+// Reset implements [Store].
+func (s *RedisStore) Reset(ctx context.Context, key string) error {
+	if err := s.client.Del(ctx, s.prefix+key).Err(); err != nil {
+		return fmt.Errorf("ratelimit: redis: %w", err)
+	}
+	return nil
+}
+
+// Take implements [Store].
+func (s *RedisStore) Take(ctx context.Context, key string, limit Limit, cost int) (Result, error) {
+	limit = limit.normalize()
+	script, ok := redisScripts[limit.Algorithm]
+	if !ok {
+		return Result{}, errUnknownAlgorithm(limit.Algorithm)
+	}
+
+	interval := limit.interval() / float64(time.Microsecond)
+	values, err := script.Run(ctx, s.client, []string{s.prefix + key},
+		interval, limit.Burst, cost, limit.Rate, limit.Period.Microseconds()).Int64Slice()
+	if err != nil {
+		return Result{}, fmt.Errorf("ratelimit: redis: %w", err)
+	}
+	if len(values) != 4 {
+		return Result{}, fmt.Errorf("ratelimit: redis: unexpected script result %v", values)
+	}
+
+	return Result{
+		Allowed:    values[0] == 1,
+		Limit:      limit.capacity(),
+		Remaining:  int(values[1]),
+		RetryAfter: time.Duration(values[2]) * time.Microsecond,
+		ResetAfter: time.Duration(values[3]) * time.Microsecond,
+	}, nil
+}
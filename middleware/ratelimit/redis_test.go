@@ -0,0 +1,96 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRedisStore(t *testing.T) (*RedisStore, *miniredis.Miniredis) {
+	t.Helper()
+
+	mr := miniredis.RunT(t)
+	mr.SetTime(testEpoch)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	return NewRedisStore(client, ""), mr
+}
+
+func TestRedisStore_Take(t *testing.T) {
+	t.Parallel()
+
+	store, mr := newTestRedisStore(t)
+	ctx := t.Context()
+	limit := Limit{Rate: 2, Burst: 3}
+
+	for i := range 3 {
+		res, err := store.Take(ctx, "k", limit, 1)
+		require.NoError(t, err)
+		assert.True(t, res.Allowed, "request %d", i+1)
+		assert.Equal(t, 2-i, res.Remaining)
+	}
+
+	res, err := store.Take(ctx, "k", limit, 1)
+	require.NoError(t, err)
+	assert.False(t, res.Allowed)
+	assert.Equal(t, 3, res.Limit)
+	assert.Equal(t, 500*time.Millisecond, res.RetryAfter)
+	assert.Equal(t, 1500*time.Millisecond, res.ResetAfter)
+	assert.True(t, mr.Exists("ratelimit:k"), "buckets use the default prefix")
+	assert.Positive(t, mr.TTL("ratelimit:k"), "buckets expire")
+
+	mr.SetTime(testEpoch.Add(500 * time.Millisecond))
+	res, err = store.Take(ctx, "k", limit, 1)
+	require.NoError(t, err)
+	assert.True(t, res.Allowed, "one token is refilled after 500ms")
+	assert.Equal(t, 0, res.Remaining)
+}
+
+func TestRedisStore_WindowLimit(t *testing.T) {
+	t.Parallel()
+
+	for _, algorithm := range []Algorithm{FixedWindowAlgorithm, SlidingWindowLogAlgorithm} {
+		t.Run(string(algorithm), func(t *testing.T) {
+			t.Parallel()
+
+			store, _ := newTestRedisStore(t)
+			res, err := store.Take(t.Context(), "k", Limit{Algorithm: algorithm, Rate: 3, Burst: 10, Period: time.Minute}, 1)
+			require.NoError(t, err)
+			assert.True(t, res.Allowed)
+			assert.Equal(t, 3, res.Limit, "window algorithms report Rate, like MemoryStore")
+			assert.Equal(t, 2, res.Remaining)
+		})
+	}
+}
+
+func TestRedisStore_Error(t *testing.T) {
+	t.Parallel()
+
+	store, mr := newTestRedisStore(t)
+	mr.Close()
+
+	_, err := store.Take(t.Context(), "k", Limit{Rate: 1}, 1)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "ratelimit: redis")
+}
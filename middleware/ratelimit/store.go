@@ -0,0 +1,193 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Limit describes the quota enforced for a key: Rate requests per Period,
+// enforced by Algorithm. For the token bucket and GCRA, Rate tokens are added
+// every Period up to Burst tokens; window algorithms ignore Burst.
+type Limit struct {
+	Algorithm Algorithm     // Enforcement algorithm (zero means TokenBucketAlgorithm)
+	Rate      int           // Requests (tokens) per Period
+	Period    time.Duration // Refill period or window (zero means one second)
+	Burst     int           // Maximum tokens (zero means Rate)
+}
+
+// normalize fills in defaults for zero fields.
+func (l Limit) normalize() Limit {
+	if l.Algorithm == "" {
+		l.Algorithm = TokenBucketAlgorithm
+	}
+	if l.Period <= 0 {
+		l.Period = time.Second
+	}
+	if l.Burst <= 0 {
+		l.Burst = l.Rate
+	}
+	return l
+}
+
+// capacity returns the maximum requests at once reported in [Result.Limit]:
+// Rate for window algorithms, which ignore Burst, and Burst otherwise.
+func (l Limit) capacity() int {
+	switch l.Algorithm {
+	case FixedWindowAlgorithm, SlidingWindowLogAlgorithm:
+		return l.Rate
+	default:
+		return l.Burst
+	}
+}
+
+// interval returns the time in nanoseconds it takes to refill one token.
+func (l Limit) interval() float64 {
+	return float64(l.Period) / float64(max(l.Rate, 1))
+}
+
+// Result is the outcome of [Store.Take].
+type Result struct {
+	Allowed    bool          // Whether the request may proceed
+	Limit      int           // Maximum requests at once (Burst, or Rate for window algorithms)
+	Remaining  int           // Requests left after this request
+	RetryAfter time.Duration // Time until the request would be allowed; zero if allowed
+	ResetAfter time.Duration // Time until the full limit is available again
+}
+
+// Store keeps rate limit state. Take must check and update the state of key
+// atomically, so a Store shared by several replicas (such as [RedisStore])
+// enforces one limit across all of them.
+//
+// The in-memory [MemoryStore] is used when no store is configured.
+type Store interface {
+	// Take consumes cost tokens for key under limit and reports whether the
+	// request is allowed. A denied request consumes nothing. A cost of zero
+	// reports the current state without changing it. Stores return an error
+	// for algorithms they do not support.
+	Take(ctx context.Context, key string, limit Limit, cost int) (Result, error)
+
+	// Reset clears the state of key, so its next request sees a full limit.
+	Reset(ctx context.Context, key string) error
+}
+
+// Stats are usage statistics of a [MemoryStore].
+type Stats struct {
+	Keys      int    `json:"keys"`      // Keys currently tracked
+	Evictions uint64 `json:"evictions"` // Idle keys removed since the store was created
+}
+
+// MemoryStore is the default [Store]. It keeps state in process memory, so
+// limits are enforced per instance. It supports every [Algorithm]. Idle keys are
+// removed periodically. It is safe for concurrent use.
+type MemoryStore struct {
+	mu        sync.Mutex
+	entries   map[string]*entry
+	interval  time.Duration // how often idle buckets are swept
+	ttl       time.Duration // how long a fresh-equivalent entry is kept
+	lastSweep time.Time
+	evictions uint64
+	now       func() time.Time
+}
+
+// NewMemoryStore creates an in-memory store. Share one store between
+// middleware instances only if their keys do not overlap.
+//
+// Example:
+//
+//	store := ratelimit.NewMemoryStore()
+//	r.Use(ratelimit.New(ratelimit.WithStore(store)))
+func NewMemoryStore() *MemoryStore {
+	return newMemoryStore(time.Minute, 5*time.Minute)
+}
+
+func newMemoryStore(interval, ttl time.Duration) *MemoryStore {
+	return &MemoryStore{
+		entries:  make(map[string]*entry),
+		interval: interval,
+		ttl:      ttl,
+		now:      time.Now,
+	}
+}
+
+// Take implements [Store].
+func (s *MemoryStore) Take(_ context.Context, key string, limit Limit, cost int) (Result, error) {
+	limit = limit.normalize()
+	if !limit.Algorithm.valid() {
+		return Result{}, errUnknownAlgorithm(limit.Algorithm)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	e, ok := s.entries[key]
+	if !ok || e.alg != limit.Algorithm {
+		e = &entry{alg: limit.Algorithm}
+		if cost == 0 {
+			// Report a fresh state without tracking the key.
+			return e.take(now, limit, 0), nil
+		}
+		s.entries[key] = e
+	}
+
+	return e.take(now, limit, cost), nil
+}
+
+// Reset implements [Store].
+func (s *MemoryStore) Reset(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.entries, key)
+	return nil
+}
+
+// Keys returns the keys currently tracked, in no particular order. Keys of
+// tiers and route overrides carry a namespace prefix.
+func (s *MemoryStore) Keys() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Collect(maps.Keys(s.entries))
+}
+
+// Stats returns usage statistics.
+func (s *MemoryStore) Stats() Stats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return Stats{Keys: len(s.entries), Evictions: s.evictions}
+}
+
+// sweep removes entries whose state has returned to that of a fresh entry and
+// that have been idle for the TTL, at most once per interval. Removing them is
+// invisible to clients. The caller must hold s.mu.
+func (s *MemoryStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < s.interval {
+		return
+	}
+	s.lastSweep = now
+
+	for key, e := range s.entries {
+		if !now.Before(e.expires.Add(s.ttl)) {
+			delete(s.entries, key)
+			s.evictions++
+		}
+	}
+}
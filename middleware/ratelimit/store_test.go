@@ -0,0 +1,167 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"rivaas.dev/router"
+)
+
+// fakeClock is a manually advanced clock for stores.
+type fakeClock struct {
+	t time.Time
+}
+
+func (c *fakeClock) now() time.Time          { return c.t }
+func (c *fakeClock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func newTestMemoryStore() (*MemoryStore, *fakeClock) {
+	clock := &fakeClock{t: testEpoch}
+	store := NewMemoryStore()
+	store.now = clock.now
+	return store, clock
+}
+
+func TestMemoryStore_Take(t *testing.T) {
+	t.Parallel()
+
+	store, clock := newTestMemoryStore()
+	ctx := t.Context()
+	limit := Limit{Rate: 2, Burst: 3}
+
+	for i := range 3 {
+		res, err := store.Take(ctx, "k", limit, 1)
+		require.NoError(t, err)
+		assert.True(t, res.Allowed, "request %d", i+1)
+		assert.Equal(t, 3, res.Limit)
+		assert.Equal(t, 2-i, res.Remaining)
+	}
+
+	res, err := store.Take(ctx, "k", limit, 1)
+	require.NoError(t, err)
+	assert.False(t, res.Allowed)
+	assert.Equal(t, 500*time.Millisecond, res.RetryAfter)
+	assert.Equal(t, 1500*time.Millisecond, res.ResetAfter)
+
+	other, err := store.Take(ctx, "other", limit, 1)
+	require.NoError(t, err)
+	assert.True(t, other.Allowed, "keys are limited independently")
+
+	clock.advance(500 * time.Millisecond)
+	res, err = store.Take(ctx, "k", limit, 1)
+	require.NoError(t, err)
+	assert.True(t, res.Allowed, "one token is refilled after 500ms")
+	assert.Equal(t, 0, res.Remaining)
+}
+
+func TestMemoryStore_Period(t *testing.T) {
+	t.Parallel()
+
+	store, clock := newTestMemoryStore()
+	limit := Limit{Rate: 60, Period: time.Minute, Burst: 1}
+
+	res, err := store.Take(t.Context(), "k", limit, 1)
+	require.NoError(t, err)
+	assert.True(t, res.Allowed)
+
+	res, err = store.Take(t.Context(), "k", limit, 1)
+	require.NoError(t, err)
+	assert.False(t, res.Allowed)
+	assert.Equal(t, time.Second, res.RetryAfter)
+
+	clock.advance(time.Second)
+	res, err = store.Take(t.Context(), "k", limit, 1)
+	require.NoError(t, err)
+	assert.True(t, res.Allowed)
+}
+
+func TestMemoryStore_Sweep(t *testing.T) {
+	t.Parallel()
+
+	store, clock := newTestMemoryStore()
+	limit := Limit{Rate: 1, Burst: 10}
+
+	_, err := store.Take(t.Context(), "idle", limit, 10)
+	require.NoError(t, err)
+
+	clock.advance(6 * time.Minute)
+	_, err = store.Take(t.Context(), "active", limit, 1)
+	require.NoError(t, err)
+	assert.Len(t, store.entries, 1, "idle entries that are fresh again are removed")
+	assert.Contains(t, store.entries, "active")
+}
+
+// errStore is a Store that always fails.
+type errStore struct{}
+
+func (errStore) Take(context.Context, string, Limit, int) (Result, error) {
+	return Result{}, errors.New("store unavailable")
+}
+
+func (errStore) Reset(context.Context, string) error {
+	return errors.New("store unavailable")
+}
+
+func TestNew_WithStore(t *testing.T) {
+	t.Parallel()
+
+	store, _ := newTestMemoryStore()
+	newRouter := func() *router.Router {
+		r := router.MustNew()
+		r.Use(New(WithRequestsPerSecond(1), WithBurst(2), WithStore(store)))
+		r.GET("/test", func(c *router.Context) {
+			//nolint:errcheck // Test handler
+			c.String(http.StatusOK, "ok")
+		})
+		return r
+	}
+
+	// Two middleware instances sharing a store enforce one limit, like replicas do.
+	replicas := []*router.Router{newRouter(), newRouter()}
+	codes := make([]int, 0, 3)
+	for i := range 3 {
+		w := httptest.NewRecorder()
+		replicas[i%2].ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/test", nil))
+		codes = append(codes, w.Code)
+	}
+	assert.Equal(t, []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests}, codes)
+}
+
+func TestNew_StoreErrorAllowsRequest(t *testing.T) {
+	t.Parallel()
+
+	r := router.MustNew()
+	r.Use(New(WithStore(errStore{})))
+	r.GET("/test", func(c *router.Context) {
+		//nolint:errcheck // Test handler
+		c.String(http.StatusOK, "ok")
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/test", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("RateLimit-Remaining"))
+}
@@ -29,6 +29,8 @@ type tokenBucketEntry struct {
 
 // InMemoryTokenBucketStore implements in-memory token bucket storage.
 // This is the default store implementation used by the token bucket rate limiter.
+//
+// Deprecated: Use [MemoryStore] instead.
 type InMemoryTokenBucketStore struct {
 	rate        int // tokens per second
 	burst       int // max tokens
@@ -48,6 +50,8 @@ type InMemoryTokenBucketStore struct {
 //	    ratelimit.TokenBucket{Rate: 100, Burst: 20, Store: store},
 //	    ratelimit.CommonOptions{},
 //	))
+//
+// Deprecated: Use [NewMemoryStore] instead.
 func NewInMemoryTokenBucketStore(rate, burst int) *InMemoryTokenBucketStore {
 	store := &InMemoryTokenBucketStore{
 		rate:        rate,
@@ -149,6 +153,8 @@ type windowEntry struct {
 }
 
 // InMemoryStore implements in-memory sliding window storage.
+//
+// Deprecated: Use [MemoryStore] instead.
 type InMemoryStore struct {
 	entries     map[string]*windowEntry
 	mu          sync.RWMutex
@@ -157,6 +163,8 @@ type InMemoryStore struct {
 }
 
 // NewInMemoryStore creates a new in-memory sliding window store.
+//
+// Deprecated: Use [NewMemoryStore] instead.
 func NewInMemoryStore() *InMemoryStore {
 	store := &InMemoryStore{
 		entries:     make(map[string]*windowEntry),
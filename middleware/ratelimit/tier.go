@@ -0,0 +1,184 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"rivaas.dev/router"
+	"rivaas.dev/router/route"
+)
+
+// TierFunc returns the name of the limit tier of a request, such as the plan
+// of the caller's API key or "anonymous" for unauthenticated requests.
+type TierFunc func(*router.Context) string
+
+// Tiers maps tier names to limits. A limit without an Algorithm uses the one
+// set with [WithAlgorithm].
+type Tiers map[string]Limit
+
+// WithTiers enforces differentiated quotas from one middleware instance. For
+// each request fn selects a tier by name; requests whose tier is not in tiers
+// use the default limit from [WithRequestsPerSecond], [WithRate], and
+// [WithBurst]. Each tier keeps separate state, so a client that changes tier
+// starts with a fresh limit.
+//
+// Example:
+//
+//	ratelimit.New(
+//	    ratelimit.WithKeyFunc(apiKey),
+//	    ratelimit.WithTiers(
+//	        func(c *router.Context) string { return planOf(apiKey(c)) },
+//	        ratelimit.Tiers{
+//	            "free": {Rate: 60, Period: time.Minute},
+//	            "pro":  {Rate: 100, Burst: 200},
+//	        },
+//	    ),
+//	)
+func WithTiers(fn TierFunc, tiers Tiers) Option {
+	return func(cfg *config) {
+		cfg.tierFunc = fn
+		cfg.tiers = tiers
+	}
+}
+
+// WithRouteTiers overrides the limits of a route for the tiers in tiers. The
+// empty tier name "" applies to requests of any other tier. Tiers not covered
+// fall back to [WithTiers] and the default limit. A route override keeps its
+// own state, so requests to the route do not count against other limits.
+//
+// route is a route pattern as registered ("/export/:id"), optionally prefixed
+// with a method ("POST /export/:id"). A method-specific match takes precedence.
+// Can be passed multiple times for different routes.
+//
+// The router has no per-route metadata for a middleware to read, so overrides
+// are matched by pattern, including any group prefix. A pattern that no longer
+// matches, e.g. after a group prefix changed, silently stops applying; call
+// [Limiter.CheckRoutes] once routes are registered to catch it.
+//
+// Example:
+//
+//	ratelimit.New(
+//	    ratelimit.WithTiers(planOf, tiers),
+//	    ratelimit.WithRouteTiers("POST /search", ratelimit.Tiers{
+//	        "":    {Rate: 1},             // everyone else
+//	        "pro": {Rate: 10, Burst: 20},
+//	    }),
+//	)
+func WithRouteTiers(route string, tiers Tiers) Option {
+	return func(cfg *config) {
+		if cfg.routeTiers == nil {
+			cfg.routeTiers = make(map[string]Tiers)
+		}
+		cfg.routeTiers[route] = tiers
+	}
+}
+
+// CheckRoutes reports an error for each [WithRouteTiers] pattern that matches
+// none of routes, such as those returned by router.Router.Routes after all
+// routes are registered. Use it at startup or in a test so that renamed routes
+// or changed group prefixes do not silently drop their overrides.
+//
+// Example:
+//
+//	limiter := ratelimit.NewLimiter(ratelimit.WithRouteTiers("POST /search", searchTiers))
+//	r.Use(limiter.Handler())
+//	r.POST("/search", search)
+//
+//	if err := limiter.CheckRoutes(r.Routes()); err != nil {
+//	    log.Fatal(err)
+//	}
+func (l *Limiter) CheckRoutes(routes []route.Info) error {
+	var errs []error
+	for _, pattern := range slices.Sorted(maps.Keys(l.cfg.routeTiers)) {
+		method, path, hasMethod := strings.Cut(pattern, " ")
+		if !hasMethod {
+			method, path = "", pattern
+		}
+
+		matched := slices.ContainsFunc(routes, func(info route.Info) bool {
+			return info.Path == path && (method == "" || info.Method == method)
+		})
+		if !matched {
+			errs = append(errs, fmt.Errorf("ratelimit: route override %q matches no registered route", pattern))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// resolveTiers replaces the configured tiers with copies that have the default
+// algorithm filled in. It panics on unknown algorithms, like [New].
+func (cfg *config) resolveTiers() {
+	resolve := func(tiers Tiers) Tiers {
+		tiers = maps.Clone(tiers)
+		for name, l := range tiers {
+			if l.Algorithm == "" {
+				l.Algorithm = cfg.algorithm
+			}
+			if l.Algorithm != "" && !l.Algorithm.valid() {
+				panic(errUnknownAlgorithm(l.Algorithm))
+			}
+			tiers[name] = l
+		}
+		return tiers
+	}
+
+	cfg.tiers = resolve(cfg.tiers)
+	routeTiers := make(map[string]Tiers, len(cfg.routeTiers))
+	for route, tiers := range cfg.routeTiers {
+		routeTiers[route] = resolve(tiers)
+	}
+	cfg.routeTiers = routeTiers
+}
+
+// limitFor returns the limit for c and the namespace that separates its state
+// from that of other limits. The default limit has an empty namespace.
+func (cfg *config) limitFor(c *router.Context, def Limit) (Limit, string) {
+	var tier string
+	if cfg.tierFunc != nil {
+		tier = cfg.tierFunc(c)
+	}
+
+	if len(cfg.routeTiers) > 0 {
+		pattern := c.RoutePattern()
+		for _, route := range [...]string{c.Request.Method + " " + pattern, pattern} {
+			tiers, ok := cfg.routeTiers[route]
+			if !ok {
+				continue
+			}
+			if l, ok := tiers[tier]; ok {
+				return l, route + "|" + tier + "|"
+			}
+			if l, ok := tiers[""]; ok {
+				return l, route + "||"
+			}
+		}
+	}
+
+	if p := cfg.policyFor(c); p != nil {
+		return p.limit, policyNamespace(p.pattern)
+	}
+
+	if l, ok := cfg.tiers[tier]; ok {
+		return l, tier + "|"
+	}
+
+	return def, ""
+}
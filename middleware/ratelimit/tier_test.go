@@ -0,0 +1,132 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package ratelimit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"rivaas.dev/router"
+	"rivaas.dev/router/route"
+)
+
+// countAllowed sends n requests and returns how many were allowed.
+func countAllowed(r http.Handler, method, path, plan string, n int) int {
+	allowed := 0
+	for range n {
+		req := httptest.NewRequest(method, path, nil)
+		if plan != "" {
+			req.Header.Set("X-Plan", plan)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		if w.Code == http.StatusOK {
+			allowed++
+		}
+	}
+	return allowed
+}
+
+func newTierRouter(opts ...Option) *router.Router {
+	r := router.MustNew()
+	r.Use(New(append([]Option{
+		WithRequestsPerSecond(1),
+		WithBurst(1),
+		WithTiers(
+			func(c *router.Context) string { return c.Request.Header.Get("X-Plan") },
+			Tiers{
+				"free": {Rate: 1, Period: time.Hour, Burst: 2},
+				"pro":  {Rate: 10, Burst: 5},
+			},
+		),
+	}, opts...)...))
+
+	ok := func(c *router.Context) {
+		//nolint:errcheck // Test handler
+		c.String(http.StatusOK, "ok")
+	}
+	r.GET("/data", ok)
+	r.GET("/search", ok)
+	r.POST("/search", ok)
+	return r
+}
+
+func TestWithTiers(t *testing.T) {
+	t.Parallel()
+
+	r := newTierRouter()
+	assert.Equal(t, 1, countAllowed(r, http.MethodGet, "/data", "", 3), "no tier uses the default limit")
+	assert.Equal(t, 0, countAllowed(r, http.MethodGet, "/data", "unknown", 3), "unknown tiers share the default limit")
+	assert.Equal(t, 2, countAllowed(r, http.MethodGet, "/data", "free", 5))
+	assert.Equal(t, 5, countAllowed(r, http.MethodGet, "/data", "pro", 8), "tiers keep separate state")
+}
+
+func TestWithRouteTiers(t *testing.T) {
+	t.Parallel()
+
+	r := newTierRouter(
+		WithRouteTiers("/search", Tiers{"": {Rate: 1, Burst: 3}}),
+		WithRouteTiers("POST /search", Tiers{"pro": {Rate: 1, Burst: 1}}),
+	)
+
+	assert.Equal(t, 3, countAllowed(r, http.MethodGet, "/search", "pro", 5), "the catch-all tier of the route applies")
+	assert.Equal(t, 1, countAllowed(r, http.MethodPost, "/search", "pro", 3), "method-specific routes take precedence")
+	assert.Equal(t, 5, countAllowed(r, http.MethodGet, "/data", "pro", 8), "route overrides keep separate state")
+	assert.Equal(t, 0, countAllowed(r, http.MethodPost, "/search", "free", 3), "the method-less route covers other tiers")
+	assert.Equal(t, 2, countAllowed(r, http.MethodGet, "/data", "free", 5), "routes without overrides use the tier limit")
+}
+
+func TestLimiter_CheckRoutes(t *testing.T) {
+	t.Parallel()
+
+	routes := []route.Info{
+		{Method: http.MethodGet, Path: "/api/search"},
+		{Method: http.MethodPost, Path: "/api/search"},
+	}
+
+	limiter := NewLimiter(
+		WithRouteTiers("/api/search", Tiers{"": {Rate: 1}}),
+		WithRouteTiers("POST /api/search", Tiers{"": {Rate: 1}}),
+	)
+	require.NoError(t, limiter.CheckRoutes(routes))
+
+	limiter = NewLimiter(
+		WithRouteTiers("/search", Tiers{"": {Rate: 1}}),
+		WithRouteTiers("DELETE /api/search", Tiers{"": {Rate: 1}}),
+	)
+	err := limiter.CheckRoutes(routes)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `"/search" matches no registered route`, "the group prefix changed")
+	assert.Contains(t, err.Error(), `"DELETE /api/search" matches no registered route`)
+}
+
+func TestWithTiers_InheritsAlgorithm(t *testing.T) {
+	t.Parallel()
+
+	r := newTierRouter(WithAlgorithm(FixedWindowAlgorithm))
+	// The fixed window ignores the burst of 5, so the pro tier allows 10.
+	assert.Equal(t, 10, countAllowed(r, http.MethodGet, "/data", "pro", 12))
+
+	assert.Panics(t, func() {
+		New(WithTiers(nil, Tiers{"x": {Algorithm: "leaky", Rate: 1}}))
+	})
+}
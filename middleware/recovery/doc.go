@@ -35,7 +35,11 @@
 //   - WithStackSize: Maximum stack trace size in bytes (default: 4KB)
 //   - WithLogger: Custom logger function for panic messages
 //   - WithHandler: Custom recovery handler for error responses
+//   - WithFormatter: Error formatter from rivaas.dev/errors for error responses
 //   - WithDisableStackAll: Disable full stack trace from all goroutines
+//   - WithReporter: Report panics with a sanitized request snapshot
+//   - WithRedactHeaders: More headers to redact in request snapshots
+//   - WithRequestIDFunc: Function returning the request ID for snapshots
 //
 // # Custom Recovery Handler
 //
@@ -50,6 +54,28 @@
 //	    }),
 //	))
 //
+// # Problem Details
+//
+// [WithFormatter] renders recovered panics with a formatter from
+// rivaas.dev/errors, such as RFC 9457 problem details or JSON:API errors. The
+// formatter receives a [PanicError], which hides the panic value from clients
+// and adds the request ID and trace ID to the body for correlation:
+//
+//	r.Use(recovery.New(recovery.WithFormatter(
+//	    errors.MustNew(errors.WithRFC9457("https://api.example.com/problems")),
+//	)))
+//
+// # Panic Reporting
+//
+// [WithReporter] hands every recovered panic to a function, e.g. to send it
+// to an error tracker. The [Report] holds the panic value, the stack trace,
+// and a [RequestSnapshot] with the method, path, route, headers (secrets
+// redacted), request ID, and trace ID:
+//
+//	r.Use(recovery.New(recovery.WithReporter(func(ctx context.Context, report recovery.Report) {
+//	    tracker.Capture(report.Error(), report.Stack, report.Request)
+//	})))
+//
 // # OpenTelemetry Integration
 //
 // The middleware automatically marks OpenTelemetry spans with exception information:
@@ -7,6 +7,7 @@ require (
 	go.opentelemetry.io/otel v1.42.0
 	go.opentelemetry.io/otel/trace v1.42.0
 	golang.org/x/term v0.41.0
+	rivaas.dev/errors v0.7.0
 	rivaas.dev/router v0.15.0
 )
 
@@ -21,4 +22,7 @@ require (
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
 
-replace rivaas.dev/router => ../../router
+replace (
+	rivaas.dev/errors => ../../errors
+	rivaas.dev/router => ../../router
+)
@@ -18,7 +18,9 @@ package recovery
 
 import (
 	"log/slog"
+	"slices"
 
+	riverrors "rivaas.dev/errors"
 	"rivaas.dev/router"
 )
 
@@ -62,6 +64,28 @@ func WithHandler(handler func(c *router.Context, err any)) Option {
 	}
 }
 
+// WithFormatter renders recovered panics with an error formatter from
+// rivaas.dev/errors, e.g. as RFC 9457 problem details or JSON:API errors,
+// instead of the default JSON body. The formatter receives a [PanicError],
+// whose request ID (see [WithRequestIDFunc]) and trace ID are added to the
+// body. It replaces a handler set with [WithHandler]; a nil formatter restores
+// the default handler.
+//
+// Example:
+//
+//	recovery.New(recovery.WithFormatter(
+//	    errors.MustNew(errors.WithRFC9457("https://api.example.com/problems")),
+//	))
+func WithFormatter(formatter riverrors.Formatter) Option {
+	return func(cfg *config) {
+		if formatter == nil {
+			cfg.handler = defaultHandler
+			return
+		}
+		cfg.handler = formatterHandler(cfg, formatter)
+	}
+}
+
 // WithStackTrace enables or disables stack trace capture.
 // Default: true
 //
@@ -103,3 +127,56 @@ func WithPrettyStack(enabled bool) Option {
 		cfg.prettyStack = &enabled
 	}
 }
+
+// WithReporter sets a function that receives every recovered panic with its
+// value, stack trace, and a sanitized snapshot of the request (method, path,
+// route, headers with secrets redacted, request ID, and trace ID). Use it to
+// forward panics to an error tracker without writing a custom handler.
+// The reporter runs before the error response is written.
+//
+// Example:
+//
+//	recovery.New(recovery.WithReporter(func(ctx context.Context, report recovery.Report) {
+//	    hub := sentry.CurrentHub().Clone()
+//	    hub.Scope().SetTag("request_id", report.Request.RequestID)
+//	    hub.Scope().SetTag("trace_id", report.Request.TraceID)
+//	    hub.CaptureException(report.Error())
+//	}))
+func WithReporter(reporter Reporter) Option {
+	return func(cfg *config) {
+		cfg.reporter = reporter
+	}
+}
+
+// WithRedactHeaders adds headers whose values are replaced by "[REDACTED]" in
+// request snapshots passed to the reporter. Authorization, Proxy-Authorization,
+// Cookie, Set-Cookie, X-Api-Key, X-Auth-Token, and X-Csrf-Token are always
+// redacted.
+//
+// Example:
+//
+//	recovery.New(
+//	    recovery.WithReporter(reporter),
+//	    recovery.WithRedactHeaders("X-Session-Token"),
+//	)
+func WithRedactHeaders(headers ...string) Option {
+	return func(cfg *config) {
+		cfg.redactHeaders = slices.Concat(cfg.redactHeaders, headers)
+	}
+}
+
+// WithRequestIDFunc sets a function that returns the request ID included in
+// request snapshots and in responses written with [WithFormatter]. By default, the X-Request-ID response header set by the
+// requestid middleware is used, or else the X-Request-ID request header.
+//
+// Example:
+//
+//	recovery.New(
+//	    recovery.WithReporter(reporter),
+//	    recovery.WithRequestIDFunc(requestid.Get),
+//	)
+func WithRequestIDFunc(fn func(c *router.Context) string) Option {
+	return func(cfg *config) {
+		cfg.requestIDFunc = fn
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recovery
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"mime"
+	"net/http"
+	"strings"
+
+	"go.opentelemetry.io/otel/trace"
+
+	riverrors "rivaas.dev/errors"
+	"rivaas.dev/router"
+)
+
+// PanicError is the error passed to the formatter set with [WithFormatter].
+// It reports status 500 and a generic message, so the panic value is not
+// exposed to clients, and carries the request ID and trace ID as problem
+// details extensions for correlating the response with logs and traces.
+// The panic value is available through errors.Unwrap, e.g. for debug output
+// or a Registry.
+type PanicError struct {
+	// Value is the value passed to panic.
+	Value any
+
+	// RequestID is the ID of the request that caused the panic; may be empty.
+	RequestID string
+
+	// TraceID is the ID of the active trace; may be empty.
+	TraceID string
+}
+
+// Error returns a generic message that does not include the panic value.
+func (e *PanicError) Error() string {
+	return http.StatusText(http.StatusInternalServerError)
+}
+
+// Unwrap returns the panic value as an error.
+func (e *PanicError) Unwrap() error {
+	return valueError(e.Value)
+}
+
+// HTTPStatus returns 500 Internal Server Error.
+func (e *PanicError) HTTPStatus() int {
+	return http.StatusInternalServerError
+}
+
+// Extensions returns the request_id and trace_id members, omitting empty ones.
+func (e *PanicError) Extensions() map[string]any {
+	extensions := make(map[string]any, 2)
+	if e.RequestID != "" {
+		extensions["request_id"] = e.RequestID
+	}
+	if e.TraceID != "" {
+		extensions["trace_id"] = e.TraceID
+	}
+
+	return extensions
+}
+
+// newPanicError returns the [PanicError] for a panic with value v in c.
+func newPanicError(c *router.Context, cfg *config, v any) *PanicError {
+	e := &PanicError{
+		Value:     v,
+		RequestID: requestID(c, cfg),
+	}
+	if sc := trace.SpanContextFromContext(c.RequestContext()); sc.IsValid() {
+		e.TraceID = sc.TraceID().String()
+	}
+
+	return e
+}
+
+// formatterHandler returns a recovery handler that writes the response of
+// formatter for the [PanicError] of a recovered panic.
+func formatterHandler(cfg *config, formatter riverrors.Formatter) func(c *router.Context, err any) {
+	return func(c *router.Context, err any) {
+		response := formatter.Format(c.Request, newPanicError(c, cfg, err))
+		for key, values := range response.Headers {
+			for _, value := range values {
+				c.Response.Header().Add(key, value)
+			}
+		}
+
+		data, encodeErr := encodeResponseBody(response)
+		if encodeErr != nil {
+			if cfg.logger != nil {
+				cfg.logger.Error("failed to encode panic response", "error", encodeErr)
+			}
+			defaultHandler(c, err)
+
+			return
+		}
+
+		//nolint:errcheck // Panic recovery handler; best-effort response
+		c.Data(response.Status, response.ContentType, data)
+	}
+}
+
+// encodeResponseBody encodes the body of response as XML if its content type
+// is an XML media type, such as application/problem+xml, or else as JSON.
+func encodeResponseBody(response riverrors.Response) ([]byte, error) {
+	if mediaType, _, err := mime.ParseMediaType(response.ContentType); err == nil &&
+		(strings.HasSuffix(mediaType, "/xml") || strings.HasSuffix(mediaType, "+xml")) {
+		return xml.Marshal(response.Body)
+	}
+
+	return json.Marshal(response.Body)
+}
@@ -0,0 +1,128 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package recovery
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+
+	riverrors "rivaas.dev/errors"
+	"rivaas.dev/router"
+)
+
+func TestRecovery_WithFormatter(t *testing.T) {
+	t.Parallel()
+
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+		SpanID:     trace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+		TraceFlags: trace.FlagsSampled,
+	})
+
+	serve := func(t *testing.T, formatter riverrors.Formatter) *httptest.ResponseRecorder {
+		t.Helper()
+		r := router.MustNew()
+		r.Use(New(WithoutLogging(), WithFormatter(formatter)))
+		r.GET("/orders/:id", func(_ *router.Context) {
+			panic("secret database password")
+		})
+
+		req := httptest.NewRequestWithContext(trace.ContextWithSpanContext(t.Context(), sc), http.MethodGet, "/orders/42", nil)
+		req.Header.Set("X-Request-ID", "req-123")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		return w
+	}
+
+	t.Run("RFC 9457", func(t *testing.T) {
+		t.Parallel()
+		w := serve(t, riverrors.MustNew(riverrors.WithRFC9457("https://api.example.com/problems")))
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Equal(t, "application/problem+json; charset=utf-8", w.Header().Get("Content-Type"))
+		assert.NotContains(t, w.Body.String(), "secret", "panic value must not leak")
+
+		var body map[string]any
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Equal(t, "about:blank", body["type"])
+		assert.InDelta(t, http.StatusInternalServerError, body["status"], 0)
+		assert.Equal(t, "/orders/42", body["instance"])
+		assert.Equal(t, "req-123", body["request_id"])
+		assert.Equal(t, sc.TraceID().String(), body["trace_id"])
+	})
+
+	t.Run("JSON:API", func(t *testing.T) {
+		t.Parallel()
+		w := serve(t, riverrors.MustNew(riverrors.WithJSONAPI()))
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Equal(t, "application/vnd.api+json; charset=utf-8", w.Header().Get("Content-Type"))
+
+		var body struct {
+			Errors []struct {
+				Status string         `json:"status"`
+				Meta   map[string]any `json:"meta"`
+			} `json:"errors"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		require.Len(t, body.Errors, 1)
+		assert.Equal(t, "500", body.Errors[0].Status)
+		assert.Equal(t, map[string]any{"request_id": "req-123", "trace_id": sc.TraceID().String()}, body.Errors[0].Meta)
+	})
+
+	t.Run("XML", func(t *testing.T) {
+		t.Parallel()
+		w := serve(t, riverrors.MustNew(riverrors.WithXML("")))
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Equal(t, "application/problem+xml; charset=utf-8", w.Header().Get("Content-Type"))
+		assert.Contains(t, w.Body.String(), "<request_id>req-123</request_id>")
+	})
+
+	t.Run("nil restores default handler", func(t *testing.T) {
+		t.Parallel()
+		w := serve(t, nil)
+
+		var body map[string]any
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Equal(t, "INTERNAL_ERROR", body["code"])
+	})
+}
+
+func TestPanicError(t *testing.T) {
+	t.Parallel()
+
+	cause := errors.New("boom")
+	err := &PanicError{Value: cause}
+
+	assert.Equal(t, "Internal Server Error", err.Error())
+	assert.Equal(t, http.StatusInternalServerError, err.HTTPStatus())
+	require.ErrorIs(t, err, cause)
+	assert.Empty(t, err.Extensions())
+
+	err = &PanicError{Value: 42, RequestID: "req-1"}
+	require.EqualError(t, errors.Unwrap(err), "panic: 42")
+	assert.Equal(t, map[string]any{"request_id": "req-1"}, err.Extensions())
+}
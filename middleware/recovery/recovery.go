@@ -21,6 +21,7 @@ import (
 	"os"
 	"regexp"
 	"runtime/debug"
+	"slices"
 	"strings"
 
 	"go.opentelemetry.io/otel/attribute"
@@ -60,6 +61,10 @@ type config struct {
 	stackTrace  bool
 	stackSize   int
 	prettyStack *bool // nil = auto-detect, true/false = explicit
+
+	reporter      Reporter
+	redactHeaders []string
+	requestIDFunc func(c *router.Context) string
 }
 
 // defaultConfig returns the default configuration for recovery middleware.
@@ -70,6 +75,9 @@ func defaultConfig() *config {
 		stackTrace:  true,
 		stackSize:   4 << 10, // 4KB
 		prettyStack: nil,     // Auto-detect based on TTY
+
+		redactHeaders: slices.Clone(defaultRedactHeaders),
+		requestIDFunc: headerRequestID,
 	}
 }
 
@@ -134,6 +142,12 @@ func handlePanic(c *router.Context, cfg *config, err any) {
 	// Record to OpenTelemetry span if available
 	recordPanicToSpan(c, err)
 
+	// Capture the stack once for logging and reporting
+	var stack []byte
+	if cfg.stackTrace && (cfg.logger != nil || cfg.reporter != nil) {
+		stack = captureStack(cfg.stackSize)
+	}
+
 	// Log if logger is configured
 	if cfg.logger != nil {
 		// Log main error with structured fields
@@ -145,11 +159,15 @@ func handlePanic(c *router.Context, cfg *config, err any) {
 
 		// Print formatted stack trace
 		if cfg.stackTrace {
-			stack := captureStack(cfg.stackSize)
 			printStackTrace(cfg, stack)
 		}
 	}
 
+	// Hand the panic to the reporter, e.g. an error tracker
+	if cfg.reporter != nil {
+		report(c, cfg, err, stack)
+	}
+
 	// Send error response
 	if cfg.handler != nil {
 		cfg.handler(c, err)
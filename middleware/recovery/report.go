@@ -0,0 +1,148 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recovery
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"rivaas.dev/router"
+)
+
+// redacted replaces the values of sensitive headers in request snapshots.
+const redacted = "[REDACTED]"
+
+// defaultRedactHeaders are the headers redacted in request snapshots by
+// default.
+var defaultRedactHeaders = []string{
+	"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie", "X-Api-Key", "X-Auth-Token", "X-Csrf-Token",
+}
+
+// Reporter receives recovered panics, for example to forward them to an
+// error tracker such as Sentry. It is called synchronously before the error
+// response is written, so it should hand slow work off to a goroutine or a
+// buffered client. A panic in the reporter is recovered and logged.
+type Reporter func(ctx context.Context, report Report)
+
+// Report describes a recovered panic.
+type Report struct {
+	// Value is the value passed to panic.
+	Value any
+
+	// Stack is the stack trace of the panicking goroutine, up to the
+	// configured stack size. It is nil if stack traces are disabled.
+	Stack []byte
+
+	// Request is a snapshot of the request that caused the panic.
+	Request RequestSnapshot
+}
+
+// RequestSnapshot holds the parts of a request that are safe to report.
+// Sensitive headers are redacted, and bodies and query strings are omitted.
+type RequestSnapshot struct {
+	Method    string
+	Path      string
+	Route     string // Route pattern, e.g. "/users/:id"; may be empty
+	Host      string
+	ClientIP  string
+	Header    http.Header // Copy with sensitive values replaced by "[REDACTED]"
+	RequestID string      // May be empty
+	TraceID   string      // Of the active span; may be empty
+	SpanID    string      // Of the active span; may be empty
+}
+
+// Error returns the panic value as an error: the value itself if it is an
+// error, or else an error with its formatted text.
+func (r Report) Error() error {
+	return valueError(r.Value)
+}
+
+// valueError returns v itself if it is an error, or else an error with its
+// formatted text.
+func valueError(v any) error {
+	if err, ok := v.(error); ok {
+		return err
+	}
+
+	return fmt.Errorf("panic: %v", v)
+}
+
+// headerRequestID returns the X-Request-ID set by the requestid middleware
+// on the response, or else the one sent by the client.
+func headerRequestID(c *router.Context) string {
+	if id := c.Response.Header().Get("X-Request-ID"); id != "" {
+		return id
+	}
+
+	return c.Request.Header.Get("X-Request-ID")
+}
+
+// snapshot returns a sanitized snapshot of the request of c.
+func snapshot(c *router.Context, cfg *config) RequestSnapshot {
+	header := c.Request.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	for _, name := range cfg.redactHeaders {
+		key := http.CanonicalHeaderKey(name)
+		if _, ok := header[key]; ok {
+			header[key] = []string{redacted}
+		}
+	}
+
+	s := RequestSnapshot{
+		Method:   c.Request.Method,
+		Path:     c.Request.URL.Path,
+		Route:    c.RoutePattern(),
+		Host:     c.Request.Host,
+		ClientIP: c.ClientIP(),
+		Header:   header,
+	}
+	s.RequestID = requestID(c, cfg)
+	if sc := trace.SpanContextFromContext(c.RequestContext()); sc.IsValid() {
+		s.TraceID = sc.TraceID().String()
+		s.SpanID = sc.SpanID().String()
+	}
+
+	return s
+}
+
+// requestID returns the request ID of c, or "" if there is no request ID
+// function.
+func requestID(c *router.Context, cfg *config) string {
+	if cfg.requestIDFunc == nil {
+		return ""
+	}
+
+	return cfg.requestIDFunc(c)
+}
+
+// report calls the reporter, recovering from panics in it.
+func report(c *router.Context, cfg *config, err any, stack []byte) {
+	defer func() {
+		if reporterErr := recover(); reporterErr != nil && cfg.logger != nil {
+			cfg.logger.Error("panic in recovery reporter", "error", fmt.Sprintf("%v", reporterErr))
+		}
+	}()
+
+	cfg.reporter(c.RequestContext(), Report{
+		Value:   err,
+		Stack:   stack,
+		Request: snapshot(c, cfg),
+	})
+}
@@ -0,0 +1,138 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package recovery
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+
+	"rivaas.dev/router"
+)
+
+func TestRecovery_Reporter(t *testing.T) {
+	t.Parallel()
+	var reports []Report
+	r := router.MustNew()
+	r.Use(New(
+		WithoutLogging(),
+		WithReporter(func(_ context.Context, report Report) {
+			reports = append(reports, report)
+		}),
+		WithRedactHeaders("X-Session"),
+	))
+	r.Use(func(c *router.Context) {
+		// Stands in for the requestid middleware
+		c.Header("X-Request-ID", "req-123")
+		c.Next()
+	})
+	r.GET("/orders/:id", func(_ *router.Context) {
+		panic("boom")
+	})
+
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+		SpanID:     trace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+		TraceFlags: trace.FlagsSampled,
+	})
+	req := httptest.NewRequestWithContext(trace.ContextWithSpanContext(t.Context(), sc), http.MethodGet, "/orders/42?token=abc", nil)
+	req.RemoteAddr = "192.0.2.10:1234"
+	req.Header.Set("Authorization", "Bearer secret")
+	req.Header.Set("Cookie", "session=abc")
+	req.Header.Set("X-Session", "s-1")
+	req.Header.Set("Accept", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	require.Len(t, reports, 1)
+	report := reports[0]
+	assert.Equal(t, "boom", report.Value)
+	assert.EqualError(t, report.Error(), "panic: boom")
+	assert.Contains(t, string(report.Stack), "goroutine")
+
+	s := report.Request
+	assert.Equal(t, http.MethodGet, s.Method)
+	assert.Equal(t, "/orders/42", s.Path)
+	assert.Equal(t, "/orders/:id", s.Route)
+	assert.Equal(t, "192.0.2.10", s.ClientIP)
+	assert.Equal(t, "req-123", s.RequestID)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", s.TraceID)
+	assert.Equal(t, "00f067aa0ba902b7", s.SpanID)
+	assert.Equal(t, "[REDACTED]", s.Header.Get("Authorization"))
+	assert.Equal(t, "[REDACTED]", s.Header.Get("Cookie"))
+	assert.Equal(t, "[REDACTED]", s.Header.Get("X-Session"))
+	assert.Equal(t, "application/json", s.Header.Get("Accept"))
+	assert.Equal(t, "Bearer secret", req.Header.Get("Authorization"), "the request itself is not modified")
+}
+
+func TestRecovery_ReporterOptions(t *testing.T) {
+	t.Parallel()
+	var report Report
+	r := router.MustNew()
+	r.Use(New(
+		WithoutLogging(),
+		WithStackTrace(false),
+		WithRequestIDFunc(func(*router.Context) string { return "custom-id" }),
+		WithReporter(func(_ context.Context, rep Report) {
+			report = rep
+		}),
+	))
+	errBoom := errors.New("boom")
+	r.GET("/panic", func(_ *router.Context) {
+		panic(errBoom)
+	})
+
+	req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/panic", nil)
+	req.Header.Set("X-Request-ID", "client-id")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	require.ErrorIs(t, report.Error(), errBoom)
+	assert.Nil(t, report.Stack, "no stack trace when disabled")
+	assert.Equal(t, "custom-id", report.Request.RequestID)
+	assert.Empty(t, report.Request.TraceID)
+}
+
+func TestRecovery_ReporterPanics(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	r := router.MustNew()
+	r.Use(New(
+		WithLogger(slog.New(slog.NewJSONHandler(&buf, nil))),
+		WithStackTrace(false),
+		WithReporter(func(context.Context, Report) {
+			panic("reporter failed")
+		}),
+	))
+	r.GET("/panic", func(_ *router.Context) {
+		panic("boom")
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/panic", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code, "the error response is still written")
+	assert.Contains(t, buf.String(), "panic in recovery reporter")
+}
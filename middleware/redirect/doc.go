@@ -0,0 +1,69 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package redirect provides middleware that redirects requests according to
+// declarative rules: HTTPS enforcement, adding or removing "www.", host
+// canonicalization, and path redirects with placeholders.
+//
+// # Basic Usage
+//
+// Path redirects usually point away from URLs that no longer have a route,
+// so the rules must run before route matching. Wrap the router:
+//
+//	import "rivaas.dev/middleware/redirect"
+//
+//	r := router.MustNew()
+//	handler := redirect.Wrap(r, redirect.WithRules(
+//	    redirect.HTTPS(),
+//	    redirect.StripWWW(),
+//	    redirect.Path("/blog/:slug", "/posts/:slug"),
+//	))
+//	http.ListenAndServe(":8080", handler)
+//
+// [New] returns the same redirects as middleware, which only sees requests
+// to registered routes.
+//
+// # Rules
+//
+//   - [HTTPS]: http://example.com/ → https://example.com/
+//   - [AddWWW]: example.com → www.example.com
+//   - [StripWWW]: www.example.com → example.com
+//   - [Host]: example.net → example.com
+//   - [Path]: /blog/hello → /posts/hello
+//
+// Rules are applied in order, and a request that several rules match is
+// redirected once, to the final location. This avoids chains of redirects
+// such as http://www → https://www → https://.
+//
+// # Status Codes
+//
+// Redirects use 308 Permanent Redirect by default, which keeps the method
+// and body of the request. [WithStatus] sets another code per rule, such as
+// 301 for clients that don't support 308, or 302 and 307 for temporary
+// redirects. When several rules match, the first one sets the status.
+//
+// # Path Patterns
+//
+// Path patterns use the router's syntax. ":name" matches one path segment
+// and "*name" matches the rest of the path; the target uses the same
+// placeholders:
+//
+//	redirect.Path("/users/:id/profile", "/profiles/:id")
+//	redirect.Path("/docs/*page", "https://docs.example.com/*page")
+//
+// # Proxies
+//
+// Behind a proxy that terminates TLS, the X-Forwarded-Proto header tells
+// which scheme the client used, so HTTPS doesn't redirect in a loop.
+package redirect
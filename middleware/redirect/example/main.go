@@ -0,0 +1,49 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package main demonstrates how to use the redirect middleware
+// to move old URLs to new ones.
+package main
+
+import (
+	"log"
+	"net/http"
+
+	"rivaas.dev/middleware/redirect"
+	"rivaas.dev/router"
+)
+
+func main() {
+	r := router.MustNew()
+
+	r.GET("/posts/:slug", func(c *router.Context) {
+		if err := c.String(http.StatusOK, "Post: "+c.Param("slug")); err != nil {
+			log.Printf("write response: %v", err)
+		}
+	})
+
+	// Wrap the router, so paths without a route are redirected too.
+	// Add redirect.HTTPS() in production.
+	handler := redirect.Wrap(r, redirect.WithRules(
+		redirect.StripWWW(),
+		redirect.Path("/blog/:slug", "/posts/:slug"),
+		redirect.Path("/docs/*page", "https://rivaas.dev/docs/*page", redirect.WithStatus(http.StatusFound)),
+	))
+
+	log.Println("Server starting on :8080")
+	log.Println("  curl -i http://localhost:8080/blog/hello")
+	log.Println("  curl -i http://localhost:8080/docs/guides/router/")
+	log.Println("  curl -i -H 'Host: www.example.com' http://localhost:8080/posts/hello")
+	log.Fatal(http.ListenAndServe(":8080", handler))
+}
@@ -0,0 +1,50 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redirect
+
+// WithRules adds redirect rules. The rules are applied in order, and a
+// request matching several of them is redirected once, to the location
+// that all of them produce together: http://www.example.com/blog/hello can
+// go straight to https://example.com/posts/hello.
+//
+// Example:
+//
+//	redirect.New(redirect.WithRules(
+//	    redirect.HTTPS(),
+//	    redirect.StripWWW(),
+//	    redirect.Path("/blog/:slug", "/posts/:slug"),
+//	))
+func WithRules(rules ...*Rule) Option {
+	return func(cfg *config) {
+		cfg.rules = append(cfg.rules, rules...)
+	}
+}
+
+// WithSkipPaths sets paths that are never redirected, such as health checks
+// of a load balancer that probes over plain HTTP.
+//
+// Example:
+//
+//	redirect.New(
+//	    redirect.WithRules(redirect.HTTPS()),
+//	    redirect.WithSkipPaths("/health"),
+//	)
+func WithSkipPaths(paths ...string) Option {
+	return func(cfg *config) {
+		for _, path := range paths {
+			cfg.skipPaths[path] = true
+		}
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redirect
+
+import (
+	"fmt"
+	"strings"
+)
+
+// pattern is a compiled path pattern.
+type pattern struct {
+	// segments are the path segments; placeholders start with ':' or '*'
+	segments []string
+}
+
+// mustCompilePattern compiles a path pattern. It panics if the pattern
+// doesn't start with '/', or has a catch-all that isn't the last segment.
+func mustCompilePattern(p string) *pattern {
+	if !strings.HasPrefix(p, "/") {
+		panic(fmt.Sprintf("redirect: pattern %q must start with '/'", p))
+	}
+	segments := strings.Split(p[1:], "/")
+	for i, segment := range segments {
+		if (segment == ":" || segment == "*") || (segment != "" && segment[0] == '*' && i != len(segments)-1) {
+			panic(fmt.Sprintf("redirect: invalid pattern %q", p))
+		}
+	}
+
+	return &pattern{segments: segments}
+}
+
+// match reports whether path matches the pattern, and returns the values
+// of its placeholders.
+func (p *pattern) match(path string) (map[string]string, bool) {
+	if !strings.HasPrefix(path, "/") {
+		return nil, false
+	}
+	rest := path[1:]
+	var params map[string]string
+	for i, segment := range p.segments {
+		if strings.HasPrefix(segment, "*") {
+			params = setParam(params, segment[1:], rest)
+			return params, true
+		}
+		value, next, found := strings.Cut(rest, "/")
+		if found == (i == len(p.segments)-1) {
+			return nil, false
+		}
+		switch {
+		case strings.HasPrefix(segment, ":"):
+			if value == "" {
+				return nil, false
+			}
+			params = setParam(params, segment[1:], value)
+		case segment != value:
+			return nil, false
+		}
+		rest = next
+	}
+
+	return params, true
+}
+
+// has reports whether the pattern defines the placeholder name.
+func (p *pattern) has(name string) bool {
+	for _, segment := range p.segments {
+		if len(segment) > 1 && (segment[0] == ':' || segment[0] == '*') && segment[1:] == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// setParam sets params[name] to value, allocating params if needed.
+func setParam(params map[string]string, name, value string) map[string]string {
+	if params == nil {
+		params = make(map[string]string)
+	}
+	params[name] = value
+
+	return params
+}
+
+// placeholders returns the names of the placeholders in path.
+func placeholders(path string) []string {
+	var names []string
+	for segment := range strings.SplitSeq(path, "/") {
+		if len(segment) > 1 && (segment[0] == ':' || segment[0] == '*') {
+			names = append(names, segment[1:])
+		}
+	}
+
+	return names
+}
+
+// expand replaces the placeholders in path with their values.
+func expand(path string, params map[string]string) string {
+	if len(params) == 0 {
+		return path
+	}
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if len(segment) > 1 && (segment[0] == ':' || segment[0] == '*') {
+			segments[i] = params[segment[1:]]
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,178 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redirect
+
+import (
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"rivaas.dev/router"
+)
+
+// Option defines functional options for redirect middleware configuration.
+type Option func(*config)
+
+// config holds the configuration for the redirect middleware.
+type config struct {
+	// rules are applied in order to every request
+	rules []*Rule
+
+	// skipPaths are paths that are never redirected
+	skipPaths map[string]bool
+}
+
+// defaultConfig returns the default configuration for redirect middleware.
+func defaultConfig() *config {
+	return &config{
+		skipPaths: make(map[string]bool),
+	}
+}
+
+// location is the URL a request is redirected to, as rules rewrite it.
+type location struct {
+	scheme   string
+	host     string
+	path     string
+	rawQuery string
+}
+
+// New returns a middleware that redirects requests matching the configured
+// rules.
+//
+// The middleware runs after route matching, so it only sees requests to
+// registered routes. Use [Wrap] to also redirect paths that have no route,
+// such as old URLs of moved pages.
+//
+// Example:
+//
+//	r := router.MustNew()
+//	r.Use(redirect.New(redirect.WithRules(
+//	    redirect.HTTPS(),
+//	    redirect.StripWWW(),
+//	)))
+func New(opts ...Option) router.HandlerFunc {
+	cfg := newConfig(opts)
+
+	return func(c *router.Context) {
+		if status, target, ok := cfg.redirect(c.Request); ok {
+			c.Redirect(status, target)
+			c.Abort()
+
+			return
+		}
+		c.Next()
+	}
+}
+
+// Wrap wraps h with the redirect rules, so that they apply before route
+// matching. Unlike [New], it also redirects paths that have no route.
+//
+// Example:
+//
+//	r := router.MustNew()
+//	r.GET("/posts/:slug", showPost)
+//	handler := redirect.Wrap(r, redirect.WithRules(
+//	    redirect.HTTPS(),
+//	    redirect.Path("/blog/:slug", "/posts/:slug"),
+//	))
+//	http.ListenAndServe(":8080", handler)
+func Wrap(h http.Handler, opts ...Option) http.Handler {
+	cfg := newConfig(opts)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if status, target, ok := cfg.redirect(r); ok {
+			w.Header().Set("Location", target)
+			w.WriteHeader(status)
+
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// newConfig applies opts to the default configuration.
+func newConfig(opts []Option) *config {
+	cfg := defaultConfig()
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return cfg
+}
+
+// redirect applies the rules to req. It returns the status and location of
+// the redirect, and false if no rule applies.
+//
+// All rules are applied in order, so a request that several rules match is
+// redirected once, to its final location, with the status of the first rule
+// that matched.
+func (cfg *config) redirect(req *http.Request) (int, string, bool) {
+	if len(cfg.rules) == 0 || cfg.skipPaths[req.URL.Path] {
+		return 0, "", false
+	}
+
+	loc := &location{
+		scheme:   requestScheme(req),
+		host:     req.Host,
+		path:     req.URL.Path,
+		rawQuery: req.URL.RawQuery,
+	}
+	original := *loc
+
+	status := 0
+	for _, rule := range cfg.rules {
+		if rule.apply(loc) && status == 0 {
+			status = rule.status
+		}
+	}
+	if status == 0 || *loc == original {
+		return 0, "", false
+	}
+
+	target := &url.URL{Path: loc.path, RawQuery: loc.rawQuery}
+	if loc.scheme != original.scheme || loc.host != original.host {
+		target.Scheme = loc.scheme
+		target.Host = loc.host
+	}
+
+	return status, target.String(), true
+}
+
+// requestScheme returns the scheme the client used. Behind a proxy that
+// terminates TLS, this is the first value of X-Forwarded-Proto. Trusting
+// the header is safe here: a client that forges it only avoids its own
+// redirect.
+func requestScheme(req *http.Request) string {
+	if req.TLS != nil || req.URL.Scheme == "https" {
+		return "https"
+	}
+	proto, _, _ := strings.Cut(req.Header.Get("X-Forwarded-Proto"), ",")
+	if strings.EqualFold(strings.TrimSpace(proto), "https") {
+		return "https"
+	}
+
+	return "http"
+}
+
+// hostname returns host without its port.
+func hostname(host string) string {
+	if name, _, err := net.SplitHostPort(host); err == nil {
+		return name
+	}
+
+	return strings.TrimSuffix(strings.TrimPrefix(host, "["), "]")
+}
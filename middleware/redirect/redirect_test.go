@@ -0,0 +1,182 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package redirect
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"rivaas.dev/router"
+)
+
+// serve sends a GET request for target through h and returns the status
+// and Location header.
+func serve(h http.Handler, target string, prepare ...func(req *http.Request)) (int, string) {
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	for _, fn := range prepare {
+		fn(req)
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	return w.Code, w.Header().Get("Location")
+}
+
+var ok = http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+	w.WriteHeader(http.StatusOK)
+})
+
+func TestRedirect_Rules(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		rules      []*Rule
+		target     string
+		prepare    func(req *http.Request)
+		wantStatus int
+		wantTarget string
+	}{
+		{name: "https", rules: []*Rule{HTTPS()}, target: "http://example.com/a?b=c",
+			wantStatus: http.StatusPermanentRedirect, wantTarget: "https://example.com/a?b=c"},
+		{name: "https drops port 80", rules: []*Rule{HTTPS()}, target: "http://example.com:80/",
+			wantStatus: http.StatusPermanentRedirect, wantTarget: "https://example.com/"},
+		{name: "https already", rules: []*Rule{HTTPS()}, target: "http://example.com/",
+			prepare: func(req *http.Request) { req.TLS = &tls.ConnectionState{} }, wantStatus: http.StatusOK},
+		{name: "https behind proxy", rules: []*Rule{HTTPS()}, target: "http://example.com/",
+			prepare: func(req *http.Request) { req.Header.Set("X-Forwarded-Proto", "https") }, wantStatus: http.StatusOK},
+		{name: "add www", rules: []*Rule{AddWWW()}, target: "http://example.com/a",
+			wantStatus: http.StatusPermanentRedirect, wantTarget: "http://www.example.com/a"},
+		{name: "add www keeps www", rules: []*Rule{AddWWW()}, target: "http://WWW.example.com/a", wantStatus: http.StatusOK},
+		{name: "add www skips localhost", rules: []*Rule{AddWWW()}, target: "http://localhost:8080/", wantStatus: http.StatusOK},
+		{name: "add www skips ip", rules: []*Rule{AddWWW()}, target: "http://10.0.0.1/", wantStatus: http.StatusOK},
+		{name: "strip www", rules: []*Rule{StripWWW(WithStatus(http.StatusMovedPermanently))}, target: "http://www.example.com:8080/a",
+			wantStatus: http.StatusMovedPermanently, wantTarget: "http://example.com:8080/a"},
+		{name: "host", rules: []*Rule{Host("example.com")}, target: "http://example.net/a",
+			wantStatus: http.StatusPermanentRedirect, wantTarget: "http://example.com/a"},
+		{name: "host canonical", rules: []*Rule{Host("example.com")}, target: "http://Example.com:8080/a", wantStatus: http.StatusOK},
+		{name: "host alias", rules: []*Rule{Host("example.com", WithAliases("example.net"))}, target: "http://example.net/a",
+			wantStatus: http.StatusPermanentRedirect, wantTarget: "http://example.com/a"},
+		{name: "host not alias", rules: []*Rule{Host("example.com", WithAliases("example.net"))}, target: "http://example.org/a", wantStatus: http.StatusOK},
+		{name: "path", rules: []*Rule{Path("/blog/:slug", "/posts/:slug")}, target: "http://example.com/blog/hello?page=2",
+			wantStatus: http.StatusPermanentRedirect, wantTarget: "/posts/hello?page=2"},
+		{name: "path no match", rules: []*Rule{Path("/blog/:slug", "/posts/:slug")}, target: "http://example.com/blog/hello/comments", wantStatus: http.StatusOK},
+		{name: "path catch-all", rules: []*Rule{Path("/docs/*page", "https://docs.example.com/v2/*page")}, target: "http://example.com/docs/guides/intro",
+			wantStatus: http.StatusPermanentRedirect, wantTarget: "https://docs.example.com/v2/guides/intro"},
+		{name: "path query", rules: []*Rule{Path("/promo", "/sale?ref=promo", WithStatus(http.StatusFound))}, target: "http://example.com/promo?x=1",
+			wantStatus: http.StatusFound, wantTarget: "/sale?ref=promo"},
+		{name: "path escapes", rules: []*Rule{Path("/old/:name", "/new/:name")}, target: "http://example.com/old/a%20b",
+			wantStatus: http.StatusPermanentRedirect, wantTarget: "/new/a%20b"},
+		{name: "combined in one hop", rules: []*Rule{
+			HTTPS(),
+			StripWWW(WithStatus(http.StatusMovedPermanently)),
+			Path("/blog/:slug", "/posts/:slug"),
+		}, target: "http://www.example.com/blog/hello",
+			wantStatus: http.StatusPermanentRedirect, wantTarget: "https://example.com/posts/hello"},
+		{name: "status of first matching rule", rules: []*Rule{
+			HTTPS(),
+			StripWWW(WithStatus(http.StatusMovedPermanently)),
+		}, target: "http://www.example.com/",
+			prepare: func(req *http.Request) { req.TLS = &tls.ConnectionState{} }, wantStatus: http.StatusMovedPermanently, wantTarget: "https://example.com/"},
+		{name: "no-op rule", rules: []*Rule{Path("/a", "/a")}, target: "http://example.com/a", wantStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			h := Wrap(ok, WithRules(tt.rules...))
+			var prepare []func(req *http.Request)
+			if tt.prepare != nil {
+				prepare = append(prepare, tt.prepare)
+			}
+			status, location := serve(h, tt.target, prepare...)
+			assert.Equal(t, tt.wantStatus, status)
+			assert.Equal(t, tt.wantTarget, location)
+		})
+	}
+}
+
+func TestRedirect_Middleware(t *testing.T) {
+	t.Parallel()
+	r := router.MustNew()
+	r.Use(New(WithRules(HTTPS(), Path("/blog/:slug", "/posts/:slug"))))
+	r.GET("/posts/:slug", func(c *router.Context) {
+		//nolint:errcheck // Test handler
+		c.String(http.StatusOK, c.Param("slug"))
+	})
+
+	status, location := serve(r, "http://example.com/posts/hello")
+	assert.Equal(t, http.StatusPermanentRedirect, status)
+	assert.Equal(t, "https://example.com/posts/hello", location)
+
+	// Unmatched routes don't reach middleware
+	status, _ = serve(r, "https://example.com/blog/hello", func(req *http.Request) { req.TLS = &tls.ConnectionState{} })
+	assert.Equal(t, http.StatusNotFound, status)
+
+	status, location = serve(Wrap(r, WithRules(Path("/blog/:slug", "/posts/:slug"))), "https://example.com/blog/hello")
+	assert.Equal(t, http.StatusPermanentRedirect, status)
+	assert.Equal(t, "/posts/hello", location)
+}
+
+func TestRedirect_SkipPaths(t *testing.T) {
+	t.Parallel()
+	h := Wrap(ok, WithRules(HTTPS()), WithSkipPaths("/health"))
+
+	status, _ := serve(h, "http://example.com/health")
+	assert.Equal(t, http.StatusOK, status)
+
+	status, _ = serve(h, "http://example.com/")
+	assert.Equal(t, http.StatusPermanentRedirect, status)
+}
+
+func TestRedirect_InvalidRules(t *testing.T) {
+	t.Parallel()
+	assert.Panics(t, func() { Path("blog", "/posts") }, "relative pattern")
+	assert.Panics(t, func() { Path("/docs/*page/edit", "/edit/*page") }, "catch-all not last")
+	assert.Panics(t, func() { Path("/blog/:slug", "/posts/:id") }, "undefined placeholder")
+	assert.Panics(t, func() { WithStatus(http.StatusOK) })
+}
+
+func TestPattern_Match(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		pattern, path string
+		want          map[string]string
+		wantOK        bool
+	}{
+		{"/", "/", nil, true},
+		{"/", "/a", nil, false},
+		{"/a/b", "/a/b", nil, true},
+		{"/a/b", "/a/b/", nil, false},
+		{"/a/:id", "/a/1", map[string]string{"id": "1"}, true},
+		{"/a/:id", "/a/", nil, false},
+		{"/a/:id/b", "/a/1/b", map[string]string{"id": "1"}, true},
+		{"/a/*rest", "/a/", map[string]string{"rest": ""}, true},
+		{"/a/*rest", "/a/b/c", map[string]string{"rest": "b/c"}, true},
+		{"/a/*rest", "/a", nil, false},
+	}
+	for _, tt := range tests {
+		params, ok := mustCompilePattern(tt.pattern).match(tt.path)
+		assert.Equal(t, tt.wantOK, ok, "%s %s", tt.pattern, tt.path)
+		assert.Equal(t, tt.want, params, "%s %s", tt.pattern, tt.path)
+	}
+}
@@ -0,0 +1,242 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redirect
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Rule is a redirect rule, created with [HTTPS], [AddWWW], [StripWWW],
+// [Host], or [Path], and registered with [WithRules].
+type Rule struct {
+	// status is the status code of the redirect
+	status int
+
+	// aliases are the hosts a Host rule redirects
+	aliases []string
+
+	// apply rewrites the location and reports whether the rule matched
+	apply func(loc *location) bool
+}
+
+// RuleOption defines functional options for a redirect rule.
+type RuleOption func(*Rule)
+
+// WithStatus sets the status code of the redirect. Use 301 or 302 when
+// clients must switch POST requests to GET, and 307 for temporary
+// redirects that keep the method. WithStatus panics for other codes than
+// 301, 302, 303, 307, and 308.
+// Default: 308 Permanent Redirect
+//
+// Example:
+//
+//	redirect.Path("/old", "/new", redirect.WithStatus(http.StatusMovedPermanently))
+func WithStatus(code int) RuleOption {
+	switch code {
+	case http.StatusMovedPermanently, http.StatusFound, http.StatusSeeOther,
+		http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+	default:
+		panic(fmt.Sprintf("redirect: invalid redirect status %d", code))
+	}
+
+	return func(rule *Rule) {
+		rule.status = code
+	}
+}
+
+// WithAliases sets the hosts that a [Host] rule redirects to the canonical
+// host. Other rules ignore it.
+// Default: every host other than the canonical one
+//
+// Example:
+//
+//	redirect.Host("example.com", redirect.WithAliases("example.net"))
+func WithAliases(hosts ...string) RuleOption {
+	return func(rule *Rule) {
+		rule.aliases = append(rule.aliases, hosts...)
+	}
+}
+
+// newRule returns a rule with the default status and opts applied.
+func newRule(apply func(loc *location) bool, opts []RuleOption) *Rule {
+	rule := &Rule{status: http.StatusPermanentRedirect, apply: apply}
+	for _, opt := range opts {
+		opt(rule)
+	}
+
+	return rule
+}
+
+// HTTPS returns a rule that redirects plain HTTP requests to HTTPS. Behind
+// a proxy that terminates TLS, the X-Forwarded-Proto header tells which
+// scheme the client used.
+//
+// Combine it with the HSTS header of the security middleware, so that
+// browsers use HTTPS without the redirect.
+//
+// Example:
+//
+//	redirect.WithRules(redirect.HTTPS())
+func HTTPS(opts ...RuleOption) *Rule {
+	return newRule(func(loc *location) bool {
+		if loc.scheme == "https" {
+			return false
+		}
+		loc.scheme = "https"
+		loc.host = stripPort(loc.host, "80")
+
+		return true
+	}, opts)
+}
+
+// AddWWW returns a rule that redirects example.com to www.example.com. IP
+// addresses and single-label hosts such as localhost are not redirected.
+//
+// Example:
+//
+//	redirect.WithRules(redirect.AddWWW())
+func AddWWW(opts ...RuleOption) *Rule {
+	return newRule(func(loc *location) bool {
+		name := hostname(loc.host)
+		if hasWWW(name) || !strings.Contains(name, ".") || net.ParseIP(name) != nil {
+			return false
+		}
+		loc.host = "www." + loc.host
+
+		return true
+	}, opts)
+}
+
+// StripWWW returns a rule that redirects www.example.com to example.com.
+//
+// Example:
+//
+//	redirect.WithRules(redirect.StripWWW())
+func StripWWW(opts ...RuleOption) *Rule {
+	return newRule(func(loc *location) bool {
+		if !hasWWW(loc.host) {
+			return false
+		}
+		loc.host = loc.host[len("www."):]
+
+		return true
+	}, opts)
+}
+
+// Host returns a rule that redirects requests for other hosts to the
+// canonical host, such as old domains to the current one. Hosts are
+// compared without their port. Use [WithAliases] to only redirect some
+// hosts; otherwise every host other than canonical is redirected.
+//
+// Example:
+//
+//	redirect.Host("example.com", redirect.WithAliases("example.net", "example-old.com"))
+func Host(canonical string, opts ...RuleOption) *Rule {
+	canonicalName := hostname(canonical)
+	var rule *Rule
+	rule = newRule(func(loc *location) bool {
+		name := hostname(loc.host)
+		if strings.EqualFold(name, canonicalName) {
+			return false
+		}
+		if len(rule.aliases) > 0 && !containsFold(rule.aliases, name) {
+			return false
+		}
+		loc.host = canonical
+
+		return true
+	}, opts)
+
+	return rule
+}
+
+// Path returns a rule that redirects requests whose path matches pattern to
+// target. Patterns use the router's syntax: ":name" matches one path
+// segment and "*name" the rest of the path. The target can use the same
+// placeholders, and can be an absolute URL to redirect to another host.
+//
+// The query string of the request is kept, unless the target has its own.
+// Path panics if pattern is invalid or target uses a placeholder that
+// pattern doesn't define.
+//
+// Example:
+//
+//	redirect.WithRules(
+//	    redirect.Path("/blog/:slug", "/posts/:slug"),
+//	    redirect.Path("/docs/*page", "https://docs.example.com/*page"),
+//	    redirect.Path("/promo", "/sale", redirect.WithStatus(http.StatusFound)),
+//	)
+func Path(pattern, target string, opts ...RuleOption) *Rule {
+	from := mustCompilePattern(pattern)
+	to, err := url.Parse(target)
+	if err != nil {
+		panic(fmt.Sprintf("redirect: invalid target %q: %v", target, err))
+	}
+	for _, name := range placeholders(to.Path) {
+		if !from.has(name) {
+			panic(fmt.Sprintf("redirect: target %q uses placeholder %q that pattern %q doesn't define", target, name, pattern))
+		}
+	}
+
+	return newRule(func(loc *location) bool {
+		params, ok := from.match(loc.path)
+		if !ok {
+			return false
+		}
+		if to.Host != "" {
+			loc.scheme = to.Scheme
+			loc.host = to.Host
+		}
+		loc.path = expand(to.Path, params)
+		if to.RawQuery != "" {
+			loc.rawQuery = to.RawQuery
+		}
+
+		return true
+	}, opts)
+}
+
+// hasWWW reports whether host starts with "www.", ignoring case.
+func hasWWW(host string) bool {
+	return len(host) > len("www.") && strings.EqualFold(host[:len("www.")], "www.")
+}
+
+// stripPort removes port from host, if host has it.
+func stripPort(host, port string) string {
+	if name, p, err := net.SplitHostPort(host); err == nil && p == port {
+		if strings.Contains(name, ":") {
+			return "[" + name + "]"
+		}
+
+		return name
+	}
+
+	return host
+}
+
+// containsFold reports whether hosts contains host, ignoring case.
+func containsFold(hosts []string, host string) bool {
+	for _, h := range hosts {
+		if strings.EqualFold(hostname(h), host) {
+			return true
+		}
+	}
+
+	return false
+}
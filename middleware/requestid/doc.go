@@ -49,6 +49,10 @@
 //   - [WithULID]: Use ULID instead of UUID v7 for shorter IDs
 //   - [WithGenerator]: Custom function for generating request IDs
 //   - [WithAllowClientID]: Control whether to accept client-provided IDs
+//   - [WithMaxLength]: Maximum length of client-provided IDs (default: 128)
+//   - [WithClientIDValidator]: Accept only client IDs in a given format
+//   - [WithTraceID]: Use the W3C trace ID of the request as the request ID
+//   - [WithSpanAttribute]: Record the request ID on the active span
 //
 // # Using ULID
 //
@@ -67,6 +71,30 @@
 //	    // Use id for logging, tracing, etc.
 //	}
 //
+// # Client IDs
+//
+// Client-provided IDs are only used if they are at most [DefaultMaxLength]
+// bytes long and consist of letters, digits, and "-_.:+/=@". Other IDs, for
+// example with newlines or quotes that could forge log lines, are replaced by
+// a generated ID. [IsUUID], [IsULID], and [MatchPattern] restrict the format
+// further:
+//
+//	r.Use(requestid.New(requestid.WithClientIDValidator(requestid.IsUUID)))
+//
+// # Trace Correlation
+//
+// [WithTraceID] uses the trace ID of the active span, or of the incoming
+// traceparent header, as the request ID. [WithSpanAttribute] instead records
+// the request ID on the span. [TraceID] returns the trace ID of a request, so
+// both IDs can be logged together:
+//
+//	r.Use(requestid.New(requestid.WithTraceID()))
+//
+//	logger.Info("order created",
+//	    "request_id", requestid.Get(c),
+//	    "trace_id", requestid.TraceID(c),
+//	)
+//
 // # Response Headers
 //
 // The middleware automatically includes the request ID in response headers,
@@ -68,6 +68,11 @@ func WithGenerator(generator func() string) Option {
 // When false, always generate a new request ID regardless of client input.
 // Default: true
 //
+// Client IDs that are too long (see [WithMaxLength]), contain characters
+// other than letters, digits, and "-_.:+/=@", or fail the validator set with
+// [WithClientIDValidator] are replaced by a generated ID, so that clients
+// can't inject log-breaking or oversized values.
+//
 // Security note: Set to false if you need to ensure all request IDs are server-generated.
 //
 // Example:
@@ -78,3 +83,71 @@ func WithAllowClientID(allow bool) Option {
 		cfg.allowClientID = allow
 	}
 }
+
+// WithTraceID uses the W3C trace ID of the request as the request ID, so a
+// request ID reported by a client can be looked up directly in the tracing
+// backend. The trace ID is taken from the active span, which requires the
+// router to be wrapped in tracing (e.g. with tracing.Middleware or the app
+// package), or else from the incoming traceparent header. A client-provided request ID still takes precedence
+// if allowed; untraced requests get a generated ID.
+//
+// Trace IDs are 32 lowercase hex characters, e.g.
+// 4bf92f3577b34da6a3ce929d0e0e4736.
+//
+// Example:
+//
+//	r.Use(requestid.New(requestid.WithTraceID()))
+//	http.ListenAndServe(":8080", tracing.MustMiddleware(tracer)(r))
+func WithTraceID() Option {
+	return func(cfg *config) {
+		cfg.useTraceID = true
+	}
+}
+
+// WithSpanAttribute records the request ID on the active span as the
+// [SpanAttributeKey] attribute, so a trace can be found by request ID.
+// Requests without an active span are left as they are.
+//
+// Example:
+//
+//	r.Use(requestid.New(requestid.WithSpanAttribute()))
+//	http.ListenAndServe(":8080", tracing.MustMiddleware(tracer)(r))
+func WithSpanAttribute() Option {
+	return func(cfg *config) {
+		cfg.spanAttribute = true
+	}
+}
+
+// WithMaxLength sets the maximum length of client-provided request IDs.
+// Longer IDs are replaced by a generated ID. A value of 0 or less disables
+// the limit.
+// Default: 128 ([DefaultMaxLength])
+//
+// Example:
+//
+//	requestid.New(requestid.WithMaxLength(64))
+func WithMaxLength(n int) Option {
+	return func(cfg *config) {
+		cfg.maxLength = max(n, 0)
+	}
+}
+
+// WithClientIDValidator sets a function that accepts or rejects
+// client-provided request IDs, in addition to the length and character
+// checks. Rejected IDs are replaced by a generated ID. [IsUUID], [IsULID],
+// and [MatchPattern] provide common formats.
+//
+// Example:
+//
+//	// Accept only UUIDs from clients
+//	requestid.New(requestid.WithClientIDValidator(requestid.IsUUID))
+//
+//	// Accept IDs of the form "req-" followed by 16 hex digits
+//	requestid.New(requestid.WithClientIDValidator(
+//	    requestid.MatchPattern(`^req-[0-9a-f]{16}$`),
+//	))
+func WithClientIDValidator(fn func(id string) bool) Option {
+	return func(cfg *config) {
+		cfg.validator = fn
+	}
+}
@@ -41,6 +41,18 @@ type config struct {
 
 	// allowClientID allows using request IDs provided by clients
 	allowClientID bool
+
+	// maxLength is the maximum length of client-provided IDs; 0 disables the limit
+	maxLength int
+
+	// validator accepts or rejects client-provided IDs; nil accepts any safe ID
+	validator func(id string) bool
+
+	// useTraceID uses the W3C trace ID of the request as the request ID
+	useTraceID bool
+
+	// spanAttribute records the request ID on the active span
+	spanAttribute bool
 }
 
 // defaultConfig returns the default configuration for requestid middleware.
@@ -49,6 +61,7 @@ func defaultConfig() *config {
 		headerName:    "X-Request-ID",
 		generator:     generateUUIDv7,
 		allowClientID: true,
+		maxLength:     DefaultMaxLength,
 	}
 }
 
@@ -82,7 +95,8 @@ func generateULID() string {
 //
 // The middleware will:
 // 1. Check if a request ID is already present in the configured header
-// 2. Use the existing ID if allowed, or generate a new one
+// 2. Use the existing ID if allowed and valid, else the trace ID with [WithTraceID],
+// or generate a new one
 // 3. Set the request ID in the response header
 //
 // Basic usage (UUID v7 by default):
@@ -130,9 +144,18 @@ func New(opts ...Option) router.HandlerFunc {
 	return func(c *router.Context) {
 		var requestID string
 
-		// Check for existing request ID if allowed
+		// Check for existing request ID if allowed, dropping invalid ones
 		if cfg.allowClientID {
-			requestID = c.Request.Header.Get(cfg.headerName)
+			if id := c.Request.Header.Get(cfg.headerName); id != "" && cfg.validClientID(id) {
+				requestID = id
+			}
+		}
+
+		// Use the trace ID, so the request ID leads straight to the trace
+		if requestID == "" && cfg.useTraceID {
+			if id := traceID(c.Request); id.IsValid() {
+				requestID = id.String()
+			}
 		}
 
 		// Generate new ID if none exists or client IDs are disabled
@@ -147,6 +170,10 @@ func New(opts ...Option) router.HandlerFunc {
 		ctx := context.WithValue(c.Request.Context(), contextKey{}, requestID)
 		c.Request = c.Request.WithContext(ctx)
 
+		if cfg.spanAttribute {
+			setSpanAttribute(c.Request, requestID)
+		}
+
 		// Continue processing
 		c.Next()
 	}
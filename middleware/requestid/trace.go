@@ -0,0 +1,95 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package requestid
+
+import (
+	"net/http"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"rivaas.dev/router"
+)
+
+// SpanAttributeKey is the span attribute the request ID is recorded under
+// with [WithSpanAttribute].
+const SpanAttributeKey = "request.id"
+
+// traceparentHeader is the W3C Trace Context header.
+const traceparentHeader = "traceparent"
+
+// traceID returns the trace ID of the request: the one of the active span
+// in the request context or, without one, the one of the incoming
+// traceparent header. It returns an invalid trace ID if there is neither.
+func traceID(r *http.Request) trace.TraceID {
+	if sc := trace.SpanContextFromContext(r.Context()); sc.IsValid() {
+		return sc.TraceID()
+	}
+
+	return parseTraceparent(r.Header.Get(traceparentHeader))
+}
+
+// parseTraceparent returns the trace ID of a W3C traceparent header value,
+// "version-traceid-parentid-flags", or an invalid trace ID if the value is
+// malformed.
+func parseTraceparent(value string) trace.TraceID {
+	parts := strings.Split(strings.TrimSpace(value), "-")
+	// Future versions may append fields, version 00 must have exactly four
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" || (parts[0] == "00" && len(parts) != 4) {
+		return trace.TraceID{}
+	}
+	if len(parts[1]) != 32 || len(parts[2]) != 16 || len(parts[3]) != 2 {
+		return trace.TraceID{}
+	}
+	id, err := trace.TraceIDFromHex(parts[1])
+	if err != nil {
+		return trace.TraceID{}
+	}
+	if _, err = trace.SpanIDFromHex(parts[2]); err != nil {
+		return trace.TraceID{}
+	}
+
+	return id
+}
+
+// TraceID returns the W3C trace ID of the request as 32 lowercase hex
+// characters: the one of the active span or, without one, the one of the
+// incoming traceparent header. It returns an empty string if the request is
+// not traced.
+//
+// Logging it next to the request ID lets a request ID reported by a client
+// be looked up in the tracing backend:
+//
+//	logger.Info("order created",
+//	    "request_id", requestid.Get(c),
+//	    "trace_id", requestid.TraceID(c),
+//	)
+func TraceID(c *router.Context) string {
+	id := traceID(c.Request)
+	if !id.IsValid() {
+		return ""
+	}
+
+	return id.String()
+}
+
+// setSpanAttribute records requestID on the active span of r, if it is
+// recording.
+func setSpanAttribute(r *http.Request, requestID string) {
+	if span := trace.SpanFromContext(r.Context()); span.IsRecording() {
+		span.SetAttributes(attribute.String(SpanAttributeKey, requestID))
+	}
+}
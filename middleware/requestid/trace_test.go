@@ -0,0 +1,149 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package requestid
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"rivaas.dev/router"
+)
+
+const testTraceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+func TestParseTraceparent(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{value: testTraceparent, want: "4bf92f3577b34da6a3ce929d0e0e4736"},
+		{value: "01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra", want: "4bf92f3577b34da6a3ce929d0e0e4736"},
+		{value: "", want: ""},
+		{value: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra", want: ""},
+		{value: "ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", want: ""},
+		{value: "00-00000000000000000000000000000000-00f067aa0ba902b7-01", want: ""},
+		{value: "00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01", want: ""},
+		{value: "00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01", want: ""},
+		{value: "00-4bf92f3577b34da6a3ce929d0e0e47-00f067aa0ba902b7-01", want: ""},
+	}
+
+	for _, tt := range tests {
+		id := parseTraceparent(tt.value)
+		if tt.want == "" {
+			assert.False(t, id.IsValid(), tt.value)
+			continue
+		}
+		assert.Equal(t, tt.want, id.String(), tt.value)
+	}
+}
+
+// serveTraced serves req through r inside a span of a new recording tracer
+// and returns the ended spans.
+func serveTraced(t *testing.T, r http.Handler, req *http.Request) (*httptest.ResponseRecorder, tracetest.SpanStubs) {
+	t.Helper()
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	ctx, span := provider.Tracer("test").Start(req.Context(), "request")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req.WithContext(ctx))
+	span.End()
+
+	return w, exporter.GetSpans()
+}
+
+func TestRequestID_WithTraceID(t *testing.T) {
+	t.Parallel()
+	r := router.MustNew()
+	r.Use(New(WithTraceID()))
+	var requestID, traceID string
+	r.GET("/test", func(c *router.Context) {
+		requestID = Get(c)
+		traceID = TraceID(c)
+		c.NoContent()
+	})
+
+	t.Run("active span", func(t *testing.T) {
+		req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/test", nil)
+		w, spans := serveTraced(t, r, req)
+		require.Len(t, spans, 1)
+		want := spans[0].SpanContext.TraceID().String()
+		assert.Equal(t, want, w.Header().Get("X-Request-ID"))
+		assert.Equal(t, want, requestID)
+		assert.Equal(t, want, traceID)
+	})
+
+	t.Run("traceparent header", func(t *testing.T) {
+		req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/test", nil)
+		req.Header.Set("Traceparent", testTraceparent)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", w.Header().Get("X-Request-ID"))
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", traceID)
+	})
+
+	t.Run("client ID takes precedence", func(t *testing.T) {
+		req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/test", nil)
+		req.Header.Set("Traceparent", testTraceparent)
+		req.Header.Set("X-Request-ID", "ticket-4711")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		assert.Equal(t, "ticket-4711", w.Header().Get("X-Request-ID"))
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", traceID)
+	})
+
+	t.Run("untraced request", func(t *testing.T) {
+		req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/test", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		assert.Len(t, w.Header().Get("X-Request-ID"), 36, "falls back to UUID v7")
+		assert.Empty(t, traceID)
+	})
+}
+
+func TestRequestID_WithSpanAttribute(t *testing.T) {
+	t.Parallel()
+	r := router.MustNew()
+	r.Use(New(WithSpanAttribute()))
+	r.GET("/test", func(c *router.Context) {
+		c.NoContent()
+	})
+
+	req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/test", nil)
+	req.Header.Set("X-Request-ID", "ticket-4711")
+	_, spans := serveTraced(t, r, req)
+	require.Len(t, spans, 1)
+
+	var got string
+	for _, attr := range spans[0].Attributes {
+		if string(attr.Key) == SpanAttributeKey {
+			got = attr.Value.AsString()
+		}
+	}
+	assert.Equal(t, "ticket-4711", got)
+
+	// Without a span the request is served as usual
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/test", nil))
+	assert.Equal(t, http.StatusNoContent, w.Code)
+}
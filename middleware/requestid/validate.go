@@ -0,0 +1,88 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package requestid
+
+import (
+	"regexp"
+
+	"github.com/google/uuid"
+	"github.com/oklog/ulid/v2"
+)
+
+// DefaultMaxLength is the default maximum length of client-provided request
+// IDs.
+const DefaultMaxLength = 128
+
+// validClientID reports whether a client-provided request ID can be used:
+// it must not be longer than the configured maximum, must consist of safe
+// characters only, and must pass the configured validator.
+func (cfg *config) validClientID(id string) bool {
+	if cfg.maxLength > 0 && len(id) > cfg.maxLength {
+		return false
+	}
+	for i := range len(id) {
+		if !isSafeByte(id[i]) {
+			return false
+		}
+	}
+
+	return cfg.validator == nil || cfg.validator(id)
+}
+
+// isSafeByte reports whether b may appear in a request ID. The allowed set
+// covers UUIDs, ULIDs, trace IDs, and common ID schemes, and excludes
+// whitespace, control characters, quotes, and other characters that could
+// break log lines or headers.
+func isSafeByte(b byte) bool {
+	switch {
+	case 'a' <= b && b <= 'z', 'A' <= b && b <= 'Z', '0' <= b && b <= '9':
+		return true
+	case b == '-', b == '_', b == '.', b == ':', b == '+', b == '/', b == '=', b == '@':
+		return true
+	default:
+		return false
+	}
+}
+
+// IsUUID reports whether id is a UUID in the canonical
+// xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx form. It can be used with
+// [WithClientIDValidator].
+func IsUUID(id string) bool {
+	if len(id) != 36 {
+		return false
+	}
+	_, err := uuid.Parse(id)
+
+	return err == nil
+}
+
+// IsULID reports whether id is a ULID. It can be used with
+// [WithClientIDValidator].
+func IsULID(id string) bool {
+	_, err := ulid.ParseStrict(id)
+	return err == nil
+}
+
+// MatchPattern returns a validator for [WithClientIDValidator] that accepts
+// IDs matching the regular expression expr. Anchor the expression to match
+// the whole ID. It panics if expr does not compile.
+//
+// Example:
+//
+//	requestid.WithClientIDValidator(requestid.MatchPattern(`^req-[0-9a-f]{16}$`))
+func MatchPattern(expr string) func(id string) bool {
+	re := regexp.MustCompile(expr)
+	return re.MatchString
+}
@@ -0,0 +1,119 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package requestid
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"rivaas.dev/router"
+)
+
+func TestValidators(t *testing.T) {
+	t.Parallel()
+	assert.True(t, IsUUID("018f3e9a-1b2c-7def-8000-abcdef123456"))
+	assert.False(t, IsUUID("018f3e9a1b2c7def8000abcdef123456"), "only the canonical form")
+	assert.False(t, IsUUID("urn:uuid:018f3e9a-1b2c-7def-8000-abcdef123456"))
+	assert.False(t, IsUUID("not-a-uuid"))
+
+	assert.True(t, IsULID("01ARZ3NDEKTSV4RRFFQ69G5FAV"))
+	assert.False(t, IsULID("01ARZ3NDEKTSV4RRFFQ69G5FA"))
+	assert.False(t, IsULID("01ARZ3NDEKTSV4RRFFQ69G5FAU"), "U is not in the ULID alphabet")
+
+	match := MatchPattern(`^req-[0-9a-f]{4}$`)
+	assert.True(t, match("req-00ff"))
+	assert.False(t, match("req-00ff-x"))
+	assert.Panics(t, func() { MatchPattern(`(`) })
+}
+
+func TestRequestID_ClientIDValidation(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		opts     []Option
+		clientID string
+		accepted bool
+	}{
+		{name: "plain ID", clientID: "client-provided-id-123", accepted: true},
+		{name: "ID scheme characters", clientID: "svc:orders/abc+def=@x_1.2", accepted: true},
+		{name: "newline", clientID: "abc\n192.0.2.1 - - GET /admin", accepted: false},
+		{name: "quote", clientID: `abc" status=200`, accepted: false},
+		{name: "space", clientID: "abc def", accepted: false},
+		{name: "non-ASCII", clientID: "abcé", accepted: false},
+		{name: "at max length", clientID: strings.Repeat("a", DefaultMaxLength), accepted: true},
+		{name: "too long", clientID: strings.Repeat("a", DefaultMaxLength+1), accepted: false},
+		{
+			name:     "custom max length",
+			opts:     []Option{WithMaxLength(8)},
+			clientID: "123456789",
+			accepted: false,
+		},
+		{
+			name:     "no max length",
+			opts:     []Option{WithMaxLength(0)},
+			clientID: strings.Repeat("a", 1000),
+			accepted: true,
+		},
+		{
+			name:     "UUID validator accepts UUID",
+			opts:     []Option{WithClientIDValidator(IsUUID)},
+			clientID: "018f3e9a-1b2c-7def-8000-abcdef123456",
+			accepted: true,
+		},
+		{
+			name:     "UUID validator rejects other IDs",
+			opts:     []Option{WithClientIDValidator(IsUUID)},
+			clientID: "client-provided-id-123",
+			accepted: false,
+		},
+		{
+			name:     "validator does not bypass character checks",
+			opts:     []Option{WithClientIDValidator(func(string) bool { return true })},
+			clientID: "abc\r\ndef",
+			accepted: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := router.MustNew()
+			r.Use(New(tt.opts...))
+			var got string
+			r.GET("/test", func(c *router.Context) {
+				got = Get(c)
+				c.NoContent()
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/test", nil)
+			req.Header["X-Request-Id"] = []string{tt.clientID}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if tt.accepted {
+				assert.Equal(t, tt.clientID, got)
+			} else {
+				assert.True(t, IsUUID(got), "replaced by a generated ID, got %q", got)
+			}
+			assert.Equal(t, got, w.Header().Get("X-Request-ID"))
+		})
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package routemetrics provides middleware that records HTTP metrics per
+// route with a [metrics.Recorder], for services that use rivaas.dev/router
+// without rivaas.dev/app. Services built with app.WithMetrics already record
+// the same metrics and don't need this middleware.
+//
+// # Basic Usage
+//
+//	import (
+//	    "rivaas.dev/metrics"
+//	    "rivaas.dev/middleware/routemetrics"
+//	)
+//
+//	recorder := metrics.MustNew(
+//	    metrics.WithPrometheus(":9090", "/metrics"),
+//	    metrics.WithServiceName("orders-api"),
+//	)
+//	if err := recorder.Start(ctx); err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer recorder.Shutdown(context.Background())
+//
+//	r := router.MustNew()
+//	r.Use(routemetrics.New(recorder))
+//
+// # Metrics
+//
+// The middleware records the standard metrics of the recorder:
+// http_requests_total, http_errors_total, http_request_duration_seconds,
+// http_request_size_bytes, and http_response_size_bytes, labeled with the
+// route pattern (http.route), method (http.method), and status code
+// (http.status_code and http.status_class), and http_requests_active
+// without labels.
+//
+// # Cardinality
+//
+// Labels use the route pattern, such as "/users/:id", instead of the path,
+// so each route adds a bounded number of series. Requests that match no
+// route are not recorded, since the router doesn't run middleware for them.
+package routemetrics
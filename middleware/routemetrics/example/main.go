@@ -0,0 +1,76 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package main demonstrates how to use the routemetrics middleware
+// with a Prometheus metrics recorder.
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+
+	"rivaas.dev/metrics"
+	"rivaas.dev/middleware/routemetrics"
+	"rivaas.dev/router"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	recorder := metrics.MustNew(
+		metrics.WithPrometheus(":9090", "/metrics"),
+		metrics.WithServiceName("routemetrics-example"),
+	)
+	if err := recorder.Start(ctx); err != nil {
+		log.Fatal(err)
+	}
+	defer func() {
+		if err := recorder.Shutdown(context.Background()); err != nil {
+			log.Printf("shutdown metrics: %v", err)
+		}
+	}()
+
+	r := router.MustNew()
+	r.Use(routemetrics.New(recorder, routemetrics.WithSkipPaths("/health")))
+
+	r.GET("/users/:id", func(c *router.Context) {
+		if err := c.JSON(http.StatusOK, map[string]string{"id": c.Param("id")}); err != nil {
+			log.Printf("write response: %v", err)
+		}
+	})
+	r.GET("/health", func(c *router.Context) {
+		c.NoContent()
+	})
+
+	server := &http.Server{Addr: ":8080", Handler: r}
+	go func() {
+		<-ctx.Done()
+		if err := server.Shutdown(context.Background()); err != nil {
+			log.Printf("shutdown server: %v", err)
+		}
+	}()
+
+	log.Println("Server starting on :8080, metrics on :9090/metrics")
+	log.Println("  curl http://localhost:8080/users/1")
+	log.Println("  curl http://localhost:8080/users/2")
+	log.Println("  curl -s http://localhost:9090/metrics | grep http_requests_total")
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Fatal(err)
+	}
+}
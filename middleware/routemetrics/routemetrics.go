@@ -0,0 +1,113 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routemetrics
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"rivaas.dev/metrics"
+	"rivaas.dev/router"
+)
+
+// Option defines functional options for routemetrics middleware configuration.
+type Option func(*config)
+
+// config holds the configuration for the routemetrics middleware.
+type config struct {
+	// skipPaths are paths that are not measured
+	skipPaths map[string]bool
+}
+
+// defaultConfig returns the default configuration for routemetrics middleware.
+func defaultConfig() *config {
+	return &config{
+		skipPaths: make(map[string]bool),
+	}
+}
+
+// New returns a middleware that records the HTTP metrics of recorder for
+// each request, for services that use rivaas.dev/router without the
+// integrated instrumentation of rivaas.dev/app:
+//
+//   - http_requests_total and http_request_duration_seconds, by route
+//     pattern, method, and status code
+//   - http_requests_active, the requests in flight
+//   - http_response_size_bytes and http_request_size_bytes
+//
+// Requests are labeled with the route pattern, such as "/users/:id",
+// rather than the path, so the number of series stays bounded. Requests
+// that match no route don't run middleware and are not recorded.
+//
+// New panics if recorder is nil.
+//
+// Example:
+//
+//	recorder := metrics.MustNew(
+//	    metrics.WithPrometheus(":9090", "/metrics"),
+//	    metrics.WithServiceName("orders-api"),
+//	)
+//	r := router.MustNew()
+//	r.Use(routemetrics.New(recorder, routemetrics.WithSkipPaths("/livez", "/readyz")))
+func New(recorder *metrics.Recorder, opts ...Option) router.HandlerFunc {
+	if recorder == nil {
+		panic("routemetrics: recorder is nil")
+	}
+
+	cfg := defaultConfig()
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return func(c *router.Context) {
+		if !recorder.IsEnabled() || cfg.skipPaths[c.Request.URL.Path] {
+			c.Next()
+			return
+		}
+
+		ctx := c.Request.Context()
+		m := recorder.BeginRequest(ctx)
+		if m == nil {
+			// Not started yet, e.g. OTLP before Recorder.Start
+			c.Next()
+			return
+		}
+		m.AddAttributes(attribute.String("http.method", c.Request.Method))
+		if c.Request.ContentLength > 0 {
+			recorder.RecordRequestSize(ctx, m, c.Request.ContentLength)
+		}
+
+		info, ok := c.Response.(router.ResponseInfo)
+		if !ok {
+			wrapped := router.NewResponseWriterWrapper(c.Response)
+			c.Response = wrapped
+			info = wrapped
+		}
+
+		panicked := true
+		defer func() {
+			status := info.StatusCode()
+			if panicked {
+				// A recovery middleware registered later responds with 500
+				status = http.StatusInternalServerError
+			}
+			recorder.Finish(ctx, m, status, info.Size(), c.RoutePattern())
+		}()
+
+		c.Next()
+		panicked = false
+	}
+}
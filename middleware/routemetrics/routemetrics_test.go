@@ -0,0 +1,192 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package routemetrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"rivaas.dev/metrics"
+	"rivaas.dev/router"
+)
+
+// newRecorder returns a recorder whose metrics are read from the returned
+// reader.
+func newRecorder(t *testing.T) (*metrics.Recorder, *sdkmetric.ManualReader) {
+	t.Helper()
+	reader := sdkmetric.NewManualReader()
+	recorder, err := metrics.New(
+		metrics.WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))),
+		metrics.WithServiceName("test"),
+	)
+	require.NoError(t, err)
+
+	return recorder, reader
+}
+
+// getUser responds with the user ID.
+func getUser(c *router.Context) {
+	//nolint:errcheck // Test handler
+	c.String(http.StatusOK, "user "+c.Param("id"))
+}
+
+func serve(t *testing.T, r http.Handler, method, path, body string) {
+	t.Helper()
+	req := httptest.NewRequestWithContext(t.Context(), method, path, strings.NewReader(body))
+	r.ServeHTTP(httptest.NewRecorder(), req)
+}
+
+// collect returns the metrics of reader by name.
+func collect(t *testing.T, reader *sdkmetric.ManualReader) map[string]metricdata.Aggregation {
+	t.Helper()
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(t.Context(), &rm))
+
+	data := make(map[string]metricdata.Aggregation)
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			data[m.Name] = m.Data
+		}
+	}
+
+	return data
+}
+
+// key returns the route, method, and status of attrs.
+func key(attrs attribute.Set) string {
+	route, _ := attrs.Value("http.route")
+	method, _ := attrs.Value("http.method")
+	status, _ := attrs.Value("http.status_code")
+
+	return method.AsString() + " " + route.AsString() + " " + status.Emit()
+}
+
+func TestRouteMetrics_Requests(t *testing.T) {
+	t.Parallel()
+	recorder, reader := newRecorder(t)
+	r := router.MustNew()
+	r.Use(New(recorder))
+	r.GET("/users/:id", getUser)
+	r.POST("/users", func(c *router.Context) {
+		c.WriteErrorResponse(http.StatusBadRequest, "invalid user")
+	})
+
+	serve(t, r, http.MethodGet, "/users/1", "")
+	serve(t, r, http.MethodGet, "/users/2", "")
+	serve(t, r, http.MethodPost, "/users", `{"name":"x"}`)
+
+	data := collect(t, reader)
+	total, ok := data["http_requests_total"].(metricdata.Sum[int64])
+	require.True(t, ok)
+	counts := make(map[string]int64)
+	for _, dp := range total.DataPoints {
+		counts[key(dp.Attributes)] = dp.Value
+	}
+	assert.Equal(t, map[string]int64{
+		"GET /users/:id 200": 2,
+		"POST /users 400":    1,
+	}, counts, "labeled by route pattern, not path")
+
+	duration, ok := data["http_request_duration_seconds"].(metricdata.Histogram[float64])
+	require.True(t, ok)
+	assert.Len(t, duration.DataPoints, 2)
+
+	size, ok := data["http_response_size_bytes"].(metricdata.Histogram[int64])
+	require.True(t, ok)
+	for _, dp := range size.DataPoints {
+		if key(dp.Attributes) == "GET /users/:id 200" {
+			assert.Equal(t, int64(len("user 1")+len("user 2")), dp.Sum)
+		}
+	}
+
+	requestSize, ok := data["http_request_size_bytes"].(metricdata.Histogram[int64])
+	require.True(t, ok)
+	require.Len(t, requestSize.DataPoints, 1)
+	assert.Equal(t, int64(len(`{"name":"x"}`)), requestSize.DataPoints[0].Sum)
+
+	active, ok := data["http_requests_active"].(metricdata.Sum[int64])
+	require.True(t, ok)
+	require.Len(t, active.DataPoints, 1, "the method label doesn't split the gauge")
+	assert.Equal(t, int64(0), active.DataPoints[0].Value)
+}
+
+func TestRouteMetrics_InFlight(t *testing.T) {
+	t.Parallel()
+	recorder, reader := newRecorder(t)
+	r := router.MustNew()
+	r.Use(New(recorder))
+	var during int64
+	r.GET("/slow", func(c *router.Context) {
+		active, ok := collect(t, reader)["http_requests_active"].(metricdata.Sum[int64])
+		if ok && len(active.DataPoints) == 1 {
+			during = active.DataPoints[0].Value
+		}
+		c.NoContent()
+	})
+
+	serve(t, r, http.MethodGet, "/slow", "")
+	assert.Equal(t, int64(1), during)
+}
+
+func TestRouteMetrics_Panic(t *testing.T) {
+	t.Parallel()
+	recorder, reader := newRecorder(t)
+	r := router.MustNew()
+	r.Use(New(recorder))
+	r.GET("/panic", func(*router.Context) {
+		panic("boom")
+	})
+
+	assert.Panics(t, func() { serve(t, r, http.MethodGet, "/panic", "") })
+
+	total, ok := collect(t, reader)["http_requests_total"].(metricdata.Sum[int64])
+	require.True(t, ok)
+	require.Len(t, total.DataPoints, 1)
+	assert.Equal(t, "GET /panic 500", key(total.DataPoints[0].Attributes))
+}
+
+func TestRouteMetrics_SkipPaths(t *testing.T) {
+	t.Parallel()
+	recorder, reader := newRecorder(t)
+	r := router.MustNew()
+	r.Use(New(recorder, WithSkipPaths("/health")))
+	r.GET("/users/:id", getUser)
+	r.GET("/health", func(c *router.Context) {
+		c.NoContent()
+	})
+
+	serve(t, r, http.MethodGet, "/health", "")
+	serve(t, r, http.MethodGet, "/users/1", "")
+
+	total, ok := collect(t, reader)["http_requests_total"].(metricdata.Sum[int64])
+	require.True(t, ok)
+	require.Len(t, total.DataPoints, 1)
+	assert.Equal(t, "GET /users/:id 200", key(total.DataPoints[0].Attributes))
+}
+
+func TestNew_PanicsWithoutRecorder(t *testing.T) {
+	t.Parallel()
+	assert.Panics(t, func() { New(nil) })
+}
@@ -66,6 +66,41 @@
 //	    security.WithCSP("default-src 'self'; script-src 'self' 'unsafe-inline'"),
 //	))
 //
+// # CSP Nonces
+//
+// WithCSPNonce adds a fresh nonce to the policy on every request. Handlers
+// read it with Nonce and set it on inline script and style elements, which
+// allows a strict policy without 'unsafe-inline':
+//
+//	r.Use(security.New(security.WithCSPNonce()))
+//
+//	r.GET("/", func(c *router.Context) {
+//	    nonce := security.Nonce(c) // <script nonce="...">
+//	})
+//
+// # Cross-Origin Isolation
+//
+// WithCrossOriginOpenerPolicy, WithCrossOriginEmbedderPolicy, and
+// WithCrossOriginResourcePolicy set the COOP, COEP, and CORP headers.
+// WithCrossOriginIsolation sets COOP and COEP so that pages can use
+// SharedArrayBuffer:
+//
+//	r.Use(security.New(security.WithCrossOriginIsolation()))
+//
+// # Reporting
+//
+// WithReportingEndpoint announces named endpoints for browser reports in the
+// Reporting-Endpoints and Report-To headers; WithNEL enables Network Error
+// Logging. A ReportCollector receives CSP violation and NEL reports, logs
+// them, and counts them by type:
+//
+//	reports := security.NewReportCollector(logger)
+//	r.POST("/_reports", reports.Handler())
+//	r.Use(security.New(
+//	    security.WithReportingEndpoint("default", "https://example.com/_reports"),
+//	    security.WithContentSecurityPolicy("default-src 'self'; report-to default"),
+//	))
+//
 // # Security Best Practices
 //
 // This middleware implements security headers recommended by:
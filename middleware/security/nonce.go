@@ -0,0 +1,125 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package security
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"strings"
+
+	"rivaas.dev/router"
+)
+
+// nonceKey is the context key of the per-request CSP nonce.
+type nonceKey struct{}
+
+// noncePlaceholder marks where the nonce goes in a pre-built policy. It
+// cannot occur in a header value.
+const noncePlaceholder = "\x00nonce\x00"
+
+// nonceDirectives are the directives that receive the nonce. If one is
+// missing, the nonce goes into default-src, which it falls back to.
+var nonceDirectives = [...]string{"script-src", "style-src"}
+
+// Nonce returns the CSP nonce of the request, set by the middleware with
+// [WithCSPNonce]. Use it in the nonce attribute of inline script and style
+// elements. Returns an empty string if no nonce has been set.
+//
+// Example:
+//
+//	func page(c *router.Context) {
+//	    tmpl.Execute(c.Response, map[string]any{"Nonce": security.Nonce(c)})
+//	}
+//
+// In the template:
+//
+//	<script nonce="{{.Nonce}}">...</script>
+func Nonce(c *router.Context) string {
+	if nonce, ok := c.Request.Context().Value(nonceKey{}).(string); ok {
+		return nonce
+	}
+
+	return ""
+}
+
+// generateNonce returns 128 random bits, base64-encoded.
+func generateNonce() string {
+	var b [16]byte
+	//nolint:errcheck // crypto/rand.Read never returns an error
+	rand.Read(b[:])
+
+	return base64.StdEncoding.EncodeToString(b[:])
+}
+
+// withNonceContext stores nonce in the request context of c.
+func withNonceContext(c *router.Context, nonce string) {
+	ctx := context.WithValue(c.Request.Context(), nonceKey{}, nonce)
+	c.Request = c.Request.WithContext(ctx)
+}
+
+// nonceTemplate adds a nonce placeholder to the script-src and style-src
+// directives of policy, or to default-src for those that are missing. If the
+// policy has no default-src either, the missing directives are added with
+// only the nonce. Directives set to 'none' are left alone.
+func nonceTemplate(policy string) string {
+	source := "'nonce-" + noncePlaceholder + "'"
+
+	var directives []string
+	index := make(map[string]int)
+	for d := range strings.SplitSeq(policy, ";") {
+		d = strings.TrimSpace(d)
+		if d == "" {
+			continue
+		}
+		// Browsers use the first of duplicate directives
+		name, _, _ := strings.Cut(d, " ")
+		if _, dup := index[strings.ToLower(name)]; !dup {
+			index[strings.ToLower(name)] = len(directives)
+		}
+		directives = append(directives, d)
+	}
+
+	var missing []string
+	for _, name := range nonceDirectives {
+		if i, ok := index[name]; ok {
+			directives[i] = addSource(directives[i], source)
+		} else {
+			missing = append(missing, name)
+		}
+	}
+
+	if len(missing) > 0 {
+		if i, ok := index["default-src"]; ok {
+			directives[i] = addSource(directives[i], source)
+		} else {
+			for _, name := range missing {
+				directives = append(directives, name+" "+source)
+			}
+		}
+	}
+
+	return strings.Join(directives, "; ")
+}
+
+// addSource appends source to directive, unless the directive is 'none':
+// adding a source would allow what it blocks.
+func addSource(directive, source string) string {
+	if _, value, _ := strings.Cut(directive, " "); strings.EqualFold(strings.TrimSpace(value), "'none'") {
+		return directive
+	}
+
+	return directive + " " + source
+}
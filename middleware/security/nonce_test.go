@@ -0,0 +1,141 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package security
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"rivaas.dev/router"
+)
+
+func TestSecurity_CSPNonce(t *testing.T) {
+	t.Parallel()
+	r := router.MustNew()
+	r.Use(New(
+		WithContentSecurityPolicy("default-src 'self'; object-src 'none'"),
+		WithCSPNonce(),
+	))
+	r.GET("/test", func(c *router.Context) {
+		//nolint:errcheck // Test handler
+		c.String(http.StatusOK, Nonce(c))
+	})
+
+	serve := func() (nonce, csp string) {
+		req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/test", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+		return w.Body.String(), w.Header().Get("Content-Security-Policy")
+	}
+
+	nonce, csp := serve()
+	raw, err := base64.StdEncoding.DecodeString(nonce)
+	require.NoError(t, err)
+	assert.Len(t, raw, 16)
+	assert.Equal(t, "default-src 'self' 'nonce-"+nonce+"'; object-src 'none'", csp)
+
+	// A fresh nonce per request
+	other, _ := serve()
+	assert.NotEqual(t, nonce, other)
+}
+
+func TestSecurity_CSPNonceDisabled(t *testing.T) {
+	t.Parallel()
+	r := router.MustNew()
+	r.Use(New(WithCSPNonce(), WithContentSecurityPolicy("")))
+	r.GET("/test", func(c *router.Context) {
+		//nolint:errcheck // Test handler
+		c.String(http.StatusOK, Nonce(c))
+	})
+
+	req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/test", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Empty(t, w.Body.String())
+	assert.Empty(t, w.Header().Get("Content-Security-Policy"))
+}
+
+func TestNonce_WithoutMiddleware(t *testing.T) {
+	t.Parallel()
+	r := router.MustNew()
+	r.GET("/test", func(c *router.Context) {
+		//nolint:errcheck // Test handler
+		c.String(http.StatusOK, "["+Nonce(c)+"]")
+	})
+
+	req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/test", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, "[]", w.Body.String())
+}
+
+func TestNonceTemplate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		policy string
+		want   string
+	}{
+		{
+			name:   "default-src only",
+			policy: "default-src 'self'",
+			want:   "default-src 'self' 'nonce-N'",
+		},
+		{
+			name:   "script-src and style-src",
+			policy: "default-src 'self'; script-src 'self' https://cdn.example.com; style-src 'self'",
+			want:   "default-src 'self'; script-src 'self' https://cdn.example.com 'nonce-N'; style-src 'self' 'nonce-N'",
+		},
+		{
+			name:   "style-src falls back to default-src",
+			policy: "default-src 'self'; script-src 'self';",
+			want:   "default-src 'self' 'nonce-N'; script-src 'self' 'nonce-N'",
+		},
+		{
+			name:   "no fetch directives",
+			policy: "frame-ancestors 'none'",
+			want:   "frame-ancestors 'none'; script-src 'nonce-N'; style-src 'nonce-N'",
+		},
+		{
+			name:   "'none' is kept",
+			policy: "default-src 'self'; style-src 'none'",
+			want:   "default-src 'self' 'nonce-N'; style-src 'none'",
+		},
+		{
+			name:   "first duplicate wins",
+			policy: "script-src 'self'; script-src *; style-src 'self'",
+			want:   "script-src 'self' 'nonce-N'; script-src *; style-src 'self' 'nonce-N'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := strings.ReplaceAll(nonceTemplate(tt.policy), noncePlaceholder, "N")
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -86,6 +86,28 @@ func WithContentSecurityPolicy(policy string) Option {
 	}
 }
 
+// WithCSPNonce generates a random nonce for every request and adds it as a
+// 'nonce-...' source to the script-src and style-src directives of the
+// Content-Security-Policy, or to default-src for those the policy lacks.
+// Handlers read it with [Nonce] and put it on inline script and style
+// elements, so the policy does not need 'unsafe-inline'. Has no effect if
+// the Content-Security-Policy is disabled.
+//
+// Responses with a nonce must not be cached and served to other clients.
+//
+// Example:
+//
+//	security.New(
+//	    security.WithContentSecurityPolicy("default-src 'self'; object-src 'none'"),
+//	    security.WithCSPNonce(),
+//	)
+//	// Content-Security-Policy: default-src 'self' 'nonce-r4nd0m...'; object-src 'none'
+func WithCSPNonce() Option {
+	return func(cfg *config) {
+		cfg.cspNonce = true
+	}
+}
+
 // WithReferrerPolicy sets the Referrer-Policy header.
 // Controls how much referrer information is sent with requests.
 // Default: "strict-origin-when-cross-origin"
@@ -119,6 +141,77 @@ func WithPermissionsPolicy(policy string) Option {
 	}
 }
 
+// WithCrossOriginOpenerPolicy sets the Cross-Origin-Opener-Policy header.
+// It controls whether cross-origin windows opened by or opening the page
+// share its browsing context group.
+// Default: "" (not set)
+//
+// Common values:
+//   - "same-origin" - Isolate from all cross-origin windows
+//   - "same-origin-allow-popups" - Keep references to popups the page opens
+//   - "unsafe-none" - No isolation (browser default)
+//
+// Example:
+//
+//	security.New(security.WithCrossOriginOpenerPolicy("same-origin"))
+func WithCrossOriginOpenerPolicy(policy string) Option {
+	return func(cfg *config) {
+		cfg.crossOriginOpenerPolicy = policy
+	}
+}
+
+// WithCrossOriginEmbedderPolicy sets the Cross-Origin-Embedder-Policy header.
+// It controls which cross-origin resources the page may load.
+// Default: "" (not set)
+//
+// Common values:
+//   - "require-corp" - Only resources that opt in with CORP or CORS
+//   - "credentialless" - Load no-cors resources without credentials
+//   - "unsafe-none" - No restriction (browser default)
+//
+// Example:
+//
+//	security.New(security.WithCrossOriginEmbedderPolicy("require-corp"))
+func WithCrossOriginEmbedderPolicy(policy string) Option {
+	return func(cfg *config) {
+		cfg.crossOriginEmbedderPolicy = policy
+	}
+}
+
+// WithCrossOriginResourcePolicy sets the Cross-Origin-Resource-Policy header.
+// It controls which sites may load the response as a resource.
+// Default: "" (not set)
+//
+// Common values:
+//   - "same-origin" - Only the same origin
+//   - "same-site" - Any origin of the same site
+//   - "cross-origin" - Any origin (needed for assets embedded by isolated pages elsewhere)
+//
+// Example:
+//
+//	security.New(security.WithCrossOriginResourcePolicy("same-site"))
+func WithCrossOriginResourcePolicy(policy string) Option {
+	return func(cfg *config) {
+		cfg.crossOriginResourcePolicy = policy
+	}
+}
+
+// WithCrossOriginIsolation makes pages cross-origin isolated, which browsers
+// require for SharedArrayBuffer and high-resolution timers. It sets
+// Cross-Origin-Opener-Policy: same-origin and Cross-Origin-Embedder-Policy:
+// require-corp. Cross-origin resources the pages load must then be served
+// with CORS or a Cross-Origin-Resource-Policy header.
+//
+// Example:
+//
+//	security.New(security.WithCrossOriginIsolation())
+func WithCrossOriginIsolation() Option {
+	return func(cfg *config) {
+		cfg.crossOriginOpenerPolicy = "same-origin"
+		cfg.crossOriginEmbedderPolicy = "require-corp"
+	}
+}
+
 // WithCustomHeader adds a custom security header.
 //
 // Example:
@@ -147,6 +240,11 @@ func NoSecurityHeaders() Option {
 		cfg.contentSecurityPolicy = ""
 		cfg.referrerPolicy = ""
 		cfg.permissionsPolicy = ""
+		cfg.crossOriginOpenerPolicy = ""
+		cfg.crossOriginEmbedderPolicy = ""
+		cfg.crossOriginResourcePolicy = ""
+		cfg.reportingEndpoints = nil
+		cfg.nelReportTo = ""
 		cfg.customHeaders = make(map[string]string)
 	}
 }
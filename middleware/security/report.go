@@ -0,0 +1,248 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package security
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"net/http"
+	"strings"
+	"sync"
+
+	"rivaas.dev/router"
+)
+
+// reportToMaxAge is the max_age of the legacy Report-To header: 30 days.
+const reportToMaxAge = 2592000
+
+// maxReportSize bounds the body of a report request.
+const maxReportSize = 64 << 10
+
+// reportingEndpoint is a named endpoint that browsers send reports to.
+type reportingEndpoint struct {
+	name string
+	url  string
+}
+
+// WithReportingEndpoint adds a named endpoint that browsers send reports to.
+// It is announced in the Reporting-Endpoints header and in the legacy
+// Report-To header, which Network Error Logging still requires. Refer to the
+// name from a report-to directive in the Content-Security-Policy or from
+// [WithNEL]. Can be passed multiple times for different endpoints.
+//
+// Example:
+//
+//	security.New(
+//	    security.WithReportingEndpoint("csp", "https://example.com/_reports"),
+//	    security.WithContentSecurityPolicy("default-src 'self'; report-to csp"),
+//	)
+//	// Reporting-Endpoints: csp="https://example.com/_reports"
+func WithReportingEndpoint(name, url string) Option {
+	return func(cfg *config) {
+		cfg.reportingEndpoints = append(cfg.reportingEndpoints, reportingEndpoint{name: name, url: url})
+	}
+}
+
+// WithNEL enables Network Error Logging: browsers report failed requests
+// (DNS, TLS, connection errors) to the endpoint named reportTo, which must
+// be added with [WithReportingEndpoint]. maxAge is in seconds; 0 disables
+// NEL and tells browsers to forget an earlier policy.
+//
+// Example:
+//
+//	security.New(
+//	    security.WithReportingEndpoint("default", "https://example.com/_reports"),
+//	    security.WithNEL("default", 2592000), // 30 days
+//	)
+func WithNEL(reportTo string, maxAge int) Option {
+	return func(cfg *config) {
+		cfg.nelReportTo = reportTo
+		cfg.nelMaxAge = maxAge
+	}
+}
+
+// buildReportingHeaders returns the values of the Reporting-Endpoints and
+// Report-To headers for endpoints.
+func buildReportingHeaders(endpoints []reportingEndpoint) (reportingEndpoints, reportTo string) {
+	if len(endpoints) == 0 {
+		return "", ""
+	}
+
+	dict := make([]string, len(endpoints))
+	groups := make([]string, len(endpoints))
+	for i, e := range endpoints {
+		// Structured field string: only backslash and quote need escaping
+		quoted := strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(e.url)
+		dict[i] = e.name + `="` + quoted + `"`
+
+		//nolint:errchkjson // Encoding strings and integers cannot fail
+		group, _ := json.Marshal(map[string]any{
+			"group":     e.name,
+			"max_age":   reportToMaxAge,
+			"endpoints": []map[string]string{{"url": e.url}},
+		})
+		groups[i] = string(group)
+	}
+
+	return strings.Join(dict, ", "), strings.Join(groups, ", ")
+}
+
+// buildNELHeader returns the value of the NEL header.
+func buildNELHeader(reportTo string, maxAge int) string {
+	if reportTo == "" {
+		return ""
+	}
+
+	return fmt.Sprintf(`{"report_to":%q,"max_age":%d}`, reportTo, max(maxAge, 0))
+}
+
+// ReportStats are the counters of a [ReportCollector].
+type ReportStats struct {
+	Reports map[string]uint64 `json:"reports"` // Reports received by type, e.g. "csp-violation"
+	Invalid uint64            `json:"invalid"` // Requests that could not be parsed
+}
+
+// ReportCollector receives reports that browsers send to reporting
+// endpoints, such as CSP violations and network errors, logs them, and
+// counts them by type. It is safe for concurrent use.
+type ReportCollector struct {
+	logger *slog.Logger
+
+	mu      sync.Mutex
+	reports map[string]uint64
+	invalid uint64
+}
+
+// NewReportCollector creates a collector that logs reports with logger at
+// warn level. A nil logger uses slog.Default().
+//
+// Example:
+//
+//	reports := security.NewReportCollector(logger)
+//	r.POST("/_reports", reports.Handler())
+//	r.Use(security.New(
+//	    security.WithReportingEndpoint("default", "https://example.com/_reports"),
+//	    security.WithContentSecurityPolicy("default-src 'self'; report-to default"),
+//	    security.WithNEL("default", 2592000),
+//	))
+func NewReportCollector(logger *slog.Logger) *ReportCollector {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &ReportCollector{
+		logger:  logger,
+		reports: make(map[string]uint64),
+	}
+}
+
+// report is a single report in the Reporting API format.
+type report struct {
+	Type      string         `json:"type"`
+	Age       int64          `json:"age"`
+	URL       string         `json:"url"`
+	UserAgent string         `json:"user_agent"`
+	Body      map[string]any `json:"body"`
+}
+
+// Handler returns the handler for the reporting endpoint. Register it for
+// POST. It accepts the Reporting API format (application/reports+json) and
+// the legacy CSP report-uri format (application/csp-report) and responds
+// with 204 No Content, or 400 Bad Request if the body cannot be parsed.
+func (rc *ReportCollector) Handler() router.HandlerFunc {
+	return func(c *router.Context) {
+		data, err := io.ReadAll(io.LimitReader(c.Request.Body, maxReportSize))
+		if err != nil {
+			rc.countInvalid()
+			c.WriteErrorResponse(http.StatusBadRequest, "invalid report")
+
+			return
+		}
+
+		reports, err := parseReports(data)
+		if err != nil {
+			rc.countInvalid()
+			c.WriteErrorResponse(http.StatusBadRequest, "invalid report")
+
+			return
+		}
+
+		for _, r := range reports {
+			rc.record(r)
+		}
+		c.NoContent()
+	}
+}
+
+// Stats returns a snapshot of the counters.
+func (rc *ReportCollector) Stats() ReportStats {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	return ReportStats{Reports: maps.Clone(rc.reports), Invalid: rc.invalid}
+}
+
+// record logs r and counts it.
+func (rc *ReportCollector) record(r report) {
+	rc.mu.Lock()
+	rc.reports[r.Type]++
+	rc.mu.Unlock()
+
+	rc.logger.Warn("browser report",
+		"type", r.Type,
+		"url", r.URL,
+		"age_ms", r.Age,
+		"user_agent", r.UserAgent,
+		"body", r.Body,
+	)
+}
+
+// countInvalid counts a request that could not be parsed.
+func (rc *ReportCollector) countInvalid() {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.invalid++
+}
+
+// parseReports parses a list of reports in the Reporting API format or a
+// single legacy CSP report.
+func parseReports(data []byte) ([]report, error) {
+	var reports []report
+	if err := json.Unmarshal(data, &reports); err == nil {
+		for _, r := range reports {
+			if r.Type == "" {
+				return nil, errors.New("report without type")
+			}
+		}
+		return reports, nil
+	}
+
+	var legacy struct {
+		CSPReport map[string]any `json:"csp-report"`
+	}
+	if err := json.Unmarshal(data, &legacy); err != nil {
+		return nil, err
+	}
+	if legacy.CSPReport == nil {
+		return nil, errors.New("unknown report format")
+	}
+
+	documentURI, _ := legacy.CSPReport["document-uri"].(string)
+	return []report{{Type: "csp-violation", URL: documentURI, Body: legacy.CSPReport}}, nil
+}
@@ -0,0 +1,133 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package security
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"rivaas.dev/router"
+)
+
+func TestSecurity_ReportingHeaders(t *testing.T) {
+	t.Parallel()
+	r := router.MustNew()
+	r.Use(New(
+		WithReportingEndpoint("csp", "https://example.com/_reports"),
+		WithReportingEndpoint("default", "https://reports.example.com/nel"),
+		WithNEL("default", 86400),
+	))
+	r.GET("/test", func(c *router.Context) {
+		//nolint:errcheck // Test handler
+		c.String(http.StatusOK, "ok")
+	})
+
+	req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/test", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t,
+		`csp="https://example.com/_reports", default="https://reports.example.com/nel"`,
+		w.Header().Get("Reporting-Endpoints"))
+	assert.Equal(t,
+		`{"endpoints":[{"url":"https://example.com/_reports"}],"group":"csp","max_age":2592000}, `+
+			`{"endpoints":[{"url":"https://reports.example.com/nel"}],"group":"default","max_age":2592000}`,
+		w.Header().Get("Report-To"))
+	assert.JSONEq(t, `{"report_to":"default","max_age":86400}`, w.Header().Get("NEL"))
+}
+
+func TestSecurity_NoReportingHeaders(t *testing.T) {
+	t.Parallel()
+	r := router.MustNew()
+	r.Use(New(
+		WithReportingEndpoint("csp", "https://example.com/_reports"),
+		WithNEL("csp", 86400),
+		NoSecurityHeaders(),
+	))
+	r.GET("/test", func(c *router.Context) {
+		//nolint:errcheck // Test handler
+		c.String(http.StatusOK, "ok")
+	})
+
+	req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/test", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Empty(t, w.Header().Get("Reporting-Endpoints"))
+	assert.Empty(t, w.Header().Get("Report-To"))
+	assert.Empty(t, w.Header().Get("NEL"))
+}
+
+func TestReportCollector(t *testing.T) {
+	t.Parallel()
+	var logs bytes.Buffer
+	reports := NewReportCollector(slog.New(slog.NewJSONHandler(&logs, nil)))
+
+	r := router.MustNew()
+	r.POST("/_reports", reports.Handler())
+
+	post := func(contentType, body string) int {
+		req := httptest.NewRequestWithContext(t.Context(), http.MethodPost, "/_reports", strings.NewReader(body))
+		req.Header.Set("Content-Type", contentType)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	// Reporting API: a batch of reports
+	assert.Equal(t, http.StatusNoContent, post("application/reports+json", `[
+		{"type":"csp-violation","age":10,"url":"https://example.com/page","user_agent":"test",
+		 "body":{"blockedURL":"https://evil.example.com/x.js","effectiveDirective":"script-src-elem"}},
+		{"type":"network-error","age":20,"url":"https://example.com/","user_agent":"test",
+		 "body":{"type":"tcp.timed_out","phase":"connection"}}
+	]`))
+
+	// Legacy report-uri format
+	assert.Equal(t, http.StatusNoContent, post("application/csp-report", `{"csp-report":{
+		"document-uri":"https://example.com/old","violated-directive":"script-src"}}`))
+
+	// Invalid bodies
+	assert.Equal(t, http.StatusBadRequest, post("application/reports+json", `not json`))
+	assert.Equal(t, http.StatusBadRequest, post("application/json", `{"other":{}}`))
+	assert.Equal(t, http.StatusBadRequest, post("application/reports+json", `[{"url":"x"}]`))
+
+	assert.Equal(t, ReportStats{
+		Reports: map[string]uint64{"csp-violation": 2, "network-error": 1},
+		Invalid: 3,
+	}, reports.Stats())
+
+	// One structured log line per report
+	lines := strings.Split(strings.TrimSpace(logs.String()), "\n")
+	require.Len(t, lines, 3)
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &entry))
+	assert.Equal(t, "browser report", entry["msg"])
+	assert.Equal(t, "csp-violation", entry["type"])
+	assert.Equal(t, "https://example.com/page", entry["url"])
+	assert.Equal(t, "script-src-elem", entry["body"].(map[string]any)["effectiveDirective"])
+
+	require.NoError(t, json.Unmarshal([]byte(lines[2]), &entry))
+	assert.Equal(t, "https://example.com/old", entry["url"])
+}
@@ -16,6 +16,7 @@ package security
 
 import (
 	"fmt"
+	"strings"
 
 	"rivaas.dev/router"
 )
@@ -42,12 +43,31 @@ type config struct {
 	// contentSecurityPolicy sets CSP header
 	contentSecurityPolicy string
 
+	// cspNonce adds a per-request nonce to the CSP header
+	cspNonce bool
+
 	// referrerPolicy sets Referrer-Policy header
 	referrerPolicy string
 
 	// permissionsPolicy sets Permissions-Policy header
 	permissionsPolicy string
 
+	// crossOriginOpenerPolicy sets Cross-Origin-Opener-Policy header
+	crossOriginOpenerPolicy string
+
+	// crossOriginEmbedderPolicy sets Cross-Origin-Embedder-Policy header
+	crossOriginEmbedderPolicy string
+
+	// crossOriginResourcePolicy sets Cross-Origin-Resource-Policy header
+	crossOriginResourcePolicy string
+
+	// reportingEndpoints are announced in Reporting-Endpoints and Report-To
+	reportingEndpoints []reportingEndpoint
+
+	// nelReportTo and nelMaxAge configure the NEL header
+	nelReportTo string
+	nelMaxAge   int
+
 	// customHeaders are additional custom headers to set
 	customHeaders map[string]string
 }
@@ -98,6 +118,14 @@ func defaultConfig() *config {
 //	    security.WithContentSecurityPolicy("default-src 'self' 'unsafe-inline' 'unsafe-eval'"),
 //	))
 //
+// Strict CSP with a nonce for inline scripts and styles:
+//
+//	r.Use(security.New(
+//	    security.WithContentSecurityPolicy("default-src 'self'; object-src 'none'"),
+//	    security.WithCSPNonce(),
+//	))
+//	// In handlers: <script nonce="{{.Nonce}}"> with security.Nonce(c)
+//
 // Disable HSTS (useful in development):
 //
 //	r.Use(security.New(
@@ -122,6 +150,16 @@ func New(opts ...Option) router.HandlerFunc {
 		}
 	}
 
+	// Pre-build reporting headers
+	reportingEndpointsHeader, reportToHeader := buildReportingHeaders(cfg.reportingEndpoints)
+	nelHeader := buildNELHeader(cfg.nelReportTo, cfg.nelMaxAge)
+
+	// Pre-build CSP with a placeholder for the nonce
+	var cspTemplate string
+	if cfg.cspNonce && cfg.contentSecurityPolicy != "" {
+		cspTemplate = nonceTemplate(cfg.contentSecurityPolicy)
+	}
+
 	return func(c *router.Context) {
 		// Set X-Frame-Options
 		if cfg.frameOptions != "" {
@@ -144,7 +182,11 @@ func New(opts ...Option) router.HandlerFunc {
 		}
 
 		// Set Content-Security-Policy
-		if cfg.contentSecurityPolicy != "" {
+		if cspTemplate != "" {
+			nonce := generateNonce()
+			withNonceContext(c, nonce)
+			c.Response.Header().Set("Content-Security-Policy", strings.ReplaceAll(cspTemplate, noncePlaceholder, nonce))
+		} else if cfg.contentSecurityPolicy != "" {
 			c.Response.Header().Set("Content-Security-Policy", cfg.contentSecurityPolicy)
 		}
 
@@ -158,6 +200,26 @@ func New(opts ...Option) router.HandlerFunc {
 			c.Response.Header().Set("Permissions-Policy", cfg.permissionsPolicy)
 		}
 
+		// Set cross-origin isolation headers
+		if cfg.crossOriginOpenerPolicy != "" {
+			c.Response.Header().Set("Cross-Origin-Opener-Policy", cfg.crossOriginOpenerPolicy)
+		}
+		if cfg.crossOriginEmbedderPolicy != "" {
+			c.Response.Header().Set("Cross-Origin-Embedder-Policy", cfg.crossOriginEmbedderPolicy)
+		}
+		if cfg.crossOriginResourcePolicy != "" {
+			c.Response.Header().Set("Cross-Origin-Resource-Policy", cfg.crossOriginResourcePolicy)
+		}
+
+		// Set reporting headers
+		if reportingEndpointsHeader != "" {
+			c.Response.Header().Set("Reporting-Endpoints", reportingEndpointsHeader)
+			c.Response.Header().Set("Report-To", reportToHeader)
+		}
+		if nelHeader != "" {
+			c.Response.Header().Set("NEL", nelHeader)
+		}
+
 		// Set custom headers
 		for name, value := range cfg.customHeaders {
 			c.Response.Header().Set(name, value)
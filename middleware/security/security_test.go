@@ -417,3 +417,65 @@ func TestSecurity_EmptyOptions(t *testing.T) {
 		})
 	}
 }
+
+func TestSecurity_CrossOriginPolicies(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name         string
+		opts         []Option
+		expectedCOOP string
+		expectedCOEP string
+		expectedCORP string
+	}{
+		{
+			name: "not set by default",
+		},
+		{
+			name: "individual policies",
+			opts: []Option{
+				WithCrossOriginOpenerPolicy("same-origin-allow-popups"),
+				WithCrossOriginEmbedderPolicy("credentialless"),
+				WithCrossOriginResourcePolicy("same-site"),
+			},
+			expectedCOOP: "same-origin-allow-popups",
+			expectedCOEP: "credentialless",
+			expectedCORP: "same-site",
+		},
+		{
+			name:         "cross-origin isolation",
+			opts:         []Option{WithCrossOriginIsolation()},
+			expectedCOOP: "same-origin",
+			expectedCOEP: "require-corp",
+		},
+		{
+			name:         "isolation with override",
+			opts:         []Option{WithCrossOriginIsolation(), WithCrossOriginEmbedderPolicy("credentialless")},
+			expectedCOOP: "same-origin",
+			expectedCOEP: "credentialless",
+		},
+		{
+			name: "disabled by NoSecurityHeaders",
+			opts: []Option{WithCrossOriginIsolation(), WithCrossOriginResourcePolicy("same-origin"), NoSecurityHeaders()},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := router.MustNew()
+			r.Use(New(tt.opts...))
+			r.GET("/test", func(c *router.Context) {
+				//nolint:errcheck // Test handler
+				c.String(http.StatusOK, "ok")
+			})
+
+			req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/test", nil)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedCOOP, w.Header().Get("Cross-Origin-Opener-Policy"))
+			assert.Equal(t, tt.expectedCOEP, w.Header().Get("Cross-Origin-Embedder-Policy"))
+			assert.Equal(t, tt.expectedCORP, w.Header().Get("Cross-Origin-Resource-Policy"))
+		})
+	}
+}
@@ -0,0 +1,193 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"time"
+)
+
+// ErrCookieTooLarge is returned by a [CookieStore] when a session does not
+// fit in a cookie. Keep large data in a server-side store instead.
+var ErrCookieTooLarge = errors.New("session: session too large for a cookie")
+
+// maxCookieValue is the largest cookie value a CookieStore produces, leaving
+// room for the name and attributes within the 4096 bytes browsers accept.
+const maxCookieValue = 3800
+
+// CookieStore is a [Store] that keeps sessions in the cookie itself, so no
+// server-side state is needed. Sessions are either signed, so clients can
+// read but not modify them, or encrypted, so clients can do neither.
+//
+// Sessions can't be revoked before they expire: Destroy only removes the
+// cookie from the browser. Use a server-side store if that matters.
+type CookieStore struct {
+	// seal encodes data into a cookie value with the first key
+	seal func(data []byte) string
+
+	// open decodes a cookie value, trying each key in turn
+	open func(value string) ([]byte, bool)
+}
+
+// NewSignedCookieStore returns a cookie store that signs sessions with
+// HMAC-SHA256. Clients can read the session data, so don't put secrets in
+// it. Each key must be at least 32 bytes long. New sessions are signed with
+// the first key; sessions signed with any of the keys are accepted, which
+// allows rotating keys. It panics if no valid key is given.
+//
+// Example:
+//
+//	store := session.NewSignedCookieStore([]byte(os.Getenv("SESSION_KEY")))
+func NewSignedCookieStore(keys ...[]byte) *CookieStore {
+	if len(keys) == 0 {
+		panic("session: at least one signing key is required")
+	}
+	for _, key := range keys {
+		if len(key) < 32 {
+			panic("session: signing keys must be at least 32 bytes long")
+		}
+	}
+
+	sign := func(key, payload []byte) []byte {
+		mac := hmac.New(sha256.New, key)
+		mac.Write(payload)
+		return mac.Sum(nil)
+	}
+
+	return &CookieStore{
+		seal: func(data []byte) string {
+			return encode(data) + "." + encode(sign(keys[0], data))
+		},
+		open: func(value string) ([]byte, bool) {
+			payload, signature, ok := strings.Cut(value, ".")
+			if !ok {
+				return nil, false
+			}
+			data, err := decode(payload)
+			if err != nil {
+				return nil, false
+			}
+			mac, err := decode(signature)
+			if err != nil {
+				return nil, false
+			}
+			for _, key := range keys {
+				if hmac.Equal(mac, sign(key, data)) {
+					return data, true
+				}
+			}
+			return nil, false
+		},
+	}
+}
+
+// NewEncryptedCookieStore returns a cookie store that encrypts and
+// authenticates sessions with AES-256-GCM. Each key must be 32 bytes long.
+// New sessions are encrypted with the first key; sessions encrypted with any
+// of the keys are accepted, which allows rotating keys. It panics if no
+// valid key is given.
+//
+// Example:
+//
+//	key, _ := hex.DecodeString(os.Getenv("SESSION_KEY")) // 64 hex digits
+//	store := session.NewEncryptedCookieStore(key)
+func NewEncryptedCookieStore(keys ...[]byte) *CookieStore {
+	if len(keys) == 0 {
+		panic("session: at least one encryption key is required")
+	}
+	aeads := make([]cipher.AEAD, len(keys))
+	for i, key := range keys {
+		if len(key) != 32 {
+			panic("session: encryption keys must be 32 bytes long")
+		}
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			panic("session: " + err.Error())
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			panic("session: " + err.Error())
+		}
+		aeads[i] = aead
+	}
+
+	return &CookieStore{
+		seal: func(data []byte) string {
+			aead := aeads[0]
+			nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(data)+aead.Overhead())
+			_, _ = rand.Read(nonce) //nolint:errcheck // crypto/rand.Read never fails
+			return encode(aead.Seal(nonce, nonce, data, nil))
+		},
+		open: func(value string) ([]byte, bool) {
+			sealed, err := decode(value)
+			if err != nil {
+				return nil, false
+			}
+			for _, aead := range aeads {
+				if len(sealed) < aead.NonceSize() {
+					return nil, false
+				}
+				nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+				if data, err := aead.Open(nil, nonce, ciphertext, nil); err == nil {
+					return data, true
+				}
+			}
+			return nil, false
+		},
+	}
+}
+
+// Load implements [Store]. Cookies that were tampered with, or sealed with
+// an unknown key, are treated as missing.
+func (s *CookieStore) Load(_ context.Context, id string) ([]byte, error) {
+	data, ok := s.open(id)
+	if !ok {
+		return nil, nil
+	}
+
+	return data, nil
+}
+
+// Save implements [Store]. The returned ID is the sealed session.
+func (s *CookieStore) Save(_ context.Context, _ string, data []byte, _ time.Duration) (string, error) {
+	value := s.seal(data)
+	if len(value) > maxCookieValue {
+		return "", ErrCookieTooLarge
+	}
+
+	return value, nil
+}
+
+// Delete implements [Store]. It does nothing: the session disappears with
+// its cookie.
+func (s *CookieStore) Delete(context.Context, string) error {
+	return nil
+}
+
+func encode(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decode(s string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(s)
+}
@@ -0,0 +1,123 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package session
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	key1 = []byte("0123456789abcdef0123456789abcdef")
+	key2 = []byte("fedcba9876543210fedcba9876543210")
+)
+
+func TestCookieStore_SealOpen(t *testing.T) {
+	t.Parallel()
+	stores := map[string]*CookieStore{
+		"signed":    NewSignedCookieStore(key1),
+		"encrypted": NewEncryptedCookieStore(key1),
+	}
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctx := t.Context()
+			value, err := store.Save(ctx, "", []byte(`{"v":{"user":"alice"}}`), time.Hour)
+			require.NoError(t, err)
+
+			data, err := store.Load(ctx, value)
+			require.NoError(t, err)
+			assert.JSONEq(t, `{"v":{"user":"alice"}}`, string(data))
+
+			// Tampered cookies are rejected
+			tampered := value[:len(value)-2] + "AA"
+			if strings.HasSuffix(value, "AA") {
+				tampered = value[:len(value)-2] + "BB"
+			}
+			for _, bad := range []string{tampered, "", "x", "a.b", "!!!"} {
+				data, err = store.Load(ctx, bad)
+				require.NoError(t, err)
+				assert.Nil(t, data, bad)
+			}
+
+			require.NoError(t, store.Delete(ctx, value))
+		})
+	}
+}
+
+func TestCookieStore_Confidentiality(t *testing.T) {
+	t.Parallel()
+	data := []byte(`{"v":{"secret":"hunter2"}}`)
+
+	signed, err := NewSignedCookieStore(key1).Save(t.Context(), "", data, 0)
+	require.NoError(t, err)
+	payload, _, _ := strings.Cut(signed, ".")
+	decoded, err := decode(payload)
+	require.NoError(t, err)
+	assert.Contains(t, string(decoded), "hunter2", "signed sessions are readable")
+
+	encrypted, err := NewEncryptedCookieStore(key1).Save(t.Context(), "", data, 0)
+	require.NoError(t, err)
+	decoded, err = decode(encrypted)
+	require.NoError(t, err)
+	assert.NotContains(t, string(decoded), "hunter2")
+}
+
+func TestCookieStore_KeyRotation(t *testing.T) {
+	t.Parallel()
+	constructors := map[string]func(keys ...[]byte) *CookieStore{
+		"signed":    NewSignedCookieStore,
+		"encrypted": NewEncryptedCookieStore,
+	}
+	for name, newStore := range constructors {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctx := t.Context()
+			oldValue, err := newStore(key1).Save(ctx, "", []byte("data"), 0)
+			require.NoError(t, err)
+
+			rotated := newStore(key2, key1)
+			data, err := rotated.Load(ctx, oldValue)
+			require.NoError(t, err)
+			assert.Equal(t, []byte("data"), data, "old keys still open sessions")
+
+			newValue, err := rotated.Save(ctx, "", []byte("data"), 0)
+			require.NoError(t, err)
+			data, err = newStore(key1).Load(ctx, newValue)
+			require.NoError(t, err)
+			assert.Nil(t, data, "new sessions use the first key")
+		})
+	}
+}
+
+func TestCookieStore_TooLarge(t *testing.T) {
+	t.Parallel()
+	_, err := NewEncryptedCookieStore(key1).Save(t.Context(), "", make([]byte, 4000), 0)
+	require.ErrorIs(t, err, ErrCookieTooLarge)
+}
+
+func TestCookieStore_InvalidKeys(t *testing.T) {
+	t.Parallel()
+	assert.Panics(t, func() { NewSignedCookieStore() })
+	assert.Panics(t, func() { NewSignedCookieStore([]byte("short")) })
+	assert.Panics(t, func() { NewEncryptedCookieStore() })
+	assert.Panics(t, func() { NewEncryptedCookieStore(key1[:16]) })
+}
@@ -0,0 +1,61 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session
+
+import (
+	"errors"
+
+	"rivaas.dev/router"
+)
+
+// csrfKey is the session key of the CSRF token.
+const csrfKey = "_csrf"
+
+// CSRFTokenStore keeps CSRF tokens in the session. It implements the
+// TokenStore interface of the csrf middleware.
+type CSRFTokenStore struct{}
+
+// CSRFStore returns a token store for the csrf middleware that keeps the
+// token in the session (synchronizer token pattern). The session middleware
+// must run before csrf.
+//
+// Example:
+//
+//	r.Use(session.New(store))
+//	r.Use(csrf.New(csrf.WithTokenStore(session.CSRFStore())))
+func CSRFStore() CSRFTokenStore {
+	return CSRFTokenStore{}
+}
+
+// LoadToken returns the CSRF token of the session, or an empty string if it
+// has none.
+func (CSRFTokenStore) LoadToken(c *router.Context) (string, error) {
+	if getSession(c) == nil {
+		return "", errors.New("session: middleware not registered")
+	}
+	token, _ := Get[string](c, csrfKey)
+
+	return token, nil
+}
+
+// SaveToken stores token in the session.
+func (CSRFTokenStore) SaveToken(c *router.Context, token string) error {
+	if getSession(c) == nil {
+		return errors.New("session: middleware not registered")
+	}
+	Set(c, csrfKey, token)
+
+	return nil
+}
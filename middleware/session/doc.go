@@ -0,0 +1,91 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package session provides middleware that keeps per-client state across
+// requests, identified by a cookie.
+//
+// Handlers read and write session values through the request context. The
+// middleware loads the session before the handler runs and saves it before
+// the response is written.
+//
+// # Basic Usage
+//
+//	import "rivaas.dev/middleware/session"
+//
+//	r := router.MustNew()
+//	r.Use(session.New(session.NewEncryptedCookieStore(key)))
+//
+//	r.POST("/login", func(c *router.Context) {
+//	    // ... authenticate ...
+//	    session.Renew(c)
+//	    session.Set(c, "user_id", user.ID)
+//	})
+//
+//	r.GET("/account", func(c *router.Context) {
+//	    userID, ok := session.Get[int64](c, "user_id")
+//	    if !ok {
+//	        c.Redirect(http.StatusSeeOther, "/login")
+//	        return
+//	    }
+//	})
+//
+// # Stores
+//
+//   - [NewSignedCookieStore]: The session lives in the cookie, signed with
+//     HMAC-SHA256; clients can read but not modify it
+//   - [NewEncryptedCookieStore]: The session lives in the cookie, encrypted
+//     with AES-256-GCM
+//   - [NewMemoryStore]: Server-side, in memory; for development and
+//     single-instance deployments
+//   - [NewRedisStore]: Server-side, in Redis; shared between replicas
+//
+// Cookie stores need no server-side state, but sessions are limited to about
+// 4 KB and can't be revoked before they expire. Server-side stores keep only
+// a random ID in the cookie. Any other backend can implement [Store].
+//
+// # Values
+//
+// [Set], [Get], and [Delete] access session values. Values are stored as
+// JSON, so any type that round-trips through encoding/json works; [Get]
+// decodes them into the requested type.
+//
+// # Expiry
+//
+// Sessions end after 30 minutes without requests ([WithIdleTimeout]) or 24
+// hours after they started ([WithAbsoluteTimeout]), whichever comes first.
+// Expired sessions are discarded and the client starts a new one.
+//
+// # Rotation
+//
+// Call [Renew] when privileges change, such as on login, to give the session
+// a new ID and prevent session fixation. [Destroy] removes the session, such
+// as on logout.
+//
+// # CSRF Protection
+//
+// [CSRFStore] keeps the tokens of the csrf middleware in the session:
+//
+//	r.Use(session.New(store))
+//	r.Use(csrf.New(csrf.WithTokenStore(session.CSRFStore())))
+//
+// # Configuration Options
+//
+//   - [WithCookieName], [WithCookiePath], [WithCookieDomain]: Session cookie
+//   - [WithSameSite]: SameSite attribute of the cookie (default: Lax)
+//   - [WithInsecureCookie]: Allow the cookie over plain HTTP
+//   - [WithIdleTimeout]: End unused sessions (default: 30 minutes)
+//   - [WithAbsoluteTimeout]: End old sessions (default: 24 hours)
+//   - [WithErrorHandler]: Custom response when the store fails
+//   - [WithLogger]: Logger for failures to save sessions
+package session
@@ -0,0 +1,94 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package main demonstrates how to use the session middleware
+// for a login with CSRF-protected forms.
+package main
+
+import (
+	"html/template"
+	"log"
+	"net/http"
+
+	"rivaas.dev/middleware/csrf"
+	"rivaas.dev/middleware/session"
+	"rivaas.dev/router"
+)
+
+var page = template.Must(template.New("page").Parse(`<!DOCTYPE html>
+<html>
+<body>
+{{ if .User }}
+    <p>Logged in as {{ .User }}. You have visited {{ .Visits }} times.</p>
+    <form method="POST" action="/logout">
+        <input type="hidden" name="_csrf" value="{{ .CSRF }}">
+        <button type="submit">Log out</button>
+    </form>
+{{ else }}
+    <form method="POST" action="/login">
+        <input type="hidden" name="_csrf" value="{{ .CSRF }}">
+        <input name="user" placeholder="Name">
+        <button type="submit">Log in</button>
+    </form>
+{{ end }}
+</body>
+</html>`))
+
+func main() {
+	r := router.MustNew()
+
+	// Use session.NewRedisStore to share sessions between replicas.
+	// Plain HTTP for local development; drop the insecure options in production.
+	r.Use(session.New(session.NewMemoryStore(), session.WithInsecureCookie()))
+	r.Use(csrf.New(csrf.WithTokenStore(session.CSRFStore())))
+
+	r.GET("/", func(c *router.Context) {
+		user, _ := session.Get[string](c, "user")
+		visits, _ := session.Get[int](c, "visits")
+		if user != "" {
+			visits++
+			session.Set(c, "visits", visits)
+		}
+
+		c.Response.Header().Set("Content-Type", "text/html; charset=utf-8")
+		err := page.Execute(c.Response, map[string]any{
+			"User":   user,
+			"Visits": visits,
+			"CSRF":   csrf.Token(c),
+		})
+		if err != nil {
+			log.Printf("render: %v", err)
+		}
+	})
+
+	r.POST("/login", func(c *router.Context) {
+		// A real application checks a password here
+		session.Renew(c)
+		if _, err := csrf.Rotate(c); err != nil {
+			c.WriteErrorResponse(http.StatusInternalServerError, "login failed")
+			return
+		}
+		session.Set(c, "user", c.Request.PostFormValue("user"))
+		c.Redirect(http.StatusSeeOther, "/")
+	})
+
+	r.POST("/logout", func(c *router.Context) {
+		session.Destroy(c)
+		c.Redirect(http.StatusSeeOther, "/")
+	})
+
+	log.Println("Server starting on http://localhost:8080")
+	log.Println("Open http://localhost:8080/ to log in and out")
+	log.Fatal(http.ListenAndServe(":8080", r))
+}
@@ -0,0 +1,138 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"rivaas.dev/router"
+)
+
+// WithCookieName sets the name of the session cookie.
+// Default: "session"
+//
+// Example:
+//
+//	session.New(store, session.WithCookieName("__Host-session"))
+func WithCookieName(name string) Option {
+	return func(cfg *config) {
+		cfg.cookie.name = name
+	}
+}
+
+// WithCookiePath sets the path of the session cookie.
+// Default: "/"
+//
+// Example:
+//
+//	session.New(store, session.WithCookiePath("/app"))
+func WithCookiePath(path string) Option {
+	return func(cfg *config) {
+		cfg.cookie.path = path
+	}
+}
+
+// WithCookieDomain sets the domain of the session cookie. By default the
+// cookie is only sent to the host that set it.
+//
+// Example:
+//
+//	session.New(store, session.WithCookieDomain("example.com"))
+func WithCookieDomain(domain string) Option {
+	return func(cfg *config) {
+		cfg.cookie.domain = domain
+	}
+}
+
+// WithSameSite sets the SameSite attribute of the session cookie.
+// Default: http.SameSiteLaxMode
+//
+// Example:
+//
+//	session.New(store, session.WithSameSite(http.SameSiteStrictMode))
+func WithSameSite(mode http.SameSite) Option {
+	return func(cfg *config) {
+		cfg.cookie.sameSite = mode
+	}
+}
+
+// WithInsecureCookie drops the Secure attribute of the session cookie, so
+// that it is sent over plain HTTP. Use it for local development only.
+//
+// Example:
+//
+//	session.New(store, session.WithInsecureCookie())
+func WithInsecureCookie() Option {
+	return func(cfg *config) {
+		cfg.cookie.secure = false
+	}
+}
+
+// WithIdleTimeout ends sessions that are not used for d. Set to 0 to keep
+// idle sessions until the absolute timeout.
+// Default: 30 minutes
+//
+// Example:
+//
+//	session.New(store, session.WithIdleTimeout(15*time.Minute))
+func WithIdleTimeout(d time.Duration) Option {
+	return func(cfg *config) {
+		cfg.idleTimeout = max(d, 0)
+	}
+}
+
+// WithAbsoluteTimeout ends sessions d after they started, however active
+// they are. Set to 0 to only end idle sessions.
+// Default: 24 hours
+//
+// Example:
+//
+//	session.New(store, session.WithAbsoluteTimeout(8*time.Hour))
+func WithAbsoluteTimeout(d time.Duration) Option {
+	return func(cfg *config) {
+		cfg.absoluteTimeout = max(d, 0)
+	}
+}
+
+// WithErrorHandler sets the function that responds when the store fails to
+// load a session. The middleware aborts the chain after the handler returns.
+// Default: 500 Internal Server Error
+//
+// Example:
+//
+//	session.New(store, session.WithErrorHandler(func(c *router.Context, err error) {
+//	    c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "try again later"})
+//	}))
+func WithErrorHandler(handler func(c *router.Context, err error)) Option {
+	return func(cfg *config) {
+		cfg.errorHandler = handler
+	}
+}
+
+// WithLogger sets the logger for failures to save sessions, which happen
+// while the response is written and can't be reported to the client. Set to
+// nil to disable logging.
+// Default: slog.Default()
+//
+// Example:
+//
+//	session.New(store, session.WithLogger(logger))
+func WithLogger(logger *slog.Logger) Option {
+	return func(cfg *config) {
+		cfg.logger = logger
+	}
+}
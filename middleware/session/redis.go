@@ -0,0 +1,81 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisStore is a [Store] backed by Redis, so every replica that uses the
+// same Redis deployment shares the sessions. Sessions expire in Redis with
+// their idle or absolute timeout.
+type RedisStore struct {
+	client redis.Cmdable
+	prefix string
+}
+
+// NewRedisStore creates a store that keeps sessions under prefix + ID. An
+// empty prefix defaults to "session:". client can be a *redis.Client,
+// *redis.ClusterClient, or *redis.Ring.
+//
+// Example:
+//
+//	client := redis.NewClient(&redis.Options{Addr: "localhost:6379"})
+//	r.Use(session.New(session.NewRedisStore(client, "myapp:session:")))
+func NewRedisStore(client redis.Cmdable, prefix string) *RedisStore {
+	if prefix == "" {
+		prefix = "session:"
+	}
+	return &RedisStore{client: client, prefix: prefix}
+}
+
+// Load implements [Store].
+func (s *RedisStore) Load(ctx context.Context, id string) ([]byte, error) {
+	data, err := s.client.Get(ctx, s.prefix+id).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("session: redis: %w", err)
+	}
+
+	return data, nil
+}
+
+// Save implements [Store].
+func (s *RedisStore) Save(ctx context.Context, id string, data []byte, ttl time.Duration) (string, error) {
+	if id == "" {
+		id = NewID()
+	}
+	if err := s.client.Set(ctx, s.prefix+id, data, ttl).Err(); err != nil {
+		return "", fmt.Errorf("session: redis: %w", err)
+	}
+
+	return id, nil
+}
+
+// Delete implements [Store].
+func (s *RedisStore) Delete(ctx context.Context, id string) error {
+	if err := s.client.Del(ctx, s.prefix+id).Err(); err != nil {
+		return fmt.Errorf("session: redis: %w", err)
+	}
+
+	return nil
+}
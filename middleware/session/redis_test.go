@@ -0,0 +1,85 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package session
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRedis(t *testing.T) (*RedisStore, *miniredis.Miniredis) {
+	t.Helper()
+
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	return NewRedisStore(client, ""), mr
+}
+
+func newTestRedisStore(t *testing.T) *RedisStore {
+	t.Helper()
+	store, _ := newTestRedis(t)
+	return store
+}
+
+func TestRedisStore(t *testing.T) {
+	t.Parallel()
+	store, mr := newTestRedis(t)
+	ctx := t.Context()
+
+	id, err := store.Save(ctx, "", []byte("data"), time.Minute)
+	require.NoError(t, err)
+	assert.True(t, mr.Exists("session:"+id), "sessions use the default prefix")
+	assert.Equal(t, time.Minute, mr.TTL("session:"+id))
+
+	data, err := store.Load(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("data"), data)
+
+	data, err = store.Load(ctx, "unknown")
+	require.NoError(t, err)
+	assert.Nil(t, data)
+
+	mr.FastForward(time.Minute)
+	data, err = store.Load(ctx, id)
+	require.NoError(t, err)
+	assert.Nil(t, data, "expired")
+
+	id, err = store.Save(ctx, "", []byte("data"), 0)
+	require.NoError(t, err)
+	require.NoError(t, store.Delete(ctx, id))
+	assert.False(t, mr.Exists("session:"+id))
+}
+
+func TestRedisStore_Error(t *testing.T) {
+	t.Parallel()
+	store, mr := newTestRedis(t)
+	mr.Close()
+
+	_, err := store.Load(t.Context(), "id")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "session: redis")
+	_, err = store.Save(t.Context(), "", []byte("data"), 0)
+	require.Error(t, err)
+	require.Error(t, store.Delete(t.Context(), "id"))
+}
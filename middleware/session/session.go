@@ -0,0 +1,436 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"rivaas.dev/router"
+)
+
+type contextKey struct{}
+
+// Option defines functional options for session middleware configuration.
+type Option func(*config)
+
+// config holds the configuration for the session middleware.
+type config struct {
+	// cookie configures the session cookie
+	cookie cookieConfig
+
+	// idleTimeout ends sessions that are not used for this long; 0 disables
+	idleTimeout time.Duration
+
+	// absoluteTimeout ends sessions this long after they started; 0 disables
+	absoluteTimeout time.Duration
+
+	// errorHandler is called when the store fails to load a session
+	errorHandler func(c *router.Context, err error)
+
+	// logger logs failures to save sessions; nil disables logging
+	logger *slog.Logger
+
+	// now returns the current time; replaced in tests
+	now func() time.Time
+}
+
+// cookieConfig holds the attributes of the session cookie.
+type cookieConfig struct {
+	name     string
+	path     string
+	domain   string
+	secure   bool
+	sameSite http.SameSite
+}
+
+// defaultConfig returns the default configuration for session middleware.
+func defaultConfig() *config {
+	return &config{
+		cookie: cookieConfig{
+			name:     "session",
+			path:     "/",
+			secure:   true,
+			sameSite: http.SameSiteLaxMode,
+		},
+		idleTimeout:     30 * time.Minute,
+		absoluteTimeout: 24 * time.Hour,
+		errorHandler:    defaultErrorHandler,
+		logger:          slog.Default(),
+		now:             time.Now,
+	}
+}
+
+// defaultErrorHandler sends a 500 Internal Server Error response.
+func defaultErrorHandler(c *router.Context, _ error) {
+	c.WriteErrorResponse(http.StatusInternalServerError, "session unavailable")
+}
+
+// record is the encoded form of a session.
+type record struct {
+	Values     map[string]json.RawMessage `json:"v,omitempty"`
+	Created    int64                      `json:"c"` // Unix seconds
+	LastActive int64                      `json:"a"` // Unix seconds
+}
+
+// session is the session of a request, stored in its context.
+type session struct {
+	id         string // Cookie value; empty for new sessions
+	values     map[string]any
+	created    time.Time
+	lastActive time.Time
+
+	dirty     bool // Values changed
+	renew     bool // Needs a new ID
+	destroy   bool // Remove the session
+	expired   bool // The client sent a session that is no longer valid
+	committed bool
+}
+
+// New returns a middleware that gives each client a session, kept in store.
+// Handlers read and write session values with [Get], [Set], and [Delete].
+//
+// The session is saved, and the session cookie sent, before the response
+// is written, but only once the session holds values: anonymous visitors
+// don't create sessions. Sessions end after 30 minutes without requests
+// or 24 hours after they started, whichever comes first.
+//
+// Basic usage with an encrypted cookie:
+//
+//	r := router.MustNew()
+//	r.Use(session.New(session.NewEncryptedCookieStore(key)))
+//
+// With Redis:
+//
+//	client := redis.NewClient(&redis.Options{Addr: "localhost:6379"})
+//	r.Use(session.New(session.NewRedisStore(client, "")))
+func New(store Store, opts ...Option) router.HandlerFunc {
+	cfg := defaultConfig()
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return func(c *router.Context) {
+		s, err := cfg.load(c, store)
+		if err != nil {
+			cfg.errorHandler(c, err)
+			c.Abort()
+
+			return
+		}
+		ctx := context.WithValue(c.Request.Context(), contextKey{}, s)
+		c.Request = c.Request.WithContext(ctx)
+
+		// Save the session before the handler's response goes out
+		original := c.Response
+		c.Response = &sessionWriter{
+			ResponseWriter: original,
+			commit:         func() { cfg.commit(c.Request.Context(), original, store, s) },
+		}
+
+		c.Next()
+
+		cfg.commit(c.Request.Context(), original, store, s)
+		c.Response = original
+	}
+}
+
+// load returns the session of the request of c, or a new session if the
+// client sent none or an invalid or expired one.
+func (cfg *config) load(c *router.Context, store Store) (*session, error) {
+	now := cfg.now()
+	s := &session{values: make(map[string]any), created: now, lastActive: now}
+
+	cookie, err := c.Request.Cookie(cfg.cookie.name)
+	if err != nil || cookie.Value == "" {
+		return s, nil
+	}
+	data, err := store.Load(c.Request.Context(), cookie.Value)
+	if err != nil {
+		return nil, fmt.Errorf("session: load: %w", err)
+	}
+
+	var rec record
+	if data == nil || json.Unmarshal(data, &rec) != nil {
+		s.expired = true
+		return s, nil
+	}
+	created, lastActive := time.Unix(rec.Created, 0), time.Unix(rec.LastActive, 0)
+	if cfg.isExpired(now, created, lastActive) {
+		// Best effort: the store expires the session anyway
+		_ = store.Delete(c.Request.Context(), cookie.Value) //nolint:errcheck // See above
+		s.expired = true
+		return s, nil
+	}
+
+	s.id = cookie.Value
+	s.created = created
+	s.lastActive = lastActive
+	for key, value := range rec.Values {
+		s.values[key] = value
+	}
+
+	return s, nil
+}
+
+// isExpired reports whether a session that started at created and was last
+// used at lastActive has ended at now.
+func (cfg *config) isExpired(now, created, lastActive time.Time) bool {
+	if cfg.idleTimeout > 0 && now.Sub(lastActive) >= cfg.idleTimeout {
+		return true
+	}
+
+	return cfg.absoluteTimeout > 0 && now.Sub(created) >= cfg.absoluteTimeout
+}
+
+// ttl returns how long a session that started at created stays valid if
+// it's not used again after now, or 0 if it does not expire.
+func (cfg *config) ttl(now, created time.Time) time.Duration {
+	ttl := cfg.idleTimeout
+	if cfg.absoluteTimeout > 0 {
+		remaining := created.Add(cfg.absoluteTimeout).Sub(now)
+		if ttl == 0 || remaining < ttl {
+			ttl = remaining
+		}
+	}
+
+	return ttl
+}
+
+// needsTouch reports whether an unmodified session should be saved anyway,
+// to extend its idle timeout. Sessions are touched at most once a minute.
+func (cfg *config) needsTouch(s *session, now time.Time) bool {
+	if cfg.idleTimeout <= 0 || s.id == "" {
+		return false
+	}
+
+	return now.Sub(s.lastActive) >= min(time.Minute, cfg.idleTimeout/2)
+}
+
+// commit saves the session and sets the session cookie on w, once per
+// request.
+func (cfg *config) commit(ctx context.Context, w http.ResponseWriter, store Store, s *session) {
+	if s.committed {
+		return
+	}
+	s.committed = true
+	now := cfg.now()
+
+	if s.destroy {
+		if s.id != "" {
+			if err := store.Delete(ctx, s.id); err != nil {
+				cfg.logError("delete", err)
+			}
+		}
+		if s.id != "" || s.expired {
+			http.SetCookie(w, cfg.newCookie("", -1))
+		}
+		return
+	}
+
+	if s.renew && s.id != "" {
+		if err := store.Delete(ctx, s.id); err != nil {
+			cfg.logError("delete", err)
+		}
+		s.id = ""
+		s.dirty = true
+	}
+
+	// Don't create sessions without values
+	if s.id == "" && len(s.values) == 0 {
+		if s.expired {
+			http.SetCookie(w, cfg.newCookie("", -1))
+		}
+		return
+	}
+	if !s.dirty && !cfg.needsTouch(s, now) {
+		return
+	}
+
+	rec := record{
+		Values:     make(map[string]json.RawMessage, len(s.values)),
+		Created:    s.created.Unix(),
+		LastActive: now.Unix(),
+	}
+	for key, value := range s.values {
+		raw, err := encodeValue(value)
+		if err != nil {
+			cfg.logError("encode "+key, err)
+			return
+		}
+		rec.Values[key] = raw
+	}
+	data, err := json.Marshal(rec)
+	if err != nil {
+		cfg.logError("encode", err)
+		return
+	}
+
+	ttl := cfg.ttl(now, s.created)
+	id, err := store.Save(ctx, s.id, data, ttl)
+	if err != nil {
+		cfg.logError("save", err)
+		return
+	}
+	maxAge := 0
+	if ttl > 0 {
+		maxAge = int((ttl + time.Second - 1) / time.Second)
+	}
+	http.SetCookie(w, cfg.newCookie(id, maxAge))
+}
+
+// newCookie returns the session cookie with value. A negative maxAge
+// removes the cookie; 0 makes it a browser-session cookie.
+func (cfg *config) newCookie(value string, maxAge int) *http.Cookie {
+	return &http.Cookie{
+		Name:     cfg.cookie.name,
+		Value:    value,
+		Path:     cfg.cookie.path,
+		Domain:   cfg.cookie.domain,
+		MaxAge:   maxAge,
+		Secure:   cfg.cookie.secure,
+		HttpOnly: true,
+		SameSite: cfg.cookie.sameSite,
+	}
+}
+
+// logError logs a failure to persist the session. The response is already
+// under way, so it can't report the failure to the client.
+func (cfg *config) logError(op string, err error) {
+	if cfg.logger != nil {
+		cfg.logger.Error("session: "+op+" failed", "error", err)
+	}
+}
+
+// encodeValue returns the JSON encoding of a session value. Values loaded
+// from the store are already encoded.
+func encodeValue(value any) (json.RawMessage, error) {
+	if raw, ok := value.(json.RawMessage); ok {
+		return raw, nil
+	}
+
+	return json.Marshal(value)
+}
+
+// getSession returns the session of the request of c, or nil if the
+// middleware did not run.
+func getSession(c *router.Context) *session {
+	s, _ := c.Request.Context().Value(contextKey{}).(*session)
+	return s
+}
+
+// Get returns the session value for key as a T. It reports false if the
+// session has no value for key, the value can't be converted to T, or the
+// middleware did not run.
+//
+// Values are stored as JSON, so a value read in a later request is decoded
+// into T: any type that round-trips through encoding/json works.
+//
+// Example:
+//
+//	userID, ok := session.Get[int64](c, "user_id")
+//	if !ok {
+//	    c.Redirect(http.StatusSeeOther, "/login")
+//	    return
+//	}
+func Get[T any](c *router.Context, key string) (T, bool) {
+	var zero T
+	s := getSession(c)
+	if s == nil {
+		return zero, false
+	}
+	value, ok := s.values[key]
+	if !ok {
+		return zero, false
+	}
+	if v, ok := value.(T); ok {
+		return v, true
+	}
+
+	raw, err := encodeValue(value)
+	if err != nil {
+		return zero, false
+	}
+	var v T
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return zero, false
+	}
+
+	return v, true
+}
+
+// Set sets the session value for key. value must be encodable as JSON. It
+// does nothing if the middleware did not run.
+//
+// Example:
+//
+//	session.Set(c, "user_id", user.ID)
+func Set(c *router.Context, key string, value any) {
+	if s := getSession(c); s != nil {
+		s.values[key] = value
+		s.dirty = true
+	}
+}
+
+// Delete removes the session value for key.
+//
+// Example:
+//
+//	session.Delete(c, "flash")
+func Delete(c *router.Context, key string) {
+	if s := getSession(c); s != nil {
+		if _, ok := s.values[key]; ok {
+			delete(s.values, key)
+			s.dirty = true
+		}
+	}
+}
+
+// Renew gives the session a new ID, keeping its values. Call it when the
+// privileges of the client change, such as on login or logout, so that a
+// session ID obtained before (session fixation) can't be used after.
+//
+// Example:
+//
+//	r.POST("/login", func(c *router.Context) {
+//	    // ... authenticate ...
+//	    session.Renew(c)
+//	    session.Set(c, "user_id", user.ID)
+//	})
+func Renew(c *router.Context) {
+	if s := getSession(c); s != nil {
+		s.renew = true
+	}
+}
+
+// Destroy removes the session and its cookie, e.g. on logout. Values set
+// afterwards in the same request are discarded.
+//
+// Example:
+//
+//	r.POST("/logout", func(c *router.Context) {
+//	    session.Destroy(c)
+//	    c.Redirect(http.StatusSeeOther, "/")
+//	})
+func Destroy(c *router.Context) {
+	if s := getSession(c); s != nil {
+		s.destroy = true
+		clear(s.values)
+	}
+}
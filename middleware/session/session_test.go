@@ -0,0 +1,398 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package session
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"rivaas.dev/router"
+)
+
+// testClock is an adjustable clock for expiry tests.
+type testClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *testClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// withClock replaces the clock of the middleware.
+func withClock(clock *testClock) Option {
+	return func(cfg *config) {
+		cfg.now = clock.Now
+	}
+}
+
+// client sends requests with the cookies it received, like a browser.
+type client struct {
+	t      *testing.T
+	r      http.Handler
+	cookie *http.Cookie
+}
+
+// newClient returns a client of an app whose handlers read and write the
+// session value "user".
+func newClient(t *testing.T, store Store, opts ...Option) *client {
+	t.Helper()
+	r := router.MustNew()
+	r.Use(New(store, opts...))
+	r.GET("/user", func(c *router.Context) {
+		user, _ := Get[string](c, "user")
+		//nolint:errcheck // Test handler
+		c.String(http.StatusOK, user)
+	})
+	r.POST("/login", func(c *router.Context) {
+		Renew(c)
+		Set(c, "user", c.Query("name"))
+		c.NoContent()
+	})
+	r.POST("/logout", func(c *router.Context) {
+		Destroy(c)
+		c.NoContent()
+	})
+	r.POST("/forget", func(c *router.Context) {
+		Delete(c, "user")
+		c.NoContent()
+	})
+
+	return &client{t: t, r: r}
+}
+
+func (cl *client) do(method, target string) *httptest.ResponseRecorder {
+	cl.t.Helper()
+	req := httptest.NewRequestWithContext(cl.t.Context(), method, target, nil)
+	if cl.cookie != nil {
+		req.AddCookie(cl.cookie)
+	}
+	w := httptest.NewRecorder()
+	cl.r.ServeHTTP(w, req)
+	for _, cookie := range w.Result().Cookies() {
+		if cookie.MaxAge < 0 {
+			cl.cookie = nil
+		} else {
+			cl.cookie = cookie
+		}
+	}
+
+	return w
+}
+
+// testStores returns each kind of store for table-driven tests.
+func testStores(t *testing.T) map[string]Store {
+	t.Helper()
+	return map[string]Store{
+		"memory":           NewMemoryStore(),
+		"signed cookie":    NewSignedCookieStore([]byte("0123456789abcdef0123456789abcdef")),
+		"encrypted cookie": NewEncryptedCookieStore([]byte("0123456789abcdef0123456789abcdef")),
+		"redis":            newTestRedisStore(t),
+	}
+}
+
+func TestSession_Lifecycle(t *testing.T) {
+	t.Parallel()
+	for name, store := range testStores(t) {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			cl := newClient(t, store)
+
+			// Anonymous visitors get no session
+			w := cl.do(http.MethodGet, "/user")
+			assert.Empty(t, w.Body.String())
+			assert.Empty(t, w.Result().Cookies())
+
+			cl.do(http.MethodPost, "/login?name=alice")
+			require.NotNil(t, cl.cookie)
+			assert.Equal(t, "session", cl.cookie.Name)
+			assert.True(t, cl.cookie.HttpOnly)
+			assert.True(t, cl.cookie.Secure)
+			assert.Equal(t, http.SameSiteLaxMode, cl.cookie.SameSite)
+			assert.Equal(t, 30*60, cl.cookie.MaxAge)
+
+			w = cl.do(http.MethodGet, "/user")
+			assert.Equal(t, "alice", w.Body.String())
+			assert.Empty(t, w.Result().Cookies(), "unmodified sessions are not saved")
+
+			cl.do(http.MethodPost, "/forget")
+			assert.Empty(t, cl.do(http.MethodGet, "/user").Body.String())
+
+			cl.do(http.MethodPost, "/login?name=bob")
+			cl.do(http.MethodPost, "/logout")
+			assert.Nil(t, cl.cookie, "the cookie is removed")
+		})
+	}
+}
+
+func TestSession_Renew(t *testing.T) {
+	t.Parallel()
+	store := NewMemoryStore()
+	cl := newClient(t, store)
+
+	cl.do(http.MethodPost, "/login?name=alice")
+	before := cl.cookie
+	cl.do(http.MethodPost, "/login?name=admin")
+	require.NotNil(t, cl.cookie)
+	assert.NotEqual(t, before.Value, cl.cookie.Value)
+	assert.Equal(t, 1, store.Len(), "the old session is deleted")
+
+	// The old session ID no longer works
+	old := &client{t: t, r: cl.r, cookie: before}
+	assert.Empty(t, old.do(http.MethodGet, "/user").Body.String())
+	assert.Equal(t, "admin", cl.do(http.MethodGet, "/user").Body.String())
+}
+
+func TestSession_Destroy(t *testing.T) {
+	t.Parallel()
+	store := NewMemoryStore()
+	cl := newClient(t, store)
+
+	cl.do(http.MethodPost, "/login?name=alice")
+	stolen := cl.cookie
+	cl.do(http.MethodPost, "/logout")
+	assert.Zero(t, store.Len())
+
+	thief := &client{t: t, r: cl.r, cookie: stolen}
+	assert.Empty(t, thief.do(http.MethodGet, "/user").Body.String())
+}
+
+func TestSession_IdleTimeout(t *testing.T) {
+	t.Parallel()
+	clock := &testClock{now: time.Now()}
+	store := NewMemoryStore()
+	cl := newClient(t, store, withClock(clock), WithIdleTimeout(10*time.Minute))
+
+	cl.do(http.MethodPost, "/login?name=alice")
+	require.NotNil(t, cl.cookie)
+
+	// Activity extends the session, saving it at most once a minute
+	clock.Advance(30 * time.Second)
+	w := cl.do(http.MethodGet, "/user")
+	assert.Equal(t, "alice", w.Body.String())
+	assert.Empty(t, w.Result().Cookies())
+	for range 3 {
+		clock.Advance(8 * time.Minute)
+		w = cl.do(http.MethodGet, "/user")
+		assert.Equal(t, "alice", w.Body.String())
+		assert.NotEmpty(t, w.Result().Cookies(), "the session is touched")
+	}
+
+	clock.Advance(11 * time.Minute)
+	w = cl.do(http.MethodGet, "/user")
+	assert.Empty(t, w.Body.String())
+	assert.Nil(t, cl.cookie, "the expired cookie is removed")
+	assert.Zero(t, store.Len(), "the expired session is deleted")
+}
+
+func TestSession_AbsoluteTimeout(t *testing.T) {
+	t.Parallel()
+	clock := &testClock{now: time.Now()}
+	cl := newClient(t, NewMemoryStore(),
+		withClock(clock),
+		WithIdleTimeout(time.Hour),
+		WithAbsoluteTimeout(2*time.Hour),
+	)
+
+	cl.do(http.MethodPost, "/login?name=alice")
+	for range 3 {
+		clock.Advance(30 * time.Minute)
+		assert.Equal(t, "alice", cl.do(http.MethodGet, "/user").Body.String())
+	}
+	assert.Equal(t, 30*60, cl.cookie.MaxAge, "the cookie expires with the session")
+
+	// Renewing the ID does not restart the absolute timeout
+	cl.do(http.MethodPost, "/login?name=alice")
+	clock.Advance(31 * time.Minute)
+	assert.Empty(t, cl.do(http.MethodGet, "/user").Body.String())
+}
+
+func TestSession_NoTimeouts(t *testing.T) {
+	t.Parallel()
+	cl := newClient(t, NewMemoryStore(), WithIdleTimeout(0), WithAbsoluteTimeout(0))
+	cl.do(http.MethodPost, "/login?name=alice")
+	require.NotNil(t, cl.cookie)
+	assert.Zero(t, cl.cookie.MaxAge, "a browser-session cookie")
+}
+
+func TestSession_Values(t *testing.T) {
+	t.Parallel()
+	type cart struct {
+		Items []string `json:"items"`
+		Total int      `json:"total"`
+	}
+	r := router.MustNew()
+	r.Use(New(NewMemoryStore()))
+	r.POST("/set", func(c *router.Context) {
+		Set(c, "cart", cart{Items: []string{"book"}, Total: 12})
+		Set(c, "count", 3)
+		count, ok := Get[int](c, "count")
+		assert.True(t, ok)
+		assert.Equal(t, 3, count, "values set in this request")
+		c.NoContent()
+	})
+	r.GET("/get", func(c *router.Context) {
+		got, ok := Get[cart](c, "cart")
+		assert.True(t, ok)
+		assert.Equal(t, cart{Items: []string{"book"}, Total: 12}, got)
+		count, ok := Get[int64](c, "count")
+		assert.True(t, ok)
+		assert.Equal(t, int64(3), count, "values decoded from JSON")
+		_, ok = Get[string](c, "count")
+		assert.False(t, ok, "wrong type")
+		_, ok = Get[string](c, "missing")
+		assert.False(t, ok)
+		c.NoContent()
+	})
+
+	cl := &client{t: t, r: r}
+	cl.do(http.MethodPost, "/set")
+	assert.Equal(t, http.StatusNoContent, cl.do(http.MethodGet, "/get").Code)
+}
+
+func TestSession_CommitBeforeWrite(t *testing.T) {
+	t.Parallel()
+	r := router.MustNew()
+	r.Use(New(NewMemoryStore()))
+	r.GET("/stream", func(c *router.Context) {
+		Set(c, "user", "alice")
+		//nolint:errcheck // Test handler
+		c.String(http.StatusOK, "hello")
+		Set(c, "late", true)
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/stream", nil))
+	assert.Equal(t, "hello", w.Body.String())
+	require.Len(t, w.Result().Cookies(), 1, "the cookie is set before the body")
+}
+
+func TestSession_Options(t *testing.T) {
+	t.Parallel()
+	cl := newClient(t, NewMemoryStore(),
+		WithCookieName("__Host-sid"),
+		WithCookiePath("/app"),
+		WithCookieDomain("example.com"),
+		WithSameSite(http.SameSiteStrictMode),
+		WithInsecureCookie(),
+	)
+	cl.do(http.MethodPost, "/login?name=alice")
+	require.NotNil(t, cl.cookie)
+	assert.Equal(t, "__Host-sid", cl.cookie.Name)
+	assert.Equal(t, "/app", cl.cookie.Path)
+	assert.Equal(t, "example.com", cl.cookie.Domain)
+	assert.Equal(t, http.SameSiteStrictMode, cl.cookie.SameSite)
+	assert.False(t, cl.cookie.Secure)
+}
+
+// failingStore fails every operation.
+type failingStore struct{}
+
+var errStore = errors.New("store down")
+
+func (failingStore) Load(context.Context, string) ([]byte, error) { return nil, errStore }
+func (failingStore) Save(context.Context, string, []byte, time.Duration) (string, error) {
+	return "", errStore
+}
+func (failingStore) Delete(context.Context, string) error { return errStore }
+
+func TestSession_StoreErrors(t *testing.T) {
+	t.Parallel()
+	var gotErr error
+	cl := newClient(t, failingStore{},
+		WithLogger(nil),
+		WithErrorHandler(func(c *router.Context, err error) {
+			gotErr = err
+			c.WriteErrorResponse(http.StatusServiceUnavailable, "")
+		}),
+	)
+
+	// Failing saves don't fail the response
+	w := cl.do(http.MethodPost, "/login?name=alice")
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Empty(t, w.Result().Cookies())
+
+	cl.cookie = &http.Cookie{Name: "session", Value: "abc"}
+	w = cl.do(http.MethodGet, "/user")
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	require.ErrorIs(t, gotErr, errStore)
+}
+
+func TestSession_InvalidCookie(t *testing.T) {
+	t.Parallel()
+	cl := newClient(t, NewSignedCookieStore([]byte("0123456789abcdef0123456789abcdef")))
+	cl.cookie = &http.Cookie{Name: "session", Value: "forged"}
+	w := cl.do(http.MethodGet, "/user")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Body.String())
+	assert.Nil(t, cl.cookie, "the invalid cookie is removed")
+}
+
+func TestWithoutMiddleware(t *testing.T) {
+	t.Parallel()
+	c := router.NewContext(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	Set(c, "user", "alice")
+	Delete(c, "user")
+	Renew(c)
+	Destroy(c)
+	_, ok := Get[string](c, "user")
+	assert.False(t, ok)
+
+	_, err := CSRFStore().LoadToken(c)
+	require.Error(t, err)
+	require.Error(t, CSRFStore().SaveToken(c, "token"))
+}
+
+func TestCSRFStore(t *testing.T) {
+	t.Parallel()
+	store := CSRFStore()
+	r := router.MustNew()
+	r.Use(New(NewMemoryStore()))
+	r.POST("/save", func(c *router.Context) {
+		token, err := store.LoadToken(c)
+		require.NoError(t, err)
+		assert.Empty(t, token)
+		require.NoError(t, store.SaveToken(c, "token-1"))
+		c.NoContent()
+	})
+	r.GET("/load", func(c *router.Context) {
+		token, err := store.LoadToken(c)
+		require.NoError(t, err)
+		//nolint:errcheck // Test handler
+		c.String(http.StatusOK, token)
+	})
+
+	cl := &client{t: t, r: r}
+	cl.do(http.MethodPost, "/save")
+	assert.Equal(t, "token-1", cl.do(http.MethodGet, "/load").Body.String())
+}
@@ -0,0 +1,142 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"sync"
+	"time"
+)
+
+// Store keeps encoded sessions. The session cookie holds the ID that Save
+// returns: a random key for server-side stores such as [MemoryStore] and
+// [RedisStore], or the sealed session itself for a [CookieStore].
+type Store interface {
+	// Load returns the session with id, or nil if there is none. It returns
+	// an error only if the lookup itself failed.
+	Load(ctx context.Context, id string) ([]byte, error)
+
+	// Save stores data for at most ttl (0 means no limit) and returns the
+	// ID to put in the cookie. If id is empty, Save creates a new session;
+	// server-side stores generate IDs with [NewID].
+	Save(ctx context.Context, id string, data []byte, ttl time.Duration) (string, error)
+
+	// Delete removes the session with id.
+	Delete(ctx context.Context, id string) error
+}
+
+// NewID returns a random session ID with 256 bits of entropy.
+func NewID() string {
+	b := make([]byte, 32)
+	_, _ = rand.Read(b) //nolint:errcheck // crypto/rand.Read never fails
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// memoryEntry is a session kept by a [MemoryStore].
+type memoryEntry struct {
+	data    []byte
+	expires time.Time // Zero if the session does not expire
+}
+
+// MemoryStore is a [Store] that keeps sessions in memory. Sessions are lost
+// on restart and not shared between replicas, so it suits development,
+// tests, and single-instance deployments.
+type MemoryStore struct {
+	mu        sync.Mutex
+	sessions  map[string]memoryEntry
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// NewMemoryStore returns an empty in-memory store. Expired sessions are
+// removed as new sessions are saved.
+//
+// Example:
+//
+//	r.Use(session.New(session.NewMemoryStore()))
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		sessions: make(map[string]memoryEntry),
+		now:      time.Now,
+	}
+}
+
+// Load implements [Store].
+func (s *MemoryStore) Load(_ context.Context, id string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.sessions[id]
+	if !ok {
+		return nil, nil
+	}
+	if !entry.expires.IsZero() && !s.now().Before(entry.expires) {
+		delete(s.sessions, id)
+		return nil, nil
+	}
+
+	return entry.data, nil
+}
+
+// Save implements [Store].
+func (s *MemoryStore) Save(_ context.Context, id string, data []byte, ttl time.Duration) (string, error) {
+	if id == "" {
+		id = NewID()
+	}
+	now := s.now()
+	var expires time.Time
+	if ttl > 0 {
+		expires = now.Add(ttl)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sweep(now)
+	s.sessions[id] = memoryEntry{data: data, expires: expires}
+
+	return id, nil
+}
+
+// Delete implements [Store].
+func (s *MemoryStore) Delete(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.sessions, id)
+
+	return nil
+}
+
+// Len returns the number of stored sessions, including expired sessions
+// that have not been removed yet.
+func (s *MemoryStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.sessions)
+}
+
+// sweep removes expired sessions, at most once a minute. It must be called
+// with s.mu held.
+func (s *MemoryStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < time.Minute {
+		return
+	}
+	s.lastSweep = now
+	for id, entry := range s.sessions {
+		if !entry.expires.IsZero() && !now.Before(entry.expires) {
+			delete(s.sessions, id)
+		}
+	}
+}
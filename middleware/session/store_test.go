@@ -0,0 +1,91 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package session
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewID(t *testing.T) {
+	t.Parallel()
+	a, b := NewID(), NewID()
+	assert.Len(t, a, 43)
+	assert.NotEqual(t, a, b)
+}
+
+func TestMemoryStore(t *testing.T) {
+	t.Parallel()
+	clock := &testClock{now: time.Now()}
+	store := NewMemoryStore()
+	store.now = clock.Now
+	ctx := t.Context()
+
+	id, err := store.Save(ctx, "", []byte("a"), time.Minute)
+	require.NoError(t, err)
+	require.NotEmpty(t, id)
+
+	data, err := store.Load(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("a"), data)
+
+	same, err := store.Save(ctx, id, []byte("b"), time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, id, same)
+	data, err = store.Load(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("b"), data)
+
+	data, err = store.Load(ctx, "unknown")
+	require.NoError(t, err)
+	assert.Nil(t, data)
+
+	clock.Advance(time.Minute)
+	data, err = store.Load(ctx, id)
+	require.NoError(t, err)
+	assert.Nil(t, data, "expired")
+	assert.Zero(t, store.Len())
+
+	id, err = store.Save(ctx, "", []byte("c"), 0)
+	require.NoError(t, err)
+	require.NoError(t, store.Delete(ctx, id))
+	assert.Zero(t, store.Len())
+}
+
+func TestMemoryStore_Sweep(t *testing.T) {
+	t.Parallel()
+	clock := &testClock{now: time.Now()}
+	store := NewMemoryStore()
+	store.now = clock.Now
+	ctx := t.Context()
+
+	for range 3 {
+		_, err := store.Save(ctx, "", []byte("x"), time.Minute)
+		require.NoError(t, err)
+	}
+	_, err := store.Save(ctx, "", []byte("x"), 0)
+	require.NoError(t, err)
+	assert.Equal(t, 4, store.Len())
+
+	clock.Advance(2 * time.Minute)
+	_, err = store.Save(ctx, "", []byte("x"), time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, 2, store.Len(), "expired sessions are swept")
+}
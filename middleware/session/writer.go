@@ -0,0 +1,73 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+
+	"rivaas.dev/router"
+)
+
+// sessionWriter commits the session before the response headers are sent,
+// while the session cookie can still be set. It forwards the optional
+// interfaces the router and other middleware use.
+type sessionWriter struct {
+	http.ResponseWriter
+	commit func()
+}
+
+func (w *sessionWriter) WriteHeader(code int) {
+	w.commit()
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *sessionWriter) Write(p []byte) (int, error) {
+	w.commit()
+	return w.ResponseWriter.Write(p)
+}
+
+// Written implements router.WrittenChecker.
+func (w *sessionWriter) Written() bool {
+	if wc, ok := w.ResponseWriter.(router.WrittenChecker); ok {
+		return wc.Written()
+	}
+
+	return false
+}
+
+// Hijack implements http.Hijacker.
+func (w *sessionWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if hijacker, ok := w.ResponseWriter.(http.Hijacker); ok {
+		w.commit()
+		return hijacker.Hijack()
+	}
+
+	return nil, nil, router.ErrResponseWriterNotHijacker
+}
+
+// Flush implements http.Flusher.
+func (w *sessionWriter) Flush() {
+	w.commit()
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap returns the underlying writer for http.ResponseController.
+func (w *sessionWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
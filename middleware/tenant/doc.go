@@ -0,0 +1,59 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tenant provides middleware that resolves the tenant of each
+// request in a multi-tenant service and stores it in the request context.
+//
+// # Basic Usage
+//
+//	import "rivaas.dev/middleware/tenant"
+//
+//	r := router.MustNew()
+//	r.Use(tenant.New(tenant.WithResolver(tenant.FromSubdomain("example.com"))))
+//
+//	r.GET("/orders", func(c *router.Context) {
+//	    orders := store.Orders(c.Request.Context(), tenant.Get(c))
+//	    c.JSON(http.StatusOK, orders)
+//	})
+//
+// # Resolvers
+//
+// A [Resolver] finds the tenant of a request. The package provides:
+//
+//   - [FromSubdomain]: acme.example.com is tenant "acme"
+//   - [FromHeader]: a header set by a gateway, such as X-Tenant-ID
+//   - [FromClaim]: a claim of the authenticated token, such as tenant_id
+//
+// [ResolverFunc] adapts custom lookups, such as custom domains. With several
+// resolvers, the first one that finds a tenant wins. Resolvers return
+// [ErrTenantUnknown] for tenants that don't exist.
+//
+// # Missing Tenants
+//
+// Requests without a tenant are rejected with 400 Bad Request, and unknown
+// tenants with 404 Not Found. [WithRequired] lets requests without a tenant
+// through, and [WithErrorHandler] customizes the responses.
+//
+// # Observability
+//
+// [WithSpanAttribute] adds the tenant to the active span. [WithMetricsLabel]
+// records the tenant.requests counter and the tenant.request.duration
+// histogram per tenant, route, and status code.
+//
+// # Accessing the Tenant
+//
+// Handlers use [Get]. Code that only has a context.Context, such as
+// repositories, uses [FromContext]. [NewContext] sets the tenant for
+// background jobs and tests.
+package tenant
@@ -0,0 +1,71 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package main demonstrates how to use the tenant middleware
+// with subdomain and header resolution.
+package main
+
+import (
+	"log"
+	"net/http"
+
+	"rivaas.dev/middleware/tenant"
+	"rivaas.dev/router"
+)
+
+var tenants = map[string]string{
+	"acme":   "Acme Corp",
+	"globex": "Globex Inc",
+}
+
+// known rejects tenants that don't exist.
+func known(resolver tenant.Resolver) tenant.Resolver {
+	return tenant.ResolverFunc(func(c *router.Context) (string, error) {
+		id, err := resolver.Resolve(c)
+		if err != nil || id == "" {
+			return id, err
+		}
+		if _, ok := tenants[id]; !ok {
+			return "", tenant.ErrTenantUnknown
+		}
+		return id, nil
+	})
+}
+
+func main() {
+	r := router.MustNew()
+
+	r.Use(tenant.New(
+		tenant.WithResolver(
+			known(tenant.FromSubdomain("localhost")),
+			known(tenant.FromHeader("X-Tenant-ID")),
+		),
+		tenant.WithSkipPaths("/health"),
+	))
+
+	r.GET("/", func(c *router.Context) {
+		if err := c.String(http.StatusOK, "Welcome, "+tenants[tenant.Get(c)]); err != nil {
+			log.Printf("write response: %v", err)
+		}
+	})
+	r.GET("/health", func(c *router.Context) {
+		c.NoContent()
+	})
+
+	log.Println("Server starting on :8080")
+	log.Println("  curl -i -H 'Host: acme.localhost' http://localhost:8080/")
+	log.Println("  curl -i -H 'X-Tenant-ID: globex' http://localhost:8080/")
+	log.Println("  curl -i -H 'X-Tenant-ID: initech' http://localhost:8080/")
+	log.Fatal(http.ListenAndServe(":8080", r))
+}
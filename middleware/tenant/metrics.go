@@ -0,0 +1,96 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tenant
+
+import (
+	"log/slog"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"rivaas.dev/router"
+)
+
+// meterName is the instrumentation scope of the middleware's metrics.
+const meterName = "rivaas.dev/middleware/tenant"
+
+// tenantMetrics counts and times requests per tenant.
+type tenantMetrics struct {
+	requests metric.Int64Counter     // nil if the instrument could not be created
+	duration metric.Float64Histogram // nil if the instrument could not be created
+}
+
+// statusCoder is implemented by response writers that track the status code.
+type statusCoder interface {
+	StatusCode() int
+}
+
+// newTenantMetrics creates the per-tenant instruments.
+func newTenantMetrics(cfg *config) *tenantMetrics {
+	mp := cfg.meterProvider
+	if mp == nil {
+		mp = otel.GetMeterProvider()
+	}
+	meter := mp.Meter(meterName)
+
+	requests, err := meter.Int64Counter("tenant.requests",
+		metric.WithDescription("Requests per tenant, by route and status code"),
+		metric.WithUnit("{request}"),
+	)
+	if err != nil {
+		requests = nil
+		slog.Warn("tenant: request counter unavailable", "error", err)
+	}
+	duration, err := meter.Float64Histogram("tenant.request.duration",
+		metric.WithDescription("Duration of requests per tenant, by route and status code"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		duration = nil
+		slog.Warn("tenant: duration histogram unavailable", "error", err)
+	}
+
+	return &tenantMetrics{requests: requests, duration: duration}
+}
+
+// observe runs the rest of the chain and records the request with the
+// tenant attribute.
+func (m *tenantMetrics) observe(c *router.Context, tenantAttr attribute.KeyValue) {
+	sc, ok := c.Response.(statusCoder)
+	if !ok {
+		wrapped := router.NewResponseWriterWrapper(c.Response)
+		c.Response = wrapped
+		sc = wrapped
+	}
+
+	start := time.Now()
+	c.Next()
+
+	attrs := metric.WithAttributes(
+		tenantAttr,
+		attribute.String("http.route", c.RoutePattern()),
+		attribute.String("http.response.status_code", strconv.Itoa(sc.StatusCode())),
+	)
+	ctx := c.Request.Context()
+	if m.requests != nil {
+		m.requests.Add(ctx, 1, attrs)
+	}
+	if m.duration != nil {
+		m.duration.Record(ctx, time.Since(start).Seconds(), attrs)
+	}
+}
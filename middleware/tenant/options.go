@@ -0,0 +1,124 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tenant
+
+import (
+	"go.opentelemetry.io/otel/metric"
+
+	"rivaas.dev/router"
+)
+
+// WithResolver adds resolvers of the tenant. They are tried in order, and
+// the first one that finds a tenant wins. At least one resolver is
+// required.
+//
+// Example:
+//
+//	tenant.New(tenant.WithResolver(
+//	    tenant.FromSubdomain("example.com"),
+//	    tenant.FromHeader("X-Tenant-ID"),
+//	))
+func WithResolver(resolvers ...Resolver) Option {
+	return func(cfg *config) {
+		cfg.resolvers = append(cfg.resolvers, resolvers...)
+	}
+}
+
+// WithRequired sets whether requests must have a tenant. When false,
+// requests without a tenant continue with an empty tenant, for example
+// for a shared landing page.
+// Default: true
+//
+// Example:
+//
+//	tenant.New(tenant.WithResolver(resolver), tenant.WithRequired(false))
+func WithRequired(required bool) Option {
+	return func(cfg *config) {
+		cfg.required = required
+	}
+}
+
+// WithSpanAttribute adds the tenant to the active span under key, so traces
+// can be filtered by tenant.
+//
+// Example:
+//
+//	tenant.New(tenant.WithResolver(resolver), tenant.WithSpanAttribute("tenant.id"))
+func WithSpanAttribute(key string) Option {
+	return func(cfg *config) {
+		cfg.spanAttribute = key
+	}
+}
+
+// WithMetricsLabel records the tenant.requests counter and the
+// tenant.request.duration histogram, labeled with the tenant under key and
+// with the route and status code. Every tenant adds time series, so use it
+// when the number of tenants is bounded.
+//
+// Example:
+//
+//	tenant.New(tenant.WithResolver(resolver), tenant.WithMetricsLabel("tenant.id"))
+func WithMetricsLabel(key string) Option {
+	return func(cfg *config) {
+		cfg.metricsLabel = key
+	}
+}
+
+// WithMeterProvider sets the OpenTelemetry meter provider for the metrics
+// enabled with [WithMetricsLabel].
+// Default: the global provider from otel.GetMeterProvider().
+//
+// Example:
+//
+//	tenant.New(
+//	    tenant.WithResolver(resolver),
+//	    tenant.WithMetricsLabel("tenant.id"),
+//	    tenant.WithMeterProvider(meterProvider),
+//	)
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return func(cfg *config) {
+		cfg.meterProvider = provider
+	}
+}
+
+// WithErrorHandler sets the function that responds to rejected requests. The
+// error wraps [ErrTenantMissing] or [ErrTenantUnknown], or is an error of a
+// resolver. The middleware aborts the chain after the handler returns.
+// Default: 400 Bad Request for a missing tenant, 404 Not Found for an
+// unknown one, and 500 for resolver errors
+//
+// Example:
+//
+//	tenant.New(tenant.WithResolver(resolver), tenant.WithErrorHandler(func(c *router.Context, err error) {
+//	    c.Redirect(http.StatusFound, "https://example.com/signup")
+//	}))
+func WithErrorHandler(handler func(c *router.Context, err error)) Option {
+	return func(cfg *config) {
+		cfg.errorHandler = handler
+	}
+}
+
+// WithSkipPaths sets paths that need no tenant.
+//
+// Example:
+//
+//	tenant.New(tenant.WithResolver(resolver), tenant.WithSkipPaths("/health"))
+func WithSkipPaths(paths ...string) Option {
+	return func(cfg *config) {
+		for _, path := range paths {
+			cfg.skipPaths[path] = true
+		}
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tenant
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"rivaas.dev/router"
+)
+
+// Resolver finds the tenant of a request. Resolve returns the tenant ID, or
+// an empty string if the request names no tenant. It returns an error
+// wrapping [ErrTenantUnknown] if the request names a tenant that doesn't
+// exist, or any other error if the lookup itself failed.
+type Resolver interface {
+	Resolve(c *router.Context) (string, error)
+}
+
+// ResolverFunc adapts a function to a [Resolver], for custom lookups such as
+// a custom domain table.
+//
+// Example:
+//
+//	tenant.ResolverFunc(func(c *router.Context) (string, error) {
+//	    id, err := db.TenantByDomain(c.Request.Context(), c.Hostname())
+//	    if errors.Is(err, sql.ErrNoRows) {
+//	        return "", tenant.ErrTenantUnknown
+//	    }
+//	    return id, err
+//	})
+type ResolverFunc func(c *router.Context) (string, error)
+
+// Resolve implements [Resolver].
+func (f ResolverFunc) Resolve(c *router.Context) (string, error) {
+	return f(c)
+}
+
+// FromSubdomain returns a resolver that takes the tenant from the subdomain
+// of baseDomain: acme.example.com is tenant "acme" for the base domain
+// example.com. The base domain itself and deeper subdomains name no tenant.
+//
+// Example:
+//
+//	tenant.WithResolver(tenant.FromSubdomain("example.com"))
+func FromSubdomain(baseDomain string) Resolver {
+	suffix := "." + strings.ToLower(strings.Trim(baseDomain, "."))
+
+	return ResolverFunc(func(c *router.Context) (string, error) {
+		host := c.Request.Host
+		if name, _, err := net.SplitHostPort(host); err == nil {
+			host = name
+		}
+		subdomain, ok := strings.CutSuffix(strings.ToLower(host), suffix)
+		if !ok || subdomain == "" || strings.Contains(subdomain, ".") {
+			return "", nil
+		}
+
+		return subdomain, nil
+	})
+}
+
+// FromHeader returns a resolver that takes the tenant from the named
+// request header. Clients can send any value, so use it behind a gateway
+// that sets the header, or check the tenant against the authenticated
+// user.
+//
+// Example:
+//
+//	tenant.WithResolver(tenant.FromHeader("X-Tenant-ID"))
+func FromHeader(name string) Resolver {
+	return ResolverFunc(func(c *router.Context) (string, error) {
+		return strings.TrimSpace(c.Request.Header.Get(name)), nil
+	})
+}
+
+// FromClaim returns a resolver that takes the tenant from a claim of the
+// authenticated token. claims returns the claims of the request, such as
+// jwtauth.Claims; register the tenant middleware after the authentication
+// middleware. String and number claims are supported.
+//
+// Example:
+//
+//	tenant.WithResolver(tenant.FromClaim("tenant_id", func(c *router.Context) map[string]any {
+//	    return jwtauth.Claims(c)
+//	}))
+func FromClaim(claim string, claims func(c *router.Context) map[string]any) Resolver {
+	return ResolverFunc(func(c *router.Context) (string, error) {
+		switch value := claims(c)[claim].(type) {
+		case nil:
+			return "", nil
+		case string:
+			return value, nil
+		case float64, int, int64, fmt.Stringer:
+			return fmt.Sprint(value), nil
+		default:
+			return "", fmt.Errorf("%w: claim %q has type %T", ErrTenantUnknown, claim, value)
+		}
+	})
+}
@@ -0,0 +1,214 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tenant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+
+	"rivaas.dev/router"
+)
+
+// Errors passed to the error handler when a request is rejected.
+var (
+	// ErrTenantMissing is returned when no resolver finds a tenant and a
+	// tenant is required.
+	ErrTenantMissing = errors.New("tenant: tenant missing")
+
+	// ErrTenantUnknown is returned by resolvers when the request names a
+	// tenant that doesn't exist.
+	ErrTenantUnknown = errors.New("tenant: tenant unknown")
+)
+
+type contextKey struct{}
+
+// Option defines functional options for tenant middleware configuration.
+type Option func(*config)
+
+// config holds the configuration for the tenant middleware.
+type config struct {
+	// resolvers are tried in order until one finds the tenant
+	resolvers []Resolver
+
+	// required rejects requests without a tenant
+	required bool
+
+	// spanAttribute is the span attribute key of the tenant; empty disables it
+	spanAttribute string
+
+	// metricsLabel is the metrics attribute key of the tenant; empty disables metrics
+	metricsLabel string
+
+	// meterProvider provides the meter for per-tenant metrics
+	meterProvider metric.MeterProvider
+
+	// errorHandler is called when a request is rejected
+	errorHandler func(c *router.Context, err error)
+
+	// skipPaths are paths that need no tenant
+	skipPaths map[string]bool
+}
+
+// defaultConfig returns the default configuration for tenant middleware.
+func defaultConfig() *config {
+	return &config{
+		required:     true,
+		errorHandler: defaultErrorHandler,
+		skipPaths:    make(map[string]bool),
+	}
+}
+
+// defaultErrorHandler sends 400 Bad Request for requests without a tenant,
+// 404 Not Found for unknown tenants, and 500 if a resolver failed.
+func defaultErrorHandler(c *router.Context, err error) {
+	switch {
+	case errors.Is(err, ErrTenantMissing):
+		c.WriteErrorResponse(http.StatusBadRequest, "tenant missing")
+	case errors.Is(err, ErrTenantUnknown):
+		c.WriteErrorResponse(http.StatusNotFound, "tenant not found")
+	default:
+		c.WriteErrorResponse(http.StatusInternalServerError, "tenant resolution failed")
+	}
+}
+
+// New returns a middleware that resolves the tenant of each request with
+// the configured resolvers, and stores it in the request context for [Get]
+// and [FromContext].
+//
+// Requests for which no resolver finds a tenant are rejected with 400 Bad
+// Request, unless [WithRequired] is false. With [WithSpanAttribute], the
+// tenant is added to the active span, and with [WithMetricsLabel], requests
+// are counted and timed per tenant.
+//
+// New panics if no resolver is configured.
+//
+// Example:
+//
+//	r := router.MustNew()
+//	r.Use(tenant.New(
+//	    tenant.WithResolver(tenant.FromSubdomain("example.com"), tenant.FromHeader("X-Tenant-ID")),
+//	    tenant.WithSpanAttribute("tenant.id"),
+//	))
+func New(opts ...Option) router.HandlerFunc {
+	cfg := defaultConfig()
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	if len(cfg.resolvers) == 0 {
+		panic("tenant: a resolver is required, use WithResolver")
+	}
+
+	var m *tenantMetrics
+	if cfg.metricsLabel != "" {
+		m = newTenantMetrics(cfg)
+	}
+
+	return func(c *router.Context) {
+		if cfg.skipPaths[c.Request.URL.Path] {
+			c.Next()
+			return
+		}
+
+		id, err := cfg.resolve(c)
+		if err == nil && id == "" && cfg.required {
+			err = ErrTenantMissing
+		}
+		if err != nil {
+			cfg.errorHandler(c, err)
+			c.Abort()
+
+			return
+		}
+		if id == "" {
+			c.Next()
+			return
+		}
+
+		ctx := NewContext(c.Request.Context(), id)
+		c.Request = c.Request.WithContext(ctx)
+		if cfg.spanAttribute != "" {
+			if span := trace.SpanFromContext(ctx); span.IsRecording() {
+				span.SetAttributes(attribute.String(cfg.spanAttribute, id))
+			}
+		}
+
+		if m == nil {
+			c.Next()
+			return
+		}
+		m.observe(c, attribute.String(cfg.metricsLabel, id))
+	}
+}
+
+// resolve returns the tenant found by the first resolver that finds one.
+func (cfg *config) resolve(c *router.Context) (string, error) {
+	for _, resolver := range cfg.resolvers {
+		id, err := resolver.Resolve(c)
+		if err != nil {
+			if errors.Is(err, ErrTenantUnknown) {
+				return "", err
+			}
+			return "", fmt.Errorf("tenant: resolve: %w", err)
+		}
+		if id != "" {
+			return id, nil
+		}
+	}
+
+	return "", nil
+}
+
+// Get returns the tenant of the request, or an empty string if it has none.
+//
+// Example:
+//
+//	func handler(c *router.Context) {
+//	    orders, err := store.Orders(c.Request.Context(), tenant.Get(c))
+//	    ...
+//	}
+func Get(c *router.Context) string {
+	return FromContext(c.Request.Context())
+}
+
+// FromContext returns the tenant stored in ctx, or an empty string. Use it
+// in code that has a context but no router.Context, such as repositories
+// that scope queries to the tenant.
+//
+// Example:
+//
+//	func (r *Repo) Orders(ctx context.Context) ([]Order, error) {
+//	    return r.query(ctx, "SELECT * FROM orders WHERE tenant_id = $1", tenant.FromContext(ctx))
+//	}
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
+
+// NewContext returns a copy of ctx that carries the tenant id, for work
+// outside of requests such as background jobs and tests.
+//
+// Example:
+//
+//	ctx := tenant.NewContext(context.Background(), job.TenantID)
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
@@ -0,0 +1,258 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package tenant
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"rivaas.dev/router"
+)
+
+// echoTenant responds with the tenant of the router context and of the
+// request context.
+func echoTenant(c *router.Context) {
+	//nolint:errcheck // Test handler
+	c.String(http.StatusOK, Get(c)+"|"+FromContext(c.Request.Context()))
+}
+
+func request(r http.Handler, host, path string, header http.Header) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.Host = host
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	return w
+}
+
+func TestTenant_Resolvers(t *testing.T) {
+	t.Parallel()
+	r := router.MustNew()
+	r.Use(New(WithResolver(FromSubdomain("example.com"), FromHeader("X-Tenant-ID"))))
+	r.GET("/orders", echoTenant)
+
+	tests := []struct {
+		name       string
+		host       string
+		header     http.Header
+		wantStatus int
+		wantTenant string
+	}{
+		{name: "subdomain", host: "acme.example.com", wantStatus: http.StatusOK, wantTenant: "acme"},
+		{name: "subdomain with port", host: "Acme.Example.com:8080", wantStatus: http.StatusOK, wantTenant: "acme"},
+		{name: "subdomain before header", host: "acme.example.com", header: http.Header{"X-Tenant-Id": {"globex"}},
+			wantStatus: http.StatusOK, wantTenant: "acme"},
+		{name: "header", host: "example.com", header: http.Header{"X-Tenant-Id": {"globex"}},
+			wantStatus: http.StatusOK, wantTenant: "globex"},
+		{name: "deep subdomain", host: "a.b.example.com", wantStatus: http.StatusBadRequest},
+		{name: "other domain", host: "acme.example.org", wantStatus: http.StatusBadRequest},
+		{name: "none", host: "example.com", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			w := request(r, tt.host, "/orders", tt.header)
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantTenant != "" {
+				assert.Equal(t, tt.wantTenant+"|"+tt.wantTenant, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestTenant_FromClaim(t *testing.T) {
+	t.Parallel()
+	claims := map[string]any{"tenant_id": "acme", "org": float64(42), "bad": []string{"x"}}
+	claimsFunc := func(*router.Context) map[string]any { return claims }
+
+	tests := []struct {
+		claim      string
+		wantStatus int
+		wantBody   string
+	}{
+		{claim: "tenant_id", wantStatus: http.StatusOK, wantBody: "acme|acme"},
+		{claim: "org", wantStatus: http.StatusOK, wantBody: "42|42"},
+		{claim: "missing", wantStatus: http.StatusBadRequest},
+		{claim: "bad", wantStatus: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		r := router.MustNew()
+		r.Use(New(WithResolver(FromClaim(tt.claim, claimsFunc))))
+		r.GET("/orders", echoTenant)
+		w := request(r, "example.com", "/orders", nil)
+		assert.Equal(t, tt.wantStatus, w.Code, tt.claim)
+		if tt.wantBody != "" {
+			assert.Equal(t, tt.wantBody, w.Body.String())
+		}
+	}
+}
+
+func TestTenant_Optional(t *testing.T) {
+	t.Parallel()
+	r := router.MustNew()
+	r.Use(New(WithResolver(FromHeader("X-Tenant-ID")), WithRequired(false)))
+	r.GET("/orders", echoTenant)
+
+	w := request(r, "example.com", "/orders", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "|", w.Body.String())
+}
+
+func TestTenant_Errors(t *testing.T) {
+	t.Parallel()
+	errDown := errors.New("database down")
+	var gotErr error
+	errorHandler := WithErrorHandler(func(c *router.Context, err error) {
+		gotErr = err
+		c.WriteErrorResponse(http.StatusTeapot, "")
+	})
+
+	r := router.MustNew()
+	r.Use(New(WithResolver(ResolverFunc(func(*router.Context) (string, error) {
+		return "", errDown
+	}))))
+	r.GET("/orders", echoTenant)
+	assert.Equal(t, http.StatusInternalServerError, request(r, "example.com", "/orders", nil).Code)
+
+	r = router.MustNew()
+	r.Use(New(errorHandler, WithResolver(ResolverFunc(func(*router.Context) (string, error) {
+		return "", errDown
+	}))))
+	r.GET("/orders", echoTenant)
+	assert.Equal(t, http.StatusTeapot, request(r, "example.com", "/orders", nil).Code)
+	require.ErrorIs(t, gotErr, errDown)
+
+	r = router.MustNew()
+	r.Use(New(errorHandler, WithResolver(ResolverFunc(func(*router.Context) (string, error) {
+		return "", ErrTenantUnknown
+	}), FromHeader("X-Tenant-ID"))))
+	r.GET("/orders", echoTenant)
+	request(r, "example.com", "/orders", http.Header{"X-Tenant-Id": {"acme"}})
+	require.ErrorIs(t, gotErr, ErrTenantUnknown, "unknown tenants stop resolution")
+
+	r = router.MustNew()
+	r.Use(New(errorHandler, WithResolver(FromHeader("X-Tenant-ID"))))
+	r.GET("/orders", echoTenant)
+	request(r, "example.com", "/orders", nil)
+	require.ErrorIs(t, gotErr, ErrTenantMissing)
+}
+
+func TestTenant_SkipPaths(t *testing.T) {
+	t.Parallel()
+	r := router.MustNew()
+	r.Use(New(WithResolver(FromHeader("X-Tenant-ID")), WithSkipPaths("/orders")))
+	r.GET("/orders", echoTenant)
+	assert.Equal(t, http.StatusOK, request(r, "example.com", "/orders", nil).Code)
+}
+
+func TestTenant_SpanAttribute(t *testing.T) {
+	t.Parallel()
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test")
+
+	r := router.MustNew()
+	r.Use(func(c *router.Context) {
+		ctx, span := tracer.Start(c.Request.Context(), "request")
+		defer span.End()
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	})
+	r.Use(New(WithResolver(FromHeader("X-Tenant-ID")), WithSpanAttribute("tenant.id")))
+	r.GET("/orders", func(c *router.Context) { c.NoContent() })
+
+	request(r, "example.com", "/orders", http.Header{"X-Tenant-Id": {"acme"}})
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Contains(t, spans[0].Attributes(), attribute.String("tenant.id", "acme"))
+}
+
+func TestTenant_Metrics(t *testing.T) {
+	t.Parallel()
+	reader := sdkmetric.NewManualReader()
+	r := router.MustNew()
+	r.Use(New(
+		WithResolver(FromHeader("X-Tenant-ID")),
+		WithMetricsLabel("tenant.id"),
+		WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))),
+	))
+	r.GET("/orders", echoTenant)
+	r.GET("/fail", func(c *router.Context) {
+		c.WriteErrorResponse(http.StatusInternalServerError, "boom")
+	})
+
+	for _, req := range []struct{ tenant, path string }{
+		{"acme", "/orders"}, {"acme", "/orders"}, {"acme", "/fail"}, {"globex", "/orders"}, {"", "/orders"},
+	} {
+		header := http.Header{}
+		if req.tenant != "" {
+			header.Set("X-Tenant-ID", req.tenant)
+		}
+		request(r, "example.com", req.path, header)
+	}
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(t.Context(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	assert.Equal(t, meterName, rm.ScopeMetrics[0].Scope.Name)
+
+	counts := make(map[string]int64)
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		if m.Name != "tenant.requests" {
+			assert.Equal(t, "tenant.request.duration", m.Name)
+			continue
+		}
+		sum, ok := m.Data.(metricdata.Sum[int64])
+		require.True(t, ok)
+		for _, dp := range sum.DataPoints {
+			id, _ := dp.Attributes.Value("tenant.id")
+			route, _ := dp.Attributes.Value("http.route")
+			status, _ := dp.Attributes.Value("http.response.status_code")
+			counts[id.AsString()+" "+route.AsString()+" "+status.AsString()] = dp.Value
+		}
+	}
+	assert.Equal(t, map[string]int64{
+		"acme /orders 200":   2,
+		"acme /fail 500":     1,
+		"globex /orders 200": 1,
+	}, counts)
+}
+
+func TestNewContext(t *testing.T) {
+	t.Parallel()
+	assert.Empty(t, FromContext(context.Background()))
+	assert.Equal(t, "acme", FromContext(NewContext(context.Background(), "acme")))
+}
+
+func TestNew_PanicsWithoutResolver(t *testing.T) {
+	t.Parallel()
+	assert.Panics(t, func() { New() })
+}
@@ -0,0 +1,189 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package timeout
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"rivaas.dev/router"
+)
+
+// budgetKey is the context key under which a budgetContext finds itself.
+type budgetKey struct{}
+
+// budgetContext is the request context installed by the middleware. Unlike a
+// context from context.WithTimeout, its deadline can be moved while the handler
+// chain runs, which is how [ForRoute] overrides the middleware's duration.
+//
+// It is done when its own deadline passes (Err returns
+// context.DeadlineExceeded) or when the parent context is done (Err returns the
+// parent's error).
+type budgetContext struct {
+	context.Context // parent; serves values and parent deadlines
+
+	start      time.Time
+	done       chan struct{}
+	stopParent func() bool
+
+	mu       sync.Mutex
+	err      error
+	duration time.Duration
+	limit    time.Duration // upper bound for setDuration (e.g. from a deadline header); 0 means none
+	timer    *time.Timer
+
+	// onChange, if set, is called after setDuration with the new total budget
+	// and the time left of it, e.g. to rewrite the budget header.
+	onChange func(total, left time.Duration)
+}
+
+// newBudgetContext returns a context that expires d after now. A positive
+// limit caps later changes of the duration. The caller must call release once
+// the request is finished.
+func newBudgetContext(parent context.Context, d, limit time.Duration) *budgetContext {
+	b := &budgetContext{
+		Context:  parent,
+		start:    time.Now(),
+		done:     make(chan struct{}),
+		duration: d,
+		limit:    limit,
+	}
+	b.timer = time.AfterFunc(d, b.expire)
+	b.stopParent = context.AfterFunc(parent, func() { b.finish(parent.Err()) })
+	return b
+}
+
+// Deadline returns the earlier of the budget's deadline and the parent's.
+func (b *budgetContext) Deadline() (time.Time, bool) {
+	b.mu.Lock()
+	deadline := b.start.Add(b.duration)
+	b.mu.Unlock()
+
+	if parent, ok := b.Context.Deadline(); ok && parent.Before(deadline) {
+		return parent, true
+	}
+	return deadline, true
+}
+
+// Done returns a channel closed when the budget is exhausted or the parent is done.
+func (b *budgetContext) Done() <-chan struct{} {
+	return b.done
+}
+
+// Err returns nil until Done is closed, then the reason.
+func (b *budgetContext) Err() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.err
+}
+
+// Value returns b for budgetKey and delegates other keys to the parent.
+func (b *budgetContext) Value(key any) any {
+	if key == (budgetKey{}) {
+		return b
+	}
+	return b.Context.Value(key)
+}
+
+// Duration returns the current total budget, measured from the request start.
+func (b *budgetContext) Duration() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.duration
+}
+
+// setDuration moves the deadline to d after the request start, capped by the
+// limit. It reports false if the budget is already exhausted.
+func (b *budgetContext) setDuration(d time.Duration) bool {
+	b.mu.Lock()
+	if b.err != nil {
+		b.mu.Unlock()
+		return false
+	}
+	if b.limit > 0 && d > b.limit {
+		d = b.limit
+	}
+	b.duration = d
+	left := time.Until(b.start.Add(d))
+	b.timer.Reset(left)
+	b.mu.Unlock()
+
+	if b.onChange != nil {
+		b.onChange(d, max(left, 0))
+	}
+	return true
+}
+
+// expire runs when the timer fires. A timer that fired just before
+// setDuration moved the deadline is ignored.
+func (b *budgetContext) expire() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if time.Now().Before(b.start.Add(b.duration)) {
+		return
+	}
+	b.finishLocked(context.DeadlineExceeded)
+}
+
+// finish closes Done with err unless it is already closed.
+func (b *budgetContext) finish(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.finishLocked(err)
+}
+
+func (b *budgetContext) finishLocked(err error) {
+	if b.err != nil {
+		return
+	}
+	b.err = err
+	close(b.done)
+}
+
+// release stops the timer and parent watcher.
+func (b *budgetContext) release() {
+	b.timer.Stop()
+	b.stopParent()
+}
+
+// budgetFrom returns the middleware's budget context from ctx, if any.
+func budgetFrom(ctx context.Context) (*budgetContext, bool) {
+	b, ok := ctx.Value(budgetKey{}).(*budgetContext)
+	return b, ok
+}
+
+// ForRoute returns a route handler that changes the timeout of the matched
+// route to d, measured from the start of the request. A budget announced by the
+// client (see [WithDeadlineHeader]) still caps d. Place it before the
+// route's handler; it requires the middleware from [New] earlier in the chain
+// and does nothing otherwise.
+//
+// Use it for slow endpoints (reports, exports) that need more time than the
+// global default without being skipped entirely. The new deadline also applies
+// to the handler's context (c.Request.Context()).
+//
+// Example:
+//
+//	r.Use(timeout.New(timeout.WithDuration(5 * time.Second)))
+//	r.GET("/reports/:id", timeout.ForRoute(2*time.Minute), exportReport)
+func ForRoute(d time.Duration) router.HandlerFunc {
+	return func(c *router.Context) {
+		if b, ok := budgetFrom(c.Request.Context()); ok && d > 0 {
+			b.setDuration(d)
+		}
+		c.Next()
+	}
+}
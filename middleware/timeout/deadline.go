@@ -0,0 +1,116 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package timeout
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GRPCTimeoutHeader is the gRPC deadline header. Values use the gRPC timeout
+// syntax: up to 8 digits followed by a unit (H, M, S, m, u, n), e.g. "1500m".
+const GRPCTimeoutHeader = "Grpc-Timeout"
+
+// Remaining returns the time left until the deadline of ctx. It reports false
+// if ctx has no deadline. The result is never negative.
+//
+// Example:
+//
+//	if left, ok := timeout.Remaining(ctx); ok && left < time.Second {
+//	    return errNotEnoughTime
+//	}
+func Remaining(ctx context.Context) (time.Duration, bool) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return 0, false
+	}
+	return max(time.Until(deadline), 0), true
+}
+
+// PropagateDeadline sets header name on h to the time remaining until the
+// deadline of ctx, so a downstream service can budget its own work. It does
+// nothing if ctx has no deadline. Values are formatted like the header
+// expects: gRPC timeout syntax for [GRPCTimeoutHeader], a Go duration string
+// (e.g. "1.5s") otherwise.
+//
+// Example:
+//
+//	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+//	timeout.PropagateDeadline(ctx, req.Header, "X-Request-Timeout")
+func PropagateDeadline(ctx context.Context, h http.Header, name string) {
+	if left, ok := Remaining(ctx); ok {
+		h.Set(name, formatTimeout(name, left))
+	}
+}
+
+// parseTimeout parses the value of deadline header name. [GRPCTimeoutHeader]
+// uses the gRPC syntax; other headers accept a Go duration ("2.5s", "500ms")
+// or whole seconds ("3").
+func parseTimeout(name, value string) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if strings.EqualFold(name, GRPCTimeoutHeader) {
+		return parseGRPCTimeout(value)
+	}
+	if secs, err := strconv.ParseUint(value, 10, 32); err == nil {
+		return time.Duration(secs) * time.Second, true
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return 0, false
+	}
+	return d, true
+}
+
+// grpcUnits maps gRPC timeout units to durations.
+var grpcUnits = map[byte]time.Duration{
+	'H': time.Hour,
+	'M': time.Minute,
+	'S': time.Second,
+	'm': time.Millisecond,
+	'u': time.Microsecond,
+	'n': time.Nanosecond,
+}
+
+func parseGRPCTimeout(value string) (time.Duration, bool) {
+	if len(value) < 2 || len(value) > 9 {
+		return 0, false
+	}
+	unit, ok := grpcUnits[value[len(value)-1]]
+	if !ok {
+		return 0, false
+	}
+	n, err := strconv.ParseUint(value[:len(value)-1], 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	if limit := uint64(1<<63-1) / uint64(unit); n > limit {
+		return time.Duration(1<<63 - 1), true
+	}
+	return time.Duration(n) * unit, true
+}
+
+// formatTimeout formats d for deadline header name; see parseTimeout.
+func formatTimeout(name string, d time.Duration) string {
+	if strings.EqualFold(name, GRPCTimeoutHeader) {
+		return strconv.FormatInt(d.Milliseconds(), 10) + "m"
+	}
+	return d.Truncate(time.Millisecond).String()
+}
@@ -0,0 +1,168 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package timeout
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"rivaas.dev/router"
+)
+
+func TestParseTimeout(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		header string
+		value  string
+		want   time.Duration
+		ok     bool
+	}{
+		{"X-Request-Timeout", "3", 3 * time.Second, true},
+		{"X-Request-Timeout", "1.5s", 1500 * time.Millisecond, true},
+		{"X-Request-Timeout", "250ms", 250 * time.Millisecond, true},
+		{"X-Request-Timeout", "-1s", 0, false},
+		{"X-Request-Timeout", "soon", 0, false},
+		{"X-Request-Timeout", "", 0, false},
+		{"grpc-timeout", "1500m", 1500 * time.Millisecond, true},
+		{"Grpc-Timeout", "2S", 2 * time.Second, true},
+		{"Grpc-Timeout", "1H", time.Hour, true},
+		{"Grpc-Timeout", "99999999H", time.Duration(1<<63 - 1), true},
+		{"Grpc-Timeout", "5s", 0, false},
+		{"Grpc-Timeout", "123456789S", 0, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := parseTimeout(tt.header, tt.value)
+		assert.Equal(t, tt.ok, ok, "%s: %q", tt.header, tt.value)
+		assert.Equal(t, tt.want, got, "%s: %q", tt.header, tt.value)
+	}
+}
+
+func TestFormatTimeout(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "1.5s", formatTimeout("X-Request-Timeout", 1500*time.Millisecond+42))
+	assert.Equal(t, "1500m", formatTimeout(GRPCTimeoutHeader, 1500*time.Millisecond))
+}
+
+func TestTimeout_DeadlineHeader(t *testing.T) {
+	t.Parallel()
+
+	var budget string
+	r := router.MustNew()
+	r.Use(New(
+		WithDuration(time.Second),
+		WithoutLogging(),
+		WithDeadlineHeader("X-Request-Timeout"),
+		WithBudgetHeader("X-Request-Timeout"),
+	))
+	r.GET("/fast", func(c *router.Context) {
+		budget = c.Request.Header.Get("X-Request-Timeout")
+		c.Status(http.StatusOK)
+	})
+	r.GET("/slow", waitOrCancel(200*time.Millisecond))
+	r.GET("/extended", ForRoute(time.Minute), waitOrCancel(200*time.Millisecond))
+
+	t.Run("budget caps the timeout", func(t *testing.T) {
+		t.Parallel()
+		req := httptest.NewRequest(http.MethodGet, "/slow", nil)
+		req.Header.Set("X-Request-Timeout", "30ms")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusRequestTimeout, w.Code)
+		assert.Equal(t, "30ms", w.Header().Get("X-Request-Timeout"))
+	})
+
+	t.Run("budget caps ForRoute", func(t *testing.T) {
+		t.Parallel()
+		req := httptest.NewRequest(http.MethodGet, "/extended", nil)
+		req.Header.Set("X-Request-Timeout", "30ms")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusRequestTimeout, w.Code)
+	})
+
+	t.Run("ForRoute rewrites the budget header", func(t *testing.T) {
+		t.Parallel()
+		var forwarded string
+		rr := router.MustNew()
+		rr.Use(New(
+			WithDuration(time.Second),
+			WithoutLogging(),
+			WithBudgetHeader("X-Request-Timeout"),
+		))
+		rr.GET("/export", ForRoute(time.Minute), func(c *router.Context) {
+			forwarded = c.Request.Header.Get("X-Request-Timeout")
+			c.Status(http.StatusOK)
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/export", nil)
+		w := httptest.NewRecorder()
+		rr.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "1m0s", w.Header().Get("X-Request-Timeout"))
+		left, err := time.ParseDuration(forwarded)
+		require.NoError(t, err)
+		assert.InDelta(t, time.Minute, left, float64(time.Second), "time left is forwarded on the request")
+	})
+
+	t.Run("budget never extends the timeout", func(t *testing.T) {
+		t.Parallel()
+		req := httptest.NewRequest(http.MethodGet, "/slow", nil)
+		req.Header.Set("X-Request-Timeout", "1h")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "1s", w.Header().Get("X-Request-Timeout"))
+	})
+
+	t.Run("malformed budget is ignored", func(t *testing.T) {
+		t.Parallel()
+		req := httptest.NewRequest(http.MethodGet, "/fast", nil)
+		req.Header.Set("X-Request-Timeout", "whenever")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "1s", budget, "budget is forwarded on the request")
+	})
+}
+
+func TestPropagateDeadline(t *testing.T) {
+	t.Parallel()
+
+	h := http.Header{}
+	PropagateDeadline(context.Background(), h, "X-Request-Timeout")
+	assert.Empty(t, h)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	PropagateDeadline(ctx, h, GRPCTimeoutHeader)
+	got, ok := parseTimeout(GRPCTimeoutHeader, h.Get(GRPCTimeoutHeader))
+	require.True(t, ok)
+	assert.InDelta(t, time.Minute, got, float64(time.Second))
+
+	left, ok := Remaining(ctx)
+	require.True(t, ok)
+	assert.LessOrEqual(t, left, time.Minute)
+}
@@ -39,6 +39,49 @@
 //   - SkipPrefix: Path prefixes to exclude from timeout
 //   - SkipSuffix: Path suffixes to exclude from timeout
 //   - Skip: Custom function to determine if timeout should be skipped
+//   - RouteDuration: Timeout for a single route pattern
+//   - DeadlineHeader: Incoming header whose budget shortens the timeout
+//   - BudgetHeader: Header echoing the granted budget on request and response
+//   - WarnAfter: Soft threshold for slow-request warnings (default: disabled)
+//   - MeterProvider: Meter provider for the slow-request counter
+//
+// # Per-Route Timeouts
+//
+// Slow endpoints can get a different timeout without being skipped entirely,
+// either by route pattern or with [ForRoute] in the route's handler chain:
+//
+//	r.Use(timeout.New(
+//	    timeout.WithDuration(5 * time.Second),
+//	    timeout.WithRouteDuration("GET /exports/:id", time.Minute),
+//	))
+//	r.GET("/reports/:id", timeout.ForRoute(2*time.Minute), exportReport)
+//
+// # Deadline Propagation
+//
+// With [WithDeadlineHeader], a budget sent by the caller (e.g. "X-Request-Timeout: 2s"
+// or "grpc-timeout: 1500m") shortens the timeout; the configured duration stays
+// the maximum. [WithBudgetHeader] echoes the granted budget, and
+// [PropagateDeadline] writes the time left into outgoing request headers:
+//
+//	r.Use(timeout.New(
+//	    timeout.WithDuration(10 * time.Second),
+//	    timeout.WithDeadlineHeader("X-Request-Timeout"),
+//	))
+//
+//	timeout.PropagateDeadline(ctx, outReq.Header, "X-Request-Timeout")
+//
+// # Slow Request Warnings
+//
+// [WithWarnAfter] reports requests that are still running after a soft
+// threshold: a "slow request" log entry, a "request.slow" event on the active
+// trace span, and the "http.server.request.slow" counter (attributes
+// http.request.method and http.route). The request keeps running until it
+// finishes or hits the timeout.
+//
+//	r.Use(timeout.New(
+//	    timeout.WithDuration(30 * time.Second),
+//	    timeout.WithWarnAfter(10 * time.Second),
+//	))
 //
 // # Timeout Behavior
 //
@@ -103,5 +146,6 @@
 //	    }
 //	}
 //
-// Timeout enforcement uses context.WithTimeout, which is standard Go practice.
+// The request context carries a regular deadline (ctx.Deadline()), so contexts
+// derived from it, database drivers, and HTTP clients observe the timeout.
 package timeout
@@ -19,6 +19,8 @@ import (
 	"log/slog"
 	"time"
 
+	"go.opentelemetry.io/otel/metric"
+
 	"rivaas.dev/router"
 )
 
@@ -34,6 +36,98 @@ func WithDuration(d time.Duration) Option {
 	}
 }
 
+// WithRouteDuration sets the timeout for one route, overriding [WithDuration].
+// route is the registered route pattern ("/reports/:id"), optionally prefixed
+// with a method ("GET /reports/:id"); the method-specific entry wins.
+// To set the timeout where the route is registered, use [ForRoute] instead.
+//
+// Example:
+//
+//	timeout.New(
+//	    timeout.WithDuration(5 * time.Second),
+//	    timeout.WithRouteDuration("GET /exports/:id", 2*time.Minute),
+//	)
+func WithRouteDuration(route string, d time.Duration) Option {
+	return func(cfg *config) {
+		if cfg.routeDurations == nil {
+			cfg.routeDurations = make(map[string]time.Duration)
+		}
+		cfg.routeDurations[route] = d
+	}
+}
+
+// WithDeadlineHeader honors a time budget sent by the client in header name,
+// such as "X-Request-Timeout" or [GRPCTimeoutHeader]. The budget shortens the
+// configured timeout but never extends it, so the configured duration (and any
+// per-route override) acts as the maximum. Missing or malformed values are
+// ignored.
+//
+// gRPC headers use the gRPC timeout syntax ("1500m"); other headers accept a Go
+// duration ("2.5s") or whole seconds ("3").
+//
+// Example:
+//
+//	timeout.New(
+//	    timeout.WithDuration(30 * time.Second),
+//	    timeout.WithDeadlineHeader("X-Request-Timeout"),
+//	)
+func WithDeadlineHeader(name string) Option {
+	return func(cfg *config) {
+		cfg.deadlineHeader = name
+	}
+}
+
+// WithBudgetHeader sets header name on the request and the response to the
+// budget granted to the request, formatted like [WithDeadlineHeader] expects.
+// Handlers that forward request headers pass the budget on to downstream
+// services; clients learn the server's timeout. [ForRoute] rewrites both: the
+// request header to the time left of the new budget, the response header to
+// its total. For the time left at the moment of a downstream call, use
+// [PropagateDeadline].
+//
+// Example:
+//
+//	timeout.New(timeout.WithBudgetHeader("X-Request-Timeout"))
+func WithBudgetHeader(name string) Option {
+	return func(cfg *config) {
+		cfg.budgetHeader = name
+	}
+}
+
+// WithWarnAfter reports requests that are still running after d, giving early
+// signal before they are canceled at the full timeout. Each such request is
+// logged (unless logging is disabled), recorded as a "request.slow" event on
+// the active trace span, and counted in the "http.server.request.slow" metric.
+// Default: disabled.
+//
+// Example:
+//
+//	timeout.New(
+//	    timeout.WithDuration(30 * time.Second),
+//	    timeout.WithWarnAfter(10 * time.Second),
+//	)
+func WithWarnAfter(d time.Duration) Option {
+	return func(cfg *config) {
+		cfg.warnAfter = d
+	}
+}
+
+// WithMeterProvider sets the OpenTelemetry meter provider for the slow-request
+// counter enabled by [WithWarnAfter].
+// Default: the global provider from otel.GetMeterProvider().
+//
+// Example:
+//
+//	timeout.New(
+//	    timeout.WithWarnAfter(10 * time.Second),
+//	    timeout.WithMeterProvider(meterProvider),
+//	)
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return func(cfg *config) {
+		cfg.meterProvider = mp
+	}
+}
+
 // WithoutLogging disables timeout logging.
 // By default, timeouts are logged using slog.Default().
 //
@@ -0,0 +1,99 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package timeout
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+
+	"rivaas.dev/router"
+)
+
+// meterName is the instrumentation scope of the middleware's metrics.
+const meterName = "rivaas.dev/middleware/timeout"
+
+// slowRequestReporter reports requests that cross the WithWarnAfter threshold.
+type slowRequestReporter struct {
+	threshold time.Duration
+	logger    *slog.Logger
+	counter   metric.Int64Counter // nil if the instrument could not be created
+}
+
+// newSlowRequestReporter returns nil when WithWarnAfter is not configured.
+func newSlowRequestReporter(cfg *config) *slowRequestReporter {
+	if cfg.warnAfter <= 0 {
+		return nil
+	}
+
+	mp := cfg.meterProvider
+	if mp == nil {
+		mp = otel.GetMeterProvider()
+	}
+	counter, err := mp.Meter(meterName).Int64Counter("http.server.request.slow",
+		metric.WithDescription("Requests still running after the slow-request threshold"),
+		metric.WithUnit("{request}"),
+	)
+	if err != nil {
+		counter = nil
+		if cfg.logger != nil {
+			cfg.logger.Warn("timeout: slow request counter unavailable", "error", err)
+		}
+	}
+
+	return &slowRequestReporter{
+		threshold: cfg.warnAfter,
+		logger:    cfg.logger,
+		counter:   counter,
+	}
+}
+
+// watch reports the request if ctx is still running after the threshold.
+// The returned function cancels the watch once the request is finished.
+func (r *slowRequestReporter) watch(ctx context.Context, c *router.Context) (stop func() bool) {
+	// Capture request details now: the handler chain owns c while it runs.
+	method, path, route := c.Request.Method, c.Request.URL.Path, c.RoutePattern()
+	span := trace.SpanFromContext(ctx)
+
+	timer := time.AfterFunc(r.threshold, func() {
+		if ctx.Err() != nil {
+			return
+		}
+
+		attrs := []attribute.KeyValue{
+			attribute.String("http.request.method", method),
+			attribute.String("http.route", route),
+		}
+		span.AddEvent("request.slow", trace.WithAttributes(
+			append(attrs, attribute.String("timeout.warn_after", r.threshold.String()))...,
+		))
+		if r.counter != nil {
+			r.counter.Add(context.WithoutCancel(ctx), 1, metric.WithAttributes(attrs...))
+		}
+		if r.logger != nil {
+			r.logger.Warn("slow request",
+				"method", method,
+				"path", path,
+				"threshold", r.threshold.String(),
+			)
+		}
+	})
+	return timer.Stop
+}
@@ -0,0 +1,105 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package timeout
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"rivaas.dev/router"
+)
+
+// syncBuffer is a bytes.Buffer safe for the concurrent writes of the warn timer.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestTimeout_WarnAfter(t *testing.T) {
+	t.Parallel()
+
+	var logs syncBuffer
+	reader := sdkmetric.NewManualReader()
+	spans := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans)).Tracer("test")
+
+	r := router.MustNew()
+	r.Use(func(c *router.Context) {
+		ctx, span := tracer.Start(c.Request.Context(), "request")
+		defer span.End()
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	})
+	r.Use(New(
+		WithDuration(time.Second),
+		WithWarnAfter(20*time.Millisecond),
+		WithLogger(slog.New(slog.NewTextHandler(&logs, nil))),
+		WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))),
+	))
+	r.GET("/slow/:id", waitOrCancel(80*time.Millisecond))
+	r.GET("/fast", waitOrCancel(0))
+
+	for _, path := range []string{"/slow/1", "/fast"} {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		require.Equal(t, http.StatusOK, w.Code, "slow requests still complete")
+	}
+
+	assert.Contains(t, logs.String(), "slow request")
+	assert.Contains(t, logs.String(), "path=/slow/1")
+	assert.NotContains(t, logs.String(), "path=/fast")
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(t.Context(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	sum, ok := rm.ScopeMetrics[0].Metrics[0].Data.(metricdata.Sum[int64])
+	require.True(t, ok)
+	require.Len(t, sum.DataPoints, 1)
+	assert.Equal(t, int64(1), sum.DataPoints[0].Value)
+	route, _ := sum.DataPoints[0].Attributes.Value("http.route")
+	assert.Equal(t, "/slow/:id", route.AsString())
+
+	ended := spans.Ended()
+	require.Len(t, ended, 2)
+	require.Len(t, ended[0].Events(), 1)
+	assert.Equal(t, "request.slow", ended[0].Events()[0].Name)
+	assert.Empty(t, ended[1].Events())
+}
@@ -22,6 +22,8 @@ import (
 	"strings"
 	"time"
 
+	"go.opentelemetry.io/otel/metric"
+
 	"rivaas.dev/router"
 )
 
@@ -50,6 +52,22 @@ type config struct {
 
 	// skipFunc is a custom function to determine if timeout should be skipped
 	skipFunc func(c *router.Context) bool
+
+	// routeDurations override duration per route pattern ("/reports/:id") or
+	// method and pattern ("GET /reports/:id")
+	routeDurations map[string]time.Duration
+
+	// deadlineHeader is the incoming header whose budget caps the timeout
+	deadlineHeader string
+
+	// budgetHeader is set on the request and response to the granted budget
+	budgetHeader string
+
+	// warnAfter is the soft threshold after which a still-running request is reported
+	warnAfter time.Duration
+
+	// meterProvider provides the slow-request counter
+	meterProvider metric.MeterProvider
 }
 
 // defaultConfig returns the default configuration for timeout middleware.
@@ -65,6 +83,20 @@ func defaultConfig() *config {
 	}
 }
 
+// durationFor returns the timeout for the matched route of c.
+func durationFor(cfg *config, c *router.Context) time.Duration {
+	if len(cfg.routeDurations) > 0 {
+		pattern := c.RoutePattern()
+		if d, ok := cfg.routeDurations[c.Request.Method+" "+pattern]; ok {
+			return d
+		}
+		if d, ok := cfg.routeDurations[pattern]; ok {
+			return d
+		}
+	}
+	return cfg.duration
+}
+
 // defaultHandler is the default timeout error handler.
 func defaultHandler(c *router.Context, timeout time.Duration) {
 	//nolint:errcheck // Timeout handler; best-effort response
@@ -151,6 +183,28 @@ func shouldSkip(cfg *config, c *router.Context) bool {
 //	    }),
 //	))
 //
+// Per-route timeouts for slow endpoints:
+//
+//	r.Use(timeout.New(
+//	    timeout.WithDuration(5 * time.Second),
+//	    timeout.WithRouteDuration("GET /exports/:id", time.Minute),
+//	))
+//	r.GET("/reports/:id", timeout.ForRoute(2*time.Minute), exportReport)
+//
+// Honor and propagate deadlines across services:
+//
+//	r.Use(timeout.New(
+//	    timeout.WithDeadlineHeader("X-Request-Timeout"),
+//	    timeout.WithBudgetHeader("X-Request-Timeout"),
+//	))
+//
+// Early warning for slow requests:
+//
+//	r.Use(timeout.New(
+//	    timeout.WithDuration(30 * time.Second),
+//	    timeout.WithWarnAfter(10 * time.Second),
+//	))
+//
 // Disable logging:
 //
 //	r.Use(timeout.New(timeout.WithoutLogging()))
@@ -197,6 +251,8 @@ func New(opts ...Option) router.HandlerFunc {
 		opt(cfg)
 	}
 
+	warn := newSlowRequestReporter(cfg)
+
 	return func(c *router.Context) {
 		// Check if timeout should be skipped
 		if shouldSkip(cfg, c) {
@@ -204,9 +260,33 @@ func New(opts ...Option) router.HandlerFunc {
 			return
 		}
 
-		// Create a context with timeout
-		ctx, cancel := context.WithTimeout(c.Request.Context(), cfg.duration)
-		defer cancel()
+		// A budget announced by the client caps the timeout, including ForRoute overrides
+		duration, limit := durationFor(cfg, c), time.Duration(0)
+		if cfg.deadlineHeader != "" {
+			if d, ok := parseTimeout(cfg.deadlineHeader, c.Request.Header.Get(cfg.deadlineHeader)); ok {
+				limit = max(d, time.Nanosecond)
+				duration = min(duration, limit)
+			}
+		}
+
+		// Create a context with timeout; ForRoute may move its deadline
+		ctx := newBudgetContext(c.Request.Context(), duration, limit)
+		defer ctx.release()
+
+		if warn != nil {
+			stop := warn.watch(ctx, c)
+			defer stop()
+		}
+
+		if cfg.budgetHeader != "" {
+			// Forward the time left and tell the client the total; ForRoute rewrites both
+			setBudgetHeader := func(total, left time.Duration) {
+				c.Request.Header.Set(cfg.budgetHeader, formatTimeout(cfg.budgetHeader, left))
+				c.Response.Header().Set(cfg.budgetHeader, formatTimeout(cfg.budgetHeader, total))
+			}
+			setBudgetHeader(duration, duration)
+			ctx.onChange = setBudgetHeader
+		}
 
 		// Update request context
 		c.Request = c.Request.WithContext(ctx)
@@ -243,17 +323,19 @@ func New(opts ...Option) router.HandlerFunc {
 			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
 				timedOut = true
 
+				duration := ctx.Duration()
+
 				// Log timeout event
 				if cfg.logger != nil {
 					cfg.logger.Warn("request timeout",
 						"method", c.Request.Method,
 						"path", c.Request.URL.Path,
-						"timeout", cfg.duration.String(),
+						"timeout", duration.String(),
 					)
 				}
 
 				// Call timeout handler
-				cfg.handler(c, cfg.duration)
+				cfg.handler(c, duration)
 			}
 		}
 
@@ -359,3 +359,94 @@ func TestTimeout_MultipleRequests(t *testing.T) {
 		})
 	}
 }
+
+// waitOrCancel writes 200 after delay unless the request context is done first.
+func waitOrCancel(delay time.Duration) router.HandlerFunc {
+	return func(c *router.Context) {
+		select {
+		case <-time.After(delay):
+			//nolint:errcheck // Test handler
+			c.JSON(http.StatusOK, map[string]string{"message": "ok"})
+		case <-c.Request.Context().Done():
+		}
+	}
+}
+
+func TestTimeout_PerRoute(t *testing.T) {
+	t.Parallel()
+
+	var reported time.Duration
+	r := router.MustNew()
+	r.Use(New(
+		WithDuration(50*time.Millisecond),
+		WithoutLogging(),
+		WithRouteDuration("/exports/:id", time.Second),
+		WithRouteDuration("POST /exports/:id", 20*time.Millisecond),
+		WithHandler(func(c *router.Context, timeout time.Duration) {
+			reported = timeout
+			c.Status(http.StatusRequestTimeout)
+		}),
+	))
+	r.GET("/reports", ForRoute(time.Second), waitOrCancel(100*time.Millisecond))
+	r.GET("/short", ForRoute(20*time.Millisecond), waitOrCancel(500*time.Millisecond))
+	r.GET("/exports/:id", waitOrCancel(100*time.Millisecond))
+	r.POST("/exports/:id", waitOrCancel(100*time.Millisecond))
+
+	tests := []struct {
+		method   string
+		path     string
+		status   int
+		reported time.Duration
+	}{
+		{http.MethodGet, "/reports", http.StatusOK, 0},
+		{http.MethodGet, "/short", http.StatusRequestTimeout, 20 * time.Millisecond},
+		{http.MethodGet, "/exports/1", http.StatusOK, 0},
+		{http.MethodPost, "/exports/1", http.StatusRequestTimeout, 20 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		reported = 0
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, nil))
+		assert.Equal(t, tt.status, w.Code, "%s %s", tt.method, tt.path)
+		assert.Equal(t, tt.reported, reported, "%s %s", tt.method, tt.path)
+	}
+}
+
+func TestForRoute_Deadline(t *testing.T) {
+	t.Parallel()
+
+	var remaining time.Duration
+	var childErr error
+	r := router.MustNew()
+	r.Use(New(WithDuration(time.Second), WithoutLogging()))
+	r.GET("/test", ForRoute(30*time.Millisecond), func(c *router.Context) {
+		deadline, ok := c.Request.Context().Deadline()
+		require.True(t, ok)
+		remaining = time.Until(deadline)
+
+		child, cancel := context.WithTimeout(c.Request.Context(), time.Minute)
+		defer cancel()
+		<-child.Done()
+		childErr = child.Err()
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/test", nil))
+
+	assert.LessOrEqual(t, remaining, 30*time.Millisecond)
+	assert.Positive(t, remaining)
+	require.ErrorIs(t, childErr, context.DeadlineExceeded, "derived contexts see the moved deadline")
+	assert.Equal(t, http.StatusRequestTimeout, w.Code)
+}
+
+func TestForRoute_WithoutMiddleware(t *testing.T) {
+	t.Parallel()
+
+	r := router.MustNew()
+	r.GET("/test", ForRoute(time.Millisecond), waitOrCancel(10*time.Millisecond))
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/test", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+}
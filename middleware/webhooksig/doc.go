@@ -0,0 +1,62 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package webhooksig provides middleware that verifies the signatures of
+// incoming webhooks.
+//
+// Webhook providers sign each request with a secret shared with the
+// receiver. The middleware checks the signature before the handler runs and
+// rejects requests that are unsigned, tampered with, or replayed.
+//
+// # Basic Usage
+//
+// Register the middleware on the webhook route:
+//
+//	import "rivaas.dev/middleware/webhooksig"
+//
+//	r := router.MustNew()
+//	r.POST("/webhooks/stripe",
+//	    webhooksig.New(webhooksig.WithVerifier(webhooksig.Stripe(os.Getenv("STRIPE_WEBHOOK_SECRET")))),
+//	    handleStripeEvent,
+//	)
+//
+// # Providers
+//
+// [GitHub], [Stripe], and [Slack] verify the signatures of these providers.
+// [HMAC] verifies other HMAC schemes, configured with the signature header,
+// prefix, hash, encoding, and an optional timestamp header.
+// [VerifierFunc] adapts any other scheme, such as Ed25519 signatures.
+//
+// All verifiers accept several secrets, so secrets can be rotated without
+// rejecting requests signed with the old one.
+//
+// # Replay Protection
+//
+// Stripe, Slack, and HMAC verifiers with a timestamp header sign the time
+// of the request. Requests whose timestamp is more than the tolerance (5
+// minutes by default, see [WithTolerance]) away from the current time are
+// rejected with [ErrTimestampExpired].
+//
+// # Request Body
+//
+// The signature covers the raw bytes of the body, so the middleware reads
+// the whole body (up to [WithMaxBodySize]) before the handler runs. It then
+// restores the body, so handlers decode the payload as usual.
+//
+// # Error Handling
+//
+// Rejected requests get 401 Unauthorized by default. [WithErrorHandler]
+// customizes the response; the error wraps [ErrSignatureMissing],
+// [ErrSignatureInvalid], or [ErrTimestampExpired].
+package webhooksig
@@ -0,0 +1,68 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package main demonstrates how to use the webhooksig middleware
+// to verify GitHub webhooks.
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"rivaas.dev/middleware/webhooksig"
+	"rivaas.dev/router"
+)
+
+// secret is shared with the webhook provider. Load it from the
+// environment or a secret manager in production.
+const secret = "example-secret"
+
+type pushEvent struct {
+	Ref        string `json:"ref"`
+	Repository struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+}
+
+func main() {
+	r := router.MustNew()
+
+	r.POST("/webhooks/github",
+		webhooksig.New(webhooksig.WithVerifier(webhooksig.GitHub(secret))),
+		func(c *router.Context) {
+			// The body is still readable after verification
+			var event pushEvent
+			if err := json.NewDecoder(c.Request.Body).Decode(&event); err != nil {
+				c.WriteErrorResponse(http.StatusBadRequest, "invalid payload")
+				return
+			}
+			log.Printf("push to %s on %s", event.Ref, event.Repository.FullName)
+			c.NoContent()
+		},
+	)
+
+	body := `{"ref":"refs/heads/main","repository":{"full_name":"acme/app"}}`
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	signature := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	log.Println("Server starting on :8080")
+	log.Printf("  curl -i -X POST -H 'X-Hub-Signature-256: %s' -d '%s' http://localhost:8080/webhooks/github", signature, body)
+	log.Printf("  curl -i -X POST -d '%s' http://localhost:8080/webhooks/github", body)
+	log.Fatal(http.ListenAndServe(":8080", r))
+}
@@ -0,0 +1,98 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhooksig
+
+import (
+	"time"
+
+	"rivaas.dev/router"
+)
+
+// WithVerifier sets the verifier of request signatures: a provider preset
+// such as [GitHub], [Stripe], or [Slack], a generic [HMAC] verifier, or a
+// custom [VerifierFunc]. A verifier is required.
+//
+// Example:
+//
+//	webhooksig.New(webhooksig.WithVerifier(webhooksig.Stripe(os.Getenv("STRIPE_WEBHOOK_SECRET"))))
+func WithVerifier(verifier Verifier) Option {
+	return func(cfg *config) {
+		cfg.verifier = verifier
+	}
+}
+
+// WithTolerance sets how far the signed timestamp may be from the current
+// time, so that captured requests can't be replayed later. It applies to
+// signatures with a timestamp, such as those of Stripe and Slack. A
+// tolerance of 0 disables the check.
+// Default: 5 minutes
+//
+// Example:
+//
+//	webhooksig.New(webhooksig.WithVerifier(verifier), webhooksig.WithTolerance(time.Minute))
+func WithTolerance(tolerance time.Duration) Option {
+	return func(cfg *config) {
+		cfg.tolerance = tolerance
+	}
+}
+
+// WithMaxBodySize sets the largest body, in bytes, that is read for
+// verification. Larger requests are rejected with 413 Request Entity Too
+// Large.
+// Default: 1 MiB
+//
+// Example:
+//
+//	webhooksig.New(webhooksig.WithVerifier(verifier), webhooksig.WithMaxBodySize(5<<20))
+func WithMaxBodySize(size int64) Option {
+	return func(cfg *config) {
+		cfg.maxBodySize = size
+	}
+}
+
+// WithErrorHandler sets the function that responds to rejected requests. The
+// error wraps [ErrSignatureMissing], [ErrSignatureInvalid], or
+// [ErrTimestampExpired], or is an error reading the body. The middleware
+// aborts the chain after the handler returns.
+// Default: 401 Unauthorized for signature errors, 413 for bodies that are
+// too large, and 400 for other read errors
+//
+// Example:
+//
+//	webhooksig.New(
+//	    webhooksig.WithVerifier(verifier),
+//	    webhooksig.WithErrorHandler(func(c *router.Context, err error) {
+//	        slog.Warn("rejected webhook", "error", err)
+//	        c.WriteErrorResponse(http.StatusUnauthorized, "invalid signature")
+//	    }),
+//	)
+func WithErrorHandler(handler func(c *router.Context, err error)) Option {
+	return func(cfg *config) {
+		cfg.errorHandler = handler
+	}
+}
+
+// WithSkipPaths sets paths that bypass verification.
+//
+// Example:
+//
+//	webhooksig.New(webhooksig.WithVerifier(verifier), webhooksig.WithSkipPaths("/webhooks/health"))
+func WithSkipPaths(paths ...string) Option {
+	return func(cfg *config) {
+		for _, path := range paths {
+			cfg.skipPaths[path] = true
+		}
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhooksig
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// GitHub returns a verifier of GitHub webhook signatures, sent in the
+// X-Hub-Signature-256 header. GitHub doesn't sign a timestamp, so the
+// tolerance of the middleware doesn't apply.
+//
+// Example:
+//
+//	webhooksig.WithVerifier(webhooksig.GitHub(os.Getenv("GITHUB_WEBHOOK_SECRET")))
+func GitHub(secrets ...string) Verifier {
+	return HMAC("X-Hub-Signature-256", WithSecrets(secrets...), WithPrefix("sha256="))
+}
+
+// Slack returns a verifier of Slack request signatures, sent in the
+// X-Slack-Signature header and signed together with the
+// X-Slack-Request-Timestamp header.
+//
+// Example:
+//
+//	webhooksig.WithVerifier(webhooksig.Slack(os.Getenv("SLACK_SIGNING_SECRET")))
+func Slack(secrets ...string) Verifier {
+	return HMAC("X-Slack-Signature",
+		WithSecrets(secrets...),
+		WithPrefix("v0="),
+		WithTimestampHeader("X-Slack-Request-Timestamp"),
+		WithPayload(func(timestamp string, body []byte) []byte {
+			return append([]byte("v0:"+timestamp+":"), body...)
+		}),
+	)
+}
+
+// stripeVerifier is the [Verifier] of [Stripe].
+type stripeVerifier struct {
+	// secrets are the accepted endpoint secrets
+	secrets [][]byte
+}
+
+// Stripe returns a verifier of Stripe webhook signatures, sent in the
+// Stripe-Signature header. Pass the endpoint secret (whsec_...), not the API
+// key. During a secret roll, Stripe signs with both secrets; pass both.
+// Empty secrets are skipped; at least one non-empty secret is required.
+//
+// Example:
+//
+//	webhooksig.WithVerifier(webhooksig.Stripe(os.Getenv("STRIPE_WEBHOOK_SECRET")))
+func Stripe(secrets ...string) Verifier {
+	v := &stripeVerifier{secrets: appendSecrets(nil, secrets)}
+	if len(v.secrets) == 0 {
+		panic("webhooksig: a non-empty secret is required")
+	}
+
+	return v
+}
+
+// Verify implements [Verifier]. The header has the form
+// "t=<unix time>,v1=<signature>[,v1=<signature>...]", and the signed payload
+// is "<unix time>.<body>".
+func (v *stripeVerifier) Verify(header http.Header, body []byte) (time.Time, error) {
+	value := header.Get("Stripe-Signature")
+	if value == "" {
+		return time.Time{}, ErrSignatureMissing
+	}
+
+	var rawTimestamp string
+	var signatures [][]byte
+	for pair := range strings.SplitSeq(value, ",") {
+		key, val, _ := strings.Cut(strings.TrimSpace(pair), "=")
+		switch key {
+		case "t":
+			rawTimestamp = val
+		case "v1":
+			if signature, err := hex.DecodeString(val); err == nil {
+				signatures = append(signatures, signature)
+			}
+		}
+	}
+	if rawTimestamp == "" || len(signatures) == 0 {
+		return time.Time{}, ErrSignatureInvalid
+	}
+	timestamp, err := parseUnix(rawTimestamp)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	payload := append([]byte(rawTimestamp+"."), body...)
+	for _, signature := range signatures {
+		if matchesAny(sha256.New, v.secrets, payload, signature) {
+			return timestamp, nil
+		}
+	}
+
+	return time.Time{}, ErrSignatureInvalid
+}
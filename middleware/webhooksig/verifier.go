@@ -0,0 +1,279 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhooksig
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"hash"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Verifier checks webhook signatures. Verify returns the time the request
+// was signed at, or the zero time if the signature has no timestamp, and an
+// error wrapping [ErrSignatureMissing] or [ErrSignatureInvalid] if the
+// signature is not valid.
+type Verifier interface {
+	Verify(header http.Header, body []byte) (time.Time, error)
+}
+
+// VerifierFunc adapts a function to a [Verifier], for providers with their
+// own signature scheme.
+//
+// Example:
+//
+//	webhooksig.WithVerifier(webhooksig.VerifierFunc(func(header http.Header, body []byte) (time.Time, error) {
+//	    if !ed25519.Verify(publicKey, body, decode(header.Get("X-Signature"))) {
+//	        return time.Time{}, webhooksig.ErrSignatureInvalid
+//	    }
+//	    return time.Time{}, nil
+//	}))
+type VerifierFunc func(header http.Header, body []byte) (time.Time, error)
+
+// Verify implements [Verifier].
+func (f VerifierFunc) Verify(header http.Header, body []byte) (time.Time, error) {
+	return f(header, body)
+}
+
+// HMACOption defines functional options for [HMAC] verifiers.
+type HMACOption func(*hmacVerifier)
+
+// hmacVerifier is the [Verifier] of [HMAC].
+type hmacVerifier struct {
+	// header carries the signature
+	header string
+
+	// secrets are the accepted signing secrets
+	secrets [][]byte
+
+	// prefix precedes the encoded signature in the header, e.g. "sha256="
+	prefix string
+
+	// hash is the hash function of the HMAC
+	hash func() hash.Hash
+
+	// decode decodes the signature in the header
+	decode func(signature string) ([]byte, error)
+
+	// timestampHeader carries the unix time of the signature; empty if none
+	timestampHeader string
+
+	// payload returns the signed bytes for the timestamp and body
+	payload func(timestamp string, body []byte) []byte
+}
+
+// HMAC returns a verifier of HMAC signatures in the named header, for
+// providers without a preset. Signatures are hex encoded HMAC-SHA256 of the
+// body by default.
+//
+// With [WithTimestampHeader], the signed payload is the timestamp, a dot,
+// and the body, and the timestamp is checked against the tolerance of the
+// middleware. [WithPayload] sets other payload formats.
+//
+// Example:
+//
+//	webhooksig.HMAC("X-Signature",
+//	    webhooksig.WithSecrets(os.Getenv("WEBHOOK_SECRET")),
+//	    webhooksig.WithTimestampHeader("X-Timestamp"),
+//	)
+func HMAC(header string, opts ...HMACOption) Verifier {
+	v := &hmacVerifier{
+		header: header,
+		hash:   sha256.New,
+		decode: hex.DecodeString,
+		payload: func(timestamp string, body []byte) []byte {
+			if timestamp == "" {
+				return body
+			}
+			return append([]byte(timestamp+"."), body...)
+		},
+	}
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	if len(v.secrets) == 0 {
+		panic("webhooksig: a non-empty secret is required, use WithSecrets")
+	}
+
+	return v
+}
+
+// WithSecrets sets the secrets signatures are verified with. A signature
+// made with any of them is accepted, so secrets can be rotated without
+// downtime. Empty secrets are skipped, since anyone can compute an HMAC with
+// an empty key; at least one non-empty secret is required.
+//
+// Example:
+//
+//	webhooksig.HMAC("X-Signature", webhooksig.WithSecrets(newSecret, oldSecret))
+func WithSecrets(secrets ...string) HMACOption {
+	return func(v *hmacVerifier) {
+		v.secrets = appendSecrets(v.secrets, secrets)
+	}
+}
+
+// appendSecrets appends the non-empty secrets to dst.
+func appendSecrets(dst [][]byte, secrets []string) [][]byte {
+	for _, secret := range secrets {
+		if secret != "" {
+			dst = append(dst, []byte(secret))
+		}
+	}
+
+	return dst
+}
+
+// WithPrefix sets the text that precedes the signature in the header, such
+// as "sha256=".
+//
+// Example:
+//
+//	webhooksig.HMAC("X-Signature", webhooksig.WithSecrets(secret), webhooksig.WithPrefix("sha256="))
+func WithPrefix(prefix string) HMACOption {
+	return func(v *hmacVerifier) {
+		v.prefix = prefix
+	}
+}
+
+// WithHash sets the hash function of the HMAC.
+// Default: sha256.New
+//
+// Example:
+//
+//	webhooksig.HMAC("X-Signature", webhooksig.WithSecrets(secret), webhooksig.WithHash(sha512.New))
+func WithHash(h func() hash.Hash) HMACOption {
+	return func(v *hmacVerifier) {
+		v.hash = h
+	}
+}
+
+// WithBase64 expects base64 (standard encoding) signatures instead of hex.
+//
+// Example:
+//
+//	webhooksig.HMAC("X-Signature", webhooksig.WithSecrets(secret), webhooksig.WithBase64())
+func WithBase64() HMACOption {
+	return func(v *hmacVerifier) {
+		v.decode = base64.StdEncoding.DecodeString
+	}
+}
+
+// WithTimestampHeader sets the header that carries the time of the
+// signature, in unix seconds. The timestamp is part of the signed payload,
+// so replayed requests are rejected once they are older than the tolerance.
+//
+// Example:
+//
+//	webhooksig.HMAC("X-Signature", webhooksig.WithSecrets(secret), webhooksig.WithTimestampHeader("X-Timestamp"))
+func WithTimestampHeader(header string) HMACOption {
+	return func(v *hmacVerifier) {
+		v.timestampHeader = header
+	}
+}
+
+// WithPayload sets the function that builds the signed payload from the
+// timestamp (empty without [WithTimestampHeader]) and the body.
+// Default: the body, or "<timestamp>.<body>" with a timestamp header
+//
+// Example:
+//
+//	webhooksig.WithPayload(func(timestamp string, body []byte) []byte {
+//	    return append([]byte("v1:"+timestamp+":"), body...)
+//	})
+func WithPayload(fn func(timestamp string, body []byte) []byte) HMACOption {
+	return func(v *hmacVerifier) {
+		v.payload = fn
+	}
+}
+
+// Verify implements [Verifier].
+func (v *hmacVerifier) Verify(header http.Header, body []byte) (time.Time, error) {
+	signatures := header.Values(v.header)
+	if len(signatures) == 0 {
+		return time.Time{}, ErrSignatureMissing
+	}
+
+	var timestamp time.Time
+	var rawTimestamp string
+	if v.timestampHeader != "" {
+		rawTimestamp = header.Get(v.timestampHeader)
+		if rawTimestamp == "" {
+			return time.Time{}, ErrSignatureMissing
+		}
+		var err error
+		if timestamp, err = parseUnix(rawTimestamp); err != nil {
+			return time.Time{}, err
+		}
+	}
+
+	payload := v.payload(rawTimestamp, body)
+	for _, signature := range signatures {
+		for candidate := range strings.SplitSeq(signature, ",") {
+			encoded, ok := strings.CutPrefix(strings.TrimSpace(candidate), v.prefix)
+			if ok && v.matches(payload, encoded) {
+				return timestamp, nil
+			}
+		}
+	}
+
+	return time.Time{}, ErrSignatureInvalid
+}
+
+// matches reports whether encoded is the signature of payload with one of
+// the secrets.
+func (v *hmacVerifier) matches(payload []byte, encoded string) bool {
+	signature, err := v.decode(encoded)
+	if err != nil {
+		return false
+	}
+
+	return matchesAny(v.hash, v.secrets, payload, signature)
+}
+
+// matchesAny reports whether signature is the HMAC of payload with one of
+// the secrets.
+func matchesAny(h func() hash.Hash, secrets [][]byte, payload, signature []byte) bool {
+	for _, secret := range secrets {
+		if hmac.Equal(sign(h, secret, payload), signature) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sign returns the HMAC of payload with secret.
+func sign(h func() hash.Hash, secret, payload []byte) []byte {
+	mac := hmac.New(h, secret)
+	mac.Write(payload)
+
+	return mac.Sum(nil)
+}
+
+// parseUnix parses a timestamp in unix seconds.
+func parseUnix(s string) (time.Time, error) {
+	seconds, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return time.Time{}, ErrSignatureInvalid
+	}
+
+	return time.Unix(seconds, 0), nil
+}
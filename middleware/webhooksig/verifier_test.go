@@ -0,0 +1,147 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package webhooksig
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/base64"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitHub(t *testing.T) {
+	t.Parallel()
+	// The example of the GitHub documentation
+	v := GitHub("It's a Secret to Everybody")
+	header := http.Header{"X-Hub-Signature-256": {"sha256=757107ea0eb2509fc211221cce984b8a37570b6d7586c22c46f4379c8b043e17"}}
+
+	timestamp, err := v.Verify(header, []byte("Hello, World!"))
+	require.NoError(t, err)
+	assert.True(t, timestamp.IsZero())
+
+	_, err = v.Verify(header, []byte("Hello, World?"))
+	require.ErrorIs(t, err, ErrSignatureInvalid)
+
+	_, err = v.Verify(http.Header{}, []byte("Hello, World!"))
+	require.ErrorIs(t, err, ErrSignatureMissing)
+
+	header.Set("X-Hub-Signature-256", "757107ea0eb2509fc211221cce984b8a37570b6d7586c22c46f4379c8b043e17")
+	_, err = v.Verify(header, []byte("Hello, World!"))
+	require.ErrorIs(t, err, ErrSignatureInvalid, "prefix is required")
+}
+
+func TestSlack(t *testing.T) {
+	t.Parallel()
+	v := Slack("old-secret", testSecret)
+	body := "token=abc&team_id=T1&command=/weather"
+	ts := "1531420618"
+	header := http.Header{
+		"X-Slack-Signature":         {"v0=" + hexHMAC(testSecret, "v0:"+ts+":"+body)},
+		"X-Slack-Request-Timestamp": {ts},
+	}
+
+	timestamp, err := v.Verify(header, []byte(body))
+	require.NoError(t, err)
+	assert.Equal(t, time.Unix(1531420618, 0), timestamp)
+
+	header.Set("X-Slack-Request-Timestamp", "1531420619")
+	_, err = v.Verify(header, []byte(body))
+	require.ErrorIs(t, err, ErrSignatureInvalid, "timestamp is signed")
+
+	header.Del("X-Slack-Request-Timestamp")
+	_, err = v.Verify(header, []byte(body))
+	require.ErrorIs(t, err, ErrSignatureMissing)
+}
+
+func TestStripe_MultipleSignatures(t *testing.T) {
+	t.Parallel()
+	now := time.Unix(1_700_000_000, 0)
+	ts := strconv.FormatInt(now.Unix(), 10)
+	header := http.Header{"Stripe-Signature": {
+		"t=" + ts + ",v1=" + hexHMAC("whsec_old", ts+".{}") + ",v1=" + hexHMAC("whsec_new", ts+".{}") + ",v0=ignored",
+	}}
+
+	timestamp, err := Stripe("whsec_new").Verify(header, []byte("{}"))
+	require.NoError(t, err)
+	assert.Equal(t, now, timestamp)
+
+	_, err = Stripe("whsec_other").Verify(header, []byte("{}"))
+	require.ErrorIs(t, err, ErrSignatureInvalid)
+}
+
+func TestHMAC(t *testing.T) {
+	t.Parallel()
+	sign512 := func(payload string) string {
+		mac := hmac.New(sha512.New, []byte(testSecret))
+		mac.Write([]byte(payload))
+		return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+	}
+
+	v := HMAC("X-Signature",
+		WithSecrets(testSecret),
+		WithHash(sha512.New),
+		WithBase64(),
+		WithTimestampHeader("X-Timestamp"),
+	)
+	header := http.Header{
+		"X-Signature": {sign512("1700000000.{}")},
+		"X-Timestamp": {"1700000000"},
+	}
+	timestamp, err := v.Verify(header, []byte("{}"))
+	require.NoError(t, err)
+	assert.Equal(t, time.Unix(1_700_000_000, 0), timestamp)
+
+	header.Set("X-Timestamp", "yesterday")
+	_, err = v.Verify(header, []byte("{}"))
+	require.ErrorIs(t, err, ErrSignatureInvalid)
+
+	v = HMAC("X-Signature", WithSecrets(testSecret), WithPayload(func(_ string, body []byte) []byte {
+		return append([]byte("v1:"), body...)
+	}))
+	_, err = v.Verify(http.Header{"X-Signature": {hexHMAC(testSecret, "v1:{}")}}, []byte("{}"))
+	require.NoError(t, err)
+
+	assert.Panics(t, func() { HMAC("X-Signature") })
+	assert.Panics(t, func() { Stripe() })
+}
+
+func TestEmptySecrets(t *testing.T) {
+	t.Parallel()
+	body := "{}"
+	ts := "1700000000"
+
+	// An unset old secret must not register an empty key
+	_, err := GitHub(testSecret, "").Verify(http.Header{
+		"X-Hub-Signature-256": {"sha256=" + hexHMAC("", body)},
+	}, []byte(body))
+	require.ErrorIs(t, err, ErrSignatureInvalid)
+
+	_, err = Stripe(testSecret, "").Verify(http.Header{
+		"Stripe-Signature": {"t=" + ts + ",v1=" + hexHMAC("", ts+"."+body)},
+	}, []byte(body))
+	require.ErrorIs(t, err, ErrSignatureInvalid)
+
+	assert.Panics(t, func() { GitHub("") })
+	assert.Panics(t, func() { Stripe("", "") })
+	assert.Panics(t, func() { HMAC("X-Signature", WithSecrets("")) })
+}
@@ -0,0 +1,168 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhooksig
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"rivaas.dev/router"
+)
+
+// Errors passed to the error handler when a request is rejected.
+var (
+	// ErrSignatureMissing is returned when the request carries no signature.
+	ErrSignatureMissing = errors.New("webhooksig: signature missing")
+
+	// ErrSignatureInvalid is returned when the signature doesn't match the
+	// body, or the signature headers are malformed.
+	ErrSignatureInvalid = errors.New("webhooksig: signature invalid")
+
+	// ErrTimestampExpired is returned when the signature is valid but its
+	// timestamp is outside the tolerance, as with replayed requests.
+	ErrTimestampExpired = errors.New("webhooksig: timestamp outside tolerance")
+)
+
+// Option defines functional options for webhooksig middleware configuration.
+type Option func(*config)
+
+// config holds the configuration for the webhooksig middleware.
+type config struct {
+	// verifier checks the signature of requests
+	verifier Verifier
+
+	// tolerance is the maximum age of signed timestamps; 0 disables the check
+	tolerance time.Duration
+
+	// maxBodySize is the largest body read for verification
+	maxBodySize int64
+
+	// errorHandler is called when a request is rejected
+	errorHandler func(c *router.Context, err error)
+
+	// skipPaths are paths that bypass verification
+	skipPaths map[string]bool
+
+	// now returns the current time
+	now func() time.Time
+}
+
+// defaultConfig returns the default configuration for webhooksig middleware.
+func defaultConfig() *config {
+	return &config{
+		tolerance:    5 * time.Minute,
+		maxBodySize:  1 << 20,
+		errorHandler: defaultErrorHandler,
+		skipPaths:    make(map[string]bool),
+		now:          time.Now,
+	}
+}
+
+// defaultErrorHandler sends 401 Unauthorized for signature errors, 413 for
+// bodies over the size limit, and 400 if the body couldn't be read.
+func defaultErrorHandler(c *router.Context, err error) {
+	var maxBytesErr *http.MaxBytesError
+	switch {
+	case errors.Is(err, ErrSignatureMissing), errors.Is(err, ErrSignatureInvalid), errors.Is(err, ErrTimestampExpired):
+		c.WriteErrorResponse(http.StatusUnauthorized, "invalid webhook signature")
+	case errors.As(err, &maxBytesErr):
+		c.WriteErrorResponse(http.StatusRequestEntityTooLarge, "request body too large")
+	default:
+		c.WriteErrorResponse(http.StatusBadRequest, "unable to read request body")
+	}
+}
+
+// New returns a middleware that verifies the signature of webhook requests
+// with the configured [Verifier], and rejects requests whose signature is
+// missing, invalid, or too old.
+//
+// The middleware reads the whole body to verify it, then restores it, so
+// handlers read or bind the payload as usual.
+//
+// New panics if no verifier is configured.
+//
+// Example:
+//
+//	r := router.MustNew()
+//	r.POST("/webhooks/github",
+//	    webhooksig.New(webhooksig.WithVerifier(webhooksig.GitHub(os.Getenv("GITHUB_WEBHOOK_SECRET")))),
+//	    handleGitHubEvent,
+//	)
+func New(opts ...Option) router.HandlerFunc {
+	cfg := defaultConfig()
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	if cfg.verifier == nil {
+		panic("webhooksig: a verifier is required, use WithVerifier")
+	}
+
+	return func(c *router.Context) {
+		if cfg.skipPaths[c.Request.URL.Path] {
+			c.Next()
+			return
+		}
+
+		body, err := cfg.readBody(c)
+		if err == nil {
+			err = cfg.verify(c.Request.Header, body)
+		}
+		if err != nil {
+			cfg.errorHandler(c, err)
+			c.Abort()
+
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// readBody reads the request body of c and replaces it with a reader of
+// the same bytes.
+func (cfg *config) readBody(c *router.Context) ([]byte, error) {
+	if c.Request.Body == nil || c.Request.Body == http.NoBody {
+		return nil, nil
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(c.Response, c.Request.Body, cfg.maxBodySize))
+	if err != nil {
+		return nil, fmt.Errorf("webhooksig: read body: %w", err)
+	}
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+	c.Request.ContentLength = int64(len(body))
+
+	return body, nil
+}
+
+// verify checks the signature of body, and the age of its timestamp.
+func (cfg *config) verify(header http.Header, body []byte) error {
+	timestamp, err := cfg.verifier.Verify(header, body)
+	if err != nil {
+		return err
+	}
+	if cfg.tolerance <= 0 || timestamp.IsZero() {
+		return nil
+	}
+	if age := cfg.now().Sub(timestamp); age > cfg.tolerance || age < -cfg.tolerance {
+		return ErrTimestampExpired
+	}
+
+	return nil
+}
@@ -0,0 +1,196 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package webhooksig
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"rivaas.dev/router"
+)
+
+const testSecret = "whsec_test"
+
+// withNow sets the clock of the middleware.
+func withNow(now time.Time) Option {
+	return func(cfg *config) {
+		cfg.now = func() time.Time { return now }
+	}
+}
+
+// hexHMAC returns the hex encoded HMAC-SHA256 of payload with secret.
+func hexHMAC(secret, payload string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(payload))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// stripeHeader returns a Stripe-Signature header for body signed at t.
+func stripeHeader(secret string, t time.Time, body string) string {
+	ts := strconv.FormatInt(t.Unix(), 10)
+	return "t=" + ts + ",v1=" + hexHMAC(secret, ts+"."+body)
+}
+
+// echoBody responds with the request body, read through the middleware.
+func echoBody(c *router.Context) {
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		c.WriteErrorResponse(http.StatusInternalServerError, err.Error())
+		return
+	}
+	//nolint:errcheck // Test handler
+	c.String(http.StatusOK, string(body))
+}
+
+func post(r http.Handler, body string, header http.Header) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(body))
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	return w
+}
+
+func TestWebhookSig_Stripe(t *testing.T) {
+	t.Parallel()
+	now := time.Unix(1_700_000_000, 0)
+	r := router.MustNew()
+	r.Use(New(WithVerifier(Stripe(testSecret)), withNow(now)))
+	r.POST("/webhook", echoBody)
+
+	body := `{"type":"invoice.paid"}`
+
+	tests := []struct {
+		name       string
+		header     string
+		wantStatus int
+	}{
+		{name: "valid", header: stripeHeader(testSecret, now, body), wantStatus: http.StatusOK},
+		{name: "slightly in the future", header: stripeHeader(testSecret, now.Add(time.Minute), body), wantStatus: http.StatusOK},
+		{name: "missing", wantStatus: http.StatusUnauthorized},
+		{name: "wrong secret", header: stripeHeader("whsec_other", now, body), wantStatus: http.StatusUnauthorized},
+		{name: "replayed", header: stripeHeader(testSecret, now.Add(-6*time.Minute), body), wantStatus: http.StatusUnauthorized},
+		{name: "tampered timestamp", header: strings.Replace(stripeHeader(testSecret, now, body), "t=1700000000", "t=1700000001", 1), wantStatus: http.StatusUnauthorized},
+		{name: "malformed", header: "v1=abc", wantStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			header := http.Header{}
+			if tt.header != "" {
+				header.Set("Stripe-Signature", tt.header)
+			}
+			w := post(r, body, header)
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantStatus == http.StatusOK {
+				assert.Equal(t, body, w.Body.String(), "handler reads the whole body")
+			}
+		})
+	}
+}
+
+func TestWebhookSig_Tolerance(t *testing.T) {
+	t.Parallel()
+	now := time.Unix(1_700_000_000, 0)
+	body := `{}`
+	header := http.Header{"Stripe-Signature": {stripeHeader(testSecret, now.Add(-time.Hour), body)}}
+
+	r := router.MustNew()
+	r.Use(New(WithVerifier(Stripe(testSecret)), withNow(now), WithTolerance(2*time.Hour)))
+	r.POST("/webhook", echoBody)
+
+	assert.Equal(t, http.StatusOK, post(r, body, header).Code)
+
+	r = router.MustNew()
+	r.Use(New(WithVerifier(Stripe(testSecret)), withNow(now), WithTolerance(0)))
+	r.POST("/webhook", echoBody)
+
+	assert.Equal(t, http.StatusOK, post(r, body, header).Code, "0 disables the check")
+}
+
+func TestWebhookSig_MaxBodySize(t *testing.T) {
+	t.Parallel()
+	r := router.MustNew()
+	r.Use(New(WithVerifier(GitHub(testSecret)), WithMaxBodySize(10)))
+	r.POST("/webhook", echoBody)
+
+	body := strings.Repeat("x", 11)
+
+	w := post(r, body, http.Header{"X-Hub-Signature-256": {"sha256=" + hexHMAC(testSecret, body)}})
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+}
+
+func TestWebhookSig_ErrorHandler(t *testing.T) {
+	t.Parallel()
+	var gotErr error
+	r := router.MustNew()
+	r.Use(New(WithVerifier(GitHub(testSecret)), WithErrorHandler(func(c *router.Context, err error) {
+		gotErr = err
+		c.WriteErrorResponse(http.StatusForbidden, "")
+	})))
+	r.POST("/webhook", echoBody)
+
+	w := post(r, "{}", nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	require.ErrorIs(t, gotErr, ErrSignatureMissing)
+
+	post(r, "{}", http.Header{"X-Hub-Signature-256": {"sha256=00"}})
+	require.ErrorIs(t, gotErr, ErrSignatureInvalid)
+
+	errDown := errors.New("verifier unavailable")
+	r = router.MustNew()
+	r.Use(New(WithVerifier(VerifierFunc(func(http.Header, []byte) (time.Time, error) {
+		return time.Time{}, errDown
+	})), WithErrorHandler(func(c *router.Context, err error) {
+		gotErr = err
+		c.WriteErrorResponse(http.StatusServiceUnavailable, "")
+	})))
+	r.POST("/webhook", echoBody)
+
+	assert.Equal(t, http.StatusServiceUnavailable, post(r, "{}", nil).Code)
+	require.ErrorIs(t, gotErr, errDown)
+}
+
+func TestWebhookSig_SkipPaths(t *testing.T) {
+	t.Parallel()
+	r := router.MustNew()
+	r.Use(New(WithVerifier(GitHub(testSecret)), WithSkipPaths("/webhook")))
+	r.POST("/webhook", echoBody)
+
+	assert.Equal(t, http.StatusOK, post(r, "{}", nil).Code)
+}
+
+func TestNew_PanicsWithoutVerifier(t *testing.T) {
+	t.Parallel()
+	assert.Panics(t, func() { New() })
+}
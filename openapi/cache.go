@@ -0,0 +1,158 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"maps"
+	"reflect"
+	"slices"
+	"sync"
+
+	"rivaas.dev/openapi/internal/build"
+)
+
+// WithGenerationCache enables or disables incremental spec generation.
+// Enabled by default.
+//
+// With the cache, [API.Spec] keeps reflected schemas per Go type and built
+// operations per route content across calls, so regenerating the spec of an API
+// with thousands of routes only recomputes operations that were added or
+// changed since the previous call. Disable it to trade speed for memory when
+// the spec is generated only once.
+//
+// Example:
+//
+//	openapi.MustNew(
+//	    openapi.WithTitle("My API", "1.0.0"),
+//	    openapi.WithGenerationCache(false),
+//	)
+func WithGenerationCache(enabled bool) Option {
+	return func(c *config) {
+		c.generationCache = enabled
+	}
+}
+
+// specCache holds converted routes and built operations between Spec calls.
+type specCache struct {
+	build *build.Cache
+
+	mu     sync.Mutex
+	routes map[string]build.EnrichedRoute
+}
+
+func newSpecCache() *specCache {
+	return &specCache{
+		build:  build.NewCache(),
+		routes: make(map[string]build.EnrichedRoute),
+	}
+}
+
+// enrichedRoutes converts ops, reusing conversions of unchanged operations when
+// caching is enabled. Each cacheable route carries its content key so the
+// builder can reuse the built operation as well.
+func (a *API) enrichedRoutes(ops []Operation) []build.EnrichedRoute {
+	enriched := make([]build.EnrichedRoute, 0, len(ops))
+	if a.cache == nil {
+		for _, op := range ops {
+			enriched = append(enriched, convertOperation(op, a.exampleGen))
+		}
+		return enriched
+	}
+
+	c := a.cache
+	keys := make(map[string]struct{}, len(ops))
+	for _, op := range ops {
+		key, ok := operationKey(op)
+		if !ok {
+			enriched = append(enriched, convertOperation(op, a.exampleGen))
+			continue
+		}
+		keys[key] = struct{}{}
+
+		c.mu.Lock()
+		er, hit := c.routes[key]
+		c.mu.Unlock()
+		if !hit {
+			er = convertOperation(op, a.exampleGen)
+			er.CacheKey = key
+			c.mu.Lock()
+			c.routes[key] = er
+			c.mu.Unlock()
+		}
+		enriched = append(enriched, er)
+	}
+
+	c.mu.Lock()
+	maps.DeleteFunc(c.routes, func(key string, _ build.EnrichedRoute) bool {
+		_, ok := keys[key]
+		return !ok
+	})
+	c.mu.Unlock()
+
+	return enriched
+}
+
+// operationKey fingerprints everything that affects an operation's output.
+// Go types are identified by their runtime identity and example values by their
+// JSON encoding. It reports false when a value cannot be encoded; such
+// operations are rebuilt on every call.
+func operationKey(op Operation) (string, bool) {
+	h := sha256.New()
+	d := op.doc
+
+	fmt.Fprintf(h, "%s %s\x00%q\x00%q\x00%q\x00%q\x00%t\x00%q\x00%q\x00",
+		op.Method, op.Path, d.Summary, d.Description, d.OperationID, d.Tags, d.Deprecated, d.Consumes, d.Produces)
+	writeType(h, d.RequestType)
+	for _, v := range d.ExampleStructs {
+		writeType(h, reflect.TypeOf(v))
+	}
+
+	values := []any{d.RequestExample, d.ExampleStructs, d.Security, d.Extensions}
+	for _, ex := range d.RequestNamedExamples {
+		values = append(values, ex.Name(), ex.Summary(), ex.Description(), ex.Value(), ex.ExternalValue())
+	}
+
+	for _, status := range slices.Sorted(maps.Keys(d.ResponseTypes)) {
+		fmt.Fprintf(h, "\x00%d", status)
+		writeType(h, d.ResponseTypes[status])
+		values = append(values, d.ResponseExample[status], d.ResponseHeaders[status])
+		for _, ex := range d.ResponseNamedExamples[status] {
+			values = append(values, ex.Name(), ex.Summary(), ex.Description(), ex.Value(), ex.ExternalValue())
+		}
+	}
+
+	enc := json.NewEncoder(h)
+	for _, v := range values {
+		if err := enc.Encode(v); err != nil {
+			return "", false
+		}
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), true
+}
+
+// writeType writes the runtime identity of t; distinct types never share it.
+func writeType(h hash.Hash, t reflect.Type) {
+	if t == nil {
+		h.Write([]byte("\x00nil"))
+		return
+	}
+	fmt.Fprintf(h, "\x00%p", t)
+}
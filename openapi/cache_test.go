@@ -0,0 +1,127 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package openapi
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"rivaas.dev/openapi/example"
+)
+
+type cacheOrder struct {
+	ID    int            `json:"id"`
+	Items []cacheItem    `json:"items"`
+	User  *exampleUser   `json:"user"`
+	Next  *cacheOrder    `json:"next,omitempty"`
+	Meta  map[string]any `json:"meta"`
+}
+
+type cacheItem struct {
+	SKU string `json:"sku" example:"A-1"`
+}
+
+func cacheTestOperations() []Operation {
+	return []Operation{
+		mustOperation(WithGET("/orders/:id", WithResponse(200, cacheOrder{}))),
+		mustOperation(WithPOST("/orders",
+			WithRequest(exampleCreateUser{}),
+			WithResponse(201, cacheOrder{}),
+			WithResponseHeader(201, "Location", "Order URL"),
+		)),
+		mustOperation(WithGET("/orders", WithResponse(200, Paginated[cacheOrder]()))),
+		mustOperation(WithGET("/users/:id", WithResponse(200, exampleUser{}))),
+	}
+}
+
+func TestSpec_GenerationCacheMatchesUncached(t *testing.T) {
+	t.Parallel()
+
+	cached := MustNew(WithTitle("API", "1.0.0"), WithOperations(cacheTestOperations()...))
+	uncached := MustNew(WithTitle("API", "1.0.0"), WithGenerationCache(false), WithOperations(cacheTestOperations()...))
+
+	want := specJSON(t, uncached)
+	assert.Equal(t, want, specJSON(t, cached))
+	assert.Equal(t, want, specJSON(t, cached), "second generation is served from the cache")
+	assert.Nil(t, uncached.cache)
+}
+
+func TestSpec_GenerationCacheIncremental(t *testing.T) {
+	t.Parallel()
+
+	api := MustNew(WithTitle("API", "1.0.0"), WithOperations(cacheTestOperations()...))
+	specJSON(t, api)
+	assert.Equal(t, 4, api.cache.build.Len())
+
+	require.NoError(t, api.AddOperation(mustOperation(WithDELETE("/orders/:id", WithSummary("Delete order")))))
+	spec := specJSON(t, api)
+	assert.Equal(t, 5, api.cache.build.Len())
+	assert.Contains(t, spec["paths"].(map[string]any)["/orders/{id}"], "delete")
+
+	// Operations are keyed by content, so an equal operation built elsewhere is a hit.
+	other := MustNew(WithTitle("API", "1.0.0"))
+	other.cache = api.cache
+	require.NoError(t, other.AddOperation(mustOperation(WithGET("/users/:id", WithResponse(200, exampleUser{})))))
+	spec = specJSON(t, other)
+	assert.Len(t, spec["paths"], 1)
+	assert.Equal(t, 1, api.cache.build.Len(), "operations missing from a build are evicted")
+	assert.Contains(t, spec["components"].(map[string]any)["schemas"], "openapi.exampleUser")
+}
+
+func TestSpec_GenerationCacheDuplicateOperationID(t *testing.T) {
+	t.Parallel()
+
+	api := MustNew(WithTitle("API", "1.0.0"), WithOperations(
+		mustOperation(WithGET("/a", WithOperationID("same"), WithSummary("A"))),
+		mustOperation(WithGET("/b", WithOperationID("same"), WithSummary("B"))),
+	))
+
+	_, err := api.Spec(t.Context())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "duplicate operation ID: same")
+}
+
+func TestOperationKey(t *testing.T) {
+	t.Parallel()
+
+	key := func(op Operation) string {
+		t.Helper()
+		k, ok := operationKey(op)
+		require.True(t, ok)
+		return k
+	}
+
+	base := key(mustOperation(WithGET("/users/:id", WithResponse(200, exampleUser{}))))
+	assert.Equal(t, base, key(mustOperation(WithGET("/users/:id", WithResponse(200, exampleUser{})))))
+
+	for i, op := range []Operation{
+		mustOperation(WithGET("/users/:id", WithResponse(200, exampleUser{Name: "Ada"}))),
+		mustOperation(WithGET("/users/:id", WithResponse(201, exampleUser{}))),
+		mustOperation(WithGET("/users/:id", WithResponse(200, cacheItem{}))),
+		mustOperation(WithGET("/users/:id", WithResponse(200, exampleUser{}), WithSummary("Get"))),
+		mustOperation(WithGET("/users/:id", WithResponse(200, exampleUser{}), WithResponseHeader(200, "ETag", ""))),
+		mustOperation(WithPUT("/users/:id", WithResponse(200, exampleUser{}))),
+	} {
+		assert.NotEqual(t, base, key(op), fmt.Sprintf("operation %d", i))
+	}
+
+	_, ok := operationKey(mustOperation(WithGET("/x", WithResponse(200, exampleUser{}, example.New("fn", func() {})))))
+	assert.False(t, ok, "values without a JSON encoding disable caching")
+}
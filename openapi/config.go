@@ -20,7 +20,9 @@ import (
 	"strings"
 	"sync"
 
+	"rivaas.dev/openapi/example"
 	"rivaas.dev/openapi/internal/model"
+	"rivaas.dev/openapi/internal/overlay"
 	"rivaas.dev/openapi/validate"
 )
 
@@ -42,6 +44,9 @@ type config struct {
 	validateSpec     bool
 	ui               uiConfig
 	operations       []Operation
+	patches          []*overlay.Patch
+	exampleGenerator example.Generator
+	generationCache  bool
 	validationErrors []error // Errors from nil options (e.g. WithSwaggerUI)
 }
 
@@ -60,6 +65,7 @@ func defaultConfig() *config {
 		serveUI:         true,
 		validateSpec:    false,
 		ui:              defaultUIConfig(),
+		generationCache: true,
 	}
 }
 
@@ -84,6 +90,9 @@ type API struct {
 	ui              uiConfig
 	operations      []Operation
 	operationsMu    sync.RWMutex
+	patches         []*overlay.Patch
+	exampleGen      example.Generator
+	cache           *specCache // nil when generation caching is disabled
 }
 
 // Option configures OpenAPI behavior using the functional options pattern.
@@ -207,6 +216,10 @@ func apiFromConfig(cfg *config) *API {
 	if ops == nil {
 		ops = []Operation{}
 	}
+	var cache *specCache
+	if cfg.generationCache {
+		cache = newSpecCache()
+	}
 	return &API{
 		info:            cfg.info,
 		servers:         cfg.servers,
@@ -223,6 +236,9 @@ func apiFromConfig(cfg *config) *API {
 		validateSpec:    cfg.validateSpec,
 		ui:              cfg.ui,
 		operations:      ops,
+		patches:         cfg.patches,
+		exampleGen:      cfg.exampleGenerator,
+		cache:           cache,
 	}
 }
 
@@ -0,0 +1,804 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package contract
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// specURL is the in-memory resource URL the specification is registered under.
+const specURL = "mem:///openapi.json"
+
+// messagePrinter renders schema error messages.
+var messagePrinter = message.NewPrinter(language.English)
+
+// config holds construction-time validator configuration.
+// Options mutate config; New builds the Validator from it.
+type config struct {
+	formatAssertions bool
+	maxBodyBytes     int64
+}
+
+// Option configures the validator using the functional options pattern.
+type Option func(*config)
+
+func defaultConfig() *config {
+	return &config{
+		formatAssertions: true,
+		maxBodyBytes:     1 << 20, // 1MB
+	}
+}
+
+// WithFormatAssertions controls whether "format" keywords (email, uuid, date-time, ...)
+// are enforced. Default: true.
+//
+// Example:
+//
+//	contract.New(spec, contract.WithFormatAssertions(false))
+func WithFormatAssertions(enabled bool) Option {
+	return func(c *config) {
+		c.formatAssertions = enabled
+	}
+}
+
+// WithMaxBodyBytes sets the maximum body size that is decoded and validated.
+// Larger bodies are not validated (a body violation is not reported for them).
+// Default: 1MB.
+//
+// Example:
+//
+//	contract.New(spec, contract.WithMaxBodyBytes(4<<20))
+func WithMaxBodyBytes(n int64) Option {
+	return func(c *config) {
+		c.maxBodyBytes = n
+	}
+}
+
+// Validator checks HTTP requests and responses against an OpenAPI specification.
+// Thread-safe. All schemas are compiled once in [New].
+type Validator struct {
+	operations   map[string][]*operation // keyed by upper-case HTTP method
+	maxBodyBytes int64
+}
+
+// operation is a compiled OpenAPI operation.
+type operation struct {
+	method      string
+	template    string
+	segments    []string
+	literals    int // number of non-parameter segments; used to prefer static matches
+	params      []*parameter
+	body        *requestBody
+	responses   map[string]*response // keyed by "200", "4XX", "default"
+	hasResponse bool
+}
+
+// parameter is a compiled OpenAPI parameter.
+type parameter struct {
+	name     string
+	in       Location
+	required bool
+	schema   *jsonschema.Schema
+	typ      string // JSON type of the value ("string", "integer", "array", ...)
+	itemType string // JSON type of array items
+}
+
+// requestBody is a compiled OpenAPI request body.
+type requestBody struct {
+	required bool
+	content  map[string]*jsonschema.Schema // media type -> schema (nil schema = no schema)
+}
+
+// response is a compiled OpenAPI response.
+type response struct {
+	content map[string]*jsonschema.Schema
+}
+
+// New builds a Validator from a serialized OpenAPI 3.0.x or 3.1.x document (JSON).
+//
+// Example:
+//
+//	result, _ := api.Spec(ctx)
+//	v, err := contract.New(result.JSON)
+func New(spec []byte, opts ...Option) (*Validator, error) {
+	cfg := defaultConfig()
+	for i, opt := range opts {
+		if opt == nil {
+			return nil, fmt.Errorf("openapi/contract: option at index %d cannot be nil", i)
+		}
+		opt(cfg)
+	}
+
+	raw, err := jsonschema.UnmarshalJSON(bytes.NewReader(spec))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSpec, err)
+	}
+	doc, ok := raw.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%w: document is not an object", ErrInvalidSpec)
+	}
+	version, _ := doc["openapi"].(string)
+
+	compiler := jsonschema.NewCompiler()
+	switch {
+	case strings.HasPrefix(version, "3.0"):
+		// OpenAPI 3.0 schema objects are an extended subset of draft-04.
+		// nullable has no draft-04 equivalent, so rewrite it into type arrays.
+		compiler.DefaultDraft(jsonschema.Draft4)
+		rewriteNullable(doc)
+	case strings.HasPrefix(version, "3.1"):
+		compiler.DefaultDraft(jsonschema.Draft2020)
+	default:
+		return nil, fmt.Errorf("%w: unsupported openapi version %q", ErrInvalidSpec, version)
+	}
+	if cfg.formatAssertions {
+		compiler.AssertFormat()
+	}
+	if err = compiler.AddResource(specURL, doc); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSpec, err)
+	}
+
+	b := &builder{doc: doc, compiler: compiler}
+	v := &Validator{
+		operations:   make(map[string][]*operation),
+		maxBodyBytes: cfg.maxBodyBytes,
+	}
+
+	paths, _ := doc["paths"].(map[string]any)
+	for _, template := range sortedKeys(paths) {
+		item, _ := b.resolve(paths[template]).(map[string]any)
+		if item == nil {
+			continue
+		}
+		itemPtr := "/paths/" + escapePointer(template)
+		for _, method := range []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"} {
+			opRaw, ok := item[method].(map[string]any)
+			if !ok {
+				continue
+			}
+			op, opErr := b.operation(strings.ToUpper(method), template, itemPtr, item, opRaw)
+			if opErr != nil {
+				return nil, opErr
+			}
+			v.operations[op.method] = append(v.operations[op.method], op)
+		}
+	}
+
+	// Prefer templates with more literal segments so /users/me wins over /users/{id}.
+	for _, ops := range v.operations {
+		sort.SliceStable(ops, func(i, j int) bool { return ops[i].literals > ops[j].literals })
+	}
+
+	return v, nil
+}
+
+// MustNew builds a Validator and panics on error.
+// Use in main() or init() where panic on startup is acceptable.
+func MustNew(spec []byte, opts ...Option) *Validator {
+	v, err := New(spec, opts...)
+	if err != nil {
+		panic(fmt.Sprintf("contract.MustNew: %v", err))
+	}
+	return v
+}
+
+// ValidateRequest checks r against the matching operation.
+//
+// Returns nil when the request conforms, [ErrOperationNotFound] when no operation
+// matches, or an [*Error] listing every violation. The request body is read and
+// restored so downstream handlers can still consume it.
+func (v *Validator) ValidateRequest(r *http.Request) error {
+	op, pathParams := v.match(r.Method, r.URL.Path)
+	if op == nil {
+		return ErrOperationNotFound
+	}
+
+	var violations []Violation
+	var query url.Values
+	for _, p := range op.params {
+		var values []string
+		switch p.in {
+		case InPath:
+			if val, ok := pathParams[p.name]; ok {
+				values = []string{val}
+			}
+		case InQuery:
+			if query == nil {
+				query = r.URL.Query()
+			}
+			values = query[p.name]
+		case InHeader:
+			values = r.Header.Values(p.name)
+		case InCookie:
+			if ck, err := r.Cookie(p.name); err == nil {
+				values = []string{ck.Value}
+			}
+		}
+		violations = append(violations, p.check(values)...)
+	}
+
+	if op.body != nil {
+		violations = append(violations, v.checkRequestBody(r, op.body)...)
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+	return &Error{Direction: DirectionRequest, Method: r.Method, Path: op.template, Violations: violations}
+}
+
+// ValidateResponse checks a response produced for r against the matching operation.
+//
+// body is the (possibly truncated) response body; pass nil when the body was not
+// captured. Returns nil when the response conforms, [ErrOperationNotFound] when no
+// operation matches r, or an [*Error] listing every violation.
+func (v *Validator) ValidateResponse(r *http.Request, status int, header http.Header, body []byte) error {
+	op, _ := v.match(r.Method, r.URL.Path)
+	if op == nil {
+		return ErrOperationNotFound
+	}
+	if !op.hasResponse {
+		return nil
+	}
+
+	resp := op.responseFor(status)
+	if resp == nil {
+		return &Error{
+			Direction: DirectionResponse,
+			Method:    r.Method,
+			Path:      op.template,
+			Violations: []Violation{{
+				In:      InStatus,
+				Message: fmt.Sprintf("status %d is not documented", status),
+			}},
+		}
+	}
+
+	// No declared content (e.g. 204) or nothing written: nothing further to check.
+	if len(resp.content) == 0 || len(body) == 0 || r.Method == http.MethodHead {
+		return nil
+	}
+
+	violations := v.checkBody(header.Get("Content-Type"), body, resp.content)
+	if len(violations) == 0 {
+		return nil
+	}
+	return &Error{Direction: DirectionResponse, Method: r.Method, Path: op.template, Violations: violations}
+}
+
+// match finds the operation for method and path and extracts path parameters.
+func (v *Validator) match(method, path string) (*operation, map[string]string) {
+	ops := v.operations[strings.ToUpper(method)]
+	if len(ops) == 0 {
+		return nil, nil
+	}
+	segments := splitPath(path)
+	for _, op := range ops {
+		if params, ok := op.matchSegments(segments); ok {
+			return op, params
+		}
+	}
+	return nil, nil
+}
+
+// matchSegments reports whether the request path segments match the operation template.
+func (op *operation) matchSegments(segments []string) (map[string]string, bool) {
+	if len(segments) != len(op.segments) {
+		return nil, false
+	}
+	var params map[string]string
+	for i, seg := range op.segments {
+		if name, ok := templateParam(seg); ok {
+			if segments[i] == "" {
+				return nil, false
+			}
+			if params == nil {
+				params = make(map[string]string)
+			}
+			val, err := url.PathUnescape(segments[i])
+			if err != nil {
+				val = segments[i]
+			}
+			params[name] = val
+			continue
+		}
+		if seg != segments[i] {
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+// responseFor resolves the documented response for status: exact code, then range, then default.
+func (op *operation) responseFor(status int) *response {
+	code := strconv.Itoa(status)
+	if r, ok := op.responses[code]; ok {
+		return r
+	}
+	if r, ok := op.responses[code[:1]+"XX"]; ok {
+		return r
+	}
+	return op.responses["default"]
+}
+
+// check validates the raw values of a parameter.
+func (p *parameter) check(values []string) []Violation {
+	if len(values) == 0 {
+		if p.required {
+			return []Violation{{In: p.in, Name: p.name, Message: "required parameter is missing"}}
+		}
+		return nil
+	}
+	if p.schema == nil {
+		return nil
+	}
+
+	var instance any
+	if p.typ == "array" {
+		items := values
+		if len(values) == 1 && strings.Contains(values[0], ",") && p.in != InQuery {
+			items = strings.Split(values[0], ",")
+		}
+		arr := make([]any, 0, len(items))
+		for _, item := range items {
+			val, err := coerce(item, p.itemType)
+			if err != nil {
+				return []Violation{{In: p.in, Name: p.name, Message: err.Error()}}
+			}
+			arr = append(arr, val)
+		}
+		instance = arr
+	} else {
+		val, err := coerce(values[0], p.typ)
+		if err != nil {
+			return []Violation{{In: p.in, Name: p.name, Message: err.Error()}}
+		}
+		instance = val
+	}
+
+	if err := p.schema.Validate(instance); err != nil {
+		var violations []Violation
+		for _, msg := range schemaMessages(err) {
+			violations = append(violations, Violation{In: p.in, Name: p.name, Message: msg.message})
+		}
+		return violations
+	}
+	return nil
+}
+
+// checkRequestBody reads, restores, and validates the request body.
+func (v *Validator) checkRequestBody(r *http.Request, rb *requestBody) []Violation {
+	var body []byte
+	if r.Body != nil && r.Body != http.NoBody {
+		limited := io.LimitReader(r.Body, v.maxBodyBytes+1)
+		data, err := io.ReadAll(limited)
+		// Restore what was read followed by anything left unread.
+		r.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(data), r.Body), r.Body}
+		if err != nil {
+			return []Violation{{In: InBody, Message: "failed to read body: " + err.Error()}}
+		}
+		if int64(len(data)) > v.maxBodyBytes {
+			return nil // too large to validate
+		}
+		body = data
+	}
+
+	if len(body) == 0 {
+		if rb.required {
+			return []Violation{{In: InBody, Message: "request body is required"}}
+		}
+		return nil
+	}
+	return v.checkBody(r.Header.Get("Content-Type"), body, rb.content)
+}
+
+// checkBody validates a body against the schema declared for its content type.
+func (v *Validator) checkBody(contentType string, body []byte, content map[string]*jsonschema.Schema) []Violation {
+	if len(content) == 0 {
+		return nil
+	}
+	mediaType := ""
+	if contentType != "" {
+		if mt, _, err := mime.ParseMediaType(contentType); err == nil {
+			mediaType = mt
+		}
+	}
+	schema, ok := lookupMediaType(content, mediaType)
+	if !ok {
+		return []Violation{{
+			In:      InHeader,
+			Name:    "Content-Type",
+			Message: fmt.Sprintf("content type %q is not declared (expected one of: %s)", contentType, strings.Join(sortedKeys(content), ", ")),
+		}}
+	}
+	if schema == nil || !isJSONMediaType(mediaType) {
+		return nil
+	}
+
+	instance, err := jsonschema.UnmarshalJSON(bytes.NewReader(body))
+	if err != nil {
+		return []Violation{{In: InBody, Message: "invalid JSON: " + err.Error()}}
+	}
+	if err := schema.Validate(instance); err != nil {
+		msgs := schemaMessages(err)
+		violations := make([]Violation, 0, len(msgs))
+		for _, m := range msgs {
+			pointer := m.pointer
+			if pointer == "" {
+				pointer = "/"
+			}
+			violations = append(violations, Violation{In: InBody, Pointer: pointer, Message: m.message})
+		}
+		return violations
+	}
+	return nil
+}
+
+// lookupMediaType finds the schema for mediaType, honoring wildcards like "application/*".
+func lookupMediaType(content map[string]*jsonschema.Schema, mediaType string) (*jsonschema.Schema, bool) {
+	if s, ok := content[mediaType]; ok {
+		return s, true
+	}
+	if major, _, found := strings.Cut(mediaType, "/"); found {
+		if s, ok := content[major+"/*"]; ok {
+			return s, true
+		}
+	}
+	s, ok := content["*/*"]
+	return s, ok
+}
+
+// isJSONMediaType reports whether mediaType is application/json or a +json suffix type.
+func isJSONMediaType(mediaType string) bool {
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// schemaMessage is a single leaf schema failure.
+type schemaMessage struct {
+	pointer string
+	message string
+}
+
+// schemaMessages flattens a jsonschema validation error into leaf messages.
+func schemaMessages(err error) []schemaMessage {
+	ve, ok := err.(*jsonschema.ValidationError)
+	if !ok {
+		return []schemaMessage{{message: err.Error()}}
+	}
+	var out []schemaMessage
+	var walk func(e *jsonschema.ValidationError)
+	walk = func(e *jsonschema.ValidationError) {
+		if len(e.Causes) == 0 {
+			out = append(out, schemaMessage{
+				pointer: instancePointer(e.InstanceLocation),
+				message: e.ErrorKind.LocalizedString(messagePrinter),
+			})
+			return
+		}
+		for _, cause := range e.Causes {
+			walk(cause)
+		}
+	}
+	walk(ve)
+	return out
+}
+
+// instancePointer renders instance location tokens as an RFC 6901 JSON pointer.
+func instancePointer(tokens []string) string {
+	var sb strings.Builder
+	for _, tok := range tokens {
+		sb.WriteByte('/')
+		sb.WriteString(escapePointer(tok))
+	}
+	return sb.String()
+}
+
+// coerce converts a raw string parameter into the JSON value implied by typ.
+func coerce(raw, typ string) (any, error) {
+	switch typ {
+	case "integer":
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("value %q is not an integer", raw)
+		}
+		return n, nil
+	case "number":
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, fmt.Errorf("value %q is not a number", raw)
+		}
+		return f, nil
+	case "boolean":
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("value %q is not a boolean", raw)
+		}
+		return b, nil
+	default:
+		return raw, nil
+	}
+}
+
+// builder compiles operations from the raw specification document.
+type builder struct {
+	doc      map[string]any
+	compiler *jsonschema.Compiler
+}
+
+// operation compiles a single operation object.
+func (b *builder) operation(method, template, itemPtr string, item, raw map[string]any) (*operation, error) {
+	segments := splitPath(template)
+	op := &operation{
+		method:    method,
+		template:  template,
+		segments:  segments,
+		responses: make(map[string]*response),
+	}
+	for _, seg := range segments {
+		if _, ok := templateParam(seg); !ok {
+			op.literals++
+		}
+	}
+	opPtr := itemPtr + "/" + strings.ToLower(method)
+
+	// Path-item parameters apply unless overridden at operation level (same name + in).
+	seen := make(map[string]bool)
+	for _, src := range []struct {
+		list any
+		ptr  string
+	}{{raw["parameters"], opPtr + "/parameters"}, {item["parameters"], itemPtr + "/parameters"}} {
+		list, _ := src.list.([]any)
+		for i, pRaw := range list {
+			p, err := b.parameter(pRaw, fmt.Sprintf("%s/%d", src.ptr, i))
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s %s: %w", ErrInvalidSpec, method, template, err)
+			}
+			if p == nil {
+				continue
+			}
+			key := string(p.in) + ":" + strings.ToLower(p.name)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			op.params = append(op.params, p)
+		}
+	}
+
+	if rbRaw, ok := raw["requestBody"]; ok {
+		rb, ptr := b.resolveWithPointer(rbRaw, opPtr+"/requestBody")
+		if rbMap, isMap := rb.(map[string]any); isMap {
+			content, err := b.content(rbMap, ptr)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s %s: %w", ErrInvalidSpec, method, template, err)
+			}
+			required, _ := rbMap["required"].(bool)
+			op.body = &requestBody{required: required, content: content}
+		}
+	}
+
+	if responses, ok := raw["responses"].(map[string]any); ok {
+		op.hasResponse = len(responses) > 0
+		for code, rRaw := range responses {
+			if strings.HasPrefix(code, "x-") {
+				continue
+			}
+			r, ptr := b.resolveWithPointer(rRaw, opPtr+"/responses/"+escapePointer(code))
+			rMap, isMap := r.(map[string]any)
+			if !isMap {
+				continue
+			}
+			content, err := b.content(rMap, ptr)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s %s: %w", ErrInvalidSpec, method, template, err)
+			}
+			if code != "default" {
+				code = strings.ToUpper(code)
+			}
+			op.responses[code] = &response{content: content}
+		}
+	}
+
+	return op, nil
+}
+
+// parameter compiles a parameter object. Returns nil for unsupported locations.
+func (b *builder) parameter(raw any, ptr string) (*parameter, error) {
+	resolved, ptr := b.resolveWithPointer(raw, ptr)
+	m, ok := resolved.(map[string]any)
+	if !ok {
+		return nil, nil
+	}
+	name, _ := m["name"].(string)
+	in, _ := m["in"].(string)
+	loc := Location(in)
+	switch loc {
+	case InPath, InQuery, InHeader, InCookie:
+	default:
+		return nil, nil
+	}
+	required, _ := m["required"].(bool)
+	p := &parameter{name: name, in: loc, required: required || loc == InPath}
+
+	if schemaRaw, hasSchema := m["schema"]; hasSchema {
+		schema, err := b.compiler.Compile(specURL + "#" + ptr + "/schema")
+		if err != nil {
+			return nil, fmt.Errorf("parameter %q: %w", name, err)
+		}
+		p.schema = schema
+		p.typ = b.schemaType(schemaRaw)
+		if p.typ == "array" {
+			if sm, isMap := b.resolve(schemaRaw).(map[string]any); isMap {
+				p.itemType = b.schemaType(sm["items"])
+			}
+		}
+	}
+	return p, nil
+}
+
+// content compiles the "content" map of a request body or response.
+func (b *builder) content(m map[string]any, ptr string) (map[string]*jsonschema.Schema, error) {
+	content, _ := m["content"].(map[string]any)
+	if len(content) == 0 {
+		return nil, nil
+	}
+	out := make(map[string]*jsonschema.Schema, len(content))
+	for mediaType, mtRaw := range content {
+		mt, _ := mtRaw.(map[string]any)
+		if _, hasSchema := mt["schema"]; !hasSchema {
+			out[mediaType] = nil
+			continue
+		}
+		schema, err := b.compiler.Compile(specURL + "#" + ptr + "/content/" + escapePointer(mediaType) + "/schema")
+		if err != nil {
+			return nil, fmt.Errorf("media type %q: %w", mediaType, err)
+		}
+		out[mediaType] = schema
+	}
+	return out, nil
+}
+
+// schemaType returns the primary JSON type of a raw schema, following local $refs.
+func (b *builder) schemaType(raw any) string {
+	m, ok := b.resolve(raw).(map[string]any)
+	if !ok {
+		return ""
+	}
+	switch t := m["type"].(type) {
+	case string:
+		return t
+	case []any:
+		for _, v := range t {
+			if s, isStr := v.(string); isStr && s != "null" {
+				return s
+			}
+		}
+	}
+	return ""
+}
+
+// resolve follows local "$ref" pointers and returns the referenced value.
+func (b *builder) resolve(raw any) any {
+	v, _ := b.resolveWithPointer(raw, "")
+	return v
+}
+
+// resolveWithPointer follows local "$ref" pointers, returning the value and its JSON pointer.
+func (b *builder) resolveWithPointer(raw any, ptr string) (any, string) {
+	for range 16 { // bound ref chains to avoid cycles
+		m, ok := raw.(map[string]any)
+		if !ok {
+			return raw, ptr
+		}
+		ref, ok := m["$ref"].(string)
+		if !ok || !strings.HasPrefix(ref, "#/") {
+			return raw, ptr
+		}
+		ptr = ref[1:]
+		raw = lookupPointer(b.doc, ptr)
+	}
+	return raw, ptr
+}
+
+// lookupPointer resolves an RFC 6901 JSON pointer inside doc.
+func lookupPointer(doc any, ptr string) any {
+	cur := doc
+	for tok := range strings.SplitSeq(strings.TrimPrefix(ptr, "/"), "/") {
+		tok = strings.ReplaceAll(strings.ReplaceAll(tok, "~1", "/"), "~0", "~")
+		switch node := cur.(type) {
+		case map[string]any:
+			cur = node[tok]
+		case []any:
+			i, err := strconv.Atoi(tok)
+			if err != nil || i < 0 || i >= len(node) {
+				return nil
+			}
+			cur = node[i]
+		default:
+			return nil
+		}
+	}
+	return cur
+}
+
+// rewriteNullable converts OpenAPI 3.0 "nullable: true" into draft-04 type arrays in place.
+func rewriteNullable(node any) {
+	switch n := node.(type) {
+	case map[string]any:
+		if nullable, _ := n["nullable"].(bool); nullable {
+			if t, ok := n["type"].(string); ok {
+				n["type"] = []any{t, "null"}
+			}
+			if enum, ok := n["enum"].([]any); ok && !slices.Contains(enum, nil) {
+				n["enum"] = append(enum, nil)
+			}
+		}
+		for _, v := range n {
+			rewriteNullable(v)
+		}
+	case []any:
+		for _, v := range n {
+			rewriteNullable(v)
+		}
+	}
+}
+
+// splitPath splits a URL path into segments, ignoring leading and trailing slashes.
+func splitPath(p string) []string {
+	p = strings.Trim(p, "/")
+	if p == "" {
+		return nil
+	}
+	return strings.Split(p, "/")
+}
+
+// templateParam returns the parameter name if seg is a "{name}" template segment.
+func templateParam(seg string) (string, bool) {
+	if len(seg) > 2 && seg[0] == '{' && seg[len(seg)-1] == '}' {
+		return seg[1 : len(seg)-1], true
+	}
+	return "", false
+}
+
+// escapePointer escapes a JSON pointer reference token (RFC 6901).
+func escapePointer(tok string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(tok, "~", "~0"), "/", "~1")
+}
+
+// sortedKeys returns the keys of m in sorted order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,226 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package contract_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"rivaas.dev/openapi"
+	"rivaas.dev/openapi/contract"
+)
+
+type createUserRequest struct {
+	Name  string `json:"name" validate:"required"`
+	Email string `json:"email" validate:"required,email"`
+	Age   int    `json:"age,omitempty" validate:"min=0"`
+}
+
+type listUsersRequest struct {
+	Limit int    `query:"limit"`
+	Sort  string `query:"sort" enum:"name,created"`
+}
+
+type user struct {
+	ID    int     `json:"id" validate:"required"`
+	Name  string  `json:"name" validate:"required"`
+	Email *string `json:"email"`
+}
+
+func buildSpec(t *testing.T, version openapi.Version) []byte {
+	t.Helper()
+	api := openapi.MustNew(
+		openapi.WithTitle("Test", "1.0.0"),
+		openapi.WithVersion(version),
+		openapi.WithOperations(
+			mustOp(openapi.WithGET("/users", openapi.WithRequest(listUsersRequest{}), openapi.WithResponse(200, []user{}))),
+			mustOp(openapi.WithPOST("/users", openapi.WithRequest(createUserRequest{}), openapi.WithResponse(201, user{}))),
+			mustOp(openapi.WithGET("/users/:id", openapi.WithSummary("Get user"), openapi.WithResponse(200, user{}))),
+			mustOp(openapi.WithGET("/users/me", openapi.WithSummary("Current user"), openapi.WithResponse(200, user{}))),
+		),
+	)
+	result, err := api.Spec(t.Context())
+	require.NoError(t, err)
+	return result.JSON
+}
+
+func mustOp(op openapi.Operation, err error) openapi.Operation {
+	if err != nil {
+		panic(err)
+	}
+	return op
+}
+
+func violationsOf(t *testing.T, err error) []contract.Violation {
+	t.Helper()
+	var cerr *contract.Error
+	require.ErrorAs(t, err, &cerr)
+	require.NotEmpty(t, cerr.Violations)
+	return cerr.Violations
+}
+
+func TestNew_InvalidSpec(t *testing.T) {
+	t.Parallel()
+
+	_, err := contract.New([]byte(`not json`))
+	require.ErrorIs(t, err, contract.ErrInvalidSpec)
+
+	_, err = contract.New([]byte(`{"openapi":"2.0"}`))
+	require.ErrorIs(t, err, contract.ErrInvalidSpec)
+
+	_, err = contract.New([]byte(`{"openapi":"3.0.4"}`), nil)
+	require.Error(t, err)
+}
+
+func TestValidateRequest(t *testing.T) {
+	t.Parallel()
+
+	for _, version := range []openapi.Version{openapi.V30x, openapi.V31x} {
+		t.Run(string(version), func(t *testing.T) {
+			t.Parallel()
+			v := contract.MustNew(buildSpec(t, version))
+
+			tests := []struct {
+				name        string
+				method      string
+				target      string
+				contentType string
+				body        string
+				wantIn      contract.Location
+				wantOK      bool
+			}{
+				{name: "valid query", method: http.MethodGet, target: "/users?limit=10&sort=name", wantOK: true},
+				{name: "query wrong type", method: http.MethodGet, target: "/users?limit=abc", wantIn: contract.InQuery},
+				{name: "query not in enum", method: http.MethodGet, target: "/users?sort=age", wantIn: contract.InQuery},
+				{name: "valid body", method: http.MethodPost, target: "/users", contentType: "application/json", body: `{"name":"Ada","email":"ada@example.com"}`, wantOK: true},
+				{name: "missing required field", method: http.MethodPost, target: "/users", contentType: "application/json", body: `{"email":"ada@example.com"}`, wantIn: contract.InBody},
+				{name: "invalid format", method: http.MethodPost, target: "/users", contentType: "application/json", body: `{"name":"Ada","email":"nope"}`, wantIn: contract.InBody},
+				{name: "invalid json", method: http.MethodPost, target: "/users", contentType: "application/json", body: `{`, wantIn: contract.InBody},
+				{name: "undeclared content type", method: http.MethodPost, target: "/users", contentType: "text/plain", body: `hi`, wantIn: contract.InHeader},
+			}
+
+			for _, tt := range tests {
+				t.Run(tt.name, func(t *testing.T) {
+					t.Parallel()
+					var body io.Reader
+					if tt.body != "" {
+						body = strings.NewReader(tt.body)
+					}
+					req := httptest.NewRequest(tt.method, tt.target, body)
+					if tt.contentType != "" {
+						req.Header.Set("Content-Type", tt.contentType)
+					}
+
+					err := v.ValidateRequest(req)
+					if tt.wantOK {
+						require.NoError(t, err)
+						return
+					}
+					violations := violationsOf(t, err)
+					assert.Equal(t, tt.wantIn, violations[0].In)
+				})
+			}
+		})
+	}
+}
+
+func TestValidateRequest_BodyIsRestored(t *testing.T) {
+	t.Parallel()
+
+	v := contract.MustNew(buildSpec(t, openapi.V30x))
+	payload := `{"name":"Ada","email":"ada@example.com"}`
+	req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+
+	require.NoError(t, v.ValidateRequest(req))
+
+	data, err := io.ReadAll(req.Body)
+	require.NoError(t, err)
+	assert.Equal(t, payload, string(data))
+}
+
+func TestValidateRequest_OperationNotFound(t *testing.T) {
+	t.Parallel()
+
+	v := contract.MustNew(buildSpec(t, openapi.V30x))
+	err := v.ValidateRequest(httptest.NewRequest(http.MethodDelete, "/users", nil))
+	require.ErrorIs(t, err, contract.ErrOperationNotFound)
+
+	err = v.ValidateRequest(httptest.NewRequest(http.MethodGet, "/orders", nil))
+	require.ErrorIs(t, err, contract.ErrOperationNotFound)
+}
+
+func TestValidateResponse(t *testing.T) {
+	t.Parallel()
+
+	v := contract.MustNew(buildSpec(t, openapi.V30x))
+	jsonHeader := http.Header{"Content-Type": []string{"application/json"}}
+
+	t.Run("valid", func(t *testing.T) {
+		t.Parallel()
+		req := httptest.NewRequest(http.MethodGet, "/users/42", nil)
+		err := v.ValidateResponse(req, http.StatusOK, jsonHeader, []byte(`{"id":42,"name":"Ada","email":null}`))
+		require.NoError(t, err)
+	})
+
+	t.Run("static segment preferred", func(t *testing.T) {
+		t.Parallel()
+		req := httptest.NewRequest(http.MethodGet, "/users/me", nil)
+		err := v.ValidateResponse(req, http.StatusOK, jsonHeader, []byte(`{"id":1}`))
+		var cerr *contract.Error
+		require.ErrorAs(t, err, &cerr)
+		assert.Equal(t, "/users/me", cerr.Path)
+		assert.Equal(t, contract.DirectionResponse, cerr.Direction)
+		assert.Equal(t, http.StatusInternalServerError, cerr.HTTPStatus())
+	})
+
+	t.Run("undocumented status", func(t *testing.T) {
+		t.Parallel()
+		req := httptest.NewRequest(http.MethodGet, "/users/42", nil)
+		violations := violationsOf(t, v.ValidateResponse(req, http.StatusTeapot, jsonHeader, nil))
+		assert.Equal(t, contract.InStatus, violations[0].In)
+	})
+
+	t.Run("schema mismatch reports pointer", func(t *testing.T) {
+		t.Parallel()
+		req := httptest.NewRequest(http.MethodGet, "/users", nil)
+		violations := violationsOf(t, v.ValidateResponse(req, http.StatusOK, jsonHeader, []byte(`[{"id":"x","name":"Ada"}]`)))
+		assert.Equal(t, contract.InBody, violations[0].In)
+		assert.Equal(t, "/0/id", violations[0].Pointer)
+	})
+}
+
+func TestError_Interfaces(t *testing.T) {
+	t.Parallel()
+
+	err := &contract.Error{
+		Direction:  contract.DirectionRequest,
+		Method:     http.MethodGet,
+		Path:       "/users",
+		Violations: []contract.Violation{{In: contract.InQuery, Name: "limit", Message: "bad"}},
+	}
+	assert.Equal(t, http.StatusBadRequest, err.HTTPStatus())
+	assert.Equal(t, "contract_request_violation", err.Code())
+	assert.Equal(t, err.Violations, err.Details())
+	assert.Contains(t, err.Error(), "query limit: bad")
+	assert.False(t, errors.Is(err, contract.ErrOperationNotFound))
+}
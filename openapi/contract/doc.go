@@ -0,0 +1,61 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package contract validates live HTTP traffic against an OpenAPI specification.
+//
+// A [Validator] is built from a serialized OpenAPI 3.0.x or 3.1.x document
+// (typically the JSON produced by [rivaas.dev/openapi.API.Spec]) and checks
+// requests and responses against the operations it declares. It is intended
+// to catch drift between handler code and the published contract, usually in
+// staging or in report-only mode in production.
+//
+// The package depends only on net/http and is framework-agnostic. The app
+// package wires it into the request pipeline via app.WithOpenAPIValidation.
+//
+// # Basic Usage
+//
+//	result, _ := api.Spec(ctx)
+//	v, err := contract.New(result.JSON)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	if err := v.ValidateRequest(req); err != nil {
+//	    var cerr *contract.Error
+//	    if errors.As(err, &cerr) {
+//	        for _, violation := range cerr.Violations {
+//	            log.Printf("%s %s: %s", violation.In, violation.Name, violation.Message)
+//	        }
+//	    }
+//	}
+//
+// # What Is Checked
+//
+// Requests:
+//
+//   - Required path, query, header, and cookie parameters are present
+//   - Parameter values match the declared schema (type, enum, bounds, pattern)
+//   - The request body is present when required
+//   - The Content-Type is one of the declared request media types
+//   - JSON bodies match the declared schema
+//
+// Responses:
+//
+//   - The status code is documented (exact code, range such as 4XX, or default)
+//   - The Content-Type is one of the declared response media types
+//   - JSON bodies match the declared schema
+//
+// Requests that do not match any documented operation return [ErrOperationNotFound];
+// callers usually ignore it so undocumented routes pass through untouched.
+package contract
@@ -0,0 +1,146 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package contract
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+var (
+	// ErrOperationNotFound indicates the request does not match any operation in the spec.
+	ErrOperationNotFound = errors.New("openapi/contract: no operation matches request")
+
+	// ErrInvalidSpec indicates the specification could not be parsed.
+	ErrInvalidSpec = errors.New("openapi/contract: invalid specification")
+)
+
+// Direction identifies which side of the exchange a violation was found on.
+type Direction string
+
+const (
+	// DirectionRequest marks violations found in the incoming request.
+	DirectionRequest Direction = "request"
+
+	// DirectionResponse marks violations found in the outgoing response.
+	DirectionResponse Direction = "response"
+)
+
+// Location identifies where in the message a violation was found.
+type Location string
+
+const (
+	// InPath marks a path parameter violation.
+	InPath Location = "path"
+
+	// InQuery marks a query parameter violation.
+	InQuery Location = "query"
+
+	// InHeader marks a header violation.
+	InHeader Location = "header"
+
+	// InCookie marks a cookie violation.
+	InCookie Location = "cookie"
+
+	// InBody marks a body violation.
+	InBody Location = "body"
+
+	// InStatus marks an undocumented response status code.
+	InStatus Location = "status"
+)
+
+// Violation describes a single contract mismatch.
+type Violation struct {
+	// In is where the violation was found (path, query, header, cookie, body, status).
+	In Location `json:"in"`
+
+	// Name is the parameter or header name. Empty for body and status violations.
+	Name string `json:"name,omitempty"`
+
+	// Pointer is the RFC 6901 JSON Pointer into the body for body violations.
+	Pointer string `json:"pointer,omitempty"`
+
+	// Message is a human-readable description of the violation.
+	Message string `json:"message"`
+}
+
+// String returns a compact representation such as "query limit: must be >= 1".
+func (v Violation) String() string {
+	var sb strings.Builder
+	sb.WriteString(string(v.In))
+	switch {
+	case v.Name != "":
+		sb.WriteString(" ")
+		sb.WriteString(v.Name)
+	case v.Pointer != "":
+		sb.WriteString(" ")
+		sb.WriteString(v.Pointer)
+	}
+	sb.WriteString(": ")
+	sb.WriteString(v.Message)
+	return sb.String()
+}
+
+// Error reports one or more contract violations for a single request or response.
+//
+// Error implements the optional interfaces recognized by rivaas.dev/errors
+// formatters (HTTPStatus, Code, Details), so it can be passed directly to
+// app.Context.Fail to produce a problem details response.
+type Error struct {
+	// Direction is whether the request or the response violated the contract.
+	Direction Direction
+
+	// Method is the HTTP method of the request.
+	Method string
+
+	// Path is the matched path template from the spec (e.g. "/users/{id}").
+	Path string
+
+	// Violations lists every mismatch found. Never empty.
+	Violations []Violation
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	parts := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		parts = append(parts, v.String())
+	}
+	return fmt.Sprintf("openapi/contract: %s %s %s does not match spec: %s",
+		e.Direction, e.Method, e.Path, strings.Join(parts, "; "))
+}
+
+// HTTPStatus returns 400 for request violations and 500 for response violations.
+func (e *Error) HTTPStatus() int {
+	if e.Direction == DirectionResponse {
+		return http.StatusInternalServerError
+	}
+	return http.StatusBadRequest
+}
+
+// Code returns a machine-readable error code.
+func (e *Error) Code() string {
+	if e.Direction == DirectionResponse {
+		return "contract_response_violation"
+	}
+	return "contract_request_violation"
+}
+
+// Details returns the violations for inclusion in error responses.
+func (e *Error) Details() any {
+	return e.Violations
+}
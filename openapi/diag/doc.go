@@ -61,6 +61,7 @@ Warnings are grouped into categories:
 
   - CategoryDownlevel: Features lost when converting 3.1 → 3.0
   - CategoryDeprecation: Using deprecated OpenAPI features
+  - CategoryOverlay: Generated values replaced by fragments or overlays, or overlay targets that matched nothing
 
 Validation issues are ERRORS, not warnings.
 */
@@ -65,6 +65,8 @@ func (c WarningCode) Category() WarningCategory {
 		return CategoryDownlevel
 	case len(c) >= 11 && c[:11] == "DEPRECATION":
 		return CategoryDeprecation
+	case len(c) >= 7 && c[:7] == "OVERLAY":
+		return CategoryOverlay
 	default:
 		return CategoryUnknown
 	}
@@ -115,6 +117,15 @@ const (
 	WarnDeprecationExampleSingular WarningCode = "DEPRECATION_EXAMPLE_SINGULAR"
 )
 
+// Overlay Warnings (merging fragments and overlay documents)
+const (
+	// WarnOverlayConflict indicates a fragment or overlay replaced a generated value.
+	WarnOverlayConflict WarningCode = "OVERLAY_CONFLICT"
+
+	// WarnOverlayNoMatch indicates an overlay action target matched no nodes.
+	WarnOverlayNoMatch WarningCode = "OVERLAY_NO_MATCH"
+)
+
 // WarningCategory groups related warning types.
 type WarningCategory string
 
@@ -129,6 +140,10 @@ const (
 	// CategoryDeprecation for deprecated feature usage.
 	// The feature still works but is discouraged.
 	CategoryDeprecation WarningCategory = "deprecation"
+
+	// CategoryOverlay for merging hand-maintained fragments and overlays.
+	// Conflicts are resolved in favor of the curated content.
+	CategoryOverlay WarningCategory = "overlay"
 )
 
 // String returns the category as a string.
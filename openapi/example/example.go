@@ -35,6 +35,10 @@
 //	example.NewExternal("large", "https://api.example.com/examples/large.json",
 //		example.WithSummary("Large dataset"),
 //	)
+//
+// Example values can also be built from Go types: [FromType] and [FromValue]
+// assemble objects from example tags, and a [Generator] such as [NewFaker]
+// fills fields that have none.
 package example
 
 // exampleConfig holds construction-time configuration for an Example.
@@ -0,0 +1,330 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package example
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math"
+	"math/rand/v2"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// fakerEpoch anchors generated timestamps so output is stable.
+var fakerEpoch = time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+var (
+	firstNames = []string{"Ada", "Alan", "Grace", "Linus", "Margaret", "Dennis", "Barbara", "Ken"}
+	lastNames  = []string{"Lovelace", "Turing", "Hopper", "Torvalds", "Hamilton", "Ritchie", "Liskov", "Thompson"}
+	cities     = []string{"Berlin", "Lisbon", "Toronto", "Osaka", "Nairobi", "Austin", "Oslo", "Melbourne"}
+	countries  = []string{"DE", "PT", "CA", "JP", "KE", "US", "NO", "AU"}
+	companies  = []string{"Acme Corp", "Globex", "Initech", "Umbrella", "Hooli", "Stark Industries"}
+	streets    = []string{"Main St", "Oak Ave", "Elm St", "Park Rd", "Lake Dr", "Hill St"}
+	words      = []string{"lorem", "ipsum", "dolor", "sit", "amet", "consectetur", "adipiscing", "elit", "sed", "do", "eiusmod", "tempor"}
+)
+
+// fakerConfig holds faker configuration.
+type fakerConfig struct {
+	seed uint64
+}
+
+// FakerOption configures the faker generator.
+type FakerOption func(*fakerConfig)
+
+// WithSeed sets the seed for generated values. The same seed always produces
+// the same values for the same fields. Default: 1.
+//
+// Example:
+//
+//	example.NewFaker(example.WithSeed(42))
+func WithSeed(seed uint64) FakerOption {
+	return func(c *fakerConfig) {
+		c.seed = seed
+	}
+}
+
+// faker generates realistic-looking values from field formats, validation
+// rules, and names.
+type faker struct {
+	seed uint64
+}
+
+// NewFaker returns a deterministic [Generator] producing realistic-looking
+// values. Values are chosen, in order, from:
+//
+//   - enum and validate:"oneof=..." tags
+//   - the field format (email, uuid, date-time, date, uri, hostname, ipv4, ipv6, ...)
+//   - the field name (name, first_name, email, phone, city, country, price, age, ...)
+//   - the field type, honoring validate min/max bounds
+//
+// Each value depends only on the seed and the field path, so regenerating a
+// spec yields identical examples.
+//
+// Example:
+//
+//	api := openapi.MustNew(
+//	    openapi.WithTitle("My API", "1.0.0"),
+//	    openapi.WithExampleGenerator(example.NewFaker()),
+//	)
+func NewFaker(opts ...FakerOption) Generator {
+	cfg := &fakerConfig{seed: 1}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(cfg)
+		}
+	}
+	return &faker{seed: cfg.seed}
+}
+
+// Generate implements [Generator].
+func (fk *faker) Generate(f Field) (any, bool) {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(f.Path))
+	r := rand.New(rand.NewPCG(fk.seed, h.Sum64()))
+
+	if choices := enumValues(f.Tag); len(choices) > 0 {
+		return schemaValue(choices[r.IntN(len(choices))], f.Type), true
+	}
+
+	if f.Type == reflect.TypeFor[time.Time]() {
+		return fakerEpoch.Add(time.Duration(r.IntN(365*24)) * time.Hour).Format(time.RFC3339), true
+	}
+
+	switch f.Type.Kind() {
+	case reflect.String:
+		return fakeString(r, f), true
+	case reflect.Slice, reflect.Array:
+		return "", true // []byte
+	case reflect.Bool:
+		return r.IntN(2) == 1, true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		lo, hi := intRange(f)
+		return lo + r.Int64N(hi-lo+1), true
+	case reflect.Float32, reflect.Float64:
+		lo, hi := floatRange(f)
+		return math.Round((lo+r.Float64()*(hi-lo))*100) / 100, true
+	}
+	return nil, false
+}
+
+// fakeString picks a string by format, then by field name.
+func fakeString(r *rand.Rand, f Field) string {
+	first, last := pick(r, firstNames), pick(r, lastNames)
+
+	switch f.Format {
+	case "email":
+		return strings.ToLower(first + "." + last + "@example.com")
+	case "uuid":
+		return fmt.Sprintf("%08x-%04x-4%03x-%04x-%012x", r.Uint32(), r.Uint32()&0xffff, r.Uint32()&0xfff, 0x8000|r.Uint32()&0x3fff, r.Uint64()&0xffffffffffff)
+	case "date-time":
+		return fakerEpoch.Add(time.Duration(r.IntN(365*24)) * time.Hour).Format(time.RFC3339)
+	case "date":
+		return fakerEpoch.AddDate(0, 0, r.IntN(365)).Format(time.DateOnly)
+	case "time":
+		return fakerEpoch.Add(time.Duration(r.IntN(24*60)) * time.Minute).Format("15:04:05Z")
+	case "uri", "url":
+		return "https://example.com/" + strings.ToLower(strings.ReplaceAll(f.Name, "_", "-"))
+	case "hostname":
+		return "api.example.com"
+	case "ipv4", "ip":
+		return fmt.Sprintf("192.0.2.%d", 1+r.IntN(254))
+	case "ipv6":
+		return fmt.Sprintf("2001:db8::%x", 1+r.IntN(0xfffe))
+	case "password":
+		return "********"
+	case "byte", "binary":
+		return ""
+	}
+
+	name := strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(f.Name))
+	switch {
+	case name == "email" || strings.HasSuffix(name, "email"):
+		return strings.ToLower(first + "." + last + "@example.com")
+	case strings.Contains(name, "firstname") || name == "givenname":
+		return first
+	case strings.Contains(name, "lastname") || name == "surname" || name == "familyname":
+		return last
+	case name == "username" || name == "login" || name == "handle":
+		return strings.ToLower(first[:1] + last)
+	case strings.Contains(name, "company") || strings.Contains(name, "organization"):
+		return pick(r, companies)
+	case strings.HasSuffix(name, "name"):
+		return first + " " + last
+	case strings.Contains(name, "phone"):
+		return fmt.Sprintf("+1-555-01%02d", r.IntN(100))
+	case strings.Contains(name, "city"):
+		return pick(r, cities)
+	case strings.Contains(name, "country"):
+		return pick(r, countries)
+	case strings.Contains(name, "street") || strings.Contains(name, "address"):
+		return fmt.Sprintf("%d %s", 1+r.IntN(999), pick(r, streets))
+	case strings.Contains(name, "zip") || strings.Contains(name, "postal"):
+		return fmt.Sprintf("%05d", r.IntN(100000))
+	case strings.Contains(name, "url") || strings.Contains(name, "website") || strings.Contains(name, "link"):
+		return "https://example.com/" + strings.ToLower(f.Name)
+	case strings.Contains(name, "currency"):
+		return "USD"
+	case strings.Contains(name, "color") || strings.Contains(name, "colour"):
+		return fmt.Sprintf("#%06x", r.IntN(0x1000000))
+	case name == "id" || strings.HasSuffix(name, "id"):
+		return fmt.Sprintf("%016x", r.Uint64())
+	case strings.Contains(name, "description") || strings.Contains(name, "summary") || strings.Contains(name, "bio") || strings.Contains(name, "comment"):
+		return sentence(r, 8)
+	case strings.Contains(name, "title"):
+		return sentence(r, 3)
+	}
+
+	s := sentence(r, 2)
+	if lo, hi, ok := lengthBounds(f.Tag); ok {
+		for len(s) < lo {
+			s += " " + pick(r, words)
+		}
+		if hi > 0 && len(s) > hi {
+			s = s[:hi]
+		}
+	}
+	return s
+}
+
+// intRange returns inclusive bounds from validate tags, or name-based defaults.
+func intRange(f Field) (int64, int64) {
+	lo, hi := int64(1), int64(1000)
+	name := strings.ToLower(f.Name)
+	switch {
+	case name == "age" || strings.HasPrefix(name, "age"):
+		lo, hi = 18, 80
+	case strings.Contains(name, "year"):
+		lo, hi = 2000, 2030
+	case strings.Contains(name, "count") || strings.Contains(name, "quantity") || strings.Contains(name, "total"):
+		lo, hi = 1, 100
+	case strings.Contains(name, "page") || strings.Contains(name, "limit") || strings.Contains(name, "size"):
+		lo, hi = 1, 50
+	}
+	if v, ok := bound(f.Tag, "min", "gte"); ok {
+		lo = int64(math.Ceil(v))
+		if hi < lo {
+			hi = lo + 100
+		}
+	}
+	if v, ok := bound(f.Tag, "max", "lte"); ok {
+		hi = int64(math.Floor(v))
+		if lo > hi {
+			lo = hi
+		}
+	}
+	if kind := f.Type.Kind(); kind >= reflect.Uint && kind <= reflect.Uint64 && lo < 0 {
+		lo = 0
+	}
+	return lo, hi
+}
+
+// floatRange returns bounds from validate tags, or name-based defaults.
+func floatRange(f Field) (float64, float64) {
+	lo, hi := 0.0, 100.0
+	name := strings.ToLower(f.Name)
+	if strings.Contains(name, "price") || strings.Contains(name, "amount") || strings.Contains(name, "cost") {
+		lo, hi = 1, 500
+	}
+	if v, ok := bound(f.Tag, "min", "gte"); ok {
+		lo = v
+		if hi < lo {
+			hi = lo + 100
+		}
+	}
+	if v, ok := bound(f.Tag, "max", "lte"); ok {
+		hi = v
+		if lo > hi {
+			lo = hi
+		}
+	}
+	return lo, hi
+}
+
+// lengthBounds returns min/max string lengths from validate tags.
+func lengthBounds(tag reflect.StructTag) (int, int, bool) {
+	lo, okLo := bound(tag, "min", "gte")
+	hi, okHi := bound(tag, "max", "lte")
+	if !okLo && !okHi {
+		return 0, 0, false
+	}
+	return int(lo), int(hi), true
+}
+
+// bound returns the first numeric value of the given validate rules.
+func bound(tag reflect.StructTag, rules ...string) (float64, bool) {
+	for part := range strings.SplitSeq(tag.Get("validate"), ",") {
+		key, val, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+		for _, rule := range rules {
+			if key == rule {
+				if v, err := strconv.ParseFloat(val, 64); err == nil {
+					return v, true
+				}
+			}
+		}
+	}
+	return 0, false
+}
+
+// enumValues returns allowed values from enum or validate:"oneof=..." tags.
+func enumValues(tag reflect.StructTag) []string {
+	if enum := tag.Get("enum"); enum != "" {
+		return strings.Split(enum, ",")
+	}
+	for part := range strings.SplitSeq(tag.Get("validate"), ",") {
+		if values, ok := strings.CutPrefix(strings.TrimSpace(part), "oneof="); ok {
+			return strings.Fields(values)
+		}
+	}
+	return nil
+}
+
+// schemaValue converts a string choice to the field's JSON type.
+func schemaValue(s string, t reflect.Type) any {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if v, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return v
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if v, err := strconv.ParseUint(s, 10, 64); err == nil {
+			return v
+		}
+	case reflect.Float32, reflect.Float64:
+		if v, err := strconv.ParseFloat(s, 64); err == nil {
+			return v
+		}
+	}
+	return s
+}
+
+func sentence(r *rand.Rand, n int) string {
+	parts := make([]string, n)
+	for i := range parts {
+		parts[i] = pick(r, words)
+	}
+	s := strings.Join(parts, " ")
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+func pick(r *rand.Rand, list []string) string {
+	return list[r.IntN(len(list))]
+}
@@ -0,0 +1,71 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package example
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakerTarget struct {
+	ID        string    `json:"id" format:"uuid"`
+	Email     string    `json:"email" validate:"email"`
+	FirstName string    `json:"first_name"`
+	Role      string    `json:"role" validate:"oneof=admin user"`
+	Age       int       `json:"age"`
+	Score     int       `json:"score" validate:"min=10,max=20"`
+	Price     float64   `json:"price"`
+	Website   string    `json:"website" validate:"url"`
+	Created   time.Time `json:"created"`
+	Verified  bool      `json:"verified"`
+}
+
+func TestNewFaker(t *testing.T) {
+	t.Parallel()
+
+	got, ok := FromType(reflect.TypeFor[fakerTarget](), NewFaker()).(map[string]any)
+	require.True(t, ok)
+
+	assert.Regexp(t, regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`), got["id"])
+	assert.Regexp(t, regexp.MustCompile(`^[a-z]+\.[a-z]+@example\.com$`), got["email"])
+	assert.Contains(t, firstNames, got["first_name"])
+	assert.Contains(t, []any{"admin", "user"}, got["role"])
+	assert.GreaterOrEqual(t, got["age"], int64(18))
+	assert.LessOrEqual(t, got["age"], int64(80))
+	assert.GreaterOrEqual(t, got["score"], int64(10))
+	assert.LessOrEqual(t, got["score"], int64(20))
+	assert.IsType(t, float64(0), got["price"])
+	assert.Equal(t, "https://example.com/website", got["website"])
+	_, err := time.Parse(time.RFC3339, got["created"].(string))
+	require.NoError(t, err)
+	assert.IsType(t, true, got["verified"])
+}
+
+func TestNewFaker_Deterministic(t *testing.T) {
+	t.Parallel()
+
+	typ := reflect.TypeFor[fakerTarget]()
+	first := FromType(typ, NewFaker(WithSeed(7)))
+	second := FromType(typ, NewFaker(WithSeed(7)))
+	other := FromType(typ, NewFaker(WithSeed(8)))
+
+	assert.Equal(t, first, second, "same seed must produce identical examples")
+	assert.NotEqual(t, first, other)
+}
@@ -0,0 +1,298 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package example
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"rivaas.dev/openapi/internal/schema"
+)
+
+// maxDepth bounds recursion through nested types.
+const maxDepth = 8
+
+// Field describes a value the generator is asked to produce.
+type Field struct {
+	// Name is the JSON name of the field ("" for array elements and map values).
+	Name string
+
+	// Path is the dotted JSON path from the root, e.g. "address.city" or "tags[]".
+	Path string
+
+	// Type is the Go type of the field (pointers are dereferenced).
+	Type reflect.Type
+
+	// Format is the OpenAPI format inferred from the format tag, the validate
+	// tag (email, url, uuid, ...), or well-known types such as time.Time.
+	Format string
+
+	// Tag is the struct tag of the field (empty for array elements and map values).
+	Tag reflect.StructTag
+}
+
+// Generator produces example values for fields that have no example tag.
+//
+// Implementations must be deterministic for a given Field so that generated
+// specifications (and their ETags) are stable across regenerations.
+type Generator interface {
+	// Generate returns an example for f, or false to leave the field out.
+	Generate(f Field) (any, bool)
+}
+
+// GeneratorFunc adapts a function to the [Generator] interface.
+type GeneratorFunc func(f Field) (any, bool)
+
+// Generate calls fn(f).
+func (fn GeneratorFunc) Generate(f Field) (any, bool) {
+	return fn(f)
+}
+
+// FromType builds an example value for t from the example tags of its fields.
+//
+// Structs become objects keyed by JSON name; example tags are converted to the
+// field's JSON type (so `example:"42"` on an int yields 42, not "42"). Fields
+// without an example tag are filled by gen, or omitted when gen is nil.
+//
+// Example:
+//
+//	type User struct {
+//	    ID    int    `json:"id" example:"42"`
+//	    Email string `json:"email" validate:"email"`
+//	}
+//
+//	example.FromType(reflect.TypeFor[User](), nil)
+//	// map[string]any{"id": int64(42)}
+//
+//	example.FromType(reflect.TypeFor[User](), example.NewFaker())
+//	// map[string]any{"id": int64(42), "email": "jane.doe@example.com"}
+func FromType(t reflect.Type, gen Generator) any {
+	if t == nil {
+		return nil
+	}
+	w := &walker{gen: gen}
+	return w.value(reflect.Value{}, t, Field{Type: deref(t)}, 0)
+}
+
+// FromValue builds an example from v, keeping every non-zero field of v and
+// filling zero fields from example tags and then gen (which may be nil).
+//
+// Example:
+//
+//	example.FromValue(User{ID: 7}, example.NewFaker())
+func FromValue(v any, gen Generator) any {
+	if v == nil {
+		return nil
+	}
+	rv := reflect.ValueOf(v)
+	w := &walker{gen: gen}
+	return w.value(rv, rv.Type(), Field{Type: deref(rv.Type())}, 0)
+}
+
+// walker walks types (and optionally values) to assemble example values.
+type walker struct {
+	gen   Generator
+	stack []reflect.Type // struct types being walked, to stop on recursion
+}
+
+// value returns the example for type t. rv is the corresponding value, or the
+// zero Value when walking a type only.
+func (w *walker) value(rv reflect.Value, t reflect.Type, f Field, depth int) any {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+		if rv.IsValid() {
+			if rv.IsNil() {
+				rv = reflect.Value{}
+			} else {
+				rv = rv.Elem()
+			}
+		}
+	}
+	if depth > maxDepth {
+		return nil
+	}
+
+	// Keep non-zero leaves (and types with custom encodings) from the value as-is.
+	if rv.IsValid() && !rv.IsZero() && isLeaf(t) {
+		return rv.Interface()
+	}
+
+	if ex := f.Tag.Get("example"); ex != "" {
+		return schema.ParseExample(ex, t)
+	}
+
+	switch {
+	case isLeaf(t):
+		return w.generate(f)
+	case t.Kind() == reflect.Struct:
+		return w.object(rv, t, f.Path, depth)
+	case t.Kind() == reflect.Slice || t.Kind() == reflect.Array:
+		return w.list(rv, t, f, depth)
+	case t.Kind() == reflect.Map:
+		return w.dict(rv, t, f, depth)
+	}
+	return nil
+}
+
+func (w *walker) object(rv reflect.Value, t reflect.Type, path string, depth int) any {
+	for _, seen := range w.stack {
+		if seen == t {
+			return nil
+		}
+	}
+	w.stack = append(w.stack, t)
+	defer func() { w.stack = w.stack[:len(w.stack)-1] }()
+
+	obj := map[string]any{}
+	w.fields(rv, t, path, depth, obj)
+	return obj
+}
+
+// fields adds the example for each exported field of struct type t to obj,
+// flattening embedded structs like encoding/json.
+func (w *walker) fields(rv reflect.Value, t reflect.Type, path string, depth int, obj map[string]any) {
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		if !sf.IsExported() && !sf.Anonymous {
+			continue
+		}
+		name, ok := jsonName(sf)
+		if !ok {
+			continue
+		}
+
+		var fv reflect.Value
+		if rv.IsValid() {
+			fv = rv.Field(i)
+		}
+
+		if sf.Anonymous && sf.Tag.Get("json") == "" {
+			et := deref(sf.Type)
+			if et.Kind() == reflect.Struct {
+				if fv.IsValid() && fv.Kind() == reflect.Pointer {
+					fv = derefValue(fv)
+				}
+				w.fields(fv, et, path, depth, obj)
+				continue
+			}
+		}
+
+		fieldPath := name
+		if path != "" {
+			fieldPath = path + "." + name
+		}
+		f := Field{Name: name, Path: fieldPath, Type: deref(sf.Type), Format: schema.InferFormat(sf), Tag: sf.Tag}
+		if v := w.value(fv, sf.Type, f, depth+1); v != nil {
+			obj[name] = v
+		}
+	}
+}
+
+func (w *walker) list(rv reflect.Value, t reflect.Type, f Field, depth int) any {
+	if t.Elem().Kind() == reflect.Uint8 {
+		return w.generate(f) // []byte encodes as a base64 string
+	}
+	elem := Field{Path: f.Path + "[]", Type: deref(t.Elem()), Format: f.Format}
+
+	if rv.IsValid() && rv.Len() > 0 {
+		out := make([]any, 0, rv.Len())
+		for i := range rv.Len() {
+			if v := w.value(rv.Index(i), t.Elem(), elem, depth+1); v != nil {
+				out = append(out, v)
+			}
+		}
+		return out
+	}
+	if v := w.value(reflect.Value{}, t.Elem(), elem, depth+1); v != nil {
+		return []any{v}
+	}
+	return nil
+}
+
+func (w *walker) dict(rv reflect.Value, t reflect.Type, f Field, depth int) any {
+	elem := Field{Path: f.Path + "{}", Type: deref(t.Elem())}
+
+	if rv.IsValid() && rv.Len() > 0 {
+		out := make(map[string]any, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			if v := w.value(iter.Value(), t.Elem(), elem, depth+1); v != nil {
+				out[fmt.Sprint(iter.Key().Interface())] = v
+			}
+		}
+		return out
+	}
+	if v := w.value(reflect.Value{}, t.Elem(), elem, depth+1); v != nil {
+		return map[string]any{"key": v}
+	}
+	return nil
+}
+
+// generate asks the generator for a leaf value.
+func (w *walker) generate(f Field) any {
+	if w.gen == nil {
+		return nil
+	}
+	if v, ok := w.gen.Generate(f); ok {
+		return v
+	}
+	return nil
+}
+
+var textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+
+// isLeaf reports whether t encodes as a JSON scalar.
+func isLeaf(t reflect.Type) bool {
+	if t == reflect.TypeFor[time.Time]() || t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType) {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.Struct, reflect.Slice, reflect.Array, reflect.Map, reflect.Interface, reflect.Func, reflect.Chan:
+		return false
+	}
+	return true
+}
+
+// jsonName returns the JSON name of a field and false when it is skipped.
+func jsonName(sf reflect.StructField) (string, bool) {
+	tag := sf.Tag.Get("json")
+	if tag == "-" {
+		return "", false
+	}
+	if name, _, _ := strings.Cut(tag, ","); name != "" {
+		return name, true
+	}
+	return sf.Name, true
+}
+
+func deref(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+func derefValue(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
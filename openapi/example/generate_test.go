@@ -0,0 +1,100 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package example
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type genAddress struct {
+	City string `json:"city" example:"Berlin"`
+	Zip  string `json:"zip"`
+}
+
+type genBase struct {
+	ID int `json:"id" example:"42"`
+}
+
+type genUser struct {
+	genBase
+	Name     string            `json:"name" example:"Ada"`
+	Email    string            `json:"email" validate:"required,email"`
+	Active   bool              `json:"active" example:"true"`
+	Tags     []string          `json:"tags" example:"admin,ops"`
+	Address  *genAddress       `json:"address"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	Created  time.Time         `json:"created"`
+	Secret   string            `json:"-"`
+	internal string
+	Friends  []genUser `json:"friends"`
+}
+
+func TestFromType_Tags(t *testing.T) {
+	t.Parallel()
+
+	got := FromType(reflect.TypeFor[genUser](), nil)
+
+	assert.Equal(t, map[string]any{
+		"id":      int64(42),
+		"name":    "Ada",
+		"active":  true,
+		"tags":    []any{"admin", "ops"},
+		"address": map[string]any{"city": "Berlin"},
+	}, got, "untagged fields and recursive types are omitted without a generator")
+}
+
+func TestFromType_Generator(t *testing.T) {
+	t.Parallel()
+
+	var fields []Field
+	gen := GeneratorFunc(func(f Field) (any, bool) {
+		fields = append(fields, f)
+		return "gen:" + f.Path, true
+	})
+
+	got, ok := FromType(reflect.TypeFor[genUser](), gen).(map[string]any)
+	require.True(t, ok)
+
+	assert.Equal(t, int64(42), got["id"], "example tags win over the generator")
+	assert.Equal(t, "gen:email", got["email"])
+	assert.Equal(t, "gen:address.zip", got["address"].(map[string]any)["zip"])
+	assert.Equal(t, map[string]any{"key": "gen:labels{}"}, got["labels"])
+	assert.NotContains(t, got, "Secret")
+	assert.NotContains(t, got, "internal")
+
+	formats := map[string]string{}
+	for _, f := range fields {
+		formats[f.Path] = f.Format
+	}
+	assert.Equal(t, "email", formats["email"])
+	assert.Equal(t, "date-time", formats["created"])
+}
+
+func TestFromValue(t *testing.T) {
+	t.Parallel()
+
+	got, ok := FromValue(genUser{Name: "Grace", Address: &genAddress{Zip: "10115"}}, nil).(map[string]any)
+	require.True(t, ok)
+
+	assert.Equal(t, "Grace", got["name"], "non-zero values are kept")
+	assert.Equal(t, int64(42), got["id"], "zero values fall back to example tags")
+	assert.Equal(t, map[string]any{"city": "Berlin", "zip": "10115"}, got["address"])
+	assert.Nil(t, FromValue(nil, nil))
+}
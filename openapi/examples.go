@@ -0,0 +1,166 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"reflect"
+	"strings"
+
+	"rivaas.dev/openapi/example"
+)
+
+// WithExampleStruct uses v as the example for the operation's request body and
+// every response whose Go type matches v's type (pointers are ignored), unless
+// an explicit example was given for them.
+//
+// Unlike passing a populated value to [WithRequest] or [WithResponse], zero
+// fields of v are filled from the fields' example tags (converted to the
+// field's JSON type) and, when configured, from the API's example generator.
+//
+// Example:
+//
+//	openapi.WithGET("/users/:id",
+//	    openapi.WithResponse(200, User{}),
+//	    openapi.WithExampleStruct(User{ID: 42, Role: "admin"}),
+//	)
+func WithExampleStruct(v any) OperationOption {
+	return func(d *operationDoc) {
+		if v != nil {
+			d.ExampleStructs = append(d.ExampleStructs, v)
+		}
+	}
+}
+
+// WithExampleGenerator populates request and response body examples that were
+// not given explicitly by generating them from the Go types: example tags
+// first, then gen for fields without one.
+//
+// Use [example.NewFaker] for realistic values driven by formats, validation
+// rules, and field names, so Swagger UI's "Try it out" starts from a usable
+// payload instead of empty objects. Generators must be deterministic so the
+// spec (and its ETag) is stable.
+//
+// Example:
+//
+//	openapi.MustNew(
+//	    openapi.WithTitle("My API", "1.0.0"),
+//	    openapi.WithExampleGenerator(example.NewFaker(example.WithSeed(7))),
+//	)
+func WithExampleGenerator(gen example.Generator) Option {
+	return func(c *config) {
+		c.exampleGenerator = gen
+	}
+}
+
+// requestExample returns the single request body example for an operation.
+func requestExample(d operationDoc, gen example.Generator) any {
+	if d.RequestExample != nil || len(d.RequestNamedExamples) > 0 || d.RequestType == nil {
+		return d.RequestExample
+	}
+	var ex any
+	if v := matchingExampleStruct(d.ExampleStructs, d.RequestType); v != nil {
+		ex = example.FromValue(v, gen)
+	} else if gen != nil {
+		ex = example.FromType(d.RequestType, gen)
+	}
+	return projectBodyExample(ex, d.RequestType)
+}
+
+// responseExamples returns the single response examples per status.
+func responseExamples(d operationDoc, gen example.Generator) map[int]any {
+	if len(d.ExampleStructs) == 0 && gen == nil {
+		return d.ResponseExample
+	}
+	out := make(map[int]any, len(d.ResponseTypes))
+	for status, rt := range d.ResponseTypes {
+		if ex, ok := d.ResponseExample[status]; ok {
+			out[status] = ex
+			continue
+		}
+		if rt == nil || len(d.ResponseNamedExamples[status]) > 0 {
+			continue
+		}
+		var ex any
+		if v := matchingExampleStruct(d.ExampleStructs, rt); v != nil {
+			ex = example.FromValue(v, gen)
+		} else if gen != nil {
+			ex = example.FromType(rt, gen)
+		}
+		if ex != nil {
+			out[status] = ex
+		}
+	}
+	return out
+}
+
+// matchingExampleStruct returns the last example struct whose type matches t.
+func matchingExampleStruct(values []any, t reflect.Type) any {
+	for i := len(values) - 1; i >= 0; i-- {
+		if derefType(reflect.TypeOf(values[i])) == derefType(t) {
+			return values[i]
+		}
+	}
+	return nil
+}
+
+// projectBodyExample keeps only JSON-tagged fields of a request example, matching
+// the request body schema (query, path, and header fields are parameters).
+func projectBodyExample(ex any, t reflect.Type) any {
+	obj, ok := ex.(map[string]any)
+	if !ok {
+		return ex
+	}
+	allowed := make(map[string]struct{})
+	collectJSONFields(derefType(t), allowed)
+	for name := range obj {
+		if _, ok := allowed[name]; !ok {
+			delete(obj, name)
+		}
+	}
+	if len(obj) == 0 {
+		return nil
+	}
+	return obj
+}
+
+// collectJSONFields adds the JSON names of explicitly json-tagged fields of t.
+func collectJSONFields(t reflect.Type, names map[string]struct{}) {
+	if t.Kind() != reflect.Struct {
+		return
+	}
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if f.Anonymous {
+			collectJSONFields(derefType(f.Type), names)
+			continue
+		}
+		tag := f.Tag.Get("json")
+		if tag == "" || tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = f.Name
+		}
+		names[name] = struct{}{}
+	}
+}
+
+func derefType(t reflect.Type) reflect.Type {
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
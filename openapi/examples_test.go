@@ -0,0 +1,111 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package openapi
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"rivaas.dev/openapi/example"
+)
+
+type exampleCreateUser struct {
+	Name  string `json:"name" example:"Ada"`
+	Email string `json:"email" validate:"required,email"`
+	Trace string `header:"X-Trace-ID" example:"abc"`
+}
+
+type exampleUser struct {
+	ID    int    `json:"id" example:"42"`
+	Name  string `json:"name"`
+	Email string `json:"email" validate:"email"`
+}
+
+func specJSON(t *testing.T, api *API) map[string]any {
+	t.Helper()
+	result, err := api.Spec(t.Context())
+	require.NoError(t, err)
+	var spec map[string]any
+	require.NoError(t, json.Unmarshal(result.JSON, &spec))
+	return spec
+}
+
+func mediaExample(t *testing.T, spec map[string]any, path, method string, response string) any {
+	t.Helper()
+	op := spec["paths"].(map[string]any)[path].(map[string]any)[method].(map[string]any)
+	var content map[string]any
+	if response == "" {
+		content = op["requestBody"].(map[string]any)["content"].(map[string]any)
+	} else {
+		content = op["responses"].(map[string]any)[response].(map[string]any)["content"].(map[string]any)
+	}
+	return content["application/json"].(map[string]any)["example"]
+}
+
+func TestWithExampleStruct(t *testing.T) {
+	t.Parallel()
+
+	api := MustNew(
+		WithTitle("API", "1.0.0"),
+		WithOperations(
+			mustOperation(WithGET("/users/:id",
+				WithResponse(200, exampleUser{}),
+				WithExampleStruct(&exampleUser{Name: "Grace"}),
+			)),
+		),
+	)
+
+	spec := specJSON(t, api)
+	assert.Equal(t, map[string]any{"id": 42.0, "name": "Grace"}, mediaExample(t, spec, "/users/{id}", "get", "200"))
+}
+
+func TestWithExampleGenerator(t *testing.T) {
+	t.Parallel()
+
+	api := MustNew(
+		WithTitle("API", "1.0.0"),
+		WithExampleGenerator(example.NewFaker()),
+		WithOperations(
+			mustOperation(WithPOST("/users",
+				WithRequest(exampleCreateUser{}),
+				WithResponse(201, exampleUser{}),
+				WithResponse(409, exampleUser{}, example.New("conflict", map[string]any{"id": 1})),
+			)),
+		),
+	)
+
+	spec := specJSON(t, api)
+
+	req, ok := mediaExample(t, spec, "/users", "post", "").(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, "Ada", req["name"])
+	assert.Contains(t, req["email"], "@example.com")
+	assert.NotContains(t, req, "Trace", "header fields are not part of the body example")
+
+	resp, ok := mediaExample(t, spec, "/users", "post", "201").(map[string]any)
+	require.True(t, ok)
+	assert.InDelta(t, 42, resp["id"], 0)
+	assert.NotEmpty(t, resp["name"])
+
+	assert.Nil(t, mediaExample(t, spec, "/users", "post", "409"), "named examples are left untouched")
+
+	// Generated examples are stable across regenerations.
+	assert.Equal(t, spec, specJSON(t, api))
+}
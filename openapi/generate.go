@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"maps"
 
+	"rivaas.dev/openapi/example"
 	"rivaas.dev/openapi/internal/build"
 	"rivaas.dev/openapi/internal/export"
 	"rivaas.dev/openapi/internal/schema"
@@ -29,8 +30,10 @@ import (
 var sharedValidator = validate.MustNew()
 
 // Spec produces an OpenAPI specification from the API's current configuration and
-// operations (from [WithOperations] and/or [API.AddOperation]). Pure function of
-// current API state; no side effects. Caching is the caller's responsibility.
+// operations (from [WithOperations] and/or [API.AddOperation]). The result depends
+// only on the current API state; unless disabled with [WithGenerationCache],
+// reflected schemas and unchanged operations are reused from earlier calls.
+// Caching the serialized result is the caller's responsibility.
 //
 // Example:
 //
@@ -50,10 +53,7 @@ func (a *API) Spec(ctx context.Context) (*Result, error) {
 	a.operationsMu.RUnlock()
 
 	builder := createBuilder(a)
-	enriched := make([]build.EnrichedRoute, 0, len(ops))
-	for _, op := range ops {
-		enriched = append(enriched, convertOperation(op))
-	}
+	enriched := a.enrichedRoutes(ops)
 
 	// Build spec
 	spec, err := builder.Build(enriched)
@@ -80,6 +80,7 @@ func (a *API) Spec(ctx context.Context) (*Result, error) {
 	exportCfg := export.Config{
 		Version:         exportVersion,
 		StrictDownlevel: a.strictDownlevel,
+		Patches:         a.patches,
 	}
 
 	// Enable validation if configured (use shared validator for performance)
@@ -119,6 +120,9 @@ func (a *API) AddOperation(ops ...Operation) error {
 // createBuilder creates a Builder from API.
 func createBuilder(a *API) *build.Builder {
 	b := build.NewBuilder(a.info)
+	if a.cache != nil {
+		b.SetCache(a.cache.build)
+	}
 
 	if a.externalDocs != nil {
 		b.SetExternalDocs(a.externalDocs)
@@ -157,7 +161,8 @@ func createBuilder(a *API) *build.Builder {
 }
 
 // convertOperation converts an Operation to build.EnrichedRoute.
-func convertOperation(op Operation) build.EnrichedRoute {
+// gen, when non-nil, fills request and response examples that were not given explicitly.
+func convertOperation(op Operation, gen example.Generator) build.EnrichedRoute {
 	var buildDoc *build.RouteDoc
 
 	// Check if there's meaningful documentation
@@ -215,11 +220,12 @@ func convertOperation(op Operation) build.EnrichedRoute {
 			Produces:              produces,
 			RequestType:           op.doc.RequestType,
 			RequestMetadata:       requestMetadata,
-			RequestExample:        op.doc.RequestExample,
+			RequestExample:        requestExample(op.doc, gen),
 			RequestNamedExamples:  requestNamedExamples,
 			ResponseTypes:         op.doc.ResponseTypes,
-			ResponseExample:       op.doc.ResponseExample,
+			ResponseExample:       responseExamples(op.doc, gen),
 			ResponseNamedExamples: responseNamedExamples,
+			ResponseHeaders:       op.doc.ResponseHeaders,
 			Security:              convertSecurityReqsToBuild(op.doc.Security),
 			Extensions:            op.doc.Extensions,
 		}
@@ -45,6 +45,7 @@ type Builder struct {
 	securitySchemes map[string]*model.SecurityScheme
 	globalSecurity  []model.SecurityRequirement
 	externalDocs    *model.ExternalDocs
+	cache           *Cache
 }
 
 // NewBuilder creates a new builder with the given API info.
@@ -142,6 +143,14 @@ func (b *Builder) SetExternalDocs(docs *model.ExternalDocs) *Builder {
 }
 
 // Build builds the complete specification from enriched routes.
+// SetCache makes Build reuse operations and schemas from c for routes with an
+// unchanged [EnrichedRoute.CacheKey]. Entries of routes missing from a build
+// are evicted.
+func (b *Builder) SetCache(c *Cache) *Builder {
+	b.cache = c
+	return b
+}
+
 func (b *Builder) Build(routes []EnrichedRoute) (*model.Spec, error) {
 	// Validate servers: variables require a server URL
 	for i, server := range b.servers {
@@ -164,6 +173,12 @@ func (b *Builder) Build(routes []EnrichedRoute) (*model.Spec, error) {
 	}
 
 	sg := schema.NewSchemaGenerator()
+	components := map[string]*model.Schema{}
+	var keys map[string]struct{}
+	if b.cache != nil {
+		sg = schema.NewCachedSchemaGenerator(b.cache.schemas)
+		keys = make(map[string]struct{}, len(routes))
+	}
 
 	// Group routes by path
 	byPath := map[string][]EnrichedRoute{}
@@ -178,7 +193,14 @@ func (b *Builder) Build(routes []EnrichedRoute) (*model.Spec, error) {
 		item := &model.PathItem{}
 
 		for _, r := range group {
-			op, err := b.buildOperation(r, sg, seenOps)
+			var op *model.Operation
+			var err error
+			if b.cache != nil && r.CacheKey != "" {
+				keys[r.CacheKey] = struct{}{}
+				op, err = b.cachedOperation(r, seenOps, components)
+			} else {
+				op, err = b.buildOperation(r, sg, seenOps)
+			}
 			if err != nil {
 				return nil, fmt.Errorf("failed to build operation for %s %s: %w", r.RouteInfo.Method, r.RouteInfo.Path, err)
 			}
@@ -206,12 +228,52 @@ func (b *Builder) Build(routes []EnrichedRoute) (*model.Spec, error) {
 
 	// Add component schemas
 	spec.Components.Schemas = sg.GetComponentSchemas()
+	for name, s := range components {
+		if _, ok := spec.Components.Schemas[name]; !ok {
+			spec.Components.Schemas[name] = s
+		}
+	}
+
+	if b.cache != nil {
+		b.cache.retain(keys)
+	}
 
 	sortSpec(spec)
 
 	return spec, nil
 }
 
+// cachedOperation returns the operation for a route with a cache key, building it
+// on a miss, and adds the component schemas it references to components.
+//
+// Cached operations are shared between builds and must not be mutated.
+func (b *Builder) cachedOperation(er EnrichedRoute, seen map[string]int, components map[string]*model.Schema) (*model.Operation, error) {
+	co, ok := b.cache.get(er.CacheKey)
+	if !ok {
+		// Each operation gets its own generator so its component set is self-contained.
+		sg := schema.NewCachedSchemaGenerator(b.cache.schemas)
+		op, err := b.buildOperation(er, sg, map[string]int{})
+		if err != nil {
+			return nil, err
+		}
+		co = &cachedOperation{op: op, components: sg.GetComponentSchemas()}
+		b.cache.put(er.CacheKey, co)
+	}
+
+	if seen[co.op.OperationID] > 0 {
+		return nil, fmt.Errorf("duplicate operation ID: %s (used by %s %s and another route)", co.op.OperationID, er.RouteInfo.Method, er.RouteInfo.Path)
+	}
+	seen[co.op.OperationID] = 1
+
+	for name, s := range co.components {
+		if _, ok := components[name]; !ok {
+			components[name] = s
+		}
+	}
+
+	return co.op, nil
+}
+
 // buildOperation builds an Operation from an enriched route.
 func (b *Builder) buildOperation(er EnrichedRoute, sg *schema.SchemaGenerator, seen map[string]int) (*model.Operation, error) {
 	op := &model.Operation{
@@ -354,6 +416,17 @@ func (b *Builder) buildOperation(er EnrichedRoute, sg *schema.SchemaGenerator, s
 			}
 		}
 
+		if headers := doc.ResponseHeaders[status]; len(headers) > 0 {
+			rs.Headers = make(map[string]*model.Header, len(headers))
+			for _, h := range headers {
+				rs.Headers[h.Name] = &model.Header{
+					Description: h.Description,
+					Schema:      &model.Schema{Kind: model.KindString},
+					Example:     h.Example,
+				}
+			}
+		}
+
 		op.Responses[strconv.Itoa(status)] = rs
 	}
 
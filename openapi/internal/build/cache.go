@@ -0,0 +1,78 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"sync"
+
+	"rivaas.dev/openapi/internal/model"
+	"rivaas.dev/openapi/internal/schema"
+)
+
+// Cache keeps built operations and reflected schemas across [Builder.Build]
+// calls, so rebuilding a spec only recomputes operations whose
+// [EnrichedRoute.CacheKey] changed. Safe for concurrent use.
+type Cache struct {
+	schemas *schema.Cache
+
+	mu  sync.Mutex
+	ops map[string]*cachedOperation
+}
+
+// cachedOperation is a built operation with the component schemas it references.
+type cachedOperation struct {
+	op         *model.Operation
+	components map[string]*model.Schema
+}
+
+// NewCache creates an empty build cache.
+func NewCache() *Cache {
+	return &Cache{
+		schemas: schema.NewCache(),
+		ops:     make(map[string]*cachedOperation),
+	}
+}
+
+// Len returns the number of cached operations.
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.ops)
+}
+
+func (c *Cache) get(key string) (*cachedOperation, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	co, ok := c.ops[key]
+	return co, ok
+}
+
+func (c *Cache) put(key string, co *cachedOperation) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ops[key] = co
+}
+
+// retain drops cached operations whose keys are not in keys, so routes that
+// were removed or changed do not accumulate.
+func (c *Cache) retain(keys map[string]struct{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key := range c.ops {
+		if _, ok := keys[key]; !ok {
+			delete(c.ops, key)
+		}
+	}
+}
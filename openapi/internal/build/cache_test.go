@@ -0,0 +1,73 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package build
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func cachedTestRoutes(n int) []EnrichedRoute {
+	routes := make([]EnrichedRoute, 0, n)
+	for i := range n {
+		routes = append(routes, EnrichedRoute{
+			RouteInfo: RouteInfo{Method: http.MethodGet, Path: fmt.Sprintf("/resource%d/:id", i)},
+			Doc: &RouteDoc{
+				Summary:       fmt.Sprintf("Get resource %d", i),
+				ResponseTypes: map[int]reflect.Type{http.StatusOK: reflect.TypeFor[User]()},
+			},
+			CacheKey: fmt.Sprintf("route-%d", i),
+		})
+	}
+	return routes
+}
+
+func TestBuilder_Cache(t *testing.T) {
+	t.Parallel()
+
+	routes := cachedTestRoutes(3)
+	want, err := newTestBuilder(t).Build(routes)
+	require.NoError(t, err)
+
+	cache := NewCache()
+	first, err := newTestBuilder(t).SetCache(cache).Build(routes)
+	require.NoError(t, err)
+	assert.Equal(t, want, first)
+	assert.Equal(t, 3, cache.Len())
+
+	second, err := newTestBuilder(t).SetCache(cache).Build(routes[:2])
+	require.NoError(t, err)
+	assert.Same(t, first.Paths["/resource0/{id}"].Get, second.Paths["/resource0/{id}"].Get, "unchanged operations are reused")
+	assert.Equal(t, want.Components.Schemas, second.Components.Schemas)
+	assert.Equal(t, 2, cache.Len(), "routes missing from the build are evicted")
+}
+
+func BenchmarkBuilder_BuildCached(b *testing.B) {
+	builder := newTestBuilder(b).SetCache(NewCache())
+	routes := cachedTestRoutes(1000)
+
+	b.ResetTimer()
+	for b.Loop() {
+		//nolint:errcheck // Benchmark focuses on performance, not error handling
+		_, _ = builder.Build(routes)
+	}
+}
@@ -59,6 +59,13 @@ type ExampleData struct {
 	ExternalValue string
 }
 
+// HeaderData describes a response header.
+type HeaderData struct {
+	Name        string
+	Description string
+	Example     any
+}
+
 // RouteDoc holds all OpenAPI metadata for a route.
 // This is a copy of the openapi.RouteDoc structure to avoid import cycles.
 type RouteDoc struct {
@@ -76,6 +83,7 @@ type RouteDoc struct {
 	ResponseTypes         map[int]reflect.Type
 	ResponseExample       map[int]any           // Single unnamed example per status
 	ResponseNamedExamples map[int][]ExampleData // Named examples per status
+	ResponseHeaders       map[int][]HeaderData  // Documented response headers per status
 	Security              []SecurityReq
 	Extensions            map[string]any // Operation-level extensions (x-*)
 }
@@ -93,4 +101,8 @@ type SecurityReq struct {
 type EnrichedRoute struct {
 	RouteInfo RouteInfo
 	Doc       *RouteDoc
+
+	// CacheKey identifies the route's content for [Cache]. Routes with equal keys
+	// must produce identical operations; empty disables caching for the route.
+	CacheKey string
 }
@@ -24,6 +24,7 @@ import (
 
 	"rivaas.dev/openapi/diag"
 	"rivaas.dev/openapi/internal/model"
+	"rivaas.dev/openapi/internal/overlay"
 	"rivaas.dev/openapi/validate"
 )
 
@@ -55,6 +56,10 @@ type Config struct {
 	// Validator is an optional validator for the generated specification.
 	// If nil, no validation is performed.
 	Validator Validator
+
+	// Patches are fragments and overlay documents applied, in order, to the
+	// projected spec before validation.
+	Patches []*overlay.Patch
 }
 
 // Result contains the output of spec projection.
@@ -123,6 +128,17 @@ func Project(ctx context.Context, spec *model.Spec, cfg Config) (Result, error)
 		return Result{Warnings: warns}, fmt.Errorf("failed to marshal spec to JSON: %w", err)
 	}
 
+	// Apply fragments and overlays; the patched document replaces both outputs
+	var yamlBytes []byte
+	if len(cfg.Patches) > 0 {
+		var patchWarns diag.Warnings
+		jsonBytes, yamlBytes, patchWarns, err = overlay.Apply(jsonBytes, cfg.Patches)
+		warns = append(warns, patchWarns...)
+		if err != nil {
+			return Result{Warnings: warns}, fmt.Errorf("failed to apply overlays: %w", err)
+		}
+	}
+
 	// Validate if validator provided
 	if cfg.Validator != nil {
 		validatorVersion := validate.V30
@@ -135,9 +151,11 @@ func Project(ctx context.Context, spec *model.Spec, cfg Config) (Result, error)
 	}
 
 	// Marshal to YAML
-	yamlBytes, err := yaml.Marshal(out)
-	if err != nil {
-		return Result{Warnings: warns}, fmt.Errorf("failed to marshal spec to YAML: %w", err)
+	if yamlBytes == nil {
+		yamlBytes, err = yaml.Marshal(out)
+		if err != nil {
+			return Result{Warnings: warns}, fmt.Errorf("failed to marshal spec to YAML: %w", err)
+		}
 	}
 
 	return Result{
@@ -0,0 +1,491 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package overlay
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// selectorKind identifies the kind of a JSONPath selector.
+type selectorKind int
+
+const (
+	selectName selectorKind = iota
+	selectWildcard
+	selectIndex
+	selectFilter
+)
+
+// selector is a single JSONPath selector within a segment.
+type selector struct {
+	kind   selectorKind
+	name   string
+	index  int
+	filter *filter
+}
+
+// segment is a JSONPath segment: one or more selectors, optionally applied to all descendants.
+type segment struct {
+	descendant bool
+	selectors  []selector
+}
+
+// filter is a comparison filter such as ?(@.x-internal == true).
+// When op is empty the filter only tests for the presence of the field.
+type filter struct {
+	field []string
+	op    string
+	value any
+}
+
+// Path is a compiled JSONPath expression.
+//
+// The supported subset covers the selectors used in practice by OpenAPI
+// Overlay documents:
+//
+//	$.paths['/users'].get          child names (dot or bracket notation)
+//	$.paths.*.*                    wildcards
+//	$.tags[0]                      array indices (negative counts from the end)
+//	$..description                 descendant segments
+//	$.paths.*[?(@.x-internal)]     presence filters
+//	$.tags[?(@.name == 'users')]   equality filters (==, !=)
+type Path struct {
+	expr     string
+	segments []segment
+}
+
+// String returns the original expression.
+func (p *Path) String() string {
+	return p.expr
+}
+
+// ParsePath compiles a JSONPath expression.
+func ParsePath(expr string) (*Path, error) {
+	p := &pathParser{src: expr}
+	segs, err := p.parse()
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSONPath %q: %w", expr, err)
+	}
+	return &Path{expr: expr, segments: segs}, nil
+}
+
+// pathParser is a hand-written recursive-descent parser for the JSONPath subset.
+type pathParser struct {
+	src string
+	pos int
+}
+
+func (p *pathParser) parse() ([]segment, error) {
+	if !strings.HasPrefix(p.src, "$") {
+		return nil, fmt.Errorf("must start with '$'")
+	}
+	p.pos = 1
+
+	var segs []segment
+	for p.pos < len(p.src) {
+		switch {
+		case strings.HasPrefix(p.src[p.pos:], ".."):
+			p.pos += 2
+			seg, err := p.parseAfterDot()
+			if err != nil {
+				return nil, err
+			}
+			seg.descendant = true
+			segs = append(segs, seg)
+		case p.src[p.pos] == '.':
+			p.pos++
+			seg, err := p.parseAfterDot()
+			if err != nil {
+				return nil, err
+			}
+			segs = append(segs, seg)
+		case p.src[p.pos] == '[':
+			seg, err := p.parseBracket()
+			if err != nil {
+				return nil, err
+			}
+			segs = append(segs, seg)
+		default:
+			return nil, fmt.Errorf("unexpected %q at offset %d", p.src[p.pos], p.pos)
+		}
+	}
+	return segs, nil
+}
+
+// parseAfterDot parses a member name, wildcard, or bracket following '.' or '..'.
+func (p *pathParser) parseAfterDot() (segment, error) {
+	if p.pos >= len(p.src) {
+		return segment{}, fmt.Errorf("unexpected end of expression")
+	}
+	switch p.src[p.pos] {
+	case '*':
+		p.pos++
+		return segment{selectors: []selector{{kind: selectWildcard}}}, nil
+	case '[':
+		return p.parseBracket()
+	}
+	start := p.pos
+	for p.pos < len(p.src) && p.src[p.pos] != '.' && p.src[p.pos] != '[' {
+		p.pos++
+	}
+	if start == p.pos {
+		return segment{}, fmt.Errorf("empty member name at offset %d", start)
+	}
+	return segment{selectors: []selector{{kind: selectName, name: p.src[start:p.pos]}}}, nil
+}
+
+// parseBracket parses a bracketed, comma-separated selector list.
+func (p *pathParser) parseBracket() (segment, error) {
+	p.pos++ // '['
+	var seg segment
+	for {
+		p.skipSpace()
+		sel, err := p.parseSelector()
+		if err != nil {
+			return segment{}, err
+		}
+		seg.selectors = append(seg.selectors, sel)
+		p.skipSpace()
+		if p.pos >= len(p.src) {
+			return segment{}, fmt.Errorf("unterminated '['")
+		}
+		if p.src[p.pos] == ']' {
+			p.pos++
+			return seg, nil
+		}
+		if p.src[p.pos] != ',' {
+			return segment{}, fmt.Errorf("expected ',' or ']' at offset %d", p.pos)
+		}
+		p.pos++
+	}
+}
+
+func (p *pathParser) parseSelector() (selector, error) {
+	if p.pos >= len(p.src) {
+		return selector{}, fmt.Errorf("unexpected end of expression")
+	}
+	switch c := p.src[p.pos]; {
+	case c == '*':
+		p.pos++
+		return selector{kind: selectWildcard}, nil
+	case c == '\'' || c == '"':
+		s, err := p.parseString()
+		if err != nil {
+			return selector{}, err
+		}
+		return selector{kind: selectName, name: s}, nil
+	case c == '?':
+		p.pos++
+		f, err := p.parseFilter()
+		if err != nil {
+			return selector{}, err
+		}
+		return selector{kind: selectFilter, filter: f}, nil
+	case c == '-' || (c >= '0' && c <= '9'):
+		start := p.pos
+		p.pos++
+		for p.pos < len(p.src) && p.src[p.pos] >= '0' && p.src[p.pos] <= '9' {
+			p.pos++
+		}
+		n, err := strconv.Atoi(p.src[start:p.pos])
+		if err != nil {
+			return selector{}, fmt.Errorf("invalid index at offset %d", start)
+		}
+		return selector{kind: selectIndex, index: n}, nil
+	default:
+		return selector{}, fmt.Errorf("unexpected %q at offset %d", c, p.pos)
+	}
+}
+
+// parseFilter parses "(@.a.b)" or "(@.a.b == literal)"; parentheses are optional.
+func (p *pathParser) parseFilter() (*filter, error) {
+	p.skipSpace()
+	paren := p.pos < len(p.src) && p.src[p.pos] == '('
+	if paren {
+		p.pos++
+		p.skipSpace()
+	}
+	if p.pos >= len(p.src) || p.src[p.pos] != '@' {
+		return nil, fmt.Errorf("filter must start with '@' at offset %d", p.pos)
+	}
+	p.pos++
+
+	f := &filter{}
+	for p.pos < len(p.src) && p.src[p.pos] == '.' {
+		p.pos++
+		start := p.pos
+		for p.pos < len(p.src) && !strings.ContainsRune(".=!)] ,", rune(p.src[p.pos])) {
+			p.pos++
+		}
+		if start == p.pos {
+			return nil, fmt.Errorf("empty filter field at offset %d", start)
+		}
+		f.field = append(f.field, p.src[start:p.pos])
+	}
+	if len(f.field) == 0 {
+		return nil, fmt.Errorf("filter must reference a field of '@'")
+	}
+
+	p.skipSpace()
+	if strings.HasPrefix(p.src[p.pos:], "==") || strings.HasPrefix(p.src[p.pos:], "!=") {
+		f.op = p.src[p.pos : p.pos+2]
+		p.pos += 2
+		p.skipSpace()
+		v, err := p.parseLiteral()
+		if err != nil {
+			return nil, err
+		}
+		f.value = v
+		p.skipSpace()
+	}
+
+	if paren {
+		if p.pos >= len(p.src) || p.src[p.pos] != ')' {
+			return nil, fmt.Errorf("expected ')' at offset %d", p.pos)
+		}
+		p.pos++
+	}
+	return f, nil
+}
+
+// parseLiteral parses a string, number, boolean, or null literal.
+func (p *pathParser) parseLiteral() (any, error) {
+	if p.pos >= len(p.src) {
+		return nil, fmt.Errorf("expected literal")
+	}
+	if c := p.src[p.pos]; c == '\'' || c == '"' {
+		return p.parseString()
+	}
+	start := p.pos
+	for p.pos < len(p.src) && !strings.ContainsRune(")] ,", rune(p.src[p.pos])) {
+		p.pos++
+	}
+	word := p.src[start:p.pos]
+	switch word {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "null":
+		return nil, nil
+	}
+	n, err := strconv.ParseFloat(word, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid literal %q", word)
+	}
+	return n, nil
+}
+
+// parseString parses a single- or double-quoted string with backslash escapes.
+func (p *pathParser) parseString() (string, error) {
+	quote := p.src[p.pos]
+	p.pos++
+	var sb strings.Builder
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		switch {
+		case c == '\\' && p.pos+1 < len(p.src):
+			sb.WriteByte(p.src[p.pos+1])
+			p.pos += 2
+		case c == quote:
+			p.pos++
+			return sb.String(), nil
+		default:
+			sb.WriteByte(c)
+			p.pos++
+		}
+	}
+	return "", fmt.Errorf("unterminated string")
+}
+
+func (p *pathParser) skipSpace() {
+	for p.pos < len(p.src) && p.src[p.pos] == ' ' {
+		p.pos++
+	}
+}
+
+// match is a node selected by a Path together with its location.
+type match struct {
+	node   *yaml.Node
+	parent *yaml.Node // nil for the root
+	index  int        // index of the node (sequence) or its key (mapping) in parent.Content
+	ptr    string     // JSON Pointer to node
+}
+
+// selectNodes returns the nodes matched by the path in document order.
+func (p *Path) selectNodes(root *yaml.Node) []match {
+	current := []match{{node: root}}
+	for _, seg := range p.segments {
+		var next []match
+		for _, m := range current {
+			if seg.descendant {
+				walkDescendants(m, func(d match) {
+					next = append(next, applySelectors(d, seg.selectors)...)
+				})
+				continue
+			}
+			next = append(next, applySelectors(m, seg.selectors)...)
+		}
+		current = dedupe(next)
+	}
+	return current
+}
+
+// walkDescendants calls fn for m and every node beneath it.
+func walkDescendants(m match, fn func(match)) {
+	fn(m)
+	for _, c := range children(m) {
+		walkDescendants(c, fn)
+	}
+}
+
+// children returns the direct children of a mapping or sequence node.
+func children(m match) []match {
+	switch m.node.Kind {
+	case yaml.MappingNode:
+		out := make([]match, 0, len(m.node.Content)/2)
+		for i := 0; i+1 < len(m.node.Content); i += 2 {
+			out = append(out, match{
+				node: m.node.Content[i+1], parent: m.node, index: i,
+				ptr: m.ptr + "/" + escapePointer(m.node.Content[i].Value),
+			})
+		}
+		return out
+	case yaml.SequenceNode:
+		out := make([]match, 0, len(m.node.Content))
+		for i, c := range m.node.Content {
+			out = append(out, match{node: c, parent: m.node, index: i, ptr: m.ptr + "/" + strconv.Itoa(i)})
+		}
+		return out
+	}
+	return nil
+}
+
+func applySelectors(m match, sels []selector) []match {
+	var out []match
+	for _, sel := range sels {
+		switch sel.kind {
+		case selectWildcard:
+			out = append(out, children(m)...)
+		case selectName:
+			if m.node.Kind != yaml.MappingNode {
+				continue
+			}
+			for i := 0; i+1 < len(m.node.Content); i += 2 {
+				if m.node.Content[i].Value == sel.name {
+					out = append(out, match{
+						node: m.node.Content[i+1], parent: m.node, index: i,
+						ptr: m.ptr + "/" + escapePointer(sel.name),
+					})
+					break
+				}
+			}
+		case selectIndex:
+			if m.node.Kind != yaml.SequenceNode {
+				continue
+			}
+			i := sel.index
+			if i < 0 {
+				i += len(m.node.Content)
+			}
+			if i >= 0 && i < len(m.node.Content) {
+				out = append(out, match{node: m.node.Content[i], parent: m.node, index: i, ptr: m.ptr + "/" + strconv.Itoa(i)})
+			}
+		case selectFilter:
+			for _, c := range children(m) {
+				if sel.filter.matches(c.node) {
+					out = append(out, c)
+				}
+			}
+		}
+	}
+	return out
+}
+
+// matches reports whether node satisfies the filter.
+func (f *filter) matches(node *yaml.Node) bool {
+	cur := node
+	for _, name := range f.field {
+		if cur.Kind != yaml.MappingNode {
+			return false
+		}
+		var found *yaml.Node
+		for i := 0; i+1 < len(cur.Content); i += 2 {
+			if cur.Content[i].Value == name {
+				found = cur.Content[i+1]
+				break
+			}
+		}
+		if found == nil {
+			return false
+		}
+		cur = found
+	}
+	if f.op == "" {
+		return true
+	}
+
+	var actual any
+	if cur.Kind == yaml.ScalarNode {
+		if err := cur.Decode(&actual); err != nil {
+			return false
+		}
+		if n, ok := toFloat(actual); ok {
+			actual = n
+		}
+	}
+	equal := actual == f.value
+	if f.op == "!=" {
+		return !equal
+	}
+	return equal
+}
+
+func toFloat(v any) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
+
+// dedupe removes repeated nodes (possible with descendant segments) preserving order.
+func dedupe(ms []match) []match {
+	seen := make(map[*yaml.Node]struct{}, len(ms))
+	out := ms[:0]
+	for _, m := range ms {
+		if _, ok := seen[m.node]; ok {
+			continue
+		}
+		seen[m.node] = struct{}{}
+		out = append(out, m)
+	}
+	return out
+}
+
+// escapePointer escapes a JSON Pointer reference token (RFC 6901).
+func escapePointer(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "~", "~0"), "/", "~1")
+}
@@ -0,0 +1,98 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package overlay
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+const pathTestDoc = `{
+  "tags": [{"name": "users"}, {"name": "admin", "x-internal": true}],
+  "paths": {
+    "/users": {
+      "get": {"summary": "List", "x-internal": false},
+      "post": {"summary": "Create"}
+    },
+    "/admin": {
+      "get": {"summary": "Admin", "x-internal": true}
+    }
+  }
+}`
+
+func TestPath_Select(t *testing.T) {
+	t.Parallel()
+
+	var doc yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(pathTestDoc), &doc))
+	root := documentRoot(&doc)
+
+	tests := []struct {
+		expr string
+		want []string
+	}{
+		{expr: "$", want: []string{""}},
+		{expr: "$.paths['/users'].get", want: []string{"/paths/~1users/get"}},
+		{expr: `$.paths["/users"]["post"]`, want: []string{"/paths/~1users/post"}},
+		{expr: "$.paths.*.*", want: []string{"/paths/~1users/get", "/paths/~1users/post", "/paths/~1admin/get"}},
+		{expr: "$.tags[1]", want: []string{"/tags/1"}},
+		{expr: "$.tags[-1]", want: []string{"/tags/1"}},
+		{expr: "$.tags[5]", want: nil},
+		{expr: "$.tags[?(@.name == 'users')]", want: []string{"/tags/0"}},
+		{expr: "$.tags[?(@.name != 'users')]", want: []string{"/tags/1"}},
+		{expr: "$.tags[?@.x-internal]", want: []string{"/tags/1"}},
+		{expr: "$.paths.*[?(@.x-internal == true)]", want: []string{"/paths/~1admin/get"}},
+		{expr: "$..summary", want: []string{"/paths/~1users/get/summary", "/paths/~1users/post/summary", "/paths/~1admin/get/summary"}},
+		{expr: "$.missing", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			t.Parallel()
+			p, err := ParsePath(tt.expr)
+			require.NoError(t, err)
+
+			var got []string
+			for _, m := range p.selectNodes(root) {
+				got = append(got, m.ptr)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParsePath_Errors(t *testing.T) {
+	t.Parallel()
+
+	for _, expr := range []string{
+		"",
+		"paths",
+		"$.",
+		"$.paths[",
+		"$.paths['/users'",
+		"$.tags[?(name == 'x')]",
+		"$.tags[?(@.name == )]",
+		"$.tags[?(@.name == 'x']",
+		"$paths",
+	} {
+		_, err := ParsePath(expr)
+		assert.Error(t, err, expr)
+	}
+}
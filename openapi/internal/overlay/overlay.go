@@ -0,0 +1,395 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package overlay merges hand-maintained content into generated specifications.
+//
+// Two kinds of patches are supported:
+//   - Fragments: partial OpenAPI documents deep-merged into the generated spec
+//   - Overlays: OpenAPI Overlay 1.0 documents whose actions target nodes via JSONPath
+//
+// Patches operate on yaml.Node trees so key order from the generated spec is
+// preserved in both the JSON and YAML output.
+package overlay
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"rivaas.dev/openapi/diag"
+)
+
+// Document is a parsed OpenAPI Overlay document.
+type Document struct {
+	// Title is info.title from the overlay, used in warnings.
+	Title string
+
+	// Actions are applied in order.
+	Actions []Action
+}
+
+// Action is a single overlay action.
+type Action struct {
+	// Target selects the nodes the action applies to.
+	Target *Path
+
+	// Update is merged into each target. Nil when the action only removes.
+	Update *yaml.Node
+
+	// Remove deletes each target from its parent.
+	Remove bool
+}
+
+// Patch is a fragment or overlay ready to be applied to a spec.
+type Patch struct {
+	name     string
+	fragment *yaml.Node
+	overlay  *Document
+}
+
+// Name returns a short identifier for the patch used in warnings.
+func (p *Patch) Name() string {
+	return p.name
+}
+
+// rawDocument mirrors the Overlay 1.0 wire format.
+type rawDocument struct {
+	Overlay string `yaml:"overlay"`
+	Info    struct {
+		Title   string `yaml:"title"`
+		Version string `yaml:"version"`
+	} `yaml:"info"`
+	Actions []struct {
+		Target      string    `yaml:"target"`
+		Description string    `yaml:"description"`
+		Update      yaml.Node `yaml:"update"`
+		Remove      bool      `yaml:"remove"`
+	} `yaml:"actions"`
+}
+
+// ParseOverlay parses an OpenAPI Overlay 1.0 document in JSON or YAML.
+func ParseOverlay(data []byte) (*Patch, error) {
+	var raw rawDocument
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("invalid overlay document: %w", err)
+	}
+	if !strings.HasPrefix(raw.Overlay, "1.") {
+		return nil, fmt.Errorf("invalid overlay document: unsupported overlay version %q (want 1.x)", raw.Overlay)
+	}
+	if len(raw.Actions) == 0 {
+		return nil, errors.New("invalid overlay document: at least one action is required")
+	}
+
+	doc := &Document{Title: raw.Info.Title, Actions: make([]Action, 0, len(raw.Actions))}
+	for i, a := range raw.Actions {
+		if a.Target == "" {
+			return nil, fmt.Errorf("invalid overlay document: action %d: target is required", i)
+		}
+		path, err := ParsePath(a.Target)
+		if err != nil {
+			return nil, fmt.Errorf("invalid overlay document: action %d: %w", i, err)
+		}
+		action := Action{Target: path, Remove: a.Remove}
+		if a.Update.Kind != 0 {
+			update := a.Update
+			action.Update = &update
+		}
+		if action.Update == nil && !action.Remove {
+			return nil, fmt.Errorf("invalid overlay document: action %d: update or remove is required", i)
+		}
+		doc.Actions = append(doc.Actions, action)
+	}
+
+	name := "overlay"
+	if doc.Title != "" {
+		name = fmt.Sprintf("overlay %q", doc.Title)
+	}
+	return &Patch{name: name, overlay: doc}, nil
+}
+
+// ParseFragment parses a partial OpenAPI document in JSON or YAML.
+func ParseFragment(data []byte) (*Patch, error) {
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, fmt.Errorf("invalid fragment: %w", err)
+	}
+	root := documentRoot(&node)
+	if root == nil || root.Kind != yaml.MappingNode {
+		return nil, errors.New("invalid fragment: top level must be an object")
+	}
+	return &Patch{name: "fragment", fragment: root}, nil
+}
+
+// Apply parses specJSON, applies patches in order, and returns the re-encoded
+// JSON and YAML along with conflict and no-match warnings.
+func Apply(specJSON []byte, patches []*Patch) (jsonOut, yamlOut []byte, warns diag.Warnings, err error) {
+	var doc yaml.Node
+	if err = yaml.Unmarshal(specJSON, &doc); err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to parse generated spec: %w", err)
+	}
+	root := documentRoot(&doc)
+	if root == nil {
+		return nil, nil, nil, errors.New("failed to parse generated spec: empty document")
+	}
+
+	for i, p := range patches {
+		m := &merger{source: fmt.Sprintf("%s #%d", p.name, i+1)}
+		switch {
+		case p.fragment != nil:
+			m.arrays = replaceArrays
+			m.merge(root, p.fragment, "")
+		case p.overlay != nil:
+			m.arrays = appendArrays
+			applyOverlay(root, p.overlay, m)
+		}
+		warns = append(warns, m.warns...)
+	}
+
+	resetStyle(root)
+
+	if jsonOut, err = EncodeJSON(root); err != nil {
+		return nil, nil, warns, err
+	}
+	if yamlOut, err = yaml.Marshal(root); err != nil {
+		return nil, nil, warns, fmt.Errorf("failed to marshal spec to YAML: %w", err)
+	}
+	return jsonOut, yamlOut, warns, nil
+}
+
+// applyOverlay runs each action of doc against root.
+func applyOverlay(root *yaml.Node, doc *Document, m *merger) {
+	for i, action := range doc.Actions {
+		matches := action.Target.selectNodes(root)
+		if len(matches) == 0 {
+			m.warns = append(m.warns, diag.NewWarning(diag.WarnOverlayNoMatch, "#",
+				fmt.Sprintf("%s: action %d target %s matched no nodes", m.source, i, action.Target)))
+			continue
+		}
+
+		if action.Remove {
+			// Remove in reverse so earlier indices stay valid within the same parent.
+			for j := len(matches) - 1; j >= 0; j-- {
+				remove(matches[j])
+			}
+			continue
+		}
+
+		for _, t := range matches {
+			if t.node.Kind == yaml.SequenceNode && action.Update.Kind != yaml.SequenceNode {
+				t.node.Content = append(t.node.Content, cloneNode(action.Update))
+				continue
+			}
+			m.merge(t.node, action.Update, t.ptr)
+		}
+	}
+}
+
+// remove deletes a matched node from its parent.
+func remove(t match) {
+	if t.parent == nil {
+		return
+	}
+	switch t.parent.Kind {
+	case yaml.MappingNode:
+		t.parent.Content = append(t.parent.Content[:t.index], t.parent.Content[t.index+2:]...)
+	case yaml.SequenceNode:
+		t.parent.Content = append(t.parent.Content[:t.index], t.parent.Content[t.index+1:]...)
+	}
+}
+
+// arrayMode controls how sequences are merged.
+type arrayMode int
+
+const (
+	replaceArrays arrayMode = iota
+	appendArrays
+)
+
+// merger deep-merges nodes and records conflicts.
+type merger struct {
+	source string
+	arrays arrayMode
+	warns  diag.Warnings
+}
+
+// merge merges src into dst in place. ptr is the JSON Pointer of dst.
+func (m *merger) merge(dst, src *yaml.Node, ptr string) {
+	if dst.Kind == yaml.MappingNode && src.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(src.Content); i += 2 {
+			key, val := src.Content[i], src.Content[i+1]
+			childPtr := ptr + "/" + escapePointer(key.Value)
+			if existing := lookup(dst, key.Value); existing != nil {
+				m.merge(existing, val, childPtr)
+				continue
+			}
+			dst.Content = append(dst.Content, cloneNode(key), cloneNode(val))
+		}
+		return
+	}
+
+	if dst.Kind == yaml.SequenceNode && src.Kind == yaml.SequenceNode && m.arrays == appendArrays {
+		for _, item := range src.Content {
+			dst.Content = append(dst.Content, cloneNode(item))
+		}
+		return
+	}
+
+	if !equalNodes(dst, src) {
+		m.warns = append(m.warns, diag.NewWarning(diag.WarnOverlayConflict, "#"+ptr,
+			fmt.Sprintf("%s replaced generated value at #%s", m.source, ptr)))
+	}
+	*dst = *cloneNode(src)
+}
+
+// lookup returns the value for key in a mapping node.
+func lookup(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// equalNodes reports whether two nodes encode the same JSON value.
+func equalNodes(a, b *yaml.Node) bool {
+	aj, errA := EncodeJSON(a)
+	bj, errB := EncodeJSON(b)
+	return errA == nil && errB == nil && bytes.Equal(aj, bj)
+}
+
+// documentRoot unwraps a DocumentNode.
+func documentRoot(n *yaml.Node) *yaml.Node {
+	if n.Kind == yaml.DocumentNode {
+		if len(n.Content) == 0 {
+			return nil
+		}
+		return n.Content[0]
+	}
+	return n
+}
+
+// cloneNode deep-copies a node, resolving aliases.
+func cloneNode(n *yaml.Node) *yaml.Node {
+	if n.Kind == yaml.AliasNode && n.Alias != nil {
+		return cloneNode(n.Alias)
+	}
+	c := *n
+	if n.Kind == yaml.DocumentNode {
+		return cloneNode(documentRoot(n))
+	}
+	c.Content = make([]*yaml.Node, len(n.Content))
+	for i, child := range n.Content {
+		c.Content[i] = cloneNode(child)
+	}
+	return &c
+}
+
+// resetStyle clears flow and quoting styles inherited from JSON input so the
+// YAML output uses block style like directly generated specs.
+func resetStyle(n *yaml.Node) {
+	n.Style = 0
+	for _, c := range n.Content {
+		resetStyle(c)
+	}
+}
+
+// EncodeJSON encodes a node as indented JSON, preserving mapping key order.
+func EncodeJSON(n *yaml.Node) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := writeJSON(&buf, n); err != nil {
+		return nil, err
+	}
+	var out bytes.Buffer
+	if err := json.Indent(&out, buf.Bytes(), "", "  "); err != nil {
+		return nil, fmt.Errorf("failed to marshal spec to JSON: %w", err)
+	}
+	return out.Bytes(), nil
+}
+
+func writeJSON(buf *bytes.Buffer, n *yaml.Node) error {
+	switch n.Kind {
+	case yaml.DocumentNode:
+		root := documentRoot(n)
+		if root == nil {
+			buf.WriteString("null")
+			return nil
+		}
+		return writeJSON(buf, root)
+	case yaml.AliasNode:
+		return writeJSON(buf, n.Alias)
+	case yaml.MappingNode:
+		buf.WriteByte('{')
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			key, err := json.Marshal(n.Content[i].Value)
+			if err != nil {
+				return err
+			}
+			buf.Write(key)
+			buf.WriteByte(':')
+			if err := writeJSON(buf, n.Content[i+1]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+		return nil
+	case yaml.SequenceNode:
+		buf.WriteByte('[')
+		for i, c := range n.Content {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeJSON(buf, c); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+		return nil
+	case yaml.ScalarNode:
+		return writeScalar(buf, n)
+	}
+	return fmt.Errorf("unsupported YAML node kind %d", n.Kind)
+}
+
+func writeScalar(buf *bytes.Buffer, n *yaml.Node) error {
+	var v any
+	switch n.ShortTag() {
+	case "!!str", "!!binary", "!!timestamp":
+		v = n.Value
+	case "!!int":
+		if i, err := strconv.ParseInt(n.Value, 0, 64); err == nil {
+			v = i
+		} else if err := n.Decode(&v); err != nil {
+			return err
+		}
+	default:
+		if err := n.Decode(&v); err != nil {
+			return err
+		}
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode %q as JSON: %w", n.Value, err)
+	}
+	buf.Write(b)
+	return nil
+}
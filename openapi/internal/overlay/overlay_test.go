@@ -0,0 +1,184 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package overlay
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"rivaas.dev/openapi/diag"
+)
+
+const baseSpec = `{
+  "openapi": "3.0.4",
+  "info": {"title": "API", "version": "1.0.0"},
+  "tags": [{"name": "users"}],
+  "paths": {
+    "/users": {"get": {"summary": "List users", "responses": {"200": {"description": "OK"}}}},
+    "/internal": {"get": {"summary": "Internal", "responses": {"200": {"description": "OK"}}}}
+  }
+}`
+
+func decode(t *testing.T, data []byte) map[string]any {
+	t.Helper()
+	var m map[string]any
+	require.NoError(t, json.Unmarshal(data, &m))
+	return m
+}
+
+func TestApply_Fragment(t *testing.T) {
+	t.Parallel()
+
+	p, err := ParseFragment([]byte(`
+info:
+  description: Curated description
+paths:
+  /users:
+    get:
+      summary: List all users
+      x-codeSamples:
+        - lang: curl
+          source: curl /users
+tags:
+  - name: users
+    description: User operations
+`))
+	require.NoError(t, err)
+
+	jsonOut, yamlOut, warns, err := Apply([]byte(baseSpec), []*Patch{p})
+	require.NoError(t, err)
+
+	spec := decode(t, jsonOut)
+	assert.Equal(t, "Curated description", spec["info"].(map[string]any)["description"])
+	get := spec["paths"].(map[string]any)["/users"].(map[string]any)["get"].(map[string]any)
+	assert.Equal(t, "List all users", get["summary"])
+	assert.Len(t, get["x-codeSamples"], 1)
+	assert.Equal(t, "User operations", spec["tags"].([]any)[0].(map[string]any)["description"])
+
+	require.Len(t, warns, 2)
+	assert.True(t, warns.Has(diag.WarnOverlayConflict))
+	assert.Equal(t, "#/paths/~1users/get/summary", warns[0].Path())
+	assert.Equal(t, "#/tags", warns[1].Path())
+
+	// Key order from the generated spec is preserved.
+	assert.Less(t, strings.Index(string(jsonOut), `"openapi"`), strings.Index(string(jsonOut), `"info"`))
+	assert.True(t, strings.HasPrefix(string(yamlOut), "openapi: 3.0.4\n"), string(yamlOut))
+}
+
+func TestApply_FragmentIdenticalValueIsNotConflict(t *testing.T) {
+	t.Parallel()
+
+	p, err := ParseFragment([]byte(`{"info": {"title": "API"}}`))
+	require.NoError(t, err)
+
+	_, _, warns, err := Apply([]byte(baseSpec), []*Patch{p})
+	require.NoError(t, err)
+	assert.Empty(t, warns)
+}
+
+func TestApply_Overlay(t *testing.T) {
+	t.Parallel()
+
+	p, err := ParseOverlay([]byte(`
+overlay: 1.0.0
+info:
+  title: Gateway
+  version: 1.0.0
+actions:
+  - target: $.paths.*.get
+    update:
+      x-gateway-timeout: 30s
+  - target: $.paths['/internal']
+    remove: true
+  - target: $.tags
+    update:
+      name: admin
+  - target: $.webhooks
+    update:
+      x-ignored: true
+`))
+	require.NoError(t, err)
+
+	jsonOut, _, warns, err := Apply([]byte(baseSpec), []*Patch{p})
+	require.NoError(t, err)
+
+	spec := decode(t, jsonOut)
+	paths := spec["paths"].(map[string]any)
+	assert.NotContains(t, paths, "/internal")
+	assert.Equal(t, "30s", paths["/users"].(map[string]any)["get"].(map[string]any)["x-gateway-timeout"])
+	assert.Len(t, spec["tags"], 2)
+
+	require.Len(t, warns, 1)
+	assert.Equal(t, diag.WarnOverlayNoMatch, warns[0].Code())
+	assert.Contains(t, warns[0].Message(), `overlay "Gateway" #1`)
+}
+
+func TestApply_PreservesScalarTypes(t *testing.T) {
+	t.Parallel()
+
+	p, err := ParseFragment([]byte(`{"x-flags": {"str": "true", "num": 1.5, "int": 10, "bool": true, "null": null}}`))
+	require.NoError(t, err)
+
+	jsonOut, yamlOut, _, err := Apply([]byte(baseSpec), []*Patch{p})
+	require.NoError(t, err)
+
+	flags := decode(t, jsonOut)["x-flags"].(map[string]any)
+	assert.Equal(t, "true", flags["str"])
+	assert.InDelta(t, 1.5, flags["num"], 0)
+	assert.InDelta(t, 10, flags["int"], 0)
+	assert.Equal(t, true, flags["bool"])
+	assert.Nil(t, flags["null"])
+	assert.Contains(t, string(yamlOut), `str: "true"`)
+}
+
+func TestParseOverlay_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		doc  string
+	}{
+		{name: "not yaml", doc: "overlay: [1"},
+		{name: "wrong version", doc: "overlay: 2.0.0\nactions: [{target: $, remove: true}]"},
+		{name: "no actions", doc: "overlay: 1.0.0"},
+		{name: "missing target", doc: "overlay: 1.0.0\nactions: [{remove: true}]"},
+		{name: "bad target", doc: "overlay: 1.0.0\nactions: [{target: paths, remove: true}]"},
+		{name: "no update or remove", doc: "overlay: 1.0.0\nactions: [{target: $.info}]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := ParseOverlay([]byte(tt.doc))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestParseFragment_Errors(t *testing.T) {
+	t.Parallel()
+
+	_, err := ParseFragment([]byte(`[1, 2]`))
+	require.Error(t, err)
+
+	_, err = ParseFragment([]byte(`{`))
+	require.Error(t, err)
+}
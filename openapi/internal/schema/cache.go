@@ -0,0 +1,89 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"reflect"
+	"sync"
+
+	"rivaas.dev/openapi/internal/model"
+)
+
+// Cache holds reflected component schemas of named struct types across spec
+// builds. Go types never change at runtime, so entries stay valid for the life
+// of the process. Safe for concurrent use.
+//
+// Cached schemas are shared between builds and must not be mutated.
+type Cache struct {
+	mu      sync.RWMutex
+	entries map[reflect.Type]map[string]*model.Schema
+}
+
+// NewCache creates an empty schema cache.
+func NewCache() *Cache {
+	return &Cache{entries: make(map[reflect.Type]map[string]*model.Schema)}
+}
+
+// Len returns the number of cached types.
+func (c *Cache) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.entries)
+}
+
+// components returns the component schemas generated for t, reflecting t on a miss.
+// The result contains t's own component and every component it references.
+func (c *Cache) components(t reflect.Type) map[string]*model.Schema {
+	c.mu.RLock()
+	comps, ok := c.entries[t]
+	c.mu.RUnlock()
+	if ok {
+		return comps
+	}
+
+	// Reflect with a fresh generator so the entry is self-contained,
+	// independent of whatever the calling generator has already seen.
+	sub := NewSchemaGenerator()
+	sub.structSchema(t)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if comps, ok := c.entries[t]; ok {
+		return comps
+	}
+	c.entries[t] = sub.schemas
+	return sub.schemas
+}
+
+// NewCachedSchemaGenerator creates a schema generator that reuses reflected
+// schemas from c. It produces the same schemas as [NewSchemaGenerator].
+func NewCachedSchemaGenerator(c *Cache) *SchemaGenerator {
+	sg := NewSchemaGenerator()
+	sg.cache = c
+	return sg
+}
+
+// cachedStructSchema returns a reference to the component schema of the named
+// struct type t, merging its cached components into the generator.
+func (sg *SchemaGenerator) cachedStructSchema(t reflect.Type, name string) *model.Schema {
+	if _, ok := sg.schemas[name]; !ok {
+		for n, s := range sg.cache.components(t) {
+			if _, exists := sg.schemas[n]; !exists {
+				sg.schemas[n] = s
+			}
+		}
+	}
+	return &model.Schema{Ref: "#/components/schemas/" + name}
+}
@@ -0,0 +1,71 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package schema
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type cacheAuthor struct {
+	Name  string       `json:"name"`
+	Posts []*cachePost `json:"posts"`
+}
+
+type cachePost struct {
+	Title  string       `json:"title" validate:"required"`
+	Author *cacheAuthor `json:"author"`
+}
+
+func TestCachedSchemaGenerator(t *testing.T) {
+	t.Parallel()
+
+	cache := NewCache()
+	types := []reflect.Type{
+		reflect.TypeFor[cachePost](),
+		reflect.TypeFor[[]cacheAuthor](),
+		reflect.TypeFor[*RegularStruct](),
+	}
+
+	for range 2 {
+		plain := NewSchemaGenerator()
+		cached := NewCachedSchemaGenerator(cache)
+		for _, typ := range types {
+			assert.Equal(t, plain.Generate(typ), cached.Generate(typ))
+		}
+		assert.Equal(t, plain.GetComponentSchemas(), cached.GetComponentSchemas())
+	}
+
+	assert.Equal(t, 2, cache.Len(), "types already present in the build are not looked up")
+}
+
+func TestCachedSchemaGenerator_SelfContainedEntries(t *testing.T) {
+	t.Parallel()
+
+	cache := NewCache()
+	NewCachedSchemaGenerator(cache).Generate(reflect.TypeFor[cachePost]())
+
+	// A later build that only uses the author still gets the post component it references.
+	sg := NewCachedSchemaGenerator(cache)
+	s := sg.Generate(reflect.TypeFor[cacheAuthor]())
+	assert.Equal(t, "#/components/schemas/schema.cacheAuthor", s.Ref)
+	require.Contains(t, sg.GetComponentSchemas(), "schema.cachePost")
+	assert.Len(t, sg.GetComponentSchemas(), 2)
+}
@@ -52,11 +52,19 @@ func walkFields(t reflect.Type, fn func(reflect.StructField)) {
 // To avoid cross-package type name collisions, the name includes the package name
 // in the format "pkgname.TypeName". The package name is the last component of
 // the package path (e.g., "github.com/user/api" -> "api").
+//
+// Generic instantiations append their type arguments, each reduced to its own
+// "pkgname.TypeName", so "Page[github.com/user/api.User]" becomes
+// "openapi.Page_api.User" and stays a valid component name.
 func schemaName(t reflect.Type) string {
 	if t.Name() == "" {
 		return ""
 	}
 
+	if base, args, ok := strings.Cut(t.Name(), "["); ok {
+		return genericSchemaName(t.PkgPath(), base, strings.TrimSuffix(args, "]"))
+	}
+
 	// Get package path and extract the last component as the package name
 	pkgPath := t.PkgPath()
 	if pkgPath == "" {
@@ -106,3 +114,33 @@ func isFieldRequired(f reflect.StructField) bool {
 
 	return strings.Contains(f.Tag.Get("validate"), "required")
 }
+
+// genericSchemaName builds the component name of a generic type instantiation
+// from its base name and the type argument list reported by reflect.
+func genericSchemaName(pkgPath, base, args string) string {
+	var b strings.Builder
+	if pkg := pkgPath[strings.LastIndex(pkgPath, "/")+1:]; pkg != "" && pkg != base {
+		b.WriteString(pkg + ".")
+	}
+	b.WriteString(base)
+
+	for arg := range strings.SplitSeq(args, ",") {
+		// "[]example.com/api.User" -> "api.UserList"
+		elem := strings.TrimLeft(arg, "[]*")
+		elem = elem[strings.LastIndex(elem, "/")+1:]
+		b.WriteByte('_')
+		b.WriteString(strings.Map(func(r rune) rune {
+			switch {
+			case r == '.' || r == '_' || r == '-',
+				r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+				return r
+			}
+			return -1
+		}, elem))
+		if strings.Contains(arg[:len(arg)-len(strings.TrimLeft(arg, "[]*"))], "[]") {
+			b.WriteString("List")
+		}
+	}
+
+	return b.String()
+}
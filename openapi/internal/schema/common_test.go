@@ -48,6 +48,11 @@ type PointerEmbedded struct {
 	Status string `json:"status"`
 }
 
+type genericPair[K, V any] struct {
+	Key   K
+	Value V
+}
+
 type RegularStruct struct {
 	Field1 string `json:"field1"`
 	Field2 int    `json:"field2"`
@@ -313,6 +318,13 @@ func TestSchemaName(t *testing.T) {
 		// This depends on the actual package structure
 		assert.NotEmpty(t, name)
 	})
+
+	t.Run("handles generic instantiations", func(t *testing.T) {
+		assert.Equal(t, "schema.genericPair_string_schema.RegularStruct",
+			schemaName(reflect.TypeFor[genericPair[string, RegularStruct]]()))
+		assert.Equal(t, "schema.genericPair_int_schema.RegularStructList",
+			schemaName(reflect.TypeFor[genericPair[int, []*RegularStruct]]()))
+	})
 }
 
 func TestParseJSONName(t *testing.T) {
@@ -38,6 +38,7 @@ import (
 type SchemaGenerator struct {
 	schemas map[string]*model.Schema
 	seen    map[reflect.Type]bool
+	cache   *Cache // Optional; see NewCachedSchemaGenerator
 }
 
 // NewSchemaGenerator creates a new schema generator.
@@ -119,6 +120,10 @@ func (sg *SchemaGenerator) Generate(t reflect.Type) *model.Schema {
 	case reflect.Interface:
 		return &model.Schema{Kind: model.KindObject}
 	case reflect.Struct:
+		if name := schemaName(t); name != "" && sg.cache != nil {
+			return sg.cachedStructSchema(t, name)
+		}
+
 		return sg.structSchema(t)
 	default:
 		return &model.Schema{Kind: model.KindObject}
@@ -165,7 +170,7 @@ func (sg *SchemaGenerator) structSchema(t reflect.Type) *model.Schema {
 		}
 
 		if ex := f.Tag.Get("example"); ex != "" {
-			fs.Example = ex
+			fs.Example = parseValue(ex, f.Type)
 		}
 
 		applyValidationConstraints(fs, f)
@@ -232,7 +237,7 @@ func (sg *SchemaGenerator) GenerateProjected(t reflect.Type, include func(reflec
 		}
 
 		if ex := f.Tag.Get("example"); ex != "" {
-			fs.Example = ex
+			fs.Example = parseValue(ex, f.Type)
 		}
 
 		applyValidationConstraints(fs, f)
@@ -263,6 +268,11 @@ func (sg *SchemaGenerator) GetComponentSchemas() map[string]*model.Schema {
 
 // applyValidationConstraints applies validation constraints from struct tags to a schema.
 func applyValidationConstraints(s *model.Schema, f reflect.StructField) {
+	// An explicit format tag takes precedence over formats implied by validate rules
+	if format := f.Tag.Get("format"); format != "" {
+		defer func() { s.Format = format }()
+	}
+
 	v := f.Tag.Get("validate")
 	if v == "" {
 		return
@@ -15,6 +15,7 @@
 package schema
 
 import (
+	"encoding/json"
 	"net"
 	"net/url"
 	"reflect"
@@ -247,11 +248,40 @@ func parseValue(s string, t reflect.Type) any {
 		if v, err := strconv.ParseBool(s); err == nil {
 			return v
 		}
+	case reflect.Slice, reflect.Array:
+		var v []any
+		if err := json.Unmarshal([]byte(s), &v); err == nil {
+			return v
+		}
+		parts := strings.Split(s, ",")
+		out := make([]any, 0, len(parts))
+		for _, p := range parts {
+			out = append(out, parseValue(strings.TrimSpace(p), t.Elem()))
+		}
+		return out
+	case reflect.Map, reflect.Struct:
+		var v map[string]any
+		if err := json.Unmarshal([]byte(s), &v); err == nil {
+			return v
+		}
 	}
 
 	return s
 }
 
+// ParseExample converts an example or default tag value to a value of the
+// JSON type matching t (numbers, booleans, arrays as JSON or comma-separated,
+// objects as JSON). Unparseable values are returned as strings.
+func ParseExample(s string, t reflect.Type) any {
+	return parseValue(s, t)
+}
+
+// InferFormat returns the OpenAPI format for a struct field, from its format
+// tag, its validate tag, or well-known types such as time.Time.
+func InferFormat(field reflect.StructField) string {
+	return inferFormat(field)
+}
+
 // inferFormat infers OpenAPI format from field type and validation tags.
 func inferFormat(field reflect.StructField) string {
 	if f := field.Tag.Get("format"); f != "" {
@@ -523,6 +523,12 @@ func TestParseValue(t *testing.T) {
 		{"pointer to int", "42", reflect.TypeFor[*int](), int64(42)},
 		{"pointer to string", "hello", reflect.TypeFor[*string](), "hello"},
 
+		// Composite types
+		{"slice as JSON", `["a","b"]`, reflect.TypeFor[[]string](), []any{"a", "b"}},
+		{"slice comma-separated", "1, 2", reflect.TypeFor[[]int](), []any{int64(1), int64(2)}},
+		{"map as JSON", `{"k":1}`, reflect.TypeFor[map[string]int](), map[string]any{"k": 1.0}},
+		{"struct invalid JSON", "{", reflect.TypeFor[struct{}](), "{"},
+
 		// Invalid values (should return string)
 		{"invalid int", "not-a-number", reflect.TypeFor[int](), "not-a-number"},
 		{"invalid float", "not-a-float", reflect.TypeFor[float64](), "not-a-float"},
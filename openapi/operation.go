@@ -20,6 +20,7 @@ import (
 	"reflect"
 
 	"rivaas.dev/openapi/example"
+	"rivaas.dev/openapi/internal/build"
 	"rivaas.dev/openapi/internal/schema"
 	"rivaas.dev/openapi/validate"
 )
@@ -60,8 +61,10 @@ type operationDoc struct {
 	RequestExample        any               // Single unnamed example
 	RequestNamedExamples  []example.Example // Named examples
 	ResponseTypes         map[int]reflect.Type
-	ResponseExample       map[int]any               // Single unnamed example per status
-	ResponseNamedExamples map[int][]example.Example // Named examples per status
+	ResponseExample       map[int]any                // Single unnamed example per status
+	ResponseNamedExamples map[int][]example.Example  // Named examples per status
+	ResponseHeaders       map[int][]build.HeaderData // Documented response headers per status
+	ExampleStructs        []any                      // Example values matched to request/response types by WithExampleStruct
 	Security              []SecurityReq
 	Extensions            map[string]any // Operation-level extensions (x-*)
 }
@@ -282,6 +285,10 @@ func WithResponse(status int, resp any, examples ...example.Example) OperationOp
 
 		d.ResponseTypes[status] = reflect.TypeOf(resp)
 
+		if _, ok := resp.(paginated); ok {
+			addResponseHeader(d, status, linkHeader())
+		}
+
 		if len(examples) == 0 {
 			if !isZeroValue(resp) {
 				d.ResponseExample[status] = resp
@@ -292,6 +299,36 @@ func WithResponse(status int, resp any, examples ...example.Example) OperationOp
 	}
 }
 
+// WithResponseHeader documents a string header sent with the response for status.
+// Declaring the same header twice for a status replaces the earlier description.
+//
+// Example:
+//
+//	openapi.WithPOST("/users",
+//	    openapi.WithResponse(201, User{}),
+//	    openapi.WithResponseHeader(201, "Location", "URL of the created user"),
+//	)
+func WithResponseHeader(status int, name, description string) OperationOption {
+	return func(d *operationDoc) {
+		addResponseHeader(d, status, build.HeaderData{Name: name, Description: description})
+	}
+}
+
+// addResponseHeader records h for status, replacing a header with the same name.
+func addResponseHeader(d *operationDoc, status int, h build.HeaderData) {
+	if d.ResponseHeaders == nil {
+		d.ResponseHeaders = make(map[int][]build.HeaderData)
+	}
+	headers := d.ResponseHeaders[status]
+	for i := range headers {
+		if http.CanonicalHeaderKey(headers[i].Name) == http.CanonicalHeaderKey(h.Name) {
+			headers[i] = h
+			return
+		}
+	}
+	d.ResponseHeaders[status] = append(headers, h)
+}
+
 // WithTags adds tags to the operation.
 //
 // Example:
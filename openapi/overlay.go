@@ -0,0 +1,97 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"fmt"
+
+	"rivaas.dev/openapi/internal/overlay"
+)
+
+// WithFragment merges a hand-maintained partial OpenAPI document (JSON or YAML)
+// into the generated specification.
+//
+// Objects are merged recursively; scalar values and arrays in the fragment
+// replace generated ones. Every replaced value that differs from the generated
+// one is reported as a [diag.WarnOverlayConflict] warning in [Result.Warnings],
+// so curated content can coexist with generated content without silently
+// masking changes in code.
+//
+// Fragments and overlays are applied in the order their options are given,
+// after generation and before spec validation.
+//
+// Example:
+//
+//	//go:embed openapi/extra.yaml
+//	var extra []byte
+//
+//	openapi.WithFragment(extra)
+//
+// where extra.yaml might contain:
+//
+//	paths:
+//	  /users/{id}:
+//	    get:
+//	      x-codeSamples:
+//	        - lang: curl
+//	          source: curl https://api.example.com/users/1
+func WithFragment(data []byte) Option {
+	return func(c *config) {
+		p, err := overlay.ParseFragment(data)
+		if err != nil {
+			c.validationErrors = append(c.validationErrors, fmt.Errorf("openapi: fragment at index %d: %w", len(c.patches), err))
+			return
+		}
+		c.patches = append(c.patches, p)
+	}
+}
+
+// WithOverlay applies an OpenAPI Overlay 1.0 document (JSON or YAML) to the
+// generated specification.
+//
+// Each action's target is a JSONPath expression. Updates are merged into every
+// matched node (objects recursively, arrays appended); actions with
+// "remove: true" delete the matched nodes. Replaced generated values are
+// reported as [diag.WarnOverlayConflict] and targets that match nothing as
+// [diag.WarnOverlayNoMatch].
+//
+// Supported JSONPath: child names (dot and bracket notation), wildcards,
+// array indices, descendant segments (..), and filters of the form
+// [?(@.field)] and [?(@.field == 'value')].
+//
+// Example:
+//
+//	openapi.WithOverlay([]byte(`
+//	overlay: 1.0.0
+//	info:
+//	  title: Gateway extensions
+//	  version: 1.0.0
+//	actions:
+//	  - target: $.paths.*.*
+//	    update:
+//	      x-gateway-timeout: 30s
+//	  - target: $.paths['/internal/metrics']
+//	    remove: true
+//	`))
+func WithOverlay(data []byte) Option {
+	return func(c *config) {
+		p, err := overlay.ParseOverlay(data)
+		if err != nil {
+			c.validationErrors = append(c.validationErrors, fmt.Errorf("openapi: overlay at index %d: %w", len(c.patches), err))
+			return
+		}
+		c.patches = append(c.patches, p)
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package openapi
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"rivaas.dev/openapi/diag"
+)
+
+func TestWithFragmentAndOverlay(t *testing.T) {
+	t.Parallel()
+
+	api := MustNew(
+		WithTitle("API", "1.0.0"),
+		WithOperations(
+			mustOperation(WithGET("/users/:id", WithSummary("Get user"))),
+			mustOperation(WithGET("/internal/metrics", WithSummary("Metrics"))),
+		),
+		WithFragment([]byte(`
+paths:
+  /users/{id}:
+    get:
+      summary: Fetch a user
+      description: Hand-written description
+`)),
+		WithOverlay([]byte(`
+overlay: 1.0.0
+info: {title: Gateway, version: 1.0.0}
+actions:
+  - target: $.paths['/internal/metrics']
+    remove: true
+  - target: $.paths.*.get
+    update:
+      x-gateway-timeout: 30s
+`)),
+	)
+
+	result, err := api.Spec(t.Context())
+	require.NoError(t, err)
+
+	var spec map[string]any
+	require.NoError(t, json.Unmarshal(result.JSON, &spec))
+	paths := spec["paths"].(map[string]any)
+	require.NotContains(t, paths, "/internal/metrics")
+
+	get := paths["/users/{id}"].(map[string]any)["get"].(map[string]any)
+	assert.Equal(t, "Fetch a user", get["summary"])
+	assert.Equal(t, "Hand-written description", get["description"])
+	assert.Equal(t, "30s", get["x-gateway-timeout"])
+	assert.Contains(t, string(result.YAML), "x-gateway-timeout: 30s")
+
+	conflicts := result.Warnings.FilterCategory(diag.CategoryOverlay)
+	require.Len(t, conflicts, 1)
+	assert.Equal(t, diag.WarnOverlayConflict, conflicts[0].Code())
+	assert.Equal(t, "#/paths/~1users~1{id}/get/summary", conflicts[0].Path())
+}
+
+func TestWithFragmentAndOverlay_InvalidDocuments(t *testing.T) {
+	t.Parallel()
+
+	_, err := New(WithTitle("API", "1.0.0"), WithFragment([]byte(`- not an object`)))
+	require.ErrorContains(t, err, "fragment at index 0")
+
+	_, err = New(WithTitle("API", "1.0.0"), WithOverlay([]byte(`overlay: 1.0.0`)))
+	require.ErrorContains(t, err, "overlay at index 0")
+}
+
+func mustOperation(op Operation, err error) Operation {
+	if err != nil {
+		panic(err)
+	}
+	return op
+}
@@ -0,0 +1,79 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import "rivaas.dev/openapi/internal/build"
+
+// Page is the standard envelope for paginated list responses.
+//
+// Handlers return it as the response body; [Paginated] documents it. The
+// generated component schema is named after the item type (for example
+// "openapi.Page_api.User"), so every list endpoint of the same item type
+// shares one schema.
+type Page[T any] struct {
+	// Items holds the current page. It is always encoded as an array.
+	Items []T `json:"items" validate:"required" doc:"Items on this page"`
+
+	// Total is the number of items across all pages.
+	Total int `json:"total" doc:"Total number of items across all pages" example:"42"`
+
+	// NextCursor is the opaque cursor of the next page, empty on the last page.
+	NextCursor string `json:"next_cursor,omitempty" doc:"Cursor for the next page; absent on the last page"`
+}
+
+// NewPage returns a page of items. A nil items slice is replaced by an empty
+// one so the response always carries an "items" array.
+//
+// Example:
+//
+//	c.JSON(http.StatusOK, openapi.NewPage(users, total, next))
+func NewPage[T any](items []T, total int, nextCursor string) Page[T] {
+	if items == nil {
+		items = []T{}
+	}
+	return Page[T]{Items: items, Total: total, NextCursor: nextCursor}
+}
+
+// Paginated returns a [Page] marker for documenting a paginated list response
+// of T with [WithResponse].
+//
+// Besides the envelope schema (items, total, next_cursor), the response
+// documents the RFC 8288 Link header carrying the next and previous page URLs,
+// replacing hand-written map[string]any response stubs.
+//
+// Example:
+//
+//	openapi.WithGET("/users",
+//	    openapi.WithResponse(200, openapi.Paginated[User]()),
+//	)
+func Paginated[T any]() Page[T] {
+	return Page[T]{}
+}
+
+// paginated is implemented by [Page] so WithResponse can document its headers.
+type paginated interface {
+	paginated()
+}
+
+func (Page[T]) paginated() {}
+
+// linkHeader documents the Link header of paginated responses.
+func linkHeader() build.HeaderData {
+	return build.HeaderData{
+		Name:        "Link",
+		Description: `Pagination links (RFC 8288) with rel="next" and rel="prev" when those pages exist`,
+		Example:     `<https://api.example.com/items?cursor=eyJpZCI6NDJ9>; rel="next"`,
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package openapi
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPaginated(t *testing.T) {
+	t.Parallel()
+
+	api := MustNew(
+		WithTitle("API", "1.0.0"),
+		WithOperations(
+			mustOperation(WithGET("/users",
+				WithResponse(200, Paginated[exampleUser]()),
+			)),
+			mustOperation(WithGET("/admins",
+				WithResponse(200, Paginated[exampleUser]()),
+			)),
+		),
+	)
+
+	spec := specJSON(t, api)
+
+	schemas := spec["components"].(map[string]any)["schemas"].(map[string]any)
+	page, ok := schemas["openapi.Page_openapi.exampleUser"].(map[string]any)
+	require.True(t, ok, "page schema is a named component: %v", schemas)
+
+	props := page["properties"].(map[string]any)
+	assert.Equal(t, "array", props["items"].(map[string]any)["type"])
+	assert.Equal(t, map[string]any{"$ref": "#/components/schemas/openapi.exampleUser"},
+		props["items"].(map[string]any)["items"])
+	assert.Equal(t, "integer", props["total"].(map[string]any)["type"])
+	assert.Equal(t, "string", props["next_cursor"].(map[string]any)["type"])
+	assert.Equal(t, []any{"items"}, page["required"])
+
+	for _, path := range []string{"/users", "/admins"} {
+		resp := spec["paths"].(map[string]any)[path].(map[string]any)["get"].(map[string]any)["responses"].(map[string]any)["200"].(map[string]any)
+		assert.Equal(t, map[string]any{"$ref": "#/components/schemas/openapi.Page_openapi.exampleUser"},
+			resp["content"].(map[string]any)["application/json"].(map[string]any)["schema"])
+
+		link := resp["headers"].(map[string]any)["Link"].(map[string]any)
+		assert.Contains(t, link["description"], "RFC 8288")
+		assert.Equal(t, "string", link["schema"].(map[string]any)["type"])
+	}
+}
+
+func TestNewPage(t *testing.T) {
+	t.Parallel()
+
+	data, err := json.Marshal(NewPage[exampleUser](nil, 0, ""))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"items":[],"total":0}`, string(data))
+
+	data, err = json.Marshal(NewPage([]exampleUser{{ID: 1, Name: "Ada"}}, 3, "abc"))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"items":[{"id":1,"name":"Ada","email":""}],"total":3,"next_cursor":"abc"}`, string(data))
+}
+
+func TestWithResponseHeader(t *testing.T) {
+	t.Parallel()
+
+	api := MustNew(
+		WithTitle("API", "1.0.0"),
+		WithOperations(
+			mustOperation(WithPOST("/users",
+				WithResponse(201, exampleUser{}),
+				WithResponseHeader(201, "Location", "first"),
+				WithResponseHeader(201, "location", "URL of the created user"),
+			)),
+		),
+	)
+
+	spec := specJSON(t, api)
+	resp := spec["paths"].(map[string]any)["/users"].(map[string]any)["post"].(map[string]any)["responses"].(map[string]any)["201"].(map[string]any)
+	assert.Equal(t, map[string]any{
+		"location": map[string]any{
+			"description": "URL of the created user",
+			"schema":      map[string]any{"type": "string"},
+		},
+	}, resp["headers"])
+}
@@ -0,0 +1,138 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postman
+
+import "encoding/json"
+
+// SchemaURL is the Postman Collection Format v2.1.0 schema identifier.
+const SchemaURL = "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"
+
+// Collection is a Postman Collection (format v2.1.0).
+type Collection struct {
+	Info     Info       `json:"info"`
+	Item     []Item     `json:"item"`
+	Auth     *Auth      `json:"auth,omitempty"`
+	Variable []Variable `json:"variable,omitempty"`
+}
+
+// JSON returns the collection serialized as indented JSON, ready to import into Postman.
+func (c *Collection) JSON() ([]byte, error) {
+	return json.MarshalIndent(c, "", "  ")
+}
+
+// Info describes the collection.
+type Info struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Schema      string `json:"schema"`
+}
+
+// Item is either a request or a folder of items.
+// Folders have Item set; requests have Request set.
+type Item struct {
+	Name        string     `json:"name"`
+	Description string     `json:"description,omitempty"`
+	Item        []Item     `json:"item,omitempty"`
+	Request     *Request   `json:"request,omitempty"`
+	Response    []Response `json:"response,omitempty"`
+}
+
+// Request is a Postman request definition.
+type Request struct {
+	Method      string   `json:"method"`
+	URL         URL      `json:"url"`
+	Header      []Header `json:"header,omitempty"`
+	Body        *Body    `json:"body,omitempty"`
+	Auth        *Auth    `json:"auth,omitempty"`
+	Description string   `json:"description,omitempty"`
+}
+
+// URL is a structured Postman request URL.
+type URL struct {
+	Raw      string       `json:"raw"`
+	Host     []string     `json:"host,omitempty"`
+	Path     []string     `json:"path,omitempty"`
+	Query    []QueryParam `json:"query,omitempty"`
+	Variable []Variable   `json:"variable,omitempty"`
+}
+
+// QueryParam is a query string parameter. Optional parameters are exported disabled.
+type QueryParam struct {
+	Key         string `json:"key"`
+	Value       string `json:"value"`
+	Description string `json:"description,omitempty"`
+	Disabled    bool   `json:"disabled,omitempty"`
+}
+
+// Header is a request or response header.
+type Header struct {
+	Key         string `json:"key"`
+	Value       string `json:"value"`
+	Description string `json:"description,omitempty"`
+	Disabled    bool   `json:"disabled,omitempty"`
+}
+
+// Body is a request body. Only raw mode is produced.
+type Body struct {
+	Mode    string       `json:"mode"`
+	Raw     string       `json:"raw,omitempty"`
+	Options *BodyOptions `json:"options,omitempty"`
+}
+
+// BodyOptions configures how Postman renders the raw body.
+type BodyOptions struct {
+	Raw BodyRawOptions `json:"raw"`
+}
+
+// BodyRawOptions sets the raw body language (json, xml, text).
+type BodyRawOptions struct {
+	Language string `json:"language"`
+}
+
+// Response is a saved example response.
+type Response struct {
+	Name            string   `json:"name"`
+	OriginalRequest *Request `json:"originalRequest,omitempty"`
+	Status          string   `json:"status,omitempty"`
+	Code            int      `json:"code"`
+	Header          []Header `json:"header,omitempty"`
+	Body            string   `json:"body,omitempty"`
+}
+
+// Auth is a Postman auth configuration.
+// Type is one of "noauth", "bearer", "basic", "apikey", or "oauth2"; the matching
+// field holds its attributes.
+type Auth struct {
+	Type   string          `json:"type"`
+	Bearer []AuthAttribute `json:"bearer,omitempty"`
+	Basic  []AuthAttribute `json:"basic,omitempty"`
+	APIKey []AuthAttribute `json:"apikey,omitempty"`
+	OAuth2 []AuthAttribute `json:"oauth2,omitempty"`
+}
+
+// AuthAttribute is a key/value pair of an auth configuration.
+type AuthAttribute struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+	Type  string `json:"type"`
+}
+
+// Variable is a collection or path variable.
+type Variable struct {
+	Key         string `json:"key"`
+	Value       string `json:"value"`
+	Type        string `json:"type,omitempty"`
+	Description string `json:"description,omitempty"`
+}
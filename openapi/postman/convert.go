@@ -0,0 +1,610 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postman
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// defaultBaseURL is used when neither WithBaseURL nor a spec server is available.
+const defaultBaseURL = "http://localhost:8080"
+
+// maxExampleDepth bounds schema recursion when synthesizing example bodies.
+const maxExampleDepth = 8
+
+// methodOrder is the order operations of a path item are emitted in.
+var methodOrder = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// converter turns a decoded OpenAPI document into a Postman collection.
+type converter struct {
+	doc      map[string]any
+	cfg      *config
+	authVars map[string]string // collection variables referenced by auth configs
+}
+
+func (cv *converter) convert() *Collection {
+	cv.authVars = make(map[string]string)
+	info := asMap(cv.doc["info"])
+
+	name := cv.cfg.name
+	if name == "" {
+		name = asString(info["title"])
+	}
+	if name == "" {
+		name = "API"
+	}
+
+	col := &Collection{
+		Info: Info{
+			Name:        name,
+			Description: asString(info["description"]),
+			Schema:      SchemaURL,
+		},
+		Item: []Item{},
+	}
+	if reqs, ok := cv.doc["security"].([]any); ok {
+		col.Auth = cv.auth(reqs)
+	}
+
+	var folders []Item
+	folderIndex := make(map[string]int)
+	paths := asMap(cv.doc["paths"])
+	for _, path := range sortedKeys(paths) {
+		pathItem := asMap(cv.resolve(paths[path]))
+		for _, method := range methodOrder {
+			op, ok := pathItem[method].(map[string]any)
+			if !ok {
+				continue
+			}
+			item := cv.operation(method, path, pathItem, op)
+
+			tags, _ := op["tags"].([]any)
+			if !cv.cfg.folders || len(tags) == 0 {
+				col.Item = append(col.Item, item)
+				continue
+			}
+			tag := asString(tags[0])
+			idx, ok := folderIndex[tag]
+			if !ok {
+				idx = len(folders)
+				folderIndex[tag] = idx
+				folders = append(folders, Item{Name: tag, Description: cv.tagDescription(tag)})
+			}
+			folders[idx].Item = append(folders[idx].Item, item)
+		}
+	}
+	col.Item = append(folders, col.Item...)
+
+	col.Variable = append(col.Variable, Variable{Key: "baseUrl", Value: cv.baseURL(), Type: "string"})
+	for _, key := range sortedKeys(cv.authVars) {
+		col.Variable = append(col.Variable, Variable{Key: key, Value: "", Type: "string", Description: cv.authVars[key]})
+	}
+	return col
+}
+
+// baseURL returns the configured base URL, the first server URL, or the default.
+func (cv *converter) baseURL() string {
+	if cv.cfg.baseURL != "" {
+		return strings.TrimSuffix(cv.cfg.baseURL, "/")
+	}
+	servers, _ := cv.doc["servers"].([]any)
+	if len(servers) == 0 {
+		return defaultBaseURL
+	}
+	server := asMap(servers[0])
+	url := asString(server["url"])
+	for name, v := range asMap(server["variables"]) {
+		url = strings.ReplaceAll(url, "{"+name+"}", asString(asMap(v)["default"]))
+	}
+	if url == "" || url == "/" {
+		return defaultBaseURL
+	}
+	return strings.TrimSuffix(url, "/")
+}
+
+// tagDescription returns the description of a top-level tag.
+func (cv *converter) tagDescription(name string) string {
+	tags, _ := cv.doc["tags"].([]any)
+	for _, t := range tags {
+		if m := asMap(t); asString(m["name"]) == name {
+			return asString(m["description"])
+		}
+	}
+	return ""
+}
+
+// operation converts one OpenAPI operation into a request item.
+func (cv *converter) operation(method, path string, pathItem, op map[string]any) Item {
+	name := asString(op["summary"])
+	if name == "" {
+		name = asString(op["operationId"])
+	}
+	if name == "" {
+		name = strings.ToUpper(method) + " " + path
+	}
+
+	req := &Request{
+		Method:      strings.ToUpper(method),
+		Description: asString(op["description"]),
+	}
+	req.URL = cv.url(path, cv.parameters(pathItem, op), req)
+
+	if rb := asMap(cv.resolve(op["requestBody"])); rb != nil {
+		mediaType, media := pickMediaType(asMap(rb["content"]))
+		if mediaType != "" {
+			req.Header = append(req.Header, Header{Key: "Content-Type", Value: mediaType})
+			req.Body = cv.body(mediaType, media)
+		}
+	}
+
+	if reqs, ok := op["security"].([]any); ok {
+		req.Auth = cv.auth(reqs)
+	}
+
+	return Item{
+		Name:     name,
+		Request:  req,
+		Response: cv.responses(asMap(op["responses"]), req),
+	}
+}
+
+// parameters merges path-item and operation parameters; operation entries win.
+func (cv *converter) parameters(pathItem, op map[string]any) []map[string]any {
+	var out []map[string]any
+	index := make(map[string]int)
+	for _, list := range []any{pathItem["parameters"], op["parameters"]} {
+		params, _ := list.([]any)
+		for _, raw := range params {
+			p := asMap(cv.resolve(raw))
+			if p == nil {
+				continue
+			}
+			key := asString(p["in"]) + ":" + asString(p["name"])
+			if i, ok := index[key]; ok {
+				out[i] = p
+				continue
+			}
+			index[key] = len(out)
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// url builds the request URL and adds header parameters to req.
+func (cv *converter) url(path string, params []map[string]any, req *Request) URL {
+	u := URL{Host: []string{"{{baseUrl}}"}}
+
+	byName := make(map[string]map[string]any)
+	for _, p := range params {
+		byName[asString(p["in"])+":"+asString(p["name"])] = p
+	}
+
+	for _, seg := range strings.Split(strings.Trim(path, "/"), "/") {
+		if seg == "" {
+			continue
+		}
+		if strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}") {
+			name := seg[1 : len(seg)-1]
+			p := byName["path:"+name]
+			u.Path = append(u.Path, ":"+name)
+			u.Variable = append(u.Variable, Variable{Key: name, Value: cv.paramValue(p), Description: asString(p["description"])})
+			continue
+		}
+		u.Path = append(u.Path, seg)
+	}
+
+	for _, p := range params {
+		required, _ := p["required"].(bool)
+		switch asString(p["in"]) {
+		case "query":
+			u.Query = append(u.Query, QueryParam{
+				Key:         asString(p["name"]),
+				Value:       cv.paramValue(p),
+				Description: asString(p["description"]),
+				Disabled:    !required,
+			})
+		case "header":
+			req.Header = append(req.Header, Header{
+				Key:         asString(p["name"]),
+				Value:       cv.paramValue(p),
+				Description: asString(p["description"]),
+				Disabled:    !required,
+			})
+		}
+	}
+
+	raw := "{{baseUrl}}/" + strings.Join(u.Path, "/")
+	if len(u.Query) > 0 {
+		pairs := make([]string, 0, len(u.Query))
+		for _, q := range u.Query {
+			if !q.Disabled {
+				pairs = append(pairs, q.Key+"="+q.Value)
+			}
+		}
+		if len(pairs) > 0 {
+			raw += "?" + strings.Join(pairs, "&")
+		}
+	}
+	u.Raw = raw
+	return u
+}
+
+// paramValue returns an example value for a parameter as a string.
+func (cv *converter) paramValue(p map[string]any) string {
+	if p == nil {
+		return ""
+	}
+	v, ok := explicitExample(p)
+	if !ok {
+		v = cv.example(p["schema"], 0, nil)
+	}
+	switch x := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return x
+	case []any:
+		parts := make([]string, 0, len(x))
+		for _, e := range x {
+			parts = append(parts, fmt.Sprint(e))
+		}
+		return strings.Join(parts, ",")
+	default:
+		b, err := json.Marshal(x)
+		if err != nil {
+			return fmt.Sprint(x)
+		}
+		return string(b)
+	}
+}
+
+// body builds a raw request body from a media type object.
+func (cv *converter) body(mediaType string, media map[string]any) *Body {
+	raw, lang := cv.mediaExample(mediaType, media)
+	if raw == "" {
+		return nil
+	}
+	b := &Body{Mode: "raw", Raw: raw}
+	if lang != "" {
+		b.Options = &BodyOptions{Raw: BodyRawOptions{Language: lang}}
+	}
+	return b
+}
+
+// mediaExample renders the example for a media type and the Postman body language.
+func (cv *converter) mediaExample(mediaType string, media map[string]any) (string, string) {
+	v, ok := explicitExample(media)
+	if !ok {
+		if media["schema"] == nil {
+			return "", ""
+		}
+		v = cv.example(media["schema"], 0, nil)
+	}
+
+	if isJSON(mediaType) {
+		b, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return "", ""
+		}
+		return string(b), "json"
+	}
+	if s, ok := v.(string); ok {
+		if strings.Contains(mediaType, "xml") {
+			return s, "xml"
+		}
+		return s, "text"
+	}
+	return "", ""
+}
+
+// responses converts documented responses into saved example responses.
+func (cv *converter) responses(responses map[string]any, req *Request) []Response {
+	var out []Response
+	for _, code := range sortedKeys(responses) {
+		status, err := strconv.Atoi(code)
+		if err != nil {
+			continue // "default" and ranges such as "4XX" have no concrete status
+		}
+		resp := asMap(cv.resolve(responses[code]))
+		name := asString(resp["description"])
+		if name == "" {
+			name = http.StatusText(status)
+		}
+
+		r := Response{
+			Name:            name,
+			OriginalRequest: req,
+			Status:          http.StatusText(status),
+			Code:            status,
+		}
+		if mediaType, media := pickMediaType(asMap(resp["content"])); mediaType != "" {
+			r.Header = []Header{{Key: "Content-Type", Value: mediaType}}
+			r.Body, _ = cv.mediaExample(mediaType, media)
+		}
+		out = append(out, r)
+	}
+	return out
+}
+
+// auth converts the first security requirement into a Postman auth config.
+// An empty requirement list (or an empty requirement) means no auth.
+func (cv *converter) auth(reqs []any) *Auth {
+	if len(reqs) == 0 {
+		return &Auth{Type: "noauth"}
+	}
+	req := asMap(reqs[0])
+	if len(req) == 0 {
+		return &Auth{Type: "noauth"}
+	}
+	schemes := asMap(asMap(cv.doc["components"])["securitySchemes"])
+	for _, name := range sortedKeys(req) {
+		if a := cv.schemeAuth(asMap(cv.resolve(schemes[name]))); a != nil {
+			return a
+		}
+	}
+	return nil
+}
+
+// schemeAuth maps a security scheme to Postman auth, registering the variables it uses.
+func (cv *converter) schemeAuth(scheme map[string]any) *Auth {
+	switch asString(scheme["type"]) {
+	case "http":
+		switch strings.ToLower(asString(scheme["scheme"])) {
+		case "bearer":
+			cv.authVars["bearerToken"] = "Bearer token"
+			return &Auth{Type: "bearer", Bearer: []AuthAttribute{{Key: "token", Value: "{{bearerToken}}", Type: "string"}}}
+		case "basic":
+			cv.authVars["username"] = "Basic auth username"
+			cv.authVars["password"] = "Basic auth password"
+			return &Auth{Type: "basic", Basic: []AuthAttribute{
+				{Key: "username", Value: "{{username}}", Type: "string"},
+				{Key: "password", Value: "{{password}}", Type: "string"},
+			}}
+		}
+	case "apiKey":
+		cv.authVars["apiKey"] = "API key"
+		in := asString(scheme["in"])
+		if in != "query" {
+			in = "header"
+		}
+		return &Auth{Type: "apikey", APIKey: []AuthAttribute{
+			{Key: "key", Value: asString(scheme["name"]), Type: "string"},
+			{Key: "value", Value: "{{apiKey}}", Type: "string"},
+			{Key: "in", Value: in, Type: "string"},
+		}}
+	case "oauth2", "openIdConnect":
+		cv.authVars["accessToken"] = "OAuth 2.0 access token"
+		return &Auth{Type: "oauth2", OAuth2: []AuthAttribute{
+			{Key: "accessToken", Value: "{{accessToken}}", Type: "string"},
+			{Key: "addTokenTo", Value: "header", Type: "string"},
+		}}
+	}
+	return nil
+}
+
+// example synthesizes an example value from a schema.
+// refs tracks the $ref chain to stop on recursive schemas.
+func (cv *converter) example(raw any, depth int, refs []string) any {
+	schema := asMap(raw)
+	if schema == nil || depth > maxExampleDepth {
+		return nil
+	}
+	if ref := asString(schema["$ref"]); ref != "" {
+		if slices.Contains(refs, ref) {
+			return nil
+		}
+		return cv.example(cv.lookup(ref), depth, append(refs, ref))
+	}
+	if v, ok := explicitExample(schema); ok {
+		return v
+	}
+	if v, ok := schema["default"]; ok {
+		return v
+	}
+	if enum, ok := schema["enum"].([]any); ok && len(enum) > 0 {
+		return enum[0]
+	}
+	if v, ok := schema["const"]; ok {
+		return v
+	}
+
+	if all, ok := schema["allOf"].([]any); ok {
+		merged := map[string]any{}
+		for _, sub := range all {
+			if m, ok := cv.example(sub, depth+1, refs).(map[string]any); ok {
+				for k, v := range m {
+					merged[k] = v
+				}
+			}
+		}
+		return merged
+	}
+	for _, key := range []string{"oneOf", "anyOf"} {
+		if subs, ok := schema[key].([]any); ok && len(subs) > 0 {
+			return cv.example(subs[0], depth+1, refs)
+		}
+	}
+
+	switch schemaType(schema) {
+	case "object":
+		obj := map[string]any{}
+		props := asMap(schema["properties"])
+		for _, name := range sortedKeys(props) {
+			if v := cv.example(props[name], depth+1, refs); v != nil {
+				obj[name] = v
+			}
+		}
+		return obj
+	case "array":
+		if item := cv.example(schema["items"], depth+1, refs); item != nil {
+			return []any{item}
+		}
+		return []any{}
+	case "integer":
+		if v, ok := schema["minimum"].(float64); ok {
+			return int64(v)
+		}
+		return 0
+	case "number":
+		if v, ok := schema["minimum"].(float64); ok {
+			return v
+		}
+		return 0.0
+	case "boolean":
+		return true
+	case "string":
+		return stringExample(asString(schema["format"]))
+	}
+	return nil
+}
+
+// lookup resolves a local "#/..." reference.
+func (cv *converter) lookup(ref string) any {
+	if !strings.HasPrefix(ref, "#/") {
+		return nil
+	}
+	var cur any = cv.doc
+	for _, tok := range strings.Split(ref[2:], "/") {
+		tok = strings.ReplaceAll(strings.ReplaceAll(tok, "~1", "/"), "~0", "~")
+		cur = asMap(cur)[tok]
+		if cur == nil {
+			return nil
+		}
+	}
+	return cur
+}
+
+// resolve follows a $ref on a non-schema object (parameter, response, request body).
+func (cv *converter) resolve(raw any) any {
+	for range maxExampleDepth {
+		ref := asString(asMap(raw)["$ref"])
+		if ref == "" {
+			return raw
+		}
+		raw = cv.lookup(ref)
+	}
+	return raw
+}
+
+// explicitExample returns the "example" value or the first entry of "examples".
+func explicitExample(m map[string]any) (any, bool) {
+	if v, ok := m["example"]; ok {
+		return v, true
+	}
+	switch ex := m["examples"].(type) {
+	case map[string]any:
+		for _, key := range sortedKeys(ex) {
+			if v, ok := asMap(ex[key])["value"]; ok {
+				return v, true
+			}
+		}
+	case []any:
+		if len(ex) > 0 {
+			return ex[0], true
+		}
+	}
+	return nil, false
+}
+
+// schemaType returns the schema type, choosing the first non-null entry of 3.1 type arrays.
+func schemaType(schema map[string]any) string {
+	switch t := schema["type"].(type) {
+	case string:
+		return t
+	case []any:
+		for _, e := range t {
+			if s := asString(e); s != "null" {
+				return s
+			}
+		}
+	}
+	if schema["properties"] != nil {
+		return "object"
+	}
+	return ""
+}
+
+// stringExample returns a plausible value for a string format.
+func stringExample(format string) string {
+	switch format {
+	case "email":
+		return "user@example.com"
+	case "uuid":
+		return "00000000-0000-0000-0000-000000000000"
+	case "date-time":
+		return "2025-01-01T00:00:00Z"
+	case "date":
+		return "2025-01-01"
+	case "time":
+		return "00:00:00Z"
+	case "uri", "url":
+		return "https://example.com"
+	case "hostname":
+		return "example.com"
+	case "ipv4":
+		return "192.0.2.1"
+	case "ipv6":
+		return "2001:db8::1"
+	case "binary", "byte":
+		return ""
+	default:
+		return "string"
+	}
+}
+
+// pickMediaType prefers JSON media types, then the first declared type.
+func pickMediaType(content map[string]any) (string, map[string]any) {
+	keys := sortedKeys(content)
+	for _, k := range keys {
+		if isJSON(k) {
+			return k, asMap(content[k])
+		}
+	}
+	if len(keys) > 0 {
+		return keys[0], asMap(content[keys[0]])
+	}
+	return "", nil
+}
+
+// isJSON reports whether a media type is JSON or uses a +json suffix.
+func isJSON(mediaType string) bool {
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+func asMap(v any) map[string]any {
+	m, _ := v.(map[string]any)
+	return m
+}
+
+func asString(v any) string {
+	s, _ := v.(string)
+	return s
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,46 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package postman exports OpenAPI specifications as Postman collections.
+//
+// The converter accepts the serialized document produced by
+// [rivaas.dev/openapi.API.Spec] (or any OpenAPI 3.0.x / 3.1.x JSON document)
+// and produces a Postman Collection v2.1 that QA teams can import directly.
+//
+// # Basic Usage
+//
+//	collection, err := postman.FromAPI(ctx, api)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	data, err := collection.JSON()
+//
+// Or from an existing document:
+//
+//	result, _ := api.Spec(ctx)
+//	collection, err := postman.New(result.JSON, postman.WithBaseURL("https://staging.example.com"))
+//
+// # What Is Exported
+//
+//   - One request per operation, grouped into folders by first tag
+//   - Path parameters as URL variables; query and header parameters with
+//     example values (optional ones disabled)
+//   - Request bodies from declared examples, or synthesized from the schema
+//   - Documented responses as saved examples
+//   - Security schemes as collection or request auth (bearer, basic, API key,
+//     OAuth 2.0), with credentials left as collection variables
+//
+// The server URL is exposed as the {{baseUrl}} collection variable so the
+// same collection can target different environments.
+package postman
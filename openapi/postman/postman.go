@@ -0,0 +1,123 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postman
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"rivaas.dev/openapi"
+)
+
+// ErrInvalidSpec indicates the specification could not be parsed.
+var ErrInvalidSpec = errors.New("openapi/postman: invalid specification")
+
+// config holds conversion configuration.
+type config struct {
+	name    string
+	baseURL string
+	folders bool
+}
+
+// Option configures the conversion using the functional options pattern.
+type Option func(*config)
+
+func defaultConfig() *config {
+	return &config{
+		folders: true,
+	}
+}
+
+// WithName overrides the collection name. Default: the spec's info.title.
+//
+// Example:
+//
+//	postman.New(spec, postman.WithName("Users API (QA)"))
+func WithName(name string) Option {
+	return func(c *config) {
+		c.name = name
+	}
+}
+
+// WithBaseURL sets the initial value of the {{baseUrl}} collection variable.
+// Default: the first server URL in the spec, or "http://localhost:8080".
+//
+// Example:
+//
+//	postman.New(spec, postman.WithBaseURL("https://staging.example.com"))
+func WithBaseURL(url string) Option {
+	return func(c *config) {
+		c.baseURL = url
+	}
+}
+
+// WithFolders controls whether requests are grouped into folders by their first tag.
+// Untagged operations are placed at the collection root. Default: true.
+//
+// Example:
+//
+//	postman.New(spec, postman.WithFolders(false))
+func WithFolders(enabled bool) Option {
+	return func(c *config) {
+		c.folders = enabled
+	}
+}
+
+// New converts a serialized OpenAPI 3.0.x or 3.1.x document (JSON) into a
+// Postman collection.
+//
+// Example:
+//
+//	result, _ := api.Spec(ctx)
+//	collection, err := postman.New(result.JSON)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	data, _ := collection.JSON()
+//	os.WriteFile("collection.json", data, 0o644)
+func New(spec []byte, opts ...Option) (*Collection, error) {
+	cfg := defaultConfig()
+	for i, opt := range opts {
+		if opt == nil {
+			return nil, fmt.Errorf("openapi/postman: option at index %d cannot be nil", i)
+		}
+		opt(cfg)
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal(spec, &doc); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSpec, err)
+	}
+	if _, ok := doc["openapi"].(string); !ok {
+		return nil, fmt.Errorf("%w: missing \"openapi\" field", ErrInvalidSpec)
+	}
+
+	return (&converter{doc: doc, cfg: cfg}).convert(), nil
+}
+
+// FromAPI generates the spec for api and converts it into a Postman collection.
+//
+// Example:
+//
+//	collection, err := postman.FromAPI(ctx, api, postman.WithBaseURL("http://localhost:3000"))
+func FromAPI(ctx context.Context, api *openapi.API, opts ...Option) (*Collection, error) {
+	result, err := api.Spec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return New(result.JSON, opts...)
+}
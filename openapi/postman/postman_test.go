@@ -0,0 +1,194 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postman_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"rivaas.dev/openapi"
+	"rivaas.dev/openapi/postman"
+)
+
+type createUserRequest struct {
+	Name  string `json:"name" example:"Ada"`
+	Email string `json:"email" validate:"required,email"`
+}
+
+type listUsersRequest struct {
+	Limit int    `query:"limit" example:"20"`
+	Trace string `header:"X-Trace-ID"`
+}
+
+type user struct {
+	ID   int    `json:"id" example:"42"`
+	Name string `json:"name" example:"Ada"`
+}
+
+func newAPI(t *testing.T) *openapi.API {
+	t.Helper()
+	return openapi.MustNew(
+		openapi.WithTitle("Users API", "1.0.0"),
+		openapi.WithDescription("Manages users"),
+		openapi.WithServer("https://api.example.com/", "Production"),
+		openapi.WithTag("users", "User operations"),
+		openapi.WithBearerAuth("bearerAuth", "JWT"),
+		openapi.WithAPIKey("apiKey", "X-API-Key", openapi.InHeader, "Key"),
+		openapi.WithDefaultSecurity(openapi.RequireSecurity("bearerAuth")),
+		openapi.WithOperations(
+			mustOp(openapi.WithGET("/users", openapi.WithTags("users"), openapi.WithSummary("List users"),
+				openapi.WithRequest(listUsersRequest{}), openapi.WithResponse(http.StatusOK, []user{}))),
+			mustOp(openapi.WithPOST("/users", openapi.WithTags("users"), openapi.WithSummary("Create user"),
+				openapi.WithSecurity("apiKey"),
+				openapi.WithRequest(createUserRequest{}), openapi.WithResponse(http.StatusCreated, user{}))),
+			mustOp(openapi.WithGET("/users/:id", openapi.WithTags("users"), openapi.WithSummary("Get user"),
+				openapi.WithResponse(http.StatusOK, user{}))),
+			mustOp(openapi.WithGET("/health", openapi.WithSummary("Health"))),
+		),
+	)
+}
+
+func mustOp(op openapi.Operation, err error) openapi.Operation {
+	if err != nil {
+		panic(err)
+	}
+	return op
+}
+
+func findRequest(t *testing.T, items []postman.Item, name string) postman.Item {
+	t.Helper()
+	for _, it := range items {
+		if it.Name == name && it.Request != nil {
+			return it
+		}
+		for _, child := range it.Item {
+			if child.Name == name {
+				return child
+			}
+		}
+	}
+	require.Failf(t, "request not found", "%s", name)
+	return postman.Item{}
+}
+
+func TestFromAPI(t *testing.T) {
+	t.Parallel()
+
+	col, err := postman.FromAPI(t.Context(), newAPI(t))
+	require.NoError(t, err)
+
+	assert.Equal(t, "Users API", col.Info.Name)
+	assert.Equal(t, "Manages users", col.Info.Description)
+	assert.Equal(t, postman.SchemaURL, col.Info.Schema)
+
+	require.NotNil(t, col.Auth)
+	assert.Equal(t, "bearer", col.Auth.Type)
+	assert.Contains(t, col.Variable, postman.Variable{Key: "baseUrl", Value: "https://api.example.com", Type: "string"})
+
+	// Tagged operations are grouped; untagged ones stay at the root.
+	require.Len(t, col.Item, 2)
+	assert.Equal(t, "users", col.Item[0].Name)
+	assert.Equal(t, "User operations", col.Item[0].Description)
+	assert.Len(t, col.Item[0].Item, 3)
+	assert.Equal(t, "Health", col.Item[1].Name)
+
+	t.Run("path variables", func(t *testing.T) {
+		t.Parallel()
+		item := findRequest(t, col.Item, "Get user")
+		assert.Equal(t, "{{baseUrl}}/users/:id", item.Request.URL.Raw)
+		assert.Equal(t, []string{"users", ":id"}, item.Request.URL.Path)
+		require.Len(t, item.Request.URL.Variable, 1)
+		assert.Equal(t, "id", item.Request.URL.Variable[0].Key)
+	})
+
+	t.Run("query and header parameters", func(t *testing.T) {
+		t.Parallel()
+		item := findRequest(t, col.Item, "List users")
+		require.Len(t, item.Request.URL.Query, 1)
+		assert.Equal(t, "limit", item.Request.URL.Query[0].Key)
+		assert.Equal(t, "20", item.Request.URL.Query[0].Value)
+		assert.True(t, item.Request.URL.Query[0].Disabled)
+		require.Len(t, item.Request.Header, 1)
+		assert.Equal(t, "X-Trace-ID", item.Request.Header[0].Key)
+	})
+
+	t.Run("request body and per-operation auth", func(t *testing.T) {
+		t.Parallel()
+		item := findRequest(t, col.Item, "Create user")
+		require.NotNil(t, item.Request.Body)
+		assert.Equal(t, "raw", item.Request.Body.Mode)
+		assert.Equal(t, "json", item.Request.Body.Options.Raw.Language)
+
+		var body map[string]any
+		require.NoError(t, json.Unmarshal([]byte(item.Request.Body.Raw), &body))
+		assert.Equal(t, "Ada", body["name"])
+		assert.Equal(t, "user@example.com", body["email"])
+
+		require.NotNil(t, item.Request.Auth)
+		assert.Equal(t, "apikey", item.Request.Auth.Type)
+		assert.Contains(t, item.Request.Auth.APIKey, postman.AuthAttribute{Key: "key", Value: "X-API-Key", Type: "string"})
+	})
+
+	t.Run("saved responses", func(t *testing.T) {
+		t.Parallel()
+		item := findRequest(t, col.Item, "Get user")
+		require.Len(t, item.Response, 1)
+		assert.Equal(t, http.StatusOK, item.Response[0].Code)
+		assert.Equal(t, "OK", item.Response[0].Status)
+		var body map[string]any
+		require.NoError(t, json.Unmarshal([]byte(item.Response[0].Body), &body))
+		assert.Equal(t, "Ada", body["name"])
+		assert.Contains(t, body, "id")
+	})
+}
+
+func TestNew_Options(t *testing.T) {
+	t.Parallel()
+
+	result, err := newAPI(t).Spec(t.Context())
+	require.NoError(t, err)
+
+	col, err := postman.New(result.JSON,
+		postman.WithName("QA"),
+		postman.WithBaseURL("http://localhost:3000/"),
+		postman.WithFolders(false),
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "QA", col.Info.Name)
+	assert.Contains(t, col.Variable, postman.Variable{Key: "baseUrl", Value: "http://localhost:3000", Type: "string"})
+	assert.Len(t, col.Item, 4)
+
+	data, err := col.JSON()
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"schema": "`+postman.SchemaURL+`"`)
+}
+
+func TestNew_Errors(t *testing.T) {
+	t.Parallel()
+
+	_, err := postman.New([]byte(`{`))
+	require.ErrorIs(t, err, postman.ErrInvalidSpec)
+
+	_, err = postman.New([]byte(`{"swagger":"2.0"}`))
+	require.ErrorIs(t, err, postman.ErrInvalidSpec)
+
+	_, err = postman.New([]byte(`{"openapi":"3.0.4"}`), nil)
+	require.Error(t, err)
+}
@@ -145,6 +145,9 @@ type Context struct {
 	// Abort flag to stop handler chain execution
 	aborted bool // Set to true when Abort() is called
 
+	// Server-Sent Events stream started by SSE, closed on reset
+	sse *SSEStream
+
 	// Error collection: Slice of errors collected during request processing.
 	// Errors are collected via Error() method and can be processed later.
 	errors []error // Lazy initialization - only created when Error() is called
@@ -1421,6 +1424,12 @@ func StreamNDJSON[T any](c *Context, each func(T) error) error {
 // reset resets the context to its initial state for reuse.
 // This method is used for context pooling.
 func (c *Context) reset() {
+	// Stop an SSE heartbeat before the response is cleared
+	if c.sse != nil {
+		c.sse.Close()
+		c.sse = nil
+	}
+
 	// Reset core request fields
 	c.Request = nil
 	c.Response = nil
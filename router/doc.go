@@ -171,6 +171,54 @@
 //   - Wildcards supported: */* and type/*
 //   - Language prefix matching (e.g., "en" matches "en-US")
 //
+// # Static Files
+//
+// [Router.Static], [Router.StaticFS], and [Router.StaticEmbed] serve files with
+// Last-Modified and ETag headers, conditional and Range requests, and
+// index.html for directories. Directory listings are off unless enabled:
+//
+//	r.Static("/assets", "./public",
+//	    router.WithStaticCacheControl(router.WithPublic(), router.WithMaxAge(24*time.Hour)),
+//	)
+//	r.StaticEmbed("/", webAssets, "web/dist")
+//
+// # Server-Sent Events
+//
+// [Context.SSE] starts a text/event-stream response. Events are flushed as
+// they are sent, heartbeats keep idle connections open through proxies, and
+// [SSEStream.Done] reports when the client disconnects:
+//
+//	r.GET("/events", func(c *router.Context) {
+//	    stream, err := c.SSE()
+//	    if err != nil {
+//	        return
+//	    }
+//	    defer stream.Close()
+//
+//	    for {
+//	        select {
+//	        case <-stream.Done():
+//	            return
+//	        case n := <-notifications:
+//	            stream.Send("notification", n)
+//	        }
+//	    }
+//	})
+//
+// # Reverse Proxy
+//
+// [Proxy] returns a handler that forwards requests to an upstream service,
+// for building API gateways. Options rewrite the path, set or remove headers,
+// retry failed idempotent requests, and propagate the trace context;
+// [Context.Proxy] forwards to an upstream chosen per request:
+//
+//	users := router.Proxy("http://users:8080",
+//	    router.WithProxyStripPrefix("/api/users"),
+//	    router.WithProxyRetries(2, 100*time.Millisecond),
+//	    router.WithProxyTraceContext(tracer.InjectTraceContext),
+//	)
+//	r.GET("/api/users/*", users)
+//
 // # Observability
 //
 // OpenTelemetry integration for metrics and tracing:
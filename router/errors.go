@@ -55,4 +55,13 @@ var (
 
 	// ErrQueryInvalidInteger indicates that a query parameter contains an invalid integer.
 	ErrQueryInvalidInteger = errors.New("query: invalid integer")
+
+	// ErrInvalidProxyTarget indicates that a proxy target is not an absolute URL.
+	ErrInvalidProxyTarget = errors.New("invalid proxy target")
+
+	// ErrSSENotSupported indicates that the response writer cannot be flushed, which Server-Sent Events require.
+	ErrSSENotSupported = errors.New("server-sent events not supported: response writer cannot be flushed")
+
+	// ErrSSEClosed indicates that an event was sent on a closed Server-Sent Events stream.
+	ErrSSEClosed = errors.New("server-sent events stream closed")
 )
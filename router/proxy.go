@@ -0,0 +1,388 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+// This file contains the reverse proxy handler and its options.
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ProxyOption defines functional options for [Proxy] and [Context.Proxy].
+type ProxyOption func(*proxyConfig)
+
+// proxyConfig holds the configuration of a reverse proxy.
+type proxyConfig struct {
+	stripPrefix     string
+	rewrite         func(path string) string
+	preserveHost    bool
+	requestHeaders  http.Header
+	removeRequest   []string
+	responseHeaders http.Header
+	removeResponse  []string
+	retries         int
+	retryBackoff    time.Duration
+	transport       http.RoundTripper
+	injectTrace     func(ctx context.Context, header http.Header)
+	errorHandler    func(c *Context, err error)
+}
+
+// WithProxyStripPrefix removes prefix from the request path before it is
+// forwarded, so /api/users behind prefix "/api" reaches the upstream as /users.
+//
+// Example:
+//
+//	r.GET("/api/*", router.Proxy("http://users:8080", router.WithProxyStripPrefix("/api")))
+func WithProxyStripPrefix(prefix string) ProxyOption {
+	return func(cfg *proxyConfig) {
+		cfg.stripPrefix = strings.TrimSuffix(prefix, "/")
+	}
+}
+
+// WithProxyRewrite sets a function that rewrites the request path before it is
+// forwarded. It runs after [WithProxyStripPrefix]; the result is appended to
+// the target's path.
+//
+// Example:
+//
+//	router.WithProxyRewrite(func(path string) string {
+//	    return strings.Replace(path, "/v1/", "/v2/", 1)
+//	})
+func WithProxyRewrite(fn func(path string) string) ProxyOption {
+	return func(cfg *proxyConfig) {
+		cfg.rewrite = fn
+	}
+}
+
+// WithProxyPreserveHost forwards the client's Host header instead of the
+// target's host, for upstreams that route by virtual host.
+func WithProxyPreserveHost() ProxyOption {
+	return func(cfg *proxyConfig) {
+		cfg.preserveHost = true
+	}
+}
+
+// WithProxyRequestHeader sets a header on forwarded requests, replacing any
+// value sent by the client.
+//
+// Example:
+//
+//	router.WithProxyRequestHeader("X-Gateway", "rivaas")
+func WithProxyRequestHeader(key, value string) ProxyOption {
+	return func(cfg *proxyConfig) {
+		if cfg.requestHeaders == nil {
+			cfg.requestHeaders = make(http.Header)
+		}
+		cfg.requestHeaders.Set(key, value)
+	}
+}
+
+// WithProxyRemoveRequestHeaders removes headers from forwarded requests, e.g.
+// credentials that are only meant for the gateway.
+//
+// Example:
+//
+//	router.WithProxyRemoveRequestHeaders("Cookie", "X-Api-Key")
+func WithProxyRemoveRequestHeaders(keys ...string) ProxyOption {
+	return func(cfg *proxyConfig) {
+		cfg.removeRequest = append(cfg.removeRequest, keys...)
+	}
+}
+
+// WithProxyResponseHeader sets a header on responses from the upstream.
+func WithProxyResponseHeader(key, value string) ProxyOption {
+	return func(cfg *proxyConfig) {
+		if cfg.responseHeaders == nil {
+			cfg.responseHeaders = make(http.Header)
+		}
+		cfg.responseHeaders.Set(key, value)
+	}
+}
+
+// WithProxyRemoveResponseHeaders removes headers from responses from the
+// upstream, e.g. Server or X-Powered-By.
+func WithProxyRemoveResponseHeaders(keys ...string) ProxyOption {
+	return func(cfg *proxyConfig) {
+		cfg.removeResponse = append(cfg.removeResponse, keys...)
+	}
+}
+
+// WithProxyRetries retries requests that fail to connect or get a 502, 503,
+// or 504 response up to n times, waiting backoff before the first retry and
+// doubling it after each one. Only requests without a body and with an
+// idempotent method (GET, HEAD, OPTIONS, PUT, DELETE) are retried.
+// Default: no retries
+//
+// Example:
+//
+//	router.WithProxyRetries(2, 100*time.Millisecond)
+func WithProxyRetries(n int, backoff time.Duration) ProxyOption {
+	return func(cfg *proxyConfig) {
+		cfg.retries = max(n, 0)
+		cfg.retryBackoff = max(backoff, 0)
+	}
+}
+
+// WithProxyTransport sets the transport used to reach the upstream.
+// Default: [http.DefaultTransport]
+func WithProxyTransport(rt http.RoundTripper) ProxyOption {
+	return func(cfg *proxyConfig) {
+		cfg.transport = rt
+	}
+}
+
+// WithProxyTraceContext sets a function that injects the trace context of the
+// request into the forwarded headers, so the upstream's spans join the trace
+// of the gateway. Without it, traceparent and tracestate headers sent by the
+// client are forwarded unchanged.
+//
+// Example:
+//
+//	router.WithProxyTraceContext(tracer.InjectTraceContext)
+func WithProxyTraceContext(inject func(ctx context.Context, header http.Header)) ProxyOption {
+	return func(cfg *proxyConfig) {
+		cfg.injectTrace = inject
+	}
+}
+
+// WithProxyErrorHandler sets the function that writes the response when the
+// upstream can't be reached.
+// Default: 504 Gateway Timeout if the request timed out, 502 Bad Gateway otherwise
+func WithProxyErrorHandler(fn func(c *Context, err error)) ProxyOption {
+	return func(cfg *proxyConfig) {
+		cfg.errorHandler = fn
+	}
+}
+
+// proxyContextKey is the request context key of the [Context] being proxied.
+type proxyContextKey struct{}
+
+// Proxy returns a handler that forwards requests to the upstream at target,
+// as an API gateway does. The request path is appended to the target's path,
+// after [WithProxyStripPrefix] and [WithProxyRewrite]. X-Forwarded-For,
+// X-Forwarded-Host, and X-Forwarded-Proto are set, and streamed responses
+// such as Server-Sent Events are flushed as they arrive.
+//
+// Proxy panics if target is not an absolute URL.
+//
+// Example:
+//
+//	users := router.Proxy("http://users:8080",
+//	    router.WithProxyStripPrefix("/api/users"),
+//	    router.WithProxyRetries(2, 100*time.Millisecond),
+//	)
+//	r.GET("/api/users/*", users)
+//	r.POST("/api/users/*", users)
+func Proxy(target string, opts ...ProxyOption) HandlerFunc {
+	rp, err := newReverseProxy(target, opts)
+	if err != nil {
+		panic(err)
+	}
+
+	return func(c *Context) {
+		serveProxy(c, rp)
+	}
+}
+
+// Proxy forwards the request to the upstream at target and writes its
+// response, for handlers that choose the upstream per request. See [Proxy]
+// for how the request is forwarded. It returns an error only if target is
+// not an absolute URL; upstream failures are handled by
+// [WithProxyErrorHandler].
+//
+// Example:
+//
+//	r.GET("/api/*", func(c *router.Context) {
+//	    region := c.Request.Header.Get("X-Region")
+//	    if err := c.Proxy(upstreams[region], router.WithProxyStripPrefix("/api")); err != nil {
+//	        c.WriteErrorResponse(http.StatusInternalServerError, err.Error())
+//	    }
+//	})
+func (c *Context) Proxy(target string, opts ...ProxyOption) error {
+	rp, err := newReverseProxy(target, opts)
+	if err != nil {
+		return err
+	}
+	serveProxy(c, rp)
+
+	return nil
+}
+
+// serveProxy forwards the request of c through rp.
+func serveProxy(c *Context, rp *httputil.ReverseProxy) {
+	req := c.Request.WithContext(context.WithValue(c.Request.Context(), proxyContextKey{}, c))
+	rp.ServeHTTP(c.Response, req)
+}
+
+// newReverseProxy builds the reverse proxy for target.
+func newReverseProxy(target string, opts []ProxyOption) (*httputil.ReverseProxy, error) {
+	u, err := url.Parse(target)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidProxyTarget, err)
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("%w: %q is not an absolute URL", ErrInvalidProxyTarget, target)
+	}
+
+	cfg := &proxyConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	transport := cfg.transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	if cfg.retries > 0 {
+		transport = &retryTransport{base: transport, retries: cfg.retries, backoff: cfg.retryBackoff}
+	}
+
+	return &httputil.ReverseProxy{
+		Rewrite:        cfg.rewriteRequest(u),
+		Transport:      transport,
+		ModifyResponse: cfg.modifyResponse,
+		ErrorHandler:   cfg.handleError,
+	}, nil
+}
+
+// rewriteRequest returns the function that turns the incoming request into
+// the one sent to target.
+func (cfg *proxyConfig) rewriteRequest(target *url.URL) func(*httputil.ProxyRequest) {
+	return func(pr *httputil.ProxyRequest) {
+		path := pr.In.URL.Path
+		if cfg.stripPrefix != "" {
+			if rest, ok := strings.CutPrefix(path, cfg.stripPrefix); ok && (rest == "" || rest[0] == '/') {
+				path = rest
+			}
+		}
+		if cfg.rewrite != nil {
+			path = cfg.rewrite(path)
+		}
+		if path != pr.In.URL.Path {
+			pr.Out.URL.Path, pr.Out.URL.RawPath = path, ""
+		}
+
+		pr.SetURL(target)
+		pr.SetXForwarded()
+		if cfg.preserveHost {
+			pr.Out.Host = pr.In.Host
+		}
+
+		for _, key := range cfg.removeRequest {
+			pr.Out.Header.Del(key)
+		}
+		for key, values := range cfg.requestHeaders {
+			pr.Out.Header[key] = values
+		}
+		if cfg.injectTrace != nil {
+			cfg.injectTrace(pr.In.Context(), pr.Out.Header)
+		}
+	}
+}
+
+// modifyResponse applies the response header options.
+func (cfg *proxyConfig) modifyResponse(resp *http.Response) error {
+	for _, key := range cfg.removeResponse {
+		resp.Header.Del(key)
+	}
+	for key, values := range cfg.responseHeaders {
+		resp.Header[key] = values
+	}
+
+	return nil
+}
+
+// handleError writes the response for a request the upstream didn't answer.
+func (cfg *proxyConfig) handleError(w http.ResponseWriter, r *http.Request, err error) {
+	c, ok := r.Context().Value(proxyContextKey{}).(*Context)
+	if !ok {
+		w.WriteHeader(http.StatusBadGateway)
+		return
+	}
+	if cfg.errorHandler != nil {
+		cfg.errorHandler(c, err)
+		return
+	}
+
+	status := http.StatusBadGateway
+	if errors.Is(err, context.DeadlineExceeded) {
+		status = http.StatusGatewayTimeout
+	}
+	c.WriteErrorResponse(status, http.StatusText(status))
+}
+
+// retryTransport retries idempotent requests that failed at the upstream.
+type retryTransport struct {
+	base    http.RoundTripper
+	retries int
+	backoff time.Duration
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	backoff := t.backoff
+	for attempt := 0; ; attempt++ {
+		resp, err := t.base.RoundTrip(req)
+		if attempt == t.retries || !retryableRequest(req) || !retryableResult(req, resp, err) {
+			return resp, err
+		}
+		if resp != nil {
+			//nolint:errcheck // Draining lets the connection be reused
+			io.Copy(io.Discard, io.LimitReader(resp.Body, 4<<10))
+			resp.Body.Close() //nolint:errcheck,gosec // Discarded response
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+		backoff *= 2
+	}
+}
+
+// retryableRequest reports whether req can be sent again safely.
+func retryableRequest(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return req.Body == nil || req.Body == http.NoBody
+	default:
+		return false
+	}
+}
+
+// retryableResult reports whether the outcome of a request is worth a retry.
+func retryableResult(req *http.Request, resp *http.Response, err error) bool {
+	if err != nil {
+		return req.Context().Err() == nil
+	}
+
+	switch resp.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,235 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// upstreamRequest is what the test upstream saw.
+type upstreamRequest struct {
+	Method string      `json:"method"`
+	Path   string      `json:"path"`
+	Query  string      `json:"query"`
+	Host   string      `json:"host"`
+	Header http.Header `json:"header"`
+}
+
+// newUpstream starts a server that echoes the request it received as JSON.
+func newUpstream(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Server", "upstream")
+		//nolint:errcheck // Test upstream
+		json.NewEncoder(w).Encode(upstreamRequest{
+			Method: r.Method,
+			Path:   r.URL.Path,
+			Query:  r.URL.RawQuery,
+			Host:   r.Host,
+			Header: r.Header,
+		})
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+// proxied sends req through a router that proxies /api/* to the upstream and
+// returns the response and what the upstream saw.
+func proxied(t *testing.T, target string, req *http.Request, opts ...ProxyOption) (*httptest.ResponseRecorder, upstreamRequest) {
+	t.Helper()
+
+	r := MustNew()
+	h := Proxy(target, opts...)
+	r.GET("/api/*", h)
+	r.POST("/api/*", h)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	var seen upstreamRequest
+	if w.Header().Get("Content-Type") == "application/json" {
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &seen))
+	}
+
+	return w, seen
+}
+
+func TestProxy(t *testing.T) {
+	t.Parallel()
+
+	upstream := newUpstream(t)
+
+	t.Run("forwards the request", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodGet, "/api/users?page=2", nil)
+		req.Header.Set("X-Request-ID", "abc")
+		w, seen := proxied(t, upstream.URL+"/base", req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "/base/api/users", seen.Path)
+		assert.Equal(t, "page=2", seen.Query)
+		assert.Equal(t, "abc", seen.Header.Get("X-Request-ID"))
+		assert.Equal(t, "example.com", seen.Header.Get("X-Forwarded-Host"))
+		assert.Equal(t, "http", seen.Header.Get("X-Forwarded-Proto"))
+		assert.Equal(t, "192.0.2.1", seen.Header.Get("X-Forwarded-For"))
+		assert.Equal(t, upstream.Listener.Addr().String(), seen.Host, "the target's host is sent")
+		assert.Equal(t, "upstream", w.Header().Get("Server"))
+	})
+
+	t.Run("path rewriting", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/users", nil)
+		_, seen := proxied(t, upstream.URL, req,
+			WithProxyStripPrefix("/api/"),
+			WithProxyRewrite(func(path string) string { return "/internal" + path }),
+		)
+		assert.Equal(t, "/internal/v1/users", seen.Path)
+
+		req = httptest.NewRequest(http.MethodGet, "/api/v1/users", nil)
+		_, seen = proxied(t, upstream.URL, req, WithProxyStripPrefix("/ap"))
+		assert.Equal(t, "/api/v1/users", seen.Path, "prefixes are stripped at segment boundaries only")
+	})
+
+	t.Run("headers", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodGet, "/api/x", nil)
+		req.Header.Set("Cookie", "session=secret")
+		req.Header.Set("X-Gateway", "spoofed")
+		w, seen := proxied(t, upstream.URL, req,
+			WithProxyRequestHeader("X-Gateway", "rivaas"),
+			WithProxyRemoveRequestHeaders("Cookie"),
+			WithProxyResponseHeader("X-Served-By", "gateway"),
+			WithProxyRemoveResponseHeaders("Server"),
+			WithProxyPreserveHost(),
+		)
+
+		assert.Equal(t, "rivaas", seen.Header.Get("X-Gateway"))
+		assert.Empty(t, seen.Header.Get("Cookie"))
+		assert.Equal(t, "example.com", seen.Host)
+		assert.Equal(t, "gateway", w.Header().Get("X-Served-By"))
+		assert.Empty(t, w.Header().Get("Server"))
+	})
+
+	t.Run("trace context", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodGet, "/api/x", nil)
+		req.Header.Set("Traceparent", "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01")
+		_, seen := proxied(t, upstream.URL, req)
+		assert.Equal(t, "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01", seen.Header.Get("Traceparent"), "forwarded as is by default")
+
+		req = httptest.NewRequest(http.MethodGet, "/api/x", nil)
+		_, seen = proxied(t, upstream.URL, req, WithProxyTraceContext(func(_ context.Context, h http.Header) {
+			h.Set("Traceparent", "00-0af7651916cd43dd8448eb211c80319c-00f067aa0ba902b7-01")
+		}))
+		assert.Equal(t, "00-0af7651916cd43dd8448eb211c80319c-00f067aa0ba902b7-01", seen.Header.Get("Traceparent"))
+	})
+}
+
+func TestProxy_Errors(t *testing.T) {
+	t.Parallel()
+
+	assert.PanicsWithError(t, `invalid proxy target: "users:8080" is not an absolute URL`, func() { Proxy("users:8080") })
+
+	// A closed server refuses connections
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close()
+
+	w, _ := proxied(t, down.URL, httptest.NewRequest(http.MethodGet, "/api/x", nil))
+	assert.Equal(t, http.StatusBadGateway, w.Code)
+
+	var handled error
+	w, _ = proxied(t, down.URL, httptest.NewRequest(http.MethodGet, "/api/x", nil), WithProxyErrorHandler(func(c *Context, err error) {
+		handled = err
+		c.WriteErrorResponse(http.StatusServiceUnavailable, "try later")
+	}))
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Error(t, handled)
+}
+
+func TestProxy_Retries(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	flaky := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(flaky.Close)
+
+	w, _ := proxied(t, flaky.URL, httptest.NewRequest(http.MethodGet, "/api/x", nil), WithProxyRetries(2, time.Millisecond))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, int32(3), calls.Load())
+
+	calls.Store(0)
+	w, _ = proxied(t, flaky.URL, httptest.NewRequest(http.MethodGet, "/api/x", nil), WithProxyRetries(1, time.Millisecond))
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code, "gives up after the last retry")
+	assert.Equal(t, int32(2), calls.Load())
+
+	calls.Store(0)
+	req := httptest.NewRequest(http.MethodPost, "/api/x", nil)
+	w, _ = proxied(t, flaky.URL, req, WithProxyRetries(2, time.Millisecond))
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code, "POST is not retried")
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestContext_Proxy(t *testing.T) {
+	t.Parallel()
+
+	upstream := newUpstream(t)
+
+	r := MustNew()
+	r.GET("/tenants/:id/orders", func(c *Context) {
+		target := upstream.URL
+		if c.Param("id") == "bad" {
+			target = "not a url"
+		}
+		if err := c.Proxy(target, WithProxyStripPrefix("/tenants/"+c.Param("id"))); err != nil {
+			c.WriteErrorResponse(http.StatusInternalServerError, err.Error())
+		}
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/tenants/acme/orders", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	var seen upstreamRequest
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &seen))
+	assert.Equal(t, "/orders", seen.Path)
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/tenants/bad/orders", nil))
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Contains(t, w.Body.String(), "invalid proxy target")
+}
@@ -0,0 +1,377 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+// This file contains Server-Sent Events support for the Context type.
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultSSEHeartbeat is the default interval between keep-alive comments.
+// It is shorter than the idle timeout of most proxies and load balancers.
+const defaultSSEHeartbeat = 15 * time.Second
+
+// SSEOption defines functional options for Server-Sent Events streams.
+type SSEOption func(*sseConfig)
+
+// sseConfig holds the configuration of a Server-Sent Events stream.
+type sseConfig struct {
+	heartbeat time.Duration
+	retry     time.Duration
+}
+
+// WithSSEHeartbeat sets the interval at which keep-alive comments are sent
+// while the stream is idle, so proxies don't close the connection.
+// A zero or negative interval disables heartbeats. Default: 15s.
+//
+// Example:
+//
+//	stream, err := c.SSE(router.WithSSEHeartbeat(30 * time.Second))
+func WithSSEHeartbeat(interval time.Duration) SSEOption {
+	return func(cfg *sseConfig) {
+		cfg.heartbeat = interval
+	}
+}
+
+// WithSSERetry sets the reconnection time sent to the client, which tells the
+// browser how long to wait before reconnecting after the stream ends.
+//
+// Example:
+//
+//	stream, err := c.SSE(router.WithSSERetry(5 * time.Second))
+func WithSSERetry(retry time.Duration) SSEOption {
+	return func(cfg *sseConfig) {
+		cfg.retry = retry
+	}
+}
+
+// SSEEvent is a Server-Sent Event with all optional fields.
+// Use [SSEStream.Send] for events that only have a name and data.
+type SSEEvent struct {
+	// ID sets the last event ID, which the browser sends back in the
+	// Last-Event-ID header when it reconnects.
+	ID string
+
+	// Event is the event name. If empty, clients receive a "message" event.
+	Event string
+
+	// Data is the event payload. Strings and byte slices are sent as is;
+	// other values are encoded as JSON.
+	Data any
+
+	// Retry sets the reconnection time; zero leaves it unchanged.
+	Retry time.Duration
+}
+
+// SSEStream writes Server-Sent Events to a client.
+// Each event is flushed as soon as it is written.
+//
+// SSEStream is safe for concurrent use. Close it before the handler
+// returns; a stream left open is closed when the Context is released.
+type SSEStream struct {
+	c    *Context
+	rc   *http.ResponseController
+	done <-chan struct{}
+
+	mu     sync.Mutex
+	closed bool
+
+	stop      chan struct{}
+	heartbeat sync.WaitGroup
+}
+
+// SSE starts a Server-Sent Events stream. It writes the text/event-stream
+// headers and starts sending heartbeats; use the returned stream to send
+// events until the client disconnects, as reported by [SSEStream.Done].
+//
+// It returns [ErrSSENotSupported], before writing any header, if the response
+// writer cannot be flushed. Close the stream before the handler returns; a
+// stream that is still open is closed when the Context is released, so the
+// heartbeat never writes to a reused Context.
+//
+// Example:
+//
+//	r.GET("/events", func(c *router.Context) {
+//		stream, err := c.SSE()
+//		if err != nil {
+//			c.WriteErrorResponse(http.StatusInternalServerError, err.Error())
+//			return
+//		}
+//		defer stream.Close()
+//
+//		for {
+//			select {
+//			case <-stream.Done():
+//				return
+//			case msg := <-messages:
+//				if err := stream.Send("message", msg); err != nil {
+//					return
+//				}
+//			}
+//		}
+//	})
+func (c *Context) SSE(opts ...SSEOption) (*SSEStream, error) {
+	if c.Response == nil {
+		return nil, ErrContextResponseNil
+	}
+
+	// Checked before any header is written, so the caller can still send an
+	// error response
+	if !flushable(c.Response) {
+		return nil, ErrSSENotSupported
+	}
+
+	cfg := &sseConfig{heartbeat: defaultSSEHeartbeat}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	h := c.Response.Header()
+	h.Set("Content-Type", "text/event-stream")
+	h.Set("Cache-Control", "no-cache")
+	h.Set("Connection", "keep-alive")
+	h.Set("X-Accel-Buffering", "no") // Disable proxy buffering (nginx)
+	h.Del("Content-Length")
+
+	var done <-chan struct{}
+	if c.Request != nil {
+		done = c.Request.Context().Done()
+	}
+
+	s := &SSEStream{
+		c:    c,
+		rc:   http.NewResponseController(c.Response),
+		done: done,
+		stop: make(chan struct{}),
+	}
+
+	c.Response.WriteHeader(http.StatusOK)
+	if cfg.retry > 0 {
+		if _, err := fmt.Fprintf(c.Response, "retry: %d\n\n", cfg.retry.Milliseconds()); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.rc.Flush(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrSSENotSupported, err)
+	}
+
+	if cfg.heartbeat > 0 {
+		s.heartbeat.Go(func() { s.keepAlive(cfg.heartbeat) })
+	}
+	c.sse = s
+
+	return s, nil
+}
+
+// flushable reports whether w, or a writer it wraps, can be flushed. It
+// follows the same Unwrap chain as [http.ResponseController].
+func flushable(w http.ResponseWriter) bool {
+	for {
+		switch t := w.(type) {
+		case interface{ FlushError() error }, http.Flusher:
+			return true
+		case interface{ Unwrap() http.ResponseWriter }:
+			w = t.Unwrap()
+		default:
+			return false
+		}
+	}
+}
+
+// Send sends an event with the given name and data. Strings and byte slices
+// are sent as is; other values are encoded as JSON. An empty event name
+// sends a "message" event.
+//
+// Send returns [ErrSSEClosed] after the stream is closed, or the request
+// context error after the client disconnects.
+//
+// Example:
+//
+//	err := stream.Send("price", map[string]any{"symbol": "ACME", "price": 42.5})
+func (s *SSEStream) Send(event string, data any) error {
+	return s.SendEvent(SSEEvent{Event: event, Data: data})
+}
+
+// SendEvent sends an event with an ID, name, data, and retry time.
+//
+// Example:
+//
+//	err := stream.SendEvent(router.SSEEvent{ID: "42", Event: "update", Data: item})
+func (s *SSEStream) SendEvent(ev SSEEvent) error {
+	payload, err := encodeSSEData(ev.Data)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if ev.ID != "" {
+		writeSSEField(&buf, "id", sanitizeSSEValue(ev.ID))
+	}
+	if ev.Event != "" {
+		writeSSEField(&buf, "event", sanitizeSSEValue(ev.Event))
+	}
+	if ev.Retry > 0 {
+		writeSSEField(&buf, "retry", strconv.FormatInt(ev.Retry.Milliseconds(), 10))
+	}
+	// Each line of the payload needs its own data field
+	for line := range strings.Lines(normalizeSSENewlines(string(payload))) {
+		writeSSEField(&buf, "data", strings.TrimRight(line, "\r\n"))
+	}
+	if len(payload) == 0 {
+		writeSSEField(&buf, "data", "")
+	}
+	buf.WriteByte('\n')
+
+	return s.write(buf.Bytes())
+}
+
+// Comment sends a comment line, which clients ignore. It can be used as a
+// custom keep-alive.
+func (s *SSEStream) Comment(text string) error {
+	var buf bytes.Buffer
+	for line := range strings.Lines(normalizeSSENewlines(text)) {
+		buf.WriteString(": ")
+		buf.WriteString(strings.TrimRight(line, "\r\n"))
+		buf.WriteByte('\n')
+	}
+	if text == "" {
+		buf.WriteString(":\n")
+	}
+	buf.WriteByte('\n')
+
+	return s.write(buf.Bytes())
+}
+
+// Done returns a channel that is closed when the client disconnects.
+func (s *SSEStream) Done() <-chan struct{} {
+	return s.done
+}
+
+// Close stops the heartbeat and marks the stream as closed.
+// It does not close the connection; return from the handler to end the response.
+// Close is idempotent.
+func (s *SSEStream) Close() {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return
+	}
+	s.closed = true
+	close(s.stop)
+	s.mu.Unlock()
+
+	s.heartbeat.Wait()
+}
+
+// keepAlive sends a comment every interval until the stream is closed or the
+// client disconnects.
+func (s *SSEStream) keepAlive(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-s.done:
+			return
+		case <-ticker.C:
+			if err := s.write([]byte(":\n\n")); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// write writes and flushes b unless the stream is closed or the client is gone.
+func (s *SSEStream) write(b []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return ErrSSEClosed
+	}
+	if err := s.clientErr(); err != nil {
+		return err
+	}
+
+	if _, err := s.c.Response.Write(b); err != nil {
+		return err
+	}
+
+	return s.rc.Flush()
+}
+
+// clientErr returns the request context error once the client disconnects.
+func (s *SSEStream) clientErr() error {
+	select {
+	case <-s.done:
+		if s.c.Request != nil {
+			return s.c.Request.Context().Err()
+		}
+
+		return context.Canceled
+	default:
+		return nil
+	}
+}
+
+// encodeSSEData converts event data to its wire representation.
+func encodeSSEData(data any) ([]byte, error) {
+	switch v := data.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []byte(v), nil
+	case []byte:
+		return v, nil
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("encode SSE data: %w", err)
+		}
+
+		return b, nil
+	}
+}
+
+// writeSSEField writes a single "name: value" line.
+func writeSSEField(buf *bytes.Buffer, name, value string) {
+	buf.WriteString(name)
+	buf.WriteString(": ")
+	buf.WriteString(value)
+	buf.WriteByte('\n')
+}
+
+// sanitizeSSEValue strips line breaks, which would end the field early and
+// allow injecting other fields.
+func sanitizeSSEValue(v string) string {
+	return strings.NewReplacer("\r", "", "\n", "").Replace(v)
+}
+
+// normalizeSSENewlines converts CRLF and lone CR line endings to LF. A lone CR
+// ends a line in an event stream too, so it must not pass through unsplit.
+func normalizeSSENewlines(v string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(v, "\r\n", "\n"), "\r", "\n")
+}
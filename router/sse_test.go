@@ -0,0 +1,290 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package router
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// syncRecorder is a flushable response writer that is safe to read while the
+// heartbeat goroutine writes.
+type syncRecorder struct {
+	mu      sync.Mutex
+	header  http.Header
+	body    strings.Builder
+	flushes int
+}
+
+func newSyncRecorder() *syncRecorder {
+	return &syncRecorder{header: make(http.Header)}
+}
+
+func (r *syncRecorder) Header() http.Header { return r.header }
+func (r *syncRecorder) WriteHeader(int)     {}
+
+func (r *syncRecorder) Write(b []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.body.Write(b)
+}
+
+func (r *syncRecorder) Flush() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.flushes++
+}
+
+func (r *syncRecorder) String() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.body.String()
+}
+
+// plainWriter is a response writer that cannot be flushed.
+type plainWriter struct {
+	header http.Header
+}
+
+func (w *plainWriter) Header() http.Header         { return w.header }
+func (w *plainWriter) Write(b []byte) (int, error) { return len(b), nil }
+func (w *plainWriter) WriteHeader(int)             {}
+
+func newSSEContext(t *testing.T, w http.ResponseWriter) (*Context, context.CancelFunc) {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(t.Context())
+	req := httptest.NewRequest(http.MethodGet, "/events", nil).WithContext(ctx)
+
+	return NewContext(w, req), cancel
+}
+
+func TestContext_SSE(t *testing.T) {
+	t.Parallel()
+
+	t.Run("writes stream headers", func(t *testing.T) {
+		t.Parallel()
+
+		w := httptest.NewRecorder()
+		c, cancel := newSSEContext(t, w)
+		defer cancel()
+
+		stream, err := c.SSE(WithSSEHeartbeat(0))
+		require.NoError(t, err)
+		defer stream.Close()
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "text/event-stream", w.Header().Get("Content-Type"))
+		assert.Equal(t, "no-cache", w.Header().Get("Cache-Control"))
+		assert.Equal(t, "no", w.Header().Get("X-Accel-Buffering"))
+		assert.True(t, w.Flushed)
+	})
+
+	t.Run("sends retry", func(t *testing.T) {
+		t.Parallel()
+
+		w := httptest.NewRecorder()
+		c, cancel := newSSEContext(t, w)
+		defer cancel()
+
+		stream, err := c.SSE(WithSSEHeartbeat(0), WithSSERetry(3*time.Second))
+		require.NoError(t, err)
+		defer stream.Close()
+
+		assert.Equal(t, "retry: 3000\n\n", w.Body.String())
+	})
+
+	t.Run("unsupported writer", func(t *testing.T) {
+		t.Parallel()
+
+		c, cancel := newSSEContext(t, &plainWriter{header: make(http.Header)})
+		defer cancel()
+
+		_, err := c.SSE()
+		require.ErrorIs(t, err, ErrSSENotSupported)
+		assert.Empty(t, c.Response.Header().Get("Content-Type"), "headers are not committed")
+	})
+
+	t.Run("error response after unsupported writer", func(t *testing.T) {
+		t.Parallel()
+
+		r := MustNew()
+		r.GET("/events", func(c *Context) {
+			if _, err := c.SSE(); err != nil {
+				c.WriteErrorResponse(http.StatusInternalServerError, err.Error())
+			}
+		})
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(struct{ http.ResponseWriter }{w}, httptest.NewRequest(http.MethodGet, "/events", nil))
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+
+	t.Run("released context closes the stream", func(t *testing.T) {
+		t.Parallel()
+
+		var stream *SSEStream
+		r := MustNew()
+		r.GET("/events", func(c *Context) {
+			var err error
+			stream, err = c.SSE(WithSSEHeartbeat(time.Millisecond))
+			assert.NoError(t, err)
+			// No Close before returning
+		})
+
+		w := newSyncRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/events", nil))
+		require.NotNil(t, stream)
+		require.ErrorIs(t, stream.Send("x", "y"), ErrSSEClosed)
+	})
+}
+
+func TestSSEStream_Send(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		event string
+		data  any
+		want  string
+	}{
+		{name: "string", event: "greeting", data: "hello", want: "event: greeting\ndata: hello\n\n"},
+		{name: "unnamed", data: "hello", want: "data: hello\n\n"},
+		{name: "bytes", data: []byte("raw"), want: "data: raw\n\n"},
+		{name: "multi-line", data: "line1\nline2\r\nline3", want: "data: line1\ndata: line2\ndata: line3\n\n"},
+		{name: "lone carriage return", data: "x\revent: admin\rdata: y", want: "data: x\ndata: event: admin\ndata: data: y\n\n"},
+		{name: "json", event: "user", data: map[string]any{"id": 1}, want: "event: user\ndata: {\"id\":1}\n\n"},
+		{name: "nil", event: "ping", want: "event: ping\ndata: \n\n"},
+		{name: "event name injection", event: "a\ndata: evil", data: "x", want: "event: adata: evil\ndata: x\n\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			w := httptest.NewRecorder()
+			c, cancel := newSSEContext(t, w)
+			defer cancel()
+
+			stream, err := c.SSE(WithSSEHeartbeat(0))
+			require.NoError(t, err)
+			defer stream.Close()
+
+			require.NoError(t, stream.Send(tt.event, tt.data))
+			assert.Equal(t, tt.want, w.Body.String())
+		})
+	}
+}
+
+func TestSSEStream_SendEvent(t *testing.T) {
+	t.Parallel()
+
+	w := httptest.NewRecorder()
+	c, cancel := newSSEContext(t, w)
+	defer cancel()
+
+	stream, err := c.SSE(WithSSEHeartbeat(0))
+	require.NoError(t, err)
+	defer stream.Close()
+
+	err = stream.SendEvent(SSEEvent{ID: "42", Event: "update", Data: "x", Retry: time.Second})
+	require.NoError(t, err)
+	assert.Equal(t, "id: 42\nevent: update\nretry: 1000\ndata: x\n\n", w.Body.String())
+
+	require.ErrorContains(t, stream.Send("bad", func() {}), "encode SSE data")
+}
+
+func TestSSEStream_Comment(t *testing.T) {
+	t.Parallel()
+
+	w := httptest.NewRecorder()
+	c, cancel := newSSEContext(t, w)
+	defer cancel()
+
+	stream, err := c.SSE(WithSSEHeartbeat(0))
+	require.NoError(t, err)
+	defer stream.Close()
+
+	require.NoError(t, stream.Comment("hello\nworld\revent: evil"))
+	assert.Equal(t, ": hello\n: world\n: event: evil\n\n", w.Body.String())
+}
+
+func TestSSEStream_Close(t *testing.T) {
+	t.Parallel()
+
+	w := httptest.NewRecorder()
+	c, cancel := newSSEContext(t, w)
+	defer cancel()
+
+	stream, err := c.SSE()
+	require.NoError(t, err)
+
+	stream.Close()
+	stream.Close() // idempotent
+
+	require.ErrorIs(t, stream.Send("x", "y"), ErrSSEClosed)
+}
+
+func TestSSEStream_ClientDisconnect(t *testing.T) {
+	t.Parallel()
+
+	w := httptest.NewRecorder()
+	c, cancel := newSSEContext(t, w)
+
+	stream, err := c.SSE(WithSSEHeartbeat(0))
+	require.NoError(t, err)
+	defer stream.Close()
+
+	cancel()
+
+	select {
+	case <-stream.Done():
+	case <-time.After(time.Second):
+		t.Fatal("Done was not closed after the client disconnected")
+	}
+	require.ErrorIs(t, stream.Send("x", "y"), context.Canceled)
+}
+
+func TestSSEStream_Heartbeat(t *testing.T) {
+	t.Parallel()
+
+	w := newSyncRecorder()
+	c, cancel := newSSEContext(t, w)
+	defer cancel()
+
+	stream, err := c.SSE(WithSSEHeartbeat(5 * time.Millisecond))
+	require.NoError(t, err)
+
+	assert.Eventually(t, func() bool {
+		return strings.Count(w.String(), ":\n\n") >= 2
+	}, time.Second, 5*time.Millisecond)
+
+	stream.Close()
+	sent := w.String()
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, sent, w.String(), "no heartbeat after Close")
+}
@@ -15,16 +15,80 @@
 package router
 
 import (
+	"crypto/sha256"
 	"embed"
+	"encoding/hex"
 	"fmt"
+	"io"
 	"io/fs"
 	"net/http"
+	"path"
+	"strconv"
 	"strings"
+	"sync"
 )
 
+// StaticOption defines functional options for static file serving.
+type StaticOption func(*staticConfig)
+
+// staticConfig holds the configuration for static file serving.
+type staticConfig struct {
+	listing      bool
+	etag         bool
+	cacheControl []CacheControlOption
+}
+
+// WithDirectoryListing enables or disables directory listings for directories
+// without an index.html. Listings are disabled by default, and such
+// directories return 404. Previous releases listed them; pass true to keep
+// that behavior.
+//
+// Example:
+//
+//	r.Static("/downloads", "./downloads", router.WithDirectoryListing(true))
+func WithDirectoryListing(enabled bool) StaticOption {
+	return func(cfg *staticConfig) {
+		cfg.listing = enabled
+	}
+}
+
+// WithStaticETag enables or disables ETag headers on served files, which let
+// clients revalidate with If-None-Match and receive 304 Not Modified.
+// Enabled by default. The ETag is derived from the modification time and
+// size, or from a hash of the content for files without a modification time,
+// such as those in an embed.FS.
+//
+// Example:
+//
+//	r.Static("/assets", "./public", router.WithStaticETag(false))
+func WithStaticETag(enabled bool) StaticOption {
+	return func(cfg *staticConfig) {
+		cfg.etag = enabled
+	}
+}
+
+// WithStaticCacheControl sets the Cache-Control header on served files.
+// By default no Cache-Control header is set.
+//
+// Example:
+//
+//	r.Static("/assets", "./public",
+//	    router.WithStaticCacheControl(router.WithPublic(), router.WithMaxAge(24*time.Hour)),
+//	)
+func WithStaticCacheControl(opts ...CacheControlOption) StaticOption {
+	return func(cfg *staticConfig) {
+		cfg.cacheControl = opts
+	}
+}
+
 // Static serves static files from the filesystem under the given URL prefix.
 // The relativePath is the URL prefix, and root is the filesystem directory.
 // This creates file serving routes with proper caching headers.
+// See [Router.StaticFS] for the features and options.
+//
+// Changed from previous releases: directories without an index.html are no
+// longer listed and return 404. Pass WithDirectoryListing(true) to keep the
+// listings.
 //
 // SECURITY: This method uses http.FileServer which automatically prevents
 // path traversal attacks (e.g., "../../../etc/passwd"). The http.Dir implementation
@@ -37,19 +101,26 @@ import (
 //
 //	r.Static("/assets", "./public")      // Serve ./public/* at /assets/*
 //	r.Static("/uploads", "/var/uploads") // Serve /var/uploads/* at /uploads/*
-func (r *Router) Static(relativePath, root string) {
-	r.StaticFS(relativePath, http.Dir(root))
+func (r *Router) Static(relativePath, root string, opts ...StaticOption) {
+	r.StaticFS(relativePath, http.Dir(root), opts...)
 }
 
 // StaticFS serves static files from the given http.FileSystem under the URL prefix.
 // This provides more control over the file system implementation.
 // Registers both GET and HEAD routes per HTTP/1.1 requirements (RFC 7231).
 //
+// Files are served with Last-Modified and ETag headers, and conditional
+// (If-None-Match, If-Modified-Since) and Range requests are supported.
+// Directories serve their index.html; see [WithDirectoryListing],
+// [WithStaticETag], and [WithStaticCacheControl] for the options. Unlike
+// previous releases, directories without an index.html are not listed unless
+// WithDirectoryListing(true) is passed.
+//
 // Example:
 //
 //	r.StaticFS("/assets", http.Dir("./public"))
 //	r.StaticFS("/files", customFileSystem)
-func (r *Router) StaticFS(relativePath string, fs http.FileSystem) {
+func (r *Router) StaticFS(relativePath string, fs http.FileSystem, opts ...StaticOption) {
 	if len(relativePath) == 0 {
 		panic("relativePath cannot be empty")
 	}
@@ -66,10 +137,38 @@ func (r *Router) StaticFS(relativePath string, fs http.FileSystem) {
 		}
 	}
 
+	cfg := &staticConfig{etag: true}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	served := fs
+	if !cfg.listing {
+		served = noListingFS{fs}
+	}
+
 	// Create a file server handler
-	fileServer := http.StripPrefix(strings.TrimSuffix(relativePath, "/*"), http.FileServer(fs))
+	prefix := strings.TrimSuffix(relativePath, "/*")
+	fileServer := http.StripPrefix(prefix, http.FileServer(served))
+	etags := &staticETags{fs: fs}
 
 	handler := func(c *Context) {
+		if cfg.etag || len(cfg.cacheControl) > 0 {
+			name := path.Clean("/" + strings.TrimPrefix(c.Request.URL.Path, prefix))
+			if info, file, ok := statStaticFile(fs, name); ok {
+				if cfg.etag {
+					if etag := etags.get(file, info); etag != "" {
+						c.Header("ETag", etag)
+					}
+				}
+				if len(cfg.cacheControl) > 0 {
+					c.CacheControl(cfg.cacheControl...)
+				}
+			}
+		}
+
+		// http.FileServer handles Range and the conditional headers,
+		// including If-None-Match against the ETag set above
 		fileServer.ServeHTTP(c.Response, c.Request)
 	}
 
@@ -131,10 +230,100 @@ func (r *Router) StaticFile(relativePath, filepath string) {
 //	var publicFS embed.FS
 //
 //	r.StaticEmbed("/public", publicFS, "public")
-func (r *Router) StaticEmbed(relativePath string, embedFS embed.FS, subdir string) {
+func (r *Router) StaticEmbed(relativePath string, embedFS embed.FS, subdir string, opts ...StaticOption) {
 	subFS, err := fs.Sub(embedFS, subdir)
 	if err != nil {
 		panic(fmt.Sprintf("StaticEmbed: invalid subdirectory %q: %v", subdir, err))
 	}
-	r.StaticFS(relativePath, http.FS(subFS))
+	r.StaticFS(relativePath, http.FS(subFS), opts...)
+}
+
+// statStaticFile returns the file info and name of the file served for name:
+// the file itself, or the index.html of a directory.
+func statStaticFile(fsys http.FileSystem, name string) (fs.FileInfo, string, bool) {
+	info, err := statFile(fsys, name)
+	if err != nil {
+		return nil, "", false
+	}
+	if info.IsDir() {
+		name = path.Join(name, "index.html")
+		if info, err = statFile(fsys, name); err != nil || info.IsDir() {
+			return nil, "", false
+		}
+	}
+
+	return info, name, true
+}
+
+// statFile returns the file info of name in fsys.
+func statFile(fsys http.FileSystem, name string) (fs.FileInfo, error) {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close() //nolint:errcheck // read-only file
+
+	return f.Stat()
+}
+
+// noListingFS is an http.FileSystem that hides directories without an
+// index.html, so http.FileServer returns 404 instead of a listing.
+type noListingFS struct {
+	http.FileSystem
+}
+
+// Open opens name, failing with fs.ErrNotExist for directories without an index.html.
+func (nfs noListingFS) Open(name string) (http.File, error) {
+	f, err := nfs.FileSystem.Open(name)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		f.Close() //nolint:errcheck,gosec // already failing
+		return nil, err
+	}
+	if info.IsDir() {
+		if _, err := statFile(nfs.FileSystem, path.Join(name, "index.html")); err != nil {
+			f.Close() //nolint:errcheck,gosec // hidden directory
+			return nil, fs.ErrNotExist
+		}
+	}
+
+	return f, nil
+}
+
+// staticETags computes ETags for static files.
+// Content hashes are cached, since files without a modification time
+// (embed.FS) never change.
+type staticETags struct {
+	fs     http.FileSystem
+	hashes sync.Map // map[string]string
+}
+
+// get returns the ETag for the named file, or "" if it cannot be computed.
+func (e *staticETags) get(name string, info fs.FileInfo) string {
+	if !info.ModTime().IsZero() {
+		return `W/"` + strconv.FormatInt(info.ModTime().UnixNano(), 16) + "-" + strconv.FormatInt(info.Size(), 16) + `"`
+	}
+
+	if etag, ok := e.hashes.Load(name); ok {
+		return etag.(string) //nolint:errcheck,forcetypeassert // only strings are stored
+	}
+
+	f, err := e.fs.Open(name)
+	if err != nil {
+		return ""
+	}
+	defer f.Close() //nolint:errcheck // read-only file
+
+	h := sha256.New()
+	if _, err = io.Copy(h, f); err != nil {
+		return ""
+	}
+	etag := `"` + hex.EncodeToString(h.Sum(nil)[:16]) + `"`
+	e.hashes.Store(name, etag)
+
+	return etag
 }
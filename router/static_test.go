@@ -23,7 +23,9 @@ import (
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -152,3 +154,146 @@ func TestStaticEmbed(t *testing.T) {
 		assert.Equal(t, http.StatusNotFound, w.Code)
 	})
 }
+
+// newStaticDir creates a directory with a file, a directory with an index.html,
+// and a directory without one.
+func newStaticDir(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "app.js"), []byte("console.log('hello');"), 0o600))
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "site"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "site", "index.html"), []byte("<h1>Site</h1>"), 0o600))
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "files"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "files", "a.txt"), []byte("a"), 0o600))
+
+	return dir
+}
+
+// TestStatic_Caching tests ETag, conditional requests, and Cache-Control
+func TestStatic_Caching(t *testing.T) {
+	t.Parallel()
+
+	dir := newStaticDir(t)
+
+	t.Run("ETag and 304 Not Modified", func(t *testing.T) {
+		t.Parallel()
+		r := MustNew()
+		r.Static("/static", dir)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/static/app.js", nil))
+		require.Equal(t, http.StatusOK, w.Code)
+		etag := w.Header().Get("ETag")
+		assert.True(t, strings.HasPrefix(etag, `W/"`), "weak ETag for files with a modification time: %s", etag)
+		assert.NotEmpty(t, w.Header().Get("Last-Modified"))
+
+		req := httptest.NewRequest(http.MethodGet, "/static/app.js", nil)
+		req.Header.Set("If-None-Match", etag)
+		w = httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotModified, w.Code)
+		assert.Empty(t, w.Body.String())
+	})
+
+	t.Run("ETag disabled", func(t *testing.T) {
+		t.Parallel()
+		r := MustNew()
+		r.Static("/static", dir, WithStaticETag(false))
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/static/app.js", nil))
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, w.Header().Get("ETag"))
+	})
+
+	t.Run("content hash ETag for embedded files", func(t *testing.T) {
+		t.Parallel()
+		r := MustNew()
+		r.StaticEmbed("/embedded", testEmbedFS, "testdata/embed")
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/embedded/hello.txt", nil))
+		require.Equal(t, http.StatusOK, w.Code)
+		etag := w.Header().Get("ETag")
+		assert.Regexp(t, `^"[0-9a-f]{32}"$`, etag)
+
+		req := httptest.NewRequest(http.MethodGet, "/embedded/hello.txt", nil)
+		req.Header.Set("If-None-Match", etag)
+		w = httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotModified, w.Code)
+	})
+
+	t.Run("Cache-Control", func(t *testing.T) {
+		t.Parallel()
+		r := MustNew()
+		r.Static("/static", dir, WithStaticCacheControl(WithPublic(), WithMaxAge(time.Hour)))
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/static/app.js", nil))
+		assert.Equal(t, "public, max-age=3600", w.Header().Get("Cache-Control"))
+
+		w = httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/static/missing.js", nil))
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Empty(t, w.Header().Get("Cache-Control"), "not set on missing files")
+	})
+
+	t.Run("Range request", func(t *testing.T) {
+		t.Parallel()
+		r := MustNew()
+		r.Static("/static", dir)
+
+		req := httptest.NewRequest(http.MethodGet, "/static/app.js", nil)
+		req.Header.Set("Range", "bytes=0-6")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusPartialContent, w.Code)
+		assert.Equal(t, "console", w.Body.String())
+	})
+}
+
+// TestStatic_Directories tests index files and directory listings
+func TestStatic_Directories(t *testing.T) {
+	t.Parallel()
+
+	dir := newStaticDir(t)
+
+	t.Run("serves index.html", func(t *testing.T) {
+		t.Parallel()
+		r := MustNew()
+		r.Static("/static", dir)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/static/site/", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "<h1>Site</h1>", w.Body.String())
+		assert.NotEmpty(t, w.Header().Get("ETag"))
+	})
+
+	t.Run("listing disabled by default", func(t *testing.T) {
+		t.Parallel()
+		r := MustNew()
+		r.Static("/static", dir)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/static/files/", nil))
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		w = httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/static/files/a.txt", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("listing enabled", func(t *testing.T) {
+		t.Parallel()
+		r := MustNew()
+		r.Static("/static", dir, WithDirectoryListing(true))
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/static/files/", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "a.txt")
+	})
+}
@@ -0,0 +1,130 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// carrierPropagator serializes trace context into a [Carrier]. The format is
+// fixed, whatever the tracer's propagator, so that carriers written by one
+// service can be read by any other.
+var carrierPropagator = propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+
+// Carrier holds a serialized span context and baggage, in W3C Trace Context
+// and W3C Baggage format, for work that outlives the request, such as a
+// message on a queue or a background job. It marshals to JSON as an object
+// of strings.
+//
+// Example:
+//
+//	type Job struct {
+//	    OrderID string          `json:"order_id"`
+//	    Trace   tracing.Carrier `json:"trace"`
+//	}
+//
+//	queue.Publish(Job{OrderID: id, Trace: tracing.NewCarrier(ctx)})
+type Carrier map[string]string
+
+var _ propagation.TextMapCarrier = Carrier(nil)
+
+// NewCarrier serializes the span context and baggage of ctx. The carrier is
+// empty if ctx has no valid span context.
+func NewCarrier(ctx context.Context) Carrier {
+	carrier := Carrier{}
+	carrierPropagator.Inject(ctx, carrier)
+
+	return carrier
+}
+
+// Get implements [propagation.TextMapCarrier].
+func (c Carrier) Get(key string) string {
+	return c[key]
+}
+
+// Set implements [propagation.TextMapCarrier].
+func (c Carrier) Set(key, value string) {
+	c[key] = value
+}
+
+// Keys implements [propagation.TextMapCarrier].
+func (c Carrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+
+	return keys
+}
+
+// SpanContext returns the serialized span context, which is invalid if the
+// carrier is empty or malformed. Use it to link spans with [Tracer.StartLinkedSpan].
+func (c Carrier) SpanContext() trace.SpanContext {
+	return trace.SpanContextFromContext(carrierPropagator.Extract(context.Background(), c))
+}
+
+// ContinueFrom returns a copy of ctx with the span context and baggage of the
+// carrier, so that spans started from it continue the trace of the request
+// that created the carrier. ctx is returned unchanged if the carrier holds no
+// valid span context.
+//
+// Use ContinueFrom when the work is part of the request, such as a job the
+// client waits for, and [Tracer.StartLinkedSpan] when it is separate work.
+//
+// Example:
+//
+//	func handle(ctx context.Context, job Job) {
+//	    ctx = tracing.ContinueFrom(ctx, job.Trace)
+//	    ctx, span := tracer.StartSpan(ctx, "process-order")
+//	    defer tracer.FinishSpan(span)
+//	    ...
+//	}
+func ContinueFrom(ctx context.Context, carrier Carrier) context.Context {
+	if len(carrier) == 0 {
+		return ctx
+	}
+
+	return carrierPropagator.Extract(ctx, carrier)
+}
+
+// StartLinkedSpan starts a span in a new trace, with links to the given span
+// contexts, such as those of the requests that queued the messages processed
+// by a batch consumer. Invalid span contexts are skipped. Like [Tracer.StartSpan],
+// it returns a non-recording span if tracing is disabled.
+//
+// Example:
+//
+//	links := make([]trace.SpanContext, 0, len(jobs))
+//	for _, job := range jobs {
+//	    links = append(links, job.Trace.SpanContext())
+//	}
+//	ctx, span := tracer.StartLinkedSpan(ctx, "process-batch", links,
+//	    trace.WithSpanKind(trace.SpanKindConsumer),
+//	)
+//	defer tracer.FinishSpan(span)
+func (t *Tracer) StartLinkedSpan(ctx context.Context, name string, linked []trace.SpanContext, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	links := make([]trace.Link, 0, len(linked))
+	for _, sc := range linked {
+		if sc.IsValid() {
+			links = append(links, trace.Link{SpanContext: sc})
+		}
+	}
+	opts = append(opts, trace.WithNewRoot(), trace.WithLinks(links...))
+
+	return t.StartSpan(ctx, name, opts...) //nolint:spancheck // span is returned to caller who manages its lifecycle
+}
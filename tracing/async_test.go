@@ -0,0 +1,100 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestCarrier_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	ctx, err := SetBaggage(remoteContext(t), "tenant.id", "acme")
+	require.NoError(t, err)
+
+	data, err := json.Marshal(NewCarrier(ctx))
+	require.NoError(t, err)
+	var carrier Carrier
+	require.NoError(t, json.Unmarshal(data, &carrier))
+
+	sc := carrier.SpanContext()
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", sc.TraceID().String())
+	assert.Equal(t, "00f067aa0ba902b7", sc.SpanID().String())
+	assert.True(t, sc.IsSampled())
+
+	continued := ContinueFrom(context.Background(), carrier)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", trace.SpanContextFromContext(continued).TraceID().String())
+	assert.Equal(t, "acme", GetBaggage(continued, "tenant.id"))
+}
+
+func TestCarrier_Empty(t *testing.T) {
+	t.Parallel()
+
+	carrier := NewCarrier(context.Background())
+	assert.Empty(t, carrier)
+	assert.False(t, carrier.SpanContext().IsValid())
+
+	type key struct{}
+	ctx := context.WithValue(context.Background(), key{}, "value")
+	assert.Equal(t, ctx, ContinueFrom(ctx, carrier))
+	assert.Equal(t, ctx, ContinueFrom(ctx, nil))
+}
+
+func TestContinueFrom_ChildSpan(t *testing.T) {
+	t.Parallel()
+
+	tracer, recorder := newRecordingTracer(t)
+	ctx := ContinueFrom(context.Background(), NewCarrier(remoteContext(t)))
+	_, span := tracer.StartSpan(ctx, "process-order")
+	tracer.FinishSpan(span)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spans[0].SpanContext().TraceID().String())
+	assert.Equal(t, "00f067aa0ba902b7", spans[0].Parent().SpanID().String())
+}
+
+func TestStartLinkedSpan(t *testing.T) {
+	t.Parallel()
+
+	tracer, recorder := newRecordingTracer(t)
+	parentCtx, parent := tracer.StartSpan(context.Background(), "request")
+	linked := NewCarrier(remoteContext(t)).SpanContext()
+
+	_, span := tracer.StartLinkedSpan(parentCtx, "process-batch",
+		[]trace.SpanContext{linked, {}},
+		trace.WithSpanKind(trace.SpanKindConsumer),
+	)
+	tracer.FinishSpan(span)
+	tracer.FinishSpan(parent)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	batch := spans[0]
+	assert.Equal(t, "process-batch", batch.Name())
+	assert.Equal(t, trace.SpanKindConsumer, batch.SpanKind())
+	assert.False(t, batch.Parent().IsValid(), "linked span starts a new trace")
+	assert.NotEqual(t, spans[1].SpanContext().TraceID(), batch.SpanContext().TraceID())
+	require.Len(t, batch.Links(), 1, "invalid span contexts are skipped")
+	assert.Equal(t, linked.TraceID(), batch.Links()[0].SpanContext.TraceID())
+}
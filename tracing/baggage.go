@@ -0,0 +1,113 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// attrPrefixBaggage is the prefix of span attributes copied from baggage.
+const attrPrefixBaggage = "baggage."
+
+// SetBaggage returns a copy of ctx with the W3C Baggage entry key set to
+// value. Baggage travels with the request to every downstream service that
+// the trace context is propagated to, so keep entries small and free of
+// sensitive data.
+// Returns an error if key or value is not valid baggage.
+//
+// Example:
+//
+//	ctx, err := tracing.SetBaggage(ctx, "tenant.id", tenantID)
+//	if err != nil {
+//	    return err
+//	}
+//	tracer.InjectTraceContext(ctx, outReq.Header)
+func SetBaggage(ctx context.Context, key, value string) (context.Context, error) {
+	member, err := baggage.NewMemberRaw(key, value)
+	if err != nil {
+		return ctx, fmt.Errorf("tracing: invalid baggage entry %q: %w", key, err)
+	}
+	bag, err := baggage.FromContext(ctx).SetMember(member)
+	if err != nil {
+		return ctx, fmt.Errorf("tracing: invalid baggage entry %q: %w", key, err)
+	}
+
+	return baggage.ContextWithBaggage(ctx, bag), nil
+}
+
+// GetBaggage returns the value of the W3C Baggage entry key in ctx, or an
+// empty string if there is none.
+//
+// Example:
+//
+//	tenantID := tracing.GetBaggage(r.Context(), "tenant.id")
+func GetBaggage(ctx context.Context, key string) string {
+	return baggage.FromContext(ctx).Member(key).Value()
+}
+
+// DeleteBaggage returns a copy of ctx without the W3C Baggage entry key,
+// so it isn't propagated further.
+//
+// Example:
+//
+//	ctx = tracing.DeleteBaggage(ctx, "debug.session")
+func DeleteBaggage(ctx context.Context, key string) context.Context {
+	bag := baggage.FromContext(ctx)
+	if bag.Member(key).Key() == "" {
+		return ctx
+	}
+
+	return baggage.ContextWithBaggage(ctx, bag.DeleteMember(key))
+}
+
+// withBaggagePropagation returns propagator, extended to propagate W3C
+// Baggage if it doesn't already.
+func withBaggagePropagation(propagator propagation.TextMapPropagator) propagation.TextMapPropagator {
+	if propagator == nil {
+		return propagation.Baggage{}
+	}
+	if slices.Contains(propagator.Fields(), "baggage") {
+		return propagator
+	}
+
+	return propagation.NewCompositeTextMapPropagator(propagator, propagation.Baggage{})
+}
+
+// baggageAttributes returns the span attributes for the baggage entries
+// keys in ctx.
+func baggageAttributes(ctx context.Context, keys []string) []attribute.KeyValue {
+	if len(keys) == 0 {
+		return nil
+	}
+	bag := baggage.FromContext(ctx)
+	if bag.Len() == 0 {
+		return nil
+	}
+
+	var attrs []attribute.KeyValue
+	for _, key := range keys {
+		if member := bag.Member(key); member.Key() != "" {
+			attrs = append(attrs, attribute.String(attrPrefixBaggage+key, member.Value()))
+		}
+	}
+
+	return attrs
+}
@@ -0,0 +1,129 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestSetBaggage(t *testing.T) {
+	t.Parallel()
+
+	ctx, err := SetBaggage(context.Background(), "tenant.id", "acme")
+	require.NoError(t, err)
+	ctx, err = SetBaggage(ctx, "user.tier", "gold plan")
+	require.NoError(t, err)
+	assert.Equal(t, "acme", GetBaggage(ctx, "tenant.id"))
+	assert.Equal(t, "gold plan", GetBaggage(ctx, "user.tier"))
+	assert.Empty(t, GetBaggage(ctx, "missing"))
+
+	// Replacing an entry
+	ctx, err = SetBaggage(ctx, "tenant.id", "globex")
+	require.NoError(t, err)
+	assert.Equal(t, "globex", GetBaggage(ctx, "tenant.id"))
+
+	deleted := DeleteBaggage(ctx, "tenant.id")
+	assert.Empty(t, GetBaggage(deleted, "tenant.id"))
+	assert.Equal(t, "gold plan", GetBaggage(deleted, "user.tier"))
+	assert.Equal(t, "globex", GetBaggage(ctx, "tenant.id"), "the original context is unchanged")
+	assert.Equal(t, ctx, DeleteBaggage(ctx, "missing"))
+
+	_, err = SetBaggage(context.Background(), "", "value")
+	require.Error(t, err)
+	assert.Empty(t, GetBaggage(context.Background(), "tenant.id"))
+}
+
+func TestBaggage_Propagation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		propagator propagation.TextMapPropagator
+	}{
+		{name: "trace context only", propagator: propagation.TraceContext{}},
+		{name: "with baggage", propagator: propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tracer := TestingTracer(t, WithCustomPropagator(tt.propagator))
+
+			var got string
+			handler := MustMiddleware(tracer)(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				got = GetBaggage(r.Context(), "tenant.id")
+			}))
+			req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/", nil)
+			req.Header.Set("baggage", "tenant.id=acme,other=1")
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+			assert.Equal(t, "acme", got, "incoming baggage is extracted")
+
+			ctx, err := SetBaggage(t.Context(), "tenant.id", "globex")
+			require.NoError(t, err)
+			headers := http.Header{}
+			tracer.InjectTraceContext(ctx, headers)
+			assert.Equal(t, "tenant.id=globex", headers.Get("baggage"), "outgoing baggage is injected")
+		})
+	}
+}
+
+func TestWithBaggageSpanAttributes(t *testing.T) {
+	t.Parallel()
+	recorder := tracetest.NewSpanRecorder()
+	tracer, err := New(
+		WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))),
+		WithCustomPropagator(propagation.TraceContext{}),
+		WithBaggageSpanAttributes("tenant.id", "user.tier"),
+	)
+	require.NoError(t, err)
+
+	handler := MustMiddleware(tracer)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/orders", nil)
+	req.Header.Set("baggage", "tenant.id=acme,session=secret")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	// Request spans started by frameworks such as app
+	ctx, err := SetBaggage(t.Context(), "user.tier", "gold")
+	require.NoError(t, err)
+	_, span := tracer.StartRequestSpan(ctx, httptest.NewRequest(http.MethodGet, "/users", nil), "/users", false)
+	tracer.FinishRequestSpan(span, http.StatusOK)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	attrs := func(i int) map[attribute.Key]string {
+		m := make(map[attribute.Key]string)
+		for _, kv := range spans[i].Attributes() {
+			m[kv.Key] = kv.Value.Emit()
+		}
+		return m
+	}
+	assert.Equal(t, "acme", attrs(0)["baggage.tenant.id"])
+	assert.NotContains(t, attrs(0), attribute.Key("baggage.session"), "only selected keys are recorded")
+	assert.Equal(t, "gold", attrs(1)["baggage.user.tier"])
+}
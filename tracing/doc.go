@@ -42,6 +42,7 @@
 //   - WithStdout(): Prints traces to stdout (for development/testing)
 //   - WithOTLP(endpoint): Sends traces to OTLP collector via gRPC (for production)
 //   - WithOTLPHTTP(endpoint): Sends traces to OTLP collector via HTTP
+//   - WithZipkin(endpoint): Sends traces to a Zipkin collector that doesn't accept OTLP
 //
 // # OTLP and Start
 //
@@ -71,6 +72,9 @@
 //	}
 //	http.ListenAndServe(":8080", handler(mux))
 //
+// WithTraceResponse and WithServerTiming write the trace ID of each sampled
+// request to the response, so clients can report it along with errors.
+//
 // # Custom Spans
 //
 // Create and manage spans using the provided methods:
@@ -114,6 +118,19 @@
 //	    doAsyncWork(ctx)
 //	}()
 //
+// # Queues and background jobs
+//
+// For work that outlives the request, such as queue messages,
+// NewCarrier serializes the span context and baggage. The consumer either
+// continues the trace with ContinueFrom, or starts a new trace linked to one
+// or more requests with StartLinkedSpan:
+//
+//	msg.Trace = tracing.NewCarrier(ctx) // Producer
+//
+//	ctx = tracing.ContinueFrom(ctx, msg.Trace) // Consumer, same trace
+//	ctx, span := tracer.StartLinkedSpan(ctx, "process-batch",
+//	    []trace.SpanContext{msg.Trace.SpanContext()}) // Consumer, new linked trace
+//
 // # WithSpan
 //
 // Run a function under a span; the span is finished with success or error based on
@@ -140,6 +157,33 @@
 //	ctx = tracer.ExtractTraceContext(ctx, req.Header)
 //	tracer.InjectTraceContext(ctx, resp.Header)
 //
+// NewTransport and NewClient trace outgoing requests with client spans and
+// inject the trace context for you:
+//
+//	client := tracing.NewClient(tracer)
+//	req, _ := http.NewRequestWithContext(ctx, "GET", "https://api.example.com/users", nil)
+//	resp, err := client.Do(req)
+//
+// W3C Trace Context is the usual format. WithPropagators selects other formats,
+// such as B3 for Zipkin and Istio or Jaeger, and combines several of them:
+//
+//	tracer := tracing.MustNew(
+//	    tracing.WithPropagators(tracing.PropagatorTraceContext, tracing.PropagatorB3Multi),
+//	)
+//
+// # Baggage
+//
+// W3C Baggage carries key-value pairs such as a tenant ID along with the trace
+// context. It is always extracted by the middleware and injected by
+// InjectTraceContext, whatever the configured propagator:
+//
+//	ctx, err := tracing.SetBaggage(ctx, "tenant.id", tenantID)
+//	tenantID := tracing.GetBaggage(ctx, "tenant.id")
+//	ctx = tracing.DeleteBaggage(ctx, "tenant.id")
+//
+// WithBaggageSpanAttributes records selected entries on request spans as
+// "baggage.{key}" attributes, and logging.WithBaggageKeys adds them to logs.
+//
 // # Sampling
 //
 // Control which requests are traced using sampling:
@@ -149,6 +193,17 @@
 //	    tracing.WithSampleRate(0.1), // Sample 10% of requests
 //	)
 //
+// WithSampler replaces the rate with a custom Sampler, such as a SamplerFunc
+// that looks at the request. The middleware can also sample routes at their
+// own rate, and trace unsampled requests that end with an error:
+//
+//	tracer := tracing.MustNew(tracing.WithSampleRate(0.01)) // 1% elsewhere
+//
+//	handler := tracing.MustMiddleware(tracer,
+//	    tracing.WithRouteSampleRate("/checkout/*", 1.0),
+//	    tracing.WithAlwaysSampleStatus(http.StatusInternalServerError),
+//	)(mux)
+//
 // # Thread Safety
 //
 // All methods are thread-safe. The Tracer struct is immutable after creation,
@@ -179,6 +234,19 @@
 //	}
 //	// ... use handler(mux)
 //
+// # Span Processors and Hooks
+//
+// Add span processors, or hooks that enrich every span, to the tracer
+// provider built by the package:
+//
+//	tracer := tracing.MustNew(
+//	    tracing.WithOTLP("localhost:4317"),
+//	    tracing.WithSpanProcessor(sdktrace.NewSimpleSpanProcessor(auditExporter)),
+//	    tracing.WithOnSpanStart(func(ctx context.Context, span sdktrace.ReadWriteSpan) {
+//	        span.SetAttributes(attribute.String("tenant.id", tenantFromContext(ctx)))
+//	    }),
+//	)
+//
 // # Custom Tracer Provider
 //
 // For advanced use cases, provide your own OpenTelemetry tracer provider:
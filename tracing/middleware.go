@@ -24,6 +24,7 @@ import (
 	"regexp"
 	"slices"
 	"strings"
+	"time"
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
@@ -49,9 +50,20 @@ type middlewareConfig struct {
 	recordParams     bool            // Whether to record URL params
 	recordParamsList []string        // Whitelist of params to record (nil = all)
 	excludeParams    map[string]bool // Blacklist of params to exclude
+	routeSamplers    []routeSampler  // Per-route sampling, first match wins
+	alwaysSampleMin  int             // Trace unsampled requests with at least this status (0 = off)
+	traceResponse    bool            // Write the traceresponse header
+	serverTiming     bool            // Write a Server-Timing traceparent entry
 	validationErrors []error         // Errors collected during option application
 }
 
+// routeSampler samples requests to a path, or to paths with a prefix.
+type routeSampler struct {
+	path    string
+	prefix  bool
+	sampler Sampler
+}
+
 // newMiddlewareConfig creates a default middleware configuration.
 func newMiddlewareConfig() *middlewareConfig {
 	return &middlewareConfig{
@@ -247,6 +259,128 @@ func WithoutParams() MiddlewareOption {
 	}
 }
 
+// WithRouteSampleRate samples requests to path at the given rate (0.0 to 1.0),
+// instead of with the tracer's sampler. A path ending in "*" matches every
+// path with that prefix. When several routes match, the first one added wins.
+// Rates outside [0.0, 1.0] cause a validation error.
+//
+// Example:
+//
+//	tracer := tracing.MustNew(tracing.WithSampleRate(0.01)) // 1% elsewhere
+//
+//	handler := tracing.MustMiddleware(tracer,
+//	    tracing.WithRouteSampleRate("/checkout/*", 1.0),
+//	    tracing.WithRouteSampleRate("/search", 0.1),
+//	)(mux)
+func WithRouteSampleRate(path string, rate float64) MiddlewareOption {
+	return func(c *middlewareConfig) {
+		if rate < 0.0 || rate > 1.0 {
+			c.validationErrors = append(c.validationErrors,
+				fmt.Errorf("routeSampleRate: rate for %q must be between 0.0 and 1.0, got %f", path, rate))
+
+			return
+		}
+		rs := routeSampler{path: path, sampler: RateSampler(rate)}
+		if trimmed, ok := strings.CutSuffix(path, "*"); ok {
+			rs.path, rs.prefix = trimmed, true
+		}
+		c.routeSamplers = append(c.routeSamplers, rs)
+	}
+}
+
+// WithAlwaysSampleStatus traces requests that weren't sampled when their
+// response status is minStatus or higher, such as 500 for server errors.
+// The request span is then created when the response is complete, with the
+// start time of the request. Spans started by the handler follow the
+// sampling decision made when the request arrived, so the span has no
+// children. minStatus must be between 100 and 599.
+//
+// Example:
+//
+//	handler := tracing.MustMiddleware(tracer,
+//	    tracing.WithAlwaysSampleStatus(http.StatusInternalServerError),
+//	)(mux)
+func WithAlwaysSampleStatus(minStatus int) MiddlewareOption {
+	return func(c *middlewareConfig) {
+		if minStatus < 100 || minStatus > 599 {
+			c.validationErrors = append(c.validationErrors,
+				fmt.Errorf("alwaysSampleStatus: must be between 100 and 599, got %d", minStatus))
+
+			return
+		}
+		c.alwaysSampleMin = minStatus
+	}
+}
+
+// WithTraceResponse writes the trace context of the request span to the
+// traceresponse response header, in the format of the traceparent header:
+//
+//	traceresponse: 00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01
+//
+// Clients and support tooling can then reference the exact trace of a
+// failed request. The header is only written for sampled requests. Browsers
+// only let scripts read it from other origins when it's listed in the
+// Access-Control-Expose-Headers CORS header.
+//
+// Example:
+//
+//	handler := tracing.MustMiddleware(tracer, tracing.WithTraceResponse())(mux)
+func WithTraceResponse() MiddlewareOption {
+	return func(c *middlewareConfig) {
+		c.traceResponse = true
+	}
+}
+
+// WithServerTiming adds the trace context of the request span to the
+// Server-Timing response header, which browser developer tools show and
+// the Resource Timing API exposes to scripts:
+//
+//	Server-Timing: traceparent;desc="00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+//
+// The entry is only added for sampled requests, next to entries set by
+// other middleware or the handler. For other origins, browsers require the
+// Timing-Allow-Origin header.
+//
+// Example:
+//
+//	handler := tracing.MustMiddleware(tracer, tracing.WithServerTiming())(mux)
+func WithServerTiming() MiddlewareOption {
+	return func(c *middlewareConfig) {
+		c.serverTiming = true
+	}
+}
+
+// writeTraceHeaders writes the trace response headers selected by
+// WithTraceResponse and WithServerTiming.
+func (c *middlewareConfig) writeTraceHeaders(w http.ResponseWriter, span trace.Span) {
+	if !c.traceResponse && !c.serverTiming {
+		return
+	}
+	sc := span.SpanContext()
+	if !sc.IsValid() {
+		return
+	}
+	traceparent := "00-" + sc.TraceID().String() + "-" + sc.SpanID().String() + "-" + sc.TraceFlags().String()
+	if c.traceResponse {
+		w.Header().Set("traceresponse", traceparent)
+	}
+	if c.serverTiming {
+		w.Header().Add("Server-Timing", `traceparent;desc="`+traceparent+`"`)
+	}
+}
+
+// shouldSample makes the head sampling decision for a request, with the
+// first matching route sampler or else the tracer's sampler.
+func (c *middlewareConfig) shouldSample(t *Tracer, req *http.Request) bool {
+	for _, rs := range c.routeSamplers {
+		if rs.path == req.URL.Path || (rs.prefix && strings.HasPrefix(req.URL.Path, rs.path)) {
+			return rs.sampler.ShouldSample(req)
+		}
+	}
+
+	return t.shouldSample(req)
+}
+
 // Middleware creates a middleware function for standalone HTTP integration.
 // This is useful when you want to add tracing to an existing router
 // without using the app package.
@@ -300,8 +434,17 @@ func Middleware(tracer *Tracer, opts ...MiddlewareOption) (func(http.Handler) ht
 				return
 			}
 
+			// Extract trace context from headers
+			ctx := tracer.ExtractTraceContext(r.Context(), r.Header)
+
+			if !cfg.shouldSample(tracer, r) {
+				serveUnsampled(tracer, cfg, w, r.WithContext(ctx), next)
+				return
+			}
+
 			// Start tracing with middleware-specific attribute recording
-			ctx, span := startMiddlewareSpan(r.Context(), tracer, cfg, r)
+			ctx, span := startMiddlewareSpan(ctx, tracer, cfg, r)
+			cfg.writeTraceHeaders(w, span)
 
 			// Wrap response writer to capture status code
 			// Check if already wrapped to prevent double-wrapping
@@ -325,6 +468,30 @@ func Middleware(tracer *Tracer, opts ...MiddlewareOption) (func(http.Handler) ht
 	}, nil
 }
 
+// serveUnsampled serves a request that wasn't sampled. With
+// WithAlwaysSampleStatus, the request span is created afterwards when the
+// response status is high enough.
+func serveUnsampled(t *Tracer, cfg *middlewareConfig, w http.ResponseWriter, req *http.Request, next http.Handler) {
+	if cfg.alwaysSampleMin == 0 {
+		next.ServeHTTP(w, req)
+		return
+	}
+	if _, ok := w.(observabilityWrappedWriter); ok {
+		// Already wrapped, the status can't be extracted
+		next.ServeHTTP(w, req)
+		return
+	}
+
+	start := time.Now()
+	rw := newResponseWriter(w)
+	next.ServeHTTP(rw, req)
+
+	if status := rw.StatusCode(); status >= cfg.alwaysSampleMin {
+		_, span := startMiddlewareSpan(req.Context(), t, cfg, req, trace.WithTimestamp(start))
+		t.FinishRequestSpan(span, status)
+	}
+}
+
 // MustMiddleware creates a middleware function for standalone HTTP integration.
 // It panics with an error if tracer is nil or any middleware option is invalid (e.g., nil option, invalid regex pattern).
 // Callers that recover from the panic get an error they can unwrap with errors.Is/errors.As.
@@ -352,22 +519,8 @@ func MustMiddleware(tracer *Tracer, opts ...MiddlewareOption) func(http.Handler)
 }
 
 // startMiddlewareSpan starts a span for HTTP request with middleware configuration.
-func startMiddlewareSpan(ctx context.Context, t *Tracer, cfg *middlewareConfig, req *http.Request) (context.Context, trace.Span) {
-	// Extract trace context from headers
-	ctx = t.ExtractTraceContext(ctx, req.Header)
-
-	// Sampling decision
-	if t.sampleRate < 1.0 {
-		if t.sampleRate == 0.0 {
-			return ctx, trace.SpanFromContext(ctx)
-		}
-		counter := t.samplingCounter.Add(1)
-		hash := counter * samplingMultiplier
-		if hash > t.samplingThreshold {
-			return ctx, trace.SpanFromContext(ctx)
-		}
-	}
-
+// ctx must carry the trace context extracted from the request headers.
+func startMiddlewareSpan(ctx context.Context, t *Tracer, cfg *middlewareConfig, req *http.Request, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
 	// Build span name
 	var spanName string
 	sb, ok := t.spanNamePool.Get().(*strings.Builder)
@@ -385,7 +538,8 @@ func startMiddlewareSpan(ctx context.Context, t *Tracer, cfg *middlewareConfig,
 		t.logOtlpNotStartedWarning()
 	}
 	// Start span
-	ctx, span := t.tracer.Start(ctx, spanName, trace.WithSpanKind(trace.SpanKindServer))
+	opts = append(opts, trace.WithSpanKind(trace.SpanKindServer))
+	ctx, span := t.tracer.Start(ctx, spanName, opts...)
 
 	// Prepare attributes
 	attrs := make([]attribute.KeyValue, 0, 9+len(cfg.recordHeaders))
@@ -421,6 +575,9 @@ func startMiddlewareSpan(ctx context.Context, t *Tracer, cfg *middlewareConfig,
 		}
 	}
 
+	// Record selected baggage entries if configured
+	attrs = append(attrs, baggageAttributes(ctx, t.baggageSpanAttributes)...)
+
 	span.SetAttributes(attrs...)
 
 	// Invoke span start hook if configured
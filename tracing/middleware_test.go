@@ -317,7 +317,7 @@ func TestMustMiddleware_ValidOptionsSucceeds(t *testing.T) {
 	assert.Equal(t, http.StatusOK, w.Code)
 }
 
-// TestMiddleware_ProbabilisticSamplingSkipsSomeSpans covers the probabilistic skip branch in startMiddlewareSpan.
+// TestMiddleware_ProbabilisticSamplingSkipsSomeSpans covers the probabilistic skip branch of the middleware.
 func TestMiddleware_ProbabilisticSamplingSkipsSomeSpans(t *testing.T) {
 	t.Parallel()
 
@@ -579,8 +579,8 @@ func TestMiddleware_BasicFunctionality(t *testing.T) {
 	t.Run("enabled tracer with 0% sample rate does not create span", func(t *testing.T) {
 		t.Parallel()
 
-		// Tracer is enabled (default) but samples 0% of requests; startMiddlewareSpan
-		// is still called and returns early without creating a span.
+		// Tracer is enabled (default) but samples 0% of requests; the middleware
+		// skips the span and still calls the handler.
 		tracer := TestingTracer(t, WithSampleRate(0))
 		middleware := MustMiddleware(tracer)
 
@@ -1125,3 +1125,57 @@ func (m *mockWrappedWriter) IsObservabilityWrapped() bool {
 func (m *mockWrappedWriter) Unwrap() http.ResponseWriter {
 	return m.ResponseWriter
 }
+
+func TestMiddleware_TraceResponseHeaders(t *testing.T) {
+	t.Parallel()
+
+	tracer, recorder := newRecordingTracer(t)
+	handler := MustMiddleware(tracer,
+		WithTraceResponse(),
+		WithServerTiming(),
+	)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Add("Server-Timing", "db;dur=53")
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/orders", nil))
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	sc := spans[0].SpanContext()
+	want := "00-" + sc.TraceID().String() + "-" + sc.SpanID().String() + "-01"
+	assert.Equal(t, want, w.Header().Get("traceresponse"))
+	assert.Equal(t, []string{`traceparent;desc="` + want + `"`, "db;dur=53"}, w.Header().Values("Server-Timing"))
+}
+
+func TestMiddleware_TraceResponseHeaders_NotSampled(t *testing.T) {
+	t.Parallel()
+
+	tracer := TestingTracer(t, WithSampleRate(0))
+	handler := MustMiddleware(tracer,
+		WithTraceResponse(),
+		WithServerTiming(),
+	)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/orders", nil))
+
+	assert.Empty(t, w.Header().Get("traceresponse"))
+	assert.Empty(t, w.Header().Get("Server-Timing"))
+}
+
+func TestMiddleware_TraceResponseHeaders_Disabled(t *testing.T) {
+	t.Parallel()
+
+	handler := MustMiddleware(TestingTracer(t))(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/orders", nil))
+
+	assert.Empty(t, w.Header().Get("traceresponse"), "headers are opt-in")
+}
@@ -21,6 +21,7 @@ import (
 	"net/http"
 
 	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -37,13 +38,20 @@ type config struct {
 	serviceVersion        string
 	sampleRate            float64
 	samplingThreshold     uint64 // Set in validate() from sampleRate
+	sampler               Sampler
+	samplerSet            bool
 	tracer                trace.Tracer
 	propagator            propagation.TextMapPropagator
 	logger                *slog.Logger
 	spanStartHook         SpanStartHook
 	spanFinishHook        SpanFinishHook
+	spanProcessors        []sdktrace.SpanProcessor
+	onSpanStart           OnSpanStartFunc
+	onSpanEnd             OnSpanEndFunc
+	baggageSpanAttributes []string
 	provider              Provider
 	otlpEndpoint          string
+	zipkinEndpoint        string
 	otlpEndpointDefaulted bool // True when endpoint was empty and set to default in validate()
 	otlpInsecure          bool
 	providerSet           bool
@@ -137,6 +145,24 @@ func WithSampleRate(rate float64) Option {
 	}
 }
 
+// WithSampler sets a custom [Sampler] that decides which requests are traced,
+// for example by route. It replaces the rate of [WithSampleRate].
+// A nil sampler causes a validation error at tracer creation.
+//
+// Example:
+//
+//	tracer := tracing.MustNew(
+//	    tracing.WithSampler(tracing.SamplerFunc(func(req *http.Request) bool {
+//	        return req.Header.Get("X-Debug-Trace") != ""
+//	    })),
+//	)
+func WithSampler(sampler Sampler) Option {
+	return func(c *config) {
+		c.sampler = sampler
+		c.samplerSet = true
+	}
+}
+
 // WithCustomTracer allows using a custom OpenTelemetry tracer.
 // This is useful when you need specific tracer configuration or
 // want to use a tracer from an existing OpenTelemetry setup.
@@ -154,7 +180,9 @@ func WithCustomTracer(tracer trace.Tracer) Option {
 
 // WithCustomPropagator allows using a custom OpenTelemetry propagator.
 // This is useful for custom trace context propagation formats.
-// By default, uses the global propagator from otel.GetTextMapPropagator().
+// By default, uses the global propagator from otel.GetTextMapPropagator(),
+// or W3C Trace Context if no global propagator is set.
+// W3C Baggage is always propagated, also if the propagator doesn't include it.
 //
 // Example:
 //
@@ -166,6 +194,31 @@ func WithCustomPropagator(propagator propagation.TextMapPropagator) Option {
 	}
 }
 
+// WithPropagators sets the formats used to propagate trace context to and
+// from other services. Outgoing requests carry every format, and incoming
+// requests are read with each format in order, so a later format wins when
+// several headers are present. Use several formats to talk to legacy Zipkin
+// or Istio services while moving to W3C Trace Context. Unknown or missing
+// formats cause a validation error at tracer creation.
+// It replaces the propagator of [WithCustomPropagator]; the last one set wins.
+// W3C Baggage is always propagated, also if it isn't listed.
+//
+// Example:
+//
+//	tracer := tracing.MustNew(
+//	    tracing.WithPropagators(tracing.PropagatorTraceContext, tracing.PropagatorB3Multi),
+//	)
+func WithPropagators(propagators ...Propagator) Option {
+	return func(c *config) {
+		propagator, err := newCompositePropagator(propagators)
+		if err != nil {
+			c.validationErrors = append(c.validationErrors, err)
+			return
+		}
+		c.propagator = propagator
+	}
+}
+
 // WithLogger sets the logger for internal operational events (errors, warnings, info, debug).
 // Internal events are logged at the appropriate slog level. If logger is nil or WithLogger is not called,
 // a discard logger is used and no internal output is produced.
@@ -218,6 +271,84 @@ func WithSpanFinishHook(hook SpanFinishHook) Option {
 	}
 }
 
+// WithSpanProcessor adds span processors to the tracer provider built by
+// the tracer, for example to forward spans to a second exporter. They run
+// in order, after the hooks of WithOnSpanStart and WithOnSpanEnd and before
+// the exporter of the provider. Processors are shut down with the tracer. They can't be combined with
+// WithTracerProvider, which brings its own processors; that causes a
+// validation error at tracer creation. A nil processor causes a validation error.
+//
+// Example:
+//
+//	tracer := tracing.MustNew(
+//	    tracing.WithOTLP("localhost:4317"),
+//	    tracing.WithSpanProcessor(sdktrace.NewSimpleSpanProcessor(auditExporter)),
+//	)
+func WithSpanProcessor(processors ...sdktrace.SpanProcessor) Option {
+	return func(c *config) {
+		for i, processor := range processors {
+			if processor == nil {
+				c.validationErrors = append(c.validationErrors, fmt.Errorf("spanProcessor: processor at index %d cannot be nil", i))
+				continue
+			}
+			c.spanProcessors = append(c.spanProcessors, processor)
+		}
+	}
+}
+
+// WithOnSpanStart sets a hook called when any span of the tracer starts,
+// not only request spans, to enrich it with attributes from the context,
+// such as the tenant, the user tier, or the request ID. Like
+// [WithSpanProcessor], it can't be combined with WithTracerProvider.
+//
+// Example:
+//
+//	tracer := tracing.MustNew(
+//	    tracing.WithOnSpanStart(func(ctx context.Context, span sdktrace.ReadWriteSpan) {
+//	        if tenantID := tenantFromContext(ctx); tenantID != "" {
+//	            span.SetAttributes(attribute.String("tenant.id", tenantID))
+//	        }
+//	    }),
+//	)
+func WithOnSpanStart(fn OnSpanStartFunc) Option {
+	return func(c *config) {
+		c.onSpanStart = fn
+	}
+}
+
+// WithOnSpanEnd sets a hook called when any span of the tracer ends, before
+// it is exported, for example to count slow spans. Like [WithSpanProcessor],
+// it can't be combined with WithTracerProvider.
+//
+// Example:
+//
+//	tracer := tracing.MustNew(
+//	    tracing.WithOnSpanEnd(func(span sdktrace.ReadOnlySpan) {
+//	        if span.EndTime().Sub(span.StartTime()) > time.Second {
+//	            slowSpans.Add(ctx, 1)
+//	        }
+//	    }),
+//	)
+func WithOnSpanEnd(fn OnSpanEndFunc) Option {
+	return func(c *config) {
+		c.onSpanEnd = fn
+	}
+}
+
+// WithBaggageSpanAttributes copies the W3C Baggage entries keys of incoming
+// requests onto their request spans, as "baggage.{key}" attributes. Entries
+// that are not listed are propagated but not recorded.
+//
+// Example:
+//
+//	tracer := tracing.MustNew(tracing.WithBaggageSpanAttributes("tenant.id", "user.tier"))
+//	// A request with "baggage: tenant.id=acme" gets the span attribute baggage.tenant.id=acme
+func WithBaggageSpanAttributes(keys ...string) Option {
+	return func(c *config) {
+		c.baggageSpanAttributes = append(c.baggageSpanAttributes, keys...)
+	}
+}
+
 // OTLPOption configures OTLP provider behavior.
 type OTLPOption func(*otlpConfig)
 
@@ -310,6 +441,33 @@ func WithStdout() Option {
 	}
 }
 
+// WithZipkin configures the Zipkin provider, which sends spans to a Zipkin
+// collector at endpoint, the full URL of its span API. An empty endpoint
+// uses DefaultZipkinEndpoint. Unlike OTLP, it needs no call to Start.
+//
+// Use it for collectors that don't accept OTLP. The OpenTelemetry Zipkin
+// exporter is deprecated upstream; prefer OTLP, which Zipkin can receive
+// through an OpenTelemetry Collector.
+//
+// Only one provider can be configured. Configuring multiple providers
+// will result in a validation error.
+//
+// Example:
+//
+//	tracer := tracing.MustNew(tracing.WithZipkin("http://zipkin:9411/api/v2/spans"))
+func WithZipkin(endpoint string) Option {
+	return func(c *config) {
+		if c.providerSet {
+			c.validationErrors = append(c.validationErrors,
+				fmt.Errorf("provider: multiple providers configured (already have %q, cannot add %q); only one provider allowed", c.provider, ZipkinProvider))
+			return
+		}
+		c.provider = ZipkinProvider
+		c.zipkinEndpoint = endpoint
+		c.providerSet = true
+	}
+}
+
 // WithNoop configures noop provider (default, no traces exported).
 //
 // Only one provider can be configured. Configuring multiple providers
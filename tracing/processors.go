@@ -0,0 +1,65 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"context"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// OnSpanStartFunc is called when any span of the tracer starts, with the
+// context of its parent. It runs before the span is returned to its
+// creator, so attributes it sets are visible to span processors and
+// exporters. It must be safe for concurrent use.
+type OnSpanStartFunc func(parent context.Context, span sdktrace.ReadWriteSpan)
+
+// OnSpanEndFunc is called when any span of the tracer ends, before it is
+// exported. The span can no longer be modified. It must be safe for
+// concurrent use.
+type OnSpanEndFunc func(span sdktrace.ReadOnlySpan)
+
+// hookProcessor is a span processor that calls the hooks of
+// [WithOnSpanStart] and [WithOnSpanEnd].
+type hookProcessor struct {
+	onStart OnSpanStartFunc
+	onEnd   OnSpanEndFunc
+}
+
+var _ sdktrace.SpanProcessor = (*hookProcessor)(nil)
+
+// OnStart implements [sdktrace.SpanProcessor].
+func (p *hookProcessor) OnStart(parent context.Context, span sdktrace.ReadWriteSpan) {
+	if p.onStart != nil {
+		p.onStart(parent, span)
+	}
+}
+
+// OnEnd implements [sdktrace.SpanProcessor].
+func (p *hookProcessor) OnEnd(span sdktrace.ReadOnlySpan) {
+	if p.onEnd != nil {
+		p.onEnd(span)
+	}
+}
+
+// Shutdown implements [sdktrace.SpanProcessor].
+func (p *hookProcessor) Shutdown(context.Context) error {
+	return nil
+}
+
+// ForceFlush implements [sdktrace.SpanProcessor].
+func (p *hookProcessor) ForceFlush(context.Context) error {
+	return nil
+}
@@ -0,0 +1,82 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+type tenantKey struct{}
+
+func TestWithSpanProcessor_AndHooks(t *testing.T) {
+	t.Parallel()
+
+	recorder := tracetest.NewSpanRecorder()
+	var ended atomic.Int32
+	tracer := TestingTracer(t,
+		WithSpanProcessor(recorder),
+		WithOnSpanStart(func(ctx context.Context, span sdktrace.ReadWriteSpan) {
+			if tenant, ok := ctx.Value(tenantKey{}).(string); ok {
+				span.SetAttributes(attribute.String("tenant.id", tenant))
+			}
+		}),
+		WithOnSpanEnd(func(sdktrace.ReadOnlySpan) {
+			ended.Add(1)
+		}),
+	)
+
+	handler := MustMiddleware(tracer)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, span := tracer.StartSpan(r.Context(), "db.query")
+		tracer.FinishSpan(span)
+		w.WriteHeader(http.StatusOK)
+	}))
+	req := httptest.NewRequest(http.MethodGet, "/orders", nil)
+	req = req.WithContext(context.WithValue(req.Context(), tenantKey{}, "acme"))
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	for _, span := range spans {
+		assert.Contains(t, span.Attributes(), attribute.String("tenant.id", "acme"), "span %s", span.Name())
+	}
+	assert.Equal(t, int32(2), ended.Load())
+}
+
+func TestWithSpanProcessor_Invalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := New(WithSpanProcessor(nil))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "processor at index 0 cannot be nil")
+
+	_, err = New(
+		WithTracerProvider(sdktrace.NewTracerProvider()),
+		WithOnSpanStart(func(context.Context, sdktrace.ReadWriteSpan) {}),
+	)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot combine WithTracerProvider")
+}
@@ -0,0 +1,85 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/contrib/propagators/b3"
+	"go.opentelemetry.io/contrib/propagators/jaeger"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// Propagator names a trace context propagation format for [WithPropagators].
+// The names are those of the OTEL_PROPAGATORS environment variable.
+type Propagator string
+
+const (
+	// PropagatorTraceContext is the W3C Trace Context format (traceparent and tracestate headers).
+	PropagatorTraceContext Propagator = "tracecontext"
+
+	// PropagatorBaggage is the W3C Baggage format (baggage header).
+	PropagatorBaggage Propagator = "baggage"
+
+	// PropagatorB3 is the Zipkin B3 single-header format (b3 header).
+	PropagatorB3 Propagator = "b3"
+
+	// PropagatorB3Multi is the Zipkin B3 multi-header format (X-B3-TraceId, X-B3-SpanId, ...),
+	// used by Istio and Envoy.
+	PropagatorB3Multi Propagator = "b3multi"
+
+	// PropagatorJaeger is the Jaeger format (uber-trace-id header).
+	PropagatorJaeger Propagator = "jaeger"
+)
+
+// newPropagator returns the OpenTelemetry propagator for the format.
+func newPropagator(p Propagator) (propagation.TextMapPropagator, error) {
+	switch p {
+	case PropagatorTraceContext:
+		return propagation.TraceContext{}, nil
+	case PropagatorBaggage:
+		return propagation.Baggage{}, nil
+	case PropagatorB3:
+		return b3.New(b3.WithInjectEncoding(b3.B3SingleHeader)), nil
+	case PropagatorB3Multi:
+		return b3.New(b3.WithInjectEncoding(b3.B3MultipleHeader)), nil
+	case PropagatorJaeger:
+		return jaeger.Jaeger{}, nil
+	default:
+		return nil, fmt.Errorf("propagators: unsupported propagator %q", p)
+	}
+}
+
+// newCompositePropagator returns a propagator that injects all formats and
+// extracts from each of them, in order.
+func newCompositePropagator(propagators []Propagator) (propagation.TextMapPropagator, error) {
+	if len(propagators) == 0 {
+		return nil, errors.New("propagators: at least one propagator is required")
+	}
+	composite := make([]propagation.TextMapPropagator, 0, len(propagators))
+	for _, p := range propagators {
+		propagator, err := newPropagator(p)
+		if err != nil {
+			return nil, err
+		}
+		composite = append(composite, propagator)
+	}
+	if len(composite) == 1 {
+		return composite[0], nil
+	}
+
+	return propagation.NewCompositeTextMapPropagator(composite...), nil
+}
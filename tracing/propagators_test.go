@@ -0,0 +1,215 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/contrib/propagators/b3"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// remoteContext returns a context with a sampled remote span context.
+func remoteContext(t *testing.T) context.Context {
+	t.Helper()
+	traceID, err := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	require.NoError(t, err)
+	spanID, err := trace.SpanIDFromHex("00f067aa0ba902b7")
+	require.NoError(t, err)
+
+	return trace.ContextWithRemoteSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	}))
+}
+
+func TestWithPropagators_Inject(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		propagators []Propagator
+		wantHeaders []string
+		wantAbsent  []string
+	}{
+		{
+			name:        "trace context",
+			propagators: []Propagator{PropagatorTraceContext},
+			wantHeaders: []string{"Traceparent"},
+			wantAbsent:  []string{"B3", "X-B3-Traceid", "Uber-Trace-Id"},
+		},
+		{
+			name:        "b3 single header",
+			propagators: []Propagator{PropagatorB3},
+			wantHeaders: []string{"B3"},
+			wantAbsent:  []string{"Traceparent", "X-B3-Traceid"},
+		},
+		{
+			name:        "b3 multiple headers",
+			propagators: []Propagator{PropagatorB3Multi},
+			wantHeaders: []string{"X-B3-Traceid", "X-B3-Spanid", "X-B3-Sampled"},
+			wantAbsent:  []string{"B3"},
+		},
+		{
+			name:        "jaeger",
+			propagators: []Propagator{PropagatorJaeger},
+			wantHeaders: []string{"Uber-Trace-Id"},
+		},
+		{
+			name:        "composite",
+			propagators: []Propagator{PropagatorTraceContext, PropagatorB3Multi, PropagatorJaeger},
+			wantHeaders: []string{"Traceparent", "X-B3-Traceid", "Uber-Trace-Id"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tracer := TestingTracer(t, WithPropagators(tt.propagators...))
+			headers := http.Header{}
+			tracer.InjectTraceContext(remoteContext(t), headers)
+
+			for _, h := range tt.wantHeaders {
+				assert.NotEmpty(t, headers.Get(h), "header %s", h)
+			}
+			for _, h := range tt.wantAbsent {
+				assert.Empty(t, headers.Get(h), "header %s", h)
+			}
+		})
+	}
+}
+
+func TestWithPropagators_Extract(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		headers map[string]string
+	}{
+		{
+			name:    "b3 single header",
+			headers: map[string]string{"b3": "4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-1"},
+		},
+		{
+			name: "b3 multiple headers",
+			headers: map[string]string{
+				"X-B3-TraceId": "4bf92f3577b34da6a3ce929d0e0e4736",
+				"X-B3-SpanId":  "00f067aa0ba902b7",
+				"X-B3-Sampled": "1",
+			},
+		},
+		{
+			name:    "jaeger",
+			headers: map[string]string{"uber-trace-id": "4bf92f3577b34da6a3ce929d0e0e4736:00f067aa0ba902b7:0:1"},
+		},
+	}
+
+	tracer := TestingTracer(t, WithPropagators(PropagatorTraceContext, PropagatorB3, PropagatorJaeger))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			headers := http.Header{}
+			for k, v := range tt.headers {
+				headers.Set(k, v)
+			}
+			sc := trace.SpanContextFromContext(tracer.ExtractTraceContext(context.Background(), headers))
+
+			assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", sc.TraceID().String())
+			assert.Equal(t, "00f067aa0ba902b7", sc.SpanID().String())
+			assert.True(t, sc.IsSampled())
+		})
+	}
+}
+
+func TestWithPropagators_Baggage(t *testing.T) {
+	t.Parallel()
+
+	tracer := TestingTracer(t, WithPropagators(PropagatorB3))
+	ctx, err := SetBaggage(context.Background(), "tenant.id", "acme")
+	require.NoError(t, err)
+	headers := http.Header{}
+	tracer.InjectTraceContext(ctx, headers)
+
+	assert.Equal(t, "tenant.id=acme", headers.Get("baggage"), "baggage is always propagated")
+}
+
+func TestWithPropagators_Invalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := New(WithPropagators())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "at least one propagator")
+
+	_, err = New(WithPropagators(PropagatorTraceContext, "xray"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unsupported propagator "xray"`)
+}
+
+// delegatingPropagator propagates nothing until a delegate is set, like the
+// global propagator before otel.SetTextMapPropagator is called.
+type delegatingPropagator struct {
+	delegate atomic.Pointer[propagation.TextMapPropagator]
+}
+
+func (p *delegatingPropagator) get() propagation.TextMapPropagator {
+	if d := p.delegate.Load(); d != nil {
+		return *d
+	}
+	return propagation.NewCompositeTextMapPropagator()
+}
+
+func (p *delegatingPropagator) Inject(ctx context.Context, carrier propagation.TextMapCarrier) {
+	p.get().Inject(ctx, carrier)
+}
+
+func (p *delegatingPropagator) Extract(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	return p.get().Extract(ctx, carrier)
+}
+
+func (p *delegatingPropagator) Fields() []string {
+	return p.get().Fields()
+}
+
+func TestTraceContextFallback_PropagatorSetLater(t *testing.T) {
+	t.Parallel()
+
+	global := &delegatingPropagator{}
+	tracer := MustNew(WithServiceName("test"), WithCustomPropagator(global))
+	t.Cleanup(func() { tracer.Shutdown(t.Context()) }) //nolint:errcheck // Test cleanup
+
+	h := http.Header{}
+	tracer.InjectTraceContext(remoteContext(t), h)
+	assert.NotEmpty(t, h.Get("traceparent"), "W3C Trace Context until a propagator is set")
+
+	var b3Propagator propagation.TextMapPropagator = b3.New()
+	global.delegate.Store(&b3Propagator)
+
+	h = http.Header{}
+	tracer.InjectTraceContext(remoteContext(t), h)
+	assert.Empty(t, h.Get("traceparent"))
+	assert.NotEmpty(t, h.Get("b3"), "a propagator set after New is used")
+}
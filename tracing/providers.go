@@ -24,6 +24,7 @@ import (
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
 	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/exporters/zipkin" //nolint:staticcheck // Deprecated upstream, kept for collectors without OTLP
 	"go.opentelemetry.io/otel/sdk/resource"
 
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
@@ -38,6 +39,8 @@ func (t *Tracer) initializeProvider() error {
 		return t.initNoopProvider()
 	case StdoutProvider:
 		return t.initStdoutProvider()
+	case ZipkinProvider:
+		return t.initZipkinProvider()
 	case OTLPProvider, OTLPHTTPProvider:
 		// OTLP providers should use initializeProviderWithContext
 		return errors.New("OTLP providers require context; use Start(ctx)")
@@ -76,10 +79,7 @@ func (t *Tracer) initNoopProvider() error {
 	}
 
 	// Create a tracer provider with no exporter
-	res := createResource(t.serviceName, t.serviceVersion)
-	tp := sdktrace.NewTracerProvider(
-		sdktrace.WithResource(res),
-	)
+	tp := t.newSDKProvider(nil)
 
 	t.sdkProvider = tp
 	t.tracerProvider = tp
@@ -117,14 +117,8 @@ func (t *Tracer) initStdoutProvider() error {
 		return fmt.Errorf("failed to create stdout exporter: %w", err)
 	}
 
-	// Create resource with service information
-	res := createResource(t.serviceName, t.serviceVersion)
-
 	// Create tracer provider
-	tp := sdktrace.NewTracerProvider(
-		sdktrace.WithBatcher(exporter),
-		sdktrace.WithResource(res),
-	)
+	tp := t.newSDKProvider(exporter)
 
 	t.sdkProvider = tp
 	t.tracerProvider = tp
@@ -142,6 +136,47 @@ func (t *Tracer) initStdoutProvider() error {
 	return nil
 }
 
+// initZipkinProvider initializes the Zipkin trace exporter.
+func (t *Tracer) initZipkinProvider() error {
+	// If user provided a custom tracer provider, use it
+	if t.customTracerProvider {
+		t.logger.Debug("Using custom user-provided tracer provider")
+		if t.tracer == nil {
+			t.tracer = t.tracerProvider.Tracer("rivaas.dev/tracing")
+		}
+		if t.registerGlobal {
+			t.logger.Debug("Setting global OpenTelemetry tracer provider", "provider", "zipkin")
+			otel.SetTracerProvider(t.tracerProvider)
+		}
+
+		return nil
+	}
+
+	// Create Zipkin exporter; it connects on the first export
+	exporter, err := zipkin.New(t.zipkinEndpoint)
+	if err != nil {
+		return fmt.Errorf("failed to create Zipkin exporter: %w", err)
+	}
+
+	// Create tracer provider
+	tp := t.newSDKProvider(exporter)
+
+	t.sdkProvider = tp
+	t.tracerProvider = tp
+	t.tracer = tp.Tracer("rivaas.dev/tracing")
+
+	if t.registerGlobal {
+		t.logger.Debug("Setting global OpenTelemetry tracer provider", "provider", "zipkin")
+		otel.SetTracerProvider(tp)
+	} else {
+		t.logger.Debug("Skipping global tracer provider registration", "provider", "zipkin")
+	}
+
+	t.logger.Info("Tracing initialized", "provider", "zipkin", "endpoint", t.zipkinEndpoint, "service", t.serviceName)
+
+	return nil
+}
+
 // initOTLPProvider initializes the OTLP gRPC trace exporter.
 // The context is used for connection establishment.
 func (t *Tracer) initOTLPProvider(ctx context.Context) error {
@@ -176,14 +211,8 @@ func (t *Tracer) initOTLPProvider(ctx context.Context) error {
 		return fmt.Errorf("failed to create OTLP gRPC exporter: %w", err)
 	}
 
-	// Create resource with service information
-	res := createResource(t.serviceName, t.serviceVersion)
-
 	// Create tracer provider
-	tp := sdktrace.NewTracerProvider(
-		sdktrace.WithBatcher(exporter),
-		sdktrace.WithResource(res),
-	)
+	tp := t.newSDKProvider(exporter)
 
 	t.sdkProvider = tp
 	t.tracerProvider = tp
@@ -251,14 +280,8 @@ func (t *Tracer) initOTLPHTTPProvider(ctx context.Context) error {
 		return fmt.Errorf("failed to create OTLP HTTP exporter: %w", err)
 	}
 
-	// Create resource with service information
-	res := createResource(t.serviceName, t.serviceVersion)
-
 	// Create tracer provider
-	tp := sdktrace.NewTracerProvider(
-		sdktrace.WithBatcher(exporter),
-		sdktrace.WithResource(res),
-	)
+	tp := t.newSDKProvider(exporter)
 
 	t.sdkProvider = tp
 	t.tracerProvider = tp
@@ -276,6 +299,26 @@ func (t *Tracer) initOTLPHTTPProvider(ctx context.Context) error {
 	return nil
 }
 
+// newSDKProvider creates a tracer provider with the service resource, the
+// span processors and hooks of the options, and a batcher for exporter if
+// it isn't nil.
+func (t *Tracer) newSDKProvider(exporter sdktrace.SpanExporter) *sdktrace.TracerProvider {
+	opts := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(createResource(t.serviceName, t.serviceVersion)),
+	}
+	if t.onSpanStart != nil || t.onSpanEnd != nil {
+		opts = append(opts, sdktrace.WithSpanProcessor(&hookProcessor{onStart: t.onSpanStart, onEnd: t.onSpanEnd}))
+	}
+	for _, processor := range t.spanProcessors {
+		opts = append(opts, sdktrace.WithSpanProcessor(processor))
+	}
+	if exporter != nil {
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+	}
+
+	return sdktrace.NewTracerProvider(opts...)
+}
+
 // createResource creates an OpenTelemetry resource with service information.
 func createResource(serviceName, serviceVersion string) *resource.Resource {
 	return resource.NewWithAttributes(
@@ -19,7 +19,10 @@ package tracing
 import (
 	"bytes"
 	"context"
+	"io"
 	"log/slog"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -187,3 +190,46 @@ func TestInitOTLPHTTPProvider_StripsHttpPrefixAndPath(t *testing.T) {
 	err = tracer.Start(ctx)
 	require.NoError(t, err)
 }
+
+// TestInitZipkinProvider_ExportsSpans covers the Zipkin provider end to end.
+func TestInitZipkinProvider_ExportsSpans(t *testing.T) {
+	t.Parallel()
+
+	received := make(chan string, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received <- string(body)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	tracer, err := New(WithZipkin(server.URL+"/api/v2/spans"), WithServiceName("orders"))
+	require.NoError(t, err)
+	assert.Equal(t, ZipkinProvider, tracer.GetProvider())
+	assert.False(t, tracer.RequiresStart())
+
+	_, span := tracer.StartSpan(t.Context(), "zipkin-span")
+	tracer.FinishSpan(span)
+	require.NoError(t, tracer.Shutdown(t.Context()))
+
+	select {
+	case body := <-received:
+		assert.Contains(t, body, "zipkin-span")
+		assert.Contains(t, body, "orders")
+	case <-time.After(5 * time.Second):
+		t.Fatal("no spans received by the Zipkin collector")
+	}
+}
+
+// TestWithZipkin_DefaultEndpointAndConflicts covers the default endpoint and provider conflicts.
+func TestWithZipkin_DefaultEndpointAndConflicts(t *testing.T) {
+	t.Parallel()
+
+	tracer, err := New(WithZipkin(""))
+	require.NoError(t, err)
+	assert.Equal(t, DefaultZipkinEndpoint, tracer.zipkinEndpoint)
+
+	_, err = New(WithZipkin(""), WithStdout())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "multiple providers configured")
+}
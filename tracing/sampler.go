@@ -0,0 +1,111 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"net/http"
+	"sync/atomic"
+)
+
+// Sampler decides whether a request is traced. ShouldSample is called once
+// per request, before the request span is started, and must be safe for
+// concurrent use.
+//
+// Example:
+//
+//	checkout := tracing.RateSampler(1.0)
+//	rest := tracing.RateSampler(0.01)
+//
+//	tracer := tracing.MustNew(
+//	    tracing.WithSampler(tracing.SamplerFunc(func(req *http.Request) bool {
+//	        if strings.HasPrefix(req.URL.Path, "/checkout") {
+//	            return checkout.ShouldSample(req)
+//	        }
+//	        return rest.ShouldSample(req)
+//	    })),
+//	)
+type Sampler interface {
+	ShouldSample(req *http.Request) bool
+}
+
+// SamplerFunc adapts a function to a [Sampler].
+type SamplerFunc func(req *http.Request) bool
+
+// ShouldSample implements [Sampler].
+func (f SamplerFunc) ShouldSample(req *http.Request) bool {
+	return f(req)
+}
+
+// rateSampler is the [Sampler] of [RateSampler].
+type rateSampler struct {
+	counter   atomic.Uint64
+	threshold uint64
+	rate      float64
+}
+
+// RateSampler returns a sampler that traces the given fraction of requests,
+// the same way as [WithSampleRate]. The rate is clamped to [0.0, 1.0].
+// Each sampler keeps its own counter, so samplers for different routes
+// don't influence each other.
+//
+// Example:
+//
+//	tracer := tracing.MustNew(tracing.WithSampler(tracing.RateSampler(0.1)))
+func RateSampler(rate float64) Sampler {
+	rate = min(max(rate, 0.0), 1.0)
+	return &rateSampler{rate: rate, threshold: samplingThreshold(rate)}
+}
+
+// ShouldSample implements [Sampler].
+func (s *rateSampler) ShouldSample(*http.Request) bool {
+	return sampleAtRate(&s.counter, s.rate, s.threshold)
+}
+
+// samplingThreshold returns the threshold of the counter hash for the rate.
+func samplingThreshold(rate float64) uint64 {
+	switch {
+	case rate >= 1.0:
+		return ^uint64(0)
+	case rate <= 0.0:
+		return 0
+	default:
+		return uint64(rate * float64(^uint64(0)))
+	}
+}
+
+// sampleAtRate makes a sampling decision using integer arithmetic: the
+// counter is spread over the uint64 range with samplingMultiplier and
+// compared with the threshold.
+func sampleAtRate(counter *atomic.Uint64, rate float64, threshold uint64) bool {
+	if rate >= 1.0 {
+		return true
+	}
+	if rate <= 0.0 {
+		return false
+	}
+	hash := counter.Add(1) * samplingMultiplier
+
+	return hash <= threshold
+}
+
+// shouldSample makes the head sampling decision for a request, with the
+// sampler of [WithSampler] or else the rate of [WithSampleRate].
+func (t *Tracer) shouldSample(req *http.Request) bool {
+	if t.sampler != nil {
+		return t.sampler.ShouldSample(req)
+	}
+
+	return sampleAtRate(&t.samplingCounter, t.sampleRate, t.samplingThreshold)
+}
@@ -0,0 +1,176 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package tracing
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// newRecordingTracer returns a tracer whose ended spans are kept by the recorder.
+func newRecordingTracer(t *testing.T, opts ...Option) (*Tracer, *tracetest.SpanRecorder) {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	opts = append(opts, WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))))
+	tracer, err := New(opts...)
+	require.NoError(t, err)
+
+	return tracer, recorder
+}
+
+// serve sends requests for each path through handler.
+func serve(handler http.Handler, paths ...string) {
+	for _, path := range paths {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+}
+
+func TestRateSampler(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		rate    float64
+		wantMin int
+		wantMax int
+	}{
+		{name: "all", rate: 1.0, wantMin: 1000, wantMax: 1000},
+		{name: "none", rate: 0.0, wantMin: 0, wantMax: 0},
+		{name: "half", rate: 0.5, wantMin: 400, wantMax: 600},
+		{name: "clamped above", rate: 2.0, wantMin: 1000, wantMax: 1000},
+		{name: "clamped below", rate: -1.0, wantMin: 0, wantMax: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			sampler := RateSampler(tt.rate)
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			sampled := 0
+			for range 1000 {
+				if sampler.ShouldSample(req) {
+					sampled++
+				}
+			}
+			assert.GreaterOrEqual(t, sampled, tt.wantMin)
+			assert.LessOrEqual(t, sampled, tt.wantMax)
+		})
+	}
+}
+
+func TestWithSampler(t *testing.T) {
+	t.Parallel()
+
+	tracer, recorder := newRecordingTracer(t,
+		WithSampleRate(0.0),
+		WithSampler(SamplerFunc(func(req *http.Request) bool {
+			return req.Header.Get("X-Debug-Trace") != ""
+		})),
+	)
+
+	_, span := tracer.StartRequestSpan(t.Context(), httptest.NewRequest(http.MethodGet, "/users", nil), "/users", false)
+	tracer.FinishRequestSpan(span, http.StatusOK)
+	assert.Empty(t, recorder.Ended(), "sampler decides, not the sample rate")
+
+	req := httptest.NewRequest(http.MethodGet, "/users", nil)
+	req.Header.Set("X-Debug-Trace", "1")
+	_, span = tracer.StartRequestSpan(t.Context(), req, "/users", false)
+	tracer.FinishRequestSpan(span, http.StatusOK)
+	assert.Len(t, recorder.Ended(), 1)
+}
+
+func TestWithSampler_Nil(t *testing.T) {
+	t.Parallel()
+
+	_, err := New(WithSampler(nil))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "sampler")
+}
+
+func TestWithRouteSampleRate(t *testing.T) {
+	t.Parallel()
+
+	tracer, recorder := newRecordingTracer(t, WithSampleRate(0.0))
+	handler := MustMiddleware(tracer,
+		WithRouteSampleRate("/checkout/*", 1.0),
+		WithRouteSampleRate("/checkout/preview", 0.0), // Shadowed by the first rule
+		WithRouteSampleRate("/orders", 1.0),
+	)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	serve(handler, "/checkout/pay", "/checkout/preview", "/orders", "/orders/1", "/users")
+
+	var names []string
+	for _, span := range recorder.Ended() {
+		names = append(names, span.Name())
+	}
+	assert.Equal(t, []string{"GET /checkout/pay", "GET /checkout/preview", "GET /orders"}, names)
+}
+
+func TestWithRouteSampleRate_InvalidRate(t *testing.T) {
+	t.Parallel()
+
+	_, err := Middleware(TestingTracer(t), WithRouteSampleRate("/orders", 1.5))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "routeSampleRate")
+}
+
+func TestWithAlwaysSampleStatus(t *testing.T) {
+	t.Parallel()
+
+	tracer, recorder := newRecordingTracer(t, WithSampleRate(0.0))
+	handler := MustMiddleware(tracer,
+		WithAlwaysSampleStatus(http.StatusInternalServerError),
+	)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/fail":
+			time.Sleep(time.Millisecond)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case "/missing":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+
+	start := time.Now()
+	serve(handler, "/ok", "/missing", "/fail")
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "GET /fail", spans[0].Name())
+	assert.False(t, spans[0].StartTime().Before(start))
+	assert.GreaterOrEqual(t, spans[0].EndTime().Sub(spans[0].StartTime()), time.Millisecond,
+		"span starts when the request arrived")
+}
+
+func TestWithAlwaysSampleStatus_InvalidStatus(t *testing.T) {
+	t.Parallel()
+
+	_, err := Middleware(TestingTracer(t), WithAlwaysSampleStatus(600))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "alwaysSampleStatus")
+}
@@ -44,6 +44,9 @@ const (
 
 	// DefaultSampleRate is the default sampling rate (100% of requests).
 	DefaultSampleRate = 1.0
+
+	// DefaultZipkinEndpoint is the default Zipkin collector endpoint.
+	DefaultZipkinEndpoint = "http://localhost:9411/api/v2/spans"
 )
 
 // Attribute key prefixes for string building
@@ -54,12 +57,14 @@ const (
 
 // samplingMultiplier is used for sampling decisions.
 //
-// The value 2654435761 is 2^32/φ (where φ is the golden ratio ≈ 1.618),
+// The value 0x9E3779B97F4A7C15 is 2^64/φ (where φ is the golden ratio ≈ 1.618),
 // rounded to the nearest odd number. This constant is from Knuth's
 // "The Art of Computer Programming, Vol. 3, Section 6.4" on multiplicative
 // hashing. Being coprime to 2^64, it ensures the sequence (counter * multiplier)
-// cycles through all values before repeating.
-const samplingMultiplier = 2654435761
+// cycles through all values before repeating, and spreads consecutive counters
+// evenly over the whole uint64 range, so the sampled fraction matches the rate
+// from the first requests on.
+const samplingMultiplier = 0x9E3779B97F4A7C15
 
 // Provider represents the available tracing providers.
 type Provider string
@@ -76,6 +81,9 @@ const (
 
 	// OTLPHTTPProvider exports traces via OTLP HTTP protocol.
 	OTLPHTTPProvider Provider = "otlp-http"
+
+	// ZipkinProvider exports traces to a Zipkin collector over HTTP.
+	ZipkinProvider Provider = "zipkin"
 )
 
 // Tracer holds OpenTelemetry tracing configuration and runtime state.
@@ -101,13 +109,23 @@ type Tracer struct {
 	serviceVersion string
 	provider       Provider
 	otlpEndpoint   string
+	zipkinEndpoint string
 
 	// Lifecycle hooks
 	spanStartHook  SpanStartHook
 	spanFinishHook SpanFinishHook
 
+	// Span processors and hooks of the SDK provider
+	spanProcessors []sdktrace.SpanProcessor
+	onSpanStart    OnSpanStartFunc
+	onSpanEnd      OnSpanEndFunc
+
+	// Baggage entries copied onto request spans
+	baggageSpanAttributes []string
+
 	// Tracing behavior settings
 	sampleRate float64
+	sampler    Sampler // Replaces sampleRate when set
 
 	// Atomic types (must be 8-byte aligned)
 	samplingCounter   atomic.Uint64 // Sampling counter
@@ -209,6 +227,9 @@ func (c *config) validate() error {
 	if c.customTracerProvider && c.providerSet {
 		return errors.New("cannot combine WithTracerProvider with provider options (WithOTLP, WithStdout, WithNoop, WithOTLPHTTP): provider options are ignored when using WithTracerProvider; use only one")
 	}
+	if c.customTracerProvider && (len(c.spanProcessors) > 0 || c.onSpanStart != nil || c.onSpanEnd != nil) {
+		return errors.New("cannot combine WithTracerProvider with WithSpanProcessor, WithOnSpanStart, or WithOnSpanEnd: register processors on your tracer provider instead")
+	}
 	if c.serviceName == "" {
 		return errors.New("serviceName: cannot be empty")
 	}
@@ -218,16 +239,17 @@ func (c *config) validate() error {
 	if c.sampleRate < 0.0 || c.sampleRate > 1.0 {
 		return fmt.Errorf("sampleRate: must be between 0.0 and 1.0, got %f", c.sampleRate)
 	}
-	if c.sampleRate > 0.0 && c.sampleRate < 1.0 {
-		c.samplingThreshold = uint64(c.sampleRate * float64(^uint64(0)))
-	} else if c.sampleRate == 1.0 {
-		c.samplingThreshold = ^uint64(0)
-	} else {
-		c.samplingThreshold = 0
+	c.samplingThreshold = samplingThreshold(c.sampleRate)
+	if c.samplerSet && c.sampler == nil {
+		return errors.New("sampler: cannot be nil when using WithSampler")
 	}
 	switch c.provider {
 	case NoopProvider, StdoutProvider:
 		// no-op
+	case ZipkinProvider:
+		if c.zipkinEndpoint == "" {
+			c.zipkinEndpoint = DefaultZipkinEndpoint
+		}
 	case OTLPProvider, OTLPHTTPProvider:
 		if c.otlpEndpoint == "" {
 			c.otlpEndpointDefaulted = true
@@ -246,23 +268,29 @@ func newTracerFromConfig(cfg *config) (*Tracer, error) {
 		logger = slog.New(slog.DiscardHandler)
 	}
 	t := &Tracer{
-		tracerProvider:       cfg.tracerProvider,
-		customTracerProvider: cfg.customTracerProvider,
-		registerGlobal:       cfg.registerGlobal,
-		serviceName:          cfg.serviceName,
-		serviceVersion:       cfg.serviceVersion,
-		sampleRate:           cfg.sampleRate,
-		samplingThreshold:    cfg.samplingThreshold,
-		tracer:               cfg.tracer,
-		propagator:           cfg.propagator,
-		logger:               logger,
-		spanStartHook:        cfg.spanStartHook,
-		spanFinishHook:       cfg.spanFinishHook,
-		provider:             cfg.provider,
-		otlpEndpoint:         cfg.otlpEndpoint,
-		otlpInsecure:         cfg.otlpInsecure,
-		providerSet:          cfg.providerSet,
-		enabled:              true,
+		tracerProvider:        cfg.tracerProvider,
+		customTracerProvider:  cfg.customTracerProvider,
+		registerGlobal:        cfg.registerGlobal,
+		serviceName:           cfg.serviceName,
+		serviceVersion:        cfg.serviceVersion,
+		sampleRate:            cfg.sampleRate,
+		samplingThreshold:     cfg.samplingThreshold,
+		sampler:               cfg.sampler,
+		tracer:                cfg.tracer,
+		propagator:            withBaggagePropagation(withTraceContextFallback(cfg.propagator)),
+		logger:                logger,
+		spanStartHook:         cfg.spanStartHook,
+		spanFinishHook:        cfg.spanFinishHook,
+		baggageSpanAttributes: cfg.baggageSpanAttributes,
+		spanProcessors:        cfg.spanProcessors,
+		onSpanStart:           cfg.onSpanStart,
+		onSpanEnd:             cfg.onSpanEnd,
+		provider:              cfg.provider,
+		otlpEndpoint:          cfg.otlpEndpoint,
+		zipkinEndpoint:        cfg.zipkinEndpoint,
+		otlpInsecure:          cfg.otlpInsecure,
+		providerSet:           cfg.providerSet,
+		enabled:               true,
 		spanNamePool: sync.Pool{
 			New: func() any {
 				return &strings.Builder{}
@@ -635,18 +663,10 @@ func (t *Tracer) StartRequestSpan(ctx context.Context, req *http.Request, path s
 	// Extract trace context from headers
 	ctx = t.ExtractTraceContext(ctx, req.Header)
 
-	// Sampling decision using integer arithmetic
-	if t.sampleRate < 1.0 {
-		if t.sampleRate == 0.0 {
-			t.logger.Debug("Request not sampled (0% sample rate)", "path", path, "method", req.Method)
-			return ctx, trace.SpanFromContext(ctx)
-		}
-		counter := t.samplingCounter.Add(1)
-		hash := counter * samplingMultiplier
-		if hash > t.samplingThreshold {
-			t.logger.Debug("Request not sampled (probabilistic)", "path", path, "method", req.Method, "sample_rate", t.sampleRate)
-			return ctx, trace.SpanFromContext(ctx)
-		}
+	// Sampling decision
+	if !t.shouldSample(req) {
+		t.logger.Debug("Request not sampled", "path", path, "method", req.Method)
+		return ctx, trace.SpanFromContext(ctx)
 	}
 
 	// Build span name from method and path
@@ -677,6 +697,7 @@ func (t *Tracer) StartRequestSpan(ctx context.Context, req *http.Request, path s
 		attribute.String("service.version", t.serviceVersion),
 		attribute.Bool("rivaas.router.static_route", isStatic),
 	}
+	attrs = append(attrs, baggageAttributes(ctx, t.baggageSpanAttributes)...)
 	span.SetAttributes(attrs...)
 
 	// Invoke span start hook if configured
@@ -704,6 +725,48 @@ func (t *Tracer) FinishRequestSpan(span trace.Span, statusCode int) {
 	setStatusFromHTTPCodeAndEnd(span, statusCode)
 }
 
+// withTraceContextFallback returns propagator, falling back to W3C Trace
+// Context while it propagates nothing. This is the case of the global
+// propagator until the application sets one with otel.SetTextMapPropagator,
+// possibly after the tracer was created, so the check is made on every call.
+func withTraceContextFallback(propagator propagation.TextMapPropagator) propagation.TextMapPropagator {
+	if propagator == nil {
+		return propagation.TraceContext{}
+	}
+
+	return traceContextFallback{propagator}
+}
+
+// traceContextFallback is a propagator that delegates to W3C Trace Context
+// while the wrapped propagator has no fields.
+type traceContextFallback struct {
+	propagator propagation.TextMapPropagator
+}
+
+// current returns the propagator to use for this call.
+func (p traceContextFallback) current() propagation.TextMapPropagator {
+	if len(p.propagator.Fields()) == 0 {
+		return propagation.TraceContext{}
+	}
+
+	return p.propagator
+}
+
+// Inject implements propagation.TextMapPropagator.
+func (p traceContextFallback) Inject(ctx context.Context, carrier propagation.TextMapCarrier) {
+	p.current().Inject(ctx, carrier)
+}
+
+// Extract implements propagation.TextMapPropagator.
+func (p traceContextFallback) Extract(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	return p.current().Extract(ctx, carrier)
+}
+
+// Fields implements propagation.TextMapPropagator.
+func (p traceContextFallback) Fields() []string {
+	return p.current().Fields()
+}
+
 // setStatusFromHTTPCodeAndEnd sets span status from HTTP status code (2xx-3xx → Ok,
 // 4xx-5xx → Error) and ends the span. Shared by FinishSpanWithHTTPStatus and FinishRequestSpan.
 func setStatusFromHTTPCodeAndEnd(span trace.Span, statusCode int) {
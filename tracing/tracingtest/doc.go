@@ -0,0 +1,36 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tracingtest records spans in memory, so that applications can test
+// their instrumentation.
+//
+// NewTracer returns a [tracing.Tracer] whose spans are kept by a [Recorder]
+// when they end. The assertion helpers report failures with the testing.TB
+// they're given:
+//
+//	func TestCreateOrder(t *testing.T) {
+//	    tracer, rec := tracingtest.NewTracer(t)
+//	    handler := tracing.MustMiddleware(tracer)(newMux())
+//
+//	    handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("POST", "/orders", body))
+//
+//	    span := rec.RequireSpan(t, "POST /orders")
+//	    tracingtest.AssertAttribute(t, span, "http.status_code", 201)
+//	    tracingtest.AssertStatus(t, span, codes.Ok)
+//	    tracingtest.AssertEvent(t, span, "order.created")
+//	}
+//
+// Use [NewRecorder] and [Recorder.TracerProvider] to record spans of a
+// tracer configured elsewhere, such as by the app package.
+package tracingtest
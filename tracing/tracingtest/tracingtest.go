@@ -0,0 +1,247 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracingtest
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"rivaas.dev/tracing"
+)
+
+// Span is an ended span, as recorded by a [Recorder].
+type Span = tracetest.SpanStub
+
+// Recorder keeps the spans of its tracer provider in memory when they end.
+// It is safe for concurrent use.
+type Recorder struct {
+	exporter *tracetest.InMemoryExporter
+	provider *sdktrace.TracerProvider
+}
+
+// NewRecorder returns a recorder that samples every span. The tracer
+// provider is shut down when the test ends.
+//
+// Example:
+//
+//	rec := tracingtest.NewRecorder(t)
+//	tracer := tracing.MustNew(tracing.WithTracerProvider(rec.TracerProvider()))
+func NewRecorder(tb testing.TB) *Recorder {
+	tb.Helper()
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithSampler(sdktrace.AlwaysSample()),
+		sdktrace.WithSyncer(exporter),
+	)
+	tb.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := provider.Shutdown(ctx); err != nil {
+			tb.Logf("tracingtest: shutdown warning: %v", err)
+		}
+	})
+
+	return &Recorder{exporter: exporter, provider: provider}
+}
+
+// NewTracer returns a tracer that samples every request, and the recorder
+// of its spans. opts are applied after the defaults, and must not set a
+// provider.
+//
+// Example:
+//
+//	tracer, rec := tracingtest.NewTracer(t, tracing.WithServiceName("orders"))
+func NewTracer(tb testing.TB, opts ...tracing.Option) (*tracing.Tracer, *Recorder) {
+	tb.Helper()
+
+	rec := NewRecorder(tb)
+	allOpts := append([]tracing.Option{
+		tracing.WithServiceName("test-service"),
+		tracing.WithServiceVersion("v1.0.0"),
+		tracing.WithSampleRate(1.0),
+	}, opts...)
+	allOpts = append(allOpts, tracing.WithTracerProvider(rec.provider))
+
+	tracer, err := tracing.New(allOpts...)
+	if err != nil {
+		tb.Fatalf("tracingtest: failed to create tracer: %v", err)
+	}
+
+	return tracer, rec
+}
+
+// TracerProvider returns the tracer provider whose spans are recorded.
+func (r *Recorder) TracerProvider() trace.TracerProvider {
+	return r.provider
+}
+
+// Spans returns the ended spans, in the order they ended.
+func (r *Recorder) Spans() []Span {
+	return r.exporter.GetSpans()
+}
+
+// Names returns the names of the ended spans, in the order they ended.
+func (r *Recorder) Names() []string {
+	spans := r.Spans()
+	names := make([]string, 0, len(spans))
+	for _, span := range spans {
+		names = append(names, span.Name)
+	}
+
+	return names
+}
+
+// FindSpan returns the first ended span with the name.
+func (r *Recorder) FindSpan(name string) (Span, bool) {
+	for _, span := range r.Spans() {
+		if span.Name == name {
+			return span, true
+		}
+	}
+
+	return Span{}, false
+}
+
+// RequireSpan returns the first ended span with the name, and stops the test
+// if there is none.
+func (r *Recorder) RequireSpan(tb testing.TB, name string) Span {
+	tb.Helper()
+
+	span, ok := r.FindSpan(name)
+	if !ok {
+		tb.Fatalf("tracingtest: no span named %q, got %q", name, r.Names())
+	}
+
+	return span
+}
+
+// Reset forgets the spans recorded so far.
+func (r *Recorder) Reset() {
+	r.exporter.Reset()
+}
+
+// AssertAttribute checks that the span has the attribute with the value.
+// Integers of any size are compared as int64, float32 as float64, and
+// slices as the matching slice type, such as []string.
+func AssertAttribute(tb testing.TB, span Span, key string, want any) bool {
+	tb.Helper()
+
+	for _, kv := range span.Attributes {
+		if string(kv.Key) != key {
+			continue
+		}
+		got := kv.Value.AsInterface()
+		if !reflect.DeepEqual(got, normalize(want)) {
+			tb.Errorf("tracingtest: span %q attribute %q = %v (%T), want %v (%T)", span.Name, key, got, got, want, want)
+			return false
+		}
+
+		return true
+	}
+	tb.Errorf("tracingtest: span %q has no attribute %q", span.Name, key)
+
+	return false
+}
+
+// AssertNoAttribute checks that the span doesn't have the attribute.
+func AssertNoAttribute(tb testing.TB, span Span, key string) bool {
+	tb.Helper()
+
+	if slices.ContainsFunc(span.Attributes, func(kv attribute.KeyValue) bool { return string(kv.Key) == key }) {
+		tb.Errorf("tracingtest: span %q has unexpected attribute %q", span.Name, key)
+		return false
+	}
+
+	return true
+}
+
+// AssertStatus checks the status code of the span.
+func AssertStatus(tb testing.TB, span Span, want codes.Code) bool {
+	tb.Helper()
+
+	if span.Status.Code != want {
+		tb.Errorf("tracingtest: span %q status = %v (%q), want %v", span.Name, span.Status.Code, span.Status.Description, want)
+		return false
+	}
+
+	return true
+}
+
+// AssertEvent checks that the span has an event with the name, and returns
+// the first one.
+func AssertEvent(tb testing.TB, span Span, name string) (sdktrace.Event, bool) {
+	tb.Helper()
+
+	for _, event := range span.Events {
+		if event.Name == name {
+			return event, true
+		}
+	}
+	tb.Errorf("tracingtest: span %q has no event %q", span.Name, name)
+
+	return sdktrace.Event{}, false
+}
+
+// AssertChildOf checks that child was started with parent as its parent span.
+func AssertChildOf(tb testing.TB, child, parent Span) bool {
+	tb.Helper()
+
+	if child.Parent.SpanID() != parent.SpanContext.SpanID() || child.Parent.TraceID() != parent.SpanContext.TraceID() {
+		tb.Errorf("tracingtest: span %q is not a child of span %q", child.Name, parent.Name)
+		return false
+	}
+
+	return true
+}
+
+// normalize converts want to the type attribute values have in Go.
+func normalize(want any) any {
+	switch v := want.(type) {
+	case int:
+		return int64(v)
+	case int8:
+		return int64(v)
+	case int16:
+		return int64(v)
+	case int32:
+		return int64(v)
+	case uint8:
+		return int64(v)
+	case uint16:
+		return int64(v)
+	case uint32:
+		return int64(v)
+	case float32:
+		return float64(v)
+	case []int:
+		out := make([]int64, len(v))
+		for i, n := range v {
+			out[i] = int64(n)
+		}
+		return out
+	default:
+		return want
+	}
+}
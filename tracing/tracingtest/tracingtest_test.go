@@ -0,0 +1,116 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package tracingtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+
+	"rivaas.dev/tracing"
+)
+
+// fakeTB records failures instead of failing the test.
+type fakeTB struct {
+	testing.TB
+
+	errors []string
+}
+
+func (f *fakeTB) Helper() {}
+
+func (f *fakeTB) Errorf(format string, args ...any) {
+	f.errors = append(f.errors, fmt.Sprintf(format, args...))
+}
+
+func TestNewTracer_RecordsMiddlewareSpans(t *testing.T) {
+	t.Parallel()
+
+	tracer, rec := NewTracer(t, tracing.WithServiceName("orders"))
+	handler := tracing.MustMiddleware(tracer)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, span := tracer.StartSpan(r.Context(), "db.insert")
+		tracing.AddSpanEventFromContext(ctx, "order.created", attribute.String("order.id", "42"))
+		tracer.FinishSpan(span)
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/orders?page=2", nil))
+
+	assert.Equal(t, []string{"db.insert", "POST /orders"}, rec.Names())
+	request := rec.RequireSpan(t, "POST /orders")
+	insert := rec.RequireSpan(t, "db.insert")
+	assert.True(t, AssertAttribute(t, request, "http.status_code", http.StatusCreated))
+	assert.True(t, AssertAttribute(t, request, "service.name", "orders"))
+	assert.True(t, AssertAttribute(t, request, "http.request.param.page", []string{"2"}))
+	assert.True(t, AssertNoAttribute(t, request, "http.request.header.authorization"))
+	assert.True(t, AssertStatus(t, request, codes.Ok))
+	assert.True(t, AssertChildOf(t, insert, request))
+	event, ok := AssertEvent(t, insert, "order.created")
+	require.True(t, ok)
+	assert.Equal(t, []attribute.KeyValue{attribute.String("order.id", "42")}, event.Attributes)
+}
+
+func TestRecorder_FindSpanAndReset(t *testing.T) {
+	t.Parallel()
+
+	rec := NewRecorder(t)
+	tracer := tracing.MustNew(tracing.WithTracerProvider(rec.TracerProvider()))
+	_, span := tracer.StartSpan(context.Background(), "job")
+	tracer.FinishSpanWithError(span, errors.New("boom"))
+
+	found, ok := rec.FindSpan("job")
+	require.True(t, ok)
+	assert.True(t, AssertStatus(t, found, codes.Error))
+	_, ok = rec.FindSpan("missing")
+	assert.False(t, ok)
+
+	rec.Reset()
+	assert.Empty(t, rec.Spans())
+}
+
+func TestAssertions_ReportFailures(t *testing.T) {
+	t.Parallel()
+
+	tracer, rec := NewTracer(t)
+	_, parent := tracer.StartSpan(context.Background(), "parent")
+	_, span := tracer.StartSpan(context.Background(), "other")
+	tracer.SetSpanAttribute(span, "count", 3)
+	tracer.FinishSpan(span)
+	tracer.FinishSpan(parent)
+	other := rec.RequireSpan(t, "other")
+
+	tb := &fakeTB{TB: t}
+	assert.False(t, AssertAttribute(tb, other, "count", 4))
+	assert.False(t, AssertAttribute(tb, other, "missing", "x"))
+	assert.False(t, AssertNoAttribute(tb, other, "count"))
+	assert.False(t, AssertStatus(tb, other, codes.Error))
+	_, ok := AssertEvent(tb, other, "missing")
+	assert.False(t, ok)
+	assert.False(t, AssertChildOf(tb, other, rec.RequireSpan(t, "parent")))
+
+	require.Len(t, tb.errors, 6)
+	assert.Contains(t, tb.errors[0], `attribute "count" = 3 (int64), want 4 (int)`)
+	assert.Contains(t, tb.errors[1], `has no attribute "missing"`)
+}
@@ -0,0 +1,134 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// retryAttemptKey is the context key of [ContextWithRetryAttempt].
+type retryAttemptKey struct{}
+
+// ContextWithRetryAttempt returns a copy of ctx that marks requests made
+// with it as the given retry attempt, starting at 1 for the first retry.
+// The transport of [NewTransport] records it on the client span, so that
+// retries show up in traces.
+//
+// Example:
+//
+//	for attempt := 0; attempt < 3; attempt++ {
+//	    req, _ := http.NewRequestWithContext(tracing.ContextWithRetryAttempt(ctx, attempt), "GET", url, nil)
+//	    resp, err = client.Do(req)
+//	    ...
+//	}
+func ContextWithRetryAttempt(ctx context.Context, attempt int) context.Context {
+	return context.WithValue(ctx, retryAttemptKey{}, attempt)
+}
+
+// transport is the [http.RoundTripper] of [NewTransport].
+type transport struct {
+	tracer *Tracer
+	base   http.RoundTripper
+}
+
+// NewTransport returns an [http.RoundTripper] that traces outgoing requests.
+// Each request gets a client span, a child of the span in the request
+// context, and carries the trace context in its headers with the tracer's
+// propagator. The span ends when the response headers are received, with
+// the status code, or with the error of base. Redirects and retry attempts
+// marked with [ContextWithRetryAttempt] are recorded as
+// "http.request.resend_count". A nil base uses [http.DefaultTransport].
+//
+// Example:
+//
+//	client := &http.Client{
+//	    Transport: tracing.NewTransport(tracer, http.DefaultTransport),
+//	    Timeout:   10 * time.Second,
+//	}
+func NewTransport(tracer *Tracer, base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &transport{tracer: tracer, base: base}
+}
+
+// NewClient returns an [http.Client] that traces outgoing requests with
+// [NewTransport] over [http.DefaultTransport].
+//
+// Example:
+//
+//	client := tracing.NewClient(tracer)
+//	req, _ := http.NewRequestWithContext(ctx, "GET", "https://api.example.com/users", nil)
+//	resp, err := client.Do(req)
+func NewClient(tracer *Tracer) *http.Client {
+	return &http.Client{Transport: NewTransport(tracer, nil)}
+}
+
+// RoundTrip implements [http.RoundTripper].
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.tracer == nil || !t.tracer.enabled {
+		return t.base.RoundTrip(req)
+	}
+
+	ctx, span := t.tracer.tracer.Start(req.Context(), req.Method+" "+req.URL.Host, trace.WithSpanKind(trace.SpanKindClient))
+	attrs := []attribute.KeyValue{
+		attribute.String("http.method", req.Method),
+		attribute.String("http.url", req.URL.Redacted()),
+		attribute.String("http.scheme", req.URL.Scheme),
+		attribute.String("http.host", req.URL.Host),
+	}
+	if resends := resendCount(req); resends > 0 {
+		attrs = append(attrs, attribute.Int("http.request.resend_count", resends))
+	}
+	span.SetAttributes(attrs...)
+
+	// A RoundTripper must not modify the request, so headers go on a clone.
+	req = req.Clone(ctx)
+	t.tracer.propagator.Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		setErrorAttributes(span, err)
+		span.SetStatus(codes.Error, err.Error())
+		span.End()
+
+		return nil, err
+	}
+	span.SetAttributes(attribute.Int("http.status_code", resp.StatusCode))
+	setStatusFromHTTPCodeAndEnd(span, resp.StatusCode)
+
+	return resp, nil
+}
+
+// resendCount returns how often the request was sent before: the number of
+// redirects that led to it, plus its retry attempt.
+func resendCount(req *http.Request) int {
+	count := 0
+	for resp := req.Response; resp != nil && resp.Request != nil; resp = resp.Request.Response {
+		count++
+	}
+	if attempt, ok := req.Context().Value(retryAttemptKey{}).(int); ok && attempt > 0 {
+		count += attempt
+	}
+
+	return count
+}
@@ -56,6 +56,17 @@
 // The package automatically selects the best strategy based on the value type, or you can
 // explicitly choose a strategy using [WithStrategy].
 //
+// # JSON Schema Files and References
+//
+// Schemas can also be loaded from files, such as an embed.FS, with
+// [WithSchemaFS] and [WithSchemaFile]; relative $refs resolve within the file
+// system. [WithRemoteSchemaRefs] allows $refs to http and https URLs, fetched
+// once per engine. Formats such as email, uuid, and date-time are asserted,
+// and fail with the code "schema.format":
+//
+//	engine := validation.MustNew(validation.WithSchemaFS(schemas))
+//	err := engine.Validate(ctx, &req, validation.WithSchemaFile("schemas/user.json"))
+//
 // # Partial Validation
 //
 // For PATCH requests where only some fields are provided, use [ValidatePartial]:
@@ -63,6 +74,17 @@
 //	presence, _ := validation.ComputePresence(rawJSON)
 //	err := engine.ValidatePartial(ctx, &user, presence)
 //
+// # Maps and Parameters
+//
+// Values without a struct to tag, such as a decoded JSON object or the
+// parameters of a dynamic endpoint, are validated against a [RuleSet] of
+// tags with [ValidateMap], [ValidateParams], and [ValidateQuery]:
+//
+//	err := validation.ValidateQuery(ctx, r.URL.Query(), validation.RuleSet{
+//	    "page": "omitempty,numeric",
+//	    "sort": "omitempty,oneof=name created_at",
+//	})
+//
 // # Custom Validation Interface
 //
 // Implement [Validator] for custom validation logic:
@@ -92,6 +114,73 @@
 //		return nil
 //	}
 //
+// # Struct Rules
+//
+// [WithStructRule] checks invariants spanning several fields of a type,
+// without implementing [Validator] on it. For simple cases, the cross-field
+// tags of go-playground/validator, such as required_if and gtfield, work too:
+//
+//	engine := validation.MustNew(
+//	    validation.WithStructRule(func(ctx context.Context, b *Booking) []validation.FieldError {
+//	        if !b.End.After(b.Start) {
+//	            return []validation.FieldError{{Path: "end", Code: "range.order", Message: "must be after start"}}
+//	        }
+//	        return nil
+//	    }),
+//	)
+//
+// # Context-Aware Rules
+//
+// [WithRule] registers a tag backed by a [RuleFunc] that receives the
+// request context, for checks that need I/O such as a uniqueness lookup.
+// Each rule runs with its own timeout, and its failures are reported in
+// [Error.Fields] like any other tag:
+//
+//	engine := validation.MustNew(
+//	    validation.WithRule("unique_email", uniqueEmail, 200*time.Millisecond),
+//	)
+//
+// # Localized Messages
+//
+// [WithCatalog] translates tag validation messages into the requester's
+// language. The locale comes from [ContextWithLocale] or a function set with
+// [WithLocaleFunc], such as the locale negotiated by middleware:
+//
+//	engine := validation.MustNew(
+//	    validation.WithCatalog(validation.MapCatalog{
+//	        "de": {"required": "ist erforderlich", "min.string": "muss mindestens {param} Zeichen lang sein"},
+//	    }),
+//	    validation.WithLocaleFunc(locale.FromContext),
+//	)
+//
+// Codes stay the same in every locale, so clients can still match on them.
+//
+// # Warnings
+//
+// Rules in the warn struct tag report problems without failing validation,
+// such as the built-in deprecated tag. They are collected only when the
+// context carries a [Warnings] collector, e.g. to return them in a response
+// header or body:
+//
+//	type Request struct {
+//	    Name     string `json:"name" validate:"required"`
+//	    Nickname string `json:"nickname" warn:"deprecated"`
+//	}
+//
+//	ctx, warnings := validation.ContextWithWarnings(ctx)
+//	err := validation.Validate(ctx, &req)
+//	for _, w := range warnings.Fields() { ... }
+//
+// # Problem Details
+//
+// [Error] implements the optional interfaces of rivaas.dev/errors, so the
+// RFC 9457 formatter turns it into a 422 response. Each invalid field is
+// listed under "errors" with an RFC 6901 JSON Pointer, and
+// [Error.ProblemFields] returns the same entries for handlers that write
+// responses themselves:
+//
+//	{"pointer": "/items/2/price", "detail": "must be at least 0", "code": "tag.min"}
+//
 // # Sentinel errors
 //
 // Use errors.Is(err, ErrValidation) for validation failures. For specific cases (nil value,
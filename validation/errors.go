@@ -41,6 +41,13 @@ var (
 
 	// ErrInvalidType is returned when a value has an unexpected type.
 	ErrInvalidType = errors.New("invalid type")
+
+	// ErrRuleTimeout is wrapped by the error of a [WithRule] rule that didn't
+	// finish within its timeout.
+	ErrRuleTimeout = errors.New("validation rule timed out")
+
+	// ErrNoSchemaFS is returned when [WithSchemaFile] is used without [WithSchemaFS].
+	ErrNoSchemaFS = errors.New("schema file requires WithSchemaFS")
 )
 
 // FieldError represents a single validation error for a specific field.
@@ -23,6 +23,7 @@ import (
 	"strings"
 
 	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/santhosh-tekuri/jsonschema/v6/kind"
 )
 
 // JSON Schema validation constants.
@@ -41,12 +42,19 @@ type jsonschemaSchema = jsonschema.Schema
 // validateWithSchema validates using JSON Schema ([StrategyJSONSchema]).
 // The schema can be provided via [JSONSchemaProvider] interface or [WithCustomSchema] option.
 func (v *Engine) validateWithSchema(ctx context.Context, val any, cfg *config) error {
-	schemaID, schemaJSON := getSchemaForValue(val, cfg)
-	if schemaJSON == "" {
-		return nil
+	var (
+		schema *jsonschemaSchema
+		err    error
+	)
+	if cfg.schemaFile != "" {
+		schema, err = v.getOrCompileSchemaFile(cfg.schemaFile)
+	} else {
+		schemaID, schemaJSON := getSchemaForValue(val, cfg)
+		if schemaJSON == "" {
+			return nil
+		}
+		schema, err = v.getOrCompileSchema(schemaID, schemaJSON)
 	}
-
-	schema, err := v.getOrCompileSchema(schemaID, schemaJSON)
 	if err != nil {
 		return &Error{Fields: []FieldError{{Code: "schema_compile_error", Message: err.Error()}}}
 	}
@@ -165,11 +173,16 @@ func getSchemaForValue(v any, cfg *config) (id, schema string) {
 	return "", ""
 }
 
-// compileSchema compiles a JSON Schema from a JSON string.
-func compileSchema(id, schemaJSON string) (*jsonschemaSchema, error) {
+// compileSchema compiles a JSON Schema from a JSON string under schemaURL.
+// External $refs are loaded with loader; with a nil loader, only refs within
+// the schema resolve.
+func compileSchema(schemaURL, schemaJSON string, loader jsonschema.URLLoader) (*jsonschemaSchema, error) {
 	compiler := jsonschema.NewCompiler()
 	compiler.AssertFormat()  // Enable format validation
 	compiler.AssertContent() // Enable content validation
+	if loader != nil {
+		compiler.UseLoader(loader)
+	}
 
 	// Parse schema JSON
 	var schemaDoc any
@@ -178,10 +191,6 @@ func compileSchema(id, schemaJSON string) (*jsonschemaSchema, error) {
 	}
 
 	// Add schema resource
-	schemaURL := id
-	if schemaURL == "" {
-		schemaURL = "schema.json"
-	}
 	if err := compiler.AddResource(schemaURL, schemaDoc); err != nil {
 		return nil, fmt.Errorf("failed to add schema resource: %w", err)
 	}
@@ -222,9 +231,8 @@ func collectSchemaErrors(verr *jsonschema.ValidationError, result *Error, cfg *c
 		field = cfg.fieldNameMapper(field)
 	}
 
-	// Extract error kind as code
-	// ErrorKind is an interface in v6, use fmt.Sprintf to get string representation
-	errorKind := fmt.Sprintf("%v", verr.ErrorKind)
+	// Use the failing keyword as a stable code, e.g. "schema.format"
+	errorKind := strings.Join(verr.ErrorKind.KeywordPath(), ".")
 	code := "schema." + errorKind
 
 	// Get error message
@@ -232,10 +240,14 @@ func collectSchemaErrors(verr *jsonschema.ValidationError, result *Error, cfg *c
 
 	// Add error if it has a meaningful message (leaf error)
 	if len(verr.Causes) == 0 {
-		result.Add(field, code, message, map[string]any{
+		meta := map[string]any{
 			"kind":       errorKind,
 			"schema_url": verr.SchemaURL,
-		})
+		}
+		if format, ok := verr.ErrorKind.(*kind.Format); ok {
+			meta["format"] = format.Want
+		}
+		result.Add(field, code, message, meta)
 
 		if cfg.maxErrors > 0 && len(result.Fields) >= cfg.maxErrors {
 			result.Truncated = true
@@ -0,0 +1,215 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+// JSON Schema loading constants.
+const (
+	// schemaFSScheme is the URL scheme of schemas loaded from the schema FS.
+	schemaFSScheme = "fs"
+
+	// maxRemoteSchemaSize limits the size of a schema fetched over HTTP.
+	maxRemoteSchemaSize = 1 << 20
+
+	// defaultRemoteSchemaTimeout is the timeout of the default client used
+	// by [WithRemoteSchemaRefs].
+	defaultRemoteSchemaTimeout = 10 * time.Second
+)
+
+// WithSchemaFS sets the file system that schema files and relative $refs
+// are loaded from, such as an embed.FS. A schema provided by
+// [JSONSchemaProvider] or [WithCustomSchema] resolves relative $refs
+// against its ID within fsys. Set it on the [Engine] with [New].
+//
+// Example:
+//
+//	//go:embed schemas
+//	var schemas embed.FS
+//
+//	engine := validation.MustNew(validation.WithSchemaFS(schemas))
+//	err := engine.Validate(ctx, &req, validation.WithSchemaFile("schemas/user.json"))
+func WithSchemaFS(fsys fs.FS) Option {
+	return func(c *config) {
+		c.schemaFS = fsys
+	}
+}
+
+// WithSchemaFile validates against the JSON Schema at path in the file
+// system set with [WithSchemaFS], instead of a schema provided by
+// [JSONSchemaProvider]. Relative $refs resolve against path, and the
+// compiled schema is cached by path.
+//
+// Example:
+//
+//	err := engine.Validate(ctx, &req,
+//	    validation.WithStrategy(validation.StrategyJSONSchema),
+//	    validation.WithSchemaFile("schemas/user.json"),
+//	)
+func WithSchemaFile(path string) Option {
+	return func(c *config) {
+		c.schemaFile = path
+	}
+}
+
+// WithRemoteSchemaRefs allows $refs to http and https URLs, fetched with
+// client. Fetched documents are cached for the life of the [Engine], so
+// each URL is fetched once. A nil client uses a client with a 10 second
+// timeout. Only enable it for schemas from trusted sources. Set it on the
+// [Engine] with [New].
+//
+// Example:
+//
+//	engine := validation.MustNew(validation.WithRemoteSchemaRefs(nil))
+func WithRemoteSchemaRefs(client *http.Client) Option {
+	return func(c *config) {
+		if client == nil {
+			client = &http.Client{Timeout: defaultRemoteSchemaTimeout}
+		}
+		c.schemaClient = client
+	}
+}
+
+// schemaFileURL returns the URL of the schema at name in the schema FS.
+func schemaFileURL(name string) string {
+	return schemaFSScheme + ":///" + strings.TrimPrefix(path.Clean("/"+name), "/")
+}
+
+// schemaURL returns the URL a schema with id is compiled under: within the
+// schema FS if there is one and id is relative, so relative $refs resolve
+// in it.
+func (v *Engine) schemaURL(id string) string {
+	if id == "" {
+		id = "schema.json"
+	}
+	if v.cfg.schemaFS != nil && !strings.Contains(id, ":") {
+		return schemaFileURL(id)
+	}
+
+	return id
+}
+
+// newSchemaLoader returns the loader for external $refs allowed by cfg, or
+// nil if only refs within a schema are allowed.
+func newSchemaLoader(cfg *config) jsonschema.URLLoader {
+	loader := jsonschema.SchemeURLLoader{}
+	if cfg.schemaFS != nil {
+		loader[schemaFSScheme] = fsSchemaLoader{fsys: cfg.schemaFS}
+	}
+	if cfg.schemaClient != nil {
+		remote := &httpSchemaLoader{client: cfg.schemaClient}
+		loader["http"] = remote
+		loader["https"] = remote
+	}
+	if len(loader) == 0 {
+		return nil
+	}
+
+	return loader
+}
+
+// fsSchemaLoader loads schemas from a file system.
+type fsSchemaLoader struct {
+	fsys fs.FS
+}
+
+// Load loads the schema at the path of an fs URL.
+func (l fsSchemaLoader) Load(rawURL string) (any, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	f, err := l.fsys.Open(strings.TrimPrefix(u.Path, "/"))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return jsonschema.UnmarshalJSON(f)
+}
+
+// httpSchemaLoader fetches schemas over HTTP and caches the documents.
+//
+// Thread-safe: Safe for concurrent use by multiple goroutines.
+type httpSchemaLoader struct {
+	client *http.Client
+	mu     sync.Mutex
+	docs   map[string]any
+}
+
+// Load returns the cached document at rawURL, fetching it if needed.
+func (l *httpSchemaLoader) Load(rawURL string) (any, error) {
+	l.mu.Lock()
+	doc, ok := l.docs[rawURL]
+	l.mu.Unlock()
+	if ok {
+		return doc, nil
+	}
+
+	resp, err := l.client.Get(rawURL) //nolint:noctx // The loader interface has no context; the client has a timeout
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	doc, err = jsonschema.UnmarshalJSON(io.LimitReader(resp.Body, maxRemoteSchemaSize))
+	if err != nil {
+		return nil, err
+	}
+
+	l.mu.Lock()
+	if l.docs == nil {
+		l.docs = make(map[string]any)
+	}
+	l.docs[rawURL] = doc
+	l.mu.Unlock()
+
+	return doc, nil
+}
+
+// getOrCompileSchemaFile gets the schema at name in the schema FS from
+// cache, or loads and compiles it.
+func (v *Engine) getOrCompileSchemaFile(name string) (*jsonschemaSchema, error) {
+	if v.cfg.schemaFS == nil {
+		return nil, ErrNoSchemaFS
+	}
+
+	id := schemaFileURL(name)
+	if schema, ok := v.cachedSchema(id); ok {
+		return schema, nil
+	}
+
+	data, err := fs.ReadFile(v.cfg.schemaFS, strings.TrimPrefix(path.Clean("/"+name), "/"))
+	if err != nil {
+		return nil, fmt.Errorf("read schema file: %w", err)
+	}
+
+	return v.getOrCompileSchema(id, string(data))
+}
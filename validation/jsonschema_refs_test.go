@@ -0,0 +1,165 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package validation
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testSchemaFS = fstest.MapFS{
+	"schemas/user.json": {Data: []byte(`{
+		"type": "object",
+		"properties": {
+			"email": {"type": "string", "format": "email"},
+			"address": {"$ref": "defs/address.json"}
+		},
+		"required": ["email"]
+	}`)},
+	"schemas/defs/address.json": {Data: []byte(`{
+		"type": "object",
+		"properties": {"zip": {"type": "string", "minLength": 5}},
+		"required": ["zip"]
+	}`)},
+	"account.json": {Data: []byte(`{"$ref": "schemas/user.json"}`)},
+}
+
+type refTestAddress struct {
+	Zip string `json:"zip"`
+}
+
+type refTestUser struct {
+	Email   string          `json:"email"`
+	Address *refTestAddress `json:"address,omitempty"`
+}
+
+// refTestProvidedUser provides a schema whose relative $ref resolves in the
+// schema FS.
+type refTestProvidedUser struct {
+	Email string `json:"email"`
+}
+
+func (refTestProvidedUser) JSONSchema() (string, string) {
+	return "account.json", `{"$ref": "schemas/user.json"}`
+}
+
+// schemaErrorCodes validates val and returns the error codes by path.
+func schemaErrorCodes(t *testing.T, engine *Engine, val any, opts ...Option) map[string]FieldError {
+	t.Helper()
+
+	err := engine.Validate(t.Context(), val, append([]Option{WithStrategy(StrategyJSONSchema)}, opts...)...)
+	require.Error(t, err)
+	var verr *Error
+	require.ErrorAs(t, err, &verr)
+
+	fields := map[string]FieldError{}
+	for _, f := range verr.Fields {
+		fields[f.Path] = f
+	}
+
+	return fields
+}
+
+func TestWithSchemaFile_ResolvesRelativeRefs(t *testing.T) {
+	t.Parallel()
+
+	engine := MustNew(WithSchemaFS(testSchemaFS))
+
+	fields := schemaErrorCodes(t, engine, &refTestUser{Email: "nope", Address: &refTestAddress{Zip: "1"}},
+		WithSchemaFile("schemas/user.json"))
+	require.Contains(t, fields, "email")
+	require.Contains(t, fields, "address.zip")
+	assert.Equal(t, "schema.format", fields["email"].Code)
+	assert.Equal(t, "email", fields["email"].Meta["format"])
+	assert.Equal(t, "schema.minLength", fields["address.zip"].Code)
+
+	require.NoError(t, engine.Validate(t.Context(), &refTestUser{Email: "a@example.com", Address: &refTestAddress{Zip: "12345"}},
+		WithSchemaFile("schemas/user.json")))
+}
+
+func TestWithSchemaFile_Errors(t *testing.T) {
+	t.Parallel()
+
+	fields := schemaErrorCodes(t, MustNew(), &refTestUser{}, WithSchemaFile("schemas/user.json"))
+	assert.Contains(t, fields[""].Message, ErrNoSchemaFS.Error())
+
+	fields = schemaErrorCodes(t, MustNew(WithSchemaFS(testSchemaFS)), &refTestUser{}, WithSchemaFile("missing.json"))
+	assert.Equal(t, "schema_compile_error", fields[""].Code)
+}
+
+func TestWithSchemaFS_ProviderRefs(t *testing.T) {
+	t.Parallel()
+
+	engine := MustNew(WithSchemaFS(testSchemaFS))
+
+	fields := schemaErrorCodes(t, engine, &refTestProvidedUser{Email: "nope"})
+	assert.Equal(t, "schema.format", fields["email"].Code)
+}
+
+func TestSchemaRefs_ExternalRefsDisabledByDefault(t *testing.T) {
+	t.Parallel()
+
+	fields := schemaErrorCodes(t, MustNew(), &refTestProvidedUser{Email: "a@example.com"})
+	assert.Equal(t, "schema_compile_error", fields[""].Code)
+}
+
+func TestWithRemoteSchemaRefs(t *testing.T) {
+	t.Parallel()
+
+	var fetches atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/common.json" {
+			http.NotFound(w, r)
+			return
+		}
+		fetches.Add(1)
+		w.Header().Set("Content-Type", "application/schema+json")
+		_, _ = w.Write([]byte(`{"$defs": {"uuid": {"type": "string", "format": "uuid"}, "time": {"type": "string", "format": "date-time"}}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	engine := MustNew(WithRemoteSchemaRefs(server.Client()))
+	type Event struct {
+		ID string `json:"id"`
+		At string `json:"at"`
+	}
+	idSchema := `{"type": "object", "properties": {"id": {"$ref": "` + server.URL + `/common.json#/$defs/uuid"}}}`
+	atSchema := `{"type": "object", "properties": {"at": {"$ref": "` + server.URL + `/common.json#/$defs/time"}}}`
+
+	fields := schemaErrorCodes(t, engine, &Event{ID: "not-a-uuid"}, WithCustomSchema("event-id", idSchema))
+	assert.Equal(t, "schema.format", fields["id"].Code)
+	assert.Equal(t, "uuid", fields["id"].Meta["format"])
+
+	fields = schemaErrorCodes(t, engine, &Event{At: "yesterday"}, WithCustomSchema("event-at", atSchema))
+	assert.Equal(t, "schema.format", fields["at"].Code)
+	assert.Equal(t, "date-time", fields["at"].Meta["format"])
+
+	require.NoError(t, engine.Validate(t.Context(), &Event{ID: "8f14e45f-ceea-467f-a9a5-5b8c2d5e6f70"},
+		WithStrategy(StrategyJSONSchema), WithCustomSchema("event-id", idSchema)))
+
+	assert.Equal(t, int32(1), fetches.Load(), "remote documents are cached across schemas")
+
+	fields = schemaErrorCodes(t, engine, &Event{}, WithCustomSchema("missing",
+		`{"$ref": "`+server.URL+`/missing.json"}`))
+	assert.Equal(t, "schema_compile_error", fields[""].Code)
+}
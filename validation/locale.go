@@ -0,0 +1,202 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"context"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Catalog provides localized message templates for validation tags.
+// Use [WithCatalog] to configure a catalog for an [Engine], and [MapCatalog]
+// for a catalog held in memory.
+//
+// Template returns the template for key in locale, and whether there is one.
+// The key is a tag such as "required", or a tag qualified by the kind of the
+// field, such as "min.string"; see [WithCatalog] for the lookup order.
+// Implementations must be safe for concurrent use.
+type Catalog interface {
+	Template(locale, key string) (string, bool)
+}
+
+// MapCatalog is a [Catalog] of templates by locale, then by key.
+//
+// Example:
+//
+//	catalog := validation.MapCatalog{
+//	    "de": {
+//	        "required":   "ist erforderlich",
+//	        "min.string": "muss mindestens {param} Zeichen lang sein",
+//	        "min":        "muss mindestens {param} sein",
+//	    },
+//	}
+type MapCatalog map[string]map[string]string
+
+// Template returns the template for key in locale.
+func (c MapCatalog) Template(locale, key string) (string, bool) {
+	tmpl, ok := c[locale][key]
+	return tmpl, ok
+}
+
+// localeContextKey is the context key of the locale set with [ContextWithLocale].
+type localeContextKey struct{}
+
+// ContextWithLocale returns a copy of ctx carrying locale, such as "de" or
+// "pt-BR", which selects the language of validation messages.
+//
+// Example:
+//
+//	ctx = validation.ContextWithLocale(ctx, "de")
+//	err := validation.Validate(ctx, &req, validation.WithCatalog(catalog))
+func ContextWithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeContextKey{}, locale)
+}
+
+// LocaleFromContext returns the locale set with [ContextWithLocale], or an
+// empty string.
+func LocaleFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	locale, _ := ctx.Value(localeContextKey{}).(string)
+
+	return locale
+}
+
+// WithCatalog sets the [Catalog] that translates tag validation messages
+// into the locale of the request. The locale is taken from [WithLocale],
+// else the function set with [WithLocaleFunc], else [ContextWithLocale].
+//
+// For a tag such as "min" on a string field in locale "pt-BR", the keys
+// "min.string" and "min" are looked up in "pt-BR", then in "pt". The kinds
+// are "string", "number", and "collection" (slices, arrays, and maps).
+// Without a template, or without a locale, the message falls back to
+// [WithMessages], [WithMessageFunc], and the built-in English messages.
+//
+// Templates may use the placeholders {field} (the field path), {param}
+// (the tag parameter), and {value} (the field value, redacted if the
+// [Redactor] says so).
+//
+// Example:
+//
+//	engine := validation.MustNew(
+//	    validation.WithCatalog(catalog),
+//	    validation.WithLocaleFunc(locale.FromContext),
+//	)
+func WithCatalog(catalog Catalog) Option {
+	return func(c *config) {
+		c.catalog = catalog
+	}
+}
+
+// WithLocale sets the locale of validation messages for a call, overriding
+// the locale of the context. It has no effect without [WithCatalog].
+//
+// Example:
+//
+//	err := engine.Validate(ctx, &req, validation.WithLocale("fr"))
+func WithLocale(locale string) Option {
+	return func(c *config) {
+		c.locale = locale
+	}
+}
+
+// WithLocaleFunc sets a function that returns the locale of validation
+// messages from the context, such as the locale negotiated by middleware.
+// An empty result falls back to [ContextWithLocale].
+//
+// Example:
+//
+//	validation.WithLocaleFunc(locale.FromContext)
+func WithLocaleFunc(fn func(context.Context) string) Option {
+	return func(c *config) {
+		c.localeFunc = fn
+	}
+}
+
+// resolveLocale returns the locale of validation messages for ctx.
+func (c *config) resolveLocale(ctx context.Context) string {
+	if c.locale != "" {
+		return c.locale
+	}
+	if c.localeFunc != nil && ctx != nil {
+		if locale := c.localeFunc(ctx); locale != "" {
+			return locale
+		}
+	}
+
+	return LocaleFromContext(ctx)
+}
+
+// localizedTagMessage returns the catalog message for a tag error at path,
+// and whether the catalog has one.
+func localizedTagMessage(e validator.FieldError, path string, cfg *config) (string, bool) {
+	if cfg.catalog == nil || cfg.locale == "" {
+		return "", false
+	}
+
+	tag := e.Tag()
+	keys := []string{tag}
+	if kind := kindCategory(fieldKind(e)); kind != "" {
+		keys = []string{tag + "." + kind, tag}
+	}
+
+	for _, locale := range localeFallbacks(cfg.locale) {
+		for _, key := range keys {
+			if tmpl, ok := cfg.catalog.Template(locale, key); ok {
+				return strings.NewReplacer(
+					"{field}", path,
+					"{param}", e.Param(),
+					"{value}", fieldValue(e.Value(), path, cfg),
+				).Replace(tmpl), true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// localeFallbacks returns locale followed by its less specific forms, e.g.
+// "pt-BR" and "pt".
+func localeFallbacks(locale string) []string {
+	locales := []string{locale}
+	for {
+		idx := strings.LastIndexAny(locale, "-_")
+		if idx <= 0 {
+			return locales
+		}
+		locale = locale[:idx]
+		locales = append(locales, locale)
+	}
+}
+
+// kindCategory returns the catalog key suffix for a field kind.
+func kindCategory(kind reflect.Kind) string {
+	switch kind {
+	case reflect.String:
+		return "string"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return "collection"
+	default:
+		return ""
+	}
+}
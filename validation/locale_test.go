@@ -0,0 +1,145 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package validation
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type localeTestUser struct {
+	Name  string   `json:"name" validate:"required"`
+	Nick  string   `json:"nick" validate:"min=3"`
+	Age   int      `json:"age" validate:"min=18"`
+	Tags  []string `json:"tags" validate:"max=1"`
+	Email string   `json:"email" validate:"email"`
+}
+
+var testCatalog = MapCatalog{
+	"de": {
+		"required":   "ist erforderlich",
+		"min.string": "muss mindestens {param} Zeichen lang sein",
+		"min":        "muss mindestens {param} sein",
+		"max":        "darf höchstens {param} Einträge haben",
+	},
+	"pt-BR": {
+		"required": "{field} é obrigatório",
+	},
+	"pt": {
+		"email": "e-mail inválido: {value}",
+	},
+}
+
+// localeTestMessages validates an invalid localeTestUser and returns the
+// messages by field path.
+func localeTestMessages(t *testing.T, ctx context.Context, engine *Engine, opts ...Option) map[string]string {
+	t.Helper()
+
+	err := engine.Validate(ctx, &localeTestUser{Nick: "ab", Age: 16, Tags: []string{"a", "b"}, Email: "nope"}, opts...)
+	require.Error(t, err)
+	var verr *Error
+	require.ErrorAs(t, err, &verr)
+
+	messages := map[string]string{}
+	for _, f := range verr.Fields {
+		messages[f.Path] = f.Message
+	}
+
+	return messages
+}
+
+func TestWithCatalog_LocaleFromContext(t *testing.T) {
+	t.Parallel()
+
+	engine := MustNew(WithCatalog(testCatalog))
+	messages := localeTestMessages(t, ContextWithLocale(t.Context(), "de"), engine)
+
+	assert.Equal(t, "ist erforderlich", messages["name"])
+	assert.Equal(t, "muss mindestens 3 Zeichen lang sein", messages["nick"], "kind-qualified key wins")
+	assert.Equal(t, "muss mindestens 18 sein", messages["age"])
+	assert.Equal(t, "darf höchstens 1 Einträge haben", messages["tags"])
+	assert.Equal(t, "must be a valid email address", messages["email"], "missing template falls back to English")
+}
+
+func TestWithCatalog_RegionFallback(t *testing.T) {
+	t.Parallel()
+
+	engine := MustNew(WithCatalog(testCatalog))
+	messages := localeTestMessages(t, ContextWithLocale(t.Context(), "pt-BR"), engine)
+
+	assert.Equal(t, "name é obrigatório", messages["name"])
+	assert.Equal(t, "e-mail inválido: nope", messages["email"], "pt-BR falls back to pt")
+}
+
+func TestWithCatalog_LocaleSources(t *testing.T) {
+	t.Parallel()
+
+	type ctxKey struct{}
+	fromMiddleware := func(ctx context.Context) string {
+		locale, _ := ctx.Value(ctxKey{}).(string)
+		return locale
+	}
+	engine := MustNew(WithCatalog(testCatalog), WithLocaleFunc(fromMiddleware))
+
+	ctx := context.WithValue(t.Context(), ctxKey{}, "de")
+	assert.Equal(t, "ist erforderlich", localeTestMessages(t, ctx, engine)["name"], "locale func")
+
+	ctx = ContextWithLocale(t.Context(), "de")
+	assert.Equal(t, "ist erforderlich", localeTestMessages(t, ctx, engine)["name"], "context fallback")
+
+	assert.Equal(t, "name é obrigatório", localeTestMessages(t, ctx, engine, WithLocale("pt-BR"))["name"], "WithLocale overrides")
+
+	assert.Equal(t, "is required", localeTestMessages(t, t.Context(), engine)["name"], "no locale")
+	assert.Equal(t, "is required", localeTestMessages(t, ContextWithLocale(t.Context(), "ja"), engine)["name"], "unknown locale")
+}
+
+func TestWithCatalog_PrecedesStaticMessages(t *testing.T) {
+	t.Parallel()
+
+	engine := MustNew(
+		WithCatalog(testCatalog),
+		WithMessages(map[string]string{"required": "must be provided"}),
+	)
+
+	assert.Equal(t, "ist erforderlich", localeTestMessages(t, ContextWithLocale(t.Context(), "de"), engine)["name"])
+	assert.Equal(t, "must be provided", localeTestMessages(t, t.Context(), engine)["name"])
+}
+
+func TestWithCatalog_RedactsValue(t *testing.T) {
+	t.Parallel()
+
+	engine := MustNew(
+		WithCatalog(testCatalog),
+		WithRedactor(func(path string) bool { return path == "email" }),
+	)
+
+	messages := localeTestMessages(t, ContextWithLocale(t.Context(), "pt"), engine)
+	assert.Equal(t, "e-mail inválido: ***REDACTED***", messages["email"])
+	assert.False(t, strings.Contains(messages["email"], "nope"))
+}
+
+func TestLocaleFallbacks(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []string{"de"}, localeFallbacks("de"))
+	assert.Equal(t, []string{"pt-BR", "pt"}, localeFallbacks("pt-BR"))
+	assert.Equal(t, []string{"zh_Hant_TW", "zh_Hant", "zh"}, localeFallbacks("zh_Hant_TW"))
+}
@@ -0,0 +1,215 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// RuleSet maps field names to validation tags in the syntax of the validate
+// struct tag, for values that have no struct to tag, such as the filters of
+// an admin query builder or the parameters forwarded by a proxy.
+//
+// For [ValidateMap], a dotted name such as "address.city" refers to a value
+// in a nested map. Tags must be built in or registered with [WithCustomTag]
+// or [WithRule]; like in struct tags, an unknown tag panics.
+//
+// Example:
+//
+//	rules := validation.RuleSet{
+//	    "limit":  "omitempty,numeric",
+//	    "sort":   "omitempty,oneof=name created_at",
+//	    "filter": "required,max=200",
+//	}
+type RuleSet map[string]string
+
+// ValidateMap validates the values of data against rules using the default
+// [Engine]. See [Engine.ValidateMap].
+func ValidateMap(ctx context.Context, data map[string]any, rules RuleSet, opts ...Option) error {
+	return getDefaultEngine().ValidateMap(ctx, data, rules, opts...)
+}
+
+// ValidateParams validates path parameters against rules using the default
+// [Engine]. See [Engine.ValidateParams].
+func ValidateParams(ctx context.Context, params map[string]string, rules RuleSet, opts ...Option) error {
+	return getDefaultEngine().ValidateParams(ctx, params, rules, opts...)
+}
+
+// ValidateQuery validates query parameters against rules using the default
+// [Engine]. See [Engine.ValidateQuery].
+func ValidateQuery(ctx context.Context, query url.Values, rules RuleSet, opts ...Option) error {
+	return getDefaultEngine().ValidateQuery(ctx, query, rules, opts...)
+}
+
+// ValidateMap validates the values of data against rules, such as a JSON
+// object decoded into a map. Values missing from data are validated as nil,
+// so they fail "required" and pass "omitempty"; keys without a rule are not
+// validated.
+//
+// ValidateMap returns nil if validation passes, or an [*Error] with one
+// [FieldError] per failed rule, whose path is the rule's name.
+//
+// Example:
+//
+//	err := engine.ValidateMap(ctx, body, validation.RuleSet{
+//	    "name":         "required,min=2",
+//	    "address.city": "required",
+//	})
+func (v *Engine) ValidateMap(ctx context.Context, data map[string]any, rules RuleSet, opts ...Option) error {
+	return v.validateRuleSet(ctx, rules, opts, func(name string) []ruleTarget {
+		value, _ := lookupMapPath(data, name)
+		return []ruleTarget{{path: name, value: value}}
+	})
+}
+
+// ValidateParams validates path parameters, such as those of a router, against
+// rules. Values are validated as strings, so use tags such as "numeric" or
+// "uuid" to check their format; missing parameters are validated as nil.
+//
+// Example:
+//
+//	err := engine.ValidateParams(ctx, map[string]string{"id": c.Param("id")},
+//	    validation.RuleSet{"id": "required,uuid"})
+func (v *Engine) ValidateParams(ctx context.Context, params map[string]string, rules RuleSet, opts ...Option) error {
+	return v.validateRuleSet(ctx, rules, opts, func(name string) []ruleTarget {
+		value, ok := params[name]
+		if !ok {
+			return []ruleTarget{{path: name}}
+		}
+
+		return []ruleTarget{{path: name, value: value}}
+	})
+}
+
+// ValidateQuery validates query parameters against rules. Values are validated
+// as strings; a parameter given several times is validated once per value,
+// with paths such as "tag[1]". Missing parameters are validated as nil.
+//
+// Example:
+//
+//	err := engine.ValidateQuery(ctx, r.URL.Query(), validation.RuleSet{
+//	    "page": "omitempty,numeric",
+//	    "tag":  "omitempty,alphanum,max=20",
+//	})
+func (v *Engine) ValidateQuery(ctx context.Context, query url.Values, rules RuleSet, opts ...Option) error {
+	return v.validateRuleSet(ctx, rules, opts, func(name string) []ruleTarget {
+		values := query[name]
+		switch len(values) {
+		case 0:
+			return []ruleTarget{{path: name}}
+		case 1:
+			return []ruleTarget{{path: name, value: values[0]}}
+		}
+
+		targets := make([]ruleTarget, len(values))
+		for i, value := range values {
+			targets[i] = ruleTarget{path: name + "[" + strconv.Itoa(i) + "]", value: value}
+		}
+
+		return targets
+	})
+}
+
+// ruleTarget is a value validated against a rule of a [RuleSet].
+type ruleTarget struct {
+	path  string
+	value any
+}
+
+// validateRuleSet validates the targets of each rule, in name order so
+// errors and context-aware rule results are deterministic.
+func (v *Engine) validateRuleSet(ctx context.Context, rules RuleSet, opts []Option, targets func(name string) []ruleTarget) error {
+	if err := v.initTagValidator(); err != nil {
+		return fmt.Errorf("initialize tag validator: %w", err)
+	}
+
+	ctx, cfg := v.callConfig(ctx, opts)
+	ctx, results := v.withRuleResults(ctx)
+
+	var result Error
+	for _, name := range slices.Sorted(maps.Keys(rules)) {
+		tag := rules[name]
+		if tag == "" {
+			continue
+		}
+
+		for _, t := range targets(name) {
+			err := v.tagValidator.VarCtx(ctx, t.value, tag)
+			if err == nil {
+				continue
+			}
+
+			path := t.path
+			if cfg.fieldNameMapper != nil {
+				path = cfg.fieldNameMapper(path)
+			}
+
+			var verrs validator.ValidationErrors
+			if !errors.As(err, &verrs) {
+				result.Add(path, "tag_error", err.Error(), nil)
+				continue
+			}
+			for _, e := range verrs {
+				result.Fields = append(result.Fields, newTagFieldError(e, path, cfg, results.next(e.Tag())))
+			}
+
+			if cfg.maxErrors > 0 && len(result.Fields) >= cfg.maxErrors {
+				result.Fields = result.Fields[:cfg.maxErrors]
+				result.Truncated = true
+
+				return &result
+			}
+		}
+	}
+
+	if result.HasErrors() {
+		return &result
+	}
+
+	return nil
+}
+
+// lookupMapPath returns the value at a dotted path in nested maps.
+func lookupMapPath(data map[string]any, path string) (any, bool) {
+	if value, ok := data[path]; ok {
+		return value, true
+	}
+
+	current := data
+	parts := strings.Split(path, ".")
+	for i, part := range parts {
+		value, ok := current[part]
+		if !ok {
+			return nil, false
+		}
+		if i == len(parts)-1 {
+			return value, true
+		}
+		if current, ok = value.(map[string]any); !ok {
+			return nil, false
+		}
+	}
+
+	return nil, false
+}
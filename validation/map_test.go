@@ -0,0 +1,171 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package validation
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateMap(t *testing.T) {
+	t.Parallel()
+
+	rules := RuleSet{
+		"name":         "required,min=2",
+		"age":          "omitempty,gte=18",
+		"address.city": "required",
+		"nickname":     "omitempty,max=5",
+	}
+
+	tests := []struct {
+		name      string
+		data      map[string]any
+		wantPaths []string
+	}{
+		{
+			name: "valid",
+			data: map[string]any{"name": "Ada", "age": 36, "address": map[string]any{"city": "London"}},
+		},
+		{
+			name:      "missing required values",
+			data:      map[string]any{"age": 36},
+			wantPaths: []string{"address.city", "name"},
+		},
+		{
+			name:      "invalid values",
+			data:      map[string]any{"name": "A", "age": 12, "address": map[string]any{"city": "London"}, "nickname": "toolongnick"},
+			wantPaths: []string{"age", "name", "nickname"},
+		},
+		{
+			name:      "nested path is not a map",
+			data:      map[string]any{"name": "Ada", "address": "London"},
+			wantPaths: []string{"address.city"},
+		},
+		{
+			name: "dotted key",
+			data: map[string]any{"name": "Ada", "address.city": "London"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := ValidateMap(t.Context(), tt.data, rules)
+			if tt.wantPaths == nil {
+				require.NoError(t, err)
+				return
+			}
+
+			var verr *Error
+			require.ErrorAs(t, err, &verr)
+			paths := make([]string, 0, len(verr.Fields))
+			for _, f := range verr.Fields {
+				paths = append(paths, f.Path)
+			}
+			assert.Equal(t, tt.wantPaths, paths)
+		})
+	}
+}
+
+func TestValidateMap_Messages(t *testing.T) {
+	t.Parallel()
+
+	err := ValidateMap(t.Context(), map[string]any{"name": "A"}, RuleSet{"name": "min=2", "email": "required,email"})
+
+	var verr *Error
+	require.ErrorAs(t, err, &verr)
+	require.Len(t, verr.Fields, 2)
+	assert.Equal(t, FieldError{Path: "email", Code: "tag.required", Message: "is required", Meta: verr.Fields[0].Meta}, verr.Fields[0])
+	assert.Equal(t, "tag.min", verr.Fields[1].Code)
+	assert.Equal(t, "must be at least 2 characters", verr.Fields[1].Message)
+}
+
+func TestValidateMap_MaxErrors(t *testing.T) {
+	t.Parallel()
+
+	err := ValidateMap(t.Context(), map[string]any{}, RuleSet{"a": "required", "b": "required", "c": "required"}, WithMaxErrors(2))
+
+	var verr *Error
+	require.ErrorAs(t, err, &verr)
+	assert.Len(t, verr.Fields, 2)
+	assert.True(t, verr.Truncated)
+}
+
+func TestValidateMap_Rules(t *testing.T) {
+	t.Parallel()
+
+	taken := errors.New("already taken")
+	engine := MustNew(WithRule("available", func(_ context.Context, value any) error {
+		if value == "admin" {
+			return taken
+		}
+		return nil
+	}, 0))
+
+	require.NoError(t, engine.ValidateMap(t.Context(), map[string]any{"user": "ada"}, RuleSet{"user": "available"}))
+
+	err := engine.ValidateMap(t.Context(), map[string]any{"user": "admin"}, RuleSet{"user": "available"})
+	var verr *Error
+	require.ErrorAs(t, err, &verr)
+	require.Len(t, verr.Fields, 1)
+	assert.Equal(t, "user", verr.Fields[0].Path)
+	assert.Equal(t, "already taken", verr.Fields[0].Message)
+}
+
+func TestValidateParams(t *testing.T) {
+	t.Parallel()
+
+	rules := RuleSet{"id": "required,uuid", "slug": "omitempty,alphanum"}
+
+	require.NoError(t, ValidateParams(t.Context(), map[string]string{"id": "9b2f1a3c-8d2e-4f5a-9c1b-2e3d4f5a6b7c"}, rules))
+
+	err := ValidateParams(t.Context(), map[string]string{"id": "42", "slug": "not a slug"}, rules)
+	var verr *Error
+	require.ErrorAs(t, err, &verr)
+	require.Len(t, verr.Fields, 2)
+	assert.Equal(t, "tag.uuid", verr.Fields[0].Code)
+	assert.Equal(t, "tag.alphanum", verr.Fields[1].Code)
+
+	err = ValidateParams(t.Context(), nil, rules)
+	require.ErrorAs(t, err, &verr)
+	assert.True(t, verr.Has("id"))
+}
+
+func TestValidateQuery(t *testing.T) {
+	t.Parallel()
+
+	rules := RuleSet{"page": "omitempty,numeric", "tag": "omitempty,alphanum,max=5"}
+
+	require.NoError(t, ValidateQuery(t.Context(), url.Values{}, rules))
+	require.NoError(t, ValidateQuery(t.Context(), url.Values{"page": {"2"}, "tag": {"go", "web"}, "other": {"!"}}, rules))
+
+	err := ValidateQuery(t.Context(), url.Values{"page": {"two"}, "tag": {"go", "web!", "toolong"}}, rules)
+	var verr *Error
+	require.ErrorAs(t, err, &verr)
+
+	paths := make([]string, 0, len(verr.Fields))
+	for _, f := range verr.Fields {
+		paths = append(paths, f.Path)
+	}
+	assert.Equal(t, []string{"page", "tag[1]", "tag[2]"}, paths)
+}
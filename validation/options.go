@@ -17,7 +17,10 @@ package validation
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io/fs"
 	"maps"
+	"net/http"
 	"reflect"
 
 	"github.com/go-playground/validator/v10"
@@ -82,12 +85,20 @@ type config struct {
 	presence              PresenceMap
 	customSchema          string
 	customSchemaID        string
+	schemaFile            string       // Schema path in schemaFS
+	schemaFS              fs.FS        // Schema files and relative $refs
+	schemaClient          *http.Client // Non-nil allows remote $refs
 	customValidator       func(any) error
 	fieldNameMapper       func(string) string
 	redactor              Redactor
 	customTags            []customTag
+	rules                 []rule
+	structRules           []structRule
 	messages              map[string]string      // tag -> static message
 	messageFuncs          map[string]MessageFunc // tag -> dynamic message function
+	catalog               Catalog                // Localized message templates
+	locale                string                 // Message locale; resolved per call when a catalog is set
+	localeFunc            func(context.Context) string
 }
 
 // validate checks the configuration for errors.
@@ -101,6 +112,19 @@ func (c *config) validate() error {
 	if c.maxCachedSchemas < 0 {
 		return errors.New("maxCachedSchemas must be non-negative")
 	}
+	for _, r := range c.structRules {
+		if r.fn == nil {
+			return fmt.Errorf("struct rule for %s cannot be nil", r.typ)
+		}
+	}
+	for _, r := range c.rules {
+		if r.name == "" || r.fn == nil {
+			return errors.New("rule requires a name and a function")
+		}
+		if r.timeout < 0 {
+			return fmt.Errorf("rule %q timeout must be non-negative", r.name)
+		}
+	}
 
 	return nil
 }
@@ -113,6 +137,12 @@ func (c *config) clone() *config {
 		clone.customTags = make([]customTag, 0, len(c.customTags))
 		clone.customTags = append(clone.customTags, c.customTags...)
 	}
+	if c.rules != nil {
+		clone.rules = append([]rule(nil), c.rules...)
+	}
+	if c.structRules != nil {
+		clone.structRules = append([]structRule(nil), c.structRules...)
+	}
 	// Deep copy maps
 	if c.messages != nil {
 		clone.messages = make(map[string]string, len(c.messages))
@@ -0,0 +1,111 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import "strings"
+
+// ProblemField is one invalid field in an RFC 9457 problem detail. It
+// locates the field in the request document with an RFC 6901 JSON Pointer.
+//
+// Example:
+//
+//	{"pointer": "/items/2/price", "detail": "must be at least 0", "code": "tag.min"}
+type ProblemField struct {
+	Pointer string         `json:"pointer"`        // JSON Pointer (e.g., "/items/2/price")
+	Detail  string         `json:"detail"`         // Human-readable message
+	Code    string         `json:"code,omitempty"` // Stable code (e.g., "tag.required")
+	Meta    map[string]any `json:"meta,omitempty"` // Additional metadata
+}
+
+// Pointer returns the RFC 6901 JSON Pointer of the field, such as
+// "/items/2/price" for the path "items[2].price" or "items.2.price".
+// It returns "" (the whole document) when the path is empty.
+func (e FieldError) Pointer() string {
+	if e.Path == "" {
+		return ""
+	}
+
+	var b strings.Builder
+	for part := range strings.SplitSeq(e.Path, ".") {
+		for _, token := range splitIndexes(part) {
+			b.WriteByte('/')
+			b.WriteString(pointerEscaper.Replace(token))
+		}
+	}
+
+	return b.String()
+}
+
+// pointerEscaper escapes a reference token as required by RFC 6901.
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// splitIndexes splits a path segment such as "items[2]" or "matrix[0][1]"
+// into its name and indexes. Malformed segments are returned unchanged.
+func splitIndexes(part string) []string {
+	open := strings.IndexByte(part, '[')
+	if open == -1 || !strings.HasSuffix(part, "]") {
+		return []string{part}
+	}
+
+	var tokens []string
+	if open > 0 {
+		tokens = append(tokens, part[:open])
+	}
+	for rest := part[open:]; rest != ""; {
+		end := strings.IndexByte(rest, ']')
+		if rest[0] != '[' || end == -1 {
+			return []string{part}
+		}
+		tokens = append(tokens, rest[1:end])
+		rest = rest[end+1:]
+	}
+
+	return tokens
+}
+
+// ProblemFields returns the field errors as RFC 9457 problem detail entries,
+// in the same order as [Error.Fields].
+//
+// Example:
+//
+//	var verr *validation.Error
+//	if errors.As(err, &verr) {
+//	    problem["errors"] = verr.ProblemFields()
+//	}
+func (v Error) ProblemFields() []ProblemField {
+	fields := make([]ProblemField, 0, len(v.Fields))
+	for _, f := range v.Fields {
+		fields = append(fields, ProblemField{
+			Pointer: f.Pointer(),
+			Detail:  f.Message,
+			Code:    f.Code,
+			Meta:    f.Meta,
+		})
+	}
+
+	return fields
+}
+
+// Extensions implements rivaas.dev/errors.ErrorExtensions. It adds the
+// invalid fields to RFC 9457 responses as an "errors" member, with a
+// "truncated" member when not all field errors were collected.
+func (v Error) Extensions() map[string]any {
+	ext := map[string]any{"errors": v.ProblemFields()}
+	if v.Truncated {
+		ext["truncated"] = true
+	}
+
+	return ext
+}
@@ -0,0 +1,92 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package validation
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFieldError_Pointer(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "", want: ""},
+		{path: "email", want: "/email"},
+		{path: "address.city", want: "/address/city"},
+		{path: "items.2.price", want: "/items/2/price"},
+		{path: "items[2].price", want: "/items/2/price"},
+		{path: "matrix[0][1]", want: "/matrix/0/1"},
+		{path: "labels[env]", want: "/labels/env"},
+		{path: "a/b.c~d", want: "/a~1b/c~0d"},
+		{path: "broken[1", want: "/broken[1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, FieldError{Path: tt.path}.Pointer())
+		})
+	}
+}
+
+type problemTestItem struct {
+	Price int `json:"price" validate:"min=0"`
+}
+
+type problemTestOrder struct {
+	Email string            `json:"email" validate:"required"`
+	Items []problemTestItem `json:"items" validate:"dive"`
+}
+
+func TestError_ProblemFields(t *testing.T) {
+	t.Parallel()
+
+	err := Validate(t.Context(), &problemTestOrder{Items: []problemTestItem{{Price: 1}, {Price: -1}}})
+
+	var verr *Error
+	require.ErrorAs(t, err, &verr)
+
+	fields := verr.ProblemFields()
+	require.Len(t, fields, 2)
+	assert.Equal(t, "/email", fields[0].Pointer)
+	assert.Equal(t, "tag.required", fields[0].Code)
+	assert.Equal(t, "is required", fields[0].Detail)
+	assert.Equal(t, "/items/1/price", fields[1].Pointer)
+	assert.Equal(t, "tag.min", fields[1].Code)
+}
+
+func TestError_Extensions(t *testing.T) {
+	t.Parallel()
+
+	verr := Error{Fields: []FieldError{{Path: "name", Code: "tag.required", Message: "is required"}}}
+	ext := verr.Extensions()
+	assert.NotContains(t, ext, "truncated")
+
+	data, err := json.Marshal(ext)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"errors":[{"pointer":"/name","detail":"is required","code":"tag.required"}]}`, string(data))
+
+	verr.Truncated = true
+	assert.Equal(t, true, verr.Extensions()["truncated"])
+}
@@ -0,0 +1,143 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// RuleFunc is a context-aware validation rule for a struct tag, typically
+// backed by I/O such as a repository lookup. It returns nil if value is
+// valid, or an error whose text becomes the message of the field error.
+// Use [WithRule] to register a rule.
+//
+// Example:
+//
+//	uniqueEmail := func(ctx context.Context, value any) error {
+//	    taken, err := users.EmailExists(ctx, value.(string))
+//	    if err != nil {
+//	        return err
+//	    }
+//	    if taken {
+//	        return errors.New("is already registered")
+//	    }
+//	    return nil
+//	}
+type RuleFunc func(ctx context.Context, value any) error
+
+// rule holds a context-aware rule registration for use with [WithRule].
+type rule struct {
+	name    string
+	fn      RuleFunc
+	timeout time.Duration
+}
+
+// WithRule registers a context-aware validation tag backed by fn. The rule
+// receives the context passed to Validate, limited to timeout if it is
+// positive, so slow lookups can't hold up a request. Failures, including
+// timeouts, are reported in [Error.Fields] with the code "tag.{name}" like
+// any other tag; timeouts wrap [ErrRuleTimeout] and set Meta["timeout"].
+// Rules are registered when the [Engine] is created.
+//
+// Example:
+//
+//	engine := validation.MustNew(
+//	    validation.WithRule("unique_email", uniqueEmail, 200*time.Millisecond),
+//	)
+//
+//	type SignupRequest struct {
+//	    Email string `json:"email" validate:"required,email,unique_email"`
+//	}
+func WithRule(name string, fn RuleFunc, timeout time.Duration) Option {
+	return func(c *config) {
+		c.rules = append(c.rules, rule{name: name, fn: fn, timeout: timeout})
+	}
+}
+
+// validatorFunc adapts the rule to go-playground/validator, recording its
+// error in the [ruleResults] of the context.
+func (r rule) validatorFunc() validator.FuncCtx {
+	return func(ctx context.Context, fl validator.FieldLevel) bool {
+		ruleCtx := ctx
+		if r.timeout > 0 {
+			var cancel context.CancelFunc
+			ruleCtx, cancel = context.WithTimeout(ctx, r.timeout)
+			defer cancel()
+		}
+
+		err := r.fn(ruleCtx, fl.Field().Interface())
+		if err == nil {
+			return true
+		}
+
+		// Distinguish the rule's own deadline from the caller's
+		if ruleCtx.Err() != nil && ctx.Err() == nil && errors.Is(err, context.DeadlineExceeded) {
+			err = fmt.Errorf("%w after %s", ErrRuleTimeout, r.timeout)
+		}
+		if results, ok := ctx.Value(ruleResultsKey{}).(*ruleResults); ok {
+			results.add(r.name, err)
+		}
+
+		return false
+	}
+}
+
+// withRuleResults returns a context that collects the errors of the
+// engine's context-aware rules, in the order they fail.
+func (v *Engine) withRuleResults(ctx context.Context) (context.Context, *ruleResults) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if len(v.cfg.rules) == 0 {
+		return ctx, nil
+	}
+	results := &ruleResults{}
+
+	return context.WithValue(ctx, ruleResultsKey{}, results), results
+}
+
+// ruleResultsKey is the context key of the [ruleResults] of a validation.
+type ruleResultsKey struct{}
+
+// ruleResults collects the errors of failed rules during one validation.
+// Tags are validated sequentially, so errors are matched to the
+// validator's field errors by tag, in order.
+type ruleResults struct {
+	errs map[string][]error
+}
+
+// add records err for the rule tag.
+func (r *ruleResults) add(tag string, err error) {
+	if r.errs == nil {
+		r.errs = make(map[string][]error)
+	}
+	r.errs[tag] = append(r.errs[tag], err)
+}
+
+// next returns the next recorded error for tag, or nil.
+func (r *ruleResults) next(tag string) error {
+	if r == nil || len(r.errs[tag]) == 0 {
+		return nil
+	}
+	err := r.errs[tag][0]
+	r.errs[tag] = r.errs[tag][1:]
+
+	return err
+}
@@ -0,0 +1,153 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package validation
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type ruleTestSignup struct {
+	Email    string   `json:"email" validate:"required,unique_email"`
+	Username string   `json:"username" validate:"unique_email"`
+	Aliases  []string `json:"aliases" validate:"dive,unique_email"`
+}
+
+// uniqueEmailRule reports the taken values as already registered, and
+// blocks on "slow" until the context is done.
+func uniqueEmailRule(taken ...string) RuleFunc {
+	return func(ctx context.Context, value any) error {
+		s, _ := value.(string)
+		if s == "slow" {
+			<-ctx.Done()
+			return ctx.Err()
+		}
+		for _, t := range taken {
+			if s == t {
+				return errors.New("is already registered: " + s)
+			}
+		}
+
+		return nil
+	}
+}
+
+func TestWithRule_AggregatesErrors(t *testing.T) {
+	t.Parallel()
+
+	engine := MustNew(WithRule("unique_email", uniqueEmailRule("a@example.com", "b", "d"), time.Second))
+
+	err := engine.Validate(t.Context(), &ruleTestSignup{
+		Email:    "a@example.com",
+		Username: "b",
+		Aliases:  []string{"c", "d"},
+	})
+	require.Error(t, err)
+
+	var verr *Error
+	require.ErrorAs(t, err, &verr)
+	require.Len(t, verr.Fields, 3)
+
+	assert.Equal(t, "tag.unique_email", verr.GetField("email").Code)
+	assert.Equal(t, "is already registered: a@example.com", verr.GetField("email").Message)
+	assert.Equal(t, "is already registered: b", verr.GetField("username").Message)
+	assert.Equal(t, "is already registered: d", verr.GetField("aliases[1]").Message)
+
+	require.NoError(t, engine.Validate(t.Context(), &ruleTestSignup{Email: "new@example.com", Username: "x"}))
+}
+
+func TestWithRule_Timeout(t *testing.T) {
+	t.Parallel()
+
+	engine := MustNew(WithRule("unique_email", uniqueEmailRule(), 10*time.Millisecond))
+
+	err := engine.Validate(t.Context(), &ruleTestSignup{Email: "slow"})
+	require.Error(t, err)
+
+	var verr *Error
+	require.ErrorAs(t, err, &verr)
+	field := verr.GetField("email")
+	require.NotNil(t, field)
+	assert.Equal(t, "tag.unique_email", field.Code)
+	assert.Contains(t, field.Message, ErrRuleTimeout.Error())
+	assert.Equal(t, true, field.Meta["timeout"])
+}
+
+func TestWithRule_ReceivesContext(t *testing.T) {
+	t.Parallel()
+
+	type ctxKey struct{}
+	var got any
+	engine := MustNew(WithRule("tenant_scoped", func(ctx context.Context, _ any) error {
+		got = ctx.Value(ctxKey{})
+		return nil
+	}, 0))
+
+	type Request struct {
+		Name string `json:"name" validate:"tenant_scoped"`
+	}
+	ctx := context.WithValue(t.Context(), ctxKey{}, "acme")
+	require.NoError(t, engine.Validate(ctx, &Request{Name: "x"}))
+	assert.Equal(t, "acme", got)
+}
+
+func TestWithRule_PartialValidation(t *testing.T) {
+	t.Parallel()
+
+	engine := MustNew(WithRule("unique_email", uniqueEmailRule("taken@example.com"), time.Second))
+
+	pm, err := ComputePresence([]byte(`{"email":"taken@example.com"}`))
+	require.NoError(t, err)
+
+	err = engine.ValidatePartial(t.Context(), &ruleTestSignup{Email: "taken@example.com"}, pm)
+	require.Error(t, err)
+	var verr *Error
+	require.ErrorAs(t, err, &verr)
+	assert.Equal(t, "is already registered: taken@example.com", verr.GetField("email").Message)
+}
+
+func TestWithRule_MessagesOverrideRuleError(t *testing.T) {
+	t.Parallel()
+
+	engine := MustNew(
+		WithRule("unique_email", uniqueEmailRule("a@example.com"), time.Second),
+		WithMessages(map[string]string{"unique_email": "is taken"}),
+	)
+
+	err := engine.Validate(t.Context(), &ruleTestSignup{Email: "a@example.com"})
+	var verr *Error
+	require.ErrorAs(t, err, &verr)
+	assert.Equal(t, "is taken", verr.GetField("email").Message)
+}
+
+func TestWithRule_InvalidConfig(t *testing.T) {
+	t.Parallel()
+
+	_, err := New(WithRule("", uniqueEmailRule(), 0))
+	require.Error(t, err)
+
+	_, err = New(WithRule("unique_email", nil, 0))
+	require.Error(t, err)
+
+	_, err = New(WithRule("unique_email", uniqueEmailRule(), -time.Second))
+	require.Error(t, err)
+}
@@ -0,0 +1,110 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"context"
+	"reflect"
+)
+
+// structRule holds a struct-level rule registration for use with [WithStructRule].
+type structRule struct {
+	typ reflect.Type
+	fn  func(ctx context.Context, val any) []FieldError
+}
+
+// WithStructRule registers a rule for invariants spanning several fields of
+// T, such as a date range, without implementing [Validator] on T. The rule
+// runs after the selected strategy whenever a T or *T is validated, except
+// in partial validation, and its errors are merged into the same [Error].
+// Errors without a code get the code "struct.invalid".
+//
+// Example:
+//
+//	engine := validation.MustNew(
+//	    validation.WithStructRule(func(ctx context.Context, b *Booking) []validation.FieldError {
+//	        if !b.End.After(b.Start) {
+//	            return []validation.FieldError{{Path: "end", Code: "range.order", Message: "must be after start"}}
+//	        }
+//	        return nil
+//	    }),
+//	)
+func WithStructRule[T any](fn func(ctx context.Context, v *T) []FieldError) Option {
+	return func(c *config) {
+		if fn == nil {
+			c.structRules = append(c.structRules, structRule{typ: reflect.TypeFor[T]()})
+			return
+		}
+		c.structRules = append(c.structRules, structRule{
+			typ: reflect.TypeFor[T](),
+			fn: func(ctx context.Context, val any) []FieldError {
+				return fn(ctx, val.(*T)) //nolint:forcetypeassert // The type is checked by applyStructRules
+			},
+		})
+	}
+}
+
+// applyStructRules runs the struct rules registered for the type of val and
+// merges their errors with err, the result of the strategy.
+func (v *Engine) applyStructRules(ctx context.Context, val any, cfg *config, err error) error {
+	if len(cfg.structRules) == 0 || cfg.partial {
+		return err
+	}
+
+	// Rules receive a pointer; copy values that aren't addressable
+	rv := reflect.ValueOf(val)
+	for rv.Kind() == reflect.Pointer && rv.Elem().Kind() == reflect.Pointer {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Pointer {
+		ptr := reflect.New(rv.Type())
+		ptr.Elem().Set(rv)
+		rv = ptr
+	}
+
+	var fields []FieldError
+	for _, rule := range cfg.structRules {
+		if rule.typ != rv.Type().Elem() {
+			continue
+		}
+		for _, fe := range rule.fn(ctx, rv.Interface()) {
+			if fe.Code == "" {
+				fe.Code = "struct.invalid"
+			}
+			if cfg.fieldNameMapper != nil && fe.Path != "" {
+				fe.Path = cfg.fieldNameMapper(fe.Path)
+			}
+			fields = append(fields, fe)
+		}
+	}
+	if len(fields) == 0 {
+		return err
+	}
+
+	var result Error
+	if err != nil {
+		result.AddError(err)
+	}
+	for _, fe := range fields {
+		if cfg.maxErrors > 0 && len(result.Fields) >= cfg.maxErrors {
+			result.Truncated = true
+			break
+		}
+		result.Fields = append(result.Fields, fe)
+	}
+	result.Sort()
+
+	return &result
+}
@@ -0,0 +1,130 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package validation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type structRuleBooking struct {
+	Room  string    `json:"room" validate:"required"`
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// bookingRangeRule requires the end of a booking to be after its start.
+func bookingRangeRule(_ context.Context, b *structRuleBooking) []FieldError {
+	if !b.End.After(b.Start) {
+		return []FieldError{{Path: "end", Code: "range.order", Message: "must be after start"}}
+	}
+
+	return nil
+}
+
+func TestWithStructRule_MergesWithStrategyErrors(t *testing.T) {
+	t.Parallel()
+
+	engine := MustNew(WithStructRule(bookingRangeRule))
+	now := time.Now()
+
+	err := engine.Validate(t.Context(), &structRuleBooking{Start: now, End: now.Add(-time.Hour)})
+	require.Error(t, err)
+	var verr *Error
+	require.ErrorAs(t, err, &verr)
+	require.Len(t, verr.Fields, 2)
+	assert.True(t, verr.HasCode("tag.required"))
+	assert.Equal(t, "range.order", verr.GetField("end").Code)
+
+	require.NoError(t, engine.Validate(t.Context(), &structRuleBooking{Room: "a", Start: now, End: now.Add(time.Hour)}))
+}
+
+func TestWithStructRule_ValuesAndDefaults(t *testing.T) {
+	t.Parallel()
+
+	var gotCtx context.Context
+	engine := MustNew(WithStructRule(func(ctx context.Context, b *structRuleBooking) []FieldError {
+		gotCtx = ctx
+		if b.Room == "closed" {
+			return []FieldError{{Path: "room", Message: "is closed"}}
+		}
+		return nil
+	}))
+
+	// Non-pointer values are passed as a pointer to a copy
+	err := engine.Validate(t.Context(), structRuleBooking{Room: "closed"})
+	var verr *Error
+	require.ErrorAs(t, err, &verr)
+	assert.Equal(t, "struct.invalid", verr.GetField("room").Code)
+	assert.Equal(t, t.Context(), gotCtx)
+
+	type Other struct {
+		Room string `json:"room"`
+	}
+	require.NoError(t, engine.Validate(t.Context(), &Other{Room: "closed"}), "rules only apply to their type")
+}
+
+func TestWithStructRule_SkippedInPartialMode(t *testing.T) {
+	t.Parallel()
+
+	engine := MustNew(WithStructRule(bookingRangeRule))
+	pm, err := ComputePresence([]byte(`{"room":"a"}`))
+	require.NoError(t, err)
+
+	require.NoError(t, engine.ValidatePartial(t.Context(), &structRuleBooking{Room: "a"}, pm))
+}
+
+func TestWithStructRule_MaxErrors(t *testing.T) {
+	t.Parallel()
+
+	engine := MustNew(WithMaxErrors(1), WithStructRule(bookingRangeRule))
+
+	err := engine.Validate(t.Context(), &structRuleBooking{})
+	var verr *Error
+	require.ErrorAs(t, err, &verr)
+	assert.Len(t, verr.Fields, 1)
+	assert.True(t, verr.Truncated)
+}
+
+func TestWithStructRule_Nil(t *testing.T) {
+	t.Parallel()
+
+	_, err := New(WithStructRule[structRuleBooking](nil))
+	require.Error(t, err)
+}
+
+func TestCrossFieldTagMessages(t *testing.T) {
+	t.Parallel()
+
+	type Shipping struct {
+		Method  string `json:"method"`
+		Address string `json:"address" validate:"required_if=Method courier"`
+		Min     int    `json:"min"`
+		Max     int    `json:"max" validate:"gtfield=Min"`
+	}
+
+	err := Validate(t.Context(), &Shipping{Method: "courier", Min: 5, Max: 3})
+	var verr *Error
+	require.ErrorAs(t, err, &verr)
+	assert.Equal(t, "is required", verr.GetField("address").Message)
+	assert.Equal(t, "tag.required_if", verr.GetField("address").Code)
+	assert.Equal(t, "must be greater than Min", verr.GetField("max").Message)
+}
@@ -15,6 +15,7 @@
 package validation
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"reflect"
@@ -26,7 +27,7 @@ import (
 
 // validateWithTags validates using go-playground/validator struct tags ([StrategyTags]).
 // It supports both full and partial validation modes.
-func (v *Engine) validateWithTags(val any, cfg *config) error {
+func (v *Engine) validateWithTags(ctx context.Context, val any, cfg *config) error {
 	if err := v.initTagValidator(); err != nil {
 		return fmt.Errorf("initialize tag validator: %w", err)
 	}
@@ -43,20 +44,22 @@ func (v *Engine) validateWithTags(val any, cfg *config) error {
 		return nil
 	}
 
+	ctx, results := v.withRuleResults(ctx)
+
 	// Partial mode: validate only leaf fields that are present
 	if cfg.partial && cfg.presence != nil {
-		return v.validatePartialLeafsOnly(val, cfg)
+		return v.validatePartialLeafsOnly(ctx, val, cfg, results)
 	}
 
 	// Full validation
-	err := v.tagValidator.Struct(val)
+	err := v.tagValidator.StructCtx(ctx, val)
 	if err == nil {
 		return nil
 	}
 
 	var validationErrs validator.ValidationErrors
 	if errors.As(err, &validationErrs) {
-		return v.formatTagErrors(validationErrs, val, cfg)
+		return v.formatTagErrors(validationErrs, val, cfg, results)
 	}
 
 	return &Error{Fields: []FieldError{{Code: "tag_error", Message: err.Error()}}}
@@ -65,7 +68,7 @@ func (v *Engine) validateWithTags(val any, cfg *config) error {
 // validatePartialLeafsOnly validates only leaf fields present in the [PresenceMap].
 // It avoids enforcing "required" on nested fields that weren't provided,
 // making it suitable for PATCH request validation.
-func (v *Engine) validatePartialLeafsOnly(val any, cfg *config) error {
+func (v *Engine) validatePartialLeafsOnly(ctx context.Context, val any, cfg *config, results *ruleResults) error {
 	leaves := cfg.presence.LeafPaths()
 	if len(leaves) == 0 {
 		return nil
@@ -100,26 +103,12 @@ func (v *Engine) validatePartialLeafsOnly(val any, cfg *config) error {
 		}
 
 		// Validate this single field
-		if err := v.tagValidator.Var(fieldVal.Interface(), validateTag); err != nil {
+		if err := v.tagValidator.VarCtx(ctx, fieldVal.Interface(), validateTag); err != nil {
 			var verrs validator.ValidationErrors
 			if errors.As(err, &verrs) {
 				// Format with a proper path context
 				for _, e := range verrs {
-					code := "tag." + e.Tag()
-					msg := getTagErrorMessage(e, cfg)
-
-					// Redact if needed
-					value := fmt.Sprint(e.Value())
-					if cfg.redactor != nil && cfg.redactor(path) {
-						value = "***REDACTED***"
-						msg = strings.ReplaceAll(msg, fmt.Sprint(e.Value()), "***REDACTED***")
-					}
-
-					result.Add(path, code, msg, map[string]any{
-						"tag":   e.Tag(),
-						"param": e.Param(),
-						"value": value,
-					})
+					result.Fields = append(result.Fields, newTagFieldError(e, path, cfg, results.next(e.Tag())))
 				}
 			}
 		}
@@ -222,7 +211,7 @@ func buildFieldMap(structType reflect.Type) map[string]int {
 }
 
 // formatTagErrors formats go-playground/validator errors into an [*Error] with stable codes.
-func (v *Engine) formatTagErrors(errs validator.ValidationErrors, structValue any, cfg *config) error {
+func (v *Engine) formatTagErrors(errs validator.ValidationErrors, structValue any, cfg *config, results *ruleResults) error {
 	var result Error
 	structType := reflect.TypeOf(structValue)
 	for structType.Kind() == reflect.Pointer {
@@ -244,22 +233,7 @@ func (v *Engine) formatTagErrors(errs validator.ValidationErrors, structValue an
 			path = cfg.fieldNameMapper(path)
 		}
 
-		// Stable code
-		code := "tag." + e.Tag()
-		msg := getTagErrorMessage(e, cfg)
-
-		// Redact
-		value := fmt.Sprint(e.Value())
-		if cfg.redactor != nil && cfg.redactor(path) {
-			value = "***REDACTED***"
-			msg = strings.ReplaceAll(msg, fmt.Sprint(e.Value()), "***REDACTED***")
-		}
-
-		result.Add(path, code, msg, map[string]any{
-			"tag":   e.Tag(),
-			"param": e.Param(),
-			"value": value,
-		})
+		result.Fields = append(result.Fields, newTagFieldError(e, path, cfg, results.next(e.Tag())))
 
 		if cfg.maxErrors > 0 && len(result.Fields) >= cfg.maxErrors {
 			result.Truncated = true
@@ -311,12 +285,67 @@ func namespaceToJSONPath(ns string, structType reflect.Type) string {
 	return strings.Join(result, ".")
 }
 
+// newTagFieldError converts a tag error at path into a [FieldError] with a
+// stable code. ruleErr is the error of the context-aware rule that failed,
+// if any.
+func newTagFieldError(e validator.FieldError, path string, cfg *config, ruleErr error) FieldError {
+	msg := getTagErrorMessage(e, path, cfg, ruleErr)
+
+	// Redact
+	value := fieldValue(e.Value(), path, cfg)
+	if value != fmt.Sprint(e.Value()) {
+		msg = strings.ReplaceAll(msg, fmt.Sprint(e.Value()), value)
+	}
+
+	meta := map[string]any{
+		"tag":   e.Tag(),
+		"param": e.Param(),
+		"value": value,
+	}
+	if errors.Is(ruleErr, ErrRuleTimeout) {
+		meta["timeout"] = true
+	}
+
+	return FieldError{
+		Path:    path,
+		Code:    "tag." + e.Tag(),
+		Message: msg,
+		Meta:    meta,
+	}
+}
+
+// fieldValue returns the value of the field at path for error metadata and
+// messages, redacted if the redactor says so.
+func fieldValue(value any, path string, cfg *config) string {
+	if cfg.redactor != nil && cfg.redactor(path) {
+		return "***REDACTED***"
+	}
+
+	return fmt.Sprint(value)
+}
+
+// fieldKind returns the kind of the value that failed, or reflect.Invalid
+// for a nil value, such as a key missing from a validated map.
+func fieldKind(e validator.FieldError) reflect.Kind {
+	if e.Type() == nil {
+		return reflect.Invalid
+	}
+
+	return e.Type().Kind()
+}
+
 // getTagErrorMessage returns a human-readable error message for a tag error.
-// Resolution order: static messages → dynamic message funcs → defaults.
-func getTagErrorMessage(e validator.FieldError, cfg *config) string {
+// Resolution order: localized catalog → static messages → rule error →
+// dynamic message funcs → defaults.
+func getTagErrorMessage(e validator.FieldError, path string, cfg *config, ruleErr error) string {
 	tag := e.Tag()
 	param := e.Param()
-	kind := e.Type().Kind()
+	kind := fieldKind(e)
+
+	// Check the catalog for the request locale
+	if msg, ok := localizedTagMessage(e, path, cfg); ok {
+		return msg
+	}
 
 	// Check static messages
 	if cfg.messages != nil {
@@ -325,6 +354,11 @@ func getTagErrorMessage(e validator.FieldError, cfg *config) string {
 		}
 	}
 
+	// Use the reason given by a context-aware rule
+	if ruleErr != nil {
+		return ruleErr.Error()
+	}
+
 	// Check dynamic message functions
 	if cfg.messageFuncs != nil {
 		if fn, ok := cfg.messageFuncs[tag]; ok {
@@ -359,6 +393,26 @@ func defaultTagMessage(tag, param string, kind reflect.Kind) string {
 		return "must be at most " + param
 	case "oneof":
 		return fmt.Sprintf("must be one of [%s]", param)
+	case "required_if", "required_unless", "required_with", "required_with_all",
+		"required_without", "required_without_all":
+		return "is required"
+	case "excluded_if", "excluded_unless", "excluded_with", "excluded_with_all",
+		"excluded_without", "excluded_without_all":
+		return "must not be set"
+	case "deprecated":
+		return "is deprecated and may be ignored"
+	case "eqfield":
+		return "must be equal to " + param
+	case "nefield":
+		return "must not be equal to " + param
+	case "gtfield":
+		return "must be greater than " + param
+	case "gtefield":
+		return "must be greater than or equal to " + param
+	case "ltfield":
+		return "must be less than " + param
+	case "ltefield":
+		return "must be less than or equal to " + param
 	default:
 		return fmt.Sprintf("failed validation (%s)", tag)
 	}
@@ -109,13 +109,7 @@ func (v *Engine) Validate(ctx context.Context, val any, opts ...Option) error {
 		return &Error{Fields: []FieldError{{Code: "nil", Message: ErrCannotValidateNilValue.Error()}}}
 	}
 
-	// Apply per-call options on top of validator's base config
-	cfg := applyOptions(v.cfg, opts...)
-
-	// Use context from config if explicitly set via WithContext, otherwise use the ctx parameter
-	if cfg.ctx != nil {
-		ctx = cfg.ctx
-	}
+	ctx, cfg := v.callConfig(ctx, opts)
 
 	// Handle nil pointers and invalid values
 	rv := reflect.ValueOf(val)
@@ -131,6 +125,9 @@ func (v *Engine) Validate(ctx context.Context, val any, opts ...Option) error {
 		rv = rv.Elem()
 	}
 
+	// Warnings never fail validation, so collect them first
+	v.collectWarnings(ctx, val, cfg)
+
 	// Get the concrete value (dereferenced) for custom validator
 	concreteV := rv.Interface()
 
@@ -143,7 +140,7 @@ func (v *Engine) Validate(ctx context.Context, val any, opts ...Option) error {
 
 	// Run all strategies if requested (use original val to preserve pointer)
 	if cfg.runAll {
-		return v.validateAll(ctx, val, cfg)
+		return v.applyStructRules(ctx, val, cfg, v.validateAll(ctx, val, cfg))
 	}
 
 	// Determine strategy (use original val to check interfaces)
@@ -153,7 +150,9 @@ func (v *Engine) Validate(ctx context.Context, val any, opts ...Option) error {
 	}
 
 	// Run single strategy (use original val to preserve pointer for interface validation)
-	return v.validateByStrategy(ctx, val, strategy, cfg)
+	err := v.validateByStrategy(ctx, val, strategy, cfg)
+
+	return v.applyStructRules(ctx, val, cfg, err)
 }
 
 // ValidatePartial validates only fields present in the [PresenceMap].
@@ -164,6 +163,29 @@ func (v *Engine) ValidatePartial(ctx context.Context, val any, pm PresenceMap, o
 	return v.Validate(ctx, val, opts...)
 }
 
+// callConfig applies per-call options on top of the engine's base config and
+// returns the context and config to validate with.
+//
+//nolint:contextcheck // intentional: WithContext option allows explicit context override
+func (v *Engine) callConfig(ctx context.Context, opts []Option) (context.Context, *config) {
+	cfg := applyOptions(v.cfg, opts...)
+
+	// Use context from config if explicitly set via WithContext, otherwise use the ctx parameter
+	if cfg.ctx != nil {
+		ctx = cfg.ctx
+	}
+
+	// Resolve the message locale once per call
+	if cfg.catalog != nil {
+		if locale := cfg.resolveLocale(ctx); locale != cfg.locale {
+			cfg = cfg.clone()
+			cfg.locale = locale
+		}
+	}
+
+	return ctx, cfg
+}
+
 // validateAll runs all applicable validation strategies and aggregates errors into an [*Error].
 func (v *Engine) validateAll(ctx context.Context, val any, cfg *config) error {
 	var all Error
@@ -241,7 +263,7 @@ func (v *Engine) isApplicable(ctx context.Context, val any, strategy Strategy, c
 
 	case StrategyJSONSchema:
 		// JSON Schema requires a schema to be available
-		if cfg.customSchema != "" {
+		if cfg.customSchema != "" || cfg.schemaFile != "" {
 			return true
 		}
 		if _, ok := val.(JSONSchemaProvider); ok {
@@ -303,7 +325,7 @@ func (v *Engine) validateByStrategy(ctx context.Context, val any, strategy Strat
 			rv = rv.Elem()
 		}
 
-		return v.validateWithTags(rv.Interface(), cfg)
+		return v.validateWithTags(ctx, rv.Interface(), cfg)
 
 	case StrategyJSONSchema:
 		// Dereference for schema validation
@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/go-playground/validator/v10"
+	"github.com/santhosh-tekuri/jsonschema/v6"
 )
 
 // Engine provides struct validation with configurable options.
@@ -53,9 +54,15 @@ type Engine struct {
 	tagValidatorOnce sync.Once
 	tagValidatorErr  error // stores init error for deferred checking
 
+	// Warning validator: reads the warn struct tag, see collectWarnings
+	warnValidator     *validator.Validate
+	warnValidatorOnce sync.Once
+	warnValidatorErr  error
+
 	// Schema cache for JSON Schema validation
 	schemaCache   map[string]*schemaCacheEntry
 	schemaCacheMu sync.RWMutex
+	schemaLoader  jsonschema.URLLoader // Loads external $refs; nil allows none
 
 	// Path cache: Type -> namespace -> JSON path
 	pathCache sync.Map // map[reflect.Type]*sync.Map[string]string
@@ -92,8 +99,9 @@ func New(opts ...Option) (*Engine, error) {
 	}
 
 	v := &Engine{
-		cfg:         cfg,
-		schemaCache: make(map[string]*schemaCacheEntry),
+		cfg:          cfg,
+		schemaCache:  make(map[string]*schemaCacheEntry),
+		schemaLoader: newSchemaLoader(cfg),
 	}
 
 	if err := v.initTagValidator(); err != nil {
@@ -127,33 +135,47 @@ func MustNew(opts ...Option) *Engine {
 // This method is safe for concurrent use.
 func (v *Engine) initTagValidator() error {
 	v.tagValidatorOnce.Do(func() {
-		v.tagValidator = validator.New(validator.WithRequiredStructEnabled())
-
-		// Use json tags as field names for better error messages
-		v.tagValidator.RegisterTagNameFunc(func(fld reflect.StructField) string {
-			name := fld.Tag.Get("json")
-			if name == "-" {
-				return ""
-			}
-			if idx := strings.Index(name, ","); idx != -1 {
-				name = name[:idx]
-			}
-			if name == "" {
-				return fld.Name
-			}
+		v.tagValidator, v.tagValidatorErr = v.newTagValidator("validate")
+	})
 
-			return name
-		})
+	return v.tagValidatorErr
+}
 
-		for _, ct := range v.cfg.customTags {
-			if err := v.tagValidator.RegisterValidation(ct.name, ct.fn); err != nil {
-				v.tagValidatorErr = fmt.Errorf("register custom tag %q: %w", ct.name, err)
-				return
-			}
+// newTagValidator creates a go-playground/validator instance that reads the
+// struct tag tagName, with the engine's custom tags and rules registered.
+func (v *Engine) newTagValidator(tagName string) (*validator.Validate, error) {
+	tv := validator.New(validator.WithRequiredStructEnabled())
+	tv.SetTagName(tagName)
+
+	// Use json tags as field names for better error messages
+	tv.RegisterTagNameFunc(func(fld reflect.StructField) string {
+		name := fld.Tag.Get("json")
+		if name == "-" {
+			return ""
+		}
+		if idx := strings.Index(name, ","); idx != -1 {
+			name = name[:idx]
 		}
+		if name == "" {
+			return fld.Name
+		}
+
+		return name
 	})
 
-	return v.tagValidatorErr
+	for _, ct := range v.cfg.customTags {
+		if err := tv.RegisterValidation(ct.name, ct.fn); err != nil {
+			return nil, fmt.Errorf("register custom tag %q: %w", ct.name, err)
+		}
+	}
+
+	for _, r := range v.cfg.rules {
+		if err := tv.RegisterValidationCtx(r.name, r.validatorFunc()); err != nil {
+			return nil, fmt.Errorf("register rule %q: %w", r.name, err)
+		}
+	}
+
+	return tv, nil
 }
 
 // getFieldMap returns a map of JSON field names to field indices for a struct type.
@@ -227,18 +249,12 @@ func (v *Engine) getOrCompileSchema(id, schemaJSON string) (*jsonschemaSchema, e
 	now := time.Now()
 
 	if id != "" {
-		v.schemaCacheMu.RLock()
-		if entry, ok := v.schemaCache[id]; ok {
-			schema := entry.schema
-			v.schemaCacheMu.RUnlock()
-			entry.lastAccess.Store(now.UnixNano())
-
+		if schema, ok := v.cachedSchema(id); ok {
 			return schema, nil
 		}
-		v.schemaCacheMu.RUnlock()
 	}
 
-	schema, err := compileSchema(id, schemaJSON)
+	schema, err := compileSchema(v.schemaURL(id), schemaJSON, v.schemaLoader)
 	if err != nil {
 		return nil, err
 	}
@@ -281,6 +297,20 @@ func (v *Engine) getOrCompileSchema(id, schemaJSON string) (*jsonschemaSchema, e
 	return schema, nil
 }
 
+// cachedSchema returns the cached JSON Schema with id, if any.
+func (v *Engine) cachedSchema(id string) (*jsonschemaSchema, bool) {
+	v.schemaCacheMu.RLock()
+	defer v.schemaCacheMu.RUnlock()
+
+	entry, ok := v.schemaCache[id]
+	if !ok {
+		return nil, false
+	}
+	entry.lastAccess.Store(time.Now().UnixNano())
+
+	return entry.schema, true
+}
+
 // schemaCacheEntry holds a cached JSON Schema and its last access time for LRU eviction.
 type schemaCacheEntry struct {
 	schema     *jsonschemaSchema
@@ -0,0 +1,159 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"slices"
+	"sync"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// warnTagName is the struct tag of warning-level rules.
+const warnTagName = "warn"
+
+// Warnings collects non-fatal validation findings, such as the use of a
+// deprecated field, during one or more validations. Warning rules are
+// declared with the warn struct tag, which takes the same rules as the
+// validate tag plus "deprecated" (fails when the field is set):
+//
+//	type CreateUserRequest struct {
+//	    Name     string `json:"name" validate:"required"`
+//	    Nickname string `json:"nickname" warn:"deprecated"`
+//	}
+//
+// Warnings are only collected when the context carries a collector from
+// [ContextWithWarnings]. They never make validation fail. Code can add its
+// own warnings, e.g. from a [ValidatorWithContext], with [Warnings.Add].
+//
+// Thread-safe: Safe for concurrent use by multiple goroutines.
+type Warnings struct {
+	mu     sync.Mutex
+	fields []FieldError
+}
+
+// Add records a warning. It is safe to call on a nil *Warnings, which
+// discards it.
+func (w *Warnings) Add(fe FieldError) {
+	if w == nil {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.fields = append(w.fields, fe)
+}
+
+// Fields returns the warnings collected so far.
+func (w *Warnings) Fields() []FieldError {
+	if w == nil {
+		return nil
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return slices.Clone(w.fields)
+}
+
+// warningsContextKey is the context key of the [Warnings] collector.
+type warningsContextKey struct{}
+
+// ContextWithWarnings returns a copy of ctx carrying a new [Warnings]
+// collector, and the collector. Validations with the returned context
+// record their warnings in it.
+//
+// Example:
+//
+//	ctx, warnings := validation.ContextWithWarnings(r.Context())
+//	if err := validation.Validate(ctx, &req); err != nil {
+//	    return err
+//	}
+//	resp.Meta.Warnings = warnings.Fields()
+func ContextWithWarnings(ctx context.Context) (context.Context, *Warnings) {
+	w := &Warnings{}
+	return context.WithValue(ctx, warningsContextKey{}, w), w
+}
+
+// WarningsFromContext returns the [Warnings] collector of ctx, or nil.
+// Adding to a nil collector is a no-op.
+func WarningsFromContext(ctx context.Context) *Warnings {
+	if ctx == nil {
+		return nil
+	}
+	w, _ := ctx.Value(warningsContextKey{}).(*Warnings)
+
+	return w
+}
+
+// isDeprecatedField is the "deprecated" warning rule: the field is valid
+// only if it is not set.
+func isDeprecatedField(fl validator.FieldLevel) bool {
+	return fl.Field().IsZero()
+}
+
+// initWarnValidator initializes the go-playground/validator instance for
+// warning rules. This method is safe for concurrent use.
+func (v *Engine) initWarnValidator() error {
+	v.warnValidatorOnce.Do(func() {
+		v.warnValidator, v.warnValidatorErr = v.newTagValidator(warnTagName)
+		if v.warnValidatorErr != nil {
+			return
+		}
+		v.warnValidatorErr = v.warnValidator.RegisterValidation("deprecated", isDeprecatedField, true)
+	})
+
+	return v.warnValidatorErr
+}
+
+// collectWarnings evaluates the warning rules of val and records the
+// findings in the collector of ctx, if there is one.
+func (v *Engine) collectWarnings(ctx context.Context, val any, cfg *config) {
+	warnings := WarningsFromContext(ctx)
+	if warnings == nil {
+		return
+	}
+
+	rv := reflect.ValueOf(val)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return
+	}
+
+	if err := v.initWarnValidator(); err != nil {
+		warnings.Add(FieldError{Code: "warning_error", Message: err.Error()})
+		return
+	}
+
+	err := v.warnValidator.StructCtx(ctx, rv.Interface())
+	var errs validator.ValidationErrors
+	if !errors.As(err, &errs) {
+		return
+	}
+
+	var result *Error
+	if errors.As(v.formatTagErrors(errs, rv.Interface(), cfg, nil), &result) {
+		for _, fe := range result.Fields {
+			warnings.Add(fe)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright 2025 The Rivaas Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !integration
+
+package validation
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type warningsTestRequest struct {
+	Name     string `json:"name" validate:"required"`
+	Nickname string `json:"nickname" warn:"deprecated"`
+	Bio      string `json:"bio" warn:"max=10"`
+}
+
+func TestWarnings_CollectedWithoutFailing(t *testing.T) {
+	t.Parallel()
+
+	ctx, warnings := ContextWithWarnings(t.Context())
+	err := Validate(ctx, &warningsTestRequest{Name: "a", Nickname: "b", Bio: "a rather long biography"})
+	require.NoError(t, err, "warnings don't fail validation")
+
+	fields := warnings.Fields()
+	require.Len(t, fields, 2)
+	assert.Equal(t, "bio", fields[0].Path)
+	assert.Equal(t, "tag.max", fields[0].Code)
+	assert.Equal(t, "nickname", fields[1].Path)
+	assert.Equal(t, "tag.deprecated", fields[1].Code)
+	assert.Equal(t, "is deprecated and may be ignored", fields[1].Message)
+}
+
+func TestWarnings_AlongsideErrors(t *testing.T) {
+	t.Parallel()
+
+	ctx, warnings := ContextWithWarnings(t.Context())
+	err := Validate(ctx, &warningsTestRequest{Nickname: "b"})
+
+	var verr *Error
+	require.ErrorAs(t, err, &verr)
+	assert.True(t, verr.Has("name"))
+	assert.False(t, verr.Has("nickname"), "warnings are not errors")
+	require.Len(t, warnings.Fields(), 1)
+}
+
+func TestWarnings_NoCollector(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, Validate(t.Context(), &warningsTestRequest{Name: "a", Nickname: "b"}))
+	assert.Nil(t, WarningsFromContext(t.Context()))
+	assert.Empty(t, WarningsFromContext(t.Context()).Fields())
+	WarningsFromContext(t.Context()).Add(FieldError{Path: "x"}) // No-op on nil
+}
+
+type warningsTestValidator struct {
+	Mode string `json:"mode"`
+}
+
+func (r *warningsTestValidator) ValidateContext(ctx context.Context) error {
+	if r.Mode == "legacy" {
+		WarningsFromContext(ctx).Add(FieldError{Path: "mode", Code: "mode.legacy", Message: "legacy mode will be removed"})
+	}
+
+	return nil
+}
+
+func TestWarnings_AddedByValidator(t *testing.T) {
+	t.Parallel()
+
+	ctx, warnings := ContextWithWarnings(t.Context())
+	require.NoError(t, Validate(ctx, &warningsTestValidator{Mode: "legacy"}))
+
+	require.Len(t, warnings.Fields(), 1)
+	assert.Equal(t, "mode.legacy", warnings.Fields()[0].Code)
+}
+
+func TestWarnings_ConcurrentAdd(t *testing.T) {
+	t.Parallel()
+
+	_, warnings := ContextWithWarnings(t.Context())
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Go(func() { warnings.Add(FieldError{Path: "x"}) })
+	}
+	wg.Wait()
+
+	assert.Len(t, warnings.Fields(), 10)
+}